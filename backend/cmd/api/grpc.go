@@ -0,0 +1,92 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Servidores gRPC (GRPC_PORT) e grpc-gateway (GRPC_GATEWAY_PORT) do contrato recibofast.v1
+// Data: 18-10-2026
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"recibofast/internal/config"
+	"recibofast/internal/grpcapi"
+)
+
+// serveGRPC serve o gRPC (serviços já registrados pelo httpserver.NewRouter) e, com
+// GRPC_GATEWAY_PORT, o grpc-gateway ligado a ele; ambos param no cancelamento de ctx.
+// Docstring: os erros de abertura das portas voltam antes de qualquer requisição; falhas depois
+// disso só são registradas no log, sem derrubar a API REST.
+func serveGRPC(ctx context.Context, cfg *config.Config, srv *grpc.Server) error {
+	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return fmt.Errorf("gRPC: %w", err)
+	}
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			log.Printf("erro no servidor gRPC: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		stopGRPC(srv)
+	}()
+	log.Printf("Servidor gRPC rodando em :%s", cfg.GRPCPort)
+
+	if cfg.GRPCGatewayPort == "" {
+		return nil
+	}
+	conn, err := grpc.NewClient("localhost:"+cfg.GRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("grpc-gateway: %w", err)
+	}
+	gateway, err := grpcapi.NewGateway(ctx, conn)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("grpc-gateway: %w", err)
+	}
+	gwLis, err := net.Listen("tcp", ":"+cfg.GRPCGatewayPort)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("grpc-gateway: %w", err)
+	}
+	gwSrv := &http.Server{Handler: gateway, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := gwSrv.Serve(gwLis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("erro no grpc-gateway: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := gwSrv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("erro ao encerrar o grpc-gateway: %v", err)
+		}
+		conn.Close()
+	}()
+	log.Printf("grpc-gateway rodando em :%s", cfg.GRPCGatewayPort)
+	return nil
+}
+
+// stopGRPC espera as chamadas em andamento até shutdownTimeout e então encerra as restantes
+func stopGRPC(srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(shutdownTimeout):
+		srv.Stop()
+	}
+}
@@ -0,0 +1,87 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Subcomando "migrate" (up, status e baseline) sobre as migrações embutidas
+// Data: 18-10-2026
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"text/tabwriter"
+
+	"recibofast/internal/config"
+	"recibofast/internal/migrate"
+	"recibofast/internal/repositories"
+	"recibofast/migrations"
+)
+
+const migrateUsage = `uso: backend migrate [up|status|baseline <versão>]
+  up                 aplica as migrações pendentes (padrão)
+  status             lista as migrações e quando foram aplicadas
+  baseline <versão>  marca como aplicadas, sem executar, as migrações até <versão> (banco migrado pelo SQL Editor)`
+
+// runMigrate executa o subcomando migrate com DB_URL
+func runMigrate(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	cmd := "up"
+	if len(args) > 0 {
+		cmd = args[0]
+	}
+	if (cmd == "baseline" && len(args) != 2) || (cmd != "baseline" && len(args) > 1) {
+		return errors.New(migrateUsage)
+	}
+	if cfg.DBURL == "" {
+		return errors.New("DB_URL não configurada")
+	}
+	pool, err := repositories.NewPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	m, err := migrate.New(pool, migrations.FS, log.Printf)
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "up":
+		done, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%d migração(ões) aplicada(s)\n", len(done))
+	case "baseline":
+		done, err := m.Baseline(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%d migração(ões) marcada(s) como aplicada(s)\n", len(done))
+	case "status":
+		items, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MIGRAÇÃO\tSITUAÇÃO\tAPLICADA EM")
+		for _, st := range items {
+			state, at := "pendente", "-"
+			if st.AppliedAt != nil {
+				state, at = "aplicada", st.AppliedAt.Format("2006-01-02 15:04:05")
+				if st.Baseline {
+					state = "baseline"
+				}
+				if st.Changed {
+					state += " (arquivo alterado)"
+				}
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", st.Name, state, at)
+		}
+		return tw.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modo mock (APP_MODE=mock) com receitas em memória e dados de exemplo para o frontend
+// Data: 18-10-2026
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/handlers"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/services"
+)
+
+// mockUserID é o usuário fixo do modo mock; todas as requisições são atendidas como ele,
+// sem validar JWT. Nunca habilite APP_MODE=mock em produção.
+const mockUserID = "00000000-0000-4000-8000-000000000001"
+
+// newMockHandler monta as rotas de receitas/pagamentos sobre o repositório em memória.
+// Docstring: usa os mesmos handlers e serviço da API real, então paginação, filtros,
+// validações e mutações se comportam como em produção; os dados somem ao reiniciar.
+// Healthcheck e hCaptcha seguem disponíveis; as demais rotas respondem 404.
+func newMockHandler(captcha *handlers.CaptchaHandlers) http.Handler {
+	logger := logging.NewLogger("dev")
+	repo := repositories.NewMemoryIncomeRepository()
+	owner := uuid.MustParse(mockUserID)
+	seedMockIncomes(repo, owner, time.Now().UTC())
+
+	svc := services.NewIncomeService(repo)
+	ih := handlers.NewIncomeHandlers(svc, logger)
+
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(ctxhelper.SetUserID(r.Context(), mockUserID)))
+		})
+	})
+	r.Route("/api/v1/incomes", func(r chi.Router) {
+		r.Get("/", ih.ListIncomes)
+		r.Post("/", ih.CreateIncome)
+		r.Get("/stats", ih.IncomeStats)
+		r.Get("/{id}", ih.GetIncome)
+		r.Put("/{id}", ih.UpdateIncome)
+		r.Patch("/{id}", ih.PatchIncome)
+		r.Delete("/{id}", ih.DeleteIncome)
+		r.Post("/{id}/duplicate", ih.DuplicateIncome)
+		r.Get("/{id}/payments", ih.GetIncomePayments)
+		r.Get("/{id}/simulate-payment", ih.SimulatePayment)
+	})
+	r.Post("/api/v1/payments", ih.AddPayment)
+	r.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	r.Route("/api/v1/captcha", func(r chi.Router) {
+		r.Get("/sitekey", captcha.SiteKey)
+		r.Post("/verify", captcha.Verify)
+		r.Get("/health", captcha.Health)
+	})
+	return r
+}
+
+// seedMockIncomes cria seis meses de receitas de exemplo (aluguel, condomínio e consultoria)
+// com pagamentos integrais, parciais e vencidos, relativos à data atual.
+func seedMockIncomes(repo repositories.IncomeRepository, owner uuid.UUID, now time.Time) {
+	ctx := context.Background()
+	type seed struct {
+		categoria string
+		tag       string
+		valor     models.Money
+		dia       int
+	}
+	seeds := []seed{
+		{"Aluguel", "Apto 12", models.NewMoney(1800), 5},
+		{"Aluguel", "Sala 3", models.NewMoney(1250), 10},
+		{"Condomínio", "Apto 12", models.NewMoney(420), 5},
+		{"Consultoria", "Cliente ACME", models.NewMoney(3500), 20},
+	}
+	base := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for m := -5; m <= 0; m++ {
+		month := base.AddDate(0, m, 0)
+		for i, s := range seeds {
+			cat := s.categoria
+			due := month.AddDate(0, 0, s.dia-1)
+			in := &models.Income{
+				ID:          uuid.New(),
+				OwnerID:     owner,
+				Categoria:   &cat,
+				Tags:        []string{s.tag},
+				Competencia: month.Format("2006-01"),
+				Valor:       s.valor,
+				Status:      models.StatusPendente,
+				DueDate:     &due,
+			}
+			_ = repo.Create(ctx, in)
+
+			paid := models.Money(0)
+			switch {
+			case m < -1 || (m == -1 && i != 3):
+				paid = s.valor // meses anteriores quitados (consultoria do mês passado em aberto)
+			case m == 0 && i == 0 && due.Before(now):
+				paid = s.valor / 2 // aluguel do mês atual pago parcialmente
+			}
+			if paid > 0 {
+				metodo := "pix"
+				obs := fmt.Sprintf("Pagamento %s", in.Competencia)
+				_ = repo.AddPayment(ctx, &models.Payment{ID: uuid.New(), IncomeID: in.ID, Valor: paid, PagoEm: due, Metodo: &metodo, Obs: &obs})
+				_ = repo.UpdateTotalPago(ctx, in.ID)
+			}
+			in.TotalPago = paid
+			switch {
+			case paid >= in.Valor:
+				in.Status = models.StatusPago
+			case paid > 0:
+				in.Status = models.StatusParcial
+			case due.Before(now):
+				in.Status = models.StatusVencido
+			}
+			_ = repo.Update(ctx, in)
+		}
+	}
+}
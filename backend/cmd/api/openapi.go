@@ -0,0 +1,73 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Subcomando "openapi" que gera (ou confere) a especificação OpenAPI da API
+// Data: 18-10-2026
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"recibofast/internal/config"
+	"recibofast/internal/httpserver"
+	"recibofast/internal/logging"
+)
+
+// runOpenAPI escreve a especificação montada a partir do roteador, sem banco nem rede.
+// Docstring: "-o arquivo" grava no arquivo (padrão: saída padrão); "-check" não grava e falha quando
+// o arquivo está diferente do contrato atual (uso no CI para o cliente tipado do frontend).
+func runOpenAPI(_ context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("openapi", flag.ContinueOnError)
+	fs.SetOutput(out)
+	file := fs.String("o", "", "arquivo de saída (padrão: saída padrão)")
+	check := fs.Bool("check", false, "confere se o arquivo -o está atualizado, sem gravar")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *check && *file == "" {
+		return fmt.Errorf("-check exige -o <arquivo>")
+	}
+
+	// Só as rotas interessam: sem banco, sem workers (Background vazio) e sem buscar o JWKS
+	routerCfg := *cfg
+	routerCfg.JWKSURL = ""
+	doc, err := httpserver.BuildOpenAPI(httpserver.NewRouter(httpserver.AppDeps{
+		Logger: logging.NewLogger(cfg.Env),
+		Cfg:    &routerCfg,
+	}))
+	if err != nil {
+		return err
+	}
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	body = append(body, '\n')
+
+	switch {
+	case *check:
+		current, err := os.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(current, body) {
+			return fmt.Errorf("%s desatualizado: rode \"backend openapi -o %s\"", *file, *file)
+		}
+		fmt.Fprintf(out, "%s atualizado\n", *file)
+	case *file != "":
+		if err := os.WriteFile(*file, body, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "especificação gravada em %s (%d caminhos)\n", *file, len(doc.Paths))
+	default:
+		_, err = out.Write(body)
+		return err
+	}
+	return nil
+}
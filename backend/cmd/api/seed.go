@@ -0,0 +1,196 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Subcomando "seed" com dados de demonstração (pagadores, contratos, receitas, pagamentos e recibos)
+// Data: 18-10-2026
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"recibofast/internal/config"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/services"
+)
+
+// demoMarker sufixo dos pagadores de demonstração; evita semear duas vezes o mesmo usuário
+const demoMarker = "(demo)"
+
+// Dependências do seed (implementadas pelos repositórios e pelo IncomeService)
+type (
+	demoPayers interface {
+		Create(ctx context.Context, p *models.Payer) error
+		List(ctx context.Context, ownerID uuid.UUID, filter *models.PayerFilter) ([]models.Payer, int, error)
+	}
+	demoContracts interface {
+		Create(ctx context.Context, c *models.Contract) error
+	}
+	demoReceipts interface {
+		Create(ctx context.Context, r *models.Receipt) error
+	}
+	demoIncomes interface {
+		CreateIncome(ctx context.Context, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error)
+		AddPayment(ctx context.Context, ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error)
+	}
+)
+
+// demoSummary quantidades criadas pelo seed
+type demoSummary struct {
+	Payers, Contracts, Incomes, Payments, Receipts int
+}
+
+// demoSeeder cria os dados de demonstração de um usuário
+type demoSeeder struct {
+	payers    demoPayers
+	contracts demoContracts
+	receipts  demoReceipts
+	incomes   demoIncomes
+}
+
+// demoContract contrato de exemplo com o respectivo pagador
+type demoContract struct {
+	payer     string
+	documento string
+	email     string
+	numero    string
+	descricao string
+	categoria string
+	valor     models.Money
+	dia       int
+}
+
+var demoContractsData = []demoContract{
+	{"Maria Souza", "123.456.789-09", "maria.souza@example.com", "DEMO-001", "Aluguel Apto 12 - Rua das Flores, 100", "Aluguel", models.NewMoney(1800), 5},
+	{"João Pereira", "987.654.321-00", "joao.pereira@example.com", "DEMO-002", "Aluguel Sala 3 - Av. Central, 500", "Aluguel", models.NewMoney(1250), 10},
+	{"ACME Tecnologia Ltda", "12.345.678/0001-95", "financeiro@acme.example.com", "DEMO-003", "Consultoria mensal em TI", "Consultoria", models.NewMoney(3500), 20},
+}
+
+// run cria, para cada contrato, as receitas dos últimos months meses (inclusive o atual).
+// Docstring: meses anteriores ficam quitados com recibo emitido, exceto o último em aberto da
+// consultoria (vencido); no mês atual o primeiro aluguel vencido fica pago pela metade.
+func (s *demoSeeder) run(ctx context.Context, owner uuid.UUID, months int, now time.Time) (*demoSummary, error) {
+	if _, total, err := s.payers.List(ctx, owner, &models.PayerFilter{Search: demoMarker, Page: 1, PerPage: 1}); err != nil {
+		return nil, err
+	} else if total > 0 {
+		return nil, errors.New("o usuário já tem dados de demonstração")
+	}
+
+	sum := &demoSummary{}
+	base := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	start := base.AddDate(0, 1-months, 0)
+	for i, d := range demoContractsData {
+		doc, email := d.documento, d.email
+		payer := &models.Payer{OwnerID: owner, Nome: d.payer + " " + demoMarker, Documento: &doc, Email: &email}
+		if err := s.payers.Create(ctx, payer); err != nil {
+			return nil, fmt.Errorf("pagador %s: %w", d.payer, err)
+		}
+		sum.Payers++
+
+		numero, descricao, dia := d.numero, d.descricao, d.dia
+		contract := &models.Contract{
+			ID: uuid.New(), OwnerID: owner, Numero: &numero, Descricao: &descricao,
+			ValorMensal: d.valor, VencimentoDia: &dia, DataInicio: &start, PayerID: &payer.ID,
+		}
+		if err := s.contracts.Create(ctx, contract); err != nil {
+			return nil, fmt.Errorf("contrato %s: %w", d.numero, err)
+		}
+		sum.Contracts++
+
+		for m := 1 - months; m <= 0; m++ {
+			month := base.AddDate(0, m, 0)
+			due := month.AddDate(0, 0, d.dia-1)
+			paid := models.Money(0)
+			switch {
+			case m < -1 || (m == -1 && i != 2):
+				paid = d.valor
+			case m == 0 && i == 0 && due.Before(now):
+				paid = d.valor / 2
+			}
+			status := models.StatusPendente
+			if paid == 0 && due.Before(now) {
+				status = models.StatusVencido
+			}
+
+			cat, dueStr := d.categoria, due.Format(time.RFC3339)
+			income, err := s.incomes.CreateIncome(ctx, owner, &models.IncomeRequest{
+				ContractID: &contract.ID, PayerID: &payer.ID, Categoria: &cat, Tags: []string{d.numero},
+				Competencia: month.Format("2006-01"), Valor: d.valor, Status: status, DueDate: &dueStr,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("receita %s %s: %w", d.numero, month.Format("2006-01"), err)
+			}
+			sum.Incomes++
+			if paid == 0 {
+				continue
+			}
+
+			metodo, obs := "pix", "Pagamento "+income.Competencia
+			pagoEm := due.Add(-24 * time.Hour).Format(time.RFC3339)
+			resp, err := s.incomes.AddPayment(ctx, owner, &models.PaymentRequest{
+				IncomeID: income.ID, Valor: paid, PagoEm: &pagoEm, Metodo: &metodo, Obs: &obs,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("pagamento %s %s: %w", d.numero, income.Competencia, err)
+			}
+			sum.Payments++
+			if resp.Income.TotalPago < resp.Income.Valor {
+				continue
+			}
+			if err := s.receipts.Create(ctx, &models.Receipt{OwnerID: owner, IncomeID: &income.ID, PayerID: &payer.ID}); err != nil {
+				return nil, fmt.Errorf("recibo %s %s: %w", d.numero, income.Competencia, err)
+			}
+			sum.Receipts++
+		}
+	}
+	return sum, nil
+}
+
+// runSeed executa o subcomando seed: "seed --user <uuid> [--months N]" (DB_URL obrigatória).
+// Docstring: o usuário precisa existir em auth.users; os dados são criados pelos mesmos
+// repositórios da API, então triggers de numeração e snapshot dos recibos também valem.
+func runSeed(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.SetOutput(out)
+	user := fs.String("user", "", "UUID do usuário (auth.users) que recebe os dados")
+	months := fs.Int("months", 6, "meses de receitas por contrato, até o atual")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	owner, err := uuid.Parse(*user)
+	if err != nil {
+		return errors.New("uso: backend seed --user <uuid> [--months N]")
+	}
+	if *months < 1 || *months > 36 {
+		return errors.New("--months deve estar entre 1 e 36")
+	}
+	if cfg.DBURL == "" {
+		return errors.New("DB_URL não configurada")
+	}
+	pool, err := repositories.NewPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	seeder := &demoSeeder{
+		payers:    repositories.NewPayerRepository(pool),
+		contracts: repositories.NewContractRepository(pool),
+		receipts:  repositories.NewReceiptRepository(pool),
+		incomes:   services.NewIncomeService(repositories.NewIncomeRepository(pool)),
+	}
+	sum, err := seeder.run(ctx, owner, *months, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "dados de demonstração criados para %s: %d pagadores, %d contratos, %d receitas, %d pagamentos, %d recibos\n",
+		owner, sum.Payers, sum.Contracts, sum.Incomes, sum.Payments, sum.Receipts)
+	return nil
+}
@@ -0,0 +1,95 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do subcomando seed (dados de demonstração sobre repositórios em memória)
+// Data: 18-10-2026
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/services"
+)
+
+type seedPayers struct{ created []models.Payer }
+
+func (f *seedPayers) Create(ctx context.Context, p *models.Payer) error {
+	p.ID = uuid.New()
+	f.created = append(f.created, *p)
+	return nil
+}
+
+func (f *seedPayers) List(ctx context.Context, ownerID uuid.UUID, filter *models.PayerFilter) ([]models.Payer, int, error) {
+	var out []models.Payer
+	for _, p := range f.created {
+		if p.OwnerID == ownerID && strings.Contains(p.Nome, filter.Search) {
+			out = append(out, p)
+		}
+	}
+	return out, len(out), nil
+}
+
+type seedContracts struct{ created []models.Contract }
+
+func (f *seedContracts) Create(ctx context.Context, c *models.Contract) error {
+	f.created = append(f.created, *c)
+	return nil
+}
+
+type seedReceipts struct{ created []models.Receipt }
+
+func (f *seedReceipts) Create(ctx context.Context, r *models.Receipt) error {
+	f.created = append(f.created, *r)
+	return nil
+}
+
+func TestDemoSeeder_CreatesRealisticData(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.New()
+	incomeRepo := repositories.NewMemoryIncomeRepository()
+	payers, contracts, receipts := &seedPayers{}, &seedContracts{}, &seedReceipts{}
+	s := &demoSeeder{payers: payers, contracts: contracts, receipts: receipts, incomes: services.NewIncomeService(incomeRepo)}
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	sum, err := s.run(ctx, owner, 6, now)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	// 3 contratos × 6 meses; quitados: 4 meses anteriores de todos + mês passado dos aluguéis
+	if sum.Payers != 3 || sum.Contracts != 3 || sum.Incomes != 18 || sum.Receipts != 14 || sum.Payments != 15 {
+		t.Fatalf("resumo = %+v", sum)
+	}
+	if len(receipts.created) != 14 || len(contracts.created) != 3 || *contracts.created[0].PayerID != payers.created[0].ID {
+		t.Fatalf("recibos=%d contratos=%+v", len(receipts.created), contracts.created)
+	}
+
+	svc := services.NewIncomeService(incomeRepo)
+	resp, err := svc.ListIncomes(ctx, owner, &models.IncomeFilter{Page: 1, PerPage: 100})
+	if err != nil {
+		t.Fatalf("ListIncomes: %v", err)
+	}
+	vencidas, quitadas := 0, 0
+	for _, in := range resp.Incomes {
+		if in.Status == models.StatusVencido {
+			vencidas++
+		}
+		if in.TotalPago == in.Valor {
+			quitadas++
+		}
+	}
+	// Vencidas sem pagamento: consultoria de setembro e aluguel do dia 10 de outubro
+	if vencidas != 2 || quitadas != 14 {
+		t.Fatalf("vencidas=%d quitadas=%d, want 2/14", vencidas, quitadas)
+	}
+
+	if _, err := s.run(ctx, owner, 6, now); err == nil {
+		t.Fatal("esperava erro ao semear o mesmo usuário duas vezes")
+	}
+}
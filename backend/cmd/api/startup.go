@@ -0,0 +1,227 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Orquestração da inicialização (banco e migrações, JWKS e buckets do Storage) antes de abrir a porta
+// Data: 18-10-2026
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	"recibofast/internal/config"
+	"recibofast/internal/migrate"
+	"recibofast/internal/repositories"
+	"recibofast/internal/storage"
+	"recibofast/migrations"
+)
+
+// Esquema mínimo exigido por esta versão do binário.
+// Docstring: requiredMigration é a última migração em migrations/ (espelho de supabase/migrations);
+// o banco é conferido pelo histórico do subcomando migrate (rf_schema_migrations), depois pelo do
+// Supabase CLI e, sem nenhum dos dois (migrações aplicadas pelo SQL Editor), pela tabela ou índice
+// criado por ela (ou pela mais recente que criou um, se ela só altera colunas). Atualize as duas ao
+// adicionar uma migração da qual o código dependa.
+const (
+	requiredMigration      = "059"
+	requiredMigrationTable = "public.idx_receipts_numero_formatado_lookup"
+)
+
+// startupDependency dependência externa verificada antes de aceitar requisições
+type startupDependency struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// startupOrchestrator verifica as dependências em ordem, com tentativas limitadas e espera
+// exponencial entre elas (baseDelay, 2×baseDelay, … até maxDelay)
+type startupOrchestrator struct {
+	deps        []startupDependency
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	logf        func(format string, args ...any)
+	sleep       func(ctx context.Context, d time.Duration) error
+}
+
+// newStartupOrchestrator lê STARTUP_MAX_ATTEMPTS (padrão 10) e STARTUP_RETRY_DELAY (padrão 1s;
+// a espera dobra a cada tentativa, até 30s)
+func newStartupOrchestrator() *startupOrchestrator {
+	o := &startupOrchestrator{maxAttempts: 10, baseDelay: time.Second, maxDelay: 30 * time.Second, logf: log.Printf, sleep: sleepCtx}
+	if n, err := strconv.Atoi(os.Getenv("STARTUP_MAX_ATTEMPTS")); err == nil && n > 0 {
+		o.maxAttempts = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("STARTUP_RETRY_DELAY")); err == nil && d > 0 {
+		o.baseDelay = d
+	}
+	return o
+}
+
+// add registra uma dependência (verificadas na ordem de registro)
+func (o *startupOrchestrator) add(name string, check func(ctx context.Context) error) {
+	o.deps = append(o.deps, startupDependency{name: name, check: check})
+}
+
+// skip registra no log uma dependência não configurada
+func (o *startupOrchestrator) skip(name, reason string) {
+	o.logf("inicialização: %s ignorado (%s)", name, reason)
+}
+
+// run aguarda cada dependência; a primeira que esgotar as tentativas interrompe a inicialização
+func (o *startupOrchestrator) run(ctx context.Context) error {
+	for _, dep := range o.deps {
+		if err := o.wait(ctx, dep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *startupOrchestrator) wait(ctx context.Context, dep startupDependency) error {
+	start := time.Now()
+	delay := o.baseDelay
+	for attempt := 1; ; attempt++ {
+		err := dep.check(ctx)
+		if err == nil {
+			o.logf("inicialização: %s pronto (tentativa %d, %s)", dep.name, attempt, time.Since(start).Round(time.Millisecond))
+			return nil
+		}
+		if attempt >= o.maxAttempts {
+			o.logf("inicialização: %s indisponível após %d tentativas: %v", dep.name, attempt, err)
+			return fmt.Errorf("%s indisponível: %w", dep.name, err)
+		}
+		o.logf("inicialização: aguardando %s (tentativa %d/%d): %v; nova tentativa em %s", dep.name, attempt, o.maxAttempts, err, delay)
+		if err := o.sleep(ctx, delay); err != nil {
+			return fmt.Errorf("%s: inicialização cancelada: %w", dep.name, err)
+		}
+		delay = min(delay*2, o.maxDelay)
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// waitForDependencies verifica banco (conexão e migrações), JWKS e buckets do Storage.
+// Docstring: dependências sem configuração são ignoradas com aviso no log. Retorna o pool do
+// banco já conectado (nil sem DB_URL); quem chama deve fechá-lo.
+func waitForDependencies(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
+	o := newStartupOrchestrator()
+	var pool *pgxpool.Pool
+
+	if cfg.DBURL == "" {
+		o.skip("banco de dados", "DB_URL não configurada")
+	} else {
+		o.add("banco de dados", func(ctx context.Context) error {
+			if pool == nil {
+				p, err := repositories.NewPool(ctx, cfg)
+				if err != nil {
+					return err
+				}
+				pool = p
+			}
+			return pool.Ping(ctx)
+		})
+		if cfg.DBAutoMigrate {
+			o.add("aplicação das migrações", func(ctx context.Context) error {
+				m, err := migrate.New(pool, migrations.FS, o.logf)
+				if err != nil {
+					return err
+				}
+				_, err = m.Up(ctx)
+				return err
+			})
+		}
+		o.add("migrações do banco", func(ctx context.Context) error {
+			return checkMigrations(ctx, pool)
+		})
+	}
+
+	if cfg.JWKSURL == "" {
+		o.skip("JWKS", "JWKS_URL não configurada")
+	} else {
+		o.add("JWKS", func(ctx context.Context) error {
+			set, err := jwk.Fetch(ctx, cfg.JWKSURL)
+			if err != nil {
+				return err
+			}
+			if set.Len() == 0 {
+				return errors.New("JWKS sem chaves")
+			}
+			return nil
+		})
+	}
+
+	if cfg.SupabaseURL == "" || cfg.SupabaseServiceRoleKey == "" {
+		o.skip("buckets do Storage", "SUPABASE_URL ou SUPABASE_SERVICE_ROLE_KEY não configurada")
+	} else {
+		store := storage.NewClient(cfg)
+		for _, bucket := range []string{cfg.BucketSigns, cfg.BucketReceipts} {
+			bucket := bucket
+			o.add("bucket "+bucket, func(ctx context.Context) error {
+				created, err := store.EnsureBucket(ctx, bucket)
+				if created {
+					o.logf("inicialização: bucket %s criado (privado)", bucket)
+				}
+				return err
+			})
+		}
+	}
+
+	if err := o.run(ctx); err != nil {
+		if pool != nil {
+			pool.Close()
+		}
+		return nil, err
+	}
+	return pool, nil
+}
+
+// checkMigrations confere se o banco já tem a migração exigida por esta versão
+func checkMigrations(ctx context.Context, pool *pgxpool.Pool) error {
+	for _, history := range []string{"public.rf_schema_migrations", "supabase_migrations.schema_migrations"} {
+		var applied *string
+		err := pool.QueryRow(ctx, `SELECT max(version) FROM `+history).Scan(&applied)
+		if err == nil && applied != nil {
+			if *applied < requiredMigration {
+				return fmt.Errorf("migração %s pendente (última aplicada: %s)", requiredMigration, *applied)
+			}
+			return nil
+		}
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) && !isUndefinedTable(err) {
+			return err
+		}
+	}
+	// Sem histórico do Supabase CLI: confere o objeto criado pela última migração
+	var exists bool
+	if err := pool.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, requiredMigrationTable).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("migração %s pendente (%s não existe)", requiredMigration, requiredMigrationTable)
+	}
+	return nil
+}
+
+// isUndefinedTable indica tabela ou schema inexistente (42P01 / 3F000)
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == "42P01" || pgErr.Code == "3F000")
+}
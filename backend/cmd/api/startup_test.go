@@ -0,0 +1,97 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da orquestração de inicialização (ordem, tentativas e espera exponencial)
+// Data: 18-10-2026
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"recibofast/internal/migrate"
+	"recibofast/migrations"
+)
+
+func newTestOrchestrator(maxAttempts int) (*startupOrchestrator, *[]time.Duration) {
+	var waits []time.Duration
+	o := &startupOrchestrator{
+		maxAttempts: maxAttempts,
+		baseDelay:   time.Second,
+		maxDelay:    4 * time.Second,
+		logf:        func(string, ...any) {},
+		sleep: func(ctx context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return ctx.Err()
+		},
+	}
+	return o, &waits
+}
+
+func TestStartupOrchestrator_RetriesWithBackoffInOrder(t *testing.T) {
+	o, waits := newTestOrchestrator(10)
+	var order []string
+	dbCalls := 0
+	o.add("banco", func(ctx context.Context) error {
+		dbCalls++
+		order = append(order, "banco")
+		if dbCalls < 5 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	o.add("jwks", func(ctx context.Context) error {
+		order = append(order, "jwks")
+		return nil
+	})
+
+	if err := o.run(context.Background()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}
+	if len(*waits) != len(want) {
+		t.Fatalf("esperas = %v, want %v", *waits, want)
+	}
+	for i := range want {
+		if (*waits)[i] != want[i] {
+			t.Fatalf("esperas = %v, want %v", *waits, want)
+		}
+	}
+	if len(order) != 6 || order[5] != "jwks" {
+		t.Fatalf("ordem = %v; jwks só depois do banco pronto", order)
+	}
+}
+
+func TestStartupOrchestrator_StopsAfterMaxAttempts(t *testing.T) {
+	o, _ := newTestOrchestrator(3)
+	calls, later := 0, false
+	o.add("bucket receipts", func(ctx context.Context) error { calls++; return errors.New("503") })
+	o.add("jwks", func(ctx context.Context) error { later = true; return nil })
+
+	err := o.run(context.Background())
+	if err == nil || calls != 3 || later {
+		t.Fatalf("err = %v, tentativas = %d, seguinte verificado = %v", err, calls, later)
+	}
+}
+
+func TestStartupOrchestrator_CanceledContext(t *testing.T) {
+	o, _ := newTestOrchestrator(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o.add("banco", func(ctx context.Context) error { return errors.New("timeout") })
+	if err := o.run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestRequiredMigration_MatchesEmbedded(t *testing.T) {
+	all, err := migrate.Load(migrations.FS)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if latest := all[len(all)-1].Version; latest != requiredMigration {
+		t.Fatalf("requiredMigration = %s, última migração embutida = %s", requiredMigration, latest)
+	}
+}
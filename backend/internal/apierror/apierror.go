@@ -0,0 +1,112 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Formato único das respostas de erro da API ({code, message, details, request_id})
+// Data: 18-10-2026
+
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// RequestIDHeader cabeçalho da resposta com o ID da requisição (preenchido pelo router)
+const RequestIDHeader = "X-Request-ID"
+
+// Códigos genéricos por status HTTP.
+// Docstring: code é estável e serve de chave de tradução no frontend; message é o texto em
+// português para exibição direta ou log. Códigos específicos de domínio (ex.: quota_exceeded)
+// refinam estes quando o cliente precisa distinguir o caso.
+const (
+	CodeBadRequest         = "bad_request"
+	CodeUnauthorized       = "unauthorized"
+	CodePaymentRequired    = "payment_required"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeConflict           = "conflict"
+	CodeGone               = "gone"
+	CodePreconditionFailed = "precondition_failed"
+	CodePayloadTooLarge    = "payload_too_large"
+	CodeUnprocessable      = "unprocessable_entity"
+	CodeLocked             = "locked"
+	CodePreconditionNeeded = "precondition_required"
+	CodeRateLimited        = "rate_limited"
+	CodeInternal           = "internal_error"
+	CodeNotImplemented     = "not_implemented"
+	CodeBadGateway         = "bad_gateway"
+	CodeUnavailable        = "service_unavailable"
+	CodeGatewayTimeout     = "gateway_timeout"
+)
+
+// Error corpo das respostas de erro
+type Error struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// CodeForStatus código genérico do status (4xx desconhecido vira bad_request, 5xx internal_error)
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusPaymentRequired:
+		return CodePaymentRequired
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusPreconditionFailed:
+		return CodePreconditionFailed
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusUnprocessableEntity:
+		return CodeUnprocessable
+	case http.StatusLocked:
+		return CodeLocked
+	case http.StatusPreconditionRequired:
+		return CodePreconditionNeeded
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusNotImplemented:
+		return CodeNotImplemented
+	case http.StatusBadGateway:
+		return CodeBadGateway
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeGatewayTimeout
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// Write responde o erro com o código genérico do status
+func Write(w http.ResponseWriter, status int, message string) {
+	WriteCode(w, status, CodeForStatus(status), message, nil)
+}
+
+// WriteDetails responde o erro com o código genérico do status e dados adicionais
+func WriteDetails(w http.ResponseWriter, status int, message string, details interface{}) {
+	WriteCode(w, status, CodeForStatus(status), message, details)
+}
+
+// WriteCode responde o erro com código específico; request_id vem do cabeçalho X-Request-ID da resposta
+func WriteCode(w http.ResponseWriter, status int, code, message string, details interface{}) {
+	body := Error{Code: code, Message: message, Details: details, RequestID: w.Header().Get(RequestIDHeader)}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
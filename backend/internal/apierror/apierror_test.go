@@ -0,0 +1,65 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do formato das respostas de erro
+// Data: 18-10-2026
+
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteUsesStatusCodeAndRequestID(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(RequestIDHeader, "req-1")
+	WriteDetails(rec, http.StatusNotFound, "recibo não encontrado", map[string]string{"id": "42"})
+
+	var body struct {
+		Code      string            `json:"code"`
+		Message   string            `json:"message"`
+		Details   map[string]string `json:"details"`
+		RequestID string            `json:"request_id"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusNotFound || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("status = %d, content-type = %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if body.Code != CodeNotFound || body.Message != "recibo não encontrado" || body.Details["id"] != "42" || body.RequestID != "req-1" {
+		t.Fatalf("corpo inesperado: %+v", body)
+	}
+}
+
+func TestCodeForStatus(t *testing.T) {
+	cases := map[int]string{
+		http.StatusBadRequest:          CodeBadRequest,
+		http.StatusUnauthorized:        CodeUnauthorized,
+		http.StatusConflict:            CodeConflict,
+		http.StatusTooManyRequests:     CodeRateLimited,
+		http.StatusTeapot:              CodeBadRequest,
+		http.StatusInternalServerError: CodeInternal,
+		http.StatusInsufficientStorage: CodeInternal,
+	}
+	for status, want := range cases {
+		if got := CodeForStatus(status); got != want {
+			t.Errorf("CodeForStatus(%d) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestWriteOmitsEmptyFields(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Write(rec, http.StatusForbidden, "sem acesso")
+	var body map[string]interface{}
+	json.NewDecoder(rec.Body).Decode(&body)
+	if _, ok := body["details"]; ok {
+		t.Fatalf("details vazio não deve ser enviado: %v", body)
+	}
+	if _, ok := body["request_id"]; ok {
+		t.Fatalf("request_id vazio não deve ser enviado: %v", body)
+	}
+}
@@ -0,0 +1,103 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Cliente mínimo da API de dados abertos do Banco Central (séries SGS)
+// Data: 18-10-2026
+
+package bcb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"recibofast/internal/config"
+	"recibofast/internal/models"
+)
+
+// series códigos SGS de cada índice armazenado em rf_rates
+var series = map[string]int{
+	models.RatePTAXUSD: 1,     // dólar americano, venda (PTAX)
+	models.RatePTAXEUR: 21619, // euro, venda (PTAX)
+	models.RateIGPM:    189,   // IGP-M, variação mensal %
+	models.RateIPCA:    433,   // IPCA, variação mensal %
+}
+
+// Client busca séries temporais no SGS do Banco Central.
+// Docstring: a API responde 404 quando não há dados no intervalo (ex.: feriados), tratado como vazio.
+type Client struct {
+	baseURL string
+	hc      *http.Client
+	now     func() time.Time
+}
+
+// NewClient cria o cliente a partir da configuração (BCB_API_URL)
+func NewClient(cfg *config.Config) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(cfg.BCBURL, "/"),
+		hc:      &http.Client{Timeout: 30 * time.Second},
+		now:     time.Now,
+	}
+}
+
+type sgsPoint struct {
+	Data  string `json:"data"`
+	Valor string `json:"valor"`
+}
+
+// FetchRates busca as cotações do índice no intervalo [from, to]
+func (c *Client) FetchRates(ctx context.Context, indice string, from, to time.Time) ([]models.Rate, error) {
+	code, ok := series[indice]
+	if !ok {
+		return nil, models.ErrInvalidRateIndex
+	}
+	if c.baseURL == "" {
+		return nil, errors.New("configuração da API do Banco Central ausente (BCB_API_URL)")
+	}
+
+	url := fmt.Sprintf("%s/dados/serie/bcdata.sgs.%d/dados?formato=json&dataInicial=%s&dataFinal=%s",
+		c.baseURL, code, from.Format("02/01/2006"), to.Format("02/01/2006"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return []models.Rate{}, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("falha ao buscar série %d no Banco Central: status=%d body=%s", code, resp.StatusCode, string(b))
+	}
+
+	var points []sgsPoint
+	if err := json.NewDecoder(resp.Body).Decode(&points); err != nil {
+		return nil, fmt.Errorf("resposta inválida da série %d: %w", code, err)
+	}
+	fetchedAt := c.now().UTC()
+	out := make([]models.Rate, 0, len(points))
+	for _, p := range points {
+		data, err := time.Parse("02/01/2006", p.Data)
+		if err != nil {
+			return nil, fmt.Errorf("data inválida na série %d: %q", code, p.Data)
+		}
+		valor, err := strconv.ParseFloat(strings.TrimSpace(p.Valor), 64)
+		if err != nil {
+			return nil, fmt.Errorf("valor inválido na série %d em %s: %q", code, p.Data, p.Valor)
+		}
+		out = append(out, models.Rate{Indice: indice, Data: data, Valor: valor, Fonte: models.RateSourceBCB, FetchedAt: &fetchedAt})
+	}
+	return out, nil
+}
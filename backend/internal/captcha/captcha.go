@@ -0,0 +1,101 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Verificação server-side de captcha (provedor hCaptcha) usada pelos handlers e pelo middleware
+// Data: 18-10-2026
+
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+// HCaptchaVerifyURL endpoint de verificação do hCaptcha
+const HCaptchaVerifyURL = "https://hcaptcha.com/siteverify"
+
+var (
+	ErrNotConfigured = errors.New("HCAPTCHA_SECRET não configurado no servidor")
+	ErrUnavailable   = errors.New("falha ao contatar serviço hCaptcha")
+)
+
+// Result resposta do provedor ({"success": true|false, "challenge_ts": "...", "hostname": "...", "error-codes": [...]})
+type Result struct {
+	Success     bool     `json:"success"`
+	ChallengeTS string   `json:"challenge_ts,omitempty"`
+	Hostname    string   `json:"hostname,omitempty"`
+	ErrorCodes  []string `json:"error-codes,omitempty"`
+}
+
+// Verifier confere um token de captcha resolvido no navegador.
+// Docstring: remoteIP e siteKey são opcionais; erro só quando o provedor não respondeu (ErrUnavailable)
+// ou respondeu algo ilegível. Token recusado é Result.Success == false, sem erro.
+type Verifier interface {
+	Verify(ctx context.Context, token, remoteIP, siteKey string) (*Result, error)
+}
+
+// New cria o Verifier configurado; sem HCAPTCHA_SECRET retorna ErrNotConfigured
+func New(cfg *config.Config) (Verifier, error) {
+	secret := strings.TrimSpace(cfg.HCaptchaSecret)
+	if secret == "" {
+		return nil, ErrNotConfigured
+	}
+	return NewHCaptcha(secret, "", nil), nil
+}
+
+// HCaptcha verificação no siteverify do hCaptcha
+type HCaptcha struct {
+	secret    string
+	verifyURL string
+	client    *http.Client
+}
+
+// NewHCaptcha cria o verificador; verifyURL vazio usa HCaptchaVerifyURL e client nil um cliente com timeout de 10s
+func NewHCaptcha(secret, verifyURL string, client *http.Client) *HCaptcha {
+	if verifyURL == "" {
+		verifyURL = HCaptchaVerifyURL
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HCaptcha{secret: secret, verifyURL: verifyURL, client: client}
+}
+
+// Verify envia o token ao siteverify (formulário com secret, response, remoteip e sitekey)
+func (h *HCaptcha) Verify(ctx context.Context, token, remoteIP, siteKey string) (*Result, error) {
+	form := url.Values{}
+	form.Set("secret", h.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	if siteKey != "" {
+		form.Set("sitekey", siteKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var out Result
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("resposta inválida do hCaptcha: %w", err)
+	}
+	return &out, nil
+}
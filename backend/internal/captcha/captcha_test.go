@@ -0,0 +1,58 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da verificação de captcha no hCaptcha
+// Data: 18-10-2026
+
+package captcha
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"recibofast/internal/config"
+)
+
+func TestHCaptchaVerify(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("secret") != "s3cret" || r.PostForm.Get("remoteip") != "203.0.113.7" {
+			t.Errorf("formulário enviado = %v", r.PostForm)
+		}
+		switch r.PostForm.Get("response") {
+		case "ok":
+			w.Write([]byte(`{"success":true,"hostname":"app.recibofast.com"}`))
+		case "bad":
+			w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+		default:
+			w.Write([]byte(`<html>`))
+		}
+	}))
+	defer upstream.Close()
+	v := NewHCaptcha("s3cret", upstream.URL, nil)
+
+	res, err := v.Verify(context.Background(), "ok", "203.0.113.7", "")
+	if err != nil || !res.Success || res.Hostname != "app.recibofast.com" {
+		t.Fatalf("token aceito: %+v (%v)", res, err)
+	}
+	res, err = v.Verify(context.Background(), "bad", "203.0.113.7", "")
+	if err != nil || res.Success || len(res.ErrorCodes) != 1 || res.ErrorCodes[0] != "invalid-input-response" {
+		t.Fatalf("token recusado: %+v (%v)", res, err)
+	}
+	if _, err := v.Verify(context.Background(), "html", "203.0.113.7", ""); err == nil || errors.Is(err, ErrUnavailable) {
+		t.Fatalf("resposta ilegível: err = %v", err)
+	}
+
+	upstream.Close()
+	if _, err := v.Verify(context.Background(), "ok", "203.0.113.7", ""); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("provedor fora do ar: err = %v", err)
+	}
+}
+
+func TestNew_NotConfigured(t *testing.T) {
+	if _, err := New(&config.Config{HCaptchaSecret: "  "}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("err = %v", err)
+	}
+}
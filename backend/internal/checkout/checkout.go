@@ -0,0 +1,141 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Links de pagamento (checkout hospedado) por gateway: Stripe Checkout ou Mercado Pago Checkout Pro
+// Data: 18-10-2026
+
+package checkout
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+// Gateways suportados (CHECKOUT_PROVIDER)
+const (
+	ProviderStripe      = "stripe"
+	ProviderMercadoPago = "mercadopago"
+)
+
+// DefaultExpiration validade padrão do link; o Stripe aceita no máximo 24h
+const DefaultExpiration = 24 * time.Hour
+
+var (
+	ErrNotConfigured    = errors.New("links de pagamento não configurados")
+	ErrUnknownProvider  = errors.New("gateway de checkout desconhecido (use stripe ou mercadopago)")
+	ErrInvalidSignature = errors.New("assinatura do webhook de checkout inválida")
+	ErrInvalidPayload   = errors.New("notificação de checkout malformada")
+)
+
+// SessionRequest dados da sessão de checkout.
+// Docstring: Reference é o id do link no ReciboFast e volta nas notificações do gateway para
+// casar o pagamento com a receita; Amount em centavos (BRL).
+type SessionRequest struct {
+	Reference   string
+	Amount      int64
+	Description string
+	PayerEmail  string
+	SuccessURL  string
+	CancelURL   string
+	Expiration  time.Duration
+}
+
+// Session checkout criado no gateway; URL é a página hospedada enviada ao cliente
+type Session struct {
+	Provider  string
+	SessionID string
+	URL       string
+	ExpiresAt time.Time
+}
+
+// WebhookRequest notificação recebida do gateway (corpo bruto, cabeçalhos e query string)
+type WebhookRequest struct {
+	Header http.Header
+	Query  url.Values
+	Body   []byte
+}
+
+// Payment pagamento aprovado no gateway; Amount em centavos e Method no vocabulário das formas
+// de pagamento (cartao, pix, boleto)
+type Payment struct {
+	Reference string
+	PaymentID string
+	Method    string
+	Amount    int64
+	PaidAt    time.Time
+}
+
+// Gateway cria sessões de checkout hospedado e lê as notificações de pagamento.
+// Docstring: ParseWebhook valida a assinatura com CHECKOUT_WEBHOOK_SECRET e devolve só pagamentos
+// aprovados; eventos de outros tipos dão lista vazia.
+type Gateway interface {
+	Name() string
+	CreateSession(ctx context.Context, req *SessionRequest) (*Session, error)
+	ParseWebhook(ctx context.Context, req *WebhookRequest, secret string) ([]Payment, error)
+}
+
+// New cria o Gateway configurado; sem CHECKOUT_PROVIDER retorna ErrNotConfigured
+func New(cfg *config.Config) (Gateway, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.CheckoutProvider)) {
+	case "":
+		return nil, ErrNotConfigured
+	case ProviderStripe:
+		if cfg.StripeSecretKey == "" || cfg.CheckoutSuccessURL == "" {
+			return nil, fmt.Errorf("%w: STRIPE_SECRET_KEY e CHECKOUT_SUCCESS_URL são obrigatórios", ErrNotConfigured)
+		}
+		return &stripeGateway{baseURL: "https://api.stripe.com", key: cfg.StripeSecretKey,
+			hc: &http.Client{Timeout: 20 * time.Second}, now: time.Now}, nil
+	case ProviderMercadoPago:
+		if cfg.MercadoPagoAccessToken == "" {
+			return nil, fmt.Errorf("%w: MERCADOPAGO_ACCESS_TOKEN é obrigatório", ErrNotConfigured)
+		}
+		return &mercadoPagoGateway{baseURL: "https://api.mercadopago.com", token: cfg.MercadoPagoAccessToken,
+			hc: &http.Client{Timeout: 20 * time.Second}, now: time.Now}, nil
+	}
+	return nil, ErrUnknownProvider
+}
+
+// validHMAC compara a assinatura hex com o HMAC-SHA256 da mensagem
+func validHMAC(secret, message, signature string) bool {
+	got, err := hex.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(got) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// signatureParts lê cabeçalhos no formato "t=...,v1=...": valores por chave (v1 pode repetir)
+func signatureParts(header string) map[string][]string {
+	out := map[string][]string{}
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok {
+			out[k] = append(out[k], v)
+		}
+	}
+	return out
+}
+
+// formatAmount centavos no formato decimal ("150.00")
+func formatAmount(cents int64) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -0,0 +1,158 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes dos gateways de checkout (Stripe Checkout e Mercado Pago Checkout Pro)
+// Data: 18-10-2026
+
+package checkout
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+func sign(secret, message string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestNew_RequiresGatewaySettings(t *testing.T) {
+	if _, err := New(&config.Config{}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("sem provedor: err = %v", err)
+	}
+	if _, err := New(&config.Config{CheckoutProvider: "paypal"}); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("provedor desconhecido: err = %v", err)
+	}
+	if _, err := New(&config.Config{CheckoutProvider: "stripe", StripeSecretKey: "sk_test_1"}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("stripe sem success url: err = %v", err)
+	}
+	g, err := New(&config.Config{CheckoutProvider: "MercadoPago", MercadoPagoAccessToken: "APP_USR-1"})
+	if err != nil || g.Name() != ProviderMercadoPago {
+		t.Fatalf("mercadopago: %v, %v", g, err)
+	}
+}
+
+func TestStripeGateway_CreatesSession(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/checkout/sessions" || r.Header.Get("Authorization") != "Bearer sk_test_1" || r.Header.Get("Idempotency-Key") != "ref-1" {
+			t.Errorf("requisição inesperada %s %v", r.URL.Path, r.Header)
+		}
+		_ = r.ParseForm()
+		form = r.PostForm
+		fmt.Fprintf(w, `{"id":"cs_test_1","url":"https://checkout.stripe.com/c/pay/cs_test_1","expires_at":%d}`, now.Add(time.Hour).Unix())
+	}))
+	defer srv.Close()
+
+	g := &stripeGateway{baseURL: srv.URL, key: "sk_test_1", hc: srv.Client(), now: func() time.Time { return now }}
+	s, err := g.CreateSession(context.Background(), &SessionRequest{Reference: "ref-1", Amount: 15050, Description: "Aluguel - 2026-10",
+		PayerEmail: "pagador@exemplo.com", SuccessURL: "https://app.exemplo.com/ok", Expiration: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.SessionID != "cs_test_1" || s.URL != "https://checkout.stripe.com/c/pay/cs_test_1" || !s.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("sessão = %+v", s)
+	}
+	if form.Get("line_items[0][price_data][unit_amount]") != "15050" || form.Get("line_items[0][price_data][currency]") != "brl" ||
+		form.Get("client_reference_id") != "ref-1" || form.Get("customer_email") != "pagador@exemplo.com" ||
+		form.Get("expires_at") != strconv.FormatInt(now.Add(time.Hour).Unix(), 10) || form.Has("cancel_url") {
+		t.Fatalf("formulário = %v", form)
+	}
+}
+
+func TestStripeGateway_ParseWebhook(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	g := &stripeGateway{now: func() time.Time { return now }}
+	event := func(typ, status string) []byte {
+		b, _ := json.Marshal(map[string]interface{}{"type": typ, "created": now.Unix(), "data": map[string]interface{}{"object": map[string]interface{}{
+			"client_reference_id": "ref-1", "payment_status": status, "amount_total": 15050, "payment_intent": "pi_1",
+			"payment_method_types": []string{"card"}}}})
+		return b
+	}
+	signed := func(body []byte, ts time.Time, secret string) *WebhookRequest {
+		t := strconv.FormatInt(ts.Unix(), 10)
+		return &WebhookRequest{Header: http.Header{"Stripe-Signature": {"t=" + t + ",v1=" + sign(secret, t+"."+string(body))}}, Body: body}
+	}
+
+	got, err := g.ParseWebhook(context.Background(), signed(event("checkout.session.completed", "paid"), now, "whsec_1"), "whsec_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Reference != "ref-1" || got[0].PaymentID != "pi_1" || got[0].Amount != 15050 || got[0].Method != "cartao" || !got[0].PaidAt.Equal(now) {
+		t.Fatalf("pagamentos = %+v", got)
+	}
+	if got, err := g.ParseWebhook(context.Background(), signed(event("checkout.session.completed", "unpaid"), now, "whsec_1"), "whsec_1"); err != nil || len(got) != 0 {
+		t.Fatalf("pagamento pendente: %+v, %v", got, err)
+	}
+	if got, err := g.ParseWebhook(context.Background(), signed(event("checkout.session.expired", "unpaid"), now, "whsec_1"), "whsec_1"); err != nil || len(got) != 0 {
+		t.Fatalf("outro evento: %+v, %v", got, err)
+	}
+	if _, err := g.ParseWebhook(context.Background(), signed(event("checkout.session.completed", "paid"), now, "outro"), "whsec_1"); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("segredo errado: err = %v", err)
+	}
+	if _, err := g.ParseWebhook(context.Background(), signed(event("checkout.session.completed", "paid"), now.Add(-10*time.Minute), "whsec_1"), "whsec_1"); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("assinatura antiga: err = %v", err)
+	}
+}
+
+func TestMercadoPagoGateway_SessionAndWebhook(t *testing.T) {
+	var pref map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer APP_USR-1" {
+			t.Errorf("token = %q", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/checkout/preferences":
+			_ = json.NewDecoder(r.Body).Decode(&pref)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"123-abc","init_point":"https://www.mercadopago.com.br/checkout/v1/redirect?pref_id=123-abc"}`)
+		case "/v1/payments/555":
+			fmt.Fprint(w, `{"id":555,"status":"approved","external_reference":"ref-1","payment_method_id":"master","payment_type_id":"credit_card",
+				"transaction_amount":150.5,"date_approved":"2026-10-18T09:00:00.000-03:00"}`)
+		default:
+			t.Errorf("rota inesperada %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	g := &mercadoPagoGateway{baseURL: srv.URL, token: "APP_USR-1", hc: srv.Client(), now: time.Now}
+	s, err := g.CreateSession(context.Background(), &SessionRequest{Reference: "ref-1", Amount: 15050, SuccessURL: "https://app.exemplo.com/ok"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.SessionID != "123-abc" || s.URL == "" || pref["external_reference"] != "ref-1" || pref["auto_return"] != "approved" {
+		t.Fatalf("sessão = %+v, preferência = %v", s, pref)
+	}
+	if item := pref["items"].([]interface{})[0].(map[string]interface{}); item["unit_price"] != 150.5 || item["currency_id"] != "BRL" {
+		t.Fatalf("item = %v", item)
+	}
+
+	body := []byte(`{"type":"payment","data":{"id":"555"}}`)
+	req := &WebhookRequest{Header: http.Header{"X-Signature": {"ts=1760788800,v1=" + sign("mp-secret", "id:555;request-id:req-1;ts:1760788800;")},
+		"X-Request-Id": {"req-1"}}, Query: url.Values{}, Body: body}
+	got, err := g.ParseWebhook(context.Background(), req, "mp-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Reference != "ref-1" || got[0].PaymentID != "555" || got[0].Amount != 15050 || got[0].Method != "cartao" ||
+		!got[0].PaidAt.Equal(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("pagamentos = %+v", got)
+	}
+	if _, err := g.ParseWebhook(context.Background(), req, "outro"); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("segredo errado: err = %v", err)
+	}
+}
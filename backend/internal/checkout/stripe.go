@@ -0,0 +1,158 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Links de pagamento pelo Stripe Checkout (sessões hospedadas e webhook assinado)
+// Data: 18-10-2026
+
+package checkout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stripeSignatureTolerance diferença máxima entre o carimbo da assinatura e o relógio do servidor
+const stripeSignatureTolerance = 5 * time.Minute
+
+// stripeGateway cria Checkout Sessions em modo "payment" com preço avulso em BRL.
+// Docstring: a referência do link vai em client_reference_id e serve de chave de idempotência.
+type stripeGateway struct {
+	baseURL string
+	key     string
+	hc      *http.Client
+	now     func() time.Time
+}
+
+func (g *stripeGateway) Name() string { return ProviderStripe }
+
+// CreateSession cria a sessão de checkout e devolve a URL hospedada
+func (g *stripeGateway) CreateSession(ctx context.Context, req *SessionRequest) (*Session, error) {
+	exp := req.Expiration
+	if exp <= 0 {
+		exp = DefaultExpiration
+	}
+	description := req.Description
+	if description == "" {
+		description = "Cobrança ReciboFast"
+	}
+	form := url.Values{
+		"mode":                                   {"payment"},
+		"client_reference_id":                    {req.Reference},
+		"metadata[reference]":                    {req.Reference},
+		"success_url":                            {req.SuccessURL},
+		"expires_at":                             {strconv.FormatInt(g.now().Add(exp).Unix(), 10)},
+		"line_items[0][quantity]":                {"1"},
+		"line_items[0][price_data][currency]":    {"brl"},
+		"line_items[0][price_data][unit_amount]": {strconv.FormatInt(req.Amount, 10)},
+		"line_items[0][price_data][product_data][name]": {truncate(description, 250)},
+		"payment_intent_data[metadata][reference]":      {req.Reference},
+	}
+	if req.CancelURL != "" {
+		form.Set("cancel_url", req.CancelURL)
+	}
+	if req.PayerEmail != "" {
+		form.Set("customer_email", req.PayerEmail)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+"/v1/checkout/sessions", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+g.key)
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Idempotency-Key", req.Reference)
+	resp, err := g.hc.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s respondeu %d: %s", ProviderStripe, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	var out struct {
+		ID        string `json:"id"`
+		URL       string `json:"url"`
+		ExpiresAt int64  `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	if out.ID == "" || out.URL == "" {
+		return nil, fmt.Errorf("%s não devolveu a URL do checkout", ProviderStripe)
+	}
+	return &Session{Provider: ProviderStripe, SessionID: out.ID, URL: out.URL, ExpiresAt: time.Unix(out.ExpiresAt, 0).UTC()}, nil
+}
+
+// ParseWebhook valida Stripe-Signature (t e v1 = HMAC-SHA256 de "{t}.{corpo}" com o segredo
+// whsec_ do endpoint) e lê checkout.session.completed pago ou async_payment_succeeded (boleto).
+func (g *stripeGateway) ParseWebhook(ctx context.Context, req *WebhookRequest, secret string) ([]Payment, error) {
+	if secret == "" {
+		return nil, ErrInvalidSignature
+	}
+	parts := signatureParts(req.Header.Get("Stripe-Signature"))
+	if len(parts["t"]) != 1 || len(parts["v1"]) == 0 {
+		return nil, ErrInvalidSignature
+	}
+	ts, err := strconv.ParseInt(parts["t"][0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	if d := g.now().Sub(time.Unix(ts, 0)); d > stripeSignatureTolerance || d < -stripeSignatureTolerance {
+		return nil, ErrInvalidSignature
+	}
+	valid := false
+	for _, sig := range parts["v1"] {
+		if validHMAC(secret, parts["t"][0]+"."+string(req.Body), sig) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, ErrInvalidSignature
+	}
+
+	var event struct {
+		Type    string `json:"type"`
+		Created int64  `json:"created"`
+		Data    struct {
+			Object struct {
+				ClientReferenceID  string   `json:"client_reference_id"`
+				PaymentStatus      string   `json:"payment_status"`
+				AmountTotal        int64    `json:"amount_total"`
+				PaymentIntent      string   `json:"payment_intent"`
+				PaymentMethodTypes []string `json:"payment_method_types"`
+			} `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(req.Body, &event); err != nil {
+		return nil, ErrInvalidPayload
+	}
+	s := event.Data.Object
+	switch event.Type {
+	case "checkout.session.completed":
+		if s.PaymentStatus != "paid" {
+			// Boleto e outros meios assíncronos chegam depois em async_payment_succeeded
+			return nil, nil
+		}
+	case "checkout.session.async_payment_succeeded":
+	default:
+		return nil, nil
+	}
+	if s.ClientReferenceID == "" || s.AmountTotal <= 0 {
+		return nil, ErrInvalidPayload
+	}
+	method := "cartao"
+	if len(s.PaymentMethodTypes) == 1 && (s.PaymentMethodTypes[0] == "boleto" || s.PaymentMethodTypes[0] == "pix") {
+		method = s.PaymentMethodTypes[0]
+	}
+	return []Payment{{Reference: s.ClientReferenceID, PaymentID: s.PaymentIntent, Method: method,
+		Amount: s.AmountTotal, PaidAt: time.Unix(event.Created, 0).UTC()}}, nil
+}
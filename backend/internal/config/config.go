@@ -5,45 +5,218 @@
 
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 // Config define as configurações do servidor.
 // Docstring: Estrutura que armazena as configurações lidas de variáveis de ambiente.
 // - APIPort: porta do servidor HTTP
+// - GRPCPort: porta do gRPC recibofast.v1 (GRPC_PORT); vazio desabilita
+// - GRPCGatewayPort: porta do grpc-gateway (REST/JSON sobre o gRPC, GRPC_GATEWAY_PORT); exige GRPC_PORT
 // - Env: ambiente (dev, prod)
 // - DBURL: string de conexão com Postgres (Supabase)
-// - CORSOrigins: origens permitidas para CORS (se aplicável)
+// - DBAutoMigrate: aplica as migrações embutidas (migrations/) na inicialização (DB_AUTO_MIGRATE)
+// - DBMaxConns/DBMinConns: tamanho do pool (DB_MAX_CONNS, padrão 10; DB_MIN_CONNS, padrão 0)
+// - DBMaxConnIdleTime/DBMaxConnLifetime: conexões ociosas e antigas são recicladas (padrões 5m e 1h)
+// - DBConnectTimeout: limite de cada tentativa de conexão (DB_CONNECT_TIMEOUT, padrão 5s)
+// - DBStatementCacheSize: cache de prepared statements por conexão (DB_STATEMENT_CACHE_SIZE, padrão 512);
+//   0 desliga o cache, necessário no pooler do Supabase em modo transação (porta 6543)
+// - CORSOrigins: origens permitidas no CORS (ALLOWED_ORIGINS, ou a legada CORS_ORIGINS; separadas por
+//   vírgula, aceita "*" e "*.dominio"); vazio permite qualquer origem sem credenciais
+// - CORSAllowCredentials: envia Access-Control-Allow-Credentials (CORS_ALLOW_CREDENTIALS)
+// - CORSMaxAge: cache do preflight no navegador (CORS_MAX_AGE, padrão 10m)
 // - JWKSURL: URL do JWKS do Supabase para validar JWT
+// - JWKSCacheTTL: intervalo de atualização do JWKS em cache (JWKS_CACHE_TTL, padrão 1h)
+// - UserReadRateLimit/UserWriteRateLimit: requisições por minuto de cada usuário autenticado em rotas
+//   de leitura (GET/HEAD/OPTIONS) e de escrita (RATE_LIMIT_USER_READ/RATE_LIMIT_USER_WRITE; 0 desabilita)
 // - SupabaseURL: URL base do projeto Supabase
 // - Storage buckets: nomes dos buckets de Storage
 // - MasterKey: chave mestra (opcional) para envelope encryption
+// - AdminToken: token das rotas administrativas (/api/v1/admin) e de diagnóstico (/debug); vazio desabilita
+// - BCBURL: URL base da API de dados abertos do Banco Central (cotações e índices)
+// - EmailProvider: provedor de e-mail (smtp, resend ou sendgrid); vazio desabilita o envio
+// - EmailFrom/EmailFromName: remetente dos e-mails; SMTP*: servidor SMTP (porta 587 com STARTTLS)
+// - ResendAPIKey/SendGridAPIKey: chaves das APIs dos provedores HTTP
+// - VAPIDPublicKey/VAPIDPrivateKey/VAPIDSubject: chaves VAPID do Web Push (PWA); vazio desabilita o push
+// - PixProvider: PSP das cobranças PIX (gerencianet, bacen ou mercadopago); vazio desabilita
+// - PixAPIURL/PixClientID/PixClientSecret/PixCertFile/PixCertKeyFile: API Pix do BCB (OAuth2 + mTLS)
+// - PixKey: chave PIX recebedora padrão; MercadoPagoAccessToken: token da API do Mercado Pago
+// - PixWebhookSecret: segredo que valida as notificações de pagamento em /webhooks/pix; vazio recusa todas
+// - CheckoutProvider: gateway dos links de pagamento (stripe ou mercadopago); vazio desabilita
+// - StripeSecretKey: chave secreta da API do Stripe; CheckoutWebhookSecret: segredo de /webhooks/checkout
+// - CheckoutSuccessURL/CheckoutCancelURL: páginas de retorno do checkout hospedado
+// - NFSeProvider: integração municipal de NFS-e registrada no pacote nfse; vazio só exporta o XML
+// - PublicBaseURL: URL pública da API usada nos links compartilhados; vazio usa o host da requisição
+// - HCaptchaSecret/HCaptchaSiteKey: verificação server-side e sitekey pública do hCaptcha
+// - CaptchaRequired: exige captcha (X-Captcha-Token) na consulta pública de recibos e no aceite de
+//   convites (CAPTCHA_REQUIRED); só vale com HCAPTCHA_SECRET configurado
+// - AccessLog*: log de acesso; AccessLogSampleRate (0 a 1, padrão 1) amostra as respostas de sucesso das
+//   rotas em AccessLogSampledPaths (prefixos separados por vírgula); erros e requisições mais lentas
+//   que AccessLogSlowThreshold sempre entram; AccessLogHeaders inclui os cabeçalhos (credenciais redigidas)
+// - OpenAPIDocs: serve o Swagger UI em /api/v1/docs (OPENAPI_DOCS); /api/v1/openapi.json é sempre servido
+// - CompressionLevel: nível do gzip das respostas JSON/CSV (COMPRESSION_LEVEL, 1..9; padrão 5)
 // Erros são tratados no nível de inicialização do app.
 type Config struct {
 	APIPort      string
+	GRPCPort     string
+	GRPCGatewayPort string
 	Env          string
 	DBURL        string
+	DBAutoMigrate bool
+	DBMaxConns        int
+	DBMinConns        int
+	DBMaxConnIdleTime time.Duration
+	DBMaxConnLifetime time.Duration
+	DBConnectTimeout  time.Duration
+	DBStatementCacheSize int
 	CORSOrigins  string
+	CORSAllowCredentials bool
+	CORSMaxAge   time.Duration
 	JWKSURL      string
+	JWKSCacheTTL time.Duration
+	UserReadRateLimit  int
+	UserWriteRateLimit int
 	SupabaseURL  string
 	BucketSigns  string
 	BucketReceipts string
+	StorageMaxAttempts      int
+	StorageRetryBaseDelay   time.Duration
+	StorageRetryMaxDelay    time.Duration
+	StorageBreakerThreshold int
+	StorageBreakerCooldown  time.Duration
+	StorageGCMinAgeDays     int
+	SignatureMinWidth      int
+	SignatureMinHeight     int
+	SignatureMaxWidth      int
+	SignatureMaxHeight     int
+	SignatureMinAspect     float64
+	SignatureMaxAspect     float64
+	SignatureMaxMegapixels float64
 	MasterKey    string
 	SupabaseServiceRoleKey string
+	AdminToken   string
+	BCBURL       string
+	EmailProvider  string
+	EmailFrom      string
+	EmailFromName  string
+	SMTPHost       string
+	SMTPPort       string
+	SMTPUser       string
+	SMTPPassword   string
+	ResendAPIKey   string
+	SendGridAPIKey string
+	PublicBaseURL  string
+	VAPIDPublicKey  string
+	VAPIDPrivateKey string
+	VAPIDSubject    string
+	PixProvider     string
+	PixAPIURL       string
+	PixClientID     string
+	PixClientSecret string
+	PixCertFile     string
+	PixCertKeyFile  string
+	PixKey          string
+	MercadoPagoAccessToken string
+	PixWebhookSecret       string
+	CheckoutProvider       string
+	StripeSecretKey        string
+	CheckoutWebhookSecret  string
+	CheckoutSuccessURL     string
+	CheckoutCancelURL      string
+	NFSeProvider           string
+	HCaptchaSecret         string
+	HCaptchaSiteKey        string
+	CaptchaRequired        bool
+	AccessLogSampleRate    float64
+	AccessLogSampledPaths  string
+	AccessLogSlowThreshold time.Duration
+	AccessLogHeaders       bool
+	OpenAPIDocs            bool
+	CompressionLevel       int
 }
 
 // FromEnv carrega configurações das variáveis de ambiente.
 func FromEnv() *Config {
 	cfg := &Config{
 		APIPort:       getEnv("API_PORT", "8080"),
+		GRPCPort:      os.Getenv("GRPC_PORT"),
+		GRPCGatewayPort: os.Getenv("GRPC_GATEWAY_PORT"),
 		Env:           getEnv("APP_ENV", "dev"),
 		DBURL:         os.Getenv("DB_URL"),
-		CORSOrigins:   os.Getenv("CORS_ORIGINS"),
+		DBAutoMigrate: getEnvBool("DB_AUTO_MIGRATE", false),
+		DBMaxConns:        getEnvInt("DB_MAX_CONNS", 10),
+		DBMinConns:        getEnvInt("DB_MIN_CONNS", 0),
+		DBMaxConnIdleTime: getEnvDuration("DB_MAX_CONN_IDLE_TIME", 5*time.Minute),
+		DBMaxConnLifetime: getEnvDuration("DB_MAX_CONN_LIFETIME", time.Hour),
+		DBConnectTimeout:  getEnvDuration("DB_CONNECT_TIMEOUT", 5*time.Second),
+		DBStatementCacheSize: getEnvInt("DB_STATEMENT_CACHE_SIZE", 512),
+		CORSOrigins:   getEnv("ALLOWED_ORIGINS", os.Getenv("CORS_ORIGINS")),
+		CORSAllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", false),
+		CORSMaxAge:    getEnvDuration("CORS_MAX_AGE", 10*time.Minute),
 		JWKSURL:       os.Getenv("JWKS_URL"),
+		JWKSCacheTTL:  getEnvDuration("JWKS_CACHE_TTL", time.Hour),
+		UserReadRateLimit:  getEnvInt("RATE_LIMIT_USER_READ", 300),
+		UserWriteRateLimit: getEnvInt("RATE_LIMIT_USER_WRITE", 60),
 		SupabaseURL:   os.Getenv("SUPABASE_URL"),
 		BucketSigns:   getEnv("STORAGE_BUCKET_SIGNATURES", "signatures"),
 		BucketReceipts:getEnv("STORAGE_BUCKET_RECEIPTS", "receipts"),
+		StorageMaxAttempts:      getEnvInt("STORAGE_MAX_ATTEMPTS", 3),
+		StorageRetryBaseDelay:   getEnvDuration("STORAGE_RETRY_BASE_DELAY", 200*time.Millisecond),
+		StorageRetryMaxDelay:    getEnvDuration("STORAGE_RETRY_MAX_DELAY", 2*time.Second),
+		StorageBreakerThreshold: getEnvInt("STORAGE_BREAKER_THRESHOLD", 5),
+		StorageBreakerCooldown:  getEnvDuration("STORAGE_BREAKER_COOLDOWN", 30*time.Second),
+		StorageGCMinAgeDays:     getEnvInt("STORAGE_GC_MIN_AGE_DAYS", 7),
+		SignatureMinWidth:      getEnvInt("SIGNATURE_MIN_WIDTH", 50),
+		SignatureMinHeight:     getEnvInt("SIGNATURE_MIN_HEIGHT", 20),
+		SignatureMaxWidth:      getEnvInt("SIGNATURE_MAX_WIDTH", 4000),
+		SignatureMaxHeight:     getEnvInt("SIGNATURE_MAX_HEIGHT", 4000),
+		SignatureMinAspect:     getEnvPositiveFloat("SIGNATURE_MIN_ASPECT", 0.5),
+		SignatureMaxAspect:     getEnvPositiveFloat("SIGNATURE_MAX_ASPECT", 12),
+		SignatureMaxMegapixels: getEnvPositiveFloat("SIGNATURE_MAX_MEGAPIXELS", 16),
 		MasterKey:     os.Getenv("MASTER_KEY"),
 		SupabaseServiceRoleKey: os.Getenv("SUPABASE_SERVICE_ROLE_KEY"),
+		AdminToken:    os.Getenv("ADMIN_TOKEN"),
+		BCBURL:        getEnv("BCB_API_URL", "https://api.bcb.gov.br"),
+		EmailProvider: os.Getenv("EMAIL_PROVIDER"),
+		EmailFrom:     os.Getenv("EMAIL_FROM"),
+		EmailFromName: getEnv("EMAIL_FROM_NAME", "ReciboFast"),
+		SMTPHost:      os.Getenv("SMTP_HOST"),
+		SMTPPort:      getEnv("SMTP_PORT", "587"),
+		SMTPUser:      os.Getenv("SMTP_USER"),
+		SMTPPassword:  os.Getenv("SMTP_PASSWORD"),
+		ResendAPIKey:  os.Getenv("RESEND_API_KEY"),
+		SendGridAPIKey:os.Getenv("SENDGRID_API_KEY"),
+		PublicBaseURL: os.Getenv("PUBLIC_BASE_URL"),
+		VAPIDPublicKey: os.Getenv("VAPID_PUBLIC_KEY"),
+		VAPIDPrivateKey:os.Getenv("VAPID_PRIVATE_KEY"),
+		VAPIDSubject:  os.Getenv("VAPID_SUBJECT"),
+		PixProvider:   os.Getenv("PIX_PROVIDER"),
+		PixAPIURL:     os.Getenv("PIX_API_URL"),
+		PixClientID:   os.Getenv("PIX_CLIENT_ID"),
+		PixClientSecret:os.Getenv("PIX_CLIENT_SECRET"),
+		PixCertFile:   os.Getenv("PIX_CERT_FILE"),
+		PixCertKeyFile:os.Getenv("PIX_CERT_KEY_FILE"),
+		PixKey:        os.Getenv("PIX_KEY"),
+		MercadoPagoAccessToken: os.Getenv("MERCADOPAGO_ACCESS_TOKEN"),
+		PixWebhookSecret:       os.Getenv("PIX_WEBHOOK_SECRET"),
+		CheckoutProvider:       os.Getenv("CHECKOUT_PROVIDER"),
+		StripeSecretKey:        os.Getenv("STRIPE_SECRET_KEY"),
+		CheckoutWebhookSecret:  os.Getenv("CHECKOUT_WEBHOOK_SECRET"),
+		CheckoutSuccessURL:     os.Getenv("CHECKOUT_SUCCESS_URL"),
+		CheckoutCancelURL:      os.Getenv("CHECKOUT_CANCEL_URL"),
+		NFSeProvider:           os.Getenv("NFSE_PROVIDER"),
+		HCaptchaSecret:         os.Getenv("HCAPTCHA_SECRET"),
+		HCaptchaSiteKey:        os.Getenv("HCAPTCHA_SITE_KEY"),
+		CaptchaRequired:        getEnvBool("CAPTCHA_REQUIRED", false),
+		AccessLogSampleRate:    getEnvFloat("ACCESS_LOG_SAMPLE_RATE", 1),
+		AccessLogSampledPaths:  getEnv("ACCESS_LOG_SAMPLED_PATHS", "/healthz,/livez,/readyz,/api/v1/sync/changes"),
+		AccessLogSlowThreshold: getEnvDuration("ACCESS_LOG_SLOW", time.Second),
+		AccessLogHeaders:       getEnvBool("ACCESS_LOG_HEADERS", false),
+		OpenAPIDocs:            getEnvBool("OPENAPI_DOCS", false),
+		CompressionLevel:       getEnvInt("COMPRESSION_LEVEL", 5),
 	}
 	return cfg
 }
@@ -52,3 +225,28 @@ func getEnv(key, def string) string {
 	if v := os.Getenv(key); v != "" { return v }
 	return def
 }
+
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 { return d }
+	return def
+}
+
+func getEnvInt(key string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n >= 0 { return n }
+	return def
+}
+
+func getEnvBool(key string, def bool) bool {
+	if b, err := strconv.ParseBool(os.Getenv(key)); err == nil { return b }
+	return def
+}
+
+func getEnvPositiveFloat(key string, def float64) float64 {
+	if f, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil && f >= 0 { return f }
+	return def
+}
+
+func getEnvFloat(key string, def float64) float64 {
+	if f, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil && f >= 0 && f <= 1 { return f }
+	return def
+}
@@ -14,7 +14,9 @@ type ctxKey string
 
 const userIDKey ctxKey = "user_id"
 
-// SetUserID adiciona o user_id ao contexto
+// SetUserID adiciona o user_id ao contexto.
+// Docstring: é o dono dos dados da requisição: o próprio usuário ou, com X-Org-ID, o dono da
+// organização (o usuário autenticado fica em ActorID).
 func SetUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, userIDKey, userID)
 }
@@ -29,4 +31,82 @@ func GetUserID(ctx context.Context) (string, bool) {
 		return s, true
 	}
 	return "", false
-}
\ No newline at end of file
+}
+
+const pageEnvelopeKey ctxKey = "page_envelope"
+
+// SetPageEnvelope marca a requisição para responder listagens no envelope Page[T]
+func SetPageEnvelope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pageEnvelopeKey, true)
+}
+
+// WantsPageEnvelope informa se a listagem deve usar o envelope Page[T]
+func WantsPageEnvelope(ctx context.Context) bool {
+	v, _ := ctx.Value(pageEnvelopeKey).(bool)
+	return v
+}
+
+const actorIDKey ctxKey = "actor_id"
+const orgRoleKey ctxKey = "org_role"
+
+// SetActorID registra o usuário autenticado quando ele atua no espaço de uma organização
+func SetActorID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, actorIDKey, userID)
+}
+
+// GetActorID obtém o usuário autenticado; fora de organização é o próprio user_id
+func GetActorID(ctx context.Context) (string, bool) {
+	if v, ok := ctx.Value(actorIDKey).(string); ok {
+		return v, true
+	}
+	return GetUserID(ctx)
+}
+
+// SetOrgRole registra o papel do usuário na organização ativa
+func SetOrgRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, orgRoleKey, role)
+}
+
+// GetOrgRole obtém o papel na organização ativa (vazio no espaço próprio)
+func GetOrgRole(ctx context.Context) string {
+	v, _ := ctx.Value(orgRoleKey).(string)
+	return v
+}
+
+const authUserKey ctxKey = "auth_user"
+
+// Origem da autenticação registrada em AuthUser.Method
+const (
+	AuthMethodJWT    = "jwt"
+	AuthMethodAPIKey = "api_key"
+	AuthMethodDebug  = "debug"
+)
+
+// AuthUser usuário autenticado com as claims do token.
+// Docstring: ID é sempre o usuário autenticado (mesmo com X-Org-ID); Email, Role e AppMetadata vêm
+// do JWT do Supabase e ficam vazios com chave de API ou X-Debug-User.
+type AuthUser struct {
+	ID          string
+	Email       string
+	Role        string
+	AppMetadata map[string]interface{}
+	Method      string
+	APIKeyID    string
+}
+
+// Plan plano do usuário em app_metadata.plan (vazio se não definido)
+func (u *AuthUser) Plan() string {
+	v, _ := u.AppMetadata["plan"].(string)
+	return v
+}
+
+// SetAuthUser adiciona o usuário autenticado ao contexto
+func SetAuthUser(ctx context.Context, u *AuthUser) context.Context {
+	return context.WithValue(ctx, authUserKey, u)
+}
+
+// GetAuthUser obtém o usuário autenticado do contexto
+func GetAuthUser(ctx context.Context) (*AuthUser, bool) {
+	u, ok := ctx.Value(authUserKey).(*AuthUser)
+	return u, ok && u != nil
+}
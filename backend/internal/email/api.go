@@ -0,0 +1,123 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Envio de e-mails pelas APIs HTTP do Resend e do SendGrid
+// Data: 18-10-2026
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+)
+
+// apiMailer envia a mensagem como JSON para a API do provedor
+type apiMailer struct {
+	name     string
+	endpoint string
+	apiKey   string
+	from     mail.Address
+	body     func(from mail.Address, msg *Message) interface{}
+	hc       *http.Client
+}
+
+func newResendMailer(apiKey string, from mail.Address) *apiMailer {
+	return &apiMailer{name: ProviderResend, endpoint: "https://api.resend.com/emails", apiKey: apiKey, from: from,
+		body: resendBody, hc: &http.Client{Timeout: 20 * time.Second}}
+}
+
+func newSendGridMailer(apiKey string, from mail.Address) *apiMailer {
+	return &apiMailer{name: ProviderSendGrid, endpoint: "https://api.sendgrid.com/v3/mail/send", apiKey: apiKey, from: from,
+		body: sendGridBody, hc: &http.Client{Timeout: 20 * time.Second}}
+}
+
+// Send publica a mensagem; respostas fora de 2xx viram erro com o corpo devolvido pelo provedor
+func (m *apiMailer) Send(ctx context.Context, msg *Message) error {
+	payload, err := json.Marshal(m.body(m.from, msg))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s respondeu %d: %s", m.name, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// resendBody formato de https://resend.com/docs/api-reference/emails/send-email
+func resendBody(from mail.Address, msg *Message) interface{} {
+	type attachment struct {
+		Filename string `json:"filename"`
+		Content  string `json:"content"`
+	}
+	body := struct {
+		From        string       `json:"from"`
+		To          []string     `json:"to"`
+		Subject     string       `json:"subject"`
+		Text        string       `json:"text"`
+		HTML        string       `json:"html,omitempty"`
+		Attachments []attachment `json:"attachments,omitempty"`
+	}{From: from.String(), To: []string{msg.To}, Subject: msg.Subject, Text: msg.Text, HTML: msg.HTML}
+	for _, a := range msg.Attachments {
+		body.Attachments = append(body.Attachments, attachment{Filename: a.Filename, Content: base64.StdEncoding.EncodeToString(a.Content)})
+	}
+	return body
+}
+
+// sendGridBody formato de https://docs.sendgrid.com/api-reference/mail-send/mail-send
+func sendGridBody(from mail.Address, msg *Message) interface{} {
+	type address struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}
+	type content struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	type attachment struct {
+		Content     string `json:"content"`
+		Type        string `json:"type"`
+		Filename    string `json:"filename"`
+		Disposition string `json:"disposition"`
+	}
+	type personalization struct {
+		To []address `json:"to"`
+	}
+	body := struct {
+		Personalizations []personalization `json:"personalizations"`
+		From             address           `json:"from"`
+		Subject          string            `json:"subject"`
+		Content          []content         `json:"content"`
+		Attachments      []attachment      `json:"attachments,omitempty"`
+	}{
+		Personalizations: []personalization{{To: []address{{Email: msg.To}}}},
+		From:             address{Email: from.Address, Name: from.Name},
+		Subject:          msg.Subject,
+		Content:          []content{{Type: "text/plain", Value: msg.Text}},
+	}
+	if msg.HTML != "" {
+		body.Content = append(body.Content, content{Type: "text/html", Value: msg.HTML})
+	}
+	for _, a := range msg.Attachments {
+		body.Attachments = append(body.Attachments, attachment{Content: base64.StdEncoding.EncodeToString(a.Content),
+			Type: a.ContentType, Filename: a.Filename, Disposition: "attachment"})
+	}
+	return body
+}
@@ -0,0 +1,128 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Envio de e-mails transacionais (SMTP, Resend ou SendGrid) com anexos
+// Data: 18-10-2026
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+// Provedores suportados (EMAIL_PROVIDER)
+const (
+	ProviderSMTP     = "smtp"
+	ProviderResend   = "resend"
+	ProviderSendGrid = "sendgrid"
+)
+
+var (
+	ErrNotConfigured   = errors.New("envio de e-mail não configurado")
+	ErrUnknownProvider = errors.New("provedor de e-mail desconhecido (use smtp, resend ou sendgrid)")
+)
+
+// Attachment arquivo anexado à mensagem
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Message e-mail a enviar; Text é obrigatório e HTML é opcional
+type Message struct {
+	To          string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []Attachment
+}
+
+// Mailer envia mensagens por um provedor.
+// Docstring: implementado por SMTP e pelas APIs HTTP do Resend e do SendGrid; o erro devolvido
+// traz a resposta do provedor para ser registrada junto ao envio.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// New cria o Mailer do provedor configurado; sem EMAIL_PROVIDER retorna ErrNotConfigured
+func New(cfg *config.Config) (Mailer, error) {
+	provider := strings.ToLower(strings.TrimSpace(cfg.EmailProvider))
+	if provider == "" {
+		return nil, ErrNotConfigured
+	}
+	if cfg.EmailFrom == "" {
+		return nil, fmt.Errorf("%w: EMAIL_FROM é obrigatório", ErrNotConfigured)
+	}
+	from := mail.Address{Name: cfg.EmailFromName, Address: cfg.EmailFrom}
+	switch provider {
+	case ProviderSMTP:
+		if cfg.SMTPHost == "" {
+			return nil, fmt.Errorf("%w: SMTP_HOST é obrigatório", ErrNotConfigured)
+		}
+		return &smtpMailer{host: cfg.SMTPHost, port: cfg.SMTPPort, user: cfg.SMTPUser, password: cfg.SMTPPassword, from: from}, nil
+	case ProviderResend:
+		if cfg.ResendAPIKey == "" {
+			return nil, fmt.Errorf("%w: RESEND_API_KEY é obrigatório", ErrNotConfigured)
+		}
+		return newResendMailer(cfg.ResendAPIKey, from), nil
+	case ProviderSendGrid:
+		if cfg.SendGridAPIKey == "" {
+			return nil, fmt.Errorf("%w: SENDGRID_API_KEY é obrigatório", ErrNotConfigured)
+		}
+		return newSendGridMailer(cfg.SendGridAPIKey, from), nil
+	}
+	return nil, ErrUnknownProvider
+}
+
+// buildMIME monta a mensagem RFC 5322 (multipart/mixed com texto, HTML opcional e anexos em base64)
+func buildMIME(from mail.Address, msg *Message, now time.Time) []byte {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	var hdr bytes.Buffer
+	fmt.Fprintf(&hdr, "From: %s\r\n", from.String())
+	fmt.Fprintf(&hdr, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&hdr, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&hdr, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	hdr.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&hdr, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mw.Boundary())
+
+	writePart(mw, "text/plain; charset=utf-8", "", []byte(msg.Text))
+	if msg.HTML != "" {
+		writePart(mw, "text/html; charset=utf-8", "", []byte(msg.HTML))
+	}
+	for _, a := range msg.Attachments {
+		writePart(mw, a.ContentType, a.Filename, a.Content)
+	}
+	mw.Close()
+	return append(hdr.Bytes(), body.Bytes()...)
+}
+
+// writePart grava uma parte em base64 (com quebras de 76 colunas); filename a marca como anexo
+func writePart(mw *multipart.Writer, contentType, filename string, content []byte) {
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Transfer-Encoding", "base64")
+	if filename != "" {
+		h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+	w, _ := mw.CreatePart(h)
+	enc := base64.StdEncoding.EncodeToString(content)
+	for len(enc) > 76 {
+		fmt.Fprintf(w, "%s\r\n", enc[:76])
+		enc = enc[76:]
+	}
+	fmt.Fprintf(w, "%s\r\n", enc)
+}
@@ -0,0 +1,84 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da montagem MIME e dos provedores HTTP de e-mail
+// Data: 18-10-2026
+
+package email
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+func TestBuildMIME_AttachmentAndEncodedSubject(t *testing.T) {
+	msg := &Message{To: "pagador@exemplo.com", Subject: "Recibo nº 7", Text: "Olá",
+		Attachments: []Attachment{{Filename: "recibo-7.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")}}}
+	raw := string(buildMIME(mail.Address{Name: "ReciboFast", Address: "nao-responda@exemplo.com"}, msg, time.Unix(0, 0)))
+
+	for _, want := range []string{
+		"To: pagador@exemplo.com\r\n",
+		"Subject: =?utf-8?q?Recibo_n=C2=BA_7?=\r\n",
+		`Content-Disposition: attachment; filename=recibo-7.pdf`,
+		base64.StdEncoding.EncodeToString([]byte("%PDF-1.4")),
+	} {
+		if !strings.Contains(raw, want) {
+			t.Fatalf("mensagem sem %q:\n%s", want, raw)
+		}
+	}
+}
+
+func TestNew_RequiresProviderSettings(t *testing.T) {
+	if _, err := New(&config.Config{}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("sem provedor: err = %v", err)
+	}
+	if _, err := New(&config.Config{EmailProvider: "resend", EmailFrom: "a@b.com"}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("resend sem chave: err = %v", err)
+	}
+	if _, err := New(&config.Config{EmailProvider: "pombo", EmailFrom: "a@b.com"}); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("provedor desconhecido: err = %v", err)
+	}
+	if m, err := New(&config.Config{EmailProvider: "SMTP", EmailFrom: "a@b.com", SMTPHost: "smtp.exemplo.com", SMTPPort: "587"}); err != nil || m == nil {
+		t.Fatalf("smtp: %v", err)
+	}
+}
+
+func TestResendMailer_PostsAttachmentAndReportsErrors(t *testing.T) {
+	var got map[string]interface{}
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer re_123" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+		w.Write([]byte(`{"message":"domínio não verificado"}`))
+	}))
+	defer srv.Close()
+
+	m := newResendMailer("re_123", mail.Address{Address: "nao-responda@exemplo.com"})
+	m.endpoint = srv.URL
+	msg := &Message{To: "pagador@exemplo.com", Subject: "Recibo", Text: "Olá",
+		Attachments: []Attachment{{Filename: "recibo.pdf", ContentType: "application/pdf", Content: []byte("pdf")}}}
+	if err := m.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	atts, _ := got["attachments"].([]interface{})
+	if len(atts) != 1 || atts[0].(map[string]interface{})["content"] != base64.StdEncoding.EncodeToString([]byte("pdf")) {
+		t.Fatalf("anexo não enviado: %v", got)
+	}
+
+	status = http.StatusForbidden
+	if err := m.Send(context.Background(), msg); err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "domínio") {
+		t.Fatalf("err = %v, want 403 com o detalhe do provedor", err)
+	}
+}
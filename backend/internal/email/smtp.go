@@ -0,0 +1,42 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Envio de e-mails por SMTP (STARTTLS quando oferecido pelo servidor)
+// Data: 18-10-2026
+
+package email
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// smtpMailer envia pelo servidor SMTP configurado; autenticação PLAIN apenas com usuário informado
+type smtpMailer struct {
+	host     string
+	port     string
+	user     string
+	password string
+	from     mail.Address
+}
+
+// Send envia a mensagem; o contexto limita apenas a espera (net/smtp não é cancelável)
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	var auth smtp.Auth
+	if m.user != "" {
+		auth = smtp.PlainAuth("", m.user, m.password, m.host)
+	}
+	raw := buildMIME(m.from, msg, time.Now())
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(net.JoinHostPort(m.host, m.port), auth, m.from.Address, []string{msg.To}, raw)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,232 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Conversão entre os modelos do backend e as mensagens recibofast.v1
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "recibofast/internal/grpcapi/recibofastv1"
+	"recibofast/internal/models"
+)
+
+// Valores monetários trafegam como string decimal ("1234.50"), iguais ao JSON do REST; IDs como
+// UUID em texto. Campos opcionais vazios viram nil nos modelos, como um null no corpo REST.
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func moneyString(m *models.Money) *string {
+	if m == nil {
+		return nil
+	}
+	s := m.String()
+	return &s
+}
+
+// parseID UUID obrigatório (caminho da rota REST); inválido responde 400 "ID inválido"
+func parseID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, errorf(http.StatusBadRequest, "ID inválido")
+	}
+	return id, nil
+}
+
+// Campos do corpo com UUID ou valor inválido respondem 400 "dados inválidos", como a decodificação
+// JSON das rotas REST (decodeJSON); vazio é o campo ausente no corpo.
+
+// invalidBody erro do corpo que o REST não conseguiria decodificar
+func invalidBody() error {
+	return errorf(http.StatusBadRequest, "dados inválidos")
+}
+
+// parseOptionalID UUID opcional do corpo; ausente ou vazio é nil
+func parseOptionalID(s *string) (*uuid.UUID, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*s)
+	if err != nil {
+		return nil, invalidBody()
+	}
+	return &id, nil
+}
+
+// parseFieldID UUID do corpo; vazio é uuid.Nil (a validação do serviço decide se é aceito)
+func parseFieldID(s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, invalidBody()
+	}
+	return id, nil
+}
+
+// parseMoney valor monetário do corpo; vazio é zero (a validação decide se é aceito)
+func parseMoney(s string) (models.Money, error) {
+	if s == "" {
+		return 0, nil
+	}
+	m, err := models.ParseMoney(s)
+	if err != nil {
+		return 0, invalidBody()
+	}
+	return m, nil
+}
+
+func parseOptionalMoney(s *string) (*models.Money, error) {
+	if s == nil {
+		return nil, nil
+	}
+	m, err := parseMoney(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// parseQueryID filtro opcional da query; inválido responde 400 "<campo> inválido", como o REST
+func parseQueryID(s *string, field string) (*uuid.UUID, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*s)
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, field+" inválido")
+	}
+	return &id, nil
+}
+
+func incomeToPB(in *models.Income) *pb.Income {
+	return &pb.Income{
+		Id:          in.ID.String(),
+		OwnerId:     in.OwnerID.String(),
+		ContractId:  uuidString(in.ContractID),
+		PayerId:     uuidString(in.PayerID),
+		Categoria:   in.Categoria,
+		Tags:        in.Tags,
+		Competencia: in.Competencia,
+		Valor:       in.Valor.String(),
+		Status:      in.Status,
+		DueDate:     timestamp(in.DueDate),
+		TotalPago:   in.TotalPago.String(),
+		DeletedAt:   timestamp(in.DeletedAt),
+		CreatedAt:   timestamp(in.CreatedAt),
+		UpdatedAt:   timestamp(in.UpdatedAt),
+		Version:     in.Version,
+	}
+}
+
+// incomeRequest models.IncomeRequest a partir dos campos editáveis
+func incomeRequest(in *pb.IncomeInput) (*models.IncomeRequest, error) {
+	if in == nil {
+		in = &pb.IncomeInput{}
+	}
+	req := &models.IncomeRequest{
+		Categoria:   in.Categoria,
+		Tags:        in.GetTags(),
+		Competencia: in.GetCompetencia(),
+		Status:      in.GetStatus(),
+		DueDate:     in.DueDate,
+		Version:     in.Version,
+	}
+	var err error
+	if req.ContractID, err = parseOptionalID(in.ContractId); err != nil {
+		return nil, err
+	}
+	if req.PayerID, err = parseOptionalID(in.PayerId); err != nil {
+		return nil, err
+	}
+	if req.Valor, err = parseMoney(in.GetValor()); err != nil {
+		return nil, err
+	}
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func paymentToPB(p *models.Payment) *pb.Payment {
+	return &pb.Payment{
+		Id:             p.ID.String(),
+		IncomeId:       p.IncomeID.String(),
+		Valor:          p.Valor.String(),
+		PagoEm:         timestamp(&p.PagoEm),
+		Metodo:         p.Metodo,
+		MethodId:       uuidString(p.MethodID),
+		Obs:            p.Obs,
+		CreatedAt:      timestamp(p.CreatedAt),
+		ReversedAt:     timestamp(p.ReversedAt),
+		ReversalReason: p.ReversalReason,
+	}
+}
+
+func creditToPB(c *models.Credit) *pb.Credit {
+	if c == nil {
+		return nil
+	}
+	return &pb.Credit{
+		Id:              c.ID.String(),
+		PayerId:         uuidString(c.PayerID),
+		SourceIncomeId:  uuidString(c.SourceIncomeID),
+		SourcePaymentId: uuidString(c.SourcePaymentID),
+		Valor:           c.Valor.String(),
+		Saldo:           c.Saldo.String(),
+		CreatedAt:       timestamp(&c.CreatedAt),
+	}
+}
+
+func paymentResultToPB(res *models.PaymentResponse) *pb.PaymentResult {
+	return &pb.PaymentResult{
+		Payment: paymentToPB(&res.Payment),
+		Income:  incomeToPB(&res.Income),
+		Credit:  creditToPB(res.Credit),
+	}
+}
+
+func receiptToPB(r *models.Receipt) *pb.Receipt {
+	return &pb.Receipt{
+		Id:              r.ID.String(),
+		OwnerId:         r.OwnerID.String(),
+		IncomeId:        uuidString(r.IncomeID),
+		PayerId:         uuidString(r.PayerID),
+		Numero:          r.Numero,
+		NumeroFormatado: r.NumeroFormatado,
+		EmitidoEm:       timestamp(r.EmitidoEm),
+		PdfUrl:          r.PDFURL,
+		Hash:            r.Hash,
+		SignatureId:     uuidString(r.SignatureID),
+		IssuerName:      r.IssuerName,
+		IssuerDocument:  r.IssuerDocument,
+		Valor:           moneyString(r.Valor),
+		Taxas:           r.Taxas.String(),
+		Descontos:       r.Descontos.String(),
+		ValorLiquido:    moneyString(r.ValorLiquido),
+		Competencia:     r.Competencia,
+		Categoria:       r.Categoria,
+		PayerNome:       r.PayerNome,
+		PayerDocumento:  r.PayerDocumento,
+		CreatedAt:       timestamp(r.CreatedAt),
+	}
+}
@@ -0,0 +1,182 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Erros gRPC com o status HTTP e o código da API (apierror) da rota REST equivalente
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+
+	"recibofast/internal/apierror"
+	"recibofast/internal/validation"
+)
+
+// ErrorDomain domínio do errdetails.ErrorInfo anexado aos erros (Reason = código da API)
+const ErrorDomain = "recibofast.api"
+
+// httpStatusKey metadado do ErrorInfo com o status HTTP que a rota REST responderia
+const httpStatusKey = "http_status"
+
+// HTTPError erro gRPC equivalente à resposta REST (status HTTP, código da API e mensagem).
+// Docstring: o código gRPC segue o status HTTP (400 InvalidArgument, 404 NotFound, 409 Aborted...)
+// e o ErrorInfo anexado guarda o código da API e o status original, que o gateway usa para
+// responder exatamente como a rota REST. details (mensagens protobuf) vão junto, como o estado
+// atual da receita num conflito de versão.
+func HTTPError(httpStatus int, code, message string, details ...protoadapt.MessageV1) error {
+	st := status.New(grpcCode(httpStatus), message)
+	info := &errdetails.ErrorInfo{Reason: code, Domain: ErrorDomain, Metadata: map[string]string{httpStatusKey: strconv.Itoa(httpStatus)}}
+	if withDetails, err := st.WithDetails(append([]protoadapt.MessageV1{info}, details...)...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// codeValidationFailed código das falhas nas tags `validate:` (handlers.CodeValidationFailed)
+const codeValidationFailed = "validation_failed"
+
+// validateRequest aplica as tags `validate:` do modelo, como o decodeJSON das rotas REST.
+// Docstring: as falhas vão num errdetails.BadRequest (Reason com a regra no formato da tag, ex.
+// "gt=0"), que o gateway devolve em details como a lista de campos do REST.
+func validateRequest(v interface{}) error {
+	err := validation.Struct(v)
+	if err == nil {
+		return nil
+	}
+	var fields validation.Errors
+	if !errors.As(err, &fields) {
+		return errorf(http.StatusBadRequest, "dados inválidos")
+	}
+	br := &errdetails.BadRequest{}
+	for _, f := range fields {
+		reason := f.Rule
+		if f.Param != "" {
+			reason += "=" + f.Param
+		}
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: f.Field, Reason: reason, Description: f.Message})
+	}
+	return HTTPError(http.StatusBadRequest, codeValidationFailed, "dados inválidos", br)
+}
+
+// validationErrors lista de campos do REST a partir do BadRequest anexado por validateRequest
+func validationErrors(br *errdetails.BadRequest) validation.Errors {
+	out := make(validation.Errors, 0, len(br.GetFieldViolations()))
+	for _, v := range br.GetFieldViolations() {
+		rule, param, _ := strings.Cut(v.GetReason(), "=")
+		out = append(out, validation.FieldError{Field: v.GetField(), Rule: rule, Param: param, Message: v.GetDescription()})
+	}
+	return out
+}
+
+// errorf erro com o código genérico do status HTTP (apierror.CodeForStatus)
+func errorf(httpStatus int, message string) error {
+	return HTTPError(httpStatus, apierror.CodeForStatus(httpStatus), message)
+}
+
+// internalError resposta dos erros inesperados (a causa fica só no log)
+func internalError() error {
+	return errorf(http.StatusInternalServerError, "erro interno do servidor")
+}
+
+// HTTPStatus status HTTP, código da API e mensagem de um erro gRPC (inverso do HTTPError).
+// Docstring: sem ErrorInfo (erros do próprio gRPC, como prazo esgotado) usa o status HTTP padrão
+// do código gRPC e o código genérico da API.
+func HTTPStatus(err error) (int, string, string) {
+	st := status.Convert(err)
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != ErrorDomain {
+			continue
+		}
+		if code, err := strconv.Atoi(info.GetMetadata()[httpStatusKey]); err == nil {
+			return code, info.GetReason(), st.Message()
+		}
+	}
+	code := httpStatusFromCode(st.Code())
+	return code, apierror.CodeForStatus(code), st.Message()
+}
+
+// grpcCode código gRPC do status HTTP
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusRequestEntityTooLarge:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusPaymentRequired, http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound, http.StatusGone:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusPreconditionFailed, http.StatusLocked, http.StatusPreconditionRequired:
+		return codes.FailedPrecondition
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if httpStatus >= 500 {
+		return codes.Internal
+	}
+	return codes.InvalidArgument
+}
+
+// httpStatusFromCode status HTTP padrão dos erros gRPC sem ErrorInfo
+func httpStatusFromCode(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// statusDetail primeiro detalhe do tipo T anexado ao erro
+func statusDetail[T proto.Message](err error) (T, bool) {
+	var zero T
+	st, ok := status.FromError(err)
+	if !ok {
+		return zero, false
+	}
+	for _, d := range st.Details() {
+		if v, ok := d.(T); ok {
+			return v, true
+		}
+	}
+	return zero, false
+}
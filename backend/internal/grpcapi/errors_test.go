@@ -0,0 +1,47 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do mapeamento de erros entre status HTTP da API e códigos gRPC
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPErrorRoundTrip(t *testing.T) {
+	cases := []struct {
+		status int
+		code   string
+		want   codes.Code
+	}{
+		{http.StatusBadRequest, "validation_failed", codes.InvalidArgument},
+		{http.StatusPaymentRequired, "plan_limit_reached", codes.ResourceExhausted},
+		{http.StatusNotFound, "not_found", codes.NotFound},
+		{http.StatusConflict, "version_conflict", codes.Aborted},
+		{http.StatusLocked, "account_locked", codes.FailedPrecondition},
+		{http.StatusUnprocessableEntity, "idempotency_key_reused", codes.InvalidArgument},
+		{http.StatusInternalServerError, "internal_error", codes.Internal},
+	}
+	for _, tc := range cases {
+		err := HTTPError(tc.status, tc.code, "mensagem")
+		if got := status.Code(err); got != tc.want {
+			t.Errorf("%d: código gRPC = %v, want %v", tc.status, got, tc.want)
+		}
+		httpStatus, code, message := HTTPStatus(err)
+		if httpStatus != tc.status || code != tc.code || message != "mensagem" {
+			t.Errorf("%d: HTTPStatus = %d %s %q", tc.status, httpStatus, code, message)
+		}
+	}
+}
+
+func TestHTTPStatusWithoutErrorInfo(t *testing.T) {
+	httpStatus, code, message := HTTPStatus(status.Error(codes.DeadlineExceeded, "prazo esgotado"))
+	if httpStatus != http.StatusGatewayTimeout || code != "gateway_timeout" || message != "prazo esgotado" {
+		t.Fatalf("HTTPStatus = %d %s %q", httpStatus, code, message)
+	}
+}
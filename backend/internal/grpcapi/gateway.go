@@ -0,0 +1,121 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: grpc-gateway (REST/JSON sobre os serviços gRPC, nas rotas anotadas em proto/recibofast/v1)
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"recibofast/internal/apierror"
+	pb "recibofast/internal/grpcapi/recibofastv1"
+)
+
+// gatewayHeaders cabeçalhos repassados como metadados (além de Authorization, que o gateway já repassa)
+var gatewayHeaders = map[string]bool{
+	"X-Api-Key":       true,
+	"X-Org-Id":        true,
+	"X-Debug-User":    true,
+	"X-Timezone":      true,
+	"Accept-Language": true,
+	"Idempotency-Key": true,
+}
+
+// gatewayJSON nomes de campo do proto (snake_case, como o REST) e campos zerados sempre presentes
+var gatewayJSON = &runtime.JSONPb{
+	MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+	UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+}
+
+// createdLocation caminho do recurso criado, por RPC (respondem 201 com Location, como o REST)
+var createdLocation = map[string]func(proto.Message) string{
+	pb.IncomeService_CreateIncome_FullMethodName: func(m proto.Message) string {
+		return incomesPath + "/" + m.(*pb.Income).GetId()
+	},
+	pb.PaymentService_AddPayment_FullMethodName: func(m proto.Message) string {
+		return paymentsPath + "/" + m.(*pb.PaymentResult).GetPayment().GetId()
+	},
+	pb.ReceiptService_CreateReceipt_FullMethodName: func(m proto.Message) string {
+		return receiptsPath + "/" + m.(*pb.Receipt).GetId()
+	},
+}
+
+// NewGateway handler REST/JSON que encaminha as rotas anotadas para o servidor gRPC em conn.
+// Docstring: erros saem no corpo padrão da API ({code, message, details, request_id}) com o status
+// HTTP da rota REST equivalente (HTTPStatus); um conflito de versão traz details.current e uma
+// falha de validação a lista de campos.
+func NewGateway(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, gatewayJSON),
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			if key = textproto.CanonicalMIMEHeaderKey(key); gatewayHeaders[key] {
+				return key, true
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
+		runtime.WithOutgoingHeaderMatcher(func(key string) (string, bool) {
+			if key == "idempotent-replayed" {
+				return "Idempotent-Replayed", true
+			}
+			return "", false
+		}),
+		runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, m proto.Message) error {
+			method, _ := runtime.RPCMethod(ctx)
+			if location, ok := createdLocation[method]; ok {
+				w.Header().Set("Location", location(m))
+				w.WriteHeader(http.StatusCreated)
+			}
+			return nil
+		}),
+		runtime.WithErrorHandler(writeGatewayError),
+		runtime.WithRoutingErrorHandler(func(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+			apierror.Write(w, httpStatus, http.StatusText(httpStatus))
+		}),
+	)
+	if err := pb.RegisterIncomeServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := pb.RegisterPaymentServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := pb.RegisterReceiptServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := pb.RegisterSyncServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// writeGatewayError responde o erro gRPC como a rota REST (apierror)
+func writeGatewayError(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	httpStatus, code, message := HTTPStatus(err)
+	// Corpo ou parâmetro que o próprio gateway não decodificou: mesma resposta do decodeJSON do REST
+	if _, ok := statusDetail[*errdetails.ErrorInfo](err); !ok && status.Code(err) == codes.InvalidArgument {
+		message = "dados inválidos"
+	}
+	var details interface{}
+	if current, ok := statusDetail[*pb.Income](err); ok {
+		if raw, err := gatewayJSON.Marshal(current); err == nil {
+			details = map[string]json.RawMessage{"current": raw}
+		}
+	} else if br, ok := statusDetail[*errdetails.BadRequest](err); ok {
+		details = validationErrors(br)
+	}
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok && len(md.HeaderMD.Get("idempotent-replayed")) > 0 {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+	apierror.WriteCode(w, httpStatus, code, message, details)
+}
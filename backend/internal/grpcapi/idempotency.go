@@ -0,0 +1,112 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Idempotency-Key nas criações gRPC (metadado idempotency-key, mesma tabela do REST)
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"recibofast/internal/models"
+)
+
+// idempotencyMethod método gravado nas chaves usadas por RPCs (o caminho é o nome completo do RPC)
+const idempotencyMethod = "GRPC"
+
+// idempotencyKeyMetadata metadado com a chave (o gateway repassa o cabeçalho Idempotency-Key)
+const idempotencyKeyMetadata = "idempotency-key"
+
+// idempotent executa call uma única vez por idempotency-key, como o middleware Idempotency do REST.
+// Docstring: a chave é por usuário. A repetição do mesmo pedido devolve a resposta guardada (sucesso
+// ou erro 4xx) com o metadado idempotent-replayed; outro pedido ou outro RPC com a mesma chave
+// responde 422 e, com o original ainda em andamento, 409. Erros 5xx liberam a chave.
+func idempotent[T proto.Message](ctx context.Context, d *Deps, ownerID uuid.UUID, rpc string, req proto.Message, newResp func() T, call func() (T, error)) (T, error) {
+	var zero T
+	key := metadataValue(ctx, idempotencyKeyMetadata)
+	if key == "" || d.Idempotency == nil {
+		return call()
+	}
+	if err := models.ValidateIdempotencyKey(key); err != nil {
+		return zero, errorf(http.StatusBadRequest, err.Error())
+	}
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return zero, d.logError("erro ao serializar pedido idempotente", err)
+	}
+	rec := &models.IdempotencyRecord{
+		OwnerID:     ownerID,
+		Key:         key,
+		Method:      idempotencyMethod,
+		Path:        rpc,
+		RequestHash: models.IdempotencyRequestHash(idempotencyMethod, rpc, body),
+	}
+	existing, err := d.Idempotency.Reserve(ctx, rec, models.IdempotencyKeyTTL)
+	if errors.Is(err, models.ErrIdempotencyInProgress) {
+		return zero, errorf(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return zero, d.logError("erro ao reservar Idempotency-Key", err)
+	}
+	if existing != nil {
+		return replayIdempotent(ctx, existing, rec, newResp)
+	}
+
+	// A resposta é guardada (ou a reserva liberada) mesmo se o cliente desconectar
+	bg := context.WithoutCancel(ctx)
+	resp, callErr := call()
+	httpStatus := http.StatusOK
+	if callErr != nil {
+		httpStatus, _, _ = HTTPStatus(callErr)
+	}
+	if httpStatus >= http.StatusInternalServerError {
+		if err := d.Idempotency.Release(bg, ownerID, key); err != nil {
+			d.Logger.Error("erro ao liberar Idempotency-Key", logField(err))
+		}
+		return resp, callErr
+	}
+	var stored proto.Message = status.Convert(callErr).Proto()
+	if callErr == nil {
+		stored = resp
+	}
+	rec.StatusCode, rec.ResponseHeaders = httpStatus, map[string]string{}
+	if rec.ResponseBody, err = proto.Marshal(stored); err != nil {
+		d.Logger.Error("erro ao serializar resposta da Idempotency-Key", logField(err))
+	} else if err := d.Idempotency.Complete(bg, rec); err != nil {
+		d.Logger.Error("erro ao guardar resposta da Idempotency-Key", logField(err))
+	}
+	return resp, callErr
+}
+
+// replayIdempotent devolve a resposta (ou o erro) da chamada original
+func replayIdempotent[T proto.Message](ctx context.Context, existing, req *models.IdempotencyRecord, newResp func() T) (T, error) {
+	var zero T
+	if !existing.Matches(req.Method, req.Path, req.RequestHash) {
+		return zero, errorf(http.StatusUnprocessableEntity, models.ErrIdempotencyKeyReused.Error())
+	}
+	if !existing.Completed() {
+		return zero, errorf(http.StatusConflict, models.ErrIdempotencyInProgress.Error())
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs("idempotent-replayed", "true"))
+	if existing.StatusCode >= http.StatusBadRequest {
+		var st spb.Status
+		if err := proto.Unmarshal(existing.ResponseBody, &st); err != nil {
+			return zero, internalError()
+		}
+		return zero, status.FromProto(&st).Err()
+	}
+	resp := newResp()
+	if err := proto.Unmarshal(existing.ResponseBody, resp); err != nil {
+		return zero, internalError()
+	}
+	return resp, nil
+}
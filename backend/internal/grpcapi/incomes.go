@@ -0,0 +1,159 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: IncomeService gRPC (espelha GET/POST /api/v1/incomes e GET/PUT/DELETE /api/v1/incomes/{id})
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "recibofast/internal/grpcapi/recibofastv1"
+	"recibofast/internal/models"
+)
+
+const incomesPath = "/api/v1/incomes"
+
+type incomeServer struct {
+	pb.UnimplementedIncomeServiceServer
+	d *Deps
+}
+
+// ListIncomes lista receitas com filtros e paginação (IDs inválidos nos filtros são ignorados, como no REST)
+func (s *incomeServer) ListIncomes(ctx context.Context, req *pb.ListIncomesRequest) (*pb.ListIncomesResponse, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodGet, incomesPath)
+	if err != nil {
+		return nil, err
+	}
+	filter := &models.IncomeFilter{
+		Search:      strings.TrimSpace(req.GetSearch()),
+		Status:      strings.TrimSpace(req.GetStatus()),
+		Categoria:   strings.TrimSpace(req.GetCategoria()),
+		Competencia: strings.TrimSpace(req.GetCompetencia()),
+		Tag:         strings.TrimSpace(req.GetTag()),
+		SortField:   strings.TrimSpace(req.GetSortField()),
+		SortOrder:   strings.TrimSpace(req.GetSortOrder()),
+	}
+	if req.GetPage() > 0 {
+		filter.Page = int(req.GetPage())
+	}
+	if req.GetPerPage() > 0 {
+		filter.PerPage = int(req.GetPerPage())
+	}
+	if id, err := uuid.Parse(req.GetContractId()); err == nil {
+		filter.ContractID = &id
+	}
+	if id, err := uuid.Parse(req.GetPayerId()); err == nil {
+		filter.PayerID = &id
+	}
+
+	res, err := s.d.Incomes.ListIncomes(ctx, ownerID, filter)
+	if err != nil {
+		return nil, s.d.logError("erro ao listar receitas", err)
+	}
+	out := &pb.ListIncomesResponse{
+		Incomes:    make([]*pb.Income, 0, len(res.Incomes)),
+		Total:      int32(res.Total),
+		Page:       int32(res.Page),
+		PerPage:    int32(res.PerPage),
+		TotalPages: int32(res.TotalPages),
+	}
+	for i := range res.Incomes {
+		out.Incomes = append(out.Incomes, incomeToPB(&res.Incomes[i]))
+	}
+	return out, nil
+}
+
+// GetIncome busca uma receita por ID
+func (s *incomeServer) GetIncome(ctx context.Context, req *pb.GetIncomeRequest) (*pb.Income, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodGet, incomesPath+"/"+req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	income, err := s.d.Incomes.GetIncome(ctx, id, ownerID)
+	if err != nil {
+		if errors.Is(err, models.ErrIncomeNotFound) {
+			return nil, errorf(http.StatusNotFound, "receita não encontrada")
+		}
+		return nil, s.d.logError("erro ao buscar receita", err)
+	}
+	return incomeToPB(income), nil
+}
+
+// CreateIncome cria uma receita (erros de validação e de gravação respondem 400, como no REST)
+func (s *incomeServer) CreateIncome(ctx context.Context, req *pb.CreateIncomeRequest) (*pb.Income, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodPost, incomesPath)
+	if err != nil {
+		return nil, err
+	}
+	in, err := incomeRequest(req.GetIncome())
+	if err != nil {
+		return nil, err
+	}
+	income, err := s.d.Incomes.CreateIncome(ctx, ownerID, in)
+	if err != nil {
+		s.d.Logger.Error("erro ao criar receita", logField(err))
+		return nil, errorf(http.StatusBadRequest, err.Error())
+	}
+	return incomeToPB(income), nil
+}
+
+// UpdateIncome atualiza uma receita; income.version é a versão esperada (concorrência otimista)
+func (s *incomeServer) UpdateIncome(ctx context.Context, req *pb.UpdateIncomeRequest) (*pb.Income, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodPut, incomesPath+"/"+req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	in, err := incomeRequest(req.GetIncome())
+	if err != nil {
+		return nil, err
+	}
+	income, err := s.d.Incomes.UpdateIncome(ctx, id, ownerID, in)
+	if err != nil {
+		var conflict *models.IncomeVersionConflictError
+		switch {
+		case errors.Is(err, models.ErrIncomeNotFound):
+			return nil, errorf(http.StatusNotFound, "receita não encontrada")
+		case errors.As(err, &conflict):
+			return nil, HTTPError(http.StatusConflict, "version_conflict", conflict.Error(), incomeToPB(conflict.Current))
+		case errors.Is(err, models.ErrIncomeVersionRequired):
+			return nil, errorf(http.StatusPreconditionRequired, err.Error())
+		}
+		s.d.Logger.Error("erro ao atualizar receita", logField(err))
+		return nil, errorf(http.StatusBadRequest, err.Error())
+	}
+	return incomeToPB(income), nil
+}
+
+// DeleteIncome exclui uma receita
+func (s *incomeServer) DeleteIncome(ctx context.Context, req *pb.DeleteIncomeRequest) (*emptypb.Empty, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodDelete, incomesPath+"/"+req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.d.Incomes.DeleteIncome(ctx, id, ownerID); err != nil {
+		if errors.Is(err, models.ErrIncomeNotFound) {
+			return nil, errorf(http.StatusNotFound, "receita não encontrada")
+		}
+		return nil, s.d.logError("erro ao deletar receita", err)
+	}
+	return &emptypb.Empty{}, nil
+}
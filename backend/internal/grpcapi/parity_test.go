@@ -0,0 +1,506 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes de paridade REST/gRPC (ida e volta pelo gRPC e pelo grpc-gateway contra as rotas REST)
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	pb "recibofast/internal/grpcapi/recibofastv1"
+	"recibofast/internal/handlers"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/services"
+)
+
+const parityToken = "Bearer parity"
+
+// tokenAuth autentica pelo metadado authorization, como o middleware de teste das rotas REST
+type tokenAuth struct{ owner uuid.UUID }
+
+func (a tokenAuth) Authenticate(ctx context.Context, method, path string) (context.Context, error) {
+	if metadataValue(ctx, "authorization") != parityToken {
+		return nil, errorf(http.StatusUnauthorized, "Token de autorização requerido")
+	}
+	return ctxhelper.SetUserID(ctx, a.owner.String()), nil
+}
+
+// memoryReceiptRepo emissão e consulta de recibos em memória (numeração sequencial por dono)
+type memoryReceiptRepo struct {
+	repositories.ReceiptRepository
+	mu    sync.Mutex
+	items []models.Receipt
+}
+
+func (r *memoryReceiptRepo) Create(ctx context.Context, m *models.Receipt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	last := int64(0)
+	for _, it := range r.items {
+		if it.OwnerID == m.OwnerID && it.Numero > last {
+			last = it.Numero
+		}
+	}
+	if m.Numero == 0 {
+		m.Numero = last + 1
+	} else if m.Numero <= last {
+		return models.ErrReceiptNumberNotMonotonic
+	}
+	now := time.Now().UTC()
+	m.ID, m.EmitidoEm, m.CreatedAt, m.UpdatedAt = uuid.New(), &now, &now, &now
+	r.items = append(r.items, *m)
+	return nil
+}
+
+func (r *memoryReceiptRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Receipt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, it := range r.items {
+		if it.ID == id && it.OwnerID == ownerID {
+			cp := it
+			return &cp, nil
+		}
+	}
+	return nil, errors.New("receipt not found")
+}
+
+func (r *memoryReceiptRepo) List(ctx context.Context, ownerID uuid.UUID, filter *models.ReceiptFilter) ([]models.Receipt, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []models.Receipt{}
+	for i := len(r.items) - 1; i >= 0; i-- {
+		if r.items[i].OwnerID == ownerID {
+			out = append(out, r.items[i])
+		}
+	}
+	return out, len(out), nil
+}
+
+// memoryIdempotencyStore implementa repositories.IdempotencyRepository em memória
+type memoryIdempotencyStore struct {
+	mu   sync.Mutex
+	recs map[string]*models.IdempotencyRecord
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, rec *models.IdempotencyRecord, ttl time.Duration) (*models.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := rec.OwnerID.String() + "/" + rec.Key
+	if existing, ok := s.recs[k]; ok {
+		cp := *existing
+		return &cp, nil
+	}
+	cp := *rec
+	s.recs[k] = &cp
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, rec *models.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *rec
+	s.recs[rec.OwnerID.String()+"/"+rec.Key] = &cp
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, ownerID uuid.UUID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.recs, ownerID.String()+"/"+key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error) {
+	return 0, nil
+}
+
+// parityEnv as mesmas receitas e recibos servidos pelas rotas REST, pelo gRPC e pelo gateway
+type parityEnv struct {
+	rest    *httptest.Server
+	gateway *httptest.Server
+	conn    *grpc.ClientConn
+}
+
+func newParityEnv(t *testing.T) *parityEnv {
+	t.Helper()
+	log := logging.NewLogger("dev")
+	owner := uuid.New()
+	incomes := services.NewIncomeService(repositories.NewMemoryIncomeRepository())
+	receipts := &memoryReceiptRepo{}
+
+	ih := handlers.NewIncomeHandlers(incomes, log)
+	rh := handlers.NewReceiptHandlers(receipts, log)
+	r := chi.NewRouter()
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.Header.Get("Authorization") != parityToken {
+					apierror.Write(w, http.StatusUnauthorized, "Token de autorização requerido")
+					return
+				}
+				next.ServeHTTP(w, req.WithContext(ctxhelper.SetUserID(req.Context(), owner.String())))
+			})
+		})
+		r.Get("/incomes", ih.ListIncomes)
+		r.Post("/incomes", ih.CreateIncome)
+		r.Get("/incomes/{id}", ih.GetIncome)
+		r.Put("/incomes/{id}", ih.UpdateIncome)
+		r.Get("/incomes/{id}/payments", ih.GetIncomePayments)
+		r.Post("/payments", ih.AddPayment)
+		r.Get("/receipts", rh.ListReceipts)
+		r.Post("/receipts", rh.CreateReceipt)
+		r.Get("/receipts/{id}", rh.GetReceipt)
+	})
+	env := &parityEnv{rest: httptest.NewServer(r)}
+	t.Cleanup(env.rest.Close)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	Register(srv, Deps{
+		Auth:        tokenAuth{owner: owner},
+		Incomes:     incomes,
+		Receipts:    services.NewReceiptService(receipts),
+		Idempotency: &memoryIdempotencyStore{recs: map[string]*models.IdempotencyRecord{}},
+		Logger:      log,
+	})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("conexão gRPC: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	env.conn = conn
+
+	gw, err := NewGateway(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("gateway: %v", err)
+	}
+	env.gateway = httptest.NewServer(gw)
+	t.Cleanup(env.gateway.Close)
+	return env
+}
+
+// call faz a requisição autenticada e devolve o status, os cabeçalhos e o corpo JSON
+func call(t *testing.T, srv *httptest.Server, method, path, body string, header ...string) (int, http.Header, map[string]interface{}) {
+	t.Helper()
+	req, _ := http.NewRequest(method, srv.URL+path, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", parityToken)
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	var out map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, resp.Header, out
+}
+
+// grpcCtx contexto da chamada gRPC com o token e os metadados informados
+func grpcCtx(kv ...string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), append([]string{"authorization", parityToken}, kv...)...)
+}
+
+// protoJSON resposta gRPC no JSON do gateway, para comparar com o corpo REST
+func protoJSON(t *testing.T, m proto.Message) map[string]interface{} {
+	t.Helper()
+	b, err := gatewayJSON.Marshal(m)
+	if err != nil {
+		t.Fatalf("protojson: %v", err)
+	}
+	var out map[string]interface{}
+	json.Unmarshal(b, &out)
+	return out
+}
+
+// normalize iguala as diferenças de codificação do protojson: int64 sai como string, datas com outra
+// precisão e listas vazias no lugar de null
+func normalize(v interface{}) interface{} {
+	switch x := v.(type) {
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case string:
+		if ts, err := time.Parse(time.RFC3339Nano, x); err == nil {
+			return ts.UTC().Format(time.RFC3339Nano)
+		}
+		return x
+	case []interface{}:
+		if len(x) == 0 {
+			return nil
+		}
+		out := make([]interface{}, len(x))
+		for i := range x {
+			out[i] = normalize(x[i])
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			out[k] = normalize(e)
+		}
+		return out
+	}
+	return v
+}
+
+// assertParity confere cada campo do contrato (chaves da resposta gRPC/gateway) contra o corpo REST;
+// campos extras do REST (fora do contrato) e os listados em skip são ignorados
+func assertParity(t *testing.T, what string, rest, got map[string]interface{}, skip ...string) {
+	t.Helper()
+	ignored := map[string]bool{}
+	for _, k := range skip {
+		ignored[k] = true
+	}
+	keys := make([]string, 0, len(got))
+	for k := range got {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if ignored[k] {
+			continue
+		}
+		want, have := normalize(rest[k]), normalize(got[k])
+		if wm, ok := want.(map[string]interface{}); ok {
+			if hm, ok := have.(map[string]interface{}); ok {
+				assertParity(t, what+"."+k, wm, hm, skip...)
+				continue
+			}
+		}
+		if wl, ok := want.([]interface{}); ok {
+			if hl, ok := have.([]interface{}); ok && len(wl) == len(hl) {
+				for i := range wl {
+					wm, wok := wl[i].(map[string]interface{})
+					hm, hok := hl[i].(map[string]interface{})
+					if wok && hok {
+						assertParity(t, what+"."+k+"["+strconv.Itoa(i)+"]", wm, hm, skip...)
+					} else if !reflect.DeepEqual(wl[i], hl[i]) {
+						t.Errorf("%s.%s[%d] = %v, REST = %v", what, k, i, hl[i], wl[i])
+					}
+				}
+				continue
+			}
+		}
+		if !reflect.DeepEqual(want, have) {
+			t.Errorf("%s.%s = %v, REST = %v", what, k, have, want)
+		}
+	}
+}
+
+func TestParity_IncomeRoundTrip(t *testing.T) {
+	env := newParityEnv(t)
+	body := `{"competencia":"2026-10","valor":"150.00","categoria":"aluguel","tags":["casa"],"due_date":"2026-10-10T00:00:00Z"}`
+
+	restCode, restHdr, restIncome := call(t, env.rest, http.MethodPost, "/api/v1/incomes", body)
+	gwCode, gwHdr, gwIncome := call(t, env.gateway, http.MethodPost, "/api/v1/incomes", body)
+	if restCode != http.StatusCreated || gwCode != http.StatusCreated {
+		t.Fatalf("status REST = %d, gateway = %d; want 201", restCode, gwCode)
+	}
+	if gwHdr.Get("Location") != "/api/v1/incomes/"+gwIncome["id"].(string) || restHdr.Get("Location") == "" {
+		t.Fatalf("Location REST = %q, gateway = %q", restHdr.Get("Location"), gwHdr.Get("Location"))
+	}
+	// Criadas em separado: só id e datas de gravação diferem
+	assertParity(t, "create", restIncome, gwIncome, "id", "created_at", "updated_at")
+
+	// A receita criada pelo gateway lida pelo REST, e a do REST lida pelo gateway e pelo gRPC
+	for _, id := range []string{restIncome["id"].(string), gwIncome["id"].(string)} {
+		_, _, rest := call(t, env.rest, http.MethodGet, "/api/v1/incomes/"+id, "")
+		_, _, gw := call(t, env.gateway, http.MethodGet, "/api/v1/incomes/"+id, "")
+		assertParity(t, "get gateway", rest, gw)
+		income, err := pb.NewIncomeServiceClient(env.conn).GetIncome(grpcCtx(), &pb.GetIncomeRequest{Id: id})
+		if err != nil {
+			t.Fatalf("GetIncome: %v", err)
+		}
+		assertParity(t, "get gRPC", rest, protoJSON(t, income))
+	}
+
+	_, _, restList := call(t, env.rest, http.MethodGet, "/api/v1/incomes?page=1&per_page=10", "")
+	_, _, gwList := call(t, env.gateway, http.MethodGet, "/api/v1/incomes?page=1&per_page=10", "")
+	if len(gwList["incomes"].([]interface{})) != 2 {
+		t.Fatalf("listagem do gateway = %v", gwList)
+	}
+	assertParity(t, "list", restList, gwList)
+
+	// Atualização com a versão atual pelo gateway; a mesma versão (já vencida) conflita nos dois
+	id := gwIncome["id"].(string)
+	_, _, current := call(t, env.gateway, http.MethodGet, "/api/v1/incomes/"+id, "")
+	version, _ := strconv.ParseInt(current["version"].(string), 10, 64)
+	update := `{"competencia":"2026-10","valor":"175.00","status":"pendente","version":` + strconv.FormatInt(version, 10) + `}`
+	if code, _, gw := call(t, env.gateway, http.MethodPut, "/api/v1/incomes/"+id, update); code != http.StatusOK || gw["valor"] != "175.00" || gw["version"] != strconv.FormatInt(version+1, 10) {
+		t.Fatalf("PUT gateway = %d %v", code, gw)
+	}
+	restCode, _, restErr := call(t, env.rest, http.MethodPut, "/api/v1/incomes/"+id, update)
+	gwCode, _, gwErr := call(t, env.gateway, http.MethodPut, "/api/v1/incomes/"+id, update)
+	if restCode != http.StatusConflict || gwCode != restCode {
+		t.Fatalf("conflito REST = %d, gateway = %d; want 409", restCode, gwCode)
+	}
+	assertParity(t, "conflict", restErr, gwErr, "request_id")
+}
+
+func TestParity_PaymentRoundTrip(t *testing.T) {
+	env := newParityEnv(t)
+	_, _, income := call(t, env.rest, http.MethodPost, "/api/v1/incomes", `{"competencia":"2026-10","valor":"300.00"}`)
+	incomeID := income["id"].(string)
+
+	restCode, _, restPay := call(t, env.rest, http.MethodPost, "/api/v1/payments", `{"income_id":"`+incomeID+`","valor":"100.00","metodo":"pix","pago_em":"2026-10-05T12:00:00Z"}`)
+	gwCode, gwHdr, gwPay := call(t, env.gateway, http.MethodPost, "/api/v1/payments", `{"income_id":"`+incomeID+`","valor":"100.00","metodo":"pix","pago_em":"2026-10-05T12:00:00Z"}`)
+	if restCode != http.StatusCreated || gwCode != http.StatusCreated {
+		t.Fatalf("status REST = %d, gateway = %d; want 201", restCode, gwCode)
+	}
+	if gwHdr.Get("Location") != "/api/v1/payments/"+gwPay["payment"].(map[string]interface{})["id"].(string) {
+		t.Fatalf("Location = %q", gwHdr.Get("Location"))
+	}
+	// O segundo pagamento vê a receita com os dois lançamentos
+	assertParity(t, "payment", restPay["payment"].(map[string]interface{}), gwPay["payment"].(map[string]interface{}), "id", "created_at")
+	if gwPay["income"].(map[string]interface{})["total_pago"] != "200.00" {
+		t.Fatalf("receita após o pagamento = %v", gwPay["income"])
+	}
+
+	_, _, restList := call(t, env.rest, http.MethodGet, "/api/v1/incomes/"+incomeID+"/payments", "")
+	_, _, gwList := call(t, env.gateway, http.MethodGet, "/api/v1/incomes/"+incomeID+"/payments", "")
+	if len(gwList["payments"].([]interface{})) != 2 {
+		t.Fatalf("pagamentos do gateway = %v", gwList)
+	}
+	assertParity(t, "list", restList, gwList)
+}
+
+func TestParity_ReceiptRoundTrip(t *testing.T) {
+	env := newParityEnv(t)
+	restCode, _, restReceipt := call(t, env.rest, http.MethodPost, "/api/v1/receipts", `{"issuer_name":"Maria","taxas":"5.00"}`)
+	created, err := pb.NewReceiptServiceClient(env.conn).CreateReceipt(grpcCtx(), &pb.CreateReceiptRequest{IssuerName: proto.String("Maria"), Taxas: proto.String("5.00")})
+	if restCode != http.StatusCreated || err != nil {
+		t.Fatalf("REST = %d, gRPC = %v", restCode, err)
+	}
+	if restReceipt["numero"] != float64(1) || created.GetNumero() != 2 {
+		t.Fatalf("numeração REST = %v, gRPC = %d; want 1 e 2", restReceipt["numero"], created.GetNumero())
+	}
+	assertParity(t, "create", restReceipt, protoJSON(t, created), "id", "numero", "emitido_em", "created_at")
+
+	for _, id := range []string{restReceipt["id"].(string), created.GetId()} {
+		_, _, rest := call(t, env.rest, http.MethodGet, "/api/v1/receipts/"+id, "")
+		_, _, gw := call(t, env.gateway, http.MethodGet, "/api/v1/receipts/"+id, "")
+		assertParity(t, "get", rest, gw)
+	}
+	_, _, restList := call(t, env.rest, http.MethodGet, "/api/v1/receipts", "")
+	_, _, gwList := call(t, env.gateway, http.MethodGet, "/api/v1/receipts", "")
+	if len(gwList["items"].([]interface{})) != 2 {
+		t.Fatalf("recibos do gateway = %v", gwList)
+	}
+	assertParity(t, "list", restList, gwList)
+}
+
+func TestParity_Errors(t *testing.T) {
+	env := newParityEnv(t)
+	missing := uuid.NewString()
+	cases := []struct {
+		name, method, path, body string
+		want                     int
+		noAuth                   bool
+	}{
+		{"sem token", http.MethodGet, "/api/v1/incomes", "", http.StatusUnauthorized, true},
+		{"receita inexistente", http.MethodGet, "/api/v1/incomes/" + missing, "", http.StatusNotFound, false},
+		{"ID inválido", http.MethodGet, "/api/v1/incomes/abc", "", http.StatusBadRequest, false},
+		{"pagamento sem receita", http.MethodPost, "/api/v1/payments", `{"income_id":"` + missing + `","valor":"10.00"}`, http.StatusNotFound, false},
+		{"pagamento sem valor", http.MethodPost, "/api/v1/payments", `{"income_id":"` + missing + `","valor":"0"}`, http.StatusBadRequest, false},
+		{"corpo malformado", http.MethodPost, "/api/v1/incomes", `{"valor":`, http.StatusBadRequest, false},
+		{"UUID inválido no corpo", http.MethodPost, "/api/v1/payments", `{"income_id":"abc","valor":"10.00"}`, http.StatusBadRequest, false},
+		{"receita sem competência", http.MethodPost, "/api/v1/incomes", `{"valor":"10.00"}`, http.StatusBadRequest, false},
+		{"recibo com número inválido", http.MethodPost, "/api/v1/receipts", `{"numero":-1}`, http.StatusBadRequest, false},
+		{"recibo com income_id inválido", http.MethodGet, "/api/v1/receipts?income_id=abc", "", http.StatusBadRequest, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var header []string
+			if tc.noAuth {
+				header = []string{"Authorization", ""}
+			}
+			restCode, _, restErr := call(t, env.rest, tc.method, tc.path, tc.body, header...)
+			gwCode, _, gwErr := call(t, env.gateway, tc.method, tc.path, tc.body, header...)
+			if restCode != tc.want || gwCode != tc.want {
+				t.Fatalf("status REST = %d, gateway = %d; want %d (%v / %v)", restCode, gwCode, tc.want, restErr, gwErr)
+			}
+			assertParity(t, "erro", restErr, gwErr, "request_id")
+		})
+	}
+
+	// Pelo gRPC nativo o status HTTP vira código gRPC e continua recuperável (HTTPStatus)
+	_, err := pb.NewIncomeServiceClient(env.conn).GetIncome(grpcCtx(), &pb.GetIncomeRequest{Id: missing})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("código = %v, want NotFound", status.Code(err))
+	}
+	if code, apiCode, msg := HTTPStatus(err); code != http.StatusNotFound || apiCode != apierror.CodeForStatus(http.StatusNotFound) || msg != "receita não encontrada" {
+		t.Fatalf("HTTPStatus = %d %s %q", code, apiCode, msg)
+	}
+}
+
+func TestAddPayment_IdempotencyKeyReplays(t *testing.T) {
+	env := newParityEnv(t)
+	client := pb.NewPaymentServiceClient(env.conn)
+	income, err := pb.NewIncomeServiceClient(env.conn).CreateIncome(grpcCtx(), &pb.CreateIncomeRequest{Income: &pb.IncomeInput{Competencia: "2026-10", Valor: "300.00"}})
+	if err != nil {
+		t.Fatalf("CreateIncome: %v", err)
+	}
+	req := &pb.AddPaymentRequest{IncomeId: income.GetId(), Valor: "50.00"}
+	first, err := client.AddPayment(grpcCtx("idempotency-key", "pay-1"), req)
+	if err != nil {
+		t.Fatalf("AddPayment: %v", err)
+	}
+	var header metadata.MD
+	again, err := client.AddPayment(grpcCtx("idempotency-key", "pay-1"), req, grpc.Header(&header))
+	if err != nil || again.GetPayment().GetId() != first.GetPayment().GetId() || len(header.Get("idempotent-replayed")) == 0 {
+		t.Fatalf("repetição = %v, %v (cabeçalhos %v)", again, err, header)
+	}
+
+	// A mesma chave com outro pedido responde 422, pelo gRPC e pelo gateway
+	_, err = client.AddPayment(grpcCtx("idempotency-key", "pay-1"), &pb.AddPaymentRequest{IncomeId: income.GetId(), Valor: "60.00"})
+	if code, _, _ := HTTPStatus(err); code != http.StatusUnprocessableEntity {
+		t.Fatalf("chave reutilizada = %v", err)
+	}
+	code, hdr, _ := call(t, env.gateway, http.MethodPost, "/api/v1/payments", `{"income_id":"`+income.GetId()+`","valor":"70.00"}`, "Idempotency-Key", "pay-2")
+	if code != http.StatusCreated || hdr.Get("Idempotent-Replayed") != "" {
+		t.Fatalf("primeiro envio pelo gateway = %d (%v)", code, hdr)
+	}
+	code, hdr, _ = call(t, env.gateway, http.MethodPost, "/api/v1/payments", `{"income_id":"`+income.GetId()+`","valor":"70.00"}`, "Idempotency-Key", "pay-2")
+	if code != http.StatusCreated || hdr.Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("repetição pelo gateway = %d (%v)", code, hdr)
+	}
+
+	payments, err := client.ListPayments(grpcCtx(), &pb.ListPaymentsRequest{IncomeId: income.GetId()})
+	if err != nil || len(payments.GetPayments()) != 2 {
+		t.Fatalf("pagamentos = %v, %v; want 2", payments, err)
+	}
+}
@@ -0,0 +1,162 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: PaymentService gRPC (espelha /api/v1/payments e GET /api/v1/incomes/{id}/payments)
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	pb "recibofast/internal/grpcapi/recibofastv1"
+	"recibofast/internal/models"
+)
+
+const paymentsPath = "/api/v1/payments"
+
+type paymentServer struct {
+	pb.UnimplementedPaymentServiceServer
+	d *Deps
+}
+
+// ListPayments pagamentos de uma receita
+func (s *paymentServer) ListPayments(ctx context.Context, req *pb.ListPaymentsRequest) (*pb.ListPaymentsResponse, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodGet, incomesPath+"/"+req.GetIncomeId()+"/payments")
+	if err != nil {
+		return nil, err
+	}
+	incomeID, err := parseID(req.GetIncomeId())
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.d.Incomes.GetIncomePayments(ctx, incomeID, ownerID)
+	if err != nil {
+		if errors.Is(err, models.ErrIncomeNotFound) {
+			return nil, errorf(http.StatusNotFound, "receita não encontrada")
+		}
+		return nil, s.d.logError("erro ao buscar pagamentos", err)
+	}
+	out := &pb.ListPaymentsResponse{Payments: make([]*pb.Payment, 0, len(payments))}
+	for i := range payments {
+		out.Payments = append(out.Payments, paymentToPB(&payments[i]))
+	}
+	return out, nil
+}
+
+// AddPayment registra um pagamento (aceita o metadado idempotency-key)
+func (s *paymentServer) AddPayment(ctx context.Context, req *pb.AddPaymentRequest) (*pb.PaymentResult, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodPost, paymentsPath)
+	if err != nil {
+		return nil, err
+	}
+	in := &models.PaymentRequest{
+		PagoEm:      req.PagoEm,
+		Metodo:      req.Metodo,
+		Obs:         req.Obs,
+		Overpayment: req.GetOverpayment(),
+	}
+	if in.IncomeID, err = parseFieldID(req.GetIncomeId()); err != nil {
+		return nil, err
+	}
+	if in.MethodID, err = parseOptionalID(req.MethodId); err != nil {
+		return nil, err
+	}
+	if in.Valor, err = parseMoney(req.GetValor()); err != nil {
+		return nil, err
+	}
+	if err := validateRequest(in); err != nil {
+		return nil, err
+	}
+
+	return idempotent(ctx, s.d, ownerID, pb.PaymentService_AddPayment_FullMethodName, req, func() *pb.PaymentResult { return &pb.PaymentResult{} },
+		func() (*pb.PaymentResult, error) {
+			res, err := s.d.Incomes.AddPayment(ctx, ownerID, in)
+			if err != nil {
+				switch {
+				case errors.Is(err, models.ErrIncomeNotFound):
+					return nil, errorf(http.StatusNotFound, "receita não encontrada")
+				case errors.Is(err, models.ErrIncomeAlreadyPaid):
+					return nil, errorf(http.StatusConflict, err.Error())
+				case errors.Is(err, models.ErrInsufficientAmount):
+					return nil, errorf(http.StatusBadRequest, "valor do pagamento excede o saldo devedor")
+				case errors.Is(err, models.ErrIncomeIDRequired), errors.Is(err, models.ErrValorInvalid),
+					errors.Is(err, models.ErrInvalidOverpaymentMode), errors.Is(err, models.ErrInvalidDateFormat),
+					errors.Is(err, models.ErrPaymentMethodNotFound), errors.Is(err, models.ErrPaymentMethodArchived):
+					return nil, errorf(http.StatusBadRequest, err.Error())
+				}
+				return nil, s.d.logError("erro ao adicionar pagamento", err)
+			}
+			return paymentResultToPB(res), nil
+		})
+}
+
+// UpdatePayment corrige um pagamento (todos os campos, como o PUT)
+func (s *paymentServer) UpdatePayment(ctx context.Context, req *pb.UpdatePaymentRequest) (*pb.PaymentResult, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodPut, paymentsPath+"/"+req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	in := &models.PaymentUpdateRequest{PagoEm: req.GetPagoEm(), Metodo: req.Metodo, Obs: req.Obs}
+	if in.MethodID, err = parseOptionalID(req.MethodId); err != nil {
+		return nil, err
+	}
+	if in.Valor, err = parseMoney(req.GetValor()); err != nil {
+		return nil, err
+	}
+	if err := validateRequest(in); err != nil {
+		return nil, err
+	}
+	res, err := s.d.Incomes.UpdatePayment(ctx, id, ownerID, in)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPaymentNotFound):
+			return nil, errorf(http.StatusNotFound, "pagamento não encontrado")
+		case errors.Is(err, models.ErrPaymentAlreadyReversed):
+			return nil, errorf(http.StatusConflict, "pagamento estornado não pode ser alterado")
+		case errors.Is(err, models.ErrCreditPaymentLocked):
+			return nil, errorf(http.StatusConflict, err.Error())
+		case errors.Is(err, models.ErrInsufficientAmount):
+			return nil, errorf(http.StatusBadRequest, "valor do pagamento excede o saldo devedor")
+		case errors.Is(err, models.ErrValorInvalid), errors.Is(err, models.ErrInvalidDateFormat),
+			errors.Is(err, models.ErrPaymentMethodNotFound), errors.Is(err, models.ErrPaymentMethodArchived):
+			return nil, errorf(http.StatusBadRequest, err.Error())
+		}
+		return nil, s.d.logError("erro ao atualizar pagamento", err)
+	}
+	return paymentResultToPB(res), nil
+}
+
+// DeletePayment exclui um pagamento lançado por engano; devolve o pagamento excluído e a receita recalculada
+func (s *paymentServer) DeletePayment(ctx context.Context, req *pb.DeletePaymentRequest) (*pb.PaymentResult, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodDelete, paymentsPath+"/"+req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	in := &models.PaymentReversalRequest{Reason: req.GetReason()}
+	if err := validateRequest(in); err != nil {
+		return nil, err
+	}
+	res, err := s.d.Payments.DeletePayment(ctx, id, ownerID, in)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPaymentNotFound):
+			return nil, errorf(http.StatusNotFound, err.Error())
+		case errors.Is(err, models.ErrPaymentAlreadyReversed), errors.Is(err, models.ErrCreditAlreadyApplied):
+			return nil, errorf(http.StatusConflict, err.Error())
+		case errors.Is(err, models.ErrReversalReasonRequired), errors.Is(err, models.ErrReversalReasonTooLong):
+			return nil, errorf(http.StatusBadRequest, err.Error())
+		}
+		return nil, s.d.logError("erro ao excluir pagamento", err)
+	}
+	return &pb.PaymentResult{Payment: paymentToPB(&res.Reversal.Payment), Income: incomeToPB(&res.Income)}, nil
+}
@@ -0,0 +1,153 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: ReceiptService gRPC (espelha GET/POST /api/v1/receipts e GET /api/v1/receipts/{id})
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	pb "recibofast/internal/grpcapi/recibofastv1"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+const receiptsPath = "/api/v1/receipts"
+
+type receiptServer struct {
+	pb.UnimplementedReceiptServiceServer
+	d *Deps
+}
+
+// ListReceipts lista recibos (mais recentes primeiro; até 100 por página)
+func (s *receiptServer) ListReceipts(ctx context.Context, req *pb.ListReceiptsRequest) (*pb.ListReceiptsResponse, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodGet, receiptsPath)
+	if err != nil {
+		return nil, err
+	}
+	filter := &models.ReceiptFilter{Search: strings.TrimSpace(req.GetSearch()), Page: int(req.GetPage()), PerPage: int(req.GetPerPage())}
+	if filter.IncomeID, err = parseQueryID(req.IncomeId, "income_id"); err != nil {
+		return nil, err
+	}
+	if filter.PayerID, err = parseQueryID(req.PayerId, "payer_id"); err != nil {
+		return nil, err
+	}
+	res, err := s.d.Receipts.ListReceipts(ctx, ownerID, filter)
+	if err != nil {
+		return nil, s.d.logError("erro ao listar recibos", err)
+	}
+	out := &pb.ListReceiptsResponse{
+		Items:      make([]*pb.Receipt, 0, len(res.Items)),
+		Total:      int32(res.Total),
+		Page:       int32(res.Page),
+		Limit:      int32(res.Limit),
+		TotalPages: int32(res.TotalPages),
+	}
+	for i := range res.Items {
+		out.Items = append(out.Items, receiptToPB(&res.Items[i]))
+	}
+	return out, nil
+}
+
+// GetReceipt busca um recibo por ID
+func (s *receiptServer) GetReceipt(ctx context.Context, req *pb.GetReceiptRequest) (*pb.Receipt, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodGet, receiptsPath+"/"+req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.d.Receipts.GetReceipt(ctx, id, ownerID)
+	if err != nil {
+		if repositories.IsReceiptNotFound(err) {
+			return nil, errorf(http.StatusNotFound, "recibo não encontrado")
+		}
+		return nil, s.d.logError("erro ao buscar recibo", err)
+	}
+	return receiptToPB(m), nil
+}
+
+// CreateReceipt emite um recibo dentro do limite do plano (aceita o metadado idempotency-key)
+func (s *receiptServer) CreateReceipt(ctx context.Context, req *pb.CreateReceiptRequest) (*pb.Receipt, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodPost, receiptsPath)
+	if err != nil {
+		return nil, err
+	}
+	in := &models.ReceiptRequest{
+		Numero:         req.Numero,
+		PDFURL:         req.PdfUrl,
+		Hash:           req.Hash,
+		IssuerName:     req.IssuerName,
+		IssuerDocument: req.IssuerDocument,
+	}
+	if in.IncomeID, err = parseOptionalID(req.IncomeId); err != nil {
+		return nil, err
+	}
+	if in.PayerID, err = parseOptionalID(req.PayerId); err != nil {
+		return nil, err
+	}
+	if in.SignatureID, err = parseOptionalID(req.SignatureId); err != nil {
+		return nil, err
+	}
+	if in.Taxas, err = parseOptionalMoney(req.Taxas); err != nil {
+		return nil, err
+	}
+	if in.Descontos, err = parseOptionalMoney(req.Descontos); err != nil {
+		return nil, err
+	}
+	if err := validateRequest(in); err != nil {
+		return nil, err
+	}
+
+	return idempotent(ctx, s.d, ownerID, pb.ReceiptService_CreateReceipt_FullMethodName, req, func() *pb.Receipt { return &pb.Receipt{} },
+		func() (*pb.Receipt, error) {
+			release, err := s.reserveQuota(ctx, ownerID)
+			if err != nil {
+				return nil, err
+			}
+			m, err := s.d.Receipts.CreateReceipt(ctx, ownerID, in)
+			if err != nil {
+				release()
+				switch {
+				case errors.Is(err, models.ErrPayerNotFound), errors.Is(err, models.ErrInvalidReceiptAdjustment),
+					errors.Is(err, models.ErrReceiptNumberInvalid):
+					return nil, errorf(http.StatusBadRequest, err.Error())
+				case errors.Is(err, models.ErrIncomeNotFound):
+					return nil, errorf(http.StatusNotFound, "receita não encontrada")
+				case errors.Is(err, models.ErrReceiptNumberConflict), errors.Is(err, models.ErrReceiptNumberNotMonotonic):
+					return nil, errorf(http.StatusConflict, err.Error())
+				}
+				return nil, s.d.logError("erro ao criar recibo", err)
+			}
+			return receiptToPB(m), nil
+		})
+}
+
+// reserveQuota reserva um recibo no limite do plano (402 esgotado, 403 indisponível no plano)
+func (s *receiptServer) reserveQuota(ctx context.Context, ownerID uuid.UUID) (func(), error) {
+	if s.d.Quota == nil {
+		return func() {}, nil
+	}
+	release, err := s.d.Quota.Reserve(ctx, ownerID, models.QuotaMetricReceipts)
+	var qe *models.QuotaError
+	if errors.As(err, &qe) {
+		status := http.StatusPaymentRequired
+		if qe.Unavailable() {
+			status = http.StatusForbidden
+		}
+		return nil, HTTPError(status, qe.Code(), qe.Error())
+	}
+	if err != nil {
+		s.d.Logger.Error("erro ao verificar limite do plano", logField(err))
+		return nil, errorf(http.StatusInternalServerError, "erro ao verificar limite do plano")
+	}
+	return release, nil
+}
@@ -0,0 +1,847 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Contrato gRPC das receitas (espelha /api/v1/incomes)
+// Data: 18-10-2026
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: recibofast/v1/incomes.proto
+
+package recibofastv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Income receita (valores monetários em string decimal, como no JSON: "1234.50")
+type Income struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	ContractId    *string                `protobuf:"bytes,3,opt,name=contract_id,json=contractId,proto3,oneof" json:"contract_id,omitempty"`
+	PayerId       *string                `protobuf:"bytes,4,opt,name=payer_id,json=payerId,proto3,oneof" json:"payer_id,omitempty"`
+	Categoria     *string                `protobuf:"bytes,5,opt,name=categoria,proto3,oneof" json:"categoria,omitempty"`
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Competencia   string                 `protobuf:"bytes,7,opt,name=competencia,proto3" json:"competencia,omitempty"`
+	Valor         string                 `protobuf:"bytes,8,opt,name=valor,proto3" json:"valor,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	TotalPago     string                 `protobuf:"bytes,11,opt,name=total_pago,json=totalPago,proto3" json:"total_pago,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,15,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Income) Reset() {
+	*x = Income{}
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Income) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Income) ProtoMessage() {}
+
+func (x *Income) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Income.ProtoReflect.Descriptor instead.
+func (*Income) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_incomes_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Income) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Income) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Income) GetContractId() string {
+	if x != nil && x.ContractId != nil {
+		return *x.ContractId
+	}
+	return ""
+}
+
+func (x *Income) GetPayerId() string {
+	if x != nil && x.PayerId != nil {
+		return *x.PayerId
+	}
+	return ""
+}
+
+func (x *Income) GetCategoria() string {
+	if x != nil && x.Categoria != nil {
+		return *x.Categoria
+	}
+	return ""
+}
+
+func (x *Income) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Income) GetCompetencia() string {
+	if x != nil {
+		return x.Competencia
+	}
+	return ""
+}
+
+func (x *Income) GetValor() string {
+	if x != nil {
+		return x.Valor
+	}
+	return ""
+}
+
+func (x *Income) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Income) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Income) GetTotalPago() string {
+	if x != nil {
+		return x.TotalPago
+	}
+	return ""
+}
+
+func (x *Income) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Income) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Income) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Income) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// IncomeInput campos editáveis da receita (models.IncomeRequest)
+type IncomeInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContractId    *string                `protobuf:"bytes,1,opt,name=contract_id,json=contractId,proto3,oneof" json:"contract_id,omitempty"`
+	PayerId       *string                `protobuf:"bytes,2,opt,name=payer_id,json=payerId,proto3,oneof" json:"payer_id,omitempty"`
+	Categoria     *string                `protobuf:"bytes,3,opt,name=categoria,proto3,oneof" json:"categoria,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Competencia   string                 `protobuf:"bytes,5,opt,name=competencia,proto3" json:"competencia,omitempty"`
+	Valor         string                 `protobuf:"bytes,6,opt,name=valor,proto3" json:"valor,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	DueDate       *string                `protobuf:"bytes,8,opt,name=due_date,json=dueDate,proto3,oneof" json:"due_date,omitempty"` // RFC3339
+	Version       *int64                 `protobuf:"varint,9,opt,name=version,proto3,oneof" json:"version,omitempty"`               // obrigatório na atualização
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncomeInput) Reset() {
+	*x = IncomeInput{}
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncomeInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomeInput) ProtoMessage() {}
+
+func (x *IncomeInput) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomeInput.ProtoReflect.Descriptor instead.
+func (*IncomeInput) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_incomes_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IncomeInput) GetContractId() string {
+	if x != nil && x.ContractId != nil {
+		return *x.ContractId
+	}
+	return ""
+}
+
+func (x *IncomeInput) GetPayerId() string {
+	if x != nil && x.PayerId != nil {
+		return *x.PayerId
+	}
+	return ""
+}
+
+func (x *IncomeInput) GetCategoria() string {
+	if x != nil && x.Categoria != nil {
+		return *x.Categoria
+	}
+	return ""
+}
+
+func (x *IncomeInput) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *IncomeInput) GetCompetencia() string {
+	if x != nil {
+		return x.Competencia
+	}
+	return ""
+}
+
+func (x *IncomeInput) GetValor() string {
+	if x != nil {
+		return x.Valor
+	}
+	return ""
+}
+
+func (x *IncomeInput) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *IncomeInput) GetDueDate() string {
+	if x != nil && x.DueDate != nil {
+		return *x.DueDate
+	}
+	return ""
+}
+
+func (x *IncomeInput) GetVersion() int64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type ListIncomesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Search        string                 `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Categoria     string                 `protobuf:"bytes,3,opt,name=categoria,proto3" json:"categoria,omitempty"`
+	Competencia   string                 `protobuf:"bytes,4,opt,name=competencia,proto3" json:"competencia,omitempty"`
+	ContractId    *string                `protobuf:"bytes,5,opt,name=contract_id,json=contractId,proto3,oneof" json:"contract_id,omitempty"`
+	PayerId       *string                `protobuf:"bytes,6,opt,name=payer_id,json=payerId,proto3,oneof" json:"payer_id,omitempty"`
+	Tag           string                 `protobuf:"bytes,7,opt,name=tag,proto3" json:"tag,omitempty"`
+	SortField     string                 `protobuf:"bytes,8,opt,name=sort_field,json=sortField,proto3" json:"sort_field,omitempty"`
+	SortOrder     string                 `protobuf:"bytes,9,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	Page          int32                  `protobuf:"varint,10,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,11,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomesRequest) Reset() {
+	*x = ListIncomesRequest{}
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomesRequest) ProtoMessage() {}
+
+func (x *ListIncomesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomesRequest.ProtoReflect.Descriptor instead.
+func (*ListIncomesRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_incomes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListIncomesRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListIncomesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListIncomesRequest) GetCategoria() string {
+	if x != nil {
+		return x.Categoria
+	}
+	return ""
+}
+
+func (x *ListIncomesRequest) GetCompetencia() string {
+	if x != nil {
+		return x.Competencia
+	}
+	return ""
+}
+
+func (x *ListIncomesRequest) GetContractId() string {
+	if x != nil && x.ContractId != nil {
+		return *x.ContractId
+	}
+	return ""
+}
+
+func (x *ListIncomesRequest) GetPayerId() string {
+	if x != nil && x.PayerId != nil {
+		return *x.PayerId
+	}
+	return ""
+}
+
+func (x *ListIncomesRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListIncomesRequest) GetSortField() string {
+	if x != nil {
+		return x.SortField
+	}
+	return ""
+}
+
+func (x *ListIncomesRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListIncomesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListIncomesRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type ListIncomesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Incomes       []*Income              `protobuf:"bytes,1,rep,name=incomes,proto3" json:"incomes,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,4,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomesResponse) Reset() {
+	*x = ListIncomesResponse{}
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomesResponse) ProtoMessage() {}
+
+func (x *ListIncomesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomesResponse.ProtoReflect.Descriptor instead.
+func (*ListIncomesResponse) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_incomes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListIncomesResponse) GetIncomes() []*Income {
+	if x != nil {
+		return x.Incomes
+	}
+	return nil
+}
+
+func (x *ListIncomesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListIncomesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListIncomesResponse) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *ListIncomesResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type GetIncomeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIncomeRequest) Reset() {
+	*x = GetIncomeRequest{}
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIncomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIncomeRequest) ProtoMessage() {}
+
+func (x *GetIncomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIncomeRequest.ProtoReflect.Descriptor instead.
+func (*GetIncomeRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_incomes_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetIncomeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateIncomeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Income        *IncomeInput           `protobuf:"bytes,1,opt,name=income,proto3" json:"income,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIncomeRequest) Reset() {
+	*x = CreateIncomeRequest{}
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIncomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIncomeRequest) ProtoMessage() {}
+
+func (x *CreateIncomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIncomeRequest.ProtoReflect.Descriptor instead.
+func (*CreateIncomeRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_incomes_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateIncomeRequest) GetIncome() *IncomeInput {
+	if x != nil {
+		return x.Income
+	}
+	return nil
+}
+
+type UpdateIncomeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Income        *IncomeInput           `protobuf:"bytes,2,opt,name=income,proto3" json:"income,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateIncomeRequest) Reset() {
+	*x = UpdateIncomeRequest{}
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIncomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIncomeRequest) ProtoMessage() {}
+
+func (x *UpdateIncomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIncomeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateIncomeRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_incomes_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateIncomeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateIncomeRequest) GetIncome() *IncomeInput {
+	if x != nil {
+		return x.Income
+	}
+	return nil
+}
+
+type DeleteIncomeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIncomeRequest) Reset() {
+	*x = DeleteIncomeRequest{}
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIncomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIncomeRequest) ProtoMessage() {}
+
+func (x *DeleteIncomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_incomes_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIncomeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteIncomeRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_incomes_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteIncomeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_recibofast_v1_incomes_proto protoreflect.FileDescriptor
+
+const file_recibofast_v1_incomes_proto_rawDesc = "" +
+	"\n" +
+	"\x1brecibofast/v1/incomes.proto\x12\rrecibofast.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcc\x04\n" +
+	"\x06Income\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12$\n" +
+	"\vcontract_id\x18\x03 \x01(\tH\x00R\n" +
+	"contractId\x88\x01\x01\x12\x1e\n" +
+	"\bpayer_id\x18\x04 \x01(\tH\x01R\apayerId\x88\x01\x01\x12!\n" +
+	"\tcategoria\x18\x05 \x01(\tH\x02R\tcategoria\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12 \n" +
+	"\vcompetencia\x18\a \x01(\tR\vcompetencia\x12\x14\n" +
+	"\x05valor\x18\b \x01(\tR\x05valor\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x125\n" +
+	"\bdue_date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x1d\n" +
+	"\n" +
+	"total_pago\x18\v \x01(\tR\ttotalPago\x129\n" +
+	"\n" +
+	"deleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x0f \x01(\x03R\aversionB\x0e\n" +
+	"\f_contract_idB\v\n" +
+	"\t_payer_idB\f\n" +
+	"\n" +
+	"_categoria\"\xdd\x02\n" +
+	"\vIncomeInput\x12$\n" +
+	"\vcontract_id\x18\x01 \x01(\tH\x00R\n" +
+	"contractId\x88\x01\x01\x12\x1e\n" +
+	"\bpayer_id\x18\x02 \x01(\tH\x01R\apayerId\x88\x01\x01\x12!\n" +
+	"\tcategoria\x18\x03 \x01(\tH\x02R\tcategoria\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12 \n" +
+	"\vcompetencia\x18\x05 \x01(\tR\vcompetencia\x12\x14\n" +
+	"\x05valor\x18\x06 \x01(\tR\x05valor\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1e\n" +
+	"\bdue_date\x18\b \x01(\tH\x03R\adueDate\x88\x01\x01\x12\x1d\n" +
+	"\aversion\x18\t \x01(\x03H\x04R\aversion\x88\x01\x01B\x0e\n" +
+	"\f_contract_idB\v\n" +
+	"\t_payer_idB\f\n" +
+	"\n" +
+	"_categoriaB\v\n" +
+	"\t_due_dateB\n" +
+	"\n" +
+	"\b_version\"\xe6\x02\n" +
+	"\x12ListIncomesRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
+	"\tcategoria\x18\x03 \x01(\tR\tcategoria\x12 \n" +
+	"\vcompetencia\x18\x04 \x01(\tR\vcompetencia\x12$\n" +
+	"\vcontract_id\x18\x05 \x01(\tH\x00R\n" +
+	"contractId\x88\x01\x01\x12\x1e\n" +
+	"\bpayer_id\x18\x06 \x01(\tH\x01R\apayerId\x88\x01\x01\x12\x10\n" +
+	"\x03tag\x18\a \x01(\tR\x03tag\x12\x1d\n" +
+	"\n" +
+	"sort_field\x18\b \x01(\tR\tsortField\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\t \x01(\tR\tsortOrder\x12\x12\n" +
+	"\x04page\x18\n" +
+	" \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\v \x01(\x05R\aperPageB\x0e\n" +
+	"\f_contract_idB\v\n" +
+	"\t_payer_id\"\xac\x01\n" +
+	"\x13ListIncomesResponse\x12/\n" +
+	"\aincomes\x18\x01 \x03(\v2\x15.recibofast.v1.IncomeR\aincomes\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x04 \x01(\x05R\aperPage\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\"\"\n" +
+	"\x10GetIncomeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x13CreateIncomeRequest\x122\n" +
+	"\x06income\x18\x01 \x01(\v2\x1a.recibofast.v1.IncomeInputR\x06income\"Y\n" +
+	"\x13UpdateIncomeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x122\n" +
+	"\x06income\x18\x02 \x01(\v2\x1a.recibofast.v1.IncomeInputR\x06income\"%\n" +
+	"\x13DeleteIncomeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\xa8\x04\n" +
+	"\rIncomeService\x12m\n" +
+	"\vListIncomes\x12!.recibofast.v1.ListIncomesRequest\x1a\".recibofast.v1.ListIncomesResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/incomes\x12a\n" +
+	"\tGetIncome\x12\x1f.recibofast.v1.GetIncomeRequest\x1a\x15.recibofast.v1.Income\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/incomes/{id}\x12j\n" +
+	"\fCreateIncome\x12\".recibofast.v1.CreateIncomeRequest\x1a\x15.recibofast.v1.Income\"\x1f\x82\xd3\xe4\x93\x02\x19:\x06income\"\x0f/api/v1/incomes\x12o\n" +
+	"\fUpdateIncome\x12\".recibofast.v1.UpdateIncomeRequest\x1a\x15.recibofast.v1.Income\"$\x82\xd3\xe4\x93\x02\x1e:\x06income\x1a\x14/api/v1/incomes/{id}\x12h\n" +
+	"\fDeleteIncome\x12\".recibofast.v1.DeleteIncomeRequest\x1a\x16.google.protobuf.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/api/v1/incomes/{id}B7Z5recibofast/internal/grpcapi/recibofastv1;recibofastv1b\x06proto3"
+
+var (
+	file_recibofast_v1_incomes_proto_rawDescOnce sync.Once
+	file_recibofast_v1_incomes_proto_rawDescData []byte
+)
+
+func file_recibofast_v1_incomes_proto_rawDescGZIP() []byte {
+	file_recibofast_v1_incomes_proto_rawDescOnce.Do(func() {
+		file_recibofast_v1_incomes_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_recibofast_v1_incomes_proto_rawDesc), len(file_recibofast_v1_incomes_proto_rawDesc)))
+	})
+	return file_recibofast_v1_incomes_proto_rawDescData
+}
+
+var file_recibofast_v1_incomes_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_recibofast_v1_incomes_proto_goTypes = []any{
+	(*Income)(nil),                // 0: recibofast.v1.Income
+	(*IncomeInput)(nil),           // 1: recibofast.v1.IncomeInput
+	(*ListIncomesRequest)(nil),    // 2: recibofast.v1.ListIncomesRequest
+	(*ListIncomesResponse)(nil),   // 3: recibofast.v1.ListIncomesResponse
+	(*GetIncomeRequest)(nil),      // 4: recibofast.v1.GetIncomeRequest
+	(*CreateIncomeRequest)(nil),   // 5: recibofast.v1.CreateIncomeRequest
+	(*UpdateIncomeRequest)(nil),   // 6: recibofast.v1.UpdateIncomeRequest
+	(*DeleteIncomeRequest)(nil),   // 7: recibofast.v1.DeleteIncomeRequest
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+}
+var file_recibofast_v1_incomes_proto_depIdxs = []int32{
+	8,  // 0: recibofast.v1.Income.due_date:type_name -> google.protobuf.Timestamp
+	8,  // 1: recibofast.v1.Income.deleted_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: recibofast.v1.Income.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: recibofast.v1.Income.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: recibofast.v1.ListIncomesResponse.incomes:type_name -> recibofast.v1.Income
+	1,  // 5: recibofast.v1.CreateIncomeRequest.income:type_name -> recibofast.v1.IncomeInput
+	1,  // 6: recibofast.v1.UpdateIncomeRequest.income:type_name -> recibofast.v1.IncomeInput
+	2,  // 7: recibofast.v1.IncomeService.ListIncomes:input_type -> recibofast.v1.ListIncomesRequest
+	4,  // 8: recibofast.v1.IncomeService.GetIncome:input_type -> recibofast.v1.GetIncomeRequest
+	5,  // 9: recibofast.v1.IncomeService.CreateIncome:input_type -> recibofast.v1.CreateIncomeRequest
+	6,  // 10: recibofast.v1.IncomeService.UpdateIncome:input_type -> recibofast.v1.UpdateIncomeRequest
+	7,  // 11: recibofast.v1.IncomeService.DeleteIncome:input_type -> recibofast.v1.DeleteIncomeRequest
+	3,  // 12: recibofast.v1.IncomeService.ListIncomes:output_type -> recibofast.v1.ListIncomesResponse
+	0,  // 13: recibofast.v1.IncomeService.GetIncome:output_type -> recibofast.v1.Income
+	0,  // 14: recibofast.v1.IncomeService.CreateIncome:output_type -> recibofast.v1.Income
+	0,  // 15: recibofast.v1.IncomeService.UpdateIncome:output_type -> recibofast.v1.Income
+	9,  // 16: recibofast.v1.IncomeService.DeleteIncome:output_type -> google.protobuf.Empty
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_recibofast_v1_incomes_proto_init() }
+func file_recibofast_v1_incomes_proto_init() {
+	if File_recibofast_v1_incomes_proto != nil {
+		return
+	}
+	file_recibofast_v1_incomes_proto_msgTypes[0].OneofWrappers = []any{}
+	file_recibofast_v1_incomes_proto_msgTypes[1].OneofWrappers = []any{}
+	file_recibofast_v1_incomes_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recibofast_v1_incomes_proto_rawDesc), len(file_recibofast_v1_incomes_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_recibofast_v1_incomes_proto_goTypes,
+		DependencyIndexes: file_recibofast_v1_incomes_proto_depIdxs,
+		MessageInfos:      file_recibofast_v1_incomes_proto_msgTypes,
+	}.Build()
+	File_recibofast_v1_incomes_proto = out.File
+	file_recibofast_v1_incomes_proto_goTypes = nil
+	file_recibofast_v1_incomes_proto_depIdxs = nil
+}
@@ -0,0 +1,283 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Contrato gRPC das receitas (espelha /api/v1/incomes)
+// Data: 18-10-2026
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: recibofast/v1/incomes.proto
+
+package recibofastv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	IncomeService_ListIncomes_FullMethodName  = "/recibofast.v1.IncomeService/ListIncomes"
+	IncomeService_GetIncome_FullMethodName    = "/recibofast.v1.IncomeService/GetIncome"
+	IncomeService_CreateIncome_FullMethodName = "/recibofast.v1.IncomeService/CreateIncome"
+	IncomeService_UpdateIncome_FullMethodName = "/recibofast.v1.IncomeService/UpdateIncome"
+	IncomeService_DeleteIncome_FullMethodName = "/recibofast.v1.IncomeService/DeleteIncome"
+)
+
+// IncomeServiceClient is the client API for IncomeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// IncomeService receitas do usuário autenticado (metadado "authorization: Bearer <JWT>" ou "x-api-key")
+type IncomeServiceClient interface {
+	ListIncomes(ctx context.Context, in *ListIncomesRequest, opts ...grpc.CallOption) (*ListIncomesResponse, error)
+	GetIncome(ctx context.Context, in *GetIncomeRequest, opts ...grpc.CallOption) (*Income, error)
+	CreateIncome(ctx context.Context, in *CreateIncomeRequest, opts ...grpc.CallOption) (*Income, error)
+	UpdateIncome(ctx context.Context, in *UpdateIncomeRequest, opts ...grpc.CallOption) (*Income, error)
+	DeleteIncome(ctx context.Context, in *DeleteIncomeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type incomeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIncomeServiceClient(cc grpc.ClientConnInterface) IncomeServiceClient {
+	return &incomeServiceClient{cc}
+}
+
+func (c *incomeServiceClient) ListIncomes(ctx context.Context, in *ListIncomesRequest, opts ...grpc.CallOption) (*ListIncomesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncomesResponse)
+	err := c.cc.Invoke(ctx, IncomeService_ListIncomes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incomeServiceClient) GetIncome(ctx context.Context, in *GetIncomeRequest, opts ...grpc.CallOption) (*Income, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Income)
+	err := c.cc.Invoke(ctx, IncomeService_GetIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incomeServiceClient) CreateIncome(ctx context.Context, in *CreateIncomeRequest, opts ...grpc.CallOption) (*Income, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Income)
+	err := c.cc.Invoke(ctx, IncomeService_CreateIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incomeServiceClient) UpdateIncome(ctx context.Context, in *UpdateIncomeRequest, opts ...grpc.CallOption) (*Income, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Income)
+	err := c.cc.Invoke(ctx, IncomeService_UpdateIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incomeServiceClient) DeleteIncome(ctx context.Context, in *DeleteIncomeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, IncomeService_DeleteIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IncomeServiceServer is the server API for IncomeService service.
+// All implementations must embed UnimplementedIncomeServiceServer
+// for forward compatibility.
+//
+// IncomeService receitas do usuário autenticado (metadado "authorization: Bearer <JWT>" ou "x-api-key")
+type IncomeServiceServer interface {
+	ListIncomes(context.Context, *ListIncomesRequest) (*ListIncomesResponse, error)
+	GetIncome(context.Context, *GetIncomeRequest) (*Income, error)
+	CreateIncome(context.Context, *CreateIncomeRequest) (*Income, error)
+	UpdateIncome(context.Context, *UpdateIncomeRequest) (*Income, error)
+	DeleteIncome(context.Context, *DeleteIncomeRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedIncomeServiceServer()
+}
+
+// UnimplementedIncomeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedIncomeServiceServer struct{}
+
+func (UnimplementedIncomeServiceServer) ListIncomes(context.Context, *ListIncomesRequest) (*ListIncomesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncomes not implemented")
+}
+func (UnimplementedIncomeServiceServer) GetIncome(context.Context, *GetIncomeRequest) (*Income, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIncome not implemented")
+}
+func (UnimplementedIncomeServiceServer) CreateIncome(context.Context, *CreateIncomeRequest) (*Income, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIncome not implemented")
+}
+func (UnimplementedIncomeServiceServer) UpdateIncome(context.Context, *UpdateIncomeRequest) (*Income, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateIncome not implemented")
+}
+func (UnimplementedIncomeServiceServer) DeleteIncome(context.Context, *DeleteIncomeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteIncome not implemented")
+}
+func (UnimplementedIncomeServiceServer) mustEmbedUnimplementedIncomeServiceServer() {}
+func (UnimplementedIncomeServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeIncomeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IncomeServiceServer will
+// result in compilation errors.
+type UnsafeIncomeServiceServer interface {
+	mustEmbedUnimplementedIncomeServiceServer()
+}
+
+func RegisterIncomeServiceServer(s grpc.ServiceRegistrar, srv IncomeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedIncomeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&IncomeService_ServiceDesc, srv)
+}
+
+func _IncomeService_ListIncomes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncomesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncomeServiceServer).ListIncomes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncomeService_ListIncomes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncomeServiceServer).ListIncomes(ctx, req.(*ListIncomesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncomeService_GetIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncomeServiceServer).GetIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncomeService_GetIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncomeServiceServer).GetIncome(ctx, req.(*GetIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncomeService_CreateIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncomeServiceServer).CreateIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncomeService_CreateIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncomeServiceServer).CreateIncome(ctx, req.(*CreateIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncomeService_UpdateIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncomeServiceServer).UpdateIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncomeService_UpdateIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncomeServiceServer).UpdateIncome(ctx, req.(*UpdateIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncomeService_DeleteIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncomeServiceServer).DeleteIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncomeService_DeleteIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncomeServiceServer).DeleteIncome(ctx, req.(*DeleteIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IncomeService_ServiceDesc is the grpc.ServiceDesc for IncomeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IncomeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "recibofast.v1.IncomeService",
+	HandlerType: (*IncomeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListIncomes",
+			Handler:    _IncomeService_ListIncomes_Handler,
+		},
+		{
+			MethodName: "GetIncome",
+			Handler:    _IncomeService_GetIncome_Handler,
+		},
+		{
+			MethodName: "CreateIncome",
+			Handler:    _IncomeService_CreateIncome_Handler,
+		},
+		{
+			MethodName: "UpdateIncome",
+			Handler:    _IncomeService_UpdateIncome_Handler,
+		},
+		{
+			MethodName: "DeleteIncome",
+			Handler:    _IncomeService_DeleteIncome_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "recibofast/v1/incomes.proto",
+}
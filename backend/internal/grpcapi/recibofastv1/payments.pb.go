@@ -0,0 +1,773 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Contrato gRPC dos pagamentos (espelha /api/v1/payments e /api/v1/incomes/{id}/payments)
+// Data: 18-10-2026
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: recibofast/v1/payments.proto
+
+package recibofastv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Payment pagamento registrado (estornados não contam no total pago)
+type Payment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncomeId       string                 `protobuf:"bytes,2,opt,name=income_id,json=incomeId,proto3" json:"income_id,omitempty"`
+	Valor          string                 `protobuf:"bytes,3,opt,name=valor,proto3" json:"valor,omitempty"`
+	PagoEm         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=pago_em,json=pagoEm,proto3" json:"pago_em,omitempty"`
+	Metodo         *string                `protobuf:"bytes,5,opt,name=metodo,proto3,oneof" json:"metodo,omitempty"`
+	MethodId       *string                `protobuf:"bytes,6,opt,name=method_id,json=methodId,proto3,oneof" json:"method_id,omitempty"`
+	Obs            *string                `protobuf:"bytes,7,opt,name=obs,proto3,oneof" json:"obs,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReversedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=reversed_at,json=reversedAt,proto3" json:"reversed_at,omitempty"`
+	ReversalReason *string                `protobuf:"bytes,10,opt,name=reversal_reason,json=reversalReason,proto3,oneof" json:"reversal_reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_recibofast_v1_payments_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_payments_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_payments_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Payment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Payment) GetIncomeId() string {
+	if x != nil {
+		return x.IncomeId
+	}
+	return ""
+}
+
+func (x *Payment) GetValor() string {
+	if x != nil {
+		return x.Valor
+	}
+	return ""
+}
+
+func (x *Payment) GetPagoEm() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PagoEm
+	}
+	return nil
+}
+
+func (x *Payment) GetMetodo() string {
+	if x != nil && x.Metodo != nil {
+		return *x.Metodo
+	}
+	return ""
+}
+
+func (x *Payment) GetMethodId() string {
+	if x != nil && x.MethodId != nil {
+		return *x.MethodId
+	}
+	return ""
+}
+
+func (x *Payment) GetObs() string {
+	if x != nil && x.Obs != nil {
+		return *x.Obs
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Payment) GetReversedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReversedAt
+	}
+	return nil
+}
+
+func (x *Payment) GetReversalReason() string {
+	if x != nil && x.ReversalReason != nil {
+		return *x.ReversalReason
+	}
+	return ""
+}
+
+// Credit excedente guardado como crédito do pagador (AddPayment com overpayment "credit")
+type Credit struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PayerId         *string                `protobuf:"bytes,2,opt,name=payer_id,json=payerId,proto3,oneof" json:"payer_id,omitempty"`
+	SourceIncomeId  *string                `protobuf:"bytes,3,opt,name=source_income_id,json=sourceIncomeId,proto3,oneof" json:"source_income_id,omitempty"`
+	SourcePaymentId *string                `protobuf:"bytes,4,opt,name=source_payment_id,json=sourcePaymentId,proto3,oneof" json:"source_payment_id,omitempty"`
+	Valor           string                 `protobuf:"bytes,5,opt,name=valor,proto3" json:"valor,omitempty"`
+	Saldo           string                 `protobuf:"bytes,6,opt,name=saldo,proto3" json:"saldo,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Credit) Reset() {
+	*x = Credit{}
+	mi := &file_recibofast_v1_payments_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Credit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credit) ProtoMessage() {}
+
+func (x *Credit) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_payments_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Credit.ProtoReflect.Descriptor instead.
+func (*Credit) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_payments_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Credit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Credit) GetPayerId() string {
+	if x != nil && x.PayerId != nil {
+		return *x.PayerId
+	}
+	return ""
+}
+
+func (x *Credit) GetSourceIncomeId() string {
+	if x != nil && x.SourceIncomeId != nil {
+		return *x.SourceIncomeId
+	}
+	return ""
+}
+
+func (x *Credit) GetSourcePaymentId() string {
+	if x != nil && x.SourcePaymentId != nil {
+		return *x.SourcePaymentId
+	}
+	return ""
+}
+
+func (x *Credit) GetValor() string {
+	if x != nil {
+		return x.Valor
+	}
+	return ""
+}
+
+func (x *Credit) GetSaldo() string {
+	if x != nil {
+		return x.Saldo
+	}
+	return ""
+}
+
+func (x *Credit) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// PaymentResult pagamento e receita atualizada (models.PaymentResponse); na exclusão, o pagamento excluído
+type PaymentResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	Income        *Income                `protobuf:"bytes,2,opt,name=income,proto3" json:"income,omitempty"`
+	Credit        *Credit                `protobuf:"bytes,3,opt,name=credit,proto3" json:"credit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentResult) Reset() {
+	*x = PaymentResult{}
+	mi := &file_recibofast_v1_payments_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentResult) ProtoMessage() {}
+
+func (x *PaymentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_payments_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentResult.ProtoReflect.Descriptor instead.
+func (*PaymentResult) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_payments_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PaymentResult) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *PaymentResult) GetIncome() *Income {
+	if x != nil {
+		return x.Income
+	}
+	return nil
+}
+
+func (x *PaymentResult) GetCredit() *Credit {
+	if x != nil {
+		return x.Credit
+	}
+	return nil
+}
+
+type ListPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncomeId      string                 `protobuf:"bytes,1,opt,name=income_id,json=incomeId,proto3" json:"income_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsRequest) Reset() {
+	*x = ListPaymentsRequest{}
+	mi := &file_recibofast_v1_payments_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsRequest) ProtoMessage() {}
+
+func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_payments_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_payments_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPaymentsRequest) GetIncomeId() string {
+	if x != nil {
+		return x.IncomeId
+	}
+	return ""
+}
+
+type ListPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsResponse) Reset() {
+	*x = ListPaymentsResponse{}
+	mi := &file_recibofast_v1_payments_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsResponse) ProtoMessage() {}
+
+func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_payments_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_payments_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+type AddPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncomeId      string                 `protobuf:"bytes,1,opt,name=income_id,json=incomeId,proto3" json:"income_id,omitempty"`
+	Valor         string                 `protobuf:"bytes,2,opt,name=valor,proto3" json:"valor,omitempty"`
+	PagoEm        *string                `protobuf:"bytes,3,opt,name=pago_em,json=pagoEm,proto3,oneof" json:"pago_em,omitempty"` // RFC3339; padrão: agora
+	MethodId      *string                `protobuf:"bytes,4,opt,name=method_id,json=methodId,proto3,oneof" json:"method_id,omitempty"`
+	Metodo        *string                `protobuf:"bytes,5,opt,name=metodo,proto3,oneof" json:"metodo,omitempty"`
+	Obs           *string                `protobuf:"bytes,6,opt,name=obs,proto3,oneof" json:"obs,omitempty"`
+	Overpayment   string                 `protobuf:"bytes,7,opt,name=overpayment,proto3" json:"overpayment,omitempty"` // "reject" (padrão) ou "credit"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPaymentRequest) Reset() {
+	*x = AddPaymentRequest{}
+	mi := &file_recibofast_v1_payments_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPaymentRequest) ProtoMessage() {}
+
+func (x *AddPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_payments_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPaymentRequest.ProtoReflect.Descriptor instead.
+func (*AddPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_payments_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddPaymentRequest) GetIncomeId() string {
+	if x != nil {
+		return x.IncomeId
+	}
+	return ""
+}
+
+func (x *AddPaymentRequest) GetValor() string {
+	if x != nil {
+		return x.Valor
+	}
+	return ""
+}
+
+func (x *AddPaymentRequest) GetPagoEm() string {
+	if x != nil && x.PagoEm != nil {
+		return *x.PagoEm
+	}
+	return ""
+}
+
+func (x *AddPaymentRequest) GetMethodId() string {
+	if x != nil && x.MethodId != nil {
+		return *x.MethodId
+	}
+	return ""
+}
+
+func (x *AddPaymentRequest) GetMetodo() string {
+	if x != nil && x.Metodo != nil {
+		return *x.Metodo
+	}
+	return ""
+}
+
+func (x *AddPaymentRequest) GetObs() string {
+	if x != nil && x.Obs != nil {
+		return *x.Obs
+	}
+	return ""
+}
+
+func (x *AddPaymentRequest) GetOverpayment() string {
+	if x != nil {
+		return x.Overpayment
+	}
+	return ""
+}
+
+type UpdatePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Valor         string                 `protobuf:"bytes,2,opt,name=valor,proto3" json:"valor,omitempty"`
+	PagoEm        string                 `protobuf:"bytes,3,opt,name=pago_em,json=pagoEm,proto3" json:"pago_em,omitempty"` // RFC3339
+	MethodId      *string                `protobuf:"bytes,4,opt,name=method_id,json=methodId,proto3,oneof" json:"method_id,omitempty"`
+	Metodo        *string                `protobuf:"bytes,5,opt,name=metodo,proto3,oneof" json:"metodo,omitempty"`
+	Obs           *string                `protobuf:"bytes,6,opt,name=obs,proto3,oneof" json:"obs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePaymentRequest) Reset() {
+	*x = UpdatePaymentRequest{}
+	mi := &file_recibofast_v1_payments_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePaymentRequest) ProtoMessage() {}
+
+func (x *UpdatePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_payments_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePaymentRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_payments_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdatePaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdatePaymentRequest) GetValor() string {
+	if x != nil {
+		return x.Valor
+	}
+	return ""
+}
+
+func (x *UpdatePaymentRequest) GetPagoEm() string {
+	if x != nil {
+		return x.PagoEm
+	}
+	return ""
+}
+
+func (x *UpdatePaymentRequest) GetMethodId() string {
+	if x != nil && x.MethodId != nil {
+		return *x.MethodId
+	}
+	return ""
+}
+
+func (x *UpdatePaymentRequest) GetMetodo() string {
+	if x != nil && x.Metodo != nil {
+		return *x.Metodo
+	}
+	return ""
+}
+
+func (x *UpdatePaymentRequest) GetObs() string {
+	if x != nil && x.Obs != nil {
+		return *x.Obs
+	}
+	return ""
+}
+
+type DeletePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // motivo opcional registrado na auditoria
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePaymentRequest) Reset() {
+	*x = DeletePaymentRequest{}
+	mi := &file_recibofast_v1_payments_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePaymentRequest) ProtoMessage() {}
+
+func (x *DeletePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_payments_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePaymentRequest.ProtoReflect.Descriptor instead.
+func (*DeletePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_payments_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeletePaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeletePaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+var File_recibofast_v1_payments_proto protoreflect.FileDescriptor
+
+const file_recibofast_v1_payments_proto_rawDesc = "" +
+	"\n" +
+	"\x1crecibofast/v1/payments.proto\x12\rrecibofast.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1brecibofast/v1/incomes.proto\"\xb2\x03\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tincome_id\x18\x02 \x01(\tR\bincomeId\x12\x14\n" +
+	"\x05valor\x18\x03 \x01(\tR\x05valor\x123\n" +
+	"\apago_em\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06pagoEm\x12\x1b\n" +
+	"\x06metodo\x18\x05 \x01(\tH\x00R\x06metodo\x88\x01\x01\x12 \n" +
+	"\tmethod_id\x18\x06 \x01(\tH\x01R\bmethodId\x88\x01\x01\x12\x15\n" +
+	"\x03obs\x18\a \x01(\tH\x02R\x03obs\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vreversed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reversedAt\x12,\n" +
+	"\x0freversal_reason\x18\n" +
+	" \x01(\tH\x03R\x0ereversalReason\x88\x01\x01B\t\n" +
+	"\a_metodoB\f\n" +
+	"\n" +
+	"_method_idB\x06\n" +
+	"\x04_obsB\x12\n" +
+	"\x10_reversal_reason\"\xb7\x02\n" +
+	"\x06Credit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\bpayer_id\x18\x02 \x01(\tH\x00R\apayerId\x88\x01\x01\x12-\n" +
+	"\x10source_income_id\x18\x03 \x01(\tH\x01R\x0esourceIncomeId\x88\x01\x01\x12/\n" +
+	"\x11source_payment_id\x18\x04 \x01(\tH\x02R\x0fsourcePaymentId\x88\x01\x01\x12\x14\n" +
+	"\x05valor\x18\x05 \x01(\tR\x05valor\x12\x14\n" +
+	"\x05saldo\x18\x06 \x01(\tR\x05saldo\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\v\n" +
+	"\t_payer_idB\x13\n" +
+	"\x11_source_income_idB\x14\n" +
+	"\x12_source_payment_id\"\x9f\x01\n" +
+	"\rPaymentResult\x120\n" +
+	"\apayment\x18\x01 \x01(\v2\x16.recibofast.v1.PaymentR\apayment\x12-\n" +
+	"\x06income\x18\x02 \x01(\v2\x15.recibofast.v1.IncomeR\x06income\x12-\n" +
+	"\x06credit\x18\x03 \x01(\v2\x15.recibofast.v1.CreditR\x06credit\"2\n" +
+	"\x13ListPaymentsRequest\x12\x1b\n" +
+	"\tincome_id\x18\x01 \x01(\tR\bincomeId\"J\n" +
+	"\x14ListPaymentsResponse\x122\n" +
+	"\bpayments\x18\x01 \x03(\v2\x16.recibofast.v1.PaymentR\bpayments\"\x89\x02\n" +
+	"\x11AddPaymentRequest\x12\x1b\n" +
+	"\tincome_id\x18\x01 \x01(\tR\bincomeId\x12\x14\n" +
+	"\x05valor\x18\x02 \x01(\tR\x05valor\x12\x1c\n" +
+	"\apago_em\x18\x03 \x01(\tH\x00R\x06pagoEm\x88\x01\x01\x12 \n" +
+	"\tmethod_id\x18\x04 \x01(\tH\x01R\bmethodId\x88\x01\x01\x12\x1b\n" +
+	"\x06metodo\x18\x05 \x01(\tH\x02R\x06metodo\x88\x01\x01\x12\x15\n" +
+	"\x03obs\x18\x06 \x01(\tH\x03R\x03obs\x88\x01\x01\x12 \n" +
+	"\voverpayment\x18\a \x01(\tR\voverpaymentB\n" +
+	"\n" +
+	"\b_pago_emB\f\n" +
+	"\n" +
+	"_method_idB\t\n" +
+	"\a_metodoB\x06\n" +
+	"\x04_obs\"\xcc\x01\n" +
+	"\x14UpdatePaymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05valor\x18\x02 \x01(\tR\x05valor\x12\x17\n" +
+	"\apago_em\x18\x03 \x01(\tR\x06pagoEm\x12 \n" +
+	"\tmethod_id\x18\x04 \x01(\tH\x00R\bmethodId\x88\x01\x01\x12\x1b\n" +
+	"\x06metodo\x18\x05 \x01(\tH\x01R\x06metodo\x88\x01\x01\x12\x15\n" +
+	"\x03obs\x18\x06 \x01(\tH\x02R\x03obs\x88\x01\x01B\f\n" +
+	"\n" +
+	"_method_idB\t\n" +
+	"\a_metodoB\x06\n" +
+	"\x04_obs\">\n" +
+	"\x14DeletePaymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason2\xec\x03\n" +
+	"\x0ePaymentService\x12\x85\x01\n" +
+	"\fListPayments\x12\".recibofast.v1.ListPaymentsRequest\x1a#.recibofast.v1.ListPaymentsResponse\",\x82\xd3\xe4\x93\x02&\x12$/api/v1/incomes/{income_id}/payments\x12i\n" +
+	"\n" +
+	"AddPayment\x12 .recibofast.v1.AddPaymentRequest\x1a\x1c.recibofast.v1.PaymentResult\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/api/v1/payments\x12t\n" +
+	"\rUpdatePayment\x12#.recibofast.v1.UpdatePaymentRequest\x1a\x1c.recibofast.v1.PaymentResult\" \x82\xd3\xe4\x93\x02\x1a:\x01*\x1a\x15/api/v1/payments/{id}\x12q\n" +
+	"\rDeletePayment\x12#.recibofast.v1.DeletePaymentRequest\x1a\x1c.recibofast.v1.PaymentResult\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/api/v1/payments/{id}B7Z5recibofast/internal/grpcapi/recibofastv1;recibofastv1b\x06proto3"
+
+var (
+	file_recibofast_v1_payments_proto_rawDescOnce sync.Once
+	file_recibofast_v1_payments_proto_rawDescData []byte
+)
+
+func file_recibofast_v1_payments_proto_rawDescGZIP() []byte {
+	file_recibofast_v1_payments_proto_rawDescOnce.Do(func() {
+		file_recibofast_v1_payments_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_recibofast_v1_payments_proto_rawDesc), len(file_recibofast_v1_payments_proto_rawDesc)))
+	})
+	return file_recibofast_v1_payments_proto_rawDescData
+}
+
+var file_recibofast_v1_payments_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_recibofast_v1_payments_proto_goTypes = []any{
+	(*Payment)(nil),               // 0: recibofast.v1.Payment
+	(*Credit)(nil),                // 1: recibofast.v1.Credit
+	(*PaymentResult)(nil),         // 2: recibofast.v1.PaymentResult
+	(*ListPaymentsRequest)(nil),   // 3: recibofast.v1.ListPaymentsRequest
+	(*ListPaymentsResponse)(nil),  // 4: recibofast.v1.ListPaymentsResponse
+	(*AddPaymentRequest)(nil),     // 5: recibofast.v1.AddPaymentRequest
+	(*UpdatePaymentRequest)(nil),  // 6: recibofast.v1.UpdatePaymentRequest
+	(*DeletePaymentRequest)(nil),  // 7: recibofast.v1.DeletePaymentRequest
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*Income)(nil),                // 9: recibofast.v1.Income
+}
+var file_recibofast_v1_payments_proto_depIdxs = []int32{
+	8,  // 0: recibofast.v1.Payment.pago_em:type_name -> google.protobuf.Timestamp
+	8,  // 1: recibofast.v1.Payment.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: recibofast.v1.Payment.reversed_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: recibofast.v1.Credit.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: recibofast.v1.PaymentResult.payment:type_name -> recibofast.v1.Payment
+	9,  // 5: recibofast.v1.PaymentResult.income:type_name -> recibofast.v1.Income
+	1,  // 6: recibofast.v1.PaymentResult.credit:type_name -> recibofast.v1.Credit
+	0,  // 7: recibofast.v1.ListPaymentsResponse.payments:type_name -> recibofast.v1.Payment
+	3,  // 8: recibofast.v1.PaymentService.ListPayments:input_type -> recibofast.v1.ListPaymentsRequest
+	5,  // 9: recibofast.v1.PaymentService.AddPayment:input_type -> recibofast.v1.AddPaymentRequest
+	6,  // 10: recibofast.v1.PaymentService.UpdatePayment:input_type -> recibofast.v1.UpdatePaymentRequest
+	7,  // 11: recibofast.v1.PaymentService.DeletePayment:input_type -> recibofast.v1.DeletePaymentRequest
+	4,  // 12: recibofast.v1.PaymentService.ListPayments:output_type -> recibofast.v1.ListPaymentsResponse
+	2,  // 13: recibofast.v1.PaymentService.AddPayment:output_type -> recibofast.v1.PaymentResult
+	2,  // 14: recibofast.v1.PaymentService.UpdatePayment:output_type -> recibofast.v1.PaymentResult
+	2,  // 15: recibofast.v1.PaymentService.DeletePayment:output_type -> recibofast.v1.PaymentResult
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_recibofast_v1_payments_proto_init() }
+func file_recibofast_v1_payments_proto_init() {
+	if File_recibofast_v1_payments_proto != nil {
+		return
+	}
+	file_recibofast_v1_incomes_proto_init()
+	file_recibofast_v1_payments_proto_msgTypes[0].OneofWrappers = []any{}
+	file_recibofast_v1_payments_proto_msgTypes[1].OneofWrappers = []any{}
+	file_recibofast_v1_payments_proto_msgTypes[5].OneofWrappers = []any{}
+	file_recibofast_v1_payments_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recibofast_v1_payments_proto_rawDesc), len(file_recibofast_v1_payments_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_recibofast_v1_payments_proto_goTypes,
+		DependencyIndexes: file_recibofast_v1_payments_proto_depIdxs,
+		MessageInfos:      file_recibofast_v1_payments_proto_msgTypes,
+	}.Build()
+	File_recibofast_v1_payments_proto = out.File
+	file_recibofast_v1_payments_proto_goTypes = nil
+	file_recibofast_v1_payments_proto_depIdxs = nil
+}
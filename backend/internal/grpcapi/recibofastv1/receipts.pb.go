@@ -0,0 +1,711 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Contrato gRPC dos recibos (espelha /api/v1/receipts)
+// Data: 18-10-2026
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: recibofast/v1/receipts.proto
+
+package recibofastv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Receipt recibo com o snapshot congelado na emissão
+type Receipt struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId         string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	IncomeId        *string                `protobuf:"bytes,3,opt,name=income_id,json=incomeId,proto3,oneof" json:"income_id,omitempty"`
+	PayerId         *string                `protobuf:"bytes,4,opt,name=payer_id,json=payerId,proto3,oneof" json:"payer_id,omitempty"`
+	Numero          int64                  `protobuf:"varint,5,opt,name=numero,proto3" json:"numero,omitempty"`
+	NumeroFormatado *string                `protobuf:"bytes,6,opt,name=numero_formatado,json=numeroFormatado,proto3,oneof" json:"numero_formatado,omitempty"`
+	EmitidoEm       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=emitido_em,json=emitidoEm,proto3" json:"emitido_em,omitempty"`
+	PdfUrl          *string                `protobuf:"bytes,8,opt,name=pdf_url,json=pdfUrl,proto3,oneof" json:"pdf_url,omitempty"`
+	Hash            *string                `protobuf:"bytes,9,opt,name=hash,proto3,oneof" json:"hash,omitempty"`
+	SignatureId     *string                `protobuf:"bytes,10,opt,name=signature_id,json=signatureId,proto3,oneof" json:"signature_id,omitempty"`
+	IssuerName      *string                `protobuf:"bytes,11,opt,name=issuer_name,json=issuerName,proto3,oneof" json:"issuer_name,omitempty"`
+	IssuerDocument  *string                `protobuf:"bytes,12,opt,name=issuer_document,json=issuerDocument,proto3,oneof" json:"issuer_document,omitempty"`
+	Valor           *string                `protobuf:"bytes,13,opt,name=valor,proto3,oneof" json:"valor,omitempty"`
+	Taxas           string                 `protobuf:"bytes,14,opt,name=taxas,proto3" json:"taxas,omitempty"`
+	Descontos       string                 `protobuf:"bytes,15,opt,name=descontos,proto3" json:"descontos,omitempty"`
+	ValorLiquido    *string                `protobuf:"bytes,16,opt,name=valor_liquido,json=valorLiquido,proto3,oneof" json:"valor_liquido,omitempty"`
+	Competencia     *string                `protobuf:"bytes,17,opt,name=competencia,proto3,oneof" json:"competencia,omitempty"`
+	Categoria       *string                `protobuf:"bytes,18,opt,name=categoria,proto3,oneof" json:"categoria,omitempty"`
+	PayerNome       *string                `protobuf:"bytes,19,opt,name=payer_nome,json=payerNome,proto3,oneof" json:"payer_nome,omitempty"`
+	PayerDocumento  *string                `protobuf:"bytes,20,opt,name=payer_documento,json=payerDocumento,proto3,oneof" json:"payer_documento,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_receipts_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Receipt) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Receipt) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Receipt) GetIncomeId() string {
+	if x != nil && x.IncomeId != nil {
+		return *x.IncomeId
+	}
+	return ""
+}
+
+func (x *Receipt) GetPayerId() string {
+	if x != nil && x.PayerId != nil {
+		return *x.PayerId
+	}
+	return ""
+}
+
+func (x *Receipt) GetNumero() int64 {
+	if x != nil {
+		return x.Numero
+	}
+	return 0
+}
+
+func (x *Receipt) GetNumeroFormatado() string {
+	if x != nil && x.NumeroFormatado != nil {
+		return *x.NumeroFormatado
+	}
+	return ""
+}
+
+func (x *Receipt) GetEmitidoEm() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EmitidoEm
+	}
+	return nil
+}
+
+func (x *Receipt) GetPdfUrl() string {
+	if x != nil && x.PdfUrl != nil {
+		return *x.PdfUrl
+	}
+	return ""
+}
+
+func (x *Receipt) GetHash() string {
+	if x != nil && x.Hash != nil {
+		return *x.Hash
+	}
+	return ""
+}
+
+func (x *Receipt) GetSignatureId() string {
+	if x != nil && x.SignatureId != nil {
+		return *x.SignatureId
+	}
+	return ""
+}
+
+func (x *Receipt) GetIssuerName() string {
+	if x != nil && x.IssuerName != nil {
+		return *x.IssuerName
+	}
+	return ""
+}
+
+func (x *Receipt) GetIssuerDocument() string {
+	if x != nil && x.IssuerDocument != nil {
+		return *x.IssuerDocument
+	}
+	return ""
+}
+
+func (x *Receipt) GetValor() string {
+	if x != nil && x.Valor != nil {
+		return *x.Valor
+	}
+	return ""
+}
+
+func (x *Receipt) GetTaxas() string {
+	if x != nil {
+		return x.Taxas
+	}
+	return ""
+}
+
+func (x *Receipt) GetDescontos() string {
+	if x != nil {
+		return x.Descontos
+	}
+	return ""
+}
+
+func (x *Receipt) GetValorLiquido() string {
+	if x != nil && x.ValorLiquido != nil {
+		return *x.ValorLiquido
+	}
+	return ""
+}
+
+func (x *Receipt) GetCompetencia() string {
+	if x != nil && x.Competencia != nil {
+		return *x.Competencia
+	}
+	return ""
+}
+
+func (x *Receipt) GetCategoria() string {
+	if x != nil && x.Categoria != nil {
+		return *x.Categoria
+	}
+	return ""
+}
+
+func (x *Receipt) GetPayerNome() string {
+	if x != nil && x.PayerNome != nil {
+		return *x.PayerNome
+	}
+	return ""
+}
+
+func (x *Receipt) GetPayerDocumento() string {
+	if x != nil && x.PayerDocumento != nil {
+		return *x.PayerDocumento
+	}
+	return ""
+}
+
+func (x *Receipt) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListReceiptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,2,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	Search        string                 `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	IncomeId      *string                `protobuf:"bytes,4,opt,name=income_id,json=incomeId,proto3,oneof" json:"income_id,omitempty"`
+	PayerId       *string                `protobuf:"bytes,5,opt,name=payer_id,json=payerId,proto3,oneof" json:"payer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReceiptsRequest) Reset() {
+	*x = ListReceiptsRequest{}
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReceiptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReceiptsRequest) ProtoMessage() {}
+
+func (x *ListReceiptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReceiptsRequest.ProtoReflect.Descriptor instead.
+func (*ListReceiptsRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_receipts_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListReceiptsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReceiptsRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *ListReceiptsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListReceiptsRequest) GetIncomeId() string {
+	if x != nil && x.IncomeId != nil {
+		return *x.IncomeId
+	}
+	return ""
+}
+
+func (x *ListReceiptsRequest) GetPayerId() string {
+	if x != nil && x.PayerId != nil {
+		return *x.PayerId
+	}
+	return ""
+}
+
+type ListReceiptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Receipt             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReceiptsResponse) Reset() {
+	*x = ListReceiptsResponse{}
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReceiptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReceiptsResponse) ProtoMessage() {}
+
+func (x *ListReceiptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReceiptsResponse.ProtoReflect.Descriptor instead.
+func (*ListReceiptsResponse) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_receipts_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListReceiptsResponse) GetItems() []*Receipt {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListReceiptsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListReceiptsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReceiptsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListReceiptsResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type GetReceiptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptRequest) Reset() {
+	*x = GetReceiptRequest{}
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptRequest) ProtoMessage() {}
+
+func (x *GetReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_receipts_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetReceiptRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateReceiptRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Numero         *int64                 `protobuf:"varint,1,opt,name=numero,proto3,oneof" json:"numero,omitempty"`
+	IncomeId       *string                `protobuf:"bytes,2,opt,name=income_id,json=incomeId,proto3,oneof" json:"income_id,omitempty"`
+	PayerId        *string                `protobuf:"bytes,3,opt,name=payer_id,json=payerId,proto3,oneof" json:"payer_id,omitempty"`
+	PdfUrl         *string                `protobuf:"bytes,4,opt,name=pdf_url,json=pdfUrl,proto3,oneof" json:"pdf_url,omitempty"`
+	Hash           *string                `protobuf:"bytes,5,opt,name=hash,proto3,oneof" json:"hash,omitempty"`
+	SignatureId    *string                `protobuf:"bytes,6,opt,name=signature_id,json=signatureId,proto3,oneof" json:"signature_id,omitempty"`
+	IssuerName     *string                `protobuf:"bytes,7,opt,name=issuer_name,json=issuerName,proto3,oneof" json:"issuer_name,omitempty"`
+	IssuerDocument *string                `protobuf:"bytes,8,opt,name=issuer_document,json=issuerDocument,proto3,oneof" json:"issuer_document,omitempty"`
+	Taxas          *string                `protobuf:"bytes,9,opt,name=taxas,proto3,oneof" json:"taxas,omitempty"`
+	Descontos      *string                `protobuf:"bytes,10,opt,name=descontos,proto3,oneof" json:"descontos,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateReceiptRequest) Reset() {
+	*x = CreateReceiptRequest{}
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReceiptRequest) ProtoMessage() {}
+
+func (x *CreateReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_receipts_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReceiptRequest.ProtoReflect.Descriptor instead.
+func (*CreateReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_receipts_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateReceiptRequest) GetNumero() int64 {
+	if x != nil && x.Numero != nil {
+		return *x.Numero
+	}
+	return 0
+}
+
+func (x *CreateReceiptRequest) GetIncomeId() string {
+	if x != nil && x.IncomeId != nil {
+		return *x.IncomeId
+	}
+	return ""
+}
+
+func (x *CreateReceiptRequest) GetPayerId() string {
+	if x != nil && x.PayerId != nil {
+		return *x.PayerId
+	}
+	return ""
+}
+
+func (x *CreateReceiptRequest) GetPdfUrl() string {
+	if x != nil && x.PdfUrl != nil {
+		return *x.PdfUrl
+	}
+	return ""
+}
+
+func (x *CreateReceiptRequest) GetHash() string {
+	if x != nil && x.Hash != nil {
+		return *x.Hash
+	}
+	return ""
+}
+
+func (x *CreateReceiptRequest) GetSignatureId() string {
+	if x != nil && x.SignatureId != nil {
+		return *x.SignatureId
+	}
+	return ""
+}
+
+func (x *CreateReceiptRequest) GetIssuerName() string {
+	if x != nil && x.IssuerName != nil {
+		return *x.IssuerName
+	}
+	return ""
+}
+
+func (x *CreateReceiptRequest) GetIssuerDocument() string {
+	if x != nil && x.IssuerDocument != nil {
+		return *x.IssuerDocument
+	}
+	return ""
+}
+
+func (x *CreateReceiptRequest) GetTaxas() string {
+	if x != nil && x.Taxas != nil {
+		return *x.Taxas
+	}
+	return ""
+}
+
+func (x *CreateReceiptRequest) GetDescontos() string {
+	if x != nil && x.Descontos != nil {
+		return *x.Descontos
+	}
+	return ""
+}
+
+var File_recibofast_v1_receipts_proto protoreflect.FileDescriptor
+
+const file_recibofast_v1_receipts_proto_rawDesc = "" +
+	"\n" +
+	"\x1crecibofast/v1/receipts.proto\x12\rrecibofast.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd3\a\n" +
+	"\aReceipt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12 \n" +
+	"\tincome_id\x18\x03 \x01(\tH\x00R\bincomeId\x88\x01\x01\x12\x1e\n" +
+	"\bpayer_id\x18\x04 \x01(\tH\x01R\apayerId\x88\x01\x01\x12\x16\n" +
+	"\x06numero\x18\x05 \x01(\x03R\x06numero\x12.\n" +
+	"\x10numero_formatado\x18\x06 \x01(\tH\x02R\x0fnumeroFormatado\x88\x01\x01\x129\n" +
+	"\n" +
+	"emitido_em\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\temitidoEm\x12\x1c\n" +
+	"\apdf_url\x18\b \x01(\tH\x03R\x06pdfUrl\x88\x01\x01\x12\x17\n" +
+	"\x04hash\x18\t \x01(\tH\x04R\x04hash\x88\x01\x01\x12&\n" +
+	"\fsignature_id\x18\n" +
+	" \x01(\tH\x05R\vsignatureId\x88\x01\x01\x12$\n" +
+	"\vissuer_name\x18\v \x01(\tH\x06R\n" +
+	"issuerName\x88\x01\x01\x12,\n" +
+	"\x0fissuer_document\x18\f \x01(\tH\aR\x0eissuerDocument\x88\x01\x01\x12\x19\n" +
+	"\x05valor\x18\r \x01(\tH\bR\x05valor\x88\x01\x01\x12\x14\n" +
+	"\x05taxas\x18\x0e \x01(\tR\x05taxas\x12\x1c\n" +
+	"\tdescontos\x18\x0f \x01(\tR\tdescontos\x12(\n" +
+	"\rvalor_liquido\x18\x10 \x01(\tH\tR\fvalorLiquido\x88\x01\x01\x12%\n" +
+	"\vcompetencia\x18\x11 \x01(\tH\n" +
+	"R\vcompetencia\x88\x01\x01\x12!\n" +
+	"\tcategoria\x18\x12 \x01(\tH\vR\tcategoria\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"payer_nome\x18\x13 \x01(\tH\fR\tpayerNome\x88\x01\x01\x12,\n" +
+	"\x0fpayer_documento\x18\x14 \x01(\tH\rR\x0epayerDocumento\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\f\n" +
+	"\n" +
+	"_income_idB\v\n" +
+	"\t_payer_idB\x13\n" +
+	"\x11_numero_formatadoB\n" +
+	"\n" +
+	"\b_pdf_urlB\a\n" +
+	"\x05_hashB\x0f\n" +
+	"\r_signature_idB\x0e\n" +
+	"\f_issuer_nameB\x12\n" +
+	"\x10_issuer_documentB\b\n" +
+	"\x06_valorB\x10\n" +
+	"\x0e_valor_liquidoB\x0e\n" +
+	"\f_competenciaB\f\n" +
+	"\n" +
+	"_categoriaB\r\n" +
+	"\v_payer_nomeB\x12\n" +
+	"\x10_payer_documento\"\xb9\x01\n" +
+	"\x13ListReceiptsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x02 \x01(\x05R\aperPage\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12 \n" +
+	"\tincome_id\x18\x04 \x01(\tH\x00R\bincomeId\x88\x01\x01\x12\x1e\n" +
+	"\bpayer_id\x18\x05 \x01(\tH\x01R\apayerId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_income_idB\v\n" +
+	"\t_payer_id\"\xa5\x01\n" +
+	"\x14ListReceiptsResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.recibofast.v1.ReceiptR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\"#\n" +
+	"\x11GetReceiptRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xee\x03\n" +
+	"\x14CreateReceiptRequest\x12\x1b\n" +
+	"\x06numero\x18\x01 \x01(\x03H\x00R\x06numero\x88\x01\x01\x12 \n" +
+	"\tincome_id\x18\x02 \x01(\tH\x01R\bincomeId\x88\x01\x01\x12\x1e\n" +
+	"\bpayer_id\x18\x03 \x01(\tH\x02R\apayerId\x88\x01\x01\x12\x1c\n" +
+	"\apdf_url\x18\x04 \x01(\tH\x03R\x06pdfUrl\x88\x01\x01\x12\x17\n" +
+	"\x04hash\x18\x05 \x01(\tH\x04R\x04hash\x88\x01\x01\x12&\n" +
+	"\fsignature_id\x18\x06 \x01(\tH\x05R\vsignatureId\x88\x01\x01\x12$\n" +
+	"\vissuer_name\x18\a \x01(\tH\x06R\n" +
+	"issuerName\x88\x01\x01\x12,\n" +
+	"\x0fissuer_document\x18\b \x01(\tH\aR\x0eissuerDocument\x88\x01\x01\x12\x19\n" +
+	"\x05taxas\x18\t \x01(\tH\bR\x05taxas\x88\x01\x01\x12!\n" +
+	"\tdescontos\x18\n" +
+	" \x01(\tH\tR\tdescontos\x88\x01\x01B\t\n" +
+	"\a_numeroB\f\n" +
+	"\n" +
+	"_income_idB\v\n" +
+	"\t_payer_idB\n" +
+	"\n" +
+	"\b_pdf_urlB\a\n" +
+	"\x05_hashB\x0f\n" +
+	"\r_signature_idB\x0e\n" +
+	"\f_issuer_nameB\x12\n" +
+	"\x10_issuer_documentB\b\n" +
+	"\x06_taxasB\f\n" +
+	"\n" +
+	"_descontos2\xd5\x02\n" +
+	"\x0eReceiptService\x12q\n" +
+	"\fListReceipts\x12\".recibofast.v1.ListReceiptsRequest\x1a#.recibofast.v1.ListReceiptsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/receipts\x12e\n" +
+	"\n" +
+	"GetReceipt\x12 .recibofast.v1.GetReceiptRequest\x1a\x16.recibofast.v1.Receipt\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/receipts/{id}\x12i\n" +
+	"\rCreateReceipt\x12#.recibofast.v1.CreateReceiptRequest\x1a\x16.recibofast.v1.Receipt\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/api/v1/receiptsB7Z5recibofast/internal/grpcapi/recibofastv1;recibofastv1b\x06proto3"
+
+var (
+	file_recibofast_v1_receipts_proto_rawDescOnce sync.Once
+	file_recibofast_v1_receipts_proto_rawDescData []byte
+)
+
+func file_recibofast_v1_receipts_proto_rawDescGZIP() []byte {
+	file_recibofast_v1_receipts_proto_rawDescOnce.Do(func() {
+		file_recibofast_v1_receipts_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_recibofast_v1_receipts_proto_rawDesc), len(file_recibofast_v1_receipts_proto_rawDesc)))
+	})
+	return file_recibofast_v1_receipts_proto_rawDescData
+}
+
+var file_recibofast_v1_receipts_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_recibofast_v1_receipts_proto_goTypes = []any{
+	(*Receipt)(nil),               // 0: recibofast.v1.Receipt
+	(*ListReceiptsRequest)(nil),   // 1: recibofast.v1.ListReceiptsRequest
+	(*ListReceiptsResponse)(nil),  // 2: recibofast.v1.ListReceiptsResponse
+	(*GetReceiptRequest)(nil),     // 3: recibofast.v1.GetReceiptRequest
+	(*CreateReceiptRequest)(nil),  // 4: recibofast.v1.CreateReceiptRequest
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_recibofast_v1_receipts_proto_depIdxs = []int32{
+	5, // 0: recibofast.v1.Receipt.emitido_em:type_name -> google.protobuf.Timestamp
+	5, // 1: recibofast.v1.Receipt.created_at:type_name -> google.protobuf.Timestamp
+	0, // 2: recibofast.v1.ListReceiptsResponse.items:type_name -> recibofast.v1.Receipt
+	1, // 3: recibofast.v1.ReceiptService.ListReceipts:input_type -> recibofast.v1.ListReceiptsRequest
+	3, // 4: recibofast.v1.ReceiptService.GetReceipt:input_type -> recibofast.v1.GetReceiptRequest
+	4, // 5: recibofast.v1.ReceiptService.CreateReceipt:input_type -> recibofast.v1.CreateReceiptRequest
+	2, // 6: recibofast.v1.ReceiptService.ListReceipts:output_type -> recibofast.v1.ListReceiptsResponse
+	0, // 7: recibofast.v1.ReceiptService.GetReceipt:output_type -> recibofast.v1.Receipt
+	0, // 8: recibofast.v1.ReceiptService.CreateReceipt:output_type -> recibofast.v1.Receipt
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_recibofast_v1_receipts_proto_init() }
+func file_recibofast_v1_receipts_proto_init() {
+	if File_recibofast_v1_receipts_proto != nil {
+		return
+	}
+	file_recibofast_v1_receipts_proto_msgTypes[0].OneofWrappers = []any{}
+	file_recibofast_v1_receipts_proto_msgTypes[1].OneofWrappers = []any{}
+	file_recibofast_v1_receipts_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recibofast_v1_receipts_proto_rawDesc), len(file_recibofast_v1_receipts_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_recibofast_v1_receipts_proto_goTypes,
+		DependencyIndexes: file_recibofast_v1_receipts_proto_depIdxs,
+		MessageInfos:      file_recibofast_v1_receipts_proto_msgTypes,
+	}.Build()
+	File_recibofast_v1_receipts_proto = out.File
+	file_recibofast_v1_receipts_proto_goTypes = nil
+	file_recibofast_v1_receipts_proto_depIdxs = nil
+}
@@ -0,0 +1,807 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Contrato gRPC da sincronização offline (espelha /api/v1/sync/changes e /api/v1/sync/push)
+// Data: 18-10-2026
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: recibofast/v1/sync.proto
+
+package recibofastv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         string                 `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"` // RFC3339
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`     // next_cursor da página anterior
+	Entities      string                 `protobuf:"bytes,4,opt,name=entities,proto3" json:"entities,omitempty"` // lista separada por vírgula (padrão: todas)
+	Fields        string                 `protobuf:"bytes,5,opt,name=fields,proto3" json:"fields,omitempty"`     // projeção de colunas (ex.: receipts.numero,receipts.valor)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangesRequest) Reset() {
+	*x = ChangesRequest{}
+	mi := &file_recibofast_v1_sync_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangesRequest) ProtoMessage() {}
+
+func (x *ChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_sync_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangesRequest.ProtoReflect.Descriptor instead.
+func (*ChangesRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_sync_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ChangesRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *ChangesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ChangesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ChangesRequest) GetEntities() string {
+	if x != nil {
+		return x.Entities
+	}
+	return ""
+}
+
+func (x *ChangesRequest) GetFields() string {
+	if x != nil {
+		return x.Fields
+	}
+	return ""
+}
+
+// Change registro alterado; data é o registro completo (ausente quando removido)
+type Change struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Deleted       bool                   `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Data          *structpb.Struct       `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_recibofast_v1_sync_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_sync_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_sync_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Change) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Change) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Change) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Change) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ChangeList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Change              `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeList) Reset() {
+	*x = ChangeList{}
+	mi := &file_recibofast_v1_sync_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeList) ProtoMessage() {}
+
+func (x *ChangeList) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_sync_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeList.ProtoReflect.Descriptor instead.
+func (*ChangeList) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_sync_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChangeList) GetItems() []*Change {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ChangesResponse alterações por entidade (models.SyncChanges)
+type ChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       map[string]*ChangeList `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangesResponse) Reset() {
+	*x = ChangesResponse{}
+	mi := &file_recibofast_v1_sync_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangesResponse) ProtoMessage() {}
+
+func (x *ChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_sync_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangesResponse.ProtoReflect.Descriptor instead.
+func (*ChangesResponse) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_sync_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChangesResponse) GetChanges() map[string]*ChangeList {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ChangesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ChangesResponse) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+// IncomeChange edição feita offline; data traz só os campos alterados
+type IncomeChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BaseVersion   *int64                 `protobuf:"varint,2,opt,name=base_version,json=baseVersion,proto3,oneof" json:"base_version,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Deleted       bool                   `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Data          *structpb.Struct       `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncomeChange) Reset() {
+	*x = IncomeChange{}
+	mi := &file_recibofast_v1_sync_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncomeChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomeChange) ProtoMessage() {}
+
+func (x *IncomeChange) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_sync_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomeChange.ProtoReflect.Descriptor instead.
+func (*IncomeChange) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_sync_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *IncomeChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IncomeChange) GetBaseVersion() int64 {
+	if x != nil && x.BaseVersion != nil {
+		return *x.BaseVersion
+	}
+	return 0
+}
+
+func (x *IncomeChange) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *IncomeChange) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *IncomeChange) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type PaymentChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncomeId      string                 `protobuf:"bytes,2,opt,name=income_id,json=incomeId,proto3" json:"income_id,omitempty"`
+	Valor         string                 `protobuf:"bytes,3,opt,name=valor,proto3" json:"valor,omitempty"`
+	PagoEm        *string                `protobuf:"bytes,4,opt,name=pago_em,json=pagoEm,proto3,oneof" json:"pago_em,omitempty"`
+	MethodId      *string                `protobuf:"bytes,5,opt,name=method_id,json=methodId,proto3,oneof" json:"method_id,omitempty"`
+	Metodo        *string                `protobuf:"bytes,6,opt,name=metodo,proto3,oneof" json:"metodo,omitempty"`
+	Obs           *string                `protobuf:"bytes,7,opt,name=obs,proto3,oneof" json:"obs,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentChange) Reset() {
+	*x = PaymentChange{}
+	mi := &file_recibofast_v1_sync_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentChange) ProtoMessage() {}
+
+func (x *PaymentChange) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_sync_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentChange.ProtoReflect.Descriptor instead.
+func (*PaymentChange) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_sync_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PaymentChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PaymentChange) GetIncomeId() string {
+	if x != nil {
+		return x.IncomeId
+	}
+	return ""
+}
+
+func (x *PaymentChange) GetValor() string {
+	if x != nil {
+		return x.Valor
+	}
+	return ""
+}
+
+func (x *PaymentChange) GetPagoEm() string {
+	if x != nil && x.PagoEm != nil {
+		return *x.PagoEm
+	}
+	return ""
+}
+
+func (x *PaymentChange) GetMethodId() string {
+	if x != nil && x.MethodId != nil {
+		return *x.MethodId
+	}
+	return ""
+}
+
+func (x *PaymentChange) GetMetodo() string {
+	if x != nil && x.Metodo != nil {
+		return *x.Metodo
+	}
+	return ""
+}
+
+func (x *PaymentChange) GetObs() string {
+	if x != nil && x.Obs != nil {
+		return *x.Obs
+	}
+	return ""
+}
+
+func (x *PaymentChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type PushRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Incomes       []*IncomeChange        `protobuf:"bytes,2,rep,name=incomes,proto3" json:"incomes,omitempty"`
+	Payments      []*PaymentChange       `protobuf:"bytes,3,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushRequest) Reset() {
+	*x = PushRequest{}
+	mi := &file_recibofast_v1_sync_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushRequest) ProtoMessage() {}
+
+func (x *PushRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_sync_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushRequest.ProtoReflect.Descriptor instead.
+func (*PushRequest) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_sync_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PushRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *PushRequest) GetIncomes() []*IncomeChange {
+	if x != nil {
+		return x.Incomes
+	}
+	return nil
+}
+
+func (x *PushRequest) GetPayments() []*PaymentChange {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+// PushResult resultado por registro (models.SyncPushResult)
+type PushResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entity        string                 `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"` // incomes ou payments
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Resolution    string                 `protobuf:"bytes,4,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	ConflictId    *string                `protobuf:"bytes,6,opt,name=conflict_id,json=conflictId,proto3,oneof" json:"conflict_id,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushResult) Reset() {
+	*x = PushResult{}
+	mi := &file_recibofast_v1_sync_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushResult) ProtoMessage() {}
+
+func (x *PushResult) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_sync_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushResult.ProtoReflect.Descriptor instead.
+func (*PushResult) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_sync_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PushResult) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *PushResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PushResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PushResult) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *PushResult) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PushResult) GetConflictId() string {
+	if x != nil && x.ConflictId != nil {
+		return *x.ConflictId
+	}
+	return ""
+}
+
+func (x *PushResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PushResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*PushResult          `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushResponse) Reset() {
+	*x = PushResponse{}
+	mi := &file_recibofast_v1_sync_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushResponse) ProtoMessage() {}
+
+func (x *PushResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recibofast_v1_sync_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushResponse.ProtoReflect.Descriptor instead.
+func (*PushResponse) Descriptor() ([]byte, []int) {
+	return file_recibofast_v1_sync_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PushResponse) GetResults() []*PushResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_recibofast_v1_sync_proto protoreflect.FileDescriptor
+
+const file_recibofast_v1_sync_proto_rawDesc = "" +
+	"\n" +
+	"\x18recibofast/v1/sync.proto\x12\rrecibofast.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x01\n" +
+	"\x0eChangesRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\tR\x05since\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1a\n" +
+	"\bentities\x18\x04 \x01(\tR\bentities\x12\x16\n" +
+	"\x06fields\x18\x05 \x01(\tR\x06fields\"\x9a\x01\n" +
+	"\x06Change\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"updated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\x12+\n" +
+	"\x04data\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x04data\"9\n" +
+	"\n" +
+	"ChangeList\x12+\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.recibofast.v1.ChangeR\x05items\"\x82\x02\n" +
+	"\x0fChangesResponse\x12E\n" +
+	"\achanges\x18\x01 \x03(\v2+.recibofast.v1.ChangesResponse.ChangesEntryR\achanges\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x1aU\n" +
+	"\fChangesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.recibofast.v1.ChangeListR\x05value:\x028\x01\"\xd9\x01\n" +
+	"\fIncomeChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\fbase_version\x18\x02 \x01(\x03H\x00R\vbaseVersion\x88\x01\x01\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\bR\adeleted\x12+\n" +
+	"\x04data\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x04dataB\x0f\n" +
+	"\r_base_version\"\xae\x02\n" +
+	"\rPaymentChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tincome_id\x18\x02 \x01(\tR\bincomeId\x12\x14\n" +
+	"\x05valor\x18\x03 \x01(\tR\x05valor\x12\x1c\n" +
+	"\apago_em\x18\x04 \x01(\tH\x00R\x06pagoEm\x88\x01\x01\x12 \n" +
+	"\tmethod_id\x18\x05 \x01(\tH\x01R\bmethodId\x88\x01\x01\x12\x1b\n" +
+	"\x06metodo\x18\x06 \x01(\tH\x02R\x06metodo\x88\x01\x01\x12\x15\n" +
+	"\x03obs\x18\a \x01(\tH\x03R\x03obs\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\n" +
+	"\n" +
+	"\b_pago_emB\f\n" +
+	"\n" +
+	"_method_idB\t\n" +
+	"\a_metodoB\x06\n" +
+	"\x04_obs\"\x9b\x01\n" +
+	"\vPushRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x125\n" +
+	"\aincomes\x18\x02 \x03(\v2\x1b.recibofast.v1.IncomeChangeR\aincomes\x128\n" +
+	"\bpayments\x18\x03 \x03(\v2\x1c.recibofast.v1.PaymentChangeR\bpayments\"\xd2\x01\n" +
+	"\n" +
+	"PushResult\x12\x16\n" +
+	"\x06entity\x18\x01 \x01(\tR\x06entity\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x04 \x01(\tR\n" +
+	"resolution\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12$\n" +
+	"\vconflict_id\x18\x06 \x01(\tH\x00R\n" +
+	"conflictId\x88\x01\x01\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05errorB\x0e\n" +
+	"\f_conflict_id\"C\n" +
+	"\fPushResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.recibofast.v1.PushResultR\aresults2\xd4\x01\n" +
+	"\vSyncService\x12f\n" +
+	"\aChanges\x12\x1d.recibofast.v1.ChangesRequest\x1a\x1e.recibofast.v1.ChangesResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/sync/changes\x12]\n" +
+	"\x04Push\x12\x1a.recibofast.v1.PushRequest\x1a\x1b.recibofast.v1.PushResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/sync/pushB7Z5recibofast/internal/grpcapi/recibofastv1;recibofastv1b\x06proto3"
+
+var (
+	file_recibofast_v1_sync_proto_rawDescOnce sync.Once
+	file_recibofast_v1_sync_proto_rawDescData []byte
+)
+
+func file_recibofast_v1_sync_proto_rawDescGZIP() []byte {
+	file_recibofast_v1_sync_proto_rawDescOnce.Do(func() {
+		file_recibofast_v1_sync_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_recibofast_v1_sync_proto_rawDesc), len(file_recibofast_v1_sync_proto_rawDesc)))
+	})
+	return file_recibofast_v1_sync_proto_rawDescData
+}
+
+var file_recibofast_v1_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_recibofast_v1_sync_proto_goTypes = []any{
+	(*ChangesRequest)(nil),        // 0: recibofast.v1.ChangesRequest
+	(*Change)(nil),                // 1: recibofast.v1.Change
+	(*ChangeList)(nil),            // 2: recibofast.v1.ChangeList
+	(*ChangesResponse)(nil),       // 3: recibofast.v1.ChangesResponse
+	(*IncomeChange)(nil),          // 4: recibofast.v1.IncomeChange
+	(*PaymentChange)(nil),         // 5: recibofast.v1.PaymentChange
+	(*PushRequest)(nil),           // 6: recibofast.v1.PushRequest
+	(*PushResult)(nil),            // 7: recibofast.v1.PushResult
+	(*PushResponse)(nil),          // 8: recibofast.v1.PushResponse
+	nil,                           // 9: recibofast.v1.ChangesResponse.ChangesEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 11: google.protobuf.Struct
+}
+var file_recibofast_v1_sync_proto_depIdxs = []int32{
+	10, // 0: recibofast.v1.Change.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 1: recibofast.v1.Change.data:type_name -> google.protobuf.Struct
+	1,  // 2: recibofast.v1.ChangeList.items:type_name -> recibofast.v1.Change
+	9,  // 3: recibofast.v1.ChangesResponse.changes:type_name -> recibofast.v1.ChangesResponse.ChangesEntry
+	10, // 4: recibofast.v1.ChangesResponse.until:type_name -> google.protobuf.Timestamp
+	10, // 5: recibofast.v1.IncomeChange.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 6: recibofast.v1.IncomeChange.data:type_name -> google.protobuf.Struct
+	10, // 7: recibofast.v1.PaymentChange.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 8: recibofast.v1.PushRequest.incomes:type_name -> recibofast.v1.IncomeChange
+	5,  // 9: recibofast.v1.PushRequest.payments:type_name -> recibofast.v1.PaymentChange
+	7,  // 10: recibofast.v1.PushResponse.results:type_name -> recibofast.v1.PushResult
+	2,  // 11: recibofast.v1.ChangesResponse.ChangesEntry.value:type_name -> recibofast.v1.ChangeList
+	0,  // 12: recibofast.v1.SyncService.Changes:input_type -> recibofast.v1.ChangesRequest
+	6,  // 13: recibofast.v1.SyncService.Push:input_type -> recibofast.v1.PushRequest
+	3,  // 14: recibofast.v1.SyncService.Changes:output_type -> recibofast.v1.ChangesResponse
+	8,  // 15: recibofast.v1.SyncService.Push:output_type -> recibofast.v1.PushResponse
+	14, // [14:16] is the sub-list for method output_type
+	12, // [12:14] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_recibofast_v1_sync_proto_init() }
+func file_recibofast_v1_sync_proto_init() {
+	if File_recibofast_v1_sync_proto != nil {
+		return
+	}
+	file_recibofast_v1_sync_proto_msgTypes[4].OneofWrappers = []any{}
+	file_recibofast_v1_sync_proto_msgTypes[5].OneofWrappers = []any{}
+	file_recibofast_v1_sync_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recibofast_v1_sync_proto_rawDesc), len(file_recibofast_v1_sync_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_recibofast_v1_sync_proto_goTypes,
+		DependencyIndexes: file_recibofast_v1_sync_proto_depIdxs,
+		MessageInfos:      file_recibofast_v1_sync_proto_msgTypes,
+	}.Build()
+	File_recibofast_v1_sync_proto = out.File
+	file_recibofast_v1_sync_proto_goTypes = nil
+	file_recibofast_v1_sync_proto_depIdxs = nil
+}
@@ -0,0 +1,111 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Serviços gRPC recibofast.v1 (receitas, pagamentos, recibos e sincronização) sobre internal/services
+// Data: 18-10-2026
+
+// Package grpcapi serve o contrato recibofast.v1 (proto/recibofast/v1) por gRPC e pelo grpc-gateway.
+// Cada RPC espelha uma rota REST: usa os mesmos serviços, a mesma autenticação (JWT, X-API-Key,
+// X-Org-ID e bloqueio de conta, via Authenticator) e os mesmos status de erro (HTTPError).
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	ctxhelper "recibofast/internal/context"
+	pb "recibofast/internal/grpcapi/recibofastv1"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/services"
+)
+
+// Authenticator autentica a chamada como a rota REST equivalente.
+// Docstring: method e path são os da rota (GET /api/v1/incomes/{id}...), usados no escopo da chave
+// de API e no papel de leitura da organização; as credenciais vêm dos metadados (authorization,
+// x-api-key, x-org-id). Devolve o contexto com o usuário e o dono dos dados, ou um HTTPError.
+type Authenticator interface {
+	Authenticate(ctx context.Context, method, path string) (context.Context, error)
+}
+
+// QuotaReserver reserva uma unidade do limite do plano (implementado por services.QuotaService)
+type QuotaReserver interface {
+	Reserve(ctx context.Context, ownerID uuid.UUID, metric string) (release func(), err error)
+}
+
+// PaymentDeleter exclui pagamentos lançados (implementado por services.PaymentReversalService)
+type PaymentDeleter interface {
+	DeletePayment(ctx context.Context, paymentID, ownerID uuid.UUID, req *models.PaymentReversalRequest) (*models.PaymentReversalResponse, error)
+}
+
+// SyncFeed feed de alterações do sincronismo (implementado por services.SyncService)
+type SyncFeed interface {
+	Snapshot(ctx context.Context, ownerID uuid.UUID, since time.Time, limit int, cursor, entities, fields string) (*services.SyncSnapshot, error)
+	FetchChanges(ctx context.Context, ownerID uuid.UUID, snap *services.SyncSnapshot) (*models.SyncChanges, error)
+}
+
+// SyncPusher aplica as alterações enviadas pelo dispositivo (implementado por services.SyncPushService)
+type SyncPusher interface {
+	Push(ctx context.Context, ownerID uuid.UUID, req *models.SyncPushRequest) (*models.SyncPushResponse, error)
+}
+
+// Deps serviços usados pelos servidores gRPC (os mesmos das rotas REST).
+// Quota nil desliga o limite do plano na emissão de recibos; Idempotency nil ignora o metadado
+// idempotency-key.
+type Deps struct {
+	Auth        Authenticator
+	Incomes     services.IncomeService
+	Payments    PaymentDeleter
+	Receipts    *services.ReceiptService
+	Quota       QuotaReserver
+	Idempotency repositories.IdempotencyRepository
+	SyncFeed    SyncFeed
+	SyncPush    SyncPusher
+	Logger      logging.Logger
+}
+
+// Register registra os serviços recibofast.v1 no servidor gRPC
+func Register(reg grpc.ServiceRegistrar, d Deps) {
+	pb.RegisterIncomeServiceServer(reg, &incomeServer{d: &d})
+	pb.RegisterPaymentServiceServer(reg, &paymentServer{d: &d})
+	pb.RegisterReceiptServiceServer(reg, &receiptServer{d: &d})
+	pb.RegisterSyncServiceServer(reg, &syncServer{d: &d})
+}
+
+// authorize autentica a chamada e devolve o contexto autenticado e o dono dos dados
+func (d *Deps) authorize(ctx context.Context, method, path string) (context.Context, uuid.UUID, error) {
+	ctx, err := d.Auth.Authenticate(ctx, method, path)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	userID, _ := ctxhelper.GetUserID(ctx)
+	ownerID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, uuid.Nil, errorf(http.StatusUnauthorized, "usuário não autenticado")
+	}
+	return ctx, ownerID, nil
+}
+
+// logError registra uma falha inesperada e devolve o erro genérico (500), como as rotas REST
+func (d *Deps) logError(msg string, err error) error {
+	d.Logger.Error(msg, logField(err))
+	return internalError()
+}
+
+func logField(err error) logging.Field {
+	return logging.Field{Key: "error", Val: err.Error()}
+}
+
+// metadataValue primeiro valor do metadado recebido (chaves em minúsculas)
+func metadataValue(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
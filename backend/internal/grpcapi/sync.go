@@ -0,0 +1,182 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: SyncService gRPC (espelha GET /api/v1/sync/changes e POST /api/v1/sync/push)
+// Data: 18-10-2026
+
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "recibofast/internal/grpcapi/recibofastv1"
+	"recibofast/internal/models"
+)
+
+const syncPath = "/api/v1/sync"
+
+type syncServer struct {
+	pb.UnimplementedSyncServiceServer
+	d *Deps
+}
+
+// Changes alterações desde since (RFC3339; vazio = sincronização completa), paginadas por cursor.
+// Docstring: limit vale por entidade (padrão 100, até 1000). Sem requisições condicionais: o
+// ETag do REST não tem equivalente aqui e a resposta vem sempre completa.
+func (s *syncServer) Changes(ctx context.Context, req *pb.ChangesRequest) (*pb.ChangesResponse, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodGet, syncPath+"/changes")
+	if err != nil {
+		return nil, err
+	}
+	var since time.Time
+	if req.GetSince() != "" {
+		if since, err = time.Parse(time.RFC3339, req.GetSince()); err != nil {
+			return nil, errorf(http.StatusBadRequest, "since inválido")
+		}
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	snap, err := s.d.SyncFeed.Snapshot(ctx, ownerID, since, limit, req.GetCursor(), req.GetEntities(), req.GetFields())
+	if errors.Is(err, models.ErrSyncCursorInvalid) || errors.Is(err, models.ErrSyncEntityInvalid) ||
+		errors.Is(err, models.ErrSyncFieldInvalid) {
+		return nil, errorf(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return nil, s.d.logError("erro ao sincronizar alterações", err)
+	}
+	res, err := s.d.SyncFeed.FetchChanges(ctx, ownerID, snap)
+	if err != nil {
+		return nil, s.d.logError("erro ao sincronizar alterações", err)
+	}
+	out, err := changesToPB(res)
+	if err != nil {
+		return nil, s.d.logError("erro ao converter alterações", err)
+	}
+	return out, nil
+}
+
+// Push aplica o lote offline; cada registro traz seu resultado, 400 só para lote inválido
+func (s *syncServer) Push(ctx context.Context, req *pb.PushRequest) (*pb.PushResponse, error) {
+	ctx, ownerID, err := s.d.authorize(ctx, http.MethodPost, syncPath+"/push")
+	if err != nil {
+		return nil, err
+	}
+	in, err := pushRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.d.SyncPush.Push(ctx, ownerID, in)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrSyncDeviceRequired), errors.Is(err, models.ErrSyncPushEmpty),
+			errors.Is(err, models.ErrSyncPushTooLarge), errors.Is(err, models.ErrSyncPushIDRequired),
+			errors.Is(err, models.ErrSyncPushDuplicateID), errors.Is(err, models.ErrSyncPushDataInvalid):
+			return nil, errorf(http.StatusBadRequest, err.Error())
+		}
+		return nil, s.d.logError("erro no envio offline", err)
+	}
+	out := &pb.PushResponse{Results: make([]*pb.PushResult, 0, len(res.Results))}
+	for _, r := range res.Results {
+		out.Results = append(out.Results, &pb.PushResult{
+			Entity:     r.Entity,
+			Id:         r.ID.String(),
+			Status:     r.Status,
+			Resolution: r.Resolution,
+			Version:    r.Version,
+			ConflictId: uuidString(r.ConflictID),
+			Error:      r.Error,
+		})
+	}
+	return out, nil
+}
+
+// changesToPB converte o feed; data (linha JSON) vira google.protobuf.Struct
+func changesToPB(res *models.SyncChanges) (*pb.ChangesResponse, error) {
+	out := &pb.ChangesResponse{
+		Changes:    make(map[string]*pb.ChangeList, len(res.Changes)),
+		NextCursor: res.NextCursor,
+		Until:      timestamp(&res.Until),
+	}
+	for entity, changes := range res.Changes {
+		list := &pb.ChangeList{Items: make([]*pb.Change, 0, len(changes))}
+		for i := range changes {
+			c := &changes[i]
+			item := &pb.Change{Id: c.ID.String(), UpdatedAt: timestamp(&c.UpdatedAt), Deleted: c.Deleted}
+			if len(c.Data) > 0 {
+				var row map[string]any
+				if err := json.Unmarshal(c.Data, &row); err != nil {
+					return nil, err
+				}
+				data, err := structpb.NewStruct(row)
+				if err != nil {
+					return nil, err
+				}
+				item.Data = data
+			}
+			list.Items = append(list.Items, item)
+		}
+		out.Changes[entity] = list
+	}
+	return out, nil
+}
+
+// pushRequest converte o lote; IDs vazios seguem para a validação do serviço (ErrSyncPushIDRequired)
+func pushRequest(req *pb.PushRequest) (*models.SyncPushRequest, error) {
+	in := &models.SyncPushRequest{
+		DeviceID: req.GetDeviceId(),
+		Incomes:  make([]models.SyncIncomeChange, 0, len(req.GetIncomes())),
+		Payments: make([]models.SyncPaymentChange, 0, len(req.GetPayments())),
+	}
+	var err error
+	for _, c := range req.GetIncomes() {
+		ch := models.SyncIncomeChange{BaseVersion: c.BaseVersion, Deleted: c.GetDeleted()}
+		if ch.ID, err = parseFieldID(c.GetId()); err != nil {
+			return nil, err
+		}
+		if c.GetUpdatedAt() != nil {
+			ch.UpdatedAt = c.GetUpdatedAt().AsTime()
+		}
+		if fields := c.GetData().GetFields(); len(fields) > 0 {
+			ch.Data = make(map[string]json.RawMessage, len(fields))
+			for name, v := range fields {
+				raw, err := protojson.Marshal(v)
+				if err != nil {
+					return nil, errorf(http.StatusBadRequest, models.ErrSyncPushDataInvalid.Error())
+				}
+				ch.Data[name] = raw
+			}
+		}
+		in.Incomes = append(in.Incomes, ch)
+	}
+	for _, p := range req.GetPayments() {
+		ch := models.SyncPaymentChange{PagoEm: p.PagoEm, Metodo: p.Metodo, Obs: p.Obs}
+		if ch.ID, err = parseFieldID(p.GetId()); err != nil {
+			return nil, err
+		}
+		if ch.IncomeID, err = parseFieldID(p.GetIncomeId()); err != nil {
+			return nil, err
+		}
+		if ch.MethodID, err = parseOptionalID(p.MethodId); err != nil {
+			return nil, err
+		}
+		if ch.Valor, err = parseMoney(p.GetValor()); err != nil {
+			return nil, err
+		}
+		if p.GetCreatedAt() != nil {
+			ch.CreatedAt = p.GetCreatedAt().AsTime()
+		}
+		in.Payments = append(in.Payments, ch)
+	}
+	if err := validateRequest(in); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
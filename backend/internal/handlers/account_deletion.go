@@ -0,0 +1,118 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers da exclusão de conta pelo titular (pedido, consulta e cancelamento na carência)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// AccountDeletionHandlers exclusão da conta do usuário autenticado (LGPD)
+type AccountDeletionHandlers struct {
+	svc *services.AccountDeletionService
+	log logging.Logger
+}
+
+// NewAccountDeletionHandlers cria uma nova instância dos handlers de exclusão de conta
+func NewAccountDeletionHandlers(svc *services.AccountDeletionService, log logging.Logger) *AccountDeletionHandlers {
+	return &AccountDeletionHandlers{svc: svc, log: log}
+}
+
+// DELETE /api/v1/account
+// Docstring: corpo {"confirm": true, "reason": "..."}; responde 202 com o pedido. A conta fica
+// bloqueada desde já e os dados são removidos em scheduled_for, salvo cancelamento.
+func (h *AccountDeletionHandlers) RequestDeletion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.AccountDeletionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	d, err := h.svc.Request(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao solicitar exclusão da conta", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(d)
+}
+
+// GET /api/v1/account/deletion
+func (h *AccountDeletionHandlers) GetDeletion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	d, err := h.svc.Status(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao consultar exclusão da conta", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(d)
+}
+
+// POST /api/v1/account/deletion/cancel
+func (h *AccountDeletionHandlers) CancelDeletion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	d, err := h.svc.Cancel(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao cancelar exclusão da conta", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(d)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *AccountDeletionHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrDeletionNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrDeletionConfirmRequired), errors.Is(err, models.ErrDeletionReasonTooLong):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrDeletionExists), errors.Is(err, models.ErrDeletionNotCancellable):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *AccountDeletionHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *AccountDeletionHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,93 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers administrativos da fusão de contas (prévia, execução e auditoria)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"recibofast/internal/apierror"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// AccountHandlers operações administrativas sobre contas de usuários
+type AccountHandlers struct {
+	svc *services.AccountMergeService
+	log logging.Logger
+}
+
+// NewAccountHandlers cria uma nova instância dos handlers de contas
+func NewAccountHandlers(svc *services.AccountMergeService, log logging.Logger) *AccountHandlers {
+	return &AccountHandlers{svc: svc, log: log}
+}
+
+// POST /api/v1/admin/accounts/merge
+// Docstring: com "dry_run": true retorna apenas a prévia; conflitos respondem 409 com a prévia.
+func (h *AccountHandlers) Merge(w http.ResponseWriter, r *http.Request) {
+	var req models.AccountMergeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.DryRun {
+		plan, err := h.svc.Preview(r.Context(), &req)
+		if err != nil {
+			h.writeServiceError(w, "erro ao calcular prévia da fusão", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(plan)
+		return
+	}
+
+	m, err := h.svc.Merge(r.Context(), &req)
+	if errors.Is(err, models.ErrMergeConflict) && m != nil {
+		apierror.WriteCode(w, http.StatusConflict, "merge_conflict", err.Error(), map[string]interface{}{"merge": m})
+		return
+	}
+	if err != nil {
+		h.writeServiceError(w, "erro na fusão de contas", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// GET /api/v1/admin/accounts/merges?limit=50
+func (h *AccountHandlers) ListMerges(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	items, err := h.svc.ListMerges(r.Context(), limit)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar fusões de contas", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *AccountHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrMergeAccountNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrMergeAccountsRequired), errors.Is(err, models.ErrMergeSameAccount),
+		errors.Is(err, models.ErrMergeReasonRequired), errors.Is(err, models.ErrMergeReasonTooLong),
+		errors.Is(err, models.ErrMergeRequesterMissing):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrMergeConflict), errors.Is(err, models.ErrMergeInProgress):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+func (h *AccountHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
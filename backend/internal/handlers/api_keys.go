@@ -0,0 +1,122 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers das chaves de API (emissão, listagem e revogação)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// APIKeyHandlers chaves de API do usuário autenticado
+type APIKeyHandlers struct {
+	svc *services.APIKeyService
+	log logging.Logger
+}
+
+// NewAPIKeyHandlers cria uma nova instância dos handlers de chaves de API
+func NewAPIKeyHandlers(svc *services.APIKeyService, log logging.Logger) *APIKeyHandlers {
+	return &APIKeyHandlers{svc: svc, log: log}
+}
+
+// POST /api/v1/api-keys
+// Docstring: corpo {"nome": "...", "scopes": ["incomes:read", "receipts:write"], "expires_at": null};
+// responde 201 com a chave em "key", exibida apenas nesta resposta.
+func (h *APIKeyHandlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.APIKeyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	k, err := h.svc.Create(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar chave de API", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(k)
+}
+
+// GET /api/v1/api-keys
+func (h *APIKeyHandlers) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar chaves de API", err)
+		return
+	}
+	writeList(w, r, items, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// DELETE /api/v1/api-keys/{id}
+func (h *APIKeyHandlers) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	if err := h.svc.Revoke(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao revogar chave de API", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *APIKeyHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrAPIKeyNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrAPIKeyNameRequired), errors.Is(err, models.ErrAPIKeyScopeInvalid),
+		errors.Is(err, models.ErrAPIKeyExpiry):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrAPIKeyLimit):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *APIKeyHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *APIKeyHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
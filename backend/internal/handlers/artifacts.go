@@ -0,0 +1,155 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers de artefatos gerados (PDFs/QRs) de recibos e modelos, e coleta administrativa
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// ArtifactHandlers expõe o envio de artefatos endereçados por conteúdo.
+// Docstring: o corpo da requisição é o próprio arquivo; Content-Type define o MIME (application/pdf, image/png).
+type ArtifactHandlers struct {
+	svc *services.ArtifactService
+	log logging.Logger
+}
+
+// NewArtifactHandlers cria uma nova instância dos handlers de artefatos
+func NewArtifactHandlers(svc *services.ArtifactService, log logging.Logger) *ArtifactHandlers {
+	return &ArtifactHandlers{svc: svc, log: log}
+}
+
+// PUT /api/v1/receipts/{id}/artifacts/{kind}
+func (h *ArtifactHandlers) PutReceiptArtifact(w http.ResponseWriter, r *http.Request) {
+	h.put(w, r, func(ref *models.ArtifactRef, id uuid.UUID) { ref.ReceiptID = &id })
+}
+
+// PUT /api/v1/income-templates/{id}/artifacts/{kind}
+func (h *ArtifactHandlers) PutTemplateArtifact(w http.ResponseWriter, r *http.Request) {
+	h.put(w, r, func(ref *models.ArtifactRef, id uuid.UUID) { ref.TemplateID = &id })
+}
+
+// DELETE /api/v1/receipts/{id}/artifacts/{kind}
+func (h *ArtifactHandlers) DeleteReceiptArtifact(w http.ResponseWriter, r *http.Request) {
+	h.delete(w, r, func(ref *models.ArtifactRef, id uuid.UUID) { ref.ReceiptID = &id })
+}
+
+// DELETE /api/v1/income-templates/{id}/artifacts/{kind}
+func (h *ArtifactHandlers) DeleteTemplateArtifact(w http.ResponseWriter, r *http.Request) {
+	h.delete(w, r, func(ref *models.ArtifactRef, id uuid.UUID) { ref.TemplateID = &id })
+}
+
+// POST /api/v1/admin/artifacts/gc
+// Docstring: executa a coleta de artefatos sem referência fora do agendamento do job de ciclo de vida.
+func (h *ArtifactHandlers) CollectGarbage(w http.ResponseWriter, r *http.Request) {
+	res, err := h.svc.CollectGarbage(r.Context())
+	if err != nil {
+		h.log.Error("erro na coleta de artefatos", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (h *ArtifactHandlers) put(w http.ResponseWriter, r *http.Request, target func(*models.ArtifactRef, uuid.UUID)) {
+	ref, ok := h.parseRef(w, r, target)
+	if !ok {
+		return
+	}
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	content, err := io.ReadAll(io.LimitReader(r.Body, models.MaxArtifactSize+1))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+		return
+	}
+	up, err := h.svc.Store(r.Context(), ref, content, contentType)
+	if err != nil {
+		h.writeServiceError(w, "erro ao armazenar artefato", err)
+		return
+	}
+	status := http.StatusCreated
+	if up.Deduplicated {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(up)
+}
+
+func (h *ArtifactHandlers) delete(w http.ResponseWriter, r *http.Request, target func(*models.ArtifactRef, uuid.UUID)) {
+	ref, ok := h.parseRef(w, r, target)
+	if !ok {
+		return
+	}
+	if err := h.svc.Detach(r.Context(), ref); err != nil {
+		h.writeServiceError(w, "erro ao remover artefato", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ArtifactHandlers) parseRef(w http.ResponseWriter, r *http.Request, target func(*models.ArtifactRef, uuid.UUID)) (*models.ArtifactRef, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return nil, false
+	}
+	ref := &models.ArtifactRef{OwnerID: userID, Kind: chi.URLParam(r, "kind")}
+	target(ref, id)
+	return ref, true
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *ArtifactHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrArtifactTargetNotFound), errors.Is(err, models.ErrArtifactNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrArtifactTooLarge):
+		h.jsonError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, models.ErrInvalidArtifactContentType):
+		h.jsonError(w, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, models.ErrInvalidArtifactKind), errors.Is(err, models.ErrArtifactEmpty):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *ArtifactHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ArtifactHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
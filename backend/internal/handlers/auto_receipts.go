@@ -0,0 +1,87 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers da opção de emissão automática de recibo ao quitar a receita
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// AutoReceiptHandlers contém os handlers do recibo automático
+type AutoReceiptHandlers struct {
+	svc *services.AutoReceiptService
+	log logging.Logger
+}
+
+// NewAutoReceiptHandlers cria uma nova instância dos handlers de recibo automático
+func NewAutoReceiptHandlers(svc *services.AutoReceiptService, log logging.Logger) *AutoReceiptHandlers {
+	return &AutoReceiptHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/settings/receipt-automation
+func (h *AutoReceiptHandlers) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	out, err := h.svc.GetSettings(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao buscar recibo automático", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// PUT /api/v1/settings/receipt-automation
+// Docstring: {"emitir_ao_quitar": true} faz o pagamento que quita uma receita emitir o recibo dela;
+// vale para os próximos pagamentos.
+func (h *AutoReceiptHandlers) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.ReceiptAutomation
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.UpdateSettings(r.Context(), userID, &req)
+	if err != nil {
+		h.log.Error("erro ao gravar recibo automático", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// Auxiliares
+func (h *AutoReceiptHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *AutoReceiptHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,129 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do envio em massa de notificações para pagadores
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// BroadcastHandlers envio em massa de mensagens personalizadas
+type BroadcastHandlers struct {
+	svc  *services.BroadcastService
+	jobs *services.JobMonitor
+	log  logging.Logger
+}
+
+// NewBroadcastHandlers cria uma nova instância dos handlers de envio em massa
+func NewBroadcastHandlers(svc *services.BroadcastService, jobs *services.JobMonitor, log logging.Logger) *BroadcastHandlers {
+	return &BroadcastHandlers{svc: svc, jobs: jobs, log: log}
+}
+
+// POST /api/v1/notifications/broadcast
+// Docstring: responde 202 com o envio na fila e dispara uma rodada do job "broadcasts" sem
+// esperar o worker; o andamento é acompanhado em GET /notifications/broadcasts/{id}.
+func (h *BroadcastHandlers) CreateBroadcast(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.BroadcastRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	b, err := h.svc.Create(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar envio em massa", err)
+		return
+	}
+	go func() {
+		_ = h.jobs.Track(context.WithoutCancel(r.Context()), "broadcasts", h.svc.ProcessPending)
+	}()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/v1/notifications/broadcasts/"+b.ID.String())
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(b)
+}
+
+// GET /api/v1/notifications/broadcasts
+func (h *BroadcastHandlers) ListBroadcasts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar envios em massa", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// GET /api/v1/notifications/broadcasts/{id}
+// Docstring: inclui o relatório de entrega (ignorados por motivo e entregas pendentes, entregues e em dead-letter).
+func (h *BroadcastHandlers) GetBroadcast(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	res, err := h.svc.Get(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar envio em massa", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *BroadcastHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrBroadcastNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrBroadcastChannelInvalid), errors.Is(err, models.ErrBroadcastTemplateInvalid),
+		errors.Is(err, models.ErrBroadcastSubjectInvalid), errors.Is(err, models.ErrBroadcastVariableInvalid):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *BroadcastHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *BroadcastHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
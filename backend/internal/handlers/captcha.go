@@ -0,0 +1,88 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do hCaptcha (sitekey pública, verificação server-side e healthcheck)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"recibofast/internal/apierror"
+	"recibofast/internal/captcha"
+	"recibofast/internal/config"
+	"recibofast/internal/logging"
+)
+
+// CaptchaHandlers rotas públicas do hCaptcha usadas pelo login e cadastro do frontend
+type CaptchaHandlers struct {
+	verifier captcha.Verifier // nil sem HCAPTCHA_SECRET
+	siteKey  string
+	log      logging.Logger
+}
+
+// NewCaptchaHandlers cria os handlers do hCaptcha a partir de HCAPTCHA_SECRET e HCAPTCHA_SITE_KEY
+func NewCaptchaHandlers(cfg *config.Config, log logging.Logger) *CaptchaHandlers {
+	verifier, _ := captcha.New(cfg)
+	return &CaptchaHandlers{
+		verifier: verifier,
+		siteKey:  strings.TrimSpace(cfg.HCaptchaSiteKey),
+		log:      log,
+	}
+}
+
+// GET /api/v1/captcha/sitekey
+// Docstring: sitekey pública (não sensível); vazia quando não configurada, para o frontend decidir o fallback.
+func (h *CaptchaHandlers) SiteKey(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"sitekey": h.siteKey})
+}
+
+// POST /api/v1/captcha/verify
+// Docstring: corpo {"token": "...", "sitekey": "..."}; repassa ao cliente a resposta do hCaptcha
+// ({"success": true|false, "challenge_ts": "...", "hostname": "...", "error-codes": [...]}).
+func (h *CaptchaHandlers) Verify(w http.ResponseWriter, r *http.Request) {
+	if h.verifier == nil {
+		// Segurança: sem secret configurado, não valida (retorna erro explícito)
+		h.jsonError(w, http.StatusInternalServerError, captcha.ErrNotConfigured.Error())
+		return
+	}
+	var payload struct {
+		Token   string `json:"token" validate:"required"`
+		SiteKey string `json:"sitekey,omitempty"`
+	}
+	if !decodeJSON(w, r, &payload) {
+		return
+	}
+
+	var remoteIP string
+	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
+		remoteIP = strings.TrimSpace(strings.Split(ip, ",")[0])
+	}
+	res, err := h.verifier.Verify(r.Context(), payload.Token, remoteIP, payload.SiteKey)
+	if errors.Is(err, captcha.ErrUnavailable) {
+		h.log.Error("erro ao verificar hcaptcha", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusBadGateway, "Falha ao contatar serviço hCaptcha")
+		return
+	}
+	if err != nil {
+		h.jsonError(w, http.StatusBadGateway, "Resposta inválida do hCaptcha")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// GET /api/v1/captcha/health
+// Docstring: informa se o servidor possui HCAPTCHA_SECRET configurado.
+func (h *CaptchaHandlers) Health(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"has_secret": h.verifier != nil})
+}
+
+func (h *CaptchaHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,66 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes dos handlers do hCaptcha
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"recibofast/internal/captcha"
+	"recibofast/internal/config"
+	"recibofast/internal/logging"
+)
+
+func TestCaptchaVerify(t *testing.T) {
+	var form map[string]string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = map[string]string{"secret": r.PostForm.Get("secret"), "response": r.PostForm.Get("response"), "remoteip": r.PostForm.Get("remoteip")}
+		w.Write([]byte(`{"success":true,"hostname":"app.recibofast.com"}`))
+	}))
+	defer upstream.Close()
+
+	h := NewCaptchaHandlers(&config.Config{HCaptchaSecret: "s3cret", HCaptchaSiteKey: "site"}, logging.NewLogger("dev"))
+	h.verifier = captcha.NewHCaptcha("s3cret", upstream.URL, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/captcha/verify", strings.NewReader(`{"token":"tok"}`))
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	rr := httptest.NewRecorder()
+	h.Verify(rr, req)
+
+	var out map[string]any
+	if err := json.NewDecoder(rr.Body).Decode(&out); err != nil || rr.Code != http.StatusOK || out["success"] != true {
+		t.Fatalf("status = %d, corpo = %v (%v)", rr.Code, out, err)
+	}
+	if form["secret"] != "s3cret" || form["response"] != "tok" || form["remoteip"] != "203.0.113.7" {
+		t.Fatalf("formulário enviado = %v", form)
+	}
+
+	rr = httptest.NewRecorder()
+	h.Verify(rr, httptest.NewRequest(http.MethodPost, "/api/v1/captcha/verify", strings.NewReader(`{}`)))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("sem token: status = %d", rr.Code)
+	}
+}
+
+func TestCaptchaWithoutSecret(t *testing.T) {
+	h := NewCaptchaHandlers(&config.Config{}, logging.NewLogger("dev"))
+
+	rr := httptest.NewRecorder()
+	h.Verify(rr, httptest.NewRequest(http.MethodPost, "/api/v1/captcha/verify", strings.NewReader(`{"token":"tok"}`)))
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	h.Health(rr, httptest.NewRequest(http.MethodGet, "/api/v1/captcha/health", nil))
+	if strings.TrimSpace(rr.Body.String()) != `{"has_secret":false}` {
+		t.Fatalf("health = %s", rr.Body.String())
+	}
+}
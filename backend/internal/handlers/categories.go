@@ -0,0 +1,179 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers para CRUD de categorias
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// CategoryHandlers contém os handlers de categorias
+type CategoryHandlers struct {
+	categoryService services.CategoryService
+	log             logging.Logger
+}
+
+// NewCategoryHandlers cria uma nova instância dos handlers de categorias
+func NewCategoryHandlers(categoryService services.CategoryService, log logging.Logger) *CategoryHandlers {
+	return &CategoryHandlers{categoryService: categoryService, log: log}
+}
+
+// GET /api/v1/categories
+// Docstring: cada item traz "uso" (receitas ativas, abertas e valor total) para o painel.
+func (h *CategoryHandlers) ListCategories(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.categoryService.ListCategories(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar categorias", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// POST /api/v1/categories
+func (h *CategoryHandlers) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.CategoryRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	c, err := h.categoryService.CreateCategory(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar categoria", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(c)
+}
+
+// GET /api/v1/categories/{id}
+func (h *CategoryHandlers) GetCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	c, err := h.categoryService.GetCategory(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar categoria", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+// PUT /api/v1/categories/{id}
+// Docstring: renomear propaga o novo nome às receitas, modelos e regras do usuário.
+func (h *CategoryHandlers) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.CategoryRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	c, err := h.categoryService.UpdateCategory(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao atualizar categoria", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+// DELETE /api/v1/categories/{id}?replace_with={id}
+// Docstring: sem replace_with, as receitas da categoria ficam sem categoria.
+func (h *CategoryHandlers) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var replaceWith *uuid.UUID
+	if v := r.URL.Query().Get("replace_with"); v != "" {
+		rid, err := uuid.Parse(v)
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, "replace_with inválido")
+			return
+		}
+		replaceWith = &rid
+	}
+	if err := h.categoryService.DeleteCategory(r.Context(), id, userID, replaceWith); err != nil {
+		h.writeServiceError(w, "erro ao remover categoria", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *CategoryHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrCategoryNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrDuplicateCategory):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrCategoryNameRequired), errors.Is(err, models.ErrCategoryNameTooLong),
+		errors.Is(err, models.ErrInvalidCategoryColor), errors.Is(err, models.ErrInvalidCategoryIcon),
+		errors.Is(err, models.ErrCategoryReplaceInvalid):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *CategoryHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *CategoryHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,167 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers de contratos (carnê de recibos e reajuste anual por índice)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// ContractHandlers contém os handlers de contratos
+type ContractHandlers struct {
+	bookService     *services.ReceiptBookService
+	readjustService *services.ContractReadjustService
+	log             logging.Logger
+}
+
+// NewContractHandlers cria uma nova instância dos handlers de contratos
+func NewContractHandlers(bookService *services.ReceiptBookService, readjustService *services.ContractReadjustService, log logging.Logger) *ContractHandlers {
+	return &ContractHandlers{bookService: bookService, readjustService: readjustService, log: log}
+}
+
+// GET /api/v1/contracts/{id}/receipt-book?year=AAAA[&format=json]
+// Docstring: gera um PDF único com uma folha por competência do ano (padrão: ano atual no fuso
+// do usuário); format=json devolve as folhas montadas, para pré-visualização.
+func (h *ContractHandlers) ReceiptBook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID do contrato inválido")
+		return
+	}
+	ls := locale.FromContext(r.Context())
+	year := ls.Now().Year()
+	if v := r.URL.Query().Get("year"); v != "" {
+		if year, err = strconv.Atoi(v); err != nil {
+			h.jsonError(w, http.StatusBadRequest, models.ErrInvalidReceiptBookYear.Error())
+			return
+		}
+	}
+
+	book, err := h.bookService.Build(r.Context(), id, userID, year)
+	if err != nil {
+		h.writeServiceError(w, "erro ao montar carnê", err)
+		return
+	}
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(book)
+		return
+	}
+
+	content := h.bookService.Render(book, ls)
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="carne-%d-%s.pdf"`, year, id.String()[:8]))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Write(content)
+}
+
+// POST /api/v1/contracts/{id}/readjust
+// Docstring: reajusta o contrato pelo IGP-M ou IPCA acumulado em 12 meses e atualiza as receitas
+// futuras ainda não pagas; dry_run devolve a simulação sem gravar.
+func (h *ContractHandlers) Readjust(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	var req models.ContractReadjustRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	rj, err := h.readjustService.Readjust(r.Context(), userID, id, &req, locale.FromContext(r.Context()))
+	if err != nil {
+		h.writeServiceError(w, "erro ao reajustar contrato", err)
+		return
+	}
+	status := http.StatusCreated
+	if rj.DryRun {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(rj)
+}
+
+// GET /api/v1/contracts/{id}/readjustments
+func (h *ContractHandlers) ListReadjustments(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	items, err := h.readjustService.List(r.Context(), userID, id)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar reajustes", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"readjustments": items})
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *ContractHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrContractNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrInvalidReceiptBookYear), errors.Is(err, models.ErrReceiptBookEmpty),
+		errors.Is(err, models.ErrReadjustIndexInvalid), errors.Is(err, models.ErrReadjustStartInvalid),
+		errors.Is(err, models.ErrReadjustPeriodInvalid):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrReadjustAlreadyApplied):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrReadjustIndexUnavailable), errors.Is(err, models.ErrReadjustContractValue):
+		h.jsonError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+func (h *ContractHandlers) userAndID(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID do contrato inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *ContractHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ContractHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
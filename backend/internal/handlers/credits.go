@@ -0,0 +1,107 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers de créditos do usuário (listagem e aplicação em receitas)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// CreditHandlers créditos gerados por pagamentos acima do saldo
+type CreditHandlers struct {
+	svc *services.CreditService
+	log logging.Logger
+}
+
+// NewCreditHandlers cria uma nova instância dos handlers de créditos
+func NewCreditHandlers(svc *services.CreditService, log logging.Logger) *CreditHandlers {
+	return &CreditHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/credits?available=true
+// Docstring: available=true lista apenas os créditos com saldo a aplicar.
+func (h *CreditHandlers) ListCredits(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.ListCredits(r.Context(), userID, r.URL.Query().Get("available") == "true")
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar créditos", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// POST /api/v1/credits/{id}/apply
+// Docstring: corpo {"income_id": "...", "valor": 10.5}; sem valor aplica o menor entre o saldo do crédito e o da receita.
+func (h *CreditHandlers) ApplyCredit(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.CreditApplyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	res, err := h.svc.ApplyCredit(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao aplicar crédito", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *CreditHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrCreditNotFound), errors.Is(err, models.ErrIncomeNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrCreditExhausted), errors.Is(err, models.ErrIncomeAlreadyPaid):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrIncomeIDRequired), errors.Is(err, models.ErrCreditAmountInvalid),
+		errors.Is(err, models.ErrCreditPayerMismatch), errors.Is(err, models.ErrInsufficientAmount):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *CreditHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *CreditHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
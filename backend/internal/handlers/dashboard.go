@@ -0,0 +1,96 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do dashboard (série temporal de previsto, recebido e vencido)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// DashboardHandlers dados agregados do dashboard
+type DashboardHandlers struct {
+	svc *services.DashboardService
+	log logging.Logger
+}
+
+// NewDashboardHandlers cria uma nova instância dos handlers do dashboard
+func NewDashboardHandlers(svc *services.DashboardService, log logging.Logger) *DashboardHandlers {
+	return &DashboardHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/dashboard/series?from=AAAA-MM-DD&to=AAAA-MM-DD&granularity=day|week|month
+// Docstring: from/to aceitam também AAAA-MM (primeiro dia do mês); sem eles, últimos 12 meses
+// (ou 12 semanas / 30 dias) até hoje. Períodos sem movimento vêm zerados.
+func (h *DashboardHandlers) Series(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	q := models.DashboardSeriesQuery{Granularity: r.URL.Query().Get("granularity")}
+	var err error
+	if q.From, err = parseSeriesDate(r.URL.Query().Get("from")); err != nil {
+		h.jsonError(w, http.StatusBadRequest, "from inválido (use AAAA-MM-DD)")
+		return
+	}
+	if q.To, err = parseSeriesDate(r.URL.Query().Get("to")); err != nil {
+		h.jsonError(w, http.StatusBadRequest, "to inválido (use AAAA-MM-DD)")
+		return
+	}
+
+	out, err := h.svc.Series(r.Context(), userID, q, locale.FromContext(r.Context()))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrDashboardGranularity), errors.Is(err, models.ErrDashboardRange):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao calcular série do dashboard", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// parseSeriesDate data AAAA-MM-DD ou AAAA-MM; vazio devolve o zero (padrão do serviço)
+func parseSeriesDate(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01", v)
+}
+
+// Auxiliares
+func (h *DashboardHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *DashboardHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,53 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Decodificação e validação dos corpos JSON das requisições
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"recibofast/internal/apierror"
+	"recibofast/internal/validation"
+)
+
+// CodeValidationFailed corpo decodificado, mas com campos que violam as tags `validate:`
+const CodeValidationFailed = "validation_failed"
+
+// decodeJSON decodifica o corpo em dst e aplica as tags `validate:` do modelo.
+// Docstring: em caso de falha já responde 400 e retorna false; falhas de validação saem com
+// code "validation_failed" e a lista de campos em details (ex.: "valor: deve ser maior que 0").
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		apierror.Write(w, http.StatusBadRequest, "dados inválidos")
+		return false
+	}
+	return validateRequest(w, dst)
+}
+
+// decodeOptionalJSON como decodeJSON, mas aceita corpo vazio (dst mantém os valores padrão)
+func decodeOptionalJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Write(w, http.StatusBadRequest, "dados inválidos")
+		return false
+	}
+	return validateRequest(w, dst)
+}
+
+func validateRequest(w http.ResponseWriter, dst interface{}) bool {
+	err := validation.Struct(dst)
+	if err == nil {
+		return true
+	}
+	var fields validation.Errors
+	if errors.As(err, &fields) {
+		apierror.WriteCode(w, http.StatusBadRequest, CodeValidationFailed, "dados inválidos", fields)
+		return false
+	}
+	apierror.Write(w, http.StatusBadRequest, "dados inválidos")
+	return false
+}
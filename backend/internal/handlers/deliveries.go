@@ -0,0 +1,153 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers administrativos da fila de entregas (dead-letter, requeue e saúde por destino)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// DeliveryAdminHandlers expõe operações administrativas sobre entregas de notificações e webhooks.
+// Docstring: rotas protegidas pelo middleware AdminAuth (token administrativo), sem escopo de usuário.
+type DeliveryAdminHandlers struct {
+	svc *services.DeliveryService
+	log logging.Logger
+}
+
+// NewDeliveryAdminHandlers cria uma nova instância dos handlers administrativos de entregas
+func NewDeliveryAdminHandlers(svc *services.DeliveryService, log logging.Logger) *DeliveryAdminHandlers {
+	return &DeliveryAdminHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/admin/deliveries/dead
+func (h *DeliveryAdminHandlers) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := &models.DeliveryFilter{
+		Channel:     strings.TrimSpace(q.Get("channel")),
+		Destination: strings.TrimSpace(q.Get("destination")),
+	}
+	if v := q.Get("owner_id"); v != "" {
+		ownerID, err := uuid.Parse(v)
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, "owner_id inválido")
+			return
+		}
+		filter.OwnerID = &ownerID
+	}
+	if filter.Channel != "" && !models.ValidDeliveryChannel(filter.Channel) {
+		h.jsonError(w, http.StatusBadRequest, models.ErrDeliveryChannelInvalid.Error())
+		return
+	}
+	if v, err := strconv.Atoi(q.Get("page")); err == nil && v > 0 {
+		filter.Page = v
+	}
+	if v, err := strconv.Atoi(q.Get("per_page")); err == nil && v > 0 {
+		filter.PerPage = v
+	}
+	if page, ok, err := pageFromCursor(r); err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if ok {
+		filter.Page = page
+	}
+
+	resp, err := h.svc.ListDeadLetters(r.Context(), filter)
+	if err != nil {
+		h.log.Error("erro ao listar dead-letters", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	writeList(w, r, resp, models.NewPage(resp.Items, resp.Total, resp.Page, resp.PerPage))
+}
+
+// POST /api/v1/admin/deliveries/{id}/requeue?reenable=true
+func (h *DeliveryAdminHandlers) RequeueDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	reenable := r.URL.Query().Get("reenable") == "true"
+
+	d, err := h.svc.Requeue(r.Context(), id, reenable)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrDeliveryNotFound):
+			h.jsonError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, models.ErrDeliveryNotDead):
+			h.jsonError(w, http.StatusConflict, err.Error())
+		default:
+			h.log.Error("erro ao reenfileirar entrega", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	h.log.Info("entrega reenfileirada", logging.Field{Key: "delivery_id", Val: id.String()}, logging.Field{Key: "reenable", Val: reenable})
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(d)
+}
+
+// GET /api/v1/admin/deliveries/destinations?disabled=true&owner_id=
+func (h *DeliveryAdminHandlers) ListDestinations(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var ownerID *uuid.UUID
+	if v := q.Get("owner_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, "owner_id inválido")
+			return
+		}
+		ownerID = &id
+	}
+	items, err := h.svc.DestinationStats(r.Context(), ownerID, q.Get("disabled") == "true")
+	if err != nil {
+		h.log.Error("erro ao listar destinos de entrega", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"destinations": items})
+}
+
+// POST /api/v1/admin/deliveries/destinations/enable
+func (h *DeliveryAdminHandlers) EnableDestination(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		OwnerID     uuid.UUID `json:"owner_id"`
+		Channel     string    `json:"channel"`
+		Destination string    `json:"destination"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.OwnerID == uuid.Nil || req.Destination == "" {
+		h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+		return
+	}
+	if err := h.svc.EnableDestination(r.Context(), req.OwnerID, req.Channel, req.Destination); err != nil {
+		switch {
+		case errors.Is(err, models.ErrDeliveryChannelInvalid):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, models.ErrDestinationNotFound):
+			h.jsonError(w, http.StatusNotFound, err.Error())
+		default:
+			h.log.Error("erro ao reabilitar destino", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *DeliveryAdminHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
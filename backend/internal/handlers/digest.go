@@ -0,0 +1,130 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do resumo semanal (preferências, prévia e descadastro)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// DigestHandlers contém os handlers do resumo semanal
+type DigestHandlers struct {
+	svc *services.DigestService
+	log logging.Logger
+}
+
+// NewDigestHandlers cria uma nova instância dos handlers do resumo semanal
+func NewDigestHandlers(svc *services.DigestService, log logging.Logger) *DigestHandlers {
+	return &DigestHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/digest/preferences
+func (h *DigestHandlers) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	settings, err := h.svc.GetPreferences(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao buscar preferências de notificação", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// PUT /api/v1/digest/preferences
+func (h *DigestHandlers) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.DigestPreferencesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	settings, err := h.svc.UpdatePreferences(r.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidDigestChannel), errors.Is(err, models.ErrInvalidWeekday),
+			errors.Is(err, models.ErrInvalidEmail):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao atualizar preferências de notificação", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// GET /api/v1/digest/preview
+func (h *DigestHandlers) Preview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	summary, err := h.svc.Preview(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao compor prévia do resumo", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
+// GET|POST /api/v1/digest/unsubscribe?token=
+// Rota pública: o token do link enviado no resumo identifica o usuário.
+func (h *DigestHandlers) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token, err := uuid.Parse(r.URL.Query().Get("token"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, models.ErrInvalidUnsubscribeToken.Error())
+		return
+	}
+	if err := h.svc.Unsubscribe(r.Context(), token); err != nil {
+		if errors.Is(err, models.ErrInvalidUnsubscribeToken) {
+			h.jsonError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.log.Error("erro ao descadastrar resumo", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"digest_enabled": false})
+}
+
+// Auxiliares
+func (h *DigestHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *DigestHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,54 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: ETags das leituras (GET condicional com If-None-Match) de entidades e listagens
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/render"
+)
+
+// entityETag ETag fraco de uma entidade a partir de id, updated_at e versão (0 quando não há).
+// Docstring: só serve para entidades sem campos derivados na leitura; o If-None-Match é conferido
+// pelo middleware Cache da rota, que responde 304 sem corpo.
+func entityETag(id uuid.UUID, updatedAt *time.Time, version int64) string {
+	h := sha256.New()
+	h.Write(id[:])
+	if updatedAt != nil {
+		h.Write([]byte(strconv.FormatInt(updatedAt.UnixNano(), 10)))
+	}
+	h.Write([]byte("|" + strconv.FormatInt(version, 10)))
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// contentETag ETag fraco do conteúdo (listagens e recursos com campos derivados, como status e
+// encargos calculados no dia). Docstring: o formato negociado (page, envelope, X-Lite, ?fields=) entra no
+// hash; o request_id do envelope não, para que a mesma página repita o ETag entre requisições.
+func contentETag(r *http.Request, v interface{}) string {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	h := sha256.New()
+	h.Write(body)
+	h.Write([]byte{'|', flag(wantsPageEnvelope(r)), flag(render.Wants(r)), flag(wantsLite(r)), '|'})
+	h.Write([]byte(strings.Join(requestedFields(r), ",")))
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+func flag(b bool) byte {
+	if b {
+		return '1'
+	}
+	return '0'
+}
@@ -0,0 +1,308 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers de despesas (CRUD, estatísticas e anexos de documentos fiscais)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+	"recibofast/internal/storage"
+)
+
+// ExpenseHandlers despesas do usuário
+type ExpenseHandlers struct {
+	svc *services.ExpenseService
+	log logging.Logger
+}
+
+// NewExpenseHandlers cria uma nova instância dos handlers de despesas
+func NewExpenseHandlers(svc *services.ExpenseService, log logging.Logger) *ExpenseHandlers {
+	return &ExpenseHandlers{svc: svc, log: log}
+}
+
+// POST /api/v1/expenses
+func (h *ExpenseHandlers) CreateExpense(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.ExpenseRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	e, err := h.svc.Create(r.Context(), userID, &req, locale.FromContext(r.Context()))
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar despesa", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(e)
+}
+
+// GET /api/v1/expenses?search=&status=&categoria=&competencia=&tag=&due_date_from=&due_date_to=&sort_field=&sort_order=&page=&per_page=
+// Docstring: status aceita pendente, pago, cancelado ou vencido (pendente com vencimento passado).
+func (h *ExpenseHandlers) ListExpenses(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	q := r.URL.Query()
+	filter := &models.ExpenseFilter{
+		Search:      strings.TrimSpace(q.Get("search")),
+		Status:      strings.TrimSpace(q.Get("status")),
+		Categoria:   strings.TrimSpace(q.Get("categoria")),
+		Competencia: strings.TrimSpace(q.Get("competencia")),
+		Tag:         strings.TrimSpace(q.Get("tag")),
+		SortField:   strings.TrimSpace(q.Get("sort_field")),
+		SortOrder:   strings.TrimSpace(q.Get("sort_order")),
+	}
+	if v, err := strconv.Atoi(q.Get("page")); err == nil && v > 0 {
+		filter.Page = v
+	}
+	if v, err := strconv.Atoi(q.Get("per_page")); err == nil && v > 0 {
+		filter.PerPage = v
+	}
+	if page, ok, err := pageFromCursor(r); err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if ok {
+		filter.Page = page
+	}
+	if v := q.Get("due_date_from"); v != "" {
+		if d, err := time.Parse(time.RFC3339, v); err == nil {
+			filter.DueDateFrom = &d
+		}
+	}
+	if v := q.Get("due_date_to"); v != "" {
+		if d, err := time.Parse(time.RFC3339, v); err == nil {
+			filter.DueDateTo = &d
+		}
+	}
+
+	response, err := h.svc.List(r.Context(), userID, filter, locale.FromContext(r.Context()))
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar despesas", err)
+		return
+	}
+	writeList(w, r, response, models.NewPage(response.Expenses, response.Total, response.Page, response.PerPage))
+}
+
+// GET /api/v1/expenses/stats?competencia=AAAA-MM
+// Docstring: sem competência usa o mês corrente no fuso do usuário.
+func (h *ExpenseHandlers) Stats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	st, err := h.svc.Stats(r.Context(), userID, strings.TrimSpace(r.URL.Query().Get("competencia")), locale.FromContext(r.Context()))
+	if err != nil {
+		h.writeServiceError(w, "erro ao calcular estatísticas de despesas", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
+
+// GET /api/v1/expenses/{id}
+func (h *ExpenseHandlers) GetExpense(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	e, err := h.svc.Get(r.Context(), id, userID, locale.FromContext(r.Context()))
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar despesa", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(e)
+}
+
+// PUT /api/v1/expenses/{id}
+func (h *ExpenseHandlers) UpdateExpense(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	var req models.ExpenseRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	e, err := h.svc.Update(r.Context(), id, userID, &req, locale.FromContext(r.Context()))
+	if err != nil {
+		h.writeServiceError(w, "erro ao atualizar despesa", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(e)
+}
+
+// DELETE /api/v1/expenses/{id}
+func (h *ExpenseHandlers) DeleteExpense(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao excluir despesa", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /api/v1/expenses/{id}/attachments
+func (h *ExpenseHandlers) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	items, err := h.svc.ListAttachments(r.Context(), userID, id)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar anexos", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// POST /api/v1/expenses/{id}/attachments?tipo=nfe (multipart, campo "file")
+// Docstring: aceita PDF, XML (NF-e/NFS-e), JPEG ou PNG até 10 MB; o tipo do arquivo é detectado
+// pelo conteúdo e, se não reconhecido, vem do cabeçalho da parte.
+func (h *ExpenseHandlers) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	part, ok := openUploadPart(w, r, "file", models.MaxExpenseAttachmentSize)
+	if !ok {
+		return
+	}
+	defer part.Close()
+
+	body := bufio.NewReaderSize(part, 512)
+	head, err := body.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		uploadReadError(w, err)
+		return
+	}
+	contentType := http.DetectContentType(head)
+	if _, _, err := models.ExpenseAttachmentType(contentType); err != nil {
+		contentType = part.Header.Get("Content-Type")
+	}
+
+	a, err := h.svc.AddAttachment(r.Context(), userID, id, &services.ExpenseAttachmentUpload{
+		Tipo:        r.URL.Query().Get("tipo"),
+		FileName:    part.FileName(),
+		ContentType: contentType,
+		Body:        body,
+	})
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			uploadReadError(w, err)
+		case storage.IsTransient(err):
+			h.log.Error("erro ao enviar anexo da despesa ao Storage", logging.Field{Key: "error", Val: err.Error()})
+			w.Header().Set("Retry-After", "30")
+			h.jsonError(w, http.StatusServiceUnavailable, "armazenamento indisponível no momento, tente novamente")
+		default:
+			h.writeServiceError(w, "erro ao anexar documento à despesa", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(a)
+}
+
+// DELETE /api/v1/expenses/{id}/attachments/{attachmentId}
+func (h *ExpenseHandlers) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	attachmentID, err := uuid.Parse(chi.URLParam(r, "attachmentId"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID do anexo inválido")
+		return
+	}
+	if err := h.svc.DeleteAttachment(r.Context(), userID, id, attachmentID); err != nil {
+		h.writeServiceError(w, "erro ao excluir anexo", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *ExpenseHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrExpenseNotFound), errors.Is(err, models.ErrExpenseAttachmentNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrExpenseAttachmentTooLarge):
+		h.jsonError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, models.ErrExpenseAttachmentContentType):
+		h.jsonError(w, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, models.ErrExpenseDescriptionRequired), errors.Is(err, models.ErrExpenseDescriptionTooLong),
+		errors.Is(err, models.ErrExpenseSupplierTooLong), errors.Is(err, models.ErrExpenseObsTooLong),
+		errors.Is(err, models.ErrExpenseStatusInvalid), errors.Is(err, models.ErrExpensePaidAtStatus),
+		errors.Is(err, models.ErrCompetenciaRequired), errors.Is(err, models.ErrReportCompetenciaInvalid),
+		errors.Is(err, models.ErrValorInvalid), errors.Is(err, models.ErrInvalidDateFormat), errors.Is(err, models.ErrInvalidStatus),
+		errors.Is(err, models.ErrExpenseAttachmentType), errors.Is(err, models.ErrExpenseAttachmentEmpty):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *ExpenseHandlers) userAndID(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *ExpenseHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ExpenseHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,94 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Projeção de campos nas listagens (?fields=id,valor,status) para reduzir o payload
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// fieldsParam parâmetro de query com os campos JSON desejados em cada item das listagens
+const fieldsParam = "fields"
+
+// requestedFields campos pedidos em ?fields=, ordenados e sem repetição; nil quando ausente.
+// Docstring: "id" entra sempre, para o cliente conciliar os itens com o cache local.
+func requestedFields(r *http.Request) []string {
+	raw := r.URL.Query().Get(fieldsParam)
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	seen := map[string]bool{"id": true}
+	fields := []string{"id"}
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		fields = append(fields, f)
+	}
+	sort.Strings(fields[1:])
+	return fields
+}
+
+// projectFields reduz os itens de uma listagem aos campos pedidos.
+// Docstring: aceita a lista de itens ou o corpo inteiro (formato legado, Page[T]); num objeto, só as
+// listas de objetos de primeiro nível são projetadas e os demais campos (total, página...) seguem.
+// Campos desconhecidos são ignorados; se a serialização falhar o valor segue inteiro.
+func projectFields(v interface{}, fields []string) interface{} {
+	body, err := json.Marshal(v)
+	if err != nil || bytes.Equal(body, []byte("null")) {
+		return v
+	}
+	keep := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		keep[f] = true
+	}
+	if list, ok := objectList(body); ok {
+		return projectList(list, keep)
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return v
+	}
+	out := make(map[string]interface{}, len(obj))
+	for k, raw := range obj {
+		if list, ok := objectList(raw); ok {
+			out[k] = projectList(list, keep)
+			continue
+		}
+		out[k] = raw
+	}
+	return out
+}
+
+// objectList decodifica uma lista JSON cujos elementos são objetos
+func objectList(raw json.RawMessage) ([]map[string]json.RawMessage, bool) {
+	if t := bytes.TrimSpace(raw); len(t) == 0 || t[0] != '[' {
+		return nil, false
+	}
+	var list []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, false
+	}
+	return list, true
+}
+
+func projectList(list []map[string]json.RawMessage, keep map[string]bool) []map[string]json.RawMessage {
+	out := make([]map[string]json.RawMessage, len(list))
+	for i, item := range list {
+		out[i] = make(map[string]json.RawMessage, len(keep))
+		for k, raw := range item {
+			if keep[k] {
+				out[i][k] = raw
+			}
+		}
+	}
+	return out
+}
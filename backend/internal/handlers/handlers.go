@@ -15,9 +15,11 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"recibofast/internal/apierror"
 	"recibofast/internal/config"
 	ctxhelper "recibofast/internal/context"
 	"recibofast/internal/logging"
+	"recibofast/internal/repositories"
 	"recibofast/internal/services"
 )
 
@@ -39,14 +41,12 @@ func NewHandlers(d Deps) *Handlers {
 		log:     d.Logger,
 		DB:      d.DB,
 		Cfg:     d.Cfg,
-		SyncSvc: services.NewSyncService(d.DB),
+		SyncSvc: services.NewSyncService(repositories.NewSyncRepository(d.DB)),
 	}
 }
 
 func (h *Handlers) jsonError(w http.ResponseWriter, code int, msg string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	_ = jsonEncode(w, map[string]string{"error": msg})
+	apierror.Write(w, code, msg)
 }
 
 // AuthUser obtém o user_id do contexto (middleware de auth)
@@ -6,11 +6,40 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
+
+	"recibofast/internal/logging"
+	"recibofast/internal/services"
 )
 
-// Health responde com 200 para verificações simples de vida.
+// Health responde com 200 para verificações simples de vida (liveness: não consulta dependências).
 func (h *Handlers) Health(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte("ok"))
 }
+
+// ReadinessHandlers readiness probe com o estado de cada dependência
+type ReadinessHandlers struct {
+	svc *services.ReadinessService
+	log logging.Logger
+}
+
+// NewReadinessHandlers cria uma nova instância dos handlers de readiness
+func NewReadinessHandlers(svc *services.ReadinessService, log logging.Logger) *ReadinessHandlers {
+	return &ReadinessHandlers{svc: svc, log: log}
+}
+
+// GET /readyz
+// Docstring: responde 200 com {"ready": true, "dependencies": [...]} ou 503 quando alguma
+// dependência (banco, Storage, JWKS, jobs) está fora, para probes do Kubernetes/Cloud Run.
+func (h *ReadinessHandlers) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.svc.Check(r.Context())
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -6,6 +6,7 @@
 package handlers
 
 import (
+    "context"
     "encoding/json"
     "io"
     "net/http"
@@ -13,18 +14,42 @@ import (
     "testing"
     "time"
 
+    "github.com/google/uuid"
     "recibofast/internal/config"
     ctxhelper "recibofast/internal/context"
     "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/services"
 )
 
 type healthSyncDeps struct{}
 
+// emptySyncRepo feed de sincronização sem alterações (os testes rodam sem banco)
+type emptySyncRepo struct{}
+
+func (emptySyncRepo) State(ctx context.Context, ownerID uuid.UUID, entity string, until time.Time) (models.SyncEntityState, error) {
+    return models.SyncEntityState{}, nil
+}
+func (emptySyncRepo) Changes(ctx context.Context, ownerID uuid.UUID, entity string, since, until time.Time, after *models.SyncPosition, columns []string, limit int) ([]models.SyncChange, error) {
+    return []models.SyncChange{}, nil
+}
+
+// modifiedSyncRepo uma receita alterada em at (só o estado; sem linhas a listar)
+type modifiedSyncRepo struct{ emptySyncRepo; at time.Time }
+
+func (f modifiedSyncRepo) State(ctx context.Context, ownerID uuid.UUID, entity string, until time.Time) (models.SyncEntityState, error) {
+    if entity != models.SyncFeedIncomes { return models.SyncEntityState{}, nil }
+    at := f.at
+    return models.SyncEntityState{Count: 1, LastModified: &at}, nil
+}
+
 func newHandlersForTest(t *testing.T) *Handlers {
     t.Helper()
     logger := logging.NewLogger("dev")
     cfg := config.FromEnv()
-    return NewHandlers(Deps{Logger: logger, DB: nil, Cfg: cfg})
+    h := NewHandlers(Deps{Logger: logger, DB: nil, Cfg: cfg})
+    h.SyncSvc = services.NewSyncService(emptySyncRepo{})
+    return h
 }
 
 func TestHealthOK(t *testing.T) {
@@ -115,3 +140,37 @@ func TestSyncChanges_OKPayload(t *testing.T) {
         t.Fatalf("payload changes ausente")
     }
 }
+
+func TestSyncChanges_LastModifiedAndIfModifiedSince(t *testing.T) {
+    h := newHandlersForTest(t)
+    at := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+    h.SyncSvc = services.NewSyncService(modifiedSyncRepo{at: at})
+
+    get := func(header, value string) *httptest.ResponseRecorder {
+        req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/changes", nil)
+        req = req.WithContext(ctxhelper.SetUserID(req.Context(), "00000000-0000-0000-0000-000000000001"))
+        if header != "" { req.Header.Set(header, value) }
+        rr := httptest.NewRecorder()
+        h.SyncChanges(rr, req)
+        return rr
+    }
+
+    rr := get("", "")
+    if rr.Code != http.StatusOK || rr.Header().Get("Last-Modified") != at.Format(http.TimeFormat) {
+        t.Fatalf("status = %d, Last-Modified = %q", rr.Code, rr.Header().Get("Last-Modified"))
+    }
+    if rr := get("If-Modified-Since", at.Format(http.TimeFormat)); rr.Code != http.StatusNotModified {
+        t.Fatalf("If-Modified-Since igual: status = %d, want 304", rr.Code)
+    }
+    if rr := get("If-Modified-Since", at.Add(-time.Minute).Format(http.TimeFormat)); rr.Code != http.StatusOK {
+        t.Fatalf("If-Modified-Since anterior: status = %d, want 200", rr.Code)
+    }
+    // If-None-Match tem precedência: ETag diferente devolve o corpo mesmo sem alteração por data
+    req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/changes", nil)
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), "00000000-0000-0000-0000-000000000001"))
+    req.Header.Set("If-None-Match", `"outro"`)
+    req.Header.Set("If-Modified-Since", at.Format(http.TimeFormat))
+    rr = httptest.NewRecorder()
+    h.SyncChanges(rr, req)
+    if rr.Code != http.StatusOK { t.Fatalf("If-None-Match diferente: status = %d, want 200", rr.Code) }
+}
@@ -0,0 +1,168 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do calendário de feriados e da opção de vencimento em dia útil
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// HolidayHandlers feriados do usuário e opção de dia útil
+type HolidayHandlers struct {
+	svc *services.HolidayService
+	log logging.Logger
+}
+
+// NewHolidayHandlers cria uma nova instância dos handlers de feriados
+func NewHolidayHandlers(svc *services.HolidayService, log logging.Logger) *HolidayHandlers {
+	return &HolidayHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/holidays?year=2026
+// Docstring: feriados nacionais e cadastrados do ano (padrão: ano corrente no fuso do usuário).
+func (h *HolidayHandlers) ListHolidays(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	year := locale.FromContext(r.Context()).Now().Year()
+	if v := r.URL.Query().Get("year"); v != "" {
+		var err error
+		if year, err = strconv.Atoi(v); err != nil {
+			h.jsonError(w, http.StatusBadRequest, models.ErrHolidayYearInvalid.Error())
+			return
+		}
+	}
+	items, err := h.svc.Calendar(r.Context(), userID, year)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar feriados", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"year": year, "holidays": items})
+}
+
+// POST /api/v1/holidays
+// Docstring: {"data": "2026-01-25", "nome": "Aniversário de São Paulo", "anual": true}
+func (h *HolidayHandlers) CreateHoliday(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.HolidayRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.Create(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao cadastrar feriado", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(out)
+}
+
+// DELETE /api/v1/holidays/{id}
+func (h *HolidayHandlers) DeleteHoliday(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao remover feriado", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /api/v1/settings/business-days
+func (h *HolidayHandlers) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	out, err := h.svc.GetSettings(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar opção de dia útil", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// PUT /api/v1/settings/business-days
+// Docstring: {"ajustar_dia_util": true} leva ao próximo dia útil os vencimentos das receitas geradas
+// por modelos que caem em fim de semana ou feriado; vale para as próximas receitas.
+func (h *HolidayHandlers) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.BusinessDaySettings
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.UpdateSettings(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao gravar opção de dia útil", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+func (h *HolidayHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrHolidayNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrHolidayNameRequired), errors.Is(err, models.ErrHolidayNameTooLong),
+		errors.Is(err, models.ErrHolidayDateRequired), errors.Is(err, models.ErrHolidayYearInvalid):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *HolidayHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *HolidayHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,209 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers dos modelos de receita (CRUD e geração de receitas)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// IncomeTemplateHandlers contém os handlers de modelos de receita
+type IncomeTemplateHandlers struct {
+	templateService services.IncomeTemplateService
+	log             logging.Logger
+}
+
+// NewIncomeTemplateHandlers cria uma nova instância dos handlers de modelos
+func NewIncomeTemplateHandlers(templateService services.IncomeTemplateService, log logging.Logger) *IncomeTemplateHandlers {
+	return &IncomeTemplateHandlers{templateService: templateService, log: log}
+}
+
+// GET /api/v1/income-templates
+func (h *IncomeTemplateHandlers) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.templateService.ListTemplates(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar modelos", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// POST /api/v1/income-templates
+// Docstring: com from_income_id, salva a receita informada como modelo (nome opcional).
+func (h *IncomeTemplateHandlers) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req struct {
+		models.IncomeTemplateRequest
+		FromIncomeID *uuid.UUID `json:"from_income_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+		return
+	}
+	// A partir de uma receita só o nome é usado; os demais campos vêm dela
+	if req.FromIncomeID == nil && !validateRequest(w, &req.IncomeTemplateRequest) {
+		return
+	}
+	var (
+		t   *models.IncomeTemplate
+		err error
+	)
+	if req.FromIncomeID != nil {
+		t, err = h.templateService.CreateTemplateFromIncome(r.Context(), *req.FromIncomeID, userID, req.Nome)
+	} else {
+		t, err = h.templateService.CreateTemplate(r.Context(), userID, &req.IncomeTemplateRequest)
+	}
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar modelo", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(t)
+}
+
+// GET /api/v1/income-templates/{id}
+func (h *IncomeTemplateHandlers) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	t, err := h.templateService.GetTemplate(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar modelo", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t)
+}
+
+// PUT /api/v1/income-templates/{id}
+func (h *IncomeTemplateHandlers) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.IncomeTemplateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	t, err := h.templateService.UpdateTemplate(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao atualizar modelo", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t)
+}
+
+// DELETE /api/v1/income-templates/{id}
+func (h *IncomeTemplateHandlers) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	if err := h.templateService.DeleteTemplate(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao remover modelo", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /api/v1/income-templates/{id}/incomes
+// Docstring: gera a receita da competência informada a partir do modelo.
+func (h *IncomeTemplateHandlers) Instantiate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.IncomeCopyRequest
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	income, err := h.templateService.Instantiate(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao gerar receita do modelo", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(income)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *IncomeTemplateHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrTemplateNotFound), errors.Is(err, models.ErrIncomeNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrTemplateNameRequired), errors.Is(err, models.ErrInvalidDueDay),
+		errors.Is(err, models.ErrValorInvalid), errors.Is(err, models.ErrCompetenciaRequired),
+		errors.Is(err, models.ErrInvalidDateFormat), errors.Is(err, models.ErrPayerNotFound):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *IncomeTemplateHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *IncomeTemplateHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,182 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers das NFS-e (geração a partir da receita paga, consulta, download do XML) e dos dados do prestador
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// InvoiceHandlers notas fiscais de serviço das receitas
+type InvoiceHandlers struct {
+	svc *services.InvoiceService
+	log logging.Logger
+}
+
+// NewInvoiceHandlers cria uma nova instância dos handlers de NFS-e
+func NewInvoiceHandlers(svc *services.InvoiceService, log logging.Logger) *InvoiceHandlers {
+	return &InvoiceHandlers{svc: svc, log: log}
+}
+
+// POST /api/v1/incomes/{id}/invoice
+// Docstring: gera o RPS da receita paga; responde 201 na criação e 200 se a receita já tinha nota.
+func (h *InvoiceHandlers) GenerateInvoice(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	out, created, err := h.svc.Generate(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao gerar nota fiscal", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(out)
+}
+
+// GET /api/v1/invoices
+func (h *InvoiceHandlers) ListInvoices(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar notas fiscais", err)
+		return
+	}
+	writeList(w, r, items, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// GET /api/v1/invoices/{id}
+func (h *InvoiceHandlers) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.Get(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar nota fiscal", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// GET /api/v1/invoices/{id}/xml
+// Docstring: XML ABRASF do RPS como anexo, para importação no portal da prefeitura.
+func (h *InvoiceHandlers) DownloadXML(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	inv, err := h.svc.Get(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar nota fiscal", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="nfse-rps-%s-%d.xml"`, inv.RPSSerie, inv.RPSNumero))
+	w.Write([]byte(inv.XML))
+}
+
+// GET /api/v1/settings/invoice
+func (h *InvoiceHandlers) GetIssuer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	out, err := h.svc.GetIssuer(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar dados do prestador", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// PUT /api/v1/settings/invoice
+// Docstring: substitui os dados do prestador; proximo_rps omitido mantém a numeração atual.
+func (h *InvoiceHandlers) UpdateIssuer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.InvoiceIssuer
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.UpdateIssuer(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao gravar dados do prestador", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *InvoiceHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrIncomeNotFound), errors.Is(err, models.ErrInvoiceNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrInvoiceIssuerInvalid):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrInvoiceIncomeNotPaid), errors.Is(err, models.ErrInvoiceIssuerIncomplete):
+		h.jsonError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *InvoiceHandlers) userAndID(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *InvoiceHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *InvoiceHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,39 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do painel de jobs em segundo plano e do readiness check
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"recibofast/internal/logging"
+	"recibofast/internal/services"
+)
+
+// JobHandlers expõe o estado dos workers e das filas.
+// Docstring: o painel completo fica em rota administrativa; /readyz usa apenas o veredito (Overview.Healthy).
+type JobHandlers struct {
+	monitor *services.JobMonitor
+	log     logging.Logger
+}
+
+// NewJobHandlers cria uma nova instância dos handlers de jobs
+func NewJobHandlers(monitor *services.JobMonitor, log logging.Logger) *JobHandlers {
+	return &JobHandlers{monitor: monitor, log: log}
+}
+
+// GET /api/v1/admin/jobs/overview
+// Docstring: responde 503 quando algum job ou fila está não saudável, para uso direto em probes.
+func (h *JobHandlers) Overview(w http.ResponseWriter, r *http.Request) {
+	ov := h.monitor.Overview(r.Context())
+	status := http.StatusOK
+	if !ov.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ov)
+}
@@ -0,0 +1,92 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers das regras de multa e juros por atraso do usuário
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// LateFeeHandlers contém os handlers das regras de encargos por atraso
+type LateFeeHandlers struct {
+	svc *services.LateFeeService
+	log logging.Logger
+}
+
+// NewLateFeeHandlers cria uma nova instância dos handlers de encargos
+func NewLateFeeHandlers(svc *services.LateFeeService, log logging.Logger) *LateFeeHandlers {
+	return &LateFeeHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/settings/late-fees
+func (h *LateFeeHandlers) GetRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	out, err := h.svc.GetRules(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao buscar regras de encargos", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// PUT /api/v1/settings/late-fees
+// Docstring: substitui as regras do usuário; campos nulos ou omitidos voltam ao padrão do produto.
+func (h *LateFeeHandlers) UpdateRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.FeeRules
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.UpdateRules(r.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrFeePercentInvalid), errors.Is(err, models.ErrGraceDaysInvalid):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao gravar regras de encargos", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// Auxiliares
+func (h *LateFeeHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *LateFeeHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
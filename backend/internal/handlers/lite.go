@@ -0,0 +1,95 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modo de baixa banda (X-Lite) das listagens: aplica as projeções reduzidas das entidades
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"recibofast/internal/models"
+)
+
+// wantsLite indica se o cliente pediu respostas reduzidas ("X-Lite: true"), usado pelo PWA em 3G
+func wantsLite(r *http.Request) bool {
+	v := strings.TrimSpace(r.Header.Get(models.LiteHeader))
+	return strings.EqualFold(v, "true") || v == "1"
+}
+
+// litePage troca os itens do envelope pelas projeções reduzidas
+func litePage[T any](page models.Page[T]) models.Page[interface{}] {
+	return models.Page[interface{}]{
+		Items:      models.LiteItems(page.Items),
+		Total:      page.Total,
+		Page:       page.Page,
+		PerPage:    page.PerPage,
+		TotalPages: page.TotalPages,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// liteLegacy aplica as projeções ao formato legado de cada listagem.
+// Docstring: o formato legado varia por endpoint (mapa {"items": ...} ou structs como
+// IncomeResponse); listas de entidades com projeção são reduzidas em qualquer campo de primeiro
+// nível e os demais campos (total, página...) seguem como estão.
+func liteLegacy(legacy interface{}) interface{} {
+	v := reflect.ValueOf(legacy)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return legacy
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return legacy
+		}
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[iter.Key().String()] = liteValue(iter.Value())
+		}
+		return out
+	case reflect.Struct:
+		out := map[string]interface{}{}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			if strings.Contains(opts, "omitempty") && v.Field(i).IsZero() {
+				continue
+			}
+			out[name] = liteValue(v.Field(i))
+		}
+		return out
+	}
+	return legacy
+}
+
+// liteValue reduz uma lista de entidades com projeção; outros valores seguem inteiros
+func liteValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice || !v.Type().Elem().Implements(reflect.TypeOf((*models.LiteProjector)(nil)).Elem()) {
+		return v.Interface()
+	}
+	out := make([]interface{}, v.Len())
+	for i := range out {
+		out[i] = v.Index(i).Interface().(models.LiteProjector).Lite()
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers das preferências de notificação por evento e canal
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// NotificationSettingsHandlers contém os handlers de preferências de notificação
+type NotificationSettingsHandlers struct {
+	dispatcher *services.NotificationDispatcher
+	log        logging.Logger
+}
+
+// NewNotificationSettingsHandlers cria uma nova instância dos handlers de preferências
+func NewNotificationSettingsHandlers(dispatcher *services.NotificationDispatcher, log logging.Logger) *NotificationSettingsHandlers {
+	return &NotificationSettingsHandlers{dispatcher: dispatcher, log: log}
+}
+
+// GET /api/v1/settings/notifications
+func (h *NotificationSettingsHandlers) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	settings, err := h.dispatcher.GetSettings(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao buscar preferências de notificação", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// PUT /api/v1/settings/notifications
+func (h *NotificationSettingsHandlers) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.NotificationSettingsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	settings, err := h.dispatcher.UpdateSettings(r.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidEventType), errors.Is(err, models.ErrInvalidNotificationChannel),
+			errors.Is(err, models.ErrInvalidNotificationMode), errors.Is(err, models.ErrInvalidPhone),
+			errors.Is(err, models.ErrInvalidDigestChannel), errors.Is(err, models.ErrInvalidWeekday),
+			errors.Is(err, models.ErrInvalidEmail):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao atualizar preferências de notificação", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// Auxiliares
+func (h *NotificationSettingsHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *NotificationSettingsHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
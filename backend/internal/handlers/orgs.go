@@ -0,0 +1,277 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers das organizações (espaço compartilhado, membros e convites)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// OrgHandlers organizações do usuário autenticado
+type OrgHandlers struct {
+	svc *services.OrgService
+	log logging.Logger
+}
+
+// NewOrgHandlers cria uma nova instância dos handlers de organizações
+func NewOrgHandlers(svc *services.OrgService, log logging.Logger) *OrgHandlers {
+	return &OrgHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/orgs
+func (h *OrgHandlers) ListOrgs(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar organizações", err)
+		return
+	}
+	writeList(w, r, items, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// POST /api/v1/orgs
+// Docstring: compartilha o espaço de dados do usuário; cada usuário tem no máximo uma organização.
+func (h *OrgHandlers) CreateOrg(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.OrgRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	org, err := h.svc.Create(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar organização", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(org)
+}
+
+// GET /api/v1/orgs/{id}
+func (h *OrgHandlers) GetOrg(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	org, err := h.svc.Get(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar organização", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(org)
+}
+
+// PATCH /api/v1/orgs/{id}
+func (h *OrgHandlers) RenameOrg(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	var req models.OrgRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	org, err := h.svc.Rename(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao renomear organização", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(org)
+}
+
+// POST /api/v1/orgs/{id}/invitations
+// Docstring: o token do convite só aparece nesta resposta; quem convida repassa o link ao convidado.
+func (h *OrgHandlers) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	var req models.OrgInvitationRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	inv, err := h.svc.Invite(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar convite", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(inv)
+}
+
+// GET /api/v1/orgs/{id}/invitations
+func (h *OrgHandlers) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	items, err := h.svc.ListInvitations(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar convites", err)
+		return
+	}
+	writeList(w, r, items, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// DELETE /api/v1/orgs/{id}/invitations/{invitationId}
+func (h *OrgHandlers) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	invitationID, err := uuid.Parse(chi.URLParam(r, "invitationId"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID do convite inválido")
+		return
+	}
+	if err := h.svc.RevokeInvitation(r.Context(), id, userID, invitationID); err != nil {
+		h.writeServiceError(w, "erro ao revogar convite", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /api/v1/orgs/invitations/accept
+// Docstring: corpo {"token": "..."}; o e-mail da conta autenticada precisa ser o convidado.
+func (h *OrgHandlers) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.OrgAcceptRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	m, err := h.svc.Accept(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao aceitar convite", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// PATCH /api/v1/orgs/{id}/members/{userId}
+func (h *OrgHandlers) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	memberID, ok := h.memberID(w, r)
+	if !ok {
+		return
+	}
+	var req models.OrgMemberUpdate
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	m, err := h.svc.UpdateMember(r.Context(), id, userID, memberID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao alterar membro", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// DELETE /api/v1/orgs/{id}/members/{userId}
+// Docstring: o dono remove qualquer membro; os demais só podem remover a si mesmos (sair).
+func (h *OrgHandlers) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	memberID, ok := h.memberID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.RemoveMember(r.Context(), id, userID, memberID); err != nil {
+		h.writeServiceError(w, "erro ao remover membro", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *OrgHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrOrgNotFound), errors.Is(err, models.ErrOrgMemberNotFound),
+		errors.Is(err, models.ErrOrgInvitationNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrOrgNameRequired), errors.Is(err, models.ErrOrgNameTooLong),
+		errors.Is(err, models.ErrOrgRoleInvalid), errors.Is(err, models.ErrOrgEmailInvalid):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrOrgForbidden), errors.Is(err, models.ErrOrgInvitationEmail):
+		h.jsonError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, models.ErrOrgExists), errors.Is(err, models.ErrOrgAlreadyMember), errors.Is(err, models.ErrOrgOwnerImmutable):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *OrgHandlers) userAndID(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *OrgHandlers) memberID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID do membro inválido")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *OrgHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *OrgHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,89 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Auxiliares de resposta das listagens (formato legado x envelope Page[T])
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/models"
+	"recibofast/internal/render"
+)
+
+// wantsPageEnvelope indica se o cliente pediu o envelope Page[T]: rotas /api/v2
+// (marcadas no contexto) ou cabeçalho "Accept-Profile: page" nas rotas v1.
+func wantsPageEnvelope(r *http.Request) bool {
+	if ctxhelper.WantsPageEnvelope(r.Context()) {
+		return true
+	}
+	for _, p := range strings.Split(r.Header.Get("Accept-Profile"), ",") {
+		if strings.EqualFold(strings.TrimSpace(p), "page") {
+			return true
+		}
+	}
+	return false
+}
+
+// pageFromCursor lê ?cursor= e retorna a página correspondente (ok=false se ausente)
+func pageFromCursor(r *http.Request) (int, bool, error) {
+	c := r.URL.Query().Get("cursor")
+	if c == "" {
+		return 0, false, nil
+	}
+	page, err := models.DecodePageCursor(c)
+	if err != nil {
+		return 0, false, err
+	}
+	return page, true, nil
+}
+
+// writeList responde no envelope Page[T] quando solicitado, senão no formato legado do endpoint.
+// Docstring: com "X-Lite: true" os itens saem nas projeções reduzidas (models.LiteProjector) e com
+// ?fields= só com os campos pedidos (aplicado depois do X-Lite); "Accept-Profile: envelope" tem
+// precedência e responde no envelope de render (meta e links).
+// O ETag vem do conteúdo da página (itens, total e página), não do corpo serializado.
+func writeList[T any](w http.ResponseWriter, r *http.Request, legacy interface{}, page models.Page[T]) {
+	// ETag do conteúdo da página: nas rotas com Cache, If-None-Match igual responde 304
+	if etag := contentETag(r, page); etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	fields := requestedFields(r)
+	if render.Wants(r) {
+		var items interface{} = page.Items
+		if wantsLite(r) {
+			w.Header().Set(models.LiteHeader, "true")
+			items = models.LiteItems(page.Items)
+		}
+		if fields != nil {
+			items = projectFields(items, fields)
+		}
+		render.List(w, r, items, render.Meta{Total: page.Total, Page: page.Page, PerPage: page.PerPage,
+			TotalPages: page.TotalPages, NextCursor: page.NextCursor})
+		return
+	}
+	var body interface{} = legacy
+	switch lite := wantsLite(r); {
+	case lite && wantsPageEnvelope(r):
+		body = litePage(page)
+	case lite:
+		body = liteLegacy(legacy)
+	case wantsPageEnvelope(r):
+		body = page
+	}
+	if fields != nil {
+		body = projectFields(body, fields)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if wantsLite(r) {
+		w.Header().Set(models.LiteHeader, "true")
+	}
+	if wantsPageEnvelope(r) {
+		w.Header().Set("Content-Profile", "page")
+	}
+	json.NewEncoder(w).Encode(body)
+}
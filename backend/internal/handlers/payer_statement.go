@@ -0,0 +1,113 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handler do extrato do pagador (JSON ou PDF)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// PayerStatementHandlers extrato dos pagadores
+type PayerStatementHandlers struct {
+	svc *services.PayerStatementService
+	log logging.Logger
+}
+
+// NewPayerStatementHandlers cria uma nova instância dos handlers do extrato
+func NewPayerStatementHandlers(svc *services.PayerStatementService, log logging.Logger) *PayerStatementHandlers {
+	return &PayerStatementHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/payers/{id}/statement?from=AAAA-MM-DD&to=AAAA-MM-DD[&format=pdf]
+// Docstring: receitas, pagamentos, estornos e recibos do pagador com saldo corrente; sem período
+// lista todo o histórico. format=pdf devolve o extrato para enviar ao pagador.
+func (h *PayerStatementHandlers) Statement(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var from, to *time.Time
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if v := r.URL.Query().Get(p.name); v != "" {
+			t, err := time.Parse("2006-01-02", v)
+			if err != nil {
+				h.jsonError(w, http.StatusBadRequest, p.name+" inválido (use AAAA-MM-DD)")
+				return
+			}
+			*p.dst = &t
+		}
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != models.ReportFormatJSON && format != models.ReportFormatPDF {
+		h.jsonError(w, http.StatusBadRequest, "formato inválido (use json ou pdf)")
+		return
+	}
+
+	ls := locale.FromContext(r.Context())
+	st, err := h.svc.Build(r.Context(), id, userID, from, to, ls)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPayerNotFound):
+			h.jsonError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, models.ErrStatementRange):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao gerar extrato do pagador", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	if format != models.ReportFormatPDF {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(st)
+		return
+	}
+	content := h.svc.Render(st, ls)
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="extrato-%s.pdf"`, id.String()[:8]))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Write(content)
+}
+
+// Auxiliares
+func (h *PayerStatementHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *PayerStatementHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,182 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers para CRUD de pagadores/clientes
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// PayerHandlers contém os handlers de pagadores
+type PayerHandlers struct {
+	payerService services.PayerService
+	log          logging.Logger
+}
+
+// NewPayerHandlers cria uma nova instância dos handlers de pagadores
+func NewPayerHandlers(payerService services.PayerService, log logging.Logger) *PayerHandlers {
+	return &PayerHandlers{payerService: payerService, log: log}
+}
+
+// POST /api/v1/payers
+func (h *PayerHandlers) CreatePayer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.PayerRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	p, err := h.payerService.CreatePayer(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar pagador", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(p)
+}
+
+// GET /api/v1/payers/{id}
+func (h *PayerHandlers) GetPayer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	p, err := h.payerService.GetPayer(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar pagador", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(p)
+}
+
+// GET /api/v1/payers?search=&page=&per_page=
+func (h *PayerHandlers) ListPayers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	q := r.URL.Query()
+	filter := &models.PayerFilter{Search: strings.TrimSpace(q.Get("search"))}
+	if v, err := strconv.Atoi(q.Get("page")); err == nil && v > 0 {
+		filter.Page = v
+	}
+	if v, err := strconv.Atoi(q.Get("per_page")); err == nil && v > 0 {
+		filter.PerPage = v
+	}
+	if page, ok, err := pageFromCursor(r); err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if ok {
+		filter.Page = page
+	}
+	resp, err := h.payerService.ListPayers(r.Context(), userID, filter)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar pagadores", err)
+		return
+	}
+	writeList(w, r, resp, models.NewPage(resp.Items, resp.Total, resp.Page, resp.PerPage))
+}
+
+// PUT /api/v1/payers/{id}
+func (h *PayerHandlers) UpdatePayer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.PayerRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	p, err := h.payerService.UpdatePayer(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao atualizar pagador", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(p)
+}
+
+// DELETE /api/v1/payers/{id}
+func (h *PayerHandlers) DeletePayer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	if err := h.payerService.DeletePayer(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao remover pagador", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *PayerHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrPayerNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrDuplicateDocument):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrPayerNameRequired), errors.Is(err, models.ErrInvalidDocument),
+		errors.Is(err, models.ErrInvalidEmail), errors.Is(err, models.ErrInvalidUF):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *PayerHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *PayerHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
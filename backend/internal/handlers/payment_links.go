@@ -0,0 +1,152 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers dos links de pagamento (checkout hospedado) das receitas e do webhook do gateway
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	"recibofast/internal/checkout"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// maxCheckoutWebhookBody limite do corpo das notificações do gateway
+const maxCheckoutWebhookBody = 256 << 10
+
+// PaymentLinkHandlers links de pagamento das receitas e webhook de pagamento do gateway
+type PaymentLinkHandlers struct {
+	svc      *services.PaymentLinkService
+	webhooks *services.CheckoutWebhookService
+	log      logging.Logger
+}
+
+// NewPaymentLinkHandlers cria uma nova instância dos handlers de links de pagamento
+func NewPaymentLinkHandlers(svc *services.PaymentLinkService, webhooks *services.CheckoutWebhookService, log logging.Logger) *PaymentLinkHandlers {
+	return &PaymentLinkHandlers{svc: svc, webhooks: webhooks, log: log}
+}
+
+// POST /api/v1/incomes/{id}/payment-link
+// Docstring: corpo opcional {"valor": "150.00", "expiracao": 3600}; sem valor cobra o saldo a
+// receber. Responde 201 com a URL do checkout hospedado para enviar ao cliente.
+func (h *PaymentLinkHandlers) CreateLink(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	var req models.PaymentLinkRequest
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.Create(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao gerar link de pagamento", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(out)
+}
+
+// GET /api/v1/incomes/{id}/payment-link
+func (h *PaymentLinkHandlers) GetLink(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.Current(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar link de pagamento", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// POST /api/v1/webhooks/checkout
+// Docstring: rota pública chamada pelo gateway; a autenticidade vem da assinatura validada com
+// CHECKOUT_WEBHOOK_SECRET. Responde 200 também para eventos ignorados ou repetidos e 5xx quando o
+// pagamento não pôde ser lançado, para que o gateway tente de novo.
+func (h *PaymentLinkHandlers) Webhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCheckoutWebhookBody))
+	if err != nil {
+		h.jsonError(w, http.StatusRequestEntityTooLarge, "notificação muito grande")
+		return
+	}
+	out, err := h.webhooks.Handle(r.Context(), &checkout.WebhookRequest{Header: r.Header, Query: r.URL.Query(), Body: body})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrCheckoutWebhookAuth):
+			h.log.Warn("webhook de checkout com assinatura inválida", logging.Field{Key: "ip", Val: r.RemoteAddr})
+			h.jsonError(w, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, models.ErrCheckoutWebhookPayload):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.writeServiceError(w, "erro ao processar webhook de checkout", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *PaymentLinkHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrIncomeNotFound), errors.Is(err, models.ErrPaymentLinkNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrPaymentLinkAmountInvalid), errors.Is(err, models.ErrPaymentLinkExpiration):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrPaymentLinkNothingDue), errors.Is(err, models.ErrPaymentLinkIncomeClosed):
+		h.jsonError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, models.ErrCheckoutUnavailable):
+		h.jsonError(w, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, models.ErrCheckoutProviderFailed):
+		h.jsonError(w, http.StatusBadGateway, models.ErrCheckoutProviderFailed.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *PaymentLinkHandlers) userAndID(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *PaymentLinkHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *PaymentLinkHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
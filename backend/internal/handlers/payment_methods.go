@@ -0,0 +1,208 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do catálogo de formas de pagamento e dos pagamentos por forma
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// PaymentMethodHandlers catálogo de formas de pagamento do usuário
+type PaymentMethodHandlers struct {
+	svc *services.PaymentMethodService
+	log logging.Logger
+}
+
+// NewPaymentMethodHandlers cria uma nova instância dos handlers de formas de pagamento
+func NewPaymentMethodHandlers(svc *services.PaymentMethodService, log logging.Logger) *PaymentMethodHandlers {
+	return &PaymentMethodHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/payment-methods?include_archived=true
+func (h *PaymentMethodHandlers) ListMethods(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.List(r.Context(), userID, r.URL.Query().Get("include_archived") == "true")
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar formas de pagamento", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// POST /api/v1/payment-methods
+func (h *PaymentMethodHandlers) CreateMethod(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.PaymentMethodRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	m, err := h.svc.Create(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar forma de pagamento", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(m)
+}
+
+// GET /api/v1/payment-methods/{id}
+func (h *PaymentMethodHandlers) GetMethod(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	m, err := h.svc.Get(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar forma de pagamento", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// PUT /api/v1/payment-methods/{id}
+func (h *PaymentMethodHandlers) UpdateMethod(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	var req models.PaymentMethodRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	m, err := h.svc.Update(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao atualizar forma de pagamento", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// POST /api/v1/payment-methods/{id}/default
+func (h *PaymentMethodHandlers) SetDefault(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	m, err := h.svc.SetDefault(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao definir forma de pagamento padrão", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// DELETE /api/v1/payment-methods/{id}
+// Docstring: arquiva a forma; os pagamentos já lançados continuam apontando para ela.
+func (h *PaymentMethodHandlers) ArchiveMethod(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Archive(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao arquivar forma de pagamento", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /api/v1/payment-methods/{id}/payments?page=&per_page=
+func (h *PaymentMethodHandlers) ListPayments(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	filter := &models.PaymentMethodPaymentsFilter{}
+	if v, err := strconv.Atoi(q.Get("page")); err == nil && v > 0 {
+		filter.Page = v
+	}
+	if v, err := strconv.Atoi(q.Get("per_page")); err == nil && v > 0 {
+		filter.PerPage = v
+	}
+	if page, ok, err := pageFromCursor(r); err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if ok {
+		filter.Page = page
+	}
+	items, total, err := h.svc.ListPayments(r.Context(), id, userID, filter)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar pagamentos da forma", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"payments": items, "total": total, "page": filter.Page, "per_page": filter.PerPage},
+		models.NewPage(items, total, filter.Page, filter.PerPage))
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *PaymentMethodHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrPaymentMethodNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrPaymentMethodNameTaken), errors.Is(err, models.ErrPaymentMethodArchived):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrPaymentMethodTypeInvalid), errors.Is(err, models.ErrPaymentMethodNameInvalid),
+		errors.Is(err, models.ErrPaymentMethodPixKey), errors.Is(err, models.ErrPaymentMethodBankMetadata):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *PaymentMethodHandlers) userAndID(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *PaymentMethodHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *PaymentMethodHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,134 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers de exclusão e estorno de pagamentos
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/render"
+	"recibofast/internal/services"
+)
+
+// PaymentReversalHandlers exclusão, estorno e histórico de estornos de pagamentos
+type PaymentReversalHandlers struct {
+	svc *services.PaymentReversalService
+	log logging.Logger
+}
+
+// NewPaymentReversalHandlers cria uma nova instância dos handlers de estorno
+func NewPaymentReversalHandlers(svc *services.PaymentReversalService, log logging.Logger) *PaymentReversalHandlers {
+	return &PaymentReversalHandlers{svc: svc, log: log}
+}
+
+// DELETE /api/v1/payments/{id}?reason=
+// Docstring: o motivo é opcional, via query ou corpo {"reason": "..."}; responde com a auditoria e a receita atualizada.
+func (h *PaymentReversalHandlers) DeletePayment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	req := models.PaymentReversalRequest{Reason: r.URL.Query().Get("reason")}
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	res, err := h.svc.DeletePayment(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao excluir pagamento", err)
+		return
+	}
+	render.JSON(w, r, http.StatusOK, res)
+}
+
+// POST /api/v1/payments/{id}/reverse
+// Docstring: corpo {"reason": "..."} obrigatório; o pagamento fica no histórico marcado como estornado.
+func (h *PaymentReversalHandlers) ReversePayment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.PaymentReversalRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	res, err := h.svc.ReversePayment(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao estornar pagamento", err)
+		return
+	}
+	render.JSON(w, r, http.StatusOK, res)
+}
+
+// GET /api/v1/incomes/{id}/payment-reversals
+func (h *PaymentReversalHandlers) ListReversals(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	items, err := h.svc.ListReversals(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar estornos", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *PaymentReversalHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrPaymentNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrPaymentAlreadyReversed), errors.Is(err, models.ErrCreditAlreadyApplied):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrReversalReasonRequired), errors.Is(err, models.ErrReversalReasonTooLong):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *PaymentReversalHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *PaymentReversalHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
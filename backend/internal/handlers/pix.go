@@ -0,0 +1,153 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers das cobranças PIX (QR dinâmico / copia e cola) das receitas
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/pix"
+	"recibofast/internal/services"
+)
+
+// maxPixWebhookBody limite do corpo das notificações do PSP
+const maxPixWebhookBody = 256 << 10
+
+// PixHandlers cobranças PIX das receitas e webhook de pagamento do PSP
+type PixHandlers struct {
+	svc      *services.PixService
+	webhooks *services.PixWebhookService
+	log      logging.Logger
+}
+
+// NewPixHandlers cria uma nova instância dos handlers de cobrança PIX
+func NewPixHandlers(svc *services.PixService, webhooks *services.PixWebhookService, log logging.Logger) *PixHandlers {
+	return &PixHandlers{svc: svc, webhooks: webhooks, log: log}
+}
+
+// POST /api/v1/incomes/{id}/pix
+// Docstring: corpo opcional {"valor": "150.00", "expiracao": 3600}; sem valor cobra o saldo a
+// receber. Responde 201 com txid, BR Code ("copia e cola") e QR Code em PNG (data URI).
+func (h *PixHandlers) CreateCharge(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	var req models.PixChargeRequest
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.Charge(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao gerar cobrança PIX", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(out)
+}
+
+// GET /api/v1/incomes/{id}/pix
+func (h *PixHandlers) GetCharge(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.Current(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar cobrança PIX", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// POST /api/v1/webhooks/pix
+// Docstring: rota pública chamada pelo PSP; a autenticidade vem da assinatura validada pelo
+// provedor configurado. Responde 200 também para txids desconhecidos ou repetidos (o PSP não deve
+// reenviar) e 5xx quando o pagamento não pôde ser lançado, para que o PSP tente de novo.
+func (h *PixHandlers) Webhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPixWebhookBody))
+	if err != nil {
+		h.jsonError(w, http.StatusRequestEntityTooLarge, "notificação muito grande")
+		return
+	}
+	out, err := h.webhooks.Handle(r.Context(), &pix.WebhookRequest{Header: r.Header, Query: r.URL.Query(), Body: body})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPixWebhookAuth):
+			h.log.Warn("webhook PIX com assinatura inválida", logging.Field{Key: "ip", Val: r.RemoteAddr})
+			h.jsonError(w, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, models.ErrPixWebhookPayload):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.writeServiceError(w, "erro ao processar webhook PIX", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *PixHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrIncomeNotFound), errors.Is(err, models.ErrPixChargeNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrPixAmountInvalid), errors.Is(err, models.ErrPixExpirationRange):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrPixKeyMissing), errors.Is(err, models.ErrPixNothingDue),
+		errors.Is(err, models.ErrPixIncomeClosed), errors.Is(err, models.ErrPixPayerEmail):
+		h.jsonError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, models.ErrPixUnavailable):
+		h.jsonError(w, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, models.ErrPixProviderFailed):
+		h.jsonError(w, http.StatusBadGateway, models.ErrPixProviderFailed.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *PixHandlers) userAndID(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *PixHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *PixHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,97 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do perfil do usuário (dados de contato, emissor padrão, idioma e fuso)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// ProfileHandlers contém os handlers do perfil
+type ProfileHandlers struct {
+	service *services.ProfileService
+	log     logging.Logger
+}
+
+// NewProfileHandlers cria uma nova instância dos handlers do perfil
+func NewProfileHandlers(service *services.ProfileService, log logging.Logger) *ProfileHandlers {
+	return &ProfileHandlers{service: service, log: log}
+}
+
+// GET /api/v1/profile
+func (h *ProfileHandlers) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	p, err := h.service.Get(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar perfil", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(p)
+}
+
+// PUT /api/v1/profile
+// Docstring: substitui todos os campos; campos omitidos, nulos ou vazios são apagados.
+func (h *ProfileHandlers) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.ProfileRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	p, err := h.service.Update(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao atualizar perfil", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(p)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *ProfileHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrProfileNameTooLong), errors.Is(err, models.ErrProfileAddressTooLong),
+		errors.Is(err, models.ErrInvalidDocument), errors.Is(err, models.ErrInvalidPhone),
+		errors.Is(err, models.ErrInvalidLocale), errors.Is(err, models.ErrInvalidTimezone):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+func (h *ProfileHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ProfileHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,159 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do Web Push do PWA (chave VAPID, registro de dispositivos e notificação de teste)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// PushHandlers contém os handlers de notificações push
+type PushHandlers struct {
+	svc *services.PushService
+	log logging.Logger
+}
+
+// NewPushHandlers cria uma nova instância dos handlers de push
+func NewPushHandlers(svc *services.PushService, log logging.Logger) *PushHandlers {
+	return &PushHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/notifications/push/public-key
+// Docstring: chave pública VAPID (applicationServerKey) para o pushManager.subscribe do PWA.
+func (h *PushHandlers) PublicKey(w http.ResponseWriter, r *http.Request) {
+	key, err := h.svc.PublicKey()
+	if err != nil {
+		h.jsonError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"public_key": key})
+}
+
+// GET /api/v1/notifications/push/subscriptions
+func (h *PushHandlers) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao listar assinaturas push", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+}
+
+// POST /api/v1/notifications/push/subscriptions
+// Docstring: corpo = PushSubscription.toJSON() do navegador ({"endpoint", "keys": {"p256dh", "auth"}}).
+func (h *PushHandlers) Subscribe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.PushSubscriptionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	sub, err := h.svc.Subscribe(r.Context(), userID, &req, r.UserAgent())
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(sub)
+}
+
+// DELETE /api/v1/notifications/push/subscriptions
+// Docstring: endpoint no corpo ({"endpoint": "..."}) ou na query (?endpoint=).
+func (h *PushHandlers) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req struct {
+		Endpoint string `json:"endpoint"`
+	}
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	if req.Endpoint == "" {
+		req.Endpoint = r.URL.Query().Get("endpoint")
+	}
+	if req.Endpoint == "" {
+		h.jsonError(w, http.StatusBadRequest, models.ErrPushEndpointInvalid.Error())
+		return
+	}
+	if err := h.svc.Unsubscribe(r.Context(), userID, req.Endpoint); err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /api/v1/notifications/push/test
+// Docstring: envia uma notificação de teste a todos os dispositivos do usuário, sem passar pela fila.
+func (h *PushHandlers) Test(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	msg := &models.NotificationMessage{Title: "ReciboFast", Body: "Notificações ativadas neste dispositivo.", URL: "/", Tag: "push-test"}
+	sent, err := h.svc.Notify(r.Context(), userID, msg)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"sent": sent})
+}
+
+// Auxiliares
+func (h *PushHandlers) writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrPushEndpointInvalid), errors.Is(err, models.ErrPushKeysInvalid):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrPushSubscriptionNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrPushUnavailable):
+		h.jsonError(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		h.log.Error("erro nas notificações push", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+func (h *PushHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *PushHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,159 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers de consulta do histórico de cotações/índices e operações administrativas
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"recibofast/internal/apierror"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// RateHandlers expõe o histórico de cotações (PTAX) e índices (IGP-M, IPCA).
+// Docstring: consultas exigem autenticação; busca, backfill e sobrescrita são administrativas.
+type RateHandlers struct {
+	svc *services.RateService
+	log logging.Logger
+}
+
+// NewRateHandlers cria uma nova instância dos handlers de cotações
+func NewRateHandlers(svc *services.RateService, log logging.Logger) *RateHandlers {
+	return &RateHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/rates/{indice}?from=AAAA-MM-DD&to=AAAA-MM-DD
+func (h *RateHandlers) ListRates(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	items, err := h.svc.List(r.Context(), chi.URLParam(r, "indice"), q.Get("from"), q.Get("to"))
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar cotações", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// GET /api/v1/rates/{indice}/at?date=AAAA-MM-DD
+// Docstring: retorna a última cotação publicada até a data (sem data, hoje).
+func (h *RateHandlers) RateAt(w http.ResponseWriter, r *http.Request) {
+	date := time.Now()
+	if v := r.URL.Query().Get("date"); v != "" {
+		d, err := time.Parse(models.RateLayout, v)
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, "data inválida (use AAAA-MM-DD)")
+			return
+		}
+		date = d
+	}
+	rt, err := h.svc.At(r.Context(), chi.URLParam(r, "indice"), date)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar cotação", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rt)
+}
+
+// GET /api/v1/rates/{indice}/accumulated?from=AAAA-MM&to=AAAA-MM
+func (h *RateHandlers) Accumulated(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, err1 := time.Parse("2006-01", q.Get("from"))
+	to, err2 := time.Parse("2006-01", q.Get("to"))
+	if err1 != nil || err2 != nil {
+		h.jsonError(w, http.StatusBadRequest, "período inválido (use from e to no formato AAAA-MM)")
+		return
+	}
+	indice := chi.URLParam(r, "indice")
+	pct, err := h.svc.Accumulated(r.Context(), indice, from, to)
+	if err != nil {
+		h.writeServiceError(w, "erro ao calcular índice acumulado", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"indice":    indice,
+		"from":      q.Get("from"),
+		"to":        q.Get("to"),
+		"acumulado": pct,
+	})
+}
+
+// POST /api/v1/admin/rates/sync
+func (h *RateHandlers) Sync(w http.ResponseWriter, r *http.Request) {
+	results, err := h.svc.Sync(r.Context())
+	status := http.StatusOK
+	resp := map[string]interface{}{"results": results}
+	if err != nil {
+		h.log.Error("erro na busca de cotações", logging.Field{Key: "error", Val: err.Error()})
+		status = http.StatusBadGateway
+		resp["error"] = err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// POST /api/v1/admin/rates/backfill
+func (h *RateHandlers) Backfill(w http.ResponseWriter, r *http.Request) {
+	var req models.RateBackfillRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	res, err := h.svc.Backfill(r.Context(), &req)
+	if err != nil {
+		h.writeServiceError(w, "erro no backfill de cotações", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// PUT /api/v1/admin/rates/{indice}/{data}
+func (h *RateHandlers) SetOverride(w http.ResponseWriter, r *http.Request) {
+	var req models.RateOverrideRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	rt, err := h.svc.SetOverride(r.Context(), chi.URLParam(r, "indice"), chi.URLParam(r, "data"), &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao sobrescrever cotação", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rt)
+}
+
+// DELETE /api/v1/admin/rates/{indice}/{data}
+func (h *RateHandlers) ClearOverride(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.ClearOverride(r.Context(), chi.URLParam(r, "indice"), chi.URLParam(r, "data")); err != nil {
+		h.writeServiceError(w, "erro ao remover cotação manual", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *RateHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrRateNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrInvalidRateIndex), errors.Is(err, models.ErrInvalidRateRange),
+		errors.Is(err, models.ErrRateValueInvalid):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+func (h *RateHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,139 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers da emissão de recibos em lote por competência
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// ReceiptBatchHandlers emissão de recibos em lote
+type ReceiptBatchHandlers struct {
+	svc  *services.ReceiptBatchService
+	jobs *services.JobMonitor
+	log  logging.Logger
+}
+
+// NewReceiptBatchHandlers cria uma nova instância dos handlers de recibos em lote
+func NewReceiptBatchHandlers(svc *services.ReceiptBatchService, jobs *services.JobMonitor, log logging.Logger) *ReceiptBatchHandlers {
+	return &ReceiptBatchHandlers{svc: svc, jobs: jobs, log: log}
+}
+
+// POST /api/v1/receipts/batch
+// Docstring: {"competencia": "2026-10"}. Responde 202 com o lote na fila e dispara uma rodada do
+// job "receipt-batches" sem esperar o worker; o andamento é acompanhado em
+// GET /receipts/batch/{id}. Sem receitas pagas sem recibo o lote já sai concluído (201).
+func (h *ReceiptBatchHandlers) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.ReceiptBatchRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	b, err := h.svc.Create(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar lote de recibos", err)
+		return
+	}
+	status := http.StatusCreated
+	if !b.Finished() {
+		status = http.StatusAccepted
+		go func() {
+			_ = h.jobs.Track(context.WithoutCancel(r.Context()), "receipt-batches", h.svc.ProcessPending)
+		}()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/v1/receipts/batch/"+b.ID.String())
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(b)
+}
+
+// GET /api/v1/receipts/batch
+func (h *ReceiptBatchHandlers) ListBatches(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar lotes de recibos", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// GET /api/v1/receipts/batch/{id}
+// Docstring: andamento (pending, issued, done, skipped, failed) e a situação de cada receita com o
+// recibo emitido; o lote terminou quando status é completed ou failed.
+func (h *ReceiptBatchHandlers) GetBatch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	b, err := h.svc.Get(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar lote de recibos", err)
+		return
+	}
+	if !b.Finished() {
+		w.Header().Set("Retry-After", "5")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(b)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *ReceiptBatchHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrReceiptBatchNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrReceiptBatchInProgress):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrReportCompetenciaInvalid):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *ReceiptBatchHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ReceiptBatchHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
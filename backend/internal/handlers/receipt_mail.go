@@ -0,0 +1,92 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handler de envio do recibo por e-mail ao pagador
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/services"
+)
+
+// ReceiptMailHandlers contém o handler de envio de recibos por e-mail
+type ReceiptMailHandlers struct {
+	svc *services.ReceiptMailService
+	log logging.Logger
+}
+
+// NewReceiptMailHandlers cria uma nova instância dos handlers de envio de recibos
+func NewReceiptMailHandlers(svc *services.ReceiptMailService, log logging.Logger) *ReceiptMailHandlers {
+	return &ReceiptMailHandlers{svc: svc, log: log}
+}
+
+// POST /api/v1/receipts/{id}/send
+// Docstring: corpo opcional {"to": "...", "mensagem": "..."}; sem "to" usa o e-mail do pagador.
+// Falha do provedor responde 502 com a situação já gravada no recibo.
+func (h *ReceiptMailHandlers) SendReceipt(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.ReceiptSendRequest
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+
+	out, err := h.svc.Send(r.Context(), id, userID, &req, locale.FromContext(r.Context()))
+	if err != nil {
+		switch {
+		case repositories.IsReceiptNotFound(err):
+			h.jsonError(w, http.StatusNotFound, "recibo não encontrado")
+		case errors.Is(err, models.ErrInvalidEmail), errors.Is(err, models.ErrReceiptMessageTooLong),
+			errors.Is(err, models.ErrReceiptRecipientMissing):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, models.ErrEmailUnavailable):
+			h.jsonError(w, http.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, models.ErrEmailSendFailed) && out != nil:
+			apierror.WriteCode(w, http.StatusBadGateway, "email_send_failed", models.ErrEmailSendFailed.Error(), map[string]interface{}{"result": out})
+		default:
+			h.log.Error("erro ao enviar recibo por e-mail", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// Auxiliares
+func (h *ReceiptMailHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ReceiptMailHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
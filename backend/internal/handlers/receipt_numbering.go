@@ -0,0 +1,93 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do esquema de numeração dos recibos do usuário
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// ReceiptNumberingHandlers contém os handlers da numeração dos recibos
+type ReceiptNumberingHandlers struct {
+	svc *services.ReceiptNumberingService
+	log logging.Logger
+}
+
+// NewReceiptNumberingHandlers cria uma nova instância dos handlers de numeração dos recibos
+func NewReceiptNumberingHandlers(svc *services.ReceiptNumberingService, log logging.Logger) *ReceiptNumberingHandlers {
+	return &ReceiptNumberingHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/settings/receipt-numbering
+func (h *ReceiptNumberingHandlers) GetScheme(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	out, err := h.svc.Get(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao buscar numeração dos recibos", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// PUT /api/v1/settings/receipt-numbering
+// Docstring: substitui o esquema (prefixo, digitos, reinicio); vale para os próximos recibos.
+func (h *ReceiptNumberingHandlers) UpdateScheme(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.ReceiptNumbering
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.Update(r.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrReceiptPrefixInvalid), errors.Is(err, models.ErrReceiptDigitsInvalid),
+			errors.Is(err, models.ErrReceiptNumberingInvalid):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao gravar numeração dos recibos", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// Auxiliares
+func (h *ReceiptNumberingHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ReceiptNumberingHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,151 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handler do envio do PDF do recibo ao Storage com hash calculado no servidor
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/storage"
+)
+
+// pdfMagic assinatura inicial de todo arquivo PDF
+var pdfMagic = []byte("%PDF-")
+
+// ReceiptPDFHandlers envio do PDF do recibo
+type ReceiptPDFHandlers struct {
+	repo   repositories.ReceiptRepository
+	store  StorageClient
+	bucket string
+	log    logging.Logger
+	now    func() time.Time
+}
+
+// NewReceiptPDFHandlers cria os handlers; bucket é o bucket de recibos do Storage
+func NewReceiptPDFHandlers(repo repositories.ReceiptRepository, store StorageClient, bucket string, log logging.Logger) *ReceiptPDFHandlers {
+	return &ReceiptPDFHandlers{repo: repo, store: store, bucket: bucket, log: log, now: time.Now}
+}
+
+// POST /api/v1/receipts/{id}/pdf-upload
+// Docstring: multipart com o PDF no campo "file" (até 10 MB). O arquivo vai em streaming para o
+// bucket de recibos em "{owner_id}/receipts/{id}_{unix}.pdf" e o SHA-256 é calculado durante o
+// envio; pdf_url e hash do recibo passam a apontar para ele. Um PDF anterior deixa de ser
+// referenciado e é removido pela coleta do Storage. Responde 200 com o recibo atualizado.
+func (h *ReceiptPDFHandlers) UploadPDF(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	// Confere o recibo antes de receber o arquivo, para não deixar objetos órfãos
+	if _, err := h.repo.GetByID(r.Context(), id, userID); err != nil {
+		if repositories.IsReceiptNotFound(err) {
+			h.jsonError(w, http.StatusNotFound, "recibo não encontrado")
+			return
+		}
+		h.log.Error("erro ao buscar recibo", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+
+	part, ok := openUploadPart(w, r, "file", models.MaxReceiptPDFSize)
+	if !ok {
+		return
+	}
+	defer part.Close()
+
+	body := bufio.NewReader(io.LimitReader(part, models.MaxReceiptPDFSize+1))
+	head, err := body.Peek(len(pdfMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		uploadReadError(w, err)
+		return
+	}
+	if !bytes.Equal(head, pdfMagic) {
+		h.jsonError(w, http.StatusBadRequest, "arquivo não é um PDF")
+		return
+	}
+
+	objectPath := fmt.Sprintf("%s/receipts/%s_%d.pdf", userID, id, h.now().UTC().Unix())
+	size, sha256hex, err := h.store.UploadStream(r.Context(), h.bucket, objectPath, body, "application/pdf")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			uploadReadError(w, err)
+		case storage.IsTransient(err):
+			h.log.Error("erro ao enviar PDF do recibo ao Storage", logging.Field{Key: "error", Val: err.Error()})
+			w.Header().Set("Retry-After", "30")
+			h.jsonError(w, http.StatusServiceUnavailable, "armazenamento indisponível no momento, tente novamente")
+		default:
+			h.log.Error("erro ao enviar PDF do recibo ao Storage", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "falha ao armazenar o PDF")
+		}
+		return
+	}
+	if size > models.MaxReceiptPDFSize {
+		h.discard(r, objectPath)
+		apierror.Write(w, http.StatusRequestEntityTooLarge, "arquivo muito grande")
+		return
+	}
+
+	m, err := h.repo.SetPDF(r.Context(), id, userID, objectPath, sha256hex)
+	if err != nil {
+		// Compensação: o recibo não aponta para o objeto enviado
+		h.discard(r, objectPath)
+		if repositories.IsReceiptNotFound(err) {
+			h.jsonError(w, http.StatusNotFound, "recibo não encontrado")
+			return
+		}
+		h.log.Error("erro ao gravar PDF do recibo", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "falha ao gravar o PDF do recibo")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// discard remove do Storage um objeto que não ficou referenciado
+func (h *ReceiptPDFHandlers) discard(r *http.Request, objectPath string) {
+	if err := h.store.DeleteObject(r.Context(), h.bucket, objectPath); err != nil {
+		h.log.Error("falha ao remover PDF não referenciado do Storage", logging.Field{Key: "error", Val: err.Error()}, logging.Field{Key: "objectPath", Val: objectPath})
+	}
+}
+
+// Auxiliares
+func (h *ReceiptPDFHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ReceiptPDFHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,128 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers da segunda via de recibos e da sua trilha de auditoria
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/services"
+)
+
+// ReceiptReissueHandlers contém os handlers de segunda via de recibos
+type ReceiptReissueHandlers struct {
+	svc *services.ReceiptReissueService
+	log logging.Logger
+}
+
+// NewReceiptReissueHandlers cria uma nova instância dos handlers de segunda via
+func NewReceiptReissueHandlers(svc *services.ReceiptReissueService, log logging.Logger) *ReceiptReissueHandlers {
+	return &ReceiptReissueHandlers{svc: svc, log: log}
+}
+
+// POST /api/v1/receipts/{id}/reissue
+// Docstring: corpo opcional {"motivo": "..."}. Responde com o PDF da via (mesmo número e data de
+// emissão do original, marcado "Nª VIA"); X-Receipt-Via e X-Receipt-Reissue-ID identificam o
+// registro gravado na auditoria, listada em GET /receipts/{id}/reissues.
+func (h *ReceiptReissueHandlers) ReissueReceipt(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.ReceiptReissueRequest
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.Reissue(r.Context(), id, userID, h.actorID(r, userID), &req, locale.FromContext(r.Context()))
+	if err != nil {
+		switch {
+		case repositories.IsReceiptNotFound(err):
+			h.jsonError(w, http.StatusNotFound, "recibo não encontrado")
+		case errors.Is(err, models.ErrReissueReasonTooLong):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao emitir segunda via do recibo", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="recibo-%d-%da-via.pdf"`, out.Receipt.Numero, out.Reissue.Via))
+	w.Header().Set("X-Receipt-Via", strconv.Itoa(out.Reissue.Via))
+	w.Header().Set("X-Receipt-Reissue-ID", out.Reissue.ID.String())
+	w.WriteHeader(http.StatusCreated)
+	w.Write(out.PDF)
+}
+
+// GET /api/v1/receipts/{id}/reissues
+func (h *ReceiptReissueHandlers) ListReissues(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	items, err := h.svc.List(r.Context(), id, userID)
+	if err != nil {
+		if repositories.IsReceiptNotFound(err) {
+			h.jsonError(w, http.StatusNotFound, "recibo não encontrado")
+			return
+		}
+		h.log.Error("erro ao listar segundas vias do recibo", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+}
+
+// Auxiliares
+func (h *ReceiptReissueHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+// actorID usuário autenticado que pediu a via; fora de organização é o próprio dono
+func (h *ReceiptReissueHandlers) actorID(r *http.Request, userID uuid.UUID) uuid.UUID {
+	s, _ := ctxhelper.GetActorID(r.Context())
+	if uid, err := uuid.Parse(s); err == nil {
+		return uid
+	}
+	return userID
+}
+
+func (h *ReceiptReissueHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,121 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers do compartilhamento de recibos por link público (WhatsApp)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/repositories"
+	"recibofast/internal/services"
+)
+
+// ReceiptShareHandlers contém os handlers de compartilhamento de recibos
+type ReceiptShareHandlers struct {
+	svc     *services.ReceiptShareService
+	baseURL string
+	log     logging.Logger
+}
+
+// NewReceiptShareHandlers cria os handlers; baseURL vazio monta o link a partir da requisição
+func NewReceiptShareHandlers(svc *services.ReceiptShareService, baseURL string, log logging.Logger) *ReceiptShareHandlers {
+	return &ReceiptShareHandlers{svc: svc, baseURL: strings.TrimSpace(baseURL), log: log}
+}
+
+// GET /api/v1/receipts/{id}/share
+// Docstring: gera um novo link público do PDF (válido por 72h) e a mensagem pronta para o WhatsApp.
+func (h *ReceiptShareHandlers) ShareReceipt(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	out, err := h.svc.Share(r.Context(), id, userID, h.publicBaseURL(r), locale.FromContext(r.Context()))
+	if err != nil {
+		if repositories.IsReceiptNotFound(err) {
+			h.jsonError(w, http.StatusNotFound, "recibo não encontrado")
+			return
+		}
+		h.log.Error("erro ao compartilhar recibo", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// GET /api/v1/public/receipts/shared/{token}
+// Docstring: rota pública que entrega o PDF do recibo enquanto o link não vence.
+func (h *ReceiptShareHandlers) OpenShared(w http.ResponseWriter, r *http.Request) {
+	token, err := uuid.Parse(chi.URLParam(r, "token"))
+	if err != nil {
+		h.jsonError(w, http.StatusNotFound, "link expirado ou inválido")
+		return
+	}
+	rec, err := h.svc.Open(r.Context(), token)
+	if err != nil {
+		if repositories.IsReceiptNotFound(err) {
+			h.jsonError(w, http.StatusNotFound, "link expirado ou inválido")
+			return
+		}
+		h.log.Error("erro ao abrir recibo compartilhado", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="recibo-%d.pdf"`, rec.Numero))
+	w.Write(h.svc.RenderPDF(r.Context(), rec, locale.FromContext(r.Context())))
+}
+
+// publicBaseURL PUBLIC_BASE_URL ou, sem ela, esquema e host da requisição (respeitando o proxy)
+func (h *ReceiptShareHandlers) publicBaseURL(r *http.Request) string {
+	if h.baseURL != "" {
+		return h.baseURL
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if p := r.Header.Get("X-Forwarded-Proto"); p != "" {
+		scheme = strings.TrimSpace(strings.Split(p, ",")[0])
+	}
+	host := r.Host
+	if fh := r.Header.Get("X-Forwarded-Host"); fh != "" {
+		host = strings.TrimSpace(strings.Split(fh, ",")[0])
+	}
+	return scheme + "://" + host
+}
+
+// Auxiliares
+func (h *ReceiptShareHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ReceiptShareHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
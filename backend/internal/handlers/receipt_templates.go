@@ -0,0 +1,220 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers dos modelos de recibo (CRUD, logotipo e prévia em PDF)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// ReceiptTemplateHandlers contém os handlers de modelos de recibo
+type ReceiptTemplateHandlers struct {
+	svc *services.ReceiptTemplateService
+	log logging.Logger
+}
+
+// NewReceiptTemplateHandlers cria uma nova instância dos handlers de modelos de recibo
+func NewReceiptTemplateHandlers(svc *services.ReceiptTemplateService, log logging.Logger) *ReceiptTemplateHandlers {
+	return &ReceiptTemplateHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/receipt-templates
+func (h *ReceiptTemplateHandlers) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar modelos de recibo", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": items}, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// POST /api/v1/receipt-templates
+// Docstring: com is_default, o modelo passa a ser usado nos PDFs de recibos e carnês do usuário.
+func (h *ReceiptTemplateHandlers) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.ReceiptTemplateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	t, err := h.svc.Create(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar modelo de recibo", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(t)
+}
+
+// GET /api/v1/receipt-templates/{id}
+func (h *ReceiptTemplateHandlers) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	t, err := h.svc.Get(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar modelo de recibo", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t)
+}
+
+// PUT /api/v1/receipt-templates/{id}
+func (h *ReceiptTemplateHandlers) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	var req models.ReceiptTemplateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	t, err := h.svc.Update(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao atualizar modelo de recibo", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t)
+}
+
+// DELETE /api/v1/receipt-templates/{id}
+func (h *ReceiptTemplateHandlers) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao remover modelo de recibo", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PUT /api/v1/receipt-templates/{id}/logo
+// Docstring: multipart com o logotipo no campo "file" (PNG ou JPEG, até 512 KB e 2000x2000 px).
+func (h *ReceiptTemplateHandlers) UploadLogo(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	part, ok := openUploadPart(w, r, "file", models.MaxTemplateLogoSize)
+	if !ok {
+		return
+	}
+	defer part.Close()
+	logo, err := io.ReadAll(io.LimitReader(part, models.MaxTemplateLogoSize+1))
+	if err != nil {
+		uploadReadError(w, err)
+		return
+	}
+	if err := h.svc.SetLogo(r.Context(), id, userID, logo); err != nil {
+		h.writeServiceError(w, "erro ao gravar logotipo do modelo de recibo", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DELETE /api/v1/receipt-templates/{id}/logo
+func (h *ReceiptTemplateHandlers) DeleteLogo(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.RemoveLogo(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao remover logotipo do modelo de recibo", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /api/v1/receipt-templates/{id}/preview
+// Docstring: PDF de um recibo de exemplo com o modelo aplicado.
+func (h *ReceiptTemplateHandlers) Preview(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	content, err := h.svc.Preview(r.Context(), id, userID, locale.FromContext(r.Context()))
+	if err != nil {
+		h.writeServiceError(w, "erro ao gerar prévia do modelo de recibo", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `inline; filename="modelo-recibo.pdf"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Write(content)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *ReceiptTemplateHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrReceiptTemplateNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrTemplateNameRequired), errors.Is(err, models.ErrInvalidTemplateColor),
+		errors.Is(err, models.ErrTemplateTextTooLong), errors.Is(err, models.ErrInvalidTemplateLogo),
+		errors.Is(err, models.ErrInvalidDocument):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *ReceiptTemplateHandlers) parseIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *ReceiptTemplateHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ReceiptTemplateHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
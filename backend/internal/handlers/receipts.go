@@ -7,24 +7,32 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"recibofast/internal/apierror"
 	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
 	"recibofast/internal/logging"
 	"recibofast/internal/models"
+	"recibofast/internal/render"
 	"recibofast/internal/repositories"
+	"recibofast/internal/services"
 )
 
 type ReceiptHandlers struct {
 	repo repositories.ReceiptRepository
+	svc  *services.ReceiptService
 	log  logging.Logger
 }
 
 func NewReceiptHandlers(repo repositories.ReceiptRepository, log logging.Logger) *ReceiptHandlers {
-	return &ReceiptHandlers{repo: repo, log: log}
+	return &ReceiptHandlers{repo: repo, svc: services.NewReceiptService(repo), log: log}
 }
 
 // POST /api/v1/receipts
@@ -35,27 +43,30 @@ func (h *ReceiptHandlers) CreateReceipt(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	var req models.ReceiptRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
-	m := &models.Receipt{
-		OwnerID:        ownerID,
-		IncomeID:       req.IncomeID,
-		PDFURL:         req.PDFURL,
-		Hash:           req.Hash,
-		SignatureID:    req.SignatureID,
-		IssuerName:     req.IssuerName,
-		IssuerDocument: req.IssuerDocument,
-	}
-	if err := h.repo.Create(r.Context(), m); err != nil {
+	m, err := h.svc.CreateReceipt(r.Context(), ownerID, &req)
+	if err != nil {
+		if errors.Is(err, models.ErrPayerNotFound) || errors.Is(err, models.ErrInvalidReceiptAdjustment) ||
+			errors.Is(err, models.ErrReceiptNumberInvalid) {
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, models.ErrIncomeNotFound) {
+			h.jsonError(w, http.StatusNotFound, "receita não encontrada")
+			return
+		}
+		if errors.Is(err, models.ErrReceiptNumberConflict) || errors.Is(err, models.ErrReceiptNumberNotMonotonic) {
+			h.jsonError(w, http.StatusConflict, err.Error())
+			return
+		}
+		// 500 libera a chave do Idempotency: uma falha transitória não fica gravada como resposta
 		h.log.Error("erro ao criar recibo", logging.Field{Key: "error", Val: err.Error()})
-		h.jsonError(w, http.StatusBadRequest, "falha ao criar recibo")
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(m)
+	render.Created(w, r, "/api/v1/receipts/"+m.ID.String(), m)
 }
 
 // GET /api/v1/receipts/{id}
@@ -71,7 +82,7 @@ func (h *ReceiptHandlers) GetReceipt(w http.ResponseWriter, r *http.Request) {
 		h.jsonError(w, http.StatusBadRequest, "ID inválido")
 		return
 	}
-	m, err := h.repo.GetByID(r.Context(), id, ownerID)
+	m, err := h.svc.GetReceipt(r.Context(), id, ownerID)
 	if err != nil {
 		if repositories.IsReceiptNotFound(err) {
 			h.jsonError(w, http.StatusNotFound, "recibo não encontrado")
@@ -81,44 +92,98 @@ func (h *ReceiptHandlers) GetReceipt(w http.ResponseWriter, r *http.Request) {
 		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(m)
+	// Snapshot imutável: só envio por e-mail e artefatos mudam o recibo, sempre com updated_at
+	w.Header().Set("ETag", entityETag(m.ID, m.UpdatedAt, 0))
+	render.JSON(w, r, http.StatusOK, m)
 }
 
 // GET /api/v1/receipts
+// Docstring: filtros opcionais income_id, payer_id, numero, emitido_from/emitido_to (AAAA-MM-DD no
+// fuso do usuário, ambos inclusivos, ou RFC3339) e search (pagador, emissor ou número exibido);
+// ordenação por sort_field (emitido_em, created_at, numero, valor, payer_nome) e sort_order.
 func (h *ReceiptHandlers) ListReceipts(w http.ResponseWriter, r *http.Request) {
 	ownerID, ok := h.getUserID(r)
 	if !ok {
 		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
 		return
 	}
-	page := 1
-	limit := 10
-	if p := r.URL.Query().Get("page"); p != "" {
-		if v, err := strconv.Atoi(p); err == nil && v > 0 {
-			page = v
-		}
-	}
-	if l := r.URL.Query().Get("limit"); l != "" {
-		if v, err := strconv.Atoi(l); err == nil && v > 0 {
-			limit = v
-		}
+	filter, err := parseReceiptFilter(r)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
 	}
-	items, total, err := h.repo.List(r.Context(), ownerID, page, limit)
+	resp, err := h.svc.ListReceipts(r.Context(), ownerID, filter)
 	if err != nil {
 		h.log.Error("erro ao listar recibos", logging.Field{Key: "error", Val: err.Error()})
 		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
 		return
 	}
-	resp := models.ReceiptListResponse{
-		Items:      items,
-		Total:      total,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: (total + limit - 1) / limit,
+	writeList(w, r, resp, models.NewPage(resp.Items, resp.Total, resp.Page, resp.Limit))
+}
+
+// parseReceiptFilter lê paginação (page, limit/per_page ou cursor), filtros e ordenação da query
+func parseReceiptFilter(r *http.Request) (*models.ReceiptFilter, error) {
+	q := r.URL.Query()
+	f := &models.ReceiptFilter{
+		Search:    strings.TrimSpace(q.Get("search")),
+		SortField: strings.TrimSpace(q.Get("sort_field")),
+		SortOrder: strings.TrimSpace(q.Get("sort_order")),
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	if v, err := strconv.Atoi(q.Get("page")); err == nil && v > 0 {
+		f.Page = v
+	}
+	for _, key := range []string{"limit", "per_page"} {
+		if v, err := strconv.Atoi(q.Get(key)); err == nil && v > 0 {
+			f.PerPage = v
+		}
+	}
+	if p, ok, err := pageFromCursor(r); err != nil {
+		return nil, err
+	} else if ok {
+		f.Page = p
+	}
+	f.SetDefaults()
+
+	for key, dst := range map[string]**uuid.UUID{"income_id": &f.IncomeID, "payer_id": &f.PayerID} {
+		if v := q.Get(key); v != "" {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				return nil, errors.New(key + " inválido")
+			}
+			*dst = &id
+		}
+	}
+	if v := q.Get("numero"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, errors.New("numero inválido")
+		}
+		f.Numero = &n
+	}
+	loc := locale.FromContext(r.Context()).Location
+	for key, dst := range map[string]**time.Time{"emitido_from": &f.EmitidoFrom, "emitido_to": &f.EmitidoTo} {
+		v := q.Get(key)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			d, derr := time.ParseInLocation("2006-01-02", v, loc)
+			if derr != nil {
+				return nil, errors.New(key + " inválido (use AAAA-MM-DD ou RFC3339)")
+			}
+			// data final inclusiva: o filtro vai até o início do dia seguinte
+			if key == "emitido_to" {
+				d = d.AddDate(0, 0, 1)
+			}
+			t = d
+		}
+		*dst = &t
+	}
+	if f.EmitidoFrom != nil && f.EmitidoTo != nil && !f.EmitidoFrom.Before(*f.EmitidoTo) {
+		return nil, errors.New("emitido_from deve ser anterior a emitido_to")
+	}
+	return f, nil
 }
 
 // PUT /api/v1/receipts/{id}
@@ -135,14 +200,14 @@ func (h *ReceiptHandlers) UpdateReceipt(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	var req models.ReceiptRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 	m := &models.Receipt{
 		ID:             id,
 		OwnerID:        ownerID,
 		IncomeID:       req.IncomeID,
+		PayerID:        req.PayerID,
 		PDFURL:         req.PDFURL,
 		Hash:           req.Hash,
 		SignatureID:    req.SignatureID,
@@ -154,12 +219,19 @@ func (h *ReceiptHandlers) UpdateReceipt(w http.ResponseWriter, r *http.Request)
 			h.jsonError(w, http.StatusNotFound, "recibo não encontrado")
 			return
 		}
+		if errors.Is(err, models.ErrPayerNotFound) {
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, models.ErrReceiptSnapshotImmutable) {
+			h.jsonError(w, http.StatusConflict, err.Error())
+			return
+		}
 		h.log.Error("erro ao atualizar recibo", logging.Field{Key: "error", Val: err.Error()})
 		h.jsonError(w, http.StatusBadRequest, "falha ao atualizar recibo")
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(m)
+	render.JSON(w, r, http.StatusOK, m)
 }
 
 // DELETE /api/v1/receipts/{id}
@@ -187,6 +259,167 @@ func (h *ReceiptHandlers) DeleteReceipt(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// GET /api/v1/receipts/numbering-report
+// Docstring: lacunas (com justificativas), duplicatas e quebras de ordem da numeração do usuário.
+func (h *ReceiptHandlers) NumberingReport(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	rep, err := h.repo.NumberingReport(r.Context(), ownerID)
+	if err != nil {
+		h.log.Error("erro ao gerar relatório de numeração", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rep)
+}
+
+// GET /api/v1/receipts/numbering-check
+// Docstring: verificação de integridade resumida; responde 200 quando íntegra e 409 caso contrário.
+func (h *ReceiptHandlers) NumberingCheck(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	rep, err := h.repo.NumberingReport(r.Context(), ownerID)
+	if err != nil {
+		h.log.Error("erro ao verificar numeração", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	status := http.StatusOK
+	if !rep.OK {
+		status = http.StatusConflict
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ok":               rep.OK,
+		"count":            rep.Count,
+		"last":             rep.Last,
+		"unjustified_gaps": rep.UnjustifiedGaps,
+		"duplicates":       len(rep.Duplicates),
+		"order_violations": len(rep.OrderViolations),
+	})
+}
+
+// GET /api/v1/receipts/{id}/consistency
+// Docstring: compara os valores congelados na emissão com a receita atual; o recibo nunca é alterado.
+func (h *ReceiptHandlers) CheckConsistency(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	c, err := h.repo.CheckConsistency(r.Context(), id, ownerID)
+	if err != nil {
+		if repositories.IsReceiptNotFound(err) {
+			h.jsonError(w, http.StatusNotFound, "recibo não encontrado")
+			return
+		}
+		h.log.Error("erro ao verificar consistência do recibo", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	if !c.Consistent {
+		h.log.Warn("receita alterada após emissão do recibo",
+			logging.Field{Key: "receipt_id", Val: id.String()},
+			logging.Field{Key: "warnings", Val: len(c.Warnings)})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+// GET /api/v1/receipts/consistency-check
+// Docstring: lista recibos cuja receita foi editada ou excluída após a emissão.
+func (h *ReceiptHandlers) ListInconsistent(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.repo.ListInconsistent(r.Context(), ownerID)
+	if err != nil {
+		h.log.Error("erro ao verificar consistência dos recibos", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ok":       len(items) == 0,
+		"receipts": items,
+	})
+}
+
+// GET /api/v1/public/receipts/lookup?numero=&documento=
+// Docstring: rota pública (sem autenticação e com limite de taxa próprio) para o pagador confirmar que um
+// recibo com o número impresso (ex.: RB-0001/2026) foi emitido pelo documento informado. Responde apenas
+// existência e mês.
+func (h *ReceiptHandlers) PublicLookup(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	numero, ok := models.NormalizeReceiptNumber(q.Get("numero"))
+	if !ok {
+		h.jsonError(w, http.StatusBadRequest, "número inválido")
+		return
+	}
+	documento := models.NormalizeDocument(q.Get("documento"))
+	if !models.ValidDocument(documento) {
+		h.jsonError(w, http.StatusBadRequest, models.ErrInvalidDocument.Error())
+		return
+	}
+	res, err := h.repo.LookupPublic(r.Context(), numero, documento)
+	if err != nil {
+		h.log.Error("erro na consulta pública de recibo", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	// Não encontrado também responde 200 para não diferenciar por status
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// POST /api/v1/receipts/numbering-gaps
+// Docstring: registra a justificativa de uma lacuna (ex.: folhas canceladas do talão em papel).
+func (h *ReceiptHandlers) JustifyNumberGap(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.NumberGapRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	j := &models.NumberGapJustification{
+		OwnerID:       ownerID,
+		SeriePeriodo:  req.SeriePeriodo,
+		NumeroInicio:  req.NumeroInicio,
+		NumeroFim:     req.NumeroFim,
+		Justificativa: strings.TrimSpace(req.Justificativa),
+	}
+	if err := h.repo.CreateNumberGap(r.Context(), j); err != nil {
+		h.log.Error("erro ao registrar justificativa de lacuna", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(j)
+}
+
 // Auxiliares
 func (h *ReceiptHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
 	userIDStr, ok := ctxhelper.GetUserID(r.Context())
@@ -202,7 +435,5 @@ func (h *ReceiptHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
 }
 
 func (h *ReceiptHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]string{"error": message})
+	apierror.Write(w, statusCode, message)
 }
@@ -0,0 +1,233 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da consulta pública de existência de recibos e do envio do PDF
+// Data: 18-10-2026
+
+package handlers
+
+import (
+    "bytes"
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/go-chi/chi/v5"
+    "github.com/google/uuid"
+    ctxhelper "recibofast/internal/context"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+// fakeReceiptRepo implementa a consulta pública e o envio do PDF; demais métodos não são usados nestes testes
+type fakeReceiptRepo struct {
+    repositories.ReceiptRepository
+    lookupNumero string
+    lookupDoc    string
+    lookupResp   *models.ReceiptLookup
+    receipt      *models.Receipt
+    setPDFErr    error
+    listFilter   *models.ReceiptFilter
+    createErr    error
+}
+
+func (f *fakeReceiptRepo) Create(ctx context.Context, m *models.Receipt) error {
+    return f.createErr
+}
+
+func (f *fakeReceiptRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Receipt, error) {
+    return f.receipt, nil
+}
+
+func (f *fakeReceiptRepo) SetPDF(ctx context.Context, id, ownerID uuid.UUID, pdfURL, hash string) (*models.Receipt, error) {
+    if f.setPDFErr != nil { return nil, f.setPDFErr }
+    f.receipt.PDFURL, f.receipt.Hash = &pdfURL, &hash
+    return f.receipt, nil
+}
+
+func (f *fakeReceiptRepo) List(ctx context.Context, ownerID uuid.UUID, filter *models.ReceiptFilter) ([]models.Receipt, int, error) {
+    f.listFilter = filter
+    return []models.Receipt{}, 0, nil
+}
+
+func (f *fakeReceiptRepo) LookupPublic(ctx context.Context, numero, documento string) (*models.ReceiptLookup, error) {
+    f.lookupNumero, f.lookupDoc = numero, documento
+    return f.lookupResp, nil
+}
+
+func TestPublicLookup_NormalizesDocument(t *testing.T) {
+    month := "2026-10"
+    repo := &fakeReceiptRepo{lookupResp: &models.ReceiptLookup{Exists: true, EmitidoEm: &month}}
+    h := NewReceiptHandlers(repo, logging.NewLogger("dev"))
+
+    req := httptest.NewRequest(http.MethodGet, "/api/v1/public/receipts/lookup?numero=+rb-0042/2026+&documento=529.982.247-25", nil)
+    rr := httptest.NewRecorder()
+    h.PublicLookup(rr, req)
+
+    if rr.Code != http.StatusOK { t.Fatalf("status = %d, want 200", rr.Code) }
+    if repo.lookupNumero != "RB-0042/2026" || repo.lookupDoc != "52998224725" { t.Fatalf("consulta = %s/%s", repo.lookupNumero, repo.lookupDoc) }
+    var body map[string]interface{}
+    json.NewDecoder(rr.Body).Decode(&body)
+    if body["exists"] != true || body["emitido_em"] != "2026-10" || len(body) != 2 { t.Fatalf("resposta inesperada: %v", body) }
+}
+
+func TestPublicLookup_RejectsInvalidParams(t *testing.T) {
+    h := NewReceiptHandlers(&fakeReceiptRepo{}, logging.NewLogger("dev"))
+    long := strings.Repeat("9", 49)
+    for _, q := range []string{"documento=52998224725", "numero=+&documento=52998224725", "numero=" + long + "&documento=52998224725", "numero=1&documento=123"} {
+        rr := httptest.NewRecorder()
+        h.PublicLookup(rr, httptest.NewRequest(http.MethodGet, "/api/v1/public/receipts/lookup?"+q, nil))
+        if rr.Code != http.StatusBadRequest { t.Fatalf("%s: status = %d, want 400", q, rr.Code) }
+    }
+}
+
+func TestCreateReceipt_ErrorStatus(t *testing.T) {
+    cases := []struct {
+        err  error
+        want int
+    }{
+        {models.ErrIncomeNotFound, http.StatusNotFound},
+        {models.ErrReceiptNumberConflict, http.StatusConflict},
+        {errors.New("conn reset by peer"), http.StatusInternalServerError},
+    }
+    for _, c := range cases {
+        h := NewReceiptHandlers(&fakeReceiptRepo{createErr: c.err}, logging.NewLogger("dev"))
+        req := httptest.NewRequest(http.MethodPost, "/api/v1/receipts", strings.NewReader(`{}`))
+        rr := httptest.NewRecorder()
+        h.CreateReceipt(rr, req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String())))
+        if rr.Code != c.want { t.Fatalf("%v: status = %d, want %d", c.err, rr.Code, c.want) }
+        if strings.Contains(rr.Body.String(), "conn reset") { t.Fatalf("erro interno vazou na resposta: %s", rr.Body) }
+    }
+}
+
+func TestListReceipts_ParsesFilters(t *testing.T) {
+    repo := &fakeReceiptRepo{}
+    h := NewReceiptHandlers(repo, logging.NewLogger("dev"))
+    owner, income := uuid.New(), uuid.New()
+    list := func(q string) *httptest.ResponseRecorder {
+        req := httptest.NewRequest(http.MethodGet, "/api/v1/receipts?"+q, nil)
+        ctx := locale.WithResolver(ctxhelper.SetUserID(req.Context(), owner.String()), func(context.Context) (locale.Settings, bool) {
+            return locale.Default(), true
+        })
+        rr := httptest.NewRecorder()
+        h.ListReceipts(rr, req.WithContext(ctx))
+        return rr
+    }
+
+    rr := list("income_id=" + income.String() + "&numero=12&search=maria&emitido_from=2026-01-01&emitido_to=2026-01-31&sort_field=numero&sort_order=asc&per_page=500")
+    if rr.Code != http.StatusOK { t.Fatalf("status = %d: %s", rr.Code, rr.Body.String()) }
+    f := repo.listFilter
+    if f.IncomeID == nil || *f.IncomeID != income || f.Numero == nil || *f.Numero != 12 || f.Search != "maria" { t.Fatalf("filtro = %+v", f) }
+    if f.SortField != "numero" || f.SortOrder != "asc" || f.PerPage != 100 || f.Page != 1 { t.Fatalf("ordenação/paginação = %+v", f) }
+    // datas no fuso do usuário; a data final inclui o dia inteiro
+    sp := locale.Default().Location
+    if !f.EmitidoFrom.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, sp)) || !f.EmitidoTo.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, sp)) {
+        t.Fatalf("período = %v a %v", f.EmitidoFrom, f.EmitidoTo)
+    }
+
+    for _, q := range []string{"income_id=x", "numero=0", "emitido_from=01/02/2026", "emitido_from=2026-02-01&emitido_to=2026-01-01"} {
+        if rr := list(q); rr.Code != http.StatusBadRequest { t.Fatalf("%s: status = %d, want 400", q, rr.Code) }
+    }
+}
+
+func newReceiptPDFRequest(t *testing.T, id, owner uuid.UUID, content []byte) *http.Request {
+    t.Helper()
+    req, _ := newMultipartRequest(t, "file", "recibo.pdf", content)
+    rctx := chi.NewRouteContext()
+    rctx.URLParams.Add("id", id.String())
+    ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+    return req.WithContext(ctxhelper.SetUserID(ctx, owner.String()))
+}
+
+func TestUploadReceiptPDF_StoresPathAndServerHash(t *testing.T) {
+    owner := uuid.New()
+    repo := &fakeReceiptRepo{receipt: &models.Receipt{ID: uuid.New(), OwnerID: owner}}
+    store := &fakeStorage{}
+    h := NewReceiptPDFHandlers(repo, store, "receipts", logging.NewLogger("dev"))
+
+    pdf := []byte("%PDF-1.7\n1 0 obj\n<<>>\nendobj\n%%EOF\n")
+    rr := httptest.NewRecorder()
+    h.UploadPDF(rr, newReceiptPDFRequest(t, repo.receipt.ID, owner, pdf))
+
+    if rr.Code != http.StatusOK { t.Fatalf("status = %d, want 200: %s", rr.Code, rr.Body.String()) }
+    if len(store.uploaded) != 1 || store.uploaded[0].bucket != "receipts" || store.uploaded[0].contentType != "application/pdf" {
+        t.Fatalf("upload = %+v", store.uploaded)
+    }
+    path := store.uploaded[0].objectPath
+    if !strings.HasPrefix(path, owner.String()+"/receipts/"+repo.receipt.ID.String()+"_") || !strings.HasSuffix(path, ".pdf") {
+        t.Fatalf("caminho = %s", path)
+    }
+    sum := sha256.Sum256(pdf)
+    var body models.Receipt
+    if err := json.NewDecoder(rr.Body).Decode(&body); err != nil { t.Fatalf("decode: %v", err) }
+    if body.Hash == nil || *body.Hash != hex.EncodeToString(sum[:]) { t.Fatalf("hash = %v", body.Hash) }
+    if body.PDFURL == nil || *body.PDFURL != path { t.Fatalf("pdf_url = %v", body.PDFURL) }
+}
+
+func TestUploadReceiptPDF_RejectsNonPDF(t *testing.T) {
+    owner := uuid.New()
+    repo := &fakeReceiptRepo{receipt: &models.Receipt{ID: uuid.New(), OwnerID: owner}}
+    store := &fakeStorage{}
+    h := NewReceiptPDFHandlers(repo, store, "receipts", logging.NewLogger("dev"))
+
+    rr := httptest.NewRecorder()
+    h.UploadPDF(rr, newReceiptPDFRequest(t, repo.receipt.ID, owner, []byte("\x89PNG\r\n")))
+    if rr.Code != http.StatusBadRequest { t.Fatalf("status = %d, want 400", rr.Code) }
+    if len(store.uploaded) != 0 { t.Fatalf("não deveria enviar ao Storage") }
+}
+
+func TestUploadReceiptPDF_TooLarge(t *testing.T) {
+    owner := uuid.New()
+    repo := &fakeReceiptRepo{receipt: &models.Receipt{ID: uuid.New(), OwnerID: owner}}
+    store := &fakeStorage{}
+    h := NewReceiptPDFHandlers(repo, store, "receipts", logging.NewLogger("dev"))
+
+    pdf := append([]byte("%PDF-1.7\n"), bytes.Repeat([]byte{' '}, models.MaxReceiptPDFSize)...)
+    rr := httptest.NewRecorder()
+    h.UploadPDF(rr, newReceiptPDFRequest(t, repo.receipt.ID, owner, pdf))
+    if rr.Code != http.StatusRequestEntityTooLarge { t.Fatalf("status = %d, want 413", rr.Code) }
+    if repo.receipt.PDFURL != nil { t.Fatalf("recibo não deveria mudar") }
+    if len(store.uploaded) == 1 && len(store.deleted) != 1 { t.Fatalf("objeto enviado deveria ser removido") }
+}
+
+func TestUploadReceiptPDF_RepoErrorRemovesObject(t *testing.T) {
+    owner := uuid.New()
+    repo := &fakeReceiptRepo{receipt: &models.Receipt{ID: uuid.New(), OwnerID: owner}, setPDFErr: errors.New("db down")}
+    store := &fakeStorage{}
+    h := NewReceiptPDFHandlers(repo, store, "receipts", logging.NewLogger("dev"))
+
+    rr := httptest.NewRecorder()
+    h.UploadPDF(rr, newReceiptPDFRequest(t, repo.receipt.ID, owner, []byte("%PDF-1.7\n%%EOF")))
+    if rr.Code != http.StatusInternalServerError { t.Fatalf("status = %d, want 500", rr.Code) }
+    if len(store.deleted) != 1 || store.deleted[0].objectPath != store.uploaded[0].objectPath { t.Fatalf("compensação = %+v", store.deleted) }
+}
+
+func TestGetReceipt_ETagFollowsUpdatedAt(t *testing.T) {
+    owner := uuid.New()
+    at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+    repo := &fakeReceiptRepo{receipt: &models.Receipt{ID: uuid.New(), OwnerID: owner, Numero: 7, UpdatedAt: &at}}
+    h := NewReceiptHandlers(repo, logging.NewLogger("dev"))
+    get := func() string {
+        req := httptest.NewRequest(http.MethodGet, "/api/v1/receipts/"+repo.receipt.ID.String(), nil)
+        req = setRouteParam(req, "id", repo.receipt.ID.String())
+        req = req.WithContext(ctxhelper.SetUserID(req.Context(), owner.String()))
+        rr := httptest.NewRecorder()
+        h.GetReceipt(rr, req)
+        if rr.Code != http.StatusOK { t.Fatalf("status = %d", rr.Code) }
+        return rr.Header().Get("ETag")
+    }
+
+    first := get()
+    if first == "" || first != get() { t.Fatalf("ETag instável ou ausente: %q", first) }
+    later := at.Add(time.Second)
+    repo.receipt.UpdatedAt = &later
+    if get() == first { t.Fatal("ETag deveria mudar com updated_at") }
+}
@@ -7,6 +7,8 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,9 +16,12 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"recibofast/internal/apierror"
 	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
 	"recibofast/internal/logging"
 	"recibofast/internal/models"
+	"recibofast/internal/render"
 	"recibofast/internal/services"
 )
 
@@ -43,21 +48,18 @@ func (h *IncomeHandlers) CreateIncome(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req models.IncomeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	income, err := h.incomeService.CreateIncome(userID, &req)
+	income, err := h.incomeService.CreateIncome(r.Context(), userID, &req)
 	if err != nil {
 		h.log.Error("erro ao criar receita", logging.Field{Key: "error", Val: err.Error()})
 		h.jsonError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(income)
+	render.Created(w, r, "/api/v1/incomes/"+income.ID.String(), income)
 }
 
 // GetIncome busca uma receita por ID
@@ -75,9 +77,11 @@ func (h *IncomeHandlers) GetIncome(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	income, err := h.incomeService.GetIncome(id, userID)
+	// valor_corrigido: saldo com multa e juros até hoje, no fuso do usuário
+	loc := locale.FromContext(r.Context()).Location
+	detail, err := h.incomeService.GetIncomeDetail(r.Context(), id, userID, time.Now(), loc)
 	if err != nil {
-		if err == models.ErrIncomeNotFound {
+		if errors.Is(err, models.ErrIncomeNotFound) {
 			h.jsonError(w, http.StatusNotFound, "receita não encontrada")
 			return
 		}
@@ -86,8 +90,14 @@ func (h *IncomeHandlers) GetIncome(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(income)
+	// Com saldo e vencimento o valor corrigido pode mudar a cada dia sem mudar a versão: no lugar
+	// do ETag de versão vai um do conteúdo (a versão segue no campo version)
+	if detail.Encargos.SaldoPrincipal == 0 || detail.DueDate == nil {
+		w.Header().Set("ETag", incomeETag(&detail.Income))
+	} else if etag := contentETag(r, detail); etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	render.JSON(w, r, http.StatusOK, detail)
 }
 
 // UpdateIncome atualiza uma receita existente
@@ -106,24 +116,109 @@ func (h *IncomeHandlers) UpdateIncome(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req models.IncomeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Version, err = resolveIncomeVersion(r, req.Version); err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	income, err := h.incomeService.UpdateIncome(id, userID, &req)
+	income, err := h.incomeService.UpdateIncome(r.Context(), id, userID, &req)
 	if err != nil {
 		if err == models.ErrIncomeNotFound {
 			h.jsonError(w, http.StatusNotFound, "receita não encontrada")
 			return
 		}
+		if h.writeVersionError(w, err) {
+			return
+		}
 		h.log.Error("erro ao atualizar receita", logging.Field{Key: "error", Val: err.Error()})
 		h.jsonError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(income)
+	w.Header().Set("ETag", incomeETag(income))
+	render.JSON(w, r, http.StatusOK, income)
+}
+
+// PatchIncome atualiza parcialmente uma receita (somente os campos enviados)
+// PATCH /api/v1/incomes/{id}
+func (h *IncomeHandlers) PatchIncome(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	var req models.IncomePatchRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Version, err = resolveIncomeVersion(r, req.Version); err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	income, err := h.incomeService.PatchIncome(r.Context(), id, userID, &req)
+	if err != nil {
+		if err == models.ErrIncomeNotFound {
+			h.jsonError(w, http.StatusNotFound, "receita não encontrada")
+			return
+		}
+		if h.writeVersionError(w, err) {
+			return
+		}
+		h.log.Error("erro ao atualizar receita", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("ETag", incomeETag(income))
+	render.JSON(w, r, http.StatusOK, income)
+}
+
+// DuplicateIncome cria uma cópia da receita para a próxima competência
+// POST /api/v1/incomes/{id}/duplicate
+func (h *IncomeHandlers) DuplicateIncome(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Corpo opcional: sem ajustes, herda tudo e avança competência e vencimento em um mês
+	var req models.IncomeCopyRequest
+	if r.ContentLength != 0 {
+		if !decodeOptionalJSON(w, r, &req) {
+			return
+		}
+	}
+
+	income, err := h.incomeService.DuplicateIncome(r.Context(), id, userID, &req)
+	if err != nil {
+		if err == models.ErrIncomeNotFound {
+			h.jsonError(w, http.StatusNotFound, "receita não encontrada")
+			return
+		}
+		h.log.Error("erro ao duplicar receita", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	render.Created(w, r, "/api/v1/incomes/"+income.ID.String(), income)
 }
 
 // DeleteIncome remove uma receita (soft delete)
@@ -141,7 +236,7 @@ func (h *IncomeHandlers) DeleteIncome(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.incomeService.DeleteIncome(id, userID)
+	err = h.incomeService.DeleteIncome(r.Context(), id, userID)
 	if err != nil {
 		if err == models.ErrIncomeNotFound {
 			h.jsonError(w, http.StatusNotFound, "receita não encontrada")
@@ -169,6 +264,7 @@ func (h *IncomeHandlers) ListIncomes(w http.ResponseWriter, r *http.Request) {
 		Status:      strings.TrimSpace(r.URL.Query().Get("status")),
 		Categoria:   strings.TrimSpace(r.URL.Query().Get("categoria")),
 		Competencia: strings.TrimSpace(r.URL.Query().Get("competencia")),
+		Tag:         strings.TrimSpace(r.URL.Query().Get("tag")),
 		SortField:   strings.TrimSpace(r.URL.Query().Get("sort_field")),
 		SortOrder:   strings.TrimSpace(r.URL.Query().Get("sort_order")),
 	}
@@ -186,6 +282,14 @@ func (h *IncomeHandlers) ListIncomes(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Cursor opaco (envelope Page[T]) tem precedência sobre page
+	if page, ok, err := pageFromCursor(r); err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if ok {
+		filter.Page = page
+	}
+
 	// Parse contract_id
 	if contractIDStr := r.URL.Query().Get("contract_id"); contractIDStr != "" {
 		if contractID, err := uuid.Parse(contractIDStr); err == nil {
@@ -193,6 +297,13 @@ func (h *IncomeHandlers) ListIncomes(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Parse payer_id
+	if payerIDStr := r.URL.Query().Get("payer_id"); payerIDStr != "" {
+		if payerID, err := uuid.Parse(payerIDStr); err == nil {
+			filter.PayerID = &payerID
+		}
+	}
+
 	// Parse date filters
 	if dueDateFromStr := r.URL.Query().Get("due_date_from"); dueDateFromStr != "" {
 		if dueDateFrom, err := time.Parse(time.RFC3339, dueDateFromStr); err == nil {
@@ -208,26 +319,114 @@ func (h *IncomeHandlers) ListIncomes(w http.ResponseWriter, r *http.Request) {
 
 	// Parse valor filters
 	if valorMinStr := r.URL.Query().Get("valor_min"); valorMinStr != "" {
-		if valorMin, err := strconv.ParseFloat(valorMinStr, 64); err == nil {
+		if valorMin, err := models.ParseMoney(valorMinStr); err == nil {
 			filter.ValorMin = &valorMin
 		}
 	}
 
 	if valorMaxStr := r.URL.Query().Get("valor_max"); valorMaxStr != "" {
-		if valorMax, err := strconv.ParseFloat(valorMaxStr, 64); err == nil {
+		if valorMax, err := models.ParseMoney(valorMaxStr); err == nil {
 			filter.ValorMax = &valorMax
 		}
 	}
 
-	response, err := h.incomeService.ListIncomes(userID, filter)
+	response, err := h.incomeService.ListIncomes(r.Context(), userID, filter)
 	if err != nil {
 		h.log.Error("erro ao listar receitas", logging.Field{Key: "error", Val: err.Error()})
 		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
 		return
 	}
 
+	writeList(w, r, response, models.NewPage(response.Incomes, response.Total, response.Page, response.PerPage))
+}
+
+// maxImportUploadBytes limita o tamanho do CSV enviado na importação
+const maxImportUploadBytes = 5 << 20
+
+// ImportIncomes importa receitas de um CSV (multipart "file" ou corpo text/csv).
+// POST /api/v1/incomes/import?dry_run=true
+// O mapeamento de colunas vem no campo "mapping" (JSON {"campo": "Cabeçalho"}), via form ou query.
+func (h *IncomeHandlers) ImportIncomes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportUploadBytes)
+
+	var file io.Reader = r.Body
+	mappingRaw := r.URL.Query().Get("mapping")
+	dryRunRaw := r.URL.Query().Get("dry_run")
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxImportUploadBytes); err != nil {
+			h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+			return
+		}
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, models.ErrImportFileRequired.Error())
+			return
+		}
+		defer f.Close()
+		file = f
+		if v := r.FormValue("mapping"); v != "" {
+			mappingRaw = v
+		}
+		if v := r.FormValue("dry_run"); v != "" {
+			dryRunRaw = v
+		}
+	}
+
+	mapping := models.IncomeImportMapping{}
+	if mappingRaw != "" {
+		if err := json.Unmarshal([]byte(mappingRaw), &mapping); err != nil {
+			h.jsonError(w, http.StatusBadRequest, models.ErrInvalidImportMapping.Error())
+			return
+		}
+	}
+	dryRun, _ := strconv.ParseBool(dryRunRaw)
+
+	result, err := h.incomeService.ImportIncomes(r.Context(), userID, file, mapping, dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrImportHasErrors):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(result)
+		case errors.Is(err, models.ErrInvalidImportMapping), errors.Is(err, models.ErrImportEmpty),
+			errors.Is(err, models.ErrImportTooManyRows), errors.Is(err, models.ErrImportMissingColumn),
+			errors.Is(err, models.ErrPayerNotFound), errors.Is(err, models.ErrImportInvalidCSV):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao importar receitas", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	if !dryRun {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+// IncomeStats totais e saldos das receitas por status (GET /api/v1/incomes/stats)
+func (h *IncomeHandlers) IncomeStats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+
+	stats, err := h.incomeService.IncomeStats(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao calcular estatísticas de receitas", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, stats)
 }
 
 // AddPayment adiciona um pagamento a uma receita
@@ -239,32 +438,78 @@ func (h *IncomeHandlers) AddPayment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req models.PaymentRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	response, err := h.incomeService.AddPayment(userID, &req)
+	response, err := h.incomeService.AddPayment(r.Context(), userID, &req)
 	if err != nil {
-		if err == models.ErrIncomeNotFound {
+		// Falhas inesperadas respondem 500: o Idempotency não guarda a resposta e a chave pode ser repetida
+		switch {
+		case errors.Is(err, models.ErrIncomeNotFound):
 			h.jsonError(w, http.StatusNotFound, "receita não encontrada")
-			return
+		case errors.Is(err, models.ErrIncomeAlreadyPaid):
+			h.jsonError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, models.ErrInsufficientAmount):
+			h.jsonError(w, http.StatusBadRequest, "valor do pagamento excede o saldo devedor")
+		case errors.Is(err, models.ErrIncomeIDRequired), errors.Is(err, models.ErrValorInvalid),
+			errors.Is(err, models.ErrInvalidOverpaymentMode), errors.Is(err, models.ErrInvalidDateFormat),
+			errors.Is(err, models.ErrPaymentMethodNotFound), errors.Is(err, models.ErrPaymentMethodArchived):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao adicionar pagamento", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
 		}
-		if err == models.ErrInsufficientAmount {
+		return
+	}
+
+	render.Created(w, r, "/api/v1/payments/"+response.Payment.ID.String(), response)
+}
+
+// UpdatePayment corrige um pagamento (PUT /api/v1/payments/{id})
+func (h *IncomeHandlers) UpdatePayment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	var req models.PaymentUpdateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	response, err := h.incomeService.UpdatePayment(r.Context(), id, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPaymentNotFound):
+			h.jsonError(w, http.StatusNotFound, "pagamento não encontrado")
+		case errors.Is(err, models.ErrPaymentAlreadyReversed):
+			h.jsonError(w, http.StatusConflict, "pagamento estornado não pode ser alterado")
+		case errors.Is(err, models.ErrCreditPaymentLocked):
+			h.jsonError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, models.ErrInsufficientAmount):
 			h.jsonError(w, http.StatusBadRequest, "valor do pagamento excede o saldo devedor")
-			return
+		case errors.Is(err, models.ErrValorInvalid), errors.Is(err, models.ErrInvalidDateFormat),
+			errors.Is(err, models.ErrPaymentMethodNotFound), errors.Is(err, models.ErrPaymentMethodArchived):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao atualizar pagamento", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
 		}
-		h.log.Error("erro ao adicionar pagamento", logging.Field{Key: "error", Val: err.Error()})
-		h.jsonError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(response)
+	render.JSON(w, r, http.StatusOK, response)
 }
 
-// GetIncomePayments busca todos os pagamentos de uma receita
+// GetIncomePayments busca todos os pagamentos de uma receita (GET /api/v1/incomes/{id}/payments?method_id=)
 func (h *IncomeHandlers) GetIncomePayments(w http.ResponseWriter, r *http.Request) {
 	userID, ok := h.getUserID(r)
 	if !ok {
@@ -279,7 +524,7 @@ func (h *IncomeHandlers) GetIncomePayments(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	payments, err := h.incomeService.GetIncomePayments(id, userID)
+	payments, err := h.incomeService.GetIncomePayments(r.Context(), id, userID)
 	if err != nil {
 		if err == models.ErrIncomeNotFound {
 			h.jsonError(w, http.StatusNotFound, "receita não encontrada")
@@ -290,15 +535,118 @@ func (h *IncomeHandlers) GetIncomePayments(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Filtro opcional pela forma de pagamento do catálogo (?method_id=)
+	if v := r.URL.Query().Get("method_id"); v != "" {
+		methodID, err := uuid.Parse(v)
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, "method_id inválido")
+			return
+		}
+		filtered := []models.Payment{}
+		for _, p := range payments {
+			if p.MethodID != nil && *p.MethodID == methodID {
+				filtered = append(filtered, p)
+			}
+		}
+		payments = filtered
+	}
+
+	writeList(w, r, map[string]interface{}{"payments": payments}, models.NewPage(payments, len(payments), 1, len(payments)))
+}
+
+// SimulatePayment calcula quanto pagar em uma data (encargos, saldo e status resultante)
+// GET /api/v1/incomes/{id}/simulate-payment?date=AAAA-MM-DD&valor=
+func (h *IncomeHandlers) SimulatePayment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Sem data, simula o pagamento hoje no fuso do usuário
+	loc := locale.FromContext(r.Context()).Location
+	payDate := time.Now().In(loc)
+	if v := r.URL.Query().Get("date"); v != "" {
+		payDate, err = time.ParseInLocation("2006-01-02", v, loc)
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, "data inválida (use AAAA-MM-DD)")
+			return
+		}
+	}
+	var valor *models.Money
+	if v := strings.TrimSpace(r.URL.Query().Get("valor")); v != "" {
+		f, err := models.ParseMoney(strings.Replace(v, ",", ".", 1))
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, "valor inválido")
+			return
+		}
+		valor = &f
+	}
+
+	sim, err := h.incomeService.SimulatePayment(r.Context(), id, userID, payDate, valor, loc)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrIncomeNotFound):
+			h.jsonError(w, http.StatusNotFound, "receita não encontrada")
+		case errors.Is(err, models.ErrValorInvalid), errors.Is(err, models.ErrInsufficientAmount):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro ao simular pagamento", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"payments": payments,
-	})
+	json.NewEncoder(w).Encode(sim)
 }
 
 // Métodos auxiliares
 
 // getUserID extrai o ID do usuário do contexto da requisição
+// incomeETag versão da receita como ETag forte (usada no If-Match de PUT/PATCH)
+func incomeETag(in *models.Income) string {
+	return `"` + strconv.FormatInt(in.Version, 10) + `"`
+}
+
+// resolveIncomeVersion obtém a versão esperada do If-Match ou do campo version do corpo.
+// Docstring: aceita "3", W/"3" ou 3; se ambos vierem, precisam coincidir.
+func resolveIncomeVersion(r *http.Request, body *int64) (*int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return body, nil
+	}
+	v, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 64)
+	if err != nil || v < 1 {
+		return nil, errors.New("If-Match inválido: informe a versão (ETag) da receita")
+	}
+	if body != nil && *body != v {
+		return nil, errors.New("If-Match e version divergem")
+	}
+	return &v, nil
+}
+
+// writeVersionError responde erros de concorrência otimista; false se err for de outro tipo
+func (h *IncomeHandlers) writeVersionError(w http.ResponseWriter, err error) bool {
+	var conflict *models.IncomeVersionConflictError
+	switch {
+	case errors.As(err, &conflict):
+		w.Header().Set("ETag", incomeETag(conflict.Current))
+		apierror.WriteCode(w, http.StatusConflict, "version_conflict", conflict.Error(), map[string]interface{}{"current": conflict.Current})
+	case errors.Is(err, models.ErrIncomeVersionRequired):
+		h.jsonError(w, http.StatusPreconditionRequired, err.Error())
+	default:
+		return false
+	}
+	return true
+}
+
 func (h *IncomeHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
 	// Extrai o user_id do contexto (definido pelo middleware SupabaseAuth)
 	userIDStr, ok := ctxhelper.GetUserID(r.Context())
@@ -317,9 +665,5 @@ func (h *IncomeHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
 
 // jsonError envia uma resposta de erro em JSON
 func (h *IncomeHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]string{
-		"error": message,
-	})
+	apierror.Write(w, statusCode, message)
 }
\ No newline at end of file
@@ -10,16 +10,21 @@ import (
     "bytes"
     "encoding/json"
     "errors"
+    "fmt"
+    "io"
     "net/http"
     "net/http/httptest"
+    "strings"
     "testing"
     "time"
 
     "github.com/go-chi/chi/v5"
     "github.com/google/uuid"
     ctxhelper "recibofast/internal/context"
+    "recibofast/internal/locale"
     "recibofast/internal/logging"
     "recibofast/internal/models"
+    "recibofast/internal/render"
     "recibofast/internal/services"
 )
 
@@ -40,33 +45,74 @@ type fakeIncomeService struct {
     listResp *models.IncomeResponse
     listErr  error
 
+    statsResp *models.IncomeStats
+    statsErr  error
+
     addPayResp *models.PaymentResponse
     addPayErr  error
 
     getPaysResp []models.Payment
     getPaysErr  error
+
+    importResp *models.IncomeImportResult
+    importErr  error
+    importDryRun bool
+
+    dupResp *models.Income
+    dupErr  error
+
+    patchResp *models.Income
+    patchErr  error
+
+    updateReq *models.IncomeRequest
+    simResp *models.PaymentSimulation
+    simErr  error
 }
 
-func (f *fakeIncomeService) CreateIncome(ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error) {
+func (f *fakeIncomeService) CreateIncome(ctx context.Context, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error) {
     return f.createResp, f.createErr
 }
-func (f *fakeIncomeService) GetIncome(id, ownerID uuid.UUID) (*models.Income, error) {
+func (f *fakeIncomeService) ImportIncomes(ctx context.Context, ownerID uuid.UUID, r io.Reader, mapping models.IncomeImportMapping, dryRun bool) (*models.IncomeImportResult, error) {
+    f.importDryRun = dryRun
+    return f.importResp, f.importErr
+}
+func (f *fakeIncomeService) DuplicateIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeCopyRequest) (*models.Income, error) {
+    return f.dupResp, f.dupErr
+}
+func (f *fakeIncomeService) GetIncome(ctx context.Context, id, ownerID uuid.UUID) (*models.Income, error) {
     return f.getResp, f.getErr
 }
-func (f *fakeIncomeService) UpdateIncome(id, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error) {
+func (f *fakeIncomeService) GetIncomeDetail(ctx context.Context, id, ownerID uuid.UUID, at time.Time, loc *time.Location) (*models.IncomeDetail, error) {
+    if f.getErr != nil { return nil, f.getErr }
+    return models.CorrectIncome(f.getResp, at, models.DefaultFeePolicy, loc), nil
+}
+func (f *fakeIncomeService) UpdateIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error) {
+    f.updateReq = req
     return f.updateResp, f.updateErr
 }
-func (f *fakeIncomeService) DeleteIncome(id, ownerID uuid.UUID) error { return f.deleteErr }
-func (f *fakeIncomeService) ListIncomes(ownerID uuid.UUID, filter *models.IncomeFilter) (*models.IncomeResponse, error) {
+func (f *fakeIncomeService) PatchIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomePatchRequest) (*models.Income, error) {
+    return f.patchResp, f.patchErr
+}
+func (f *fakeIncomeService) DeleteIncome(ctx context.Context, id, ownerID uuid.UUID) error { return f.deleteErr }
+func (f *fakeIncomeService) ListIncomes(ctx context.Context, ownerID uuid.UUID, filter *models.IncomeFilter) (*models.IncomeResponse, error) {
     return f.listResp, f.listErr
 }
-func (f *fakeIncomeService) AddPayment(ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error) {
+func (f *fakeIncomeService) IncomeStats(ctx context.Context, ownerID uuid.UUID) (*models.IncomeStats, error) {
+    return f.statsResp, f.statsErr
+}
+func (f *fakeIncomeService) AddPayment(ctx context.Context, ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error) {
+    return f.addPayResp, f.addPayErr
+}
+func (f *fakeIncomeService) UpdatePayment(ctx context.Context, paymentID, ownerID uuid.UUID, req *models.PaymentUpdateRequest) (*models.PaymentResponse, error) {
     return f.addPayResp, f.addPayErr
 }
-func (f *fakeIncomeService) GetIncomePayments(incomeID, ownerID uuid.UUID) ([]models.Payment, error) {
+func (f *fakeIncomeService) GetIncomePayments(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.Payment, error) {
     return f.getPaysResp, f.getPaysErr
 }
-func (f *fakeIncomeService) CalculateIncomeStatus(income *models.Income) string { return models.StatusPendente }
+func (f *fakeIncomeService) SimulatePayment(ctx context.Context, id, ownerID uuid.UUID, payDate time.Time, valor *models.Money, loc *time.Location) (*models.PaymentSimulation, error) {
+    return f.simResp, f.simErr
+}
+func (f *fakeIncomeService) CalculateIncomeStatus(income *models.Income, loc *time.Location) string { return models.StatusPendente }
 
 func newIncomeHandlersForTest(svc services.IncomeService) *IncomeHandlers {
     return NewIncomeHandlers(svc, logging.NewLogger("dev"))
@@ -80,11 +126,11 @@ func setRouteParam(r *http.Request, key, val string) *http.Request {
 
 func TestCreateIncome_Success(t *testing.T) {
     ownerID := uuid.New()
-    svc := &fakeIncomeService{createResp: &models.Income{ID: uuid.New(), OwnerID: ownerID, Valor: 100, Status: models.StatusPendente}}
+    svc := &fakeIncomeService{createResp: &models.Income{ID: uuid.New(), OwnerID: ownerID, Valor: models.NewMoney(100), Status: models.StatusPendente}}
     h := newIncomeHandlersForTest(svc)
 
     cat := "Serviços"
-    payload := models.IncomeRequest{Categoria: &cat, Competencia: "2025-09", Valor: 100}
+    payload := models.IncomeRequest{Categoria: &cat, Competencia: "2025-09", Valor: models.NewMoney(100)}
     b, _ := json.Marshal(payload)
 
     req := httptest.NewRequest(http.MethodPost, "/api/v1/incomes", bytes.NewReader(b))
@@ -99,6 +145,27 @@ func TestCreateIncome_Success(t *testing.T) {
     if out.OwnerID != ownerID { t.Fatalf("owner = %s, want %s", out.OwnerID, ownerID) }
 }
 
+func TestCreateIncome_ValidationFailed(t *testing.T) {
+    svc := &fakeIncomeService{}
+    h := newIncomeHandlersForTest(svc)
+
+    req := httptest.NewRequest(http.MethodPost, "/api/v1/incomes", bytes.NewReader([]byte(`{"competencia":"2025-09","valor":-10}`)))
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
+    rr := httptest.NewRecorder()
+
+    h.CreateIncome(rr, req)
+
+    if rr.Code != http.StatusBadRequest { t.Fatalf("status = %d, want %d", rr.Code, http.StatusBadRequest) }
+    var out struct {
+        Code    string `json:"code"`
+        Details []struct{ Field, Message string } `json:"details"`
+    }
+    if err := json.NewDecoder(rr.Body).Decode(&out); err != nil { t.Fatalf("decode: %v", err) }
+    if out.Code != "validation_failed" || len(out.Details) != 1 || out.Details[0].Message != "valor: deve ser maior que 0" {
+        t.Fatalf("corpo = %+v", out)
+    }
+}
+
 func TestCreateIncome_Unauthorized(t *testing.T) {
     svc := &fakeIncomeService{}
     h := newIncomeHandlersForTest(svc)
@@ -126,12 +193,52 @@ func TestGetIncome_NotFound(t *testing.T) {
     if rr.Code != http.StatusNotFound { t.Fatalf("status = %d, want %d", rr.Code, http.StatusNotFound) }
 }
 
+func TestGetIncome_ValorCorrigido(t *testing.T) {
+    // Vencimento gravado à meia-noite UTC, 40 dias civis antes de hoje no fuso padrão
+    y, m, d := time.Now().In(locale.Default().Location).Date()
+    due := time.Date(y, m, d-40, 0, 0, 0, 0, time.UTC)
+    paid := due
+    cases := []struct {
+        name    string
+        income  *models.Income
+        version bool
+    }{
+        {"vencida", &models.Income{ID: uuid.New(), Valor: models.NewMoney(1000), Status: models.StatusVencido, DueDate: &due, Version: 3}, false},
+        {"quitada", &models.Income{ID: uuid.New(), Valor: models.NewMoney(1000), TotalPago: models.NewMoney(1000), Status: models.StatusPago, DueDate: &paid, Version: 3}, true},
+    }
+    for _, tc := range cases {
+        h := newIncomeHandlersForTest(&fakeIncomeService{getResp: tc.income})
+        req := httptest.NewRequest(http.MethodGet, "/api/v1/incomes/"+tc.income.ID.String(), nil)
+        req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
+        req = setRouteParam(req, "id", tc.income.ID.String())
+        rr := httptest.NewRecorder()
+        h.GetIncome(rr, req)
+
+        if rr.Code != http.StatusOK { t.Fatalf("%s: status = %d", tc.name, rr.Code) }
+        var out models.IncomeDetail
+        if err := json.NewDecoder(rr.Body).Decode(&out); err != nil { t.Fatalf("%s: decode: %v", tc.name, err) }
+        if out.Version != 3 { t.Fatalf("%s: versão deveria seguir no corpo, got %d", tc.name, out.Version) }
+        // com cobranças o ETag é do conteúdo (não vale como If-Match)
+        etag := rr.Header().Get("ETag")
+        if etag == "" { t.Fatalf("%s: ETag ausente", tc.name) }
+        if got := etag == `"3"`; got != tc.version { t.Fatalf("%s: ETag de versão = %v, want %v (%s)", tc.name, got, tc.version, etag) }
+        if tc.version {
+            if out.ValorCorrigido != 0 { t.Fatalf("%s: receita quitada sem valor a corrigir: %+v", tc.name, out) }
+            continue
+        }
+        // 40 dias: multa 2% (20,00) e juros 1% a.m. pro rata (13,33)
+        if out.Encargos.Multa != models.NewMoney(20) || out.Encargos.Juros != models.NewMoney(13.33) || out.ValorCorrigido != models.NewMoney(1033.33) {
+            t.Fatalf("%s: valor corrigido inesperado: %+v", tc.name, out)
+        }
+    }
+}
+
 func TestAddPayment_Insufficient(t *testing.T) {
     svc := &fakeIncomeService{addPayErr: models.ErrInsufficientAmount}
     h := newIncomeHandlersForTest(svc)
 
     ownerID := uuid.New()
-    reqBody := models.PaymentRequest{IncomeID: uuid.New(), Valor: 200}
+    reqBody := models.PaymentRequest{IncomeID: uuid.New(), Valor: models.NewMoney(200)}
     b, _ := json.Marshal(reqBody)
 
     req := httptest.NewRequest(http.MethodPost, "/api/v1/incomes/payments", bytes.NewReader(b))
@@ -143,6 +250,20 @@ func TestAddPayment_Insufficient(t *testing.T) {
     if rr.Code != http.StatusBadRequest { t.Fatalf("status = %d, want %d", rr.Code, http.StatusBadRequest) }
 }
 
+func TestAddPayment_UnexpectedErrorIs500(t *testing.T) {
+    svc := &fakeIncomeService{addPayErr: fmt.Errorf("erro ao adicionar pagamento: %w", errors.New("conn reset by peer"))}
+    h := newIncomeHandlersForTest(svc)
+
+    b, _ := json.Marshal(models.PaymentRequest{IncomeID: uuid.New(), Valor: models.NewMoney(20)})
+    req := httptest.NewRequest(http.MethodPost, "/api/v1/payments", bytes.NewReader(b))
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
+    rr := httptest.NewRecorder()
+    h.AddPayment(rr, req)
+
+    if rr.Code != http.StatusInternalServerError { t.Fatalf("status = %d, want 500", rr.Code) }
+    if strings.Contains(rr.Body.String(), "conn reset") { t.Fatalf("erro interno vazou na resposta: %s", rr.Body) }
+}
+
 func TestDeleteIncome_Success(t *testing.T) {
     svc := &fakeIncomeService{}
     h := newIncomeHandlersForTest(svc)
@@ -162,7 +283,7 @@ func TestDeleteIncome_Success(t *testing.T) {
 func TestListIncomes_Success(t *testing.T) {
     ownerID := uuid.New()
     now := time.Now().UTC()
-    incomes := []models.Income{{ID: uuid.New(), OwnerID: ownerID, Competencia: "2025-09", Valor: 100, Status: models.StatusPendente, CreatedAt: &[]time.Time{now}[0]}}
+    incomes := []models.Income{{ID: uuid.New(), OwnerID: ownerID, Competencia: "2025-09", Valor: models.NewMoney(100), Status: models.StatusPendente, CreatedAt: &[]time.Time{now}[0]}}
     svc := &fakeIncomeService{listResp: &models.IncomeResponse{Incomes: incomes, Total: 1, Page: 1, PerPage: 10, TotalPages: 1}}
     h := newIncomeHandlersForTest(svc)
 
@@ -179,6 +300,184 @@ func TestListIncomes_Success(t *testing.T) {
     if out.Total != 1 || len(out.Incomes) != 1 { t.Fatalf("unexpected resp: %+v", out) }
 }
 
+func TestListIncomes_PageEnvelope(t *testing.T) {
+    ownerID := uuid.New()
+    incomes := []models.Income{{ID: uuid.New(), OwnerID: ownerID, Competencia: "2025-09", Valor: models.NewMoney(100)}}
+    svc := &fakeIncomeService{listResp: &models.IncomeResponse{Incomes: incomes, Total: 25, Page: 1, PerPage: 10, TotalPages: 3}}
+    h := newIncomeHandlersForTest(svc)
+
+    req := httptest.NewRequest(http.MethodGet, "/api/v1/incomes?per_page=10", nil)
+    req.Header.Set("Accept-Profile", "page")
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), ownerID.String()))
+    rr := httptest.NewRecorder()
+
+    h.ListIncomes(rr, req)
+
+    if rr.Code != http.StatusOK { t.Fatalf("status = %d, want %d", rr.Code, http.StatusOK) }
+    var out models.Page[models.Income]
+    if err := json.NewDecoder(rr.Body).Decode(&out); err != nil { t.Fatalf("decode: %v", err) }
+    if len(out.Items) != 1 || out.Total != 25 || out.TotalPages != 3 || out.NextCursor == nil {
+        t.Fatalf("envelope inesperado: %+v", out)
+    }
+    if p, err := models.DecodePageCursor(*out.NextCursor); err != nil || p != 2 { t.Fatalf("next_cursor = %v (%v), want página 2", p, err) }
+}
+
+func TestListIncomes_ResponseEnvelope(t *testing.T) {
+    ownerID := uuid.New()
+    incomes := []models.Income{{ID: uuid.New(), OwnerID: ownerID, Competencia: "2025-09", Valor: models.NewMoney(100)}}
+    svc := &fakeIncomeService{listResp: &models.IncomeResponse{Incomes: incomes, Total: 25, Page: 1, PerPage: 10, TotalPages: 3}}
+    h := newIncomeHandlersForTest(svc)
+
+    req := httptest.NewRequest(http.MethodGet, "/api/v1/incomes?per_page=10", nil)
+    req.Header.Set("Accept-Profile", "envelope")
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), ownerID.String()))
+    rr := httptest.NewRecorder()
+    rr.Header().Set("X-Request-ID", "req-42")
+
+    h.ListIncomes(rr, req)
+
+    if rr.Code != http.StatusOK || rr.Header().Get("Content-Profile") != "envelope" { t.Fatalf("status = %d, profile = %q", rr.Code, rr.Header().Get("Content-Profile")) }
+    var out struct {
+        Data      []models.Income `json:"data"`
+        Meta      render.Meta     `json:"meta"`
+        Links     render.Links    `json:"links"`
+        RequestID string          `json:"request_id"`
+    }
+    if err := json.NewDecoder(rr.Body).Decode(&out); err != nil { t.Fatalf("decode: %v", err) }
+    if len(out.Data) != 1 || out.Meta.Total != 25 || out.RequestID != "req-42" { t.Fatalf("envelope inesperado: %+v", out) }
+    if out.Links.Self != "/api/v1/incomes?per_page=10" || out.Links.Next == nil || *out.Links.Next != "/api/v1/incomes?page=2&per_page=10" || out.Links.Prev != nil {
+        t.Fatalf("links inesperados: %+v", out.Links)
+    }
+}
+
+func TestListIncomes_ETag(t *testing.T) {
+    ownerID := uuid.New()
+    incomes := []models.Income{{ID: uuid.New(), OwnerID: ownerID, Competencia: "2025-09", Valor: models.NewMoney(100), Status: models.StatusPendente}}
+    svc := &fakeIncomeService{listResp: &models.IncomeResponse{Incomes: incomes, Total: 1, Page: 1, PerPage: 10, TotalPages: 1}}
+    h := newIncomeHandlersForTest(svc)
+    etag := func(profile, requestID string) string {
+        req := httptest.NewRequest(http.MethodGet, "/api/v1/incomes", nil)
+        if profile != "" { req.Header.Set("Accept-Profile", profile) }
+        req = req.WithContext(ctxhelper.SetUserID(req.Context(), ownerID.String()))
+        rr := httptest.NewRecorder()
+        rr.Header().Set("X-Request-ID", requestID)
+        h.ListIncomes(rr, req)
+        return rr.Header().Get("ETag")
+    }
+
+    legacy := etag("", "a")
+    if legacy == "" || legacy != etag("", "b") { t.Fatalf("ETag ausente ou instável: %q", legacy) }
+    // o request_id do envelope não entra no ETag; o formato negociado entra
+    if env := etag("envelope", "a"); env != etag("envelope", "b") || env == legacy { t.Fatalf("ETag do envelope = %q (legado %q)", env, legacy) }
+
+    // status derivado na leitura (ex.: vencida à meia-noite) muda o ETag sem mudar version
+    incomes[0].Status = models.StatusVencido
+    if etag("", "a") == legacy { t.Fatal("ETag deveria mudar com o conteúdo") }
+}
+
+func TestListIncomes_Fields(t *testing.T) {
+    ownerID := uuid.New()
+    incomes := []models.Income{{ID: uuid.New(), OwnerID: ownerID, Competencia: "2025-09", Valor: models.NewMoney(100), Status: models.StatusPendente}}
+    h := newIncomeHandlersForTest(&fakeIncomeService{listResp: &models.IncomeResponse{Incomes: incomes, Total: 1, Page: 1, PerPage: 10, TotalPages: 1}})
+    list := func(query, profile string) (map[string]json.RawMessage, string) {
+        req := httptest.NewRequest(http.MethodGet, "/api/v1/incomes"+query, nil)
+        if profile != "" { req.Header.Set("Accept-Profile", profile) }
+        req = req.WithContext(ctxhelper.SetUserID(req.Context(), ownerID.String()))
+        rr := httptest.NewRecorder()
+        h.ListIncomes(rr, req)
+        if rr.Code != http.StatusOK { t.Fatalf("status = %d", rr.Code) }
+        var out map[string]json.RawMessage
+        if err := json.NewDecoder(rr.Body).Decode(&out); err != nil { t.Fatalf("decode: %v", err) }
+        return out, rr.Header().Get("ETag")
+    }
+    items := func(raw json.RawMessage) []map[string]interface{} {
+        var it []map[string]interface{}
+        if err := json.Unmarshal(raw, &it); err != nil || len(it) != 1 { t.Fatalf("itens inesperados: %s", raw) }
+        return it
+    }
+
+    full, fullETag := list("", "")
+    legacy, legacyETag := list("?fields=valor,status,inexistente", "")
+    // id entra sempre; campo desconhecido é ignorado; total e página seguem no formato legado
+    got := items(legacy["incomes"])[0]
+    if len(got) != 3 || got["id"] != incomes[0].ID.String() || got["status"] != models.StatusPendente || got["valor"] == nil {
+        t.Fatalf("item projetado = %v", got)
+    }
+    if string(legacy["total"]) != "1" || string(legacy["page"]) != "1" { t.Fatalf("paginação perdida: %v", legacy) }
+    if len(items(full["incomes"])[0]) <= 3 { t.Fatal("sem fields o item deveria vir inteiro") }
+    if legacyETag == fullETag { t.Fatal("ETag deveria variar com fields") }
+
+    env, _ := list("?fields=status", "envelope")
+    if got := items(env["data"])[0]; len(got) != 2 || got["status"] != models.StatusPendente { t.Fatalf("envelope projetado = %v", got) }
+    page, _ := list("?fields=status", "page")
+    if got := items(page["items"])[0]; len(got) != 2 { t.Fatalf("Page projetada = %v", got) }
+}
+
+func TestCreateIncome_ResponseEnvelope(t *testing.T) {
+    ownerID := uuid.New()
+    created := &models.Income{ID: uuid.New(), OwnerID: ownerID, Valor: models.NewMoney(100), Status: models.StatusPendente}
+    h := newIncomeHandlersForTest(&fakeIncomeService{createResp: created})
+
+    b, _ := json.Marshal(models.IncomeRequest{Competencia: "2025-09", Valor: models.NewMoney(100)})
+    req := httptest.NewRequest(http.MethodPost, "/api/v1/incomes", bytes.NewReader(b))
+    req.Header.Set("Accept-Profile", "envelope")
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), ownerID.String()))
+    rr := httptest.NewRecorder()
+
+    h.CreateIncome(rr, req)
+
+    self := "/api/v1/incomes/" + created.ID.String()
+    if rr.Code != http.StatusCreated || rr.Header().Get("Location") != self { t.Fatalf("status = %d, location = %q", rr.Code, rr.Header().Get("Location")) }
+    var out struct {
+        Data  models.Income `json:"data"`
+        Links render.Links  `json:"links"`
+    }
+    if err := json.NewDecoder(rr.Body).Decode(&out); err != nil { t.Fatalf("decode: %v", err) }
+    if out.Data.ID != created.ID || out.Links.Self != self { t.Fatalf("envelope inesperado: %+v", out) }
+}
+
+func TestListIncomes_LiteMode(t *testing.T) {
+    ownerID := uuid.New()
+    due := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+    cat := "Aluguel"
+    incomes := []models.Income{{ID: uuid.New(), OwnerID: ownerID, Competencia: "2026-10", Categoria: &cat, Valor: models.NewMoney(100), Status: models.StatusPendente, DueDate: &due}}
+    svc := &fakeIncomeService{listResp: &models.IncomeResponse{Incomes: incomes, Total: 1, Page: 1, PerPage: 10, TotalPages: 1}}
+    h := newIncomeHandlersForTest(svc)
+
+    for _, profile := range []string{"", "page"} {
+        req := httptest.NewRequest(http.MethodGet, "/api/v1/incomes", nil)
+        req.Header.Set(models.LiteHeader, "true")
+        req.Header.Set("Accept-Profile", profile)
+        req = req.WithContext(ctxhelper.SetUserID(req.Context(), ownerID.String()))
+        rr := httptest.NewRecorder()
+        h.ListIncomes(rr, req)
+
+        var out map[string]json.RawMessage
+        if err := json.NewDecoder(rr.Body).Decode(&out); err != nil { t.Fatalf("decode: %v", err) }
+        key := "incomes"
+        if profile == "page" { key = "items" }
+        var items []map[string]interface{}
+        if err := json.Unmarshal(out[key], &items); err != nil || len(items) != 1 { t.Fatalf("%q: itens = %s (%v)", profile, out[key], err) }
+        if len(items[0]) != 4 || items[0]["status"] != models.StatusPendente || items[0]["due_date"] == nil {
+            t.Fatalf("%q: projeção = %v, esperado id, valor, status e due_date", profile, items[0])
+        }
+        if string(out["total"]) != "1" || rr.Header().Get(models.LiteHeader) != "true" {
+            t.Fatalf("%q: total = %s, X-Lite = %q", profile, out["total"], rr.Header().Get(models.LiteHeader))
+        }
+    }
+}
+
+func TestListIncomes_InvalidCursor(t *testing.T) {
+    ownerID := uuid.New()
+    h := newIncomeHandlersForTest(&fakeIncomeService{listResp: &models.IncomeResponse{}})
+
+    req := httptest.NewRequest(http.MethodGet, "/api/v2/incomes?cursor=not-a-cursor", nil)
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), ownerID.String()))
+    rr := httptest.NewRecorder()
+    h.ListIncomes(rr, req)
+    if rr.Code != http.StatusBadRequest { t.Fatalf("status = %d, want %d", rr.Code, http.StatusBadRequest) }
+}
+
 func TestListIncomes_Error(t *testing.T) {
     ownerID := uuid.New()
     svc := &fakeIncomeService{listErr: errors.New("boom")}
@@ -205,10 +504,10 @@ func TestListIncomes_Unauthorized(t *testing.T) {
 func TestUpdateIncome_Success(t *testing.T) {
     ownerID := uuid.New()
     id := uuid.New()
-    svc := &fakeIncomeService{updateResp: &models.Income{ID: id, OwnerID: ownerID, Competencia: "2025-09", Valor: 150, Status: models.StatusParcial}}
+    svc := &fakeIncomeService{updateResp: &models.Income{ID: id, OwnerID: ownerID, Competencia: "2025-09", Valor: models.NewMoney(150), Status: models.StatusParcial}}
     h := newIncomeHandlersForTest(svc)
 
-    payload := models.IncomeRequest{Competencia: "2025-09", Valor: 150}
+    payload := models.IncomeRequest{Competencia: "2025-09", Valor: models.NewMoney(150)}
     b, _ := json.Marshal(payload)
     req := httptest.NewRequest(http.MethodPut, "/api/v1/incomes/"+id.String(), bytes.NewReader(b))
     req = req.WithContext(ctxhelper.SetUserID(req.Context(), ownerID.String()))
@@ -228,7 +527,7 @@ func TestUpdateIncome_NotFound(t *testing.T) {
     id := uuid.New()
     svc := &fakeIncomeService{updateErr: models.ErrIncomeNotFound}
     h := newIncomeHandlersForTest(svc)
-    payload := models.IncomeRequest{Competencia: "2025-09", Valor: 150}
+    payload := models.IncomeRequest{Competencia: "2025-09", Valor: models.NewMoney(150)}
     b, _ := json.Marshal(payload)
 
     req := httptest.NewRequest(http.MethodPut, "/api/v1/incomes/"+id.String(), bytes.NewReader(b))
@@ -244,7 +543,7 @@ func TestUpdateIncome_Unauthorized(t *testing.T) {
     id := uuid.New()
     svc := &fakeIncomeService{}
     h := newIncomeHandlersForTest(svc)
-    payload := models.IncomeRequest{Competencia: "2025-09", Valor: 150}
+    payload := models.IncomeRequest{Competencia: "2025-09", Valor: models.NewMoney(150)}
     b, _ := json.Marshal(payload)
 
     req := httptest.NewRequest(http.MethodPut, "/api/v1/incomes/"+id.String(), bytes.NewReader(b))
@@ -255,10 +554,46 @@ func TestUpdateIncome_Unauthorized(t *testing.T) {
     if rr.Code != http.StatusUnauthorized { t.Fatalf("status = %d, want %d", rr.Code, http.StatusUnauthorized) }
 }
 
+func TestUpdateIncome_IfMatchAndVersionConflict(t *testing.T) {
+    ownerID := uuid.New()
+    id := uuid.New()
+    current := &models.Income{ID: id, OwnerID: ownerID, Competencia: "2025-09", Valor: models.NewMoney(180), Version: 5}
+    svc := &fakeIncomeService{updateErr: &models.IncomeVersionConflictError{Current: current}}
+    h := newIncomeHandlersForTest(svc)
+    b, _ := json.Marshal(models.IncomeRequest{Competencia: "2025-09", Valor: models.NewMoney(150)})
+
+    put := func(ifMatch string) *httptest.ResponseRecorder {
+        req := httptest.NewRequest(http.MethodPut, "/api/v1/incomes/"+id.String(), bytes.NewReader(b))
+        if ifMatch != "" { req.Header.Set("If-Match", ifMatch) }
+        req = req.WithContext(ctxhelper.SetUserID(req.Context(), ownerID.String()))
+        req = setRouteParam(req, "id", id.String())
+        rr := httptest.NewRecorder()
+        h.UpdateIncome(rr, req)
+        return rr
+    }
+
+    rr := put(`W/"4"`)
+    if rr.Code != http.StatusConflict { t.Fatalf("status = %d, want %d", rr.Code, http.StatusConflict) }
+    if svc.updateReq.Version == nil || *svc.updateReq.Version != 4 { t.Fatalf("If-Match não repassado como versão") }
+    if rr.Header().Get("ETag") != `"5"` { t.Fatalf("ETag = %q, want \"5\"", rr.Header().Get("ETag")) }
+    var out struct {
+        Code    string `json:"code"`
+        Details struct{ Current models.Income `json:"current"` } `json:"details"`
+    }
+    if err := json.NewDecoder(rr.Body).Decode(&out); err != nil || out.Code != "version_conflict" || out.Details.Current.Version != 5 || out.Details.Current.Valor != models.NewMoney(180) {
+        t.Fatalf("corpo do conflito sem o estado atual: %+v (%v)", out, err)
+    }
+
+    if rr := put("abc"); rr.Code != http.StatusBadRequest { t.Fatalf("If-Match inválido: status = %d", rr.Code) }
+
+    svc.updateErr = models.ErrIncomeVersionRequired
+    if rr := put(""); rr.Code != http.StatusPreconditionRequired { t.Fatalf("sem versão: status = %d", rr.Code) }
+}
+
 func TestGetIncomePayments_Success(t *testing.T) {
     ownerID := uuid.New()
     id := uuid.New()
-    pays := []models.Payment{{ID: uuid.New(), IncomeID: id, Valor: 50}}
+    pays := []models.Payment{{ID: uuid.New(), IncomeID: id, Valor: models.NewMoney(50)}}
     svc := &fakeIncomeService{getPaysResp: pays}
     h := newIncomeHandlersForTest(svc)
 
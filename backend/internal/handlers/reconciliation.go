@@ -0,0 +1,178 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers da conciliação bancária (importação de extratos e sugestões de vínculo)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// ReconciliationHandlers importação de extratos e decisão das sugestões de conciliação
+type ReconciliationHandlers struct {
+	svc *services.ReconciliationService
+	log logging.Logger
+}
+
+// NewReconciliationHandlers cria uma nova instância dos handlers de conciliação
+func NewReconciliationHandlers(svc *services.ReconciliationService, log logging.Logger) *ReconciliationHandlers {
+	return &ReconciliationHandlers{svc: svc, log: log}
+}
+
+// POST /api/v1/reconciliation/import?format=ofx|csv
+// Docstring: extrato em multipart "file" ou no corpo; sem format, o tipo vem da extensão e do
+// conteúdo. Responde 201 com os créditos importados e as sugestões geradas.
+func (h *ReconciliationHandlers) Import(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportUploadBytes)
+
+	var file io.Reader = r.Body
+	var filename string
+	format := r.URL.Query().Get("format")
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxImportUploadBytes); err != nil {
+			h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+			return
+		}
+		f, fh, err := r.FormFile("file")
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, models.ErrStatementFileRequired.Error())
+			return
+		}
+		defer f.Close()
+		file, filename = f, fh.Filename
+		if v := r.FormValue("format"); v != "" {
+			format = v
+		}
+	}
+
+	out, err := h.svc.Import(r.Context(), userID, file, filename, format)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.jsonError(w, http.StatusRequestEntityTooLarge, "arquivo muito grande")
+			return
+		}
+		h.writeServiceError(w, "erro ao importar extrato", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(out)
+}
+
+// GET /api/v1/reconciliation/matches?status=suggested|confirmed|rejected
+func (h *ReconciliationHandlers) ListMatches(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.ListMatches(r.Context(), userID, r.URL.Query().Get("status"))
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar sugestões de conciliação", err)
+		return
+	}
+	writeList(w, r, items, models.NewPage(items, len(items), 1, len(items)))
+}
+
+// POST /api/v1/reconciliation/matches/{id}/confirm
+// Docstring: lança o crédito do extrato como pagamento da receita e descarta as demais sugestões
+// do mesmo lançamento.
+func (h *ReconciliationHandlers) ConfirmMatch(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	m, payment, err := h.svc.Confirm(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao confirmar conciliação", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"match": m, "payment": payment.Payment, "income": payment.Income, "credit": payment.Credit})
+}
+
+// POST /api/v1/reconciliation/matches/{id}/reject
+func (h *ReconciliationHandlers) RejectMatch(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	m, err := h.svc.Reject(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao rejeitar sugestão de conciliação", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *ReconciliationHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrMatchNotFound), errors.Is(err, models.ErrIncomeNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrMatchDecided), errors.Is(err, models.ErrStatementLineDone):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrStatementFileRequired), errors.Is(err, models.ErrStatementFormat),
+		errors.Is(err, models.ErrStatementInvalid), errors.Is(err, models.ErrStatementEmpty),
+		errors.Is(err, models.ErrStatementTooLarge), errors.Is(err, models.ErrInvalidMatchStatus):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrIncomeAlreadyPaid), errors.Is(err, models.ErrInsufficientAmount),
+		errors.Is(err, models.ErrValorInvalid):
+		h.jsonError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *ReconciliationHandlers) userAndID(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *ReconciliationHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ReconciliationHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,131 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers dos relatórios (mensal em JSON, PDF ou CSV e aging dos recebíveis)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// ReportHandlers relatórios do usuário
+type ReportHandlers struct {
+	svc *services.ReportService
+	log logging.Logger
+}
+
+// NewReportHandlers cria uma nova instância dos handlers de relatórios
+func NewReportHandlers(svc *services.ReportService, log logging.Logger) *ReportHandlers {
+	return &ReportHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/reports/monthly?competencia=AAAA-MM&format=json|pdf|csv
+// Docstring: sem competência usa o mês atual no fuso do usuário; format padrão json. PDF e CSV
+// vêm como anexo ("relatorio-AAAA-MM.pdf"), prontos para enviar ao cliente ou ao contador.
+func (h *ReportHandlers) MonthlyReport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	ls := locale.FromContext(r.Context())
+	competencia := r.URL.Query().Get("competencia")
+	if competencia == "" {
+		competencia = ls.Now().Format("2006-01")
+	}
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = models.ReportFormatJSON
+	}
+	if format != models.ReportFormatJSON && format != models.ReportFormatPDF && format != models.ReportFormatCSV {
+		h.jsonError(w, http.StatusBadRequest, models.ErrReportFormatInvalid.Error())
+		return
+	}
+
+	rep, err := h.svc.Monthly(r.Context(), userID, competencia, ls)
+	if err != nil {
+		if errors.Is(err, models.ErrReportCompetenciaInvalid) {
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.log.Error("erro ao gerar relatório mensal", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+
+	var content []byte
+	switch format {
+	case models.ReportFormatJSON:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(rep)
+		return
+	case models.ReportFormatPDF:
+		content = h.svc.RenderPDF(rep, ls)
+		w.Header().Set("Content-Type", "application/pdf")
+	case models.ReportFormatCSV:
+		if content, err = h.svc.RenderCSV(rep, ls); err != nil {
+			h.log.Error("erro ao gerar CSV do relatório mensal", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="relatorio-%s.%s"`, rep.Competencia, format))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Write(content)
+}
+
+// GET /api/v1/reports/aging?group_by=payer|contract
+// Docstring: saldo vencido em 0-30, 31-60, 61-90 e 90+ dias de atraso por pagador (padrão) ou
+// contrato, com o maior atraso e o atraso médio de cada grupo; os maiores devedores vêm primeiro.
+func (h *ReportHandlers) AgingReport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	rep, err := h.svc.Aging(r.Context(), userID, r.URL.Query().Get("group_by"), locale.FromContext(r.Context()))
+	if err != nil {
+		if errors.Is(err, models.ErrAgingGroupInvalid) {
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.log.Error("erro ao gerar relatório de aging", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rep)
+}
+
+// Auxiliares
+func (h *ReportHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *ReportHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,205 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers das regras de categorização de receitas (CRUD, ordenação e teste)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// RuleHandlers contém os handlers de regras de categorização
+type RuleHandlers struct {
+	ruleService services.RuleService
+	log         logging.Logger
+}
+
+// NewRuleHandlers cria uma nova instância dos handlers de regras
+func NewRuleHandlers(ruleService services.RuleService, log logging.Logger) *RuleHandlers {
+	return &RuleHandlers{ruleService: ruleService, log: log}
+}
+
+// GET /api/v1/rules
+func (h *RuleHandlers) ListRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	rules, err := h.ruleService.ListRules(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar regras", err)
+		return
+	}
+	writeList(w, r, map[string]interface{}{"items": rules}, models.NewPage(rules, len(rules), 1, len(rules)))
+}
+
+// POST /api/v1/rules
+func (h *RuleHandlers) CreateRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.IncomeRuleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	rule, err := h.ruleService.CreateRule(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar regra", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(rule)
+}
+
+// GET /api/v1/rules/{id}
+func (h *RuleHandlers) GetRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	rule, err := h.ruleService.GetRule(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar regra", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rule)
+}
+
+// PUT /api/v1/rules/{id}
+func (h *RuleHandlers) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.IncomeRuleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	rule, err := h.ruleService.UpdateRule(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao atualizar regra", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rule)
+}
+
+// DELETE /api/v1/rules/{id}
+func (h *RuleHandlers) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	if err := h.ruleService.DeleteRule(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao remover regra", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PUT /api/v1/rules/order
+func (h *RuleHandlers) ReorderRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.RuleOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.IDs) == 0 {
+		h.jsonError(w, http.StatusBadRequest, "dados inválidos")
+		return
+	}
+	rules, err := h.ruleService.ReorderRules(r.Context(), userID, req.IDs)
+	if err != nil {
+		h.writeServiceError(w, "erro ao reordenar regras", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"items": rules})
+}
+
+// POST /api/v1/rules/test
+func (h *RuleHandlers) TestRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.RuleTestRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	resp, err := h.ruleService.TestRules(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao testar regras", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *RuleHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrRuleNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrRuleNameRequired), errors.Is(err, models.ErrRuleConditionRequired),
+		errors.Is(err, models.ErrRuleActionRequired), errors.Is(err, models.ErrRuleInvalidRange):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *RuleHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *RuleHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
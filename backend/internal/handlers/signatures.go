@@ -6,30 +6,41 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"recibofast/internal/apierror"
 	ctxhelper "recibofast/internal/context"
 	"recibofast/internal/config"
 	"recibofast/internal/logging"
 	"recibofast/internal/models"
 	"recibofast/internal/repositories"
 	"recibofast/internal/services"
+	"recibofast/internal/storage"
 )
 
 // StorageClient define as operações necessárias de Storage para o handler.
 // Docstring: Interface fina para permitir mocking em testes, evitando dependência
 // direta do cliente concreto do Supabase Storage. Em PT-BR.
 type StorageClient interface {
-	UploadObject(ctx context.Context, bucket, objectPath string, content []byte, contentType string) error
+	UploadStream(ctx context.Context, bucket, objectPath string, body io.Reader, contentType string) (size int64, sha256hex string, err error)
 	DeleteObject(ctx context.Context, bucket, objectPath string) error
 }
 
+// QuotaReserver reserva uma unidade da métrica no plano do dono dos dados (services.QuotaService)
+type QuotaReserver interface {
+	Reserve(ctx context.Context, ownerID uuid.UUID, metric string) (func(), error)
+}
+
 // SignatureHandlers contém os handlers para operações com assinaturas
 // Docstring: expõe endpoint para upload multipart, valida PNG e retorna metadados.
 type SignatureHandlers struct {
@@ -38,14 +49,16 @@ type SignatureHandlers struct {
 	cfg   *config.Config
 	store StorageClient
 	repo  repositories.SignatureRepository
+	quota QuotaReserver
 }
 
-// NewSignatureHandlers cria uma nova instância dos handlers de assinatura
-func NewSignatureHandlers(sigSvc *services.SignatureService, log logging.Logger, cfg *config.Config, store StorageClient, repo repositories.SignatureRepository) *SignatureHandlers {
-	return &SignatureHandlers{sigSvc: sigSvc, log: log, cfg: cfg, store: store, repo: repo}
+// NewSignatureHandlers cria uma nova instância dos handlers de assinatura (quota nil: sem limite de plano)
+func NewSignatureHandlers(sigSvc *services.SignatureService, log logging.Logger, cfg *config.Config, store StorageClient, repo repositories.SignatureRepository, quota QuotaReserver) *SignatureHandlers {
+	return &SignatureHandlers{sigSvc: sigSvc, log: log, cfg: cfg, store: store, repo: repo, quota: quota}
 }
 
-// UploadSignature recebe um arquivo PNG (campo "file"), valida e retorna metadados
+// UploadSignature recebe um arquivo PNG (campo "file"), valida, trata a imagem e retorna metadados
+// (201 "uploaded"; 200 "duplicate" com o registro existente quando a imagem tratada já foi enviada)
 // Requer autenticação (middleware SupabaseAuth) e respeita limite de 2MB.
 func (h *SignatureHandlers) UploadSignature(w http.ResponseWriter, r *http.Request) {
 	userID, ok := h.getUserID(r)
@@ -54,67 +67,95 @@ func (h *SignatureHandlers) UploadSignature(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// Limite seguro para multipart (3MB) – serviço valida 2MB
-	err := r.ParseMultipartForm(3 * 1024 * 1024)
-	if err != nil {
-		h.jsonError(w, http.StatusBadRequest, "falha ao processar formulário de upload")
+	// Lê o campo "file" em streaming (sem ParseMultipartForm) até 2MB + 1 para detectar excesso;
+	// a imagem é decodificada no tratamento, então fica só este buffer
+	part, ok := openUploadPart(w, r, "file", services.MaxSignatureSize)
+	if !ok {
 		return
 	}
+	defer part.Close()
+	fileName := part.FileName()
 
-	file, header, err := r.FormFile("file")
+	b, err := io.ReadAll(io.LimitReader(part, services.MaxSignatureSize+1))
 	if err != nil {
-		h.jsonError(w, http.StatusBadRequest, "arquivo não encontrado no campo 'file'")
+		uploadReadError(w, err)
 		return
 	}
-	defer file.Close()
-
-	// Lê até 2MB + 1 para detectar excesso
-	maxPlus := services.MaxSignatureSize + 1
-	limited := io.LimitedReader{R: file, N: maxPlus}
-	b, _ := io.ReadAll(&limited)
 	if int64(len(b)) > services.MaxSignatureSize {
-		h.jsonError(w, http.StatusBadRequest, "arquivo excede 2MB")
+		apierror.WriteCode(w, http.StatusBadRequest, services.SignatureCodeFileTooLarge, "arquivo excede 2MB", nil)
+		return
+	}
+
+	if _, _, _, _, err := h.sigSvc.ValidatePNG(b); err != nil {
+		h.signatureError(w, err)
 		return
 	}
 
-	width, height, sha256hex, contentType, err := h.sigSvc.ValidatePNG(b)
+	// Remove o fundo branco, recorta e padroniza a altura; metadados valem para a imagem tratada
+	b, err = h.sigSvc.NormalizePNG(b)
 	if err != nil {
-		h.jsonError(w, http.StatusBadRequest, err.Error())
+		h.signatureError(w, err)
+		return
+	}
+	width, height, sha256hex, contentType, err := h.sigSvc.InspectPNG(b)
+	if err != nil {
+		h.log.Error("assinatura tratada inválida", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "falha ao processar a assinatura")
+		return
+	}
+
+	// Mesma imagem já enviada: devolve o registro existente sem duplicar o objeto no Storage
+	existing, err := h.repo.GetByHash(r.Context(), userID, sha256hex)
+	if err != nil && !errors.Is(err, models.ErrSignatureNotFound) {
+		h.log.Error("erro ao buscar assinatura pelo hash", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	if existing != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"status":   "duplicate",
+			"metadata": existing.Metadata(),
+		})
 		return
 	}
 
+	// Limite do plano só depois da deduplicação: o reenvio da mesma imagem não é barrado
+	release, ok := h.reserveQuota(w, r, userID)
+	if !ok {
+		return
+	}
+	stored := false
+	defer func() {
+		if !stored {
+			release()
+		}
+	}()
+
 	// Faz upload do arquivo para o Supabase Storage
 	objectPath := fmt.Sprintf("%s/%s_%d.png", userID.String(), sha256hex[:12], time.Now().UTC().Unix())
-	if err := h.store.UploadObject(r.Context(), h.cfg.BucketSigns, objectPath, b, contentType); err != nil {
+	if _, _, err := h.store.UploadStream(r.Context(), h.cfg.BucketSigns, objectPath, bytes.NewReader(b), contentType); err != nil {
 		h.log.Error("erro ao fazer upload para Storage", logging.Field{Key: "error", Val: err.Error()})
+		if storage.IsTransient(err) {
+			// falha temporária (rede, 5xx, circuito aberto): o cliente pode reenviar
+			w.Header().Set("Retry-After", "30")
+			h.jsonError(w, http.StatusServiceUnavailable, "armazenamento indisponível no momento, tente novamente")
+			return
+		}
 		h.jsonError(w, http.StatusInternalServerError, "falha ao armazenar a assinatura")
 		return
 	}
 
-	meta := models.SignatureMetadata{
-		OwnerID:     userID.String(),
-		FileName:    header.Filename,
-		Size:        int64(len(b)),
-		Width:       width,
-		Height:      height,
-		Hash:        sha256hex,
-		ContentType: contentType,
-		StoragePath: objectPath,
-		CreatedAt:   time.Now().UTC(),
-		Version:     1,
-	}
-
-	// Persiste metadados no banco (rf_signatures)
+	// Persiste metadados no banco (rf_signatures) como a próxima versão do usuário
 	rec := &models.SignatureRecord{
 		OwnerID:  userID,
 		FilePath: objectPath,
-		FileName: header.Filename,
+		FileName: fileName,
 		FileSize: int64(len(b)),
 		MimeType: contentType,
 		WidthPX:  width,
 		HeightPX: height,
 		Hash:     sha256hex,
-		Version:  1,
 	}
 	if err := h.repo.Create(r.Context(), rec); err != nil {
 		// Compensação: remover objeto do Storage se persistência falhar
@@ -126,14 +167,88 @@ func (h *SignatureHandlers) UploadSignature(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	stored = true
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]any{
 		"status":    "uploaded",
-		"metadata":  meta,
+		"metadata":  rec.Metadata(),
 	})
 }
 
+// reserveQuota aplica o limite de assinaturas do plano (402 quota_exceeded; 403 fora do plano),
+// com a mesma resposta do middleware Quota
+func (h *SignatureHandlers) reserveQuota(w http.ResponseWriter, r *http.Request, userID uuid.UUID) (func(), bool) {
+	if h.quota == nil {
+		return func() {}, true
+	}
+	release, err := h.quota.Reserve(r.Context(), userID, models.QuotaMetricSignatures)
+	var qe *models.QuotaError
+	if errors.As(err, &qe) {
+		status := http.StatusPaymentRequired
+		if qe.Unavailable() {
+			status = http.StatusForbidden
+		}
+		apierror.WriteCode(w, status, qe.Code(), qe.Error(), qe)
+		return nil, false
+	}
+	if err != nil {
+		h.log.Error("erro ao verificar limite do plano", logging.Field{Key: "metric", Val: models.QuotaMetricSignatures}, logging.Field{Key: "error", Val: err.Error()})
+		apierror.Write(w, http.StatusInternalServerError, "erro ao verificar limite do plano")
+		return nil, false
+	}
+	return release, true
+}
+
+// ListSignatures histórico de versões da assinatura do usuário (a primeira é a atual)
+// GET /api/v1/signatures
+func (h *SignatureHandlers) ListSignatures(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.repo.List(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao listar assinaturas", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	versions := make([]models.SignatureMetadata, 0, len(items))
+	for i := range items {
+		versions = append(versions, items[i].Metadata())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"signatures": versions})
+}
+
+// GetSignatureVersion metadados de uma versão, inclusive anteriores (recibos antigos a referenciam)
+// GET /api/v1/signatures/versions/{version}
+func (h *SignatureHandlers) GetSignatureVersion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || version <= 0 {
+		h.jsonError(w, http.StatusBadRequest, "versão inválida")
+		return
+	}
+	rec, err := h.repo.GetByVersion(r.Context(), userID, version)
+	if errors.Is(err, models.ErrSignatureNotFound) {
+		h.jsonError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.Error("erro ao buscar assinatura", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rec.Metadata())
+}
+
 // Métodos auxiliares
 
 func (h *SignatureHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
@@ -149,8 +264,16 @@ func (h *SignatureHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
 	return uid, true
 }
 
+// signatureError responde 400 com o código específico da violação (ex.: signature_width_too_small)
+func (h *SignatureHandlers) signatureError(w http.ResponseWriter, err error) {
+	var se *services.SignatureError
+	if errors.As(err, &se) {
+		apierror.WriteCode(w, http.StatusBadRequest, se.Code, se.Message, se.Details())
+		return
+	}
+	h.jsonError(w, http.StatusBadRequest, err.Error())
+}
+
 func (h *SignatureHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]string{"error": message})
+	apierror.Write(w, statusCode, message)
 }
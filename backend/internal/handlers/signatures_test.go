@@ -8,16 +8,21 @@ package handlers
 import (
     "context"
     "bytes"
+    "crypto/sha256"
+    "encoding/hex"
     "encoding/json"
     "image"
     "image/color"
+    "image/draw"
     "image/png"
     "io"
     "mime/multipart"
     "net/http"
     "net/http/httptest"
+    "strings"
     "testing"
 
+    "github.com/go-chi/chi/v5"
     "github.com/google/uuid"
     "recibofast/internal/config"
     ctxhelper "recibofast/internal/context"
@@ -25,6 +30,7 @@ import (
     "recibofast/internal/models"
     "recibofast/internal/repositories"
     "recibofast/internal/services"
+    "recibofast/internal/storage"
 )
 
 type fakeStorage struct {
@@ -34,9 +40,12 @@ type fakeStorage struct {
     deleteErr error
 }
 
-func (f *fakeStorage) UploadObject(ctx context.Context, bucket, objectPath string, content []byte, contentType string) error {
-    f.uploaded = append(f.uploaded, struct{ bucket, objectPath, contentType string; size int }{bucket, objectPath, contentType, len(content)})
-    return f.uploadErr
+func (f *fakeStorage) UploadStream(ctx context.Context, bucket, objectPath string, body io.Reader, contentType string) (int64, string, error) {
+    hasher := sha256.New()
+    n, err := io.Copy(hasher, body)
+    if err != nil { return 0, "", err }
+    f.uploaded = append(f.uploaded, struct{ bucket, objectPath, contentType string; size int }{bucket, objectPath, contentType, int(n)})
+    return n, hex.EncodeToString(hasher.Sum(nil)), f.uploadErr
 }
 
 func (f *fakeStorage) DeleteObject(ctx context.Context, bucket, objectPath string) error {
@@ -47,8 +56,35 @@ func (f *fakeStorage) DeleteObject(ctx context.Context, bucket, objectPath strin
 type fakeSignRepo struct{ createErr error; created []*models.SignatureRecord }
 
 func (r *fakeSignRepo) Create(ctx context.Context, s *models.SignatureRecord) error {
+    if r.createErr != nil { return r.createErr }
+    s.Version = 1
+    for _, c := range r.created {
+        if c.OwnerID == s.OwnerID { s.Version++ }
+    }
     r.created = append(r.created, s)
-    return r.createErr
+    return nil
+}
+
+func (r *fakeSignRepo) List(ctx context.Context, ownerID uuid.UUID) ([]models.SignatureRecord, error) {
+    out := []models.SignatureRecord{}
+    for i := len(r.created) - 1; i >= 0; i-- {
+        if r.created[i].OwnerID == ownerID { out = append(out, *r.created[i]) }
+    }
+    return out, nil
+}
+
+func (r *fakeSignRepo) GetByHash(ctx context.Context, ownerID uuid.UUID, hash string) (*models.SignatureRecord, error) {
+    for i := len(r.created) - 1; i >= 0; i-- {
+        if c := r.created[i]; c.OwnerID == ownerID && c.Hash == hash { cp := *c; return &cp, nil }
+    }
+    return nil, models.ErrSignatureNotFound
+}
+
+func (r *fakeSignRepo) GetByVersion(ctx context.Context, ownerID uuid.UUID, version int) (*models.SignatureRecord, error) {
+    for _, c := range r.created {
+        if c.OwnerID == ownerID && c.Version == version { cp := *c; return &cp, nil }
+    }
+    return nil, models.ErrSignatureNotFound
 }
 
 func newSignatureHandlersForTest(t *testing.T, repo repositories.SignatureRepository, store StorageClient) *SignatureHandlers {
@@ -56,7 +92,15 @@ func newSignatureHandlersForTest(t *testing.T, repo repositories.SignatureReposi
     logger := logging.NewLogger("dev")
     cfg := &config.Config{BucketSigns: "signatures"}
     svc := services.NewSignatureService()
-    return NewSignatureHandlers(svc, logger, cfg, store, repo)
+    return NewSignatureHandlers(svc, logger, cfg, store, repo, nil)
+}
+
+// fullSignatureQuota simula o plano com o limite de assinaturas atingido
+type fullSignatureQuota struct{ calls int }
+
+func (q *fullSignatureQuota) Reserve(ctx context.Context, ownerID uuid.UUID, metric string) (func(), error) {
+    q.calls++
+    return nil, &models.QuotaError{Metric: metric, Plan: "free", Limit: 1, Used: 1}
 }
 
 func makePNGBytes(t *testing.T, w, h int) []byte {
@@ -93,7 +137,7 @@ func TestUploadSignature_Success(t *testing.T) {
     store := &fakeStorage{}
     h := newSignatureHandlersForTest(t, repo, store)
 
-    pngData := makePNGBytes(t, 4, 4)
+    pngData := makePNGBytes(t, 60, 30)
     req, _ := newMultipartRequest(t, "file", "sig.png", pngData)
     req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
     rr := httptest.NewRecorder()
@@ -116,6 +160,101 @@ func TestUploadSignature_Success(t *testing.T) {
     if err := json.NewDecoder(rr.Body).Decode(&body); err != nil { t.Fatalf("decode body: %v", err) }
     if body.Status != "uploaded" { t.Fatalf("status body = %s, want uploaded", body.Status) }
     if body.Metadata.FileName != "sig.png" { t.Fatalf("filename = %s, want sig.png", body.Metadata.FileName) }
+    if body.Metadata.Version != 1 { t.Fatalf("version = %d, want 1", body.Metadata.Version) }
+    if body.Metadata.Height != services.SignatureHeight { t.Fatalf("height = %d, want %d", body.Metadata.Height, services.SignatureHeight) }
+    if body.Metadata.Size != int64(store.uploaded[0].size) { t.Fatalf("size = %d, want tamanho do PNG tratado", body.Metadata.Size) }
+}
+
+func TestUploadSignature_NewVersionKeepsHistory(t *testing.T) {
+    repo := &fakeSignRepo{}
+    h := newSignatureHandlersForTest(t, repo, &fakeStorage{})
+    owner := uuid.New().String()
+    withUser := func(req *http.Request) *http.Request { return req.WithContext(ctxhelper.SetUserID(req.Context(), owner)) }
+
+    for i := 0; i < 2; i++ {
+        req, _ := newMultipartRequest(t, "file", "sig.png", makePNGBytes(t, 60+10*i, 30))
+        rr := httptest.NewRecorder()
+        h.UploadSignature(rr, withUser(req))
+        if rr.Code != http.StatusCreated { t.Fatalf("upload %d: status = %d", i+1, rr.Code) }
+    }
+
+    rr := httptest.NewRecorder()
+    h.ListSignatures(rr, withUser(httptest.NewRequest(http.MethodGet, "/api/v1/signatures", nil)))
+    var list struct{ Signatures []models.SignatureMetadata `json:"signatures"` }
+    if err := json.NewDecoder(rr.Body).Decode(&list); err != nil { t.Fatalf("decode: %v", err) }
+    if len(list.Signatures) != 2 || list.Signatures[0].Version != 2 || list.Signatures[1].Version != 1 {
+        t.Fatalf("histórico = %+v", list.Signatures)
+    }
+
+    get := func(version string) *httptest.ResponseRecorder {
+        req := httptest.NewRequest(http.MethodGet, "/api/v1/signatures/versions/"+version, nil)
+        rctx := chi.NewRouteContext()
+        rctx.URLParams.Add("version", version)
+        req = withUser(req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx)))
+        rr := httptest.NewRecorder()
+        h.GetSignatureVersion(rr, req)
+        return rr
+    }
+    rr = get("1")
+    var v1 models.SignatureMetadata
+    json.NewDecoder(rr.Body).Decode(&v1)
+    if rr.Code != http.StatusOK || v1.Version != 1 || v1.Hash != list.Signatures[1].Hash { t.Fatalf("versão 1: status = %d, %+v", rr.Code, v1) }
+    if rr := get("3"); rr.Code != http.StatusNotFound { t.Fatalf("versão inexistente: status = %d", rr.Code) }
+    if rr := get("x"); rr.Code != http.StatusBadRequest { t.Fatalf("versão inválida: status = %d", rr.Code) }
+}
+
+func TestUploadSignature_DuplicateReturnsExisting(t *testing.T) {
+    repo := &fakeSignRepo{}
+    store := &fakeStorage{}
+    h := newSignatureHandlersForTest(t, repo, store)
+    owner := uuid.New().String()
+
+    upload := func() (*httptest.ResponseRecorder, models.SignatureMetadata) {
+        req, _ := newMultipartRequest(t, "file", "sig.png", makePNGBytes(t, 60, 30))
+        req = req.WithContext(ctxhelper.SetUserID(req.Context(), owner))
+        rr := httptest.NewRecorder()
+        h.UploadSignature(rr, req)
+        var body struct{ Metadata models.SignatureMetadata `json:"metadata"` }
+        json.NewDecoder(rr.Body).Decode(&body)
+        return rr, body.Metadata
+    }
+    first, meta1 := upload()
+    if first.Code != http.StatusCreated { t.Fatalf("primeiro envio: status = %d", first.Code) }
+    second, meta2 := upload()
+    if second.Code != http.StatusOK { t.Fatalf("reenvio: status = %d, want %d", second.Code, http.StatusOK) }
+    if meta2.Version != meta1.Version || meta2.StoragePath != meta1.StoragePath {
+        t.Fatalf("reenvio devolveu %+v, want registro existente %+v", meta2, meta1)
+    }
+    if len(store.uploaded) != 1 || len(repo.created) != 1 {
+        t.Fatalf("uploads = %d, registros = %d; reenvio idêntico não deve armazenar de novo", len(store.uploaded), len(repo.created))
+    }
+}
+
+func TestUploadSignature_DuplicateSkipsQuota(t *testing.T) {
+    repo := &fakeSignRepo{}
+    store := &fakeStorage{}
+    h := newSignatureHandlersForTest(t, repo, store)
+    owner := uuid.New().String()
+    upload := func() *httptest.ResponseRecorder {
+        req, _ := newMultipartRequest(t, "file", "sig.png", makePNGBytes(t, 60, 30))
+        rr := httptest.NewRecorder()
+        h.UploadSignature(rr, req.WithContext(ctxhelper.SetUserID(req.Context(), owner)))
+        return rr
+    }
+    if rr := upload(); rr.Code != http.StatusCreated { t.Fatalf("primeiro envio: status = %d", rr.Code) }
+
+    // Limite atingido: a mesma imagem devolve o registro existente; outra imagem recebe 402
+    quota := &fullSignatureQuota{}
+    h.quota = quota
+    if rr := upload(); rr.Code != http.StatusOK || quota.calls != 0 {
+        t.Fatalf("reenvio no limite: status = %d, reservas = %d; want 200 sem consultar a cota", rr.Code, quota.calls)
+    }
+    req, _ := newMultipartRequest(t, "file", "sig.png", makePNGBytes(t, 80, 30))
+    rr := httptest.NewRecorder()
+    h.UploadSignature(rr, req.WithContext(ctxhelper.SetUserID(req.Context(), owner)))
+    if rr.Code != http.StatusPaymentRequired || !strings.Contains(rr.Body.String(), "quota_exceeded") || len(store.uploaded) != 1 {
+        t.Fatalf("nova imagem no limite: status = %d, body = %s, uploads = %d", rr.Code, rr.Body, len(store.uploaded))
+    }
 }
 
 func TestUploadSignature_InvalidPNG(t *testing.T) {
@@ -135,6 +274,48 @@ func TestUploadSignature_InvalidPNG(t *testing.T) {
     }
 }
 
+func TestUploadSignature_BlankImage(t *testing.T) {
+    repo := &fakeSignRepo{}
+    store := &fakeStorage{}
+    h := newSignatureHandlersForTest(t, repo, store)
+
+    // folha toda branca: nenhum traço depois de remover o fundo
+    img := image.NewRGBA(image.Rect(0, 0, 60, 30))
+    draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+    var buf bytes.Buffer
+    if err := png.Encode(&buf, img); err != nil { t.Fatalf("falha ao gerar png: %v", err) }
+    req, _ := newMultipartRequest(t, "file", "sig.png", buf.Bytes())
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
+    rr := httptest.NewRecorder()
+
+    h.UploadSignature(rr, req)
+
+    if rr.Code != http.StatusBadRequest { t.Fatalf("status = %d, want %d", rr.Code, http.StatusBadRequest) }
+    if len(store.uploaded) != 0 { t.Fatalf("assinatura em branco não deve ser armazenada") }
+}
+
+func TestUploadSignature_DimensionErrorCode(t *testing.T) {
+    store := &fakeStorage{}
+    h := newSignatureHandlersForTest(t, &fakeSignRepo{}, store)
+
+    req, _ := newMultipartRequest(t, "file", "sig.png", makePNGBytes(t, 10, 30))
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
+    rr := httptest.NewRecorder()
+
+    h.UploadSignature(rr, req)
+
+    if rr.Code != http.StatusBadRequest { t.Fatalf("status = %d, want %d", rr.Code, http.StatusBadRequest) }
+    var body struct{
+        Code    string `json:"code"`
+        Details struct{ Limit, Value float64 } `json:"details"`
+    }
+    if err := json.NewDecoder(rr.Body).Decode(&body); err != nil { t.Fatalf("decode body: %v", err) }
+    if body.Code != services.SignatureCodeWidthTooSmall || body.Details.Limit != 50 || body.Details.Value != 10 {
+        t.Fatalf("erro = %+v, want %s com limite 50 e valor 10", body, services.SignatureCodeWidthTooSmall)
+    }
+    if len(store.uploaded) != 0 { t.Fatalf("imagem recusada não deve ser armazenada") }
+}
+
 func TestUploadSignature_TooLarge(t *testing.T) {
     repo := &fakeSignRepo{}
     store := &fakeStorage{}
@@ -152,12 +333,61 @@ func TestUploadSignature_TooLarge(t *testing.T) {
     }
 }
 
+func TestUploadSignature_BodyOverLimit(t *testing.T) {
+    store := &fakeStorage{}
+    h := newSignatureHandlersForTest(t, &fakeSignRepo{}, store)
+
+    // campo extra antes do arquivo estoura o limite do corpo: 413 sem ler o arquivo
+    var body bytes.Buffer
+    mw := multipart.NewWriter(&body)
+    mw.WriteField("notes", strings.Repeat("x", int(services.MaxSignatureSize)+128*1024))
+    fw, _ := mw.CreateFormFile("file", "sig.png")
+    fw.Write(makePNGBytes(t, 60, 30))
+    mw.Close()
+    req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures", &body)
+    req.Header.Set("Content-Type", mw.FormDataContentType())
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
+    rr := httptest.NewRecorder()
+
+    h.UploadSignature(rr, req)
+
+    if rr.Code != http.StatusRequestEntityTooLarge { t.Fatalf("status = %d, want %d", rr.Code, http.StatusRequestEntityTooLarge) }
+    if len(store.uploaded) != 0 { t.Fatalf("nada deve ser armazenado") }
+}
+
+func TestUploadSignature_StorageTransientFailure(t *testing.T) {
+    repo := &fakeSignRepo{}
+    store := &fakeStorage{uploadErr: &storage.Error{Status: http.StatusBadGateway, Transient: true, Err: io.ErrUnexpectedEOF}}
+    h := newSignatureHandlersForTest(t, repo, store)
+    req, _ := newMultipartRequest(t, "file", "sig.png", makePNGBytes(t, 60, 30))
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
+    rr := httptest.NewRecorder()
+
+    h.UploadSignature(rr, req)
+
+    if rr.Code != http.StatusServiceUnavailable || rr.Header().Get("Retry-After") == "" {
+        t.Fatalf("status = %d Retry-After = %q, want 503 com Retry-After", rr.Code, rr.Header().Get("Retry-After"))
+    }
+    if len(repo.created) != 0 { t.Fatalf("nada deve ser persistido") }
+}
+
+func TestUploadSignature_MissingFileField(t *testing.T) {
+    h := newSignatureHandlersForTest(t, &fakeSignRepo{}, &fakeStorage{})
+    req, _ := newMultipartRequest(t, "other", "sig.png", makePNGBytes(t, 60, 30))
+    req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
+    rr := httptest.NewRecorder()
+
+    h.UploadSignature(rr, req)
+
+    if rr.Code != http.StatusBadRequest { t.Fatalf("status = %d, want %d", rr.Code, http.StatusBadRequest) }
+}
+
 func TestUploadSignature_RepoErrorTriggersCompensation(t *testing.T) {
     repo := &fakeSignRepo{createErr: io.EOF}
     store := &fakeStorage{}
     h := newSignatureHandlersForTest(t, repo, store)
 
-    pngData := makePNGBytes(t, 2, 2)
+    pngData := makePNGBytes(t, 60, 30)
     req, _ := newMultipartRequest(t, "file", "sig.png", pngData)
     req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.New().String()))
     rr := httptest.NewRecorder()
@@ -7,20 +7,29 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
 )
 
 // SyncChanges
-// Docstring: Retorna alterações desde o parâmetro 'since' para reduzir payload; suporta ETag e paginação por cursor.
+// Docstring: Retorna alterações desde o parâmetro 'since' (RFC3339; vazio = sincronização completa) para
+// reduzir payload; suporta paginação por cursor e requisições condicionais (ETag forte e
+// Last-Modified calculados do estado dos dados; If-None-Match tem precedência sobre If-Modified-Since). 'entities' restringe as entidades
+// (incomes,payments,receipts,signatures), 'fields' projeta as colunas de cada uma
+// (ex.: receipts.numero,receipts.valor; as demais vêm completas) e 'limit' vale por entidade.
 func (h *Handlers) SyncChanges(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 	sinceStr := q.Get("since")
 	var since time.Time
 	var err error
-	if sinceStr == "" {
-		since = time.Now().Add(-24 * time.Hour)
-	} else {
+	if sinceStr != "" {
 		since, err = time.Parse(time.RFC3339, sinceStr)
 		if err != nil {
 			h.jsonError(w, http.StatusBadRequest, "since inválido")
@@ -29,23 +38,58 @@ func (h *Handlers) SyncChanges(w http.ResponseWriter, r *http.Request) {
 	}
 	limit := h.parseLimit(q.Get("limit"), 100)
 	cursor := q.Get("cursor")
+	entities := q.Get("entities")
 	fields := q.Get("fields")
 
 	uid, ok := h.AuthUser(r.Context())
 	if !ok { h.jsonError(w, http.StatusUnauthorized, "não autorizado"); return }
+	ownerID, err := uuid.Parse(uid)
+	if err != nil { h.jsonError(w, http.StatusUnauthorized, "não autorizado"); return }
 
-	res, nextCursor, etag, err := h.SyncSvc.FetchChanges(r.Context(), uid, since, limit, cursor, fields)
-	if err != nil { h.jsonError(w, http.StatusInternalServerError, err.Error()); return }
+	snap, err := h.SyncSvc.Snapshot(r.Context(), ownerID, since, limit, cursor, entities, fields)
+	if errors.Is(err, models.ErrSyncCursorInvalid) || errors.Is(err, models.ErrSyncEntityInvalid) ||
+		errors.Is(err, models.ErrSyncFieldInvalid) {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.Error("erro ao sincronizar alterações", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
 
-	if match := r.Header.Get("If-None-Match"); match != "" && match == etag {
+	w.Header().Set("ETag", snap.ETag)
+	if !snap.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", snap.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if syncNotModified(r, snap) {
 		w.WriteHeader(http.StatusNotModified)
 		return
 	}
-	w.Header().Set("ETag", etag)
+
+	res, err := h.SyncSvc.FetchChanges(r.Context(), ownerID, snap)
+	if err != nil {
+		h.log.Error("erro ao sincronizar alterações", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
 
-	_ = json.NewEncoder(w).Encode(struct{
-		Changes interface{} `json:"changes"`
-		Next   string      `json:"next_cursor,omitempty"`
-	}{Changes: res, Next: nextCursor})
+// syncNotModified confere If-None-Match (lista ou "*") ou, sem ele, If-Modified-Since (precisão de segundos)
+func syncNotModified(r *http.Request, snap *services.SyncSnapshot) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, v := range strings.Split(inm, ",") {
+			if v = strings.TrimSpace(v); v == "*" || v == snap.ETag {
+				return true
+			}
+		}
+		return false
+	}
+	ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || snap.LastModified.IsZero() {
+		return false
+	}
+	return !snap.LastModified.Truncate(time.Second).After(ims)
 }
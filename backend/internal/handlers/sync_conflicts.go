@@ -0,0 +1,138 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers de inspeção e resolução dos conflitos de sincronização offline
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// SyncConflictHandlers conflitos pendentes do usuário e atendimento pelo suporte
+type SyncConflictHandlers struct {
+	svc *services.SyncConflictService
+	log logging.Logger
+}
+
+// NewSyncConflictHandlers cria uma nova instância dos handlers de conflitos de sincronização
+func NewSyncConflictHandlers(svc *services.SyncConflictService, log logging.Logger) *SyncConflictHandlers {
+	return &SyncConflictHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/sync/pending-conflicts?device_id=
+// Docstring: conflitos pendentes agrupados por dispositivo, com as versões do dispositivo e do servidor lado a lado.
+func (h *SyncConflictHandlers) ListPending(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	h.listPending(w, r, userID)
+}
+
+// POST /api/v1/sync/conflicts/{id}/resolve
+// Docstring: corpo {"strategy": "keep_mine"|"keep_server"|"merge", "fields": {"valor": "mine", ...}}.
+func (h *SyncConflictHandlers) Resolve(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	h.resolve(w, r, userID, models.SyncResolvedByUser)
+}
+
+// GET /api/v1/admin/sync/pending-conflicts?owner_id=&device_id=
+func (h *SyncConflictHandlers) AdminListPending(w http.ResponseWriter, r *http.Request) {
+	ownerID, err := uuid.Parse(r.URL.Query().Get("owner_id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "owner_id inválido")
+		return
+	}
+	h.listPending(w, r, ownerID)
+}
+
+// POST /api/v1/admin/sync/conflicts/{id}/resolve?owner_id=
+func (h *SyncConflictHandlers) AdminResolve(w http.ResponseWriter, r *http.Request) {
+	ownerID, err := uuid.Parse(r.URL.Query().Get("owner_id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "owner_id inválido")
+		return
+	}
+	h.resolve(w, r, ownerID, models.SyncResolvedBySupport)
+}
+
+func (h *SyncConflictHandlers) listPending(w http.ResponseWriter, r *http.Request, ownerID uuid.UUID) {
+	devices, err := h.svc.ListPending(r.Context(), ownerID, r.URL.Query().Get("device_id"))
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar conflitos de sincronização", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"devices": devices})
+}
+
+func (h *SyncConflictHandlers) resolve(w http.ResponseWriter, r *http.Request, ownerID uuid.UUID, resolvedBy string) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+	var req models.SyncConflictResolution
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	c, income, err := h.svc.Resolve(r.Context(), id, ownerID, resolvedBy, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao resolver conflito de sincronização", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"conflict": c, "income": income})
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *SyncConflictHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrSyncConflictNotFound), errors.Is(err, models.ErrIncomeNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrSyncResolutionInvalid), errors.Is(err, models.ErrSyncMergeFieldsRequired),
+		errors.Is(err, models.ErrSyncMergeFieldInvalid), errors.Is(err, models.ErrSyncMergeSideInvalid),
+		errors.Is(err, models.ErrCompetenciaRequired), errors.Is(err, models.ErrValorInvalid),
+		errors.Is(err, models.ErrInvalidStatus), errors.Is(err, models.ErrInvalidDateFormat):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, models.ErrSyncConflictResolved), errors.Is(err, models.ErrIncomeVersionConflict):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *SyncConflictHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *SyncConflictHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,79 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handler do envio offline (receitas e pagamentos criados ou editados no dispositivo)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// SyncPushHandlers envio do lote offline
+type SyncPushHandlers struct {
+	svc *services.SyncPushService
+	log logging.Logger
+}
+
+// NewSyncPushHandlers cria uma nova instância dos handlers do envio offline
+func NewSyncPushHandlers(svc *services.SyncPushService, log logging.Logger) *SyncPushHandlers {
+	return &SyncPushHandlers{svc: svc, log: log}
+}
+
+// POST /api/v1/sync/push
+// Docstring: corpo {"device_id", "incomes": [{id, base_version, updated_at, deleted, data}],
+// "payments": [{id, income_id, valor, pago_em, ...}]}. Responde 200 com o resultado de cada
+// registro (created, updated, deleted, unchanged, conflict ou rejected); 400 só para lote inválido.
+func (h *SyncPushHandlers) Push(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.SyncPushRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	out, err := h.svc.Push(r.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrSyncDeviceRequired), errors.Is(err, models.ErrSyncPushEmpty),
+			errors.Is(err, models.ErrSyncPushTooLarge), errors.Is(err, models.ErrSyncPushIDRequired),
+			errors.Is(err, models.ErrSyncPushDuplicateID), errors.Is(err, models.ErrSyncPushDataInvalid):
+			h.jsonError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.log.Error("erro no envio offline", logging.Field{Key: "error", Val: err.Error()})
+			h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// Auxiliares
+func (h *SyncPushHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *SyncPushHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
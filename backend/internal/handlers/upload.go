@@ -0,0 +1,58 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Leitura em streaming de arquivos enviados em multipart (sem ParseMultipartForm)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"recibofast/internal/apierror"
+)
+
+// multipartOverhead folga do corpo multipart além do arquivo (boundaries, cabeçalhos das partes)
+const multipartOverhead = 64 * 1024
+
+// openUploadPart devolve o campo de arquivo do corpo multipart para leitura em streaming.
+// Docstring: ao contrário de ParseMultipartForm, não copia o arquivo para memória ou disco; o
+// corpo é limitado por http.MaxBytesReader a maxFile + multipartOverhead. Partes antes do campo
+// são descartadas. Em caso de falha já responde (413 corpo grande demais, 400 demais erros) e
+// retorna false; quem lê a parte trata o 413 com uploadReadError.
+func openUploadPart(w http.ResponseWriter, r *http.Request, field string, maxFile int64) (*multipart.Part, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxFile+multipartOverhead)
+	mr, err := r.MultipartReader()
+	if err != nil {
+		apierror.Write(w, http.StatusBadRequest, "falha ao processar formulário de upload")
+		return nil, false
+	}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			apierror.Write(w, http.StatusBadRequest, fmt.Sprintf("arquivo não encontrado no campo '%s'", field))
+			return nil, false
+		}
+		if err != nil {
+			uploadReadError(w, err)
+			return nil, false
+		}
+		if part.FormName() == field && part.FileName() != "" {
+			return part, true
+		}
+		part.Close()
+	}
+}
+
+// uploadReadError responde a falha de leitura do corpo do upload (413 se excedeu o limite)
+func uploadReadError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		apierror.Write(w, http.StatusRequestEntityTooLarge, "arquivo muito grande")
+		return
+	}
+	apierror.Write(w, http.StatusBadRequest, "falha ao processar formulário de upload")
+}
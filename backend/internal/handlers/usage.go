@@ -0,0 +1,65 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handler do consumo do plano (cotas restantes para o frontend)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/services"
+)
+
+// UsageHandlers consumo do plano do dono dos dados
+type UsageHandlers struct {
+	svc *services.QuotaService
+	log logging.Logger
+}
+
+// NewUsageHandlers cria uma nova instância dos handlers de consumo
+func NewUsageHandlers(svc *services.QuotaService, log logging.Logger) *UsageHandlers {
+	return &UsageHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/usage
+// Docstring: plano, período (AAAA-MM), virada do mês e, por métrica, usado, limite e restante
+// (limite e restante nulos quando ilimitado).
+func (h *UsageHandlers) GetUsage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	u, err := h.svc.Usage(r.Context(), userID)
+	if err != nil {
+		h.log.Error("erro ao consultar consumo do plano", logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(u)
+}
+
+// Auxiliares
+func (h *UsageHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *UsageHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
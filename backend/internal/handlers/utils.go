@@ -0,0 +1,45 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers utilitários (valor por extenso)
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"recibofast/internal/apierror"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+)
+
+// UtilHandlers contém os handlers utilitários usados pelo app na emissão de recibos
+type UtilHandlers struct {
+	log logging.Logger
+}
+
+// NewUtilHandlers cria uma nova instância dos handlers utilitários
+func NewUtilHandlers(log logging.Logger) *UtilHandlers {
+	return &UtilHandlers{log: log}
+}
+
+// GET /api/v1/utils/extenso?valor=1234.56
+// Docstring: valor decimal com ponto; a resposta traz o texto que sai nos PDFs de recibo.
+func (h *UtilHandlers) Extenso(w http.ResponseWriter, r *http.Request) {
+	valor, err := models.ParseMoney(r.URL.Query().Get("valor"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "valor inválido (use 1234.56)")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"valor":   valor,
+		"extenso": locale.Extenso(valor),
+	})
+}
+
+func (h *UtilHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
@@ -0,0 +1,229 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Handlers dos webhooks do usuário e do log de entregas
+// Data: 18-10-2026
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// WebhookHandlers cadastro de webhooks e consulta das entregas
+type WebhookHandlers struct {
+	svc *services.WebhookService
+	log logging.Logger
+}
+
+// NewWebhookHandlers cria uma nova instância dos handlers de webhooks
+func NewWebhookHandlers(svc *services.WebhookService, log logging.Logger) *WebhookHandlers {
+	return &WebhookHandlers{svc: svc, log: log}
+}
+
+// GET /api/v1/webhooks
+func (h *WebhookHandlers) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	items, err := h.svc.List(r.Context(), userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar webhooks", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"items": items, "events": models.WebhookEventTypes()})
+}
+
+// POST /api/v1/webhooks
+// Docstring: corpo {"url": "https://...", "events": [...], "description": "..."}; events vazio
+// assina todos. A resposta traz o segredo de assinatura, que não é exibido novamente.
+func (h *WebhookHandlers) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+	var req models.WebhookRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	hook, err := h.svc.Create(r.Context(), userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao criar webhook", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(hook)
+}
+
+// GET /api/v1/webhooks/{id}
+func (h *WebhookHandlers) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	hook, err := h.svc.Get(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao buscar webhook", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hook)
+}
+
+// PATCH /api/v1/webhooks/{id}
+func (h *WebhookHandlers) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	var req models.WebhookRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	hook, err := h.svc.Update(r.Context(), id, userID, &req)
+	if err != nil {
+		h.writeServiceError(w, "erro ao atualizar webhook", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hook)
+}
+
+// DELETE /api/v1/webhooks/{id}
+func (h *WebhookHandlers) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id, userID); err != nil {
+		h.writeServiceError(w, "erro ao remover webhook", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /api/v1/webhooks/{id}/rotate-secret
+func (h *WebhookHandlers) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	hook, err := h.svc.RotateSecret(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao gerar novo segredo do webhook", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hook)
+}
+
+// POST /api/v1/webhooks/{id}/ping
+func (h *WebhookHandlers) PingWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	d, err := h.svc.Ping(r.Context(), id, userID)
+	if err != nil {
+		h.writeServiceError(w, "erro ao enviar teste do webhook", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(d)
+}
+
+// GET /api/v1/webhooks/{id}/deliveries?status=&page=&per_page=
+func (h *WebhookHandlers) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	filter := &models.DeliveryFilter{Status: q.Get("status")}
+	if filter.Status != "" && !models.ValidDeliveryStatus(filter.Status) {
+		h.jsonError(w, http.StatusBadRequest, "status inválido (use pending, processing, delivered ou dead)")
+		return
+	}
+	if v, err := strconv.Atoi(q.Get("page")); err == nil && v > 0 {
+		filter.Page = v
+	}
+	if v, err := strconv.Atoi(q.Get("per_page")); err == nil && v > 0 {
+		filter.PerPage = v
+	}
+	if page, ok, err := pageFromCursor(r); err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if ok {
+		filter.Page = page
+	}
+	out, err := h.svc.Deliveries(r.Context(), id, userID, filter)
+	if err != nil {
+		h.writeServiceError(w, "erro ao listar entregas do webhook", err)
+		return
+	}
+	writeList(w, r, out, models.NewPage(out.Items, out.Total, out.Page, out.PerPage))
+}
+
+// writeServiceError mapeia erros de domínio para status HTTP
+func (h *WebhookHandlers) writeServiceError(w http.ResponseWriter, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrWebhookNotFound):
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, models.ErrWebhookURLTaken), errors.Is(err, models.ErrWebhookLimit):
+		h.jsonError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, models.ErrWebhookURLInvalid), errors.Is(err, models.ErrWebhookEventInvalid),
+		errors.Is(err, models.ErrWebhookDescription):
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.log.Error(logMsg, logging.Field{Key: "error", Val: err.Error()})
+		h.jsonError(w, http.StatusInternalServerError, "erro interno do servidor")
+	}
+}
+
+// Auxiliares
+func (h *WebhookHandlers) userAndID(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.getUserID(r)
+	if !ok {
+		h.jsonError(w, http.StatusUnauthorized, "usuário não autenticado")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, "ID inválido")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func (h *WebhookHandlers) getUserID(r *http.Request) (uuid.UUID, bool) {
+	userIDStr, ok := ctxhelper.GetUserID(r.Context())
+	if !ok || userIDStr == "" {
+		return uuid.Nil, false
+	}
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.log.Error("erro ao parsear user_id do contexto", logging.Field{Key: "user_id_str", Val: userIDStr}, logging.Field{Key: "error", Val: err.Error()})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (h *WebhookHandlers) jsonError(w http.ResponseWriter, statusCode int, message string) {
+	apierror.Write(w, statusCode, message)
+}
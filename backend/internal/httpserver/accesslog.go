@@ -0,0 +1,160 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Log de acesso estruturado (zap) com amostragem por rota e redação de credenciais
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"recibofast/internal/config"
+	"recibofast/internal/logging"
+)
+
+// redactedHeaders cabeçalhos com credenciais, nunca registrados em claro
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"X-Api-Key":           true,
+	"X-Admin-Token":       true,
+}
+
+// redactedParams parâmetros de query que carregam segredos (links de descadastro, webhooks)
+var redactedParams = []string{"token", "hmac", "secret", "key", "signature"}
+
+// AccessLogOptions configuração do log de acesso.
+// Docstring: SampleRate (0 a 1) vale só para respostas de sucesso nas rotas com prefixo em
+// SampledPaths (probes, sync); erros (status >= 400) e requisições mais lentas que SlowThreshold
+// são sempre registrados. Com LogHeaders, os cabeçalhos da requisição entram no log com as
+// credenciais redigidas.
+type AccessLogOptions struct {
+	SampleRate    float64
+	SampledPaths  []string
+	SlowThreshold time.Duration
+	LogHeaders    bool
+}
+
+// AccessLogOptionsFromConfig opções a partir de ACCESS_LOG_SAMPLE_RATE, ACCESS_LOG_SAMPLED_PATHS,
+// ACCESS_LOG_SLOW e ACCESS_LOG_HEADERS
+func AccessLogOptionsFromConfig(cfg *config.Config) AccessLogOptions {
+	return AccessLogOptions{
+		SampleRate:    cfg.AccessLogSampleRate,
+		SampledPaths:  parseOrigins(cfg.AccessLogSampledPaths),
+		SlowThreshold: cfg.AccessLogSlowThreshold,
+		LogHeaders:    cfg.AccessLogHeaders,
+	}
+}
+
+type accessLogKey struct{}
+
+// accessLogEntry dados preenchidos pelas camadas internas (o contexto não volta para o middleware)
+type accessLogEntry struct {
+	userID string
+}
+
+// setAccessLogUser registra o usuário autenticado no log de acesso da requisição
+func setAccessLogUser(ctx context.Context, userID string) {
+	if e, ok := ctx.Value(accessLogKey{}).(*accessLogEntry); ok {
+		e.userID = userID
+	}
+}
+
+// AccessLog registra uma linha por requisição com método, rota, status, duração, bytes,
+// user_id e request_id. Deve vir depois de middleware.RequestID.
+func AccessLog(log logging.Logger, opts AccessLogOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			entry := &accessLogEntry{}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), accessLogKey{}, entry)))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			duration := time.Since(start)
+			if !opts.shouldLog(r.URL.Path, status, duration) {
+				return
+			}
+
+			fields := []logging.Field{
+				{Key: "method", Val: r.Method},
+				{Key: "path", Val: r.URL.Path},
+				{Key: "status", Val: status},
+				{Key: "duration_ms", Val: float64(duration.Microseconds()) / 1000},
+				{Key: "bytes", Val: ww.BytesWritten()},
+				{Key: "request_id", Val: middleware.GetReqID(r.Context())},
+				{Key: "remote_ip", Val: r.RemoteAddr},
+				{Key: "user_agent", Val: r.UserAgent()},
+			}
+			if r.URL.RawQuery != "" {
+				fields = append(fields, logging.Field{Key: "query", Val: redactQuery(r.URL.Query())})
+			}
+			if entry.userID != "" {
+				fields = append(fields, logging.Field{Key: "user_id", Val: entry.userID})
+			}
+			if opts.LogHeaders {
+				fields = append(fields, logging.Field{Key: "headers", Val: redactHeaders(r.Header)})
+			}
+
+			switch {
+			case status >= 500:
+				log.Error("requisição", fields...)
+			case status >= 400 || (opts.SlowThreshold > 0 && duration >= opts.SlowThreshold):
+				log.Warn("requisição", fields...)
+			default:
+				log.Info("requisição", fields...)
+			}
+		})
+	}
+}
+
+// shouldLog aplica a amostragem só a respostas rápidas e de sucesso das rotas amostradas
+func (o AccessLogOptions) shouldLog(path string, status int, duration time.Duration) bool {
+	if status >= 400 || o.SampleRate >= 1 || (o.SlowThreshold > 0 && duration >= o.SlowThreshold) {
+		return true
+	}
+	for _, p := range o.SampledPaths {
+		if strings.HasPrefix(path, p) {
+			return o.SampleRate > 0 && rand.Float64() < o.SampleRate
+		}
+	}
+	return true
+}
+
+// redactHeaders copia os cabeçalhos trocando os valores de credenciais por "[REDACTED]"
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if redactedHeaders[http.CanonicalHeaderKey(k)] {
+			out[k] = "[REDACTED]"
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// redactQuery devolve a query com os valores de parâmetros sensíveis redigidos
+func redactQuery(q url.Values) string {
+	for k := range q {
+		lk := strings.ToLower(k)
+		for _, p := range redactedParams {
+			if strings.Contains(lk, p) {
+				q[k] = []string{"REDACTED"}
+				break
+			}
+		}
+	}
+	return q.Encode()
+}
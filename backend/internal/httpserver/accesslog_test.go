@@ -0,0 +1,96 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do log de acesso (campos, user_id, amostragem e redação)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"recibofast/internal/logging"
+)
+
+// recordingLogger guarda as linhas registradas (nível e campos)
+type recordingLogger struct {
+	logging.Logger
+	lines []recordedLine
+}
+
+type recordedLine struct {
+	level  string
+	fields map[string]interface{}
+}
+
+func (l *recordingLogger) add(level string, fields []logging.Field) {
+	m := map[string]interface{}{}
+	for _, f := range fields {
+		m[f.Key] = f.Val
+	}
+	l.lines = append(l.lines, recordedLine{level: level, fields: m})
+}
+
+func (l *recordingLogger) Info(_ string, fields ...logging.Field)  { l.add("info", fields) }
+func (l *recordingLogger) Warn(_ string, fields ...logging.Field)  { l.add("warn", fields) }
+func (l *recordingLogger) Error(_ string, fields ...logging.Field) { l.add("error", fields) }
+
+func TestAccessLogFields(t *testing.T) {
+	log := &recordingLogger{}
+	h := middleware.RequestID(AccessLog(log, AccessLogOptions{SampleRate: 1, LogHeaders: true})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			setAccessLogUser(r.Context(), "user-1")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("criado"))
+		})))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/incomes?token=abc&page=2", nil)
+	req.Header.Set("Authorization", "Bearer segredo")
+	req.Header.Set("X-API-Key", "rfk_segredo")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(log.lines) != 1 || log.lines[0].level != "info" {
+		t.Fatalf("linhas = %+v", log.lines)
+	}
+	f := log.lines[0].fields
+	if f["method"] != "POST" || f["path"] != "/api/v1/incomes" || f["status"] != http.StatusCreated || f["bytes"] != 6 ||
+		f["user_id"] != "user-1" || f["request_id"] == "" {
+		t.Fatalf("campos = %+v", f)
+	}
+	if q := f["query"].(string); strings.Contains(q, "abc") || !strings.Contains(q, "page=2") {
+		t.Fatalf("query sem redação: %q", q)
+	}
+	headers := f["headers"].(map[string]string)
+	if headers["Authorization"] != "[REDACTED]" || headers["X-Api-Key"] != "[REDACTED]" {
+		t.Fatalf("cabeçalhos sem redação: %v", headers)
+	}
+}
+
+func TestAccessLogSampling(t *testing.T) {
+	log := &recordingLogger{}
+	status := http.StatusOK
+	h := AccessLog(log, AccessLogOptions{SampleRate: 0, SampledPaths: []string{"/healthz"}, SlowThreshold: time.Hour})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(status) }))
+
+	serve := func(path string) {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	serve("/healthz")
+	if len(log.lines) != 0 {
+		t.Fatalf("sucesso em rota amostrada com taxa 0 não deveria ser registrado: %+v", log.lines)
+	}
+	serve("/api/v1/incomes")
+	status = http.StatusServiceUnavailable
+	serve("/healthz")
+	if len(log.lines) != 2 || log.lines[1].level != "error" {
+		t.Fatalf("linhas = %+v", log.lines)
+	}
+	if _, ok := log.lines[0].fields["user_id"]; ok {
+		t.Fatal("user_id registrado sem autenticação")
+	}
+}
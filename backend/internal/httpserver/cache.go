@@ -0,0 +1,148 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Políticas de cache HTTP declaradas por rota (Cache-Control, ETag e If-None-Match)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CachePolicy cacheabilidade de uma rota de leitura.
+// Docstring: Private restringe ao navegador do usuário (respostas autenticadas); MaxAge zero
+// com cache permitido exige revalidação a cada uso (no-cache), o que combinado ao ETag rende
+// 304 sem corpo; StaleWhileRevalidate permite servir a cópia vencida enquanto revalida.
+type CachePolicy struct {
+	Private              bool
+	MaxAge               time.Duration
+	StaleWhileRevalidate time.Duration
+	NoStore              bool
+}
+
+// Políticas usadas pelas rotas
+var (
+	// CacheNoStore dados sensíveis ou voláteis (nunca guardados)
+	CacheNoStore = CachePolicy{Private: true, NoStore: true}
+	// CacheRevalidate recursos com ETag de versão: o cliente guarda, mas sempre revalida
+	CacheRevalidate = CachePolicy{Private: true}
+	// CacheDashboard painéis e estatísticas: toleram alguns segundos de atraso
+	CacheDashboard = CachePolicy{Private: true, MaxAge: 30 * time.Second, StaleWhileRevalidate: 5 * time.Minute}
+	// CacheReference dados de referência que mudam no máximo uma vez por dia (cotações e índices)
+	CacheReference = CachePolicy{Private: true, MaxAge: time.Hour, StaleWhileRevalidate: 24 * time.Hour}
+)
+
+// Header valor do Cache-Control da política
+func (p CachePolicy) Header() string {
+	scope := "public"
+	if p.Private {
+		scope = "private"
+	}
+	if p.NoStore {
+		return scope + ", no-store"
+	}
+	if p.MaxAge <= 0 {
+		return scope + ", no-cache"
+	}
+	v := scope + ", max-age=" + strconv.Itoa(int(p.MaxAge/time.Second))
+	if p.StaleWhileRevalidate > 0 {
+		v += ", stale-while-revalidate=" + strconv.Itoa(int(p.StaleWhileRevalidate/time.Second))
+	}
+	return v
+}
+
+// Cache aplica a política às respostas GET/HEAD bem-sucedidas da rota.
+// Docstring: Cache-Control e ETag definidos pelo próprio handler têm precedência (ex.: versão da
+// receita, cursor do sync); sem ETag é gerado um fraco a partir do corpo. If-None-Match é
+// conferido contra o ETag final e responde 304 sem corpo. Respostas de erro não são guardadas.
+func Cache(policy CachePolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			bw := &bufferedWriter{ResponseWriter: w}
+			next.ServeHTTP(bw, r)
+			if bw.status == 0 {
+				bw.status = http.StatusOK
+			}
+
+			h := w.Header()
+			cacheable := bw.status == http.StatusOK || bw.status == http.StatusNotModified
+			if h.Get("Cache-Control") == "" {
+				if cacheable {
+					h.Set("Cache-Control", policy.Header())
+				} else {
+					h.Set("Cache-Control", "no-store")
+				}
+			}
+			if policy.Private {
+				h.Add("Vary", "Authorization, Accept-Language, Accept-Profile, X-Lite, X-Org-ID, X-API-Key")
+			}
+			if bw.status == http.StatusNotModified {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			if !cacheable || policy.NoStore {
+				w.WriteHeader(bw.status)
+				w.Write(bw.body.Bytes())
+				return
+			}
+			etag := h.Get("ETag")
+			if etag == "" {
+				sum := sha256.Sum256(bw.body.Bytes())
+				etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+				h.Set("ETag", etag)
+			}
+			if etagMatches(r.Header.Get("If-None-Match"), etag) {
+				h.Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.WriteHeader(bw.status)
+			w.Write(bw.body.Bytes())
+		})
+	}
+}
+
+// etagMatches comparação fraca do If-None-Match (lista separada por vírgula ou "*")
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, v := range strings.Split(header, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || strings.TrimPrefix(v, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter retém status e corpo até a política ser aplicada
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
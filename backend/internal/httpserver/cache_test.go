@@ -0,0 +1,81 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes das políticas de cache por rota (cabeçalhos, ETag e 304)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCachePolicy_Header(t *testing.T) {
+	cases := []struct {
+		policy CachePolicy
+		want   string
+	}{
+		{CacheNoStore, "private, no-store"},
+		{CacheRevalidate, "private, no-cache"},
+		{CacheDashboard, "private, max-age=30, stale-while-revalidate=300"},
+		{CachePolicy{MaxAge: time.Minute}, "public, max-age=60"},
+	}
+	for _, c := range cases {
+		if got := c.policy.Header(); got != c.want {
+			t.Errorf("Header() = %q, esperado %q", got, c.want)
+		}
+	}
+}
+
+func serveCached(policy CachePolicy, h http.HandlerFunc, method, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	Cache(policy)(h).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCache_GeneratesETagAndAnswersNotModified(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{"total":3}`)) }
+
+	first := serveCached(CacheDashboard, h, http.MethodGet, "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Body.String() != `{"total":3}` {
+		t.Fatalf("primeira resposta: code=%d etag=%q body=%q", first.Code, etag, first.Body.String())
+	}
+	if got := first.Header().Get("Cache-Control"); got != CacheDashboard.Header() {
+		t.Fatalf("Cache-Control = %q", got)
+	}
+
+	again := serveCached(CacheDashboard, h, http.MethodGet, `"outro", `+etag)
+	if again.Code != http.StatusNotModified || again.Body.Len() != 0 {
+		t.Fatalf("revalidação: code=%d body=%q", again.Code, again.Body.String())
+	}
+}
+
+func TestCache_RespectsHandlerHeadersAndErrors(t *testing.T) {
+	versioned := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"7"`)
+		w.Write([]byte("v7"))
+	}
+	if rec := serveCached(CacheRevalidate, versioned, http.MethodGet, `W/"7"`); rec.Code != http.StatusNotModified {
+		t.Fatalf("ETag do handler deveria valer no If-None-Match, code=%d", rec.Code)
+	}
+
+	failing := func(w http.ResponseWriter, r *http.Request) { http.Error(w, "falhou", http.StatusInternalServerError) }
+	rec := serveCached(CacheDashboard, failing, http.MethodGet, "*")
+	if rec.Code != http.StatusInternalServerError || rec.Header().Get("ETag") != "" || rec.Header().Get("Cache-Control") != "no-store" {
+		t.Fatalf("erro: code=%d etag=%q cache=%q", rec.Code, rec.Header().Get("ETag"), rec.Header().Get("Cache-Control"))
+	}
+
+	if rec := serveCached(CacheNoStore, versioned, http.MethodGet, `"7"`); rec.Code != http.StatusOK {
+		t.Fatalf("no-store não deveria responder 304, code=%d", rec.Code)
+	}
+	if rec := serveCached(CacheDashboard, versioned, http.MethodPost, `"7"`); rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "" {
+		t.Fatalf("métodos de escrita passam direto, code=%d", rec.Code)
+	}
+}
@@ -0,0 +1,65 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Middleware que exige captcha resolvido (hCaptcha) em rotas públicas sensíveis
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"recibofast/internal/apierror"
+	"recibofast/internal/captcha"
+	"recibofast/internal/logging"
+)
+
+// CaptchaTokenHeader cabeçalho com o token do captcha resolvido no navegador
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// Códigos de erro do captcha (campo code do corpo de erro)
+const (
+	CodeCaptchaRequired = "captcha_required"
+	CodeCaptchaInvalid  = "captcha_invalid"
+)
+
+// CaptchaRequired exige um captcha válido antes da rota (consulta pública de recibos, aceite de convites).
+// Docstring: o token vem em X-Captcha-Token; ausente ou recusado responde 403 (captcha_required ou
+// captcha_invalid, com os error-codes do provedor em details) e provedor fora do ar 503, sem chamar a
+// rota. Com v nil (sem HCAPTCHA_SECRET ou com CAPTCHA_REQUIRED desligado) a rota segue direto, como o
+// frontend, que dispensa o desafio quando não há sitekey.
+func CaptchaRequired(v captcha.Verifier, log logging.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if v == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := strings.TrimSpace(r.Header.Get(CaptchaTokenHeader))
+			if token == "" {
+				apierror.WriteCode(w, http.StatusForbidden, CodeCaptchaRequired, "captcha obrigatório ("+CaptchaTokenHeader+")", nil)
+				return
+			}
+			res, err := v.Verify(r.Context(), token, remoteIP(r), "")
+			if err != nil {
+				log.Error("erro ao verificar captcha", logging.Field{Key: "error", Val: err.Error()})
+				apierror.Write(w, http.StatusServiceUnavailable, "verificação de captcha indisponível")
+				return
+			}
+			if !res.Success {
+				apierror.WriteCode(w, http.StatusForbidden, CodeCaptchaInvalid, "captcha inválido ou expirado",
+					map[string]interface{}{"error_codes": res.ErrorCodes})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// remoteIP IP do cliente (RemoteAddr já ajustado pelo middleware RealIP), sem a porta
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
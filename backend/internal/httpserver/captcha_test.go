@@ -0,0 +1,87 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do middleware de captcha obrigatório
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"recibofast/internal/apierror"
+	"recibofast/internal/captcha"
+	"recibofast/internal/logging"
+)
+
+type fakeVerifier struct {
+	result   *captcha.Result
+	err      error
+	token    string
+	remoteIP string
+}
+
+func (f *fakeVerifier) Verify(_ context.Context, token, remoteIP, _ string) (*captcha.Result, error) {
+	f.token, f.remoteIP = token, remoteIP
+	return f.result, f.err
+}
+
+func serveCaptcha(v captcha.Verifier, token string) (*httptest.ResponseRecorder, bool) {
+	called := false
+	h := CaptchaRequired(v, logging.NewLogger("dev"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/public/receipts/lookup", nil)
+	req.RemoteAddr = "203.0.113.7:5555"
+	if token != "" {
+		req.Header.Set(CaptchaTokenHeader, token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec, called
+}
+
+func TestCaptchaRequired(t *testing.T) {
+	ok := &fakeVerifier{result: &captcha.Result{Success: true}}
+	cases := []struct {
+		name     string
+		verifier captcha.Verifier
+		token    string
+		status   int
+		code     string
+	}{
+		{"sem verificador segue direto", nil, "", http.StatusOK, ""},
+		{"token ausente", ok, "", http.StatusForbidden, CodeCaptchaRequired},
+		{"token aceito", ok, "tok", http.StatusOK, ""},
+		{"token recusado", &fakeVerifier{result: &captcha.Result{ErrorCodes: []string{"invalid-input-response"}}}, "tok", http.StatusForbidden, CodeCaptchaInvalid},
+		{"provedor fora do ar", &fakeVerifier{err: captcha.ErrUnavailable}, "tok", http.StatusServiceUnavailable, apierror.CodeUnavailable},
+	}
+	for _, c := range cases {
+		rec, called := serveCaptcha(c.verifier, c.token)
+		if rec.Code != c.status || called != (c.status == http.StatusOK) {
+			t.Fatalf("%s: status = %d, rota chamada = %v", c.name, rec.Code, called)
+		}
+		if c.code == "" {
+			continue
+		}
+		var body apierror.Error
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || body.Code != c.code {
+			t.Fatalf("%s: corpo = %+v (%v), esperado code %q", c.name, body, err, c.code)
+		}
+	}
+	if ok.token != "tok" || ok.remoteIP != "203.0.113.7" {
+		t.Fatalf("verificador recebeu token=%q ip=%q", ok.token, ok.remoteIP)
+	}
+}
+
+func TestCaptchaRequired_UnexpectedError(t *testing.T) {
+	rec, called := serveCaptcha(&fakeVerifier{err: errors.New("resposta inválida do hCaptcha")}, "tok")
+	if rec.Code != http.StatusServiceUnavailable || called {
+		t.Fatalf("status = %d, rota chamada = %v", rec.Code, called)
+	}
+}
@@ -0,0 +1,37 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Compressão das respostas (gzip/deflate) conforme Accept-Encoding e o tipo do conteúdo
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// DefaultCompressionLevel nível padrão do gzip: em listagens JSON grandes fica a poucos por cento
+// do nível 9 com uma fração do custo de CPU
+const DefaultCompressionLevel = 5
+
+// compressibleTypes tipos comprimidos: JSON, exportações CSV, XML das notas e a página da
+// documentação. PDFs e imagens já saem comprimidos e passam direto.
+var compressibleTypes = []string{
+	"application/json",
+	"text/csv",
+	"application/xml",
+	"text/html",
+	"text/plain",
+}
+
+// Compress comprime as respostas de compressibleTypes no melhor codificador aceito pelo cliente.
+// Docstring: nível fora de 1..9 usa DefaultCompressionLevel. O Compressor do chi prefere o
+// codificador registrado por último: um Brotli ("br") entra com SetEncoder e passa à frente do
+// gzip para quem o aceita. Vary: Accept-Encoding é acrescentado pelo próprio compressor.
+func Compress(level int) func(http.Handler) http.Handler {
+	if level < 1 || level > 9 {
+		level = DefaultCompressionLevel
+	}
+	return middleware.NewCompressor(level, compressibleTypes...).Handler
+}
@@ -0,0 +1,69 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da compressão das respostas por tipo de conteúdo
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompress_ByContentType(t *testing.T) {
+	body := strings.Repeat(`{"id":"x","valor":"100.00","status":"pendente"},`, 200)
+	cases := []struct {
+		contentType string
+		gzipped     bool
+	}{
+		{"application/json", true},
+		{"text/csv; charset=utf-8", true},
+		{"application/xml; charset=utf-8", true},
+		{"application/pdf", false},
+		{"image/png", false},
+	}
+	for _, c := range cases {
+		h := Compress(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", c.contentType)
+			w.Write([]byte(body))
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		gzipped := rec.Header().Get("Content-Encoding") == "gzip"
+		if gzipped != c.gzipped {
+			t.Fatalf("%s: gzip = %v, esperado %v", c.contentType, gzipped, c.gzipped)
+		}
+		if !gzipped {
+			continue
+		}
+		if rec.Body.Len() >= len(body) || !strings.Contains(rec.Header().Get("Vary"), "Accept-Encoding") {
+			t.Fatalf("%s: %d bytes comprimidos de %d, Vary=%q", c.contentType, rec.Body.Len(), len(body), rec.Header().Get("Vary"))
+		}
+		zr, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("%s: gzip inválido: %v", c.contentType, err)
+		}
+		if got, _ := io.ReadAll(zr); string(got) != body {
+			t.Fatalf("%s: corpo descomprimido diferente", c.contentType)
+		}
+	}
+}
+
+func TestCompress_WithoutAcceptEncoding(t *testing.T) {
+	h := Compress(5)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != `{"items":[]}` {
+		t.Fatalf("sem Accept-Encoding: encoding=%q body=%q", rec.Header().Get("Content-Encoding"), rec.Body.String())
+	}
+}
@@ -0,0 +1,126 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Middleware de CORS do roteador (origens permitidas, credenciais e cache do preflight)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+// Cabeçalhos de CORS comuns a todas as rotas
+const (
+	corsAllowMethods = "GET,POST,PUT,PATCH,DELETE,OPTIONS"
+	corsAllowHeaders = "Content-Type, Authorization, X-Requested-With, If-Match, If-None-Match, Idempotency-Key, X-Lite, X-Org-ID, X-API-Key, Accept-Profile, X-Captcha-Token"
+	// ETag carrega a versão da receita usada no If-Match (concorrência otimista); Idempotent-Replayed marca respostas repetidas (Idempotency-Key)
+	// Content-Profile confirma o formato negociado em Accept-Profile (page ou envelope)
+	corsExposeHeaders = "ETag, Idempotent-Replayed, Retry-After, X-Request-ID, Location, Content-Profile"
+)
+
+// CORSOptions política de CORS de um grupo de rotas.
+// Docstring: AllowedOrigins aceita "*" (qualquer origem), a origem exata ("https://dominio.com") ou
+// um sufixo ("*.vercel.app"); vazio responde "*" para qualquer origem, sem credenciais. AllowCredentials
+// só vale com lista de origens (a origem é ecoada). MaxAge define o cache do preflight (0 omite).
+type CORSOptions struct {
+	AllowedOrigins   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// CORSOptionsFromConfig política padrão da API (CORS_ORIGINS, CORS_ALLOW_CREDENTIALS e CORS_MAX_AGE)
+func CORSOptionsFromConfig(cfg *config.Config) CORSOptions {
+	return CORSOptions{
+		AllowedOrigins:   parseOrigins(cfg.CORSOrigins),
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	}
+}
+
+// CORS aplica os cabeçalhos de CORS e responde o preflight (OPTIONS) com 204.
+// Docstring: com lista de origens, bloqueia por padrão e só libera a origem que bater em uma regra.
+// Pode ser usado em um grupo de rotas para sobrepor a política global nas respostas.
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	maxAge := ""
+	if opts.MaxAge > 0 {
+		maxAge = strconv.Itoa(int(opts.MaxAge / time.Second))
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			allowOrigin := ""
+			switch {
+			case len(opts.AllowedOrigins) == 0:
+				allowOrigin = "*"
+			case origin != "" && matchOrigin(opts.AllowedOrigins, origin):
+				allowOrigin = origin
+			}
+
+			h := w.Header()
+			h.Del("Access-Control-Allow-Credentials")
+			if allowOrigin != "" {
+				h.Set("Access-Control-Allow-Origin", allowOrigin)
+				if allowOrigin != "*" {
+					h.Add("Vary", "Origin")
+					if opts.AllowCredentials {
+						h.Set("Access-Control-Allow-Credentials", "true")
+					}
+				}
+			}
+			h.Set("Access-Control-Allow-Methods", corsAllowMethods)
+			h.Set("Access-Control-Allow-Headers", corsAllowHeaders)
+			h.Set("Access-Control-Expose-Headers", corsExposeHeaders)
+
+			if r.Method == http.MethodOptions {
+				if maxAge != "" {
+					h.Set("Access-Control-Max-Age", maxAge)
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// parseOrigins converte a lista de origens separadas por vírgula em slice.
+// Ex.: "https://app.vercel.app,https://dominio.com" -> [ ... ]
+func parseOrigins(v string) []string {
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		if t := strings.TrimSpace(p); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// matchOrigin verifica se a origem bate em alguma regra permitida.
+// Regras suportadas:
+//   - "*" : permite qualquer origem
+//   - "https://dominio.com" : match exato
+//   - "*.vercel.app" : match por sufixo (subdomínios, não o domínio raiz)
+func matchOrigin(allowed []string, origin string) bool {
+	for _, a := range allowed {
+		a = strings.TrimSpace(a)
+		switch {
+		case a == "":
+			continue
+		case a == "*":
+			return true
+		case strings.HasPrefix(a, "*."):
+			// Sufixo: remove o '*' e mantém o ponto
+			if strings.HasSuffix(origin, strings.TrimPrefix(a, "*")) {
+				return true
+			}
+		case origin == a:
+			return true
+		}
+	}
+	return false
+}
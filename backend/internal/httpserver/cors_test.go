@@ -0,0 +1,79 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do middleware de CORS
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMatchOrigin(t *testing.T) {
+	allowed := []string{"https://recibofast.com", "*.vercel.app", " "}
+	cases := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://recibofast.com", true},
+		{"https://app.recibofast.com", false},
+		{"https://recibofast-git-main.vercel.app", true},
+		{"https://a.b.vercel.app", true},
+		{"https://vercel.app", false},
+		{"https://evilvercel.app", false},
+		{"https://x.vercel.app.evil.com", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		if got := matchOrigin(allowed, c.origin); got != c.want {
+			t.Errorf("matchOrigin(%q) = %v, want %v", c.origin, got, c.want)
+		}
+	}
+	if !matchOrigin([]string{"*"}, "http://localhost:3000") {
+		t.Error(`"*" deve aceitar qualquer origem`)
+	}
+}
+
+func corsRequest(opts CORSOptions, method, origin string) (*httptest.ResponseRecorder, bool) {
+	called := false
+	h := CORS(opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+	req := httptest.NewRequest(method, "/api/v1/incomes", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr, called
+}
+
+func TestCORSPreflight(t *testing.T) {
+	opts := CORSOptions{AllowedOrigins: []string{"*.vercel.app"}, AllowCredentials: true, MaxAge: 10 * time.Minute}
+
+	rr, called := corsRequest(opts, http.MethodOptions, "https://app.vercel.app")
+	if called || rr.Code != http.StatusNoContent {
+		t.Fatalf("preflight: status = %d, handler chamado = %v", rr.Code, called)
+	}
+	h := rr.Header()
+	if h.Get("Access-Control-Allow-Origin") != "https://app.vercel.app" || h.Get("Access-Control-Allow-Credentials") != "true" ||
+		h.Get("Access-Control-Max-Age") != "600" || h.Get("Vary") != "Origin" {
+		t.Fatalf("cabeçalhos = %v", h)
+	}
+
+	rr, called = corsRequest(opts, http.MethodGet, "https://evil.com")
+	if !called || rr.Header().Get("Access-Control-Allow-Origin") != "" || rr.Header().Get("Access-Control-Allow-Credentials") != "" {
+		t.Fatalf("origem fora da lista: chamado = %v, cabeçalhos = %v", called, rr.Header())
+	}
+}
+
+func TestCORSWithoutOriginList(t *testing.T) {
+	rr, called := corsRequest(CORSOptions{AllowCredentials: true}, http.MethodGet, "https://qualquer.com")
+	if !called || rr.Header().Get("Access-Control-Allow-Origin") != "*" || rr.Header().Get("Access-Control-Allow-Credentials") != "" {
+		t.Fatalf("chamado = %v, cabeçalhos = %v", called, rr.Header())
+	}
+	if rr.Header().Get("Access-Control-Max-Age") != "" {
+		t.Fatalf("Max-Age fora do preflight")
+	}
+}
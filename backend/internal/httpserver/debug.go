@@ -0,0 +1,77 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Diagnóstico em produção (pprof, expvar e estatísticas do runtime) atrás do token administrativo
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// debugPrefix rotas de diagnóstico; ficam fora do timeout global (perfis de CPU levam 30s por padrão)
+const debugPrefix = "/debug/"
+
+// processStart início do processo, para o uptime em /debug/runtime
+var processStart = time.Now()
+
+// debugRoutes /debug/pprof/* (net/http/pprof), /debug/vars (expvar) e /debug/runtime.
+// Docstring: protegidas por AdminAuth (X-Admin-Token); sem ADMIN_TOKEN respondem 403. Como o
+// go tool pprof não envia cabeçalhos, baixe o perfil com curl e abra o arquivo localmente:
+// curl -H "X-Admin-Token: $ADMIN_TOKEN" https://api/debug/pprof/profile?seconds=30 -o cpu.pprof
+func debugRoutes(deps AppDeps) http.Handler {
+	r := chi.NewRouter()
+	r.Use(AdminAuth(deps))
+	r.Get("/runtime", runtimeStats)
+	r.Mount("/", middleware.Profiler())
+	return r
+}
+
+// runtimeStats memória, GC e goroutines do processo em JSON
+func runtimeStats(w http.ResponseWriter, r *http.Request) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	var lastGC *time.Time
+	if m.LastGC > 0 {
+		t := time.Unix(0, int64(m.LastGC)).UTC()
+		lastGC = &t
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"go_version":       runtime.Version(),
+		"uptime_seconds":   int64(time.Since(processStart).Seconds()),
+		"goroutines":       runtime.NumGoroutine(),
+		"gomaxprocs":       runtime.GOMAXPROCS(0),
+		"num_cpu":          runtime.NumCPU(),
+		"heap_alloc_bytes": m.HeapAlloc,
+		"heap_inuse_bytes": m.HeapInuse,
+		"heap_objects":     m.HeapObjects,
+		"sys_bytes":        m.Sys,
+		"total_alloc":      m.TotalAlloc,
+		"num_gc":           m.NumGC,
+		"gc_pause_total":   time.Duration(m.PauseTotalNs).String(),
+		"last_gc":          lastGC,
+	})
+}
+
+// requestTimeout aplica middleware.Timeout, exceto nas rotas de diagnóstico
+func requestTimeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		limited := middleware.Timeout(d)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, debugPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			limited.ServeHTTP(w, r)
+		})
+	}
+}
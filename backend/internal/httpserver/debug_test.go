@@ -0,0 +1,63 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes das rotas de diagnóstico (token administrativo, pprof, expvar e runtime)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"recibofast/internal/config"
+	"recibofast/internal/logging"
+)
+
+func TestDebugRoutesRequireAdminToken(t *testing.T) {
+	h := debugRoutes(AppDeps{Logger: logging.NewLogger("dev"), Cfg: &config.Config{AdminToken: "adm"}})
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("X-Admin-Token", token)
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := get("/runtime", ""); rr.Code != http.StatusUnauthorized {
+		t.Fatalf("sem token: status = %d", rr.Code)
+	}
+	if rr := get("/pprof/", "errado"); rr.Code != http.StatusUnauthorized {
+		t.Fatalf("token errado: status = %d", rr.Code)
+	}
+
+	rr := get("/runtime", "adm")
+	var stats map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&stats); err != nil || rr.Code != http.StatusOK || stats["goroutines"].(float64) < 1 {
+		t.Fatalf("runtime: status = %d, corpo = %v (%v)", rr.Code, stats, err)
+	}
+	if rr := get("/pprof/", "adm"); rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "heap") {
+		t.Fatalf("pprof: status = %d", rr.Code)
+	}
+	if rr := get("/vars", "adm"); rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "memstats") {
+		t.Fatalf("vars: status = %d", rr.Code)
+	}
+}
+
+func TestRequestTimeoutSkipsDebug(t *testing.T) {
+	var deadlines []bool
+	h := requestTimeout(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.Context().Deadline()
+		deadlines = append(deadlines, ok)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/incomes", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/debug/pprof/profile", nil))
+	if len(deadlines) != 2 || !deadlines[0] || deadlines[1] {
+		t.Fatalf("prazos = %v; /debug não deveria ter timeout", deadlines)
+	}
+}
@@ -0,0 +1,77 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Autenticação das chamadas gRPC com o mesmo SupabaseAuth (JWT, X-API-Key, X-Org-ID) das rotas REST
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc/metadata"
+
+	"recibofast/internal/apierror"
+	"recibofast/internal/grpcapi"
+	"recibofast/internal/repositories"
+)
+
+// grpcAuthHeaders metadados gRPC repassados como cabeçalhos ao SupabaseAuth e ao Locale
+var grpcAuthHeaders = []string{"Authorization", "X-API-Key", "X-Org-ID", "X-Debug-User", "Accept-Language", "X-Timezone"}
+
+type grpcAuthContextKey struct{}
+
+// grpcAuth implementa grpcapi.Authenticator passando a chamada pela cadeia Locale + SupabaseAuth.
+// Docstring: a chamada vira uma requisição sintética com o método e a rota REST equivalentes (escopo
+// da chave de API, papel de leitura na organização, limite por usuário); a resposta de erro do
+// middleware (apierror) volta como grpcapi.HTTPError com o mesmo status, código e mensagem.
+type grpcAuth struct {
+	chain http.Handler
+}
+
+func newGRPCAuth(deps AppDeps, settings repositories.SettingsRepository) *grpcAuth {
+	capture := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if dst, ok := r.Context().Value(grpcAuthContextKey{}).(*context.Context); ok {
+			*dst = r.Context()
+		}
+	})
+	return &grpcAuth{chain: Locale(deps, settings)(SupabaseAuth(deps)(capture))}
+}
+
+// Authenticate autentica a chamada como method + path na API REST
+func (a *grpcAuth) Authenticate(ctx context.Context, method, path string) (context.Context, error) {
+	var authed context.Context
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, grpcAuthContextKey{}, &authed), method, path, nil)
+	if err != nil {
+		return nil, grpcapi.HTTPError(http.StatusBadRequest, apierror.CodeForStatus(http.StatusBadRequest), "rota inválida")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, h := range grpcAuthHeaders {
+		if v := md.Get(h); len(v) > 0 {
+			req.Header.Set(h, v[0])
+		}
+	}
+	rec := &grpcAuthRecorder{header: http.Header{}, status: http.StatusOK}
+	a.chain.ServeHTTP(rec, req)
+	if authed != nil {
+		return authed, nil
+	}
+	var body apierror.Error
+	if err := json.Unmarshal(rec.body.Bytes(), &body); err != nil || body.Code == "" {
+		body.Code, body.Message = apierror.CodeForStatus(rec.status), http.StatusText(rec.status)
+	}
+	return nil, grpcapi.HTTPError(rec.status, body.Code, body.Message)
+}
+
+// grpcAuthRecorder guarda o status e o corpo da resposta de erro do middleware
+type grpcAuthRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *grpcAuthRecorder) Header() http.Header         { return r.header }
+func (r *grpcAuthRecorder) WriteHeader(status int)      { r.status = status }
+func (r *grpcAuthRecorder) Write(b []byte) (int, error) { return r.body.Write(b) }
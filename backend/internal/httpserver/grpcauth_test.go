@@ -0,0 +1,83 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da autenticação das chamadas gRPC pelo SupabaseAuth (chave de API, bloqueio de conta)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"recibofast/internal/config"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/grpcapi"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+)
+
+func TestGRPCAuthUsesRESTRules(t *testing.T) {
+	owner, locked := uuid.New(), uuid.New()
+	deps := AppDeps{Logger: logging.NewLogger("dev"), Cfg: &config.Config{Env: "dev"}}
+	deps.apiKeys = keySet{"rfk_ok": {OwnerID: owner, Scopes: []string{"incomes:read"}}}
+	deps.accountLocks = lockSet{locked: map[uuid.UUID]bool{locked: true}}
+	auth := newGRPCAuth(deps, nil)
+
+	incoming := func(kv ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	}
+
+	ctx, err := auth.Authenticate(incoming("x-api-key", "rfk_ok"), http.MethodGet, "/api/v1/incomes/123")
+	if err != nil {
+		t.Fatalf("leitura com escopo: %v", err)
+	}
+	if user, _ := ctxhelper.GetUserID(ctx); user != owner.String() {
+		t.Fatalf("user = %s, want %s", user, owner)
+	}
+
+	cases := []struct {
+		name       string
+		md         []string
+		method     string
+		wantCode   codes.Code
+		wantStatus int
+		wantAPI    string
+	}{
+		{"escrita sem escopo", []string{"x-api-key", "rfk_ok"}, http.MethodPost, codes.PermissionDenied, http.StatusForbidden, "api_key_scope"},
+		{"chave inválida", []string{"x-api-key", "rfk_outra"}, http.MethodGet, codes.Unauthenticated, http.StatusUnauthorized, "api_key_invalid"},
+		{"sem credenciais", nil, http.MethodGet, codes.Unauthenticated, http.StatusUnauthorized, "unauthorized"},
+		{"conta bloqueada", []string{"x-debug-user", locked.String()}, http.MethodGet, codes.FailedPrecondition, http.StatusLocked, "account_locked"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := auth.Authenticate(incoming(tc.md...), tc.method, "/api/v1/incomes")
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("código = %v, want %v (%v)", status.Code(err), tc.wantCode, err)
+			}
+			if code, api, _ := grpcapi.HTTPStatus(err); code != tc.wantStatus || api != tc.wantAPI {
+				t.Fatalf("HTTPStatus = %d %s, want %d %s", code, api, tc.wantStatus, tc.wantAPI)
+			}
+		})
+	}
+
+	// Leitor da organização só lê, como nas rotas REST
+	org := uuid.New()
+	member := uuid.New()
+	deps.workspaces = orgSet{member: {OrgID: org, WorkspaceOwnerID: owner, Role: models.OrgRoleViewer}}
+	auth = newGRPCAuth(deps, nil)
+	md := []string{"x-debug-user", member.String(), "x-org-id", org.String()}
+	if ctx, err := auth.Authenticate(incoming(md...), http.MethodGet, "/api/v1/incomes"); err != nil {
+		t.Fatalf("leitura na organização: %v", err)
+	} else if user, _ := ctxhelper.GetUserID(ctx); user != owner.String() {
+		t.Fatalf("dono dos dados = %s, want %s", user, owner)
+	}
+	if _, err := auth.Authenticate(incoming(md...), http.MethodPost, "/api/v1/incomes"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("escrita do leitor: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Middleware de idempotência (Idempotency-Key) para criações sujeitas a repetição
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// maxIdempotentBody limite do corpo lido para calcular o resumo da requisição
+const maxIdempotentBody = 1 << 20
+
+// Cabeçalhos da resposta original repetidos junto com o corpo
+var idempotentReplayHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Idempotency repete a resposta original quando o cliente reenvia a mesma Idempotency-Key.
+// Docstring: deve vir depois do SupabaseAuth (a chave é por usuário). Sem o cabeçalho a requisição
+// segue normalmente. Mesma chave com outro corpo ou rota responde 422; com a original ainda em
+// andamento, 409. Respostas 5xx não são guardadas: a repetição executa de novo.
+func Idempotency(store repositories.IdempotencyRepository, log logging.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(models.IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if err := models.ValidateIdempotencyKey(key); err != nil {
+				idempotencyError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			userIDStr, _ := ctxhelper.GetUserID(r.Context())
+			ownerID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				idempotencyError(w, http.StatusUnauthorized, "usuário não autenticado")
+				return
+			}
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBody))
+			if err != nil {
+				idempotencyError(w, http.StatusRequestEntityTooLarge, "corpo da requisição muito grande")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			rec := &models.IdempotencyRecord{
+				OwnerID:     ownerID,
+				Key:         key,
+				Method:      r.Method,
+				Path:        r.URL.Path,
+				RequestHash: models.IdempotencyRequestHash(r.Method, r.URL.Path, body),
+			}
+			existing, err := store.Reserve(r.Context(), rec, models.IdempotencyKeyTTL)
+			if errors.Is(err, models.ErrIdempotencyInProgress) {
+				w.Header().Set("Retry-After", "1")
+				idempotencyError(w, http.StatusConflict, err.Error())
+				return
+			}
+			if err != nil {
+				log.Error("erro ao reservar Idempotency-Key", logging.Field{Key: "error", Val: err.Error()})
+				idempotencyError(w, http.StatusInternalServerError, "erro interno do servidor")
+				return
+			}
+			if existing != nil {
+				replayIdempotent(w, existing, rec)
+				return
+			}
+
+			rw := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+			// A resposta é guardada (ou a reserva liberada) mesmo se o cliente desconectar
+			bg := context.WithoutCancel(r.Context())
+			defer func() {
+				if p := recover(); p != nil {
+					_ = store.Release(bg, ownerID, key)
+					panic(p)
+				}
+			}()
+			next.ServeHTTP(rw, r)
+
+			if rw.status >= http.StatusInternalServerError {
+				if err := store.Release(bg, ownerID, key); err != nil {
+					log.Error("erro ao liberar Idempotency-Key", logging.Field{Key: "error", Val: err.Error()})
+				}
+				return
+			}
+			rec.StatusCode, rec.ResponseBody = rw.status, rw.body.Bytes()
+			rec.ResponseHeaders = map[string]string{}
+			for _, h := range idempotentReplayHeaders {
+				if v := w.Header().Get(h); v != "" {
+					rec.ResponseHeaders[h] = v
+				}
+			}
+			if err := store.Complete(bg, rec); err != nil {
+				log.Error("erro ao guardar resposta da Idempotency-Key", logging.Field{Key: "error", Val: err.Error()})
+			}
+		})
+	}
+}
+
+// replayIdempotent responde a repetição com a resposta original
+func replayIdempotent(w http.ResponseWriter, existing, req *models.IdempotencyRecord) {
+	if !existing.Matches(req.Method, req.Path, req.RequestHash) {
+		idempotencyError(w, http.StatusUnprocessableEntity, models.ErrIdempotencyKeyReused.Error())
+		return
+	}
+	if !existing.Completed() {
+		w.Header().Set("Retry-After", "1")
+		idempotencyError(w, http.StatusConflict, models.ErrIdempotencyInProgress.Error())
+		return
+	}
+	for k, v := range existing.ResponseHeaders {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(existing.StatusCode)
+	w.Write(existing.ResponseBody)
+}
+
+// recordingWriter repassa a resposta ao cliente e guarda status e corpo
+type recordingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status, w.wroteHeader = code, true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func idempotencyError(w http.ResponseWriter, code int, msg string) {
+	apierror.Write(w, code, msg)
+}
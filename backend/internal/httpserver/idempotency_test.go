@@ -0,0 +1,207 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do middleware de idempotência (repetição, reutilização da chave e falhas)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/handlers"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/services"
+)
+
+// memoryIdempotencyStore implementa repositories.IdempotencyRepository em memória
+type memoryIdempotencyStore struct {
+	mu   sync.Mutex
+	recs map[string]*models.IdempotencyRecord
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, rec *models.IdempotencyRecord, ttl time.Duration) (*models.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := rec.OwnerID.String() + "/" + rec.Key
+	if existing, ok := s.recs[k]; ok {
+		cp := *existing
+		return &cp, nil
+	}
+	cp := *rec
+	s.recs[k] = &cp
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, rec *models.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *rec
+	s.recs[rec.OwnerID.String()+"/"+rec.Key] = &cp
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, ownerID uuid.UUID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := ownerID.String() + "/" + key
+	if rec, ok := s.recs[k]; ok && !rec.Completed() {
+		delete(s.recs, k)
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error) {
+	return 0, nil
+}
+
+func newIdempotentServer(status int) (http.Handler, *int) {
+	calls := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"call":` + strconv.Itoa(calls) + `}`))
+	})
+	store := &memoryIdempotencyStore{recs: map[string]*models.IdempotencyRecord{}}
+	return Idempotency(store, logging.NewLogger("dev"))(next), &calls
+}
+
+func idempotentRequest(owner uuid.UUID, key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/payments/", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(models.IdempotencyKeyHeader, key)
+	}
+	return req.WithContext(ctxhelper.SetUserID(req.Context(), owner.String()))
+}
+
+func TestIdempotency_ReplaysOriginalResponse(t *testing.T) {
+	h, calls := newIdempotentServer(http.StatusCreated)
+	owner := uuid.New()
+
+	first := httptest.NewRecorder()
+	h.ServeHTTP(first, idempotentRequest(owner, "k1", `{"valor":"10.00"}`))
+	retry := httptest.NewRecorder()
+	h.ServeHTTP(retry, idempotentRequest(owner, "k1", `{"valor":"10.00"}`))
+
+	if *calls != 1 {
+		t.Fatalf("handler chamado %d vezes, esperado 1", *calls)
+	}
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Fatalf("repetição = %d %s, esperado %d %s", retry.Code, retry.Body, first.Code, first.Body)
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" || retry.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("cabeçalhos da repetição = %v", retry.Header())
+	}
+
+	// Outro usuário com a mesma chave não recebe a resposta guardada
+	other := httptest.NewRecorder()
+	h.ServeHTTP(other, idempotentRequest(uuid.New(), "k1", `{"valor":"10.00"}`))
+	if *calls != 2 {
+		t.Fatalf("chave deveria ser por usuário; chamadas = %d", *calls)
+	}
+}
+
+func TestIdempotency_KeyReusedWithDifferentBody(t *testing.T) {
+	h, calls := newIdempotentServer(http.StatusCreated)
+	owner := uuid.New()
+	h.ServeHTTP(httptest.NewRecorder(), idempotentRequest(owner, "k1", `{"valor":"10.00"}`))
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, idempotentRequest(owner, "k1", `{"valor":"99.00"}`))
+	if rr.Code != http.StatusUnprocessableEntity || *calls != 1 {
+		t.Fatalf("status = %d, chamadas = %d; esperado 422 sem executar", rr.Code, *calls)
+	}
+}
+
+func TestIdempotency_ServerErrorIsNotStored(t *testing.T) {
+	h, calls := newIdempotentServer(http.StatusInternalServerError)
+	owner := uuid.New()
+	for i := 0; i < 2; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), idempotentRequest(owner, "k1", `{}`))
+	}
+	if *calls != 2 {
+		t.Fatalf("após 5xx a repetição deve executar de novo; chamadas = %d", *calls)
+	}
+}
+
+// flakyPaymentService falha no primeiro AddPayment (banco indisponível) e registra nos seguintes
+type flakyPaymentService struct {
+	services.IncomeService
+	calls int
+}
+
+func (f *flakyPaymentService) AddPayment(ctx context.Context, ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error) {
+	f.calls++
+	if f.calls == 1 {
+		return nil, fmt.Errorf("erro ao adicionar pagamento: %w", errors.New("conn reset by peer"))
+	}
+	return &models.PaymentResponse{Payment: models.Payment{ID: uuid.New(), IncomeID: req.IncomeID, Valor: req.Valor}}, nil
+}
+
+func TestIdempotency_TransientPaymentFailureCanBeRetried(t *testing.T) {
+	svc := &flakyPaymentService{}
+	store := &memoryIdempotencyStore{recs: map[string]*models.IdempotencyRecord{}}
+	h := Idempotency(store, logging.NewLogger("dev"))(
+		http.HandlerFunc(handlers.NewIncomeHandlers(svc, logging.NewLogger("dev")).AddPayment))
+	owner, body := uuid.New(), `{"income_id":"`+uuid.New().String()+`","valor":"10.00"}`
+
+	first := httptest.NewRecorder()
+	h.ServeHTTP(first, idempotentRequest(owner, "k1", body))
+	retry := httptest.NewRecorder()
+	h.ServeHTTP(retry, idempotentRequest(owner, "k1", body))
+
+	if first.Code != http.StatusInternalServerError {
+		t.Fatalf("falha transitória = %d, esperado 500", first.Code)
+	}
+	if retry.Code != http.StatusCreated || retry.Header().Get("Idempotent-Replayed") != "" || svc.calls != 2 {
+		t.Fatalf("repetição = %d (replay %q), chamadas = %d; esperado 201 executado de novo",
+			retry.Code, retry.Header().Get("Idempotent-Replayed"), svc.calls)
+	}
+}
+
+func TestIdempotency_WithoutKeyAndInvalidKey(t *testing.T) {
+	h, calls := newIdempotentServer(http.StatusCreated)
+	owner := uuid.New()
+	h.ServeHTTP(httptest.NewRecorder(), idempotentRequest(owner, "", `{}`))
+	h.ServeHTTP(httptest.NewRecorder(), idempotentRequest(owner, "", `{}`))
+	if *calls != 2 {
+		t.Fatalf("sem chave cada requisição executa; chamadas = %d", *calls)
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, idempotentRequest(owner, strings.Repeat("x", models.MaxIdempotencyKeyLen+1), `{}`))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("chave longa: status = %d, esperado 400", rr.Code)
+	}
+}
+
+func TestIdempotency_InProgressReturnsConflict(t *testing.T) {
+	owner := uuid.New()
+	store := &memoryIdempotencyStore{recs: map[string]*models.IdempotencyRecord{}}
+	req := idempotentRequest(owner, "k1", `{}`)
+	store.Reserve(context.Background(), &models.IdempotencyRecord{
+		OwnerID: owner, Key: "k1", Method: http.MethodPost, Path: req.URL.Path,
+		RequestHash: models.IdempotencyRequestHash(http.MethodPost, req.URL.Path, []byte(`{}`)),
+	}, models.IdempotencyKeyTTL)
+
+	h := Idempotency(store, logging.NewLogger("dev"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler não deve executar com a original em andamento")
+	}))
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusConflict || rr.Header().Get("Retry-After") == "" {
+		t.Fatalf("status = %d, Retry-After = %q; esperado 409", rr.Code, rr.Header().Get("Retry-After"))
+	}
+}
@@ -0,0 +1,91 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Cache do JWKS do Supabase usado na validação dos JWT
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	"recibofast/internal/logging"
+)
+
+// jwksCache mantém o JWKS em memória e o atualiza em segundo plano a cada ttl.
+// Docstring: se uma atualização falhar, segue com o último conjunto de chaves válido, de modo
+// que uma indisponibilidade do Supabase não derruba a autenticação de quem já tem token.
+type jwksCache struct {
+	url   string
+	ttl   time.Duration
+	cache *jwk.Cache
+	log   logging.Logger
+
+	mu        sync.RWMutex
+	last      jwk.Set
+	fetchedAt time.Time
+}
+
+// newJWKSCache registra a URL no jwk.Cache; a atualização em segundo plano vive enquanto ctx viver
+func newJWKSCache(ctx context.Context, url string, ttl time.Duration, log logging.Logger) *jwksCache {
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	c := &jwksCache{url: url, ttl: ttl, cache: jwk.NewCache(ctx), log: log}
+	if url != "" {
+		fetched := jwk.PostFetchFunc(func(_ string, set jwk.Set) (jwk.Set, error) {
+			c.mu.Lock()
+			c.fetchedAt = time.Now()
+			c.mu.Unlock()
+			return set, nil
+		})
+		if err := c.cache.Register(url, jwk.WithRefreshInterval(ttl), jwk.WithHTTPClient(http.DefaultClient), jwk.WithPostFetcher(fetched)); err != nil {
+			log.Error("erro ao registrar JWKS no cache", logging.Field{Key: "error", Val: err.Error()})
+		}
+	}
+	return c
+}
+
+// KeySet retorna o JWKS em cache (busca na primeira chamada) ou o último conjunto válido
+func (c *jwksCache) KeySet(ctx context.Context) (jwk.Set, error) {
+	set, err := c.cache.Get(ctx, c.url)
+	if err == nil && set.Len() > 0 {
+		c.mu.Lock()
+		c.last = set
+		c.mu.Unlock()
+		return set, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("JWKS sem chaves")
+	}
+
+	c.mu.RLock()
+	last := c.last
+	c.mu.RUnlock()
+	if last != nil {
+		c.log.Warn("falha ao obter JWKS; usando o último conjunto válido", logging.Field{Key: "error", Val: err.Error()})
+		return last, nil
+	}
+	return nil, fmt.Errorf("falha ao buscar JWKS: %w", err)
+}
+
+// Check informa se há chaves disponíveis e se a última busca bem-sucedida está dentro de 2×ttl (readiness).
+// Docstring: o fallback para o último conjunto válido mantém a autenticação funcionando, mas um JWKS
+// que não atualiza há mais de dois ciclos indica Supabase inacessível e não detecta rotação de chaves.
+func (c *jwksCache) Check(ctx context.Context) error {
+	if _, err := c.KeySet(ctx); err != nil {
+		return err
+	}
+	c.mu.RLock()
+	fetchedAt := c.fetchedAt
+	c.mu.RUnlock()
+	if age := time.Since(fetchedAt); age > 2*c.ttl {
+		return fmt.Errorf("JWKS desatualizado: última atualização há %s", age.Round(time.Second))
+	}
+	return nil
+}
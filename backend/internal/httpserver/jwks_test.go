@@ -0,0 +1,101 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do cache de JWKS (uma busca por TTL, reserva com o último conjunto válido e claims)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+)
+
+func TestJWKSCacheFetchesOnceAndFallsBack(t *testing.T) {
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, err := jwk.FromRaw(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv.Set(jwk.KeyIDKey, "k1")
+	priv.Set(jwk.AlgorithmKey, jwa.RS256)
+	pub, err := priv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := jwk.NewSet()
+	set.AddKey(pub)
+
+	var fetches atomic.Int32
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if down.Load() {
+			http.Error(w, "indisponível", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(set)
+	}))
+	defer srv.Close()
+
+	tok, _ := jwt.NewBuilder().Subject("user-1").Expiration(time.Now().Add(time.Hour)).
+		Claim("email", "ana@exemplo.com").Claim("role", "authenticated").
+		Claim("app_metadata", map[string]interface{}{"plan": "pro"}).Build()
+	signed, err := jwt.Sign(tok, jwt.WithKey(jwa.RS256, priv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := logging.NewLogger("dev")
+	keys := newJWKSCache(ctx, srv.URL, time.Hour, log)
+	for i := 0; i < 3; i++ {
+		user, err := validateSupabaseJWT(ctx, string(signed), keys, log)
+		if err != nil || user.ID != "user-1" {
+			t.Fatalf("validação %d: user = %+v, err = %v", i, user, err)
+		}
+	}
+	user, _ := validateSupabaseJWT(ctx, string(signed), keys, log)
+	if user.Email != "ana@exemplo.com" || user.Role != "authenticated" || user.Plan() != "pro" || user.Method != ctxhelper.AuthMethodJWT {
+		t.Fatalf("claims inesperadas: %+v", user)
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Fatalf("JWKS buscado %d vezes; esperado 1", n)
+	}
+
+	// Supabase fora do ar: sem conjunto anterior falha, com conjunto anterior segue validando
+	down.Store(true)
+	fresh := newJWKSCache(ctx, srv.URL, time.Hour, log)
+	if _, err := fresh.KeySet(ctx); err == nil {
+		t.Fatal("esperado erro sem conjunto válido anterior")
+	}
+	fresh.last = keys.last
+	if user, err := validateSupabaseJWT(ctx, string(signed), fresh, log); err != nil || user.ID != "user-1" {
+		t.Fatalf("reserva: user = %+v, err = %v", user, err)
+	}
+
+	// Readiness: o cache atualizado está pronto; a reserva sem busca recente está desatualizada
+	if err := keys.Check(ctx); err != nil {
+		t.Fatalf("Check com JWKS recente: %v", err)
+	}
+	if err := fresh.Check(ctx); err == nil {
+		t.Fatal("Check deveria acusar JWKS desatualizado")
+	}
+}
@@ -7,17 +7,24 @@ package httpserver
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
-	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 
+	"recibofast/internal/apierror"
 	"recibofast/internal/config"
 	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/locale"
 	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
 )
 
 
@@ -26,7 +33,15 @@ import (
 // Docstring: Middleware que valida tokens JWT do Supabase, extrai o user_id do subject
 // e adiciona ao contexto da requisição. Em ambiente dev, aceita header X-Debug-User como fallback.
 func SupabaseAuth(deps AppDeps) func(http.Handler) http.Handler {
+	keys := deps.jwks
+	if keys == nil {
+		keys = newJWKSCache(context.Background(), deps.Cfg.JWKSURL, deps.Cfg.JWKSCacheTTL, deps.Logger)
+	}
 	return func(next http.Handler) http.Handler {
+		// Limite por usuário roda depois da autenticação (usa o AuthUser do contexto)
+		if deps.userLimits != nil {
+			next = deps.userLimits.Wrap(next)
+		}
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			deps.Logger.Debug("SupabaseAuth middleware executado", logging.Field{Key: "env", Val: deps.Cfg.Env}, logging.Field{Key: "path", Val: r.URL.Path})
 			
@@ -34,73 +49,258 @@ func SupabaseAuth(deps AppDeps) func(http.Handler) http.Handler {
 			if deps.Cfg.Env == "dev" {
 				if debugUser := r.Header.Get("X-Debug-User"); debugUser != "" {
 					deps.Logger.Debug("Usando X-Debug-User", logging.Field{Key: "user", Val: debugUser})
-					ctx := ctxhelper.SetUserID(r.Context(), debugUser)
-					next.ServeHTTP(w, r.WithContext(ctx))
+					serveAuthenticated(deps, next, w, r, &ctxhelper.AuthUser{ID: debugUser, Method: ctxhelper.AuthMethodDebug})
 					return
 				}
 				deps.Logger.Debug("X-Debug-User não encontrado no header")
 			}
 
+			// Chave de API (X-API-Key) substitui o JWT, limitada aos escopos da chave
+			if apiKey := r.Header.Get("X-API-Key"); apiKey != "" && deps.apiKeys != nil {
+				serveAPIKey(deps, next, w, r, apiKey)
+				return
+			}
+
 			// Extrai o token JWT do header Authorization
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				http.Error(w, "Token de autorização requerido", http.StatusUnauthorized)
+				apierror.Write(w, http.StatusUnauthorized, "Token de autorização requerido")
 				return
 			}
 
 			// Verifica se o header tem o formato "Bearer <token>"
 			parts := strings.SplitN(authHeader, " ", 2)
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				http.Error(w, "Formato de token inválido", http.StatusUnauthorized)
+				apierror.Write(w, http.StatusUnauthorized, "Formato de token inválido")
 				return
 			}
 
 			tokenString := parts[1]
 
 			// Valida o token JWT usando JWKS do Supabase
-			userID, err := validateSupabaseJWT(tokenString, deps.Cfg.JWKSURL, deps.Logger)
+			user, err := validateSupabaseJWT(r.Context(), tokenString, keys, deps.Logger)
 			if err != nil {
 				deps.Logger.Error("Falha na validação do JWT", logging.Field{Key: "error", Val: err.Error()})
-				http.Error(w, "Token inválido", http.StatusUnauthorized)
+				apierror.Write(w, http.StatusUnauthorized, "Token inválido")
 				return
 			}
 
-			// Adiciona o user_id ao contexto
-			ctx := ctxhelper.SetUserID(r.Context(), userID)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			// Adiciona o usuário (user_id e claims) ao contexto
+			serveAuthenticated(deps, next, w, r, user)
 		})
 	}
 }
 
+// serveAuthenticated registra o AuthUser e o dono dos dados da requisição e segue, salvo se a conta
+// estiver bloqueada.
+// Docstring: com X-Org-ID o usuário precisa ser membro da organização e os dados passam a ser os do
+// dono dela (viewer só com métodos de leitura). Com exclusão da conta solicitada (do usuário ou do
+// dono da organização) responde 423; as rotas /account e /orgs montam o SupabaseAuth sem essas
+// etapas, e as da conta pessoal (perfil, configurações, webhooks, Web Push) sem o X-Org-ID.
+func serveAuthenticated(deps AppDeps, next http.Handler, w http.ResponseWriter, r *http.Request, user *ctxhelper.AuthUser) {
+	ctx := ctxhelper.SetAuthUser(r.Context(), user)
+	userID := user.ID
+	setAccessLogUser(ctx, userID)
+	owners := []string{userID}
+	workspaceID := userID
+	if orgHeader := r.Header.Get("X-Org-ID"); orgHeader != "" && deps.workspaces != nil {
+		orgID, err := uuid.Parse(orgHeader)
+		uid, uerr := uuid.Parse(userID)
+		if err != nil || uerr != nil {
+			apierror.Write(w, http.StatusBadRequest, "X-Org-ID inválido")
+			return
+		}
+		m, err := deps.workspaces.ResolveWorkspace(ctx, orgID, uid)
+		if errors.Is(err, models.ErrOrgNotFound) {
+			apierror.Write(w, http.StatusForbidden, "Sem acesso à organização")
+			return
+		}
+		if err != nil {
+			deps.Logger.Error("erro ao resolver organização", logging.Field{Key: "error", Val: err.Error()})
+			apierror.Write(w, http.StatusInternalServerError, "Erro ao verificar a organização")
+			return
+		}
+		if !models.OrgRoleCanWrite(m.Role) && !readOnlyMethod(r.Method) {
+			apierror.WriteCode(w, http.StatusForbidden, "org_read_only", "Papel de leitura na organização", nil)
+			return
+		}
+		workspaceID = m.WorkspaceOwnerID.String()
+		if workspaceID != userID {
+			owners = append(owners, workspaceID)
+		}
+		ctx = ctxhelper.SetOrgRole(ctxhelper.SetActorID(ctx, userID), m.Role)
+	}
+
+	if deps.accountLocks != nil {
+		for _, owner := range owners {
+			uid, err := uuid.Parse(owner)
+			if err != nil {
+				continue
+			}
+			locked, err := deps.accountLocks.IsLocked(ctx, uid)
+			if err != nil {
+				deps.Logger.Error("erro ao verificar bloqueio da conta", logging.Field{Key: "error", Val: err.Error()})
+				apierror.Write(w, http.StatusInternalServerError, "Erro ao verificar a conta")
+				return
+			}
+			if locked {
+				apierror.WriteCode(w, http.StatusLocked, "account_locked", "Conta bloqueada: exclusão solicitada (cancele em /api/v1/account/deletion/cancel)", nil)
+				return
+			}
+		}
+	}
+	ctx = ctxhelper.SetUserID(ctx, workspaceID)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// serveAPIKey autentica pela chave de API e confere o escopo do recurso da rota.
+// Docstring: a chave age sempre no espaço do próprio dono (X-Org-ID é ignorado); métodos de leitura
+// exigem "<recurso>:read" e os demais "<recurso>:write".
+func serveAPIKey(deps AppDeps, next http.Handler, w http.ResponseWriter, r *http.Request, rawKey string) {
+	key, err := deps.apiKeys.Authenticate(r.Context(), rawKey)
+	if errors.Is(err, models.ErrAPIKeyInvalid) {
+		apierror.WriteCode(w, http.StatusUnauthorized, "api_key_invalid", "Chave de API inválida", nil)
+		return
+	}
+	if err != nil {
+		deps.Logger.Error("erro ao validar chave de API", logging.Field{Key: "error", Val: err.Error()})
+		apierror.Write(w, http.StatusInternalServerError, "Erro ao validar a chave de API")
+		return
+	}
+	access := models.APIKeyAccessWrite
+	if readOnlyMethod(r.Method) {
+		access = models.APIKeyAccessRead
+	}
+	resource := apiKeyResource(r.URL.Path)
+	if !models.APIKeyAllows(key.Scopes, resource, access) {
+		apierror.WriteCode(w, http.StatusForbidden, "api_key_scope", fmt.Sprintf("Chave de API sem o escopo %s:%s", resource, access),
+			map[string]string{"scope": resource + ":" + access})
+		return
+	}
+	deps.workspaces = nil
+	serveAuthenticated(deps, next, w, r, &ctxhelper.AuthUser{ID: key.OwnerID.String(), Method: ctxhelper.AuthMethodAPIKey, APIKeyID: key.ID.String()})
+}
+
+// apiKeyResource primeiro segmento da rota após /api/vN (ex.: /api/v1/incomes/123 -> incomes)
+func apiKeyResource(path string) string {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segs) >= 2 && segs[0] == "api" && strings.HasPrefix(segs[1], "v") {
+		segs = segs[2:]
+	}
+	if len(segs) == 0 {
+		return ""
+	}
+	return segs[0]
+}
+
+// readOnlyMethod métodos permitidos ao papel viewer
+func readOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
 // validateSupabaseJWT valida um token JWT usando JWKS do Supabase.
-// Docstring: Função que baixa as chaves públicas do Supabase via JWKS,
-// valida a assinatura do token e extrai o subject (user_id).
-func validateSupabaseJWT(tokenString, jwksURL string, logger logging.Logger) (string, error) {
-	// Cria um cache de chaves JWKS com TTL de 1 hora
-	set, err := jwk.Fetch(context.Background(), jwksURL, jwk.WithHTTPClient(http.DefaultClient))
+// Docstring: Função que obtém as chaves públicas do Supabase do cache de JWKS,
+// valida a assinatura do token e extrai o subject (user_id) e as claims email, role e app_metadata.
+func validateSupabaseJWT(ctx context.Context, tokenString string, keys *jwksCache, logger logging.Logger) (*ctxhelper.AuthUser, error) {
+	set, err := keys.KeySet(ctx)
 	if err != nil {
-		return "", fmt.Errorf("falha ao buscar JWKS: %w", err)
+		return nil, err
 	}
 
 	// Parseia e valida o token
 	token, err := jwt.Parse([]byte(tokenString), jwt.WithKeySet(set), jwt.WithValidate(true))
 	if err != nil {
-		return "", fmt.Errorf("falha ao validar token: %w", err)
+		return nil, fmt.Errorf("falha ao validar token: %w", err)
 	}
 
 	// Verifica se o token não expirou
 	if time.Now().After(token.Expiration()) {
-		return "", fmt.Errorf("token expirado")
+		return nil, fmt.Errorf("token expirado")
 	}
 
 	// Extrai o subject (user_id) do token
 	userID := token.Subject()
 	if userID == "" {
-		return "", fmt.Errorf("subject não encontrado no token")
+		return nil, fmt.Errorf("subject não encontrado no token")
 	}
 
+	// Claims do Supabase (ausentes ou de outro tipo ficam vazias)
+	user := &ctxhelper.AuthUser{ID: userID, Method: ctxhelper.AuthMethodJWT}
+	claims := token.PrivateClaims()
+	user.Email, _ = claims["email"].(string)
+	user.Role, _ = claims["role"].(string)
+	user.AppMetadata, _ = claims["app_metadata"].(map[string]interface{})
+
 	logger.Debug("Token JWT validado com sucesso", logging.Field{Key: "user_id", Val: userID})
-	return userID, nil
+	return user, nil
+}
+
+// AdminAuth protege rotas administrativas com o token configurado em ADMIN_TOKEN.
+// Docstring: compara o header X-Admin-Token em tempo constante; sem token configurado
+// as rotas administrativas ficam desabilitadas (403).
+func AdminAuth(deps AppDeps) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if deps.Cfg.AdminToken == "" {
+				apierror.Write(w, http.StatusForbidden, "Rotas administrativas desabilitadas")
+				return
+			}
+			token := r.Header.Get("X-Admin-Token")
+			if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(deps.Cfg.AdminToken)) != 1 {
+				deps.Logger.Warn("Acesso administrativo negado", logging.Field{Key: "path", Val: r.URL.Path})
+				apierror.Write(w, http.StatusUnauthorized, "Token administrativo inválido")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// PageEnvelope marca as requisições para responder listagens no envelope Page[T] (rotas /api/v2)
+func PageEnvelope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(ctxhelper.SetPageEnvelope(r.Context())))
+	})
+}
+
+// Locale resolve idioma e fuso do usuário para o contexto da requisição (pacote locale).
+// Docstring: rf_settings do usuário autenticado tem precedência; sem valor no perfil usa os
+// cabeçalhos Accept-Language e X-Timezone e, por fim, o padrão (pt-BR, America/Sao_Paulo).
+// O perfil só é consultado quando alguma saída formatada pede as configurações.
+func Locale(deps AppDeps, settings repositories.SettingsRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang := locale.FromAcceptLanguage(r.Header.Get("Accept-Language"))
+			tz := r.Header.Get("X-Timezone")
+			if !locale.ValidTimezone(tz) {
+				tz = ""
+			}
+			ctx := locale.WithResolver(r.Context(), func(ctx context.Context) (locale.Settings, bool) {
+				userIDStr, ok := ctxhelper.GetUserID(ctx)
+				if !ok {
+					return locale.Resolve(lang, tz), false
+				}
+				uid, err := uuid.Parse(userIDStr)
+				if err != nil {
+					return locale.Resolve(lang, tz), true
+				}
+				us, err := settings.Get(ctx, uid)
+				if err != nil {
+					deps.Logger.Warn("erro ao carregar idioma/fuso do usuário", logging.Field{Key: "error", Val: err.Error()})
+					return locale.Resolve(lang, tz), true
+				}
+				userLang, userTZ := lang, tz
+				if us.Locale != nil && locale.Normalize(*us.Locale) != "" {
+					userLang = *us.Locale
+				}
+				if us.Timezone != nil && locale.ValidTimezone(*us.Timezone) {
+					userTZ = *us.Timezone
+				}
+				return locale.Resolve(userLang, userTZ), true
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
 }
 
 // Helpers para obter dados do contexto
@@ -110,3 +310,14 @@ func UserIDFromContext(ctx context.Context) (string, bool) {
 
 // Evita import circular nas dependências
 func _avoid(_ *config.Config, _ logging.Logger) {}
+
+// RequestIDHeader devolve o ID da requisição (middleware.RequestID) no cabeçalho X-Request-ID.
+// Docstring: o apierror lê o cabeçalho para preencher request_id nas respostas de erro.
+func RequestIDHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := middleware.GetReqID(r.Context()); id != "" {
+			w.Header().Set(apierror.RequestIDHeader, id)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,191 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do SupabaseAuth (bloqueio de contas com exclusão solicitada e escopo de organização)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"recibofast/internal/config"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+)
+
+// lockSet implementa AccountLockChecker com um conjunto fixo de contas bloqueadas
+type lockSet struct {
+	locked map[uuid.UUID]bool
+	err    error
+}
+
+func (l lockSet) IsLocked(ctx context.Context, ownerID uuid.UUID) (bool, error) {
+	return l.locked[ownerID], l.err
+}
+
+func TestSupabaseAuthBlocksLockedAccounts(t *testing.T) {
+	lockedID, freeID := uuid.New(), uuid.New()
+	deps := AppDeps{Logger: logging.NewLogger("dev"), Cfg: &config.Config{Env: "dev"}}
+	deps.accountLocks = lockSet{locked: map[uuid.UUID]bool{lockedID: true}}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+
+	do := func(deps AppDeps, user uuid.UUID) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/incomes", nil)
+		req.Header.Set("X-Debug-User", user.String())
+		rec := httptest.NewRecorder()
+		SupabaseAuth(deps)(ok).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := do(deps, lockedID); code != http.StatusLocked {
+		t.Fatalf("conta bloqueada: status = %d", code)
+	}
+	if code := do(deps, freeID); code != http.StatusNoContent {
+		t.Fatalf("conta livre: status = %d", code)
+	}
+
+	// Rotas /account montam o middleware sem a checagem
+	lockFree := deps
+	lockFree.accountLocks = nil
+	if code := do(lockFree, lockedID); code != http.StatusNoContent {
+		t.Fatalf("rota sem checagem: status = %d", code)
+	}
+
+	deps.accountLocks = lockSet{err: errors.New("banco indisponível")}
+	if code := do(deps, freeID); code != http.StatusInternalServerError {
+		t.Fatalf("erro na checagem: status = %d", code)
+	}
+}
+
+// orgSet implementa WorkspaceResolver com vínculos fixos
+type orgSet map[uuid.UUID]models.OrgMembership
+
+func (o orgSet) ResolveWorkspace(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMembership, error) {
+	m, ok := o[userID]
+	if !ok || m.OrgID != orgID {
+		return nil, models.ErrOrgNotFound
+	}
+	return &m, nil
+}
+
+func TestSupabaseAuthOrgScope(t *testing.T) {
+	orgID, owner, editor, viewer, outsider := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	deps := AppDeps{Logger: logging.NewLogger("dev"), Cfg: &config.Config{Env: "dev"}}
+	deps.workspaces = orgSet{
+		editor: {OrgID: orgID, WorkspaceOwnerID: owner, Role: models.OrgRoleEditor},
+		viewer: {OrgID: orgID, WorkspaceOwnerID: owner, Role: models.OrgRoleViewer},
+	}
+	deps.accountLocks = lockSet{locked: map[uuid.UUID]bool{}}
+
+	var gotUser, gotActor, gotRole string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _ = ctxhelper.GetUserID(r.Context())
+		gotActor, _ = ctxhelper.GetActorID(r.Context())
+		gotRole = ctxhelper.GetOrgRole(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	do := func(method string, user uuid.UUID, org string) int {
+		req := httptest.NewRequest(method, "/api/v1/incomes", nil)
+		req.Header.Set("X-Debug-User", user.String())
+		if org != "" {
+			req.Header.Set("X-Org-ID", org)
+		}
+		rec := httptest.NewRecorder()
+		SupabaseAuth(deps)(next).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := do(http.MethodPost, editor, orgID.String()); code != http.StatusNoContent {
+		t.Fatalf("editor: status = %d", code)
+	}
+	if gotUser != owner.String() || gotActor != editor.String() || gotRole != models.OrgRoleEditor {
+		t.Fatalf("contexto inesperado: user=%s actor=%s role=%s", gotUser, gotActor, gotRole)
+	}
+	if code := do(http.MethodGet, viewer, orgID.String()); code != http.StatusNoContent {
+		t.Fatalf("viewer lendo: status = %d", code)
+	}
+	if code := do(http.MethodDelete, viewer, orgID.String()); code != http.StatusForbidden {
+		t.Fatalf("viewer alterando: status = %d", code)
+	}
+	if code := do(http.MethodGet, outsider, orgID.String()); code != http.StatusForbidden {
+		t.Fatalf("não membro: status = %d", code)
+	}
+	if code := do(http.MethodGet, editor, "abc"); code != http.StatusBadRequest {
+		t.Fatalf("X-Org-ID inválido: status = %d", code)
+	}
+	if code := do(http.MethodGet, editor, ""); code != http.StatusNoContent || gotUser != editor.String() || gotRole != "" {
+		t.Fatalf("sem organização o espaço é o próprio: status = %d, user = %s", code, gotUser)
+	}
+
+	// Conta do dono com exclusão solicitada bloqueia também os membros
+	deps.accountLocks = lockSet{locked: map[uuid.UUID]bool{owner: true}}
+	if code := do(http.MethodGet, editor, orgID.String()); code != http.StatusLocked {
+		t.Fatalf("dono bloqueado: status = %d", code)
+	}
+}
+
+// keySet implementa APIKeyAuthenticator com chaves fixas
+type keySet map[string]*models.APIKey
+
+func (k keySet) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	if ak, ok := k[key]; ok {
+		return ak, nil
+	}
+	return nil, models.ErrAPIKeyInvalid
+}
+
+func TestSupabaseAuthAPIKey(t *testing.T) {
+	owner := uuid.New()
+	deps := AppDeps{Logger: logging.NewLogger("prod"), Cfg: &config.Config{Env: "prod"}}
+	deps.apiKeys = keySet{"rfk_ok": {OwnerID: owner, Scopes: []string{"incomes:read", "receipts:write"}}}
+	deps.workspaces = orgSet{}
+
+	var gotUser string
+	var gotAuth *ctxhelper.AuthUser
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _ = ctxhelper.GetUserID(r.Context())
+		gotAuth, _ = ctxhelper.GetAuthUser(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	do := func(deps AppDeps, method, path, key string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-API-Key", key)
+		req.Header.Set("X-Org-ID", uuid.NewString())
+		rec := httptest.NewRecorder()
+		SupabaseAuth(deps)(next).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := do(deps, http.MethodGet, "/api/v1/incomes/123", "rfk_ok"); code != http.StatusNoContent || gotUser != owner.String() {
+		t.Fatalf("leitura com escopo: status = %d, user = %s", code, gotUser)
+	}
+	if gotAuth == nil || gotAuth.Method != ctxhelper.AuthMethodAPIKey || gotAuth.ID != owner.String() {
+		t.Fatalf("AuthUser inesperado: %+v", gotAuth)
+	}
+	if code := do(deps, http.MethodPost, "/api/v1/incomes", "rfk_ok"); code != http.StatusForbidden {
+		t.Fatalf("escrita sem escopo: status = %d", code)
+	}
+	if code := do(deps, http.MethodGet, "/api/v1/receipts", "rfk_ok"); code != http.StatusNoContent {
+		t.Fatalf("write inclui read: status = %d", code)
+	}
+	if code := do(deps, http.MethodGet, "/api/v1/payers", "rfk_ok"); code != http.StatusForbidden {
+		t.Fatalf("recurso fora dos escopos: status = %d", code)
+	}
+	if code := do(deps, http.MethodGet, "/api/v1/incomes", "rfk_outra"); code != http.StatusUnauthorized {
+		t.Fatalf("chave inválida: status = %d", code)
+	}
+
+	// Rotas que exigem login montam o middleware sem chaves: cai na exigência do JWT
+	loginOnly := deps
+	loginOnly.apiKeys = nil
+	if code := do(loginOnly, http.MethodGet, "/api/v1/api-keys", "rfk_ok"); code != http.StatusUnauthorized {
+		t.Fatalf("rota só com login: status = %d", code)
+	}
+}
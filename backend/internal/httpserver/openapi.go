@@ -0,0 +1,278 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Especificação OpenAPI da API (catálogo das operações, /api/v1/openapi.json e Swagger UI)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"recibofast/internal/apierror"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/openapi"
+)
+
+// apiVersion versão do contrato publicada em info.version
+const apiVersion = "1.0.0"
+
+// BuildOpenAPI monta a especificação a partir das rotas registradas no roteador (NewRouter).
+// Docstring: todas as rotas entram no documento; as do catálogo (apiEndpoints) trazem resumo e os
+// esquemas dos corpos, as demais entram com corpo livre. Diagnóstico (/debug) e a página do Swagger UI
+// ficam de fora.
+func BuildOpenAPI(h http.Handler) (*openapi.Document, error) {
+	routes, ok := h.(chi.Routes)
+	if !ok {
+		return nil, errNotChiRouter
+	}
+	var found []openapi.Route
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if strings.HasPrefix(route, "/debug/") || route == "/api/v1/docs" || method == http.MethodHead || method == http.MethodOptions {
+			return nil
+		}
+		found = append(found, openapi.Route{Method: method, Path: route})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reg := openapi.NewRegistry()
+	reg.Override(models.Money(0), &openapi.Schema{Type: "string", Format: "decimal",
+		Description: "valor em reais com duas casas (aceita também número na entrada)", Example: "1234.50"})
+
+	return openapi.Build(openapi.Spec{
+		Info: openapi.Info{
+			Title:       "ReciboFast API",
+			Description: "API do ReciboFast: receitas, pagamentos, recibos e integrações. Erros seguem o formato {code, message, details, request_id}.",
+			Version:     apiVersion,
+		},
+		Servers:   []openapi.Server{{URL: "/", Description: "servidor atual"}},
+		Routes:    found,
+		Endpoints: apiEndpoints(),
+		Registry:  reg,
+		Auth:      routeAuth,
+	})
+}
+
+var errNotChiRouter = errors.New("roteador sem suporte a listagem de rotas")
+
+// routeAuth autenticação de cada caminho, seguindo os grupos de NewRouter
+func routeAuth(path string) string {
+	switch {
+	case strings.HasPrefix(path, "/api/v1/admin/"), strings.HasPrefix(path, "/debug/"):
+		return openapi.AuthAdmin
+	case path == "/healthz", path == "/livez", path == "/readyz",
+		strings.HasPrefix(path, "/api/v1/captcha/"), strings.HasPrefix(path, "/api/v1/public/"),
+		path == "/api/v1/webhooks/pix", path == "/api/v1/webhooks/checkout",
+		path == "/api/v1/digest/unsubscribe", path == "/api/v1/openapi.json":
+		return openapi.AuthNone
+	}
+	return openapi.AuthUser
+}
+
+// Parâmetros de query comuns às listagens
+var (
+	qPage    = openapi.Parameter{Name: "page", In: "query", Description: "página (a partir de 1)", Schema: &openapi.Schema{Type: "integer"}}
+	qPerPage = openapi.Parameter{Name: "per_page", In: "query", Description: "itens por página", Schema: &openapi.Schema{Type: "integer"}}
+	qFields  = openapi.Parameter{Name: "fields", In: "query", Description: "campos de cada item, separados por vírgula (id vem sempre; ex.: valor,status,due_date)", Schema: &openapi.Schema{Type: "string"}}
+	qProfile = openapi.Parameter{Name: "Accept-Profile", In: "header", Description: `"page" devolve o envelope Page (mesmo formato da /api/v2); "envelope" devolve {data, meta, links, request_id}`, Schema: &openapi.Schema{Type: "string", Enum: []string{"page", "envelope"}}}
+)
+
+// apiEndpoints catálogo das operações com corpos tipados.
+// Docstring: cada entrada precisa de uma rota registrada (o teste e o gerador falham se o catálogo
+// ficar desatualizado). Rotas novas sem entrada aparecem no documento com corpo livre.
+func apiEndpoints() []openapi.Endpoint {
+	list := []openapi.Parameter{qPage, qPerPage, qFields, qProfile}
+	return []openapi.Endpoint{
+		// Receitas
+		{Method: "GET", Path: "/api/v1/incomes", Summary: "Lista receitas", Query: list, Response: models.IncomeResponse{}},
+		{Method: "POST", Path: "/api/v1/incomes", Summary: "Cria receita", Request: models.IncomeRequest{}, Response: models.Income{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/incomes/{id}", Summary: "Detalha receita (com encargos do atraso)", Response: models.IncomeDetail{}},
+		{Method: "PUT", Path: "/api/v1/incomes/{id}", Summary: "Atualiza receita", Request: models.IncomeRequest{}, Response: models.Income{}},
+		{Method: "PATCH", Path: "/api/v1/incomes/{id}", Summary: "Atualiza campos da receita", Request: models.IncomePatchRequest{}, Response: models.Income{}},
+		{Method: "DELETE", Path: "/api/v1/incomes/{id}", Summary: "Remove receita", Status: http.StatusNoContent},
+		{Method: "POST", Path: "/api/v1/incomes/{id}/duplicate", Summary: "Duplica receita", Request: models.IncomeCopyRequest{}, Response: models.Income{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/incomes/{id}/payment-reversals", Summary: "Lista estornos da receita", Response: openapi.Items[models.PaymentReversalResponse]{}},
+
+		// Pagamentos
+		{Method: "POST", Path: "/api/v1/payments", Summary: "Registra pagamento", Request: models.PaymentRequest{}, Response: models.PaymentResponse{}, Status: http.StatusCreated},
+		{Method: "PUT", Path: "/api/v1/payments/{id}", Summary: "Corrige pagamento", Request: models.PaymentUpdateRequest{}, Response: models.PaymentResponse{}},
+		{Method: "POST", Path: "/api/v1/payments/{id}/reverse", Summary: "Estorna pagamento", Request: models.PaymentReversalRequest{}, Response: models.PaymentReversalResponse{}},
+
+		// Recibos
+		{Method: "GET", Path: "/api/v1/receipts", Summary: "Lista recibos", Query: list, Response: models.ReceiptListResponse{}},
+		{Method: "POST", Path: "/api/v1/receipts", Summary: "Emite recibo", Request: models.ReceiptRequest{}, Response: models.Receipt{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/receipts/{id}", Summary: "Detalha recibo", Response: models.Receipt{}},
+		{Method: "DELETE", Path: "/api/v1/receipts/{id}", Summary: "Remove recibo", Status: http.StatusNoContent},
+		{Method: "POST", Path: "/api/v1/receipts/{id}/send", Summary: "Envia recibo por e-mail", Request: models.ReceiptSendRequest{}, Response: models.ReceiptSendResponse{}},
+		{Method: "GET", Path: "/api/v1/receipts/{id}/share", Summary: "Link público do recibo", Response: models.ReceiptShareResponse{}},
+
+		// Pagadores
+		{Method: "GET", Path: "/api/v1/payers", Summary: "Lista pagadores", Query: list, Response: models.PayerListResponse{}},
+		{Method: "POST", Path: "/api/v1/payers", Summary: "Cadastra pagador", Request: models.PayerRequest{}, Response: models.Payer{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/payers/{id}", Summary: "Detalha pagador", Response: models.Payer{}},
+		{Method: "PUT", Path: "/api/v1/payers/{id}", Summary: "Atualiza pagador", Request: models.PayerRequest{}, Response: models.Payer{}},
+		{Method: "DELETE", Path: "/api/v1/payers/{id}", Summary: "Remove pagador", Status: http.StatusNoContent},
+
+		// Categorias
+		{Method: "GET", Path: "/api/v1/categories", Summary: "Lista categorias", Query: []openapi.Parameter{qFields, qProfile}, Response: openapi.Items[models.Category]{}},
+		{Method: "POST", Path: "/api/v1/categories", Summary: "Cria categoria", Request: models.CategoryRequest{}, Response: models.Category{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/categories/{id}", Summary: "Detalha categoria", Response: models.Category{}},
+		{Method: "PUT", Path: "/api/v1/categories/{id}", Summary: "Atualiza categoria", Request: models.CategoryRequest{}, Response: models.Category{}},
+		{Method: "DELETE", Path: "/api/v1/categories/{id}", Summary: "Remove categoria", Status: http.StatusNoContent},
+
+		// Modelos de receita
+		{Method: "GET", Path: "/api/v1/income-templates", Summary: "Lista modelos de receita", Query: []openapi.Parameter{qFields, qProfile}, Response: openapi.Items[models.IncomeTemplate]{}},
+		{Method: "POST", Path: "/api/v1/income-templates", Summary: "Cria modelo de receita", Request: models.IncomeTemplateRequest{}, Response: models.IncomeTemplate{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/income-templates/{id}", Summary: "Detalha modelo de receita", Response: models.IncomeTemplate{}},
+		{Method: "PUT", Path: "/api/v1/income-templates/{id}", Summary: "Atualiza modelo de receita", Request: models.IncomeTemplateRequest{}, Response: models.IncomeTemplate{}},
+		{Method: "DELETE", Path: "/api/v1/income-templates/{id}", Summary: "Remove modelo de receita", Status: http.StatusNoContent},
+		{Method: "POST", Path: "/api/v1/income-templates/{id}/incomes", Summary: "Gera receita a partir do modelo", Request: models.IncomeCopyRequest{}, Response: models.Income{}, Status: http.StatusCreated},
+
+		// Regras de categorização
+		{Method: "GET", Path: "/api/v1/rules", Summary: "Lista regras", Query: []openapi.Parameter{qFields, qProfile}, Response: openapi.Items[models.IncomeRule]{}},
+		{Method: "POST", Path: "/api/v1/rules", Summary: "Cria regra", Request: models.IncomeRuleRequest{}, Response: models.IncomeRule{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/rules/{id}", Summary: "Detalha regra", Response: models.IncomeRule{}},
+		{Method: "PUT", Path: "/api/v1/rules/{id}", Summary: "Atualiza regra", Request: models.IncomeRuleRequest{}, Response: models.IncomeRule{}},
+		{Method: "DELETE", Path: "/api/v1/rules/{id}", Summary: "Remove regra", Status: http.StatusNoContent},
+		{Method: "POST", Path: "/api/v1/rules/test", Summary: "Testa as regras com uma descrição", Request: models.RuleTestRequest{}, Response: models.RuleTestResponse{}},
+
+		// Despesas
+		{Method: "GET", Path: "/api/v1/expenses", Summary: "Lista despesas", Query: list, Response: models.ExpenseResponse{}},
+		{Method: "POST", Path: "/api/v1/expenses", Summary: "Registra despesa", Request: models.ExpenseRequest{}, Response: models.Expense{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/expenses/stats", Summary: "Totais de despesas", Response: models.ExpenseStats{}},
+		{Method: "GET", Path: "/api/v1/expenses/{id}", Summary: "Detalha despesa", Response: models.Expense{}},
+		{Method: "PUT", Path: "/api/v1/expenses/{id}", Summary: "Atualiza despesa", Request: models.ExpenseRequest{}, Response: models.Expense{}},
+		{Method: "DELETE", Path: "/api/v1/expenses/{id}", Summary: "Remove despesa", Status: http.StatusNoContent},
+
+		// Formas de pagamento
+		{Method: "GET", Path: "/api/v1/payment-methods", Summary: "Lista formas de pagamento", Response: openapi.Items[models.PaymentMethod]{}},
+		{Method: "POST", Path: "/api/v1/payment-methods", Summary: "Cadastra forma de pagamento", Request: models.PaymentMethodRequest{}, Response: models.PaymentMethod{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/payment-methods/{id}", Summary: "Detalha forma de pagamento", Response: models.PaymentMethod{}},
+		{Method: "PUT", Path: "/api/v1/payment-methods/{id}", Summary: "Atualiza forma de pagamento", Request: models.PaymentMethodRequest{}, Response: models.PaymentMethod{}},
+		{Method: "POST", Path: "/api/v1/payment-methods/{id}/default", Summary: "Define a forma de pagamento padrão", Response: models.PaymentMethod{}},
+
+		// Créditos
+		{Method: "GET", Path: "/api/v1/credits", Summary: "Lista créditos de pagadores", Response: openapi.Items[models.Credit]{}},
+		{Method: "POST", Path: "/api/v1/credits/{id}/apply", Summary: "Aplica crédito em receita", Request: models.CreditApplyRequest{}, Response: models.CreditApplyResponse{}},
+
+		// Contratos
+		{Method: "POST", Path: "/api/v1/contracts/{id}/readjust", Summary: "Reajusta contrato por índice", Request: models.ContractReadjustRequest{}, Response: models.ContractReadjustment{}, Status: http.StatusCreated},
+
+		// Webhooks e chaves de API
+		{Method: "POST", Path: "/api/v1/webhooks", Summary: "Cadastra webhook", Request: models.WebhookRequest{}, Response: models.Webhook{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/webhooks/{id}", Summary: "Detalha webhook", Response: models.Webhook{}},
+		{Method: "PATCH", Path: "/api/v1/webhooks/{id}", Summary: "Atualiza webhook", Request: models.WebhookRequest{}, Response: models.Webhook{}},
+		{Method: "DELETE", Path: "/api/v1/webhooks/{id}", Summary: "Remove webhook", Status: http.StatusNoContent},
+		{Method: "POST", Path: "/api/v1/webhooks/{id}/rotate-secret", Summary: "Gira o segredo de assinatura", Response: models.Webhook{}},
+		{Method: "GET", Path: "/api/v1/api-keys", Summary: "Lista chaves de API", Response: []models.APIKey{}},
+		{Method: "POST", Path: "/api/v1/api-keys", Summary: "Cria chave de API (o segredo aparece só nesta resposta)", Request: models.APIKeyRequest{}, Response: models.APIKey{}, Status: http.StatusCreated},
+		{Method: "DELETE", Path: "/api/v1/api-keys/{id}", Summary: "Revoga chave de API", Status: http.StatusNoContent},
+
+		// Perfil e configurações
+		{Method: "GET", Path: "/api/v1/profile", Summary: "Perfil do emissor", Response: models.Profile{}},
+		{Method: "PUT", Path: "/api/v1/profile", Summary: "Atualiza perfil do emissor", Request: models.ProfileRequest{}, Response: models.Profile{}},
+		{Method: "GET", Path: "/api/v1/settings/business-days", Summary: "Opção de vencimento em dia útil", Response: models.BusinessDaySettings{}},
+		{Method: "PUT", Path: "/api/v1/settings/business-days", Summary: "Grava opção de vencimento em dia útil", Request: models.BusinessDaySettings{}, Response: models.BusinessDaySettings{}},
+		{Method: "GET", Path: "/api/v1/settings/receipt-automation", Summary: "Emissão automática de recibos", Response: models.ReceiptAutomation{}},
+		{Method: "PUT", Path: "/api/v1/settings/receipt-automation", Summary: "Grava emissão automática de recibos", Request: models.ReceiptAutomation{}, Response: models.ReceiptAutomation{}},
+		{Method: "GET", Path: "/api/v1/settings/late-fees", Summary: "Multa e juros de atraso", Response: models.FeeRules{}},
+		{Method: "PUT", Path: "/api/v1/settings/late-fees", Summary: "Grava multa e juros de atraso", Request: models.FeeRules{}, Response: models.FeeRules{}},
+		{Method: "GET", Path: "/api/v1/settings/receipt-numbering", Summary: "Numeração dos recibos", Response: models.ReceiptNumbering{}},
+		{Method: "PUT", Path: "/api/v1/settings/receipt-numbering", Summary: "Grava numeração dos recibos", Request: models.ReceiptNumbering{}, Response: models.ReceiptNumbering{}},
+		{Method: "GET", Path: "/api/v1/settings/invoice", Summary: "Dados do prestador para NFS-e", Response: models.InvoiceIssuer{}},
+		{Method: "PUT", Path: "/api/v1/settings/invoice", Summary: "Grava dados do prestador para NFS-e", Request: models.InvoiceIssuer{}, Response: models.InvoiceIssuer{}},
+
+		// Feriados
+		{Method: "GET", Path: "/api/v1/holidays", Summary: "Feriados do ano (nacionais e cadastrados)",
+			Query: []openapi.Parameter{{Name: "year", In: "query", Description: "ano (padrão: o corrente)", Schema: &openapi.Schema{Type: "integer"}}},
+			Response: struct {
+				Year     int              `json:"year"`
+				Holidays []models.Holiday `json:"holidays"`
+			}{}},
+		{Method: "POST", Path: "/api/v1/holidays", Summary: "Cadastra feriado municipal", Request: models.HolidayRequest{}, Response: models.Holiday{}, Status: http.StatusCreated},
+		{Method: "DELETE", Path: "/api/v1/holidays/{id}", Summary: "Remove feriado", Status: http.StatusNoContent},
+
+		// Modelos de recibo
+		{Method: "POST", Path: "/api/v1/receipt-templates", Summary: "Cria modelo de recibo", Request: models.ReceiptTemplateRequest{}, Response: models.ReceiptTemplate{}, Status: http.StatusCreated},
+		{Method: "GET", Path: "/api/v1/receipt-templates/{id}", Summary: "Detalha modelo de recibo", Response: models.ReceiptTemplate{}},
+		{Method: "PUT", Path: "/api/v1/receipt-templates/{id}", Summary: "Atualiza modelo de recibo", Request: models.ReceiptTemplateRequest{}, Response: models.ReceiptTemplate{}},
+
+		// Sincronização offline, painel e relatórios
+		{Method: "POST", Path: "/api/v1/sync/push", Summary: "Envia alterações feitas offline", Request: models.SyncPushRequest{}, Response: models.SyncPushResponse{}},
+		{Method: "GET", Path: "/api/v1/dashboard/series", Summary: "Séries mensais do painel", Response: models.DashboardSeries{}},
+		{Method: "GET", Path: "/api/v1/reports/aging", Summary: "Relatório de inadimplência por faixa de atraso", Response: models.AgingReport{}},
+		{Method: "GET", Path: "/api/v1/reports/monthly", Summary: "Relatório mensal",
+			Query:    []openapi.Parameter{{Name: "format", In: "query", Description: "json (padrão), pdf ou csv", Schema: &openapi.Schema{Type: "string", Enum: []string{"json", "pdf", "csv"}}}},
+			Response: models.MonthlyReport{}},
+
+		// Especificação
+		{Method: "GET", Path: "/api/v1/openapi.json", Summary: "Esta especificação (OpenAPI 3)"},
+
+		// API v2 (envelope Page)
+		{Method: "GET", Path: "/api/v2/incomes", Summary: "Lista receitas (Page)", Query: []openapi.Parameter{qPage, qPerPage, qFields}, Response: models.Page[models.Income]{}},
+		{Method: "GET", Path: "/api/v2/receipts", Summary: "Lista recibos (Page)", Query: []openapi.Parameter{qPage, qPerPage, qFields}, Response: models.Page[models.Receipt]{}},
+		{Method: "GET", Path: "/api/v2/payers", Summary: "Lista pagadores (Page)", Query: []openapi.Parameter{qPage, qPerPage, qFields}, Response: models.Page[models.Payer]{}},
+		{Method: "GET", Path: "/api/v2/rules", Summary: "Lista regras (Page)", Response: models.Page[models.IncomeRule]{}},
+		{Method: "GET", Path: "/api/v2/categories", Summary: "Lista categorias (Page)", Response: models.Page[models.Category]{}},
+	}
+}
+
+// openAPIHandlers servem a especificação, montada uma vez a partir do roteador já configurado
+type openAPIHandlers struct {
+	log    logging.Logger
+	routes http.Handler
+
+	once sync.Once
+	body []byte
+	err  error
+}
+
+// Spec GET /api/v1/openapi.json
+func (h *openAPIHandlers) Spec(w http.ResponseWriter, r *http.Request) {
+	h.once.Do(func() {
+		doc, err := BuildOpenAPI(h.routes)
+		if err != nil {
+			h.err = err
+			return
+		}
+		h.body, h.err = json.Marshal(doc)
+	})
+	if h.err != nil {
+		h.log.Error("erro ao montar especificação OpenAPI", logging.Field{Key: "error", Val: h.err.Error()})
+		apierror.Write(w, http.StatusInternalServerError, "erro interno do servidor")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(h.body)
+}
+
+// Docs GET /api/v1/docs: Swagger UI (assets do CDN) apontando para a especificação
+func (h *openAPIHandlers) Docs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(swaggerUIPage))
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>ReciboFast API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+<script>
+window.ui = SwaggerUIBundle({url: "openapi.json", dom_id: "#swagger-ui"});
+</script>
+</body>
+</html>
+`
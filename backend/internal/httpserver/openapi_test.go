@@ -0,0 +1,96 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da especificação OpenAPI montada a partir do roteador
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"recibofast/internal/config"
+	"recibofast/internal/logging"
+)
+
+func newDocsRouter(docs bool) http.Handler {
+	return NewRouter(AppDeps{Logger: logging.NewLogger("dev"), Cfg: &config.Config{OpenAPIDocs: docs}})
+}
+
+func TestBuildOpenAPI_CoversRouterAndCatalog(t *testing.T) {
+	doc, err := BuildOpenAPI(newDocsRouter(false))
+	if err != nil {
+		t.Fatalf("BuildOpenAPI: %v (catálogo desatualizado?)", err)
+	}
+	if doc.Paths["/api/v1/incomes"]["post"] == nil || doc.Paths["/api/v1/incomes/{id}"]["patch"] == nil {
+		t.Fatalf("rotas de receitas ausentes: %v", doc.Paths["/api/v1/incomes"])
+	}
+	for path := range doc.Paths {
+		if strings.HasPrefix(path, "/debug") || strings.HasSuffix(path, "/") && path != "/" {
+			t.Errorf("caminho inesperado no documento: %s", path)
+		}
+	}
+
+	create := doc.Paths["/api/v1/incomes"]["post"]
+	if create.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/IncomeRequest" ||
+		create.Responses["201"].Content["application/json"].Schema.Ref != "#/components/schemas/Income" {
+		t.Errorf("POST /incomes sem corpos tipados: %+v", create)
+	}
+	if valor := doc.Components.Schemas["Income"].Properties["valor"]; valor == nil || valor.Format != "decimal" {
+		t.Errorf("Income.valor deveria usar o esquema de Money: %+v", valor)
+	}
+
+	if sec := doc.Paths["/api/v1/incomes"]["get"].Security; len(sec) != 2 {
+		t.Errorf("rota de usuário: security = %v", sec)
+	}
+	if sec := doc.Paths["/healthz"]["get"].Security; len(sec) != 0 {
+		t.Errorf("rota pública: security = %v", sec)
+	}
+	if sec := doc.Paths["/api/v1/admin/jobs/overview"]["get"].Security; len(sec) != 1 || sec[0]["adminToken"] == nil {
+		t.Errorf("rota administrativa: security = %v", sec)
+	}
+}
+
+func TestBuildOpenAPI_RefsResolve(t *testing.T) {
+	doc, err := BuildOpenAPI(newDocsRouter(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(doc)
+	refs := regexp.MustCompile(`"\$ref":"#/components/(schemas|responses)/([^"]+)"`).FindAllStringSubmatch(string(body), -1)
+	if len(refs) == 0 {
+		t.Fatal("nenhuma referência no documento")
+	}
+	for _, m := range refs {
+		if m[1] == "schemas" && doc.Components.Schemas[m[2]] == nil || m[1] == "responses" && doc.Components.Responses[m[2]] == nil {
+			t.Errorf("referência sem componente: %s/%s", m[1], m[2])
+		}
+	}
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	get := func(h http.Handler, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+
+	h := newDocsRouter(false)
+	rr := get(h, "/api/v1/openapi.json")
+	var doc map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&doc); err != nil || rr.Code != http.StatusOK || doc["openapi"] != "3.0.3" {
+		t.Fatalf("openapi.json: status = %d, openapi = %v (%v)", rr.Code, doc["openapi"], err)
+	}
+	if rr := get(h, "/api/v1/docs"); rr.Code != http.StatusUnauthorized && rr.Code != http.StatusNotFound {
+		t.Errorf("Swagger UI desligado: status = %d", rr.Code)
+	}
+
+	rr = get(newDocsRouter(true), "/api/v1/docs")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "openapi.json") {
+		t.Fatalf("Swagger UI: status = %d", rr.Code)
+	}
+}
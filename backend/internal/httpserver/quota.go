@@ -0,0 +1,77 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Middleware de limites por plano (402/403 estruturados)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+)
+
+// QuotaReserver reserva uma unidade da métrica no plano do dono dos dados
+type QuotaReserver interface {
+	Reserve(ctx context.Context, ownerID uuid.UUID, metric string) (func(), error)
+}
+
+// Quota aplica o limite do plano à rota.
+// Docstring: deve vir depois do SupabaseAuth (e do Idempotency, para repetições não consumirem
+// cota). Limite atingido responde 402 (quota_exceeded) e recurso fora do plano 403
+// (plan_feature_unavailable), com metric, plan, limit e used em details. Se a rota responder erro
+// (status >= 400) a reserva é devolvida.
+func Quota(svc QuotaReserver, metric string, log logging.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userIDStr, _ := ctxhelper.GetUserID(r.Context())
+			ownerID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				apierror.Write(w, http.StatusUnauthorized, "usuário não autenticado")
+				return
+			}
+			release, err := svc.Reserve(r.Context(), ownerID, metric)
+			var qe *models.QuotaError
+			if errors.As(err, &qe) {
+				status := http.StatusPaymentRequired
+				if qe.Unavailable() {
+					status = http.StatusForbidden
+				}
+				apierror.WriteCode(w, status, qe.Code(), qe.Error(), qe)
+				return
+			}
+			if err != nil {
+				log.Error("erro ao verificar limite do plano", logging.Field{Key: "metric", Val: metric}, logging.Field{Key: "error", Val: err.Error()})
+				apierror.Write(w, http.StatusInternalServerError, "erro ao verificar limite do plano")
+				return
+			}
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+			if sw.status >= http.StatusBadRequest {
+				release()
+			}
+		})
+	}
+}
+
+// statusWriter guarda o status escrito pela rota
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status, w.wroteHeader = code, true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
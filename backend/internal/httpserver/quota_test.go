@@ -0,0 +1,66 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do middleware de limites por plano
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	ctxhelper "recibofast/internal/context"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+)
+
+// quotaStub implementa QuotaReserver com um erro fixo e conta as devoluções
+type quotaStub struct {
+	err      error
+	released int
+}
+
+func (q *quotaStub) Reserve(ctx context.Context, ownerID uuid.UUID, metric string) (func(), error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	return func() { q.released++ }, nil
+}
+
+func TestQuotaMiddleware(t *testing.T) {
+	do := func(q *quotaStub, status int) *httptest.ResponseRecorder {
+		h := Quota(q, models.QuotaMetricReceipts, logging.NewLogger("dev"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/receipts", nil)
+		req = req.WithContext(ctxhelper.SetUserID(req.Context(), uuid.NewString()))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	q := &quotaStub{}
+	if rec := do(q, http.StatusCreated); rec.Code != http.StatusCreated || q.released != 0 {
+		t.Fatalf("criação: status = %d, devoluções = %d", rec.Code, q.released)
+	}
+	if rec := do(q, http.StatusBadRequest); rec.Code != http.StatusBadRequest || q.released != 1 {
+		t.Fatalf("falha deve devolver a cota: status = %d, devoluções = %d", rec.Code, q.released)
+	}
+
+	rec := do(&quotaStub{err: &models.QuotaError{Metric: models.QuotaMetricReceipts, Plan: "free", Limit: 20, Used: 20}}, http.StatusCreated)
+	var body map[string]interface{}
+	json.NewDecoder(rec.Body).Decode(&body)
+	details, _ := body["details"].(map[string]interface{})
+	if rec.Code != http.StatusPaymentRequired || body["code"] != "quota_exceeded" || details["limit"] != float64(20) || details["plan"] != "free" {
+		t.Fatalf("limite atingido: status = %d, corpo = %v", rec.Code, body)
+	}
+	rec = do(&quotaStub{err: &models.QuotaError{Metric: models.QuotaMetricSignatures, Plan: "free"}}, http.StatusCreated)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("recurso fora do plano: status = %d", rec.Code)
+	}
+}
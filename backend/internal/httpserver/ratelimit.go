@@ -0,0 +1,62 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Limite de requisições por usuário autenticado (orçamentos de leitura e escrita)
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/httprate"
+
+	"recibofast/internal/apierror"
+	ctxhelper "recibofast/internal/context"
+)
+
+// userRateLimiter limita requisições por usuário autenticado, depois do SupabaseAuth.
+// Docstring: a chave é o usuário autenticado (subject do JWT, dono da chave de API), não o IP, então
+// quem está atrás de NAT compartilhado não divide o orçamento e uma conta não escapa trocando de IP.
+// Leitura e escrita têm orçamentos separados; ao estourar responde 429 com Retry-After.
+type userRateLimiter struct {
+	read  func(http.Handler) http.Handler
+	write func(http.Handler) http.Handler
+}
+
+// newUserRateLimiter cria os limitadores por janela; limite 0 desabilita o respectivo orçamento
+func newUserRateLimiter(readLimit, writeLimit int, window time.Duration) *userRateLimiter {
+	return &userRateLimiter{read: userLimit(readLimit, window), write: userLimit(writeLimit, window)}
+}
+
+func userLimit(limit int, window time.Duration) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return httprate.Limit(limit, window,
+		httprate.WithKeyFuncs(keyByAuthUser),
+		httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
+			apierror.Write(w, http.StatusTooManyRequests, "limite de requisições excedido; tente novamente mais tarde")
+		}),
+	)
+}
+
+// keyByAuthUser usa o usuário autenticado; sem AuthUser (não deveria ocorrer) recai no IP
+func keyByAuthUser(r *http.Request) (string, error) {
+	if u, ok := ctxhelper.GetAuthUser(r.Context()); ok && u.ID != "" {
+		return "user:" + u.ID, nil
+	}
+	return httprate.KeyByIP(r)
+}
+
+// Wrap aplica o orçamento conforme o método (leitura ou escrita)
+func (l *userRateLimiter) Wrap(next http.Handler) http.Handler {
+	read, write := l.read(next), l.write(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if readOnlyMethod(r.Method) {
+			read.ServeHTTP(w, r)
+			return
+		}
+		write.ServeHTTP(w, r)
+	})
+}
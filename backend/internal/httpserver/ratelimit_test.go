@@ -0,0 +1,51 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do limite de requisições por usuário autenticado
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"recibofast/internal/config"
+	"recibofast/internal/logging"
+)
+
+func TestUserRateLimitPerUserAndMethod(t *testing.T) {
+	deps := AppDeps{Logger: logging.NewLogger("dev"), Cfg: &config.Config{Env: "dev"}}
+	deps.userLimits = newUserRateLimiter(2, 1, time.Minute)
+	h := SupabaseAuth(deps)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }))
+
+	do := func(method, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/incomes", nil)
+		req.RemoteAddr = "10.0.0.1:1234" // mesmo IP (NAT) para todos os usuários
+		req.Header.Set("X-Debug-User", user)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 2; i++ {
+		if rec := do(http.MethodGet, "ana"); rec.Code != http.StatusNoContent {
+			t.Fatalf("leitura %d: status = %d", i, rec.Code)
+		}
+	}
+	rec := do(http.MethodGet, "ana")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("leitura acima do limite: status = %d, Retry-After = %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	// Escrita tem orçamento próprio e outro usuário no mesmo IP não é afetado
+	if rec := do(http.MethodPost, "ana"); rec.Code != http.StatusNoContent {
+		t.Fatalf("escrita: status = %d", rec.Code)
+	}
+	if rec := do(http.MethodPost, "ana"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("escrita acima do limite: status = %d", rec.Code)
+	}
+	if rec := do(http.MethodGet, "bia"); rec.Code != http.StatusNoContent {
+		t.Fatalf("outro usuário no mesmo IP: status = %d", rec.Code)
+	}
+}
@@ -6,51 +6,355 @@
 package httpserver
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/httprate"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
 
+	"recibofast/internal/bcb"
+	"recibofast/internal/captcha"
+	"recibofast/internal/checkout"
 	"recibofast/internal/config"
+	"recibofast/internal/email"
+	"recibofast/internal/grpcapi"
 	"recibofast/internal/handlers"
 	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/nfse"
+	"recibofast/internal/pix"
 	"recibofast/internal/repositories"
 	"recibofast/internal/services"
 	"recibofast/internal/storage"
+	"recibofast/internal/webpush"
 )
 
+// Intervalos dos workers em segundo plano
+const (
+	deliveryWorkerInterval    = 30 * time.Second
+	digestWorkerInterval      = time.Hour
+	lifecycleWorkerInterval   = time.Hour
+	rateWorkerInterval        = 6 * time.Hour
+	broadcastWorkerInterval   = time.Minute
+	batchWorkerInterval       = time.Minute
+	autoReceiptWorkerInterval = time.Minute
+	overdueWorkerInterval     = 24 * time.Hour
+	reminderWorkerInterval    = time.Hour
+	outboxWorkerInterval      = 15 * time.Second
+	deletionWorkerInterval    = time.Hour
+	storageGCWorkerInterval   = 24 * time.Hour
+)
+
+// outboxRetention tempo que eventos já publicados ficam na outbox (auditoria e reprocessamento)
+const outboxRetention = 7 * 24 * time.Hour
+
 // AppDeps injeta dependências no roteador.
+// Background é o contexto de vida do processo: quando informado, os workers em segundo plano
+// são iniciados e param com o seu cancelamento.
 type AppDeps struct {
-	Logger logging.Logger
-	DB     *pgxpool.Pool
-	Cfg    *config.Config
+	Logger     logging.Logger
+	DB         *pgxpool.Pool
+	Cfg        *config.Config
+	Background context.Context
+	// GRPC recebe os serviços recibofast.v1 (grpcapi) com a mesma autenticação das rotas REST; nil não registra
+	GRPC grpc.ServiceRegistrar
+
+	// accountLocks bloqueia contas com exclusão solicitada, workspaces resolve o X-Org-ID,
+	// apiKeys valida X-API-Key, jwks guarda as chaves do Supabase e userLimits limita cada usuário
+	// (preenchidos pelo NewRouter; accountLocks e workspaces podem vir prontos, como nos testes)
+	accountLocks AccountLockChecker
+	workspaces   WorkspaceResolver
+	apiKeys      APIKeyAuthenticator
+	jwks         *jwksCache
+	userLimits   *userRateLimiter
+}
+
+// APIKeyAuthenticator valida chaves recebidas em X-API-Key
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+// WorkspaceResolver resolve o vínculo do usuário com a organização informada em X-Org-ID
+type WorkspaceResolver interface {
+	ResolveWorkspace(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMembership, error)
+}
+
+// AccountLockChecker informa se a conta está bloqueada por um pedido de exclusão
+type AccountLockChecker interface {
+	IsLocked(ctx context.Context, ownerID uuid.UUID) (bool, error)
 }
 
 // NewRouter cria e retorna um roteador configurado.
 func NewRouter(deps AppDeps) http.Handler {
 	r := chi.NewRouter()
 
+	// CORS antes dos demais: o preflight (OPTIONS) responde sem passar por limites e autenticação
+	r.Use(CORS(CORSOptionsFromConfig(deps.Cfg)))
 	// Middlewares padrão com foco em leveza
 	r.Use(middleware.RequestID)
+	r.Use(RequestIDHeader)
 	r.Use(middleware.RealIP)
+	r.Use(AccessLog(deps.Logger, AccessLogOptionsFromConfig(deps.Cfg)))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(15 * time.Second))
-	r.Use(middleware.Compress(5)) // gzip nível moderado
-	// Limite simples por IP (ajuste conforme necessidade)
-	r.Use(httprate.LimitByIP(100, 1*time.Minute))
+	r.Use(requestTimeout(15 * time.Second))
+	r.Use(Compress(deps.Cfg.CompressionLevel))
+	// Limite por IP só contra flood (NAT compartilhado); o orçamento de cada conta fica no userLimits
+	r.Use(httprate.LimitByIP(600, 1*time.Minute))
+
+	// JWKS do Supabase em cache (atualizado em segundo plano, com o último conjunto válido como reserva)
+	jwksCtx := deps.Background
+	if jwksCtx == nil {
+		jwksCtx = context.Background()
+	}
+	deps.jwks = newJWKSCache(jwksCtx, deps.Cfg.JWKSURL, deps.Cfg.JWKSCacheTTL, deps.Logger)
+	// Limite por usuário autenticado (além do limite por IP acima), com orçamentos de leitura e escrita
+	deps.userLimits = newUserRateLimiter(deps.Cfg.UserReadRateLimit, deps.Cfg.UserWriteRateLimit, time.Minute)
 
 	// Repositories
 	incomeRepo := repositories.NewIncomeRepository(deps.DB)
 	signRepo := repositories.NewSignatureRepository(deps.DB)
 	receiptRepo := repositories.NewReceiptRepository(deps.DB)
+	deliveryRepo := repositories.NewDeliveryRepository(deps.DB)
+	payerRepo := repositories.NewPayerRepository(deps.DB)
+	ruleRepo := repositories.NewRuleRepository(deps.DB)
+	notificationRepo := repositories.NewNotificationRepository(deps.DB)
+	templateRepo := repositories.NewIncomeTemplateRepository(deps.DB)
+	artifactRepo := repositories.NewArtifactRepository(deps.DB)
+	settingsRepo := repositories.NewSettingsRepository(deps.DB)
+	profileRepo := repositories.NewProfileRepository(deps.DB)
+	categoryRepo := repositories.NewCategoryRepository(deps.DB)
+	rateRepo := repositories.NewRateRepository(deps.DB)
+	contractRepo := repositories.NewContractRepository(deps.DB)
+	contractReadjustRepo := repositories.NewContractReadjustRepository(deps.DB)
+	holidayRepo := repositories.NewHolidayRepository(deps.DB)
+	accountMergeRepo := repositories.NewAccountMergeRepository(deps.DB)
+	syncConflictRepo := repositories.NewSyncConflictRepository(deps.DB)
+	syncVersionRepo := repositories.NewSyncVersionRepository(deps.DB)
+	idempotencyRepo := repositories.NewIdempotencyRepository(deps.DB)
+	paymentReversalRepo := repositories.NewPaymentReversalRepository(deps.DB)
+	creditRepo := repositories.NewCreditRepository(deps.DB)
+	broadcastRepo := repositories.NewBroadcastRepository(deps.DB)
+	receiptBatchRepo := repositories.NewReceiptBatchRepository(deps.DB)
+	autoReceiptRepo := repositories.NewAutoReceiptRepository(deps.DB)
+	paymentMethodRepo := repositories.NewPaymentMethodRepository(deps.DB)
+	pushRepo := repositories.NewPushSubscriptionRepository(deps.DB)
+	webhookRepo := repositories.NewWebhookRepository(deps.DB)
+	outboxRepo := repositories.NewOutboxRepository(deps.DB)
+	pixChargeRepo := repositories.NewPixChargeRepository(deps.DB)
+	reconciliationRepo := repositories.NewReconciliationRepository(deps.DB)
+	paymentLinkRepo := repositories.NewPaymentLinkRepository(deps.DB)
+	invoiceRepo := repositories.NewInvoiceRepository(deps.DB)
+	reportRepo := repositories.NewReportRepository(deps.DB)
+	dashboardRepo := repositories.NewDashboardRepository(deps.DB)
+	expenseRepo := repositories.NewExpenseRepository(deps.DB)
+	accountDeletionRepo := repositories.NewAccountDeletionRepository(deps.DB)
+	orgRepo := repositories.NewOrgRepository(deps.DB)
+	apiKeyRepo := repositories.NewAPIKeyRepository(deps.DB)
+	usageRepo := repositories.NewUsageRepository(deps.DB)
+	storageGCRepo := repositories.NewStorageGCRepository(deps.DB)
+	receiptTemplateRepo := repositories.NewReceiptTemplateRepository(deps.DB)
+
+	// Idioma e fuso do usuário no contexto de todas as requisições (pacote locale)
+	r.Use(Locale(deps, settingsRepo))
 
 	// Services
-	incomeService := services.NewIncomeService(incomeRepo)
-	signatureService := services.NewSignatureService()
+	ruleService := services.NewRuleService(ruleRepo)
+	paymentMethodService := services.NewPaymentMethodService(paymentMethodRepo, deps.Logger)
+	deliveryService := services.NewDeliveryService(deliveryRepo, deps.Logger)
+	notificationDispatcher := services.NewNotificationDispatcher(notificationRepo, deliveryService, deps.Logger)
+	// Limites por plano (recibos por mês, assinaturas) aplicados nas rotas de criação
+	quotaService := services.NewQuotaService(usageRepo, deps.Logger)
+	// Recibo automático: o pagamento que quita a receita grava o pedido e o serviço emite o recibo
+	autoReceiptService := services.NewAutoReceiptService(autoReceiptRepo, settingsRepo, receiptRepo, quotaService, deps.Logger)
+	incomeService := services.NewIncomeService(incomeRepo, services.WithRuleEvaluator(ruleService), services.WithCredits(creditRepo),
+		services.WithPaymentMethods(paymentMethodService), services.WithEvents(notificationDispatcher, deps.Logger),
+		services.WithAutoReceipts(autoReceiptService))
+	signatureService := services.NewSignatureService(services.WithSignatureLimits(services.SignatureLimits{
+		MinWidth: deps.Cfg.SignatureMinWidth, MinHeight: deps.Cfg.SignatureMinHeight,
+		MaxWidth: deps.Cfg.SignatureMaxWidth, MaxHeight: deps.Cfg.SignatureMaxHeight,
+		MinAspect: deps.Cfg.SignatureMinAspect, MaxAspect: deps.Cfg.SignatureMaxAspect,
+		MaxMegapixels: deps.Cfg.SignatureMaxMegapixels,
+	}))
 	storeClient := storage.NewClient(deps.Cfg)
+	artifactService := services.NewArtifactService(artifactRepo, storeClient, deps.Cfg.BucketReceipts, deps.Logger)
+	payerService := services.NewPayerService(payerRepo)
+	categoryService := services.NewCategoryService(categoryRepo)
+	holidayService := services.NewHolidayService(holidayRepo, settingsRepo, deps.Logger)
+	templateService := services.NewIncomeTemplateService(templateRepo, incomeService, holidayService)
+	digestService := services.NewDigestService(notificationRepo, deliveryService, deps.Logger)
+	rateService := services.NewRateService(rateRepo, bcb.NewClient(deps.Cfg), deps.Logger)
+	receiptTemplateService := services.NewReceiptTemplateService(receiptTemplateRepo, deps.Logger)
+	contractReadjustService := services.NewContractReadjustService(contractReadjustRepo, contractRepo, rateService, deps.Logger)
+	receiptBookService := services.NewReceiptBookService(contractRepo, settingsRepo, receiptTemplateService, deps.Logger)
+	accountMergeService := services.NewAccountMergeService(accountMergeRepo, storeClient,
+		[]string{deps.Cfg.BucketSigns, deps.Cfg.BucketReceipts}, deps.Logger)
+	// Exclusão da conta pelo titular (LGPD): bloqueia a API já no pedido; o worker remove após a carência
+	accountDeletionService := services.NewAccountDeletionService(accountDeletionRepo, storeClient,
+		[]string{deps.Cfg.BucketSigns, deps.Cfg.BucketReceipts}, deps.Logger)
+	if deps.accountLocks == nil {
+		deps.accountLocks = accountDeletionService
+	}
+	// Organizações: com X-Org-ID as rotas autenticadas operam no espaço de dados do dono da organização
+	orgService := services.NewOrgService(orgRepo, deps.Logger)
+	if deps.workspaces == nil {
+		deps.workspaces = orgService
+	}
+	// Chaves de API: X-API-Key substitui o JWT nas rotas de dados, limitada aos escopos da chave
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, deps.Logger)
+	deps.apiKeys = apiKeyService
+	// Conta pessoal: perfil, configurações e destinos de entrega (webhooks, Web Push) ignoram X-Org-ID.
+	// Um membro agindo na conta do dono deixaria webhooks e dispositivos recebendo os dados dela
+	// depois de sair da organização, e alteraria o perfil e as configurações do dono.
+	personal := deps
+	personal.workspaces = nil
+	syncConflictService := services.NewSyncConflictService(syncConflictRepo, incomeService, deps.Logger)
+	syncPushService := services.NewSyncPushService(incomeService, syncConflictRepo, syncVersionRepo, deps.Logger)
+	paymentReversalService := services.NewPaymentReversalService(paymentReversalRepo, deps.Logger)
+	creditService := services.NewCreditService(creditRepo, deps.Logger)
+	broadcastService := services.NewBroadcastService(broadcastRepo, deliveryService, deps.Logger)
+	lateFeeService := services.NewLateFeeService(settingsRepo, deps.Logger)
+	receiptNumberingService := services.NewReceiptNumberingService(settingsRepo, deps.Logger)
+	// E-mail (EMAIL_PROVIDER): sem provedor, o envio de recibos responde 503
+	mailer, err := email.New(deps.Cfg)
+	if err != nil && deps.Cfg.EmailProvider != "" {
+		deps.Logger.Warn("envio de e-mail desabilitado", logging.Field{Key: "error", Val: err.Error()})
+	}
+	receiptMailService := services.NewReceiptMailService(receiptRepo, receiptTemplateService, mailer, deps.Logger)
+	receiptShareService := services.NewReceiptShareService(receiptRepo, receiptTemplateService, deps.Logger)
+	receiptReissueService := services.NewReceiptReissueService(receiptRepo, receiptTemplateService, deps.Logger)
+	receiptBatchService := services.NewReceiptBatchService(receiptBatchRepo, receiptRepo, storeClient, deps.Cfg.BucketReceipts,
+		receiptTemplateService, quotaService, deps.Logger)
+	// Web Push do PWA (VAPID_*): sem chaves, o registro de dispositivos responde 503
+	var pushClient services.PushClient
+	if c, err := webpush.New(deps.Cfg); err == nil {
+		pushClient = c
+	} else if deps.Cfg.VAPIDPrivateKey != "" {
+		deps.Logger.Warn("notificações push desabilitadas", logging.Field{Key: "error", Val: err.Error()})
+	}
+	pushService := services.NewPushService(pushRepo, pushClient, deps.Logger)
+	deliveryService.RegisterSender(models.DeliveryChannelPush, pushService)
+	reminderService := services.NewReminderService(notificationRepo, notificationDispatcher, deps.Logger)
+	// Cobranças PIX (PIX_PROVIDER): sem PSP, a geração de cobranças responde 503
+	pixProvider, err := pix.New(deps.Cfg)
+	if err != nil && deps.Cfg.PixProvider != "" {
+		deps.Logger.Warn("cobranças PIX desabilitadas", logging.Field{Key: "error", Val: err.Error()})
+	}
+	pixService := services.NewPixService(pixChargeRepo, incomeRepo, payerRepo, paymentMethodRepo, pixProvider, deps.Cfg.PixKey, deps.Logger)
+	pixWebhookService := services.NewPixWebhookService(pixChargeRepo, incomeService, pixProvider, deps.Cfg.PixWebhookSecret, deps.Logger)
+	// Links de pagamento: checkout hospedado (cartão) no gateway; sem CHECKOUT_PROVIDER respondem 503
+	checkoutGateway, err := checkout.New(deps.Cfg)
+	if err != nil && deps.Cfg.CheckoutProvider != "" {
+		deps.Logger.Warn("links de pagamento desabilitados", logging.Field{Key: "error", Val: err.Error()})
+	}
+	paymentLinkService := services.NewPaymentLinkService(paymentLinkRepo, incomeRepo, payerRepo, checkoutGateway,
+		deps.Cfg.CheckoutSuccessURL, deps.Cfg.CheckoutCancelURL, deps.Logger)
+	checkoutWebhookService := services.NewCheckoutWebhookService(paymentLinkRepo, incomeService, checkoutGateway, deps.Cfg.CheckoutWebhookSecret, deps.Logger)
+	// NFS-e: sem NFSE_PROVIDER as notas ficam só como XML ABRASF para download
+	nfseProvider, err := nfse.New(deps.Cfg)
+	if err != nil && deps.Cfg.NFSeProvider != "" {
+		deps.Logger.Warn("envio de NFS-e à prefeitura desabilitado", logging.Field{Key: "error", Val: err.Error()})
+	}
+	invoiceService := services.NewInvoiceService(invoiceRepo, incomeRepo, payerRepo, settingsRepo, nfseProvider, deps.Logger)
+	reportService := services.NewReportService(reportRepo, deps.Logger)
+	dashboardService := services.NewDashboardService(dashboardRepo, deps.Logger)
+	// Despesas: contrapartida das receitas no resultado; anexos fiscais no bucket de recibos
+	expenseService := services.NewExpenseService(expenseRepo, storeClient, deps.Cfg.BucketReceipts, deps.Logger)
+	payerStatementService := services.NewPayerStatementService(payerRepo, deps.Logger)
+	// Perfil: emissor padrão dos recibos, idioma e fuso (prevalecem sobre rf_settings)
+	profileService := services.NewProfileService(profileRepo, deps.Logger)
+	// Conciliação bancária: extratos OFX/CSV sugerem vínculos com receitas em aberto
+	reconciliationService := services.NewReconciliationService(reconciliationRepo, incomeService, deps.Logger)
+	// Webhooks do usuário: eventos da outbox viram entregas assinadas (HMAC) no canal webhook
+	webhookService := services.NewWebhookService(webhookRepo, deliveryService, deps.Logger)
+	webhookPublisher := services.NewWebhookPublisher(outboxRepo, webhookRepo, deliveryService, deps.Logger)
+	deliveryService.RegisterSender(models.DeliveryChannelWebhook, services.NewWebhookSender(webhookRepo))
+
+	overdueService := services.NewOverdueService(incomeRepo, deps.Logger)
+	lifecycleJob := services.NewLifecycleJob(artifactService, deps.Logger)
+	lifecycleJob.AddTask("idempotency_keys", func(ctx context.Context) error {
+		_, err := idempotencyRepo.DeleteExpired(ctx, models.IdempotencyKeyTTL)
+		return err
+	})
+	lifecycleJob.AddTask("receipt_shares", func(ctx context.Context) error {
+		_, err := receiptShareService.DeleteExpired(ctx)
+		return err
+	})
+	lifecycleJob.AddTask("outbox_events", func(ctx context.Context) error {
+		_, err := webhookPublisher.Cleanup(ctx, outboxRetention)
+		return err
+	})
+
+	// Monitor dos jobs em segundo plano (painel administrativo e readiness)
+	jobMonitor := services.NewJobMonitor(deps.Logger)
+	jobMonitor.AddQueueSource(deliveryService.QueueDepths)
+	jobMonitor.AddQueueSource(accountDeletionService.QueueDepths)
+
+	// Workers em segundo plano (registrados no monitor; iniciados só com deps.Background)
+	workers := services.NewWorkers(jobMonitor, deps.Logger)
+	workers.Add(services.Worker{Name: "deliveries", Interval: deliveryWorkerInterval, Run: func(ctx context.Context) error {
+		_, err := deliveryService.ProcessDue(ctx, 50)
+		return err
+	}})
+	workers.Add(services.Worker{Name: "outbox", Interval: outboxWorkerInterval, Run: func(ctx context.Context) error {
+		_, err := webhookPublisher.PublishPending(ctx, 100)
+		return err
+	}})
+	workers.Add(services.Worker{Name: "digest", Interval: digestWorkerInterval, Run: func(ctx context.Context) error {
+		_, err := digestService.SendDue(ctx)
+		return err
+	}})
+	workers.Add(services.Worker{Name: "lifecycle", Interval: lifecycleWorkerInterval, Run: func(ctx context.Context) error {
+		if failed := lifecycleJob.RunOnce(ctx); failed > 0 {
+			return fmt.Errorf("%d tarefa(s) de ciclo de vida falharam", failed)
+		}
+		return nil
+	}})
+	workers.Add(services.Worker{Name: "rates", Interval: rateWorkerInterval, Run: func(ctx context.Context) error {
+		_, err := rateService.Sync(ctx)
+		return err
+	}})
+	workers.Add(services.Worker{Name: "broadcasts", Interval: broadcastWorkerInterval, Run: broadcastService.ProcessPending})
+	workers.Add(services.Worker{Name: "receipt-batches", Interval: batchWorkerInterval, Run: receiptBatchService.ProcessPending})
+	workers.Add(services.Worker{Name: "auto-receipts", Interval: autoReceiptWorkerInterval, Run: autoReceiptService.ProcessPending})
+	workers.Add(services.Worker{Name: "reminders", Interval: reminderWorkerInterval, Run: func(ctx context.Context) error {
+		_, err := reminderService.SendDueReminders(ctx)
+		return err
+	}})
+	workers.Add(services.Worker{Name: "overdue", Interval: overdueWorkerInterval, RunAtStart: true, Run: func(ctx context.Context) error {
+		_, err := overdueService.MarkOverdue(ctx)
+		return err
+	}})
+	workers.Add(services.Worker{Name: "account-deletions", Interval: deletionWorkerInterval, RunAtStart: true, Run: func(ctx context.Context) error {
+		_, err := accountDeletionService.ProcessDue(ctx, 10)
+		return err
+	}})
+	// Objetos órfãos do Storage (upload concluído, registro nunca gravado); 0 dias desliga
+	if deps.Cfg.StorageGCMinAgeDays > 0 {
+		storageGCService := services.NewStorageGCService(storageGCRepo, storeClient, []string{deps.Cfg.BucketSigns, deps.Cfg.BucketReceipts},
+			time.Duration(deps.Cfg.StorageGCMinAgeDays)*24*time.Hour, deps.Logger)
+		workers.Add(services.Worker{Name: "storage-gc", Interval: storageGCWorkerInterval, Run: func(ctx context.Context) error {
+			res, err := storageGCService.Collect(ctx)
+			if res.Deleted > 0 || res.StorageFails > 0 || res.Truncated {
+				deps.Logger.Info("coleta de objetos órfãos do Storage concluída",
+					logging.Field{Key: "scanned", Val: res.Scanned},
+					logging.Field{Key: "deleted", Val: res.Deleted},
+					logging.Field{Key: "freed_bytes", Val: res.FreedBytes},
+					logging.Field{Key: "failures", Val: res.StorageFails},
+					logging.Field{Key: "truncated", Val: res.Truncated})
+			}
+			return err
+		}})
+	}
+	if deps.Background != nil {
+		workers.Start(deps.Background)
+	}
 
 	// Handlers
 	h := handlers.NewHandlers(handlers.Deps{
@@ -58,54 +362,554 @@ func NewRouter(deps AppDeps) http.Handler {
 		DB:       deps.DB,
 		Cfg:      deps.Cfg,
 	})
+	if deps.GRPC != nil {
+		grpcapi.Register(deps.GRPC, grpcapi.Deps{
+			Auth:        newGRPCAuth(deps, settingsRepo),
+			Incomes:     incomeService,
+			Payments:    paymentReversalService,
+			Receipts:    services.NewReceiptService(receiptRepo),
+			Quota:       quotaService,
+			Idempotency: idempotencyRepo,
+			SyncFeed:    h.SyncSvc,
+			SyncPush:    syncPushService,
+			Logger:      deps.Logger,
+		})
+	}
 
 	// Income Handlers
 	incomeHandlers := handlers.NewIncomeHandlers(incomeService, deps.Logger)
 	// Signature Handlers
-	signatureHandlers := handlers.NewSignatureHandlers(signatureService, deps.Logger, deps.Cfg, storeClient, signRepo)
+	signatureHandlers := handlers.NewSignatureHandlers(signatureService, deps.Logger, deps.Cfg, storeClient, signRepo, quotaService)
 	// Receipt Handlers
 	receiptHandlers := handlers.NewReceiptHandlers(receiptRepo, deps.Logger)
+	receiptPDFHandlers := handlers.NewReceiptPDFHandlers(receiptRepo, storeClient, deps.Cfg.BucketReceipts, deps.Logger)
+	// Payer Handlers
+	payerHandlers := handlers.NewPayerHandlers(payerService, deps.Logger)
+	// Rule Handlers
+	ruleHandlers := handlers.NewRuleHandlers(ruleService, deps.Logger)
+	// Category Handlers
+	categoryHandlers := handlers.NewCategoryHandlers(categoryService, deps.Logger)
+	templateHandlers := handlers.NewIncomeTemplateHandlers(templateService, deps.Logger)
+	holidayHandlers := handlers.NewHolidayHandlers(holidayService, deps.Logger)
+	receiptTemplateHandlers := handlers.NewReceiptTemplateHandlers(receiptTemplateService, deps.Logger)
+	contractHandlers := handlers.NewContractHandlers(receiptBookService, contractReadjustService, deps.Logger)
+	// Digest Handlers
+	digestHandlers := handlers.NewDigestHandlers(digestService, deps.Logger)
+	// Profile Handlers
+	profileHandlers := handlers.NewProfileHandlers(profileService, deps.Logger)
+	// Notification Settings Handlers
+	notificationHandlers := handlers.NewNotificationSettingsHandlers(notificationDispatcher, deps.Logger)
+	// Delivery Admin Handlers
+	deliveryAdminHandlers := handlers.NewDeliveryAdminHandlers(deliveryService, deps.Logger)
+	// Artifact Handlers
+	artifactHandlers := handlers.NewArtifactHandlers(artifactService, deps.Logger)
+	// Rate Handlers
+	rateHandlers := handlers.NewRateHandlers(rateService, deps.Logger)
+	utilHandlers := handlers.NewUtilHandlers(deps.Logger)
+	// Account Handlers (fusão de contas)
+	accountHandlers := handlers.NewAccountHandlers(accountMergeService, deps.Logger)
+	// Account Deletion Handlers (exclusão da conta pelo titular)
+	accountDeletionHandlers := handlers.NewAccountDeletionHandlers(accountDeletionService, deps.Logger)
+	// Org Handlers (espaço compartilhado, membros e convites)
+	orgHandlers := handlers.NewOrgHandlers(orgService, deps.Logger)
+	// API Key Handlers (acesso programático)
+	apiKeyHandlers := handlers.NewAPIKeyHandlers(apiKeyService, deps.Logger)
+	// Usage Handlers (consumo do plano)
+	usageHandlers := handlers.NewUsageHandlers(quotaService, deps.Logger)
+	// Sync Conflict Handlers
+	syncConflictHandlers := handlers.NewSyncConflictHandlers(syncConflictService, deps.Logger)
+	// Sync Push Handlers (envio offline)
+	syncPushHandlers := handlers.NewSyncPushHandlers(syncPushService, deps.Logger)
+	// Payment Reversal Handlers (exclusão e estorno)
+	paymentReversalHandlers := handlers.NewPaymentReversalHandlers(paymentReversalService, deps.Logger)
+	// Credit Handlers (excedente de pagamentos)
+	creditHandlers := handlers.NewCreditHandlers(creditService, deps.Logger)
+	// Broadcast Handlers (envio em massa para pagadores)
+	broadcastHandlers := handlers.NewBroadcastHandlers(broadcastService, jobMonitor, deps.Logger)
+	// Receipt Batch Handlers (emissão de recibos em lote por competência)
+	receiptBatchHandlers := handlers.NewReceiptBatchHandlers(receiptBatchService, jobMonitor, deps.Logger)
+	// Payment Method Handlers (catálogo de formas de pagamento)
+	paymentMethodHandlers := handlers.NewPaymentMethodHandlers(paymentMethodService, deps.Logger)
+	// Receipt Mail Handlers (envio do recibo por e-mail)
+	receiptMailHandlers := handlers.NewReceiptMailHandlers(receiptMailService, deps.Logger)
+	pushHandlers := handlers.NewPushHandlers(pushService, deps.Logger)
+	// Pix Handlers (cobrança PIX das receitas)
+	pixHandlers := handlers.NewPixHandlers(pixService, pixWebhookService, deps.Logger)
+	reconciliationHandlers := handlers.NewReconciliationHandlers(reconciliationService, deps.Logger)
+	paymentLinkHandlers := handlers.NewPaymentLinkHandlers(paymentLinkService, checkoutWebhookService, deps.Logger)
+	invoiceHandlers := handlers.NewInvoiceHandlers(invoiceService, deps.Logger)
+	reportHandlers := handlers.NewReportHandlers(reportService, deps.Logger)
+	dashboardHandlers := handlers.NewDashboardHandlers(dashboardService, deps.Logger)
+	expenseHandlers := handlers.NewExpenseHandlers(expenseService, deps.Logger)
+	payerStatementHandlers := handlers.NewPayerStatementHandlers(payerStatementService, deps.Logger)
+	// Webhook Handlers (integrações: cadastro e log de entregas)
+	webhookHandlers := handlers.NewWebhookHandlers(webhookService, deps.Logger)
+	receiptShareHandlers := handlers.NewReceiptShareHandlers(receiptShareService, deps.Cfg.PublicBaseURL, deps.Logger)
+	// Receipt Reissue Handlers (segunda via com auditoria)
+	receiptReissueHandlers := handlers.NewReceiptReissueHandlers(receiptReissueService, deps.Logger)
+	// Late Fee Handlers (regras de multa e juros do usuário)
+	lateFeeHandlers := handlers.NewLateFeeHandlers(lateFeeService, deps.Logger)
+	receiptNumberingHandlers := handlers.NewReceiptNumberingHandlers(receiptNumberingService, deps.Logger)
+	autoReceiptHandlers := handlers.NewAutoReceiptHandlers(autoReceiptService, deps.Logger)
+	// Job Handlers
+	jobHandlers := handlers.NewJobHandlers(jobMonitor, deps.Logger)
+	// Readiness: banco, Storage e JWKS (quando configurados) e o veredito do monitor de jobs
+	readiness := services.NewReadinessService(deps.Logger)
+	if deps.DB != nil {
+		readiness.AddCheck("database", deps.DB.Ping)
+	}
+	if deps.Cfg.SupabaseURL != "" && deps.Cfg.SupabaseServiceRoleKey != "" {
+		readiness.AddCheck("storage", func(ctx context.Context) error {
+			return storeClient.Ping(ctx, deps.Cfg.BucketReceipts)
+		})
+	}
+	if deps.Cfg.JWKSURL != "" {
+		readiness.AddCheck("jwks", deps.jwks.Check)
+	}
+	readiness.AddCheck("jobs", func(ctx context.Context) error {
+		ov := jobMonitor.Overview(ctx)
+		if ov.Healthy {
+			return nil
+		}
+		problems := ov.Errors
+		for _, j := range ov.Jobs {
+			if !j.Healthy {
+				problems = append(problems, j.Name+" ("+j.State+")")
+			}
+		}
+		return fmt.Errorf("jobs não saudáveis: %s", strings.Join(problems, ", "))
+	})
+	readinessHandlers := handlers.NewReadinessHandlers(readiness, deps.Logger)
+	// Captcha Handlers (hCaptcha do login e cadastro)
+	captchaHandlers := handlers.NewCaptchaHandlers(deps.Cfg, deps.Logger)
+	// Captcha nas rotas públicas sensíveis (CAPTCHA_REQUIRED); sem verificador o middleware não age
+	var captchaVerifier captcha.Verifier
+	if deps.Cfg.CaptchaRequired {
+		if v, err := captcha.New(deps.Cfg); err == nil {
+			captchaVerifier = v
+		} else {
+			deps.Logger.Warn("CAPTCHA_REQUIRED ignorado", logging.Field{Key: "error", Val: err.Error()})
+		}
+	}
+	requireCaptcha := CaptchaRequired(captchaVerifier, deps.Logger)
+	// Especificação OpenAPI: montada na primeira requisição a partir deste roteador
+	apiDocs := &openAPIHandlers{log: deps.Logger, routes: r}
 
 	// Healthcheck
+	// Cache HTTP: cada rota de leitura declara sua política (Cache); ETag e 304 ficam no middleware
+	// Liveness (/healthz, /livez) não consulta dependências; readiness (/readyz) verifica cada uma
 	r.Get("/healthz", h.Health)
+	r.Get("/livez", h.Health)
+	r.With(Cache(CacheNoStore)).Get("/readyz", readinessHandlers.Ready)
+	// Diagnóstico (pprof, expvar e runtime) com o token administrativo
+	r.Mount("/debug", debugRoutes(deps))
 
 	// API v1
 	r.Route("/api/v1", func(r chi.Router) {
+		// hCaptcha (público): sitekey, verificação server-side e healthcheck
+		r.Route("/captcha", func(r chi.Router) {
+			r.Use(Cache(CacheNoStore))
+			r.Get("/sitekey", captchaHandlers.SiteKey)
+			r.With(httprate.LimitByIP(30, 1*time.Minute)).Post("/verify", captchaHandlers.Verify)
+			r.Get("/health", captchaHandlers.Health)
+		})
+
+		// Contrato da API (público) e Swagger UI opcional (OPENAPI_DOCS)
+		r.With(Cache(CacheRevalidate)).Get("/openapi.json", apiDocs.Spec)
+		if deps.Cfg.OpenAPIDocs {
+			r.With(Cache(CacheNoStore)).Get("/docs", apiDocs.Docs)
+		}
+
 		// Middleware de Auth JWT Supabase com validação completa via JWKS
-		r.With(SupabaseAuth(deps)).Get("/sync/changes", h.SyncChanges)
+		r.With(SupabaseAuth(deps), Cache(CacheRevalidate)).Get("/sync/changes", h.SyncChanges)
+		// Envio offline: receitas e pagamentos do dispositivo, com resultado por registro
+		r.With(SupabaseAuth(deps)).Post("/sync/push", syncPushHandlers.Push)
+		// Conflitos do envio offline: inspeção e resolução (manter a minha, a do servidor ou mesclar)
+		r.With(SupabaseAuth(deps)).Get("/sync/pending-conflicts", syncConflictHandlers.ListPending)
+		r.With(SupabaseAuth(deps)).Post("/sync/conflicts/{id}/resolve", syncConflictHandlers.Resolve)
 		
 		// Rotas de receitas (protegidas por autenticação)
 		r.Route("/incomes", func(r chi.Router) {
 			r.Use(SupabaseAuth(deps))
-			r.Get("/", incomeHandlers.ListIncomes)
+			r.With(Cache(CacheRevalidate)).Get("/", incomeHandlers.ListIncomes)
 			r.Post("/", incomeHandlers.CreateIncome)
-			r.Get("/{id}", incomeHandlers.GetIncome)
+			r.Post("/import", incomeHandlers.ImportIncomes)
+			r.With(Cache(CacheDashboard)).Get("/stats", incomeHandlers.IncomeStats)
+			r.With(Cache(CacheRevalidate)).Get("/{id}", incomeHandlers.GetIncome)
 			r.Put("/{id}", incomeHandlers.UpdateIncome)
+			r.Patch("/{id}", incomeHandlers.PatchIncome)
 			r.Delete("/{id}", incomeHandlers.DeleteIncome)
-			r.Get("/{id}/payments", incomeHandlers.GetIncomePayments)
+			r.Post("/{id}/duplicate", incomeHandlers.DuplicateIncome)
+			r.With(Cache(CacheRevalidate)).Get("/{id}/payments", incomeHandlers.GetIncomePayments)
+			r.Get("/{id}/simulate-payment", incomeHandlers.SimulatePayment)
+			r.Get("/{id}/payment-reversals", paymentReversalHandlers.ListReversals)
+			r.With(httprate.LimitByIP(20, 1*time.Minute)).Post("/{id}/pix", pixHandlers.CreateCharge)
+			r.With(Cache(CacheNoStore)).Get("/{id}/pix", pixHandlers.GetCharge)
+			r.With(httprate.LimitByIP(20, 1*time.Minute)).Post("/{id}/payment-link", paymentLinkHandlers.CreateLink)
+			r.With(Cache(CacheNoStore)).Get("/{id}/payment-link", paymentLinkHandlers.GetLink)
+			r.Post("/{id}/invoice", invoiceHandlers.GenerateInvoice)
+		})
+
+		// Notas fiscais de serviço (NFS-e) geradas a partir de receitas pagas
+		r.Route("/invoices", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheNoStore)).Get("/", invoiceHandlers.ListInvoices)
+			r.With(Cache(CacheNoStore)).Get("/{id}", invoiceHandlers.GetInvoice)
+			r.Get("/{id}/xml", invoiceHandlers.DownloadXML)
+		})
+
+		// Relatórios (mensal para o contador em JSON, PDF ou CSV; aging dos recebíveis vencidos)
+		r.Route("/reports", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheDashboard), httprate.LimitByIP(30, 1*time.Minute)).Get("/monthly", reportHandlers.MonthlyReport)
+			r.With(Cache(CacheDashboard)).Get("/aging", reportHandlers.AgingReport)
+		})
+
+		// Dashboard: séries agregadas no servidor (substitui a agregação da lista completa no frontend)
+		r.Route("/dashboard", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheDashboard)).Get("/series", dashboardHandlers.Series)
+		})
+
+		// Calendário de feriados (nacionais e municipais cadastrados) para vencimentos em dia útil
+		r.Route("/holidays", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheNoStore)).Get("/", holidayHandlers.ListHolidays)
+			r.Post("/", holidayHandlers.CreateHoliday)
+			r.Delete("/{id}", holidayHandlers.DeleteHoliday)
+		})
+
+		// Despesas (contas a pagar), estatísticas por competência e documentos fiscais anexados
+		r.Route("/expenses", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheRevalidate)).Get("/", expenseHandlers.ListExpenses)
+			r.Post("/", expenseHandlers.CreateExpense)
+			r.With(Cache(CacheDashboard)).Get("/stats", expenseHandlers.Stats)
+			r.Get("/{id}", expenseHandlers.GetExpense)
+			r.Put("/{id}", expenseHandlers.UpdateExpense)
+			r.Delete("/{id}", expenseHandlers.DeleteExpense)
+			r.Get("/{id}/attachments", expenseHandlers.ListAttachments)
+			r.Post("/{id}/attachments", expenseHandlers.UploadAttachment)
+			r.Delete("/{id}/attachments/{attachmentId}", expenseHandlers.DeleteAttachment)
 		})
 
 		// Rotas de pagamentos (protegidas por autenticação)
 		r.Route("/payments", func(r chi.Router) {
 			r.Use(SupabaseAuth(deps))
-			r.Post("/", incomeHandlers.AddPayment)
+			r.With(Idempotency(idempotencyRepo, deps.Logger)).Post("/", incomeHandlers.AddPayment)
+			r.Put("/{id}", incomeHandlers.UpdatePayment)
+			r.Delete("/{id}", paymentReversalHandlers.DeletePayment)
+			r.Post("/{id}/reverse", paymentReversalHandlers.ReversePayment)
+		})
+
+		// Catálogo de formas de pagamento (PIX, transferência, dinheiro...) e pagamentos por forma
+		r.Route("/payment-methods", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheRevalidate)).Get("/", paymentMethodHandlers.ListMethods)
+			r.Post("/", paymentMethodHandlers.CreateMethod)
+			r.Get("/{id}", paymentMethodHandlers.GetMethod)
+			r.Put("/{id}", paymentMethodHandlers.UpdateMethod)
+			r.Delete("/{id}", paymentMethodHandlers.ArchiveMethod)
+			r.Post("/{id}/default", paymentMethodHandlers.SetDefault)
+			r.Get("/{id}/payments", paymentMethodHandlers.ListPayments)
+		})
+
+		// Webhooks do usuário (income.created, payment.added, receipt.issued) e log de entregas
+		r.Route("/webhooks", func(r chi.Router) {
+			// Notificações de pagamento do PSP: sem JWT, autenticadas pela assinatura (PIX_WEBHOOK_SECRET)
+			r.With(httprate.LimitByIP(120, 1*time.Minute)).Post("/pix", pixHandlers.Webhook)
+			// Notificações do gateway de checkout: autenticadas pela assinatura (CHECKOUT_WEBHOOK_SECRET)
+			r.With(httprate.LimitByIP(120, 1*time.Minute)).Post("/checkout", paymentLinkHandlers.Webhook)
+
+			r.Group(func(r chi.Router) {
+				r.Use(SupabaseAuth(personal))
+				r.With(Cache(CacheNoStore)).Get("/", webhookHandlers.ListWebhooks)
+				r.Post("/", webhookHandlers.CreateWebhook)
+				r.With(Cache(CacheNoStore)).Get("/{id}", webhookHandlers.GetWebhook)
+				r.Patch("/{id}", webhookHandlers.UpdateWebhook)
+				r.Delete("/{id}", webhookHandlers.DeleteWebhook)
+				r.Post("/{id}/rotate-secret", webhookHandlers.RotateSecret)
+				r.With(httprate.LimitByIP(5, 1*time.Minute)).Post("/{id}/ping", webhookHandlers.PingWebhook)
+				r.With(Cache(CacheNoStore)).Get("/{id}/deliveries", webhookHandlers.ListDeliveries)
+			})
+		})
+
+		// Conciliação bancária (importação de extratos e sugestões de vínculo com receitas)
+		r.Route("/reconciliation", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(httprate.LimitByIP(10, 1*time.Minute)).Post("/import", reconciliationHandlers.Import)
+			r.With(Cache(CacheNoStore)).Get("/matches", reconciliationHandlers.ListMatches)
+			r.Post("/matches/{id}/confirm", reconciliationHandlers.ConfirmMatch)
+			r.Post("/matches/{id}/reject", reconciliationHandlers.RejectMatch)
+		})
+
+		// Créditos gerados por pagamentos acima do saldo (overpayment "credit")
+		r.Route("/credits", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.Get("/", creditHandlers.ListCredits)
+			r.Post("/{id}/apply", creditHandlers.ApplyCredit)
 		})
 
 		// Rotas de assinaturas (protegidas por autenticação)
 		r.Route("/signatures", func(r chi.Router) {
 			r.Use(SupabaseAuth(deps))
+			// O limite do plano é conferido no handler, depois da deduplicação pelo hash
 			r.Post("/", signatureHandlers.UploadSignature)
+			r.Get("/", signatureHandlers.ListSignatures)
+			r.Get("/versions/{version}", signatureHandlers.GetSignatureVersion)
 		})
 
 		// Rotas de recibos (protegidas por autenticação)
 		r.Route("/receipts", func(r chi.Router) {
 			r.Use(SupabaseAuth(deps))
-			r.Get("/", receiptHandlers.ListReceipts)
-			r.Post("/", receiptHandlers.CreateReceipt)
-			r.Get("/{id}", receiptHandlers.GetReceipt)
+			r.With(Cache(CacheRevalidate)).Get("/", receiptHandlers.ListReceipts)
+			r.With(Idempotency(idempotencyRepo, deps.Logger), Quota(quotaService, models.QuotaMetricReceipts, deps.Logger)).Post("/", receiptHandlers.CreateReceipt)
+			r.With(Cache(CacheDashboard)).Get("/numbering-report", receiptHandlers.NumberingReport)
+			r.Get("/numbering-check", receiptHandlers.NumberingCheck)
+			r.Post("/numbering-gaps", receiptHandlers.JustifyNumberGap)
+			r.Get("/consistency-check", receiptHandlers.ListInconsistent)
+			r.Post("/batch", receiptBatchHandlers.CreateBatch)
+			r.Get("/batch", receiptBatchHandlers.ListBatches)
+			r.With(Cache(CacheNoStore)).Get("/batch/{id}", receiptBatchHandlers.GetBatch)
+			r.With(Cache(CacheRevalidate)).Get("/{id}", receiptHandlers.GetReceipt)
+			r.Get("/{id}/consistency", receiptHandlers.CheckConsistency)
+			r.With(httprate.LimitByIP(10, 1*time.Minute)).Post("/{id}/send", receiptMailHandlers.SendReceipt)
+			r.With(Cache(CacheNoStore)).Get("/{id}/share", receiptShareHandlers.ShareReceipt)
+			r.Post("/{id}/reissue", receiptReissueHandlers.ReissueReceipt)
+			r.Get("/{id}/reissues", receiptReissueHandlers.ListReissues)
+			r.Post("/{id}/pdf-upload", receiptPDFHandlers.UploadPDF)
+			r.Put("/{id}/artifacts/{kind}", artifactHandlers.PutReceiptArtifact)
+			r.Delete("/{id}/artifacts/{kind}", artifactHandlers.DeleteReceiptArtifact)
 			r.Put("/{id}", receiptHandlers.UpdateReceipt)
 			r.Delete("/{id}", receiptHandlers.DeleteReceipt)
 		})
+
+		// Rotas de pagadores/clientes (protegidas por autenticação)
+		r.Route("/payers", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheRevalidate)).Get("/", payerHandlers.ListPayers)
+			r.Post("/", payerHandlers.CreatePayer)
+			r.Get("/{id}", payerHandlers.GetPayer)
+			r.Put("/{id}", payerHandlers.UpdatePayer)
+			r.Delete("/{id}", payerHandlers.DeletePayer)
+			r.With(Cache(CacheNoStore)).Get("/{id}/statement", payerStatementHandlers.Statement)
+		})
+
+		// Rotas de categorias (protegidas por autenticação)
+		r.Route("/categories", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheDashboard)).Get("/", categoryHandlers.ListCategories)
+			r.Post("/", categoryHandlers.CreateCategory)
+			r.Get("/{id}", categoryHandlers.GetCategory)
+			r.Put("/{id}", categoryHandlers.UpdateCategory)
+			r.Delete("/{id}", categoryHandlers.DeleteCategory)
+		})
+
+		// Contratos: documentos gerados (carnê anual de recibos) e reajuste anual por índice
+		r.Route("/contracts", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheNoStore)).Get("/{id}/receipt-book", contractHandlers.ReceiptBook)
+			r.Post("/{id}/readjust", contractHandlers.Readjust)
+			r.Get("/{id}/readjustments", contractHandlers.ListReadjustments)
+		})
+
+		// Rotas de regras de categorização (protegidas por autenticação)
+		r.Route("/rules", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheRevalidate)).Get("/", ruleHandlers.ListRules)
+			r.Post("/", ruleHandlers.CreateRule)
+			r.Put("/order", ruleHandlers.ReorderRules)
+			r.Post("/test", ruleHandlers.TestRules)
+			r.Get("/{id}", ruleHandlers.GetRule)
+			r.Put("/{id}", ruleHandlers.UpdateRule)
+			r.Delete("/{id}", ruleHandlers.DeleteRule)
+		})
+
+		// Rotas de modelos de receita (protegidas por autenticação)
+		r.Route("/income-templates", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheRevalidate)).Get("/", templateHandlers.ListTemplates)
+			r.Post("/", templateHandlers.CreateTemplate)
+			r.Get("/{id}", templateHandlers.GetTemplate)
+			r.Put("/{id}", templateHandlers.UpdateTemplate)
+			r.Delete("/{id}", templateHandlers.DeleteTemplate)
+			r.Post("/{id}/incomes", templateHandlers.Instantiate)
+			r.Put("/{id}/artifacts/{kind}", artifactHandlers.PutTemplateArtifact)
+			r.Delete("/{id}/artifacts/{kind}", artifactHandlers.DeleteTemplateArtifact)
+		})
+
+		// Rotas de modelos de recibo: identidade visual dos PDFs (protegidas por autenticação)
+		r.Route("/receipt-templates", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.Get("/", receiptTemplateHandlers.ListTemplates)
+			r.Post("/", receiptTemplateHandlers.CreateTemplate)
+			r.Get("/{id}", receiptTemplateHandlers.GetTemplate)
+			r.Put("/{id}", receiptTemplateHandlers.UpdateTemplate)
+			r.Delete("/{id}", receiptTemplateHandlers.DeleteTemplate)
+			r.Put("/{id}/logo", receiptTemplateHandlers.UploadLogo)
+			r.Delete("/{id}/logo", receiptTemplateHandlers.DeleteLogo)
+			r.With(Cache(CacheNoStore)).Get("/{id}/preview", receiptTemplateHandlers.Preview)
+		})
+
+		// Histórico de cotações e índices (protegido por autenticação)
+		r.Route("/rates", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.Use(Cache(CacheReference))
+			r.Get("/{indice}", rateHandlers.ListRates)
+			r.Get("/{indice}/at", rateHandlers.RateAt)
+			r.Get("/{indice}/accumulated", rateHandlers.Accumulated)
+		})
+
+		// Utilitários de emissão (protegidos por autenticação); resultado determinístico, cacheável
+		r.Route("/utils", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.Use(Cache(CacheReference))
+			r.Get("/extenso", utilHandlers.Extenso)
+		})
+
+		// Consulta pública (sem autenticação) com limite mais restrito por IP contra enumeração
+		r.Route("/public", func(r chi.Router) {
+			// Links compartilhados abrem de qualquer origem, sem credenciais
+			r.Use(CORS(CORSOptions{}))
+			r.Use(httprate.LimitByIP(10, 1*time.Minute))
+			r.With(requireCaptcha, Cache(CacheNoStore)).Get("/receipts/lookup", receiptHandlers.PublicLookup)
+			r.With(Cache(CacheNoStore)).Get("/receipts/shared/{token}", receiptShareHandlers.OpenShared)
+		})
+
+		// Resumo semanal: preferências e prévia (autenticadas) e descadastro via token (pública)
+		r.Route("/digest", func(r chi.Router) {
+			r.Get("/unsubscribe", digestHandlers.Unsubscribe)
+			r.Post("/unsubscribe", digestHandlers.Unsubscribe)
+			r.With(SupabaseAuth(personal)).Get("/preferences", digestHandlers.GetPreferences)
+			r.With(SupabaseAuth(personal)).Put("/preferences", digestHandlers.UpdatePreferences)
+			r.With(SupabaseAuth(deps), Cache(CacheDashboard)).Get("/preview", digestHandlers.Preview)
+		})
+
+		// Envio em massa aos pagadores do usuário e Web Push (dispositivos do PWA)
+		r.Route("/notifications", func(r chi.Router) {
+			r.Group(func(r chi.Router) {
+				r.Use(SupabaseAuth(deps))
+				r.Post("/broadcast", broadcastHandlers.CreateBroadcast)
+				r.Get("/broadcasts", broadcastHandlers.ListBroadcasts)
+				r.With(Cache(CacheNoStore)).Get("/broadcasts/{id}", broadcastHandlers.GetBroadcast)
+			})
+			// Dispositivos do PWA: sempre na conta de quem está logado
+			r.Route("/push", func(r chi.Router) {
+				r.Use(SupabaseAuth(personal))
+				r.Get("/public-key", pushHandlers.PublicKey)
+				r.Get("/subscriptions", pushHandlers.ListSubscriptions)
+				r.Post("/subscriptions", pushHandlers.Subscribe)
+				r.Delete("/subscriptions", pushHandlers.Unsubscribe)
+				r.With(httprate.LimitByIP(5, 1*time.Minute)).Post("/test", pushHandlers.Test)
+			})
+		})
+
+		// Configurações do usuário (protegidas por autenticação):
+		//   - preferências de notificação por evento e canal
+		//   - regras de multa e juros
+		//   - dados do prestador da NFS-e
+		//   - numeração dos recibos
+		//   - recibo automático
+		//   - vencimento em dia útil
+		r.Route("/settings", func(r chi.Router) {
+			r.Use(SupabaseAuth(personal))
+			r.Get("/notifications", notificationHandlers.GetSettings)
+			r.Put("/notifications", notificationHandlers.UpdateSettings)
+			r.Get("/late-fees", lateFeeHandlers.GetRules)
+			r.Put("/late-fees", lateFeeHandlers.UpdateRules)
+			r.Get("/invoice", invoiceHandlers.GetIssuer)
+			r.Put("/invoice", invoiceHandlers.UpdateIssuer)
+			r.Get("/receipt-numbering", receiptNumberingHandlers.GetScheme)
+			r.Put("/receipt-numbering", receiptNumberingHandlers.UpdateScheme)
+			r.Get("/receipt-automation", autoReceiptHandlers.GetSettings)
+			r.Put("/receipt-automation", autoReceiptHandlers.UpdateSettings)
+			r.Get("/business-days", holidayHandlers.GetSettings)
+			r.Put("/business-days", holidayHandlers.UpdateSettings)
+		})
+
+		// Perfil do usuário (protegido por autenticação)
+		r.Route("/profile", func(r chi.Router) {
+			r.Use(SupabaseAuth(personal))
+			r.With(Cache(CacheNoStore)).Get("/", profileHandlers.GetProfile)
+			r.Put("/", profileHandlers.UpdateProfile)
+		})
+
+		// Exclusão da própria conta (LGPD): pedido, consulta e cancelamento durante a carência.
+		// Estas rotas continuam acessíveis com a conta bloqueada.
+		r.Route("/account", func(r chi.Router) {
+			lockFree := deps
+			lockFree.accountLocks, lockFree.workspaces, lockFree.apiKeys = nil, nil, nil
+			r.Use(SupabaseAuth(lockFree))
+			r.With(httprate.LimitByIP(5, 1*time.Minute)).Delete("/", accountDeletionHandlers.RequestDeletion)
+			r.With(Cache(CacheNoStore)).Get("/deletion", accountDeletionHandlers.GetDeletion)
+			r.Post("/deletion/cancel", accountDeletionHandlers.CancelDeletion)
+		})
+
+		// Organizações: sempre com o usuário autenticado (X-Org-ID e X-API-Key são ignorados nestas rotas)
+		r.Route("/orgs", func(r chi.Router) {
+			ownScope := deps
+			ownScope.workspaces, ownScope.apiKeys = nil, nil
+			r.Use(SupabaseAuth(ownScope))
+			r.With(Cache(CacheNoStore)).Get("/", orgHandlers.ListOrgs)
+			r.Post("/", orgHandlers.CreateOrg)
+			r.With(httprate.LimitByIP(10, 1*time.Minute), requireCaptcha).Post("/invitations/accept", orgHandlers.AcceptInvitation)
+			r.With(Cache(CacheNoStore)).Get("/{id}", orgHandlers.GetOrg)
+			r.Patch("/{id}", orgHandlers.RenameOrg)
+			r.With(Cache(CacheNoStore)).Get("/{id}/invitations", orgHandlers.ListInvitations)
+			r.With(httprate.LimitByIP(20, 1*time.Minute)).Post("/{id}/invitations", orgHandlers.CreateInvitation)
+			r.Delete("/{id}/invitations/{invitationId}", orgHandlers.RevokeInvitation)
+			r.Patch("/{id}/members/{userId}", orgHandlers.UpdateMember)
+			r.Delete("/{id}/members/{userId}", orgHandlers.RemoveMember)
+		})
+
+		// Consumo do plano (cotas restantes)
+		r.Route("/usage", func(r chi.Router) {
+			r.Use(SupabaseAuth(deps))
+			r.With(Cache(CacheNoStore)).Get("/", usageHandlers.GetUsage)
+		})
+
+		// Chaves de API: gerenciadas só com o login do usuário (uma chave não cria nem revoga chaves)
+		r.Route("/api-keys", func(r chi.Router) {
+			ownKeys := deps
+			ownKeys.workspaces, ownKeys.apiKeys = nil, nil
+			r.Use(SupabaseAuth(ownKeys))
+			r.With(Cache(CacheNoStore)).Get("/", apiKeyHandlers.ListAPIKeys)
+			r.With(httprate.LimitByIP(10, 1*time.Minute)).Post("/", apiKeyHandlers.CreateAPIKey)
+			r.Delete("/{id}", apiKeyHandlers.RevokeAPIKey)
+		})
+
+		// Rotas administrativas (protegidas por token administrativo)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(AdminAuth(deps))
+			r.Get("/deliveries/dead", deliveryAdminHandlers.ListDeadLetters)
+			r.Post("/deliveries/{id}/requeue", deliveryAdminHandlers.RequeueDelivery)
+			r.Get("/deliveries/destinations", deliveryAdminHandlers.ListDestinations)
+			r.Post("/deliveries/destinations/enable", deliveryAdminHandlers.EnableDestination)
+			r.Post("/artifacts/gc", artifactHandlers.CollectGarbage)
+			r.With(Cache(CacheNoStore)).Get("/jobs/overview", jobHandlers.Overview)
+			r.Post("/rates/sync", rateHandlers.Sync)
+			r.Post("/rates/backfill", rateHandlers.Backfill)
+			r.Put("/rates/{indice}/{data}", rateHandlers.SetOverride)
+			r.Delete("/rates/{indice}/{data}", rateHandlers.ClearOverride)
+			r.Post("/accounts/merge", accountHandlers.Merge)
+			r.Get("/accounts/merges", accountHandlers.ListMerges)
+			r.Get("/sync/pending-conflicts", syncConflictHandlers.AdminListPending)
+			r.Post("/sync/conflicts/{id}/resolve", syncConflictHandlers.AdminResolve)
+		})
+	})
+
+	// API v2: listagens com envelope de paginação unificado (Page[T]: items, total, page,
+	// per_page, total_pages, next_cursor). Nas rotas v1 o mesmo formato é obtido com o
+	// cabeçalho "Accept-Profile: page".
+	r.Route("/api/v2", func(r chi.Router) {
+		r.Use(PageEnvelope)
+		r.Use(SupabaseAuth(deps))
+		r.Use(Cache(CacheRevalidate))
+		r.Get("/incomes", incomeHandlers.ListIncomes)
+		r.Get("/receipts", receiptHandlers.ListReceipts)
+		r.Get("/payers", payerHandlers.ListPayers)
+		r.Get("/rules", ruleHandlers.ListRules)
+		r.Get("/categories", categoryHandlers.ListCategories)
 	})
 
 	return r
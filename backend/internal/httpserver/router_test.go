@@ -0,0 +1,87 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do roteador: rotas da conta pessoal ignoram o X-Org-ID e rotas fixas antes de /{id}
+// Data: 18-10-2026
+
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"recibofast/internal/config"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+)
+
+// countingOrgs implementa WorkspaceResolver contando as resoluções; o usuário não é membro de nada
+type countingOrgs struct {
+	calls int
+}
+
+func (c *countingOrgs) ResolveWorkspace(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMembership, error) {
+	c.calls++
+	return nil, models.ErrOrgNotFound
+}
+
+func TestRouter_PersonalRoutesIgnoreOrgScope(t *testing.T) {
+	orgs := &countingOrgs{}
+	deps := AppDeps{Logger: logging.NewLogger("dev"), Cfg: &config.Config{Env: "dev"}}
+	deps.workspaces = orgs
+	deps.accountLocks = lockSet{}
+	h := NewRouter(deps)
+
+	do := func(method, path string) int {
+		// corpo inválido: a rota responde 400 antes de tocar no banco
+		req := httptest.NewRequest(method, path, strings.NewReader("{"))
+		req.Header.Set("X-Debug-User", uuid.NewString())
+		req.Header.Set("X-Org-ID", uuid.NewString())
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Dados da organização continuam resolvendo o X-Org-ID (e o não membro é barrado)
+	if code := do(http.MethodPost, "/api/v1/incomes"); code != http.StatusForbidden || orgs.calls != 1 {
+		t.Fatalf("receitas: status = %d, resoluções = %d", code, orgs.calls)
+	}
+
+	// Webhooks, dispositivos, perfil e configurações ficam na conta de quem está logado
+	for _, rt := range []struct{ method, path string }{
+		{http.MethodPost, "/api/v1/webhooks"},
+		{http.MethodPost, "/api/v1/notifications/push/subscriptions"},
+		{http.MethodPut, "/api/v1/profile"},
+		{http.MethodPut, "/api/v1/settings/notifications"},
+		{http.MethodPut, "/api/v1/settings/late-fees"},
+		{http.MethodPut, "/api/v1/digest/preferences"},
+	} {
+		orgs.calls = 0
+		if code := do(rt.method, rt.path); code != http.StatusBadRequest || orgs.calls != 0 {
+			t.Fatalf("%s %s: status = %d, resoluções = %d", rt.method, rt.path, code, orgs.calls)
+		}
+	}
+}
+
+func TestRouter_IncomeStatsIsNotAnIncomeID(t *testing.T) {
+	deps := AppDeps{Logger: logging.NewLogger("dev"), Cfg: &config.Config{Env: "dev"}}
+	deps.workspaces = &countingOrgs{}
+	deps.accountLocks = lockSet{}
+	routes, ok := NewRouter(deps).(chi.Routes)
+	if !ok {
+		t.Fatalf("NewRouter não devolveu um roteador chi")
+	}
+
+	rctx := chi.NewRouteContext()
+	if !routes.Match(rctx, http.MethodGet, "/api/v1/incomes/stats") {
+		t.Fatalf("GET /api/v1/incomes/stats sem rota")
+	}
+	if pattern := rctx.RoutePattern(); pattern != "/api/v1/incomes/stats" {
+		t.Fatalf("GET /api/v1/incomes/stats casou com %q", pattern)
+	}
+}
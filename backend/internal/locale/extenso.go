@@ -0,0 +1,145 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Valor por extenso em português (reais e centavos), usado nos recibos
+// Data: 18-10-2026
+
+package locale
+
+import (
+	"strings"
+
+	"recibofast/internal/models"
+)
+
+var (
+	extensoUnidades = [...]string{"", "um", "dois", "três", "quatro", "cinco", "seis", "sete", "oito", "nove",
+		"dez", "onze", "doze", "treze", "quatorze", "quinze", "dezesseis", "dezessete", "dezoito", "dezenove"}
+	extensoDezenas  = [...]string{"", "", "vinte", "trinta", "quarenta", "cinquenta", "sessenta", "setenta", "oitenta", "noventa"}
+	extensoCentenas = [...]string{"", "cento", "duzentos", "trezentos", "quatrocentos", "quinhentos", "seiscentos",
+		"setecentos", "oitocentos", "novecentos"}
+	// classes a partir do milhão (singular, plural); "mil" é invariável
+	extensoClasses = [...][2]string{{"milhão", "milhões"}, {"bilhão", "bilhões"}, {"trilhão", "trilhões"},
+		{"quatrilhão", "quatrilhões"}, {"quintilhão", "quintilhões"}}
+)
+
+// Extenso escreve um valor em reais por extenso ("mil duzentos e trinta e quatro reais e
+// cinquenta e seis centavos").
+// Docstring: milhões exatos levam "de reais" ("um milhão de reais"); sem centavos a parte é
+// omitida, e sem reais sai só "cinquenta centavos". Valores negativos começam com "menos".
+func Extenso(m models.Money) string {
+	cents := uint64(m)
+	neg := m < 0
+	if neg {
+		cents = -cents
+	}
+	reais, centavos := cents/100, cents%100
+
+	var parts []string
+	switch {
+	case reais == 1:
+		parts = append(parts, "um real")
+	case reais >= 1_000_000 && reais%1_000_000 == 0:
+		parts = append(parts, ExtensoNumero(reais, false)+" de reais")
+	case reais > 0:
+		parts = append(parts, ExtensoNumero(reais, false)+" reais")
+	}
+	switch {
+	case centavos == 1:
+		parts = append(parts, "um centavo")
+	case centavos > 0:
+		parts = append(parts, ExtensoNumero(centavos, false)+" centavos")
+	}
+	if len(parts) == 0 {
+		return "zero reais"
+	}
+	out := strings.Join(parts, " e ")
+	if neg {
+		return "menos " + out
+	}
+	return out
+}
+
+// ExtensoNumero escreve um número inteiro por extenso; feminino concorda unidades e centenas com
+// substantivo feminino ("duzentas e uma parcelas", "duas mil"). Milhões e acima são masculinos.
+func ExtensoNumero(n uint64, feminino bool) string {
+	if n == 0 {
+		return "zero"
+	}
+	// classes de três dígitos, da menor para a maior
+	var grupos []uint64
+	for ; n > 0; n /= 1000 {
+		grupos = append(grupos, n%1000)
+	}
+
+	var classes []string
+	last := uint64(0) // menor classe não nula (decide o "e" da última junção)
+	for i := len(grupos) - 1; i >= 0; i-- {
+		g := grupos[i]
+		if g == 0 {
+			continue
+		}
+		var s string
+		switch i {
+		case 0:
+			s = extensoCentena(g, feminino)
+		case 1:
+			s = "mil"
+			if g > 1 {
+				s = extensoCentena(g, feminino) + " mil"
+			}
+		default:
+			nome := extensoClasses[i-2]
+			if g == 1 {
+				s = "um " + nome[0]
+			} else {
+				s = extensoCentena(g, false) + " " + nome[1]
+			}
+		}
+		classes = append(classes, s)
+		last = g
+	}
+
+	if len(classes) == 1 {
+		return classes[0]
+	}
+	// a última classe entra com "e" quando é menor que cem ou centena redonda ("mil e cem",
+	// "um milhão e duzentos mil"); as demais são separadas por vírgula
+	sep := " "
+	if last < 100 || last%100 == 0 {
+		sep = " e "
+	}
+	return strings.Join(classes[:len(classes)-1], ", ") + sep + classes[len(classes)-1]
+}
+
+// extensoCentena escreve de 1 a 999
+func extensoCentena(n uint64, feminino bool) string {
+	if n == 100 {
+		return "cem"
+	}
+	var parts []string
+	if c := n / 100; c > 0 {
+		s := extensoCentenas[c]
+		if feminino && c > 1 {
+			s = strings.TrimSuffix(s, "os") + "as"
+		}
+		parts = append(parts, s)
+	}
+	du := n % 100
+	if du >= 20 {
+		parts = append(parts, extensoDezenas[du/10])
+		du %= 10
+	}
+	if du > 0 {
+		s := extensoUnidades[du]
+		if feminino {
+			switch du {
+			case 1:
+				s = "uma"
+			case 2:
+				s = "duas"
+			}
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " e ")
+}
@@ -0,0 +1,56 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do valor por extenso
+// Data: 18-10-2026
+
+package locale
+
+import (
+	"testing"
+
+	"recibofast/internal/models"
+)
+
+func TestExtenso(t *testing.T) {
+	cases := []struct {
+		cents int64
+		want  string
+	}{
+		{0, "zero reais"},
+		{1, "um centavo"},
+		{50, "cinquenta centavos"},
+		{100, "um real"},
+		{10000, "cem reais"},
+		{10100, "cento e um reais"},
+		{123456, "mil duzentos e trinta e quatro reais e cinquenta e seis centavos"},
+		{110000, "mil e cem reais"},
+		{2001500, "vinte mil e quinze reais"},
+		{100000000, "um milhão de reais"},
+		{200000050, "dois milhões de reais e cinquenta centavos"},
+		{120000000, "um milhão e duzentos mil reais"},
+		{250030000, "dois milhões, quinhentos mil e trezentos reais"},
+		{100000100, "um milhão e um reais"},
+		{-1499, "menos quatorze reais e noventa e nove centavos"},
+	}
+	for _, c := range cases {
+		if got := Extenso(models.Money(c.cents)); got != c.want {
+			t.Errorf("Extenso(%d) = %q, esperado %q", c.cents, got, c.want)
+		}
+	}
+}
+
+func TestExtensoNumero_GenderAndLargeValues(t *testing.T) {
+	if got, want := ExtensoNumero(221222, true), "duzentas e vinte e uma mil duzentas e vinte e duas"; got != want {
+		t.Errorf("feminino = %q, esperado %q", got, want)
+	}
+	if got, want := ExtensoNumero(2_200_000, true), "dois milhões e duzentas mil"; got != want {
+		t.Errorf("milhões no feminino = %q, esperado %q", got, want)
+	}
+	if got, want := ExtensoNumero(1_000_000_001, false), "um bilhão e um"; got != want {
+		t.Errorf("bilhão = %q, esperado %q", got, want)
+	}
+	// maior valor de Money: nenhuma classe fica sem nome
+	if got := Extenso(models.Money(1<<63 - 1)); got == "" || got[:8] != "noventa " {
+		t.Errorf("valor máximo = %q", got)
+	}
+}
@@ -0,0 +1,114 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Formatação de valores, datas e competências conforme idioma e fuso do usuário
+// Data: 18-10-2026
+
+package locale
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"recibofast/internal/models"
+)
+
+// separadores de milhar e decimal por idioma
+func (s Settings) separators() (thousands, decimal string) {
+	if s.Locale == "en-US" {
+		return ",", "."
+	}
+	return ".", ","
+}
+
+// In converte o instante para o fuso do usuário
+func (s Settings) In(t time.Time) time.Time {
+	return t.In(s.Location)
+}
+
+// Now instante atual no fuso do usuário
+func (s Settings) Now() time.Time {
+	return time.Now().In(s.Location)
+}
+
+// FormatMoney formata um valor em reais (R$ 1.234,56 / R$1,234.56), arredondado por models.DefaultRounding
+func (s Settings) FormatMoney(v float64) string {
+	return s.FormatAmount(models.NewMoney(v))
+}
+
+// FormatAmount formata um valor em centavos sem passar por float
+func (s Settings) FormatAmount(m models.Money) string {
+	thousands, decimal := s.separators()
+	cents := int64(m)
+	neg := cents < 0
+	if neg {
+		cents = -cents
+	}
+	intPart := strconv.FormatInt(cents/100, 10)
+
+	var b strings.Builder
+	for i, d := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(d)
+	}
+	out := b.String() + decimal + strconv.FormatInt(cents%100+100, 10)[1:]
+
+	prefix := "R$ "
+	if s.Locale == "en-US" {
+		prefix = "R$"
+	}
+	if neg {
+		return "-" + prefix + out
+	}
+	return prefix + out
+}
+
+// FormatDate formata a data no fuso do usuário (02/01/2006; en-US 01/02/2006)
+func (s Settings) FormatDate(t time.Time) string {
+	if s.Locale == "en-US" {
+		return s.In(t).Format("01/02/2006")
+	}
+	return s.In(t).Format("02/01/2006")
+}
+
+// FormatDateTime formata data e hora no fuso do usuário
+func (s Settings) FormatDateTime(t time.Time) string {
+	if s.Locale == "en-US" {
+		return s.In(t).Format("01/02/2006 3:04 PM")
+	}
+	return s.In(t).Format("02/01/2006 15:04")
+}
+
+// FormatCompetencia exibe a competência "AAAA-MM" como "MM/AAAA"; outros formatos são mantidos
+func (s Settings) FormatCompetencia(competencia string) string {
+	t, err := time.Parse("2006-01", competencia)
+	if err != nil {
+		return competencia
+	}
+	return t.Format("01/2006")
+}
+
+// Atalhos que usam as configurações do contexto
+
+// FormatMoney formata o valor conforme o idioma da requisição
+func FormatMoney(ctx context.Context, v float64) string {
+	return FromContext(ctx).FormatMoney(v)
+}
+
+// FormatAmount formata o valor em centavos conforme o idioma da requisição
+func FormatAmount(ctx context.Context, m models.Money) string {
+	return FromContext(ctx).FormatAmount(m)
+}
+
+// FormatDate formata a data conforme idioma e fuso da requisição
+func FormatDate(ctx context.Context, t time.Time) string {
+	return FromContext(ctx).FormatDate(t)
+}
+
+// FormatDateTime formata data e hora conforme idioma e fuso da requisição
+func FormatDateTime(ctx context.Context, t time.Time) string {
+	return FromContext(ctx).FormatDateTime(t)
+}
@@ -0,0 +1,157 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Idioma e fuso horário do usuário no contexto da requisição e formatação de saída
+// Data: 18-10-2026
+
+package locale
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // base de fusos embutida: imagens mínimas não trazem /usr/share/zoneinfo
+)
+
+// Idioma e fuso padrão do produto
+const (
+	DefaultLocale   = "pt-BR"
+	DefaultTimezone = "America/Sao_Paulo"
+)
+
+// Idiomas suportados na saída (demais valores caem para o padrão)
+var supported = []string{"pt-BR", "en-US", "es-ES"}
+
+// Settings idioma e fuso usados em toda saída voltada ao usuário
+type Settings struct {
+	Locale   string
+	Location *time.Location
+}
+
+// Default retorna as configurações padrão (pt-BR, America/Sao_Paulo)
+func Default() Settings {
+	return Resolve("", "")
+}
+
+// Timezone nome IANA do fuso
+func (s Settings) Timezone() string {
+	return s.Location.String()
+}
+
+// Resolve normaliza idioma e fuso informados, caindo para o padrão quando vazios ou inválidos
+func Resolve(lang, timezone string) Settings {
+	s := Settings{Locale: Normalize(lang)}
+	if s.Locale == "" {
+		s.Locale = DefaultLocale
+	}
+	if loc := loadLocation(timezone); loc != nil {
+		s.Location = loc
+	} else {
+		s.Location = loadLocation(DefaultTimezone)
+	}
+	return s
+}
+
+// Normalize mapeia uma tag de idioma (pt, pt_br, en-GB...) para um idioma suportado; "" se não suportado
+func Normalize(lang string) string {
+	lang = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+	if lang == "" {
+		return ""
+	}
+	for _, s := range supported {
+		if strings.ToLower(s) == lang {
+			return s
+		}
+	}
+	base := strings.SplitN(lang, "-", 2)[0]
+	for _, s := range supported {
+		if strings.HasPrefix(strings.ToLower(s), base+"-") {
+			return s
+		}
+	}
+	return ""
+}
+
+// FromAcceptLanguage escolhe o primeiro idioma suportado do cabeçalho Accept-Language
+// Docstring: respeita a ordem do cabeçalho; pesos q=0 são ignorados.
+func FromAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		if len(fields) > 1 && strings.TrimSpace(fields[1]) == "q=0" {
+			continue
+		}
+		if l := Normalize(fields[0]); l != "" {
+			return l
+		}
+	}
+	return ""
+}
+
+// ValidTimezone verifica se o nome IANA do fuso é conhecido
+func ValidTimezone(name string) bool {
+	return loadLocation(name) != nil
+}
+
+var (
+	locMu    sync.Mutex
+	locCache = map[string]*time.Location{}
+)
+
+// loadLocation carrega (com cache) o fuso IANA; nil se vazio ou desconhecido
+func loadLocation(name string) *time.Location {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return nil
+	}
+	locMu.Lock()
+	defer locMu.Unlock()
+	if loc, ok := locCache[name]; ok {
+		return loc
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = nil
+	}
+	locCache[name] = loc
+	return loc
+}
+
+type ctxKey struct{}
+
+// resolver resolve as configurações sob demanda (perfil do usuário só é consultado se houver saída formatada)
+type resolver struct {
+	mu       sync.Mutex
+	done     bool
+	settings Settings
+	load     func(ctx context.Context) (Settings, bool)
+}
+
+// WithSettings fixa as configurações no contexto (jobs em segundo plano, testes)
+func WithSettings(ctx context.Context, s Settings) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &resolver{done: true, settings: s})
+}
+
+// WithResolver registra no contexto uma resolução tardia das configurações.
+// Docstring: load recebe o contexto do ponto de uso (já autenticado) e informa se o resultado é
+// definitivo; resultados provisórios (ex.: antes da autenticação) não ficam em cache.
+func WithResolver(ctx context.Context, load func(ctx context.Context) (Settings, bool)) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &resolver{load: load})
+}
+
+// FromContext retorna as configurações da requisição ou o padrão quando ausentes
+func FromContext(ctx context.Context) Settings {
+	r, ok := ctx.Value(ctxKey{}).(*resolver)
+	if !ok {
+		return Default()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.done {
+		return r.settings
+	}
+	s, final := r.load(ctx)
+	if final {
+		r.settings, r.done = s, true
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes de resolução de idioma/fuso e formatação de saída
+// Data: 18-10-2026
+
+package locale
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"recibofast/internal/models"
+)
+
+func TestResolve_FallsBackToDefaults(t *testing.T) {
+	s := Resolve("fr-FR", "Mars/Olympus")
+	if s.Locale != DefaultLocale || s.Timezone() != DefaultTimezone {
+		t.Fatalf("esperado padrão, obtido %s %s", s.Locale, s.Timezone())
+	}
+	if got := FromAcceptLanguage("fr;q=0.9, en-GB;q=0.8, pt;q=0"); got != "en-US" {
+		t.Fatalf("Accept-Language = %q", got)
+	}
+}
+
+func TestFormat_PerLocale(t *testing.T) {
+	at := time.Date(2026, 10, 18, 2, 30, 0, 0, time.UTC) // 17/10 23:30 em São Paulo
+	cases := []struct {
+		lang, tz, money, date string
+	}{
+		{"pt-BR", "America/Sao_Paulo", "R$ 1.234.567,89", "17/10/2026"},
+		{"en-US", "UTC", "R$1,234,567.89", "10/18/2026"},
+	}
+	for _, c := range cases {
+		s := Resolve(c.lang, c.tz)
+		if got := s.FormatMoney(1234567.891); got != c.money {
+			t.Errorf("%s FormatMoney = %q, esperado %q", c.lang, got, c.money)
+		}
+		if got := s.FormatDate(at); got != c.date {
+			t.Errorf("%s FormatDate = %q, esperado %q", c.lang, got, c.date)
+		}
+	}
+	if got := Default().FormatMoney(-5); got != "-R$ 5,00" {
+		t.Errorf("valor negativo = %q", got)
+	}
+	// float e centavos passam pela mesma política de arredondamento
+	if got, want := Default().FormatMoney(1.005), Default().FormatAmount(models.NewMoney(1.005)); got != want || got != "R$ 1,01" {
+		t.Errorf("FormatMoney(1.005) = %q, FormatAmount = %q", got, want)
+	}
+}
+
+func TestFromContext_ResolverCachesOnlyFinalResult(t *testing.T) {
+	calls := 0
+	final := false
+	ctx := WithResolver(context.Background(), func(context.Context) (Settings, bool) {
+		calls++
+		return Resolve("en-US", "UTC"), final
+	})
+	FromContext(ctx)
+	final = true
+	FromContext(ctx)
+	if s := FromContext(ctx); s.Locale != "en-US" || calls != 2 {
+		t.Fatalf("locale=%s calls=%d", s.Locale, calls)
+	}
+	if s := FromContext(context.Background()); s.Locale != DefaultLocale {
+		t.Fatalf("sem configurações deveria usar o padrão, obtido %s", s.Locale)
+	}
+}
@@ -0,0 +1,230 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Aplicação versionada das migrações SQL embutidas (histórico em rf_schema_migrations)
+// Data: 18-10-2026
+
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockKey chave do advisory lock que serializa réplicas migrando ao mesmo tempo
+const lockKey int64 = 7_266_001
+
+// createHistory cria o histórico (com RLS e sem políticas: só o dono do banco o lê pela API)
+const createHistory = `CREATE TABLE IF NOT EXISTS public.rf_schema_migrations (
+    name text PRIMARY KEY,
+    version text NOT NULL,
+    checksum text NOT NULL,
+    baseline boolean NOT NULL DEFAULT false,
+    applied_at timestamptz NOT NULL DEFAULT now()
+);
+ALTER TABLE public.rf_schema_migrations ENABLE ROW LEVEL SECURITY`
+
+// Migration arquivo de migração; Name é o nome sem ".sql" e Version o prefixo numérico
+// (dois arquivos podem dividir a versão, então o histórico é indexado pelo nome)
+type Migration struct {
+	Name     string
+	Version  string
+	SQL      string
+	Checksum string
+}
+
+// Status situação de uma migração no banco
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+	Baseline  bool // marcada como aplicada sem executar (banco migrado pelo SQL Editor)
+	Changed   bool // o arquivo mudou depois de aplicado
+}
+
+// Load lê os arquivos *.sql da raiz de fsys, em ordem alfabética
+func Load(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	out := make([]Migration, 0, len(names))
+	for _, file := range names {
+		name := strings.TrimSuffix(path.Base(file), ".sql")
+		version, _, _ := strings.Cut(name, "_")
+		if version == "" || strings.Trim(version, "0123456789") != "" {
+			return nil, fmt.Errorf("migração %s sem prefixo numérico (NNN_nome.sql)", file)
+		}
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		out = append(out, Migration{Name: name, Version: version, SQL: string(data), Checksum: hex.EncodeToString(sum[:])})
+	}
+	return out, nil
+}
+
+// pending migrações ainda fora do histórico (applied: nome → checksum), na ordem de aplicação
+func pending(all []Migration, applied map[string]string) []Migration {
+	var out []Migration
+	for _, m := range all {
+		if _, ok := applied[m.Name]; !ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Migrator aplica as migrações e mantém o histórico em public.rf_schema_migrations.
+// Docstring: cada migração roda na própria transação junto com o registro no histórico; um
+// advisory lock impede que duas instâncias apliquem as mesmas migrações ao mesmo tempo.
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	logf       func(format string, args ...any)
+}
+
+// New carrega as migrações de fsys
+func New(pool *pgxpool.Pool, fsys fs.FS, logf func(format string, args ...any)) (*Migrator, error) {
+	all, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if logf == nil {
+		logf = func(string, ...any) {}
+	}
+	return &Migrator{pool: pool, migrations: all, logf: logf}, nil
+}
+
+// Up aplica as migrações pendentes e retorna os nomes aplicados
+func (m *Migrator) Up(ctx context.Context) ([]string, error) {
+	var done []string
+	err := m.locked(ctx, func(conn *pgxpool.Conn, applied map[string]string) error {
+		for _, mig := range m.migrations {
+			if sum, ok := applied[mig.Name]; ok && sum != mig.Checksum {
+				m.logf("migração %s alterada depois de aplicada (ignorada)", mig.Name)
+			}
+		}
+		for _, mig := range pending(m.migrations, applied) {
+			start := time.Now()
+			if err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.SQL); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, `INSERT INTO public.rf_schema_migrations (name, version, checksum) VALUES ($1, $2, $3)`,
+					mig.Name, mig.Version, mig.Checksum)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migração %s: %w", mig.Name, err)
+			}
+			m.logf("migração %s aplicada (%s)", mig.Name, time.Since(start).Round(time.Millisecond))
+			done = append(done, mig.Name)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline registra como aplicadas, sem executar, as migrações até version (inclusive).
+// Docstring: para bancos já migrados manualmente pelo SQL Editor antes do histórico existir.
+func (m *Migrator) Baseline(ctx context.Context, version string) ([]string, error) {
+	var done []string
+	err := m.locked(ctx, func(conn *pgxpool.Conn, applied map[string]string) error {
+		for _, mig := range pending(m.migrations, applied) {
+			if mig.Version > version {
+				break
+			}
+			if _, err := conn.Exec(ctx, `INSERT INTO public.rf_schema_migrations (name, version, checksum, baseline) VALUES ($1, $2, $3, true)`,
+				mig.Name, mig.Version, mig.Checksum); err != nil {
+				return fmt.Errorf("migração %s: %w", mig.Name, err)
+			}
+			done = append(done, mig.Name)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status lista todas as migrações embutidas com a situação no banco
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if _, err := m.pool.Exec(ctx, createHistory); err != nil {
+		return nil, err
+	}
+	rows, err := m.pool.Query(ctx, `SELECT name, checksum, baseline, applied_at FROM public.rf_schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	type row struct {
+		checksum  string
+		baseline  bool
+		appliedAt time.Time
+	}
+	applied := map[string]row{}
+	for rows.Next() {
+		var name string
+		var r row
+		if err := rows.Scan(&name, &r.checksum, &r.baseline, &r.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[name] = r
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	out := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		st := Status{Migration: mig}
+		if r, ok := applied[mig.Name]; ok {
+			at := r.appliedAt
+			st.AppliedAt, st.Baseline, st.Changed = &at, r.baseline, r.checksum != mig.Checksum
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+// locked executa fn numa conexão dedicada, com o advisory lock e o histórico já carregado
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgxpool.Conn, applied map[string]string) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return err
+	}
+	defer conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	if _, err := conn.Exec(ctx, createHistory); err != nil {
+		return fmt.Errorf("erro ao criar rf_schema_migrations: %w", err)
+	}
+	rows, err := conn.Query(ctx, `SELECT name, checksum FROM public.rf_schema_migrations`)
+	if err != nil {
+		return err
+	}
+	applied := map[string]string{}
+	for rows.Next() {
+		var name, sum string
+		if err := rows.Scan(&name, &sum); err != nil {
+			rows.Close()
+			return err
+		}
+		applied[name] = sum
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return fn(conn, applied)
+}
@@ -0,0 +1,62 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da leitura e ordenação das migrações embutidas
+// Data: 18-10-2026
+
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"recibofast/migrations"
+)
+
+func TestLoad_SortsAndParsesVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"010_b.sql": {Data: []byte("select 2;")},
+		"002_a.sql": {Data: []byte("select 1;")},
+		"010_a.sql": {Data: []byte("select 3;")},
+		"README.md": {Data: []byte("ignorado")},
+	}
+	got, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := []string{"002_a", "010_a", "010_b"}
+	if len(got) != len(want) {
+		t.Fatalf("migrações = %+v", got)
+	}
+	for i, name := range want {
+		if got[i].Name != name {
+			t.Fatalf("ordem[%d] = %s, want %s", i, got[i].Name, name)
+		}
+	}
+	if got[1].Version != "010" || got[0].SQL != "select 1;" || len(got[0].Checksum) != 64 {
+		t.Fatalf("migração inesperada: %+v", got[1])
+	}
+}
+
+func TestLoad_RejectsNameWithoutVersion(t *testing.T) {
+	if _, err := Load(fstest.MapFS{"init.sql": {Data: []byte("select 1;")}}); err == nil {
+		t.Fatal("esperava erro para arquivo sem prefixo numérico")
+	}
+}
+
+func TestPending_SkipsAppliedKeepsOrder(t *testing.T) {
+	all := []Migration{{Name: "001_a"}, {Name: "005_b"}, {Name: "005_c"}, {Name: "006_d"}}
+	got := pending(all, map[string]string{"001_a": "x", "005_c": "y"})
+	if len(got) != 2 || got[0].Name != "005_b" || got[1].Name != "006_d" {
+		t.Fatalf("pendentes = %+v", got)
+	}
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	all, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(all) == 0 || all[0].Name != "001_init" {
+		t.Fatalf("primeira migração embutida = %+v", all)
+	}
+}
@@ -0,0 +1,98 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos da exclusão de conta a pedido do titular (LGPD)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Situação de um pedido de exclusão (rf_account_deletions.status)
+const (
+	AccountDeletionPending    = "pending"
+	AccountDeletionCancelled  = "cancelled"
+	AccountDeletionProcessing = "processing"
+	AccountDeletionCompleted  = "completed"
+	AccountDeletionFailed     = "failed"
+)
+
+// AccountDeletionGracePeriod carência entre o pedido e a remoção; nela o titular pode desistir
+const AccountDeletionGracePeriod = 30 * 24 * time.Hour
+
+// AccountDeletionMaxAttempts tentativas do worker antes de o pedido exigir intervenção manual
+const AccountDeletionMaxAttempts = 5
+
+// MaxDeletionReasonLen limite do motivo informado pelo titular
+const MaxDeletionReasonLen = 500
+
+// Erros da exclusão de conta
+var (
+	ErrDeletionConfirmRequired = errors.New("confirme a exclusão da conta (confirm: true)")
+	ErrDeletionReasonTooLong   = errors.New("motivo da exclusão muito longo")
+	ErrDeletionNotFound        = errors.New("nenhum pedido de exclusão para esta conta")
+	ErrDeletionExists          = errors.New("já existe um pedido de exclusão em andamento para esta conta")
+	ErrDeletionNotCancellable  = errors.New("a exclusão já está em execução e não pode ser cancelada")
+)
+
+// AccountDeletionRequest corpo de DELETE /account
+type AccountDeletionRequest struct {
+	Confirm bool   `json:"confirm"`
+	Reason  string `json:"reason"`
+}
+
+// Validate exige a confirmação explícita e limita o motivo
+func (req *AccountDeletionRequest) Validate() error {
+	if !req.Confirm {
+		return ErrDeletionConfirmRequired
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if utf8.RuneCountInString(req.Reason) > MaxDeletionReasonLen {
+		return ErrDeletionReasonTooLong
+	}
+	return nil
+}
+
+// AccountDeletion pedido de exclusão de conta.
+// Docstring: ScheduledFor é o fim da carência; Result guarda o que foi removido (linhas por tabela
+// e objetos por bucket). O motivo é apagado quando a exclusão termina.
+type AccountDeletion struct {
+	ID           uuid.UUID              `json:"id"`
+	OwnerID      uuid.UUID              `json:"owner_id"`
+	Status       string                 `json:"status"`
+	Reason       *string                `json:"reason,omitempty"`
+	RequestedAt  time.Time              `json:"requested_at"`
+	ScheduledFor time.Time              `json:"scheduled_for"`
+	Attempts     int                    `json:"attempts"`
+	StartedAt    *time.Time             `json:"started_at,omitempty"`
+	CancelledAt  *time.Time             `json:"cancelled_at,omitempty"`
+	CompletedAt  *time.Time             `json:"completed_at,omitempty"`
+	Result       *AccountDeletionResult `json:"result,omitempty"`
+	Error        *string                `json:"error,omitempty"`
+}
+
+// Locked indica se o pedido mantém a conta bloqueada na API
+func (d *AccountDeletion) Locked() bool {
+	switch d.Status {
+	case AccountDeletionPending, AccountDeletionProcessing, AccountDeletionFailed:
+		return true
+	}
+	return false
+}
+
+// Cancellable indica se o titular ainda pode desistir (só durante a carência)
+func (d *AccountDeletion) Cancellable() bool {
+	return d.Status == AccountDeletionPending
+}
+
+// AccountDeletionResult o que foi removido da conta
+type AccountDeletionResult struct {
+	Rows    map[string]int64 `json:"rows"`
+	Objects map[string]int   `json:"objects"`
+}
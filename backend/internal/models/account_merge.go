@@ -0,0 +1,200 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos da fusão assistida de contas (prévia, conflitos e auditoria)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Situação de uma fusão (rf_account_merges.status)
+const (
+	AccountMergeRunning   = "running"
+	AccountMergeCompleted = "completed"
+	AccountMergeFailed    = "failed"
+)
+
+// Códigos de conflitos que bloqueiam a fusão
+const (
+	MergeConflictReceiptNumbering = "receipt_numbering"
+	MergeConflictReceiptSeries    = "receipt_series"
+	MergeConflictStoragePath      = "storage_path"
+)
+
+// MaxMergeReasonLen limite do motivo registrado na auditoria
+const MaxMergeReasonLen = 500
+
+// Erros da fusão de contas
+var (
+	ErrMergeAccountsRequired = errors.New("source_id e target_id são obrigatórios")
+	ErrMergeSameAccount      = errors.New("conta de origem e de destino devem ser diferentes")
+	ErrMergeReasonRequired   = errors.New("motivo da fusão é obrigatório")
+	ErrMergeReasonTooLong    = errors.New("motivo da fusão muito longo")
+	ErrMergeRequesterMissing = errors.New("requested_by é obrigatório (quem autorizou a fusão)")
+	ErrMergeAccountNotFound  = errors.New("conta não encontrada")
+	ErrMergeConflict         = errors.New("fusão bloqueada por conflitos")
+	ErrMergeInProgress       = errors.New("já existe uma fusão em andamento envolvendo estas contas")
+)
+
+// AccountMergeRequest pedido de fusão: tudo da conta de origem passa para a de destino.
+// Docstring: DryRun apenas calcula a prévia; a conta de origem continua existindo no Auth
+// (sem dados) e deve ser desativada no painel do Supabase.
+type AccountMergeRequest struct {
+	SourceID    uuid.UUID `json:"source_id"`
+	TargetID    uuid.UUID `json:"target_id"`
+	RequestedBy string    `json:"requested_by"`
+	Reason      string    `json:"reason"`
+	DryRun      bool      `json:"dry_run"`
+}
+
+// Validate valida o pedido; na prévia motivo e solicitante são opcionais
+func (req *AccountMergeRequest) Validate() error {
+	if req.SourceID == uuid.Nil || req.TargetID == uuid.Nil {
+		return ErrMergeAccountsRequired
+	}
+	if req.SourceID == req.TargetID {
+		return ErrMergeSameAccount
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	req.RequestedBy = strings.TrimSpace(req.RequestedBy)
+	if utf8.RuneCountInString(req.Reason) > MaxMergeReasonLen {
+		return ErrMergeReasonTooLong
+	}
+	if req.DryRun {
+		return nil
+	}
+	if req.Reason == "" {
+		return ErrMergeReasonRequired
+	}
+	if req.RequestedBy == "" {
+		return ErrMergeRequesterMissing
+	}
+	return nil
+}
+
+// MergeAccount identificação de uma das contas na prévia
+type MergeAccount struct {
+	ID        uuid.UUID  `json:"id"`
+	Email     *string    `json:"email"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// ReceiptRange faixa de numeração dos recibos de uma conta (Count = 0: nenhum recibo)
+type ReceiptRange struct {
+	Count int   `json:"count"`
+	First int64 `json:"first,omitempty"`
+	Last  int64 `json:"last,omitempty"`
+}
+
+// MergeConflict motivo que impede a fusão
+type MergeConflict struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// AccountMergePlan prévia da fusão.
+// Docstring: Rows e Objects contam o que sai da origem (por tabela e por bucket); Resolutions
+// descreve os ajustes automáticos (pagadores/categorias duplicados, configurações mantidas).
+type AccountMergePlan struct {
+	Source              MergeAccount     `json:"source"`
+	Target              MergeAccount     `json:"target"`
+	Rows                map[string]int64 `json:"rows"`
+	Objects             map[string]int   `json:"objects"`
+	SourceReceipts      ReceiptRange     `json:"source_receipts"`
+	TargetReceipts      ReceiptRange     `json:"target_receipts"`
+	SeriesCollisions    int64            `json:"series_collisions"` // números exibidos da origem já usados no destino
+	DuplicatePayers     int              `json:"duplicate_payers"`
+	DuplicateCategories int              `json:"duplicate_categories"`
+	SettingsKept        []string         `json:"settings_kept"`
+	StorageCollisions   []string         `json:"storage_collisions"`
+	Conflicts           []MergeConflict  `json:"conflicts"`
+	Resolutions         []string         `json:"resolutions"`
+	CanMerge            bool             `json:"can_merge"`
+}
+
+// Evaluate calcula conflitos e ajustes automáticos a partir das contagens.
+// Docstring: recibos das duas contas só podem ser unidos se as faixas de numeração não se
+// cruzarem (a sequência por usuário é única e crescente); uma lacuna entre as faixas é
+// justificada automaticamente em rf_receipt_number_gaps. O mesmo vale para o número exibido
+// (migração 051): com reinício anual, duas contas podem ter usado o mesmo número no mesmo ano.
+func (p *AccountMergePlan) Evaluate() {
+	p.Conflicts, p.Resolutions = []MergeConflict{}, []string{}
+	s, t := p.SourceReceipts, p.TargetReceipts
+	if s.Count > 0 && t.Count > 0 {
+		if s.First <= t.Last && t.First <= s.Last {
+			p.Conflicts = append(p.Conflicts, MergeConflict{
+				Code: MergeConflictReceiptNumbering,
+				Message: fmt.Sprintf("numeração dos recibos se sobrepõe (origem %d a %d, destino %d a %d)",
+					s.First, s.Last, t.First, t.Last),
+			})
+		} else if from, to, ok := ReceiptNumberGap(s, t); ok {
+			p.Resolutions = append(p.Resolutions, fmt.Sprintf("lacuna %d a %d na numeração será justificada como fusão de contas", from, to))
+		}
+	}
+	if p.SeriesCollisions > 0 {
+		p.Conflicts = append(p.Conflicts, MergeConflict{
+			Code:    MergeConflictReceiptSeries,
+			Message: fmt.Sprintf("%d recibo(s) da origem têm número exibido já usado no destino", p.SeriesCollisions),
+		})
+	}
+	for _, path := range p.StorageCollisions {
+		p.Conflicts = append(p.Conflicts, MergeConflict{
+			Code:    MergeConflictStoragePath,
+			Message: "objeto já existe no destino: " + path,
+		})
+	}
+	if p.DuplicatePayers > 0 {
+		p.Resolutions = append(p.Resolutions, fmt.Sprintf("%d pagador(es) com o mesmo CPF/CNPJ serão unificados no cadastro do destino", p.DuplicatePayers))
+	}
+	if p.DuplicateCategories > 0 {
+		p.Resolutions = append(p.Resolutions, fmt.Sprintf("%d categoria(s) com o mesmo nome serão unificadas", p.DuplicateCategories))
+	}
+	for _, table := range p.SettingsKept {
+		p.Resolutions = append(p.Resolutions, fmt.Sprintf("%s: mantidas as configurações do destino", table))
+	}
+	p.CanMerge = len(p.Conflicts) == 0
+}
+
+// ReceiptNumberGap lacuna entre duas faixas de numeração que não se cruzam
+func ReceiptNumberGap(a, b ReceiptRange) (from, to int64, ok bool) {
+	if a.Count == 0 || b.Count == 0 {
+		return 0, 0, false
+	}
+	if a.First > b.First {
+		a, b = b, a
+	}
+	if b.First <= a.Last+1 {
+		return 0, 0, false
+	}
+	return a.Last + 1, b.First - 1, true
+}
+
+// AccountMergeResult o que foi efetivamente transferido
+type AccountMergeResult struct {
+	Rows        map[string]int64 `json:"rows"`
+	Objects     map[string]int   `json:"objects"`
+	Resolutions []string         `json:"resolutions"`
+}
+
+// AccountMerge registro de auditoria de uma fusão (rf_account_merges)
+type AccountMerge struct {
+	ID            uuid.UUID           `json:"id" db:"id"`
+	SourceOwnerID uuid.UUID           `json:"source_owner_id" db:"source_owner_id"`
+	TargetOwnerID uuid.UUID           `json:"target_owner_id" db:"target_owner_id"`
+	RequestedBy   string              `json:"requested_by" db:"requested_by"`
+	Reason        string              `json:"reason" db:"reason"`
+	Status        string              `json:"status" db:"status"`
+	Plan          *AccountMergePlan   `json:"plan" db:"plan"`
+	Result        *AccountMergeResult `json:"result,omitempty" db:"result"`
+	Error         *string             `json:"error,omitempty" db:"error"`
+	StartedAt     time.Time           `json:"started_at" db:"started_at"`
+	FinishedAt    *time.Time          `json:"finished_at,omitempty" db:"finished_at"`
+}
@@ -0,0 +1,97 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da prévia da fusão de contas (validação, conflitos de numeração e lacunas)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAccountMergeRequestValidate(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	cases := []struct {
+		name string
+		req  AccountMergeRequest
+		err  error
+	}{
+		{"sem contas", AccountMergeRequest{TargetID: b, Reason: "x", RequestedBy: "y"}, ErrMergeAccountsRequired},
+		{"mesma conta", AccountMergeRequest{SourceID: a, TargetID: a, Reason: "x", RequestedBy: "y"}, ErrMergeSameAccount},
+		{"sem motivo", AccountMergeRequest{SourceID: a, TargetID: b, Reason: "  ", RequestedBy: "y"}, ErrMergeReasonRequired},
+		{"sem solicitante", AccountMergeRequest{SourceID: a, TargetID: b, Reason: "duplicada"}, ErrMergeRequesterMissing},
+		{"prévia sem motivo", AccountMergeRequest{SourceID: a, TargetID: b, DryRun: true}, nil},
+		{"válida", AccountMergeRequest{SourceID: a, TargetID: b, Reason: " cadastro duplicado ", RequestedBy: "suporte"}, nil},
+	}
+	for _, c := range cases {
+		err := c.req.Validate()
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: err = %v, esperado %v", c.name, err, c.err)
+		}
+	}
+}
+
+func TestAccountMergePlanEvaluate(t *testing.T) {
+	p := &AccountMergePlan{
+		SourceReceipts: ReceiptRange{Count: 3, First: 1, Last: 3},
+		TargetReceipts: ReceiptRange{Count: 2, First: 3, Last: 4},
+	}
+	p.Evaluate()
+	if p.CanMerge || len(p.Conflicts) != 1 || p.Conflicts[0].Code != MergeConflictReceiptNumbering {
+		t.Fatalf("faixas sobrepostas devem bloquear: %+v", p.Conflicts)
+	}
+
+	p = &AccountMergePlan{
+		SourceReceipts:  ReceiptRange{Count: 2, First: 10, Last: 11},
+		TargetReceipts:  ReceiptRange{Count: 5, First: 1, Last: 5},
+		DuplicatePayers: 2,
+		SettingsKept:    []string{"rf_settings"},
+	}
+	p.Evaluate()
+	if !p.CanMerge || len(p.Resolutions) != 3 {
+		t.Fatalf("esperava fusão liberada com 3 ajustes: %+v", p)
+	}
+
+	p = &AccountMergePlan{StorageCollisions: []string{"signatures/x/a.png"}}
+	p.Evaluate()
+	if p.CanMerge || p.Conflicts[0].Code != MergeConflictStoragePath {
+		t.Fatalf("colisão no Storage deve bloquear: %+v", p.Conflicts)
+	}
+
+	p = &AccountMergePlan{
+		SourceReceipts:   ReceiptRange{Count: 2, First: 10, Last: 11},
+		TargetReceipts:   ReceiptRange{Count: 5, First: 1, Last: 5},
+		SeriesCollisions: 2,
+	}
+	p.Evaluate()
+	if p.CanMerge || p.Conflicts[0].Code != MergeConflictReceiptSeries {
+		t.Fatalf("número exibido repetido deve bloquear: %+v", p.Conflicts)
+	}
+
+	p = &AccountMergePlan{TargetReceipts: ReceiptRange{Count: 4, First: 1, Last: 4}}
+	p.Evaluate()
+	if !p.CanMerge || len(p.Resolutions) != 0 {
+		t.Fatalf("origem sem recibos não gera conflito nem ajuste: %+v", p)
+	}
+}
+
+func TestReceiptNumberGap(t *testing.T) {
+	cases := []struct {
+		a, b     ReceiptRange
+		from, to int64
+		ok       bool
+	}{
+		{ReceiptRange{Count: 5, First: 1, Last: 5}, ReceiptRange{Count: 2, First: 6, Last: 7}, 0, 0, false},
+		{ReceiptRange{Count: 2, First: 10, Last: 11}, ReceiptRange{Count: 5, First: 1, Last: 5}, 6, 9, true},
+		{ReceiptRange{}, ReceiptRange{Count: 5, First: 1, Last: 5}, 0, 0, false},
+	}
+	for _, c := range cases {
+		from, to, ok := ReceiptNumberGap(c.a, c.b)
+		if from != c.from || to != c.to || ok != c.ok {
+			t.Errorf("ReceiptNumberGap(%+v, %+v) = %d, %d, %v", c.a, c.b, from, to, ok)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Relatório de aging (saldo vencido por faixa de atraso) agrupado por pagador ou contrato
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Agrupamentos do relatório de aging
+const (
+	AgingByPayer    = "payer"
+	AgingByContract = "contract"
+)
+
+var ErrAgingGroupInvalid = errors.New("agrupamento inválido (use payer ou contract)")
+
+// AgingItem receita em aberto e vencida, com pagador e contrato
+type AgingItem struct {
+	IncomeID          uuid.UUID
+	Competencia       string
+	DueDate           time.Time
+	Saldo             Money
+	PayerID           *uuid.UUID
+	PayerNome         *string
+	ContractID        *uuid.UUID
+	ContractNumero    *string
+	ContractDescricao *string
+}
+
+// AgingBuckets saldo vencido por faixa de dias de atraso
+type AgingBuckets struct {
+	D0To30   Money `json:"0-30"`
+	D31To60  Money `json:"31-60"`
+	D61To90  Money `json:"61-90"`
+	DOver90  Money `json:"90+"`
+	Total    Money `json:"total"`
+	Receitas int   `json:"receitas"`
+}
+
+func (b *AgingBuckets) add(days int, saldo Money) {
+	switch {
+	case days <= 30:
+		b.D0To30 += saldo
+	case days <= 60:
+		b.D31To60 += saldo
+	case days <= 90:
+		b.D61To90 += saldo
+	default:
+		b.DOver90 += saldo
+	}
+	b.Total += saldo
+	b.Receitas++
+}
+
+// AgingGroup saldo vencido de um pagador ou contrato (ID nulo: receitas sem pagador/contrato)
+type AgingGroup struct {
+	ID          *uuid.UUID `json:"id"`
+	Nome        string     `json:"nome"`
+	MaxDays     int        `json:"max_days"`
+	AverageDays int        `json:"average_days"`
+	AgingBuckets
+	daysSum int
+}
+
+// AgingReport aging dos recebíveis em uma data
+type AgingReport struct {
+	AsOf    string       `json:"as_of"`
+	GroupBy string       `json:"group_by"`
+	Totals  AgingBuckets `json:"totals"`
+	Groups  []AgingGroup `json:"groups"`
+}
+
+// BuildAgingReport distribui as receitas vencidas em faixas pelos dias de atraso em today.
+// Docstring: dias de atraso contam a partir do dia seguinte ao vencimento (vence hoje = não
+// vencida); grupos ordenados pelo maior saldo vencido e, no empate, pelo atraso mais antigo.
+func BuildAgingReport(items []AgingItem, today time.Time, groupBy string) (*AgingReport, error) {
+	if groupBy == "" {
+		groupBy = AgingByPayer
+	}
+	if groupBy != AgingByPayer && groupBy != AgingByContract {
+		return nil, ErrAgingGroupInvalid
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	rep := &AgingReport{AsOf: today.Format("2006-01-02"), GroupBy: groupBy, Groups: []AgingGroup{}}
+	index := map[uuid.UUID]int{}
+	for _, it := range items {
+		due := time.Date(it.DueDate.Year(), it.DueDate.Month(), it.DueDate.Day(), 0, 0, 0, 0, time.UTC)
+		days := int(today.Sub(due).Hours() / 24)
+		if days <= 0 || it.Saldo <= 0 {
+			continue
+		}
+		id, nome := it.PayerID, strings.TrimSpace(derefString(it.PayerNome))
+		if groupBy == AgingByContract {
+			id, nome = it.ContractID, agingContractName(&it)
+		}
+		key := uuid.Nil
+		if id != nil {
+			key = *id
+		}
+		k, ok := index[key]
+		if !ok {
+			if nome == "" {
+				nome = map[string]string{AgingByPayer: "Sem pagador", AgingByContract: "Sem contrato"}[groupBy]
+			}
+			rep.Groups = append(rep.Groups, AgingGroup{ID: id, Nome: nome})
+			k = len(rep.Groups) - 1
+			index[key] = k
+		}
+		g := &rep.Groups[k]
+		g.add(days, it.Saldo)
+		g.daysSum += days
+		if days > g.MaxDays {
+			g.MaxDays = days
+		}
+		rep.Totals.add(days, it.Saldo)
+	}
+	for k := range rep.Groups {
+		rep.Groups[k].AverageDays = rep.Groups[k].daysSum / rep.Groups[k].Receitas
+	}
+	sort.SliceStable(rep.Groups, func(a, b int) bool {
+		if rep.Groups[a].Total != rep.Groups[b].Total {
+			return rep.Groups[a].Total > rep.Groups[b].Total
+		}
+		return rep.Groups[a].MaxDays > rep.Groups[b].MaxDays
+	})
+	return rep, nil
+}
+
+func agingContractName(it *AgingItem) string {
+	desc, num := strings.TrimSpace(derefString(it.ContractDescricao)), strings.TrimSpace(derefString(it.ContractNumero))
+	switch {
+	case it.ContractID == nil:
+		return ""
+	case desc != "" && num != "":
+		return desc + " (nº " + num + ")"
+	case desc != "":
+		return desc
+	case num != "":
+		return "Contrato nº " + num
+	}
+	return "Contrato " + it.ContractID.String()[:8]
+}
@@ -0,0 +1,50 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes das faixas e agrupamentos do relatório de aging
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildAgingReport(t *testing.T) {
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	ago := func(days int) time.Time { return today.AddDate(0, 0, -days) }
+	maria, joao := uuid.New(), uuid.New()
+	nomeMaria, nomeJoao := "Maria", "João"
+	contrato, num := uuid.New(), "12"
+	items := []AgingItem{
+		{DueDate: ago(120), Saldo: NewMoney(100), PayerID: &maria, PayerNome: &nomeMaria, ContractID: &contrato, ContractNumero: &num},
+		{DueDate: ago(45), Saldo: NewMoney(100), PayerID: &maria, PayerNome: &nomeMaria, ContractID: &contrato, ContractNumero: &num},
+		{DueDate: ago(30), Saldo: NewMoney(50), PayerID: &joao, PayerNome: &nomeJoao},
+		{DueDate: ago(61), Saldo: NewMoney(20)},
+		{DueDate: today, Saldo: NewMoney(999), PayerID: &joao, PayerNome: &nomeJoao}, // vence hoje: não entra
+	}
+
+	rep, err := BuildAgingReport(items, today, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := AgingBuckets{D0To30: NewMoney(50), D31To60: NewMoney(100), D61To90: NewMoney(20), DOver90: NewMoney(100), Total: NewMoney(270), Receitas: 4}
+	if rep.GroupBy != AgingByPayer || rep.AsOf != "2026-10-18" || rep.Totals != want {
+		t.Fatalf("totais = %+v", rep.Totals)
+	}
+	if len(rep.Groups) != 3 || rep.Groups[0].Nome != "Maria" || rep.Groups[0].MaxDays != 120 || rep.Groups[0].AverageDays != 82 ||
+		rep.Groups[1].Nome != "João" || rep.Groups[2].Nome != "Sem pagador" || rep.Groups[2].ID != nil {
+		t.Fatalf("grupos = %+v", rep.Groups)
+	}
+
+	rep, err = BuildAgingReport(items, today, AgingByContract)
+	if err != nil || len(rep.Groups) != 2 || rep.Groups[0].Nome != "Contrato nº 12" || rep.Groups[1].Nome != "Sem contrato" || rep.Groups[1].Total != NewMoney(70) {
+		t.Fatalf("por contrato: %+v, %v", rep, err)
+	}
+	if _, err := BuildAgingReport(items, today, "categoria"); !errors.Is(err, ErrAgingGroupInvalid) {
+		t.Fatalf("err = %v", err)
+	}
+}
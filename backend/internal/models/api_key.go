@@ -0,0 +1,132 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Chaves de API do usuário (rf_api_keys) e escopos por recurso
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Acesso concedido por um escopo ("<recurso>:read" ou "<recurso>:write")
+const (
+	APIKeyAccessRead  = "read"
+	APIKeyAccessWrite = "write"
+)
+
+// APIKeyAllResources recurso curinga dos escopos ("*:read", "*:write")
+const APIKeyAllResources = "*"
+
+// MaxAPIKeys limite de chaves ativas por usuário
+const MaxAPIKeys = 20
+
+// MaxAPIKeyNameLen tamanho máximo do nome da chave
+const MaxAPIKeyNameLen = 100
+
+// Erros das chaves de API
+var (
+	ErrAPIKeyNotFound     = errors.New("chave de API não encontrada")
+	ErrAPIKeyInvalid      = errors.New("chave de API inválida, revogada ou expirada")
+	ErrAPIKeyNameRequired = errors.New("nome da chave é obrigatório (até 100 caracteres)")
+	ErrAPIKeyScopeInvalid = errors.New("escopo inválido (use <recurso>:read ou <recurso>:write)")
+	ErrAPIKeyExpiry       = errors.New("expires_at deve estar no futuro")
+	ErrAPIKeyLimit        = errors.New("limite de chaves de API atingido")
+)
+
+// APIKeyResources recursos da API v1 que podem ser acessados por chave (primeiro segmento da rota).
+// Docstring: conta, organizações e as próprias chaves exigem o login do usuário.
+func APIKeyResources() []string {
+	return []string{
+		"incomes", "payments", "payment-methods", "receipts", "payers", "categories", "contracts",
+		"rules", "income-templates", "invoices", "reports", "dashboard", "credits", "reconciliation",
+		"webhooks", "notifications", "settings", "rates", "digest", "signatures", "sync", "usage",
+	}
+}
+
+// ValidAPIKeyScope verifica o formato "<recurso>:<read|write>"
+func ValidAPIKeyScope(scope string) bool {
+	resource, access, ok := strings.Cut(scope, ":")
+	if !ok || (access != APIKeyAccessRead && access != APIKeyAccessWrite) {
+		return false
+	}
+	if resource == APIKeyAllResources {
+		return true
+	}
+	for _, r := range APIKeyResources() {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyAllows verifica se os escopos liberam o acesso ao recurso; write inclui read
+func APIKeyAllows(scopes []string, resource, access string) bool {
+	for _, s := range scopes {
+		r, a, _ := strings.Cut(s, ":")
+		if r != resource && r != APIKeyAllResources {
+			continue
+		}
+		if a == access || a == APIKeyAccessWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey chave de acesso programático.
+// Docstring: Key só é preenchida na criação; o banco guarda apenas o hash. Prefix (início da
+// chave) permite reconhecê-la na listagem.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	OwnerID    uuid.UUID  `json:"owner_id"`
+	Nome       string     `json:"nome"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	Key        string     `json:"key,omitempty"`
+}
+
+// APIKeyRequest corpo de POST /api-keys
+type APIKeyRequest struct {
+	Nome      string     `json:"nome"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Validate normaliza nome e escopos (sem repetição) e valida a expiração
+func (req *APIKeyRequest) Validate(now time.Time) error {
+	req.Nome = strings.TrimSpace(req.Nome)
+	if req.Nome == "" || utf8.RuneCountInString(req.Nome) > MaxAPIKeyNameLen {
+		return ErrAPIKeyNameRequired
+	}
+	if len(req.Scopes) == 0 {
+		return ErrAPIKeyScopeInvalid
+	}
+	seen := map[string]bool{}
+	scopes := make([]string, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if !ValidAPIKeyScope(s) {
+			return ErrAPIKeyScopeInvalid
+		}
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+	req.Scopes = scopes
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return ErrAPIKeyExpiry
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes dos escopos das chaves de API
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyAllows(t *testing.T) {
+	scopes := []string{"incomes:read", "receipts:write"}
+	cases := []struct {
+		resource, access string
+		want             bool
+	}{
+		{"incomes", APIKeyAccessRead, true},
+		{"incomes", APIKeyAccessWrite, false},
+		{"receipts", APIKeyAccessRead, true},
+		{"receipts", APIKeyAccessWrite, true},
+		{"payers", APIKeyAccessRead, false},
+	}
+	for _, c := range cases {
+		if got := APIKeyAllows(scopes, c.resource, c.access); got != c.want {
+			t.Errorf("APIKeyAllows(%s, %s) = %v", c.resource, c.access, got)
+		}
+	}
+	if !APIKeyAllows([]string{"*:read"}, "payers", APIKeyAccessRead) || APIKeyAllows([]string{"*:read"}, "payers", APIKeyAccessWrite) {
+		t.Error("curinga deve valer para todos os recursos, respeitando o acesso")
+	}
+}
+
+func TestAPIKeyRequestValidate(t *testing.T) {
+	now := time.Now()
+	req := APIKeyRequest{Nome: " n8n ", Scopes: []string{" Incomes:Read", "incomes:read", "*:write"}}
+	if err := req.Validate(now); err != nil {
+		t.Fatal(err)
+	}
+	if req.Nome != "n8n" || len(req.Scopes) != 2 || req.Scopes[0] != "incomes:read" {
+		t.Fatalf("requisição não normalizada: %+v", req)
+	}
+	for _, scopes := range [][]string{nil, {"orgs:read"}, {"incomes"}, {"incomes:delete"}} {
+		req := APIKeyRequest{Nome: "x", Scopes: scopes}
+		if err := req.Validate(now); !errors.Is(err, ErrAPIKeyScopeInvalid) {
+			t.Errorf("escopos %v: err = %v", scopes, err)
+		}
+	}
+}
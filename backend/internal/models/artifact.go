@@ -0,0 +1,98 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos de artefatos gerados (PDFs/QRs) endereçados por conteúdo (rf_artifacts)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de artefato aceitos
+const (
+	ArtifactKindPDF = "pdf"
+	ArtifactKindQR  = "qr"
+)
+
+// MaxArtifactSize limita o tamanho de um artefato enviado (10 MB)
+const MaxArtifactSize = 10 << 20
+
+// artifactContentTypes tipos MIME aceitos por tipo de artefato
+var artifactContentTypes = map[string][]string{
+	ArtifactKindPDF: {"application/pdf"},
+	ArtifactKindQR:  {"image/png", "image/svg+xml"},
+}
+
+// Artifact representa um objeto no Storage identificado pelo sha256 do conteúdo
+type Artifact struct {
+	Hash           string     `json:"hash" db:"hash"`
+	Bucket         string     `json:"bucket" db:"bucket"`
+	Path           string     `json:"path" db:"path"`
+	ContentType    string     `json:"content_type" db:"content_type"`
+	SizeBytes      int64      `json:"size_bytes" db:"size_bytes"`
+	RefCount       int        `json:"ref_count" db:"ref_count"`
+	UnreferencedAt *time.Time `json:"unreferenced_at,omitempty" db:"unreferenced_at"`
+	CreatedAt      *time.Time `json:"created_at" db:"created_at"`
+}
+
+// ArtifactRef vincula um artefato a um recibo ou a um modelo de receita (exatamente um dos dois)
+type ArtifactRef struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	OwnerID      uuid.UUID  `json:"owner_id" db:"owner_id"`
+	ArtifactHash string     `json:"artifact_hash" db:"artifact_hash"`
+	Kind         string     `json:"kind" db:"kind"`
+	ReceiptID    *uuid.UUID `json:"receipt_id,omitempty" db:"receipt_id"`
+	TemplateID   *uuid.UUID `json:"template_id,omitempty" db:"template_id"`
+	CreatedAt    *time.Time `json:"created_at" db:"created_at"`
+}
+
+// ArtifactUpload resposta do envio de artefato; Deduplicated indica conteúdo já armazenado
+type ArtifactUpload struct {
+	Artifact     Artifact    `json:"artifact"`
+	Ref          ArtifactRef `json:"ref"`
+	Deduplicated bool        `json:"deduplicated"`
+}
+
+// ArtifactGCResult resultado de uma execução da coleta de lixo de artefatos
+type ArtifactGCResult struct {
+	Scanned      int   `json:"scanned"`
+	Deleted      int   `json:"deleted"`
+	FreedBytes   int64 `json:"freed_bytes"`
+	StorageFails int   `json:"storage_failures"`
+}
+
+// ArtifactHash calcula o endereço (sha256 hexadecimal) do conteúdo
+func ArtifactHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ArtifactPath caminho do objeto no Storage, particionado pelos dois primeiros caracteres do hash
+func ArtifactPath(hash, kind string) string {
+	return "cas/" + hash[:2] + "/" + hash + "." + kind
+}
+
+// ValidArtifactKind verifica se o tipo de artefato é suportado
+func ValidArtifactKind(kind string) bool {
+	_, ok := artifactContentTypes[kind]
+	return ok
+}
+
+// ValidArtifactType verifica o tipo de artefato e o MIME correspondente
+func ValidArtifactType(kind, contentType string) error {
+	types, ok := artifactContentTypes[kind]
+	if !ok {
+		return ErrInvalidArtifactKind
+	}
+	for _, t := range types {
+		if t == contentType {
+			return nil
+		}
+	}
+	return ErrInvalidArtifactContentType
+}
@@ -0,0 +1,43 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Emissão automática de recibo ao quitar a receita (opção do usuário e fila rf_auto_receipts)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status de um pedido de recibo automático
+const (
+	AutoReceiptPending = "pending"
+	AutoReceiptIssued  = "issued"
+	AutoReceiptSkipped = "skipped" // a receita já tinha recibo
+	AutoReceiptFailed  = "failed"
+)
+
+// ReceiptAutomation opção de emissão automática (rf_settings.recibo_automatico)
+type ReceiptAutomation struct {
+	EmitirAoQuitar bool `json:"emitir_ao_quitar" db:"recibo_automatico"`
+}
+
+// AutoReceipt pedido de recibo gravado com o pagamento que quitou a receita
+type AutoReceipt struct {
+	IncomeID    uuid.UUID  `json:"income_id" db:"income_id"`
+	OwnerID     uuid.UUID  `json:"owner_id" db:"owner_id"`
+	PaymentID   uuid.UUID  `json:"payment_id" db:"payment_id"`
+	Status      string     `json:"status" db:"status"`
+	ReceiptID   *uuid.UUID `json:"receipt_id" db:"receipt_id"`
+	Attempts    int        `json:"-" db:"attempts"`
+	LastError   *string    `json:"last_error" db:"last_error"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	ProcessedAt *time.Time `json:"processed_at" db:"processed_at"`
+}
+
+// IncomeSettled indica se a receita está quitada (total pago cobre o valor)
+func IncomeSettled(i *Income) bool {
+	return i != nil && i.Valor > 0 && i.TotalPago >= i.Valor
+}
@@ -0,0 +1,225 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Envio em massa de notificações personalizadas para pagadores (rf_broadcasts)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status de um envio em massa
+const (
+	BroadcastStatusQueued    = "queued"
+	BroadcastStatusRunning   = "running"
+	BroadcastStatusCompleted = "completed"
+	BroadcastStatusFailed    = "failed"
+)
+
+// Status de um destinatário do envio
+const (
+	BroadcastRecipientQueued  = "queued"
+	BroadcastRecipientSkipped = "skipped"
+)
+
+// EventBroadcast tipo de evento das entregas geradas por um envio em massa
+const EventBroadcast = "notification.broadcast"
+
+// Limites do envio em massa
+const (
+	MaxBroadcastTemplateLen = 5000
+	MaxBroadcastSubjectLen  = 200
+)
+
+// Motivos para um pagador ser ignorado no envio
+const (
+	BroadcastSkipNoEmail = "pagador sem e-mail"
+	BroadcastSkipNoPhone = "pagador sem telefone"
+)
+
+// Erros do envio em massa
+var (
+	ErrBroadcastNotFound        = errors.New("envio em massa não encontrado")
+	ErrBroadcastChannelInvalid  = errors.New("canal do envio em massa inválido (use email ou whatsapp)")
+	ErrBroadcastTemplateInvalid = errors.New("modelo da mensagem é obrigatório e deve ter até 5000 caracteres")
+	ErrBroadcastSubjectInvalid  = errors.New("assunto é obrigatório no e-mail e deve ter até 200 caracteres")
+	ErrBroadcastVariableInvalid = errors.New("variável do modelo inválida (use {{nome_da_variavel}} com letras minúsculas, dígitos e _)")
+)
+
+// BroadcastThrottle limite de envio de um provedor (mensagens por minuto)
+type BroadcastThrottle struct {
+	PerMinute int
+}
+
+// Interval espaçamento entre duas entregas consecutivas respeitando o limite
+func (t BroadcastThrottle) Interval() time.Duration {
+	if t.PerMinute <= 0 {
+		return 0
+	}
+	return time.Minute / time.Duration(t.PerMinute)
+}
+
+// DefaultBroadcastThrottles limites padrão por canal.
+// Docstring: e-mail segue a cota típica de SMTP transacional; WhatsApp Business limita bem mais.
+func DefaultBroadcastThrottles() map[string]BroadcastThrottle {
+	return map[string]BroadcastThrottle{
+		DeliveryChannelEmail:    {PerMinute: 60},
+		DeliveryChannelWhatsApp: {PerMinute: 20},
+	}
+}
+
+// BroadcastFilter seleciona os pagadores do envio; filtros vazios alcançam todos os pagadores do usuário
+type BroadcastFilter struct {
+	Search      string      `json:"search,omitempty"`
+	PayerIDs    []uuid.UUID `json:"payer_ids,omitempty"`
+	OpenBalance bool        `json:"open_balance,omitempty"` // apenas com receitas em aberto
+	Overdue     bool        `json:"overdue,omitempty"`      // apenas com receitas vencidas
+}
+
+// BroadcastRequest pedido de envio em massa.
+// Docstring: Variables guarda variáveis extras por pagador (chave = ID do pagador) que
+// complementam ou sobrescrevem as variáveis padrão (BroadcastVariables).
+type BroadcastRequest struct {
+	Channel   string                       `json:"channel"`
+	Subject   string                       `json:"subject"`
+	Template  string                       `json:"template"`
+	Filter    BroadcastFilter              `json:"filter"`
+	Variables map[string]map[string]string `json:"variables"`
+}
+
+// Broadcast envio em massa (rf_broadcasts)
+type Broadcast struct {
+	ID         uuid.UUID                    `json:"id" db:"id"`
+	OwnerID    uuid.UUID                    `json:"owner_id" db:"owner_id"`
+	Channel    string                       `json:"channel" db:"channel"`
+	Subject    *string                      `json:"subject" db:"subject"`
+	Template   string                       `json:"template" db:"template"`
+	Filter     BroadcastFilter              `json:"filter" db:"filter"`
+	Variables  map[string]map[string]string `json:"variables,omitempty" db:"variables"`
+	Locale     string                       `json:"locale" db:"locale"`
+	Timezone   string                       `json:"timezone" db:"timezone"`
+	Status     string                       `json:"status" db:"status"`
+	Total      int                          `json:"total" db:"total"`
+	Enqueued   int                          `json:"enqueued" db:"enqueued"`
+	Skipped    int                          `json:"skipped" db:"skipped"`
+	LastError  *string                      `json:"last_error" db:"last_error"`
+	CreatedAt  time.Time                    `json:"created_at" db:"created_at"`
+	StartedAt  *time.Time                   `json:"started_at" db:"started_at"`
+	FinishedAt *time.Time                   `json:"finished_at" db:"finished_at"`
+}
+
+// BroadcastTarget pagador alcançado pelo filtro, com os dados usados na personalização
+type BroadcastTarget struct {
+	PayerID        uuid.UUID
+	Nome           string
+	Documento      *string
+	Email          *string
+	Telefone       *string
+	SaldoAberto    Money
+	Abertas        int
+	Vencidas       int
+	ProxVencimento *time.Time
+}
+
+// BroadcastRecipient resultado do envio para um pagador (rf_broadcast_recipients)
+type BroadcastRecipient struct {
+	BroadcastID uuid.UUID  `json:"broadcast_id" db:"broadcast_id"`
+	PayerID     uuid.UUID  `json:"payer_id" db:"payer_id"`
+	OwnerID     uuid.UUID  `json:"-" db:"owner_id"`
+	Destination *string    `json:"destination" db:"destination"`
+	DeliveryID  *uuid.UUID `json:"delivery_id" db:"delivery_id"`
+	Status      string     `json:"status" db:"status"`
+	Reason      *string    `json:"reason" db:"reason"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// BroadcastPayload payload das entregas do envio em massa (mensagem já personalizada)
+type BroadcastPayload struct {
+	BroadcastID uuid.UUID `json:"broadcast_id"`
+	PayerID     uuid.UUID `json:"payer_id"`
+	Subject     string    `json:"subject,omitempty"`
+	Body        string    `json:"body"`
+}
+
+// BroadcastReport relatório de entrega: destinatários ignorados e entregas por status da fila
+type BroadcastReport struct {
+	Total          int            `json:"total"`
+	Skipped        int            `json:"skipped"`
+	SkippedReasons map[string]int `json:"skipped_reasons"`
+	Pending        int            `json:"pending"`
+	Delivered      int            `json:"delivered"`
+	Dead           int            `json:"dead"`
+}
+
+// BroadcastResponse envio em massa com o relatório de entrega
+type BroadcastResponse struct {
+	Broadcast
+	Report *BroadcastReport `json:"report,omitempty"`
+}
+
+var broadcastVarPattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+var broadcastVarName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Validate valida e normaliza o pedido de envio em massa
+func (req *BroadcastRequest) Validate() error {
+	req.Channel = strings.ToLower(strings.TrimSpace(req.Channel))
+	if req.Channel != DeliveryChannelEmail && req.Channel != DeliveryChannelWhatsApp {
+		return ErrBroadcastChannelInvalid
+	}
+	req.Template = strings.TrimSpace(req.Template)
+	if req.Template == "" || len([]rune(req.Template)) > MaxBroadcastTemplateLen {
+		return ErrBroadcastTemplateInvalid
+	}
+	for _, m := range broadcastVarPattern.FindAllStringSubmatch(req.Template, -1) {
+		if !broadcastVarName.MatchString(m[1]) {
+			return ErrBroadcastVariableInvalid
+		}
+	}
+	req.Subject = strings.TrimSpace(req.Subject)
+	if len([]rune(req.Subject)) > MaxBroadcastSubjectLen {
+		return ErrBroadcastSubjectInvalid
+	}
+	if req.Channel == DeliveryChannelEmail && req.Subject == "" {
+		return ErrBroadcastSubjectInvalid
+	}
+	for _, vars := range req.Variables {
+		for k := range vars {
+			if !broadcastVarName.MatchString(k) {
+				return ErrBroadcastVariableInvalid
+			}
+		}
+	}
+	req.Filter.Search = strings.TrimSpace(req.Filter.Search)
+	return nil
+}
+
+// RenderBroadcastTemplate substitui as variáveis {{nome}} do modelo; variáveis desconhecidas ficam vazias
+func RenderBroadcastTemplate(tpl string, vars map[string]string) string {
+	return broadcastVarPattern.ReplaceAllStringFunc(tpl, func(m string) string {
+		name := broadcastVarPattern.FindStringSubmatch(m)[1]
+		return vars[name]
+	})
+}
+
+// Destination endereço do pagador no canal do envio e, quando ausente, o motivo para ignorá-lo
+func (t *BroadcastTarget) Destination(channel string) (string, string) {
+	switch channel {
+	case DeliveryChannelEmail:
+		if t.Email == nil || strings.TrimSpace(*t.Email) == "" {
+			return "", BroadcastSkipNoEmail
+		}
+		return strings.TrimSpace(*t.Email), ""
+	default:
+		if t.Telefone == nil || strings.TrimSpace(*t.Telefone) == "" {
+			return "", BroadcastSkipNoPhone
+		}
+		return strings.TrimSpace(*t.Telefone), ""
+	}
+}
@@ -0,0 +1,49 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da validação, personalização e limite de envio do envio em massa
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBroadcastRequest_Validate(t *testing.T) {
+	cases := []struct {
+		name string
+		req  BroadcastRequest
+		want error
+	}{
+		{"whatsapp sem assunto", BroadcastRequest{Channel: " WhatsApp ", Template: "Olá {{nome}}"}, nil},
+		{"e-mail sem assunto", BroadcastRequest{Channel: "email", Template: "Olá"}, ErrBroadcastSubjectInvalid},
+		{"canal push", BroadcastRequest{Channel: "push", Template: "Olá"}, ErrBroadcastChannelInvalid},
+		{"modelo vazio", BroadcastRequest{Channel: "whatsapp", Template: "  "}, ErrBroadcastTemplateInvalid},
+		{"variável inválida", BroadcastRequest{Channel: "whatsapp", Template: "Olá {{Nome Completo}}"}, ErrBroadcastVariableInvalid},
+		{"variável extra inválida", BroadcastRequest{Channel: "whatsapp", Template: "Olá",
+			Variables: map[string]map[string]string{"p1": {"X-Y": "1"}}}, ErrBroadcastVariableInvalid},
+	}
+	for _, c := range cases {
+		if err := c.req.Validate(); !errors.Is(err, c.want) {
+			t.Errorf("%s: err = %v, want %v", c.name, err, c.want)
+		}
+	}
+}
+
+func TestRenderBroadcastTemplate(t *testing.T) {
+	got := RenderBroadcastTemplate("Olá {{ nome }}, saldo {{saldo}}{{desconhecida}}.", map[string]string{"nome": "Ana", "saldo": "R$ 10,00"})
+	if want := "Olá Ana, saldo R$ 10,00."; got != want {
+		t.Fatalf("render = %q, want %q", got, want)
+	}
+}
+
+func TestBroadcastThrottle_Interval(t *testing.T) {
+	if got := (BroadcastThrottle{PerMinute: 20}).Interval(); got != 3*time.Second {
+		t.Fatalf("intervalo = %v, want 3s", got)
+	}
+	if got := (BroadcastThrottle{}).Interval(); got != 0 {
+		t.Fatalf("sem limite: intervalo = %v, want 0", got)
+	}
+}
@@ -0,0 +1,83 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos do cadastro de categorias (rf_categories) e uso por categoria
+// Data: 18-10-2026
+
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Limites do cadastro de categorias (espelham os CHECKs da migração 021)
+const (
+	MaxCategoryNameLen = 100
+	MaxCategoryIconLen = 50
+)
+
+var categoryColorRe = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// Category categoria do usuário; receitas referenciam pelo nome (rf_incomes.categoria)
+type Category struct {
+	ID        uuid.UUID      `json:"id" db:"id"`
+	OwnerID   uuid.UUID      `json:"owner_id" db:"owner_id"`
+	Nome      string         `json:"nome" db:"nome"`
+	Cor       *string        `json:"cor" db:"cor"`
+	Icone     *string        `json:"icone" db:"icone"`
+	Uso       *CategoryUsage `json:"uso,omitempty"`
+	CreatedAt *time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt *time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// CategoryUsage uso da categoria pelas receitas ativas (painel)
+type CategoryUsage struct {
+	Receitas   int   `json:"receitas"`
+	Abertas    int   `json:"abertas"`
+	ValorTotal Money `json:"valor_total"`
+}
+
+// CategoryRequest dados de entrada para criar/atualizar categoria.
+// Docstring: alterar o nome renomeia a categoria nas receitas, modelos e regras do usuário.
+type CategoryRequest struct {
+	Nome  string  `json:"nome" validate:"required"`
+	Cor   *string `json:"cor"`
+	Icone *string `json:"icone"`
+}
+
+// Validate valida e normaliza os dados da categoria; cor é gravada em maiúsculas
+func (req *CategoryRequest) Validate() error {
+	req.Nome = strings.TrimSpace(req.Nome)
+	if req.Nome == "" {
+		return ErrCategoryNameRequired
+	}
+	if utf8.RuneCountInString(req.Nome) > MaxCategoryNameLen {
+		return ErrCategoryNameTooLong
+	}
+	if req.Cor != nil {
+		cor := strings.TrimSpace(*req.Cor)
+		if cor == "" {
+			req.Cor = nil
+		} else if !categoryColorRe.MatchString(cor) {
+			return ErrInvalidCategoryColor
+		} else {
+			cor = strings.ToUpper(cor)
+			req.Cor = &cor
+		}
+	}
+	if req.Icone != nil {
+		icone := strings.TrimSpace(*req.Icone)
+		if icone == "" {
+			req.Icone = nil
+		} else if utf8.RuneCountInString(icone) > MaxCategoryIconLen {
+			return ErrInvalidCategoryIcon
+		} else {
+			req.Icone = &icone
+		}
+	}
+	return nil
+}
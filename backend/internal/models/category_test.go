@@ -0,0 +1,41 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes de validação do cadastro de categorias
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCategoryRequestValidate(t *testing.T) {
+	strp := func(s string) *string { return &s }
+	cases := []struct {
+		name string
+		req  CategoryRequest
+		err  error
+	}{
+		{"nome vazio", CategoryRequest{Nome: "  "}, ErrCategoryNameRequired},
+		{"nome longo", CategoryRequest{Nome: strings.Repeat("a", MaxCategoryNameLen+1)}, ErrCategoryNameTooLong},
+		{"cor inválida", CategoryRequest{Nome: "Aluguel", Cor: strp("verde")}, ErrInvalidCategoryColor},
+		{"ícone longo", CategoryRequest{Nome: "Aluguel", Icone: strp(strings.Repeat("x", MaxCategoryIconLen+1))}, ErrInvalidCategoryIcon},
+		{"válida", CategoryRequest{Nome: " Aluguel ", Cor: strp("#00ff7f"), Icone: strp(" ")}, nil},
+	}
+	for _, c := range cases {
+		err := c.req.Validate()
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: err = %v, esperado %v", c.name, err, c.err)
+		}
+	}
+
+	req := CategoryRequest{Nome: " Aluguel ", Cor: strp("#00ff7f"), Icone: strp(" ")}
+	if err := req.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if req.Nome != "Aluguel" || *req.Cor != "#00FF7F" || req.Icone != nil {
+		t.Fatalf("normalização inesperada: %+v", req)
+	}
+}
@@ -0,0 +1,102 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Reajuste anual de contratos por índice (IGP-M/IPCA) aplicado às receitas futuras
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Erros do reajuste de contratos
+var (
+	ErrReadjustIndexInvalid     = errors.New("índice de reajuste inválido (use igpm ou ipca)")
+	ErrReadjustStartInvalid     = errors.New("a_partir_de inválido (use AAAA-MM)")
+	ErrReadjustPeriodInvalid    = errors.New("ate inválido (use AAAA-MM anterior a a_partir_de)")
+	ErrReadjustIndexUnavailable = errors.New("índice ainda não publicado para todos os meses do período")
+	ErrReadjustAlreadyApplied   = errors.New("contrato já reajustado a partir desta competência")
+	ErrReadjustContractValue    = errors.New("contrato sem valor mensal para reajustar")
+)
+
+// ReadjustPeriodMonths meses acumulados no reajuste anual
+const ReadjustPeriodMonths = 12
+
+// ContractReadjustRequest pedido de reajuste.
+// Docstring: APartirDe é a primeira competência com o novo valor (padrão: o próximo mês); o índice
+// é acumulado nos 12 meses que terminam em Ate (padrão: o mês anterior a APartirDe). Acumulado
+// negativo só reduz o valor com PermitirReducao. DryRun calcula sem gravar.
+type ContractReadjustRequest struct {
+	Indice          string `json:"indice"`
+	APartirDe       string `json:"a_partir_de"`
+	Ate             string `json:"ate"`
+	PermitirReducao bool   `json:"permitir_reducao"`
+	DryRun          bool   `json:"dry_run"`
+}
+
+// ReadjustPeriod valida o pedido e devolve a competência inicial e o período do índice
+// (primeiros dias dos meses, UTC); nextMonth é o padrão de a_partir_de
+func (req *ContractReadjustRequest) ReadjustPeriod(nextMonth time.Time) (start, from, to time.Time, err error) {
+	req.Indice = strings.ToLower(strings.TrimSpace(req.Indice))
+	if !MonthlyRate(req.Indice) {
+		return start, from, to, ErrReadjustIndexInvalid
+	}
+	start = nextMonth
+	if strings.TrimSpace(req.APartirDe) != "" {
+		if start, err = ParseReportCompetencia(req.APartirDe); err != nil {
+			return start, from, to, ErrReadjustStartInvalid
+		}
+	}
+	to = start.AddDate(0, -1, 0)
+	if strings.TrimSpace(req.Ate) != "" {
+		if to, err = ParseReportCompetencia(req.Ate); err != nil || !to.Before(start) {
+			return start, from, to, ErrReadjustPeriodInvalid
+		}
+	}
+	from = to.AddDate(0, -(ReadjustPeriodMonths - 1), 0)
+	return start, from, to, nil
+}
+
+// ReadjustedValue aplica o percentual ao valor, arredondando aos centavos (metade para longe do zero, como round() do Postgres)
+func ReadjustedValue(valor Money, percentual float64) Money {
+	return Money(math.Round(float64(valor) * (1 + percentual/100)))
+}
+
+// ContractReadjustment reajuste calculado (e gravado, fora do dry run) de um contrato.
+// Docstring: Acumulado é a variação do índice no período; Percentual o aplicado (zero quando o
+// acumulado é negativo sem PermitirReducao). As receitas atualizadas são as do contrato a partir de
+// APartirDe ainda sem pagamento (pendentes ou vencidas); os modelos de receita do contrato também.
+type ContractReadjustment struct {
+	ID                  uuid.UUID  `json:"id"`
+	OwnerID             uuid.UUID  `json:"owner_id"`
+	ContractID          uuid.UUID  `json:"contract_id"`
+	Indice              string     `json:"indice"`
+	PeriodoInicio       time.Time  `json:"-"`
+	PeriodoFim          time.Time  `json:"-"`
+	Acumulado           float64    `json:"acumulado"`
+	Percentual          float64    `json:"percentual"`
+	ValorAnterior       Money      `json:"valor_anterior"`
+	ValorNovo           Money      `json:"valor_novo"`
+	APartirDe           time.Time  `json:"-"`
+	ReceitasAtualizadas int        `json:"receitas_atualizadas"`
+	ModelosAtualizados  int        `json:"modelos_atualizados"`
+	DryRun              bool       `json:"dry_run"`
+	CreatedAt           *time.Time `json:"created_at"`
+}
+
+// MarshalJSON expõe período e competência inicial como AAAA-MM
+func (c ContractReadjustment) MarshalJSON() ([]byte, error) {
+	type alias ContractReadjustment
+	return json.Marshal(struct {
+		alias
+		PeriodoInicio string `json:"periodo_inicio"`
+		PeriodoFim    string `json:"periodo_fim"`
+		APartirDe     string `json:"a_partir_de"`
+	}{alias(c), c.PeriodoInicio.Format("2006-01"), c.PeriodoFim.Format("2006-01"), c.APartirDe.Format("2006-01")})
+}
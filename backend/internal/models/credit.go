@@ -0,0 +1,106 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Créditos gerados por pagamentos acima do saldo (rf_credits) e sua aplicação em receitas
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Modos de tratamento de pagamento acima do saldo (PaymentRequest.Overpayment)
+const (
+	OverpaymentReject = "reject" // padrão: o pagamento é recusado
+	OverpaymentCredit = "credit" // o saldo é quitado e o excedente vira crédito do usuário
+)
+
+// CreditPaymentMethod método dos pagamentos gerados pela aplicação de um crédito
+const CreditPaymentMethod = "credito"
+
+// Erros de créditos
+var (
+	ErrInvalidOverpaymentMode = errors.New("modo de excedente inválido (use reject ou credit)")
+	ErrIncomeAlreadyPaid      = errors.New("receita já quitada: não há saldo para o pagamento")
+	ErrCreditNotFound         = errors.New("crédito não encontrado")
+	ErrCreditExhausted        = errors.New("crédito sem saldo disponível")
+	ErrCreditAmountInvalid    = errors.New("valor a aplicar deve ser maior que zero e até o saldo do crédito")
+	ErrCreditPayerMismatch    = errors.New("crédito de outro pagador não pode ser aplicado a esta receita")
+	ErrCreditAlreadyApplied   = errors.New("o crédito gerado por este pagamento já foi aplicado: desfaça antes os pagamentos com esse crédito")
+	ErrCreditPaymentLocked    = errors.New("pagamento com crédito não pode ter valor ou método alterado: estorne e aplique o crédito de novo")
+)
+
+// IsCreditPayment indica se o método do pagamento é a aplicação de um crédito
+func IsCreditPayment(metodo *string) bool {
+	return metodo != nil && *metodo == CreditPaymentMethod
+}
+
+// Credit crédito do usuário (rf_credits); Saldo é o que ainda pode ser aplicado
+type Credit struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	OwnerID         uuid.UUID  `json:"owner_id" db:"owner_id"`
+	PayerID         *uuid.UUID `json:"payer_id" db:"payer_id"`
+	SourceIncomeID  *uuid.UUID `json:"source_income_id" db:"source_income_id"`
+	SourcePaymentID *uuid.UUID `json:"source_payment_id" db:"source_payment_id"`
+	Valor           Money      `json:"valor" db:"valor"`
+	Saldo           Money      `json:"saldo" db:"saldo"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreditApplication aplicação de um crédito em uma receita (rf_credit_applications)
+type CreditApplication struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	OwnerID   uuid.UUID `json:"owner_id" db:"owner_id"`
+	CreditID  uuid.UUID `json:"credit_id" db:"credit_id"`
+	IncomeID  uuid.UUID `json:"income_id" db:"income_id"`
+	PaymentID uuid.UUID `json:"payment_id" db:"payment_id"`
+	Valor     Money     `json:"valor" db:"valor"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreditApplyRequest pedido de aplicação; sem valor aplica o menor entre o saldo do crédito e o da receita
+type CreditApplyRequest struct {
+	IncomeID uuid.UUID `json:"income_id"`
+	Valor    *Money    `json:"valor"`
+}
+
+// Validate valida o pedido de aplicação
+func (req *CreditApplyRequest) Validate() error {
+	if req.IncomeID == uuid.Nil {
+		return ErrIncomeIDRequired
+	}
+	if req.Valor != nil && *req.Valor <= 0 {
+		return ErrCreditAmountInvalid
+	}
+	return nil
+}
+
+// CreditApplyResponse resultado da aplicação com o crédito e a receita atualizados
+type CreditApplyResponse struct {
+	Application CreditApplication `json:"application"`
+	Credit      Credit            `json:"credit"`
+	Income      Income            `json:"income"`
+}
+
+// CreditApplyAmount valor efetivamente aplicado (pedido ou o menor entre os saldos).
+// Docstring: ErrIncomeAlreadyPaid sem saldo na receita, ErrCreditAmountInvalid quando o pedido
+// excede o saldo do crédito e ErrInsufficientAmount quando excede o da receita.
+func CreditApplyAmount(requested *Money, creditSaldo, incomeSaldo Money) (Money, error) {
+	if incomeSaldo <= 0 {
+		return 0, ErrIncomeAlreadyPaid
+	}
+	if requested == nil {
+		return min(creditSaldo, incomeSaldo), nil
+	}
+	if *requested <= 0 || *requested > creditSaldo {
+		return 0, ErrCreditAmountInvalid
+	}
+	if *requested > incomeSaldo {
+		return 0, ErrInsufficientAmount
+	}
+	return *requested, nil
+}
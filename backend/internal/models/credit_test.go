@@ -0,0 +1,48 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do modo de excedente e do valor aplicado de créditos
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPaymentRequest_ValidatesOverpaymentMode(t *testing.T) {
+	req := &PaymentRequest{IncomeID: uuid.New(), Valor: NewMoney(10), Overpayment: OverpaymentCredit}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("modo crédito: %v", err)
+	}
+	req.Overpayment = "refund"
+	if err := req.Validate(); !errors.Is(err, ErrInvalidOverpaymentMode) {
+		t.Fatalf("modo desconhecido: err = %v", err)
+	}
+}
+
+func TestCreditApplyAmount(t *testing.T) {
+	m := func(v float64) *Money { x := NewMoney(v); return &x }
+	cases := []struct {
+		name           string
+		requested      *Money
+		credit, income Money
+		want           Money
+		wantErr        error
+	}{
+		{"sem valor: saldo do crédito", nil, NewMoney(30), NewMoney(100), NewMoney(30), nil},
+		{"sem valor: saldo da receita", nil, NewMoney(300), NewMoney(100), NewMoney(100), nil},
+		{"valor pedido", m(20), NewMoney(30), NewMoney(100), NewMoney(20), nil},
+		{"acima do crédito", m(40), NewMoney(30), NewMoney(100), 0, ErrCreditAmountInvalid},
+		{"acima da receita", m(40), NewMoney(50), NewMoney(30), 0, ErrInsufficientAmount},
+		{"receita quitada", nil, NewMoney(50), 0, 0, ErrIncomeAlreadyPaid},
+	}
+	for _, c := range cases {
+		got, err := CreditApplyAmount(c.requested, c.credit, c.income)
+		if !errors.Is(err, c.wantErr) || got != c.want {
+			t.Errorf("%s: got %v, %v; esperado %v, %v", c.name, got, err, c.want, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Série temporal do dashboard (previsto, recebido, vencido, despesas e resultado por período)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Granularidades da série do dashboard
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+var (
+	ErrDashboardGranularity = errors.New("granularidade inválida (use day, week ou month)")
+	ErrDashboardRange       = errors.New("período inválido")
+)
+
+// dashboardMaxPoints limite de períodos por granularidade (um ano de dias, cinco de semanas, dez de meses)
+var dashboardMaxPoints = map[string]int{GranularityDay: 366, GranularityWeek: 260, GranularityMonth: 120}
+
+// DashboardSeriesQuery intervalo [From, To] (datas, inclusive) e granularidade da série
+type DashboardSeriesQuery struct {
+	From        time.Time
+	To          time.Time
+	Granularity string
+}
+
+// Validate confere a granularidade, a ordem das datas e o número de períodos
+func (q *DashboardSeriesQuery) Validate() error {
+	limit, ok := dashboardMaxPoints[q.Granularity]
+	if !ok {
+		return ErrDashboardGranularity
+	}
+	if q.To.Before(q.From) {
+		return fmt.Errorf("%w: from deve ser anterior a to", ErrDashboardRange)
+	}
+	if n := q.Points(); n > limit {
+		return fmt.Errorf("%w: no máximo %d períodos com granularidade %s (pedidos %d)", ErrDashboardRange, limit, q.Granularity, n)
+	}
+	return nil
+}
+
+// Points número de períodos cobertos pelo intervalo
+func (q *DashboardSeriesQuery) Points() int {
+	from, to := PeriodStart(q.From, q.Granularity), PeriodStart(q.To, q.Granularity)
+	switch q.Granularity {
+	case GranularityMonth:
+		return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+	case GranularityWeek:
+		return int(to.Sub(from).Hours()/(24*7)) + 1
+	}
+	return int(to.Sub(from).Hours()/24) + 1
+}
+
+// PeriodStart início do período que contém a data (semanas começam na segunda, como date_trunc)
+func PeriodStart(d time.Time, granularity string) time.Time {
+	d = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	switch granularity {
+	case GranularityMonth:
+		return d.AddDate(0, 0, 1-d.Day())
+	case GranularityWeek:
+		return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+	}
+	return d
+}
+
+// PeriodLabel rótulo do período: "2026-10", "2026-W42" (semana ISO) ou "2026-10-18"
+func PeriodLabel(start time.Time, granularity string) string {
+	switch granularity {
+	case GranularityMonth:
+		return start.Format("2006-01")
+	case GranularityWeek:
+		y, w := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w)
+	}
+	return start.Format("2006-01-02")
+}
+
+// DashboardPoint valores de um período.
+// Docstring: Expected soma as receitas com vencimento no período (sem vencimento, pela competência;
+// canceladas fora); Received os pagamentos recebidos no período; Overdue o saldo ainda em aberto
+// das receitas do período já vencidas. Expenses soma as despesas do período pela mesma data de
+// referência e ExpensesPaid as pagas no período; Net é Received - ExpensesPaid (regime de caixa).
+// Os acumulados somam desde o início da série.
+type DashboardPoint struct {
+	Period             string    `json:"period"`
+	Start              time.Time `json:"start"`
+	Expected           Money     `json:"expected"`
+	Received           Money     `json:"received"`
+	Overdue            Money     `json:"overdue"`
+	ExpectedCumulative Money     `json:"expected_cumulative"`
+	ReceivedCumulative Money     `json:"received_cumulative"`
+	Expenses           Money     `json:"expenses"`
+	ExpensesPaid       Money     `json:"expenses_paid"`
+	Net                Money     `json:"net"`
+	NetCumulative      Money     `json:"net_cumulative"`
+}
+
+// DashboardSeries série do dashboard com os totais do intervalo
+type DashboardSeries struct {
+	Granularity  string           `json:"granularity"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	Points       []DashboardPoint `json:"points"`
+	Expected     Money            `json:"expected"`
+	Received     Money            `json:"received"`
+	Overdue      Money            `json:"overdue"`
+	Expenses     Money            `json:"expenses"`
+	ExpensesPaid Money            `json:"expenses_paid"`
+	Net          Money            `json:"net"`
+}
+
+// NewDashboardSeries rotula os períodos e soma os totais
+func NewDashboardSeries(q *DashboardSeriesQuery, points []DashboardPoint) *DashboardSeries {
+	s := &DashboardSeries{Granularity: q.Granularity, From: q.From.Format("2006-01-02"), To: q.To.Format("2006-01-02"), Points: points}
+	if s.Points == nil {
+		s.Points = []DashboardPoint{}
+	}
+	for k := range s.Points {
+		p := &s.Points[k]
+		p.Period = PeriodLabel(p.Start, q.Granularity)
+		s.Expected += p.Expected
+		s.Received += p.Received
+		s.Overdue += p.Overdue
+		s.Expenses += p.Expenses
+		s.ExpensesPaid += p.ExpensesPaid
+	}
+	s.Net = s.Received - s.ExpensesPaid
+	return s
+}
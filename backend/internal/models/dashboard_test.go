@@ -0,0 +1,52 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes dos períodos da série do dashboard
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDashboardPeriods(t *testing.T) {
+	d := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC) // domingo
+	if got := PeriodStart(d, GranularityWeek); !got.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("início da semana = %v", got)
+	}
+	if got := PeriodStart(d, GranularityMonth); !got.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("início do mês = %v", got)
+	}
+	for gran, want := range map[string]string{GranularityDay: "2026-10-12", GranularityWeek: "2026-W42", GranularityMonth: "2026-10"} {
+		if got := PeriodLabel(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), gran); got != want {
+			t.Errorf("%s: rótulo = %s, esperado %s", gran, got, want)
+		}
+	}
+
+	q := DashboardSeriesQuery{From: time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC), To: d, Granularity: GranularityMonth}
+	if q.Points() != 12 || q.Validate() != nil {
+		t.Fatalf("períodos = %d, %v", q.Points(), q.Validate())
+	}
+	q.Granularity = GranularityWeek
+	if q.Points() != 48 {
+		t.Fatalf("semanas = %d", q.Points())
+	}
+	q.Granularity = GranularityDay
+	if err := q.Validate(); err != nil {
+		t.Fatalf("333 dias: %v", err)
+	}
+	q.From = q.From.AddDate(-1, 0, 0)
+	if err := q.Validate(); !errors.Is(err, ErrDashboardRange) {
+		t.Fatalf("mais de um ano de dias: err = %v", err)
+	}
+	q.Granularity = "year"
+	if err := q.Validate(); !errors.Is(err, ErrDashboardGranularity) {
+		t.Fatalf("err = %v", err)
+	}
+	q = DashboardSeriesQuery{From: d, To: d.AddDate(0, 0, -1), Granularity: GranularityDay}
+	if err := q.Validate(); !errors.Is(err, ErrDashboardRange) {
+		t.Fatalf("intervalo invertido: err = %v", err)
+	}
+}
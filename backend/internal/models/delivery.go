@@ -0,0 +1,129 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos da fila de entregas assíncronas (rf_deliveries) e saúde por destino
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Canais de entrega suportados pela fila
+const (
+	DeliveryChannelEmail    = "email"
+	DeliveryChannelWebhook  = "webhook"
+	DeliveryChannelPush     = "push"
+	DeliveryChannelWhatsApp = "whatsapp"
+)
+
+// Status de uma entrega
+const (
+	DeliveryStatusPending    = "pending"
+	DeliveryStatusProcessing = "processing"
+	DeliveryStatusDelivered  = "delivered"
+	DeliveryStatusDead       = "dead"
+)
+
+// Erros da fila de entregas
+var (
+	ErrDeliveryNotFound       = errors.New("entrega não encontrada")
+	ErrDeliveryNotDead        = errors.New("apenas entregas em dead-letter podem ser reenfileiradas")
+	ErrDeliveryChannelInvalid = errors.New("canal de entrega inválido")
+	ErrDestinationRequired    = errors.New("destino da entrega é obrigatório")
+	ErrDestinationNotFound    = errors.New("destino não encontrado")
+)
+
+// Delivery representa uma entrega (notificação ou webhook) na fila rf_deliveries.
+// Docstring: cada tentativa incrementa Attempts; ao esgotar tentativas ou o SLA a
+// entrega vai para dead-letter (Status = dead) e só volta à fila via requeue administrativo.
+type Delivery struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	OwnerID       uuid.UUID       `json:"owner_id" db:"owner_id"`
+	Channel       string          `json:"channel" db:"channel"`
+	Destination   string          `json:"destination" db:"destination"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Status        string          `json:"status" db:"status"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	MaxAttempts   int             `json:"max_attempts" db:"max_attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	SLADeadline   *time.Time      `json:"sla_deadline" db:"sla_deadline"`
+	LastError     *string         `json:"last_error" db:"last_error"`
+	LastAttemptAt *time.Time      `json:"last_attempt_at" db:"last_attempt_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at" db:"delivered_at"`
+	DeadAt        *time.Time      `json:"dead_at" db:"dead_at"`
+	DeadReason    *string         `json:"dead_reason" db:"dead_reason"`
+	CreatedAt     *time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt     *time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// DeliveryDestination agrega a saúde de um destino (e-mail, URL de webhook ou assinatura push).
+// Docstring: FirstFailureAt marca o início da sequência atual de falhas e é zerado no
+// primeiro sucesso; DeadCount é calculado na consulta a partir de rf_deliveries.
+type DeliveryDestination struct {
+	OwnerID             uuid.UUID  `json:"owner_id" db:"owner_id"`
+	Channel             string     `json:"channel" db:"channel"`
+	Destination         string     `json:"destination" db:"destination"`
+	TotalSuccess        int64      `json:"total_success" db:"total_success"`
+	TotalFailures       int64      `json:"total_failures" db:"total_failures"`
+	ConsecutiveFailures int        `json:"consecutive_failures" db:"consecutive_failures"`
+	FirstFailureAt      *time.Time `json:"first_failure_at" db:"first_failure_at"`
+	LastFailureAt       *time.Time `json:"last_failure_at" db:"last_failure_at"`
+	LastSuccessAt       *time.Time `json:"last_success_at" db:"last_success_at"`
+	LastError           *string    `json:"last_error" db:"last_error"`
+	DisabledAt          *time.Time `json:"disabled_at" db:"disabled_at"`
+	DisabledReason      *string    `json:"disabled_reason" db:"disabled_reason"`
+	DeadCount           int        `json:"dead_count" db:"dead_count"`
+}
+
+// DeliveryFilter representa os filtros das listagens de entregas (dead-letter e log de webhooks)
+type DeliveryFilter struct {
+	OwnerID     *uuid.UUID `json:"owner_id"`
+	Channel     string     `json:"channel"`
+	Destination string     `json:"destination"`
+	Status      string     `json:"status"`
+	Page        int        `json:"page"`
+	PerPage     int        `json:"per_page"`
+}
+
+// DeliveryListResponse resposta paginada de entregas
+type DeliveryListResponse struct {
+	Items      []Delivery `json:"items"`
+	Total      int        `json:"total"`
+	Page       int        `json:"page"`
+	PerPage    int        `json:"per_page"`
+	TotalPages int        `json:"total_pages"`
+}
+
+// ValidDeliveryStatus verifica se o status é conhecido
+func ValidDeliveryStatus(status string) bool {
+	switch status {
+	case DeliveryStatusPending, DeliveryStatusProcessing, DeliveryStatusDelivered, DeliveryStatusDead:
+		return true
+	}
+	return false
+}
+
+// ValidDeliveryChannel verifica se o canal é suportado
+func ValidDeliveryChannel(channel string) bool {
+	switch channel {
+	case DeliveryChannelEmail, DeliveryChannelWebhook, DeliveryChannelPush, DeliveryChannelWhatsApp:
+		return true
+	}
+	return false
+}
+
+// SetDefaults define valores padrão para o filtro
+func (f *DeliveryFilter) SetDefaults() {
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+	if f.PerPage <= 0 || f.PerPage > 100 {
+		f.PerPage = 20
+	}
+}
@@ -0,0 +1,95 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Normalização e validação de documentos brasileiros (CPF/CNPJ)
+// Data: 18-10-2026
+
+package models
+
+import "strings"
+
+// NormalizeDocument remove pontuação e mantém apenas os dígitos do documento
+func NormalizeDocument(doc string) string {
+	var b strings.Builder
+	for _, r := range doc {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ValidDocument verifica se o documento (já normalizado) é um CPF ou CNPJ válido
+func ValidDocument(doc string) bool {
+	switch len(doc) {
+	case 11:
+		return ValidCPF(doc)
+	case 14:
+		return ValidCNPJ(doc)
+	}
+	return false
+}
+
+// ValidCPF valida os dígitos verificadores de um CPF com 11 dígitos
+func ValidCPF(cpf string) bool {
+	if len(cpf) != 11 || allSameDigit(cpf) {
+		return false
+	}
+	d := digits(cpf)
+	for _, n := range []int{9, 10} {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += d[i] * (n + 1 - i)
+		}
+		check := (sum * 10) % 11
+		if check == 10 {
+			check = 0
+		}
+		if check != d[n] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidCNPJ valida os dígitos verificadores de um CNPJ com 14 dígitos
+func ValidCNPJ(cnpj string) bool {
+	if len(cnpj) != 14 || allSameDigit(cnpj) {
+		return false
+	}
+	d := digits(cnpj)
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for _, n := range []int{12, 13} {
+		sum := 0
+		w := weights[13-n:]
+		for i := 0; i < n; i++ {
+			sum += d[i] * w[i]
+		}
+		check := sum % 11
+		if check < 2 {
+			check = 0
+		} else {
+			check = 11 - check
+		}
+		if check != d[n] {
+			return false
+		}
+	}
+	return true
+}
+
+func digits(s string) []int {
+	out := make([]int, len(s))
+	for i, r := range s {
+		out[i] = int(r - '0')
+	}
+	return out
+}
+
+func allSameDigit(s string) bool {
+	for i := 1; i < len(s); i++ {
+		if s[i] != s[0] {
+			return false
+		}
+	}
+	return true
+}
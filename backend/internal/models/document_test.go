@@ -0,0 +1,47 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes de normalização e validação de CPF/CNPJ e do PayerRequest
+// Data: 18-10-2026
+
+package models
+
+import "testing"
+
+func TestValidDocument(t *testing.T) {
+	cases := []struct {
+		in   string
+		want bool
+	}{
+		{"529.982.247-25", true},
+		{"52998224724", false},
+		{"111.111.111-11", false},
+		{"11.222.333/0001-81", true},
+		{"11.222.333/0001-80", false},
+		{"123", false},
+	}
+	for _, c := range cases {
+		if got := ValidDocument(NormalizeDocument(c.in)); got != c.want {
+			t.Fatalf("ValidDocument(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestPayerRequestValidate_NormalizesDocument(t *testing.T) {
+	doc := "529.982.247-25"
+	uf := "sp"
+	req := &PayerRequest{Nome: "  Maria  ", Documento: &doc, UF: &uf}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate err: %v", err)
+	}
+	if req.Nome != "Maria" || *req.Documento != "52998224725" || *req.UF != "SP" {
+		t.Fatalf("normalização inesperada: %+v", req)
+	}
+
+	bad := "000.000.000-01"
+	if err := (&PayerRequest{Nome: "X", Documento: &bad}).Validate(); err != ErrInvalidDocument {
+		t.Fatalf("err = %v, want ErrInvalidDocument", err)
+	}
+	if err := (&PayerRequest{}).Validate(); err != ErrPayerNameRequired {
+		t.Fatalf("err = %v, want ErrPayerNameRequired", err)
+	}
+}
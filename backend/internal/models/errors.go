@@ -19,6 +19,28 @@ var (
 	ErrInvalidDateFormat   = errors.New("formato de data inválido")
 	ErrUnauthorized        = errors.New("não autorizado")
 	ErrDuplicatePayment    = errors.New("pagamento duplicado")
+	ErrIncomeVersionRequired = errors.New("versão da receita é obrigatória (If-Match ou version)")
+	ErrIncomeVersionConflict = errors.New("a receita foi alterada por outra pessoa ou dispositivo")
+)
+
+// IncomeVersionConflictError conflito de versão com o estado atual da receita no servidor
+type IncomeVersionConflictError struct {
+	Current *Income
+}
+
+func (e *IncomeVersionConflictError) Error() string { return ErrIncomeVersionConflict.Error() }
+
+// Unwrap permite errors.Is(err, ErrIncomeVersionConflict)
+func (e *IncomeVersionConflictError) Unwrap() error { return ErrIncomeVersionConflict }
+
+// Erros de validação para pagadores
+var (
+	ErrPayerNotFound      = errors.New("pagador não encontrado")
+	ErrPayerNameRequired  = errors.New("nome do pagador é obrigatório")
+	ErrInvalidDocument    = errors.New("documento inválido: informe um CPF ou CNPJ válido")
+	ErrInvalidEmail       = errors.New("e-mail inválido")
+	ErrInvalidUF          = errors.New("UF deve ter 2 letras")
+	ErrDuplicateDocument  = errors.New("já existe um pagador com este documento")
 )
 
 // Constantes para status de receitas
@@ -57,4 +79,88 @@ func GetValidStatuses() []string {
 		StatusVencido,
 		StatusCancelado,
 	}
-}
\ No newline at end of file
+}
+
+// Erros de numeração de recibos
+var (
+	ErrReceiptNumberConflict     = errors.New("número de recibo já utilizado")
+	ErrReceiptNumberNotMonotonic = errors.New("número de recibo deve ser maior que o último emitido")
+	ErrReceiptNumberImmutable    = errors.New("número do recibo não pode ser alterado")
+	ErrReceiptNumberInvalid      = errors.New("número do recibo deve ser positivo")
+	ErrInvalidNumberRange        = errors.New("intervalo de numeração inválido")
+	ErrJustificationRequired     = errors.New("justificativa é obrigatória")
+	ErrInvalidReceiptAdjustment  = errors.New("taxas e descontos não podem ser negativos")
+	ErrReceiptSnapshotImmutable  = errors.New("valores congelados do recibo não podem ser alterados")
+)
+
+// Erros das regras de categorização
+var (
+	ErrRuleNotFound          = errors.New("regra não encontrada")
+	ErrRuleNameRequired      = errors.New("nome da regra é obrigatório")
+	ErrRuleConditionRequired = errors.New("a regra precisa de ao menos uma condição")
+	ErrRuleActionRequired    = errors.New("a regra precisa definir categoria ou tags")
+	ErrRuleInvalidRange      = errors.New("valor mínimo maior que o valor máximo")
+)
+
+// Erros de modelos de receita
+var (
+	ErrTemplateNotFound     = errors.New("modelo de receita não encontrado")
+	ErrTemplateNameRequired = errors.New("nome do modelo é obrigatório")
+	ErrInvalidDueDay        = errors.New("dia de vencimento deve estar entre 1 e 31")
+)
+
+// Erros de modelos de recibo (identidade visual dos PDFs)
+var (
+	ErrReceiptTemplateNotFound = errors.New("modelo de recibo não encontrado")
+	ErrInvalidTemplateColor    = errors.New("cor inválida: use o formato #rrggbb")
+	ErrTemplateTextTooLong     = errors.New("texto do modelo excede o limite de caracteres")
+	ErrInvalidTemplateLogo     = errors.New("logotipo inválido: envie PNG ou JPEG de até 512 KB e 2000x2000 px")
+)
+
+// Erros de artefatos gerados
+var (
+	ErrInvalidArtifactKind        = errors.New("tipo de artefato inválido (use pdf ou qr)")
+	ErrInvalidArtifactContentType = errors.New("tipo de conteúdo incompatível com o artefato")
+	ErrArtifactEmpty              = errors.New("conteúdo do artefato vazio")
+	ErrArtifactTooLarge           = errors.New("artefato excede o tamanho máximo")
+	ErrArtifactTargetNotFound     = errors.New("recibo ou modelo do artefato não encontrado")
+	ErrArtifactNotFound           = errors.New("artefato não encontrado")
+)
+
+// Erros de preferências de notificação e resumo semanal
+var (
+	ErrNotificationSettingsNotFound = errors.New("preferências de notificação não encontradas")
+	ErrInvalidDigestChannel         = errors.New("canal do resumo inválido (use email ou push)")
+	ErrInvalidWeekday               = errors.New("dia da semana inválido (0 a 6)")
+	ErrInvalidUnsubscribeToken      = errors.New("link de descadastro inválido")
+)
+
+// Erros da matriz de preferências de notificação
+var (
+	ErrInvalidEventType           = errors.New("tipo de evento de notificação inválido")
+	ErrInvalidNotificationChannel = errors.New("canal de notificação inválido (use email, push ou whatsapp)")
+	ErrInvalidNotificationMode    = errors.New("modo de notificação inválido (use immediate, digest ou off)")
+	ErrInvalidPhone               = errors.New("telefone inválido")
+)
+
+// Erros da importação de receitas
+var (
+	ErrInvalidImportMapping = errors.New("mapeamento de colunas inválido")
+	ErrImportFileRequired   = errors.New("arquivo CSV é obrigatório")
+	ErrImportInvalidCSV     = errors.New("CSV inválido")
+	ErrImportEmpty          = errors.New("arquivo CSV sem linhas de dados")
+	ErrImportTooManyRows    = errors.New("arquivo CSV excede o limite de linhas")
+	ErrImportMissingColumn  = errors.New("coluna obrigatória ausente no CSV")
+	ErrImportHasErrors      = errors.New("importação com erros de validação; nada foi gravado")
+)
+
+// Erros do cadastro de categorias
+var (
+	ErrCategoryNotFound       = errors.New("categoria não encontrada")
+	ErrCategoryNameRequired   = errors.New("nome da categoria é obrigatório")
+	ErrCategoryNameTooLong    = errors.New("nome da categoria excede 100 caracteres")
+	ErrInvalidCategoryColor   = errors.New("cor inválida (use #RRGGBB)")
+	ErrInvalidCategoryIcon    = errors.New("ícone inválido (máximo de 50 caracteres)")
+	ErrDuplicateCategory      = errors.New("já existe uma categoria com este nome")
+	ErrCategoryReplaceInvalid = errors.New("categoria substituta inválida")
+)
@@ -0,0 +1,302 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Despesas (rf_expenses), anexos de documentos fiscais e estatísticas por competência
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Limites das despesas (espelham os CHECKs da migração 055)
+const (
+	MaxExpenseDescriptionLen = 200
+	MaxExpenseSupplierLen    = 200
+	MaxExpenseObsLen         = 1000
+	MaxExpenseFileNameLen    = 255
+)
+
+// MaxExpenseAttachmentSize limite de um documento anexado à despesa (10 MB)
+const MaxExpenseAttachmentSize = 10 << 20
+
+// Tipos de documento fiscal anexado
+const (
+	ExpenseDocNFe    = "nfe"
+	ExpenseDocNFSe   = "nfse"
+	ExpenseDocCupom  = "cupom"
+	ExpenseDocBoleto = "boleto"
+	ExpenseDocRecibo = "recibo"
+	ExpenseDocOutro  = "outro"
+)
+
+var expenseDocTypes = map[string]bool{
+	ExpenseDocNFe: true, ExpenseDocNFSe: true, ExpenseDocCupom: true,
+	ExpenseDocBoleto: true, ExpenseDocRecibo: true, ExpenseDocOutro: true,
+}
+
+// expenseAttachmentTypes tipos MIME aceitos nos anexos e a extensão gravada no Storage
+var expenseAttachmentTypes = map[string]string{
+	"application/pdf": "pdf",
+	"application/xml": "xml",
+	"text/xml":        "xml",
+	"image/jpeg":      "jpg",
+	"image/png":       "png",
+}
+
+// Erros das despesas
+var (
+	ErrExpenseNotFound              = errors.New("despesa não encontrada")
+	ErrExpenseDescriptionRequired   = errors.New("descrição da despesa é obrigatória")
+	ErrExpenseDescriptionTooLong    = errors.New("descrição da despesa deve ter até 200 caracteres")
+	ErrExpenseSupplierTooLong       = errors.New("fornecedor deve ter até 200 caracteres")
+	ErrExpenseObsTooLong            = errors.New("observação deve ter até 1000 caracteres")
+	ErrExpenseStatusInvalid         = errors.New("status inválido (use pendente, pago ou cancelado)")
+	ErrExpensePaidAtStatus          = errors.New("pago_em só pode ser informado em despesa paga")
+	ErrExpenseAttachmentNotFound    = errors.New("anexo não encontrado")
+	ErrExpenseAttachmentType        = errors.New("tipo de documento inválido (use nfe, nfse, cupom, boleto, recibo ou outro)")
+	ErrExpenseAttachmentContentType = errors.New("arquivo deve ser PDF, XML, JPEG ou PNG")
+	ErrExpenseAttachmentEmpty       = errors.New("arquivo vazio")
+	ErrExpenseAttachmentTooLarge    = errors.New("arquivo muito grande")
+)
+
+// Expense despesa do usuário.
+// Docstring: Status gravado é pendente, pago ou cancelado; nas leituras uma despesa pendente com
+// vencimento passado é exibida como "vencido" (DisplayStatus), como as receitas.
+type Expense struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	OwnerID     uuid.UUID  `json:"owner_id" db:"owner_id"`
+	Descricao   string     `json:"descricao" db:"descricao"`
+	Fornecedor  *string    `json:"fornecedor" db:"fornecedor"`
+	Categoria   *string    `json:"categoria" db:"categoria"`
+	Tags        []string   `json:"tags" db:"tags"`
+	Competencia string     `json:"competencia" db:"competencia"`
+	Valor       Money      `json:"valor" db:"valor"`
+	Status      string     `json:"status" db:"status"`
+	DueDate     *time.Time `json:"due_date" db:"due_date"`
+	PagoEm      *time.Time `json:"pago_em" db:"pago_em"`
+	Metodo      *string    `json:"metodo" db:"metodo"`
+	Obs         *string    `json:"obs" db:"obs"`
+	Anexos      int        `json:"anexos" db:"anexos"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt   *time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DisplayStatus status exibido na data local today (meia-noite UTC, como due_date)
+func (e *Expense) DisplayStatus(today time.Time) string {
+	if e.Status == StatusPendente && e.DueDate != nil && e.DueDate.Before(today) {
+		return StatusVencido
+	}
+	return e.Status
+}
+
+// ExpenseRequest dados de entrada para criar/atualizar despesa (PUT substitui todos os campos).
+// Docstring: pago_em marca a despesa como paga; status "pago" sem pago_em usa o momento do pedido.
+type ExpenseRequest struct {
+	Descricao   string   `json:"descricao" validate:"required"`
+	Fornecedor  *string  `json:"fornecedor"`
+	Categoria   *string  `json:"categoria"`
+	Tags        []string `json:"tags"`
+	Competencia string   `json:"competencia" validate:"required"`
+	Valor       Money    `json:"valor" validate:"required,gt=0"`
+	Status      string   `json:"status"`
+	DueDate     *string  `json:"due_date"` // RFC3339
+	PagoEm      *string  `json:"pago_em"`  // RFC3339
+	Metodo      *string  `json:"metodo"`
+	Obs         *string  `json:"obs"`
+}
+
+// Validate valida e normaliza a despesa
+func (req *ExpenseRequest) Validate() error {
+	req.Descricao = strings.TrimSpace(req.Descricao)
+	if req.Descricao == "" {
+		return ErrExpenseDescriptionRequired
+	}
+	if utf8.RuneCountInString(req.Descricao) > MaxExpenseDescriptionLen {
+		return ErrExpenseDescriptionTooLong
+	}
+	trimOptional(&req.Fornecedor)
+	if req.Fornecedor != nil && utf8.RuneCountInString(*req.Fornecedor) > MaxExpenseSupplierLen {
+		return ErrExpenseSupplierTooLong
+	}
+	trimOptional(&req.Categoria)
+	trimOptional(&req.Metodo)
+	trimOptional(&req.Obs)
+	if req.Obs != nil && utf8.RuneCountInString(*req.Obs) > MaxExpenseObsLen {
+		return ErrExpenseObsTooLong
+	}
+	req.Competencia = strings.TrimSpace(req.Competencia)
+	if req.Competencia == "" {
+		return ErrCompetenciaRequired
+	}
+	if _, ok := parseCompetencia(req.Competencia); !ok {
+		return ErrReportCompetenciaInvalid
+	}
+	if req.Valor <= 0 {
+		return ErrValorInvalid
+	}
+	trimOptional(&req.PagoEm)
+	if req.Status == "" {
+		req.Status = StatusPendente
+		if req.PagoEm != nil {
+			req.Status = StatusPago
+		}
+	}
+	if req.Status != StatusPendente && req.Status != StatusPago && req.Status != StatusCancelado {
+		return ErrExpenseStatusInvalid
+	}
+	if req.PagoEm != nil && req.Status != StatusPago {
+		return ErrExpensePaidAtStatus
+	}
+	for _, d := range []*string{req.DueDate, req.PagoEm} {
+		if d != nil && *d != "" {
+			if _, err := time.Parse(time.RFC3339, *d); err != nil {
+				return ErrInvalidDateFormat
+			}
+		}
+	}
+	req.Tags = normalizeTags(req.Tags)
+	return nil
+}
+
+// Apply grava os campos na despesa (chamar após Validate); now é o pagamento de "pago" sem data
+func (req *ExpenseRequest) Apply(e *Expense, now time.Time) {
+	e.Descricao, e.Fornecedor, e.Categoria, e.Tags = req.Descricao, req.Fornecedor, req.Categoria, req.Tags
+	e.Competencia, e.Valor, e.Status, e.Metodo, e.Obs = req.Competencia, req.Valor, req.Status, req.Metodo, req.Obs
+	e.DueDate = nil
+	if req.DueDate != nil && *req.DueDate != "" {
+		due, _ := time.Parse(time.RFC3339, *req.DueDate)
+		due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+		e.DueDate = &due
+	}
+	switch {
+	case req.PagoEm != nil:
+		pago, _ := time.Parse(time.RFC3339, *req.PagoEm)
+		e.PagoEm = &pago
+	case e.Status == StatusPago && e.PagoEm == nil:
+		e.PagoEm = &now
+	case e.Status != StatusPago:
+		e.PagoEm = nil
+	}
+}
+
+// ExpenseFilter filtros da listagem de despesas; Status aceita também "vencido"
+type ExpenseFilter struct {
+	Search      string     `json:"search"`
+	Status      string     `json:"status"`
+	Categoria   string     `json:"categoria"`
+	Competencia string     `json:"competencia"`
+	Tag         string     `json:"tag"`
+	DueDateFrom *time.Time `json:"due_date_from"`
+	DueDateTo   *time.Time `json:"due_date_to"`
+	SortField   string     `json:"sort_field"`
+	SortOrder   string     `json:"sort_order"`
+	Page        int        `json:"page"`
+	PerPage     int        `json:"per_page"`
+	Today       time.Time  `json:"-"` // data local do usuário, para "vencido"
+}
+
+// SetDefaults define valores padrão para o filtro
+func (f *ExpenseFilter) SetDefaults() {
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+	if f.PerPage <= 0 {
+		f.PerPage = 10
+	}
+	if f.PerPage > 100 {
+		f.PerPage = 100
+	}
+	if f.SortField == "" {
+		f.SortField = "created_at"
+	}
+	if f.SortOrder != "asc" && f.SortOrder != "desc" {
+		f.SortOrder = "desc"
+	}
+}
+
+// ExpenseResponse resposta paginada de despesas
+type ExpenseResponse struct {
+	Expenses   []Expense `json:"expenses"`
+	Total      int       `json:"total"`
+	Page       int       `json:"page"`
+	PerPage    int       `json:"per_page"`
+	TotalPages int       `json:"total_pages"`
+}
+
+// ExpenseCategoryTotal despesas de uma categoria na competência (nil = sem categoria)
+type ExpenseCategoryTotal struct {
+	Categoria  *string `json:"categoria"`
+	Quantidade int     `json:"quantidade"`
+	Total      Money   `json:"total"`
+	Pago       Money   `json:"pago"`
+}
+
+// ExpenseStats estatísticas das despesas de uma competência.
+// Docstring: Total soma as despesas da competência (canceladas fora); Pago as já pagas; EmAberto
+// as pendentes; Vencido as pendentes com vencimento passado (parte de EmAberto).
+type ExpenseStats struct {
+	Competencia  string                 `json:"competencia"`
+	Quantidade   int                    `json:"quantidade"`
+	Total        Money                  `json:"total"`
+	Pago         Money                  `json:"pago"`
+	EmAberto     Money                  `json:"em_aberto"`
+	Vencido      Money                  `json:"vencido"`
+	Canceladas   int                    `json:"canceladas"`
+	PorCategoria []ExpenseCategoryTotal `json:"por_categoria"`
+}
+
+// ExpenseAttachment documento fiscal anexado à despesa
+type ExpenseAttachment struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	OwnerID     uuid.UUID  `json:"owner_id" db:"owner_id"`
+	ExpenseID   uuid.UUID  `json:"expense_id" db:"expense_id"`
+	Tipo        string     `json:"tipo" db:"tipo"`
+	FileName    string     `json:"file_name" db:"file_name"`
+	FilePath    string     `json:"file_path" db:"file_path"`
+	ContentType string     `json:"content_type" db:"content_type"`
+	SizeBytes   int64      `json:"size_bytes" db:"size_bytes"`
+	SHA256      string     `json:"sha256" db:"sha256"`
+	CreatedAt   *time.Time `json:"created_at" db:"created_at"`
+}
+
+// ValidExpenseDocType confere o tipo de documento; vazio vale "outro"
+func ValidExpenseDocType(tipo string) (string, error) {
+	tipo = strings.ToLower(strings.TrimSpace(tipo))
+	if tipo == "" {
+		return ExpenseDocOutro, nil
+	}
+	if !expenseDocTypes[tipo] {
+		return "", ErrExpenseAttachmentType
+	}
+	return tipo, nil
+}
+
+// ExpenseAttachmentType tipo MIME sem parâmetros e extensão do arquivo, se aceito
+func ExpenseAttachmentType(contentType string) (mediaType, ext string, err error) {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	ext, ok := expenseAttachmentTypes[mediaType]
+	if !ok {
+		return "", "", ErrExpenseAttachmentContentType
+	}
+	return mediaType, ext, nil
+}
+
+// CleanAttachmentName nome exibido do anexo: sem diretórios e limitado a MaxExpenseFileNameLen
+func CleanAttachmentName(name, ext string) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, `\`, "/")))
+	if name == "" || name == "." || name == "/" {
+		name = "documento." + ext
+	}
+	if r := []rune(name); len(r) > MaxExpenseFileNameLen {
+		name = string(r[:MaxExpenseFileNameLen])
+	}
+	return name
+}
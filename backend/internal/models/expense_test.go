@@ -0,0 +1,86 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da validação das despesas, do status exibido e dos tipos de anexo
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExpenseRequest_ValidateAndApply(t *testing.T) {
+	forn, pago := "  Imobiliária  ", "2026-10-05T14:00:00Z"
+	req := ExpenseRequest{Descricao: " Aluguel da sala ", Fornecedor: &forn, Competencia: "10/2026", Valor: NewMoney(1200),
+		PagoEm: &pago, Tags: []string{" Fixo ", "fixo"}}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if req.Descricao != "Aluguel da sala" || *req.Fornecedor != "Imobiliária" || req.Status != StatusPago || len(req.Tags) != 1 {
+		t.Fatalf("normalizado = %+v", req)
+	}
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	var e Expense
+	req.Apply(&e, now)
+	if e.PagoEm == nil || !e.PagoEm.Equal(time.Date(2026, 10, 5, 14, 0, 0, 0, time.UTC)) {
+		t.Fatalf("pago_em = %v", e.PagoEm)
+	}
+	// "pago" sem data mantém o pagamento já gravado; voltar a pendente o apaga
+	again := ExpenseRequest{Descricao: "Aluguel", Competencia: "2026-10", Valor: NewMoney(1200), Status: StatusPago}
+	if err := again.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	again.Apply(&e, now)
+	if e.PagoEm == nil || e.PagoEm.Equal(now) {
+		t.Fatalf("pago_em deveria ser mantido: %v", e.PagoEm)
+	}
+	again.Status = StatusPendente
+	again.Apply(&e, now)
+	if e.PagoEm != nil {
+		t.Fatalf("pago_em = %v, esperado nil", e.PagoEm)
+	}
+
+	cases := []struct {
+		req  ExpenseRequest
+		want error
+	}{
+		{ExpenseRequest{Competencia: "2026-10", Valor: 1}, ErrExpenseDescriptionRequired},
+		{ExpenseRequest{Descricao: "x", Valor: 1}, ErrCompetenciaRequired},
+		{ExpenseRequest{Descricao: "x", Competencia: "outubro", Valor: 1}, ErrReportCompetenciaInvalid},
+		{ExpenseRequest{Descricao: "x", Competencia: "2026-10"}, ErrValorInvalid},
+		{ExpenseRequest{Descricao: "x", Competencia: "2026-10", Valor: 1, Status: StatusVencido}, ErrExpenseStatusInvalid},
+		{ExpenseRequest{Descricao: "x", Competencia: "2026-10", Valor: 1, Status: StatusCancelado, PagoEm: &pago}, ErrExpensePaidAtStatus},
+	}
+	for _, c := range cases {
+		if err := c.req.Validate(); !errors.Is(err, c.want) {
+			t.Errorf("Validate(%+v) = %v, esperado %v", c.req, err, c.want)
+		}
+	}
+}
+
+func TestExpense_DisplayStatusAndAttachmentTypes(t *testing.T) {
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	due := today.AddDate(0, 0, -1)
+	if s := (&Expense{Status: StatusPendente, DueDate: &due}).DisplayStatus(today); s != StatusVencido {
+		t.Fatalf("status = %s", s)
+	}
+	if s := (&Expense{Status: StatusPendente, DueDate: &today}).DisplayStatus(today); s != StatusPendente {
+		t.Fatalf("vence hoje: status = %s", s)
+	}
+
+	if ct, ext, err := ExpenseAttachmentType("text/xml; charset=utf-8"); err != nil || ct != "text/xml" || ext != "xml" {
+		t.Fatalf("xml = %s, %s, %v", ct, ext, err)
+	}
+	if _, _, err := ExpenseAttachmentType("application/zip"); !errors.Is(err, ErrExpenseAttachmentContentType) {
+		t.Fatalf("zip: err = %v", err)
+	}
+	if tipo, err := ValidExpenseDocType(""); err != nil || tipo != ExpenseDocOutro {
+		t.Fatalf("tipo vazio = %s, %v", tipo, err)
+	}
+	if name := CleanAttachmentName(`C:\notas\nf 123.xml`, "xml"); name != "nf 123.xml" {
+		t.Fatalf("nome = %q", name)
+	}
+}
@@ -0,0 +1,241 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Cálculo de encargos por atraso (multa e juros de mora) e simulação de pagamento
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFeePercentInvalid = errors.New("percentual de multa ou juros deve estar entre 0 e 100")
+	ErrGraceDaysInvalid  = errors.New("carência deve estar entre 0 e 90 dias")
+)
+
+// FeePolicy encargos por atraso de uma receita (do contrato, do usuário ou padrão do produto).
+// Docstring: com JurosDiaPercent > 0 os juros são diários e JurosMesPercent é ignorado.
+type FeePolicy struct {
+	MultaPercent    float64 `json:"multa_percent"`
+	JurosMesPercent float64 `json:"juros_mes_percent"`
+	JurosDiaPercent float64 `json:"juros_dia_percent"`
+	CarenciaDias    int     `json:"carencia_dias"`
+}
+
+// DefaultFeePolicy padrão do produto: multa de 2% e juros de 1% ao mês, sem carência
+var DefaultFeePolicy = FeePolicy{MultaPercent: 2, JurosMesPercent: 1}
+
+// FeeRules regras de encargos configuradas em um nível (contrato ou usuário); nulos herdam do nível seguinte
+type FeeRules struct {
+	MultaPercent    *float64 `json:"multa_percent"`
+	JurosMesPercent *float64 `json:"juros_mes_percent"`
+	JurosDiaPercent *float64 `json:"juros_dia_percent"`
+	CarenciaDias    *int     `json:"carencia_dias"`
+}
+
+// Validate verifica os limites das regras (os mesmos das colunas do banco)
+func (r *FeeRules) Validate() error {
+	for _, v := range []*float64{r.MultaPercent, r.JurosMesPercent, r.JurosDiaPercent} {
+		if v != nil && (*v < 0 || *v > 100) {
+			return ErrFeePercentInvalid
+		}
+	}
+	if r.CarenciaDias != nil && (*r.CarenciaDias < 0 || *r.CarenciaDias > 90) {
+		return ErrGraceDaysInvalid
+	}
+	return nil
+}
+
+// hasJuros indica se o nível define a regra de juros (mensal ou diária)
+func (r *FeeRules) hasJuros() bool {
+	return r.JurosMesPercent != nil || r.JurosDiaPercent != nil
+}
+
+// ResolveFeePolicy combina os níveis de regras, do mais específico (contrato) ao mais geral (usuário),
+// sobre o padrão do produto.
+// Docstring: multa e carência são herdadas campo a campo; os juros são herdados como uma regra só,
+// para que um contrato com juros ao mês não receba os juros diários do usuário.
+func ResolveFeePolicy(levels ...*FeeRules) FeePolicy {
+	p := DefaultFeePolicy
+	var multa, carencia, juros bool
+	for _, r := range levels {
+		if r == nil {
+			continue
+		}
+		if !multa && r.MultaPercent != nil {
+			p.MultaPercent, multa = *r.MultaPercent, true
+		}
+		if !carencia && r.CarenciaDias != nil {
+			p.CarenciaDias, carencia = *r.CarenciaDias, true
+		}
+		if !juros && r.hasJuros() {
+			p.JurosMesPercent, p.JurosDiaPercent, juros = 0, 0, true
+			if r.JurosMesPercent != nil {
+				p.JurosMesPercent = *r.JurosMesPercent
+			}
+			if r.JurosDiaPercent != nil {
+				p.JurosDiaPercent = *r.JurosDiaPercent
+			}
+		}
+	}
+	return p
+}
+
+// LateFees encargos calculados para uma data de pagamento
+type LateFees struct {
+	DiasAtraso int   `json:"dias_atraso"`
+	Multa      Money `json:"multa"`
+	Juros      Money `json:"juros"`
+}
+
+// Total soma de multa e juros
+func (f LateFees) Total() Money {
+	return f.Multa + f.Juros
+}
+
+// CalculateLateFees calcula os encargos sobre o saldo em aberto para pagamento em payDate.
+// Docstring: o vencimento é uma data (gravada à meia-noite UTC) e payDate é tomada pelo dia civil
+// em loc; dentro da carência não há encargos e, após ela, os juros contam desde o vencimento
+// (juros simples: ao dia, ou ao mês pro rata die com mês de 30 dias).
+func CalculateLateFees(saldo Money, due, payDate time.Time, p FeePolicy, loc *time.Location) LateFees {
+	if saldo <= 0 {
+		return LateFees{}
+	}
+	days := civilDaysBetween(due.UTC(), payDate.In(loc))
+	if days <= 0 {
+		return LateFees{}
+	}
+	fees := LateFees{DiasAtraso: days}
+	if days <= p.CarenciaDias {
+		return fees
+	}
+	fees.Multa = DefaultRounding.Percent(saldo, p.MultaPercent)
+	if p.JurosDiaPercent > 0 {
+		fees.Juros = DefaultRounding.Prorate(saldo, p.JurosDiaPercent, int64(days), 1)
+	} else {
+		fees.Juros = DefaultRounding.Prorate(saldo, p.JurosMesPercent, int64(days), 30)
+	}
+	return fees
+}
+
+// IncomeCharges valor corrigido de uma receita em uma data: saldo principal mais encargos por atraso
+type IncomeCharges struct {
+	Data           string    `json:"data"`
+	Policy         FeePolicy `json:"policy"`
+	SaldoPrincipal Money     `json:"saldo_principal"`
+	DiasAtraso     int       `json:"dias_atraso"`
+	Multa          Money     `json:"multa"`
+	Juros          Money     `json:"juros"`
+	TotalEncargos  Money     `json:"total_encargos"`
+}
+
+// IncomeDetail receita com o valor corrigido (saldo + encargos) e a composição do cálculo
+type IncomeDetail struct {
+	Income
+	ValorCorrigido Money         `json:"valor_corrigido"`
+	Encargos       IncomeCharges `json:"encargos"`
+}
+
+// CorrectIncome calcula o valor corrigido da receita em at (dia civil em loc); sem atraso é o próprio saldo
+func CorrectIncome(income *Income, at time.Time, p FeePolicy, loc *time.Location) *IncomeDetail {
+	saldo := max(income.Valor-income.TotalPago, 0)
+	var fees LateFees
+	if income.DueDate != nil {
+		fees = CalculateLateFees(saldo, *income.DueDate, at, p, loc)
+	}
+	return &IncomeDetail{
+		Income:         *income,
+		ValorCorrigido: saldo + fees.Total(),
+		Encargos: IncomeCharges{
+			Data:           at.In(loc).Format("2006-01-02"),
+			Policy:         p,
+			SaldoPrincipal: saldo,
+			DiasAtraso:     fees.DiasAtraso,
+			Multa:          fees.Multa,
+			Juros:          fees.Juros,
+			TotalEncargos:  fees.Total(),
+		},
+	}
+}
+
+// PaymentSimulation resultado de "quanto pagar" em uma data, sem registrar o pagamento.
+// Docstring: o valor pago quita primeiro os encargos e depois o saldo principal.
+type PaymentSimulation struct {
+	IncomeID         uuid.UUID `json:"income_id"`
+	Data             string    `json:"data"`
+	Policy           FeePolicy `json:"policy"`
+	SaldoPrincipal   Money     `json:"saldo_principal"`
+	DiasAtraso       int       `json:"dias_atraso"`
+	Multa            Money     `json:"multa"`
+	Juros            Money     `json:"juros"`
+	TotalDevido      Money     `json:"total_devido"`
+	ValorPago        Money     `json:"valor_pago"`
+	AbatidoEncargos  Money     `json:"abatido_encargos"`
+	AbatidoPrincipal Money     `json:"abatido_principal"`
+	SaldoRestante    Money     `json:"saldo_restante"`
+	StatusResultante string    `json:"status_resultante"`
+}
+
+// SimulatePayment simula o pagamento da receita em payDate; valor nil simula a quitação total
+func SimulatePayment(income *Income, payDate time.Time, valor *Money, p FeePolicy, loc *time.Location) (*PaymentSimulation, error) {
+	saldo := max(income.Valor-income.TotalPago, 0)
+	var fees LateFees
+	if income.DueDate != nil {
+		fees = CalculateLateFees(saldo, *income.DueDate, payDate, p, loc)
+	}
+	sim := &PaymentSimulation{
+		IncomeID:       income.ID,
+		Data:           payDate.In(loc).Format("2006-01-02"),
+		Policy:         p,
+		SaldoPrincipal: saldo,
+		DiasAtraso:     fees.DiasAtraso,
+		Multa:          fees.Multa,
+		Juros:          fees.Juros,
+		TotalDevido:    saldo + fees.Total(),
+	}
+
+	pago := sim.TotalDevido
+	if valor != nil {
+		if *valor <= 0 {
+			return nil, ErrValorInvalid
+		}
+		if *valor > sim.TotalDevido {
+			return nil, ErrInsufficientAmount
+		}
+		pago = *valor
+	}
+	sim.ValorPago = pago
+	sim.AbatidoEncargos = min(pago, fees.Total())
+	sim.AbatidoPrincipal = pago - sim.AbatidoEncargos
+	sim.SaldoRestante = sim.TotalDevido - pago
+
+	switch {
+	case sim.SaldoRestante <= 0:
+		sim.StatusResultante = StatusPago
+	case income.TotalPago > 0 || sim.AbatidoPrincipal > 0:
+		sim.StatusResultante = StatusParcial
+	case fees.DiasAtraso > 0:
+		sim.StatusResultante = StatusVencido
+	default:
+		sim.StatusResultante = StatusPendente
+	}
+	return sim, nil
+}
+
+// PastDue indica se o vencimento (data à meia-noite UTC) já passou no dia civil de now em loc
+func PastDue(due, now time.Time, loc *time.Location) bool {
+	return civilDaysBetween(due.UTC(), now.In(loc)) > 0
+}
+
+// civilDaysBetween dias civis entre as datas de a e b, cada uma no próprio fuso (negativo se b for anterior)
+func civilDaysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	da := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	db := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
@@ -0,0 +1,103 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do cálculo de encargos por atraso e da simulação de pagamento
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSimulatePayment_LateFeesAndAllocation(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	due := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	in := &Income{Valor: NewMoney(1000), DueDate: &due}
+	// 20/10 às 22h em São Paulo já é dia 21 em UTC; o atraso conta pelo dia civil do usuário
+	pay := time.Date(2026, 10, 20, 22, 0, 0, 0, loc)
+
+	sim, err := SimulatePayment(in, pay, nil, DefaultFeePolicy, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim.DiasAtraso != 10 || sim.Multa != NewMoney(20) || sim.Juros != NewMoney(3.33) || sim.TotalDevido != NewMoney(1023.33) {
+		t.Fatalf("encargos inesperados: %+v", sim)
+	}
+	if sim.SaldoRestante != 0 || sim.StatusResultante != StatusPago {
+		t.Fatalf("quitação total esperada: %+v", sim)
+	}
+
+	valor := NewMoney(500)
+	sim, err = SimulatePayment(in, pay, &valor, DefaultFeePolicy, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim.AbatidoEncargos != NewMoney(23.33) || sim.AbatidoPrincipal != NewMoney(476.67) || sim.SaldoRestante != NewMoney(523.33) || sim.StatusResultante != StatusParcial {
+		t.Fatalf("pagamento parcial deveria quitar encargos primeiro: %+v", sim)
+	}
+
+	valor = NewMoney(2000)
+	if _, err := SimulatePayment(in, pay, &valor, DefaultFeePolicy, loc); !errors.Is(err, ErrInsufficientAmount) {
+		t.Fatalf("valor acima do devido deveria falhar, err = %v", err)
+	}
+}
+
+func TestCalculateLateFees_GraceAndEarlyPayment(t *testing.T) {
+	due := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	p := FeePolicy{MultaPercent: 10, JurosMesPercent: 1, CarenciaDias: 5}
+
+	if f := CalculateLateFees(NewMoney(1000), due, due.AddDate(0, 0, 5), p, time.UTC); f.Total() != 0 || f.DiasAtraso != 5 {
+		t.Fatalf("dentro da carência não há encargos: %+v", f)
+	}
+	if f := CalculateLateFees(NewMoney(1000), due, due.AddDate(0, 0, 6), p, time.UTC); f.Multa != NewMoney(100) || f.Juros != NewMoney(2) {
+		t.Fatalf("após a carência os juros contam desde o vencimento: %+v", f)
+	}
+	if f := CalculateLateFees(NewMoney(1000), due, due.AddDate(0, 0, -1), p, time.UTC); f != (LateFees{}) {
+		t.Fatalf("pagamento antecipado sem encargos: %+v", f)
+	}
+}
+
+func TestResolveFeePolicy_ContractOverUserOverDefault(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	grace := 3
+	user := &FeeRules{MultaPercent: f(5), JurosDiaPercent: f(0.05), CarenciaDias: &grace}
+
+	if p := ResolveFeePolicy(nil, user); p != (FeePolicy{MultaPercent: 5, JurosDiaPercent: 0.05, CarenciaDias: 3}) {
+		t.Fatalf("sem regras no contrato valem as do usuário: %+v", p)
+	}
+	// Juros ao mês no contrato substituem a regra de juros do usuário inteira (inclusive os diários)
+	contract := &FeeRules{JurosMesPercent: f(2)}
+	if p := ResolveFeePolicy(contract, user); p != (FeePolicy{MultaPercent: 5, JurosMesPercent: 2, CarenciaDias: 3}) {
+		t.Fatalf("contrato deveria prevalecer nos juros: %+v", p)
+	}
+	if p := ResolveFeePolicy(&FeeRules{}, &FeeRules{}); p != DefaultFeePolicy {
+		t.Fatalf("sem regras vale o padrão do produto: %+v", p)
+	}
+	if err := (&FeeRules{MultaPercent: f(101)}).Validate(); !errors.Is(err, ErrFeePercentInvalid) {
+		t.Fatalf("multa acima de 100%% deveria falhar, err = %v", err)
+	}
+	over := 91
+	if err := (&FeeRules{CarenciaDias: &over}).Validate(); !errors.Is(err, ErrGraceDaysInvalid) {
+		t.Fatalf("carência acima de 90 dias deveria falhar, err = %v", err)
+	}
+}
+
+func TestCorrectIncome_DailyInterest(t *testing.T) {
+	due := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	in := &Income{Valor: NewMoney(1000), TotalPago: NewMoney(200), DueDate: &due}
+	p := FeePolicy{MultaPercent: 2, JurosMesPercent: 1, JurosDiaPercent: 0.1}
+
+	d := CorrectIncome(in, due.AddDate(0, 0, 10), p, time.UTC)
+	// saldo 800: multa 16,00 e juros diários 0,1% × 10 dias = 8,00 (os juros ao mês são ignorados)
+	if d.Encargos.Multa != NewMoney(16) || d.Encargos.Juros != NewMoney(8) || d.ValorCorrigido != NewMoney(824) {
+		t.Fatalf("valor corrigido inesperado: %+v", d)
+	}
+	if d.Encargos.Data != "2026-10-20" || d.Encargos.DiasAtraso != 10 {
+		t.Fatalf("composição inesperada: %+v", d.Encargos)
+	}
+	if d := CorrectIncome(in, due, p, time.UTC); d.ValorCorrigido != NewMoney(800) || d.Encargos.TotalEncargos != 0 {
+		t.Fatalf("no vencimento o valor corrigido é o saldo: %+v", d)
+	}
+}
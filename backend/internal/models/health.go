@@ -0,0 +1,30 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos do readiness check (estado de cada dependência do servidor)
+// Data: 18-10-2026
+
+package models
+
+import "time"
+
+// Estados de uma dependência no readiness
+const (
+	DependencyUp   = "up"
+	DependencyDown = "down"
+)
+
+// DependencyStatus resultado da verificação de uma dependência (banco, Storage, JWKS, jobs)
+type DependencyStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs int64   `json:"latency_ms"`
+	Error     *string `json:"error,omitempty"`
+}
+
+// ReadinessReport resposta de /readyz.
+// Docstring: Ready é falso se qualquer dependência estiver "down"; o probe recebe 503 nesse caso.
+type ReadinessReport struct {
+	Ready        bool               `json:"ready"`
+	CheckedAt    time.Time          `json:"checked_at"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
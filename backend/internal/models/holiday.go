@@ -0,0 +1,204 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Calendário de feriados (nacionais e municipais do usuário) e dias úteis para vencimentos
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de feriado do calendário
+const (
+	HolidayNacional  = "nacional"
+	HolidayMunicipal = "municipal" // cadastrado pelo usuário (municipal, estadual ou local)
+)
+
+// MaxHolidayNameLength limite do nome de um feriado cadastrado
+const MaxHolidayNameLength = 80
+
+// Erros do calendário de feriados
+var (
+	ErrHolidayNotFound     = errors.New("feriado não encontrado")
+	ErrHolidayNameRequired = errors.New("nome do feriado é obrigatório")
+	ErrHolidayNameTooLong  = errors.New("nome do feriado muito longo (máximo de 80 caracteres)")
+	ErrHolidayDateRequired = errors.New("data do feriado é obrigatória (AAAA-MM-DD)")
+	ErrHolidayYearInvalid  = errors.New("ano inválido")
+)
+
+// Holiday feriado do calendário; os nacionais são calculados (sem ID), os municipais vêm de rf_holidays.
+// Docstring: Anual repete o feriado todo ano no mesmo dia e mês (a data guarda o primeiro ano).
+type Holiday struct {
+	ID        *uuid.UUID `json:"id,omitempty" db:"id"`
+	OwnerID   *uuid.UUID `json:"-" db:"owner_id"`
+	Data      time.Time  `json:"data" db:"data"`
+	Nome      string     `json:"nome" db:"nome"`
+	Tipo      string     `json:"tipo"`
+	Anual     bool       `json:"anual" db:"anual"`
+	CreatedAt *time.Time `json:"created_at,omitempty" db:"created_at"`
+}
+
+// MarshalJSON expõe a data como AAAA-MM-DD
+func (h Holiday) MarshalJSON() ([]byte, error) {
+	type alias Holiday
+	return json.Marshal(struct {
+		alias
+		Data string `json:"data"`
+	}{alias(h), h.Data.Format("2006-01-02")})
+}
+
+// HolidayRequest cadastro de feriado municipal
+type HolidayRequest struct {
+	Data  string `json:"data"` // AAAA-MM-DD
+	Nome  string `json:"nome"`
+	Anual bool   `json:"anual"`
+}
+
+// Validate normaliza o nome e interpreta a data
+func (req *HolidayRequest) Validate() (time.Time, error) {
+	req.Nome = strings.TrimSpace(req.Nome)
+	if req.Nome == "" {
+		return time.Time{}, ErrHolidayNameRequired
+	}
+	if utf8.RuneCountInString(req.Nome) > MaxHolidayNameLength {
+		return time.Time{}, ErrHolidayNameTooLong
+	}
+	d, err := time.Parse("2006-01-02", strings.TrimSpace(req.Data))
+	if err != nil {
+		return time.Time{}, ErrHolidayDateRequired
+	}
+	return d, nil
+}
+
+// BusinessDaySettings opção de vencimento em dia útil (rf_settings.vencimento_dia_util)
+type BusinessDaySettings struct {
+	AjustarDiaUtil bool `json:"ajustar_dia_util" db:"vencimento_dia_util"`
+}
+
+// EasterSunday domingo de Páscoa do ano (algoritmo de Meeus/Jones/Butcher, calendário gregoriano)
+func EasterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// NationalHolidays feriados nacionais do ano, em ordem de data.
+// Docstring: inclui Carnaval (segunda e terça) e Corpus Christi, pontos facultativos em que os bancos
+// não abrem, pois o calendário serve para vencimentos; Consciência Negra é nacional desde 2024.
+func NationalHolidays(year int) []Holiday {
+	day := func(m time.Month, d int) time.Time { return time.Date(year, m, d, 0, 0, 0, 0, time.UTC) }
+	easter := EasterSunday(year)
+	list := []Holiday{
+		{Data: day(time.January, 1), Nome: "Confraternização Universal"},
+		{Data: easter.AddDate(0, 0, -48), Nome: "Carnaval"},
+		{Data: easter.AddDate(0, 0, -47), Nome: "Carnaval"},
+		{Data: easter.AddDate(0, 0, -2), Nome: "Sexta-feira Santa"},
+		{Data: day(time.April, 21), Nome: "Tiradentes"},
+		{Data: day(time.May, 1), Nome: "Dia do Trabalho"},
+		{Data: easter.AddDate(0, 0, 60), Nome: "Corpus Christi"},
+		{Data: day(time.September, 7), Nome: "Independência do Brasil"},
+		{Data: day(time.October, 12), Nome: "Nossa Senhora Aparecida"},
+		{Data: day(time.November, 2), Nome: "Finados"},
+		{Data: day(time.November, 15), Nome: "Proclamação da República"},
+		{Data: day(time.December, 25), Nome: "Natal"},
+	}
+	if year >= 2024 {
+		list = append(list, Holiday{Data: day(time.November, 20), Nome: "Dia Nacional de Zumbi e da Consciência Negra"})
+	}
+	for i := range list {
+		list[i].Tipo = HolidayNacional
+	}
+	sortHolidays(list)
+	return list
+}
+
+// HolidayCalendar feriados do usuário (nacionais mais os cadastrados) para consulta por data
+type HolidayCalendar struct {
+	custom []Holiday
+	years  map[int]map[string]Holiday
+}
+
+// NewHolidayCalendar monta o calendário com os feriados cadastrados pelo usuário
+func NewHolidayCalendar(custom []Holiday) *HolidayCalendar {
+	return &HolidayCalendar{custom: custom, years: map[int]map[string]Holiday{}}
+}
+
+// Year feriados do ano em ordem de data; um feriado cadastrado na data de um nacional não se repete
+func (c *HolidayCalendar) Year(year int) []Holiday {
+	byDate := c.year(year)
+	out := make([]Holiday, 0, len(byDate))
+	for _, h := range byDate {
+		out = append(out, h)
+	}
+	sortHolidays(out)
+	return out
+}
+
+// IsHoliday indica se a data (dia civil) é feriado
+func (c *HolidayCalendar) IsHoliday(d time.Time) bool {
+	_, ok := c.year(d.Year())[d.Format("2006-01-02")]
+	return ok
+}
+
+// IsBusinessDay indica se a data é dia útil (segunda a sexta e não feriado)
+func (c *HolidayCalendar) IsBusinessDay(d time.Time) bool {
+	if wd := d.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	return !c.IsHoliday(d)
+}
+
+// NextBusinessDay a própria data, se for dia útil, ou o próximo dia útil (preserva o horário)
+func (c *HolidayCalendar) NextBusinessDay(d time.Time) time.Time {
+	for !c.IsBusinessDay(d) {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+func (c *HolidayCalendar) year(year int) map[string]Holiday {
+	if byDate, ok := c.years[year]; ok {
+		return byDate
+	}
+	byDate := map[string]Holiday{}
+	for _, h := range NationalHolidays(year) {
+		byDate[h.Data.Format("2006-01-02")] = h
+	}
+	for _, h := range c.custom {
+		switch {
+		case h.Anual && h.Data.Year() <= year:
+			// 29/02 anual cai em 28/02 nos anos não bissextos
+			h.Data = dayInMonth(time.Date(year, h.Data.Month(), 1, 0, 0, 0, 0, time.UTC), h.Data.Day())
+		case h.Data.Year() != year:
+			continue
+		}
+		key := h.Data.Format("2006-01-02")
+		if _, ok := byDate[key]; !ok {
+			h.Tipo = HolidayMunicipal
+			byDate[key] = h
+		}
+	}
+	c.years[year] = byDate
+	return byDate
+}
+
+func sortHolidays(list []Holiday) {
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Data.Before(list[j].Data) })
+}
@@ -0,0 +1,72 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do calendário de feriados e do próximo dia útil
+// Data: 18-10-2026
+
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+func TestEasterSunday(t *testing.T) {
+	for year, want := range map[int]time.Time{
+		2024: date(2024, time.March, 31),
+		2025: date(2025, time.April, 20),
+		2026: date(2026, time.April, 5),
+		2027: date(2027, time.March, 28),
+	} {
+		if got := EasterSunday(year); !got.Equal(want) {
+			t.Errorf("Páscoa %d = %s, esperado %s", year, got.Format("2006-01-02"), want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestNationalHolidays(t *testing.T) {
+	cal := NewHolidayCalendar(nil)
+	for _, d := range []time.Time{
+		date(2026, time.February, 16), date(2026, time.February, 17), // Carnaval
+		date(2026, time.April, 3), // Sexta-feira Santa
+		date(2026, time.June, 4),  // Corpus Christi
+		date(2026, time.November, 20),
+	} {
+		if !cal.IsHoliday(d) {
+			t.Errorf("%s deveria ser feriado", d.Format("2006-01-02"))
+		}
+	}
+	if cal.IsHoliday(date(2023, time.November, 20)) {
+		t.Fatalf("Consciência Negra só é nacional desde 2024")
+	}
+	if n := len(NationalHolidays(2026)); n != 13 {
+		t.Fatalf("feriados nacionais de 2026 = %d, esperado 13", n)
+	}
+}
+
+func TestHolidayCalendar_NextBusinessDay(t *testing.T) {
+	cal := NewHolidayCalendar([]Holiday{
+		{Data: date(2020, time.January, 25), Nome: "Aniversário de São Paulo", Anual: true},
+		{Data: date(2026, time.December, 28), Nome: "Ponto facultativo"},
+	})
+	cases := []struct{ in, want time.Time }{
+		{date(2026, time.October, 19), date(2026, time.October, 19)},   // segunda comum
+		{date(2026, time.October, 17), date(2026, time.October, 19)},   // sábado
+		{date(2026, time.October, 12), date(2026, time.October, 13)},   // feriado nacional
+		{date(2027, time.January, 25), date(2027, time.January, 26)},   // municipal anual
+		{date(2026, time.December, 25), date(2026, time.December, 29)}, // Natal, fim de semana e municipal
+	}
+	for _, c := range cases {
+		if got := cal.NextBusinessDay(c.in); !got.Equal(c.want) {
+			t.Errorf("NextBusinessDay(%s) = %s, esperado %s", c.in.Format("2006-01-02"), got.Format("2006-01-02"), c.want.Format("2006-01-02"))
+		}
+	}
+	if cal.IsHoliday(date(2019, time.January, 25)) {
+		t.Fatalf("feriado anual não vale antes do primeiro ano")
+	}
+	year := cal.Year(2027)
+	if year[0].Nome != "Confraternização Universal" || year[1].Tipo != HolidayMunicipal {
+		t.Fatalf("calendário 2027: %+v", year[:2])
+	}
+}
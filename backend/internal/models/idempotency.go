@@ -0,0 +1,76 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Chaves de idempotência das criações (pagamentos e recibos) e resposta guardada
+// Data: 18-10-2026
+
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader cabeçalho enviado pelos clientes nas criações
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyKeyTTL tempo em que uma chave é lembrada; depois disso pode ser reutilizada
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyPendingTimeout após este tempo uma reserva sem resposta é considerada abandonada
+// (bem acima do timeout das requisições)
+const IdempotencyPendingTimeout = time.Minute
+
+// MaxIdempotencyKeyLen tamanho máximo da chave (UUIDs e afins)
+const MaxIdempotencyKeyLen = 255
+
+// Erros de idempotência
+var (
+	ErrIdempotencyKeyInvalid = errors.New("Idempotency-Key inválida (1 a 255 caracteres)")
+	ErrIdempotencyInProgress = errors.New("requisição com esta Idempotency-Key ainda em processamento")
+	ErrIdempotencyKeyReused  = errors.New("Idempotency-Key já usada em outra requisição")
+)
+
+// IdempotencyRecord requisição original associada a uma chave e a resposta enviada.
+// Docstring: StatusCode 0 indica que a requisição original ainda não terminou.
+type IdempotencyRecord struct {
+	OwnerID         uuid.UUID         `json:"owner_id" db:"owner_id"`
+	Key             string            `json:"key" db:"key"`
+	Method          string            `json:"method" db:"method"`
+	Path            string            `json:"path" db:"path"`
+	RequestHash     string            `json:"request_hash" db:"request_hash"`
+	StatusCode      int               `json:"status_code" db:"status_code"`
+	ResponseHeaders map[string]string `json:"response_headers" db:"response_headers"`
+	ResponseBody    []byte            `json:"-" db:"response_body"`
+	CreatedAt       time.Time         `json:"created_at" db:"created_at"`
+	CompletedAt     *time.Time        `json:"completed_at" db:"completed_at"`
+}
+
+// ValidateIdempotencyKey confere o tamanho da chave informada
+func ValidateIdempotencyKey(key string) error {
+	if key == "" || len(key) > MaxIdempotencyKeyLen {
+		return ErrIdempotencyKeyInvalid
+	}
+	return nil
+}
+
+// IdempotencyRequestHash resumo de método, rota e corpo da requisição
+func IdempotencyRequestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Completed indica se a requisição original já terminou (resposta disponível para repetição)
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Matches indica se a repetição é da mesma requisição (mesmo método, rota e corpo)
+func (r *IdempotencyRecord) Matches(method, path, hash string) bool {
+	return r.Method == method && r.Path == path && r.RequestHash == hash
+}
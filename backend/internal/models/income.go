@@ -16,25 +16,47 @@ type Income struct {
 	ID         uuid.UUID  `json:"id" db:"id"`
 	OwnerID    uuid.UUID  `json:"owner_id" db:"owner_id"`
 	ContractID *uuid.UUID `json:"contract_id" db:"contract_id"`
+	PayerID    *uuid.UUID `json:"payer_id" db:"payer_id"`
 	Categoria  *string    `json:"categoria" db:"categoria"`
+	Tags       []string   `json:"tags" db:"tags"`
 	Competencia string    `json:"competencia" db:"competencia"`
-	Valor      float64    `json:"valor" db:"valor"`
+	Valor      Money      `json:"valor" db:"valor"`
 	Status     string     `json:"status" db:"status"`
 	DueDate    *time.Time `json:"due_date" db:"due_date"`
-	TotalPago  float64    `json:"total_pago" db:"total_pago"`
+	TotalPago  Money      `json:"total_pago" db:"total_pago"`
 	DeletedAt  *time.Time `json:"deleted_at" db:"deleted_at"`
 	CreatedAt  *time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt  *time.Time `json:"updated_at" db:"updated_at"`
+	Version    int64      `json:"version" db:"version"`
 }
 
 // IncomeRequest representa os dados de entrada para criar/atualizar receita
 type IncomeRequest struct {
+	ID          *uuid.UUID `json:"-"` // gerado no dispositivo (envio offline); nil gera um novo
 	ContractID  *uuid.UUID `json:"contract_id"`
+	PayerID     *uuid.UUID `json:"payer_id"`
 	Categoria   *string    `json:"categoria"`
+	Tags        []string   `json:"tags"`
 	Competencia string     `json:"competencia" validate:"required"`
-	Valor       float64    `json:"valor" validate:"required,gt=0"`
+	Valor       Money      `json:"valor" validate:"required,gt=0"`
 	Status      string     `json:"status"`
 	DueDate     *string    `json:"due_date"` // RFC3339 format
+	Version     *int64     `json:"version,omitempty"` // obrigatório na atualização (ou If-Match)
+}
+
+// IncomePatchRequest representa a atualização parcial de uma receita (PATCH).
+// Docstring: campos omitidos (ou null) são mantidos. Para limpar campos opcionais, envie
+// categoria "" ou due_date "", e payer_id/contract_id com o UUID nulo.
+type IncomePatchRequest struct {
+	ContractID  *uuid.UUID `json:"contract_id"`
+	PayerID     *uuid.UUID `json:"payer_id"`
+	Categoria   *string    `json:"categoria"`
+	Tags        *[]string  `json:"tags"`
+	Competencia *string    `json:"competencia"`
+	Valor       *Money     `json:"valor"`
+	Status      *string    `json:"status"`
+	DueDate     *string    `json:"due_date"` // RFC3339; "" remove o vencimento
+	Version     *int64     `json:"version"`  // versão lida; obrigatória (ou If-Match)
 }
 
 // IncomeResponse representa a resposta paginada de receitas
@@ -46,6 +68,37 @@ type IncomeResponse struct {
 	TotalPages int      `json:"total_pages"`
 }
 
+// IncomeStats estatísticas das receitas do usuário (excluídas fora).
+// Docstring: pendentes incluem as parciais; valor_pendente e valor_vencido somam o saldo devedor
+// e valor_pago o total já recebido de todas as receitas.
+type IncomeStats struct {
+	TotalReceitas     int   `json:"total_receitas"`
+	TotalValor        Money `json:"total_valor"`
+	ReceitasPendentes int   `json:"receitas_pendentes"`
+	ReceitasPagas     int   `json:"receitas_pagas"`
+	ReceitasVencidas  int   `json:"receitas_vencidas"`
+	ValorPendente     Money `json:"valor_pendente"`
+	ValorPago         Money `json:"valor_pago"`
+	ValorVencido      Money `json:"valor_vencido"`
+}
+
+// Add acumula uma receita com o status informado
+func (s *IncomeStats) Add(status string, valor, totalPago Money) {
+	s.TotalReceitas++
+	s.TotalValor += valor
+	s.ValorPago += totalPago
+	switch status {
+	case StatusPendente, StatusParcial:
+		s.ReceitasPendentes++
+		s.ValorPendente += valor - totalPago
+	case StatusPago:
+		s.ReceitasPagas++
+	case StatusVencido:
+		s.ReceitasVencidas++
+		s.ValorVencido += valor - totalPago
+	}
+}
+
 // IncomeFilters representa os filtros para listagem de receitas
 type IncomeFilters struct {
 	Page  int `json:"page"`
@@ -64,26 +117,43 @@ type IncomeListResponse struct {
 type Payment struct {
 	ID       uuid.UUID `json:"id" db:"id"`
 	IncomeID uuid.UUID `json:"income_id" db:"income_id"`
-	Valor    float64   `json:"valor" db:"valor"`
+	Valor    Money     `json:"valor" db:"valor"`
 	PagoEm   time.Time `json:"pago_em" db:"pago_em"`
-	Metodo   *string   `json:"metodo" db:"metodo"`
+	Metodo   *string   `json:"metodo" db:"metodo"` // nome da forma de pagamento no lançamento
+	MethodID *uuid.UUID `json:"method_id" db:"method_id"` // forma do catálogo (rf_payment_methods)
 	Obs      *string   `json:"obs" db:"obs"`
 	CreatedAt *time.Time `json:"created_at" db:"created_at"`
+	ReversedAt     *time.Time `json:"reversed_at,omitempty" db:"reversed_at"`         // estornado: não conta no total pago
+	ReversalReason *string    `json:"reversal_reason,omitempty" db:"reversal_reason"`
 }
 
 // PaymentRequest representa os dados de entrada para registrar pagamento
 type PaymentRequest struct {
+	ID       *uuid.UUID `json:"-"` // gerado no dispositivo (envio offline); nil gera um novo
 	IncomeID uuid.UUID `json:"income_id" validate:"required"`
-	Valor    float64   `json:"valor" validate:"required,gt=0"`
+	Valor    Money     `json:"valor" validate:"required,gt=0"`
 	PagoEm   *string   `json:"pago_em"` // RFC3339 format, opcional (default: now)
-	Metodo   *string   `json:"metodo"`
+	MethodID *uuid.UUID `json:"method_id"` // forma do catálogo; sem ela e sem metodo usa a forma padrão
+	Metodo   *string   `json:"metodo"`    // legado: nome ou tipo de uma forma do catálogo
 	Obs      *string   `json:"obs"`
+	// Overpayment define o que fazer com valor acima do saldo: "reject" (padrão) ou "credit"
+	Overpayment string `json:"overpayment,omitempty"`
+}
+
+// PaymentUpdateRequest correção de um pagamento já lançado (PUT: todos os campos)
+type PaymentUpdateRequest struct {
+	Valor  Money   `json:"valor" validate:"required,gt=0"`
+	PagoEm string  `json:"pago_em" validate:"required"` // RFC3339
+	MethodID *uuid.UUID `json:"method_id"`
+	Metodo *string `json:"metodo"`
+	Obs    *string `json:"obs"`
 }
 
 // PaymentResponse representa a resposta de um pagamento
 type PaymentResponse struct {
 	Payment Payment `json:"payment"`
 	Income  Income  `json:"income"` // Receita atualizada após o pagamento
+	Credit  *Credit `json:"credit,omitempty"` // Excedente registrado como crédito (modo "credit")
 }
 
 // IncomeFilter representa os filtros para busca de receitas
@@ -93,10 +163,12 @@ type IncomeFilter struct {
 	Categoria   string     `json:"categoria"`
 	Competencia string     `json:"competencia"`
 	ContractID  *uuid.UUID `json:"contract_id"`
+	PayerID     *uuid.UUID `json:"payer_id"`
+	Tag         string     `json:"tag"`
 	DueDateFrom *time.Time `json:"due_date_from"`
 	DueDateTo   *time.Time `json:"due_date_to"`
-	ValorMin    *float64   `json:"valor_min"`
-	ValorMax    *float64   `json:"valor_max"`
+	ValorMin    *Money     `json:"valor_min"`
+	ValorMax    *Money     `json:"valor_max"`
 	SortField   string     `json:"sort_field"`
 	SortOrder   string     `json:"sort_order"`
 	Page        int        `json:"page"`
@@ -114,9 +186,79 @@ func (req *IncomeRequest) Validate() error {
 	if req.Status == "" {
 		req.Status = "pendente"
 	}
+	if req.Tags != nil {
+		req.Tags = normalizeTags(req.Tags)
+	}
+	return nil
+}
+
+// Validate valida os campos informados na atualização parcial
+func (req *IncomePatchRequest) Validate() error {
+	if req.Competencia != nil && *req.Competencia == "" {
+		return ErrCompetenciaRequired
+	}
+	if req.Valor != nil && *req.Valor <= 0 {
+		return ErrValorInvalid
+	}
+	if req.Status != nil && !ValidStatus(*req.Status) {
+		return ErrInvalidStatus
+	}
+	if req.DueDate != nil && *req.DueDate != "" {
+		if _, err := time.Parse(time.RFC3339, *req.DueDate); err != nil {
+			return ErrInvalidDateFormat
+		}
+	}
+	if req.Tags != nil {
+		tags := normalizeTags(*req.Tags)
+		req.Tags = &tags
+	}
 	return nil
 }
 
+// Apply aplica os campos informados à receita (chamar após Validate)
+func (req *IncomePatchRequest) Apply(income *Income) {
+	if req.ContractID != nil {
+		income.ContractID = nilIfNilUUID(*req.ContractID)
+	}
+	if req.PayerID != nil {
+		income.PayerID = nilIfNilUUID(*req.PayerID)
+	}
+	if req.Categoria != nil {
+		if *req.Categoria == "" {
+			income.Categoria = nil
+		} else {
+			cat := *req.Categoria
+			income.Categoria = &cat
+		}
+	}
+	if req.Tags != nil {
+		income.Tags = *req.Tags
+	}
+	if req.Competencia != nil {
+		income.Competencia = *req.Competencia
+	}
+	if req.Valor != nil {
+		income.Valor = *req.Valor
+	}
+	if req.Status != nil {
+		income.Status = *req.Status
+	}
+	if req.DueDate != nil {
+		if *req.DueDate == "" {
+			income.DueDate = nil
+		} else if due, err := time.Parse(time.RFC3339, *req.DueDate); err == nil {
+			income.DueDate = &due
+		}
+	}
+}
+
+func nilIfNilUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
 // Validate valida os dados de um pagamento
 func (req *PaymentRequest) Validate() error {
 	if req.IncomeID == uuid.Nil {
@@ -125,9 +267,24 @@ func (req *PaymentRequest) Validate() error {
 	if req.Valor <= 0 {
 		return ErrValorInvalid
 	}
+	if req.Overpayment != "" && req.Overpayment != OverpaymentReject && req.Overpayment != OverpaymentCredit {
+		return ErrInvalidOverpaymentMode
+	}
 	return nil
 }
 
+// Validate valida a correção de um pagamento e retorna a data interpretada
+func (req *PaymentUpdateRequest) Validate() (time.Time, error) {
+	if req.Valor <= 0 {
+		return time.Time{}, ErrValorInvalid
+	}
+	pagoEm, err := time.Parse(time.RFC3339, req.PagoEm)
+	if err != nil {
+		return time.Time{}, ErrInvalidDateFormat
+	}
+	return pagoEm, nil
+}
+
 // SetDefaults define valores padrão para o filtro
 func (f *IncomeFilter) SetDefaults() {
 	if f.Page <= 0 {
@@ -136,6 +293,9 @@ func (f *IncomeFilter) SetDefaults() {
 	if f.PerPage <= 0 {
 		f.PerPage = 10
 	}
+	if f.PerPage > 100 {
+		f.PerPage = 100
+	}
 	if f.SortField == "" {
 		f.SortField = "created_at"
 	}
@@ -0,0 +1,75 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos da importação de receitas via CSV (mapeamento de colunas e resultado por linha)
+// Data: 18-10-2026
+
+package models
+
+import "strings"
+
+// Campos de receita aceitos no mapeamento da importação
+const (
+	ImportFieldCompetencia = "competencia"
+	ImportFieldValor       = "valor"
+	ImportFieldCategoria   = "categoria"
+	ImportFieldStatus      = "status"
+	ImportFieldDueDate     = "due_date"
+	ImportFieldPayerID     = "payer_id"
+	ImportFieldContractID  = "contract_id"
+	ImportFieldTags        = "tags"
+)
+
+// MaxImportRows limite de linhas por arquivo importado
+const MaxImportRows = 5000
+
+// ImportFields lista os campos mapeáveis
+func ImportFields() []string {
+	return []string{
+		ImportFieldCompetencia, ImportFieldValor, ImportFieldCategoria, ImportFieldStatus,
+		ImportFieldDueDate, ImportFieldPayerID, ImportFieldContractID, ImportFieldTags,
+	}
+}
+
+// IncomeImportMapping associa campo da receita -> cabeçalho da coluna no CSV.
+// Docstring: campos não mapeados são procurados por um cabeçalho com o próprio nome do campo
+// (sem diferenciar maiúsculas); competencia e valor são obrigatórios.
+type IncomeImportMapping map[string]string
+
+// IncomeImportError erro de validação de uma linha do CSV (linha 1 = cabeçalho)
+type IncomeImportError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// IncomeImportResult resultado da importação (ou da simulação, com DryRun)
+type IncomeImportResult struct {
+	DryRun    bool                `json:"dry_run"`
+	TotalRows int                 `json:"total_rows"`
+	ValidRows int                 `json:"valid_rows"`
+	Imported  int                 `json:"imported"`
+	Errors    []IncomeImportError `json:"errors"`
+	Preview   []Income            `json:"preview,omitempty"`
+}
+
+// Validate verifica se o mapeamento só usa campos conhecidos
+func (m IncomeImportMapping) Validate() error {
+	known := map[string]bool{}
+	for _, f := range ImportFields() {
+		known[f] = true
+	}
+	for field, col := range m {
+		if !known[field] || strings.TrimSpace(col) == "" {
+			return ErrInvalidImportMapping
+		}
+	}
+	return nil
+}
+
+// Column retorna o cabeçalho mapeado para o campo (ou o próprio nome do campo)
+func (m IncomeImportMapping) Column(field string) string {
+	if col, ok := m[field]; ok {
+		return strings.TrimSpace(col)
+	}
+	return field
+}
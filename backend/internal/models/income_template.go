@@ -0,0 +1,162 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos de receita (rf_income_templates) e cópia de receitas entre competências
+// Data: 18-10-2026
+
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IncomeTemplate representa um modelo reutilizável de receita (ex.: mensalidade de um cliente)
+type IncomeTemplate struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	OwnerID    uuid.UUID  `json:"owner_id" db:"owner_id"`
+	Nome       string     `json:"nome" db:"nome"`
+	PayerID    *uuid.UUID `json:"payer_id" db:"payer_id"`
+	ContractID *uuid.UUID `json:"contract_id" db:"contract_id"`
+	Categoria  *string    `json:"categoria" db:"categoria"`
+	Tags       []string   `json:"tags" db:"tags"`
+	Valor      Money      `json:"valor" db:"valor"`
+	DueDay     *int       `json:"due_day" db:"due_day"`
+	CreatedAt  *time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IncomeTemplateRequest dados de entrada para criar/atualizar um modelo
+type IncomeTemplateRequest struct {
+	Nome       string     `json:"nome" validate:"required"`
+	PayerID    *uuid.UUID `json:"payer_id"`
+	ContractID *uuid.UUID `json:"contract_id"`
+	Categoria  *string    `json:"categoria"`
+	Tags       []string   `json:"tags"`
+	Valor      Money      `json:"valor" validate:"required,gt=0"`
+	DueDay     *int       `json:"due_day"` // 1-31; limitado ao último dia do mês
+}
+
+// IncomeCopyRequest dados para gerar uma receita a partir de outra receita ou de um modelo.
+// Docstring: campos omitidos são herdados da origem; na duplicação, a competência avança um mês.
+type IncomeCopyRequest struct {
+	Competencia string  `json:"competencia"`
+	DueDate     *string `json:"due_date"` // RFC3339
+	Valor       *Money  `json:"valor"`
+}
+
+// Validate valida e normaliza os dados do modelo
+func (req *IncomeTemplateRequest) Validate() error {
+	req.Nome = strings.TrimSpace(req.Nome)
+	if req.Nome == "" {
+		return ErrTemplateNameRequired
+	}
+	if req.Valor <= 0 {
+		return ErrValorInvalid
+	}
+	if req.DueDay != nil && (*req.DueDay < 1 || *req.DueDay > 31) {
+		return ErrInvalidDueDay
+	}
+	req.Tags = normalizeTags(req.Tags)
+	return nil
+}
+
+// IncomeRequest monta a requisição de criação de receita para a competência informada.
+// Docstring: sem due_date explícito, o vencimento é o DueDay do modelo no mês da competência.
+func (t *IncomeTemplate) IncomeRequest(req *IncomeCopyRequest) (*IncomeRequest, error) {
+	competencia := strings.TrimSpace(req.Competencia)
+	if competencia == "" {
+		return nil, ErrCompetenciaRequired
+	}
+	out := &IncomeRequest{
+		ContractID:  t.ContractID,
+		PayerID:     t.PayerID,
+		Categoria:   t.Categoria,
+		Tags:        append([]string(nil), t.Tags...),
+		Competencia: competencia,
+		Valor:       t.Valor,
+		Status:      StatusPendente,
+		DueDate:     req.DueDate,
+	}
+	if req.Valor != nil {
+		out.Valor = *req.Valor
+	}
+	if out.DueDate == nil && t.DueDay != nil {
+		if month, ok := parseCompetencia(competencia); ok {
+			due := dayInMonth(month, *t.DueDay).Format(time.RFC3339)
+			out.DueDate = &due
+		}
+	}
+	return out, nil
+}
+
+// DuplicateIncomeRequest monta a requisição para recobrar uma receita existente.
+// Docstring: status e pagamentos não são copiados; competência e vencimento avançam um mês quando omitidos.
+func DuplicateIncomeRequest(src *Income, req *IncomeCopyRequest) (*IncomeRequest, error) {
+	competencia := strings.TrimSpace(req.Competencia)
+	if competencia == "" {
+		next, ok := NextCompetencia(src.Competencia)
+		if !ok {
+			return nil, ErrCompetenciaRequired
+		}
+		competencia = next
+	}
+	out := &IncomeRequest{
+		ContractID:  src.ContractID,
+		PayerID:     src.PayerID,
+		Categoria:   src.Categoria,
+		Tags:        append([]string(nil), src.Tags...),
+		Competencia: competencia,
+		Valor:       src.Valor,
+		Status:      StatusPendente,
+		DueDate:     req.DueDate,
+	}
+	if req.Valor != nil {
+		out.Valor = *req.Valor
+	}
+	if out.DueDate == nil && src.DueDate != nil {
+		due := addMonthClamped(*src.DueDate).Format(time.RFC3339)
+		out.DueDate = &due
+	}
+	return out, nil
+}
+
+// competenciaLayouts formatos de competência reconhecidos ("2026-10" e "10/2026")
+var competenciaLayouts = []string{"2006-01", "01/2006"}
+
+// NextCompetencia avança a competência em um mês preservando o formato original
+func NextCompetencia(c string) (string, bool) {
+	c = strings.TrimSpace(c)
+	for _, layout := range competenciaLayouts {
+		if t, err := time.Parse(layout, c); err == nil {
+			return t.AddDate(0, 1, 0).Format(layout), true
+		}
+	}
+	return "", false
+}
+
+func parseCompetencia(c string) (time.Time, bool) {
+	for _, layout := range competenciaLayouts {
+		if t, err := time.Parse(layout, c); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// dayInMonth retorna o dia informado no mês de ref, limitado ao último dia do mês
+func dayInMonth(ref time.Time, day int) time.Time {
+	last := time.Date(ref.Year(), ref.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(ref.Year(), ref.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// addMonthClamped avança um mês sem transbordar (31/01 vira 28/02 ou 29/02)
+func addMonthClamped(t time.Time) time.Time {
+	next := time.Date(t.Year(), t.Month()+1, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	day := dayInMonth(next, t.Day()).Day()
+	return time.Date(next.Year(), next.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
@@ -0,0 +1,62 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da duplicação de receitas e da geração a partir de modelos
+// Data: 18-10-2026
+
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextCompetencia(t *testing.T) {
+	cases := map[string]string{"2026-10": "2026-11", "2026-12": "2027-01", "12/2026": "01/2027"}
+	for in, want := range cases {
+		if got, ok := NextCompetencia(in); !ok || got != want {
+			t.Fatalf("NextCompetencia(%q) = %q,%v want %q", in, got, ok, want)
+		}
+	}
+	if _, ok := NextCompetencia("Outubro"); ok {
+		t.Fatalf("competência livre não deveria ser reconhecida")
+	}
+}
+
+func TestDuplicateIncomeRequest_AdvancesMonth(t *testing.T) {
+	due := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	cat := "Aluguel"
+	src := &Income{Competencia: "2026-01", Valor: NewMoney(1200), Categoria: &cat, Status: StatusPago, TotalPago: NewMoney(1200),
+		DueDate: &due, Tags: []string{"apto 12"}}
+
+	req, err := DuplicateIncomeRequest(src, &IncomeCopyRequest{})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if req.Competencia != "2026-02" || req.Status != StatusPendente || req.Valor != NewMoney(1200) || *req.Categoria != "Aluguel" {
+		t.Fatalf("requisição inesperada: %+v", req)
+	}
+	if req.DueDate == nil || *req.DueDate != "2026-02-28T00:00:00Z" {
+		t.Fatalf("due_date = %v, want 2026-02-28", req.DueDate)
+	}
+
+	if _, err := DuplicateIncomeRequest(&Income{Competencia: "Outubro"}, &IncomeCopyRequest{}); err != ErrCompetenciaRequired {
+		t.Fatalf("err = %v, want ErrCompetenciaRequired", err)
+	}
+}
+
+func TestIncomeTemplate_IncomeRequestUsesDueDay(t *testing.T) {
+	day := 31
+	tpl := &IncomeTemplate{Nome: "Mensalidade", Valor: NewMoney(300), DueDay: &day}
+	valor := NewMoney(350)
+
+	req, err := tpl.IncomeRequest(&IncomeCopyRequest{Competencia: "2026-04", Valor: &valor})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if req.Valor != NewMoney(350) || req.DueDate == nil || *req.DueDate != "2026-04-30T00:00:00Z" {
+		t.Fatalf("requisição inesperada: valor=%v due=%v", req.Valor, req.DueDate)
+	}
+	if _, err := tpl.IncomeRequest(&IncomeCopyRequest{}); err != ErrCompetenciaRequired {
+		t.Fatalf("err = %v, want ErrCompetenciaRequired", err)
+	}
+}
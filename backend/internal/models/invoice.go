@@ -0,0 +1,110 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: NFS-e das receitas pagas (rf_invoices) e dados do prestador para o RPS (rf_settings)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status de uma NFS-e
+const (
+	InvoiceGenerated = "generated" // XML pronto para download (sem integração ou aguardando envio)
+	InvoiceSubmitted = "submitted" // lote recebido pela prefeitura, em processamento
+	InvoiceIssued    = "issued"    // NFS-e emitida
+	InvoiceFailed    = "failed"    // prefeitura recusou o RPS
+)
+
+// DefaultRPSSerie série do RPS quando o usuário não define outra
+const DefaultRPSSerie = "RF"
+
+var (
+	ErrInvoiceNotFound         = errors.New("nota fiscal não encontrada")
+	ErrInvoiceExists           = errors.New("receita já tem nota fiscal")
+	ErrInvoiceIncomeNotPaid    = errors.New("só receitas pagas podem gerar nota fiscal")
+	ErrInvoiceIssuerIncomplete = errors.New("complete os dados do prestador em /settings/invoice para gerar notas fiscais")
+	ErrInvoiceIssuerInvalid    = errors.New("dados do prestador inválidos")
+)
+
+var itemListaServicoPattern = regexp.MustCompile(`^\d{1,2}\.\d{2}$`)
+
+// InvoiceIssuer dados do prestador usados no RPS; nulos ainda não foram configurados.
+// Docstring: CodigoMunicipio é o código IBGE (7 dígitos) e ItemListaServico o item da LC 116/2003
+// ("17.01"). ProximoRPS é o número do próximo RPS (a prefeitura exige sequência sem repetição).
+type InvoiceIssuer struct {
+	Documento          *string  `json:"documento"`
+	InscricaoMunicipal *string  `json:"inscricao_municipal"`
+	RazaoSocial        *string  `json:"razao_social"`
+	CodigoMunicipio    *string  `json:"codigo_municipio"`
+	ItemListaServico   *string  `json:"item_lista_servico"`
+	CodigoTributacao   *string  `json:"codigo_tributacao"`
+	AliquotaISS        *float64 `json:"aliquota_iss"`
+	OptanteSimples     *bool    `json:"optante_simples"`
+	SerieRPS           *string  `json:"serie_rps"`
+	ProximoRPS         *int64   `json:"proximo_rps"`
+}
+
+// Validate normaliza o documento e confere o formato dos campos informados
+func (i *InvoiceIssuer) Validate() error {
+	if i.Documento != nil {
+		doc := NormalizeDocument(*i.Documento)
+		if !ValidDocument(doc) {
+			return fmt.Errorf("%w: informe um CPF ou CNPJ válido", ErrInvoiceIssuerInvalid)
+		}
+		i.Documento = &doc
+	}
+	if i.CodigoMunicipio != nil {
+		if c := strings.TrimSpace(*i.CodigoMunicipio); len(c) != 7 || NormalizeDocument(c) != c {
+			return fmt.Errorf("%w: código do município deve ter os 7 dígitos do IBGE", ErrInvoiceIssuerInvalid)
+		}
+	}
+	if i.ItemListaServico != nil && !itemListaServicoPattern.MatchString(strings.TrimSpace(*i.ItemListaServico)) {
+		return fmt.Errorf("%w: item da lista de serviços deve seguir a LC 116 (ex.: 17.01)", ErrInvoiceIssuerInvalid)
+	}
+	if i.AliquotaISS != nil && (*i.AliquotaISS < 0 || *i.AliquotaISS > 5) {
+		return fmt.Errorf("%w: alíquota do ISS deve estar entre 0 e 5%%", ErrInvoiceIssuerInvalid)
+	}
+	if i.SerieRPS != nil && len(strings.TrimSpace(*i.SerieRPS)) > 5 {
+		return fmt.Errorf("%w: série do RPS deve ter até 5 caracteres", ErrInvoiceIssuerInvalid)
+	}
+	if i.ProximoRPS != nil && *i.ProximoRPS < 1 {
+		return fmt.Errorf("%w: número do próximo RPS deve ser maior que zero", ErrInvoiceIssuerInvalid)
+	}
+	return nil
+}
+
+// Complete indica se há dados suficientes para gerar o RPS
+func (i *InvoiceIssuer) Complete() bool {
+	filled := func(s *string) bool { return s != nil && strings.TrimSpace(*s) != "" }
+	return filled(i.Documento) && filled(i.InscricaoMunicipal) && filled(i.CodigoMunicipio) &&
+		filled(i.ItemListaServico) && i.AliquotaISS != nil
+}
+
+// Invoice NFS-e gerada para uma receita paga.
+// Docstring: guarda o XML ABRASF do RPS (baixado em /invoices/{id}/xml) e, quando há integração
+// municipal, o retorno da prefeitura. Há no máximo uma nota por receita.
+type Invoice struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	OwnerID           uuid.UUID  `json:"owner_id" db:"owner_id"`
+	IncomeID          uuid.UUID  `json:"income_id" db:"income_id"`
+	Provider          *string    `json:"provider" db:"provider"`
+	RPSSerie          string     `json:"rps_serie" db:"rps_serie"`
+	RPSNumero         int64      `json:"rps_numero" db:"rps_numero"`
+	Valor             Money      `json:"valor" db:"valor"`
+	Status            string     `json:"status" db:"status"`
+	XML               string     `json:"-" db:"xml"`
+	Numero            *string    `json:"numero" db:"numero"`
+	CodigoVerificacao *string    `json:"codigo_verificacao" db:"codigo_verificacao"`
+	Protocolo         *string    `json:"protocolo" db:"protocolo"`
+	Erro              *string    `json:"erro" db:"erro"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         *time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,58 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos do painel de jobs em segundo plano (estado dos workers e profundidade das filas)
+// Data: 18-10-2026
+
+package models
+
+import "time"
+
+// Estados de um job no painel
+const (
+	JobStateIdle    = "idle"    // registrado, mas o worker ainda não foi iniciado
+	JobStateRunning = "running" // execução em andamento
+	JobStateOK      = "ok"      // última execução concluída sem erro
+	JobStateFailing = "failing" // última execução falhou
+	JobStateStale   = "stale"   // worker iniciado, mas sem execução recente
+)
+
+// JobStatus estado de um job periódico (worker) registrado no monitor
+type JobStatus struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	Healthy             bool       `json:"healthy"`
+	Interval            string     `json:"interval"`
+	Started             bool       `json:"started"`
+	Running             bool       `json:"running"`
+	Runs                int64      `json:"runs"`
+	Failures            int64      `json:"failures"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastStartedAt       *time.Time `json:"last_started_at,omitempty"`
+	LastFinishedAt      *time.Time `json:"last_finished_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastDurationMs      int64      `json:"last_duration_ms"`
+	LastError           *string    `json:"last_error,omitempty"`
+	NextRunAt           *time.Time `json:"next_run_at,omitempty"`
+}
+
+// QueueDepth profundidade de uma fila de trabalho, agrupada por canal quando aplicável
+type QueueDepth struct {
+	Queue       string     `json:"queue"`
+	Channel     string     `json:"channel,omitempty"`
+	Pending     int        `json:"pending"`
+	Due         int        `json:"due"`
+	Processing  int        `json:"processing"`
+	Dead        int        `json:"dead"`
+	OldestDueAt *time.Time `json:"oldest_due_at,omitempty"`
+}
+
+// JobsOverview resumo dos jobs e filas para readiness e painel operacional.
+// Docstring: Healthy é falso se algum job estiver falhando repetidamente ou parado, ou se as filas
+// não puderem ser consultadas (Errors).
+type JobsOverview struct {
+	Healthy     bool         `json:"healthy"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Jobs        []JobStatus  `json:"jobs"`
+	Queues      []QueueDepth `json:"queues"`
+	Errors      []string     `json:"errors,omitempty"`
+}
@@ -0,0 +1,137 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Projeções reduzidas das entidades para o modo de baixa banda (X-Lite)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LiteHeader cabeçalho que ativa o modo de baixa banda nas listagens
+const LiteHeader = "X-Lite"
+
+// LiteProjector entidade com projeção reduzida para o modo X-Lite.
+// Docstring: a projeção traz só os campos essenciais (id, valor, status, vencimento ou o
+// equivalente da entidade) e nunca objetos embutidos; o cliente busca o detalhe pelo id.
+type LiteProjector interface {
+	Lite() interface{}
+}
+
+// IncomeLite projeção reduzida de receita
+type IncomeLite struct {
+	ID      uuid.UUID  `json:"id"`
+	Valor   Money      `json:"valor"`
+	Status  string     `json:"status"`
+	DueDate *time.Time `json:"due_date"`
+}
+
+// Lite implementa LiteProjector
+func (i Income) Lite() interface{} {
+	return IncomeLite{ID: i.ID, Valor: i.Valor, Status: i.Status, DueDate: i.DueDate}
+}
+
+// PaymentLite projeção reduzida de pagamento
+type PaymentLite struct {
+	ID         uuid.UUID  `json:"id"`
+	IncomeID   uuid.UUID  `json:"income_id"`
+	Valor      Money      `json:"valor"`
+	PagoEm     time.Time  `json:"pago_em"`
+	ReversedAt *time.Time `json:"reversed_at,omitempty"`
+}
+
+// Lite implementa LiteProjector
+func (p Payment) Lite() interface{} {
+	return PaymentLite{ID: p.ID, IncomeID: p.IncomeID, Valor: p.Valor, PagoEm: p.PagoEm, ReversedAt: p.ReversedAt}
+}
+
+// ReceiptLite projeção reduzida de recibo
+type ReceiptLite struct {
+	ID        uuid.UUID  `json:"id"`
+	Numero    int64      `json:"numero"`
+	Valor     *Money     `json:"valor"`
+	EmitidoEm *time.Time `json:"emitido_em"`
+}
+
+// Lite implementa LiteProjector
+func (r Receipt) Lite() interface{} {
+	return ReceiptLite{ID: r.ID, Numero: r.Numero, Valor: r.Valor, EmitidoEm: r.EmitidoEm}
+}
+
+// NamedLite projeção reduzida das entidades de cadastro (pagadores, categorias, regras, modelos)
+type NamedLite struct {
+	ID   uuid.UUID `json:"id"`
+	Nome string    `json:"nome"`
+}
+
+// Lite implementa LiteProjector
+func (p Payer) Lite() interface{} { return NamedLite{ID: p.ID, Nome: p.Nome} }
+
+// Lite implementa LiteProjector
+func (c Category) Lite() interface{} { return NamedLite{ID: c.ID, Nome: c.Nome} }
+
+// Lite implementa LiteProjector
+func (r IncomeRule) Lite() interface{} { return NamedLite{ID: r.ID, Nome: r.Nome} }
+
+// IncomeTemplateLite projeção reduzida de modelo de receita
+type IncomeTemplateLite struct {
+	ID    uuid.UUID `json:"id"`
+	Nome  string    `json:"nome"`
+	Valor Money     `json:"valor"`
+}
+
+// Lite implementa LiteProjector
+func (t IncomeTemplate) Lite() interface{} {
+	return IncomeTemplateLite{ID: t.ID, Nome: t.Nome, Valor: t.Valor}
+}
+
+// CreditLite projeção reduzida de crédito
+type CreditLite struct {
+	ID    uuid.UUID `json:"id"`
+	Valor Money     `json:"valor"`
+	Saldo Money     `json:"saldo"`
+}
+
+// Lite implementa LiteProjector
+func (c Credit) Lite() interface{} { return CreditLite{ID: c.ID, Valor: c.Valor, Saldo: c.Saldo} }
+
+// PaymentMethodLite projeção reduzida de forma de pagamento
+type PaymentMethodLite struct {
+	ID        uuid.UUID `json:"id"`
+	Tipo      string    `json:"tipo"`
+	Nome      string    `json:"nome"`
+	IsDefault bool      `json:"is_default"`
+}
+
+// Lite implementa LiteProjector
+func (m PaymentMethod) Lite() interface{} {
+	return PaymentMethodLite{ID: m.ID, Tipo: m.Tipo, Nome: m.Nome, IsDefault: m.IsDefault}
+}
+
+// StatusLite projeção reduzida das entidades de fila (entregas e envios em massa)
+type StatusLite struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+}
+
+// Lite implementa LiteProjector
+func (d Delivery) Lite() interface{} { return StatusLite{ID: d.ID, Status: d.Status} }
+
+// Lite implementa LiteProjector
+func (b Broadcast) Lite() interface{} { return StatusLite{ID: b.ID, Status: b.Status} }
+
+// LiteItems aplica a projeção reduzida a cada item; itens sem projeção seguem inteiros
+func LiteItems[T any](items []T) []interface{} {
+	out := make([]interface{}, len(items))
+	for i, it := range items {
+		if p, ok := any(it).(LiteProjector); ok {
+			out[i] = p.Lite()
+		} else {
+			out[i] = it
+		}
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Tipo monetário em centavos (evita arredondamentos de float64 em somas de pagamentos)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Money valor monetário em centavos.
+// Docstring: somas e subtrações são exatas (inteiros); no JSON é serializado como string decimal
+// ("1234.56") e aceita na entrada tanto string quanto número, para compatibilidade com clientes
+// antigos. No banco é lido e gravado como numeric.
+type Money int64
+
+// ErrInvalidMoney valor monetário malformado
+var ErrInvalidMoney = errors.New("valor monetário inválido")
+
+// NewMoney converte um valor em reais para centavos conforme DefaultRounding
+func NewMoney(reais float64) Money {
+	return DefaultRounding.FromFloat(reais)
+}
+
+// ParseMoney interpreta um decimal com ponto ("1234.5", "-0.25", "10");
+// casas além dos centavos são arredondadas conforme DefaultRounding.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	intPart, frac, hasDot := strings.Cut(s, ".")
+	if (intPart == "" && frac == "") || (hasDot && frac == "") || !isDigits(intPart) || !isDigits(frac) {
+		return 0, ErrInvalidMoney
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	reais, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || reais > math.MaxInt64/100-1 {
+		return 0, ErrInvalidMoney
+	}
+	padded := frac + "00"
+	cents := reais*100 + int64(padded[0]-'0')*10 + int64(padded[1]-'0')
+	if neg {
+		cents = -cents
+	}
+	if len(frac) <= 2 {
+		return DefaultRounding.Round(Money(cents)), nil
+	}
+	// casas além dos centavos: arredonda o valor exato (centavos + fração) uma única vez
+	extra, ok := new(big.Int).SetString(frac[2:], 10)
+	if !ok {
+		return 0, ErrInvalidMoney
+	}
+	if neg {
+		extra.Neg(extra)
+	}
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(frac)-2)), nil)
+	r := new(big.Rat).SetFrac(extra, den)
+	return DefaultRounding.RoundRat(r.Add(r, new(big.Rat).SetInt64(cents))), nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Float valor em reais (apenas para exibição e cálculos percentuais)
+func (m Money) Float() float64 {
+	return float64(m) / 100
+}
+
+// Percent aplica o percentual ao valor conforme DefaultRounding
+func (m Money) Percent(p float64) Money {
+	return DefaultRounding.Percent(m, p)
+}
+
+// String formata como decimal com duas casas ("1234.56", "-0.05")
+func (m Money) String() string {
+	sign := ""
+	c := int64(m)
+	if c < 0 {
+		sign, c = "-", -c
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, c/100, c%100)
+}
+
+// MarshalJSON serializa como string decimal
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + m.String() + `"`), nil
+}
+
+// UnmarshalJSON aceita string decimal ou número JSON; null mantém o valor atual
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := strings.TrimSpace(string(b))
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return ErrInvalidMoney
+		}
+	} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+		// número JSON: usa a representação decimal mais curta para não herdar o erro binário
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	} else {
+		return ErrInvalidMoney
+	}
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// ScanNumeric lê uma coluna numeric do banco
+func (m *Money) ScanNumeric(n pgtype.Numeric) error {
+	if !n.Valid {
+		return errors.New("não é possível ler NULL em Money")
+	}
+	if n.NaN || n.InfinityModifier != pgtype.Finite {
+		return ErrInvalidMoney
+	}
+	v := new(big.Int).Set(n.Int)
+	exp := int64(n.Exp) + 2
+	if exp >= 0 {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil))
+	} else {
+		v = DefaultRounding.roundQuo(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(-exp), nil))
+	}
+	if !v.IsInt64() {
+		return ErrInvalidMoney
+	}
+	*m = Money(v.Int64())
+	return nil
+}
+
+// NumericValue grava como numeric com duas casas
+func (m Money) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(int64(m)), Exp: -2, Valid: true}, nil
+}
@@ -0,0 +1,75 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do tipo monetário em centavos (JSON, parse e numeric do banco)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestMoney_SumsAreExact(t *testing.T) {
+	var total Money
+	for i := 0; i < 10; i++ {
+		total += NewMoney(0.1)
+	}
+	if total != NewMoney(1) || total.String() != "1.00" {
+		t.Fatalf("total = %s, want 1.00", total)
+	}
+}
+
+func TestMoney_JSONAcceptsNumbersAndStrings(t *testing.T) {
+	var p PaymentRequest
+	if err := json.Unmarshal([]byte(`{"valor": 0.29}`), &p); err != nil || p.Valor != 29 {
+		t.Fatalf("número: valor = %d, err = %v", p.Valor, err)
+	}
+	if err := json.Unmarshal([]byte(`{"valor": "1234.5"}`), &p); err != nil || p.Valor != 123450 {
+		t.Fatalf("string: valor = %d, err = %v", p.Valor, err)
+	}
+	if err := json.Unmarshal([]byte(`{"valor": "12,50"}`), &p); err == nil {
+		t.Fatalf("vírgula decimal deveria ser rejeitada no JSON")
+	}
+
+	b, _ := json.Marshal(Payment{Valor: NewMoney(-0.05)})
+	var out map[string]interface{}
+	_ = json.Unmarshal(b, &out)
+	if out["valor"] != "-0.05" {
+		t.Fatalf("valor serializado = %v, want \"-0.05\"", out["valor"])
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	cases := map[string]Money{"10": 1000, "10.5": 1050, ".75": 75, "-3.333": -333, "2.005": 201, "+1.99": 199}
+	for in, want := range cases {
+		if got, err := ParseMoney(in); err != nil || got != want {
+			t.Errorf("ParseMoney(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "-", "1.", "1.2.3", "abc", "1e3", "99999999999999999999"} {
+		if _, err := ParseMoney(in); err == nil {
+			t.Errorf("ParseMoney(%q) deveria falhar", in)
+		}
+	}
+}
+
+func TestMoney_ScanNumericRoundsToCents(t *testing.T) {
+	var m Money
+	if err := m.ScanNumeric(pgtype.Numeric{Int: big.NewInt(12345), Exp: -3, Valid: true}); err != nil || m != 1235 {
+		t.Fatalf("12.345 -> %d, err = %v", m, err)
+	}
+	if err := m.ScanNumeric(pgtype.Numeric{Int: big.NewInt(-15), Exp: 1, Valid: true}); err != nil || m != -15000 {
+		t.Fatalf("-150 -> %d, err = %v", m, err)
+	}
+	if err := m.ScanNumeric(pgtype.Numeric{}); err == nil {
+		t.Fatalf("NULL deveria falhar")
+	}
+	n, _ := NewMoney(19.9).NumericValue()
+	if n.Int.Int64() != 1990 || n.Exp != -2 {
+		t.Fatalf("numeric = %v e%d", n.Int, n.Exp)
+	}
+}
@@ -0,0 +1,190 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Relatório mensal de receitas e despesas (faturado, recebido, em aberto, vencidos e resultado) para o contador
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReportCompetenciaInvalid = errors.New("competência inválida (use AAAA-MM)")
+	ErrReportFormatInvalid      = errors.New("formato inválido (use json, pdf ou csv)")
+)
+
+// Formatos de saída do relatório mensal
+const (
+	ReportFormatJSON = "json"
+	ReportFormatPDF  = "pdf"
+	ReportFormatCSV  = "csv"
+)
+
+// MonthlyReportIncome receita da competência (ou vencida) com o pagador
+type MonthlyReportIncome struct {
+	ID          uuid.UUID  `json:"id"`
+	Competencia string     `json:"competencia"`
+	Categoria   *string    `json:"categoria"`
+	PayerNome   *string    `json:"payer_nome"`
+	DueDate     *time.Time `json:"due_date"`
+	Status      string     `json:"status"`
+	Valor       Money      `json:"valor"`
+	TotalPago   Money      `json:"total_pago"`
+	Saldo       Money      `json:"saldo"`
+}
+
+// MonthlyReportPayment pagamento recebido no mês (estornos não entram)
+type MonthlyReportPayment struct {
+	ID          uuid.UUID `json:"id"`
+	IncomeID    uuid.UUID `json:"income_id"`
+	Competencia string    `json:"competencia"`
+	Categoria   *string   `json:"categoria"`
+	PayerNome   *string   `json:"payer_nome"`
+	Valor       Money     `json:"valor"`
+	PagoEm      time.Time `json:"pago_em"`
+	Metodo      *string   `json:"metodo"`
+}
+
+// MonthlyReportExpense despesa da competência ou paga no mês
+type MonthlyReportExpense struct {
+	ID          uuid.UUID  `json:"id"`
+	Descricao   string     `json:"descricao"`
+	Fornecedor  *string    `json:"fornecedor"`
+	Categoria   *string    `json:"categoria"`
+	Competencia string     `json:"competencia"`
+	DueDate     *time.Time `json:"due_date"`
+	Status      string     `json:"status"`
+	Valor       Money      `json:"valor"`
+	PagoEm      *time.Time `json:"pago_em"`
+}
+
+// MonthlyReportTotals resumo do mês.
+// Docstring: Faturado soma as receitas da competência (canceladas fora); Recebido soma os
+// pagamentos com data no mês, de qualquer competência (regime de caixa); EmAberto é o saldo das
+// receitas da competência; Vencido é o saldo das receitas vencidas até o fim do mês. Despesas soma
+// as despesas da competência e DespesasPagas as pagas no mês; Resultado é Recebido - DespesasPagas.
+type MonthlyReportTotals struct {
+	Faturado      Money `json:"faturado"`
+	Recebido      Money `json:"recebido"`
+	EmAberto      Money `json:"em_aberto"`
+	Vencido       Money `json:"vencido"`
+	Receitas      int   `json:"receitas"`
+	Pagamentos    int   `json:"pagamentos"`
+	Vencidas      int   `json:"vencidas"`
+	Canceladas    int   `json:"canceladas"`
+	ReceitasPagas int   `json:"receitas_pagas"`
+	Despesas      Money `json:"despesas"`
+	DespesasPagas Money `json:"despesas_pagas"`
+	Resultado     Money `json:"resultado"`
+}
+
+// MonthlyReport relatório de uma competência
+type MonthlyReport struct {
+	Competencia string                 `json:"competencia"`
+	PeriodStart time.Time              `json:"period_start"`
+	PeriodEnd   time.Time              `json:"period_end"`
+	GeneratedAt time.Time              `json:"generated_at"`
+	Totals      MonthlyReportTotals    `json:"totals"`
+	Incomes     []MonthlyReportIncome  `json:"incomes"`
+	Payments    []MonthlyReportPayment `json:"payments"`
+	Overdue     []MonthlyReportIncome  `json:"overdue"`
+	Expenses    []MonthlyReportExpense `json:"expenses"`
+}
+
+// ParseReportCompetencia aceita "AAAA-MM" ou "MM/AAAA" e devolve o primeiro dia do mês
+func ParseReportCompetencia(c string) (time.Time, error) {
+	t, ok := parseCompetencia(strings.TrimSpace(c))
+	if !ok {
+		return time.Time{}, ErrReportCompetenciaInvalid
+	}
+	return t, nil
+}
+
+// CompetenciaForms formas em que a competência pode estar gravada ("2026-10" e "10/2026")
+func CompetenciaForms(month time.Time) []string {
+	forms := make([]string, 0, len(competenciaLayouts))
+	for _, layout := range competenciaLayouts {
+		forms = append(forms, month.Format(layout))
+	}
+	return forms
+}
+
+// BuildMonthlyReport calcula saldos e totais a partir das linhas lidas do banco
+func BuildMonthlyReport(month time.Time, start, end, now time.Time, incomes []MonthlyReportIncome,
+	payments []MonthlyReportPayment, overdue []MonthlyReportIncome) *MonthlyReport {
+	rep := &MonthlyReport{
+		Competencia: month.Format("2006-01"),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		GeneratedAt: now,
+		Incomes:     withSaldo(incomes),
+		Payments:    payments,
+		Overdue:     withSaldo(overdue),
+	}
+	if rep.Payments == nil {
+		rep.Payments = []MonthlyReportPayment{}
+	}
+	t := &rep.Totals
+	for _, i := range rep.Incomes {
+		if i.Status == StatusCancelado {
+			t.Canceladas++
+			continue
+		}
+		t.Receitas++
+		t.Faturado += i.Valor
+		t.EmAberto += i.Saldo
+		if i.Status == StatusPago {
+			t.ReceitasPagas++
+		}
+	}
+	for _, p := range rep.Payments {
+		t.Pagamentos++
+		t.Recebido += p.Valor
+	}
+	for _, i := range rep.Overdue {
+		t.Vencidas++
+		t.Vencido += i.Saldo
+	}
+	return rep
+}
+
+// AddExpenses inclui as despesas (da competência ou pagas no mês) e calcula o resultado de caixa
+func (rep *MonthlyReport) AddExpenses(items []MonthlyReportExpense) {
+	rep.Expenses = items
+	if rep.Expenses == nil {
+		rep.Expenses = []MonthlyReportExpense{}
+	}
+	t := &rep.Totals
+	t.Despesas, t.DespesasPagas = 0, 0
+	for _, e := range rep.Expenses {
+		if e.Status == StatusCancelado {
+			continue
+		}
+		if month, ok := parseCompetencia(e.Competencia); ok && month.Format("2006-01") == rep.Competencia {
+			t.Despesas += e.Valor
+		}
+		if e.PagoEm != nil && !e.PagoEm.Before(rep.PeriodStart) && e.PagoEm.Before(rep.PeriodEnd) {
+			t.DespesasPagas += e.Valor
+		}
+	}
+	t.Resultado = t.Recebido - t.DespesasPagas
+}
+
+// withSaldo preenche o saldo (nunca negativo; canceladas não têm saldo)
+func withSaldo(items []MonthlyReportIncome) []MonthlyReportIncome {
+	if items == nil {
+		return []MonthlyReportIncome{}
+	}
+	for k := range items {
+		items[k].Saldo = 0
+		if items[k].Status != StatusCancelado && items[k].TotalPago < items[k].Valor {
+			items[k].Saldo = items[k].Valor - items[k].TotalPago
+		}
+	}
+	return items
+}
@@ -0,0 +1,46 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes dos totais do relatório mensal
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBuildMonthlyReport_Totals(t *testing.T) {
+	month, err := ParseReportCompetencia("09/2025")
+	if err != nil || month.Format("2006-01") != "2025-09" {
+		t.Fatalf("competência = %v, %v", month, err)
+	}
+	if forms := CompetenciaForms(month); len(forms) != 2 || forms[0] != "2025-09" || forms[1] != "09/2025" {
+		t.Fatalf("formas = %v", forms)
+	}
+	if _, err := ParseReportCompetencia("2025-13"); !errors.Is(err, ErrReportCompetenciaInvalid) {
+		t.Fatalf("err = %v", err)
+	}
+
+	incomes := []MonthlyReportIncome{
+		{Status: StatusPago, Valor: NewMoney(100), TotalPago: NewMoney(110)},
+		{Status: StatusParcial, Valor: NewMoney(200), TotalPago: NewMoney(50)},
+		{Status: StatusCancelado, Valor: NewMoney(300)},
+	}
+	payments := []MonthlyReportPayment{{Valor: NewMoney(110)}, {Valor: NewMoney(50)}, {Valor: NewMoney(40)}}
+	overdue := []MonthlyReportIncome{{Status: StatusVencido, Valor: NewMoney(80), TotalPago: NewMoney(30)}}
+	rep := BuildMonthlyReport(month, month, month.AddDate(0, 1, 0), time.Now(), incomes, payments, overdue)
+
+	want := MonthlyReportTotals{Faturado: NewMoney(300), Recebido: NewMoney(200), EmAberto: NewMoney(150), Vencido: NewMoney(50),
+		Receitas: 2, Pagamentos: 3, Vencidas: 1, Canceladas: 1, ReceitasPagas: 1}
+	if rep.Totals != want {
+		t.Fatalf("totais = %+v, esperado %+v", rep.Totals, want)
+	}
+	if rep.Incomes[0].Saldo != 0 || rep.Incomes[1].Saldo != NewMoney(150) || rep.Incomes[2].Saldo != 0 {
+		t.Fatalf("saldos = %+v", rep.Incomes)
+	}
+	if empty := BuildMonthlyReport(month, month, month, time.Now(), nil, nil, nil); empty.Incomes == nil || empty.Payments == nil || empty.Overdue == nil {
+		t.Fatal("listas vazias devem ser serializadas como []")
+	}
+}
@@ -0,0 +1,280 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos de preferências de notificação e do resumo semanal (digest)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventDigestWeekly tipo de evento das entregas do resumo semanal
+const EventDigestWeekly = "digest.weekly"
+
+// Tipos de evento notificáveis configuráveis pelo usuário
+const (
+	EventIncomeDueSoon   = "income.due_soon"
+	EventIncomeOverdue   = "income.overdue"
+	EventPaymentReceived = "payment.received"
+	EventReceiptIssued   = "receipt.issued"
+)
+
+// Modos de notificação por evento e canal
+const (
+	NotificationModeImmediate = "immediate"
+	NotificationModeDigest    = "digest"
+	NotificationModeOff       = "off"
+)
+
+// NotificationEventTypes lista os eventos configuráveis, na ordem exibida ao usuário
+func NotificationEventTypes() []string {
+	return []string{EventIncomeDueSoon, EventIncomeOverdue, EventPaymentReceived, EventReceiptIssued}
+}
+
+// NotificationChannels lista os canais de notificação ao usuário (webhook é configurado à parte)
+func NotificationChannels() []string {
+	return []string{DeliveryChannelEmail, DeliveryChannelPush, DeliveryChannelWhatsApp}
+}
+
+// NotificationPreferences matriz evento -> canal -> modo (rf_notification_settings.preferences)
+type NotificationPreferences map[string]map[string]string
+
+// Mode retorna o modo configurado ou o padrão do canal: e-mail imediato, demais desligados
+func (p NotificationPreferences) Mode(eventType, channel string) string {
+	if m, ok := p[eventType][channel]; ok {
+		return m
+	}
+	if channel == DeliveryChannelEmail {
+		return NotificationModeImmediate
+	}
+	return NotificationModeOff
+}
+
+// Resolved devolve a matriz completa (eventos x canais) com os padrões preenchidos
+func (p NotificationPreferences) Resolved() NotificationPreferences {
+	out := NotificationPreferences{}
+	for _, ev := range NotificationEventTypes() {
+		out[ev] = map[string]string{}
+		for _, ch := range NotificationChannels() {
+			out[ev][ch] = p.Mode(ev, ch)
+		}
+	}
+	return out
+}
+
+// NotificationSettings representa as preferências de notificação do usuário (rf_notification_settings)
+type NotificationSettings struct {
+	OwnerID          uuid.UUID               `json:"owner_id" db:"owner_id"`
+	Email            *string                 `json:"email" db:"email"`
+	PushSubscription *string                 `json:"push_subscription" db:"push_subscription"` // legado: dispositivos em rf_push_subscriptions
+	WhatsApp         *string                 `json:"whatsapp" db:"whatsapp"`
+	DigestEnabled    bool                    `json:"digest_enabled" db:"digest_enabled"`
+	DigestChannels   []string                `json:"digest_channels" db:"digest_channels"`
+	DigestWeekday    int                     `json:"digest_weekday" db:"digest_weekday"`
+	UnsubscribeToken uuid.UUID               `json:"-" db:"unsubscribe_token"`
+	LastDigestAt     *time.Time              `json:"last_digest_at" db:"last_digest_at"`
+	Preferences      NotificationPreferences `json:"preferences" db:"preferences"`
+	AccountEmail     *string                 `json:"account_email" db:"-"`
+	PushEndpoints    []string                `json:"-" db:"-"` // endpoints dos dispositivos com push (destinos do canal)
+	CreatedAt        *time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt        *time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+// DigestPreferencesRequest atualização parcial das preferências do resumo
+type DigestPreferencesRequest struct {
+	Enabled          *bool    `json:"digest_enabled"`
+	Channels         []string `json:"digest_channels"`
+	Weekday          *int     `json:"digest_weekday"`
+	Email            *string  `json:"email"`
+	PushSubscription *string  `json:"push_subscription"`
+}
+
+// NotificationSettingsRequest atualização parcial de destinos, resumo e matriz de preferências.
+// Docstring: Preferences é mesclada à matriz atual; eventos/canais omitidos mantêm o valor salvo.
+type NotificationSettingsRequest struct {
+	DigestPreferencesRequest
+	WhatsApp    *string                 `json:"whatsapp"`
+	Preferences NotificationPreferences `json:"preferences"`
+}
+
+// DigestRecipient usuário elegível para o resumo, com o e-mail de fallback de auth.users
+type DigestRecipient struct {
+	OwnerID      uuid.UUID
+	AccountEmail *string
+	Locale       *string
+	Timezone     *string
+}
+
+// DueReminder receita a vencer reservada pelo job de lembretes, com idioma e fuso do dono
+type DueReminder struct {
+	OwnerID  uuid.UUID
+	Locale   *string
+	Timezone string
+	DigestDue
+}
+
+// DigestDue receita a vencer listada no resumo
+type DigestDue struct {
+	IncomeID    uuid.UUID `json:"income_id"`
+	Competencia string    `json:"competencia"`
+	Categoria   *string   `json:"categoria"`
+	Saldo       Money     `json:"saldo"`
+	DueDate     time.Time `json:"due_date"`
+}
+
+// DigestSummary resumo da atividade da conta no período [PeriodStart, PeriodEnd).
+// Docstring: Overdue considera o saldo em aberto na data de corte; Upcoming lista os
+// vencimentos dos próximos 7 dias a partir de PeriodEnd.
+type DigestSummary struct {
+	PeriodStart    time.Time   `json:"period_start"`
+	PeriodEnd      time.Time   `json:"period_end"`
+	Received       Money       `json:"received"`
+	ReceivedCount  int         `json:"received_count"`
+	Overdue        Money       `json:"overdue"`
+	OverdueCount   int         `json:"overdue_count"`
+	Upcoming       []DigestDue `json:"upcoming"`
+	ReceiptsIssued int         `json:"receipts_issued"`
+}
+
+// DigestPayload conteúdo enfileirado para os canais de entrega
+// Docstring: Locale/Timezone e Formatted vêm das configurações do usuário, para que e-mail e push
+// exibam valores e datas como no restante do app.
+type DigestPayload struct {
+	Summary          DigestSummary   `json:"summary"`
+	UnsubscribeToken uuid.UUID       `json:"unsubscribe_token"`
+	Locale           string          `json:"locale"`
+	Timezone         string          `json:"timezone"`
+	Formatted        DigestFormatted `json:"formatted"`
+}
+
+// DigestFormatted textos do resumo já formatados no idioma e fuso do usuário
+type DigestFormatted struct {
+	Received    string `json:"received"`
+	Overdue     string `json:"overdue"`
+	PeriodStart string `json:"period_start"`
+	PeriodEnd   string `json:"period_end"`
+}
+
+// Empty indica que não houve atividade relevante no período (resumo não é enviado)
+func (s *DigestSummary) Empty() bool {
+	return s.ReceivedCount == 0 && s.OverdueCount == 0 && len(s.Upcoming) == 0 && s.ReceiptsIssued == 0
+}
+
+// ValidDigestChannel verifica se o canal é aceito pelo resumo
+func ValidDigestChannel(ch string) bool {
+	return ch == DeliveryChannelEmail || ch == DeliveryChannelPush
+}
+
+// Validate valida e normaliza as preferências do resumo
+func (req *DigestPreferencesRequest) Validate() error {
+	if req.Channels != nil {
+		seen := map[string]bool{}
+		channels := make([]string, 0, len(req.Channels))
+		for _, ch := range req.Channels {
+			ch = strings.ToLower(strings.TrimSpace(ch))
+			if !ValidDigestChannel(ch) {
+				return ErrInvalidDigestChannel
+			}
+			if !seen[ch] {
+				seen[ch] = true
+				channels = append(channels, ch)
+			}
+		}
+		req.Channels = channels
+	}
+	if req.Weekday != nil && (*req.Weekday < 0 || *req.Weekday > 6) {
+		return ErrInvalidWeekday
+	}
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if email != "" {
+			if _, err := mail.ParseAddress(email); err != nil {
+				return ErrInvalidEmail
+			}
+		}
+		req.Email = &email
+	}
+	return nil
+}
+
+// Apply aplica a atualização parcial sobre as preferências atuais
+func (req *DigestPreferencesRequest) Apply(s *NotificationSettings) {
+	if req.Enabled != nil {
+		s.DigestEnabled = *req.Enabled
+	}
+	if req.Channels != nil {
+		s.DigestChannels = req.Channels
+	}
+	if req.Weekday != nil {
+		s.DigestWeekday = *req.Weekday
+	}
+	if req.Email != nil {
+		s.Email = nilIfEmpty(*req.Email)
+	}
+	if req.PushSubscription != nil {
+		s.PushSubscription = nilIfEmpty(strings.TrimSpace(*req.PushSubscription))
+	}
+}
+
+// Validate valida destinos e a matriz de preferências
+func (req *NotificationSettingsRequest) Validate() error {
+	if err := req.DigestPreferencesRequest.Validate(); err != nil {
+		return err
+	}
+	if req.WhatsApp != nil {
+		phone := NormalizeDocument(*req.WhatsApp) // apenas dígitos
+		if phone != "" && (len(phone) < 10 || len(phone) > 15) {
+			return ErrInvalidPhone
+		}
+		req.WhatsApp = &phone
+	}
+	events := map[string]bool{}
+	for _, ev := range NotificationEventTypes() {
+		events[ev] = true
+	}
+	for ev, channels := range req.Preferences {
+		if !events[ev] {
+			return ErrInvalidEventType
+		}
+		for ch, mode := range channels {
+			if ch != DeliveryChannelEmail && ch != DeliveryChannelPush && ch != DeliveryChannelWhatsApp {
+				return ErrInvalidNotificationChannel
+			}
+			if mode != NotificationModeImmediate && mode != NotificationModeDigest && mode != NotificationModeOff {
+				return ErrInvalidNotificationMode
+			}
+		}
+	}
+	return nil
+}
+
+// Apply aplica a atualização sobre as preferências atuais
+func (req *NotificationSettingsRequest) Apply(s *NotificationSettings) {
+	req.DigestPreferencesRequest.Apply(s)
+	if req.WhatsApp != nil {
+		s.WhatsApp = nilIfEmpty(*req.WhatsApp)
+	}
+	if len(req.Preferences) > 0 && s.Preferences == nil {
+		s.Preferences = NotificationPreferences{}
+	}
+	for ev, channels := range req.Preferences {
+		if s.Preferences[ev] == nil {
+			s.Preferences[ev] = map[string]string{}
+		}
+		for ch, mode := range channels {
+			s.Preferences[ev][ch] = mode
+		}
+	}
+}
+
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
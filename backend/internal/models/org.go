@@ -0,0 +1,144 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos das organizações (espaço compartilhado, membros, papéis e convites)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Papéis dos membros de uma organização (rf_org_members.role)
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleEditor = "editor"
+	OrgRoleViewer = "viewer"
+)
+
+// OrgInvitationTTL validade de um convite
+const OrgInvitationTTL = 7 * 24 * time.Hour
+
+// MaxOrgNameLen limite do nome da organização
+const MaxOrgNameLen = 120
+
+// Erros das organizações
+var (
+	ErrOrgNotFound           = errors.New("organização não encontrada")
+	ErrOrgExists             = errors.New("você já possui uma organização")
+	ErrOrgNameRequired       = errors.New("nome da organização é obrigatório")
+	ErrOrgNameTooLong        = errors.New("nome da organização muito longo")
+	ErrOrgRoleInvalid        = errors.New("papel inválido (use editor ou viewer)")
+	ErrOrgForbidden          = errors.New("apenas o dono da organização pode fazer isso")
+	ErrOrgMemberNotFound     = errors.New("membro não encontrado")
+	ErrOrgOwnerImmutable     = errors.New("o dono da organização não pode ser alterado nem removido")
+	ErrOrgEmailInvalid       = errors.New("e-mail do convite inválido")
+	ErrOrgInvitationNotFound = errors.New("convite não encontrado ou expirado")
+	ErrOrgInvitationEmail    = errors.New("o convite foi enviado para outro e-mail")
+	ErrOrgAlreadyMember      = errors.New("você já é membro desta organização")
+)
+
+// InvitableOrgRole verifica se o papel pode ser atribuído por convite ou alteração (não owner)
+func InvitableOrgRole(role string) bool {
+	return role == OrgRoleEditor || role == OrgRoleViewer
+}
+
+// OrgRoleCanWrite indica se o papel pode alterar os dados do espaço compartilhado
+func OrgRoleCanWrite(role string) bool {
+	return role == OrgRoleOwner || role == OrgRoleEditor
+}
+
+// Org organização: o espaço de dados do dono compartilhado com os membros.
+// Docstring: Role é o papel de quem consulta; Members só vem na consulta individual.
+type Org struct {
+	ID        uuid.UUID   `json:"id"`
+	OwnerID   uuid.UUID   `json:"owner_id"`
+	Nome      string      `json:"nome"`
+	Role      string      `json:"role,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt *time.Time  `json:"updated_at,omitempty"`
+	Members   []OrgMember `json:"members,omitempty"`
+}
+
+// OrgMember membro da organização
+type OrgMember struct {
+	OrgID     uuid.UUID  `json:"org_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Email     *string    `json:"email,omitempty"`
+	Role      string     `json:"role"`
+	InvitedBy *uuid.UUID `json:"invited_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// OrgMembership vínculo resolvido de um usuário com uma organização (escopo das requisições)
+type OrgMembership struct {
+	OrgID            uuid.UUID
+	WorkspaceOwnerID uuid.UUID
+	Role             string
+}
+
+// OrgInvitation convite para a organização.
+// Docstring: Token só é preenchido na criação; o banco guarda apenas o hash.
+type OrgInvitation struct {
+	ID         uuid.UUID  `json:"id"`
+	OrgID      uuid.UUID  `json:"org_id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	InvitedBy  uuid.UUID  `json:"invited_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	Token      string     `json:"token,omitempty"`
+}
+
+// OrgRequest criação ou renomeação da organização
+type OrgRequest struct {
+	Nome string `json:"nome"`
+}
+
+// Validate normaliza e valida o nome
+func (req *OrgRequest) Validate() error {
+	req.Nome = strings.TrimSpace(req.Nome)
+	if req.Nome == "" {
+		return ErrOrgNameRequired
+	}
+	if utf8.RuneCountInString(req.Nome) > MaxOrgNameLen {
+		return ErrOrgNameTooLong
+	}
+	return nil
+}
+
+// OrgInvitationRequest convite de um novo membro
+type OrgInvitationRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// Validate normaliza o e-mail (minúsculo) e valida o papel
+func (req *OrgInvitationRequest) Validate() error {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if addr, err := mail.ParseAddress(req.Email); err != nil || addr.Address != req.Email {
+		return ErrOrgEmailInvalid
+	}
+	if !InvitableOrgRole(req.Role) {
+		return ErrOrgRoleInvalid
+	}
+	return nil
+}
+
+// OrgMemberUpdate troca do papel de um membro
+type OrgMemberUpdate struct {
+	Role string `json:"role"`
+}
+
+// OrgAcceptRequest aceite de convite
+type OrgAcceptRequest struct {
+	Token string `json:"token"`
+}
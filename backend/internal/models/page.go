@@ -0,0 +1,62 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Envelope de paginação genérico compartilhado pelas listagens (Page[T])
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCursor cursor de paginação malformado
+var ErrInvalidCursor = errors.New("cursor de paginação inválido")
+
+// Page é o envelope padrão das listagens (/api/v2 ou Accept-Profile: page).
+// Docstring: NextCursor é opaco para o cliente e nulo na última página; enviar ?cursor=
+// equivale a pedir a próxima página com os mesmos filtros.
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	Total      int     `json:"total"`
+	Page       int     `json:"page"`
+	PerPage    int     `json:"per_page"`
+	TotalPages int     `json:"total_pages"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// NewPage monta o envelope calculando total de páginas e o cursor da próxima página
+func NewPage[T any](items []T, total, page, perPage int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	p := Page[T]{Items: items, Total: total, Page: page, PerPage: perPage}
+	if perPage > 0 {
+		p.TotalPages = (total + perPage - 1) / perPage
+	}
+	if page < p.TotalPages {
+		c := EncodePageCursor(page + 1)
+		p.NextCursor = &c
+	}
+	return p
+}
+
+// EncodePageCursor gera o cursor opaco de uma página
+func EncodePageCursor(page int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("p:" + strconv.Itoa(page)))
+}
+
+// DecodePageCursor devolve a página representada pelo cursor
+func DecodePageCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(string(raw), "p:"))
+	if err != nil || !strings.HasPrefix(string(raw), "p:") || n < 1 {
+		return 0, ErrInvalidCursor
+	}
+	return n, nil
+}
@@ -0,0 +1,36 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do envelope de paginação Page[T] e do cursor opaco
+// Data: 18-10-2026
+
+package models
+
+import "testing"
+
+func TestNewPage_NextCursor(t *testing.T) {
+	p := NewPage([]int{1, 2}, 5, 2, 2)
+	if p.TotalPages != 3 || p.NextCursor == nil {
+		t.Fatalf("total_pages=%d next=%v, want 3 e cursor", p.TotalPages, p.NextCursor)
+	}
+	if n, err := DecodePageCursor(*p.NextCursor); err != nil || n != 3 {
+		t.Fatalf("cursor decodificado = %d (%v), want 3", n, err)
+	}
+
+	last := NewPage([]int{5}, 5, 3, 2)
+	if last.NextCursor != nil {
+		t.Fatalf("última página não deveria ter next_cursor")
+	}
+
+	empty := NewPage[int](nil, 0, 1, 10)
+	if empty.Items == nil || empty.TotalPages != 0 {
+		t.Fatalf("página vazia deveria serializar items como []")
+	}
+}
+
+func TestDecodePageCursor_Invalid(t *testing.T) {
+	for _, c := range []string{"not-a-cursor", "%%%", EncodePageCursor(0)} {
+		if _, err := DecodePageCursor(c); err != ErrInvalidCursor {
+			t.Fatalf("DecodePageCursor(%q) err = %v, want ErrInvalidCursor", c, err)
+		}
+	}
+}
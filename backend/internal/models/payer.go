@@ -0,0 +1,105 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos de pagadores/clientes (rf_payers)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payer representa uma pessoa ou empresa que paga o usuário (rf_payers)
+type Payer struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	OwnerID   uuid.UUID  `json:"owner_id" db:"owner_id"`
+	Nome      string     `json:"nome" db:"nome"`
+	Documento *string    `json:"documento" db:"documento"`
+	Email     *string    `json:"email" db:"email"`
+	Telefone  *string    `json:"telefone" db:"telefone"`
+	Endereco  *string    `json:"endereco" db:"endereco"`
+	Cidade    *string    `json:"cidade" db:"cidade"`
+	UF        *string    `json:"uf" db:"uf"`
+	CEP       *string    `json:"cep" db:"cep"`
+	CreatedAt *time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// PayerRequest representa os dados de entrada para criar/atualizar pagador
+type PayerRequest struct {
+	Nome      string  `json:"nome" validate:"required"`
+	Documento *string `json:"documento"` // CPF ou CNPJ, com ou sem pontuação
+	Email     *string `json:"email"`
+	Telefone  *string `json:"telefone"`
+	Endereco  *string `json:"endereco"`
+	Cidade    *string `json:"cidade"`
+	UF        *string `json:"uf"`
+	CEP       *string `json:"cep"`
+}
+
+// PayerFilter representa os filtros da listagem de pagadores
+type PayerFilter struct {
+	Search  string `json:"search"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+}
+
+// PayerListResponse resposta paginada de pagadores
+type PayerListResponse struct {
+	Items      []Payer `json:"items"`
+	Total      int     `json:"total"`
+	Page       int     `json:"page"`
+	PerPage    int     `json:"per_page"`
+	TotalPages int     `json:"total_pages"`
+}
+
+// Validate valida e normaliza os dados do pagador.
+// Docstring: documento é reduzido a dígitos e validado como CPF/CNPJ; UF em maiúsculas.
+func (req *PayerRequest) Validate() error {
+	req.Nome = strings.TrimSpace(req.Nome)
+	if req.Nome == "" {
+		return ErrPayerNameRequired
+	}
+	if req.Documento != nil {
+		doc := NormalizeDocument(*req.Documento)
+		if doc == "" {
+			req.Documento = nil
+		} else if !ValidDocument(doc) {
+			return ErrInvalidDocument
+		} else {
+			req.Documento = &doc
+		}
+	}
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if email == "" {
+			req.Email = nil
+		} else if _, err := mail.ParseAddress(email); err != nil {
+			return ErrInvalidEmail
+		} else {
+			req.Email = &email
+		}
+	}
+	if req.UF != nil {
+		uf := strings.ToUpper(strings.TrimSpace(*req.UF))
+		if uf != "" && len(uf) != 2 {
+			return ErrInvalidUF
+		}
+		req.UF = &uf
+	}
+	return nil
+}
+
+// SetDefaults define valores padrão para o filtro
+func (f *PayerFilter) SetDefaults() {
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+	if f.PerPage <= 0 || f.PerPage > 100 {
+		f.PerPage = 20
+	}
+}
@@ -0,0 +1,120 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Extrato do pagador (receitas, pagamentos, estornos e recibos com saldo corrente)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de lançamento do extrato
+const (
+	StatementIncome   = "income"   // receita lançada (débito do pagador)
+	StatementPayment  = "payment"  // pagamento recebido (crédito)
+	StatementReversal = "reversal" // estorno de pagamento (volta a débito)
+	StatementReceipt  = "receipt"  // recibo emitido (informativo, não altera o saldo)
+)
+
+var ErrStatementRange = errors.New("período inválido: from deve ser anterior ou igual a to (AAAA-MM-DD)")
+
+// statementKindOrder ordem dos lançamentos no mesmo instante: cobrança, pagamento, estorno, recibo
+var statementKindOrder = map[string]int{StatementIncome: 0, StatementPayment: 1, StatementReversal: 2, StatementReceipt: 3}
+
+// StatementEntry lançamento do extrato.
+// Docstring: At é o vencimento da receita (data, meia-noite UTC; sem vencimento, a criação), a data
+// do pagamento, do estorno ou da emissão do recibo. Balance é o saldo devedor após o lançamento
+// (negativo = crédito do pagador).
+type StatementEntry struct {
+	Kind          string    `json:"kind"`
+	RefID         uuid.UUID `json:"ref_id"`
+	IncomeID      uuid.UUID `json:"income_id"`
+	At            time.Time `json:"at"`
+	Competencia   *string   `json:"competencia"`
+	Categoria     *string   `json:"categoria"`
+	Metodo        *string   `json:"metodo,omitempty"`
+	ReceiptNumero *int64    `json:"receipt_numero,omitempty"`
+	Valor         Money     `json:"valor"`
+	Debit         Money     `json:"debit"`
+	Credit        Money     `json:"credit"`
+	Balance       Money     `json:"balance"`
+	DateOnly      bool      `json:"-"` // At é uma data (vencimento), sem fuso
+}
+
+// PayerStatement extrato de um pagador no período
+type PayerStatement struct {
+	Payer          Payer            `json:"payer"`
+	From           *string          `json:"from"`
+	To             *string          `json:"to"`
+	OpeningBalance Money            `json:"opening_balance"`
+	Debits         Money            `json:"debits"`
+	Credits        Money            `json:"credits"`
+	ClosingBalance Money            `json:"closing_balance"`
+	Entries        []StatementEntry `json:"entries"`
+	GeneratedAt    time.Time        `json:"generated_at"`
+}
+
+// BuildPayerStatement ordena os lançamentos e calcula o saldo corrente.
+// Docstring: from/to são datas locais (inclusive) comparadas no fuso loc; lançamentos anteriores
+// a from entram no saldo de abertura e os posteriores a to ficam de fora.
+func BuildPayerStatement(payer *Payer, entries []StatementEntry, from, to *time.Time, loc *time.Location, now time.Time) (*PayerStatement, error) {
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, ErrStatementRange
+	}
+	st := &PayerStatement{Payer: *payer, Entries: []StatementEntry{}, GeneratedAt: now}
+	if from != nil {
+		v := from.Format("2006-01-02")
+		st.From = &v
+	}
+	if to != nil {
+		v := to.Format("2006-01-02")
+		st.To = &v
+	}
+	day := func(e *StatementEntry) string {
+		if e.DateOnly {
+			return e.At.UTC().Format("2006-01-02")
+		}
+		return e.At.In(loc).Format("2006-01-02")
+	}
+	sort.SliceStable(entries, func(a, b int) bool {
+		da, db := day(&entries[a]), day(&entries[b])
+		if da != db {
+			return da < db
+		}
+		if statementKindOrder[entries[a].Kind] != statementKindOrder[entries[b].Kind] {
+			return statementKindOrder[entries[a].Kind] < statementKindOrder[entries[b].Kind]
+		}
+		return entries[a].At.Before(entries[b].At)
+	})
+
+	balance := Money(0)
+	for _, e := range entries {
+		switch e.Kind {
+		case StatementIncome, StatementReversal:
+			e.Debit = e.Valor
+		case StatementPayment:
+			e.Credit = e.Valor
+		}
+		d := day(&e)
+		if st.From != nil && d < *st.From {
+			balance += e.Debit - e.Credit
+			st.OpeningBalance = balance
+			continue
+		}
+		if st.To != nil && d > *st.To {
+			continue
+		}
+		balance += e.Debit - e.Credit
+		e.Balance = balance
+		st.Debits += e.Debit
+		st.Credits += e.Credit
+		st.Entries = append(st.Entries, e)
+	}
+	st.ClosingBalance = st.OpeningBalance + st.Debits - st.Credits
+	return st, nil
+}
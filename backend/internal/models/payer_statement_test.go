@@ -0,0 +1,53 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do saldo corrente do extrato do pagador
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBuildPayerStatement_RunningBalance(t *testing.T) {
+	sp, _ := time.LoadLocation("America/Sao_Paulo")
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+	entries := []StatementEntry{
+		{Kind: StatementReceipt, At: time.Date(2026, 9, 10, 15, 0, 0, 0, time.UTC), Valor: NewMoney(100)},
+		{Kind: StatementPayment, At: time.Date(2026, 9, 10, 14, 0, 0, 0, time.UTC), Valor: NewMoney(100)},
+		{Kind: StatementIncome, At: day(9, 10), DateOnly: true, Valor: NewMoney(100)},
+		{Kind: StatementIncome, At: day(8, 10), DateOnly: true, Valor: NewMoney(100)},
+		{Kind: StatementIncome, At: day(10, 10), DateOnly: true, Valor: NewMoney(100)},
+		// 01:00 UTC de 11/10 é 10/10 em São Paulo: fica no período
+		{Kind: StatementPayment, At: time.Date(2026, 10, 11, 1, 0, 0, 0, time.UTC), Valor: NewMoney(40)},
+		{Kind: StatementReversal, At: time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC), Valor: NewMoney(100)},
+	}
+	from, to := day(9, 1), day(10, 10)
+	st, err := BuildPayerStatement(&Payer{Nome: "Maria"}, entries, &from, &to, sp, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.OpeningBalance != NewMoney(100) || st.Debits != NewMoney(200) || st.Credits != NewMoney(140) || st.ClosingBalance != NewMoney(160) {
+		t.Fatalf("extrato = abertura %s, débitos %s, créditos %s, saldo %s", st.OpeningBalance, st.Debits, st.Credits, st.ClosingBalance)
+	}
+	kinds := []string{StatementIncome, StatementPayment, StatementReceipt, StatementIncome, StatementPayment}
+	balances := []Money{NewMoney(200), NewMoney(100), NewMoney(100), NewMoney(200), NewMoney(160)}
+	if len(st.Entries) != len(kinds) {
+		t.Fatalf("lançamentos = %+v", st.Entries)
+	}
+	for k, e := range st.Entries {
+		if e.Kind != kinds[k] || e.Balance != balances[k] {
+			t.Errorf("lançamento %d = %s saldo %s, esperado %s saldo %s", k, e.Kind, e.Balance, kinds[k], balances[k])
+		}
+	}
+
+	all, _ := BuildPayerStatement(&Payer{}, entries, nil, nil, sp, time.Now())
+	if all.From != nil || all.ClosingBalance != NewMoney(260) || len(all.Entries) != len(entries) {
+		t.Fatalf("histórico completo = %+v", all)
+	}
+	if _, err := BuildPayerStatement(&Payer{}, nil, &to, &from, sp, time.Now()); !errors.Is(err, ErrStatementRange) {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Links de pagamento (checkout hospedado no Stripe ou Mercado Pago) das receitas (rf_payment_links)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status de um link de pagamento
+const (
+	PaymentLinkOpen     = "open"
+	PaymentLinkPaid     = "paid"
+	PaymentLinkExpired  = "expired"
+	PaymentLinkCanceled = "canceled"
+)
+
+// Limites da validade do link (expiracao, em segundos); o Stripe aceita de 30 minutos a 24 horas
+const (
+	MinPaymentLinkExpiration = 30 * time.Minute
+	MaxPaymentLinkExpiration = 24 * time.Hour
+)
+
+// Erros dos links de pagamento
+var (
+	ErrCheckoutUnavailable      = errors.New("links de pagamento não configurados no servidor")
+	ErrPaymentLinkNotFound      = errors.New("link de pagamento não encontrado")
+	ErrPaymentLinkIncomeClosed  = errors.New("receita paga ou cancelada não aceita link de pagamento")
+	ErrPaymentLinkNothingDue    = errors.New("receita sem saldo a receber")
+	ErrPaymentLinkAmountInvalid = errors.New("valor do link de pagamento deve ser maior que zero")
+	ErrPaymentLinkExpiration    = errors.New("expiração do link de pagamento deve ficar entre 30 minutos e 24 horas")
+	ErrCheckoutProviderFailed   = errors.New("falha ao criar o checkout no gateway de pagamento")
+	ErrCheckoutWebhookAuth      = errors.New("assinatura do webhook de checkout inválida")
+	ErrCheckoutWebhookPayload   = errors.New("notificação de checkout malformada")
+)
+
+// PaymentLink sessão de checkout hospedado criada no gateway para uma receita.
+// Docstring: URL é a página de pagamento enviada ao cliente; o id do link vai como referência
+// ao gateway e casa a notificação de pagamento com a receita. Pago pelo webhook, guarda o id do
+// pagamento no gateway e o pagamento lançado na receita.
+type PaymentLink struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	OwnerID           uuid.UUID  `json:"owner_id" db:"owner_id"`
+	IncomeID          uuid.UUID  `json:"income_id" db:"income_id"`
+	Provider          string     `json:"provider" db:"provider"`
+	SessionID         string     `json:"session_id" db:"session_id"`
+	URL               string     `json:"url" db:"url"`
+	Valor             Money      `json:"valor" db:"valor"`
+	Status            string     `json:"status" db:"status"`
+	ExpiresAt         time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	PaidAt            *time.Time `json:"paid_at" db:"paid_at"`
+	ProviderPaymentID *string    `json:"provider_payment_id" db:"provider_payment_id"`
+	PaymentID         *uuid.UUID `json:"payment_id" db:"payment_id"`
+}
+
+// PaymentLinkRequest corpo opcional de POST /incomes/{id}/payment-link
+type PaymentLinkRequest struct {
+	Valor     *Money `json:"valor"`     // padrão: saldo a receber da receita
+	Expiracao *int   `json:"expiracao"` // segundos; padrão 24h
+}
+
+// Validate confere valor e validade informados
+func (req *PaymentLinkRequest) Validate() error {
+	if req.Valor != nil && *req.Valor <= 0 {
+		return ErrPaymentLinkAmountInvalid
+	}
+	if req.Expiracao != nil {
+		d := time.Duration(*req.Expiracao) * time.Second
+		if d < MinPaymentLinkExpiration || d > MaxPaymentLinkExpiration {
+			return ErrPaymentLinkExpiration
+		}
+	}
+	return nil
+}
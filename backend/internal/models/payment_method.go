@@ -0,0 +1,175 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Catálogo de formas de pagamento do usuário (rf_payment_methods)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de forma de pagamento
+const (
+	PaymentMethodPix           = "pix"
+	PaymentMethodTransferencia = "transferencia"
+	PaymentMethodDinheiro      = "dinheiro"
+	PaymentMethodCartao        = "cartao"
+	PaymentMethodBoleto        = "boleto"
+	PaymentMethodOutro         = "outro"
+)
+
+// MaxPaymentMethodNameLen tamanho máximo do nome exibido
+const MaxPaymentMethodNameLen = 60
+
+// Erros do catálogo de formas de pagamento
+var (
+	ErrPaymentMethodNotFound     = errors.New("forma de pagamento não encontrada")
+	ErrPaymentMethodArchived     = errors.New("forma de pagamento arquivada não aceita novos pagamentos")
+	ErrPaymentMethodTypeInvalid  = errors.New("tipo de forma de pagamento inválido (use pix, transferencia, dinheiro, cartao, boleto ou outro)")
+	ErrPaymentMethodNameInvalid  = errors.New("nome da forma de pagamento deve ter até 60 caracteres")
+	ErrPaymentMethodNameTaken    = errors.New("já existe uma forma de pagamento com esse nome")
+	ErrPaymentMethodPixKey       = errors.New("chave PIX inválida (CPF, CNPJ, e-mail, telefone +55 ou chave aleatória)")
+	ErrPaymentMethodBankMetadata = errors.New("banco, agência e conta só se aplicam a transferências")
+)
+
+var paymentMethodLabels = map[string]string{
+	PaymentMethodPix:           "PIX",
+	PaymentMethodTransferencia: "Transferência",
+	PaymentMethodDinheiro:      "Dinheiro",
+	PaymentMethodCartao:        "Cartão",
+	PaymentMethodBoleto:        "Boleto",
+	PaymentMethodOutro:         "Outro",
+}
+
+// ValidPaymentMethodType verifica se o tipo é suportado
+func ValidPaymentMethodType(tipo string) bool {
+	_, ok := paymentMethodLabels[tipo]
+	return ok
+}
+
+// PaymentMethod forma de pagamento do catálogo do usuário.
+// Docstring: PixChave vale para o tipo pix; Banco, Agencia e Conta para transferências.
+// Formas arquivadas continuam nos pagamentos antigos mas não aceitam novos lançamentos.
+type PaymentMethod struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	OwnerID    uuid.UUID  `json:"owner_id" db:"owner_id"`
+	Tipo       string     `json:"tipo" db:"tipo"`
+	Nome       string     `json:"nome" db:"nome"`
+	IsDefault  bool       `json:"is_default" db:"is_default"`
+	PixChave   *string    `json:"pix_chave" db:"pix_chave"`
+	Banco      *string    `json:"banco" db:"banco"`
+	Agencia    *string    `json:"agencia" db:"agencia"`
+	Conta      *string    `json:"conta" db:"conta"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// PaymentMethodRequest dados de entrada para criar/atualizar uma forma de pagamento
+type PaymentMethodRequest struct {
+	Tipo      string  `json:"tipo"`
+	Nome      string  `json:"nome"` // vazio: rótulo do tipo (ex.: "PIX")
+	IsDefault bool    `json:"is_default"`
+	PixChave  *string `json:"pix_chave"`
+	Banco     *string `json:"banco"`
+	Agencia   *string `json:"agencia"`
+	Conta     *string `json:"conta"`
+}
+
+// PaymentMethodPaymentsFilter paginação dos pagamentos de uma forma de pagamento
+type PaymentMethodPaymentsFilter struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+// SetDefaults define valores padrão para o filtro
+func (f *PaymentMethodPaymentsFilter) SetDefaults() {
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+	if f.PerPage <= 0 || f.PerPage > 100 {
+		f.PerPage = 20
+	}
+}
+
+var pixPhonePattern = regexp.MustCompile(`^\+55\d{10,11}$`)
+
+// trimOptional remove espaços de um campo opcional; vazio vira nil
+func trimOptional(p **string) {
+	if *p == nil {
+		return
+	}
+	v := strings.TrimSpace(**p)
+	if v == "" {
+		*p = nil
+		return
+	}
+	*p = &v
+}
+
+// Validate valida e normaliza a forma de pagamento.
+// Docstring: a chave PIX é opcional (a forma pode só identificar o meio), mas quando informada
+// precisa ser CPF/CNPJ válido, e-mail, telefone no formato +55DDNNNNNNNNN ou chave aleatória (UUID).
+func (req *PaymentMethodRequest) Validate() error {
+	req.Tipo = strings.ToLower(strings.TrimSpace(req.Tipo))
+	if !ValidPaymentMethodType(req.Tipo) {
+		return ErrPaymentMethodTypeInvalid
+	}
+	req.Nome = strings.TrimSpace(req.Nome)
+	if req.Nome == "" {
+		req.Nome = paymentMethodLabels[req.Tipo]
+	}
+	if len([]rune(req.Nome)) > MaxPaymentMethodNameLen {
+		return ErrPaymentMethodNameInvalid
+	}
+	for _, p := range []**string{&req.PixChave, &req.Banco, &req.Agencia, &req.Conta} {
+		trimOptional(p)
+	}
+	if req.PixChave != nil {
+		key, ok := NormalizePixKey(*req.PixChave)
+		if req.Tipo != PaymentMethodPix || !ok {
+			return ErrPaymentMethodPixKey
+		}
+		req.PixChave = &key
+	}
+	if req.Tipo != PaymentMethodTransferencia && (req.Banco != nil || req.Agencia != nil || req.Conta != nil) {
+		return ErrPaymentMethodBankMetadata
+	}
+	return nil
+}
+
+// NormalizePixKey valida a chave PIX e retorna sua forma canônica
+func NormalizePixKey(key string) (string, bool) {
+	key = strings.TrimSpace(key)
+	if _, err := uuid.Parse(key); err == nil && len(key) == 36 {
+		return strings.ToLower(key), true
+	}
+	if strings.Contains(key, "@") {
+		if addr, err := mail.ParseAddress(key); err == nil && addr.Address == key {
+			return strings.ToLower(key), true
+		}
+		return "", false
+	}
+	if strings.HasPrefix(key, "+") {
+		phone := "+" + NormalizeDocument(key)
+		return phone, pixPhonePattern.MatchString(phone)
+	}
+	doc := NormalizeDocument(key)
+	return doc, ValidDocument(doc)
+}
+
+// DefaultPaymentMethods formas criadas no primeiro acesso ao catálogo (PIX como padrão)
+func DefaultPaymentMethods() []PaymentMethodRequest {
+	return []PaymentMethodRequest{
+		{Tipo: PaymentMethodPix, Nome: paymentMethodLabels[PaymentMethodPix], IsDefault: true},
+		{Tipo: PaymentMethodTransferencia, Nome: paymentMethodLabels[PaymentMethodTransferencia]},
+		{Tipo: PaymentMethodDinheiro, Nome: paymentMethodLabels[PaymentMethodDinheiro]},
+	}
+}
@@ -0,0 +1,60 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da validação das formas de pagamento e das chaves PIX
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizePixKey(t *testing.T) {
+	cases := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"529.982.247-25", "52998224725", true},
+		{"52.998.224/0001-11", "", false},
+		{"Fulano@Exemplo.com", "fulano@exemplo.com", true},
+		{"+55 (11) 98888-7777", "+5511988887777", true},
+		{"+1 555 0100", "", false},
+		{"0F9C5A3E-1B2D-4C5E-8F90-1A2B3C4D5E6F", "0f9c5a3e-1b2d-4c5e-8f90-1a2b3c4d5e6f", true},
+	}
+	for _, c := range cases {
+		got, ok := NormalizePixKey(c.in)
+		if ok != c.ok || (ok && got != c.want) {
+			t.Errorf("NormalizePixKey(%q) = %q, %v; want %q, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestPaymentMethodRequest_Validate(t *testing.T) {
+	s := func(v string) *string { return &v }
+
+	req := &PaymentMethodRequest{Tipo: " PIX ", PixChave: s("fulano@exemplo.com")}
+	if err := req.Validate(); err != nil || req.Tipo != PaymentMethodPix || req.Nome != "PIX" {
+		t.Fatalf("pix com chave: %+v, %v", req, err)
+	}
+	req = &PaymentMethodRequest{Tipo: "transferencia", Nome: "Conta Itaú", Banco: s("341"), Agencia: s(" 0001 "), Conta: s("  ")}
+	if err := req.Validate(); err != nil || *req.Agencia != "0001" || req.Conta != nil {
+		t.Fatalf("transferência: %+v, %v", req, err)
+	}
+
+	cases := []struct {
+		name string
+		req  PaymentMethodRequest
+		want error
+	}{
+		{"tipo desconhecido", PaymentMethodRequest{Tipo: "cheque"}, ErrPaymentMethodTypeInvalid},
+		{"chave fora do pix", PaymentMethodRequest{Tipo: "dinheiro", PixChave: s("fulano@exemplo.com")}, ErrPaymentMethodPixKey},
+		{"chave inválida", PaymentMethodRequest{Tipo: "pix", PixChave: s("123")}, ErrPaymentMethodPixKey},
+		{"banco fora da transferência", PaymentMethodRequest{Tipo: "pix", Banco: s("341")}, ErrPaymentMethodBankMetadata},
+	}
+	for _, c := range cases {
+		if err := c.req.Validate(); !errors.Is(err, c.want) {
+			t.Errorf("%s: err = %v, want %v", c.name, err, c.want)
+		}
+	}
+}
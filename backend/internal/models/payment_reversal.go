@@ -0,0 +1,90 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Exclusão e estorno de pagamentos (pedido, efeito na receita e auditoria)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de desfazimento de pagamento (rf_payment_reversals.kind)
+const (
+	PaymentReversalDelete  = "delete"  // lançamento errado: o pagamento é excluído
+	PaymentReversalReverse = "reverse" // estorno: o pagamento fica registrado, mas não conta no total
+)
+
+// MaxReversalReasonLen limite do motivo registrado na auditoria
+const MaxReversalReasonLen = 500
+
+// Erros de exclusão e estorno de pagamentos
+var (
+	ErrPaymentAlreadyReversed = errors.New("pagamento já estornado")
+	ErrReversalReasonRequired = errors.New("motivo do estorno é obrigatório")
+	ErrReversalReasonTooLong  = errors.New("motivo muito longo (máximo 500 caracteres)")
+)
+
+// PaymentReversalRequest pedido de exclusão ou estorno; o motivo é obrigatório no estorno
+type PaymentReversalRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Validate valida o motivo para o tipo informado
+func (req *PaymentReversalRequest) Validate(kind string) error {
+	req.Reason = strings.TrimSpace(req.Reason)
+	if utf8.RuneCountInString(req.Reason) > MaxReversalReasonLen {
+		return ErrReversalReasonTooLong
+	}
+	if kind == PaymentReversalReverse && req.Reason == "" {
+		return ErrReversalReasonRequired
+	}
+	return nil
+}
+
+// PaymentReversal registro de auditoria de uma exclusão ou estorno (rf_payment_reversals)
+type PaymentReversal struct {
+	ID              uuid.UUID `json:"id" db:"id"`
+	OwnerID         uuid.UUID `json:"owner_id" db:"owner_id"`
+	PaymentID       uuid.UUID `json:"payment_id" db:"payment_id"`
+	IncomeID        uuid.UUID `json:"income_id" db:"income_id"`
+	Kind            string    `json:"kind" db:"kind"`
+	Valor           Money     `json:"valor" db:"valor"`
+	Payment         Payment   `json:"payment" db:"payment"` // cópia do pagamento antes da operação
+	Reason          *string   `json:"reason" db:"reason"`
+	TotalPagoBefore Money     `json:"total_pago_before" db:"total_pago_before"`
+	TotalPagoAfter  Money     `json:"total_pago_after" db:"total_pago_after"`
+	StatusBefore    string    `json:"status_before" db:"status_before"`
+	StatusAfter     string    `json:"status_after" db:"status_after"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// PaymentReversalResponse resultado da operação com a receita atualizada
+type PaymentReversalResponse struct {
+	Reversal PaymentReversal `json:"reversal"`
+	Income   Income          `json:"income"`
+}
+
+// DeriveIncomeStatus status da receita a partir do total pago e do vencimento.
+// Docstring: receitas canceladas mantêm o status; nas demais vale a mesma regra da listagem
+// (pago, parcial, vencido ou pendente). Vencida só a partir do dia seguinte ao vencimento no fuso
+// do usuário (loc), como no job diário.
+func DeriveIncomeStatus(current string, valor, totalPago Money, due *time.Time, now time.Time, loc *time.Location) string {
+	switch {
+	case current == StatusCancelado:
+		return current
+	case totalPago >= valor:
+		return StatusPago
+	case totalPago > 0:
+		return StatusParcial
+	case due != nil && PastDue(*due, now, loc):
+		return StatusVencido
+	default:
+		return StatusPendente
+	}
+}
@@ -0,0 +1,65 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do pedido de estorno e do status derivado da receita
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPaymentReversalRequest_Validate(t *testing.T) {
+	del := &PaymentReversalRequest{Reason: "  "}
+	if err := del.Validate(PaymentReversalDelete); err != nil || del.Reason != "" {
+		t.Fatalf("exclusão sem motivo deveria valer: err=%v reason=%q", err, del.Reason)
+	}
+	if err := (&PaymentReversalRequest{}).Validate(PaymentReversalReverse); !errors.Is(err, ErrReversalReasonRequired) {
+		t.Fatalf("estorno sem motivo: err = %v", err)
+	}
+	long := &PaymentReversalRequest{Reason: strings.Repeat("é", MaxReversalReasonLen+1)}
+	if err := long.Validate(PaymentReversalDelete); !errors.Is(err, ErrReversalReasonTooLong) {
+		t.Fatalf("motivo longo: err = %v", err)
+	}
+}
+
+func TestDeriveIncomeStatus(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	past, future := now.AddDate(0, 0, -1), now.AddDate(0, 0, 1)
+	valor := NewMoney(100)
+	cases := []struct {
+		current string
+		total   Money
+		due     *time.Time
+		want    string
+	}{
+		{StatusPago, valor, &past, StatusPago},
+		{StatusPago, NewMoney(40), &past, StatusParcial},
+		{StatusPago, 0, &past, StatusVencido},
+		{StatusParcial, 0, &future, StatusPendente},
+		{StatusParcial, 0, nil, StatusPendente},
+		{StatusCancelado, 0, &past, StatusCancelado},
+	}
+	for _, c := range cases {
+		if got := DeriveIncomeStatus(c.current, valor, c.total, c.due, now, time.UTC); got != c.want {
+			t.Errorf("DeriveIncomeStatus(%s, total=%d) = %s, esperado %s", c.current, c.total, got, c.want)
+		}
+	}
+
+	// 22h de 18/10 em São Paulo já é 19/10 em UTC: a receita que vence em 18/10 ainda não venceu
+	sp, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("tzdata indisponível: %v", err)
+	}
+	due := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	evening := time.Date(2026, 10, 19, 1, 0, 0, 0, time.UTC)
+	if got := DeriveIncomeStatus(StatusPendente, valor, 0, &due, evening, sp); got != StatusPendente {
+		t.Fatalf("vencimento hoje no fuso local: status = %s, esperado %s", got, StatusPendente)
+	}
+	if got := DeriveIncomeStatus(StatusPendente, valor, 0, &due, evening.Add(3*time.Hour), sp); got != StatusVencido {
+		t.Fatalf("após a meia-noite local: status = %s, esperado %s", got, StatusVencido)
+	}
+}
@@ -0,0 +1,89 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Cobranças PIX com QR dinâmico vinculadas às receitas (rf_pix_charges)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status de uma cobrança PIX
+const (
+	PixChargeActive   = "active"
+	PixChargePaid     = "paid"
+	PixChargeExpired  = "expired"
+	PixChargeCanceled = "canceled"
+)
+
+// Limites da validade da cobrança (expiracao, em segundos)
+const (
+	MinPixExpiration = 5 * time.Minute
+	MaxPixExpiration = 30 * 24 * time.Hour
+)
+
+// Erros das cobranças PIX
+var (
+	ErrPixUnavailable     = errors.New("cobrança PIX não configurada no servidor")
+	ErrPixChargeNotFound  = errors.New("cobrança PIX não encontrada")
+	ErrPixKeyMissing      = errors.New("cadastre uma forma de pagamento PIX com chave para gerar cobranças")
+	ErrPixNothingDue      = errors.New("receita sem saldo a receber")
+	ErrPixIncomeClosed    = errors.New("receita paga ou cancelada não aceita cobrança PIX")
+	ErrPixAmountInvalid   = errors.New("valor da cobrança PIX deve ser maior que zero")
+	ErrPixExpirationRange = errors.New("expiração da cobrança PIX deve ficar entre 5 minutos e 30 dias")
+	ErrPixPayerEmail      = errors.New("o provedor PIX exige o e-mail do pagador; cadastre-o no pagador da receita")
+	ErrPixProviderFailed  = errors.New("falha ao criar a cobrança no provedor PIX")
+	ErrPixWebhookAuth     = errors.New("assinatura do webhook PIX inválida")
+	ErrPixWebhookPayload  = errors.New("notificação PIX malformada")
+)
+
+// PixCharge cobrança PIX criada no PSP para uma receita.
+// Docstring: BRCode é o "copia e cola" (conteúdo do QR Code); TxID identifica a cobrança no PSP
+// e é usado para casar a confirmação do pagamento com a receita. Paga pelo webhook, guarda o
+// endToEndId do PIX e o pagamento lançado na receita.
+type PixCharge struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	OwnerID    uuid.UUID  `json:"owner_id" db:"owner_id"`
+	IncomeID   uuid.UUID  `json:"income_id" db:"income_id"`
+	Provider   string     `json:"provider" db:"provider"`
+	TxID       string     `json:"txid" db:"txid"`
+	Valor      Money      `json:"valor" db:"valor"`
+	BRCode     string     `json:"br_code" db:"br_code"`
+	Location   *string    `json:"location" db:"location"`
+	Status     string     `json:"status" db:"status"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	PaidAt     *time.Time `json:"paid_at" db:"paid_at"`
+	EndToEndID *string    `json:"end_to_end_id" db:"end_to_end_id"`
+	PaymentID  *uuid.UUID `json:"payment_id" db:"payment_id"`
+}
+
+// PixChargeRequest corpo opcional de POST /incomes/{id}/pix
+type PixChargeRequest struct {
+	Valor     *Money `json:"valor"`     // padrão: saldo a receber da receita
+	Expiracao *int   `json:"expiracao"` // segundos; padrão 24h
+}
+
+// Validate confere valor e validade informados
+func (req *PixChargeRequest) Validate() error {
+	if req.Valor != nil && *req.Valor <= 0 {
+		return ErrPixAmountInvalid
+	}
+	if req.Expiracao != nil {
+		d := time.Duration(*req.Expiracao) * time.Second
+		if d < MinPixExpiration || d > MaxPixExpiration {
+			return ErrPixExpirationRange
+		}
+	}
+	return nil
+}
+
+// PixChargeResponse cobrança com o QR Code em PNG (data URI) para exibir ao pagador
+type PixChargeResponse struct {
+	*PixCharge
+	QRCodeImage string `json:"qr_code_image"`
+}
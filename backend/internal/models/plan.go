@@ -0,0 +1,105 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Planos, limites por plano e consumo (rf_plans, rf_usage)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultPlan plano de quem não tem app_metadata.plan (ou tem um código desconhecido)
+const DefaultPlan = "free"
+
+// Métricas limitadas por plano
+const (
+	QuotaMetricReceipts   = "receipts"   // recibos emitidos no mês
+	QuotaMetricSignatures = "signatures" // assinaturas cadastradas
+)
+
+// Plan plano com seus limites; limite nulo é ilimitado e 0 deixa o recurso fora do plano
+type Plan struct {
+	Code             string `json:"code"`
+	Nome             string `json:"nome"`
+	ReceiptsPerMonth *int   `json:"receipts_per_month"`
+	MaxSignatures    *int   `json:"max_signatures"`
+}
+
+// Limit limite do plano para a métrica (nil = ilimitado)
+func (p *Plan) Limit(metric string) *int {
+	switch metric {
+	case QuotaMetricReceipts:
+		return p.ReceiptsPerMonth
+	case QuotaMetricSignatures:
+		return p.MaxSignatures
+	}
+	return nil
+}
+
+// UsagePeriod início do mês (UTC) ao qual o consumo de now pertence
+func UsagePeriod(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// UsageMetric consumo de uma métrica; Limit e Remaining nulos quando ilimitado
+type UsageMetric struct {
+	Metric    string `json:"metric"`
+	Monthly   bool   `json:"monthly"`
+	Used      int    `json:"used"`
+	Limit     *int   `json:"limit"`
+	Remaining *int   `json:"remaining"`
+}
+
+// NewUsageMetric calcula o restante a partir do uso e do limite
+func NewUsageMetric(metric string, monthly bool, used int, limit *int) UsageMetric {
+	m := UsageMetric{Metric: metric, Monthly: monthly, Used: used, Limit: limit}
+	if limit != nil {
+		rest := *limit - used
+		if rest < 0 {
+			rest = 0
+		}
+		m.Remaining = &rest
+	}
+	return m
+}
+
+// Usage resposta de GET /api/v1/usage
+type Usage struct {
+	Plan     Plan          `json:"plan"`
+	Period   string        `json:"period"` // AAAA-MM
+	ResetsAt time.Time     `json:"resets_at"`
+	Metrics  []UsageMetric `json:"metrics"`
+}
+
+// QuotaError limite do plano atingido.
+// Docstring: Unavailable indica recurso fora do plano (limite 0, responde 403); nos demais casos o
+// limite foi consumido (402: precisa de upgrade ou esperar a virada do mês).
+type QuotaError struct {
+	Metric string `json:"metric"`
+	Plan   string `json:"plan"`
+	Limit  int    `json:"limit"`
+	Used   int    `json:"used"`
+}
+
+func (e *QuotaError) Error() string {
+	if e.Limit == 0 {
+		return fmt.Sprintf("recurso %s não disponível no plano %s", e.Metric, e.Plan)
+	}
+	return fmt.Sprintf("limite do plano %s atingido para %s (%d de %d)", e.Plan, e.Metric, e.Used, e.Limit)
+}
+
+// Unavailable recurso fora do plano (limite 0)
+func (e *QuotaError) Unavailable() bool {
+	return e.Limit == 0
+}
+
+// Code código estável do erro para o frontend
+func (e *QuotaError) Code() string {
+	if e.Unavailable() {
+		return "plan_feature_unavailable"
+	}
+	return "quota_exceeded"
+}
@@ -0,0 +1,103 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Perfil do usuário (rf_profiles: contato, emissor padrão dos recibos, idioma e fuso)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Limites dos campos de texto do perfil
+const (
+	MaxProfileNameLength    = 120
+	MaxProfileAddressLength = 300
+)
+
+// Erros do perfil
+var (
+	ErrProfileNameTooLong    = errors.New("nome muito longo (máximo de 120 caracteres)")
+	ErrProfileAddressTooLong = errors.New("endereço muito longo (máximo de 300 caracteres)")
+	ErrInvalidLocale         = errors.New("idioma não suportado")
+	ErrInvalidTimezone       = errors.New("fuso horário inválido (use um nome IANA, ex.: America/Sao_Paulo)")
+)
+
+// Profile perfil do usuário; id é o do usuário (auth.users).
+// Docstring: Nome e Documento identificam o usuário e são o emissor dos recibos quando não há
+// emissor padrão (Emissor*) nem emissor informado na emissão. Locale e Timezone prevalecem sobre
+// os de rf_settings em PDFs, notificações e datas calculadas no backend.
+type Profile struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	Nome             *string    `json:"nome" db:"nome"`
+	Documento        *string    `json:"documento" db:"documento"`
+	Endereco         *string    `json:"endereco" db:"endereco"`
+	Telefone         *string    `json:"telefone" db:"telefone"`
+	EmissorNome      *string    `json:"emissor_nome" db:"emissor_nome"`
+	EmissorDocumento *string    `json:"emissor_documento" db:"emissor_documento"`
+	Locale           *string    `json:"locale" db:"locale"`
+	Timezone         *string    `json:"timezone" db:"timezone"`
+	CreatedAt        *time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ProfileRequest dados do perfil; o PUT substitui todos os campos (nulos ou vazios apagam o valor)
+type ProfileRequest struct {
+	Nome             *string `json:"nome"`
+	Documento        *string `json:"documento"` // CPF ou CNPJ, com ou sem pontuação
+	Endereco         *string `json:"endereco"`
+	Telefone         *string `json:"telefone"`
+	EmissorNome      *string `json:"emissor_nome"`
+	EmissorDocumento *string `json:"emissor_documento"`
+	Locale           *string `json:"locale"`
+	Timezone         *string `json:"timezone"`
+}
+
+// Validate normaliza os textos e valida documentos e telefone.
+// Docstring: documentos são reduzidos a dígitos e validados como CPF/CNPJ; telefone a dígitos
+// (10 a 15, com DDD e opcionalmente DDI). Idioma e fuso são conferidos pelo serviço (pacote locale).
+func (req *ProfileRequest) Validate() error {
+	for _, f := range []**string{&req.Nome, &req.EmissorNome, &req.Endereco, &req.Locale, &req.Timezone} {
+		if *f != nil {
+			*f = nilIfEmpty(strings.TrimSpace(**f))
+		}
+	}
+	for _, f := range []*string{req.Nome, req.EmissorNome} {
+		if f != nil && utf8.RuneCountInString(*f) > MaxProfileNameLength {
+			return ErrProfileNameTooLong
+		}
+	}
+	if req.Endereco != nil && utf8.RuneCountInString(*req.Endereco) > MaxProfileAddressLength {
+		return ErrProfileAddressTooLong
+	}
+	for _, f := range []**string{&req.Documento, &req.EmissorDocumento} {
+		if *f == nil {
+			continue
+		}
+		doc := NormalizeDocument(**f)
+		if doc != "" && !ValidDocument(doc) {
+			return ErrInvalidDocument
+		}
+		*f = nilIfEmpty(doc)
+	}
+	if req.Telefone != nil {
+		phone := NormalizeDocument(*req.Telefone) // apenas dígitos
+		if phone != "" && (len(phone) < 10 || len(phone) > 15) {
+			return ErrInvalidPhone
+		}
+		req.Telefone = nilIfEmpty(phone)
+	}
+	return nil
+}
+
+// Apply copia os campos validados para o perfil
+func (req *ProfileRequest) Apply(p *Profile) {
+	p.Nome, p.Documento, p.Endereco, p.Telefone = req.Nome, req.Documento, req.Endereco, req.Telefone
+	p.EmissorNome, p.EmissorDocumento = req.EmissorNome, req.EmissorDocumento
+	p.Locale, p.Timezone = req.Locale, req.Timezone
+}
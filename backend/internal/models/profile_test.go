@@ -0,0 +1,44 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da validação do perfil do usuário
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestProfileRequest_Validate(t *testing.T) {
+	nome, doc, emissor, vazio, tel := "  Maria Souza ", "529.982.247-25", "11.222.333/0001-81", "  ", "(11) 98765-4321"
+	req := ProfileRequest{Nome: &nome, Documento: &doc, EmissorDocumento: &emissor, EmissorNome: &vazio, Telefone: &tel}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if *req.Nome != "Maria Souza" || *req.Documento != "52998224725" || *req.EmissorDocumento != "11222333000181" ||
+		req.EmissorNome != nil || *req.Telefone != "11987654321" {
+		t.Fatalf("normalizado = %+v", req)
+	}
+	var p Profile
+	req.Apply(&p)
+	if p.Nome != req.Nome || p.EmissorDocumento != req.EmissorDocumento {
+		t.Fatalf("perfil = %+v", p)
+	}
+
+	bad, curto, longo := "123.456.789-00", "1234", strings.Repeat("x", MaxProfileNameLength+1)
+	cases := []struct {
+		req  ProfileRequest
+		want error
+	}{
+		{ProfileRequest{EmissorDocumento: &bad}, ErrInvalidDocument},
+		{ProfileRequest{Telefone: &curto}, ErrInvalidPhone},
+		{ProfileRequest{EmissorNome: &longo}, ErrProfileNameTooLong},
+	}
+	for _, c := range cases {
+		if err := c.req.Validate(); !errors.Is(err, c.want) {
+			t.Errorf("Validate(%+v) = %v, esperado %v", c.req, err, c.want)
+		}
+	}
+}
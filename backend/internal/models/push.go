@@ -0,0 +1,79 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Assinaturas Web Push dos dispositivos do usuário (rf_push_subscriptions) e mensagens de notificação
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxPushSubscriptions limite de dispositivos com push por usuário (os mais antigos são substituídos)
+const MaxPushSubscriptions = 10
+
+// Erros das assinaturas push
+var (
+	ErrPushSubscriptionNotFound = errors.New("assinatura push não encontrada")
+	ErrPushEndpointInvalid      = errors.New("endpoint da assinatura push inválido (URL https)")
+	ErrPushKeysInvalid          = errors.New("chaves da assinatura push inválidas (p256dh e auth em base64url)")
+	ErrPushUnavailable          = errors.New("notificações push não configuradas no servidor")
+)
+
+// PushSubscription assinatura Web Push de um dispositivo (PWA instalado ou navegador).
+// Docstring: o endpoint é único; registrar de novo o mesmo endpoint atualiza as chaves e o dono.
+type PushSubscription struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	OwnerID    uuid.UUID  `json:"owner_id" db:"owner_id"`
+	Endpoint   string     `json:"endpoint" db:"endpoint"`
+	P256dh     string     `json:"-" db:"p256dh"`
+	Auth       string     `json:"-" db:"auth"`
+	UserAgent  *string    `json:"user_agent" db:"user_agent"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at"`
+}
+
+// PushSubscriptionRequest corpo de registro: o JSON de PushSubscription.toJSON() do navegador
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// Validate confere o endpoint (https) e o tamanho das chaves (ponto P-256 de 65 bytes e auth de 16)
+func (req *PushSubscriptionRequest) Validate() error {
+	req.Endpoint = strings.TrimSpace(req.Endpoint)
+	u, err := url.Parse(req.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" || len(req.Endpoint) > 2048 {
+		return ErrPushEndpointInvalid
+	}
+	req.Keys.P256dh = strings.TrimRight(strings.TrimSpace(req.Keys.P256dh), "=")
+	req.Keys.Auth = strings.TrimRight(strings.TrimSpace(req.Keys.Auth), "=")
+	p, err := base64.RawURLEncoding.DecodeString(req.Keys.P256dh)
+	if err != nil || len(p) != 65 || p[0] != 4 {
+		return ErrPushKeysInvalid
+	}
+	a, err := base64.RawURLEncoding.DecodeString(req.Keys.Auth)
+	if err != nil || len(a) != 16 {
+		return ErrPushKeysInvalid
+	}
+	return nil
+}
+
+// NotificationMessage conteúdo exibido ao usuário pelas notificações (payload do push no service worker).
+// Docstring: URL é a rota do app aberta ao tocar na notificação; Tag agrupa notificações do mesmo assunto.
+type NotificationMessage struct {
+	Title string                 `json:"title"`
+	Body  string                 `json:"body"`
+	URL   string                 `json:"url,omitempty"`
+	Tag   string                 `json:"tag,omitempty"`
+	Data  map[string]interface{} `json:"data,omitempty"`
+}
@@ -0,0 +1,110 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos do histórico de cotações e índices (rf_rates)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Índices e cotações armazenados
+const (
+	RatePTAXUSD = "ptax_usd" // dólar PTAX venda (diário)
+	RatePTAXEUR = "ptax_eur" // euro PTAX venda (diário)
+	RateIGPM    = "igpm"     // IGP-M, variação mensal %
+	RateIPCA    = "ipca"     // IPCA, variação mensal %
+)
+
+// Fontes de uma cotação
+const (
+	RateSourceBCB    = "bcb"
+	RateSourceManual = "manual"
+)
+
+// RateLayout formato das datas de cotação na API (AAAA-MM-DD)
+const RateLayout = "2006-01-02"
+
+// MaxRateRange limita o intervalo de consultas e backfills
+const MaxRateRange = 10 * 366 * 24 * time.Hour
+
+// Erros do histórico de cotações
+var (
+	ErrInvalidRateIndex = errors.New("índice inválido (use ptax_usd, ptax_eur, igpm ou ipca)")
+	ErrInvalidRateRange = errors.New("intervalo de datas inválido")
+	ErrRateNotFound     = errors.New("cotação não encontrada")
+	ErrRateValueInvalid = errors.New("valor da cotação inválido")
+)
+
+// Rate cotação ou variação de índice em uma data
+type Rate struct {
+	Indice     string     `json:"indice" db:"indice"`
+	Data       time.Time  `json:"-" db:"data"`
+	Valor      float64    `json:"valor" db:"valor"`
+	Fonte      string     `json:"fonte" db:"fonte"`
+	Observacao *string    `json:"observacao,omitempty" db:"observacao"`
+	FetchedAt  *time.Time `json:"fetched_at,omitempty" db:"fetched_at"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// MarshalJSON expõe a data como AAAA-MM-DD
+func (r Rate) MarshalJSON() ([]byte, error) {
+	type alias Rate
+	return json.Marshal(struct {
+		alias
+		Data string `json:"data"`
+	}{alias(r), r.Data.Format(RateLayout)})
+}
+
+// RateOverrideRequest sobrescrita manual de uma cotação
+type RateOverrideRequest struct {
+	Valor      float64 `json:"valor"`
+	Observacao *string `json:"observacao"`
+}
+
+// RateBackfillRequest solicita a busca retroativa de um índice
+type RateBackfillRequest struct {
+	Indice string `json:"indice"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// RateSyncResult resultado da busca de cotações
+type RateSyncResult struct {
+	Indice  string `json:"indice"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Fetched int    `json:"fetched"`
+	Stored  int    `json:"stored"`
+}
+
+// ValidRateIndex verifica se o índice é suportado
+func ValidRateIndex(indice string) bool {
+	switch indice {
+	case RatePTAXUSD, RatePTAXEUR, RateIGPM, RateIPCA:
+		return true
+	}
+	return false
+}
+
+// MonthlyRate indica índices com uma variação por mês (data = 1º dia do mês)
+func MonthlyRate(indice string) bool {
+	return indice == RateIGPM || indice == RateIPCA
+}
+
+// RateIndices lista os índices suportados na ordem de sincronização
+func RateIndices() []string {
+	return []string{RatePTAXUSD, RatePTAXEUR, RateIGPM, RateIPCA}
+}
+
+// AccumulatedRate acumula variações mensais (%) por capitalização composta; retorna o fator-1 em %
+func AccumulatedRate(rates []Rate) float64 {
+	factor := 1.0
+	for _, r := range rates {
+		factor *= 1 + r.Valor/100
+	}
+	return (factor - 1) * 100
+}
@@ -6,7 +6,12 @@
 package models
 
 import (
+	"errors"
+	"net/mail"
+	"strconv"
+	"strings"
 	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -16,6 +21,7 @@ type Receipt struct {
 	ID             uuid.UUID  `json:"id" db:"id"`
 	OwnerID        uuid.UUID  `json:"owner_id" db:"owner_id"`
 	IncomeID       *uuid.UUID `json:"income_id" db:"income_id"`
+	PayerID        *uuid.UUID `json:"payer_id" db:"payer_id"`
 	Numero         int64      `json:"numero" db:"numero"`
 	EmitidoEm      *time.Time `json:"emitido_em" db:"emitido_em"`
 	PDFURL         *string    `json:"pdf_url" db:"pdf_url"`
@@ -24,17 +30,130 @@ type Receipt struct {
 	IssuerName     *string    `json:"issuer_name" db:"issuer_name"`
 	IssuerDocument *string    `json:"issuer_document" db:"issuer_document"`
 	CreatedAt      *time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at" db:"updated_at"` // trigger a cada alteração (migração 047); base do ETag
+
+	// Snapshot congelado na emissão (preenchido pelo banco; imutável após a emissão)
+	Valor           *Money     `json:"valor" db:"valor"`
+	Taxas           Money      `json:"taxas" db:"taxas"`
+	Descontos       Money      `json:"descontos" db:"descontos"`
+	ValorLiquido    *Money     `json:"valor_liquido" db:"valor_liquido"`
+	Competencia     *string    `json:"competencia" db:"competencia"`
+	Categoria       *string    `json:"categoria" db:"categoria"`
+	PayerNome       *string    `json:"payer_nome" db:"payer_nome"`
+	PayerDocumento  *string    `json:"payer_documento" db:"payer_documento"`
+	IncomeUpdatedAt *time.Time `json:"income_updated_at" db:"income_updated_at"`
+	NumeroFormatado *string    `json:"numero_formatado" db:"numero_formatado"` // esquema do usuário (migração 051)
+
+	// Envio por e-mail ao pagador (POST /receipts/{id}/send); fora do snapshot, muda a cada reenvio
+	EmailStatus        *string    `json:"email_status" db:"email_status"`
+	EmailTo            *string    `json:"email_to" db:"email_to"`
+	EmailAttempts      int        `json:"email_attempts" db:"email_attempts"`
+	EmailLastAttemptAt *time.Time `json:"email_last_attempt_at" db:"email_last_attempt_at"`
+	EmailSentAt        *time.Time `json:"email_sent_at" db:"email_sent_at"`
+	EmailError         *string    `json:"email_error" db:"email_error"`
+}
+
+// NumeroExibido número impresso no recibo: o formatado na emissão ou, sem ele, o sequencial
+func (r *Receipt) NumeroExibido() string {
+	if r.NumeroFormatado != nil && *r.NumeroFormatado != "" {
+		return *r.NumeroFormatado
+	}
+	return strconv.FormatInt(r.Numero, 10)
+}
+
+// Situação do último envio do recibo por e-mail
+const (
+	ReceiptEmailSent   = "sent"
+	ReceiptEmailFailed = "failed"
+)
+
+var (
+	ErrReceiptRecipientMissing = errors.New("pagador sem e-mail cadastrado: informe o destinatário")
+	ErrReceiptMessageTooLong   = errors.New("mensagem do e-mail excede 2000 caracteres")
+	ErrEmailUnavailable        = errors.New("envio de e-mail não configurado no servidor")
+	ErrEmailSendFailed         = errors.New("o provedor de e-mail recusou ou não respondeu ao envio")
+)
+
+// ReceiptSendRequest envio do recibo por e-mail; sem To usa o e-mail do pagador
+type ReceiptSendRequest struct {
+	To       *string `json:"to"`
+	Mensagem *string `json:"mensagem"` // texto adicional no corpo do e-mail
+}
+
+// Validate normaliza e valida destinatário e mensagem
+func (req *ReceiptSendRequest) Validate() error {
+	trimOptional(&req.To)
+	trimOptional(&req.Mensagem)
+	if req.To != nil {
+		if addr, err := mail.ParseAddress(*req.To); err != nil || addr.Address != *req.To {
+			return ErrInvalidEmail
+		}
+	}
+	if req.Mensagem != nil && len([]rune(*req.Mensagem)) > 2000 {
+		return ErrReceiptMessageTooLong
+	}
+	return nil
+}
+
+// ReceiptSendResponse resultado do envio com a situação gravada no recibo
+type ReceiptSendResponse struct {
+	Receipt *Receipt `json:"receipt"`
+	To      string   `json:"to"`
+	Status  string   `json:"status"`
+}
+
+// MaxReceiptPDFSize limita o PDF do recibo enviado pelo cliente (10 MB)
+const MaxReceiptPDFSize = 10 << 20
+
+// ReceiptShareTTL validade do link público de compartilhamento do recibo
+const ReceiptShareTTL = 72 * time.Hour
+
+// ReceiptShare token do link público que dá acesso ao PDF do recibo até ExpiresAt
+type ReceiptShare struct {
+	Token     uuid.UUID `json:"token"`
+	ReceiptID uuid.UUID `json:"receipt_id"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReceiptShareResponse link público e mensagem pronta para o WhatsApp
+// Docstring: whatsapp_url abre a conversa com o pagador (telefone cadastrado) ou, sem telefone,
+// a escolha do contato, já com a mensagem preenchida.
+type ReceiptShareResponse struct {
+	URL         string    `json:"url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Message     string    `json:"message"`
+	WhatsAppURL string    `json:"whatsapp_url"`
 }
 
 // ReceiptRequest representa o payload de criação/edição
 // Docstring (PT-BR): campos opcionais, handler completará owner_id e datas.
 type ReceiptRequest struct {
+	Numero         *int64     `json:"numero"` // opcional (migração de talões); deve seguir a sequência
 	IncomeID       *uuid.UUID `json:"income_id"`
+	PayerID        *uuid.UUID `json:"payer_id"` // opcional; herdado da receita quando omitido
 	PDFURL         *string    `json:"pdf_url"`
 	Hash           *string    `json:"hash"`
 	SignatureID    *uuid.UUID `json:"signature_id"`
 	IssuerName     *string    `json:"issuer_name"`
 	IssuerDocument *string    `json:"issuer_document"`
+	Taxas          *Money     `json:"taxas"`     // multa/juros somados ao valor; só na emissão
+	Descontos      *Money     `json:"descontos"` // abatimentos; só na emissão
+}
+
+// Validate valida o número e os ajustes de valor informados na emissão
+func (req *ReceiptRequest) Validate() error {
+	if req.Numero != nil && *req.Numero <= 0 {
+		return ErrReceiptNumberInvalid
+	}
+	if req.Taxas != nil && *req.Taxas < 0 {
+		return ErrInvalidReceiptAdjustment
+	}
+	if req.Descontos != nil && *req.Descontos < 0 {
+		return ErrInvalidReceiptAdjustment
+	}
+	return nil
 }
 
 // ReceiptListResponse resposta de listagem paginada
@@ -46,3 +165,247 @@ type ReceiptListResponse struct {
 	Limit      int       `json:"limit"`
 	TotalPages int       `json:"total_pages"`
 }
+
+// ReceiptFilter filtros da listagem de recibos.
+// Docstring (PT-BR): Search procura no pagador, no emissor e no número exibido; Numero é o
+// sequencial interno. EmitidoFrom é inclusivo e EmitidoTo exclusivo.
+type ReceiptFilter struct {
+	Search      string     `json:"search"`
+	IncomeID    *uuid.UUID `json:"income_id"`
+	PayerID     *uuid.UUID `json:"payer_id"`
+	Numero      *int64     `json:"numero"`
+	EmitidoFrom *time.Time `json:"emitido_from"`
+	EmitidoTo   *time.Time `json:"emitido_to"`
+	SortField   string     `json:"sort_field"`
+	SortOrder   string     `json:"sort_order"`
+	Page        int        `json:"page"`
+	PerPage     int        `json:"per_page"`
+}
+
+// SetDefaults aplica paginação e ordenação padrão (mais recentes primeiro)
+func (f *ReceiptFilter) SetDefaults() {
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+	if f.PerPage <= 0 {
+		f.PerPage = 10
+	}
+	if f.PerPage > 100 {
+		f.PerPage = 100
+	}
+	if f.SortField == "" {
+		f.SortField = "emitido_em"
+	}
+	if f.SortOrder != "asc" && f.SortOrder != "desc" {
+		f.SortOrder = "desc"
+	}
+}
+
+// NumberGap representa uma lacuna na série de números exibidos de recibos
+// Docstring (PT-BR): From/To são números da série (serie_numero) do período; Justificativa preenchida
+// quando o usuário registrou o motivo da lacuna.
+type NumberGap struct {
+	Periodo       int     `json:"serie_periodo"`
+	From          int64   `json:"from"`
+	To            int64   `json:"to"`
+	Missing       int64   `json:"missing"`
+	Justified     bool    `json:"justified"`
+	Justificativa *string `json:"justificativa,omitempty"`
+}
+
+// NumberDuplicate representa um número da série usado por mais de um recibo
+type NumberDuplicate struct {
+	Periodo    int         `json:"serie_periodo"`
+	Numero     int64       `json:"numero"`
+	Count      int         `json:"count"`
+	ReceiptIDs []uuid.UUID `json:"receipt_ids"`
+}
+
+// NumberOrderViolation representa um recibo com número menor que o de um recibo emitido antes na mesma série
+type NumberOrderViolation struct {
+	ReceiptID         uuid.UUID  `json:"receipt_id"`
+	Periodo           int        `json:"serie_periodo"`
+	Numero            int64      `json:"numero"`
+	NumeroFormatado   string     `json:"numero_formatado"`
+	EmitidoEm         *time.Time `json:"emitido_em"`
+	PreviousNumero    int64      `json:"previous_numero"`
+	PreviousFormatado string     `json:"previous_numero_formatado"`
+	PreviousEmitido   *time.Time `json:"previous_emitido_em"`
+}
+
+// NumberSeries resumo de uma série de numeração (período 0 = sem reinício; ano = reinício anual)
+type NumberSeries struct {
+	Periodo int   `json:"serie_periodo"`
+	Count   int   `json:"count"`
+	First   int64 `json:"first"`
+	Last    int64 `json:"last"`
+}
+
+// NumberingReport relatório de integridade da numeração exibida dos recibos do usuário
+// Docstring (PT-BR): a análise é feita por série (serie_periodo/serie_numero), não pela sequência interna;
+// First/Last são os números formatados do primeiro e do último recibo. OK é verdadeiro quando não há
+// duplicatas, quebras de ordem nem lacunas sem justificativa.
+type NumberingReport struct {
+	OK              bool                   `json:"ok"`
+	Count           int                    `json:"count"`
+	First           *string                `json:"first"`
+	Last            *string                `json:"last"`
+	Series          []NumberSeries         `json:"series"`
+	Gaps            []NumberGap            `json:"gaps"`
+	UnjustifiedGaps int                    `json:"unjustified_gaps"`
+	Duplicates      []NumberDuplicate      `json:"duplicates"`
+	OrderViolations []NumberOrderViolation `json:"order_violations"`
+}
+
+// NumberGapRequest payload para justificar uma lacuna de numeração
+// Docstring (PT-BR): serie_periodo omitido (0) refere-se à série sem reinício.
+type NumberGapRequest struct {
+	SeriePeriodo  int    `json:"serie_periodo" validate:"gte=0"`
+	NumeroInicio  int64  `json:"numero_inicio" validate:"required,gt=0"`
+	NumeroFim     int64  `json:"numero_fim" validate:"required,gtefield=NumeroInicio"`
+	Justificativa string `json:"justificativa" validate:"required"`
+}
+
+// NumberGapJustification representa uma justificativa registrada em rf_receipt_number_gaps
+type NumberGapJustification struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	OwnerID       uuid.UUID  `json:"owner_id" db:"owner_id"`
+	SeriePeriodo  int        `json:"serie_periodo" db:"serie_periodo"`
+	NumeroInicio  int64      `json:"numero_inicio" db:"numero_inicio"`
+	NumeroFim     int64      `json:"numero_fim" db:"numero_fim"`
+	Justificativa string     `json:"justificativa" db:"justificativa"`
+	CreatedAt     *time.Time `json:"created_at" db:"created_at"`
+}
+
+// ApplyJustifications marca as lacunas cobertas por justificativas e recalcula OK.
+// Docstring (PT-BR): uma lacuna é justificada quando um único intervalo registrado na mesma série a cobre por inteiro.
+func (r *NumberingReport) ApplyJustifications(js []NumberGapJustification) {
+	r.UnjustifiedGaps = 0
+	for i := range r.Gaps {
+		g := &r.Gaps[i]
+		g.Justified = false
+		g.Justificativa = nil
+		for j := range js {
+			if js[j].SeriePeriodo == g.Periodo && js[j].NumeroInicio <= g.From && js[j].NumeroFim >= g.To {
+				g.Justified = true
+				g.Justificativa = &js[j].Justificativa
+				break
+			}
+		}
+		if !g.Justified {
+			r.UnjustifiedGaps++
+		}
+	}
+	r.OK = r.UnjustifiedGaps == 0 && len(r.Duplicates) == 0 && len(r.OrderViolations) == 0
+}
+
+// Validate valida o intervalo e a justificativa
+func (req *NumberGapRequest) Validate() error {
+	if req.SeriePeriodo < 0 || req.NumeroInicio <= 0 || req.NumeroFim < req.NumeroInicio {
+		return ErrInvalidNumberRange
+	}
+	if strings.TrimSpace(req.Justificativa) == "" {
+		return ErrJustificationRequired
+	}
+	return nil
+}
+
+// maxReceiptNumberLen prefixo (20) + número da série (até 19 dígitos) + "/ano"
+const maxReceiptNumberLen = 48
+
+// NormalizeReceiptNumber prepara o número impresso no recibo (ex.: "RB-0001/2026") para a consulta pública.
+// Docstring (PT-BR): remove espaços nas pontas e compara sem diferenciar maiúsculas; vazio ou longo demais é inválido.
+func NormalizeReceiptNumber(numero string) (string, bool) {
+	numero = strings.ToUpper(strings.TrimSpace(numero))
+	if numero == "" || len(numero) > maxReceiptNumberLen {
+		return "", false
+	}
+	return numero, true
+}
+
+// ReceiptLookup resposta da consulta pública de existência de recibo.
+// Docstring (PT-BR): resposta propositalmente grosseira (apenas existência e mês de emissão), sem valores nem pagador.
+type ReceiptLookup struct {
+	Exists    bool    `json:"exists"`
+	EmitidoEm *string `json:"emitido_em,omitempty"` // formato 2006-01
+}
+
+// ReceiptIncomeState estado atual da receita e do pagador vinculados a um recibo
+// Docstring (PT-BR): IncomeFound falso indica que a receita foi excluída (ou desvinculada) após a emissão.
+type ReceiptIncomeState struct {
+	IncomeFound    bool
+	Valor          Money
+	Competencia    string
+	Categoria      *string
+	UpdatedAt      *time.Time
+	DeletedAt      *time.Time
+	PayerNome      *string
+	PayerDocumento *string
+}
+
+// ReceiptConsistencyWarning divergência entre o snapshot do recibo e o estado atual
+type ReceiptConsistencyWarning struct {
+	Field    string      `json:"field"`
+	Snapshot interface{} `json:"snapshot"`
+	Current  interface{} `json:"current"`
+}
+
+// ReceiptConsistency resultado da verificação de consistência de um recibo emitido
+// Docstring (PT-BR): o recibo nunca é alterado; os avisos apenas informam que a receita mudou depois da emissão.
+type ReceiptConsistency struct {
+	ReceiptID         uuid.UUID                   `json:"receipt_id"`
+	IncomeID          *uuid.UUID                  `json:"income_id"`
+	Numero            int64                       `json:"numero"`
+	Consistent        bool                        `json:"consistent"`
+	EditedAfterIssued bool                        `json:"income_edited_after_issuance"`
+	IncomeDeleted     bool                        `json:"income_deleted"`
+	Warnings          []ReceiptConsistencyWarning `json:"warnings"`
+}
+
+// CheckReceiptConsistency compara o snapshot do recibo com o estado atual da receita e do pagador.
+// Docstring (PT-BR): recibos sem receita vinculada ou sem snapshot (anteriores ao congelamento) são consistentes.
+func CheckReceiptConsistency(rec *Receipt, cur *ReceiptIncomeState) *ReceiptConsistency {
+	out := &ReceiptConsistency{
+		ReceiptID: rec.ID,
+		IncomeID:  rec.IncomeID,
+		Numero:    rec.Numero,
+		Warnings:  []ReceiptConsistencyWarning{},
+	}
+	if rec.IncomeID != nil && rec.Valor != nil && cur != nil {
+		if !cur.IncomeFound || cur.DeletedAt != nil {
+			out.IncomeDeleted = true
+		} else {
+			if rec.IncomeUpdatedAt != nil && cur.UpdatedAt != nil && cur.UpdatedAt.After(*rec.IncomeUpdatedAt) {
+				out.EditedAfterIssued = true
+			}
+			if *rec.Valor != cur.Valor {
+				out.warn("valor", *rec.Valor, cur.Valor)
+			}
+			if rec.Competencia != nil && *rec.Competencia != cur.Competencia {
+				out.warn("competencia", *rec.Competencia, cur.Competencia)
+			}
+			if derefString(rec.Categoria) != derefString(cur.Categoria) {
+				out.warn("categoria", rec.Categoria, cur.Categoria)
+			}
+		}
+		if rec.PayerNome != nil && cur.PayerNome != nil && *rec.PayerNome != *cur.PayerNome {
+			out.warn("payer_nome", *rec.PayerNome, *cur.PayerNome)
+		}
+		if derefString(rec.PayerDocumento) != derefString(cur.PayerDocumento) && cur.PayerNome != nil {
+			out.warn("payer_documento", rec.PayerDocumento, cur.PayerDocumento)
+		}
+	}
+	out.Consistent = !out.EditedAfterIssued && !out.IncomeDeleted && len(out.Warnings) == 0
+	return out
+}
+
+func (c *ReceiptConsistency) warn(field string, snapshot, current interface{}) {
+	c.Warnings = append(c.Warnings, ReceiptConsistencyWarning{Field: field, Snapshot: snapshot, Current: current})
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,88 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Emissão de recibos em lote para as receitas pagas de uma competência (rf_receipt_batches)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status de um lote de recibos
+const (
+	ReceiptBatchStatusQueued    = "queued"
+	ReceiptBatchStatusRunning   = "running"
+	ReceiptBatchStatusCompleted = "completed"
+	ReceiptBatchStatusFailed    = "failed"
+)
+
+// Status de uma receita do lote
+const (
+	ReceiptBatchItemPending = "pending" // aguardando emissão
+	ReceiptBatchItemIssued  = "issued"  // recibo emitido, PDF pendente
+	ReceiptBatchItemDone    = "done"
+	ReceiptBatchItemSkipped = "skipped"
+	ReceiptBatchItemFailed  = "failed"
+)
+
+// ReceiptBatchSkipHasReceipt motivo de uma receita que ganhou recibo depois do pedido
+const ReceiptBatchSkipHasReceipt = "receita já tem recibo"
+
+// Erros do lote de recibos
+var (
+	ErrReceiptBatchNotFound   = errors.New("lote de recibos não encontrado")
+	ErrReceiptBatchInProgress = errors.New("já existe um lote de recibos em andamento para esta competência")
+)
+
+// ReceiptBatchRequest pedido de emissão em lote
+type ReceiptBatchRequest struct {
+	Competencia string `json:"competencia"` // AAAA-MM (ou MM/AAAA)
+}
+
+// Month valida a competência e retorna o primeiro dia do mês
+func (req *ReceiptBatchRequest) Month() (time.Time, error) {
+	return ParseReportCompetencia(req.Competencia)
+}
+
+// ReceiptBatch lote de recibos com o andamento calculado a partir dos itens
+type ReceiptBatch struct {
+	ID          uuid.UUID          `json:"id" db:"id"`
+	OwnerID     uuid.UUID          `json:"owner_id" db:"owner_id"`
+	Competencia string             `json:"competencia" db:"competencia"`
+	Locale      string             `json:"locale" db:"locale"`
+	Timezone    string             `json:"timezone" db:"timezone"`
+	Status      string             `json:"status" db:"status"`
+	Attempts    int                `json:"-" db:"attempts"`
+	Total       int                `json:"total" db:"total"`
+	Pending     int                `json:"pending"`
+	Issued      int                `json:"issued"`
+	Done        int                `json:"done"`
+	Skipped     int                `json:"skipped"`
+	Failed      int                `json:"failed"`
+	LastError   *string            `json:"last_error" db:"last_error"`
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+	StartedAt   *time.Time         `json:"started_at" db:"started_at"`
+	FinishedAt  *time.Time         `json:"finished_at" db:"finished_at"`
+	Items       []ReceiptBatchItem `json:"items,omitempty"`
+}
+
+// Finished indica se o lote não será mais processado
+func (b *ReceiptBatch) Finished() bool {
+	return b.Status == ReceiptBatchStatusCompleted || b.Status == ReceiptBatchStatusFailed
+}
+
+// ReceiptBatchItem situação de uma receita do lote (rf_receipt_batch_items)
+type ReceiptBatchItem struct {
+	BatchID         uuid.UUID  `json:"-" db:"batch_id"`
+	IncomeID        uuid.UUID  `json:"income_id" db:"income_id"`
+	OwnerID         uuid.UUID  `json:"-" db:"owner_id"`
+	ReceiptID       *uuid.UUID `json:"receipt_id" db:"receipt_id"`
+	NumeroFormatado *string    `json:"numero_formatado"`
+	Status          string     `json:"status" db:"status"`
+	Error           *string    `json:"error" db:"error"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
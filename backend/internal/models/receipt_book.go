@@ -0,0 +1,155 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Carnê de recibos de um contrato (uma página por competência do ano, pré-numerada)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Erros do carnê de recibos
+var (
+	ErrContractNotFound       = errors.New("contrato não encontrado")
+	ErrInvalidReceiptBookYear = errors.New("ano inválido (use AAAA entre 2000 e 2100)")
+	ErrReceiptBookEmpty       = errors.New("contrato sem competências vigentes no ano informado")
+)
+
+// Contract dados do contrato (rf_contracts) usados na emissão de documentos
+type Contract struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OwnerID        uuid.UUID  `json:"owner_id" db:"owner_id"`
+	Numero         *string    `json:"numero" db:"numero"`
+	Descricao      *string    `json:"descricao" db:"descricao"`
+	ValorMensal    Money      `json:"valor_mensal" db:"valor_mensal"`
+	VencimentoDia  *int       `json:"vencimento_dia" db:"vencimento_dia"`
+	DataInicio     *time.Time `json:"data_inicio" db:"data_inicio"`
+	DataFim        *time.Time `json:"data_fim" db:"data_fim"`
+	PayerID        *uuid.UUID `json:"payer_id" db:"payer_id"`
+	PayerNome      *string    `json:"payer_nome"`
+	PayerDocumento *string    `json:"payer_documento"`
+	IssuerName     *string    `json:"issuer_name" db:"issuer_name"`
+	IssuerDocument *string    `json:"issuer_document" db:"issuer_document"`
+}
+
+// ReceiptBookEntry receita do contrato no ano, com o número do recibo já emitido (se houver)
+type ReceiptBookEntry struct {
+	IncomeID      uuid.UUID
+	Competencia   string
+	Valor         Money
+	DueDate       *time.Time
+	Status        string
+	ReceiptNumero *int64
+	// ReceiptNumeroFormatado número exibido do recibo (esquema do usuário na emissão)
+	ReceiptNumeroFormatado *string
+}
+
+// ReceiptBookPage uma folha do carnê.
+// Docstring: Seq/Total é a pré-numeração do carnê (01/12...); ReceiptNumero só existe quando o
+// recibo da competência já foi emitido. Valor nil indica valor a preencher à mão.
+type ReceiptBookPage struct {
+	Seq           int        `json:"seq"`
+	Total         int        `json:"total"`
+	Competencia   string     `json:"competencia"` // AAAA-MM
+	Valor         *Money     `json:"valor"`
+	DueDate       *time.Time `json:"due_date"`
+	IncomeID      *uuid.UUID `json:"income_id"`
+	Status        string     `json:"status"`
+	ReceiptNumero *int64     `json:"receipt_numero"`
+	Watermark     string     `json:"watermark,omitempty"` // marca d'água da folha (receita não quitada)
+
+	ReceiptNumeroFormatado *string `json:"receipt_numero_formatado"`
+}
+
+// ReceiptBook carnê anual de um contrato
+type ReceiptBook struct {
+	Contract Contract          `json:"contract"`
+	Year     int               `json:"year"`
+	Pages    []ReceiptBookPage `json:"pages"`
+	Template *ReceiptTemplate  `json:"-"` // modelo de recibo padrão aplicado ao PDF
+}
+
+// BuildReceiptBook monta as folhas do ano: uma por competência dentro da vigência do contrato.
+// Docstring: competências com receita usam valor, vencimento e status da receita (a primeira
+// de entries, que deve vir com recibo emitido primeiro); as demais usam o valor mensal e o dia
+// de vencimento do contrato.
+func BuildReceiptBook(c *Contract, year int, entries []ReceiptBookEntry) (*ReceiptBook, error) {
+	if year < 2000 || year > 2100 {
+		return nil, ErrInvalidReceiptBookYear
+	}
+	byComp := map[string]ReceiptBookEntry{}
+	for _, e := range entries {
+		t, ok := parseCompetencia(e.Competencia)
+		if !ok {
+			continue
+		}
+		key := t.Format("2006-01")
+		if _, seen := byComp[key]; !seen {
+			byComp[key] = e
+		}
+	}
+
+	var pages []ReceiptBookPage
+	for m := time.January; m <= time.December; m++ {
+		month := time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+		if !c.activeIn(month) {
+			continue
+		}
+		p := ReceiptBookPage{Competencia: month.Format("2006-01"), Status: StatusPendente}
+		if e, ok := byComp[p.Competencia]; ok {
+			id, valor := e.IncomeID, e.Valor
+			p.IncomeID, p.Valor, p.DueDate, p.ReceiptNumero = &id, &valor, e.DueDate, e.ReceiptNumero
+			p.ReceiptNumeroFormatado = e.ReceiptNumeroFormatado
+			if e.Status != "" {
+				p.Status = e.Status
+			}
+		} else {
+			if c.ValorMensal > 0 {
+				valor := c.ValorMensal
+				p.Valor = &valor
+			}
+			if c.VencimentoDia != nil && *c.VencimentoDia >= 1 && *c.VencimentoDia <= 31 {
+				due := dayInMonth(month, *c.VencimentoDia)
+				p.DueDate = &due
+			}
+		}
+		pages = append(pages, p)
+	}
+	if len(pages) == 0 {
+		return nil, ErrReceiptBookEmpty
+	}
+	for i := range pages {
+		pages[i].Seq, pages[i].Total = i+1, len(pages)
+	}
+	return &ReceiptBook{Contract: *c, Year: year, Pages: pages}, nil
+}
+
+// ApplyUnpaidWatermark marca as folhas não pagas com o texto informado ("" não marca nada)
+func (b *ReceiptBook) ApplyUnpaidWatermark(text string) {
+	for i := range b.Pages {
+		if b.Pages[i].Status != StatusPago {
+			b.Pages[i].Watermark = text
+		}
+	}
+}
+
+// activeIn indica se o contrato vigora em algum dia do mês (datas de início/fim por mês)
+func (c *Contract) activeIn(month time.Time) bool {
+	if c.DataInicio != nil {
+		start := time.Date(c.DataInicio.Year(), c.DataInicio.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if month.Before(start) {
+			return false
+		}
+	}
+	if c.DataFim != nil {
+		end := time.Date(c.DataFim.Year(), c.DataFim.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if month.After(end) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da montagem do carnê de recibos por contrato
+// Data: 18-10-2026
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildReceiptBook_UsesIncomesAndContractDefaults(t *testing.T) {
+	day := 31
+	start := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	c := &Contract{ID: uuid.New(), ValorMensal: NewMoney(1500), VencimentoDia: &day, DataInicio: &start}
+	numero := int64(42)
+	due := time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)
+	entries := []ReceiptBookEntry{
+		{IncomeID: uuid.New(), Competencia: "04/2026", Valor: NewMoney(1600), DueDate: &due, Status: StatusPago, ReceiptNumero: &numero},
+		{IncomeID: uuid.New(), Competencia: "2026-04", Valor: NewMoney(10)},
+		{IncomeID: uuid.New(), Competencia: "2025-12", Valor: NewMoney(10)},
+	}
+
+	book, err := BuildReceiptBook(c, 2026, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Pages) != 10 || book.Pages[0].Competencia != "2026-03" || book.Pages[9].Seq != 10 || book.Pages[9].Total != 10 {
+		t.Fatalf("folhas devem ir de março (início do contrato) a dezembro: %+v", book.Pages)
+	}
+	apr := book.Pages[1]
+	if apr.Valor == nil || *apr.Valor != NewMoney(1600) || apr.ReceiptNumero == nil || *apr.ReceiptNumero != 42 || apr.Status != StatusPago {
+		t.Fatalf("abril deveria usar a receita com recibo emitido: %+v", apr)
+	}
+	feb := book.Pages[0]
+	if feb.Valor == nil || *feb.Valor != NewMoney(1500) || feb.DueDate == nil || feb.DueDate.Day() != 31 || feb.IncomeID != nil {
+		t.Fatalf("março sem receita deveria usar valor e vencimento do contrato: %+v", feb)
+	}
+	if jun := book.Pages[3]; jun.DueDate.Day() != 30 {
+		t.Fatalf("vencimento dia 31 deveria ser limitado ao fim de junho: %v", jun.DueDate)
+	}
+}
+
+func TestBuildReceiptBook_ValidatesYearAndTerm(t *testing.T) {
+	end := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	c := &Contract{ID: uuid.New(), DataFim: &end}
+	if _, err := BuildReceiptBook(c, 1999, nil); err != ErrInvalidReceiptBookYear {
+		t.Fatalf("err = %v, want ErrInvalidReceiptBookYear", err)
+	}
+	if _, err := BuildReceiptBook(c, 2026, nil); err != ErrReceiptBookEmpty {
+		t.Fatalf("contrato encerrado em 2025: err = %v, want ErrReceiptBookEmpty", err)
+	}
+	book, err := BuildReceiptBook(c, 2025, nil)
+	if err != nil || len(book.Pages) != 6 || book.Pages[0].Valor != nil {
+		t.Fatalf("sem valor mensal a folha fica em branco para preencher: %+v, %v", book, err)
+	}
+}
+
+func TestReceiptBook_UnpaidWatermarkFollowsSettings(t *testing.T) {
+	off, custom := false, "  CÓPIA PARA CONFERÊNCIA "
+	if got := (*UserSettings)(nil).WatermarkForUnpaid(); got != DefaultUnpaidWatermark {
+		t.Fatalf("sem configurações deveria usar o padrão, obtido %q", got)
+	}
+	if got := (&UserSettings{UnpaidWatermarkText: &custom}).WatermarkForUnpaid(); got != "CÓPIA PARA CONFERÊNCIA" {
+		t.Fatalf("texto configurado = %q", got)
+	}
+	if got := (&UserSettings{UnpaidWatermark: &off, UnpaidWatermarkText: &custom}).WatermarkForUnpaid(); got != "" {
+		t.Fatalf("desligada deveria ser vazia, obtido %q", got)
+	}
+
+	book := &ReceiptBook{Pages: []ReceiptBookPage{{Status: StatusPago}, {Status: StatusParcial}, {Status: StatusPendente}}}
+	book.ApplyUnpaidWatermark(DefaultUnpaidWatermark)
+	if book.Pages[0].Watermark != "" || book.Pages[1].Watermark != DefaultUnpaidWatermark || book.Pages[2].Watermark != DefaultUnpaidWatermark {
+		t.Fatalf("só folhas não quitadas levam marca d'água: %+v", book.Pages)
+	}
+}
@@ -0,0 +1,82 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Esquema de numeração exibida dos recibos (prefixo, dígitos e reinício anual)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Reinício da numeração exibida
+const (
+	ReceiptNumberingNever  = "never"
+	ReceiptNumberingYearly = "yearly"
+)
+
+// Limites do esquema (os mesmos das colunas de rf_settings)
+const (
+	MaxReceiptPrefixLength = 20
+	MaxReceiptDigits       = 10
+)
+
+var (
+	ErrReceiptPrefixInvalid    = errors.New("prefixo da numeração deve ter até 20 caracteres, sem barra")
+	ErrReceiptDigitsInvalid    = errors.New("quantidade de dígitos da numeração deve estar entre 0 e 10")
+	ErrReceiptNumberingInvalid = errors.New("reinício da numeração inválido (use never ou yearly)")
+)
+
+// ReceiptNumbering esquema de numeração do usuário.
+// Docstring: vale para os recibos emitidos depois da alteração; o número exibido é gravado na
+// emissão (migração 051), então recibos antigos não mudam. Com reinício anual a sequência volta a
+// 1 em cada ano de emissão e o número leva o ano ("RB-0001/2026").
+type ReceiptNumbering struct {
+	Prefixo  *string `json:"prefixo"`
+	Digitos  int     `json:"digitos"`  // zeros à esquerda; 0 sem preenchimento
+	Reinicio string  `json:"reinicio"` // never | yearly
+}
+
+// Validate normaliza e valida o esquema; reinício vazio é never
+func (n *ReceiptNumbering) Validate() error {
+	trimOptional(&n.Prefixo)
+	if n.Prefixo != nil && (len([]rune(*n.Prefixo)) > MaxReceiptPrefixLength || strings.Contains(*n.Prefixo, "/")) {
+		return ErrReceiptPrefixInvalid
+	}
+	if n.Digitos < 0 || n.Digitos > MaxReceiptDigits {
+		return ErrReceiptDigitsInvalid
+	}
+	switch n.Reinicio {
+	case "":
+		n.Reinicio = ReceiptNumberingNever
+	case ReceiptNumberingNever, ReceiptNumberingYearly:
+	default:
+		return ErrReceiptNumberingInvalid
+	}
+	return nil
+}
+
+// Periodo chave da sequência para uma emissão no ano informado (0 sem reinício)
+func (n *ReceiptNumbering) Periodo(year int) int {
+	if n.Reinicio == ReceiptNumberingYearly {
+		return year
+	}
+	return 0
+}
+
+// Format número exibido de seq no período; espelha o trigger rf_receipts_numero_guard
+func (n *ReceiptNumbering) Format(seq int64, periodo int) string {
+	s := strconv.FormatInt(seq, 10)
+	if pad := n.Digitos - len(s); pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	if n.Prefixo != nil {
+		s = *n.Prefixo + s
+	}
+	if n.Reinicio == ReceiptNumberingYearly {
+		s += "/" + strconv.Itoa(periodo)
+	}
+	return s
+}
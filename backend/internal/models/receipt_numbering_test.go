@@ -0,0 +1,55 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do esquema de numeração exibida dos recibos
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReceiptNumbering_ValidateAndFormat(t *testing.T) {
+	prefix := "  RB-  "
+	n := &ReceiptNumbering{Prefixo: &prefix, Digitos: 4}
+	if err := n.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if *n.Prefixo != "RB-" || n.Reinicio != ReceiptNumberingNever {
+		t.Fatalf("normalização: prefixo %q reinício %q", *n.Prefixo, n.Reinicio)
+	}
+	if p := n.Periodo(2026); p != 0 {
+		t.Fatalf("sem reinício o período é 0, obtido %d", p)
+	}
+	if got := n.Format(7, 0); got != "RB-0007" {
+		t.Fatalf("Format = %q", got)
+	}
+	if got := n.Format(123456, 0); got != "RB-123456" {
+		t.Fatalf("número maior que os dígitos não é truncado: %q", got)
+	}
+
+	n.Reinicio = ReceiptNumberingYearly
+	if got := n.Format(1, n.Periodo(2027)); got != "RB-0001/2027" {
+		t.Fatalf("Format anual = %q", got)
+	}
+	if got := (&ReceiptNumbering{}).Format(42, 0); got != "42" {
+		t.Fatalf("esquema padrão = %q", got)
+	}
+
+	long, slash := strings.Repeat("x", MaxReceiptPrefixLength+1), "A/B"
+	for _, c := range []struct {
+		n    ReceiptNumbering
+		want error
+	}{
+		{ReceiptNumbering{Prefixo: &long}, ErrReceiptPrefixInvalid},
+		{ReceiptNumbering{Prefixo: &slash}, ErrReceiptPrefixInvalid},
+		{ReceiptNumbering{Digitos: MaxReceiptDigits + 1}, ErrReceiptDigitsInvalid},
+		{ReceiptNumbering{Reinicio: "monthly"}, ErrReceiptNumberingInvalid},
+	} {
+		if err := c.n.Validate(); !errors.Is(err, c.want) {
+			t.Errorf("%+v: err = %v, esperado %v", c.n, err, c.want)
+		}
+	}
+}
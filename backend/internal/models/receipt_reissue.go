@@ -0,0 +1,50 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Segunda via de recibos (pedido, marca da via e trilha de auditoria rf_receipt_reissues)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxReissueReasonLen limite do motivo registrado na auditoria da via
+const MaxReissueReasonLen = 500
+
+// ErrReissueReasonTooLong motivo da segunda via acima do limite
+var ErrReissueReasonTooLong = errors.New("motivo da segunda via deve ter até 500 caracteres")
+
+// ReceiptReissueRequest pedido de segunda via; o motivo é opcional
+type ReceiptReissueRequest struct {
+	Motivo *string `json:"motivo"`
+}
+
+// Validate normaliza e valida o motivo
+func (req *ReceiptReissueRequest) Validate() error {
+	trimOptional(&req.Motivo)
+	if req.Motivo != nil && len([]rune(*req.Motivo)) > MaxReissueReasonLen {
+		return ErrReissueReasonTooLong
+	}
+	return nil
+}
+
+// ReceiptReissue registro de auditoria de uma via reemitida (rf_receipt_reissues)
+type ReceiptReissue struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	OwnerID   uuid.UUID `json:"owner_id" db:"owner_id"`
+	ReceiptID uuid.UUID `json:"receipt_id" db:"receipt_id"`
+	Via       int       `json:"via" db:"via"`
+	ActorID   uuid.UUID `json:"actor_id" db:"actor_id"`
+	Motivo    *string   `json:"motivo" db:"motivo"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Label marca impressa no PDF da via ("2ª VIA")
+func (r *ReceiptReissue) Label() string {
+	return fmt.Sprintf("%dª VIA", r.Via)
+}
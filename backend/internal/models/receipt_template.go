@@ -0,0 +1,100 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos de recibo (rf_receipt_templates): identidade visual usada nos PDFs
+// Data: 18-10-2026
+
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limites dos textos do modelo de recibo (em caracteres)
+const (
+	MaxTemplateNameLength = 80
+	MaxTemplateTextLength = 300
+)
+
+// MaxTemplateLogoSize limita o logotipo do modelo (512 KB; vai dentro de cada PDF)
+const MaxTemplateLogoSize = 512 << 10
+
+var templateColorRe = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// ReceiptTemplate identidade visual do profissional nos PDFs de recibo.
+// Docstring: o modelo padrão (is_default) é aplicado aos PDFs de recibos e carnês; o emissor do
+// modelo só é usado quando o recibo foi emitido sem emissor. Logo fica fora do JSON (has_logo).
+type ReceiptTemplate struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OwnerID        uuid.UUID  `json:"owner_id" db:"owner_id"`
+	Nome           string     `json:"nome" db:"nome"`
+	Logo           []byte     `json:"-" db:"logo"`
+	HasLogo        bool       `json:"has_logo"`
+	PrimaryColor   *string    `json:"primary_color" db:"primary_color"`
+	AccentColor    *string    `json:"accent_color" db:"accent_color"`
+	HeaderText     *string    `json:"header_text" db:"header_text"`
+	FooterText     *string    `json:"footer_text" db:"footer_text"`
+	IssuerName     *string    `json:"issuer_name" db:"issuer_name"`
+	IssuerDocument *string    `json:"issuer_document" db:"issuer_document"`
+	IssuerAddress  *string    `json:"issuer_address" db:"issuer_address"`
+	IsDefault      bool       `json:"is_default" db:"is_default"`
+	CreatedAt      *time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ReceiptTemplateRequest dados de entrada para criar/atualizar um modelo de recibo (o logotipo
+// é enviado à parte, em PUT /receipt-templates/{id}/logo)
+type ReceiptTemplateRequest struct {
+	Nome           string  `json:"nome"`
+	PrimaryColor   *string `json:"primary_color"` // #rrggbb
+	AccentColor    *string `json:"accent_color"`
+	HeaderText     *string `json:"header_text"`
+	FooterText     *string `json:"footer_text"`
+	IssuerName     *string `json:"issuer_name"`
+	IssuerDocument *string `json:"issuer_document"` // CPF ou CNPJ
+	IssuerAddress  *string `json:"issuer_address"`
+	IsDefault      bool    `json:"is_default"`
+}
+
+// Validate valida e normaliza os dados do modelo (cores em minúsculas, documento só com dígitos)
+func (req *ReceiptTemplateRequest) Validate() error {
+	req.Nome = strings.TrimSpace(req.Nome)
+	if req.Nome == "" {
+		return ErrTemplateNameRequired
+	}
+	if len([]rune(req.Nome)) > MaxTemplateNameLength {
+		return ErrTemplateTextTooLong
+	}
+	for _, c := range []**string{&req.PrimaryColor, &req.AccentColor} {
+		trimOptional(c)
+		if *c == nil {
+			continue
+		}
+		v := strings.ToLower(**c)
+		if !strings.HasPrefix(v, "#") {
+			v = "#" + v
+		}
+		if !templateColorRe.MatchString(v) {
+			return ErrInvalidTemplateColor
+		}
+		*c = &v
+	}
+	for _, t := range []**string{&req.HeaderText, &req.FooterText, &req.IssuerName, &req.IssuerAddress} {
+		trimOptional(t)
+		if *t != nil && len([]rune(**t)) > MaxTemplateTextLength {
+			return ErrTemplateTextTooLong
+		}
+	}
+	trimOptional(&req.IssuerDocument)
+	if req.IssuerDocument != nil {
+		doc := NormalizeDocument(*req.IssuerDocument)
+		if !ValidDocument(doc) {
+			return ErrInvalidDocument
+		}
+		req.IssuerDocument = &doc
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da validação dos modelos de recibo
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReceiptTemplateRequest_ValidateNormalizes(t *testing.T) {
+	primary, accent, blank := "1E40AF", " #FDE68A ", "  "
+	doc := "529.982.247-25"
+	req := &ReceiptTemplateRequest{Nome: " Consultório ", PrimaryColor: &primary, AccentColor: &accent,
+		HeaderText: &blank, IssuerDocument: &doc}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if req.Nome != "Consultório" || *req.PrimaryColor != "#1e40af" || *req.AccentColor != "#fde68a" {
+		t.Fatalf("não normalizou: %+v", req)
+	}
+	if req.HeaderText != nil || *req.IssuerDocument != "52998224725" {
+		t.Fatalf("texto vazio deveria virar nulo e documento só dígitos: %+v", req)
+	}
+}
+
+func TestReceiptTemplateRequest_ValidateRejects(t *testing.T) {
+	str := func(s string) *string { return &s }
+	long := strings.Repeat("a", MaxTemplateTextLength+1)
+	cases := []struct {
+		req  ReceiptTemplateRequest
+		want error
+	}{
+		{ReceiptTemplateRequest{Nome: " "}, ErrTemplateNameRequired},
+		{ReceiptTemplateRequest{Nome: "A", PrimaryColor: str("azul")}, ErrInvalidTemplateColor},
+		{ReceiptTemplateRequest{Nome: "A", AccentColor: str("#fff")}, ErrInvalidTemplateColor},
+		{ReceiptTemplateRequest{Nome: "A", FooterText: &long}, ErrTemplateTextTooLong},
+		{ReceiptTemplateRequest{Nome: "A", IssuerDocument: str("123.456.789-00")}, ErrInvalidDocument},
+	}
+	for i, c := range cases {
+		if err := c.req.Validate(); !errors.Is(err, c.want) {
+			t.Fatalf("caso %d: err = %v, want %v", i, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do relatório de numeração e da consistência do snapshot de recibos
+// Data: 18-10-2026
+
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNumberingReport_ApplyJustifications(t *testing.T) {
+	rep := &NumberingReport{
+		Gaps: []NumberGap{{From: 3, To: 4, Missing: 2}, {From: 10, To: 10, Missing: 1}},
+	}
+	rep.ApplyJustifications([]NumberGapJustification{{NumeroInicio: 1, NumeroFim: 5, Justificativa: "folhas rasgadas"}})
+
+	if !rep.Gaps[0].Justified || rep.Gaps[0].Justificativa == nil {
+		t.Fatalf("lacuna 3-4 deveria estar justificada")
+	}
+	if rep.Gaps[1].Justified {
+		t.Fatalf("lacuna 10 não deveria estar justificada")
+	}
+	if rep.UnjustifiedGaps != 1 || rep.OK {
+		t.Fatalf("unjustified=%d ok=%v, want 1/false", rep.UnjustifiedGaps, rep.OK)
+	}
+
+	rep.ApplyJustifications([]NumberGapJustification{{NumeroInicio: 1, NumeroFim: 5}, {NumeroInicio: 10, NumeroFim: 12}})
+	if rep.UnjustifiedGaps != 0 || !rep.OK {
+		t.Fatalf("esperava relatório íntegro após justificar todas as lacunas")
+	}
+}
+
+func TestNumberingReport_JustificationsArePerSeries(t *testing.T) {
+	rep := &NumberingReport{
+		Gaps: []NumberGap{{Periodo: 0, From: 3, To: 3, Missing: 1}, {Periodo: 2026, From: 3, To: 3, Missing: 1}},
+	}
+	rep.ApplyJustifications([]NumberGapJustification{{SeriePeriodo: 2026, NumeroInicio: 1, NumeroFim: 5, Justificativa: "cancelado"}})
+
+	if rep.Gaps[0].Justified {
+		t.Fatalf("lacuna da série sem reinício não deveria usar a justificativa de 2026")
+	}
+	if !rep.Gaps[1].Justified || rep.UnjustifiedGaps != 1 || rep.OK {
+		t.Fatalf("gaps=%+v unjustified=%d ok=%v", rep.Gaps, rep.UnjustifiedGaps, rep.OK)
+	}
+}
+
+func TestNormalizeReceiptNumber(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{" rb-0001/2026 ", "RB-0001/2026", true},
+		{"42", "42", true},
+		{"   ", "", false},
+		{strings.Repeat("1", maxReceiptNumberLen+1), "", false},
+	}
+	for _, c := range cases {
+		got, ok := NormalizeReceiptNumber(c.in)
+		if got != c.want || ok != c.ok {
+			t.Errorf("NormalizeReceiptNumber(%q) = %q, %v; want %q, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestCheckReceiptConsistency(t *testing.T) {
+	issued := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	incomeID := uuid.New()
+	valor, comp, nome := NewMoney(1500), "2026-10", "Maria"
+	rec := &Receipt{ID: uuid.New(), IncomeID: &incomeID, Numero: 7,
+		Valor: &valor, Competencia: &comp, PayerNome: &nome, IncomeUpdatedAt: &issued}
+
+	same := &ReceiptIncomeState{IncomeFound: true, Valor: NewMoney(1500), Competencia: "2026-10", UpdatedAt: &issued, PayerNome: &nome}
+	if c := CheckReceiptConsistency(rec, same); !c.Consistent || len(c.Warnings) != 0 {
+		t.Fatalf("recibo sem alterações deveria ser consistente: %+v", c)
+	}
+
+	edited := issued.Add(24 * time.Hour)
+	changed := &ReceiptIncomeState{IncomeFound: true, Valor: NewMoney(1800), Competencia: "2026-10", UpdatedAt: &edited, PayerNome: &nome}
+	c := CheckReceiptConsistency(rec, changed)
+	if c.Consistent || !c.EditedAfterIssued {
+		t.Fatalf("esperava aviso de edição após emissão: %+v", c)
+	}
+	if len(c.Warnings) != 1 || c.Warnings[0].Field != "valor" || c.Warnings[0].Snapshot != NewMoney(1500) {
+		t.Fatalf("warnings = %+v, want divergência de valor", c.Warnings)
+	}
+
+	if c := CheckReceiptConsistency(rec, &ReceiptIncomeState{}); c.Consistent || !c.IncomeDeleted {
+		t.Fatalf("receita excluída deveria gerar aviso: %+v", c)
+	}
+
+	legacy := &Receipt{ID: uuid.New(), IncomeID: &incomeID}
+	if c := CheckReceiptConsistency(legacy, changed); !c.Consistent {
+		t.Fatalf("recibo sem snapshot não deveria gerar avisos: %+v", c)
+	}
+}
+
+func TestReceiptRequest_ValidateAdjustments(t *testing.T) {
+	neg := NewMoney(-1)
+	if err := (&ReceiptRequest{Descontos: &neg}).Validate(); err != ErrInvalidReceiptAdjustment {
+		t.Fatalf("err = %v, want ErrInvalidReceiptAdjustment", err)
+	}
+	zero := int64(0)
+	if err := (&ReceiptRequest{Numero: &zero}).Validate(); err != ErrReceiptNumberInvalid {
+		t.Fatalf("err = %v, want ErrReceiptNumberInvalid", err)
+	}
+	ok := NewMoney(10)
+	if err := (&ReceiptRequest{Taxas: &ok, Descontos: &ok}).Validate(); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+}
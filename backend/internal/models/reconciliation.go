@@ -0,0 +1,123 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Conciliação bancária: extratos importados (OFX/CSV), lançamentos e sugestões de vínculo com receitas
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Formatos de extrato aceitos
+const (
+	StatementFormatOFX = "ofx"
+	StatementFormatCSV = "csv"
+)
+
+// Situação de um lançamento do extrato
+const (
+	StatementLineOpen       = "open"
+	StatementLineReconciled = "reconciled"
+)
+
+// Situação de uma sugestão de conciliação
+const (
+	MatchSuggested = "suggested"
+	MatchConfirmed = "confirmed"
+	MatchRejected  = "rejected"
+)
+
+// Parâmetros do motor de sugestões
+const (
+	MaxStatementLines     = 5000
+	MinMatchScore         = 50 // pontuação mínima (0-100) para sugerir um vínculo
+	MaxSuggestionsPerLine = 3
+)
+
+// Erros da conciliação bancária
+var (
+	ErrStatementFileRequired = errors.New("arquivo de extrato é obrigatório")
+	ErrStatementFormat       = errors.New("formato de extrato não reconhecido (use OFX ou CSV)")
+	ErrStatementInvalid      = errors.New("extrato inválido")
+	ErrStatementEmpty        = errors.New("extrato sem lançamentos de crédito")
+	ErrStatementTooLarge     = errors.New("extrato com lançamentos demais (máximo 5000)")
+	ErrMatchNotFound         = errors.New("sugestão de conciliação não encontrada")
+	ErrMatchDecided          = errors.New("sugestão de conciliação já confirmada ou rejeitada")
+	ErrStatementLineDone     = errors.New("lançamento do extrato já conciliado")
+	ErrInvalidMatchStatus    = errors.New("status de sugestão inválido (use suggested, confirmed ou rejected)")
+)
+
+// BankStatement extrato importado
+type BankStatement struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	OwnerID    uuid.UUID `json:"owner_id" db:"owner_id"`
+	Filename   *string   `json:"filename" db:"filename"`
+	Format     string    `json:"format" db:"format"`
+	Account    *string   `json:"account" db:"account"`
+	ImportedAt time.Time `json:"imported_at" db:"imported_at"`
+}
+
+// BankStatementLine lançamento de crédito do extrato.
+// Docstring: FitID identifica o lançamento no banco (FITID do OFX; no CSV, hash de data, valor e
+// histórico) e evita importar o mesmo crédito duas vezes.
+type BankStatementLine struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	OwnerID     uuid.UUID  `json:"owner_id" db:"owner_id"`
+	StatementID uuid.UUID  `json:"statement_id" db:"statement_id"`
+	FitID       string     `json:"fit_id" db:"fit_id"`
+	PostedAt    time.Time  `json:"posted_at" db:"posted_at"`
+	Valor       Money      `json:"valor" db:"valor"`
+	Descricao   string     `json:"descricao" db:"descricao"`
+	Status      string     `json:"status" db:"status"`
+	IncomeID    *uuid.UUID `json:"income_id" db:"income_id"`
+	PaymentID   *uuid.UUID `json:"payment_id" db:"payment_id"`
+}
+
+// ReconciliationCandidate receita em aberto considerada pelo motor de sugestões
+type ReconciliationCandidate struct {
+	IncomeID      uuid.UUID
+	Competencia   string
+	Categoria     *string
+	Valor         Money
+	TotalPago     Money
+	DueDate       *time.Time
+	PayerName     *string
+	PayerDocument *string
+}
+
+// ReconciliationMatch sugestão de vínculo entre um lançamento e uma receita.
+// Docstring: Score vai de 0 a 100 (valor, proximidade da data e pagador citado no histórico);
+// Reasons explica a pontuação. Line e Income vêm preenchidos nas listagens.
+type ReconciliationMatch struct {
+	ID        uuid.UUID          `json:"id" db:"id"`
+	OwnerID   uuid.UUID          `json:"owner_id" db:"owner_id"`
+	LineID    uuid.UUID          `json:"line_id" db:"line_id"`
+	IncomeID  uuid.UUID          `json:"income_id" db:"income_id"`
+	Score     int                `json:"score" db:"score"`
+	Reasons   []string           `json:"reasons" db:"reasons"`
+	Status    string             `json:"status" db:"status"`
+	PaymentID *uuid.UUID         `json:"payment_id" db:"payment_id"`
+	CreatedAt time.Time          `json:"created_at" db:"created_at"`
+	DecidedAt *time.Time         `json:"decided_at" db:"decided_at"`
+	Line      *BankStatementLine `json:"line,omitempty"`
+	Income    *Income            `json:"income,omitempty"`
+}
+
+// ReconciliationImportResult resultado de POST /reconciliation/import
+type ReconciliationImportResult struct {
+	Statement     BankStatement         `json:"statement"`
+	TotalLines    int                   `json:"total_lines"`
+	Imported      int                   `json:"imported"`
+	Duplicates    int                   `json:"duplicates"`
+	IgnoredDebits int                   `json:"ignored_debits"`
+	Suggestions   []ReconciliationMatch `json:"suggestions"`
+}
+
+// ValidMatchStatus indica se o status de sugestão é conhecido
+func ValidMatchStatus(s string) bool {
+	return s == MatchSuggested || s == MatchConfirmed || s == MatchRejected
+}
@@ -0,0 +1,157 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Política central de arredondamento monetário (modo e casas decimais por moeda)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode regra de desempate quando o valor cai exatamente na metade
+type RoundingMode string
+
+// Modos de arredondamento suportados
+const (
+	// RoundHalfUp meio para longe do zero (0,125 → 0,13; -0,125 → -0,13); padrão comercial no Brasil
+	RoundHalfUp RoundingMode = "half_up"
+	// RoundHalfEven meio para o par, "do banqueiro" (0,125 → 0,12; 0,135 → 0,14)
+	RoundHalfEven RoundingMode = "half_even"
+)
+
+// ErrInvalidRounding política de arredondamento inválida
+var ErrInvalidRounding = errors.New("política de arredondamento inválida")
+
+// CurrencyDecimals casas decimais por moeda (ISO 4217); moedas ausentes usam 2
+var CurrencyDecimals = map[string]int{
+	"BRL": 2, "USD": 2, "EUR": 2, "GBP": 2, "ARS": 2, "UYU": 2,
+	"JPY": 0, "CLP": 0, "PYG": 0, "KRW": 0,
+}
+
+// RoundingPolicy como valores calculados (percentuais, juros, conversões, importações) viram centavos.
+// Docstring: todo cálculo é feito em decimal exato e arredondado uma única vez no fim; Decimals
+// acima de 2 não é representável em Money (centavos) e é tratado como 2.
+type RoundingPolicy struct {
+	Mode     RoundingMode `json:"mode"`
+	Decimals int          `json:"decimals"`
+}
+
+// DefaultRounding política do produto (reais, meio para longe do zero), usada em pagamentos,
+// encargos, relatórios e PDFs
+var DefaultRounding = RoundingFor("BRL")
+
+// RoundingFor política da moeda informada
+func RoundingFor(currency string) RoundingPolicy {
+	decimals, ok := CurrencyDecimals[strings.ToUpper(currency)]
+	if !ok {
+		decimals = 2
+	}
+	return RoundingPolicy{Mode: RoundHalfUp, Decimals: decimals}
+}
+
+// Validate confere modo e casas decimais
+func (p RoundingPolicy) Validate() error {
+	if p.Mode != RoundHalfUp && p.Mode != RoundHalfEven {
+		return ErrInvalidRounding
+	}
+	if p.Decimals < 0 || p.Decimals > 4 {
+		return ErrInvalidRounding
+	}
+	return nil
+}
+
+// step menor quantidade de centavos representável na política (1 centavo; 100 sem casas decimais)
+func (p RoundingPolicy) step() int64 {
+	switch {
+	case p.Decimals >= 2:
+		return 1
+	case p.Decimals == 1:
+		return 10
+	default:
+		return 100
+	}
+}
+
+// RoundRat arredonda um valor exato em centavos conforme a política
+func (p RoundingPolicy) RoundRat(cents *big.Rat) Money {
+	den := new(big.Int).Mul(cents.Denom(), big.NewInt(p.step()))
+	q := p.roundQuo(new(big.Int).Set(cents.Num()), den)
+	q.Mul(q, big.NewInt(p.step()))
+	if !q.IsInt64() {
+		if q.Sign() < 0 {
+			return Money(math.MinInt64)
+		}
+		return Money(math.MaxInt64)
+	}
+	return Money(q.Int64())
+}
+
+// Round ajusta um valor já em centavos às casas decimais da política
+func (p RoundingPolicy) Round(m Money) Money {
+	return p.RoundRat(new(big.Rat).SetInt64(int64(m)))
+}
+
+// FromFloat converte reais para centavos usando a representação decimal mais curta do float
+// (1.005 é tratado como 1,005 e não como 1,00499999…)
+func (p RoundingPolicy) FromFloat(reais float64) Money {
+	r := decimalRat(reais)
+	return p.RoundRat(r.Mul(r, big.NewRat(100, 1)))
+}
+
+// Percent aplica o percentual ao valor com um único arredondamento
+func (p RoundingPolicy) Percent(m Money, percent float64) Money {
+	return p.Prorate(m, percent, 1, 1)
+}
+
+// Prorate aplica percent × num/den ao valor (ex.: juros ao mês × dias/30) com um único arredondamento
+func (p RoundingPolicy) Prorate(m Money, percent float64, num, den int64) Money {
+	if den == 0 {
+		return 0
+	}
+	r := new(big.Rat).SetInt64(int64(m))
+	r.Mul(r, decimalRat(percent))
+	r.Mul(r, big.NewRat(num, den*100))
+	return p.RoundRat(r)
+}
+
+// Convert converte o valor pela cotação (ex.: reais × cotação em outra moeda) e arredonda
+func (p RoundingPolicy) Convert(m Money, rate float64) Money {
+	r := new(big.Rat).SetInt64(int64(m))
+	return p.RoundRat(r.Mul(r, decimalRat(rate)))
+}
+
+// roundQuo divide num por den (den > 0) arredondando conforme o modo
+func (p RoundingPolicy) roundQuo(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// compara 2|r| com den: abaixo da metade, metade ou acima
+	twice := new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2))
+	cmp := twice.Cmp(den)
+	up := cmp > 0
+	if cmp == 0 {
+		up = p.Mode != RoundHalfEven || q.Bit(0) == 1
+	}
+	if up {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+	return q
+}
+
+// decimalRat valor decimal exato da representação mais curta do float
+func decimalRat(f float64) *big.Rat {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return new(big.Rat)
+	}
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
@@ -0,0 +1,134 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da política de arredondamento (casos de desempate e propriedades com testing/quick)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+var halfEven = RoundingPolicy{Mode: RoundHalfEven, Decimals: 2}
+
+func TestRoundingTies(t *testing.T) {
+	cases := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{"float 1.005 não herda erro binário", NewMoney(1.005), 101},
+		{"meio para cima", DefaultRounding.FromFloat(0.125), 13},
+		{"meio para cima negativo", DefaultRounding.FromFloat(-0.125), -13},
+		{"banqueiro para o par abaixo", halfEven.FromFloat(0.125), 12},
+		{"banqueiro para o par acima", halfEven.FromFloat(0.135), 14},
+		{"percentual 2% de 0,25", DefaultRounding.Percent(25, 2), 1},
+		{"percentual banqueiro 2% de 0,25", halfEven.Percent(25, 2), 0},
+		{"juros 1% ao mês por 15 dias de 10,00", DefaultRounding.Prorate(NewMoney(10), 1, 15, 30), 5},
+		{"moeda sem casas decimais", RoundingFor("JPY").Round(150), 200},
+		{"moeda sem casas decimais banqueiro", RoundingPolicy{Mode: RoundHalfEven}.Round(250), 200},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s: got %d, want %d", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestParseMoneyUsesPolicy(t *testing.T) {
+	cases := map[string]Money{"0.125": 13, "-0.125": -13, "0.1249999": 12, "0.1250001": 13, "10.995": 1100}
+	for in, want := range cases {
+		if got, err := ParseMoney(in); err != nil || got != want {
+			t.Errorf("ParseMoney(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+}
+
+func TestRoundingPolicyValidate(t *testing.T) {
+	if err := DefaultRounding.Validate(); err != nil {
+		t.Fatalf("DefaultRounding inválida: %v", err)
+	}
+	if err := (RoundingPolicy{Mode: "truncate", Decimals: 2}).Validate(); err != ErrInvalidRounding {
+		t.Fatalf("modo desconhecido: err = %v", err)
+	}
+	if RoundingFor("xyz").Decimals != 2 || RoundingFor("clp").Decimals != 0 {
+		t.Fatal("casas decimais por moeda incorretas")
+	}
+}
+
+// exactPercent valor exato (em centavos) de m × pct/100 × num/den
+func exactPercent(m Money, pct int64, num, den int64) *big.Rat {
+	r := new(big.Rat).SetInt64(int64(m))
+	return r.Mul(r, big.NewRat(pct*num, 100*den))
+}
+
+// Propriedade: o resultado fica a no máximo meio centavo do valor exato (um único arredondamento)
+func TestRoundingProperty_WithinHalfCent(t *testing.T) {
+	half := big.NewRat(1, 2)
+	for _, p := range []RoundingPolicy{DefaultRounding, halfEven} {
+		f := func(m int32, pct uint8, days uint8) bool {
+			exact := exactPercent(Money(m), int64(pct), int64(days), 30)
+			got := p.Prorate(Money(m), float64(pct), int64(days), 30)
+			diff := new(big.Rat).Sub(new(big.Rat).SetInt64(int64(got)), exact)
+			return diff.Abs(diff).Cmp(half) <= 0
+		}
+		if err := quick.Check(f, nil); err != nil {
+			t.Errorf("%s: %v", p.Mode, err)
+		}
+	}
+}
+
+// Propriedade: meio para cima é simétrico em torno do zero
+func TestRoundingProperty_HalfUpSymmetric(t *testing.T) {
+	f := func(m int32, pct uint16) bool {
+		return DefaultRounding.Percent(Money(-int64(m)), float64(pct)/10) == -DefaultRounding.Percent(Money(m), float64(pct)/10)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// Propriedade: os modos só divergem em empates exatos, e o banqueiro sempre escolhe o par
+func TestRoundingProperty_ModesDifferOnlyOnTies(t *testing.T) {
+	f := func(num int32, den uint8) bool {
+		d := int64(den%16) + 1
+		r := big.NewRat(int64(num), d)
+		up, even := DefaultRounding.RoundRat(r), halfEven.RoundRat(r)
+		if up == even {
+			return true
+		}
+		tie := new(big.Rat).Sub(r, new(big.Rat).SetInt64(int64(even)))
+		return tie.Abs(tie).Cmp(big.NewRat(1, 2)) == 0 && even%2 == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// Propriedade: arredondar é idempotente e respeita as casas decimais da moeda
+func TestRoundingProperty_IdempotentAndStep(t *testing.T) {
+	for _, p := range []RoundingPolicy{DefaultRounding, RoundingFor("JPY"), {Mode: RoundHalfEven, Decimals: 1}} {
+		f := func(m int64) bool {
+			m /= 4 // evita o limite de int64 ao arredondar para cima
+			once := p.Round(Money(m))
+			return p.Round(once) == once && int64(once)%p.step() == 0
+		}
+		if err := quick.Check(f, nil); err != nil {
+			t.Errorf("%+v: %v", p, err)
+		}
+	}
+}
+
+// Propriedade: formatar e interpretar de volta preserva o valor
+func TestRoundingProperty_StringRoundTrip(t *testing.T) {
+	f := func(m int64) bool {
+		m /= 1000
+		got, err := ParseMoney(Money(m).String())
+		return err == nil && got == Money(m)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,189 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Modelos do motor de regras de categorização de receitas (rf_income_rules)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RuleConditions define as condições de uma regra; todas as informadas devem casar.
+// Docstring: ValorMin/ValorMax são inclusivos; CategoriaVazia restringe a receitas sem categoria.
+type RuleConditions struct {
+	PayerID        *uuid.UUID `json:"payer_id,omitempty"`
+	ContractID     *uuid.UUID `json:"contract_id,omitempty"`
+	ValorMin       *Money     `json:"valor_min,omitempty"`
+	ValorMax       *Money     `json:"valor_max,omitempty"`
+	CategoriaVazia bool       `json:"categoria_vazia,omitempty"`
+}
+
+// RuleActions define o que a regra aplica à receita.
+// Docstring: por padrão a categoria só é preenchida se estiver vazia; SobrescreverCategoria
+// força a troca. Tags são acrescentadas sem duplicar.
+type RuleActions struct {
+	Categoria             *string  `json:"categoria,omitempty"`
+	Tags                  []string `json:"tags,omitempty"`
+	SobrescreverCategoria bool     `json:"sobrescrever_categoria,omitempty"`
+}
+
+// IncomeRule representa uma regra de categorização do usuário
+type IncomeRule struct {
+	ID         uuid.UUID      `json:"id" db:"id"`
+	OwnerID    uuid.UUID      `json:"owner_id" db:"owner_id"`
+	Nome       string         `json:"nome" db:"nome"`
+	Priority   int            `json:"priority" db:"priority"`
+	Enabled    bool           `json:"enabled" db:"enabled"`
+	Conditions RuleConditions `json:"conditions" db:"conditions"`
+	Actions    RuleActions    `json:"actions" db:"actions"`
+	CreatedAt  *time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt  *time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// IncomeRuleRequest payload de criação/edição de regra
+type IncomeRuleRequest struct {
+	Nome       string         `json:"nome" validate:"required"`
+	Priority   *int           `json:"priority"`
+	Enabled    *bool          `json:"enabled"`
+	Conditions RuleConditions `json:"conditions"`
+	Actions    RuleActions    `json:"actions"`
+}
+
+// RuleOrderRequest define a nova ordem das regras (IDs da maior para a menor prioridade)
+type RuleOrderRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// RuleTestRequest representa uma receita de exemplo para o endpoint de teste
+type RuleTestRequest struct {
+	PayerID    *uuid.UUID `json:"payer_id"`
+	ContractID *uuid.UUID `json:"contract_id"`
+	Categoria  *string    `json:"categoria"`
+	Valor      Money      `json:"valor"`
+	Tags       []string   `json:"tags"`
+}
+
+// RuleEvaluation registra o resultado de uma regra para a receita avaliada
+type RuleEvaluation struct {
+	RuleID  uuid.UUID `json:"rule_id"`
+	Nome    string    `json:"nome"`
+	Matched bool      `json:"matched"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// RuleTestResponse mostra qual regra casaria e o resultado aplicado
+type RuleTestResponse struct {
+	Matched     *IncomeRule      `json:"matched_rule"`
+	Categoria   *string          `json:"categoria"`
+	Tags        []string         `json:"tags"`
+	Evaluations []RuleEvaluation `json:"evaluations"`
+}
+
+// Validate valida e normaliza a regra
+func (req *IncomeRuleRequest) Validate() error {
+	req.Nome = strings.TrimSpace(req.Nome)
+	if req.Nome == "" {
+		return ErrRuleNameRequired
+	}
+	c := req.Conditions
+	if c.PayerID == nil && c.ContractID == nil && c.ValorMin == nil && c.ValorMax == nil && !c.CategoriaVazia {
+		return ErrRuleConditionRequired
+	}
+	if c.ValorMin != nil && c.ValorMax != nil && *c.ValorMin > *c.ValorMax {
+		return ErrRuleInvalidRange
+	}
+	req.Actions.Tags = normalizeTags(req.Actions.Tags)
+	if req.Actions.Categoria != nil {
+		cat := strings.TrimSpace(*req.Actions.Categoria)
+		if cat == "" {
+			req.Actions.Categoria = nil
+		} else {
+			req.Actions.Categoria = &cat
+		}
+	}
+	if req.Actions.Categoria == nil && len(req.Actions.Tags) == 0 {
+		return ErrRuleActionRequired
+	}
+	return nil
+}
+
+// Check avalia as condições da regra e devolve o motivo da primeira condição que falhou
+func (r *IncomeRule) Check(in *Income) (bool, string) {
+	c := r.Conditions
+	if c.PayerID != nil && (in.PayerID == nil || *in.PayerID != *c.PayerID) {
+		return false, "pagador diferente"
+	}
+	if c.ContractID != nil && (in.ContractID == nil || *in.ContractID != *c.ContractID) {
+		return false, "contrato diferente"
+	}
+	if c.ValorMin != nil && in.Valor < *c.ValorMin {
+		return false, "valor abaixo do mínimo"
+	}
+	if c.ValorMax != nil && in.Valor > *c.ValorMax {
+		return false, "valor acima do máximo"
+	}
+	if c.CategoriaVazia && in.Categoria != nil && strings.TrimSpace(*in.Categoria) != "" {
+		return false, "receita já possui categoria"
+	}
+	return true, ""
+}
+
+// Apply aplica as ações da regra à receita
+func (r *IncomeRule) Apply(in *Income) {
+	a := r.Actions
+	if a.Categoria != nil {
+		empty := in.Categoria == nil || strings.TrimSpace(*in.Categoria) == ""
+		if empty || a.SobrescreverCategoria {
+			cat := *a.Categoria
+			in.Categoria = &cat
+		}
+	}
+	if len(a.Tags) > 0 {
+		in.Tags = normalizeTags(append(append([]string{}, in.Tags...), a.Tags...))
+	}
+}
+
+// EvaluateRules aplica a primeira regra habilitada que casar (regras já ordenadas por prioridade).
+// Docstring: retorna a regra aplicada (ou nil) e o rastro de avaliação de cada regra.
+func EvaluateRules(rules []IncomeRule, in *Income) (*IncomeRule, []RuleEvaluation) {
+	evals := make([]RuleEvaluation, 0, len(rules))
+	var matched *IncomeRule
+	for i := range rules {
+		r := &rules[i]
+		ev := RuleEvaluation{RuleID: r.ID, Nome: r.Nome}
+		switch {
+		case !r.Enabled:
+			ev.Reason = "regra desabilitada"
+		case matched != nil:
+			ev.Reason = "regra de maior prioridade já aplicada"
+		default:
+			ev.Matched, ev.Reason = r.Check(in)
+			if ev.Matched {
+				matched = r
+				r.Apply(in)
+			}
+		}
+		evals = append(evals, ev)
+	}
+	return matched, evals
+}
+
+// normalizeTags remove espaços, vazios e duplicatas preservando a ordem
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		key := strings.ToLower(t)
+		if t == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, t)
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do motor de regras de categorização de receitas
+// Data: 18-10-2026
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestEvaluateRules_FirstMatchByPriorityWins(t *testing.T) {
+	payer := uuid.New()
+	minV, maxV := NewMoney(1000), NewMoney(2000)
+	aluguel, outros := "Aluguel", "Outros"
+	rules := []IncomeRule{
+		{ID: uuid.New(), Nome: "desabilitada", Priority: 5, Enabled: false,
+			Conditions: RuleConditions{PayerID: &payer}, Actions: RuleActions{Categoria: &outros}},
+		{ID: uuid.New(), Nome: "aluguel apto 12", Priority: 10, Enabled: true,
+			Conditions: RuleConditions{PayerID: &payer, ValorMin: &minV, ValorMax: &maxV},
+			Actions:    RuleActions{Categoria: &aluguel, Tags: []string{"Apto 12"}}},
+		{ID: uuid.New(), Nome: "genérica", Priority: 20, Enabled: true,
+			Conditions: RuleConditions{PayerID: &payer}, Actions: RuleActions{Categoria: &outros}},
+	}
+
+	in := &Income{PayerID: &payer, Valor: NewMoney(1500), Tags: []string{"apto 12"}}
+	matched, evals := EvaluateRules(rules, in)
+	if matched == nil || matched.Nome != "aluguel apto 12" {
+		t.Fatalf("regra aplicada = %v, want aluguel apto 12", matched)
+	}
+	if in.Categoria == nil || *in.Categoria != "Aluguel" {
+		t.Fatalf("categoria = %v, want Aluguel", in.Categoria)
+	}
+	if len(in.Tags) != 1 {
+		t.Fatalf("tags = %v, esperava tag sem duplicar", in.Tags)
+	}
+	if len(evals) != 3 || evals[0].Matched || evals[2].Matched {
+		t.Fatalf("avaliações inesperadas: %+v", evals)
+	}
+}
+
+func TestEvaluateRules_KeepsExistingCategoryUnlessOverride(t *testing.T) {
+	aluguel, manual := "Aluguel", "Manual"
+	minV := Money(0)
+	rule := IncomeRule{ID: uuid.New(), Enabled: true,
+		Conditions: RuleConditions{ValorMin: &minV}, Actions: RuleActions{Categoria: &aluguel}}
+
+	in := &Income{Valor: NewMoney(10), Categoria: &manual}
+	EvaluateRules([]IncomeRule{rule}, in)
+	if *in.Categoria != "Manual" {
+		t.Fatalf("categoria informada não deveria ser sobrescrita")
+	}
+
+	rule.Actions.SobrescreverCategoria = true
+	EvaluateRules([]IncomeRule{rule}, in)
+	if *in.Categoria != "Aluguel" {
+		t.Fatalf("categoria = %s, want Aluguel com sobrescrita", *in.Categoria)
+	}
+}
+
+func TestIncomeRuleRequest_Validate(t *testing.T) {
+	cat := "Aluguel"
+	minV, maxV := NewMoney(200), NewMoney(100)
+	cases := []struct {
+		name string
+		req  IncomeRuleRequest
+		want error
+	}{
+		{"sem nome", IncomeRuleRequest{Nome: " "}, ErrRuleNameRequired},
+		{"sem condição", IncomeRuleRequest{Nome: "r", Actions: RuleActions{Categoria: &cat}}, ErrRuleConditionRequired},
+		{"faixa invertida", IncomeRuleRequest{Nome: "r", Conditions: RuleConditions{ValorMin: &minV, ValorMax: &maxV}, Actions: RuleActions{Categoria: &cat}}, ErrRuleInvalidRange},
+		{"sem ação", IncomeRuleRequest{Nome: "r", Conditions: RuleConditions{CategoriaVazia: true}, Actions: RuleActions{Tags: []string{" "}}}, ErrRuleActionRequired},
+		{"válida", IncomeRuleRequest{Nome: "r", Conditions: RuleConditions{CategoriaVazia: true}, Actions: RuleActions{Categoria: &cat}}, nil},
+	}
+	for _, tc := range cases {
+		if err := tc.req.Validate(); err != tc.want {
+			t.Fatalf("%s: err = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
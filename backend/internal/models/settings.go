@@ -0,0 +1,40 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Configurações gerais do usuário (rf_settings: fuso, idioma, modelo padrão e marca d'água)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DefaultUnpaidWatermark texto padrão da marca d'água de documentos de receitas não quitadas
+const DefaultUnpaidWatermark = "SEM VALOR DE QUITAÇÃO"
+
+// UserSettings configurações gerais do usuário; campos nulos usam os padrões do produto
+type UserSettings struct {
+	OwnerID             uuid.UUID `json:"owner_id" db:"owner_id"`
+	Timezone            *string   `json:"timezone" db:"timezone"`
+	Locale              *string   `json:"locale" db:"locale"`
+	TemplatePadrao      *string   `json:"template_padrao" db:"template_padrao"`
+	UnpaidWatermark     *bool     `json:"unpaid_watermark" db:"unpaid_watermark"`
+	UnpaidWatermarkText *string   `json:"unpaid_watermark_text" db:"unpaid_watermark_text"`
+}
+
+// WatermarkForUnpaid texto da marca d'água para receitas não quitadas ("" se o usuário desligou).
+// Docstring: sem configurações (nil) vale o padrão ligado, para que um documento de cobrança nunca
+// saia como quitação por falta de preferência.
+func (s *UserSettings) WatermarkForUnpaid() string {
+	if s != nil && s.UnpaidWatermark != nil && !*s.UnpaidWatermark {
+		return ""
+	}
+	if s != nil && s.UnpaidWatermarkText != nil {
+		if v := strings.TrimSpace(*s.UnpaidWatermarkText); v != "" {
+			return v
+		}
+	}
+	return DefaultUnpaidWatermark
+}
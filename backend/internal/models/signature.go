@@ -6,6 +6,7 @@
 package models
 
 import (
+ "errors"
  "time"
  "github.com/google/uuid"
 )
@@ -20,11 +21,12 @@ import (
 // - ContentType: tipo MIME do arquivo
 // - StoragePath: caminho do objeto no bucket
 // - CreatedAt: timestamp de criação
-// - Version: número da versão do artefato
+// - Version: versão da assinatura do usuário (cada envio cria a próxima; as anteriores seguem consultáveis)
 // - Notes: observações opcionais
 // Tudo em PT-BR.
 
 type SignatureMetadata struct {
+	ID          string    `json:"id"`
 	OwnerID     string    `json:"owner_id"`
 	FileName    string    `json:"file_name"`
 	Size        int64     `json:"size"`
@@ -38,8 +40,11 @@ type SignatureMetadata struct {
 	Notes       string    `json:"notes,omitempty"`
 }
 
+// ErrSignatureNotFound versão de assinatura inexistente para o usuário
+var ErrSignatureNotFound = errors.New("assinatura não encontrada")
+
 // SignatureRecord representa o registro persistido em `rf_signatures` no banco
-// Docstring: Estrutura refletindo colunas da tabela para inserção via repositório
+// Docstring: Estrutura refletindo colunas da tabela; Version e CreatedAt são definidos na inserção
 type SignatureRecord struct {
 	ID        uuid.UUID
 	OwnerID   uuid.UUID
@@ -51,4 +56,22 @@ type SignatureRecord struct {
 	HeightPX  int
 	Hash      string
 	Version   int
+	CreatedAt time.Time
+}
+
+// Metadata metadados da versão no formato da API
+func (s *SignatureRecord) Metadata() SignatureMetadata {
+	return SignatureMetadata{
+		ID:          s.ID.String(),
+		OwnerID:     s.OwnerID.String(),
+		FileName:    s.FileName,
+		Size:        s.FileSize,
+		Width:       s.WidthPX,
+		Height:      s.HeightPX,
+		Hash:        s.Hash,
+		ContentType: s.MimeType,
+		StoragePath: s.FilePath,
+		CreatedAt:   s.CreatedAt,
+		Version:     s.Version,
+	}
 }
@@ -0,0 +1,16 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Resultado da coleta de objetos órfãos do Storage
+// Data: 18-10-2026
+
+package models
+
+// StorageGCResult resultado de uma execução da coleta de objetos órfãos
+type StorageGCResult struct {
+	Scanned      int   `json:"scanned"` // objetos listados nos buckets
+	Orphans      int   `json:"orphans"` // sem referência e mais antigos que a carência
+	Deleted      int   `json:"deleted"`
+	FreedBytes   int64 `json:"freed_bytes"`
+	StorageFails int   `json:"storage_failures"`
+	Truncated    bool  `json:"truncated"` // limite de remoções por execução atingido
+}
@@ -0,0 +1,176 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Feed incremental de sincronização (alterações por entidade, tombstones e cursor)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entidades do feed GET /api/v1/sync/changes (chaves de "changes" e valores de "fields")
+const (
+	SyncFeedIncomes    = "incomes"
+	SyncFeedPayments   = "payments"
+	SyncFeedReceipts   = "receipts"
+	SyncFeedSignatures = "signatures"
+)
+
+// SyncFeedEntities entidades do feed, na ordem de resposta
+var SyncFeedEntities = []string{SyncFeedIncomes, SyncFeedPayments, SyncFeedReceipts, SyncFeedSignatures}
+
+// Erros do feed de sincronização
+var (
+	ErrSyncCursorInvalid = errors.New("cursor de sincronização inválido")
+	ErrSyncEntityInvalid = errors.New("entidade de sincronização inválida (incomes, payments, receipts ou signatures)")
+	ErrSyncFieldInvalid  = errors.New("campo de sincronização inválido (use entidade.coluna, ex.: receipts.numero)")
+)
+
+// syncColumnPattern nome de coluna aceito na projeção (as colunas inexistentes só não aparecem)
+var syncColumnPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// SyncChange linha alterada desde o último sincronismo.
+// Docstring: Data traz a linha completa (colunas da tabela rf_*); em exclusões (receita com
+// deleted_at ou marca em rf_sync_tombstones) Deleted é true e Data vem vazio.
+type SyncChange struct {
+	ID        uuid.UUID       `json:"id"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Deleted   bool            `json:"deleted,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// SyncPosition última linha entregue de uma entidade (paginação por updated_at, id)
+type SyncPosition struct {
+	UpdatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// SyncCursor estado da paginação entre as páginas de um mesmo sincronismo.
+// Docstring: Until fica fixo na primeira página para que alterações feitas durante a paginação
+// não desloquem as páginas; elas entram no próximo sincronismo (since = until). Fields mantém
+// a projeção de colunas pedida na primeira página.
+type SyncCursor struct {
+	Since    time.Time               `json:"s"`
+	Until    time.Time               `json:"u"`
+	Entities []string                `json:"e"`
+	After    map[string]SyncPosition `json:"a,omitempty"`
+	Fields   map[string][]string     `json:"f,omitempty"`
+}
+
+// Encode serializa o cursor (JSON em base64 URL-safe)
+func (c *SyncCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeSyncCursor lê o cursor devolvido em next_cursor
+func DecodeSyncCursor(s string) (*SyncCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrSyncCursorInvalid
+	}
+	var c SyncCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.Until.Before(c.Since) || len(c.Entities) == 0 {
+		return nil, ErrSyncCursorInvalid
+	}
+	for _, e := range c.Entities {
+		if !validSyncEntity(e) {
+			return nil, ErrSyncCursorInvalid
+		}
+	}
+	for e, cols := range c.Fields {
+		if !validSyncEntity(e) || len(cols) == 0 {
+			return nil, ErrSyncCursorInvalid
+		}
+		for _, col := range cols {
+			if !syncColumnPattern.MatchString(col) {
+				return nil, ErrSyncCursorInvalid
+			}
+		}
+	}
+	return &c, nil
+}
+
+// ParseSyncEntities lê a lista de entidades separadas por vírgula; vazio seleciona todas
+func ParseSyncEntities(fields string) ([]string, error) {
+	if strings.TrimSpace(fields) == "" {
+		return append([]string(nil), SyncFeedEntities...), nil
+	}
+	want := map[string]bool{}
+	for _, f := range strings.Split(fields, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if !validSyncEntity(f) {
+			return nil, ErrSyncEntityInvalid
+		}
+		want[f] = true
+	}
+	var out []string
+	for _, e := range SyncFeedEntities {
+		if want[e] {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// ParseSyncFields lê a projeção de colunas "entidade.coluna,..." (ex.: receipts.numero,receipts.valor).
+// Docstring: entidades sem colunas listadas vêm com a linha completa; vazio não projeta nada.
+// Colunas que a tabela não tem são ignoradas (o data da alteração só traz as existentes).
+func ParseSyncFields(fields string) (map[string][]string, error) {
+	if strings.TrimSpace(fields) == "" {
+		return nil, nil
+	}
+	seen := map[string]map[string]bool{}
+	for _, f := range strings.Split(fields, ",") {
+		entity, col, ok := strings.Cut(strings.ToLower(strings.TrimSpace(f)), ".")
+		if !ok || !validSyncEntity(entity) || !syncColumnPattern.MatchString(col) {
+			return nil, ErrSyncFieldInvalid
+		}
+		if seen[entity] == nil {
+			seen[entity] = map[string]bool{}
+		}
+		seen[entity][col] = true
+	}
+	out := make(map[string][]string, len(seen))
+	for entity, cols := range seen {
+		for col := range cols {
+			out[entity] = append(out[entity], col)
+		}
+		sort.Strings(out[entity])
+	}
+	return out, nil
+}
+
+func validSyncEntity(e string) bool {
+	for _, v := range SyncFeedEntities {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncEntityState estado de uma entidade do usuário até o limite do sincronismo (linhas e
+// exclusões): qualquer inclusão, alteração ou exclusão muda a contagem ou a última data
+type SyncEntityState struct {
+	Count        int64
+	LastModified *time.Time
+}
+
+// SyncChanges página do feed de sincronização.
+// Docstring: com NextCursor vazio o sincronismo terminou e Until é o since da próxima vez
+// (a última alteração existente no limite do sincronismo, ou o próprio since sem alterações).
+type SyncChanges struct {
+	Changes    map[string][]SyncChange `json:"changes"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+	Until      time.Time               `json:"until"`
+}
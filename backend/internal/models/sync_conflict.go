@@ -0,0 +1,219 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Conflitos de sincronização offline (versão do dispositivo x servidor) e resolução
+// Data: 18-10-2026
+
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Situação de um conflito (rf_sync_conflicts.status)
+const (
+	SyncConflictPending  = "pending"
+	SyncConflictResolved = "resolved"
+)
+
+// Entidades sujeitas a conflito de sincronização
+const SyncEntityIncome = "income"
+
+// Estratégias de resolução
+const (
+	SyncResolveKeepMine   = "keep_mine"
+	SyncResolveKeepServer = "keep_server"
+	SyncResolveMerge      = "merge"
+)
+
+// Quem resolveu o conflito
+const (
+	SyncResolvedByUser    = "user"
+	SyncResolvedBySupport = "support"
+)
+
+// Lado escolhido por campo na mesclagem
+const (
+	SyncSideMine   = "mine"
+	SyncSideServer = "server"
+)
+
+// SyncIncomeFields campos de receita editáveis offline (nomes do JSON da API), na ordem de exibição
+var SyncIncomeFields = []string{"competencia", "valor", "status", "due_date", "categoria", "tags", "payer_id", "contract_id"}
+
+// Erros dos conflitos de sincronização
+var (
+	ErrSyncConflictNotFound    = errors.New("conflito de sincronização não encontrado")
+	ErrSyncConflictResolved    = errors.New("conflito de sincronização já resolvido")
+	ErrSyncResolutionInvalid   = errors.New("estratégia de resolução inválida (keep_mine, keep_server ou merge)")
+	ErrSyncMergeFieldsRequired = errors.New("informe os campos da mesclagem (campo: mine ou server)")
+	ErrSyncMergeFieldInvalid   = errors.New("campo da mesclagem inválido ou ausente na versão do dispositivo")
+	ErrSyncMergeSideInvalid    = errors.New("lado da mesclagem deve ser mine ou server")
+)
+
+// SyncConflict edição feita offline que não pôde ser aplicada porque a entidade mudou no servidor.
+// Docstring: ClientData guarda os campos enviados pelo dispositivo; ServerData o estado do servidor
+// (atualizado na listagem). Fields traz as duas versões lado a lado para o suporte.
+type SyncConflict struct {
+	ID            uuid.UUID                  `json:"id" db:"id"`
+	OwnerID       uuid.UUID                  `json:"owner_id" db:"owner_id"`
+	DeviceID      string                     `json:"device_id" db:"device_id"`
+	Entity        string                     `json:"entity" db:"entity"`
+	EntityID      uuid.UUID                  `json:"entity_id" db:"entity_id"`
+	BaseVersion   *int64                     `json:"base_version" db:"base_version"`
+	ClientData    map[string]json.RawMessage `json:"client_data" db:"client_data"`
+	ServerData    map[string]json.RawMessage `json:"server_data" db:"server_data"`
+	ServerVersion int64                      `json:"server_version" db:"server_version"`
+	ServerDeleted bool                       `json:"server_deleted,omitempty"`
+	Status        string                     `json:"status" db:"status"`
+	Resolution    *string                    `json:"resolution,omitempty" db:"resolution"`
+	ResolvedData  map[string]json.RawMessage `json:"resolved_data,omitempty" db:"resolved_data"`
+	ResolvedBy    *string                    `json:"resolved_by,omitempty" db:"resolved_by"`
+	CreatedAt     time.Time                  `json:"created_at" db:"created_at"`
+	ResolvedAt    *time.Time                 `json:"resolved_at,omitempty" db:"resolved_at"`
+	Fields        []SyncConflictField        `json:"fields"`
+}
+
+// SyncConflictField um campo nas duas versões
+type SyncConflictField struct {
+	Field   string          `json:"field"`
+	Mine    json.RawMessage `json:"mine"`
+	Server  json.RawMessage `json:"server"`
+	Differs bool            `json:"differs"`
+}
+
+// SyncConflictDevice conflitos pendentes de um dispositivo
+type SyncConflictDevice struct {
+	DeviceID  string         `json:"device_id"`
+	Conflicts []SyncConflict `json:"conflicts"`
+}
+
+// SyncConflictResolution pedido de resolução.
+// Docstring: em "merge", Fields indica o lado de cada campo; campos omitidos ficam com o servidor.
+type SyncConflictResolution struct {
+	Strategy string            `json:"strategy"`
+	Fields   map[string]string `json:"fields,omitempty"`
+}
+
+// Validate valida a estratégia e os lados escolhidos
+func (r *SyncConflictResolution) Validate() error {
+	switch r.Strategy {
+	case SyncResolveKeepMine, SyncResolveKeepServer:
+		return nil
+	case SyncResolveMerge:
+	default:
+		return ErrSyncResolutionInvalid
+	}
+	if len(r.Fields) == 0 {
+		return ErrSyncMergeFieldsRequired
+	}
+	for field, side := range r.Fields {
+		if !isSyncIncomeField(field) {
+			return ErrSyncMergeFieldInvalid
+		}
+		if side != SyncSideMine && side != SyncSideServer {
+			return ErrSyncMergeSideInvalid
+		}
+	}
+	return nil
+}
+
+// Compare preenche Fields com os campos editados no dispositivo e o valor atual do servidor
+func (c *SyncConflict) Compare() {
+	c.Fields = []SyncConflictField{}
+	for _, field := range SyncIncomeFields {
+		mine, ok := c.ClientData[field]
+		if !ok {
+			continue
+		}
+		server := c.ServerData[field]
+		if server == nil {
+			server = json.RawMessage("null")
+		}
+		c.Fields = append(c.Fields, SyncConflictField{Field: field, Mine: mine, Server: server, Differs: !sameJSON(mine, server)})
+	}
+}
+
+// ResolvedFields campos do dispositivo a gravar no servidor conforme a resolução
+// (vazio em keep_server ou quando todos os campos da mesclagem ficam com o servidor)
+func (c *SyncConflict) ResolvedFields(res *SyncConflictResolution) (map[string]json.RawMessage, error) {
+	if err := res.Validate(); err != nil {
+		return nil, err
+	}
+	out := map[string]json.RawMessage{}
+	switch res.Strategy {
+	case SyncResolveKeepMine:
+		for _, field := range SyncIncomeFields {
+			if v, ok := c.ClientData[field]; ok {
+				out[field] = v
+			}
+		}
+	case SyncResolveMerge:
+		for field, side := range res.Fields {
+			if side != SyncSideMine {
+				continue
+			}
+			v, ok := c.ClientData[field]
+			if !ok {
+				return nil, ErrSyncMergeFieldInvalid
+			}
+			out[field] = v
+		}
+	}
+	return out, nil
+}
+
+// IncomeSyncFields estado da receita restrito aos campos editáveis offline
+func IncomeSyncFields(inc *Income) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(inc)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+	out := make(map[string]json.RawMessage, len(SyncIncomeFields))
+	for _, field := range SyncIncomeFields {
+		out[field] = all[field]
+	}
+	return out, nil
+}
+
+// GroupSyncConflictsByDevice agrupa conflitos por dispositivo (dispositivos em ordem alfabética,
+// conflitos na ordem recebida)
+func GroupSyncConflictsByDevice(items []SyncConflict) []SyncConflictDevice {
+	byDevice := map[string][]SyncConflict{}
+	for _, c := range items {
+		byDevice[c.DeviceID] = append(byDevice[c.DeviceID], c)
+	}
+	devices := make([]SyncConflictDevice, 0, len(byDevice))
+	for id, list := range byDevice {
+		devices = append(devices, SyncConflictDevice{DeviceID: id, Conflicts: list})
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].DeviceID < devices[j].DeviceID })
+	return devices
+}
+
+func isSyncIncomeField(field string) bool {
+	for _, f := range SyncIncomeFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// sameJSON compara dois valores JSON ignorando espaços
+func sameJSON(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
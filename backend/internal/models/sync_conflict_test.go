@@ -0,0 +1,89 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes dos conflitos de sincronização (comparação, validação e campos resolvidos)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func newTestConflict() *SyncConflict {
+	return &SyncConflict{
+		DeviceID: "tablet",
+		ClientData: map[string]json.RawMessage{
+			"valor":     json.RawMessage(`"150.00"`),
+			"categoria": json.RawMessage(`"Aluguel"`),
+			"owner_id":  json.RawMessage(`"ignorado"`),
+		},
+		ServerData: map[string]json.RawMessage{
+			"valor":     json.RawMessage(`"120.00"`),
+			"categoria": json.RawMessage(` "Aluguel" `),
+		},
+	}
+}
+
+func TestSyncConflictCompare(t *testing.T) {
+	c := newTestConflict()
+	c.Compare()
+	if len(c.Fields) != 2 {
+		t.Fatalf("Fields = %+v, esperado valor e categoria", c.Fields)
+	}
+	// Ordem de SyncIncomeFields: valor antes de categoria
+	if c.Fields[0].Field != "valor" || !c.Fields[0].Differs {
+		t.Errorf("valor = %+v, esperado divergente", c.Fields[0])
+	}
+	if c.Fields[1].Field != "categoria" || c.Fields[1].Differs {
+		t.Errorf("categoria = %+v, esperado igual", c.Fields[1])
+	}
+}
+
+func TestSyncConflictResolutionValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		res  SyncConflictResolution
+		err  error
+	}{
+		{"estratégia inválida", SyncConflictResolution{Strategy: "latest"}, ErrSyncResolutionInvalid},
+		{"mesclagem sem campos", SyncConflictResolution{Strategy: SyncResolveMerge}, ErrSyncMergeFieldsRequired},
+		{"campo não editável", SyncConflictResolution{Strategy: SyncResolveMerge, Fields: map[string]string{"owner_id": "mine"}}, ErrSyncMergeFieldInvalid},
+		{"lado inválido", SyncConflictResolution{Strategy: SyncResolveMerge, Fields: map[string]string{"valor": "both"}}, ErrSyncMergeSideInvalid},
+		{"manter a minha", SyncConflictResolution{Strategy: SyncResolveKeepMine}, nil},
+		{"mesclagem", SyncConflictResolution{Strategy: SyncResolveMerge, Fields: map[string]string{"valor": "mine"}}, nil},
+	}
+	for _, c := range cases {
+		if err := c.res.Validate(); !errors.Is(err, c.err) {
+			t.Errorf("%s: err = %v, esperado %v", c.name, err, c.err)
+		}
+	}
+}
+
+func TestSyncConflictResolvedFields(t *testing.T) {
+	c := newTestConflict()
+
+	mine, err := c.ResolvedFields(&SyncConflictResolution{Strategy: SyncResolveKeepMine})
+	if err != nil || len(mine) != 2 || mine["owner_id"] != nil {
+		t.Errorf("keep_mine = %v, %v; esperado apenas campos editáveis", mine, err)
+	}
+	server, err := c.ResolvedFields(&SyncConflictResolution{Strategy: SyncResolveKeepServer})
+	if err != nil || len(server) != 0 {
+		t.Errorf("keep_server = %v, %v; esperado vazio", server, err)
+	}
+	merged, err := c.ResolvedFields(&SyncConflictResolution{Strategy: SyncResolveMerge, Fields: map[string]string{"valor": "mine", "categoria": "server"}})
+	if err != nil || len(merged) != 1 || string(merged["valor"]) != `"150.00"` {
+		t.Errorf("merge = %v, %v; esperado só valor", merged, err)
+	}
+	if _, err := c.ResolvedFields(&SyncConflictResolution{Strategy: SyncResolveMerge, Fields: map[string]string{"status": "mine"}}); !errors.Is(err, ErrSyncMergeFieldInvalid) {
+		t.Errorf("campo ausente no dispositivo: err = %v", err)
+	}
+}
+
+func TestGroupSyncConflictsByDevice(t *testing.T) {
+	groups := GroupSyncConflictsByDevice([]SyncConflict{{DeviceID: "web"}, {DeviceID: "android"}, {DeviceID: "web"}})
+	if len(groups) != 2 || groups[0].DeviceID != "android" || len(groups[1].Conflicts) != 2 {
+		t.Errorf("grupos = %+v", groups)
+	}
+}
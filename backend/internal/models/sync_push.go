@@ -0,0 +1,196 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Envio offline (sync push): lote de receitas e pagamentos criados ou editados no dispositivo
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxSyncPushItems limite de registros (receitas + pagamentos) por lote
+const MaxSyncPushItems = 500
+
+// Situação de cada registro do lote
+const (
+	SyncPushCreated   = "created"
+	SyncPushUpdated   = "updated"
+	SyncPushDeleted   = "deleted"
+	SyncPushUnchanged = "unchanged" // já aplicado antes (reenvio) ou exclusão de registro inexistente
+	SyncPushConflict  = "conflict"  // servidor venceu; conflito pendente em conflict_id
+	SyncPushRejected  = "rejected"  // dados inválidos para o estado atual (error explica)
+)
+
+// Vencedor de uma edição concorrente (last-write-wins pela data da edição)
+const (
+	SyncPushClientWins = "client_wins"
+	SyncPushServerWins = "server_wins"
+)
+
+// Erros do envio offline
+var (
+	ErrSyncDeviceRequired  = errors.New("device_id é obrigatório")
+	ErrSyncPushEmpty       = errors.New("lote de sincronização vazio")
+	ErrSyncPushTooLarge    = errors.New("lote de sincronização excede 500 registros")
+	ErrSyncPushIDRequired  = errors.New("todo registro precisa de id gerado no dispositivo")
+	ErrSyncPushDuplicateID = errors.New("registro repetido no lote")
+	ErrSyncPushDataInvalid = errors.New("campos da receita inválidos para sincronização")
+)
+
+// SyncPushRequest lote enviado pelo dispositivo ao reconectar.
+// Docstring: receitas são aplicadas antes dos pagamentos, então um pagamento pode referenciar
+// uma receita criada no mesmo lote. IDs são gerados no dispositivo e tornam o reenvio seguro.
+type SyncPushRequest struct {
+	DeviceID string              `json:"device_id"`
+	Incomes  []SyncIncomeChange  `json:"incomes"`
+	Payments []SyncPaymentChange `json:"payments"`
+}
+
+// SyncIncomeChange receita criada, editada ou excluída offline.
+// Docstring: BaseVersion é a versão do servidor sobre a qual a edição foi feita (nil = criada
+// offline); UpdatedAt a data da edição no dispositivo, usada no last-write-wins. Data traz só
+// os campos alterados (nomes de SyncIncomeFields).
+type SyncIncomeChange struct {
+	ID          uuid.UUID                  `json:"id"`
+	BaseVersion *int64                     `json:"base_version"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+	Deleted     bool                       `json:"deleted,omitempty"`
+	Data        map[string]json.RawMessage `json:"data"`
+}
+
+// SyncPaymentChange pagamento lançado offline (pagamentos só são inseridos, nunca editados)
+type SyncPaymentChange struct {
+	ID        uuid.UUID  `json:"id"`
+	IncomeID  uuid.UUID  `json:"income_id"`
+	Valor     Money      `json:"valor"`
+	PagoEm    *string    `json:"pago_em"`
+	MethodID  *uuid.UUID `json:"method_id"`
+	Metodo    *string    `json:"metodo"`
+	Obs       *string    `json:"obs"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// SyncPushResult resultado de um registro do lote
+type SyncPushResult struct {
+	Entity     string     `json:"entity"` // incomes ou payments
+	ID         uuid.UUID  `json:"id"`
+	Status     string     `json:"status"`
+	Resolution string     `json:"resolution,omitempty"` // só em edições concorrentes
+	Version    int64      `json:"version,omitempty"`    // versão atual da receita
+	ConflictID *uuid.UUID `json:"conflict_id,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// SyncPushResponse resultados na ordem de aplicação (receitas, depois pagamentos)
+type SyncPushResponse struct {
+	Results []SyncPushResult `json:"results"`
+}
+
+// Validate confere o dispositivo, o tamanho do lote e os IDs
+func (r *SyncPushRequest) Validate() error {
+	if r.DeviceID == "" || len(r.DeviceID) > 200 {
+		return ErrSyncDeviceRequired
+	}
+	n := len(r.Incomes) + len(r.Payments)
+	if n == 0 {
+		return ErrSyncPushEmpty
+	}
+	if n > MaxSyncPushItems {
+		return ErrSyncPushTooLarge
+	}
+	seen := make(map[uuid.UUID]bool, n)
+	for _, c := range r.Incomes {
+		if c.ID == uuid.Nil {
+			return ErrSyncPushIDRequired
+		}
+		if seen[c.ID] {
+			return ErrSyncPushDuplicateID
+		}
+		seen[c.ID] = true
+		for field := range c.Data {
+			if !isSyncIncomeField(field) {
+				return ErrSyncPushDataInvalid
+			}
+		}
+		if !c.Deleted && len(c.Data) == 0 {
+			return ErrSyncPushDataInvalid
+		}
+	}
+	for _, p := range r.Payments {
+		if p.ID == uuid.Nil || p.IncomeID == uuid.Nil {
+			return ErrSyncPushIDRequired
+		}
+		if seen[p.ID] {
+			return ErrSyncPushDuplicateID
+		}
+		seen[p.ID] = true
+	}
+	return nil
+}
+
+// SyncVersionsConcurrent indica se a edição do dispositivo é concorrente com a versão do servidor.
+// Docstring: não é concorrente quando o dispositivo já tinha visto a versão atual (base) ou quando
+// a versão atual foi gravada pelo próprio dispositivo (vector[deviceID]); receitas alteradas por
+// outro dispositivo ou pela web depois da base são concorrentes.
+func SyncVersionsConcurrent(vector map[string]int64, deviceID string, base *int64, current int64) bool {
+	if base != nil && *base >= current {
+		return false
+	}
+	return vector[deviceID] != current
+}
+
+// IncomeRequestFromSync monta a criação de receita a partir dos campos enviados pelo dispositivo
+func IncomeRequestFromSync(id uuid.UUID, data map[string]json.RawMessage) (*IncomeRequest, error) {
+	var req IncomeRequest
+	if err := decodeSyncFields(data, &req); err != nil {
+		return nil, err
+	}
+	req.ID = &id
+	return &req, nil
+}
+
+// IncomePatchFromSync monta a edição parcial a partir dos campos enviados pelo dispositivo
+func IncomePatchFromSync(data map[string]json.RawMessage, version int64) (*IncomePatchRequest, error) {
+	var req IncomePatchRequest
+	if err := decodeSyncFields(data, &req); err != nil {
+		return nil, err
+	}
+	req.Version = &version
+	return &req, nil
+}
+
+// PaymentRequest converte o pagamento offline no lançamento comum; sem pago_em vale a data
+// do lançamento no dispositivo, não a do envio
+func (p *SyncPaymentChange) PaymentRequest() *PaymentRequest {
+	id := p.ID
+	pagoEm := p.PagoEm
+	if (pagoEm == nil || *pagoEm == "") && !p.CreatedAt.IsZero() {
+		s := p.CreatedAt.Format(time.RFC3339)
+		pagoEm = &s
+	}
+	return &PaymentRequest{
+		ID:       &id,
+		IncomeID: p.IncomeID,
+		Valor:    p.Valor,
+		PagoEm:   pagoEm,
+		MethodID: p.MethodID,
+		Metodo:   p.Metodo,
+		Obs:      p.Obs,
+	}
+}
+
+func decodeSyncFields(data map[string]json.RawMessage, dst any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, dst); err != nil {
+		return ErrSyncPushDataInvalid
+	}
+	return nil
+}
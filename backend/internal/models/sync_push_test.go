@@ -0,0 +1,60 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do lote de envio offline (validação e vetor de versões)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSyncVersionsConcurrent(t *testing.T) {
+	v := func(n int64) *int64 { return &n }
+	vector := map[string]int64{"android": 7, "ios": 5}
+	cases := []struct {
+		name    string
+		device  string
+		base    *int64
+		current int64
+		want    bool
+	}{
+		{"base atual", "android", v(7), 7, false},
+		{"versão atual gravada pelo dispositivo", "android", v(5), 7, false},
+		{"alterada por outro dispositivo", "ios", v(5), 7, true},
+		{"alterada pela web", "web", v(3), 4, true},
+		{"criada offline e já existente", "tablet", nil, 1, true},
+	}
+	for _, c := range cases {
+		if got := SyncVersionsConcurrent(vector, c.device, c.base, c.current); got != c.want {
+			t.Errorf("%s: concorrente = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestSyncPushRequestValidate(t *testing.T) {
+	id := uuid.New()
+	data := map[string]json.RawMessage{"valor": json.RawMessage(`"10.00"`)}
+	cases := []struct {
+		name string
+		req  SyncPushRequest
+		want error
+	}{
+		{"sem dispositivo", SyncPushRequest{Incomes: []SyncIncomeChange{{ID: id, Data: data}}}, ErrSyncDeviceRequired},
+		{"vazio", SyncPushRequest{DeviceID: "a"}, ErrSyncPushEmpty},
+		{"sem id", SyncPushRequest{DeviceID: "a", Incomes: []SyncIncomeChange{{Data: data}}}, ErrSyncPushIDRequired},
+		{"repetido", SyncPushRequest{DeviceID: "a", Incomes: []SyncIncomeChange{{ID: id, Data: data}}, Payments: []SyncPaymentChange{{ID: id, IncomeID: id}}}, ErrSyncPushDuplicateID},
+		{"campo desconhecido", SyncPushRequest{DeviceID: "a", Incomes: []SyncIncomeChange{{ID: id, Data: map[string]json.RawMessage{"total_pago": json.RawMessage(`"1"`)}}}}, ErrSyncPushDataInvalid},
+		{"edição sem campos", SyncPushRequest{DeviceID: "a", Incomes: []SyncIncomeChange{{ID: id}}}, ErrSyncPushDataInvalid},
+		{"exclusão", SyncPushRequest{DeviceID: "a", Incomes: []SyncIncomeChange{{ID: id, Deleted: true}}}, nil},
+	}
+	for _, c := range cases {
+		if err := c.req.Validate(); !errors.Is(err, c.want) {
+			t.Errorf("%s: err = %v, want %v", c.name, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do cursor e das entidades do feed de sincronização
+// Data: 18-10-2026
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSyncCursorRoundTrip(t *testing.T) {
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	id := uuid.New()
+	c := &SyncCursor{
+		Since: since, Until: since.Add(time.Hour), Entities: []string{SyncFeedPayments},
+		After: map[string]SyncPosition{SyncFeedPayments: {UpdatedAt: since.Add(time.Minute), ID: id}},
+	}
+	got, err := DecodeSyncCursor(c.Encode())
+	if err != nil {
+		t.Fatalf("DecodeSyncCursor: %v", err)
+	}
+	if !got.Since.Equal(since) || !got.Until.Equal(c.Until) || got.After[SyncFeedPayments].ID != id {
+		t.Fatalf("cursor = %+v", got)
+	}
+}
+
+func TestDecodeSyncCursorInvalid(t *testing.T) {
+	now := time.Now()
+	for name, s := range map[string]string{
+		"base64":    "%%%",
+		"json":      "bm9wZQ",
+		"intervalo": (&SyncCursor{Since: now, Until: now.Add(-time.Hour), Entities: []string{SyncFeedIncomes}}).Encode(),
+		"entidade":  (&SyncCursor{Since: now, Until: now, Entities: []string{"rf_users"}}).Encode(),
+		"vazio":     (&SyncCursor{Since: now, Until: now}).Encode(),
+		"coluna":    (&SyncCursor{Since: now, Until: now, Entities: []string{SyncFeedReceipts}, Fields: map[string][]string{SyncFeedReceipts: {"x; drop"}}}).Encode(),
+	} {
+		if _, err := DecodeSyncCursor(s); !errors.Is(err, ErrSyncCursorInvalid) {
+			t.Errorf("%s: err = %v, want ErrSyncCursorInvalid", name, err)
+		}
+	}
+}
+
+func TestParseSyncEntities(t *testing.T) {
+	all, err := ParseSyncEntities("")
+	if err != nil || len(all) != len(SyncFeedEntities) {
+		t.Fatalf("vazio = %v, %v", all, err)
+	}
+	got, err := ParseSyncEntities(" Receipts ,incomes,receipts")
+	if err != nil || len(got) != 2 || got[0] != SyncFeedIncomes || got[1] != SyncFeedReceipts {
+		t.Fatalf("entidades = %v, %v", got, err)
+	}
+	if _, err := ParseSyncEntities("incomes,contracts"); !errors.Is(err, ErrSyncEntityInvalid) {
+		t.Fatalf("err = %v, want ErrSyncEntityInvalid", err)
+	}
+}
+
+func TestParseSyncFields(t *testing.T) {
+	if got, err := ParseSyncFields(" "); err != nil || got != nil {
+		t.Fatalf("vazio = %v, %v", got, err)
+	}
+	got, err := ParseSyncFields("receipts.valor, Receipts.numero,receipts.valor,incomes.status")
+	if err != nil || len(got) != 2 || len(got[SyncFeedReceipts]) != 2 || got[SyncFeedReceipts][0] != "numero" || got[SyncFeedIncomes][0] != "status" {
+		t.Fatalf("projeção = %v, %v", got, err)
+	}
+	for _, bad := range []string{"numero", "contracts.id", "receipts.", "receipts.valor)--"} {
+		if _, err := ParseSyncFields(bad); !errors.Is(err, ErrSyncFieldInvalid) {
+			t.Errorf("%q: err = %v, want ErrSyncFieldInvalid", bad, err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Webhooks do usuário (rf_webhooks) e eventos de domínio da outbox (rf_outbox_events)
+// Data: 18-10-2026
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Eventos de domínio publicados nos webhooks (receipt.issued é compartilhado com as notificações;
+// webhook.ping é enviado apenas pelo teste manual)
+const (
+	EventIncomeCreated = "income.created"
+	EventPaymentAdded  = "payment.added"
+	EventWebhookPing   = "webhook.ping"
+)
+
+// MaxWebhooks limite de webhooks por usuário
+const MaxWebhooks = 10
+
+// MaxWebhookDescriptionLen tamanho máximo da descrição
+const MaxWebhookDescriptionLen = 200
+
+// Erros dos webhooks
+var (
+	ErrWebhookNotFound     = errors.New("webhook não encontrado")
+	ErrWebhookURLInvalid   = errors.New("URL do webhook inválida (https com host público)")
+	ErrWebhookURLTaken     = errors.New("já existe um webhook com essa URL")
+	ErrWebhookEventInvalid = errors.New("evento de webhook inválido (use income.created, payment.added ou receipt.issued)")
+	ErrWebhookDescription  = errors.New("descrição do webhook deve ter até 200 caracteres")
+	ErrWebhookLimit        = errors.New("limite de webhooks atingido")
+)
+
+// WebhookEventTypes lista os eventos que podem ser assinados
+func WebhookEventTypes() []string {
+	return []string{EventIncomeCreated, EventPaymentAdded, EventReceiptIssued}
+}
+
+// ValidWebhookEvent verifica se o evento pode ser assinado
+func ValidWebhookEvent(event string) bool {
+	for _, ev := range WebhookEventTypes() {
+		if ev == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook endpoint do usuário que recebe eventos de domínio por POST.
+// Docstring: Events vazio assina todos os eventos; Secret assina o corpo (HMAC-SHA256) e só é
+// exibido na criação e na rotação. Webhooks inativos não recebem novos eventos.
+type Webhook struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	OwnerID     uuid.UUID `json:"owner_id" db:"owner_id"`
+	URL         string    `json:"url" db:"url"`
+	Secret      string    `json:"secret,omitempty" db:"secret"`
+	Events      []string  `json:"events" db:"events"`
+	Description *string   `json:"description" db:"description"`
+	Active      bool      `json:"active" db:"active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Subscribed indica se o webhook recebe o evento
+func (w *Webhook) Subscribed(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, ev := range w.Events {
+		if ev == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookRequest dados de entrada para criar/atualizar um webhook (campos nil mantêm o valor atual)
+type WebhookRequest struct {
+	URL         *string  `json:"url"`
+	Events      []string `json:"events"`
+	Description *string  `json:"description"`
+	Active      *bool    `json:"active"`
+}
+
+// Validate valida e normaliza o pedido; na criação a URL é obrigatória
+func (req *WebhookRequest) Validate(create bool) error {
+	if req.URL == nil {
+		if create {
+			return ErrWebhookURLInvalid
+		}
+	} else {
+		u := strings.TrimSpace(*req.URL)
+		if !ValidWebhookURL(u) {
+			return ErrWebhookURLInvalid
+		}
+		req.URL = &u
+	}
+	if req.Events != nil {
+		seen := map[string]bool{}
+		events := []string{}
+		for _, ev := range req.Events {
+			ev = strings.ToLower(strings.TrimSpace(ev))
+			if !ValidWebhookEvent(ev) {
+				return ErrWebhookEventInvalid
+			}
+			if !seen[ev] {
+				seen[ev] = true
+				events = append(events, ev)
+			}
+		}
+		req.Events = events
+	}
+	trimOptional(&req.Description)
+	if req.Description != nil && len([]rune(*req.Description)) > MaxWebhookDescriptionLen {
+		return ErrWebhookDescription
+	}
+	return nil
+}
+
+// Apply aplica os campos informados ao webhook
+func (req *WebhookRequest) Apply(w *Webhook) {
+	if req.URL != nil {
+		w.URL = *req.URL
+	}
+	if req.Events != nil {
+		w.Events = req.Events
+	}
+	if req.Description != nil {
+		w.Description = req.Description
+	}
+	if req.Active != nil {
+		w.Active = *req.Active
+	}
+}
+
+// ValidWebhookURL aceita apenas https com host público (recusa localhost e IPs privados/loopback)
+func ValidWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil || len(raw) > 2048 {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+			return false
+		}
+	}
+	return true
+}
+
+// OutboxEvent evento de domínio gravado na outbox pelo trigger da alteração (migração 037)
+type OutboxEvent struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	OwnerID     uuid.UUID       `json:"owner_id" db:"owner_id"`
+	EventType   string          `json:"event_type" db:"event_type"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	PublishedAt *time.Time      `json:"published_at" db:"published_at"`
+}
+
+// WebhookEnvelope corpo JSON enviado ao webhook
+type WebhookEnvelope struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
@@ -0,0 +1,175 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: XML do RPS no padrão nacional ABRASF 2.04 (GerarNfseEnvio)
+// Data: 18-10-2026
+
+package nfse
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ABRASFNamespace namespace do esquema nacional de NFS-e (nfse.xsd)
+const ABRASFNamespace = "http://www.abrasf.org.br/nfse.xsd"
+
+type abrasfEnvio struct {
+	XMLName xml.Name  `xml:"GerarNfseEnvio"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Rps     abrasfRps `xml:"Rps"`
+}
+
+type abrasfRps struct {
+	Inf abrasfDeclaracao `xml:"InfDeclaracaoPrestacaoServico"`
+}
+
+type abrasfDeclaracao struct {
+	ID             string          `xml:"Id,attr"`
+	Rps            abrasfRpsInfo   `xml:"Rps"`
+	Competencia    string          `xml:"Competencia"`
+	Servico        abrasfServico   `xml:"Servico"`
+	Prestador      abrasfPrestador `xml:"Prestador"`
+	Tomador        *abrasfTomador  `xml:"TomadorServico,omitempty"`
+	OptanteSimples int             `xml:"OptanteSimplesNacional"`
+	Incentivo      int             `xml:"IncentivoFiscal"`
+}
+
+type abrasfRpsInfo struct {
+	Numero      int64  `xml:"IdentificacaoRps>Numero"`
+	Serie       string `xml:"IdentificacaoRps>Serie"`
+	Tipo        int    `xml:"IdentificacaoRps>Tipo"`
+	DataEmissao string `xml:"DataEmissao"`
+	Status      int    `xml:"Status"`
+}
+
+type abrasfServico struct {
+	ValorServicos       string `xml:"Valores>ValorServicos"`
+	ValorIss            string `xml:"Valores>ValorIss"`
+	Aliquota            string `xml:"Valores>Aliquota"`
+	IssRetido           int    `xml:"IssRetido"`
+	ItemListaServico    string `xml:"ItemListaServico"`
+	CodigoTributacao    string `xml:"CodigoTributacaoMunicipio,omitempty"`
+	Discriminacao       string `xml:"Discriminacao"`
+	CodigoMunicipio     string `xml:"CodigoMunicipio"`
+	ExigibilidadeISS    int    `xml:"ExigibilidadeISS"`
+	MunicipioIncidencia string `xml:"MunicipioIncidencia"`
+}
+
+type abrasfCpfCnpj struct {
+	Cpf  string `xml:"Cpf,omitempty"`
+	Cnpj string `xml:"Cnpj,omitempty"`
+}
+
+type abrasfPrestador struct {
+	CpfCnpj            abrasfCpfCnpj `xml:"CpfCnpj"`
+	InscricaoMunicipal string        `xml:"InscricaoMunicipal"`
+}
+
+type abrasfTomador struct {
+	CpfCnpj     *abrasfCpfCnpj `xml:"IdentificacaoTomador>CpfCnpj,omitempty"`
+	RazaoSocial string         `xml:"RazaoSocial,omitempty"`
+	Email       string         `xml:"Contato>Email,omitempty"`
+}
+
+// BuildABRASF gera o XML GerarNfseEnvio (ABRASF 2.04) do RPS, sem assinatura digital.
+// Docstring: alíquota em percentual com duas casas, como no esquema 2.x (a versão 1.0 usa fração);
+// o ISS é calculado sobre o valor dos serviços e arredondado ao centavo.
+func BuildABRASF(doc *Document) ([]byte, error) {
+	if err := doc.validate(); err != nil {
+		return nil, err
+	}
+	iss := int64(math.Round(float64(doc.ValorServicos) * doc.AliquotaISS / 100))
+	inf := abrasfDeclaracao{
+		ID: fmt.Sprintf("RPS%s%d", doc.RPSSerie, doc.RPSNumero),
+		Rps: abrasfRpsInfo{Numero: doc.RPSNumero, Serie: doc.RPSSerie, Tipo: 1,
+			DataEmissao: doc.DataEmissao.Format("2006-01-02"), Status: 1},
+		Competencia: doc.Competencia.Format("2006-01-02"),
+		Servico: abrasfServico{
+			ValorServicos:       formatCents(doc.ValorServicos),
+			ValorIss:            formatCents(iss),
+			Aliquota:            strconv.FormatFloat(doc.AliquotaISS, 'f', 2, 64),
+			IssRetido:           yesNo(doc.IssRetido),
+			ItemListaServico:    doc.ItemListaServico,
+			CodigoTributacao:    doc.CodigoTributacao,
+			Discriminacao:       truncate(doc.Discriminacao, 2000),
+			CodigoMunicipio:     doc.CodigoMunicipio,
+			ExigibilidadeISS:    1,
+			MunicipioIncidencia: doc.CodigoMunicipio,
+		},
+		Prestador:      abrasfPrestador{CpfCnpj: cpfCnpj(doc.Prestador.Documento), InscricaoMunicipal: doc.Prestador.InscricaoMunicipal},
+		OptanteSimples: yesNo(doc.OptanteSimples),
+		Incentivo:      2,
+	}
+	if t := doc.Tomador; t.Documento != "" || t.RazaoSocial != "" || t.Email != "" {
+		inf.Tomador = &abrasfTomador{RazaoSocial: truncate(t.RazaoSocial, 150), Email: t.Email}
+		if len(t.Documento) == 11 || len(t.Documento) == 14 {
+			id := cpfCnpj(t.Documento)
+			inf.Tomador.CpfCnpj = &id
+		}
+	}
+	out, err := xml.MarshalIndent(abrasfEnvio{Xmlns: ABRASFNamespace, Rps: abrasfRps{Inf: inf}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// validate campos exigidos pelo esquema ABRASF
+func (d *Document) validate() error {
+	var missing []string
+	if d.RPSNumero <= 0 {
+		missing = append(missing, "número do RPS")
+	}
+	if n := len(d.Prestador.Documento); n != 11 && n != 14 {
+		missing = append(missing, "CPF/CNPJ do prestador")
+	}
+	if d.Prestador.InscricaoMunicipal == "" {
+		missing = append(missing, "inscrição municipal")
+	}
+	if len(d.CodigoMunicipio) != 7 {
+		missing = append(missing, "código IBGE do município")
+	}
+	if d.ItemListaServico == "" {
+		missing = append(missing, "item da lista de serviços")
+	}
+	if d.ValorServicos <= 0 {
+		missing = append(missing, "valor dos serviços")
+	}
+	if strings.TrimSpace(d.Discriminacao) == "" {
+		missing = append(missing, "discriminação")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidDocument, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func cpfCnpj(doc string) abrasfCpfCnpj {
+	if len(doc) == 11 {
+		return abrasfCpfCnpj{Cpf: doc}
+	}
+	return abrasfCpfCnpj{Cnpj: doc}
+}
+
+// yesNo códigos sim/não do esquema (1 = sim, 2 = não)
+func yesNo(v bool) int {
+	if v {
+		return 1
+	}
+	return 2
+}
+
+func formatCents(cents int64) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -0,0 +1,111 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: NFS-e (nota fiscal de serviço eletrônica): documento do RPS e integrações municipais plugáveis
+// Data: 18-10-2026
+
+package nfse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+var (
+	ErrNotConfigured   = errors.New("integração de NFS-e não configurada (somente exportação do XML)")
+	ErrUnknownProvider = errors.New("integração de NFS-e desconhecida")
+	ErrInvalidDocument = errors.New("dados do RPS incompletos para a NFS-e")
+)
+
+// Party prestador ou tomador do serviço; Documento é CPF (11) ou CNPJ (14), só dígitos
+type Party struct {
+	Documento          string
+	InscricaoMunicipal string
+	RazaoSocial        string
+	Email              string
+}
+
+// Document RPS (recibo provisório de serviços) que a prefeitura converte em NFS-e.
+// Docstring: ValorServicos em centavos; AliquotaISS em percentual (2 = 2%). CodigoMunicipio é o
+// código IBGE de 7 dígitos do município de prestação e ItemListaServico o item da LC 116/2003
+// ("17.01"). Tomador sem documento é enviado como não identificado.
+type Document struct {
+	RPSNumero        int64
+	RPSSerie         string
+	DataEmissao      time.Time
+	Competencia      time.Time
+	Prestador        Party
+	Tomador          Party
+	ValorServicos    int64
+	AliquotaISS      float64
+	IssRetido        bool
+	ItemListaServico string
+	CodigoTributacao string
+	CodigoMunicipio  string
+	Discriminacao    string
+	OptanteSimples   bool
+}
+
+// Result resposta da prefeitura: NFS-e emitida (Numero) ou lote em processamento (Protocolo)
+type Result struct {
+	Numero            string
+	CodigoVerificacao string
+	Protocolo         string
+}
+
+// Provider envia o RPS ao sistema de NFS-e de uma prefeitura.
+// Docstring: payload é o XML ABRASF gerado pelo ReciboFast; integrações que exigem assinatura
+// digital ou layout próprio (São Paulo, por exemplo) assinam ou convertem o documento por conta própria.
+type Provider interface {
+	Name() string
+	Submit(ctx context.Context, doc *Document, payload []byte) (*Result, error)
+}
+
+// Factory cria a integração a partir da configuração
+type Factory func(cfg *config.Config) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register disponibiliza uma integração municipal para NFSE_PROVIDER (chamado no init do pacote dela)
+func Register(name string, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(name)] = f
+}
+
+// New cria a integração configurada; sem NFSE_PROVIDER retorna ErrNotConfigured e as notas ficam
+// só como XML para download
+func New(cfg *config.Config) (Provider, error) {
+	name := strings.ToLower(strings.TrimSpace(cfg.NFSeProvider))
+	if name == "" {
+		return nil, ErrNotConfigured
+	}
+	registryMu.RLock()
+	f, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (disponíveis: %s)", ErrUnknownProvider, name, strings.Join(Providers(), ", "))
+	}
+	return f(cfg)
+}
+
+// Providers nomes das integrações registradas, em ordem alfabética
+func Providers() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]string, 0, len(registry))
+	for name := range registry {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,90 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do XML ABRASF do RPS e do registro de integrações municipais
+// Data: 18-10-2026
+
+package nfse
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+func sampleDocument() *Document {
+	return &Document{
+		RPSNumero:        42,
+		RPSSerie:         "RF",
+		DataEmissao:      time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		Competencia:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Prestador:        Party{Documento: "11222333000181", InscricaoMunicipal: "12345"},
+		Tomador:          Party{Documento: "52998224725", RazaoSocial: "Maria Silva", Email: "maria@exemplo.com"},
+		ValorServicos:    150050,
+		AliquotaISS:      2,
+		ItemListaServico: "17.01",
+		CodigoMunicipio:  "3550308",
+		Discriminacao:    "Consultoria - 2026-10",
+	}
+}
+
+func TestBuildABRASF(t *testing.T) {
+	out, err := BuildABRASF(sampleDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(out)
+	for _, want := range []string{
+		`<GerarNfseEnvio xmlns="http://www.abrasf.org.br/nfse.xsd">`,
+		`<InfDeclaracaoPrestacaoServico Id="RPSRF42">`,
+		`<Numero>42</Numero>`, `<DataEmissao>2026-10-18</DataEmissao>`, `<Competencia>2026-10-01</Competencia>`,
+		`<ValorServicos>1500.50</ValorServicos>`, `<ValorIss>30.01</ValorIss>`, `<Aliquota>2.00</Aliquota>`,
+		`<Cnpj>11222333000181</Cnpj>`, `<Cpf>52998224725</Cpf>`, `<Email>maria@exemplo.com</Email>`,
+		`<OptanteSimplesNacional>2</OptanteSimplesNacional>`,
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("XML sem %s:\n%s", want, s)
+		}
+	}
+	if !strings.HasPrefix(s, xml.Header) {
+		t.Fatal("XML sem cabeçalho")
+	}
+	if err := xml.Unmarshal(out, new(abrasfEnvio)); err != nil {
+		t.Fatalf("XML inválido: %v", err)
+	}
+
+	doc := sampleDocument()
+	doc.Tomador = Party{}
+	if out, _ := BuildABRASF(doc); strings.Contains(string(out), "TomadorServico") {
+		t.Fatal("tomador não identificado não deveria aparecer")
+	}
+	doc.Prestador.InscricaoMunicipal, doc.CodigoMunicipio = "", "35"
+	if _, err := BuildABRASF(doc); !errors.Is(err, ErrInvalidDocument) || !strings.Contains(err.Error(), "inscrição municipal") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+type fakeProvider struct{}
+
+func (fakeProvider) Name() string { return "teste" }
+func (fakeProvider) Submit(ctx context.Context, doc *Document, payload []byte) (*Result, error) {
+	return &Result{Numero: "1"}, nil
+}
+
+func TestRegistry(t *testing.T) {
+	if _, err := New(&config.Config{}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("sem provedor: err = %v", err)
+	}
+	if _, err := New(&config.Config{NFSeProvider: "curitiba"}); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("provedor desconhecido: err = %v", err)
+	}
+	Register("Teste", func(cfg *config.Config) (Provider, error) { return fakeProvider{}, nil })
+	p, err := New(&config.Config{NFSeProvider: "teste"})
+	if err != nil || p.Name() != "teste" {
+		t.Fatalf("provedor registrado: %v, %v", p, err)
+	}
+}
@@ -0,0 +1,210 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Montagem do documento OpenAPI a partir das rotas registradas e do catálogo de operações
+// Data: 18-10-2026
+
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"recibofast/internal/apierror"
+)
+
+// Autenticação exigida por uma rota
+const (
+	AuthUser  = "user"  // JWT do Supabase (Authorization: Bearer) ou chave de API (X-API-Key)
+	AuthAdmin = "admin" // token administrativo (X-Admin-Token)
+	AuthNone  = "none"  // pública
+)
+
+// Route rota registrada no roteador (método e padrão do chi, ex.: /api/v1/incomes/{id})
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Endpoint metadados de uma operação do catálogo.
+// Docstring: Request e Response são valores dos tipos Go dos corpos JSON (ex.: models.Income{});
+// ContentType troca a resposta JSON por outro formato (PDF, CSV). Status padrão: 200.
+type Endpoint struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Query       []Parameter
+	Request     interface{}
+	Response    interface{}
+	Status      int
+	ContentType string
+}
+
+// Spec entrada da montagem: identificação, rotas do roteador e catálogo.
+// Docstring: rotas fora do catálogo entram com resumo genérico e corpo livre; Auth decide a
+// autenticação de cada caminho e Tag o grupo (padrão: o primeiro segmento após a versão).
+type Spec struct {
+	Info      Info
+	Servers   []Server
+	Tags      []Tag
+	Routes    []Route
+	Endpoints []Endpoint
+	Registry  *Registry
+	Auth      func(path string) string
+	Tag       func(path string) string
+}
+
+var pathParam = regexp.MustCompile(`\{([^}/:]+)(:[^}]*)?\}`)
+
+// Build monta o documento; um endpoint do catálogo sem rota correspondente é erro (catálogo desatualizado)
+func Build(spec Spec) (*Document, error) {
+	reg := spec.Registry
+	if reg == nil {
+		reg = NewRegistry()
+	}
+	tagOf := spec.Tag
+	if tagOf == nil {
+		tagOf = DefaultTag
+	}
+	errSchema := reg.SchemaFor(apierror.Error{})
+
+	catalog := map[string]Endpoint{}
+	for _, ep := range spec.Endpoints {
+		catalog[strings.ToUpper(ep.Method)+" "+normalizePath(ep.Path)] = ep
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    spec.Info,
+		Servers: spec.Servers,
+		Tags:    spec.Tags,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Responses: map[string]*Response{
+				"Error": {Description: "Erro ({code, message, details, request_id})",
+					Content: map[string]MediaType{"application/json": {Schema: errSchema}}},
+			},
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "JWT do Supabase Auth"},
+				"apiKey":     {Type: "apiKey", In: "header", Name: "X-API-Key", Description: "Chave de API do usuário (integrações)"},
+				"adminToken": {Type: "apiKey", In: "header", Name: "X-Admin-Token", Description: "Token administrativo (ADMIN_TOKEN)"},
+			},
+		},
+	}
+
+	seen := map[string]bool{}
+	for _, rt := range spec.Routes {
+		method := strings.ToUpper(rt.Method)
+		path := normalizePath(rt.Path)
+		key := method + " " + path
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		ep, inCatalog := catalog[key]
+		if !inCatalog {
+			ep = Endpoint{Method: method, Path: path}
+		}
+		delete(catalog, key)
+
+		op := &Operation{
+			OperationID: operationID(method, path),
+			Summary:     ep.Summary,
+			Description: ep.Description,
+			Responses:   map[string]*Response{},
+			Security:    []SecurityRequirement{},
+		}
+		if op.Summary == "" {
+			op.Summary = method + " " + path
+		}
+		if tag := tagOf(path); tag != "" {
+			op.Tags = []string{tag}
+		}
+		for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+			op.Parameters = append(op.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		op.Parameters = append(op.Parameters, ep.Query...)
+
+		auth := AuthUser
+		if spec.Auth != nil {
+			auth = spec.Auth(path)
+		}
+		switch auth {
+		case AuthUser:
+			op.Security = []SecurityRequirement{{"bearerAuth": {}}, {"apiKey": {}}}
+		case AuthAdmin:
+			op.Security = []SecurityRequirement{{"adminToken": {}}}
+		}
+
+		if ep.Request != nil {
+			op.RequestBody = &RequestBody{Required: true,
+				Content: map[string]MediaType{"application/json": {Schema: reg.SchemaFor(ep.Request)}}}
+		} else if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
+			op.RequestBody = &RequestBody{Content: map[string]MediaType{"application/json": {Schema: &Schema{}}}}
+		}
+
+		status := ep.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		resp := &Response{Description: http.StatusText(status)}
+		switch {
+		case ep.ContentType != "":
+			resp.Content = map[string]MediaType{ep.ContentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+		case ep.Response != nil:
+			resp.Content = map[string]MediaType{"application/json": {Schema: reg.SchemaFor(ep.Response)}}
+		case status != http.StatusNoContent && !inCatalog:
+			resp.Content = map[string]MediaType{"application/json": {Schema: &Schema{}}}
+		}
+		op.Responses[strconv.Itoa(status)] = resp
+		op.Responses["default"] = &Response{Ref: "#/components/responses/Error"}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(method)] = op
+	}
+
+	if len(catalog) > 0 {
+		return nil, fmt.Errorf("operações do catálogo sem rota registrada: %s", strings.Join(sortedKeys(catalog), ", "))
+	}
+	doc.Components.Schemas = reg.Schemas()
+	return doc, nil
+}
+
+// DefaultTag grupo da rota: o primeiro segmento após /api/vN (ex.: /api/v1/incomes/{id} → incomes)
+func DefaultTag(path string) string {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segs) > 2 && segs[0] == "api" {
+		return segs[2]
+	}
+	return segs[0]
+}
+
+// normalizePath remove a barra final do chi ("/api/v1/incomes/" vira "/api/v1/incomes") e
+// expressões regulares dos parâmetros ("{id:[0-9]+}" vira "{id}")
+func normalizePath(p string) string {
+	if len(p) > 1 {
+		p = strings.TrimSuffix(p, "/")
+	}
+	return pathParam.ReplaceAllString(p, "{$1}")
+}
+
+// operationID identificador estável da operação (ex.: get_api_v1_incomes_id)
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, r := range path {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '/' || r == '-' || r == '.':
+			b.WriteByte('_')
+		}
+	}
+	return strings.ReplaceAll(b.String(), "__", "_")
+}
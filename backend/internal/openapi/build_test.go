@@ -0,0 +1,145 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da geração de esquemas e da montagem do documento OpenAPI
+// Data: 18-10-2026
+
+package openapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type sampleOwner struct {
+	ID   uuid.UUID `json:"id"`
+	Nome string    `json:"nome"`
+}
+
+type sampleBase struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type sample struct {
+	sampleBase
+	Valor    float64        `json:"valor"`
+	Due      *time.Time     `json:"due,omitempty"`
+	Owner    *sampleOwner   `json:"owner"`
+	Tags     []string       `json:"tags"`
+	Extra    map[string]int `json:"extra"`
+	Count    int64          `json:"count,string"`
+	Secret   string         `json:"-"`
+	internal string
+}
+
+func TestRegistry_SchemaFor(t *testing.T) {
+	reg := NewRegistry()
+	s := reg.SchemaFor(sample{})
+	if s.Ref != "#/components/schemas/sample" {
+		t.Fatalf("ref = %q", s.Ref)
+	}
+	c := reg.Schemas()["sample"]
+	if c == nil {
+		t.Fatal("componente sample não registrado")
+	}
+	if p := c.Properties["created_at"]; p == nil || p.Format != "date-time" {
+		t.Errorf("campo embutido não achatado: %+v", p)
+	}
+	if p := c.Properties["due"]; p == nil || !p.Nullable || p.Format != "date-time" {
+		t.Errorf("ponteiro deveria ser nullable: %+v", p)
+	}
+	if p := c.Properties["owner"]; p == nil || !p.Nullable || len(p.AllOf) != 1 || p.AllOf[0].Ref != "#/components/schemas/sampleOwner" {
+		t.Errorf("ponteiro para struct: %+v", p)
+	}
+	if p := c.Properties["tags"]; p == nil || p.Type != "array" || p.Items.Type != "string" {
+		t.Errorf("slice: %+v", p)
+	}
+	if p := c.Properties["extra"]; p == nil || p.AdditionalProperties.Type != "integer" {
+		t.Errorf("mapa: %+v", p)
+	}
+	if p := c.Properties["count"]; p == nil || p.Type != "string" {
+		t.Errorf(`opção ",string": %+v`, p)
+	}
+	for _, name := range []string{"Secret", "internal", "sampleBase"} {
+		if _, ok := c.Properties[name]; ok {
+			t.Errorf("campo %s não deveria aparecer", name)
+		}
+	}
+	if reg.Schemas()["sampleOwner"].Properties["id"].Format != "uuid" {
+		t.Error("uuid.UUID deveria ser string/uuid")
+	}
+}
+
+func TestRegistry_GenericNameAndOverride(t *testing.T) {
+	type cents int64
+	reg := NewRegistry()
+	reg.Override(cents(0), &Schema{Type: "string", Format: "decimal"})
+	if s := reg.SchemaFor(Items[sampleOwner]{}); s.Ref != "#/components/schemas/ItemssampleOwner" {
+		t.Errorf("genérico: ref = %q", s.Ref)
+	}
+	if s := reg.SchemaFor(cents(0)); s.Format != "decimal" {
+		t.Errorf("override: %+v", s)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	doc, err := Build(Spec{
+		Info: Info{Title: "teste", Version: "1"},
+		Routes: []Route{
+			{Method: "GET", Path: "/api/v1/owners/"},
+			{Method: "POST", Path: "/api/v1/owners/"},
+			{Method: "DELETE", Path: "/api/v1/owners/{id:[0-9]+}"},
+			{Method: "GET", Path: "/healthz"},
+		},
+		Endpoints: []Endpoint{
+			{Method: "POST", Path: "/api/v1/owners", Summary: "Cria", Request: sampleOwner{}, Response: sampleOwner{}, Status: http.StatusCreated},
+			{Method: "DELETE", Path: "/api/v1/owners/{id}", Status: http.StatusNoContent},
+		},
+		Auth: func(path string) string {
+			if path == "/healthz" {
+				return AuthNone
+			}
+			return AuthUser
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := doc.Paths["/api/v1/owners"]["post"]
+	if post == nil || post.Summary != "Cria" || post.Responses["201"] == nil || post.Tags[0] != "owners" {
+		t.Fatalf("POST /owners: %+v", post)
+	}
+	if post.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/sampleOwner" {
+		t.Errorf("corpo da requisição: %+v", post.RequestBody)
+	}
+	del := doc.Paths["/api/v1/owners/{id}"]["delete"]
+	if del == nil || del.OperationID != "delete_api_v1_owners_id" || len(del.Parameters) != 1 || del.Parameters[0].In != "path" {
+		t.Fatalf("DELETE /owners/{id}: %+v", del)
+	}
+	if del.Responses["204"].Content != nil || del.Responses["default"].Ref != "#/components/responses/Error" {
+		t.Errorf("respostas do DELETE: %+v", del.Responses)
+	}
+	if get := doc.Paths["/api/v1/owners"]["get"]; get.Summary != "GET /api/v1/owners" || get.Responses["200"].Content == nil {
+		t.Errorf("rota fora do catálogo: %+v", get)
+	}
+	if len(doc.Paths["/healthz"]["get"].Security) != 0 || len(post.Security) != 2 {
+		t.Error("autenticação por caminho não aplicada")
+	}
+	if doc.Components.Schemas["Error"] == nil {
+		t.Error("esquema de erro ausente")
+	}
+}
+
+func TestBuild_StaleCatalog(t *testing.T) {
+	_, err := Build(Spec{
+		Routes:    []Route{{Method: "GET", Path: "/api/v1/owners"}},
+		Endpoints: []Endpoint{{Method: "GET", Path: "/api/v1/removed"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "GET /api/v1/removed") {
+		t.Fatalf("catálogo desatualizado deveria falhar: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Tipos do documento OpenAPI 3.0 (subconjunto usado pela especificação da API)
+// Data: 18-10-2026
+
+package openapi
+
+// Version versão da especificação OpenAPI gerada
+const Version = "3.0.3"
+
+// Document documento OpenAPI (servido em /api/v1/openapi.json)
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers,omitempty"`
+	Tags       []Tag               `json:"tags,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info identificação da API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server URL base da API
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag agrupamento das operações (um recurso da API)
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem operações de um caminho, por método em minúsculas (get, post...)
+type PathItem map[string]*Operation
+
+// Operation uma rota da API
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security"` // vazio: rota pública
+}
+
+// Parameter parâmetro de caminho, query ou cabeçalho
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query ou header
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody corpo da requisição
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response resposta de uma operação
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType conteúdo de um corpo (JSON, PDF, CSV...)
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Schema esquema JSON (dialeto do OpenAPI 3.0)
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Example              interface{}        `json:"example,omitempty"`
+}
+
+// Components esquemas, respostas e esquemas de segurança reutilizados
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	Responses       map[string]*Response       `json:"responses,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme forma de autenticação
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// SecurityRequirement esquemas aceitos por uma operação (nome → escopos)
+type SecurityRequirement map[string][]string
+
+// Items corpo das listagens no formato {"items": [...]} (componente ItemsT, ex.: ItemsCategory)
+type Items[T any] struct {
+	Items []T `json:"items"`
+}
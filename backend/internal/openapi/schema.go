@@ -0,0 +1,191 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Geração dos esquemas OpenAPI a partir dos tipos Go (tags json) por reflexão
+// Data: 18-10-2026
+
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Registry esquemas nomeados (components/schemas) gerados a partir dos tipos Go.
+// Docstring: structs nomeadas viram componentes referenciados por $ref; campos seguem as tags json
+// (omitidos com "-", embutidos sem tag são achatados). Tipos com serialização própria usam o esquema
+// registrado em Override; os que implementam TextMarshaler viram string.
+type Registry struct {
+	schemas   map[string]*Schema
+	names     map[reflect.Type]string
+	overrides map[reflect.Type]*Schema
+}
+
+var (
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// NewRegistry cria o registro com os tipos comuns (datas, UUID e JSON bruto) já mapeados
+func NewRegistry() *Registry {
+	r := &Registry{schemas: map[string]*Schema{}, names: map[reflect.Type]string{}, overrides: map[reflect.Type]*Schema{}}
+	r.Override(time.Time{}, &Schema{Type: "string", Format: "date-time"})
+	r.Override(uuid.UUID{}, &Schema{Type: "string", Format: "uuid"})
+	r.Override(json.RawMessage{}, &Schema{Description: "JSON livre"})
+	return r
+}
+
+// Override define o esquema de um tipo com serialização própria (ex.: valores monetários)
+func (r *Registry) Override(v interface{}, s *Schema) {
+	r.overrides[reflect.TypeOf(v)] = s
+}
+
+// SchemaFor esquema do tipo do valor informado (nil não tem esquema)
+func (r *Registry) SchemaFor(v interface{}) *Schema {
+	if v == nil {
+		return nil
+	}
+	return r.schemaOf(reflect.TypeOf(v))
+}
+
+// Schemas componentes registrados até aqui, por nome
+func (r *Registry) Schemas() map[string]*Schema {
+	return r.schemas
+}
+
+func (r *Registry) schemaOf(t reflect.Type) *Schema {
+	if s, ok := r.overrides[t]; ok {
+		out := *s
+		return &out
+	}
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Struct && t.Implements(jsonMarshaler) {
+		return &Schema{Description: "formato próprio"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := r.schemaOf(t.Elem())
+		if s.Ref != "" {
+			return &Schema{AllOf: []*Schema{s}, Nullable: true}
+		}
+		s.Nullable = true
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Interface:
+		return &Schema{}
+	}
+	if t.Implements(textMarshaler) && !t.Implements(jsonMarshaler) {
+		return &Schema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + r.register(t)}
+	}
+	return &Schema{}
+}
+
+// register guarda o esquema da struct nomeada e devolve o nome do componente
+func (r *Registry) register(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	name := componentName(t)
+	if _, taken := r.schemas[name]; taken {
+		pkg := t.PkgPath()
+		pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	r.names[t] = name
+	r.schemas[name] = &Schema{} // reservado antes dos campos: tipos recursivos
+	*r.schemas[name] = *r.structSchema(t)
+	return name
+}
+
+func (r *Registry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	r.addFields(s, t)
+	return s
+}
+
+func (r *Registry) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				r.addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fs := r.schemaOf(ft)
+		if strings.Contains(opts, "string") && fs.Type != "string" {
+			fs = &Schema{Type: "string"}
+		}
+		s.Properties[name] = fs
+	}
+}
+
+// componentName nome do componente: o nome do tipo; genéricos juntam os argumentos
+// (Page[models.Income] vira PageIncome)
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	base, args, generic := strings.Cut(name, "[")
+	if !generic {
+		return name
+	}
+	parts := strings.Split(strings.TrimSuffix(args, "]"), ",")
+	for i, p := range parts {
+		p = strings.TrimLeft(strings.TrimSpace(p), "*[]")
+		parts[i] = p[strings.LastIndex(p, ".")+1:]
+	}
+	return base + strings.Join(parts, "")
+}
+
+// sortedKeys chaves do mapa em ordem (documento estável entre execuções)
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
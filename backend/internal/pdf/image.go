@@ -0,0 +1,65 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Imagens no gerador de PDF (logotipos), com transparência por máscara
+// Data: 18-10-2026
+
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// ImageRef referência a uma imagem adicionada ao documento
+type ImageRef int
+
+type imageObject struct {
+	width, height int
+	rgb           []byte // RGB comprimido (FlateDecode)
+	alpha         []byte // máscara em cinza comprimida; nil quando a imagem é opaca
+}
+
+// AddImage inclui img no documento uma única vez; desenhe com DrawImage em quantas páginas quiser
+func (d *Document) AddImage(img image.Image) ImageRef {
+	b := img.Bounds()
+	rgb := make([]byte, 0, b.Dx()*b.Dy()*3)
+	alpha := make([]byte, 0, b.Dx()*b.Dy())
+	opaque := true
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			rgb = append(rgb, c.R, c.G, c.B)
+			alpha = append(alpha, c.A)
+			opaque = opaque && c.A == 0xff
+		}
+	}
+	obj := &imageObject{width: b.Dx(), height: b.Dy(), rgb: deflate(rgb)}
+	if !opaque {
+		obj.alpha = deflate(alpha)
+	}
+	d.images = append(d.images, obj)
+	return ImageRef(len(d.images) - 1)
+}
+
+// DrawImage desenha a imagem com o canto superior esquerdo em (x, y) e tamanho w×h
+func (d *Document) DrawImage(ref ImageRef, x, y, w, h float64) {
+	fmt.Fprintf(d.page(), "q %s 0 0 %s %s %s cm /Im%d Do Q\n", num(w), num(h), num(x), num(PageHeight-y-h), int(ref))
+}
+
+// FitImage dimensões para desenhar a imagem dentro de maxW×maxH mantendo a proporção
+func (d *Document) FitImage(ref ImageRef, maxW, maxH float64) (float64, float64) {
+	img := d.images[ref]
+	scale := min(maxW/float64(img.width), maxH/float64(img.height))
+	return float64(img.width) * scale, float64(img.height) * scale
+}
+
+func deflate(b []byte) []byte {
+	var buf bytes.Buffer
+	zw, _ := zlib.NewWriterLevel(&buf, zlib.BestCompression)
+	zw.Write(b)
+	zw.Close()
+	return buf.Bytes()
+}
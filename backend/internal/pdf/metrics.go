@@ -0,0 +1,80 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Larguras das fontes Helvetica (métricas AFM padrão) para alinhamento e quebra de linha
+// Data: 18-10-2026
+
+package pdf
+
+import "strings"
+
+// larguras em milésimos do corpo para os caracteres ASCII 32..126
+var helvetica = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBold = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// TextWidth largura de s em pontos no corpo size.
+// Docstring: letras acentuadas usam a largura da letra base (igual nas métricas da Helvetica).
+func TextWidth(s string, size float64, bold bool) float64 {
+	table := &helvetica
+	if bold {
+		table = &helveticaBold
+	}
+	total := 0
+	for _, r := range s {
+		r = baseLetter(r)
+		if r < 32 || r > 126 {
+			r = 'o'
+		}
+		total += table[r-32]
+	}
+	return float64(total) * size / 1000
+}
+
+// Wrap quebra s em linhas que cabem em width (palavras maiores que a linha ficam sozinhas)
+func Wrap(s string, width, size float64, bold bool) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && TextWidth(candidate, size, bold) > width {
+			lines = append(lines, line)
+			candidate = word
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+var accents = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'é': 'e', 'ê': 'e', 'è': 'e', 'í': 'i', 'ì': 'i',
+	'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ú': 'u', 'ù': 'u', 'ü': 'u', 'ç': 'c', 'ñ': 'n',
+	'Á': 'A', 'À': 'A', 'Â': 'A', 'Ã': 'A', 'É': 'E', 'Ê': 'E', 'Í': 'I', 'Ó': 'O', 'Ô': 'O',
+	'Õ': 'O', 'Ú': 'U', 'Ç': 'C', 'Ñ': 'N', 'º': 'o', 'ª': 'a',
+}
+
+func baseLetter(r rune) rune {
+	if b, ok := accents[r]; ok {
+		return b
+	}
+	return r
+}
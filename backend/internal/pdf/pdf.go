@@ -0,0 +1,243 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Gerador mínimo de PDF (texto e traços com fontes padrão) para documentos gerados no backend
+// Data: 18-10-2026
+
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Dimensões da página A4 em pontos (1/72 pol.)
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Document acumula páginas com texto e traços em coordenadas a partir do canto superior esquerdo.
+// Docstring: usa Helvetica e Helvetica-Bold (fontes padrão, sem embutir) com WinAnsiEncoding,
+// suficiente para o português; a saída é determinística (sem data de criação) para que o mesmo
+// conteúdo gere o mesmo hash.
+type Document struct {
+	pages  []*bytes.Buffer
+	cur    *bytes.Buffer
+	images []*imageObject
+}
+
+// New cria um documento vazio
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage inicia uma nova página; os desenhos seguintes vão para ela
+func (d *Document) AddPage() {
+	d.cur = &bytes.Buffer{}
+	d.pages = append(d.pages, d.cur)
+}
+
+// PageCount número de páginas
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text escreve s com a linha de base em (x, y)
+func (d *Document) Text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page(), "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, num(size), num(x), num(PageHeight-y), escape(s))
+}
+
+// TextRight escreve s alinhado à direita em x
+func (d *Document) TextRight(x, y, size float64, bold bool, s string) {
+	d.Text(x-TextWidth(s, size, bold), y, size, bold, s)
+}
+
+// Paragraph escreve o texto quebrando linhas em width; retorna o y da próxima linha
+func (d *Document) Paragraph(x, y, width, size float64, s string) float64 {
+	lineHeight := size * 1.4
+	for _, line := range Wrap(s, width, size, false) {
+		d.Text(x, y, size, false, line)
+		y += lineHeight
+	}
+	return y
+}
+
+// Line traça uma linha; dashed usa tracejado (linha de corte)
+func (d *Document) Line(x1, y1, x2, y2, width float64, dashed bool) {
+	dash := "[] 0 d"
+	if dashed {
+		dash = "[4 3] 0 d"
+	}
+	fmt.Fprintf(d.page(), "%s %s w %s %s m %s %s l S\n", dash, num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Rect traça um retângulo; fill > 0 preenche com cinza (0 = preto, 1 = branco) antes da borda
+func (d *Document) Rect(x, y, w, h, fill float64) {
+	box := fmt.Sprintf("%s %s %s %s re", num(x), num(PageHeight-y-h), num(w), num(h))
+	if fill > 0 {
+		fmt.Fprintf(d.page(), "%s g %s f 0 g\n", num(fill), box)
+	}
+	fmt.Fprintf(d.page(), "[] 0 d 0.8 w %s S\n", box)
+}
+
+// Color cor RGB com componentes entre 0 e 1
+type Color struct{ R, G, B float64 }
+
+// Black cor padrão de texto e traços
+var Black = Color{}
+
+// HexColor converte "#rrggbb" (com ou sem "#") em Color
+func HexColor(s string) (Color, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) != 6 {
+		return Color{}, false
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return Color{}, false
+	}
+	return Color{R: float64(v>>16&0xff) / 255, G: float64(v>>8&0xff) / 255, B: float64(v&0xff) / 255}, true
+}
+
+func (c Color) fill() string {
+	return fmt.Sprintf("%s %s %s rg", num(c.R), num(c.G), num(c.B))
+}
+
+// TextColor escreve s como Text, na cor c
+func (d *Document) TextColor(x, y, size float64, bold bool, s string, c Color) {
+	fmt.Fprintf(d.page(), "q %s\n", c.fill())
+	d.Text(x, y, size, bold, s)
+	d.page().WriteString("Q\n")
+}
+
+// FillRect preenche um retângulo sem borda na cor c (faixas de cabeçalho e rodapé)
+func (d *Document) FillRect(x, y, w, h float64, c Color) {
+	fmt.Fprintf(d.page(), "q %s %s %s %s %s re f Q\n", c.fill(), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// Watermark escreve s em diagonal, centralizado e em cinza claro na página atual.
+// Docstring: chame logo após AddPage para que o conteúdo fique por cima; o corpo é reduzido
+// até o texto caber na diagonal útil da página.
+func (d *Document) Watermark(s string) {
+	if strings.TrimSpace(s) == "" {
+		return
+	}
+	const angle = math.Pi / 4
+	size := 64.0
+	if w := TextWidth(s, size, true); w > 0.75*math.Hypot(PageWidth, PageHeight) {
+		size *= 0.75 * math.Hypot(PageWidth, PageHeight) / w
+	}
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	half := TextWidth(s, size, true) / 2
+	// origem da linha de base para que o centro do texto caia no centro da página
+	x := PageWidth/2 - half*cos + size/3*sin
+	y := PageHeight/2 - half*sin - size/3*cos
+	fmt.Fprintf(d.page(), "q 0.85 g BT /F2 %s Tf %s %s %s %s %s %s Tm (%s) Tj ET Q\n",
+		num(size), num(cos), num(sin), num(-sin), num(cos), num(x), num(y), escape(s))
+}
+
+func (d *Document) page() *bytes.Buffer {
+	if d.cur == nil {
+		d.AddPage()
+	}
+	return d.cur
+}
+
+// Bytes serializa o documento
+func (d *Document) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	n := len(d.pages)
+	// 1 catálogo, 2 árvore de páginas, 3-4 fontes, depois as imagens (cada uma com a máscara
+	// de transparência, se houver) e por fim pares (página, conteúdo)
+	first := 5
+	for _, img := range d.images {
+		first++
+		if img.alpha != nil {
+			first++
+		}
+	}
+	kids := make([]string, n)
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", first+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), n))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	var xobjects []string
+	for i, img := range d.images {
+		xobjects = append(xobjects, fmt.Sprintf("/Im%d %d 0 R", i, len(offsets)+1))
+		smask := ""
+		if img.alpha != nil {
+			smask = fmt.Sprintf(" /SMask %d 0 R", len(offsets)+2)
+		}
+		obj(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode%s /Length %d >>\nstream\n%s\nendstream",
+			img.width, img.height, smask, len(img.rgb), img.rgb))
+		if img.alpha != nil {
+			obj(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+				img.width, img.height, len(img.alpha), img.alpha))
+		}
+	}
+	resources := "/Font << /F1 3 0 R /F2 4 0 R >>"
+	if len(xobjects) > 0 {
+		resources += " /XObject << " + strings.Join(xobjects, " ") + " >>"
+	}
+	for i, content := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << %s >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), resources, first+1+2*i))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// escape converte para WinAnsi (Latin-1; outros caracteres viram "?") e escapa a string literal
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,143 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do gerador mínimo de PDF
+// Data: 18-10-2026
+
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestDocument_XrefOffsetsPointToObjects(t *testing.T) {
+	d := New()
+	d.AddPage()
+	d.Text(50, 50, 12, true, "Recibo (cópia) \\ competência")
+	d.AddPage()
+	d.Line(10, 10, 100, 10, 1, true)
+	out := d.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("cabeçalho/rodapé inválidos")
+	}
+	if !bytes.Contains(out, []byte("/Count 2")) {
+		t.Fatalf("deveria ter 2 páginas")
+	}
+	if !bytes.Contains(out, []byte(`(Recibo \(c\363pia\) \\ compet\352ncia) Tj`)) {
+		t.Fatalf("texto não foi escapado em WinAnsi: %s", out)
+	}
+	m := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref não aponta para a tabela xref")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out, -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if !bytes.HasPrefix(out[off:], []byte(fmt.Sprintf("%d 0 obj", i+1))) {
+			t.Fatalf("offset do objeto %d incorreto", i+1)
+		}
+	}
+	if !bytes.Equal(out, d.Bytes()) {
+		t.Fatalf("saída deveria ser determinística")
+	}
+}
+
+func TestWrap_FitsWidth(t *testing.T) {
+	lines := Wrap("Recebi de Maria da Silva a importância de R$ 1.500,00 referente ao aluguel", 150, 12, false)
+	if len(lines) < 2 {
+		t.Fatalf("texto deveria quebrar: %q", lines)
+	}
+	for _, l := range lines {
+		if TextWidth(l, 12, false) > 150 {
+			t.Fatalf("linha excede a largura: %q", l)
+		}
+	}
+}
+
+func TestDocument_WatermarkIsRotatedAndCentered(t *testing.T) {
+	d := New()
+	d.AddPage()
+	d.Watermark("")
+	d.Watermark("SEM VALOR DE QUITAÇÃO")
+	out := d.Bytes()
+
+	m := regexp.MustCompile(`BT /F2 ([\d.]+) Tf 0.71 0.71 -0.71 0.71 ([\d.]+) ([\d.]+) Tm \(SEM VALOR DE QUITA\\307\\303O\) Tj ET`).FindSubmatch(out)
+	if m == nil {
+		t.Fatalf("marca d'água diagonal ausente: %s", out)
+	}
+	if n := bytes.Count(out, []byte(" Tm ")); n != 1 {
+		t.Fatalf("texto vazio não deveria desenhar nada, %d marcas", n)
+	}
+	size, _ := strconv.ParseFloat(string(m[1]), 64)
+	x, _ := strconv.ParseFloat(string(m[2]), 64)
+	y, _ := strconv.ParseFloat(string(m[3]), 64)
+	// o centro do texto (meia largura ao longo da diagonal) cai perto do centro da página
+	half := TextWidth("SEM VALOR DE QUITAÇÃO", size, true) / 2
+	cx, cy := x+half*0.7071, y+half*0.7071
+	if cx < PageWidth/2-size || cx > PageWidth/2+size || cy < PageHeight/2-size || cy > PageHeight/2+size {
+		t.Fatalf("centro (%.0f, %.0f) longe do centro da página", cx, cy)
+	}
+}
+
+func TestDocument_ImageWithTransparencyIsSharedAcrossPages(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 0x1e, G: 0x40, B: 0xaf, A: 0xff})
+	d := New()
+	ref := d.AddImage(img)
+	for i := 0; i < 2; i++ {
+		d.AddPage()
+		w, h := d.FitImage(ref, 100, 100)
+		if w != 100 || h != 50 {
+			t.Fatalf("FitImage = %vx%v, want 100x50", w, h)
+		}
+		d.DrawImage(ref, 50, 20, w, h)
+	}
+	out := d.Bytes()
+
+	if n := bytes.Count(out, []byte("/Subtype /Image")); n != 2 {
+		t.Fatalf("esperava a imagem e a máscara uma única vez, got %d objetos", n)
+	}
+	if !bytes.Contains(out, []byte("/SMask 6 0 R")) || bytes.Count(out, []byte("/XObject << /Im0 5 0 R >>")) != 2 {
+		t.Fatalf("imagem sem máscara ou fora dos recursos das páginas: %s", out)
+	}
+	if bytes.Count(out, []byte("q 100 0 0 50 50 771.89 cm /Im0 Do Q")) != 2 {
+		t.Fatalf("imagem não desenhada nas duas páginas")
+	}
+	m := regexp.MustCompile(`(?s)/Width 4 /Height 2 /ColorSpace /DeviceRGB .*?/Length (\d+) >>\nstream\n`).FindSubmatchIndex(out)
+	n, _ := strconv.Atoi(string(out[m[2]:m[3]]))
+	zr, err := zlib.NewReader(bytes.NewReader(out[m[1] : m[1]+n]))
+	if err != nil {
+		t.Fatalf("stream da imagem inválido: %v", err)
+	}
+	rgb, _ := io.ReadAll(zr)
+	if len(rgb) != 4*2*3 || !bytes.Equal(rgb[:3], []byte{0x1e, 0x40, 0xaf}) {
+		t.Fatalf("pixels = %x", rgb)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out, -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if !bytes.HasPrefix(out[off:], []byte(fmt.Sprintf("%d 0 obj", i+1))) {
+			t.Fatalf("offset do objeto %d incorreto", i+1)
+		}
+	}
+}
+
+func TestHexColor(t *testing.T) {
+	if c, ok := HexColor("#FF8000"); !ok || c.R != 1 || c.B != 0 || c.G < 0.5 || c.G > 0.51 {
+		t.Fatalf("HexColor = %+v, %v", c, ok)
+	}
+	for _, s := range []string{"", "#fff", "#gg0000", "#12345678"} {
+		if _, ok := HexColor(s); ok {
+			t.Fatalf("%q deveria ser inválida", s)
+		}
+	}
+}
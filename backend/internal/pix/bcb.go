@@ -0,0 +1,223 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Cobrança imediata pela API Pix padrão do Banco Central (Efí/Gerencianet e bancos), com OAuth2 e mTLS
+// Data: 18-10-2026
+
+package pix
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bcbProvider implementa PUT /v2/cob/{txid} da especificação da API Pix do BCB.
+// Docstring: o token OAuth2 (client credentials) é reaproveitado até perto de expirar; a Efí
+// recebe o pedido de token em JSON e os demais PSPs em form-urlencoded.
+type bcbProvider struct {
+	name         string
+	baseURL      string
+	clientID     string
+	clientSecret string
+	jsonToken    bool
+	hc           *http.Client
+	now          func() time.Time
+
+	mu       sync.Mutex
+	token    string
+	tokenExp time.Time
+}
+
+// mtlsClient cliente HTTP com o certificado do PSP; sem keyFile o PEM do certificado deve conter a chave
+func mtlsClient(certFile, keyFile string) (*http.Client, error) {
+	if certFile == "" {
+		return nil, fmt.Errorf("%w: PIX_CERT_FILE é obrigatório (mTLS)", ErrNotConfigured)
+	}
+	if keyFile == "" {
+		keyFile = certFile
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: certificado PIX inválido: %v", ErrNotConfigured, err)
+	}
+	return &http.Client{
+		Timeout:   20 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}},
+	}, nil
+}
+
+func (p *bcbProvider) Name() string { return p.name }
+
+// CreateCharge cria a cobrança imediata com o txid informado
+func (p *bcbProvider) CreateCharge(ctx context.Context, req *ChargeRequest) (*Charge, error) {
+	token, err := p.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exp := req.Expiration
+	if exp <= 0 {
+		exp = DefaultExpiration
+	}
+	body := map[string]interface{}{
+		"calendario": map[string]interface{}{"expiracao": int(exp.Seconds())},
+		"valor":      map[string]string{"original": formatAmount(req.Amount)},
+		"chave":      req.Key,
+	}
+	if req.Description != "" {
+		body["solicitacaoPagador"] = truncate(req.Description, 140)
+	}
+	if req.PayerName != "" && (len(req.PayerDocument) == 11 || len(req.PayerDocument) == 14) {
+		devedor := map[string]string{"nome": truncate(req.PayerName, 200)}
+		if len(req.PayerDocument) == 11 {
+			devedor["cpf"] = req.PayerDocument
+		} else {
+			devedor["cnpj"] = req.PayerDocument
+		}
+		body["devedor"] = devedor
+	}
+	payload, _ := json.Marshal(body)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, p.baseURL+"/v2/cob/"+url.PathEscape(req.TxID), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	httpReq.Header.Set("Content-Type", "application/json")
+	var out struct {
+		TxID          string `json:"txid"`
+		Status        string `json:"status"`
+		Location      string `json:"location"`
+		PixCopiaECola string `json:"pixCopiaECola"`
+		Loc           struct {
+			Location string `json:"location"`
+		} `json:"loc"`
+	}
+	if err := p.do(httpReq, &out); err != nil {
+		return nil, err
+	}
+	if !ValidBRCode(out.PixCopiaECola) {
+		return nil, ErrInvalidBRCode
+	}
+	location := out.Location
+	if location == "" {
+		location = out.Loc.Location
+	}
+	txid := out.TxID
+	if txid == "" {
+		txid = req.TxID
+	}
+	return &Charge{Provider: p.name, TxID: txid, BRCode: out.PixCopiaECola, Location: location, ExpiresAt: p.now().Add(exp)}, nil
+}
+
+// accessToken obtém (ou reaproveita) o token OAuth2 do PSP
+func (p *bcbProvider) accessToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && p.now().Before(p.tokenExp) {
+		return p.token, nil
+	}
+	var body io.Reader
+	contentType := "application/x-www-form-urlencoded"
+	if p.jsonToken {
+		body = strings.NewReader(`{"grant_type":"client_credentials"}`)
+		contentType = "application/json"
+	} else {
+		body = strings.NewReader(url.Values{"grant_type": {"client_credentials"}}.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/oauth/token", body)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(p.clientID, p.clientSecret)
+	req.Header.Set("Content-Type", contentType)
+	var out struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := p.do(req, &out); err != nil {
+		return "", fmt.Errorf("autenticação no PSP: %w", err)
+	}
+	if out.AccessToken == "" {
+		return "", fmt.Errorf("%s não devolveu access_token", p.name)
+	}
+	ttl := time.Duration(out.ExpiresIn) * time.Second
+	if ttl <= time.Minute {
+		ttl = 5 * time.Minute
+	}
+	p.token, p.tokenExp = out.AccessToken, p.now().Add(ttl-time.Minute)
+	return p.token, nil
+}
+
+// do executa a requisição e decodifica a resposta; fora de 2xx devolve o corpo do PSP no erro
+func (p *bcbProvider) do(req *http.Request, out interface{}) error {
+	resp, err := p.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s respondeu %d: %s", p.name, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// truncate corta s em n caracteres (runas)
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
+
+// ParseWebhook lê o POST {"pix": [...]} da API Pix do BCB.
+// Docstring: aceita a assinatura HMAC do corpo em X-Pix-Signature ou, para PSPs que não assinam
+// (a Efí), o segredo no parâmetro hmac da URL cadastrada. Pix sem txid (QR estático) é ignorado.
+func (p *bcbProvider) ParseWebhook(ctx context.Context, req *WebhookRequest, secret string) ([]Payment, error) {
+	if secret == "" {
+		return nil, ErrInvalidSignature
+	}
+	if sig := req.Header.Get(SignatureHeader); sig != "" {
+		if !validHMAC(secret, string(req.Body), sig) {
+			return nil, ErrInvalidSignature
+		}
+	} else if !equalSecret(req.Query.Get("hmac"), secret) {
+		return nil, ErrInvalidSignature
+	}
+	var body struct {
+		Pix []struct {
+			EndToEndID string    `json:"endToEndId"`
+			TxID       string    `json:"txid"`
+			Valor      string    `json:"valor"`
+			Horario    time.Time `json:"horario"`
+		} `json:"pix"`
+	}
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		return nil, ErrInvalidPayload
+	}
+	var out []Payment
+	for _, item := range body.Pix {
+		if item.TxID == "" {
+			continue
+		}
+		amount, err := parseAmount(item.Valor)
+		if err != nil {
+			return nil, err
+		}
+		paidAt := item.Horario
+		if paidAt.IsZero() {
+			paidAt = p.now()
+		}
+		out = append(out, Payment{TxID: item.TxID, EndToEndID: item.EndToEndID, Amount: amount, PaidAt: paidAt})
+	}
+	return out, nil
+}
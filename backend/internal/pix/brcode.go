@@ -0,0 +1,52 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Validação do BR Code (EMV MPM) devolvido pelos PSPs: estrutura TLV e CRC16
+// Data: 18-10-2026
+
+package pix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CRC16 CRC-16/CCITT-FALSE (polinômio 0x1021, inicial 0xFFFF) usado no campo 63 do BR Code
+func CRC16(s string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for b := 0; b < 8; b++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// ValidBRCode confere o formato do "copia e cola": campos TLV bem formados, indicador de
+// formato 01, arranjo PIX (br.gov.bcb.pix) e CRC do campo 63 correto.
+func ValidBRCode(code string) bool {
+	if !strings.HasPrefix(code, "000201") || len(code) < 12 || code[len(code)-8:len(code)-4] != "6304" {
+		return false
+	}
+	want := fmt.Sprintf("%04X", CRC16(code[:len(code)-4]))
+	if !strings.EqualFold(code[len(code)-4:], want) {
+		return false
+	}
+	// Percorre os campos: ID (2) + tamanho (2) + valor
+	for i := 0; i < len(code); {
+		if i+4 > len(code) {
+			return false
+		}
+		n, err := strconv.Atoi(code[i+2 : i+4])
+		if err != nil || i+4+n > len(code) {
+			return false
+		}
+		i += 4 + n
+	}
+	return strings.Contains(strings.ToLower(code), "br.gov.bcb.pix")
+}
@@ -0,0 +1,193 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Cobrança PIX pela API de pagamentos do Mercado Pago
+// Data: 18-10-2026
+
+package pix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mercadoPagoProvider cria pagamentos com payment_method_id "pix".
+// Docstring: o Mercado Pago gera o próprio identificador; o id do pagamento passa a ser o txid
+// da cobrança e o txid gerado pelo ReciboFast vai como chave de idempotência.
+type mercadoPagoProvider struct {
+	endpoint string
+	token    string
+	hc       *http.Client
+	now      func() time.Time
+}
+
+func (p *mercadoPagoProvider) Name() string { return ProviderMercadoPago }
+
+// CreateCharge cria o pagamento PIX pendente
+func (p *mercadoPagoProvider) CreateCharge(ctx context.Context, req *ChargeRequest) (*Charge, error) {
+	if req.PayerEmail == "" {
+		return nil, ErrPayerEmailRequired
+	}
+	exp := req.Expiration
+	if exp <= 0 {
+		exp = DefaultExpiration
+	}
+	expiresAt := p.now().Add(exp)
+	payer := map[string]interface{}{"email": req.PayerEmail}
+	if req.PayerName != "" {
+		payer["first_name"] = truncate(req.PayerName, 100)
+	}
+	switch len(req.PayerDocument) {
+	case 11:
+		payer["identification"] = map[string]string{"type": "CPF", "number": req.PayerDocument}
+	case 14:
+		payer["identification"] = map[string]string{"type": "CNPJ", "number": req.PayerDocument}
+	}
+	description := req.Description
+	if description == "" {
+		description = "Cobrança ReciboFast"
+	}
+	amount, _ := strconv.ParseFloat(formatAmount(req.Amount), 64)
+	payload, _ := json.Marshal(map[string]interface{}{
+		"transaction_amount": amount,
+		"description":        truncate(description, 140),
+		"payment_method_id":  "pix",
+		"external_reference": req.Reference,
+		"date_of_expiration": expiresAt.Format("2006-01-02T15:04:05.000-07:00"),
+		"payer":              payer,
+	})
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+p.token)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-Idempotency-Key", req.TxID)
+	resp, err := p.hc.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s respondeu %d: %s", ProviderMercadoPago, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	var out struct {
+		ID                 json.Number `json:"id"`
+		PointOfInteraction struct {
+			TransactionData struct {
+				QRCode    string `json:"qr_code"`
+				TicketURL string `json:"ticket_url"`
+			} `json:"transaction_data"`
+		} `json:"point_of_interaction"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	code := strings.TrimSpace(out.PointOfInteraction.TransactionData.QRCode)
+	if !ValidBRCode(code) {
+		return nil, ErrInvalidBRCode
+	}
+	return &Charge{Provider: ProviderMercadoPago, TxID: out.ID.String(), BRCode: code,
+		Location: out.PointOfInteraction.TransactionData.TicketURL, ExpiresAt: expiresAt}, nil
+}
+
+// ParseWebhook valida x-signature (ts e v1 = HMAC-SHA256 do manifesto
+// "id:{data.id};request-id:{x-request-id};ts:{ts};") e consulta o pagamento notificado, pois a
+// notificação do Mercado Pago traz só o id. Só pagamentos aprovados são devolvidos.
+func (p *mercadoPagoProvider) ParseWebhook(ctx context.Context, req *WebhookRequest, secret string) ([]Payment, error) {
+	if secret == "" {
+		return nil, ErrInvalidSignature
+	}
+	var n struct {
+		Type string `json:"type"`
+		Data struct {
+			ID json.Number `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(req.Body, &n); err != nil {
+		return nil, ErrInvalidPayload
+	}
+	id := req.Query.Get("data.id")
+	if id == "" {
+		id = n.Data.ID.String()
+	}
+	var ts, v1 string
+	for _, part := range strings.Split(req.Header.Get("X-Signature"), ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "ts":
+			ts = v
+		case "v1":
+			v1 = v
+		}
+	}
+	if ts == "" || v1 == "" {
+		return nil, ErrInvalidSignature
+	}
+	// Partes ausentes ficam fora do manifesto, como na validação documentada pelo Mercado Pago
+	var manifest strings.Builder
+	if id != "" {
+		manifest.WriteString("id:" + strings.ToLower(id) + ";")
+	}
+	if rid := req.Header.Get("X-Request-Id"); rid != "" {
+		manifest.WriteString("request-id:" + rid + ";")
+	}
+	manifest.WriteString("ts:" + ts + ";")
+	if !validHMAC(secret, manifest.String(), v1) {
+		return nil, ErrInvalidSignature
+	}
+	if n.Type != "payment" || id == "" {
+		return nil, nil
+	}
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return nil, ErrInvalidPayload
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+p.token)
+	resp, err := p.hc.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s respondeu %d: %s", ProviderMercadoPago, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	var payment struct {
+		Status             string     `json:"status"`
+		TransactionAmount  float64    `json:"transaction_amount"`
+		DateApproved       *time.Time `json:"date_approved"`
+		PointOfInteraction struct {
+			TransactionData struct {
+				E2EID string `json:"e2e_id"`
+			} `json:"transaction_data"`
+		} `json:"point_of_interaction"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return nil, err
+	}
+	if payment.Status != "approved" {
+		return nil, nil
+	}
+	paidAt := p.now()
+	if payment.DateApproved != nil {
+		paidAt = *payment.DateApproved
+	}
+	return []Payment{{TxID: id, EndToEndID: payment.PointOfInteraction.TransactionData.E2EID,
+		Amount: int64(math.Round(payment.TransactionAmount * 100)), PaidAt: paidAt}}, nil
+}
@@ -0,0 +1,105 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Cobranças PIX com QR dinâmico por PSP (API Pix do Banco Central, Efí/Gerencianet ou Mercado Pago)
+// Data: 18-10-2026
+
+package pix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+// Provedores suportados (PIX_PROVIDER)
+const (
+	ProviderGerencianet = "gerencianet" // Efí (ex-Gerencianet), API Pix padrão do BCB com mTLS
+	ProviderBank        = "bacen"       // qualquer banco/PSP que implemente a API Pix do BCB (PIX_API_URL)
+	ProviderMercadoPago = "mercadopago"
+)
+
+// DefaultExpiration validade padrão da cobrança imediata
+const DefaultExpiration = 24 * time.Hour
+
+var (
+	ErrNotConfigured      = errors.New("cobrança PIX não configurada")
+	ErrUnknownProvider    = errors.New("provedor PIX desconhecido (use gerencianet, bacen ou mercadopago)")
+	ErrPayerEmailRequired = errors.New("o provedor PIX exige o e-mail do pagador")
+	ErrInvalidBRCode      = errors.New("o provedor PIX devolveu um BR Code inválido")
+)
+
+// ChargeRequest dados da cobrança imediata.
+// Docstring: TxID segue o padrão do BCB ([a-zA-Z0-9]{26,35}); Amount em centavos; Key é a chave
+// PIX recebedora (ignorada pelo Mercado Pago, que usa a chave da conta).
+type ChargeRequest struct {
+	TxID          string
+	Amount        int64
+	Key           string
+	Expiration    time.Duration
+	Description   string
+	PayerName     string
+	PayerDocument string // CPF (11) ou CNPJ (14), só dígitos
+	PayerEmail    string
+	Reference     string
+}
+
+// Charge cobrança criada no PSP; BRCode é o "copia e cola" (conteúdo do QR Code)
+type Charge struct {
+	Provider  string
+	TxID      string
+	BRCode    string
+	Location  string
+	ExpiresAt time.Time
+}
+
+// Provider cria cobranças PIX em um PSP e lê as notificações de pagamento dele.
+// Docstring: ParseWebhook valida a assinatura com o segredo do webhook (PIX_WEBHOOK_SECRET) e
+// devolve só pagamentos confirmados; notificações de teste ou de outros eventos dão lista vazia.
+type Provider interface {
+	Name() string
+	CreateCharge(ctx context.Context, req *ChargeRequest) (*Charge, error)
+	ParseWebhook(ctx context.Context, req *WebhookRequest, secret string) ([]Payment, error)
+}
+
+// New cria o Provider configurado; sem PIX_PROVIDER retorna ErrNotConfigured
+func New(cfg *config.Config) (Provider, error) {
+	provider := strings.ToLower(strings.TrimSpace(cfg.PixProvider))
+	switch provider {
+	case "":
+		return nil, ErrNotConfigured
+	case ProviderGerencianet, ProviderBank:
+		baseURL := strings.TrimRight(cfg.PixAPIURL, "/")
+		if baseURL == "" && provider == ProviderGerencianet {
+			baseURL = "https://pix.api.efipay.com.br"
+		}
+		if baseURL == "" {
+			return nil, fmt.Errorf("%w: PIX_API_URL é obrigatório", ErrNotConfigured)
+		}
+		if cfg.PixClientID == "" || cfg.PixClientSecret == "" {
+			return nil, fmt.Errorf("%w: PIX_CLIENT_ID e PIX_CLIENT_SECRET são obrigatórios", ErrNotConfigured)
+		}
+		hc, err := mtlsClient(cfg.PixCertFile, cfg.PixCertKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &bcbProvider{name: provider, baseURL: baseURL, clientID: cfg.PixClientID, clientSecret: cfg.PixClientSecret,
+			jsonToken: provider == ProviderGerencianet, hc: hc, now: time.Now}, nil
+	case ProviderMercadoPago:
+		if cfg.MercadoPagoAccessToken == "" {
+			return nil, fmt.Errorf("%w: MERCADOPAGO_ACCESS_TOKEN é obrigatório", ErrNotConfigured)
+		}
+		return &mercadoPagoProvider{endpoint: "https://api.mercadopago.com/v1/payments", token: cfg.MercadoPagoAccessToken,
+			hc: &http.Client{Timeout: 20 * time.Second}, now: time.Now}, nil
+	}
+	return nil, ErrUnknownProvider
+}
+
+// formatAmount centavos no formato decimal exigido pelas APIs ("150.00")
+func formatAmount(cents int64) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
@@ -0,0 +1,317 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do BR Code, do gerador de QR Code e dos provedores PIX (API do BCB e Mercado Pago)
+// Data: 18-10-2026
+
+package pix
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+// testBRCode monta um "copia e cola" válido com CRC
+func testBRCode() string {
+	gui := "0014br.gov.bcb.pix2560qrpix.exemplo.com/qr/v2/cobv/9d36b84fc70b478fb95c12729b90ca25"
+	body := "000201010212" + fmt.Sprintf("26%02d", len(gui)) + gui +
+		"52040000530398654041.005802BR5909RECIBOFAS6009SAO PAULO62070503***6304"
+	return body + fmt.Sprintf("%04X", CRC16(body))
+}
+
+func TestCRC16_CheckValue(t *testing.T) {
+	if got := CRC16("123456789"); got != 0x29B1 {
+		t.Fatalf("CRC16 = %04X, want 29B1", got)
+	}
+}
+
+func TestValidBRCode(t *testing.T) {
+	code := testBRCode()
+	if !ValidBRCode(code) {
+		t.Fatalf("BR Code válido rejeitado: %s", code)
+	}
+	if ValidBRCode(strings.ToLower(code[:len(code)-4]) + code[len(code)-4:]) {
+		t.Fatal("CRC divergente aceito")
+	}
+	broken := code[:len(code)-4]
+	broken = strings.Replace(broken, "5909RECIBOFAS", "5999RECIBOFAS", 1) + "6304"
+	if ValidBRCode(broken + fmt.Sprintf("%04X", CRC16(broken))) {
+		t.Fatal("TLV malformado aceito")
+	}
+	if ValidBRCode("") || ValidBRCode("000201") {
+		t.Fatal("código vazio ou truncado aceito")
+	}
+}
+
+func TestRSRemainder_KnownVector(t *testing.T) {
+	// Versão 1-M de "HELLO WORLD" em modo alfanumérico (exemplo clássico da ISO/IEC 18004)
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsRemainder(data, rsDivisor(10)); !bytes.Equal(got, want) {
+		t.Fatalf("EC = %v, want %v", got, want)
+	}
+}
+
+func TestEncodeQR_VersionAndPNG(t *testing.T) {
+	code := testBRCode()
+	qr, err := EncodeQR(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qr.Version < 1 || qr.Size != qr.Version*4+17 {
+		t.Fatalf("versão %d com tamanho %d", qr.Version, qr.Size)
+	}
+	// Padrão localizador no canto superior esquerdo
+	if !qr.Dark(0, 0) || !qr.Dark(6, 6) || qr.Dark(1, 1) || !qr.Dark(3, 3) {
+		t.Fatal("padrão localizador ausente")
+	}
+	raw, err := qr.PNG(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if side := (qr.Size + 8) * 4; img.Bounds().Dx() != side || img.Bounds().Dy() != side {
+		t.Fatalf("imagem %v, want %dx%d", img.Bounds(), side, side)
+	}
+
+	if _, err := EncodeQR(strings.Repeat("x", 700)); !errors.Is(err, ErrQRTooLong) {
+		t.Fatalf("texto longo: err = %v", err)
+	}
+}
+
+func TestNew_RequiresProviderSettings(t *testing.T) {
+	if _, err := New(&config.Config{}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("sem provedor: err = %v", err)
+	}
+	if _, err := New(&config.Config{PixProvider: "bacen", PixClientID: "id", PixClientSecret: "s"}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("bacen sem URL: err = %v", err)
+	}
+	if _, err := New(&config.Config{PixProvider: "gerencianet", PixClientID: "id", PixClientSecret: "s"}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("gerencianet sem certificado: err = %v", err)
+	}
+	if _, err := New(&config.Config{PixProvider: "mercadopago"}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("mercadopago sem token: err = %v", err)
+	}
+	if _, err := New(&config.Config{PixProvider: "picpay"}); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("provedor desconhecido: err = %v", err)
+	}
+	if p, err := New(&config.Config{PixProvider: "MercadoPago", MercadoPagoAccessToken: "APP_USR-1"}); err != nil || p.Name() != ProviderMercadoPago {
+		t.Fatalf("mercadopago: %v", err)
+	}
+}
+
+func TestBCBProvider_CreatesChargeAndCachesToken(t *testing.T) {
+	code := testBRCode()
+	tokens := 0
+	var cob map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/oauth/token":
+			tokens++
+			if id, secret, _ := r.BasicAuth(); id != "cid" || secret != "csecret" {
+				t.Errorf("basic auth = %q/%q", id, secret)
+			}
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("token Content-Type = %q", r.Header.Get("Content-Type"))
+			}
+			fmt.Fprint(w, `{"access_token":"tok","expires_in":3600}`)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/v2/cob/"):
+			if r.Header.Get("Authorization") != "Bearer tok" {
+				t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+			}
+			_ = json.NewDecoder(r.Body).Decode(&cob)
+			txid := strings.TrimPrefix(r.URL.Path, "/v2/cob/")
+			fmt.Fprintf(w, `{"txid":%q,"status":"ATIVA","loc":{"location":"qrpix.exemplo.com/qr/v2/1"},"pixCopiaECola":%q}`, txid, code)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	p := &bcbProvider{name: ProviderGerencianet, baseURL: srv.URL, clientID: "cid", clientSecret: "csecret", jsonToken: true,
+		hc: srv.Client(), now: func() time.Time { return now }}
+	req := &ChargeRequest{TxID: "abc123abc123abc123abc123abc1", Amount: 15050, Key: "chave@exemplo.com",
+		Expiration: time.Hour, Description: "Aluguel", PayerName: "Maria", PayerDocument: "12345678909"}
+	charge, err := p.CreateCharge(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if charge.TxID != req.TxID || charge.BRCode != code || charge.Location != "qrpix.exemplo.com/qr/v2/1" || !charge.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("charge = %+v", charge)
+	}
+	if v := cob["valor"].(map[string]interface{})["original"]; v != "150.50" {
+		t.Fatalf("valor.original = %v", v)
+	}
+	if d := cob["devedor"].(map[string]interface{}); d["cpf"] != "12345678909" || d["nome"] != "Maria" {
+		t.Fatalf("devedor = %v", d)
+	}
+	if _, err := p.CreateCharge(context.Background(), req); err != nil || tokens != 1 {
+		t.Fatalf("token reaproveitado: err = %v, pedidos de token = %d", err, tokens)
+	}
+}
+
+func TestBCBProvider_RejectsInvalidBRCodeAndErrors(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			fmt.Fprint(w, `{"access_token":"tok","expires_in":3600}`)
+			return
+		}
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"pixCopiaECola":"000201qualquer"}`)
+	}))
+	defer srv.Close()
+
+	p := &bcbProvider{name: ProviderBank, baseURL: srv.URL, clientID: "cid", clientSecret: "s", hc: srv.Client(), now: time.Now}
+	if _, err := p.CreateCharge(context.Background(), &ChargeRequest{TxID: "t", Amount: 100}); !errors.Is(err, ErrInvalidBRCode) {
+		t.Fatalf("BR Code inválido: err = %v", err)
+	}
+	status = http.StatusBadRequest
+	if _, err := p.CreateCharge(context.Background(), &ChargeRequest{TxID: "t", Amount: 100}); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Fatalf("erro do PSP: err = %v", err)
+	}
+}
+
+func TestMercadoPagoProvider_CreatesPixPayment(t *testing.T) {
+	code := testBRCode()
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer APP_USR-1" || r.Header.Get("X-Idempotency-Key") != "tx1" {
+			t.Errorf("headers = %v", r.Header)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":1234567890,"point_of_interaction":{"transaction_data":{"qr_code":%q,"ticket_url":"https://mp.exemplo.com/t"}}}`, code)
+	}))
+	defer srv.Close()
+
+	p := &mercadoPagoProvider{endpoint: srv.URL, token: "APP_USR-1", hc: srv.Client(), now: time.Now}
+	if _, err := p.CreateCharge(context.Background(), &ChargeRequest{TxID: "tx1", Amount: 100}); !errors.Is(err, ErrPayerEmailRequired) {
+		t.Fatalf("sem e-mail: err = %v", err)
+	}
+	charge, err := p.CreateCharge(context.Background(), &ChargeRequest{TxID: "tx1", Amount: 9990, PayerEmail: "pagador@exemplo.com", PayerDocument: "12345678000195"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if charge.TxID != "1234567890" || charge.BRCode != code || charge.Location != "https://mp.exemplo.com/t" {
+		t.Fatalf("charge = %+v", charge)
+	}
+	if got["transaction_amount"] != 99.9 || got["payment_method_id"] != "pix" {
+		t.Fatalf("payload = %v", got)
+	}
+	if id := got["payer"].(map[string]interface{})["identification"].(map[string]interface{}); id["type"] != "CNPJ" {
+		t.Fatalf("identification = %v", id)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	for in, want := range map[string]int64{"150.50": 15050, "150.5": 15050, "7": 700, "0.01": 1} {
+		if got, err := parseAmount(in); err != nil || got != want {
+			t.Fatalf("parseAmount(%q) = %d, %v", in, got, err)
+		}
+	}
+	for _, in := range []string{"", "0.00", "-1.00", "1.234", "1e3", "abc", ".50"} {
+		if _, err := parseAmount(in); !errors.Is(err, ErrInvalidPayload) {
+			t.Fatalf("parseAmount(%q) aceito", in)
+		}
+	}
+}
+
+func TestBCBProvider_ParseWebhook(t *testing.T) {
+	p := &bcbProvider{name: ProviderGerencianet, now: time.Now}
+	body := []byte(`{"pix":[{"endToEndId":"E1234","txid":"abc","valor":"150.50","horario":"2026-10-18T12:00:00.000Z"},{"endToEndId":"E5678","valor":"9.90"}]}`)
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	req := &WebhookRequest{Header: http.Header{SignatureHeader: {sign("s3cr3t")}}, Query: url.Values{}, Body: body}
+	got, err := p.ParseWebhook(context.Background(), req, "s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].TxID != "abc" || got[0].EndToEndID != "E1234" || got[0].Amount != 15050 ||
+		!got[0].PaidAt.Equal(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("pagamentos = %+v", got)
+	}
+
+	// Efí: segredo na URL cadastrada
+	if got, err := p.ParseWebhook(context.Background(), &WebhookRequest{Header: http.Header{}, Query: url.Values{"hmac": {"s3cr3t"}}, Body: body}, "s3cr3t"); err != nil || len(got) != 1 {
+		t.Fatalf("hmac na URL: %v", err)
+	}
+	for name, req := range map[string]*WebhookRequest{
+		"assinatura de outro segredo": {Header: http.Header{SignatureHeader: {sign("outro")}}, Query: url.Values{}, Body: body},
+		"hmac errado na URL":          {Header: http.Header{}, Query: url.Values{"hmac": {"outro"}}, Body: body},
+		"sem assinatura":              {Header: http.Header{}, Query: url.Values{}, Body: body},
+	} {
+		if _, err := p.ParseWebhook(context.Background(), req, "s3cr3t"); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("%s: err = %v", name, err)
+		}
+	}
+	if _, err := p.ParseWebhook(context.Background(), &WebhookRequest{Header: http.Header{}, Query: url.Values{"hmac": {""}}, Body: body}, ""); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("sem segredo configurado: err = %v", err)
+	}
+	bad := &WebhookRequest{Header: http.Header{}, Query: url.Values{"hmac": {"s3cr3t"}}, Body: []byte(`{"pix":[{"txid":"abc","valor":"1,00"}]}`)}
+	if _, err := p.ParseWebhook(context.Background(), bad, "s3cr3t"); !errors.Is(err, ErrInvalidPayload) {
+		t.Fatalf("valor malformado: err = %v", err)
+	}
+}
+
+func TestMercadoPagoProvider_ParseWebhook(t *testing.T) {
+	status := "approved"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/payments/1234567890" || r.Header.Get("Authorization") != "Bearer APP_USR-1" {
+			t.Errorf("consulta inesperada %s (%q)", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		fmt.Fprintf(w, `{"id":1234567890,"status":%q,"transaction_amount":99.9,"date_approved":"2026-10-18T09:00:00.000-03:00",
+			"point_of_interaction":{"transaction_data":{"e2e_id":"E9999"}}}`, status)
+	}))
+	defer srv.Close()
+
+	p := &mercadoPagoProvider{endpoint: srv.URL + "/v1/payments", token: "APP_USR-1", hc: srv.Client(), now: time.Now}
+	body := []byte(`{"action":"payment.updated","type":"payment","data":{"id":"1234567890"}}`)
+	mac := hmac.New(sha256.New, []byte("mp-secret"))
+	mac.Write([]byte("id:1234567890;request-id:req-1;ts:1760788800;"))
+	header := http.Header{"X-Signature": {"ts=1760788800,v1=" + hex.EncodeToString(mac.Sum(nil))}, "X-Request-Id": {"req-1"}}
+	req := &WebhookRequest{Header: header, Query: url.Values{"data.id": {"1234567890"}, "type": {"payment"}}, Body: body}
+
+	got, err := p.ParseWebhook(context.Background(), req, "mp-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].TxID != "1234567890" || got[0].Amount != 9990 || got[0].EndToEndID != "E9999" ||
+		!got[0].PaidAt.Equal(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("pagamentos = %+v", got)
+	}
+
+	status = "pending"
+	if got, err := p.ParseWebhook(context.Background(), req, "mp-secret"); err != nil || len(got) != 0 {
+		t.Fatalf("pagamento pendente: %+v, %v", got, err)
+	}
+	if _, err := p.ParseWebhook(context.Background(), req, "outro"); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("segredo errado: err = %v", err)
+	}
+	req.Header = http.Header{}
+	if _, err := p.ParseWebhook(context.Background(), req, "mp-secret"); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("sem x-signature: err = %v", err)
+	}
+}
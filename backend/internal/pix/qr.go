@@ -0,0 +1,475 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Gerador de QR Code (modo byte, correção M) para o BR Code do PIX, sem dependências externas
+// Data: 18-10-2026
+
+package pix
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// ErrQRTooLong o texto não cabe nas versões suportadas (até a 20, 669 bytes em correção M)
+var ErrQRTooLong = errors.New("texto longo demais para o QR Code")
+
+// QR matriz de módulos (true = escuro) de um QR Code
+type QR struct {
+	Size    int
+	Version int
+	Mask    int
+	modules [][]bool
+}
+
+// Dark indica se o módulo (x = coluna, y = linha) é escuro
+func (q *QR) Dark(x, y int) bool {
+	return q.modules[y][x]
+}
+
+// qrBlocks estrutura de blocos de correção de erros do nível M por versão
+type qrBlocks struct {
+	ecPerBlock     int
+	blocks1, data1 int
+	blocks2, data2 int
+	alignment      []int
+	remainderBits  int
+}
+
+// Tabela do nível M (ISO/IEC 18004, tabela 9) e posições dos padrões de alinhamento
+var qrVersionsM = [...]qrBlocks{
+	1:  {10, 1, 16, 0, 0, nil, 0},
+	2:  {16, 1, 28, 0, 0, []int{6, 18}, 7},
+	3:  {26, 1, 44, 0, 0, []int{6, 22}, 7},
+	4:  {18, 2, 32, 0, 0, []int{6, 26}, 7},
+	5:  {24, 2, 43, 0, 0, []int{6, 30}, 7},
+	6:  {16, 4, 27, 0, 0, []int{6, 34}, 7},
+	7:  {18, 4, 31, 0, 0, []int{6, 22, 38}, 0},
+	8:  {22, 2, 38, 2, 39, []int{6, 24, 42}, 0},
+	9:  {22, 3, 36, 2, 37, []int{6, 26, 46}, 0},
+	10: {26, 4, 43, 1, 44, []int{6, 28, 50}, 0},
+	11: {30, 1, 50, 4, 51, []int{6, 30, 54}, 0},
+	12: {22, 6, 36, 2, 37, []int{6, 32, 58}, 0},
+	13: {22, 8, 37, 1, 38, []int{6, 34, 62}, 0},
+	14: {24, 4, 40, 5, 41, []int{6, 26, 46, 66}, 3},
+	15: {24, 5, 41, 5, 42, []int{6, 26, 48, 70}, 3},
+	16: {28, 7, 45, 3, 46, []int{6, 26, 50, 74}, 3},
+	17: {28, 10, 46, 1, 47, []int{6, 30, 54, 78}, 3},
+	18: {26, 9, 43, 4, 44, []int{6, 30, 56, 82}, 3},
+	19: {26, 3, 44, 11, 45, []int{6, 30, 58, 86}, 3},
+	20: {26, 3, 41, 13, 42, []int{6, 34, 62, 90}, 3},
+}
+
+func (b qrBlocks) dataCodewords() int {
+	return b.blocks1*b.data1 + b.blocks2*b.data2
+}
+
+// EncodeQR gera o QR Code do texto escolhendo a menor versão e a máscara de menor penalidade
+func EncodeQR(text string) (*QR, error) {
+	return encodeQR([]byte(text), -1)
+}
+
+// encodeQR gera o QR Code; mask < 0 escolhe a máscara pela penalidade
+func encodeQR(data []byte, mask int) (*QR, error) {
+	version := 0
+	for v := 1; v < len(qrVersionsM); v++ {
+		if 4+qrCountBits(v)+8*len(data) <= qrVersionsM[v].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrQRTooLong
+	}
+	codewords := qrInterleave(qrDataCodewords(data, version), qrVersionsM[version])
+
+	q := newQRMatrix(version)
+	q.drawCodewords(codewords)
+	if mask < 0 {
+		best := -1
+		for m := 0; m < 8; m++ {
+			q.applyMask(m)
+			q.drawFormat(m)
+			if p := q.penalty(); best < 0 || p < best {
+				best, mask = p, m
+			}
+			q.applyMask(m) // XOR desfaz a máscara
+		}
+	}
+	q.applyMask(mask)
+	q.drawFormat(mask)
+	return &QR{Size: q.size, Version: version, Mask: mask, modules: q.modules}, nil
+}
+
+// qrCountBits tamanho do contador de caracteres do modo byte
+func qrCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// qrDataCodewords codifica o texto no modo byte com terminador e bytes de preenchimento
+func qrDataCodewords(data []byte, version int) []byte {
+	capacity := qrVersionsM[version].dataCodewords()
+	var bits qrBitBuffer
+	bits.append(0x4, 4)
+	bits.append(len(data), qrCountBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	if rest := capacity*8 - bits.len; rest > 0 {
+		if rest > 4 {
+			rest = 4
+		}
+		bits.append(0, rest)
+	}
+	if r := bits.len % 8; r != 0 {
+		bits.append(0, 8-r)
+	}
+	for pad := 0xEC; len(bits.bytes) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	return bits.bytes
+}
+
+type qrBitBuffer struct {
+	bytes []byte
+	len   int
+}
+
+func (b *qrBitBuffer) append(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if b.len%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if (v>>i)&1 == 1 {
+			b.bytes[b.len/8] |= 0x80 >> (b.len % 8)
+		}
+		b.len++
+	}
+}
+
+// qrInterleave divide em blocos, calcula a correção Reed-Solomon e intercala os codewords
+func qrInterleave(data []byte, v qrBlocks) []byte {
+	divisor := rsDivisor(v.ecPerBlock)
+	var dataBlocks, ecBlocks [][]byte
+	for i, off := 0, 0; i < v.blocks1+v.blocks2; i++ {
+		n := v.data1
+		if i >= v.blocks1 {
+			n = v.data2
+		}
+		block := data[off : off+n]
+		off += n
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, divisor))
+	}
+	out := make([]byte, 0, len(data)+len(ecBlocks)*v.ecPerBlock)
+	for i := 0; i < v.data1 || i < v.data2; i++ {
+		for _, b := range dataBlocks {
+			if i < len(b) {
+				out = append(out, b[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for _, b := range ecBlocks {
+			out = append(out, b[i])
+		}
+	}
+	return out
+}
+
+// rsMul multiplicação em GF(256) com o polinômio 0x11D
+func rsMul(x, y byte) byte {
+	var z byte
+	for i := 7; i >= 0; i-- {
+		hi := z & 0x80
+		z <<= 1
+		if hi != 0 {
+			z ^= 0x1D
+		}
+		if (y>>i)&1 == 1 {
+			z ^= x
+		}
+	}
+	return z
+}
+
+// rsDivisor polinômio gerador de grau degree (coeficientes do maior para o menor, sem o líder)
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = rsMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = rsMul(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder codewords de correção do bloco
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= rsMul(d, factor)
+		}
+	}
+	return result
+}
+
+// qrMatrix matriz em construção com a marcação dos módulos de função (não mascarados)
+type qrMatrix struct {
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+func newQRMatrix(version int) *qrMatrix {
+	size := version*4 + 17
+	q := &qrMatrix{size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for i := range q.modules {
+		q.modules[i] = make([]bool, size)
+		q.function[i] = make([]bool, size)
+	}
+	for i := 0; i < size; i++ {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(size-4, 3)
+	q.drawFinder(3, size-4)
+	align := qrVersionsM[version].alignment
+	for i, x := range align {
+		for j, y := range align {
+			last := len(align) - 1
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+	q.drawFormat(0) // reserva as áreas de formato; redesenhadas após a máscara
+	if version >= 7 {
+		rem := version
+		for i := 0; i < 12; i++ {
+			rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+		}
+		bits := version<<12 | rem
+		for i := 0; i < 18; i++ {
+			dark := (bits>>i)&1 == 1
+			a, b := size-11+i%3, i/3
+			q.set(a, b, dark)
+			q.set(b, a, dark)
+		}
+	}
+	return q
+}
+
+// set grava um módulo de função (x = coluna, y = linha)
+func (q *qrMatrix) set(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+func (q *qrMatrix) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= q.size || y >= q.size {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			q.set(x, y, d != 2 && d != 4)
+		}
+	}
+}
+
+// drawFormat grava as duas cópias da informação de formato (nível M + máscara) e o módulo escuro
+func (q *qrMatrix) drawFormat(mask int) {
+	data := 0<<3 | mask // nível M = 00
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return (bits>>i)&1 == 1 }
+	for i := 0; i <= 5; i++ {
+		q.set(8, i, bit(i))
+	}
+	q.set(8, 7, bit(6))
+	q.set(8, 8, bit(7))
+	q.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		q.set(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.size-15+i, bit(i))
+	}
+	q.set(8, q.size-8, true)
+}
+
+// drawCodewords posiciona os bits em zigue-zague pelas colunas duplas, da direita para a esquerda
+func (q *qrMatrix) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+				if !q.function[y][x] && i < len(data)*8 {
+					q.modules[y][x] = (data[i>>3]>>(7-i&7))&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverte os módulos de dados conforme o padrão de máscara (aplicar duas vezes desfaz)
+func (q *qrMatrix) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.function[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty pontuação das regras de avaliação de máscara (sequências, blocos 2x2, padrões de localizador e equilíbrio)
+func (q *qrMatrix) penalty() int {
+	n := q.size
+	get := func(x, y int, transpose bool) bool {
+		if transpose {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+	finderA := []bool{true, false, true, true, true, false, true, false, false, false, false}
+	finderB := []bool{false, false, false, false, true, false, true, true, true, false, true}
+	score := 0
+	for _, t := range []bool{false, true} {
+		for y := 0; y < n; y++ {
+			run := 1
+			for x := 1; x <= n; x++ {
+				if x < n && get(x, y, t) == get(x-1, y, t) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+			for x := 0; x+11 <= n; x++ {
+				matchA, matchB := true, true
+				for k := 0; k < 11; k++ {
+					v := get(x+k, y, t)
+					matchA = matchA && v == finderA[k]
+					matchB = matchB && v == finderB[k]
+				}
+				if matchA {
+					score += 40
+				}
+				if matchB {
+					score += 40
+				}
+			}
+		}
+	}
+	dark := 0
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < n && y+1 < n {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+	total := n * n
+	score += abs(dark*20-total*10) / total * 10
+	return score
+}
+
+// PNG desenha o QR Code com zona de silêncio de 4 módulos; scale é o tamanho do módulo em pixels
+func (q *QR) PNG(scale int) ([]byte, error) {
+	if scale <= 0 {
+		scale = 8
+	}
+	const quiet = 4
+	side := (q.Size + 2*quiet) * scale
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if !q.modules[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex((x+quiet)*scale+dx, (y+quiet)*scale+dy, 1)
+				}
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Notificações de pagamento dos PSPs (webhook PIX): validação da assinatura e leitura dos pagamentos
+// Data: 18-10-2026
+
+package pix
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SignatureHeader assinatura HMAC-SHA256 (hex, com ou sem "sha256=") do corpo enviada pelos PSPs
+// que assinam o webhook da API Pix do BCB
+const SignatureHeader = "X-Pix-Signature"
+
+var (
+	ErrInvalidSignature = errors.New("assinatura do webhook PIX inválida")
+	ErrInvalidPayload   = errors.New("notificação PIX malformada")
+)
+
+// WebhookRequest notificação recebida do PSP (corpo bruto, cabeçalhos e query string)
+type WebhookRequest struct {
+	Header http.Header
+	Query  url.Values
+	Body   []byte
+}
+
+// Payment pagamento confirmado pelo PSP; Amount em centavos
+type Payment struct {
+	TxID       string
+	EndToEndID string
+	Amount     int64
+	PaidAt     time.Time
+}
+
+// validHMAC compara a assinatura hex (aceita o prefixo "sha256=") com o HMAC-SHA256 da mensagem
+func validHMAC(secret, message, signature string) bool {
+	got, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "sha256="))
+	if err != nil || len(got) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// equalSecret comparação em tempo constante
+func equalSecret(a, b string) bool {
+	return a != "" && subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// parseAmount decimal do PSP ("150.5", "150.50") em centavos; mais de duas casas é inválido
+func parseAmount(s string) (int64, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if whole == "" || len(whole) > 12 || len(frac) > 2 {
+		return 0, ErrInvalidPayload
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+	var cents int64
+	for _, c := range whole + frac {
+		if c < '0' || c > '9' {
+			return 0, ErrInvalidPayload
+		}
+		cents = cents*10 + int64(c-'0')
+	}
+	if cents == 0 {
+		return 0, ErrInvalidPayload
+	}
+	return cents, nil
+}
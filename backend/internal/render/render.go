@@ -0,0 +1,107 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Respostas de sucesso no envelope padrão ({data, meta, links, request_id}) sob negociação
+// Data: 18-10-2026
+
+package render
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"recibofast/internal/apierror"
+)
+
+// Profile valor de Accept-Profile que pede o envelope (ex.: "Accept-Profile: envelope");
+// sem ele as rotas mantêm o formato atual de cada endpoint
+const Profile = "envelope"
+
+// Envelope corpo das respostas de sucesso com o perfil "envelope".
+// Docstring: Data é o recurso (ou a lista de itens); Meta só aparece em listagens; RequestID é o
+// mesmo do cabeçalho X-Request-ID e dos erros, para correlacionar com os logs.
+type Envelope struct {
+	Data      interface{} `json:"data"`
+	Meta      *Meta       `json:"meta,omitempty"`
+	Links     Links       `json:"links"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// Meta paginação da listagem
+type Meta struct {
+	Total      int     `json:"total"`
+	Page       int     `json:"page"`
+	PerPage    int     `json:"per_page"`
+	TotalPages int     `json:"total_pages"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// Links navegação: o próprio recurso e, nas listagens, as páginas vizinhas (nulas nas pontas)
+type Links struct {
+	Self string  `json:"self"`
+	Next *string `json:"next,omitempty"`
+	Prev *string `json:"prev,omitempty"`
+}
+
+// Wants indica se o cliente pediu o envelope (Accept-Profile contém "envelope")
+func Wants(r *http.Request) bool {
+	for _, p := range strings.Split(r.Header.Get("Accept-Profile"), ",") {
+		if strings.EqualFold(strings.TrimSpace(p), Profile) {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON responde o recurso: no envelope quando pedido, senão o próprio valor
+func JSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	write(w, r, status, v, nil, Links{Self: r.URL.RequestURI()})
+}
+
+// Created responde 201 com Location apontando para o recurso criado (também links.self)
+func Created(w http.ResponseWriter, r *http.Request, location string, v interface{}) {
+	w.Header().Set("Location", location)
+	write(w, r, http.StatusCreated, v, nil, Links{Self: location})
+}
+
+// List responde uma listagem no envelope, com meta e links self/next/prev (mesmos filtros,
+// trocando só page; ?cursor= é descartado). Só deve ser chamada quando Wants(r).
+func List(w http.ResponseWriter, r *http.Request, items interface{}, meta Meta) {
+	links := Links{Self: r.URL.RequestURI()}
+	if meta.Page < meta.TotalPages {
+		next := pageURL(r.URL, meta.Page+1)
+		links.Next = &next
+	}
+	if meta.Page > 1 {
+		prev := pageURL(r.URL, min(meta.Page-1, max(meta.TotalPages, 1)))
+		links.Prev = &prev
+	}
+	write(w, r, http.StatusOK, items, &meta, links)
+}
+
+func write(w http.ResponseWriter, r *http.Request, status int, v interface{}, meta *Meta, links Links) {
+	w.Header().Set("Content-Type", "application/json")
+	if !Wants(r) {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+		return
+	}
+	w.Header().Set("Content-Profile", Profile)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Envelope{
+		Data:      v,
+		Meta:      meta,
+		Links:     links,
+		RequestID: w.Header().Get(apierror.RequestIDHeader),
+	})
+}
+
+// pageURL mesma URL com outra página
+func pageURL(u *url.URL, page int) string {
+	q := u.Query()
+	q.Del("cursor")
+	q.Set("page", strconv.Itoa(page))
+	return u.Path + "?" + q.Encode()
+}
@@ -0,0 +1,111 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do envelope de respostas (data, meta, links e request_id)
+// Data: 18-10-2026
+
+package render
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"recibofast/internal/apierror"
+)
+
+type item struct {
+	ID string `json:"id"`
+}
+
+func newRequest(target string, envelope bool) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if envelope {
+		req.Header.Set("Accept-Profile", "page, envelope")
+	}
+	return req
+}
+
+func TestJSONWithoutProfileKeepsBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	JSON(rec, newRequest("/api/v1/incomes/1", false), http.StatusOK, item{ID: "1"})
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || body["id"] != "1" || body["data"] != nil || rec.Header().Get("Content-Profile") != "" {
+		t.Fatalf("status = %d, corpo = %v", rec.Code, body)
+	}
+}
+
+func TestCreatedEnvelope(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(apierror.RequestIDHeader, "req-7")
+	Created(rec, newRequest("/api/v1/incomes", true), "/api/v1/incomes/9", item{ID: "9"})
+
+	var body struct {
+		Data      item            `json:"data"`
+		Meta      json.RawMessage `json:"meta"`
+		Links     Links           `json:"links"`
+		RequestID string          `json:"request_id"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusCreated || rec.Header().Get("Location") != "/api/v1/incomes/9" || rec.Header().Get("Content-Profile") != Profile {
+		t.Fatalf("status = %d, cabeçalhos = %v", rec.Code, rec.Header())
+	}
+	if body.Data.ID != "9" || body.Links.Self != "/api/v1/incomes/9" || body.RequestID != "req-7" || body.Meta != nil {
+		t.Fatalf("corpo inesperado: %+v", body)
+	}
+}
+
+func TestListLinks(t *testing.T) {
+	cursor := "abc"
+	cases := []struct {
+		name       string
+		meta       Meta
+		next, prev string
+	}{
+		{"primeira", Meta{Total: 45, Page: 1, PerPage: 20, TotalPages: 3, NextCursor: &cursor}, "2", ""},
+		{"meio", Meta{Total: 45, Page: 2, PerPage: 20, TotalPages: 3, NextCursor: &cursor}, "3", "1"},
+		{"última", Meta{Total: 45, Page: 3, PerPage: 20, TotalPages: 3}, "", "2"},
+		{"além do fim", Meta{Total: 45, Page: 9, PerPage: 20, TotalPages: 3}, "", "3"},
+		{"vazia", Meta{Page: 1, PerPage: 20}, "", ""},
+	}
+	for _, c := range cases {
+		rec := httptest.NewRecorder()
+		target := "/api/v1/incomes?status=pendente&cursor=xyz"
+		List(rec, newRequest(target, true), []item{{ID: "1"}}, c.meta)
+
+		var body Envelope
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.Links.Self != target || body.Meta == nil || body.Meta.Total != c.meta.Total {
+			t.Errorf("%s: self/meta inesperados: %+v", c.name, body)
+		}
+		for rel, want := range map[string]string{"next": c.next, "prev": c.prev} {
+			link := body.Links.Next
+			if rel == "prev" {
+				link = body.Links.Prev
+			}
+			if want == "" {
+				if link != nil {
+					t.Errorf("%s: %s = %q, esperado ausente", c.name, rel, *link)
+				}
+				continue
+			}
+			if link == nil {
+				t.Errorf("%s: %s ausente", c.name, rel)
+				continue
+			}
+			u, _ := url.Parse(*link)
+			if q := u.Query(); u.Path != "/api/v1/incomes" || q.Get("page") != want || q.Get("status") != "pendente" || q.Has("cursor") {
+				t.Errorf("%s: %s = %q", c.name, rel, *link)
+			}
+		}
+	}
+}
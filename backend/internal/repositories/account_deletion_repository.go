@@ -0,0 +1,244 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório dos pedidos de exclusão de conta (bloqueio, fila do worker e remoção das linhas rf_*)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// AccountDeletionRepository operações dos pedidos de exclusão de conta.
+// Docstring: ClaimDue reserva os pedidos vencidos para o worker; Purge remove as linhas do
+// titular em uma única transação. Objetos do Storage são responsabilidade do serviço.
+type AccountDeletionRepository interface {
+	Create(ctx context.Context, d *models.AccountDeletion) error
+	GetLatest(ctx context.Context, ownerID uuid.UUID) (*models.AccountDeletion, error)
+	IsLocked(ctx context.Context, ownerID uuid.UUID) (bool, error)
+	Cancel(ctx context.Context, id uuid.UUID, now time.Time) (*models.AccountDeletion, error)
+	ClaimDue(ctx context.Context, now, staleBefore time.Time, limit int) ([]models.AccountDeletion, error)
+	Purge(ctx context.Context, ownerID uuid.UUID) (map[string]int64, error)
+	Finish(ctx context.Context, d *models.AccountDeletion, now time.Time) error
+	QueueDepth(ctx context.Context, now time.Time) (*models.QueueDepth, error)
+}
+
+type accountDeletionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAccountDeletionRepository cria uma nova instância do repositório de exclusão de contas
+func NewAccountDeletionRepository(db *pgxpool.Pool) AccountDeletionRepository {
+	return &accountDeletionRepository{db: db}
+}
+
+const accountDeletionColumns = `id, owner_id, status, reason, requested_at, scheduled_for, attempts,
+	started_at, cancelled_at, completed_at, result, error`
+
+func scanAccountDeletion(row pgx.Row) (*models.AccountDeletion, error) {
+	var d models.AccountDeletion
+	var result []byte
+	if err := row.Scan(&d.ID, &d.OwnerID, &d.Status, &d.Reason, &d.RequestedAt, &d.ScheduledFor, &d.Attempts,
+		&d.StartedAt, &d.CancelledAt, &d.CompletedAt, &result, &d.Error); err != nil {
+		return nil, err
+	}
+	if len(result) > 0 {
+		d.Result = &models.AccountDeletionResult{}
+		if err := json.Unmarshal(result, d.Result); err != nil {
+			return nil, err
+		}
+	}
+	return &d, nil
+}
+
+// Create registra o pedido; falha com ErrDeletionExists se já houver um em andamento
+func (r *accountDeletionRepository) Create(ctx context.Context, d *models.AccountDeletion) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO rf_account_deletions (owner_id, status, reason, requested_at, scheduled_for)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, d.OwnerID, d.Status, d.Reason, d.RequestedAt, d.ScheduledFor).Scan(&d.ID)
+	if isConstraintViolation(err, pgUniqueViolation, "uq_account_deletions_active") {
+		return models.ErrDeletionExists
+	}
+	return err
+}
+
+// GetLatest retorna o pedido mais recente do titular
+func (r *accountDeletionRepository) GetLatest(ctx context.Context, ownerID uuid.UUID) (*models.AccountDeletion, error) {
+	d, err := scanAccountDeletion(r.db.QueryRow(ctx, `
+		SELECT `+accountDeletionColumns+`
+		FROM rf_account_deletions
+		WHERE owner_id = $1
+		ORDER BY requested_at DESC
+		LIMIT 1
+	`, ownerID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrDeletionNotFound
+	}
+	return d, err
+}
+
+// IsLocked indica se há pedido mantendo a conta bloqueada (carência, execução ou falha)
+func (r *accountDeletionRepository) IsLocked(ctx context.Context, ownerID uuid.UUID) (bool, error) {
+	var locked bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM rf_account_deletions
+			WHERE owner_id = $1 AND status IN ('pending', 'processing', 'failed')
+		)
+	`, ownerID).Scan(&locked)
+	return locked, err
+}
+
+// Cancel encerra o pedido ainda em carência; fora dela retorna ErrDeletionNotCancellable
+func (r *accountDeletionRepository) Cancel(ctx context.Context, id uuid.UUID, now time.Time) (*models.AccountDeletion, error) {
+	d, err := scanAccountDeletion(r.db.QueryRow(ctx, `
+		UPDATE rf_account_deletions SET status = 'cancelled', cancelled_at = $2
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+accountDeletionColumns, id, now))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrDeletionNotCancellable
+	}
+	return d, err
+}
+
+// ClaimDue reserva até limit pedidos com a carência vencida (ou falhos, com tentativas restantes).
+// Docstring: pedidos em processing desde antes de staleBefore são retomados (processo interrompido);
+// SKIP LOCKED evita que duas instâncias processem o mesmo pedido.
+func (r *accountDeletionRepository) ClaimDue(ctx context.Context, now, staleBefore time.Time, limit int) ([]models.AccountDeletion, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE rf_account_deletions SET status = 'processing', attempts = attempts + 1, started_at = $1, error = NULL
+		WHERE id IN (
+			SELECT id FROM rf_account_deletions
+			WHERE ((status IN ('pending', 'failed') AND scheduled_for <= $1 AND attempts < $3)
+			    OR (status = 'processing' AND started_at < $2))
+			ORDER BY scheduled_for
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+accountDeletionColumns, now, staleBefore, models.AccountDeletionMaxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.AccountDeletion{}
+	for rows.Next() {
+		d, err := scanAccountDeletion(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *d)
+	}
+	return items, rows.Err()
+}
+
+// Purge remove todas as linhas do titular e anonimiza os registros que sobrevivem à conta.
+// Docstring: as tabelas rf_* com owner_id são descobertas no catálogo (inclui as que vierem a ser
+// criadas) e contadas antes da remoção. A exclusão do usuário em auth.users propaga para todas
+// elas (ON DELETE CASCADE); o DELETE por tabela depois disso só alcança tabelas sem a FK. Nas
+// auditorias de fusão de contas o e-mail da prévia é apagado.
+func (r *accountDeletionRepository) Purge(ctx context.Context, ownerID uuid.UUID) (map[string]int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT table_name FROM information_schema.columns
+		WHERE table_schema = 'public' AND column_name = 'owner_id'
+		  AND table_name LIKE 'rf\_%' AND table_name <> 'rf_account_deletions'
+		ORDER BY table_name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int64{}
+	for _, table := range append(tables, "rf_profiles") {
+		key := "owner_id"
+		if table == "rf_profiles" {
+			key = "id"
+		}
+		var n int64
+		if err := tx.QueryRow(ctx, fmt.Sprintf(`SELECT count(*) FROM %s WHERE %s = $1`, pgx.Identifier{table}.Sanitize(), key), ownerID).Scan(&n); err != nil {
+			return nil, fmt.Errorf("erro ao contar %s: %w", table, err)
+		}
+		if n > 0 {
+			counts[table] = n
+		}
+	}
+
+	steps := []string{
+		`UPDATE rf_account_merges SET plan = jsonb_set(plan, '{source,email}', 'null') WHERE source_owner_id = $1 AND plan ? 'source'`,
+		`UPDATE rf_account_merges SET plan = jsonb_set(plan, '{target,email}', 'null') WHERE target_owner_id = $1 AND plan ? 'target'`,
+		`DELETE FROM auth.users WHERE id = $1`,
+	}
+	for _, sql := range steps {
+		if _, err := tx.Exec(ctx, sql, ownerID); err != nil {
+			return nil, fmt.Errorf("erro na exclusão da conta: %w", err)
+		}
+	}
+	for _, table := range tables {
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE owner_id = $1`, pgx.Identifier{table}.Sanitize()), ownerID); err != nil {
+			return nil, fmt.Errorf("erro ao remover %s: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// Finish grava a situação final; concluído o pedido, o motivo informado pelo titular é apagado
+func (r *accountDeletionRepository) Finish(ctx context.Context, d *models.AccountDeletion, now time.Time) error {
+	var result []byte
+	if d.Result != nil {
+		b, err := json.Marshal(d.Result)
+		if err != nil {
+			return err
+		}
+		result = b
+	}
+	return r.db.QueryRow(ctx, `
+		UPDATE rf_account_deletions SET status = $2, result = $3, error = $4,
+			completed_at = CASE WHEN $2 = 'completed' THEN $5::timestamptz END,
+			reason = CASE WHEN $2 = 'completed' THEN NULL ELSE reason END
+		WHERE id = $1
+		RETURNING completed_at, reason
+	`, d.ID, d.Status, result, d.Error, now).Scan(&d.CompletedAt, &d.Reason)
+}
+
+// QueueDepth resume a fila de exclusões: em carência, vencidas, em execução e esgotadas
+func (r *accountDeletionRepository) QueueDepth(ctx context.Context, now time.Time) (*models.QueueDepth, error) {
+	q := &models.QueueDepth{Queue: "account_deletions"}
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE status IN ('pending', 'failed') AND attempts < $2),
+		       COUNT(*) FILTER (WHERE status IN ('pending', 'failed') AND attempts < $2 AND scheduled_for <= $1),
+		       COUNT(*) FILTER (WHERE status = 'processing'),
+		       COUNT(*) FILTER (WHERE status = 'failed' AND attempts >= $2),
+		       MIN(scheduled_for) FILTER (WHERE status IN ('pending', 'failed') AND attempts < $2 AND scheduled_for <= $1)
+		FROM rf_account_deletions
+		WHERE status IN ('pending', 'processing', 'failed')
+	`, now, models.AccountDeletionMaxAttempts).Scan(&q.Pending, &q.Due, &q.Processing, &q.Dead, &q.OldestDueAt)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
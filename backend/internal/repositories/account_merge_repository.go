@@ -0,0 +1,399 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório da fusão de contas (transferência das linhas rf_* e auditoria)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// AccountMergeRepository operações da fusão de contas.
+// Docstring: Plan conta o que será transferido; Merge refaz a prévia sob trava e move tudo em uma
+// única transação. Objetos do Storage são responsabilidade do serviço.
+type AccountMergeRepository interface {
+	Plan(ctx context.Context, sourceID, targetID uuid.UUID) (*models.AccountMergePlan, error)
+	Merge(ctx context.Context, m *models.AccountMerge) (*models.AccountMergeResult, error)
+	CreateAudit(ctx context.Context, m *models.AccountMerge) error
+	FinishAudit(ctx context.Context, m *models.AccountMerge) error
+	ListAudits(ctx context.Context, limit int) ([]models.AccountMerge, error)
+}
+
+type accountMergeRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAccountMergeRepository cria uma nova instância do repositório de fusão de contas
+func NewAccountMergeRepository(db *pgxpool.Pool) AccountMergeRepository {
+	return &accountMergeRepository{db: db}
+}
+
+// Tabelas em que basta trocar owner_id (sem unicidade por usuário além da numeração dos recibos)
+var mergeOwnedTables = []string{
+	"rf_contracts", "rf_incomes", "rf_receipts", "rf_signatures", "rf_deliveries",
+	"rf_receipt_number_gaps", "rf_income_rules", "rf_income_templates", "rf_artifact_refs",
+	"rf_receipt_templates", "rf_receipt_reissues", "rf_auto_receipts", "rf_expenses", "rf_expense_attachments",
+	"rf_contract_readjustments", "rf_holidays",
+}
+
+// Tabelas com uma linha por usuário: a do destino prevalece
+var mergeSingletonTables = []struct{ table, key string }{
+	{"rf_profiles", "id"},
+	{"rf_settings", "owner_id"},
+	{"rf_notification_settings", "owner_id"},
+}
+
+type mergeQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Plan conta as linhas da origem, as faixas de numeração e os registros duplicados
+func (r *accountMergeRepository) Plan(ctx context.Context, sourceID, targetID uuid.UUID) (*models.AccountMergePlan, error) {
+	return planMerge(ctx, r.db, sourceID, targetID)
+}
+
+func planMerge(ctx context.Context, q mergeQuerier, sourceID, targetID uuid.UUID) (*models.AccountMergePlan, error) {
+	p := &models.AccountMergePlan{Rows: map[string]int64{}, Objects: map[string]int{}}
+	for _, acc := range []*models.MergeAccount{&p.Source, &p.Target} {
+		acc.ID = sourceID
+		if acc == &p.Target {
+			acc.ID = targetID
+		}
+		err := q.QueryRow(ctx, `SELECT email, created_at FROM auth.users WHERE id = $1`, acc.ID).Scan(&acc.Email, &acc.CreatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", models.ErrMergeAccountNotFound, acc.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tables := append(append([]string{}, mergeOwnedTables...), "rf_payers", "rf_categories", "rf_delivery_destinations")
+	for _, t := range mergeSingletonTables {
+		tables = append(tables, t.table)
+	}
+	for _, table := range tables {
+		key := "owner_id"
+		for _, t := range mergeSingletonTables {
+			if t.table == table {
+				key = t.key
+			}
+		}
+		var n int64
+		if err := q.QueryRow(ctx, fmt.Sprintf(`SELECT count(*) FROM %s WHERE %s = $1`, table, key), sourceID).Scan(&n); err != nil {
+			return nil, fmt.Errorf("erro ao contar %s: %w", table, err)
+		}
+		if n > 0 {
+			p.Rows[table] = n
+		}
+	}
+
+	for _, rr := range []struct {
+		owner uuid.UUID
+		out   *models.ReceiptRange
+	}{{sourceID, &p.SourceReceipts}, {targetID, &p.TargetReceipts}} {
+		err := q.QueryRow(ctx, `SELECT count(*), COALESCE(MIN(numero), 0), COALESCE(MAX(numero), 0) FROM rf_receipts WHERE owner_id = $1`,
+			rr.owner).Scan(&rr.out.Count, &rr.out.First, &rr.out.Last)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := q.QueryRow(ctx, `
+		SELECT count(*) FROM rf_receipts s JOIN rf_receipts t
+			ON t.owner_id = $2 AND t.serie_periodo = s.serie_periodo AND t.serie_numero = s.serie_numero
+		WHERE s.owner_id = $1
+	`, sourceID, targetID).Scan(&p.SeriesCollisions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.QueryRow(ctx, `
+		SELECT
+			(SELECT count(*) FROM rf_payers s JOIN rf_payers t ON t.owner_id = $2 AND t.documento = s.documento
+			 WHERE s.owner_id = $1 AND s.documento IS NOT NULL AND s.documento <> ''),
+			(SELECT count(*) FROM rf_categories s JOIN rf_categories t ON t.owner_id = $2 AND lower(t.nome) = lower(s.nome)
+			 WHERE s.owner_id = $1)
+	`, sourceID, targetID).Scan(&p.DuplicatePayers, &p.DuplicateCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	p.SettingsKept = []string{}
+	for _, t := range mergeSingletonTables {
+		var both bool
+		err := q.QueryRow(ctx, fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %[1]s WHERE %[2]s = $1) AND EXISTS (SELECT 1 FROM %[1]s WHERE %[2]s = $2)`,
+			t.table, t.key), sourceID, targetID).Scan(&both)
+		if err != nil {
+			return nil, err
+		}
+		if both {
+			p.SettingsKept = append(p.SettingsKept, t.table)
+		}
+	}
+	var dupDest bool
+	err = q.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM rf_delivery_destinations s JOIN rf_delivery_destinations t
+			ON t.owner_id = $2 AND t.channel = s.channel AND t.destination = s.destination WHERE s.owner_id = $1)
+	`, sourceID, targetID).Scan(&dupDest)
+	if err != nil {
+		return nil, err
+	}
+	if dupDest {
+		p.SettingsKept = append(p.SettingsKept, "rf_delivery_destinations")
+	}
+	p.StorageCollisions = []string{}
+	p.Evaluate()
+	return p, nil
+}
+
+// Merge transfere todas as linhas da origem para o destino.
+// Docstring: trava a numeração de recibos das duas contas (mesma chave do trigger da migração 013),
+// refaz a prévia e aborta com ErrMergeConflict se algo mudou. Pagadores com o mesmo documento são
+// unificados no cadastro do destino; como receitas e recibos referenciam o pagador com o dono
+// (FK composta), payer_id é guardado, limpo e restaurado após a troca de dono. updated_at é
+// preservado (migração 024) para não marcar recibos como editados após a emissão.
+func (r *accountMergeRepository) Merge(ctx context.Context, m *models.AccountMerge) (*models.AccountMergeResult, error) {
+	src, dst := m.SourceOwnerID, m.TargetOwnerID
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT set_config('rf.preserve_updated_at', 'on', true)`); err != nil {
+		return nil, err
+	}
+	owners := []string{src.String(), dst.String()}
+	sort.Strings(owners)
+	for _, o := range owners {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('rf_receipts:' || $1))`, o); err != nil {
+			return nil, err
+		}
+	}
+	// Versões de assinatura: mesma chave do envio (signatureRepository.Create)
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('rf_signatures:' || $1))`, dst.String()); err != nil {
+		return nil, err
+	}
+
+	plan, err := planMerge(ctx, tx, src, dst)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.CanMerge {
+		return nil, fmt.Errorf("%w: %s", models.ErrMergeConflict, plan.Conflicts[0].Message)
+	}
+
+	res := &models.AccountMergeResult{Rows: map[string]int64{}, Objects: map[string]int{}, Resolutions: plan.Resolutions}
+	exec := func(table, sql string, args ...any) error {
+		tag, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			if table == "" {
+				return fmt.Errorf("erro na fusão de contas: %w", err)
+			}
+			return fmt.Errorf("erro ao transferir %s: %w", table, err)
+		}
+		if table != "" && tag.RowsAffected() > 0 {
+			res.Rows[table] += tag.RowsAffected()
+		}
+		return nil
+	}
+
+	// Pagadores duplicados (mesmo documento) e referências de payer_id das linhas da origem
+	steps := []struct {
+		sql  string
+		args []any
+	}{
+		{`CREATE TEMP TABLE merge_payer_map (source_id uuid PRIMARY KEY, target_id uuid NOT NULL) ON COMMIT DROP`, nil},
+		{`CREATE TEMP TABLE merge_payer_refs (tbl text NOT NULL, id uuid NOT NULL, payer_id uuid NOT NULL) ON COMMIT DROP`, nil},
+		{`INSERT INTO merge_payer_map (source_id, target_id)
+			SELECT s.id, t.id
+			FROM rf_payers s JOIN rf_payers t ON t.owner_id = $2 AND t.documento = s.documento
+			WHERE s.owner_id = $1 AND s.documento IS NOT NULL AND s.documento <> ''`, []any{src, dst}},
+		{`INSERT INTO merge_payer_refs (tbl, id, payer_id)
+			SELECT 'rf_incomes', id, payer_id FROM rf_incomes WHERE owner_id = $1 AND payer_id IS NOT NULL
+			UNION ALL
+			SELECT 'rf_receipts', id, payer_id FROM rf_receipts WHERE owner_id = $1 AND payer_id IS NOT NULL`, []any{src}},
+		{`UPDATE merge_payer_refs r SET payer_id = m.target_id FROM merge_payer_map m WHERE r.payer_id = m.source_id`, nil},
+		{`UPDATE rf_incomes SET payer_id = NULL WHERE owner_id = $1 AND payer_id IS NOT NULL`, []any{src}},
+		{`UPDATE rf_receipts SET payer_id = NULL WHERE owner_id = $1 AND payer_id IS NOT NULL`, []any{src}},
+		{`UPDATE rf_contracts c SET payer_id = m.target_id FROM merge_payer_map m WHERE c.owner_id = $1 AND c.payer_id = m.source_id`, []any{src}},
+		{`UPDATE rf_income_templates t SET payer_id = m.target_id FROM merge_payer_map m WHERE t.owner_id = $1 AND t.payer_id = m.source_id`, []any{src}},
+		{`UPDATE rf_income_rules ru SET conditions = jsonb_set(ru.conditions, '{payer_id}', to_jsonb(m.target_id::text))
+			FROM merge_payer_map m WHERE ru.owner_id = $1 AND ru.conditions->>'payer_id' = m.source_id::text`, []any{src}},
+		{`DELETE FROM rf_payers WHERE owner_id = $1 AND id IN (SELECT source_id FROM merge_payer_map)`, []any{src}},
+		// Categorias com o mesmo nome: receitas guardam o nome, basta manter a do destino
+		{`DELETE FROM rf_categories s WHERE s.owner_id = $1
+			AND EXISTS (SELECT 1 FROM rf_categories t WHERE t.owner_id = $2 AND lower(t.nome) = lower(s.nome))`, []any{src, dst}},
+		{`DELETE FROM rf_delivery_destinations s WHERE s.owner_id = $1
+			AND EXISTS (SELECT 1 FROM rf_delivery_destinations t WHERE t.owner_id = $2 AND t.channel = s.channel AND t.destination = s.destination)`, []any{src, dst}},
+	}
+	for _, st := range steps {
+		if err := exec("", st.sql, st.args...); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, table := range []string{"rf_payers", "rf_categories", "rf_delivery_destinations"} {
+		if err := exec(table, fmt.Sprintf(`UPDATE %s SET owner_id = $2 WHERE owner_id = $1`, table), src, dst); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range mergeSingletonTables {
+		if err := exec("", fmt.Sprintf(`DELETE FROM %[1]s WHERE %[2]s = $1 AND EXISTS (SELECT 1 FROM %[1]s WHERE %[2]s = $2)`, t.table, t.key), src, dst); err != nil {
+			return nil, err
+		}
+		if err := exec(t.table, fmt.Sprintf(`UPDATE %s SET %s = $2 WHERE %[2]s = $1`, t.table, t.key), src, dst); err != nil {
+			return nil, err
+		}
+	}
+	for _, table := range mergeOwnedTables {
+		sql := fmt.Sprintf(`UPDATE %s SET owner_id = $2 WHERE owner_id = $1`, table)
+		switch table {
+		case "rf_signatures":
+			// Objetos no bucket ficam em "{owner_id}/..."; o serviço já os moveu. As versões da
+			// origem entram depois das do destino (mesma ordem, numeração contínua)
+			sql = `UPDATE rf_signatures SET owner_id = $2,
+				file_path = CASE WHEN file_path LIKE $1::text || '/%' THEN $2::text || substr(file_path, length($1::text) + 1) ELSE file_path END,
+				version = version + COALESCE((SELECT MAX(version) FROM rf_signatures WHERE owner_id = $2), 0)
+				WHERE owner_id = $1`
+		case "rf_receipt_templates":
+			// Um modelo padrão por usuário: o do destino prevalece
+			sql = `UPDATE rf_receipt_templates SET owner_id = $2,
+				is_default = is_default AND NOT EXISTS (SELECT 1 FROM rf_receipt_templates WHERE owner_id = $2 AND is_default)
+				WHERE owner_id = $1`
+		case "rf_receipts":
+			sql = `UPDATE rf_receipts SET owner_id = $2, pdf_url = replace(pdf_url, $1::text || '/', $2::text || '/') WHERE owner_id = $1`
+		case "rf_expense_attachments":
+			sql = `UPDATE rf_expense_attachments SET owner_id = $2,
+				file_path = CASE WHEN file_path LIKE $1::text || '/%' THEN $2::text || substr(file_path, length($1::text) + 1) ELSE file_path END
+				WHERE owner_id = $1`
+		}
+		if err := exec(table, sql, src, dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sequências do número exibido: o destino continua do maior número reservado nas duas contas
+	err = exec("", `INSERT INTO rf_receipt_sequences (owner_id, periodo, ultimo)
+		SELECT $2, periodo, ultimo FROM rf_receipt_sequences WHERE owner_id = $1
+		ON CONFLICT (owner_id, periodo) DO UPDATE
+		SET ultimo = GREATEST(rf_receipt_sequences.ultimo, EXCLUDED.ultimo), updated_at = now()`, src, dst)
+	if err != nil {
+		return nil, err
+	}
+	if err := exec("", `DELETE FROM rf_receipt_sequences WHERE owner_id = $1`, src); err != nil {
+		return nil, err
+	}
+
+	restore := []string{
+		`UPDATE rf_incomes i SET payer_id = r.payer_id FROM merge_payer_refs r WHERE r.tbl = 'rf_incomes' AND i.id = r.id AND i.owner_id = $1`,
+		`UPDATE rf_receipts rc SET payer_id = r.payer_id FROM merge_payer_refs r WHERE r.tbl = 'rf_receipts' AND rc.id = r.id AND rc.owner_id = $1`,
+	}
+	for _, sql := range restore {
+		if err := exec("", sql, dst); err != nil {
+			return nil, err
+		}
+	}
+
+	if from, to, ok := models.ReceiptNumberGap(plan.SourceReceipts, plan.TargetReceipts); ok {
+		err := exec("", `INSERT INTO rf_receipt_number_gaps (owner_id, numero_inicio, numero_fim, justificativa) VALUES ($1, $2, $3, $4)`,
+			dst, from, to, fmt.Sprintf("Fusão com a conta %s (registro %s)", src, m.ID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateAudit registra o início da fusão; falha com ErrMergeInProgress se uma das contas já
+// participa de uma fusão em andamento
+func (r *accountMergeRepository) CreateAudit(ctx context.Context, m *models.AccountMerge) error {
+	plan, err := json.Marshal(m.Plan)
+	if err != nil {
+		return err
+	}
+	err = r.db.QueryRow(ctx, `
+		INSERT INTO rf_account_merges (source_owner_id, target_owner_id, requested_by, reason, status, plan)
+		SELECT $1, $2, $3, $4, 'running', $5
+		WHERE NOT EXISTS (
+			SELECT 1 FROM rf_account_merges
+			WHERE status = 'running'
+			  AND (source_owner_id IN ($1, $2) OR target_owner_id IN ($1, $2))
+		)
+		RETURNING id, status, started_at
+	`, m.SourceOwnerID, m.TargetOwnerID, m.RequestedBy, m.Reason, plan).Scan(&m.ID, &m.Status, &m.StartedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrMergeInProgress
+	}
+	return err
+}
+
+// FinishAudit grava a situação final, o resultado e o erro (se houver)
+func (r *accountMergeRepository) FinishAudit(ctx context.Context, m *models.AccountMerge) error {
+	var result []byte
+	if m.Result != nil {
+		b, err := json.Marshal(m.Result)
+		if err != nil {
+			return err
+		}
+		result = b
+	}
+	return r.db.QueryRow(ctx, `
+		UPDATE rf_account_merges SET status = $2, result = $3, error = $4, finished_at = now()
+		WHERE id = $1
+		RETURNING finished_at
+	`, m.ID, m.Status, result, m.Error).Scan(&m.FinishedAt)
+}
+
+// ListAudits lista as fusões mais recentes
+func (r *accountMergeRepository) ListAudits(ctx context.Context, limit int) ([]models.AccountMerge, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, source_owner_id, target_owner_id, requested_by, reason, status, plan, result, error, started_at, finished_at
+		FROM rf_account_merges
+		ORDER BY started_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.AccountMerge{}
+	for rows.Next() {
+		var m models.AccountMerge
+		var plan, result []byte
+		if err := rows.Scan(&m.ID, &m.SourceOwnerID, &m.TargetOwnerID, &m.RequestedBy, &m.Reason, &m.Status,
+			&plan, &result, &m.Error, &m.StartedAt, &m.FinishedAt); err != nil {
+			return nil, err
+		}
+		if len(plan) > 0 {
+			m.Plan = &models.AccountMergePlan{}
+			if err := json.Unmarshal(plan, m.Plan); err != nil {
+				return nil, err
+			}
+		}
+		if len(result) > 0 {
+			m.Result = &models.AccountMergeResult{}
+			if err := json.Unmarshal(result, m.Result); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, m)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,121 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório das chaves de API do usuário (rf_api_keys)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// APIKeyRepository operações das chaves de API
+type APIKeyRepository interface {
+	Create(ctx context.Context, k *models.APIKey, keyHash string, max int) error
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id, ownerID uuid.UUID, now time.Time) error
+	GetActiveByHash(ctx context.Context, keyHash string, now time.Time) (*models.APIKey, error)
+	Touch(ctx context.Context, id uuid.UUID, now time.Time) error
+}
+
+type apiKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAPIKeyRepository cria uma nova instância do repositório de chaves de API
+func NewAPIKeyRepository(db *pgxpool.Pool) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, owner_id, nome, prefix, scopes, created_at, expires_at, last_used_at, revoked_at`
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var k models.APIKey
+	if err := row.Scan(&k.ID, &k.OwnerID, &k.Nome, &k.Prefix, &k.Scopes, &k.CreatedAt, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// Create grava a chave se o usuário tiver menos de max chaves ativas (senão ErrAPIKeyLimit)
+func (r *apiKeyRepository) Create(ctx context.Context, k *models.APIKey, keyHash string, max int) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO rf_api_keys (owner_id, nome, prefix, key_hash, scopes, expires_at)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE (SELECT count(*) FROM rf_api_keys
+		       WHERE owner_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > now())) < $7
+		RETURNING id, created_at
+	`, k.OwnerID, k.Nome, k.Prefix, keyHash, k.Scopes, k.ExpiresAt, max).Scan(&k.ID, &k.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrAPIKeyLimit
+	}
+	return err
+}
+
+// List lista as chaves do usuário (inclusive revogadas e expiradas), mais recentes primeiro
+func (r *apiKeyRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.APIKey, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM rf_api_keys
+		WHERE owner_id = $1
+		ORDER BY created_at DESC
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *k)
+	}
+	return items, rows.Err()
+}
+
+// Revoke revoga a chave; revogar de novo responde ErrAPIKeyNotFound
+func (r *apiKeyRepository) Revoke(ctx context.Context, id, ownerID uuid.UUID, now time.Time) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE rf_api_keys SET revoked_at = $3
+		WHERE id = $1 AND owner_id = $2 AND revoked_at IS NULL
+	`, id, ownerID, now)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// GetActiveByHash retorna a chave ativa (não revogada nem expirada) com o hash informado
+func (r *apiKeyRepository) GetActiveByHash(ctx context.Context, keyHash string, now time.Time) (*models.APIKey, error) {
+	k, err := scanAPIKey(r.db.QueryRow(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM rf_api_keys
+		WHERE key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > $2)
+	`, keyHash, now))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrAPIKeyInvalid
+	}
+	return k, err
+}
+
+// Touch registra o uso da chave; no máximo uma escrita por minuto
+func (r *apiKeyRepository) Touch(ctx context.Context, id uuid.UUID, now time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE rf_api_keys SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2 - interval '1 minute')
+	`, id, now)
+	return err
+}
@@ -0,0 +1,186 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de artefatos endereçados por conteúdo (rf_artifacts) e suas referências
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// ArtifactRepository define operações de persistência de artefatos e referências.
+// Docstring: ref_count é mantido pelo trigger de rf_artifact_refs (migração 020).
+type ArtifactRepository interface {
+	Get(ctx context.Context, hash string) (*models.Artifact, error)
+	Create(ctx context.Context, a *models.Artifact) (bool, error)
+	AttachRef(ctx context.Context, ref *models.ArtifactRef) error
+	DetachRef(ctx context.Context, ref *models.ArtifactRef) error
+	ListCollectable(ctx context.Context, before time.Time, limit int) ([]models.Artifact, error)
+	Collect(ctx context.Context, hash string, before time.Time, remove func(*models.Artifact) error) (bool, error)
+}
+
+type artifactRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewArtifactRepository cria uma nova instância do repositório de artefatos
+func NewArtifactRepository(db *pgxpool.Pool) ArtifactRepository {
+	return &artifactRepository{db: db}
+}
+
+const artifactColumns = `hash, bucket, path, content_type, size_bytes, ref_count, unreferenced_at, created_at`
+
+func scanArtifact(row pgx.Row, a *models.Artifact) error {
+	return row.Scan(&a.Hash, &a.Bucket, &a.Path, &a.ContentType, &a.SizeBytes, &a.RefCount, &a.UnreferencedAt, &a.CreatedAt)
+}
+
+// Get busca um artefato pelo hash do conteúdo
+func (r *artifactRepository) Get(ctx context.Context, hash string) (*models.Artifact, error) {
+	var a models.Artifact
+	err := scanArtifact(r.db.QueryRow(ctx, `SELECT `+artifactColumns+` FROM rf_artifacts WHERE hash = $1`, hash), &a)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrArtifactNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Create registra o artefato e informa se foi inserido agora (o chamador deve enviar o objeto).
+// Docstring: quando o conteúdo já existe, renova a carência de coleta para não disputar com o GC.
+func (r *artifactRepository) Create(ctx context.Context, a *models.Artifact) (bool, error) {
+	query := `
+		INSERT INTO rf_artifacts (hash, bucket, path, content_type, size_bytes)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (hash) DO UPDATE
+		SET unreferenced_at = CASE WHEN rf_artifacts.ref_count = 0 THEN now() ELSE NULL END
+		RETURNING ` + artifactColumns + `, (xmax = 0)
+	`
+	var inserted bool
+	err := r.db.QueryRow(ctx, query, a.Hash, a.Bucket, a.Path, a.ContentType, a.SizeBytes).
+		Scan(&a.Hash, &a.Bucket, &a.Path, &a.ContentType, &a.SizeBytes, &a.RefCount, &a.UnreferencedAt, &a.CreatedAt, &inserted)
+	return inserted, err
+}
+
+// AttachRef vincula o artefato ao recibo/modelo do usuário, substituindo o artefato anterior do mesmo tipo
+func (r *artifactRepository) AttachRef(ctx context.Context, ref *models.ArtifactRef) error {
+	if ref.ID == uuid.Nil {
+		ref.ID = uuid.New()
+	}
+	var query string
+	var target uuid.UUID
+	switch {
+	case ref.ReceiptID != nil:
+		target = *ref.ReceiptID
+		query = `
+			INSERT INTO rf_artifact_refs (id, owner_id, artifact_hash, kind, receipt_id)
+			SELECT $1, $2, $3, $4, $5
+			WHERE EXISTS (SELECT 1 FROM rf_receipts WHERE id = $5 AND owner_id = $2)
+			ON CONFLICT (receipt_id, kind) WHERE receipt_id IS NOT NULL
+			DO UPDATE SET artifact_hash = EXCLUDED.artifact_hash, created_at = now()
+			WHERE rf_artifact_refs.owner_id = EXCLUDED.owner_id
+			RETURNING id, created_at
+		`
+	case ref.TemplateID != nil:
+		target = *ref.TemplateID
+		query = `
+			INSERT INTO rf_artifact_refs (id, owner_id, artifact_hash, kind, template_id)
+			SELECT $1, $2, $3, $4, $5
+			WHERE EXISTS (SELECT 1 FROM rf_income_templates WHERE id = $5 AND owner_id = $2)
+			ON CONFLICT (template_id, kind) WHERE template_id IS NOT NULL
+			DO UPDATE SET artifact_hash = EXCLUDED.artifact_hash, created_at = now()
+			WHERE rf_artifact_refs.owner_id = EXCLUDED.owner_id
+			RETURNING id, created_at
+		`
+	default:
+		return models.ErrArtifactTargetNotFound
+	}
+	err := r.db.QueryRow(ctx, query, ref.ID, ref.OwnerID, ref.ArtifactHash, ref.Kind, target).Scan(&ref.ID, &ref.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrArtifactTargetNotFound
+	}
+	return err
+}
+
+// DetachRef remove a referência do tipo informado do recibo/modelo do usuário
+func (r *artifactRepository) DetachRef(ctx context.Context, ref *models.ArtifactRef) error {
+	query := `
+		DELETE FROM rf_artifact_refs
+		WHERE owner_id = $1 AND kind = $2
+		  AND receipt_id IS NOT DISTINCT FROM $3 AND template_id IS NOT DISTINCT FROM $4
+	`
+	cmd, err := r.db.Exec(ctx, query, ref.OwnerID, ref.Kind, ref.ReceiptID, ref.TemplateID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrArtifactNotFound
+	}
+	return nil
+}
+
+// ListCollectable lista artefatos sem referências desde antes de before (carência da coleta).
+// Docstring: artefatos que nunca receberam referência usam created_at (upload interrompido).
+func (r *artifactRepository) ListCollectable(ctx context.Context, before time.Time, limit int) ([]models.Artifact, error) {
+	query := `
+		SELECT ` + artifactColumns + `
+		FROM rf_artifacts
+		WHERE ref_count = 0 AND COALESCE(unreferenced_at, created_at) < $1
+		ORDER BY COALESCE(unreferenced_at, created_at)
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []models.Artifact{}
+	for rows.Next() {
+		var a models.Artifact
+		if err := scanArtifact(rows, &a); err != nil {
+			return nil, err
+		}
+		items = append(items, a)
+	}
+	return items, rows.Err()
+}
+
+// Collect remove o artefato se continuar sem referências e fora da carência.
+// Docstring: a linha fica bloqueada (FOR UPDATE) enquanto remove apaga o objeto no Storage, de modo que
+// envios concorrentes do mesmo conteúdo aguardem; se remove falhar, nada é excluído.
+func (r *artifactRepository) Collect(ctx context.Context, hash string, before time.Time, remove func(*models.Artifact) error) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var a models.Artifact
+	err = scanArtifact(tx.QueryRow(ctx, `
+		SELECT `+artifactColumns+` FROM rf_artifacts
+		WHERE hash = $1 AND ref_count = 0 AND COALESCE(unreferenced_at, created_at) < $2
+		FOR UPDATE
+	`, hash, before), &a)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := remove(&a); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM rf_artifacts WHERE hash = $1`, hash); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
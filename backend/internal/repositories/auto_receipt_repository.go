@@ -0,0 +1,111 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório da fila de emissão automática de recibos (rf_auto_receipts)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// AutoReceiptRepository define a fila de recibos automáticos.
+// Docstring: os pedidos são gravados por enqueueAutoReceipt na transação do pagamento; ClaimPending
+// usa FOR UPDATE SKIP LOCKED como os lotes de recibos, e pedidos presos além do lockTimeout voltam
+// a ser elegíveis.
+type AutoReceiptRepository interface {
+	ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.AutoReceipt, error)
+	HasReceipt(ctx context.Context, ownerID, incomeID uuid.UUID) (bool, error)
+	Release(ctx context.Context, ar *models.AutoReceipt) error
+	Finish(ctx context.Context, ar *models.AutoReceipt, now time.Time) error
+}
+
+type autoReceiptRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAutoReceiptRepository cria uma nova instância do repositório de recibos automáticos
+func NewAutoReceiptRepository(db *pgxpool.Pool) AutoReceiptRepository {
+	return &autoReceiptRepository{db: db}
+}
+
+const autoReceiptColumns = `income_id, owner_id, payment_id, status, receipt_id, attempts, last_error, created_at, processed_at`
+
+// enqueueAutoReceipt grava o pedido de recibo da receita quitada dentro da transação do pagamento.
+// Docstring: só grava se o dono ligou a emissão automática; um pedido já emitido não é reaberto,
+// e um ignorado ou com falha volta à fila (receita quitada de novo após estorno).
+func enqueueAutoReceipt(ctx context.Context, tx pgx.Tx, ownerID, incomeID, paymentID uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO rf_auto_receipts (income_id, owner_id, payment_id)
+		SELECT $1, $2, $3 FROM rf_settings WHERE owner_id = $2 AND recibo_automatico
+		ON CONFLICT (income_id) DO UPDATE SET
+			payment_id = EXCLUDED.payment_id, status = 'pending', receipt_id = NULL, attempts = 0,
+			last_error = NULL, created_at = NOW(), locked_at = NULL, processed_at = NULL
+		WHERE rf_auto_receipts.status IN ('skipped', 'failed')
+	`, incomeID, ownerID, paymentID)
+	if err != nil {
+		return fmt.Errorf("erro ao agendar recibo automático: %w", err)
+	}
+	return nil
+}
+
+// ClaimPending reserva até limit pedidos pendentes, contando a tentativa
+func (r *autoReceiptRepository) ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.AutoReceipt, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE rf_auto_receipts a
+		SET locked_at = $1, attempts = a.attempts + 1
+		WHERE a.income_id IN (
+			SELECT income_id FROM rf_auto_receipts
+			WHERE status = 'pending' AND (locked_at IS NULL OR locked_at < $2)
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+autoReceiptColumns, now, now.Add(-lockTimeout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.AutoReceipt
+	for rows.Next() {
+		var a models.AutoReceipt
+		if err := rows.Scan(&a.IncomeID, &a.OwnerID, &a.PaymentID, &a.Status, &a.ReceiptID, &a.Attempts,
+			&a.LastError, &a.CreatedAt, &a.ProcessedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, a)
+	}
+	return items, rows.Err()
+}
+
+// HasReceipt indica se a receita já tem recibo
+func (r *autoReceiptRepository) HasReceipt(ctx context.Context, ownerID, incomeID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM rf_receipts WHERE owner_id = $1 AND income_id = $2)`,
+		ownerID, incomeID).Scan(&exists)
+	return exists, err
+}
+
+// Release devolve o pedido à fila com o erro da tentativa
+func (r *autoReceiptRepository) Release(ctx context.Context, ar *models.AutoReceipt) error {
+	_, err := r.db.Exec(ctx, `UPDATE rf_auto_receipts SET locked_at = NULL, last_error = $2 WHERE income_id = $1`,
+		ar.IncomeID, ar.LastError)
+	return err
+}
+
+// Finish grava a situação final do pedido (issued, skipped ou failed)
+func (r *autoReceiptRepository) Finish(ctx context.Context, ar *models.AutoReceipt, now time.Time) error {
+	return r.db.QueryRow(ctx, `
+		UPDATE rf_auto_receipts SET status = $2, receipt_id = $3, last_error = $4, processed_at = $5, locked_at = NULL
+		WHERE income_id = $1
+		RETURNING processed_at
+	`, ar.IncomeID, ar.Status, ar.ReceiptID, ar.LastError, now).Scan(&ar.ProcessedAt)
+}
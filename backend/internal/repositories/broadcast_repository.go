@@ -0,0 +1,263 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de envios em massa (rf_broadcasts), destinatários e relatório de entrega
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// BroadcastRepository define as operações de envios em massa.
+// Docstring: ClaimPending usa FOR UPDATE SKIP LOCKED como a fila de entregas; envios presos em
+// "running" além do lockTimeout voltam a ser elegíveis e ListTargets ignora os pagadores já
+// registrados, de modo que o reprocessamento não duplica mensagens.
+type BroadcastRepository interface {
+	Create(ctx context.Context, b *models.Broadcast) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Broadcast, error)
+	List(ctx context.Context, ownerID uuid.UUID, limit int) ([]models.Broadcast, error)
+	ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.Broadcast, error)
+	ListTargets(ctx context.Context, b *models.Broadcast, now time.Time) ([]models.BroadcastTarget, error)
+	AddRecipient(ctx context.Context, rc *models.BroadcastRecipient) error
+	Finish(ctx context.Context, b *models.Broadcast, now time.Time) error
+	Report(ctx context.Context, id uuid.UUID) (*models.BroadcastReport, error)
+}
+
+type broadcastRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBroadcastRepository cria uma nova instância do repositório de envios em massa
+func NewBroadcastRepository(db *pgxpool.Pool) BroadcastRepository {
+	return &broadcastRepository{db: db}
+}
+
+const broadcastColumns = `id, owner_id, channel, subject, template, filter, variables, locale, timezone,
+	status, total, enqueued, skipped, last_error, created_at, started_at, finished_at`
+
+func scanBroadcast(row pgx.Row, b *models.Broadcast) error {
+	var filter, vars []byte
+	if err := row.Scan(&b.ID, &b.OwnerID, &b.Channel, &b.Subject, &b.Template, &filter, &vars, &b.Locale,
+		&b.Timezone, &b.Status, &b.Total, &b.Enqueued, &b.Skipped, &b.LastError, &b.CreatedAt,
+		&b.StartedAt, &b.FinishedAt); err != nil {
+		return err
+	}
+	if len(filter) > 0 {
+		if err := json.Unmarshal(filter, &b.Filter); err != nil {
+			return err
+		}
+	}
+	if len(vars) > 0 {
+		if err := json.Unmarshal(vars, &b.Variables); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create grava um envio em massa na fila (status queued)
+func (r *broadcastRepository) Create(ctx context.Context, b *models.Broadcast) error {
+	filter, err := json.Marshal(b.Filter)
+	if err != nil {
+		return err
+	}
+	vars, err := json.Marshal(b.Variables)
+	if err != nil {
+		return err
+	}
+	return r.db.QueryRow(ctx, `
+		INSERT INTO rf_broadcasts (owner_id, channel, subject, template, filter, variables, locale, timezone, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'queued')
+		RETURNING id, status, created_at
+	`, b.OwnerID, b.Channel, b.Subject, b.Template, filter, vars, b.Locale, b.Timezone).Scan(&b.ID, &b.Status, &b.CreatedAt)
+}
+
+// GetByID busca um envio do usuário
+func (r *broadcastRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Broadcast, error) {
+	var b models.Broadcast
+	err := scanBroadcast(r.db.QueryRow(ctx, `SELECT `+broadcastColumns+` FROM rf_broadcasts WHERE id = $1 AND owner_id = $2`, id, ownerID), &b)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrBroadcastNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// List lista os envios mais recentes do usuário
+func (r *broadcastRepository) List(ctx context.Context, ownerID uuid.UUID, limit int) ([]models.Broadcast, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+broadcastColumns+` FROM rf_broadcasts WHERE owner_id = $1 ORDER BY created_at DESC LIMIT $2`, ownerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.Broadcast{}
+	for rows.Next() {
+		var b models.Broadcast
+		if err := scanBroadcast(rows, &b); err != nil {
+			return nil, err
+		}
+		b.Variables = nil
+		items = append(items, b)
+	}
+	return items, rows.Err()
+}
+
+// ClaimPending reserva até limit envios na fila, marcando-os como "running"
+func (r *broadcastRepository) ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.Broadcast, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE rf_broadcasts
+		SET status = 'running', started_at = $1
+		WHERE id IN (
+			SELECT id FROM rf_broadcasts
+			WHERE status = 'queued' OR (status = 'running' AND started_at < $2)
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+broadcastColumns, now, now.Add(-lockTimeout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.Broadcast
+	for rows.Next() {
+		var b models.Broadcast
+		if err := scanBroadcast(rows, &b); err != nil {
+			return nil, err
+		}
+		items = append(items, b)
+	}
+	return items, rows.Err()
+}
+
+// ListTargets resolve os pagadores do filtro com saldo em aberto e vencimentos.
+// Docstring: pagadores já registrados em rf_broadcast_recipients para o envio são excluídos.
+func (r *broadcastRepository) ListTargets(ctx context.Context, b *models.Broadcast, now time.Time) ([]models.BroadcastTarget, error) {
+	where := "p.owner_id = $1 AND NOT EXISTS (SELECT 1 FROM rf_broadcast_recipients br WHERE br.broadcast_id = $2 AND br.payer_id = p.id)"
+	args := []interface{}{b.OwnerID, b.ID, now}
+	if b.Filter.Search != "" {
+		args = append(args, "%"+b.Filter.Search+"%")
+		where += fmt.Sprintf(" AND (p.nome ILIKE $%[1]d OR p.documento ILIKE $%[1]d OR p.email ILIKE $%[1]d)", len(args))
+	}
+	if len(b.Filter.PayerIDs) > 0 {
+		args = append(args, b.Filter.PayerIDs)
+		where += fmt.Sprintf(" AND p.id = ANY($%d)", len(args))
+	}
+	if b.Filter.OpenBalance {
+		where += " AND COALESCE(o.abertas, 0) > 0"
+	}
+	if b.Filter.Overdue {
+		where += " AND COALESCE(o.vencidas, 0) > 0"
+	}
+
+	query := `
+		SELECT p.id, p.nome, p.documento, p.email, p.telefone,
+		       COALESCE(o.saldo, 0), COALESCE(o.abertas, 0), COALESCE(o.vencidas, 0), o.prox_vencimento
+		FROM rf_payers p
+		LEFT JOIN LATERAL (
+			SELECT SUM(i.valor - i.total_pago) AS saldo,
+			       COUNT(*) AS abertas,
+			       COUNT(*) FILTER (WHERE i.due_date < $3) AS vencidas,
+			       MIN(i.due_date) FILTER (WHERE i.due_date >= $3) AS prox_vencimento
+			FROM rf_incomes i
+			WHERE i.owner_id = p.owner_id AND i.payer_id = p.id AND i.deleted_at IS NULL
+			  AND i.status NOT IN ('pago', 'cancelado') AND i.valor > i.total_pago
+		) o ON true
+		WHERE ` + where + `
+		ORDER BY lower(p.nome)`
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.BroadcastTarget
+	for rows.Next() {
+		var t models.BroadcastTarget
+		if err := rows.Scan(&t.PayerID, &t.Nome, &t.Documento, &t.Email, &t.Telefone,
+			&t.SaldoAberto, &t.Abertas, &t.Vencidas, &t.ProxVencimento); err != nil {
+			return nil, err
+		}
+		items = append(items, t)
+	}
+	return items, rows.Err()
+}
+
+// AddRecipient registra o resultado de um pagador (idempotente por envio e pagador)
+func (r *broadcastRepository) AddRecipient(ctx context.Context, rc *models.BroadcastRecipient) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO rf_broadcast_recipients (broadcast_id, payer_id, owner_id, destination, delivery_id, status, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (broadcast_id, payer_id) DO NOTHING
+	`, rc.BroadcastID, rc.PayerID, rc.OwnerID, rc.Destination, rc.DeliveryID, rc.Status, rc.Reason)
+	return err
+}
+
+// Finish grava a situação final; os contadores são recalculados a partir dos destinatários
+func (r *broadcastRepository) Finish(ctx context.Context, b *models.Broadcast, now time.Time) error {
+	return r.db.QueryRow(ctx, `
+		UPDATE rf_broadcasts bc SET status = $2, last_error = $3, finished_at = $4,
+			total = c.total, enqueued = c.enqueued, skipped = c.skipped
+		FROM (
+			SELECT COUNT(*) AS total,
+			       COUNT(*) FILTER (WHERE status = 'queued') AS enqueued,
+			       COUNT(*) FILTER (WHERE status = 'skipped') AS skipped
+			FROM rf_broadcast_recipients WHERE broadcast_id = $1
+		) c
+		WHERE bc.id = $1
+		RETURNING bc.total, bc.enqueued, bc.skipped, bc.finished_at
+	`, b.ID, b.Status, b.LastError, now).Scan(&b.Total, &b.Enqueued, &b.Skipped, &b.FinishedAt)
+}
+
+// Report agrega os destinatários ignorados e o status atual das entregas do envio
+func (r *broadcastRepository) Report(ctx context.Context, id uuid.UUID) (*models.BroadcastReport, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT br.status, COALESCE(br.reason, ''), COALESCE(d.status, ''), COUNT(*)
+		FROM rf_broadcast_recipients br
+		LEFT JOIN rf_deliveries d ON d.id = br.delivery_id
+		WHERE br.broadcast_id = $1
+		GROUP BY 1, 2, 3
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rep := &models.BroadcastReport{SkippedReasons: map[string]int{}}
+	for rows.Next() {
+		var status, reason, delivery string
+		var n int
+		if err := rows.Scan(&status, &reason, &delivery, &n); err != nil {
+			return nil, err
+		}
+		rep.Total += n
+		if status == models.BroadcastRecipientSkipped {
+			rep.Skipped += n
+			rep.SkippedReasons[reason] += n
+			continue
+		}
+		switch delivery {
+		case models.DeliveryStatusDelivered:
+			rep.Delivered += n
+		case models.DeliveryStatusDead:
+			rep.Dead += n
+		default:
+			rep.Pending += n
+		}
+	}
+	return rep, rows.Err()
+}
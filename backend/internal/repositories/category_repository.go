@@ -0,0 +1,209 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de categorias (rf_categories) com renomeação propagada às receitas
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// CategoryRepository define operações de persistência para categorias.
+// Docstring: receitas, modelos e regras guardam o nome da categoria; Update e Delete
+// mantêm esses nomes em sincronia na mesma transação (recibos emitidos não são alterados).
+type CategoryRepository interface {
+	Create(ctx context.Context, c *models.Category) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Category, error)
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.Category, error)
+	Update(ctx context.Context, c *models.Category) error
+	Delete(ctx context.Context, id, ownerID uuid.UUID, replaceWith *uuid.UUID) error
+}
+
+type categoryRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCategoryRepository cria uma nova instância do repositório de categorias
+func NewCategoryRepository(db *pgxpool.Pool) CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+const categoryColumns = `id, owner_id, nome, cor, icone, created_at, updated_at`
+
+func scanCategory(row pgx.Row, c *models.Category) error {
+	return row.Scan(&c.ID, &c.OwnerID, &c.Nome, &c.Cor, &c.Icone, &c.CreatedAt, &c.UpdatedAt)
+}
+
+// mapCategoryError traduz violações de constraint em erros de domínio
+func mapCategoryError(err error) error {
+	if isConstraintViolation(err, pgUniqueViolation, "idx_categories_owner_nome") {
+		return models.ErrDuplicateCategory
+	}
+	return err
+}
+
+// Create insere uma nova categoria
+func (r *categoryRepository) Create(ctx context.Context, c *models.Category) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	query := `
+		INSERT INTO rf_categories (id, owner_id, nome, cor, icone)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, c.ID, c.OwnerID, c.Nome, c.Cor, c.Icone).Scan(&c.CreatedAt, &c.UpdatedAt)
+	return mapCategoryError(err)
+}
+
+// GetByID busca uma categoria do usuário
+func (r *categoryRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Category, error) {
+	var c models.Category
+	err := scanCategory(r.db.QueryRow(ctx, `SELECT `+categoryColumns+` FROM rf_categories WHERE id = $1 AND owner_id = $2`, id, ownerID), &c)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// List lista as categorias do usuário com o uso pelas receitas ativas
+func (r *categoryRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.Category, error) {
+	query := `
+		SELECT c.id, c.owner_id, c.nome, c.cor, c.icone, c.created_at, c.updated_at,
+		       COUNT(i.id), COUNT(i.id) FILTER (WHERE i.status <> 'pago'), COALESCE(SUM(i.valor), 0)
+		FROM rf_categories c
+		LEFT JOIN rf_incomes i
+		  ON i.owner_id = c.owner_id AND lower(i.categoria) = lower(c.nome) AND i.deleted_at IS NULL
+		WHERE c.owner_id = $1
+		GROUP BY c.id
+		ORDER BY lower(c.nome)
+	`
+	rows, err := r.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.Category{}
+	for rows.Next() {
+		var c models.Category
+		u := &models.CategoryUsage{}
+		if err := rows.Scan(&c.ID, &c.OwnerID, &c.Nome, &c.Cor, &c.Icone, &c.CreatedAt, &c.UpdatedAt,
+			&u.Receitas, &u.Abertas, &u.ValorTotal); err != nil {
+			return nil, err
+		}
+		c.Uso = u
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+// Update atualiza a categoria; se o nome mudar, renomeia nas receitas, modelos e regras do usuário
+func (r *categoryRepository) Update(ctx context.Context, c *models.Category) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var oldNome string
+	err = tx.QueryRow(ctx, `SELECT nome FROM rf_categories WHERE id = $1 AND owner_id = $2 FOR UPDATE`, c.ID, c.OwnerID).Scan(&oldNome)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrCategoryNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE rf_categories SET nome = $3, cor = $4, icone = $5
+		WHERE id = $1 AND owner_id = $2
+		RETURNING created_at, updated_at
+	`, c.ID, c.OwnerID, c.Nome, c.Cor, c.Icone).Scan(&c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return mapCategoryError(err)
+	}
+	if oldNome != c.Nome {
+		if err := renameCategory(ctx, tx, c.OwnerID, oldNome, &c.Nome); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// Delete remove a categoria; as receitas e modelos passam para replaceWith ou ficam sem categoria
+func (r *categoryRepository) Delete(ctx context.Context, id, ownerID uuid.UUID, replaceWith *uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var nome string
+	err = tx.QueryRow(ctx, `DELETE FROM rf_categories WHERE id = $1 AND owner_id = $2 RETURNING nome`, id, ownerID).Scan(&nome)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrCategoryNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var target *string
+	if replaceWith != nil {
+		var replacement string
+		err := tx.QueryRow(ctx, `SELECT nome FROM rf_categories WHERE id = $1 AND owner_id = $2`, *replaceWith, ownerID).Scan(&replacement)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrCategoryReplaceInvalid
+		}
+		if err != nil {
+			return err
+		}
+		target = &replacement
+	}
+	if err := renameCategory(ctx, tx, ownerID, nome, target); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// renameCategory troca o nome da categoria (nil limpa) em receitas, despesas, modelos e ações de regras.
+// Docstring: recibos guardam a categoria congelada na emissão (migração 017) e não são alterados;
+// regras só são alteradas quando há novo nome, pois uma regra sem categoria pode ficar sem ações.
+func renameCategory(ctx context.Context, tx pgx.Tx, ownerID uuid.UUID, oldNome string, newNome *string) error {
+	if _, err := tx.Exec(ctx, `
+		UPDATE rf_incomes SET categoria = $3, updated_at = NOW()
+		WHERE owner_id = $1 AND lower(btrim(categoria)) = lower($2)
+	`, ownerID, oldNome, newNome); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE rf_income_templates SET categoria = $3
+		WHERE owner_id = $1 AND lower(btrim(categoria)) = lower($2)
+	`, ownerID, oldNome, newNome); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE rf_expenses SET categoria = $3
+		WHERE owner_id = $1 AND lower(btrim(categoria)) = lower($2)
+	`, ownerID, oldNome, newNome); err != nil {
+		return err
+	}
+	if newNome == nil {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `
+		UPDATE rf_income_rules SET actions = jsonb_set(actions, '{categoria}', to_jsonb($3::text))
+		WHERE owner_id = $1 AND lower(btrim(actions->>'categoria')) = lower($2)
+	`, ownerID, oldNome, *newNome)
+	return err
+}
@@ -0,0 +1,135 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório dos reajustes de contratos (valor do contrato, receitas futuras, modelos e histórico)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// ContractReadjustRepository aplicação e histórico dos reajustes de contratos
+type ContractReadjustRepository interface {
+	Apply(ctx context.Context, rj *models.ContractReadjustment) error
+	List(ctx context.Context, contractID, ownerID uuid.UUID) ([]models.ContractReadjustment, error)
+}
+
+type contractReadjustRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewContractReadjustRepository cria uma nova instância do repositório de reajustes
+func NewContractReadjustRepository(db *pgxpool.Pool) ContractReadjustRepository {
+	return &contractReadjustRepository{db: db}
+}
+
+// Apply aplica o reajuste em uma transação e preenche ValorAnterior, ValorNovo e as contagens.
+// Docstring: trava o contrato, recalcula o valor mensal a partir do valor gravado e multiplica pelo
+// mesmo fator as receitas do contrato com competência a partir de APartirDe que ainda não receberam
+// pagamento (pendentes ou vencidas, com versão incrementada) e os modelos de receita do contrato.
+// Com DryRun tudo é desfeito no fim, devolvendo só a simulação.
+func (r *contractReadjustRepository) Apply(ctx context.Context, rj *models.ContractReadjustment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `SELECT valor_mensal FROM rf_contracts WHERE id = $1 AND owner_id = $2 FOR UPDATE`,
+		rj.ContractID, rj.OwnerID).Scan(&rj.ValorAnterior)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrContractNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if rj.ValorAnterior <= 0 {
+		return models.ErrReadjustContractValue
+	}
+	rj.ValorNovo = models.ReadjustedValue(rj.ValorAnterior, rj.Percentual)
+	factor := 1 + rj.Percentual/100
+
+	if _, err := tx.Exec(ctx, `UPDATE rf_contracts SET valor_mensal = $3 WHERE id = $1 AND owner_id = $2`,
+		rj.ContractID, rj.OwnerID, rj.ValorNovo); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE rf_incomes
+		SET valor = round(valor * $4::numeric, 2), version = version + 1, updated_at = NOW()
+		WHERE contract_id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		  AND status IN ('pendente', 'vencido') AND COALESCE(total_pago, 0) = 0
+		  AND (CASE
+				WHEN competencia ~ '^\d{4}-\d{2}$' THEN to_date(competencia, 'YYYY-MM')
+				WHEN competencia ~ '^\d{2}/\d{4}$' THEN to_date(competencia, 'MM/YYYY')
+			END) >= $3
+	`, rj.ContractID, rj.OwnerID, rj.APartirDe, factor)
+	if err != nil {
+		return err
+	}
+	rj.ReceitasAtualizadas = int(tag.RowsAffected())
+
+	tag, err = tx.Exec(ctx, `UPDATE rf_income_templates SET valor = round(valor * $3::numeric, 2)
+		WHERE contract_id = $1 AND owner_id = $2`, rj.ContractID, rj.OwnerID, factor)
+	if err != nil {
+		return err
+	}
+	rj.ModelosAtualizados = int(tag.RowsAffected())
+
+	if rj.ID == uuid.Nil {
+		rj.ID = uuid.New()
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_contract_readjustments (
+			id, owner_id, contract_id, indice, periodo_inicio, periodo_fim, acumulado, percentual,
+			valor_anterior, valor_novo, a_partir_de, receitas_atualizadas, modelos_atualizados
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING created_at
+	`, rj.ID, rj.OwnerID, rj.ContractID, rj.Indice, rj.PeriodoInicio, rj.PeriodoFim, rj.Acumulado, rj.Percentual,
+		rj.ValorAnterior, rj.ValorNovo, rj.APartirDe, rj.ReceitasAtualizadas, rj.ModelosAtualizados).Scan(&rj.CreatedAt)
+	if isConstraintViolation(err, pgUniqueViolation, "uq_contract_readjustments_start") {
+		return models.ErrReadjustAlreadyApplied
+	}
+	if err != nil {
+		return err
+	}
+	if rj.DryRun {
+		rj.ID, rj.CreatedAt = uuid.Nil, nil
+		return nil
+	}
+	return tx.Commit(ctx)
+}
+
+// List lista os reajustes do contrato, do mais recente ao mais antigo
+func (r *contractReadjustRepository) List(ctx context.Context, contractID, ownerID uuid.UUID) ([]models.ContractReadjustment, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, owner_id, contract_id, indice, periodo_inicio, periodo_fim, acumulado::float8, percentual::float8,
+		       valor_anterior, valor_novo, a_partir_de, receitas_atualizadas, modelos_atualizados, created_at
+		FROM rf_contract_readjustments
+		WHERE contract_id = $1 AND owner_id = $2
+		ORDER BY a_partir_de DESC, created_at DESC
+	`, contractID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.ContractReadjustment{}
+	for rows.Next() {
+		var rj models.ContractReadjustment
+		if err := rows.Scan(&rj.ID, &rj.OwnerID, &rj.ContractID, &rj.Indice, &rj.PeriodoInicio, &rj.PeriodoFim,
+			&rj.Acumulado, &rj.Percentual, &rj.ValorAnterior, &rj.ValorNovo, &rj.APartirDe,
+			&rj.ReceitasAtualizadas, &rj.ModelosAtualizados, &rj.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, rj)
+	}
+	return items, rows.Err()
+}
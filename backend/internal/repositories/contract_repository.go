@@ -0,0 +1,108 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de leitura de contratos (rf_contracts) para documentos gerados no backend
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// ContractRepository consultas de contratos; o cadastro em si é feito pelo app via Supabase
+// (Create só é usado pelos dados de demonstração do subcomando seed)
+type ContractRepository interface {
+	Create(ctx context.Context, c *models.Contract) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Contract, error)
+	ListReceiptBookEntries(ctx context.Context, id, ownerID uuid.UUID, year int) ([]models.ReceiptBookEntry, error)
+}
+
+type contractRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewContractRepository cria uma nova instância do repositório de contratos
+func NewContractRepository(db *pgxpool.Pool) ContractRepository {
+	return &contractRepository{db: db}
+}
+
+// Create cadastra o contrato (ativo, status "ativo")
+func (r *contractRepository) Create(ctx context.Context, c *models.Contract) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	query := `
+		INSERT INTO rf_contracts (
+			id, owner_id, numero, descricao, valor_mensal, vencimento_dia, data_inicio, data_fim,
+			payer_id, issuer_name, issuer_document, ativo, status
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, true, 'ativo'
+		)
+	`
+	_, err := r.db.Exec(ctx, query, c.ID, c.OwnerID, c.Numero, c.Descricao, c.ValorMensal, c.VencimentoDia,
+		c.DataInicio, c.DataFim, c.PayerID, c.IssuerName, c.IssuerDocument)
+	return err
+}
+
+// GetByID busca o contrato do usuário com nome e documento do pagador.
+// Docstring: sem emissor no contrato vale o do perfil (emissor padrão e, sem ele, nome e documento),
+// como no snapshot dos recibos.
+func (r *contractRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Contract, error) {
+	query := `
+		SELECT c.id, c.owner_id, c.numero, c.descricao, c.valor_mensal, c.vencimento_dia,
+		       c.data_inicio, c.data_fim, c.payer_id, p.nome, p.documento,
+		       COALESCE(c.issuer_name, pr.emissor_nome, pr.nome), COALESCE(c.issuer_document, pr.emissor_documento, pr.documento)
+		FROM rf_contracts c
+		LEFT JOIN rf_payers p ON p.id = c.payer_id AND p.owner_id = c.owner_id
+		LEFT JOIN rf_profiles pr ON pr.id = c.owner_id
+		WHERE c.id = $1 AND c.owner_id = $2
+	`
+	var c models.Contract
+	err := r.db.QueryRow(ctx, query, id, ownerID).Scan(&c.ID, &c.OwnerID, &c.Numero, &c.Descricao, &c.ValorMensal, &c.VencimentoDia,
+		&c.DataInicio, &c.DataFim, &c.PayerID, &c.PayerNome, &c.PayerDocumento, &c.IssuerName, &c.IssuerDocument)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrContractNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// ListReceiptBookEntries lista as receitas ativas do contrato no ano (competência "AAAA-MM" ou "MM/AAAA").
+// Docstring: por competência, receitas com recibo emitido vêm primeiro (menor número), depois as mais antigas.
+func (r *contractRepository) ListReceiptBookEntries(ctx context.Context, id, ownerID uuid.UUID, year int) ([]models.ReceiptBookEntry, error) {
+	query := `
+		SELECT i.id, i.competencia, i.valor, i.due_date, i.status, rc.numero, rc.numero_formatado
+		FROM rf_incomes i
+		LEFT JOIN LATERAL (
+			SELECT numero, numero_formatado FROM rf_receipts WHERE income_id = i.id AND owner_id = i.owner_id
+			ORDER BY numero LIMIT 1
+		) rc ON true
+		WHERE i.contract_id = $1 AND i.owner_id = $2 AND i.deleted_at IS NULL
+		  AND (i.competencia LIKE $3 || '-%' OR i.competencia LIKE '%/' || $3)
+		ORDER BY i.competencia, rc.numero NULLS LAST, i.created_at
+	`
+	rows, err := r.db.Query(ctx, query, id, ownerID, strconv.Itoa(year))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.ReceiptBookEntry{}
+	for rows.Next() {
+		var e models.ReceiptBookEntry
+		if err := rows.Scan(&e.IncomeID, &e.Competencia, &e.Valor, &e.DueDate, &e.Status, &e.ReceiptNumero, &e.ReceiptNumeroFormatado); err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+	}
+	return items, rows.Err()
+}
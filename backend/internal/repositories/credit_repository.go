@@ -0,0 +1,299 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de créditos (pagamento excedente e aplicação em receitas) em transação
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/locale"
+	"recibofast/internal/models"
+)
+
+// CreditRepository define as operações de créditos do usuário
+type CreditRepository interface {
+	AddPaymentWithCredit(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, *models.Credit, error)
+	List(ctx context.Context, ownerID uuid.UUID, onlyAvailable bool) ([]models.Credit, error)
+	Apply(ctx context.Context, creditID, ownerID uuid.UUID, req *models.CreditApplyRequest) (*models.CreditApplyResponse, error)
+}
+
+type creditRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCreditRepository cria uma nova instância do repositório de créditos
+func NewCreditRepository(db *pgxpool.Pool) CreditRepository {
+	return &creditRepository{db: db}
+}
+
+const creditColumns = `id, owner_id, payer_id, source_income_id, source_payment_id, valor, saldo, created_at, updated_at`
+
+func scanCredit(row pgx.Row, c *models.Credit) error {
+	return row.Scan(&c.ID, &c.OwnerID, &c.PayerID, &c.SourceIncomeID, &c.SourcePaymentID, &c.Valor, &c.Saldo, &c.CreatedAt, &c.UpdatedAt)
+}
+
+// AddPaymentWithCredit registra o pagamento quitando o saldo e guarda o excedente como crédito.
+// Docstring: mesma trava de AddPaymentTx; o pagamento gravado fica limitado ao saldo devedor
+// (payment.Valor é ajustado) e o restante vira um crédito do pagador da receita. Sem excedente,
+// nenhum crédito é criado (retorno nil).
+func (r *creditRepository) AddPaymentWithCredit(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, *models.Credit, error) {
+	return addPaymentWithCreditTx(ctx, r.db, payment, ownerID, time.Now())
+}
+
+func addPaymentWithCreditTx(ctx context.Context, db paymentTxBeginner, payment *models.Payment, ownerID uuid.UUID, now time.Time) (*models.Income, *models.Credit, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var valor, totalPago models.Money
+	var status string
+	var due *time.Time
+	var payerID *uuid.UUID
+	err = tx.QueryRow(ctx, `
+		SELECT valor, total_pago, status, due_date, payer_id FROM rf_incomes
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, payment.IncomeID, ownerID).Scan(&valor, &totalPago, &status, &due, &payerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, models.ErrIncomeNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao travar receita: %w", err)
+	}
+	saldo := valor - totalPago
+	if saldo <= 0 {
+		return nil, nil, models.ErrIncomeAlreadyPaid
+	}
+	excess := payment.Valor - saldo
+	if excess > 0 {
+		payment.Valor = saldo
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_payments (id, income_id, valor, pago_em, metodo, method_id, obs, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at
+	`, payment.ID, payment.IncomeID, payment.Valor, payment.PagoEm, payment.Metodo, payment.MethodID, payment.Obs).Scan(&payment.CreatedAt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao adicionar pagamento: %w", err)
+	}
+
+	income, err := recalcIncomeTotal(ctx, tx, payment.IncomeID, valor, status, due, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var credit *models.Credit
+	if excess > 0 {
+		credit = &models.Credit{}
+		err = scanCredit(tx.QueryRow(ctx, `
+			INSERT INTO rf_credits (owner_id, payer_id, source_income_id, source_payment_id, valor, saldo)
+			VALUES ($1, $2, $3, $4, $5, $5)
+			RETURNING `+creditColumns,
+			ownerID, payerID, payment.IncomeID, payment.ID, excess), credit)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao registrar crédito: %w", err)
+		}
+	}
+	if models.IncomeSettled(income) {
+		if err := enqueueAutoReceipt(ctx, tx, ownerID, payment.IncomeID, payment.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("erro ao confirmar pagamento: %w", err)
+	}
+	return income, credit, nil
+}
+
+// Apply aplica o crédito em uma receita do usuário como um pagamento (método "credito").
+// Docstring: crédito e receita são travados; o valor aplicado é o pedido ou o menor entre os
+// dois saldos. Crédito com pagador só quita receitas do mesmo pagador.
+func (r *creditRepository) Apply(ctx context.Context, creditID, ownerID uuid.UUID, req *models.CreditApplyRequest) (*models.CreditApplyResponse, error) {
+	return applyCreditTx(ctx, r.db, creditID, ownerID, req, time.Now())
+}
+
+func applyCreditTx(ctx context.Context, db paymentTxBeginner, creditID, ownerID uuid.UUID, req *models.CreditApplyRequest, now time.Time) (*models.CreditApplyResponse, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	res := &models.CreditApplyResponse{}
+	err = scanCredit(tx.QueryRow(ctx, `
+		SELECT `+creditColumns+` FROM rf_credits WHERE id = $1 AND owner_id = $2
+		FOR UPDATE
+	`, creditID, ownerID), &res.Credit)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrCreditNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao travar crédito: %w", err)
+	}
+	if res.Credit.Saldo <= 0 {
+		return nil, models.ErrCreditExhausted
+	}
+
+	var valor, totalPago models.Money
+	var status string
+	var due *time.Time
+	var payerID *uuid.UUID
+	err = tx.QueryRow(ctx, `
+		SELECT valor, total_pago, status, due_date, payer_id FROM rf_incomes
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, req.IncomeID, ownerID).Scan(&valor, &totalPago, &status, &due, &payerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrIncomeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao travar receita: %w", err)
+	}
+	if res.Credit.PayerID != nil && (payerID == nil || *payerID != *res.Credit.PayerID) {
+		return nil, models.ErrCreditPayerMismatch
+	}
+	amount, err := models.CreditApplyAmount(req.Valor, res.Credit.Saldo, valor-totalPago)
+	if err != nil {
+		return nil, err
+	}
+
+	app := &res.Application
+	app.PaymentID = uuid.New()
+	obs := "Crédito " + creditID.String()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO rf_payments (id, income_id, valor, pago_em, metodo, obs, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	`, app.PaymentID, req.IncomeID, amount, now, models.CreditPaymentMethod, obs)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao adicionar pagamento do crédito: %w", err)
+	}
+
+	err = scanCredit(tx.QueryRow(ctx, `
+		UPDATE rf_credits SET saldo = saldo - $2, updated_at = NOW() WHERE id = $1
+		RETURNING `+creditColumns,
+		creditID, amount), &res.Credit)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar saldo do crédito: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_credit_applications (owner_id, credit_id, income_id, payment_id, valor)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, owner_id, credit_id, income_id, payment_id, valor, created_at
+	`, ownerID, creditID, req.IncomeID, app.PaymentID, amount).Scan(
+		&app.ID, &app.OwnerID, &app.CreditID, &app.IncomeID, &app.PaymentID, &app.Valor, &app.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar aplicação do crédito: %w", err)
+	}
+
+	income, err := recalcIncomeTotal(ctx, tx, req.IncomeID, valor, status, due, now)
+	if err != nil {
+		return nil, err
+	}
+	res.Income = *income
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar aplicação do crédito: %w", err)
+	}
+	return res, nil
+}
+
+// releasePaymentCredits desfaz os créditos ligados a um pagamento excluído ou estornado, na
+// transação de quem chama (receita e pagamento já travados).
+// Docstring: pagamento "credito" devolve o valor ao saldo do crédito de origem e remove a aplicação;
+// pagamento que gerou crédito (source_payment_id) anula o crédito ainda intacto e falha com
+// ErrCreditAlreadyApplied se parte dele já foi aplicada (o excedente já quitou outra receita).
+func releasePaymentCredits(ctx context.Context, tx pgx.Tx, ownerID uuid.UUID, p *models.Payment) error {
+	if models.IsCreditPayment(p.Metodo) {
+		_, err := tx.Exec(ctx, `
+			WITH app AS (
+				DELETE FROM rf_credit_applications WHERE payment_id = $1 AND owner_id = $2
+				RETURNING credit_id, valor
+			)
+			UPDATE rf_credits c SET saldo = c.saldo + app.valor, updated_at = NOW()
+			FROM app WHERE c.id = app.credit_id
+		`, p.ID, ownerID)
+		if err != nil {
+			return fmt.Errorf("erro ao devolver saldo do crédito: %w", err)
+		}
+	}
+
+	var creditID uuid.UUID
+	var valor, saldo models.Money
+	err := tx.QueryRow(ctx, `
+		SELECT id, valor, saldo FROM rf_credits WHERE source_payment_id = $1 AND owner_id = $2
+		FOR UPDATE
+	`, p.ID, ownerID).Scan(&creditID, &valor, &saldo)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao travar crédito do pagamento: %w", err)
+	}
+	if saldo < valor {
+		return models.ErrCreditAlreadyApplied
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM rf_credits WHERE id = $1`, creditID); err != nil {
+		return fmt.Errorf("erro ao anular crédito do pagamento: %w", err)
+	}
+	return nil
+}
+
+// recalcIncomeTotal recalcula total pago (sem estornados) e status da receita travada na transação
+func recalcIncomeTotal(ctx context.Context, tx pgx.Tx, incomeID uuid.UUID, valor models.Money, status string, due *time.Time, now time.Time) (*models.Income, error) {
+	var total models.Money
+	err := tx.QueryRow(ctx, `SELECT COALESCE(SUM(valor), 0) FROM rf_payments WHERE income_id = $1 AND reversed_at IS NULL`, incomeID).
+		Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao recalcular total pago: %w", err)
+	}
+	income := &models.Income{}
+	err = tx.QueryRow(ctx, `
+		UPDATE rf_incomes SET total_pago = $2, status = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, owner_id, contract_id, categoria, competencia, valor,
+		          status, due_date, total_pago, deleted_at, created_at, updated_at, payer_id, tags, version
+	`, incomeID, total, models.DeriveIncomeStatus(status, valor, total, due, now, locale.FromContext(ctx).Location)).Scan(
+		&income.ID, &income.OwnerID, &income.ContractID, &income.Categoria,
+		&income.Competencia, &income.Valor, &income.Status, &income.DueDate,
+		&income.TotalPago, &income.DeletedAt, &income.CreatedAt, &income.UpdatedAt, &income.PayerID, &income.Tags, &income.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar total pago: %w", err)
+	}
+	return income, nil
+}
+
+// List lista os créditos do usuário (mais recentes primeiro); onlyAvailable filtra os com saldo
+func (r *creditRepository) List(ctx context.Context, ownerID uuid.UUID, onlyAvailable bool) ([]models.Credit, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+creditColumns+` FROM rf_credits
+		WHERE owner_id = $1 AND (NOT $2 OR saldo > 0)
+		ORDER BY created_at DESC
+	`, ownerID, onlyAvailable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []models.Credit{}
+	for rows.Next() {
+		var c models.Credit
+		if err := scanCredit(rows, &c); err != nil {
+			return nil, err
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
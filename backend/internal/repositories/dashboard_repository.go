@@ -0,0 +1,113 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Série temporal do dashboard agregada no banco (generate_series e funções de janela)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// DashboardRepository agregações do dashboard
+type DashboardRepository interface {
+	// Series valores por período; today é a data local do usuário e timezone o fuso dos pagamentos
+	Series(ctx context.Context, ownerID uuid.UUID, q *models.DashboardSeriesQuery, today time.Time, timezone string) ([]models.DashboardPoint, error)
+}
+
+type dashboardRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDashboardRepository cria uma nova instância do repositório do dashboard
+func NewDashboardRepository(db *pgxpool.Pool) DashboardRepository {
+	return &dashboardRepository{db: db}
+}
+
+// dashboardSeriesSQL períodos sem movimento aparecem zerados; a data de referência da receita (e da
+// despesa) é o vencimento ou, sem ele, o primeiro dia da competência ("AAAA-MM" ou "MM/AAAA")
+const dashboardSeriesSQL = `
+	WITH periods AS (
+		SELECT gs::date AS period_start, (gs + ('1 ' || $3)::interval)::date AS period_end
+		FROM generate_series(date_trunc($3, $1::date::timestamp), date_trunc($3, $2::date::timestamp), ('1 ' || $3)::interval) gs
+	), bounds AS (
+		SELECT min(period_start) AS lo, max(period_end) AS hi FROM periods
+	), incomes AS (
+		SELECT i.valor, i.total_pago, i.status, i.due_date,
+			COALESCE(i.due_date, CASE
+				WHEN i.competencia ~ '^\d{4}-\d{2}$' THEN to_date(i.competencia, 'YYYY-MM')
+				WHEN i.competencia ~ '^\d{2}/\d{4}$' THEN to_date(i.competencia, 'MM/YYYY')
+			END) AS ref
+		FROM rf_incomes i
+		WHERE i.owner_id = $4 AND i.deleted_at IS NULL AND i.status <> 'cancelado'
+	), expected AS (
+		SELECT date_trunc($3, ref::timestamp)::date AS period_start,
+			SUM(valor) AS expected,
+			SUM(CASE WHEN due_date < $5::date AND status <> 'pago' AND total_pago < valor THEN valor - total_pago ELSE 0 END) AS overdue
+		FROM incomes, bounds
+		WHERE ref >= bounds.lo AND ref < bounds.hi
+		GROUP BY 1
+	), received AS (
+		SELECT date_trunc($3, (p.pago_em AT TIME ZONE $6)::date::timestamp)::date AS period_start, SUM(p.valor) AS received
+		FROM rf_payments p
+		JOIN rf_incomes i ON i.id = p.income_id, bounds
+		WHERE i.owner_id = $4 AND i.deleted_at IS NULL AND p.reversed_at IS NULL
+		  AND (p.pago_em AT TIME ZONE $6)::date >= bounds.lo AND (p.pago_em AT TIME ZONE $6)::date < bounds.hi
+		GROUP BY 1
+	), expenses AS (
+		SELECT x.valor, x.pago_em,
+			COALESCE(x.due_date, CASE
+				WHEN x.competencia ~ '^\d{4}-\d{2}$' THEN to_date(x.competencia, 'YYYY-MM')
+				WHEN x.competencia ~ '^\d{2}/\d{4}$' THEN to_date(x.competencia, 'MM/YYYY')
+			END) AS ref
+		FROM rf_expenses x
+		WHERE x.owner_id = $4 AND x.deleted_at IS NULL AND x.status <> 'cancelado'
+	), expenses_due AS (
+		SELECT date_trunc($3, ref::timestamp)::date AS period_start, SUM(valor) AS expenses
+		FROM expenses, bounds
+		WHERE ref >= bounds.lo AND ref < bounds.hi
+		GROUP BY 1
+	), expenses_paid AS (
+		SELECT date_trunc($3, (pago_em AT TIME ZONE $6)::date::timestamp)::date AS period_start, SUM(valor) AS paid
+		FROM expenses, bounds
+		WHERE pago_em IS NOT NULL
+		  AND (pago_em AT TIME ZONE $6)::date >= bounds.lo AND (pago_em AT TIME ZONE $6)::date < bounds.hi
+		GROUP BY 1
+	)
+	SELECT pe.period_start,
+		COALESCE(e.expected, 0), COALESCE(r.received, 0), COALESCE(e.overdue, 0),
+		SUM(COALESCE(e.expected, 0)) OVER w, SUM(COALESCE(r.received, 0)) OVER w,
+		COALESCE(xd.expenses, 0), COALESCE(xp.paid, 0),
+		SUM(COALESCE(r.received, 0) - COALESCE(xp.paid, 0)) OVER w
+	FROM periods pe
+	LEFT JOIN expected e ON e.period_start = pe.period_start
+	LEFT JOIN received r ON r.period_start = pe.period_start
+	LEFT JOIN expenses_due xd ON xd.period_start = pe.period_start
+	LEFT JOIN expenses_paid xp ON xp.period_start = pe.period_start
+	WINDOW w AS (ORDER BY pe.period_start ROWS UNBOUNDED PRECEDING)
+	ORDER BY pe.period_start
+`
+
+func (r *dashboardRepository) Series(ctx context.Context, ownerID uuid.UUID, q *models.DashboardSeriesQuery, today time.Time, timezone string) ([]models.DashboardPoint, error) {
+	rows, err := r.db.Query(ctx, dashboardSeriesSQL, q.From, q.To, q.Granularity, ownerID, today, timezone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.DashboardPoint
+	for rows.Next() {
+		var p models.DashboardPoint
+		if err := rows.Scan(&p.Start, &p.Expected, &p.Received, &p.Overdue, &p.ExpectedCumulative, &p.ReceivedCumulative,
+			&p.Expenses, &p.ExpensesPaid, &p.NetCumulative); err != nil {
+			return nil, err
+		}
+		p.Net = p.Received - p.ExpensesPaid
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
@@ -7,16 +7,78 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/config"
 )
 
+// NewPool cria o pool do Postgres com os limites de cfg (DB_MAX_CONNS, DB_STATEMENT_CACHE_SIZE…).
+// Docstring: o pool conecta sob demanda; quem precisa do banco pronto deve chamar Ping (a
+// inicialização em cmd/api faz isso com novas tentativas e espera exponencial).
+func NewPool(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
+	pc, err := poolConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return pgxpool.NewWithConfig(ctx, pc)
+}
+
+// poolConfig aplica a configuração do app sobre a DB_URL (parâmetros pool_* da URL são sobrescritos)
+func poolConfig(cfg *config.Config) (*pgxpool.Config, error) {
+	pc, err := pgxpool.ParseConfig(cfg.DBURL)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.DBMaxConns > 0 {
+		pc.MaxConns = int32(cfg.DBMaxConns)
+	}
+	pc.MinConns = int32(min(cfg.DBMinConns, int(pc.MaxConns)))
+	if cfg.DBMaxConnIdleTime > 0 {
+		pc.MaxConnIdleTime = cfg.DBMaxConnIdleTime
+	}
+	if cfg.DBMaxConnLifetime > 0 {
+		pc.MaxConnLifetime = cfg.DBMaxConnLifetime
+	}
+	if cfg.DBConnectTimeout > 0 {
+		pc.ConnConfig.ConnectTimeout = cfg.DBConnectTimeout
+	}
+	if cfg.DBStatementCacheSize > 0 {
+		pc.ConnConfig.StatementCacheCapacity = cfg.DBStatementCacheSize
+	} else {
+		// Sem prepared statements nomeados: compatível com PgBouncer/Supavisor em modo transação
+		pc.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+		pc.ConnConfig.StatementCacheCapacity = 0
+		pc.ConnConfig.DescriptionCacheCapacity = 0
+	}
+	return pc, nil
+}
+
 // WithTimeout devolve um contexto com deadline curto para operações no DB.
 func WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, d)
 }
 
+// Códigos SQLSTATE usados no mapeamento de erros
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+)
+
+// isConstraintViolation verifica se err é uma violação do tipo code na constraint informada.
+// Docstring: constraint vazia aceita qualquer constraint do código.
+func isConstraintViolation(err error, code, constraint string) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != code {
+		return false
+	}
+	return constraint == "" || pgErr.ConstraintName == constraint
+}
+
 // Tx helpers e repositórios específicos serão adicionados conforme implementação.
 
 type DB struct{ Pool *pgxpool.Pool }
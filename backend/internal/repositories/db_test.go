@@ -0,0 +1,55 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da configuração do pool do Postgres a partir das variáveis do app
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"recibofast/internal/config"
+)
+
+func TestPoolConfig_AppliesLimits(t *testing.T) {
+	cfg := &config.Config{
+		DBURL:                "postgres://u:p@localhost:5432/db?pool_max_conns=50",
+		DBMaxConns:           4,
+		DBMinConns:           8,
+		DBMaxConnIdleTime:    time.Minute,
+		DBMaxConnLifetime:    30 * time.Minute,
+		DBConnectTimeout:     2 * time.Second,
+		DBStatementCacheSize: 128,
+	}
+	pc, err := poolConfig(cfg)
+	if err != nil {
+		t.Fatalf("poolConfig: %v", err)
+	}
+	if pc.MaxConns != 4 || pc.MinConns != 4 {
+		t.Fatalf("max=%d min=%d, want 4/4 (mínimo limitado ao máximo)", pc.MaxConns, pc.MinConns)
+	}
+	if pc.MaxConnIdleTime != time.Minute || pc.MaxConnLifetime != 30*time.Minute || pc.ConnConfig.ConnectTimeout != 2*time.Second {
+		t.Fatalf("tempos inesperados: idle=%s life=%s connect=%s", pc.MaxConnIdleTime, pc.MaxConnLifetime, pc.ConnConfig.ConnectTimeout)
+	}
+	if pc.ConnConfig.StatementCacheCapacity != 128 || pc.ConnConfig.DefaultQueryExecMode != pgx.QueryExecModeCacheStatement {
+		t.Fatalf("cache=%d modo=%v", pc.ConnConfig.StatementCacheCapacity, pc.ConnConfig.DefaultQueryExecMode)
+	}
+}
+
+func TestPoolConfig_ZeroStatementCacheUsesExecMode(t *testing.T) {
+	pc, err := poolConfig(&config.Config{DBURL: "postgres://u:p@localhost:6543/db"})
+	if err != nil {
+		t.Fatalf("poolConfig: %v", err)
+	}
+	if pc.ConnConfig.DefaultQueryExecMode != pgx.QueryExecModeExec || pc.ConnConfig.StatementCacheCapacity != 0 {
+		t.Fatalf("modo=%v cache=%d, want exec sem cache", pc.ConnConfig.DefaultQueryExecMode, pc.ConnConfig.StatementCacheCapacity)
+	}
+}
+
+func TestPoolConfig_InvalidURL(t *testing.T) {
+	if _, err := poolConfig(&config.Config{DBURL: "postgres://%zz"}); err == nil {
+		t.Fatal("esperava erro para DB_URL inválida")
+	}
+}
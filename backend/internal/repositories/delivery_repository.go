@@ -0,0 +1,404 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório da fila de entregas (rf_deliveries) e saúde por destino
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// DeliveryRepository define a persistência da fila de entregas.
+// Docstring: ClaimDue usa FOR UPDATE SKIP LOCKED para permitir vários workers em paralelo;
+// entregas presas em "processing" além do lockTimeout voltam a ser elegíveis.
+type DeliveryRepository interface {
+	Enqueue(ctx context.Context, d *models.Delivery) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Delivery, error)
+	ClaimDue(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.Delivery, error)
+	MarkDelivered(ctx context.Context, id uuid.UUID, attempts int, at time.Time) error
+	MarkRetry(ctx context.Context, id uuid.UUID, attempts int, next time.Time, lastErr string) error
+	MarkDead(ctx context.Context, id uuid.UUID, attempts int, reason, lastErr string, at time.Time) error
+	ListDead(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, int, error)
+	List(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, int, error)
+	Requeue(ctx context.Context, id uuid.UUID, at time.Time) (*models.Delivery, error)
+	GetDestination(ctx context.Context, ownerID uuid.UUID, channel, destination string) (*models.DeliveryDestination, error)
+	RecordSuccess(ctx context.Context, ownerID uuid.UUID, channel, destination string, at time.Time) error
+	RecordFailure(ctx context.Context, ownerID uuid.UUID, channel, destination, lastErr string, at time.Time) (*models.DeliveryDestination, error)
+	SetDestinationDisabled(ctx context.Context, ownerID uuid.UUID, channel, destination string, disabled bool, reason string, at time.Time) error
+	ListDestinations(ctx context.Context, ownerID *uuid.UUID, onlyDisabled bool) ([]models.DeliveryDestination, error)
+	QueueDepths(ctx context.Context, now time.Time) ([]models.QueueDepth, error)
+}
+
+type deliveryRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeliveryRepository cria uma nova instância do repositório de entregas
+func NewDeliveryRepository(db *pgxpool.Pool) DeliveryRepository {
+	return &deliveryRepository{db: db}
+}
+
+const deliveryColumns = `id, owner_id, channel, destination, event_type, payload, status, attempts,
+	max_attempts, next_attempt_at, sla_deadline, last_error, last_attempt_at, delivered_at,
+	dead_at, dead_reason, created_at, updated_at`
+
+func scanDelivery(row pgx.Row, d *models.Delivery) error {
+	return row.Scan(&d.ID, &d.OwnerID, &d.Channel, &d.Destination, &d.EventType, &d.Payload,
+		&d.Status, &d.Attempts, &d.MaxAttempts, &d.NextAttemptAt, &d.SLADeadline, &d.LastError,
+		&d.LastAttemptAt, &d.DeliveredAt, &d.DeadAt, &d.DeadReason, &d.CreatedAt, &d.UpdatedAt)
+}
+
+// Enqueue insere uma nova entrega pendente
+func (r *deliveryRepository) Enqueue(ctx context.Context, d *models.Delivery) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	if len(d.Payload) == 0 {
+		d.Payload = []byte("{}")
+	}
+	query := `
+		INSERT INTO rf_deliveries (
+			id, owner_id, channel, destination, event_type, payload, status,
+			attempts, max_attempts, next_attempt_at, sla_deadline
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, 'pending', 0, $7, $8, $9
+		) RETURNING status, created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		d.ID, d.OwnerID, d.Channel, d.Destination, d.EventType, d.Payload,
+		d.MaxAttempts, d.NextAttemptAt, d.SLADeadline,
+	).Scan(&d.Status, &d.CreatedAt, &d.UpdatedAt)
+}
+
+// GetByID busca uma entrega por ID (uso administrativo, sem filtro de owner)
+func (r *deliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM rf_deliveries WHERE id = $1`
+	var d models.Delivery
+	if err := scanDelivery(r.db.QueryRow(ctx, query, id), &d); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ClaimDue reserva até limit entregas vencidas, marcando-as como "processing"
+func (r *deliveryRepository) ClaimDue(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.Delivery, error) {
+	query := `
+		UPDATE rf_deliveries
+		SET status = 'processing', locked_at = $1
+		WHERE id IN (
+			SELECT id FROM rf_deliveries
+			WHERE (status = 'pending' AND next_attempt_at <= $1)
+			   OR (status = 'processing' AND locked_at < $2)
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+	rows, err := r.db.Query(ctx, query, now, now.Add(-lockTimeout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.Delivery
+	for rows.Next() {
+		var d models.Delivery
+		if err := scanDelivery(rows, &d); err != nil {
+			return nil, err
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+// MarkDelivered registra a entrega como concluída
+func (r *deliveryRepository) MarkDelivered(ctx context.Context, id uuid.UUID, attempts int, at time.Time) error {
+	query := `
+		UPDATE rf_deliveries
+		SET status = 'delivered', attempts = $2, last_attempt_at = $3, delivered_at = $3,
+		    locked_at = NULL, last_error = NULL
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, query, id, attempts, at)
+	return err
+}
+
+// MarkRetry devolve a entrega à fila com nova data de tentativa
+func (r *deliveryRepository) MarkRetry(ctx context.Context, id uuid.UUID, attempts int, next time.Time, lastErr string) error {
+	query := `
+		UPDATE rf_deliveries
+		SET status = 'pending', attempts = $2, next_attempt_at = $3, last_error = $4,
+		    last_attempt_at = NOW(), locked_at = NULL
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, query, id, attempts, next, lastErr)
+	return err
+}
+
+// MarkDead move a entrega para dead-letter
+func (r *deliveryRepository) MarkDead(ctx context.Context, id uuid.UUID, attempts int, reason, lastErr string, at time.Time) error {
+	query := `
+		UPDATE rf_deliveries
+		SET status = 'dead', attempts = $2, dead_reason = $3, last_error = NULLIF($4, ''),
+		    dead_at = $5, last_attempt_at = COALESCE(last_attempt_at, $5), locked_at = NULL
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, query, id, attempts, reason, lastErr, at)
+	return err
+}
+
+// ListDead lista entregas em dead-letter com filtros opcionais
+func (r *deliveryRepository) ListDead(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, int, error) {
+	filter.Status = models.DeliveryStatusDead
+	return r.list(ctx, filter, "dead_at DESC")
+}
+
+// List lista entregas de qualquer status com filtros opcionais, das mais recentes às mais antigas
+func (r *deliveryRepository) List(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, int, error) {
+	return r.list(ctx, filter, "created_at DESC")
+}
+
+func (r *deliveryRepository) list(ctx context.Context, filter *models.DeliveryFilter, orderBy string) ([]models.Delivery, int, error) {
+	filter.SetDefaults()
+
+	conds := []string{"TRUE"}
+	args := []interface{}{}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conds = append(conds, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.OwnerID != nil {
+		args = append(args, *filter.OwnerID)
+		conds = append(conds, fmt.Sprintf("owner_id = $%d", len(args)))
+	}
+	if filter.Channel != "" {
+		args = append(args, filter.Channel)
+		conds = append(conds, fmt.Sprintf("channel = $%d", len(args)))
+	}
+	if filter.Destination != "" {
+		args = append(args, filter.Destination)
+		conds = append(conds, fmt.Sprintf("destination = $%d", len(args)))
+	}
+	where := strings.Join(conds, " AND ")
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM rf_deliveries WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.PerPage
+	args = append(args, filter.PerPage, offset)
+	query := fmt.Sprintf(`SELECT %s FROM rf_deliveries WHERE %s ORDER BY %s LIMIT $%d OFFSET $%d`,
+		deliveryColumns, where, orderBy, len(args)-1, len(args))
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []models.Delivery
+	for rows.Next() {
+		var d models.Delivery
+		if err := scanDelivery(rows, &d); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, d)
+	}
+	return items, total, rows.Err()
+}
+
+// Requeue devolve uma entrega em dead-letter à fila, zerando as tentativas
+func (r *deliveryRepository) Requeue(ctx context.Context, id uuid.UUID, at time.Time) (*models.Delivery, error) {
+	query := `
+		UPDATE rf_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = $2, dead_at = NULL,
+		    dead_reason = NULL, locked_at = NULL,
+		    sla_deadline = CASE WHEN sla_deadline IS NULL THEN NULL
+		                        ELSE $2 + (sla_deadline - created_at) END
+		WHERE id = $1 AND status = 'dead'
+		RETURNING ` + deliveryColumns
+	var d models.Delivery
+	if err := scanDelivery(r.db.QueryRow(ctx, query, id, at), &d); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, gerr := r.GetByID(ctx, id); gerr != nil {
+				return nil, gerr
+			}
+			return nil, models.ErrDeliveryNotDead
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+const destinationColumns = `d.owner_id, d.channel, d.destination, d.total_success, d.total_failures,
+	d.consecutive_failures, d.first_failure_at, d.last_failure_at, d.last_success_at, d.last_error,
+	d.disabled_at, d.disabled_reason`
+
+func scanDestination(row pgx.Row, m *models.DeliveryDestination) error {
+	return row.Scan(&m.OwnerID, &m.Channel, &m.Destination, &m.TotalSuccess, &m.TotalFailures,
+		&m.ConsecutiveFailures, &m.FirstFailureAt, &m.LastFailureAt, &m.LastSuccessAt, &m.LastError,
+		&m.DisabledAt, &m.DisabledReason, &m.DeadCount)
+}
+
+// GetDestination busca a saúde de um destino; retorna ErrDestinationNotFound se nunca usado
+func (r *deliveryRepository) GetDestination(ctx context.Context, ownerID uuid.UUID, channel, destination string) (*models.DeliveryDestination, error) {
+	query := `SELECT ` + destinationColumns + `, 0
+		FROM rf_delivery_destinations d
+		WHERE d.owner_id = $1 AND d.channel = $2 AND d.destination = $3`
+	var m models.DeliveryDestination
+	if err := scanDestination(r.db.QueryRow(ctx, query, ownerID, channel, destination), &m); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrDestinationNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// RecordSuccess contabiliza sucesso e encerra a sequência de falhas do destino
+func (r *deliveryRepository) RecordSuccess(ctx context.Context, ownerID uuid.UUID, channel, destination string, at time.Time) error {
+	query := `
+		INSERT INTO rf_delivery_destinations (owner_id, channel, destination, total_success, last_success_at)
+		VALUES ($1, $2, $3, 1, $4)
+		ON CONFLICT (owner_id, channel, destination) DO UPDATE
+		SET total_success = rf_delivery_destinations.total_success + 1,
+		    consecutive_failures = 0, first_failure_at = NULL, last_success_at = $4
+	`
+	_, err := r.db.Exec(ctx, query, ownerID, channel, destination, at)
+	return err
+}
+
+// RecordFailure contabiliza falha e devolve o estado atualizado do destino
+func (r *deliveryRepository) RecordFailure(ctx context.Context, ownerID uuid.UUID, channel, destination, lastErr string, at time.Time) (*models.DeliveryDestination, error) {
+	query := `
+		INSERT INTO rf_delivery_destinations (
+			owner_id, channel, destination, total_failures, consecutive_failures,
+			first_failure_at, last_failure_at, last_error
+		) VALUES ($1, $2, $3, 1, 1, $4, $4, $5)
+		ON CONFLICT (owner_id, channel, destination) DO UPDATE
+		SET total_failures = rf_delivery_destinations.total_failures + 1,
+		    consecutive_failures = rf_delivery_destinations.consecutive_failures + 1,
+		    first_failure_at = COALESCE(rf_delivery_destinations.first_failure_at, $4),
+		    last_failure_at = $4, last_error = $5
+		RETURNING owner_id, channel, destination, total_success, total_failures,
+		          consecutive_failures, first_failure_at, last_failure_at, last_success_at,
+		          last_error, disabled_at, disabled_reason, 0
+	`
+	var m models.DeliveryDestination
+	if err := scanDestination(r.db.QueryRow(ctx, query, ownerID, channel, destination, at, lastErr), &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// SetDestinationDisabled desabilita ou reabilita um destino
+func (r *deliveryRepository) SetDestinationDisabled(ctx context.Context, ownerID uuid.UUID, channel, destination string, disabled bool, reason string, at time.Time) error {
+	var query string
+	var args []interface{}
+	if disabled {
+		query = `
+			INSERT INTO rf_delivery_destinations (owner_id, channel, destination, disabled_at, disabled_reason)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (owner_id, channel, destination) DO UPDATE
+			SET disabled_at = $4, disabled_reason = $5
+		`
+		args = []interface{}{ownerID, channel, destination, at, reason}
+	} else {
+		query = `
+			UPDATE rf_delivery_destinations
+			SET disabled_at = NULL, disabled_reason = NULL, consecutive_failures = 0, first_failure_at = NULL
+			WHERE owner_id = $1 AND channel = $2 AND destination = $3
+		`
+		args = []interface{}{ownerID, channel, destination}
+	}
+	cmd, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if !disabled && cmd.RowsAffected() == 0 {
+		return models.ErrDestinationNotFound
+	}
+	return nil
+}
+
+// ListDestinations lista estatísticas por destino, incluindo contagem de dead-letters
+func (r *deliveryRepository) ListDestinations(ctx context.Context, ownerID *uuid.UUID, onlyDisabled bool) ([]models.DeliveryDestination, error) {
+	conds := []string{"TRUE"}
+	args := []interface{}{}
+	if ownerID != nil {
+		args = append(args, *ownerID)
+		conds = append(conds, fmt.Sprintf("d.owner_id = $%d", len(args)))
+	}
+	if onlyDisabled {
+		conds = append(conds, "d.disabled_at IS NOT NULL")
+	}
+	query := `
+		SELECT ` + destinationColumns + `,
+		       (SELECT COUNT(*) FROM rf_deliveries x
+		        WHERE x.owner_id = d.owner_id AND x.channel = d.channel
+		          AND x.destination = d.destination AND x.status = 'dead')
+		FROM rf_delivery_destinations d
+		WHERE ` + strings.Join(conds, " AND ") + `
+		ORDER BY d.consecutive_failures DESC, d.last_failure_at DESC NULLS LAST
+		LIMIT 500
+	`
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.DeliveryDestination
+	for rows.Next() {
+		var m models.DeliveryDestination
+		if err := scanDestination(rows, &m); err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+	return items, rows.Err()
+}
+
+// QueueDepths conta as entregas não concluídas por canal (pendentes, vencidas, em processamento e dead-letter)
+func (r *deliveryRepository) QueueDepths(ctx context.Context, now time.Time) ([]models.QueueDepth, error) {
+	query := `
+		SELECT channel,
+		       COUNT(*) FILTER (WHERE status = 'pending'),
+		       COUNT(*) FILTER (WHERE status = 'pending' AND next_attempt_at <= $1),
+		       COUNT(*) FILTER (WHERE status = 'processing'),
+		       COUNT(*) FILTER (WHERE status = 'dead'),
+		       MIN(next_attempt_at) FILTER (WHERE status = 'pending' AND next_attempt_at <= $1)
+		FROM rf_deliveries
+		WHERE status IN ('pending', 'processing', 'dead')
+		GROUP BY channel
+		ORDER BY channel
+	`
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.QueueDepth{}
+	for rows.Next() {
+		q := models.QueueDepth{Queue: "deliveries"}
+		if err := rows.Scan(&q.Channel, &q.Pending, &q.Due, &q.Processing, &q.Dead, &q.OldestDueAt); err != nil {
+			return nil, err
+		}
+		items = append(items, q)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,318 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de despesas (rf_expenses), anexos fiscais e estatísticas por competência
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// ExpenseRepository define as operações de despesas.
+// Docstring: espelha as receitas (exclusão lógica, filtros e ordenação por whitelist); a
+// competência é comparada nas duas formas gravadas ("2026-10" e "10/2026").
+type ExpenseRepository interface {
+	Create(ctx context.Context, e *models.Expense) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Expense, error)
+	Update(ctx context.Context, e *models.Expense) error
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+	List(ctx context.Context, ownerID uuid.UUID, filter *models.ExpenseFilter) ([]models.Expense, int, error)
+	Stats(ctx context.Context, ownerID uuid.UUID, month, today time.Time) (*models.ExpenseStats, error)
+	AddAttachment(ctx context.Context, a *models.ExpenseAttachment) error
+	ListAttachments(ctx context.Context, expenseID, ownerID uuid.UUID) ([]models.ExpenseAttachment, error)
+	GetAttachment(ctx context.Context, id, expenseID, ownerID uuid.UUID) (*models.ExpenseAttachment, error)
+	DeleteAttachment(ctx context.Context, id, expenseID, ownerID uuid.UUID) error
+}
+
+type expenseRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewExpenseRepository cria uma nova instância do repositório de despesas
+func NewExpenseRepository(db *pgxpool.Pool) ExpenseRepository {
+	return &expenseRepository{db: db}
+}
+
+const expenseColumns = `e.id, e.owner_id, e.descricao, e.fornecedor, e.categoria, e.tags, e.competencia, e.valor, e.status,
+	e.due_date, e.pago_em, e.metodo, e.obs,
+	(SELECT COUNT(*) FROM rf_expense_attachments a WHERE a.expense_id = e.id)::int,
+	e.deleted_at, e.created_at, e.updated_at`
+
+func scanExpense(row pgx.Row, e *models.Expense) error {
+	return row.Scan(&e.ID, &e.OwnerID, &e.Descricao, &e.Fornecedor, &e.Categoria, &e.Tags, &e.Competencia,
+		&e.Valor, &e.Status, &e.DueDate, &e.PagoEm, &e.Metodo, &e.Obs, &e.Anexos, &e.DeletedAt, &e.CreatedAt, &e.UpdatedAt)
+}
+
+// Create grava a despesa
+func (r *expenseRepository) Create(ctx context.Context, e *models.Expense) error {
+	if e.Tags == nil {
+		e.Tags = []string{}
+	}
+	return r.db.QueryRow(ctx, `
+		INSERT INTO rf_expenses (owner_id, descricao, fornecedor, categoria, tags, competencia, valor, status,
+			due_date, pago_em, metodo, obs)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, created_at, updated_at
+	`, e.OwnerID, e.Descricao, e.Fornecedor, e.Categoria, e.Tags, e.Competencia, e.Valor, e.Status,
+		e.DueDate, e.PagoEm, e.Metodo, e.Obs).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+}
+
+// GetByID busca a despesa (não excluída) do usuário
+func (r *expenseRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Expense, error) {
+	var e models.Expense
+	err := scanExpense(r.db.QueryRow(ctx, `SELECT `+expenseColumns+`
+		FROM rf_expenses e WHERE e.id = $1 AND e.owner_id = $2 AND e.deleted_at IS NULL`, id, ownerID), &e)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrExpenseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Update grava todos os campos editáveis da despesa
+func (r *expenseRepository) Update(ctx context.Context, e *models.Expense) error {
+	if e.Tags == nil {
+		e.Tags = []string{}
+	}
+	err := r.db.QueryRow(ctx, `
+		UPDATE rf_expenses SET descricao = $3, fornecedor = $4, categoria = $5, tags = $6, competencia = $7,
+			valor = $8, status = $9, due_date = $10, pago_em = $11, metodo = $12, obs = $13
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		RETURNING updated_at
+	`, e.ID, e.OwnerID, e.Descricao, e.Fornecedor, e.Categoria, e.Tags, e.Competencia, e.Valor, e.Status,
+		e.DueDate, e.PagoEm, e.Metodo, e.Obs).Scan(&e.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrExpenseNotFound
+	}
+	return err
+}
+
+// Delete exclui a despesa logicamente; os anexos continuam até a exclusão definitiva da conta
+func (r *expenseRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `UPDATE rf_expenses SET deleted_at = NOW() WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL`,
+		id, ownerID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrExpenseNotFound
+	}
+	return nil
+}
+
+// expenseSortColumns whitelist de ordenação da listagem
+var expenseSortColumns = map[string]string{
+	"created_at":  "e.created_at",
+	"updated_at":  "e.updated_at",
+	"due_date":    "e.due_date",
+	"pago_em":     "e.pago_em",
+	"competencia": "e.competencia",
+	"valor":       "e.valor",
+	"status":      "e.status",
+	"categoria":   "e.categoria",
+	"descricao":   "e.descricao",
+	"fornecedor":  "e.fornecedor",
+}
+
+// buildExpenseListWhere monta o WHERE da listagem com argumentos posicionais ($1 = owner_id)
+func buildExpenseListWhere(ownerID uuid.UUID, f *models.ExpenseFilter) (string, []interface{}) {
+	conds := []string{"e.owner_id = $1", "e.deleted_at IS NULL"}
+	args := []interface{}{ownerID}
+	add := func(cond string, v interface{}) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.Search != "" {
+		add("(e.descricao ILIKE $%[1]d OR e.fornecedor ILIKE $%[1]d OR e.categoria ILIKE $%[1]d OR array_to_string(e.tags, ' ') ILIKE $%[1]d)",
+			"%"+escapeLike(f.Search)+"%")
+	}
+	switch f.Status {
+	case "":
+	case models.StatusVencido:
+		add("e.status = 'pendente' AND e.due_date < $%d::date", f.Today)
+	case models.StatusPendente:
+		add("e.status = 'pendente' AND (e.due_date IS NULL OR e.due_date >= $%d::date)", f.Today)
+	default:
+		add("e.status = $%d", f.Status)
+	}
+	if f.Categoria != "" {
+		add("e.categoria = $%d", f.Categoria)
+	}
+	if f.Competencia != "" {
+		if month, err := models.ParseReportCompetencia(f.Competencia); err == nil {
+			add("e.competencia = ANY($%d)", models.CompetenciaForms(month))
+		} else {
+			add("e.competencia = $%d", f.Competencia)
+		}
+	}
+	if f.Tag != "" {
+		add("$%d = ANY(e.tags)", f.Tag)
+	}
+	if f.DueDateFrom != nil {
+		add("e.due_date >= $%d::date", *f.DueDateFrom)
+	}
+	if f.DueDateTo != nil {
+		add("e.due_date <= $%d::date", *f.DueDateTo)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// expenseOrderBy retorna a cláusula ORDER BY a partir da whitelist; id desempata a paginação
+func expenseOrderBy(f *models.ExpenseFilter) string {
+	col, ok := expenseSortColumns[f.SortField]
+	if !ok {
+		col = "e.created_at"
+	}
+	dir := "DESC"
+	if f.SortOrder == "asc" {
+		dir = "ASC"
+	}
+	return col + " " + dir + " NULLS LAST, e.id " + dir
+}
+
+// List busca despesas com filtros, ordenação e paginação
+func (r *expenseRepository) List(ctx context.Context, ownerID uuid.UUID, filter *models.ExpenseFilter) ([]models.Expense, int, error) {
+	filter.SetDefaults()
+	where, args := buildExpenseListWhere(ownerID, filter)
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM rf_expenses e WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.PerPage
+	query := fmt.Sprintf(`SELECT %s FROM rf_expenses e WHERE %s ORDER BY %s LIMIT $%d OFFSET $%d`,
+		expenseColumns, where, expenseOrderBy(filter), len(args)+1, len(args)+2)
+	rows, err := r.db.Query(ctx, query, append(args, filter.PerPage, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	expenses := []models.Expense{}
+	for rows.Next() {
+		var e models.Expense
+		if err := scanExpense(rows, &e); err != nil {
+			return nil, 0, err
+		}
+		expenses = append(expenses, e)
+	}
+	return expenses, total, rows.Err()
+}
+
+// Stats soma as despesas da competência por situação e por categoria (canceladas só contadas)
+func (r *expenseRepository) Stats(ctx context.Context, ownerID uuid.UUID, month, today time.Time) (*models.ExpenseStats, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT NULLIF(btrim(categoria), ''),
+		       COUNT(*) FILTER (WHERE status <> 'cancelado')::int,
+		       COALESCE(SUM(valor) FILTER (WHERE status <> 'cancelado'), 0),
+		       COALESCE(SUM(valor) FILTER (WHERE status = 'pago'), 0),
+		       COALESCE(SUM(valor) FILTER (WHERE status = 'pendente'), 0),
+		       COALESCE(SUM(valor) FILTER (WHERE status = 'pendente' AND due_date < $3::date), 0),
+		       COUNT(*) FILTER (WHERE status = 'cancelado')::int
+		FROM rf_expenses
+		WHERE owner_id = $1 AND deleted_at IS NULL AND competencia = ANY($2)
+		GROUP BY 1
+		ORDER BY 3 DESC, 1 NULLS LAST
+	`, ownerID, models.CompetenciaForms(month), today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	st := &models.ExpenseStats{Competencia: month.Format("2006-01"), PorCategoria: []models.ExpenseCategoryTotal{}}
+	for rows.Next() {
+		var c models.ExpenseCategoryTotal
+		var emAberto, vencido models.Money
+		var canceladas int
+		if err := rows.Scan(&c.Categoria, &c.Quantidade, &c.Total, &c.Pago, &emAberto, &vencido, &canceladas); err != nil {
+			return nil, err
+		}
+		st.Quantidade += c.Quantidade
+		st.Total += c.Total
+		st.Pago += c.Pago
+		st.EmAberto += emAberto
+		st.Vencido += vencido
+		st.Canceladas += canceladas
+		if c.Quantidade > 0 {
+			st.PorCategoria = append(st.PorCategoria, c)
+		}
+	}
+	return st, rows.Err()
+}
+
+const expenseAttachmentColumns = `id, owner_id, expense_id, tipo, file_name, file_path, content_type, size_bytes, sha256, created_at`
+
+func scanExpenseAttachment(row pgx.Row, a *models.ExpenseAttachment) error {
+	return row.Scan(&a.ID, &a.OwnerID, &a.ExpenseID, &a.Tipo, &a.FileName, &a.FilePath, &a.ContentType,
+		&a.SizeBytes, &a.SHA256, &a.CreatedAt)
+}
+
+// AddAttachment grava o anexo (o ID vem do serviço, que já o usou no caminho do arquivo)
+func (r *expenseRepository) AddAttachment(ctx context.Context, a *models.ExpenseAttachment) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO rf_expense_attachments (id, owner_id, expense_id, tipo, file_name, file_path, content_type, size_bytes, sha256)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at
+	`, a.ID, a.OwnerID, a.ExpenseID, a.Tipo, a.FileName, a.FilePath, a.ContentType, a.SizeBytes, a.SHA256).Scan(&a.CreatedAt)
+}
+
+// ListAttachments lista os anexos da despesa em ordem de envio
+func (r *expenseRepository) ListAttachments(ctx context.Context, expenseID, ownerID uuid.UUID) ([]models.ExpenseAttachment, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+expenseAttachmentColumns+`
+		FROM rf_expense_attachments WHERE expense_id = $1 AND owner_id = $2 ORDER BY created_at, id`, expenseID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.ExpenseAttachment{}
+	for rows.Next() {
+		var a models.ExpenseAttachment
+		if err := scanExpenseAttachment(rows, &a); err != nil {
+			return nil, err
+		}
+		items = append(items, a)
+	}
+	return items, rows.Err()
+}
+
+// GetAttachment busca um anexo da despesa
+func (r *expenseRepository) GetAttachment(ctx context.Context, id, expenseID, ownerID uuid.UUID) (*models.ExpenseAttachment, error) {
+	var a models.ExpenseAttachment
+	err := scanExpenseAttachment(r.db.QueryRow(ctx, `SELECT `+expenseAttachmentColumns+`
+		FROM rf_expense_attachments WHERE id = $1 AND expense_id = $2 AND owner_id = $3`, id, expenseID, ownerID), &a)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrExpenseAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// DeleteAttachment remove o registro do anexo (o arquivo é apagado pelo serviço)
+func (r *expenseRepository) DeleteAttachment(ctx context.Context, id, expenseID, ownerID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rf_expense_attachments WHERE id = $1 AND expense_id = $2 AND owner_id = $3`,
+		id, expenseID, ownerID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrExpenseAttachmentNotFound
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório dos feriados municipais do usuário (rf_holidays)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// HolidayRepository feriados cadastrados pelo usuário
+type HolidayRepository interface {
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.Holiday, error)
+	Create(ctx context.Context, h *models.Holiday) error
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+}
+
+type holidayRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewHolidayRepository cria uma nova instância do repositório de feriados
+func NewHolidayRepository(db *pgxpool.Pool) HolidayRepository {
+	return &holidayRepository{db: db}
+}
+
+const holidayColumns = `id, owner_id, data, nome, anual, created_at`
+
+func scanHoliday(row pgx.Row, h *models.Holiday) error {
+	if err := row.Scan(&h.ID, &h.OwnerID, &h.Data, &h.Nome, &h.Anual, &h.CreatedAt); err != nil {
+		return err
+	}
+	h.Tipo = models.HolidayMunicipal
+	return nil
+}
+
+// List lista os feriados cadastrados pelo usuário em ordem de data
+func (r *holidayRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.Holiday, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+holidayColumns+` FROM rf_holidays WHERE owner_id = $1 ORDER BY data, nome`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.Holiday{}
+	for rows.Next() {
+		var h models.Holiday
+		if err := scanHoliday(rows, &h); err != nil {
+			return nil, err
+		}
+		items = append(items, h)
+	}
+	return items, rows.Err()
+}
+
+// Create cadastra um feriado e preenche ID e created_at
+func (r *holidayRepository) Create(ctx context.Context, h *models.Holiday) error {
+	row := r.db.QueryRow(ctx, `
+		INSERT INTO rf_holidays (owner_id, data, nome, anual) VALUES ($1, $2, $3, $4)
+		RETURNING `+holidayColumns, h.OwnerID, h.Data, h.Nome, h.Anual)
+	return scanHoliday(row, h)
+}
+
+// Delete remove um feriado do usuário
+func (r *holidayRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rf_holidays WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrHolidayNotFound
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório das chaves de idempotência (rf_idempotency_keys)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// IdempotencyRepository define operações de persistência das chaves de idempotência.
+// Docstring: Reserve grava a chave antes de executar a requisição; se ela já existir (e não
+// tiver expirado) devolve o registro existente em vez de reservar. Release apaga a reserva de
+// uma requisição que falhou, para que a repetição execute de novo.
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, rec *models.IdempotencyRecord, ttl time.Duration) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, rec *models.IdempotencyRecord) error
+	Release(ctx context.Context, ownerID uuid.UUID, key string) error
+	DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewIdempotencyRepository cria uma nova instância do repositório de chaves de idempotência
+func NewIdempotencyRepository(db *pgxpool.Pool) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Reserve reserva a chave; retorna (nil, nil) se reservou ou o registro existente
+func (r *idempotencyRepository) Reserve(ctx context.Context, rec *models.IdempotencyRecord, ttl time.Duration) (*models.IdempotencyRecord, error) {
+	// Chave expirada (ou reserva abandonada por um processo interrompido) pode ser reutilizada
+	if _, err := r.db.Exec(ctx, `
+		DELETE FROM rf_idempotency_keys
+		WHERE owner_id = $1 AND key = $2
+		  AND (created_at < now() - make_interval(secs => $3)
+		       OR (status_code IS NULL AND created_at < now() - make_interval(secs => $4)))
+	`, rec.OwnerID, rec.Key, ttl.Seconds(), models.IdempotencyPendingTimeout.Seconds()); err != nil {
+		return nil, err
+	}
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO rf_idempotency_keys (owner_id, key, method, path, request_hash)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (owner_id, key) DO NOTHING
+		RETURNING created_at
+	`, rec.OwnerID, rec.Key, rec.Method, rec.Path, rec.RequestHash).Scan(&rec.CreatedAt)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	var existing models.IdempotencyRecord
+	var status *int
+	var headers []byte
+	err = r.db.QueryRow(ctx, `
+		SELECT owner_id, key, method, path, request_hash, status_code, response_headers, response_body, created_at, completed_at
+		FROM rf_idempotency_keys WHERE owner_id = $1 AND key = $2
+	`, rec.OwnerID, rec.Key).Scan(&existing.OwnerID, &existing.Key, &existing.Method, &existing.Path, &existing.RequestHash,
+		&status, &headers, &existing.ResponseBody, &existing.CreatedAt, &existing.CompletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Liberada entre o INSERT e o SELECT: o cliente pode repetir
+		return nil, models.ErrIdempotencyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status != nil {
+		existing.StatusCode = *status
+	}
+	if headers != nil {
+		if err := json.Unmarshal(headers, &existing.ResponseHeaders); err != nil {
+			return nil, err
+		}
+	}
+	return &existing, nil
+}
+
+// Complete grava a resposta da requisição original
+func (r *idempotencyRepository) Complete(ctx context.Context, rec *models.IdempotencyRecord) error {
+	headers, err := json.Marshal(rec.ResponseHeaders)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(ctx, `
+		UPDATE rf_idempotency_keys
+		SET status_code = $3, response_headers = $4, response_body = $5, completed_at = now()
+		WHERE owner_id = $1 AND key = $2
+	`, rec.OwnerID, rec.Key, rec.StatusCode, headers, rec.ResponseBody)
+	return err
+}
+
+// Release apaga a reserva ainda não concluída
+func (r *idempotencyRepository) Release(ctx context.Context, ownerID uuid.UUID, key string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM rf_idempotency_keys WHERE owner_id = $1 AND key = $2 AND status_code IS NULL`, ownerID, key)
+	return err
+}
+
+// DeleteExpired remove as chaves mais antigas que ttl; retorna quantas foram removidas
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rf_idempotency_keys WHERE created_at < now() - make_interval(secs => $1)`, ttl.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
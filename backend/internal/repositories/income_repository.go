@@ -7,22 +7,34 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/locale"
 	"recibofast/internal/models"
 )
 
 // IncomeRepository interface para operações de receitas
 type IncomeRepository interface {
-	Create(income *models.Income) error
-	GetByID(id, ownerID uuid.UUID) (*models.Income, error)
-	Update(income *models.Income) error
-	Delete(id, ownerID uuid.UUID) error
-	List(ownerID uuid.UUID, filter *models.IncomeFilter) ([]models.Income, int, error)
-	AddPayment(payment *models.Payment) error
-	GetPayments(incomeID, ownerID uuid.UUID) ([]models.Payment, error)
-	UpdateTotalPago(incomeID uuid.UUID) error
+	Create(ctx context.Context, income *models.Income) error
+	CreateMany(ctx context.Context, incomes []*models.Income) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Income, error)
+	Update(ctx context.Context, income *models.Income) error
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+	List(ctx context.Context, ownerID uuid.UUID, filter *models.IncomeFilter) ([]models.Income, int, error)
+	AddPayment(ctx context.Context, payment *models.Payment) error
+	AddPaymentTx(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, error)
+	UpdatePaymentTx(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, error)
+	GetPayments(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.Payment, error)
+	UpdateTotalPago(ctx context.Context, incomeID uuid.UUID) error
+	GetFeePolicy(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.FeePolicy, error)
+	MarkOverdue(ctx context.Context, now time.Time, defaultTimezone string) (int64, error)
+	Stats(ctx context.Context, ownerID uuid.UUID) (*models.IncomeStats, error)
 }
 
 // incomeRepository implementa a interface IncomeRepository
@@ -36,38 +48,86 @@ func NewIncomeRepository(db *pgxpool.Pool) IncomeRepository {
 }
 
 // Create cria uma nova receita
-func (r *incomeRepository) Create(income *models.Income) error {
+func (r *incomeRepository) Create(ctx context.Context, income *models.Income) error {
 	query := `
 		INSERT INTO rf_incomes (
 			id, owner_id, contract_id, categoria, competencia, valor,
-			status, due_date, created_at, updated_at
+			status, due_date, payer_id, tags, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW()
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW()
 		)
+		RETURNING version
 	`
 
-	_, err := r.db.Exec(context.Background(), query,
+	err := r.db.QueryRow(ctx, query,
 		income.ID, income.OwnerID, income.ContractID, income.Categoria,
-		income.Competencia, income.Valor, income.Status, income.DueDate,
-	)
+		income.Competencia, income.Valor, income.Status, income.DueDate, income.PayerID,
+		tagsOrEmpty(income.Tags),
+	).Scan(&income.Version)
+
+	return mapIncomeError(err)
+}
+
+// CreateMany cria várias receitas em uma única transação (tudo ou nada)
+func (r *incomeRepository) CreateMany(ctx context.Context, incomes []*models.Income) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO rf_incomes (
+			id, owner_id, contract_id, categoria, competencia, valor,
+			status, due_date, payer_id, tags, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW()
+		)
+		RETURNING version
+	`
+	for _, income := range incomes {
+		err := tx.QueryRow(ctx, query,
+			income.ID, income.OwnerID, income.ContractID, income.Categoria,
+			income.Competencia, income.Valor, income.Status, income.DueDate, income.PayerID,
+			tagsOrEmpty(income.Tags),
+		).Scan(&income.Version)
+		if err != nil {
+			return mapIncomeError(err)
+		}
+	}
+	return tx.Commit(ctx)
+}
 
+// mapIncomeError traduz a violação da FK composta de pagador em erro de domínio
+func mapIncomeError(err error) error {
+	if isConstraintViolation(err, pgForeignKeyViolation, "fk_incomes_payer") {
+		return models.ErrPayerNotFound
+	}
 	return err
 }
 
+// tagsOrEmpty evita gravar NULL na coluna tags (NOT NULL DEFAULT '{}')
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
 // GetByID busca uma receita por ID
-func (r *incomeRepository) GetByID(id, userID uuid.UUID) (*models.Income, error) {
+func (r *incomeRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Income, error) {
 	query := `
 		SELECT id, owner_id, contract_id, categoria, competencia, valor,
-		       status, due_date, total_pago, deleted_at, created_at, updated_at
+		       status, due_date, total_pago, deleted_at, created_at, updated_at, payer_id, tags, version
 		FROM rf_incomes 
 		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
 	`
 
 	income := &models.Income{}
-	err := r.db.QueryRow(context.Background(), query, id, userID).Scan(
+	err := r.db.QueryRow(ctx, query, id, userID).Scan(
 		&income.ID, &income.OwnerID, &income.ContractID, &income.Categoria,
 		&income.Competencia, &income.Valor, &income.Status, &income.DueDate,
-		&income.TotalPago, &income.DeletedAt, &income.CreatedAt, &income.UpdatedAt,
+		&income.TotalPago, &income.DeletedAt, &income.CreatedAt, &income.UpdatedAt, &income.PayerID, &income.Tags, &income.Version,
 	)
 	if err != nil {
 		if err.Error() == "no rows in result set" {
@@ -79,39 +139,47 @@ func (r *incomeRepository) GetByID(id, userID uuid.UUID) (*models.Income, error)
 	return income, nil
 }
 
-// Update atualiza uma receita existente
-func (r *incomeRepository) Update(income *models.Income) error {
+// Update atualiza uma receita existente.
+// Docstring: grava somente se a versão no banco ainda for income.Version (a versão lida); caso
+// contrário retorna IncomeVersionConflictError com o estado atual. A versão nova (incrementada
+// pelo trigger da migração 025) volta em income.Version.
+func (r *incomeRepository) Update(ctx context.Context, income *models.Income) error {
 	query := `
 		UPDATE rf_incomes 
 		SET contract_id = $3, categoria = $4, competencia = $5, valor = $6, 
-		    status = $7, due_date = $8, updated_at = NOW()
-		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		    status = $7, due_date = $8, payer_id = $9, tags = $10, updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL AND version = $11
+		RETURNING version, updated_at
 	`
 
-	result, err := r.db.Exec(context.Background(), query,
+	err := r.db.QueryRow(ctx, query,
 		income.ID, income.OwnerID, income.ContractID, income.Categoria,
-		income.Competencia, income.Valor, income.Status, income.DueDate,
-	)
-	if err != nil {
-		return err
+		income.Competencia, income.Valor, income.Status, income.DueDate, income.PayerID,
+		tagsOrEmpty(income.Tags), income.Version,
+	).Scan(&income.Version, &income.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		current, gerr := r.GetByID(ctx, income.ID, income.OwnerID)
+		if gerr != nil {
+			return gerr
+		}
+		return &models.IncomeVersionConflictError{Current: current}
 	}
-
-	if result.RowsAffected() == 0 {
-		return models.ErrIncomeNotFound
+	if err != nil {
+		return mapIncomeError(err)
 	}
 
 	return nil
 }
 
 // Delete marca uma receita como deletada (soft delete)
-func (r *incomeRepository) Delete(id, userID uuid.UUID) error {
+func (r *incomeRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
 	query := `
 		UPDATE rf_incomes 
 		SET deleted_at = NOW(), updated_at = NOW()
 		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
 	`
 
-	result, err := r.db.Exec(context.Background(), query, id, userID)
+	result, err := r.db.Exec(ctx, query, id, userID)
 	if err != nil {
 		return err
 	}
@@ -124,34 +192,32 @@ func (r *incomeRepository) Delete(id, userID uuid.UUID) error {
 }
 
 // List busca receitas com filtros, ordenação e paginação
-func (r *incomeRepository) List(ownerID uuid.UUID, filter *models.IncomeFilter) ([]models.Income, int, error) {
+func (r *incomeRepository) List(ctx context.Context, ownerID uuid.UUID, filter *models.IncomeFilter) ([]models.Income, int, error) {
 	filter.SetDefaults()
 
+	where, args := buildIncomeListWhere(ownerID, filter)
+
 	// Contar total de registros
-	countQuery := `
-		SELECT COUNT(*) 
-		FROM rf_incomes 
-		WHERE owner_id = $1 AND deleted_at IS NULL
-	`
+	countQuery := `SELECT COUNT(*) FROM rf_incomes WHERE ` + where
 
 	var total int
-	err := r.db.QueryRow(context.Background(), countQuery, ownerID).Scan(&total)
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Buscar dados com paginação
-	query := `
+	offset := (filter.Page - 1) * filter.PerPage
+	query := fmt.Sprintf(`
 		SELECT id, owner_id, contract_id, categoria, competencia, valor,
-		       status, due_date, total_pago, deleted_at, created_at, updated_at
+		       status, due_date, total_pago, deleted_at, created_at, updated_at, payer_id, tags, version
 		FROM rf_incomes 
-		WHERE owner_id = $1 AND deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, where, incomeOrderBy(filter), len(args)+1, len(args)+2)
 
-	offset := (filter.Page - 1) * filter.PerPage
-	rows, err := r.db.Query(context.Background(), query, ownerID, filter.PerPage, offset)
+	rows, err := r.db.Query(ctx, query, append(args, filter.PerPage, offset)...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -163,7 +229,7 @@ func (r *incomeRepository) List(ownerID uuid.UUID, filter *models.IncomeFilter)
 		err := rows.Scan(
 			&income.ID, &income.OwnerID, &income.ContractID, &income.Categoria,
 			&income.Competencia, &income.Valor, &income.Status, &income.DueDate,
-			&income.TotalPago, &income.DeletedAt, &income.CreatedAt, &income.UpdatedAt,
+			&income.TotalPago, &income.DeletedAt, &income.CreatedAt, &income.UpdatedAt, &income.PayerID, &income.Tags, &income.Version,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -171,38 +237,286 @@ func (r *incomeRepository) List(ownerID uuid.UUID, filter *models.IncomeFilter)
 		incomes = append(incomes, income)
 	}
 
-	return incomes, total, nil
+	return incomes, total, rows.Err()
+}
+
+// incomeSortColumns colunas aceitas em sort_field (evita injeção via ORDER BY)
+var incomeSortColumns = map[string]string{
+	"created_at":  "created_at",
+	"updated_at":  "updated_at",
+	"due_date":    "due_date",
+	"competencia": "competencia",
+	"valor":       "valor",
+	"total_pago":  "total_pago",
+	"status":      "status",
+	"categoria":   "categoria",
+}
+
+// buildIncomeListWhere monta o WHERE da listagem com argumentos posicionais ($1 = owner_id)
+func buildIncomeListWhere(ownerID uuid.UUID, f *models.IncomeFilter) (string, []interface{}) {
+	conds := []string{"owner_id = $1", "deleted_at IS NULL"}
+	args := []interface{}{ownerID}
+	add := func(cond string, v interface{}) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.Search != "" {
+		add("(competencia ILIKE $%[1]d OR categoria ILIKE $%[1]d OR array_to_string(tags, ' ') ILIKE $%[1]d)",
+			"%"+escapeLike(f.Search)+"%")
+	}
+	if f.Status != "" {
+		add("status = $%d", f.Status)
+	}
+	if f.Categoria != "" {
+		add("categoria = $%d", f.Categoria)
+	}
+	if f.Competencia != "" {
+		add("competencia = $%d", f.Competencia)
+	}
+	if f.ContractID != nil {
+		add("contract_id = $%d", *f.ContractID)
+	}
+	if f.PayerID != nil {
+		add("payer_id = $%d", *f.PayerID)
+	}
+	if f.Tag != "" {
+		add("$%d = ANY(tags)", f.Tag)
+	}
+	if f.DueDateFrom != nil {
+		add("due_date >= $%d::date", *f.DueDateFrom)
+	}
+	if f.DueDateTo != nil {
+		add("due_date <= $%d::date", *f.DueDateTo)
+	}
+	if f.ValorMin != nil {
+		add("valor >= $%d", *f.ValorMin)
+	}
+	if f.ValorMax != nil {
+		add("valor <= $%d", *f.ValorMax)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// incomeOrderBy retorna a cláusula ORDER BY a partir da whitelist; id desempata a paginação
+func incomeOrderBy(f *models.IncomeFilter) string {
+	col, ok := incomeSortColumns[f.SortField]
+	if !ok {
+		col = "created_at"
+	}
+	dir := "DESC"
+	if f.SortOrder == "asc" {
+		dir = "ASC"
+	}
+	return col + " " + dir + " NULLS LAST, id " + dir
+}
+
+// escapeLike escapa curingas do LIKE/ILIKE no termo de busca
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 // AddPayment adiciona um pagamento a uma receita
-func (r *incomeRepository) AddPayment(payment *models.Payment) error {
+func (r *incomeRepository) AddPayment(ctx context.Context, payment *models.Payment) error {
 	query := `
 		INSERT INTO rf_payments (
-			id, income_id, valor, pago_em, metodo, obs, created_at
+			id, income_id, valor, pago_em, metodo, method_id, obs, created_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, NOW()
+			$1, $2, $3, $4, $5, $6, $7, NOW()
 		)
 	`
 
-	_, err := r.db.Exec(context.Background(), query,
+	_, err := r.db.Exec(ctx, query,
 		payment.ID, payment.IncomeID, payment.Valor, payment.PagoEm,
-		payment.Metodo, payment.Obs,
+		payment.Metodo, payment.MethodID, payment.Obs,
 	)
 
 	return err
 }
 
+// paymentTxBeginner origem das transações de AddPaymentTx (o pool; substituível nos testes)
+type paymentTxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// AddPaymentTx registra o pagamento e recalcula total pago e status em uma única transação.
+// Docstring: a receita é travada (FOR UPDATE) e o saldo devedor conferido dentro da transação,
+// então pagamentos simultâneos não ultrapassam o valor; qualquer falha desfaz tudo. Retorna a
+// receita atualizada (com a nova versão). O pagamento que quita a receita agenda, na mesma
+// transação, o recibo automático (rf_auto_receipts) quando o dono ligou a opção.
+func (r *incomeRepository) AddPaymentTx(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, error) {
+	return addPaymentTx(ctx, r.db, payment, ownerID, time.Now())
+}
+
+func addPaymentTx(ctx context.Context, db paymentTxBeginner, payment *models.Payment, ownerID uuid.UUID, now time.Time) (*models.Income, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var valor, totalPago models.Money
+	var status string
+	var due *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT valor, total_pago, status, due_date FROM rf_incomes
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, payment.IncomeID, ownerID).Scan(&valor, &totalPago, &status, &due)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrIncomeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao travar receita: %w", err)
+	}
+	if payment.Valor > valor-totalPago {
+		return nil, models.ErrInsufficientAmount
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_payments (id, income_id, valor, pago_em, metodo, method_id, obs, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at
+	`, payment.ID, payment.IncomeID, payment.Valor, payment.PagoEm, payment.Metodo, payment.MethodID, payment.Obs).Scan(&payment.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao adicionar pagamento: %w", err)
+	}
+
+	var total models.Money
+	err = tx.QueryRow(ctx, `SELECT COALESCE(SUM(valor), 0) FROM rf_payments WHERE income_id = $1 AND reversed_at IS NULL`, payment.IncomeID).
+		Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao recalcular total pago: %w", err)
+	}
+
+	income := &models.Income{}
+	err = tx.QueryRow(ctx, `
+		UPDATE rf_incomes SET total_pago = $2, status = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, owner_id, contract_id, categoria, competencia, valor,
+		          status, due_date, total_pago, deleted_at, created_at, updated_at, payer_id, tags, version
+	`, payment.IncomeID, total, models.DeriveIncomeStatus(status, valor, total, due, now, locale.FromContext(ctx).Location)).Scan(
+		&income.ID, &income.OwnerID, &income.ContractID, &income.Categoria,
+		&income.Competencia, &income.Valor, &income.Status, &income.DueDate,
+		&income.TotalPago, &income.DeletedAt, &income.CreatedAt, &income.UpdatedAt, &income.PayerID, &income.Tags, &income.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar total pago: %w", err)
+	}
+	if models.IncomeSettled(income) {
+		if err := enqueueAutoReceipt(ctx, tx, ownerID, payment.IncomeID, payment.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar pagamento: %w", err)
+	}
+	return income, nil
+}
+
+// UpdatePaymentTx corrige valor, data, método e observação de um pagamento em uma transação.
+// Docstring: receita e pagamento são travados; o novo valor é conferido contra o saldo sem o
+// valor antigo, e total pago e status são recalculados. Pagamentos estornados não são editáveis, e
+// nos pagamentos com crédito valor e método ficam fixos (ErrCreditPaymentLocked).
+// payment traz ID e os novos campos; IncomeID e CreatedAt são preenchidos.
+func (r *incomeRepository) UpdatePaymentTx(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, error) {
+	return updatePaymentTx(ctx, r.db, payment, ownerID, time.Now())
+}
+
+func updatePaymentTx(ctx context.Context, db paymentTxBeginner, payment *models.Payment, ownerID uuid.UUID, now time.Time) (*models.Income, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var valor, totalPago models.Money
+	var status string
+	var due *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT id, valor, total_pago, status, due_date FROM rf_incomes
+		WHERE id = (SELECT income_id FROM rf_payments WHERE id = $1) AND owner_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, payment.ID, ownerID).Scan(&payment.IncomeID, &valor, &totalPago, &status, &due)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao travar receita: %w", err)
+	}
+
+	var old models.Money
+	var oldMetodo *string
+	var reversedAt *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT valor, metodo, reversed_at, created_at FROM rf_payments WHERE id = $1 AND income_id = $2
+		FOR UPDATE
+	`, payment.ID, payment.IncomeID).Scan(&old, &oldMetodo, &reversedAt, &payment.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao travar pagamento: %w", err)
+	}
+	if reversedAt != nil {
+		return nil, models.ErrPaymentAlreadyReversed
+	}
+	// O valor de um pagamento com crédito espelha a aplicação (rf_credit_applications)
+	if wasCredit := models.IsCreditPayment(oldMetodo); wasCredit != models.IsCreditPayment(payment.Metodo) ||
+		(wasCredit && payment.Valor != old) {
+		return nil, models.ErrCreditPaymentLocked
+	}
+	if payment.Valor > valor-(totalPago-old) {
+		return nil, models.ErrInsufficientAmount
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE rf_payments SET valor = $2, pago_em = $3, metodo = $4, method_id = $5, obs = $6 WHERE id = $1
+	`, payment.ID, payment.Valor, payment.PagoEm, payment.Metodo, payment.MethodID, payment.Obs)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar pagamento: %w", err)
+	}
+
+	var total models.Money
+	err = tx.QueryRow(ctx, `SELECT COALESCE(SUM(valor), 0) FROM rf_payments WHERE income_id = $1 AND reversed_at IS NULL`, payment.IncomeID).
+		Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao recalcular total pago: %w", err)
+	}
+
+	income := &models.Income{}
+	err = tx.QueryRow(ctx, `
+		UPDATE rf_incomes SET total_pago = $2, status = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, owner_id, contract_id, categoria, competencia, valor,
+		          status, due_date, total_pago, deleted_at, created_at, updated_at, payer_id, tags, version
+	`, payment.IncomeID, total, models.DeriveIncomeStatus(status, valor, total, due, now, locale.FromContext(ctx).Location)).Scan(
+		&income.ID, &income.OwnerID, &income.ContractID, &income.Categoria,
+		&income.Competencia, &income.Valor, &income.Status, &income.DueDate,
+		&income.TotalPago, &income.DeletedAt, &income.CreatedAt, &income.UpdatedAt, &income.PayerID, &income.Tags, &income.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar total pago: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar correção do pagamento: %w", err)
+	}
+	return income, nil
+}
+
 // GetPayments busca todos os pagamentos de uma receita
-func (r *incomeRepository) GetPayments(incomeID, ownerID uuid.UUID) ([]models.Payment, error) {
+func (r *incomeRepository) GetPayments(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.Payment, error) {
 	query := `
-		SELECT p.id, p.income_id, p.valor, p.pago_em, p.metodo, p.obs, p.created_at
+		SELECT p.id, p.income_id, p.valor, p.pago_em, p.metodo, p.method_id, p.obs, p.created_at, p.reversed_at, p.reversal_reason
 		FROM rf_payments p
 		INNER JOIN rf_incomes i ON p.income_id = i.id
 		WHERE p.income_id = $1 AND i.owner_id = $2
 		ORDER BY p.pago_em DESC
 	`
 
-	rows, err := r.db.Query(context.Background(), query, incomeID, ownerID)
+	rows, err := r.db.Query(ctx, query, incomeID, ownerID)
 	if err != nil {
 		return nil, err
 	}
@@ -213,7 +527,7 @@ func (r *incomeRepository) GetPayments(incomeID, ownerID uuid.UUID) ([]models.Pa
 		payment := models.Payment{}
 		err := rows.Scan(
 			&payment.ID, &payment.IncomeID, &payment.Valor, &payment.PagoEm,
-			&payment.Metodo, &payment.Obs, &payment.CreatedAt,
+			&payment.Metodo, &payment.MethodID, &payment.Obs, &payment.CreatedAt, &payment.ReversedAt, &payment.ReversalReason,
 		)
 		if err != nil {
 			return nil, err
@@ -229,17 +543,90 @@ func (r *incomeRepository) GetPayments(incomeID, ownerID uuid.UUID) ([]models.Pa
 }
 
 // UpdateTotalPago atualiza o total pago de uma receita
-func (r *incomeRepository) UpdateTotalPago(incomeID uuid.UUID) error {
+func (r *incomeRepository) UpdateTotalPago(ctx context.Context, incomeID uuid.UUID) error {
 	query := `
 		UPDATE rf_incomes 
 		SET total_pago = (
 			SELECT COALESCE(SUM(valor), 0) 
 			FROM rf_payments 
-			WHERE income_id = $1
+			WHERE income_id = $1 AND reversed_at IS NULL
 		), updated_at = NOW()
 		WHERE id = $1
 	`
 
-	_, err := r.db.Exec(context.Background(), query, incomeID)
+	_, err := r.db.Exec(ctx, query, incomeID)
 	return err
-}
\ No newline at end of file
+}
+
+// GetFeePolicy retorna os encargos por atraso da receita: regras do contrato, depois as do usuário
+// (rf_settings) e, para campos nulos nos dois, o padrão do produto
+func (r *incomeRepository) GetFeePolicy(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.FeePolicy, error) {
+	query := `
+		SELECT c.multa_percent, c.juros_mes_percent, c.juros_dia_percent, c.carencia_dias,
+		       s.multa_percent, s.juros_mes_percent, s.juros_dia_percent, s.carencia_dias
+		FROM rf_incomes i
+		LEFT JOIN rf_contracts c ON c.id = i.contract_id AND c.owner_id = i.owner_id
+		LEFT JOIN rf_settings s ON s.owner_id = i.owner_id
+		WHERE i.id = $1 AND i.owner_id = $2 AND i.deleted_at IS NULL
+	`
+	var contract, user models.FeeRules
+	err := r.db.QueryRow(ctx, query, incomeID, ownerID).Scan(
+		&contract.MultaPercent, &contract.JurosMesPercent, &contract.JurosDiaPercent, &contract.CarenciaDias,
+		&user.MultaPercent, &user.JurosMesPercent, &user.JurosDiaPercent, &user.CarenciaDias)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrIncomeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := models.ResolveFeePolicy(&contract, &user)
+	return &p, nil
+}
+
+// MarkOverdue marca como vencidas, em lote, as receitas pendentes cujo vencimento já passou.
+// Docstring: due_date é uma data; o dia civil de now é o do fuso do usuário (perfil ou rf_settings; fuso ausente ou inválido usa
+// defaultTimezone). Versão e updated_at avançam, para que o sync e o If-Match vejam a mudança.
+func (r *incomeRepository) MarkOverdue(ctx context.Context, now time.Time, defaultTimezone string) (int64, error) {
+	query := `
+		UPDATE rf_incomes i
+		SET status = $3, version = i.version + 1, updated_at = NOW()
+		FROM (
+			SELECT p.id, COALESCE(tz.name, $2) AS tz
+			FROM rf_incomes p
+			LEFT JOIN rf_settings s ON s.owner_id = p.owner_id
+			LEFT JOIN rf_profiles pf ON pf.id = p.owner_id
+			LEFT JOIN pg_timezone_names tz ON tz.name = COALESCE(pf.timezone, s.timezone)
+			WHERE p.status = $4 AND p.deleted_at IS NULL AND p.due_date IS NOT NULL AND p.total_pago = 0
+		) d
+		WHERE i.id = d.id AND i.status = $4
+		  AND i.due_date < ($1::timestamptz AT TIME ZONE d.tz)::date
+	`
+	tag, err := r.db.Exec(ctx, query, now, defaultTimezone, models.StatusVencido, models.StatusPendente)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Stats totais e saldos das receitas do usuário por status gravado (o job diário marca as vencidas)
+func (r *incomeRepository) Stats(ctx context.Context, ownerID uuid.UUID) (*models.IncomeStats, error) {
+	st := &models.IncomeStats{}
+	err := r.db.QueryRow(ctx, `
+		SELECT count(*), COALESCE(sum(valor), 0),
+		       count(*) FILTER (WHERE status IN ($2, $3)),
+		       count(*) FILTER (WHERE status = $4),
+		       count(*) FILTER (WHERE status = $5),
+		       COALESCE(sum(valor - total_pago) FILTER (WHERE status IN ($2, $3)), 0),
+		       COALESCE(sum(total_pago), 0),
+		       COALESCE(sum(valor - total_pago) FILTER (WHERE status = $5), 0)
+		FROM rf_incomes
+		WHERE owner_id = $1 AND deleted_at IS NULL
+	`, ownerID, models.StatusPendente, models.StatusParcial, models.StatusPago, models.StatusVencido).Scan(
+		&st.TotalReceitas, &st.TotalValor, &st.ReceitasPendentes, &st.ReceitasPagas, &st.ReceitasVencidas,
+		&st.ValorPendente, &st.ValorPago, &st.ValorVencido,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return st, nil
+}
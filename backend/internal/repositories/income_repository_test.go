@@ -0,0 +1,279 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da montagem dinâmica do filtro e ordenação da listagem de receitas
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"recibofast/internal/models"
+)
+
+func TestBuildIncomeListWhere_ParameterizesFilters(t *testing.T) {
+	owner := uuid.New()
+	minV := models.NewMoney(100)
+	f := &models.IncomeFilter{Search: "50%_off", Status: "pendente", ValorMin: &minV}
+
+	where, args := buildIncomeListWhere(owner, f)
+
+	if len(args) != 4 || args[0] != owner {
+		t.Fatalf("args = %v, want owner + 3 filtros", args)
+	}
+	if args[1] != `%50\%\_off%` {
+		t.Fatalf("busca deveria escapar curingas, got %v", args[1])
+	}
+	for _, want := range []string{"owner_id = $1", "deleted_at IS NULL", "ILIKE $2", "status = $3", "valor >= $4"} {
+		if !strings.Contains(where, want) {
+			t.Fatalf("WHERE sem %q: %s", want, where)
+		}
+	}
+	if strings.Contains(where, "pendente") || strings.Contains(where, "off") {
+		t.Fatalf("valores não devem ser interpolados no SQL: %s", where)
+	}
+}
+
+func TestIncomeOrderBy_Whitelist(t *testing.T) {
+	cases := []struct {
+		field, order, want string
+	}{
+		{"valor", "asc", "valor ASC NULLS LAST, id ASC"},
+		{"due_date", "desc", "due_date DESC NULLS LAST, id DESC"},
+		{"valor; DROP TABLE rf_incomes", "asc", "created_at ASC NULLS LAST, id ASC"},
+		{"", "", "created_at DESC NULLS LAST, id DESC"},
+	}
+	for _, tc := range cases {
+		f := &models.IncomeFilter{SortField: tc.field, SortOrder: tc.order}
+		f.SetDefaults()
+		if got := incomeOrderBy(f); got != tc.want {
+			t.Fatalf("incomeOrderBy(%q, %q) = %q, want %q", tc.field, tc.order, got, tc.want)
+		}
+	}
+}
+
+// fakePaymentTx simula a transação de AddPaymentTx: cada QueryRow consome um passo
+// (trava da receita, inserção do pagamento, soma dos pagamentos, atualização da receita); failAt
+// faz o passo falhar. O status gravado fica em status; com settled o Exec do recibo automático é aceito.
+type fakePaymentTx struct {
+	pgx.Tx
+	valor, totalPago models.Money
+	paid             models.Money
+	status           string
+	failAt           int
+	commitErr        error
+	settled          bool
+	execs            []string
+	steps            int
+	committed        bool
+	rolledBack       bool
+}
+
+func (f *fakePaymentTx) Begin(ctx context.Context) (pgx.Tx, error) { return f, nil }
+
+func (f *fakePaymentTx) Commit(ctx context.Context) error {
+	if f.commitErr != nil {
+		return f.commitErr
+	}
+	f.committed = true
+	return nil
+}
+
+func (f *fakePaymentTx) Rollback(ctx context.Context) error {
+	if f.committed {
+		return pgx.ErrTxClosed
+	}
+	f.rolledBack = true
+	return nil
+}
+
+func (f *fakePaymentTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if !f.settled {
+		return pgconn.CommandTag{}, errors.New("Exec não esperado")
+	}
+	f.execs = append(f.execs, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakePaymentTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	f.steps++
+	switch f.steps {
+	case 2:
+		f.paid = args[2].(models.Money)
+	case 4:
+		f.status = args[2].(string)
+	}
+	return fakePaymentRow{tx: f, step: f.steps}
+}
+
+type fakePaymentRow struct {
+	tx   *fakePaymentTx
+	step int
+}
+
+func (r fakePaymentRow) Scan(dest ...any) error {
+	if r.step == r.tx.failAt {
+		return errors.New("conexão perdida")
+	}
+	switch r.step {
+	case 1:
+		*dest[0].(*models.Money), *dest[1].(*models.Money) = r.tx.valor, r.tx.totalPago
+		*dest[2].(*string) = models.StatusPendente
+	case 3:
+		*dest[0].(*models.Money) = r.tx.totalPago + r.tx.paid
+	case 4:
+		*dest[5].(*models.Money), *dest[6].(*string) = r.tx.valor, r.tx.status
+		*dest[8].(*models.Money) = r.tx.totalPago + r.tx.paid
+	}
+	return nil
+}
+
+func TestAddPaymentTx_CommitsAllSteps(t *testing.T) {
+	tx := &fakePaymentTx{valor: models.NewMoney(200), totalPago: models.NewMoney(50)}
+	payment := &models.Payment{ID: uuid.New(), IncomeID: uuid.New(), Valor: models.NewMoney(100)}
+
+	income, err := addPaymentTx(context.Background(), tx, payment, uuid.New(), time.Now())
+	if err != nil {
+		t.Fatalf("addPaymentTx: %v", err)
+	}
+	if tx.steps != 4 || !tx.committed || tx.rolledBack {
+		t.Fatalf("passos = %d, commit = %v, rollback = %v; esperado 4 passos confirmados", tx.steps, tx.committed, tx.rolledBack)
+	}
+	if tx.status != models.StatusParcial || income.Status != models.StatusParcial {
+		t.Fatalf("status gravado = %q, devolvido = %q; esperado parcial", tx.status, income.Status)
+	}
+}
+
+func TestAddPaymentTx_FullPaymentPersistsPaidStatus(t *testing.T) {
+	tx := &fakePaymentTx{valor: models.NewMoney(200), totalPago: models.NewMoney(50), settled: true}
+	payment := &models.Payment{ID: uuid.New(), IncomeID: uuid.New(), Valor: models.NewMoney(150)}
+
+	income, err := addPaymentTx(context.Background(), tx, payment, uuid.New(), time.Now())
+	if err != nil {
+		t.Fatalf("addPaymentTx: %v", err)
+	}
+	if tx.status != models.StatusPago || income.Status != models.StatusPago {
+		t.Fatalf("status gravado = %q, devolvido = %q; esperado pago", tx.status, income.Status)
+	}
+}
+
+func TestAddPaymentTx_SchedulesAutoReceiptWhenSettled(t *testing.T) {
+	tx := &fakePaymentTx{valor: models.NewMoney(200), totalPago: models.NewMoney(50), settled: true}
+	payment := &models.Payment{ID: uuid.New(), IncomeID: uuid.New(), Valor: models.NewMoney(150)}
+
+	income, err := addPaymentTx(context.Background(), tx, payment, uuid.New(), time.Now())
+	if err != nil {
+		t.Fatalf("addPaymentTx: %v", err)
+	}
+	if !models.IncomeSettled(income) || len(tx.execs) != 1 || !strings.Contains(tx.execs[0], "rf_auto_receipts") {
+		t.Fatalf("pedido de recibo automático = %v", tx.execs)
+	}
+	if !tx.committed {
+		t.Fatalf("pedido deveria ser confirmado com o pagamento")
+	}
+}
+
+func TestAddPaymentTx_RollsBackOnFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		tx        *fakePaymentTx
+		valor     models.Money
+		wantErr   error
+		wantSteps int
+	}{
+		{"saldo excedido", &fakePaymentTx{valor: models.NewMoney(100), totalPago: models.NewMoney(80)}, models.NewMoney(30), models.ErrInsufficientAmount, 1},
+		{"falha na inserção", &fakePaymentTx{valor: models.NewMoney(100), failAt: 2}, models.NewMoney(30), nil, 2},
+		{"falha no recálculo do total", &fakePaymentTx{valor: models.NewMoney(100), failAt: 3}, models.NewMoney(30), nil, 3},
+		{"falha na atualização da receita", &fakePaymentTx{valor: models.NewMoney(100), failAt: 4}, models.NewMoney(30), nil, 4},
+		{"falha no commit", &fakePaymentTx{valor: models.NewMoney(100), commitErr: errors.New("serialização")}, models.NewMoney(30), nil, 4},
+	}
+	for _, c := range cases {
+		payment := &models.Payment{ID: uuid.New(), IncomeID: uuid.New(), Valor: c.valor}
+		income, err := addPaymentTx(context.Background(), c.tx, payment, uuid.New(), time.Now())
+		if err == nil || income != nil {
+			t.Fatalf("%s: esperava erro, got income=%v", c.name, income)
+		}
+		if c.wantErr != nil && !errors.Is(err, c.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", c.name, err, c.wantErr)
+		}
+		if c.tx.steps != c.wantSteps || c.tx.committed || !c.tx.rolledBack {
+			t.Fatalf("%s: passos = %d, commit = %v, rollback = %v", c.name, c.tx.steps, c.tx.committed, c.tx.rolledBack)
+		}
+	}
+}
+
+// fakeUpdatePaymentTx simula updatePaymentTx: trava da receita, trava do pagamento (valor e
+// método atuais), recálculo do total e atualização da receita; os Exec são a correção do pagamento.
+type fakeUpdatePaymentTx struct {
+	fakePaymentTx
+	old    models.Money
+	metodo *string
+}
+
+func (f *fakeUpdatePaymentTx) Begin(ctx context.Context) (pgx.Tx, error) { return f, nil }
+
+func (f *fakeUpdatePaymentTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.execs = append(f.execs, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeUpdatePaymentTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	f.steps++
+	return fakeUpdatePaymentRow{tx: f, step: f.steps}
+}
+
+type fakeUpdatePaymentRow struct {
+	tx   *fakeUpdatePaymentTx
+	step int
+}
+
+func (r fakeUpdatePaymentRow) Scan(dest ...any) error {
+	switch r.step {
+	case 1:
+		*dest[1].(*models.Money), *dest[2].(*models.Money) = r.tx.valor, r.tx.totalPago
+		*dest[3].(*string) = models.StatusParcial
+	case 2:
+		*dest[0].(*models.Money), *dest[1].(**string) = r.tx.old, r.tx.metodo
+	}
+	return nil
+}
+
+func TestUpdatePaymentTx_CreditPaymentAmountIsLocked(t *testing.T) {
+	credito, pix := models.CreditPaymentMethod, "pix"
+	cases := []struct {
+		name    string
+		old     *string
+		metodo  *string
+		valor   models.Money
+		wantErr error
+	}{
+		{"valor de pagamento com crédito", &credito, &credito, models.NewMoney(20), models.ErrCreditPaymentLocked},
+		{"crédito vira outro método", &credito, &pix, models.NewMoney(30), models.ErrCreditPaymentLocked},
+		{"outro método vira crédito", &pix, &credito, models.NewMoney(30), models.ErrCreditPaymentLocked},
+		{"crédito sem método", &credito, nil, models.NewMoney(30), models.ErrCreditPaymentLocked},
+		{"data e observação do crédito", &credito, &credito, models.NewMoney(30), nil},
+		{"valor de pagamento comum", &pix, &pix, models.NewMoney(20), nil},
+	}
+	for _, c := range cases {
+		tx := &fakeUpdatePaymentTx{fakePaymentTx: fakePaymentTx{valor: models.NewMoney(100), totalPago: models.NewMoney(30)},
+			old: models.NewMoney(30), metodo: c.old}
+		payment := &models.Payment{ID: uuid.New(), Valor: c.valor, Metodo: c.metodo}
+		_, err := updatePaymentTx(context.Background(), tx, payment, uuid.New(), time.Now())
+		if !errors.Is(err, c.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", c.name, err, c.wantErr)
+		}
+		if c.wantErr != nil && (len(tx.execs) != 0 || tx.committed || !tx.rolledBack) {
+			t.Fatalf("%s: execs = %v, commit = %v, rollback = %v", c.name, tx.execs, tx.committed, tx.rolledBack)
+		}
+		if c.wantErr == nil && (len(tx.execs) != 1 || !tx.committed) {
+			t.Fatalf("%s: execs = %v, commit = %v", c.name, tx.execs, tx.committed)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório dos modelos de receita (rf_income_templates)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// IncomeTemplateRepository define operações de persistência dos modelos de receita
+type IncomeTemplateRepository interface {
+	Create(ctx context.Context, t *models.IncomeTemplate) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.IncomeTemplate, error)
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.IncomeTemplate, error)
+	Update(ctx context.Context, t *models.IncomeTemplate) error
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+}
+
+type incomeTemplateRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewIncomeTemplateRepository cria uma nova instância do repositório de modelos
+func NewIncomeTemplateRepository(db *pgxpool.Pool) IncomeTemplateRepository {
+	return &incomeTemplateRepository{db: db}
+}
+
+const incomeTemplateColumns = `id, owner_id, nome, payer_id, contract_id, categoria, tags, valor, due_day, created_at, updated_at`
+
+func scanIncomeTemplate(row pgx.Row, m *models.IncomeTemplate) error {
+	return row.Scan(&m.ID, &m.OwnerID, &m.Nome, &m.PayerID, &m.ContractID, &m.Categoria, &m.Tags,
+		&m.Valor, &m.DueDay, &m.CreatedAt, &m.UpdatedAt)
+}
+
+// Create insere um novo modelo
+func (r *incomeTemplateRepository) Create(ctx context.Context, m *models.IncomeTemplate) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	if m.Tags == nil {
+		m.Tags = []string{}
+	}
+	query := `
+		INSERT INTO rf_income_templates (id, owner_id, nome, payer_id, contract_id, categoria, tags, valor, due_day)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, m.ID, m.OwnerID, m.Nome, m.PayerID, m.ContractID, m.Categoria, m.Tags, m.Valor, m.DueDay).
+		Scan(&m.CreatedAt, &m.UpdatedAt)
+	return mapIncomeTemplateError(err)
+}
+
+// GetByID busca um modelo do usuário
+func (r *incomeTemplateRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.IncomeTemplate, error) {
+	query := `SELECT ` + incomeTemplateColumns + ` FROM rf_income_templates WHERE id = $1 AND owner_id = $2`
+	var m models.IncomeTemplate
+	if err := scanIncomeTemplate(r.db.QueryRow(ctx, query, id, ownerID), &m); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// List retorna os modelos do usuário em ordem alfabética
+func (r *incomeTemplateRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.IncomeTemplate, error) {
+	query := `SELECT ` + incomeTemplateColumns + ` FROM rf_income_templates WHERE owner_id = $1 ORDER BY nome, created_at`
+	rows, err := r.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.IncomeTemplate{}
+	for rows.Next() {
+		var m models.IncomeTemplate
+		if err := scanIncomeTemplate(rows, &m); err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+	return items, rows.Err()
+}
+
+// Update atualiza um modelo existente
+func (r *incomeTemplateRepository) Update(ctx context.Context, m *models.IncomeTemplate) error {
+	if m.Tags == nil {
+		m.Tags = []string{}
+	}
+	query := `
+		UPDATE rf_income_templates
+		SET nome = $3, payer_id = $4, contract_id = $5, categoria = $6, tags = $7, valor = $8, due_day = $9
+		WHERE id = $1 AND owner_id = $2
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, m.ID, m.OwnerID, m.Nome, m.PayerID, m.ContractID, m.Categoria, m.Tags, m.Valor, m.DueDay).
+		Scan(&m.CreatedAt, &m.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrTemplateNotFound
+	}
+	return mapIncomeTemplateError(err)
+}
+
+// Delete remove um modelo
+func (r *incomeTemplateRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM rf_income_templates WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrTemplateNotFound
+	}
+	return nil
+}
+
+// mapIncomeTemplateError traduz pagador inexistente em erro de domínio
+func mapIncomeTemplateError(err error) error {
+	if isConstraintViolation(err, pgForeignKeyViolation, "rf_income_templates_payer_id_fkey") {
+		return models.ErrPayerNotFound
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório das NFS-e das receitas (rf_invoices) e da numeração dos RPS
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// InvoiceRepository define a persistência das notas fiscais.
+// Docstring: NextRPSNumber reserva o número do RPS em rf_settings (a linha existe desde que o
+// prestador foi configurado); Create devolve ErrInvoiceExists se a receita já tem nota.
+type InvoiceRepository interface {
+	NextRPSNumber(ctx context.Context, ownerID uuid.UUID) (int64, error)
+	Create(ctx context.Context, inv *models.Invoice) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Invoice, error)
+	GetByIncome(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.Invoice, error)
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.Invoice, error)
+	UpdateResult(ctx context.Context, inv *models.Invoice) error
+}
+
+type invoiceRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewInvoiceRepository cria uma nova instância do repositório de notas fiscais
+func NewInvoiceRepository(db *pgxpool.Pool) InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+const invoiceColumns = `id, owner_id, income_id, provider, rps_serie, rps_numero, valor, status, xml, numero,
+	codigo_verificacao, protocolo, erro, created_at, updated_at`
+
+func scanInvoice(row pgx.Row, inv *models.Invoice) error {
+	return row.Scan(&inv.ID, &inv.OwnerID, &inv.IncomeID, &inv.Provider, &inv.RPSSerie, &inv.RPSNumero, &inv.Valor,
+		&inv.Status, &inv.XML, &inv.Numero, &inv.CodigoVerificacao, &inv.Protocolo, &inv.Erro, &inv.CreatedAt, &inv.UpdatedAt)
+}
+
+// NextRPSNumber reserva e devolve o próximo número de RPS do usuário
+func (r *invoiceRepository) NextRPSNumber(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	var n int64
+	err := r.db.QueryRow(ctx, `
+		UPDATE rf_settings SET nfse_proximo_rps = nfse_proximo_rps + 1
+		WHERE owner_id = $1 RETURNING nfse_proximo_rps - 1`, ownerID).Scan(&n)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, models.ErrInvoiceIssuerIncomplete
+	}
+	return n, err
+}
+
+// Create grava a nota com o XML do RPS
+func (r *invoiceRepository) Create(ctx context.Context, inv *models.Invoice) error {
+	err := scanInvoice(r.db.QueryRow(ctx, `
+		INSERT INTO rf_invoices (owner_id, income_id, provider, rps_serie, rps_numero, valor, status, xml)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+invoiceColumns,
+		inv.OwnerID, inv.IncomeID, inv.Provider, inv.RPSSerie, inv.RPSNumero, inv.Valor, inv.Status, inv.XML), inv)
+	if isConstraintViolation(err, pgUniqueViolation, "uq_invoices_income") {
+		return models.ErrInvoiceExists
+	}
+	return err
+}
+
+// GetByID nota do usuário
+func (r *invoiceRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Invoice, error) {
+	return r.getOne(ctx, `id = $1 AND owner_id = $2`, id, ownerID)
+}
+
+// GetByIncome nota da receita
+func (r *invoiceRepository) GetByIncome(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.Invoice, error) {
+	return r.getOne(ctx, `income_id = $1 AND owner_id = $2`, incomeID, ownerID)
+}
+
+func (r *invoiceRepository) getOne(ctx context.Context, where string, args ...interface{}) (*models.Invoice, error) {
+	var inv models.Invoice
+	err := scanInvoice(r.db.QueryRow(ctx, `SELECT `+invoiceColumns+` FROM rf_invoices WHERE `+where, args...), &inv)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrInvoiceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// List notas do usuário, das mais recentes para as mais antigas (até 500)
+func (r *invoiceRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.Invoice, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+invoiceColumns+` FROM rf_invoices
+		WHERE owner_id = $1 ORDER BY created_at DESC LIMIT 500`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.Invoice
+	for rows.Next() {
+		var inv models.Invoice
+		if err := scanInvoice(rows, &inv); err != nil {
+			return nil, err
+		}
+		out = append(out, inv)
+	}
+	return out, rows.Err()
+}
+
+// UpdateResult grava o retorno da prefeitura (status, número da NFS-e, protocolo ou erro)
+func (r *invoiceRepository) UpdateResult(ctx context.Context, inv *models.Invoice) error {
+	return r.db.QueryRow(ctx, `
+		UPDATE rf_invoices SET status = $2, numero = $3, codigo_verificacao = $4, protocolo = $5, erro = $6, updated_at = now()
+		WHERE id = $1 RETURNING updated_at`,
+		inv.ID, inv.Status, inv.Numero, inv.CodigoVerificacao, inv.Protocolo, inv.Erro).Scan(&inv.UpdatedAt)
+}
@@ -0,0 +1,403 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de receitas em memória para o modo mock (APP_MODE=mock) e testes
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/models"
+)
+
+// memoryIncomeRepository implementa IncomeRepository em memória.
+// Docstring: aplica os mesmos filtros, ordenação e paginação do repositório Postgres para que
+// o frontend veja um comportamento realista sem banco. Os dados se perdem ao reiniciar.
+type memoryIncomeRepository struct {
+	mu       sync.RWMutex
+	incomes  map[uuid.UUID]*models.Income
+	payments map[uuid.UUID][]models.Payment
+}
+
+// NewMemoryIncomeRepository cria um repositório de receitas em memória
+func NewMemoryIncomeRepository() IncomeRepository {
+	return &memoryIncomeRepository{
+		incomes:  map[uuid.UUID]*models.Income{},
+		payments: map[uuid.UUID][]models.Payment{},
+	}
+}
+
+// Create armazena uma nova receita
+func (r *memoryIncomeRepository) Create(ctx context.Context, income *models.Income) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now().UTC()
+	income.CreatedAt, income.UpdatedAt = &now, &now
+	income.Tags = tagsOrEmpty(income.Tags)
+	income.Version = 1
+	cp := *income
+	r.incomes[income.ID] = &cp
+	return nil
+}
+
+// CreateMany armazena várias receitas
+func (r *memoryIncomeRepository) CreateMany(ctx context.Context, incomes []*models.Income) error {
+	for _, in := range incomes {
+		if err := r.Create(ctx, in); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByID busca uma receita ativa do usuário
+func (r *memoryIncomeRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Income, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	in, ok := r.incomes[id]
+	if !ok || in.OwnerID != ownerID || in.DeletedAt != nil {
+		return nil, models.ErrIncomeNotFound
+	}
+	cp := *in
+	return &cp, nil
+}
+
+// Update substitui os campos editáveis da receita se a versão ainda for income.Version
+func (r *memoryIncomeRepository) Update(ctx context.Context, income *models.Income) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.incomes[income.ID]
+	if !ok || cur.OwnerID != income.OwnerID || cur.DeletedAt != nil {
+		return models.ErrIncomeNotFound
+	}
+	if cur.Version != income.Version {
+		current := *cur
+		return &models.IncomeVersionConflictError{Current: &current}
+	}
+	income.Version++
+	now := time.Now().UTC()
+	income.UpdatedAt = &now
+	income.TotalPago = cur.TotalPago
+	income.CreatedAt = cur.CreatedAt
+	cp := *income
+	r.incomes[income.ID] = &cp
+	return nil
+}
+
+// Delete marca a receita como removida (soft delete)
+func (r *memoryIncomeRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	in, ok := r.incomes[id]
+	if !ok || in.OwnerID != ownerID || in.DeletedAt != nil {
+		return models.ErrIncomeNotFound
+	}
+	now := time.Now().UTC()
+	in.DeletedAt = &now
+	return nil
+}
+
+// List filtra, ordena e pagina as receitas do usuário
+func (r *memoryIncomeRepository) List(ctx context.Context, ownerID uuid.UUID, filter *models.IncomeFilter) ([]models.Income, int, error) {
+	filter.SetDefaults()
+	r.mu.RLock()
+	var items []models.Income
+	for _, in := range r.incomes {
+		if in.OwnerID == ownerID && in.DeletedAt == nil && matchIncomeFilter(in, filter) {
+			items = append(items, *in)
+		}
+	}
+	r.mu.RUnlock()
+
+	sortIncomes(items, filter)
+	total := len(items)
+	start := (filter.Page - 1) * filter.PerPage
+	if start > total {
+		start = total
+	}
+	end := start + filter.PerPage
+	if end > total {
+		end = total
+	}
+	return items[start:end], total, nil
+}
+
+// AddPayment registra um pagamento
+func (r *memoryIncomeRepository) AddPayment(ctx context.Context, payment *models.Payment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.incomes[payment.IncomeID]; !ok {
+		return models.ErrIncomeNotFound
+	}
+	now := time.Now().UTC()
+	payment.CreatedAt = &now
+	r.payments[payment.IncomeID] = append(r.payments[payment.IncomeID], *payment)
+	return nil
+}
+
+// AddPaymentTx registra o pagamento e recalcula total e status sob a mesma trava (saldo conferido antes)
+func (r *memoryIncomeRepository) AddPaymentTx(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	in, ok := r.incomes[payment.IncomeID]
+	if !ok || in.OwnerID != ownerID || in.DeletedAt != nil {
+		return nil, models.ErrIncomeNotFound
+	}
+	if payment.Valor > in.Valor-in.TotalPago {
+		return nil, models.ErrInsufficientAmount
+	}
+	now := time.Now().UTC()
+	payment.CreatedAt = &now
+	r.payments[payment.IncomeID] = append(r.payments[payment.IncomeID], *payment)
+	in.TotalPago += payment.Valor
+	in.Status = models.DeriveIncomeStatus(in.Status, in.Valor, in.TotalPago, in.DueDate, now, locale.FromContext(ctx).Location)
+	in.UpdatedAt = &now
+	in.Version++
+	cp := *in
+	return &cp, nil
+}
+
+// UpdatePaymentTx corrige o pagamento conferindo o saldo sem o valor antigo
+func (r *memoryIncomeRepository) UpdatePaymentTx(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for incomeID, pays := range r.payments {
+		for i := range pays {
+			if pays[i].ID != payment.ID {
+				continue
+			}
+			in, ok := r.incomes[incomeID]
+			if !ok || in.OwnerID != ownerID || in.DeletedAt != nil {
+				return nil, models.ErrPaymentNotFound
+			}
+			if pays[i].ReversedAt != nil {
+				return nil, models.ErrPaymentAlreadyReversed
+			}
+			if payment.Valor > in.Valor-(in.TotalPago-pays[i].Valor) {
+				return nil, models.ErrInsufficientAmount
+			}
+			now := time.Now().UTC()
+			in.TotalPago += payment.Valor - pays[i].Valor
+			in.Status = models.DeriveIncomeStatus(in.Status, in.Valor, in.TotalPago, in.DueDate, now, locale.FromContext(ctx).Location)
+			in.UpdatedAt = &now
+			in.Version++
+			payment.IncomeID, payment.CreatedAt = incomeID, pays[i].CreatedAt
+			pays[i] = *payment
+			cp := *in
+			return &cp, nil
+		}
+	}
+	return nil, models.ErrPaymentNotFound
+}
+
+// GetPayments lista os pagamentos de uma receita do usuário (mais recentes primeiro)
+func (r *memoryIncomeRepository) GetPayments(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	in, ok := r.incomes[incomeID]
+	if !ok || in.OwnerID != ownerID {
+		return nil, nil
+	}
+	out := append([]models.Payment(nil), r.payments[incomeID]...)
+	sort.Slice(out, func(i, j int) bool { return out[i].PagoEm.After(out[j].PagoEm) })
+	return out, nil
+}
+
+// UpdateTotalPago recalcula o total pago a partir dos pagamentos
+func (r *memoryIncomeRepository) UpdateTotalPago(ctx context.Context, incomeID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	in, ok := r.incomes[incomeID]
+	if !ok {
+		return models.ErrIncomeNotFound
+	}
+	var total models.Money
+	for _, p := range r.payments[incomeID] {
+		total += p.Valor
+	}
+	in.TotalPago = total
+	in.Version++
+	return nil
+}
+
+// GetFeePolicy retorna o padrão do produto (o modo mock não tem contratos)
+func (r *memoryIncomeRepository) GetFeePolicy(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.FeePolicy, error) {
+	if _, err := r.GetByID(ctx, incomeID, ownerID); err != nil {
+		return nil, err
+	}
+	p := models.DefaultFeePolicy
+	return &p, nil
+}
+
+// MarkOverdue marca como vencidas as receitas pendentes com vencimento anterior ao dia de now
+// (o modo mock não tem configurações por usuário: vale defaultTimezone)
+func (r *memoryIncomeRepository) MarkOverdue(ctx context.Context, now time.Time, defaultTimezone string) (int64, error) {
+	loc, err := time.LoadLocation(defaultTimezone)
+	if err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for _, in := range r.incomes {
+		if in.Status != models.StatusPendente || in.DeletedAt != nil || in.DueDate == nil || in.TotalPago != 0 {
+			continue
+		}
+		if models.PastDue(*in.DueDate, now, loc) {
+			in.Status = models.StatusVencido
+			in.Version++
+			at := now.UTC()
+			in.UpdatedAt = &at
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Stats totais por status; sem o job diário, o status é derivado na hora (vencidas incluídas)
+func (r *memoryIncomeRepository) Stats(ctx context.Context, ownerID uuid.UUID) (*models.IncomeStats, error) {
+	now, loc := time.Now().UTC(), locale.FromContext(ctx).Location
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	st := &models.IncomeStats{}
+	for _, in := range r.incomes {
+		if in.OwnerID != ownerID || in.DeletedAt != nil {
+			continue
+		}
+		st.Add(models.DeriveIncomeStatus(in.Status, in.Valor, in.TotalPago, in.DueDate, now, loc), in.Valor, in.TotalPago)
+	}
+	return st, nil
+}
+
+// matchIncomeFilter replica em memória o WHERE de buildIncomeListWhere
+func matchIncomeFilter(in *models.Income, f *models.IncomeFilter) bool {
+	if f.Search != "" {
+		term := strings.ToLower(f.Search)
+		hay := strings.ToLower(in.Competencia + " " + strings.Join(in.Tags, " "))
+		if in.Categoria != nil {
+			hay += " " + strings.ToLower(*in.Categoria)
+		}
+		if !strings.Contains(hay, term) {
+			return false
+		}
+	}
+	if f.Status != "" && in.Status != f.Status {
+		return false
+	}
+	if f.Categoria != "" && (in.Categoria == nil || *in.Categoria != f.Categoria) {
+		return false
+	}
+	if f.Competencia != "" && in.Competencia != f.Competencia {
+		return false
+	}
+	if f.ContractID != nil && (in.ContractID == nil || *in.ContractID != *f.ContractID) {
+		return false
+	}
+	if f.PayerID != nil && (in.PayerID == nil || *in.PayerID != *f.PayerID) {
+		return false
+	}
+	if f.Tag != "" {
+		found := false
+		for _, t := range in.Tags {
+			if t == f.Tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.DueDateFrom != nil && (in.DueDate == nil || in.DueDate.Before(*f.DueDateFrom)) {
+		return false
+	}
+	if f.DueDateTo != nil && (in.DueDate == nil || in.DueDate.After(*f.DueDateTo)) {
+		return false
+	}
+	if f.ValorMin != nil && in.Valor < *f.ValorMin {
+		return false
+	}
+	if f.ValorMax != nil && in.Valor > *f.ValorMax {
+		return false
+	}
+	return true
+}
+
+// sortIncomes ordena pelo campo da whitelist (padrão created_at), desempatando por id
+func sortIncomes(items []models.Income, f *models.IncomeFilter) {
+	desc := f.SortOrder != "asc"
+	key := f.SortField
+	if _, ok := incomeSortColumns[key]; !ok {
+		key = "created_at"
+	}
+	less := func(a, b *models.Income) int {
+		switch key {
+		case "valor":
+			return cmp.Compare(a.Valor, b.Valor)
+		case "total_pago":
+			return cmp.Compare(a.TotalPago, b.TotalPago)
+		case "competencia":
+			return strings.Compare(a.Competencia, b.Competencia)
+		case "status":
+			return strings.Compare(a.Status, b.Status)
+		case "categoria":
+			return strings.Compare(strOrEmpty(a.Categoria), strOrEmpty(b.Categoria))
+		case "due_date":
+			return compareTime(a.DueDate, b.DueDate)
+		case "updated_at":
+			return compareTime(a.UpdatedAt, b.UpdatedAt)
+		default:
+			return compareTime(a.CreatedAt, b.CreatedAt)
+		}
+	}
+	isNull := func(in *models.Income) bool {
+		switch key {
+		case "categoria":
+			return in.Categoria == nil
+		case "due_date":
+			return in.DueDate == nil
+		}
+		return false
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		// NULLS LAST em ambas as direções, como no Postgres
+		if ni, nj := isNull(&items[i]), isNull(&items[j]); ni != nj {
+			return nj
+		}
+		c := less(&items[i], &items[j])
+		if c == 0 {
+			c = strings.Compare(items[i].ID.String(), items[j].ID.String())
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+func compareTime(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return a.Compare(*b)
+}
+
+func strOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
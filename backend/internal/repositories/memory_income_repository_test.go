@@ -0,0 +1,199 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do repositório de receitas em memória (filtros, ordenação e paginação)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/models"
+)
+
+func TestMemoryIncomeRepository_ListFiltersAndPaginates(t *testing.T) {
+	ctx, repo := context.Background(), NewMemoryIncomeRepository()
+	owner, other := uuid.New(), uuid.New()
+	aluguel := "Aluguel"
+	for i, v := range []models.Money{10000, 30000, 20000, 40000} {
+		in := &models.Income{ID: uuid.New(), OwnerID: owner, Competencia: fmt.Sprintf("2026-%02d", i+1), Valor: v, Status: models.StatusPendente}
+		if v >= 20000 {
+			in.Categoria = &aluguel
+		}
+		if err := repo.Create(ctx, in); err != nil {
+			t.Fatalf("Create err: %v", err)
+		}
+	}
+	_ = repo.Create(ctx, &models.Income{ID: uuid.New(), OwnerID: other, Competencia: "2026-01", Valor: models.NewMoney(999), Categoria: &aluguel})
+
+	items, total, err := repo.List(ctx, owner, &models.IncomeFilter{Categoria: "Aluguel", SortField: "valor", SortOrder: "asc", PerPage: 2})
+	if err != nil {
+		t.Fatalf("List err: %v", err)
+	}
+	if total != 3 || len(items) != 2 {
+		t.Fatalf("total=%d itens=%d, want 3/2", total, len(items))
+	}
+	if items[0].Valor != models.NewMoney(200) || items[1].Valor != models.NewMoney(300) {
+		t.Fatalf("ordenação inesperada: %v, %v", items[0].Valor, items[1].Valor)
+	}
+
+	items, _, _ = repo.List(ctx, owner, &models.IncomeFilter{Categoria: "Aluguel", SortField: "valor", SortOrder: "asc", PerPage: 2, Page: 2})
+	if len(items) != 1 || items[0].Valor != models.NewMoney(400) {
+		t.Fatalf("segunda página inesperada: %+v", items)
+	}
+}
+
+func TestMemoryIncomeRepository_DeleteHidesIncome(t *testing.T) {
+	ctx, repo := context.Background(), NewMemoryIncomeRepository()
+	owner := uuid.New()
+	in := &models.Income{ID: uuid.New(), OwnerID: owner, Competencia: "2026-10", Valor: models.NewMoney(10)}
+	_ = repo.Create(ctx, in)
+
+	if err := repo.Delete(ctx, in.ID, owner); err != nil {
+		t.Fatalf("Delete err: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, in.ID, owner); err != models.ErrIncomeNotFound {
+		t.Fatalf("err = %v, want ErrIncomeNotFound", err)
+	}
+}
+
+func TestMemoryIncomeRepository_UpdateChecksVersion(t *testing.T) {
+	ctx, repo := context.Background(), NewMemoryIncomeRepository()
+	owner := uuid.New()
+	in := &models.Income{ID: uuid.New(), OwnerID: owner, Competencia: "2026-10", Valor: models.NewMoney(10)}
+	_ = repo.Create(ctx, in)
+
+	first, _ := repo.GetByID(ctx, in.ID, owner)
+	second, _ := repo.GetByID(ctx, in.ID, owner)
+	first.Valor = models.NewMoney(20)
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Update err: %v", err)
+	}
+	if first.Version != 2 {
+		t.Fatalf("versão = %d, want 2", first.Version)
+	}
+
+	second.Valor = models.NewMoney(30)
+	err := repo.Update(ctx, second)
+	var conflict *models.IncomeVersionConflictError
+	if !errors.As(err, &conflict) || conflict.Current.Valor != models.NewMoney(20) {
+		t.Fatalf("err = %v, want conflito com o estado atual", err)
+	}
+}
+
+func TestMemoryIncomeRepository_AddPaymentTxChecksBalance(t *testing.T) {
+	ctx, repo := context.Background(), NewMemoryIncomeRepository()
+	owner := uuid.New()
+	in := &models.Income{ID: uuid.New(), OwnerID: owner, Competencia: "2026-09", Valor: models.NewMoney(100)}
+	if err := repo.Create(ctx, in); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	got, err := repo.AddPaymentTx(ctx, &models.Payment{ID: uuid.New(), IncomeID: in.ID, Valor: models.NewMoney(60)}, owner)
+	if err != nil || got.TotalPago != models.NewMoney(60) || got.Version != 2 || got.Status != models.StatusParcial {
+		t.Fatalf("AddPaymentTx = %+v, %v", got, err)
+	}
+	if _, err := repo.AddPaymentTx(ctx, &models.Payment{ID: uuid.New(), IncomeID: in.ID, Valor: models.NewMoney(50)}, owner); !errors.Is(err, models.ErrInsufficientAmount) {
+		t.Fatalf("pagamento acima do saldo: err = %v", err)
+	}
+	if pays, _ := repo.GetPayments(ctx, in.ID, owner); len(pays) != 1 {
+		t.Fatalf("pagamentos = %d, esperado 1 (o recusado não é gravado)", len(pays))
+	}
+	got, err = repo.AddPaymentTx(ctx, &models.Payment{ID: uuid.New(), IncomeID: in.ID, Valor: models.NewMoney(40)}, owner)
+	if err != nil || got.Status != models.StatusPago {
+		t.Fatalf("quitação: status = %v, %v; esperado pago", got, err)
+	}
+	if stored, _ := repo.GetByID(ctx, in.ID, owner); stored.Status != models.StatusPago {
+		t.Fatalf("status gravado = %q, esperado pago", stored.Status)
+	}
+}
+
+func TestMemoryIncomeRepository_UpdatePaymentTxRechecksBalance(t *testing.T) {
+	ctx, repo := context.Background(), NewMemoryIncomeRepository()
+	owner := uuid.New()
+	in := &models.Income{ID: uuid.New(), OwnerID: owner, Competencia: "2026-09", Valor: models.NewMoney(100), Status: models.StatusPendente}
+	if err := repo.Create(ctx, in); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	first := &models.Payment{ID: uuid.New(), IncomeID: in.ID, Valor: models.NewMoney(60)}
+	if _, err := repo.AddPaymentTx(ctx, first, owner); err != nil {
+		t.Fatalf("AddPaymentTx: %v", err)
+	}
+	if _, err := repo.AddPaymentTx(ctx, &models.Payment{ID: uuid.New(), IncomeID: in.ID, Valor: models.NewMoney(30)}, owner); err != nil {
+		t.Fatalf("AddPaymentTx: %v", err)
+	}
+
+	// saldo sem o pagamento corrigido: 100 - 30 = 70
+	if _, err := repo.UpdatePaymentTx(ctx, &models.Payment{ID: first.ID, Valor: models.NewMoney(71)}, owner); !errors.Is(err, models.ErrInsufficientAmount) {
+		t.Fatalf("correção acima do saldo: err = %v", err)
+	}
+	got, err := repo.UpdatePaymentTx(ctx, &models.Payment{ID: first.ID, Valor: models.NewMoney(70)}, owner)
+	if err != nil || got.TotalPago != models.NewMoney(100) || got.Status != models.StatusPago {
+		t.Fatalf("UpdatePaymentTx = %+v, %v", got, err)
+	}
+	if _, err := repo.UpdatePaymentTx(ctx, &models.Payment{ID: first.ID, Valor: models.NewMoney(10)}, uuid.New()); !errors.Is(err, models.ErrPaymentNotFound) {
+		t.Fatalf("outro usuário: err = %v", err)
+	}
+}
+
+func TestMemoryIncomeRepository_MarkOverdue(t *testing.T) {
+	ctx, repo := context.Background(), NewMemoryIncomeRepository()
+	owner := uuid.New()
+	due := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	late := &models.Income{ID: uuid.New(), OwnerID: owner, Competencia: "2026-10", Valor: models.NewMoney(10), Status: models.StatusPendente, DueDate: &due}
+	today := due.AddDate(0, 0, 1)
+	notYet := &models.Income{ID: uuid.New(), OwnerID: owner, Competencia: "2026-10", Valor: models.NewMoney(10), Status: models.StatusPendente, DueDate: &today}
+	_ = repo.Create(ctx, late)
+	_ = repo.Create(ctx, notYet)
+
+	// 18/10 às 01h em UTC ainda é 17/10 em São Paulo: nada vence
+	if n, err := repo.MarkOverdue(ctx, time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC), "America/Sao_Paulo"); err != nil || n != 0 {
+		t.Fatalf("MarkOverdue = %d, %v; want 0", n, err)
+	}
+	if n, err := repo.MarkOverdue(ctx, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), "America/Sao_Paulo"); err != nil || n != 1 {
+		t.Fatalf("MarkOverdue = %d, %v; want 1", n, err)
+	}
+	got, _ := repo.GetByID(ctx, late.ID, owner)
+	if got.Status != models.StatusVencido || got.Version != 2 {
+		t.Fatalf("receita vencida = %s v%d, want vencido v2", got.Status, got.Version)
+	}
+	if got, _ := repo.GetByID(ctx, notYet.ID, owner); got.Status != models.StatusPendente {
+		t.Fatalf("receita que vence hoje não deveria mudar: %s", got.Status)
+	}
+}
+
+func TestMemoryIncomeRepository_Stats(t *testing.T) {
+	ctx, repo := context.Background(), NewMemoryIncomeRepository()
+	owner := uuid.New()
+	past := time.Now().AddDate(0, 0, -10)
+	for _, in := range []*models.Income{
+		{ID: uuid.New(), OwnerID: owner, Competencia: "2026-09", Valor: models.NewMoney(100), Status: models.StatusPendente},
+		{ID: uuid.New(), OwnerID: owner, Competencia: "2026-09", Valor: models.NewMoney(50), Status: models.StatusPendente, DueDate: &past},
+		{ID: uuid.New(), OwnerID: uuid.New(), Competencia: "2026-09", Valor: models.NewMoney(999), Status: models.StatusPendente},
+	} {
+		if err := repo.Create(ctx, in); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	paid := &models.Income{ID: uuid.New(), OwnerID: owner, Competencia: "2026-09", Valor: models.NewMoney(80), Status: models.StatusPendente}
+	if err := repo.Create(ctx, paid); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := repo.AddPaymentTx(ctx, &models.Payment{ID: uuid.New(), IncomeID: paid.ID, Valor: models.NewMoney(80)}, owner); err != nil {
+		t.Fatalf("AddPaymentTx: %v", err)
+	}
+
+	st, err := repo.Stats(ctx, owner)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	want := models.IncomeStats{TotalReceitas: 3, TotalValor: models.NewMoney(230), ReceitasPendentes: 1, ReceitasPagas: 1,
+		ReceitasVencidas: 1, ValorPendente: models.NewMoney(100), ValorPago: models.NewMoney(80), ValorVencido: models.NewMoney(50)}
+	if *st != want {
+		t.Fatalf("Stats = %+v, want %+v", *st, want)
+	}
+}
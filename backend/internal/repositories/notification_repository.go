@@ -0,0 +1,233 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de preferências de notificação e consultas do resumo semanal
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// NotificationRepository define a persistência das preferências de notificação.
+// Docstring: GetSettings cria a linha com os valores padrão na primeira leitura, garantindo
+// que todo usuário tenha um unsubscribe_token antes do primeiro envio.
+type NotificationRepository interface {
+	GetSettings(ctx context.Context, ownerID uuid.UUID) (*models.NotificationSettings, error)
+	UpdateSettings(ctx context.Context, s *models.NotificationSettings) error
+	UnsubscribeDigest(ctx context.Context, token uuid.UUID) error
+	ListDigestRecipients(ctx context.Context, weekday int, sentBefore time.Time) ([]models.DigestRecipient, error)
+	DigestSummary(ctx context.Context, ownerID uuid.UUID, from, to time.Time) (*models.DigestSummary, error)
+	MarkDigestSent(ctx context.Context, ownerID uuid.UUID, at time.Time) error
+	ClaimDueReminders(ctx context.Context, now time.Time, leadDays int, defaultTimezone string) ([]models.DueReminder, error)
+}
+
+type notificationRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewNotificationRepository cria uma nova instância do repositório de notificações
+func NewNotificationRepository(db *pgxpool.Pool) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+const notificationSettingsColumns = `owner_id, email, push_subscription, whatsapp, digest_enabled, digest_channels,
+	digest_weekday, unsubscribe_token, last_digest_at, preferences, created_at, updated_at`
+
+func scanNotificationSettings(row pgx.Row, s *models.NotificationSettings) error {
+	return row.Scan(&s.OwnerID, &s.Email, &s.PushSubscription, &s.WhatsApp, &s.DigestEnabled, &s.DigestChannels,
+		&s.DigestWeekday, &s.UnsubscribeToken, &s.LastDigestAt, &s.Preferences, &s.CreatedAt, &s.UpdatedAt, &s.AccountEmail, &s.PushEndpoints)
+}
+
+// GetSettings retorna as preferências do usuário (com o e-mail da conta), criando-as com os padrões se necessário
+func (r *notificationRepository) GetSettings(ctx context.Context, ownerID uuid.UUID) (*models.NotificationSettings, error) {
+	query := `
+		WITH s AS (
+			INSERT INTO rf_notification_settings (owner_id) VALUES ($1)
+			ON CONFLICT (owner_id) DO UPDATE SET owner_id = EXCLUDED.owner_id
+			RETURNING ` + notificationSettingsColumns + `
+		)
+		SELECT s.*, u.email,
+		       ARRAY(SELECT p.endpoint FROM rf_push_subscriptions p WHERE p.owner_id = s.owner_id ORDER BY p.created_at DESC)
+		FROM s LEFT JOIN auth.users u ON u.id = s.owner_id`
+	var s models.NotificationSettings
+	if err := scanNotificationSettings(r.db.QueryRow(ctx, query, ownerID), &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateSettings grava as preferências do usuário
+func (r *notificationRepository) UpdateSettings(ctx context.Context, s *models.NotificationSettings) error {
+	query := `
+		UPDATE rf_notification_settings
+		SET email = $2, push_subscription = $3, digest_enabled = $4, digest_channels = $5,
+			digest_weekday = $6, whatsapp = $7, preferences = $8, updated_at = NOW()
+		WHERE owner_id = $1
+		RETURNING updated_at
+	`
+	prefs := s.Preferences
+	if prefs == nil {
+		prefs = models.NotificationPreferences{}
+	}
+	err := r.db.QueryRow(ctx, query, s.OwnerID, s.Email, s.PushSubscription, s.DigestEnabled,
+		s.DigestChannels, s.DigestWeekday, s.WhatsApp, prefs).Scan(&s.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrNotificationSettingsNotFound
+	}
+	return err
+}
+
+// UnsubscribeDigest desabilita o resumo a partir do token do link de descadastro
+func (r *notificationRepository) UnsubscribeDigest(ctx context.Context, token uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx,
+		`UPDATE rf_notification_settings SET digest_enabled = false, updated_at = NOW() WHERE unsubscribe_token = $1`,
+		token)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrInvalidUnsubscribeToken
+	}
+	return nil
+}
+
+// ListDigestRecipients lista usuários com resumo habilitado no dia informado e ainda não enviado
+func (r *notificationRepository) ListDigestRecipients(ctx context.Context, weekday int, sentBefore time.Time) ([]models.DigestRecipient, error) {
+	query := `
+		SELECT u.id, u.email, COALESCE(pf.locale, st.locale), COALESCE(pf.timezone, st.timezone)
+		FROM auth.users u
+		LEFT JOIN rf_notification_settings ns ON ns.owner_id = u.id
+		LEFT JOIN rf_settings st ON st.owner_id = u.id
+		LEFT JOIN rf_profiles pf ON pf.id = u.id
+		WHERE COALESCE(ns.digest_enabled, true)
+		  AND COALESCE(ns.digest_weekday, 1) = $1
+		  AND (ns.last_digest_at IS NULL OR ns.last_digest_at < $2)
+		ORDER BY u.id
+	`
+	rows, err := r.db.Query(ctx, query, weekday, sentBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.DigestRecipient
+	for rows.Next() {
+		var rc models.DigestRecipient
+		if err := rows.Scan(&rc.OwnerID, &rc.AccountEmail, &rc.Locale, &rc.Timezone); err != nil {
+			return nil, err
+		}
+		items = append(items, rc)
+	}
+	return items, rows.Err()
+}
+
+// DigestSummary agrega recebimentos, inadimplência, vencimentos próximos e recibos do período
+func (r *notificationRepository) DigestSummary(ctx context.Context, ownerID uuid.UUID, from, to time.Time) (*models.DigestSummary, error) {
+	sum := &models.DigestSummary{PeriodStart: from, PeriodEnd: to, Upcoming: []models.DigestDue{}}
+
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(p.valor), 0), COUNT(p.id)
+		FROM rf_payments p
+		JOIN rf_incomes i ON i.id = p.income_id
+		WHERE i.owner_id = $1 AND i.deleted_at IS NULL AND p.reversed_at IS NULL AND p.pago_em >= $2 AND p.pago_em < $3
+	`, ownerID, from, to).Scan(&sum.Received, &sum.ReceivedCount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(valor - total_pago), 0), COUNT(*)
+		FROM rf_incomes
+		WHERE owner_id = $1 AND deleted_at IS NULL AND due_date < $2::date
+		  AND status NOT IN ('pago', 'cancelado') AND total_pago < valor
+	`, ownerID, to).Scan(&sum.Overdue, &sum.OverdueCount)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, competencia, categoria, valor - total_pago, due_date
+		FROM rf_incomes
+		WHERE owner_id = $1 AND deleted_at IS NULL
+		  AND due_date >= $2::date AND due_date < ($2::date + 7)
+		  AND status NOT IN ('pago', 'cancelado') AND total_pago < valor
+		ORDER BY due_date, competencia
+		LIMIT 20
+	`, ownerID, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var d models.DigestDue
+		if err := rows.Scan(&d.IncomeID, &d.Competencia, &d.Categoria, &d.Saldo, &d.DueDate); err != nil {
+			return nil, err
+		}
+		sum.Upcoming = append(sum.Upcoming, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	err = r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM rf_receipts WHERE owner_id = $1 AND emitido_em >= $2 AND emitido_em < $3
+	`, ownerID, from, to).Scan(&sum.ReceiptsIssued)
+	if err != nil {
+		return nil, err
+	}
+	return sum, nil
+}
+
+// MarkDigestSent registra o envio do resumo
+func (r *notificationRepository) MarkDigestSent(ctx context.Context, ownerID uuid.UUID, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO rf_notification_settings (owner_id, last_digest_at) VALUES ($1, $2)
+		ON CONFLICT (owner_id) DO UPDATE SET last_digest_at = EXCLUDED.last_digest_at
+	`, ownerID, at)
+	return err
+}
+
+// ClaimDueReminders reserva as receitas em aberto que vencem entre hoje e hoje + leadDays (no fuso
+// do dono) e ainda não foram lembradas; a marca due_reminder_at impede avisos repetidos
+func (r *notificationRepository) ClaimDueReminders(ctx context.Context, now time.Time, leadDays int, defaultTimezone string) ([]models.DueReminder, error) {
+	query := `
+		UPDATE rf_incomes i
+		SET due_reminder_at = $1
+		FROM (
+			SELECT p.id, COALESCE(tz.name, $2) AS tz, COALESCE(pf.locale, s.locale) AS locale
+			FROM rf_incomes p
+			LEFT JOIN rf_settings s ON s.owner_id = p.owner_id
+			LEFT JOIN rf_profiles pf ON pf.id = p.owner_id
+			LEFT JOIN pg_timezone_names tz ON tz.name = COALESCE(pf.timezone, s.timezone)
+			WHERE p.due_reminder_at IS NULL AND p.deleted_at IS NULL AND p.due_date IS NOT NULL
+			  AND p.valor > p.total_pago AND p.status <> $4
+		) d
+		WHERE i.id = d.id AND i.due_reminder_at IS NULL
+		  AND i.due_date BETWEEN ($1::timestamptz AT TIME ZONE d.tz)::date
+		                     AND ($1::timestamptz AT TIME ZONE d.tz)::date + $3::int
+		RETURNING i.owner_id, d.locale, d.tz, i.id, i.competencia, i.categoria, i.valor - i.total_pago, i.due_date
+	`
+	rows, err := r.db.Query(ctx, query, now, defaultTimezone, leadDays, models.StatusVencido)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.DueReminder
+	for rows.Next() {
+		var d models.DueReminder
+		if err := rows.Scan(&d.OwnerID, &d.Locale, &d.Timezone, &d.IncomeID, &d.Competencia, &d.Categoria, &d.Saldo, &d.DueDate); err != nil {
+			return nil, err
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,335 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório das organizações (membros, papéis e convites)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// OrgRepository operações das organizações.
+// Docstring: GetMembership resolve o espaço de dados de cada requisição com X-Org-ID; as regras
+// de papel (quem convida, altera ou remove) ficam no serviço.
+type OrgRepository interface {
+	Create(ctx context.Context, org *models.Org) error
+	GetByID(ctx context.Context, orgID, userID uuid.UUID) (*models.Org, error)
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]models.Org, error)
+	UpdateName(ctx context.Context, orgID uuid.UUID, nome string) (*models.Org, error)
+	GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMembership, error)
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]models.OrgMember, error)
+	UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string) (*models.OrgMember, error)
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
+	CreateInvitation(ctx context.Context, inv *models.OrgInvitation, tokenHash string) error
+	ListInvitations(ctx context.Context, orgID uuid.UUID) ([]models.OrgInvitation, error)
+	RevokeInvitation(ctx context.Context, orgID, id uuid.UUID, now time.Time) error
+	GetInvitationByToken(ctx context.Context, tokenHash string, now time.Time) (*models.OrgInvitation, error)
+	AcceptInvitation(ctx context.Context, inv *models.OrgInvitation, userID uuid.UUID, now time.Time) (*models.OrgMember, error)
+	UserEmail(ctx context.Context, userID uuid.UUID) (string, error)
+}
+
+type orgRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOrgRepository cria uma nova instância do repositório de organizações
+func NewOrgRepository(db *pgxpool.Pool) OrgRepository {
+	return &orgRepository{db: db}
+}
+
+const orgInvitationColumns = `id, org_id, email, role, invited_by, created_at, expires_at, accepted_at, revoked_at`
+
+func scanOrgInvitation(row pgx.Row) (*models.OrgInvitation, error) {
+	var inv models.OrgInvitation
+	if err := row.Scan(&inv.ID, &inv.OrgID, &inv.Email, &inv.Role, &inv.InvitedBy, &inv.CreatedAt, &inv.ExpiresAt,
+		&inv.AcceptedAt, &inv.RevokedAt); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// Create cria a organização do usuário e o registra como membro owner
+func (r *orgRepository) Create(ctx context.Context, org *models.Org) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_orgs (owner_id, nome) VALUES ($1, $2)
+		RETURNING id, created_at
+	`, org.OwnerID, org.Nome).Scan(&org.ID, &org.CreatedAt)
+	if isConstraintViolation(err, pgUniqueViolation, "uq_orgs_owner") {
+		return models.ErrOrgExists
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO rf_org_members (org_id, user_id, role) VALUES ($1, $2, 'owner')`, org.ID, org.OwnerID); err != nil {
+		return err
+	}
+	org.Role = models.OrgRoleOwner
+	return tx.Commit(ctx)
+}
+
+// GetByID retorna a organização com o papel de userID; quem não é membro recebe ErrOrgNotFound
+func (r *orgRepository) GetByID(ctx context.Context, orgID, userID uuid.UUID) (*models.Org, error) {
+	var o models.Org
+	err := r.db.QueryRow(ctx, `
+		SELECT o.id, o.owner_id, o.nome, m.role, o.created_at, o.updated_at
+		FROM rf_orgs o JOIN rf_org_members m ON m.org_id = o.id AND m.user_id = $2
+		WHERE o.id = $1
+	`, orgID, userID).Scan(&o.ID, &o.OwnerID, &o.Nome, &o.Role, &o.CreatedAt, &o.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrOrgNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// ListForUser lista as organizações de que o usuário participa (a própria primeiro)
+func (r *orgRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]models.Org, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT o.id, o.owner_id, o.nome, m.role, o.created_at, o.updated_at
+		FROM rf_orgs o JOIN rf_org_members m ON m.org_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY (m.role = 'owner') DESC, o.nome
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.Org{}
+	for rows.Next() {
+		var o models.Org
+		if err := rows.Scan(&o.ID, &o.OwnerID, &o.Nome, &o.Role, &o.CreatedAt, &o.UpdatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, o)
+	}
+	return items, rows.Err()
+}
+
+// UpdateName renomeia a organização
+func (r *orgRepository) UpdateName(ctx context.Context, orgID uuid.UUID, nome string) (*models.Org, error) {
+	var o models.Org
+	err := r.db.QueryRow(ctx, `
+		UPDATE rf_orgs SET nome = $2, updated_at = now() WHERE id = $1
+		RETURNING id, owner_id, nome, created_at, updated_at
+	`, orgID, nome).Scan(&o.ID, &o.OwnerID, &o.Nome, &o.CreatedAt, &o.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrOrgNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// GetMembership retorna o papel do usuário e o dono do espaço de dados da organização
+func (r *orgRepository) GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMembership, error) {
+	m := &models.OrgMembership{OrgID: orgID}
+	err := r.db.QueryRow(ctx, `
+		SELECT o.owner_id, m.role
+		FROM rf_orgs o JOIN rf_org_members m ON m.org_id = o.id
+		WHERE o.id = $1 AND m.user_id = $2
+	`, orgID, userID).Scan(&m.WorkspaceOwnerID, &m.Role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrOrgNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListMembers lista os membros com o e-mail da conta (dono primeiro)
+func (r *orgRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]models.OrgMember, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT m.org_id, m.user_id, u.email, m.role, m.invited_by, m.created_at
+		FROM rf_org_members m LEFT JOIN auth.users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY (m.role = 'owner') DESC, m.created_at
+	`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.OrgMember{}
+	for rows.Next() {
+		var m models.OrgMember
+		if err := rows.Scan(&m.OrgID, &m.UserID, &m.Email, &m.Role, &m.InvitedBy, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+	return items, rows.Err()
+}
+
+// UpdateMemberRole troca o papel de um membro que não seja o dono
+func (r *orgRepository) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string) (*models.OrgMember, error) {
+	var m models.OrgMember
+	err := r.db.QueryRow(ctx, `
+		UPDATE rf_org_members SET role = $3, updated_at = now()
+		WHERE org_id = $1 AND user_id = $2 AND role <> 'owner'
+		RETURNING org_id, user_id, role, invited_by, created_at
+	`, orgID, userID, role).Scan(&m.OrgID, &m.UserID, &m.Role, &m.InvitedBy, &m.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrOrgMemberNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// RemoveMember remove um membro que não seja o dono
+func (r *orgRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rf_org_members WHERE org_id = $1 AND user_id = $2 AND role <> 'owner'`, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrOrgMemberNotFound
+	}
+	return nil
+}
+
+// CreateInvitation registra o convite, revogando o pendente anterior para o mesmo e-mail
+func (r *orgRepository) CreateInvitation(ctx context.Context, inv *models.OrgInvitation, tokenHash string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE rf_org_invitations SET revoked_at = $3
+		WHERE org_id = $1 AND lower(email) = lower($2) AND accepted_at IS NULL AND revoked_at IS NULL
+	`, inv.OrgID, inv.Email, inv.CreatedAt)
+	if err != nil {
+		return err
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_org_invitations (org_id, email, role, token_hash, invited_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, inv.OrgID, inv.Email, inv.Role, tokenHash, inv.InvitedBy, inv.CreatedAt, inv.ExpiresAt).Scan(&inv.ID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListInvitations lista os convites pendentes (inclusive os já expirados, para reenvio)
+func (r *orgRepository) ListInvitations(ctx context.Context, orgID uuid.UUID) ([]models.OrgInvitation, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+orgInvitationColumns+`
+		FROM rf_org_invitations
+		WHERE org_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.OrgInvitation{}
+	for rows.Next() {
+		inv, err := scanOrgInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *inv)
+	}
+	return items, rows.Err()
+}
+
+// RevokeInvitation cancela um convite pendente
+func (r *orgRepository) RevokeInvitation(ctx context.Context, orgID, id uuid.UUID, now time.Time) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE rf_org_invitations SET revoked_at = $3
+		WHERE id = $1 AND org_id = $2 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, id, orgID, now)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrOrgInvitationNotFound
+	}
+	return nil
+}
+
+// GetInvitationByToken retorna o convite pendente e não expirado com o hash do token
+func (r *orgRepository) GetInvitationByToken(ctx context.Context, tokenHash string, now time.Time) (*models.OrgInvitation, error) {
+	inv, err := scanOrgInvitation(r.db.QueryRow(ctx, `
+		SELECT `+orgInvitationColumns+`
+		FROM rf_org_invitations
+		WHERE token_hash = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2
+	`, tokenHash, now))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrOrgInvitationNotFound
+	}
+	return inv, err
+}
+
+// AcceptInvitation marca o convite como aceito e inclui o usuário com o papel convidado.
+// Docstring: o convite é reservado na mesma transação (aceites concorrentes recebem
+// ErrOrgInvitationNotFound); quem já é membro recebe ErrOrgAlreadyMember e o convite segue pendente.
+func (r *orgRepository) AcceptInvitation(ctx context.Context, inv *models.OrgInvitation, userID uuid.UUID, now time.Time) (*models.OrgMember, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE rf_org_invitations SET accepted_at = $2, accepted_by = $3
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2
+	`, inv.ID, now, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, models.ErrOrgInvitationNotFound
+	}
+	m := models.OrgMember{OrgID: inv.OrgID, UserID: userID, Role: inv.Role, InvitedBy: &inv.InvitedBy}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_org_members (org_id, user_id, role, invited_by, created_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (org_id, user_id) DO NOTHING
+		RETURNING created_at
+	`, m.OrgID, m.UserID, m.Role, m.InvitedBy, now).Scan(&m.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrOrgAlreadyMember
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// UserEmail retorna o e-mail da conta (auth.users)
+func (r *orgRepository) UserEmail(ctx context.Context, userID uuid.UUID) (string, error) {
+	var email string
+	err := r.db.QueryRow(ctx, `SELECT COALESCE(email, '') FROM auth.users WHERE id = $1`, userID).Scan(&email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return email, err
+}
@@ -0,0 +1,191 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de pagadores/clientes (rf_payers)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// PayerRepository define operações de persistência para pagadores
+type PayerRepository interface {
+	Create(ctx context.Context, p *models.Payer) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Payer, error)
+	List(ctx context.Context, ownerID uuid.UUID, filter *models.PayerFilter) ([]models.Payer, int, error)
+	Update(ctx context.Context, p *models.Payer) error
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+	ListStatementEntries(ctx context.Context, id, ownerID uuid.UUID) ([]models.StatementEntry, error)
+}
+
+type payerRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPayerRepository cria uma nova instância do repositório de pagadores
+func NewPayerRepository(db *pgxpool.Pool) PayerRepository {
+	return &payerRepository{db: db}
+}
+
+const payerColumns = `id, owner_id, nome, documento, email, telefone, endereco, cidade, uf, cep, created_at, updated_at`
+
+func scanPayer(row pgx.Row, p *models.Payer) error {
+	return row.Scan(&p.ID, &p.OwnerID, &p.Nome, &p.Documento, &p.Email, &p.Telefone,
+		&p.Endereco, &p.Cidade, &p.UF, &p.CEP, &p.CreatedAt, &p.UpdatedAt)
+}
+
+// mapPayerError traduz violações de constraint em erros de domínio
+func mapPayerError(err error) error {
+	if isConstraintViolation(err, pgUniqueViolation, "idx_payers_owner_documento") {
+		return models.ErrDuplicateDocument
+	}
+	return err
+}
+
+// Create insere um novo pagador
+func (r *payerRepository) Create(ctx context.Context, p *models.Payer) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	query := `
+		INSERT INTO rf_payers (
+			id, owner_id, nome, documento, email, telefone, endereco, cidade, uf, cep
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		) RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query,
+		p.ID, p.OwnerID, p.Nome, p.Documento, p.Email, p.Telefone, p.Endereco, p.Cidade, p.UF, p.CEP,
+	).Scan(&p.CreatedAt, &p.UpdatedAt)
+	return mapPayerError(err)
+}
+
+// GetByID busca um pagador do usuário
+func (r *payerRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Payer, error) {
+	query := `SELECT ` + payerColumns + ` FROM rf_payers WHERE id = $1 AND owner_id = $2`
+	var p models.Payer
+	if err := scanPayer(r.db.QueryRow(ctx, query, id, ownerID), &p); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrPayerNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// List busca pagadores com busca textual por nome, documento ou e-mail
+func (r *payerRepository) List(ctx context.Context, ownerID uuid.UUID, filter *models.PayerFilter) ([]models.Payer, int, error) {
+	filter.SetDefaults()
+
+	where := "owner_id = $1"
+	args := []interface{}{ownerID}
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		where += fmt.Sprintf(" AND (nome ILIKE $%[1]d OR documento ILIKE $%[1]d OR email ILIKE $%[1]d)", len(args))
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM rf_payers WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.PerPage
+	args = append(args, filter.PerPage, offset)
+	query := fmt.Sprintf(`SELECT %s FROM rf_payers WHERE %s ORDER BY lower(nome) LIMIT $%d OFFSET $%d`,
+		payerColumns, where, len(args)-1, len(args))
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []models.Payer
+	for rows.Next() {
+		var p models.Payer
+		if err := scanPayer(rows, &p); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, p)
+	}
+	return items, total, rows.Err()
+}
+
+// Update atualiza os dados de um pagador
+func (r *payerRepository) Update(ctx context.Context, p *models.Payer) error {
+	query := `
+		UPDATE rf_payers
+		SET nome = $3, documento = $4, email = $5, telefone = $6, endereco = $7,
+		    cidade = $8, uf = $9, cep = $10, updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query,
+		p.ID, p.OwnerID, p.Nome, p.Documento, p.Email, p.Telefone, p.Endereco, p.Cidade, p.UF, p.CEP,
+	).Scan(&p.CreatedAt, &p.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrPayerNotFound
+	}
+	return mapPayerError(err)
+}
+
+// Delete remove um pagador; receitas e recibos vinculados ficam sem pagador (ON DELETE SET NULL)
+func (r *payerRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM rf_payers WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrPayerNotFound
+	}
+	return nil
+}
+
+// ListStatementEntries lançamentos do extrato do pagador (receitas não canceladas, pagamentos,
+// estornos e recibos), sem ordenação nem saldo (calculados em models.BuildPayerStatement)
+func (r *payerRepository) ListStatementEntries(ctx context.Context, id, ownerID uuid.UUID) ([]models.StatementEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH incomes AS (
+			SELECT i.id, i.competencia, i.categoria, i.valor, i.due_date, i.created_at
+			FROM rf_incomes i
+			WHERE i.owner_id = $1 AND i.payer_id = $2 AND i.deleted_at IS NULL AND i.status <> 'cancelado'
+		)
+		SELECT 'income', i.id, i.id, COALESCE(i.due_date::timestamp AT TIME ZONE 'UTC', i.created_at), i.due_date IS NOT NULL,
+			i.competencia, i.categoria, NULL::text, NULL::bigint, i.valor
+		FROM incomes i
+		UNION ALL
+		SELECT 'payment', p.id, i.id, p.pago_em, false, i.competencia, i.categoria, p.metodo, NULL, p.valor
+		FROM rf_payments p JOIN incomes i ON i.id = p.income_id
+		UNION ALL
+		SELECT 'reversal', p.id, i.id, p.reversed_at, false, i.competencia, i.categoria, p.metodo, NULL, p.valor
+		FROM rf_payments p JOIN incomes i ON i.id = p.income_id
+		WHERE p.reversed_at IS NOT NULL
+		UNION ALL
+		SELECT 'receipt', rc.id, rc.income_id, rc.emitido_em, false, rc.competencia, rc.categoria, NULL, rc.numero,
+			COALESCE(rc.valor_liquido, rc.valor, 0)
+		FROM rf_receipts rc
+		WHERE rc.owner_id = $1 AND rc.emitido_em IS NOT NULL AND rc.income_id IS NOT NULL
+		  AND (rc.payer_id = $2 OR rc.income_id IN (SELECT id FROM incomes))
+	`, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.StatementEntry
+	for rows.Next() {
+		var e models.StatementEntry
+		if err := rows.Scan(&e.Kind, &e.RefID, &e.IncomeID, &e.At, &e.DateOnly, &e.Competencia, &e.Categoria, &e.Metodo,
+			&e.ReceiptNumero, &e.Valor); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,105 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório dos links de pagamento das receitas (rf_payment_links)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// PaymentLinkRepository define a persistência dos links de pagamento.
+// Docstring: GetOpen devolve o link aberto e ainda válido mais recente da receita, reaproveitado
+// enquanto o valor não muda. ClaimPaid, Release e SetPayment servem ao webhook do gateway e não
+// filtram por usuário.
+type PaymentLinkRepository interface {
+	Create(ctx context.Context, l *models.PaymentLink) error
+	GetOpen(ctx context.Context, incomeID, ownerID uuid.UUID, now time.Time) (*models.PaymentLink, error)
+	ClaimPaid(ctx context.Context, provider string, id uuid.UUID, providerPaymentID *string, paidAt time.Time) (*models.PaymentLink, error)
+	Release(ctx context.Context, id uuid.UUID) error
+	SetPayment(ctx context.Context, id, paymentID uuid.UUID) error
+}
+
+type paymentLinkRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPaymentLinkRepository cria uma nova instância do repositório de links de pagamento
+func NewPaymentLinkRepository(db *pgxpool.Pool) PaymentLinkRepository {
+	return &paymentLinkRepository{db: db}
+}
+
+const paymentLinkColumns = `id, owner_id, income_id, provider, session_id, url, valor, status, expires_at, created_at, paid_at,
+	provider_payment_id, payment_id`
+
+func scanPaymentLink(row pgx.Row, l *models.PaymentLink) error {
+	return row.Scan(&l.ID, &l.OwnerID, &l.IncomeID, &l.Provider, &l.SessionID, &l.URL, &l.Valor, &l.Status,
+		&l.ExpiresAt, &l.CreatedAt, &l.PaidAt, &l.ProviderPaymentID, &l.PaymentID)
+}
+
+// Create grava o link criado no gateway (o id já foi enviado como referência)
+func (r *paymentLinkRepository) Create(ctx context.Context, l *models.PaymentLink) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return scanPaymentLink(r.db.QueryRow(ctx, `
+		INSERT INTO rf_payment_links (id, owner_id, income_id, provider, session_id, url, valor, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+paymentLinkColumns,
+		l.ID, l.OwnerID, l.IncomeID, l.Provider, l.SessionID, l.URL, l.Valor, l.ExpiresAt), l)
+}
+
+// GetOpen link aberto e não vencido mais recente da receita
+func (r *paymentLinkRepository) GetOpen(ctx context.Context, incomeID, ownerID uuid.UUID, now time.Time) (*models.PaymentLink, error) {
+	var l models.PaymentLink
+	err := scanPaymentLink(r.db.QueryRow(ctx, `
+		SELECT `+paymentLinkColumns+` FROM rf_payment_links
+		WHERE income_id = $1 AND owner_id = $2 AND status = 'open' AND expires_at > $3
+		ORDER BY created_at DESC LIMIT 1`, incomeID, ownerID, now), &l)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// ClaimPaid marca o link como pago; só uma notificação consegue (as repetidas recebem
+// ErrPaymentLinkNotFound, assim como referências desconhecidas ou links cancelados)
+func (r *paymentLinkRepository) ClaimPaid(ctx context.Context, provider string, id uuid.UUID, providerPaymentID *string, paidAt time.Time) (*models.PaymentLink, error) {
+	var l models.PaymentLink
+	err := scanPaymentLink(r.db.QueryRow(ctx, `
+		UPDATE rf_payment_links SET status = 'paid', paid_at = $3, provider_payment_id = $4
+		WHERE provider = $1 AND id = $2 AND status IN ('open', 'expired')
+		RETURNING `+paymentLinkColumns, provider, id, paidAt, providerPaymentID), &l)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// Release reabre o link cujo pagamento não pôde ser lançado (o gateway reenvia a notificação)
+func (r *paymentLinkRepository) Release(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE rf_payment_links SET status = 'open', paid_at = NULL, provider_payment_id = NULL
+		WHERE id = $1 AND status = 'paid' AND payment_id IS NULL`, id)
+	return err
+}
+
+// SetPayment liga o link pago ao pagamento lançado na receita
+func (r *paymentLinkRepository) SetPayment(ctx context.Context, id, paymentID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `UPDATE rf_payment_links SET payment_id = $2 WHERE id = $1`, id, paymentID)
+	return err
+}
@@ -0,0 +1,259 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório do catálogo de formas de pagamento (rf_payment_methods) e pagamentos por forma
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// PaymentMethodRepository define as operações do catálogo de formas de pagamento.
+// Docstring: a forma padrão é única por usuário (índice parcial); SetDefault troca a padrão
+// em uma transação e Archive tira a marca de padrão da forma arquivada.
+type PaymentMethodRepository interface {
+	Create(ctx context.Context, m *models.PaymentMethod) error
+	CreateDefaults(ctx context.Context, ownerID uuid.UUID, items []models.PaymentMethod) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.PaymentMethod, error)
+	GetDefault(ctx context.Context, ownerID uuid.UUID) (*models.PaymentMethod, error)
+	List(ctx context.Context, ownerID uuid.UUID, includeArchived bool) ([]models.PaymentMethod, error)
+	Update(ctx context.Context, m *models.PaymentMethod) error
+	SetDefault(ctx context.Context, id, ownerID uuid.UUID) error
+	Archive(ctx context.Context, id, ownerID uuid.UUID) error
+	ListPayments(ctx context.Context, id, ownerID uuid.UUID, filter *models.PaymentMethodPaymentsFilter) ([]models.Payment, int, error)
+}
+
+type paymentMethodRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPaymentMethodRepository cria uma nova instância do repositório de formas de pagamento
+func NewPaymentMethodRepository(db *pgxpool.Pool) PaymentMethodRepository {
+	return &paymentMethodRepository{db: db}
+}
+
+const paymentMethodColumns = `id, owner_id, tipo, nome, is_default, pix_chave, banco, agencia, conta, archived_at, created_at, updated_at`
+
+func scanPaymentMethod(row pgx.Row, m *models.PaymentMethod) error {
+	return row.Scan(&m.ID, &m.OwnerID, &m.Tipo, &m.Nome, &m.IsDefault, &m.PixChave, &m.Banco, &m.Agencia,
+		&m.Conta, &m.ArchivedAt, &m.CreatedAt, &m.UpdatedAt)
+}
+
+// mapPaymentMethodError traduz a violação do nome único para erro de domínio
+func mapPaymentMethodError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uq_payment_methods_owner_nome" {
+		return models.ErrPaymentMethodNameTaken
+	}
+	return err
+}
+
+// Create grava uma forma de pagamento; se marcada como padrão, desmarca a anterior na mesma transação
+func (r *paymentMethodRepository) Create(ctx context.Context, m *models.PaymentMethod) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if m.IsDefault {
+		if _, err := tx.Exec(ctx, `UPDATE rf_payment_methods SET is_default = false, updated_at = now() WHERE owner_id = $1 AND is_default`, m.OwnerID); err != nil {
+			return err
+		}
+	}
+	err = scanPaymentMethod(tx.QueryRow(ctx, `
+		INSERT INTO rf_payment_methods (owner_id, tipo, nome, is_default, pix_chave, banco, agencia, conta)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+paymentMethodColumns,
+		m.OwnerID, m.Tipo, m.Nome, m.IsDefault, m.PixChave, m.Banco, m.Agencia, m.Conta), m)
+	if err != nil {
+		return mapPaymentMethodError(err)
+	}
+	return tx.Commit(ctx)
+}
+
+// CreateDefaults cria as formas iniciais apenas se o usuário ainda não tem nenhuma (inclusive arquivadas)
+func (r *paymentMethodRepository) CreateDefaults(ctx context.Context, ownerID uuid.UUID, items []models.PaymentMethod) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Trava por usuário: duas listagens simultâneas não duplicam o catálogo inicial
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('rf_payment_methods:' || $1::text))`, ownerID); err != nil {
+		return err
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM rf_payment_methods WHERE owner_id = $1)`, ownerID).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	for _, m := range items {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO rf_payment_methods (owner_id, tipo, nome, is_default) VALUES ($1, $2, $3, $4)
+		`, ownerID, m.Tipo, m.Nome, m.IsDefault); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// GetByID busca uma forma de pagamento do usuário (inclusive arquivada)
+func (r *paymentMethodRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.PaymentMethod, error) {
+	var m models.PaymentMethod
+	err := scanPaymentMethod(r.db.QueryRow(ctx, `SELECT `+paymentMethodColumns+` FROM rf_payment_methods WHERE id = $1 AND owner_id = $2`, id, ownerID), &m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentMethodNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetDefault busca a forma padrão ativa do usuário
+func (r *paymentMethodRepository) GetDefault(ctx context.Context, ownerID uuid.UUID) (*models.PaymentMethod, error) {
+	var m models.PaymentMethod
+	err := scanPaymentMethod(r.db.QueryRow(ctx, `
+		SELECT `+paymentMethodColumns+` FROM rf_payment_methods
+		WHERE owner_id = $1 AND is_default AND archived_at IS NULL
+	`, ownerID), &m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentMethodNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// List lista as formas do usuário (padrão primeiro, depois por nome)
+func (r *paymentMethodRepository) List(ctx context.Context, ownerID uuid.UUID, includeArchived bool) ([]models.PaymentMethod, error) {
+	query := `SELECT ` + paymentMethodColumns + ` FROM rf_payment_methods WHERE owner_id = $1`
+	if !includeArchived {
+		query += ` AND archived_at IS NULL`
+	}
+	rows, err := r.db.Query(ctx, query+` ORDER BY is_default DESC, archived_at NULLS FIRST, lower(nome)`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.PaymentMethod{}
+	for rows.Next() {
+		var m models.PaymentMethod
+		if err := scanPaymentMethod(rows, &m); err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+	return items, rows.Err()
+}
+
+// Update atualiza nome, tipo e dados da forma; a marca de padrão muda apenas por SetDefault
+func (r *paymentMethodRepository) Update(ctx context.Context, m *models.PaymentMethod) error {
+	err := scanPaymentMethod(r.db.QueryRow(ctx, `
+		UPDATE rf_payment_methods
+		SET tipo = $3, nome = $4, pix_chave = $5, banco = $6, agencia = $7, conta = $8, updated_at = now()
+		WHERE id = $1 AND owner_id = $2 AND archived_at IS NULL
+		RETURNING `+paymentMethodColumns,
+		m.ID, m.OwnerID, m.Tipo, m.Nome, m.PixChave, m.Banco, m.Agencia, m.Conta), m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrPaymentMethodNotFound
+	}
+	return mapPaymentMethodError(err)
+}
+
+// SetDefault marca a forma como padrão e desmarca a anterior
+func (r *paymentMethodRepository) SetDefault(ctx context.Context, id, ownerID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var archivedAt *time.Time
+	err = tx.QueryRow(ctx, `SELECT archived_at FROM rf_payment_methods WHERE id = $1 AND owner_id = $2 FOR UPDATE`, id, ownerID).Scan(&archivedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrPaymentMethodNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if archivedAt != nil {
+		return models.ErrPaymentMethodArchived
+	}
+	if _, err := tx.Exec(ctx, `UPDATE rf_payment_methods SET is_default = false, updated_at = now() WHERE owner_id = $1 AND is_default AND id <> $2`, ownerID, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE rf_payment_methods SET is_default = true, updated_at = now() WHERE id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Archive arquiva a forma (os pagamentos antigos mantêm a referência)
+func (r *paymentMethodRepository) Archive(ctx context.Context, id, ownerID uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `
+		UPDATE rf_payment_methods SET archived_at = now(), is_default = false, updated_at = now()
+		WHERE id = $1 AND owner_id = $2 AND archived_at IS NULL
+	`, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrPaymentMethodNotFound
+	}
+	return nil
+}
+
+// ListPayments lista os pagamentos lançados com a forma, do mais recente ao mais antigo
+func (r *paymentMethodRepository) ListPayments(ctx context.Context, id, ownerID uuid.UUID, filter *models.PaymentMethodPaymentsFilter) ([]models.Payment, int, error) {
+	filter.SetDefaults()
+
+	var total int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM rf_payments p
+		JOIN rf_incomes i ON i.id = p.income_id
+		WHERE p.method_id = $1 AND i.owner_id = $2 AND i.deleted_at IS NULL
+	`, id, ownerID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT p.id, p.income_id, p.valor, p.pago_em, p.metodo, p.method_id, p.obs, p.created_at, p.reversed_at, p.reversal_reason
+		FROM rf_payments p
+		JOIN rf_incomes i ON i.id = p.income_id
+		WHERE p.method_id = $1 AND i.owner_id = $2 AND i.deleted_at IS NULL
+		ORDER BY p.pago_em DESC, p.id
+		LIMIT $3 OFFSET $4
+	`, id, ownerID, filter.PerPage, (filter.Page-1)*filter.PerPage)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.Payment{}
+	for rows.Next() {
+		var p models.Payment
+		if err := rows.Scan(&p.ID, &p.IncomeID, &p.Valor, &p.PagoEm, &p.Metodo, &p.MethodID, &p.Obs,
+			&p.CreatedAt, &p.ReversedAt, &p.ReversalReason); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, p)
+	}
+	return items, total, rows.Err()
+}
@@ -0,0 +1,169 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Exclusão e estorno de pagamentos em transação, com trilha de auditoria (rf_payment_reversals)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/locale"
+	"recibofast/internal/models"
+)
+
+// PaymentReversalRepository define as operações de desfazimento de pagamentos.
+// Docstring: Reverse trava receita e pagamento, exclui (kind "delete") ou marca como estornado
+// (kind "reverse"), acerta os créditos ligados ao pagamento, recalcula total pago e status da
+// receita e grava a auditoria, tudo na mesma transação. rev traz OwnerID, PaymentID, Kind e Reason; o restante é preenchido.
+type PaymentReversalRepository interface {
+	Reverse(ctx context.Context, rev *models.PaymentReversal) (*models.Income, error)
+	ListByIncome(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.PaymentReversal, error)
+}
+
+type paymentReversalRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPaymentReversalRepository cria uma nova instância do repositório de estornos
+func NewPaymentReversalRepository(db *pgxpool.Pool) PaymentReversalRepository {
+	return &paymentReversalRepository{db: db}
+}
+
+// Reverse exclui ou estorna o pagamento e atualiza a receita
+func (r *paymentReversalRepository) Reverse(ctx context.Context, rev *models.PaymentReversal) (*models.Income, error) {
+	return reversePaymentTx(ctx, r.db, rev, time.Now())
+}
+
+func reversePaymentTx(ctx context.Context, db paymentTxBeginner, rev *models.PaymentReversal, now time.Time) (*models.Income, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Trava a receita e o pagamento (a receita primeiro, na mesma ordem de AddPaymentTx)
+	var valor models.Money
+	var status string
+	var due *time.Time
+	p := &rev.Payment
+	err = tx.QueryRow(ctx, `
+		SELECT i.id, i.valor, i.total_pago, i.status, i.due_date
+		FROM rf_incomes i
+		WHERE i.id = (SELECT income_id FROM rf_payments WHERE id = $1) AND i.owner_id = $2 AND i.deleted_at IS NULL
+		FOR UPDATE
+	`, rev.PaymentID, rev.OwnerID).Scan(&rev.IncomeID, &valor, &rev.TotalPagoBefore, &status, &due)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao travar receita: %w", err)
+	}
+	err = tx.QueryRow(ctx, `
+		SELECT id, income_id, valor, pago_em, metodo, obs, created_at, reversed_at, reversal_reason, method_id
+		FROM rf_payments WHERE id = $1 AND income_id = $2
+		FOR UPDATE
+	`, rev.PaymentID, rev.IncomeID).Scan(&p.ID, &p.IncomeID, &p.Valor, &p.PagoEm, &p.Metodo, &p.Obs, &p.CreatedAt, &p.ReversedAt, &p.ReversalReason, &p.MethodID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao travar pagamento: %w", err)
+	}
+	if p.ReversedAt != nil {
+		return nil, models.ErrPaymentAlreadyReversed
+	}
+	rev.Valor, rev.StatusBefore = p.Valor, status
+
+	// Créditos do pagamento: aplicação volta ao saldo; crédito gerado por ele é anulado
+	if err := releasePaymentCredits(ctx, tx, rev.OwnerID, p); err != nil {
+		return nil, err
+	}
+
+	if rev.Kind == models.PaymentReversalDelete {
+		_, err = tx.Exec(ctx, `DELETE FROM rf_payments WHERE id = $1`, rev.PaymentID)
+	} else {
+		_, err = tx.Exec(ctx, `UPDATE rf_payments SET reversed_at = $2, reversal_reason = $3 WHERE id = $1`, rev.PaymentID, now, rev.Reason)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao desfazer pagamento: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `SELECT COALESCE(SUM(valor), 0) FROM rf_payments WHERE income_id = $1 AND reversed_at IS NULL`, rev.IncomeID).
+		Scan(&rev.TotalPagoAfter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao recalcular total pago: %w", err)
+	}
+	rev.StatusAfter = models.DeriveIncomeStatus(status, valor, rev.TotalPagoAfter, due, now, locale.FromContext(ctx).Location)
+
+	income := &models.Income{}
+	err = tx.QueryRow(ctx, `
+		UPDATE rf_incomes SET total_pago = $2, status = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, owner_id, contract_id, categoria, competencia, valor,
+		          status, due_date, total_pago, deleted_at, created_at, updated_at, payer_id, tags, version
+	`, rev.IncomeID, rev.TotalPagoAfter, rev.StatusAfter).Scan(
+		&income.ID, &income.OwnerID, &income.ContractID, &income.Categoria,
+		&income.Competencia, &income.Valor, &income.Status, &income.DueDate,
+		&income.TotalPago, &income.DeletedAt, &income.CreatedAt, &income.UpdatedAt, &income.PayerID, &income.Tags, &income.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar receita: %w", err)
+	}
+
+	snapshot, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_payment_reversals (owner_id, payment_id, income_id, kind, valor, payment, reason,
+			total_pago_before, total_pago_after, status_before, status_after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at
+	`, rev.OwnerID, rev.PaymentID, rev.IncomeID, rev.Kind, rev.Valor, snapshot, rev.Reason,
+		rev.TotalPagoBefore, rev.TotalPagoAfter, rev.StatusBefore, rev.StatusAfter).Scan(&rev.ID, &rev.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar auditoria do estorno: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar estorno: %w", err)
+	}
+	return income, nil
+}
+
+// ListByIncome lista exclusões e estornos de uma receita do usuário (mais recentes primeiro)
+func (r *paymentReversalRepository) ListByIncome(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.PaymentReversal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, owner_id, payment_id, income_id, kind, valor, payment, reason,
+		       total_pago_before, total_pago_after, status_before, status_after, created_at
+		FROM rf_payment_reversals
+		WHERE income_id = $1 AND owner_id = $2
+		ORDER BY created_at DESC
+	`, incomeID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []models.PaymentReversal{}
+	for rows.Next() {
+		var rev models.PaymentReversal
+		var snapshot []byte
+		if err := rows.Scan(&rev.ID, &rev.OwnerID, &rev.PaymentID, &rev.IncomeID, &rev.Kind, &rev.Valor, &snapshot, &rev.Reason,
+			&rev.TotalPagoBefore, &rev.TotalPagoAfter, &rev.StatusBefore, &rev.StatusAfter, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(snapshot, &rev.Payment); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar pagamento da auditoria: %w", err)
+		}
+		items = append(items, rev)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,196 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da transação de exclusão e estorno de pagamentos
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"recibofast/internal/models"
+)
+
+// fakeReversalTx simula a transação de reversePaymentTx: os QueryRow são, em ordem, trava da
+// receita, trava do pagamento, soma dos pagamentos, atualização da receita e auditoria. A trava do
+// crédito gerado pelo pagamento (rf_credits) não conta passo: devolve sourceCredit ou nenhuma linha.
+// applied é o valor da aplicação de crédito do pagamento (rf_credit_applications), devolvido ao
+// appliedFrom pelo Exec correspondente.
+type fakeReversalTx struct {
+	fakePaymentTx
+	payment      models.Money
+	metodo       *string
+	remaining    models.Money
+	reversed     bool
+	sourceCredit *models.Credit
+	applied      models.Money
+	appliedFrom  *models.Credit
+	execs        []string
+}
+
+func (f *fakeReversalTx) Begin(ctx context.Context) (pgx.Tx, error) { return f, nil }
+
+func (f *fakeReversalTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.execs = append(f.execs, sql)
+	switch {
+	case strings.Contains(sql, "DELETE FROM rf_credit_applications"):
+		if f.appliedFrom != nil && f.applied > 0 {
+			f.appliedFrom.Saldo += f.applied
+			f.applied = 0
+		}
+	case strings.Contains(sql, "DELETE FROM rf_credits"):
+		f.sourceCredit = nil
+	}
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeReversalTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if strings.Contains(sql, "FROM rf_credits") {
+		return fakeCreditRow{credit: f.sourceCredit}
+	}
+	f.steps++
+	return fakeReversalRow{tx: f, step: f.steps}
+}
+
+type fakeCreditRow struct {
+	credit *models.Credit
+}
+
+func (r fakeCreditRow) Scan(dest ...any) error {
+	if r.credit == nil {
+		return pgx.ErrNoRows
+	}
+	*dest[0].(*uuid.UUID), *dest[1].(*models.Money), *dest[2].(*models.Money) = r.credit.ID, r.credit.Valor, r.credit.Saldo
+	return nil
+}
+
+type fakeReversalRow struct {
+	tx   *fakeReversalTx
+	step int
+}
+
+func (r fakeReversalRow) Scan(dest ...any) error {
+	if r.step == r.tx.failAt {
+		return errors.New("conexão perdida")
+	}
+	switch r.step {
+	case 1:
+		*dest[1].(*models.Money), *dest[2].(*models.Money) = r.tx.valor, r.tx.totalPago
+		*dest[3].(*string) = models.StatusPago
+	case 2:
+		*dest[2].(*models.Money) = r.tx.payment
+		*dest[4].(**string) = r.tx.metodo
+		if r.tx.reversed {
+			at := time.Now()
+			*dest[7].(**time.Time) = &at
+		}
+	case 3:
+		*dest[0].(*models.Money) = r.tx.remaining
+	}
+	return nil
+}
+
+func TestReversePaymentTx_RecalculatesAndAudits(t *testing.T) {
+	for _, kind := range []string{models.PaymentReversalDelete, models.PaymentReversalReverse} {
+		tx := &fakeReversalTx{fakePaymentTx: fakePaymentTx{valor: models.NewMoney(100), totalPago: models.NewMoney(100)},
+			payment: models.NewMoney(60), remaining: models.NewMoney(40)}
+		rev := &models.PaymentReversal{OwnerID: uuid.New(), PaymentID: uuid.New(), Kind: kind}
+
+		if _, err := reversePaymentTx(context.Background(), tx, rev, time.Now()); err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+		if tx.steps != 5 || len(tx.execs) != 1 || !tx.committed {
+			t.Fatalf("%s: passos = %d, execs = %d, commit = %v", kind, tx.steps, len(tx.execs), tx.committed)
+		}
+		if rev.Valor != models.NewMoney(60) || rev.TotalPagoAfter != models.NewMoney(40) ||
+			rev.StatusBefore != models.StatusPago || rev.StatusAfter != models.StatusParcial {
+			t.Fatalf("%s: auditoria inesperada %+v", kind, rev)
+		}
+	}
+}
+
+func TestReversePaymentTx_RollsBackOnFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		tx        *fakeReversalTx
+		wantErr   error
+		wantSteps int
+	}{
+		{"pagamento inexistente", &fakeReversalTx{fakePaymentTx: fakePaymentTx{failAt: 2}}, nil, 2},
+		{"já estornado", &fakeReversalTx{reversed: true}, models.ErrPaymentAlreadyReversed, 2},
+		{"falha no recálculo", &fakeReversalTx{fakePaymentTx: fakePaymentTx{failAt: 3}}, nil, 3},
+		{"falha na auditoria", &fakeReversalTx{fakePaymentTx: fakePaymentTx{failAt: 5}}, nil, 5},
+		{"falha no commit", &fakeReversalTx{fakePaymentTx: fakePaymentTx{commitErr: errors.New("serialização")}}, nil, 5},
+	}
+	for _, c := range cases {
+		rev := &models.PaymentReversal{OwnerID: uuid.New(), PaymentID: uuid.New(), Kind: models.PaymentReversalDelete}
+		income, err := reversePaymentTx(context.Background(), c.tx, rev, time.Now())
+		if err == nil || income != nil {
+			t.Fatalf("%s: esperava erro, got income=%v", c.name, income)
+		}
+		if c.wantErr != nil && !errors.Is(err, c.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", c.name, err, c.wantErr)
+		}
+		if c.tx.steps != c.wantSteps || c.tx.committed || !c.tx.rolledBack {
+			t.Fatalf("%s: passos = %d, commit = %v, rollback = %v", c.name, c.tx.steps, c.tx.committed, c.tx.rolledBack)
+		}
+	}
+}
+
+func TestReversePaymentTx_CreditPaymentRestoresSaldo(t *testing.T) {
+	metodo := models.CreditPaymentMethod
+	for _, kind := range []string{models.PaymentReversalDelete, models.PaymentReversalReverse} {
+		credit := &models.Credit{ID: uuid.New(), Valor: models.NewMoney(50), Saldo: models.NewMoney(20)}
+		tx := &fakeReversalTx{fakePaymentTx: fakePaymentTx{valor: models.NewMoney(100), totalPago: models.NewMoney(100)},
+			payment: models.NewMoney(30), metodo: &metodo, remaining: models.NewMoney(70), applied: models.NewMoney(30), appliedFrom: credit}
+		rev := &models.PaymentReversal{OwnerID: uuid.New(), PaymentID: uuid.New(), Kind: kind}
+
+		if _, err := reversePaymentTx(context.Background(), tx, rev, time.Now()); err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+		// a aplicação sai e o valor volta ao crédito, na mesma transação do estorno
+		if credit.Saldo != credit.Valor || tx.applied != 0 || !tx.committed {
+			t.Fatalf("%s: saldo = %s, aplicação restante = %s, commit = %v", kind, credit.Saldo, tx.applied, tx.committed)
+		}
+		if len(tx.execs) != 2 || !strings.Contains(tx.execs[0], "rf_credit_applications") {
+			t.Fatalf("%s: execs = %v", kind, tx.execs)
+		}
+	}
+}
+
+func TestReversePaymentTx_SourcePaymentVoidsUnusedCredit(t *testing.T) {
+	credit := &models.Credit{ID: uuid.New(), Valor: models.NewMoney(25), Saldo: models.NewMoney(25)}
+	tx := &fakeReversalTx{fakePaymentTx: fakePaymentTx{valor: models.NewMoney(100), totalPago: models.NewMoney(100)},
+		payment: models.NewMoney(100), sourceCredit: credit}
+	rev := &models.PaymentReversal{OwnerID: uuid.New(), PaymentID: uuid.New(), Kind: models.PaymentReversalReverse}
+
+	if _, err := reversePaymentTx(context.Background(), tx, rev, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if tx.sourceCredit != nil || !tx.committed {
+		t.Fatalf("crédito do excedente deveria ser anulado (restante = %+v, commit = %v)", tx.sourceCredit, tx.committed)
+	}
+}
+
+func TestReversePaymentTx_SourcePaymentWithAppliedCreditIsBlocked(t *testing.T) {
+	credit := &models.Credit{ID: uuid.New(), Valor: models.NewMoney(25), Saldo: models.NewMoney(5)}
+	tx := &fakeReversalTx{fakePaymentTx: fakePaymentTx{valor: models.NewMoney(100), totalPago: models.NewMoney(100)},
+		payment: models.NewMoney(100), sourceCredit: credit}
+	rev := &models.PaymentReversal{OwnerID: uuid.New(), PaymentID: uuid.New(), Kind: models.PaymentReversalDelete}
+
+	income, err := reversePaymentTx(context.Background(), tx, rev, time.Now())
+	if !errors.Is(err, models.ErrCreditAlreadyApplied) || income != nil {
+		t.Fatalf("err = %v, want ErrCreditAlreadyApplied", err)
+	}
+	// nada foi excluído: o pagamento segue e o crédito fica como estava
+	if len(tx.execs) != 0 || tx.sourceCredit == nil || tx.committed || !tx.rolledBack {
+		t.Fatalf("execs = %v, commit = %v, rollback = %v", tx.execs, tx.committed, tx.rolledBack)
+	}
+}
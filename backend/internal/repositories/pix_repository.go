@@ -0,0 +1,105 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório das cobranças PIX das receitas (rf_pix_charges)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// PixChargeRepository define a persistência das cobranças PIX.
+// Docstring: o txid é único por provedor (uq_pix_charges_txid); GetActive devolve a cobrança
+// ativa e ainda válida mais recente da receita, reaproveitada enquanto o valor não muda.
+// ClaimPaid, Release e SetPayment servem ao webhook do PSP e não filtram por usuário.
+type PixChargeRepository interface {
+	Create(ctx context.Context, c *models.PixCharge) error
+	GetActive(ctx context.Context, incomeID, ownerID uuid.UUID, now time.Time) (*models.PixCharge, error)
+	ClaimPaid(ctx context.Context, provider, txid string, endToEndID *string, paidAt time.Time) (*models.PixCharge, error)
+	Release(ctx context.Context, id uuid.UUID) error
+	SetPayment(ctx context.Context, id, paymentID uuid.UUID) error
+}
+
+type pixChargeRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPixChargeRepository cria uma nova instância do repositório de cobranças PIX
+func NewPixChargeRepository(db *pgxpool.Pool) PixChargeRepository {
+	return &pixChargeRepository{db: db}
+}
+
+const pixChargeColumns = `id, owner_id, income_id, provider, txid, valor, br_code, location, status, expires_at, created_at, paid_at,
+	end_to_end_id, payment_id`
+
+func scanPixCharge(row pgx.Row, c *models.PixCharge) error {
+	return row.Scan(&c.ID, &c.OwnerID, &c.IncomeID, &c.Provider, &c.TxID, &c.Valor, &c.BRCode, &c.Location,
+		&c.Status, &c.ExpiresAt, &c.CreatedAt, &c.PaidAt, &c.EndToEndID, &c.PaymentID)
+}
+
+// Create grava a cobrança criada no PSP
+func (r *pixChargeRepository) Create(ctx context.Context, c *models.PixCharge) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return scanPixCharge(r.db.QueryRow(ctx, `
+		INSERT INTO rf_pix_charges (id, owner_id, income_id, provider, txid, valor, br_code, location, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+pixChargeColumns,
+		c.ID, c.OwnerID, c.IncomeID, c.Provider, c.TxID, c.Valor, c.BRCode, c.Location, c.ExpiresAt), c)
+}
+
+// GetActive cobrança ativa e não vencida mais recente da receita
+func (r *pixChargeRepository) GetActive(ctx context.Context, incomeID, ownerID uuid.UUID, now time.Time) (*models.PixCharge, error) {
+	var c models.PixCharge
+	err := scanPixCharge(r.db.QueryRow(ctx, `
+		SELECT `+pixChargeColumns+` FROM rf_pix_charges
+		WHERE income_id = $1 AND owner_id = $2 AND status = 'active' AND expires_at > $3
+		ORDER BY created_at DESC LIMIT 1`, incomeID, ownerID, now), &c)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPixChargeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// ClaimPaid marca a cobrança como paga; só uma notificação consegue (as repetidas recebem
+// ErrPixChargeNotFound, assim como txids desconhecidos ou cobranças canceladas)
+func (r *pixChargeRepository) ClaimPaid(ctx context.Context, provider, txid string, endToEndID *string, paidAt time.Time) (*models.PixCharge, error) {
+	var c models.PixCharge
+	err := scanPixCharge(r.db.QueryRow(ctx, `
+		UPDATE rf_pix_charges SET status = 'paid', paid_at = $3, end_to_end_id = $4
+		WHERE provider = $1 AND txid = $2 AND status IN ('active', 'expired')
+		RETURNING `+pixChargeColumns, provider, txid, paidAt, endToEndID), &c)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPixChargeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Release devolve ao estado ativo a cobrança cujo pagamento não pôde ser lançado (o PSP reenvia)
+func (r *pixChargeRepository) Release(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE rf_pix_charges SET status = 'active', paid_at = NULL, end_to_end_id = NULL
+		WHERE id = $1 AND status = 'paid' AND payment_id IS NULL`, id)
+	return err
+}
+
+// SetPayment liga a cobrança paga ao pagamento lançado na receita
+func (r *pixChargeRepository) SetPayment(ctx context.Context, id, paymentID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `UPDATE rf_pix_charges SET payment_id = $2 WHERE id = $1`, id, paymentID)
+	return err
+}
@@ -0,0 +1,69 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório do perfil do usuário (rf_profiles)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// ProfileRepository leitura e gravação do perfil do usuário
+type ProfileRepository interface {
+	Get(ctx context.Context, ownerID uuid.UUID) (*models.Profile, error)
+	Upsert(ctx context.Context, p *models.Profile) error
+}
+
+type profileRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewProfileRepository cria uma nova instância do repositório de perfis
+func NewProfileRepository(db *pgxpool.Pool) ProfileRepository {
+	return &profileRepository{db: db}
+}
+
+const profileColumns = `id, nome, documento, endereco, telefone, emissor_nome, emissor_documento, locale, timezone,
+	created_at, updated_at`
+
+func scanProfile(row pgx.Row, p *models.Profile) error {
+	return row.Scan(&p.ID, &p.Nome, &p.Documento, &p.Endereco, &p.Telefone, &p.EmissorNome, &p.EmissorDocumento,
+		&p.Locale, &p.Timezone, &p.CreatedAt, &p.UpdatedAt)
+}
+
+// Get retorna o perfil do usuário; sem linha em rf_profiles, retorna um perfil vazio
+func (r *profileRepository) Get(ctx context.Context, ownerID uuid.UUID) (*models.Profile, error) {
+	p := &models.Profile{ID: ownerID}
+	err := scanProfile(r.db.QueryRow(ctx, `SELECT `+profileColumns+` FROM rf_profiles WHERE id = $1`, ownerID), p)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Upsert grava todos os campos do perfil (cria a linha de rf_profiles se preciso)
+func (r *profileRepository) Upsert(ctx context.Context, p *models.Profile) error {
+	row := r.db.QueryRow(ctx, `
+		INSERT INTO rf_profiles (id, nome, documento, endereco, telefone, emissor_nome, emissor_documento,
+			locale, timezone, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			nome = EXCLUDED.nome, documento = EXCLUDED.documento, endereco = EXCLUDED.endereco,
+			telefone = EXCLUDED.telefone, emissor_nome = EXCLUDED.emissor_nome,
+			emissor_documento = EXCLUDED.emissor_documento,
+			locale = EXCLUDED.locale, timezone = EXCLUDED.timezone, updated_at = NOW()
+		RETURNING `+profileColumns,
+		p.ID, p.Nome, p.Documento, p.Endereco, p.Telefone, p.EmissorNome, p.EmissorDocumento,
+		p.Locale, p.Timezone)
+	return scanProfile(row, p)
+}
@@ -0,0 +1,125 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório das assinaturas Web Push por dispositivo (rf_push_subscriptions)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// PushSubscriptionRepository define a persistência das assinaturas push.
+// Docstring: Upsert usa o endpoint como chave e mantém no máximo models.MaxPushSubscriptions
+// por usuário, descartando as mais antigas.
+type PushSubscriptionRepository interface {
+	Upsert(ctx context.Context, s *models.PushSubscription) error
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.PushSubscription, error)
+	GetByEndpoint(ctx context.Context, ownerID uuid.UUID, endpoint string) (*models.PushSubscription, error)
+	Delete(ctx context.Context, ownerID uuid.UUID, endpoint string) error
+	Touch(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type pushSubscriptionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPushSubscriptionRepository cria uma nova instância do repositório de assinaturas push
+func NewPushSubscriptionRepository(db *pgxpool.Pool) PushSubscriptionRepository {
+	return &pushSubscriptionRepository{db: db}
+}
+
+const pushSubscriptionColumns = `id, owner_id, endpoint, p256dh, auth, user_agent, created_at, last_used_at`
+
+func scanPushSubscription(row pgx.Row, s *models.PushSubscription) error {
+	return row.Scan(&s.ID, &s.OwnerID, &s.Endpoint, &s.P256dh, &s.Auth, &s.UserAgent, &s.CreatedAt, &s.LastUsedAt)
+}
+
+// Upsert registra o dispositivo (ou atualiza as chaves do endpoint já registrado) e poda o excedente
+func (r *pushSubscriptionRepository) Upsert(ctx context.Context, s *models.PushSubscription) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = scanPushSubscription(tx.QueryRow(ctx, `
+		INSERT INTO rf_push_subscriptions (owner_id, endpoint, p256dh, auth, user_agent)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (endpoint) DO UPDATE
+		SET owner_id = EXCLUDED.owner_id, p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth,
+		    user_agent = EXCLUDED.user_agent, created_at = now()
+		RETURNING `+pushSubscriptionColumns,
+		s.OwnerID, s.Endpoint, s.P256dh, s.Auth, s.UserAgent), s)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		DELETE FROM rf_push_subscriptions
+		WHERE owner_id = $1 AND id NOT IN (
+			SELECT id FROM rf_push_subscriptions WHERE owner_id = $1 ORDER BY created_at DESC LIMIT $2
+		)`, s.OwnerID, models.MaxPushSubscriptions)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// List dispositivos registrados do usuário, do mais recente ao mais antigo
+func (r *pushSubscriptionRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.PushSubscription, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+pushSubscriptionColumns+` FROM rf_push_subscriptions WHERE owner_id = $1 ORDER BY created_at DESC`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.PushSubscription{}
+	for rows.Next() {
+		var s models.PushSubscription
+		if err := scanPushSubscription(rows, &s); err != nil {
+			return nil, err
+		}
+		items = append(items, s)
+	}
+	return items, rows.Err()
+}
+
+// GetByEndpoint assinatura do usuário pelo endpoint (destino das entregas do canal push)
+func (r *pushSubscriptionRepository) GetByEndpoint(ctx context.Context, ownerID uuid.UUID, endpoint string) (*models.PushSubscription, error) {
+	var s models.PushSubscription
+	err := scanPushSubscription(r.db.QueryRow(ctx,
+		`SELECT `+pushSubscriptionColumns+` FROM rf_push_subscriptions WHERE owner_id = $1 AND endpoint = $2`,
+		ownerID, endpoint), &s)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPushSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Delete remove o dispositivo do usuário
+func (r *pushSubscriptionRepository) Delete(ctx context.Context, ownerID uuid.UUID, endpoint string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rf_push_subscriptions WHERE owner_id = $1 AND endpoint = $2`, ownerID, endpoint)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrPushSubscriptionNotFound
+	}
+	return nil
+}
+
+// Touch registra o último envio aceito pelo serviço de push
+func (r *pushSubscriptionRepository) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE rf_push_subscriptions SET last_used_at = $2 WHERE id = $1`, id, at)
+	return err
+}
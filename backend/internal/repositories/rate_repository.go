@@ -0,0 +1,142 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório do histórico de cotações e índices (rf_rates)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// RateRepository define a persistência das cotações.
+// Docstring: Upsert nunca altera linhas com fonte manual; a sobrescrita só sai por ClearOverride.
+type RateRepository interface {
+	Upsert(ctx context.Context, rates []models.Rate) (int, error)
+	SetOverride(ctx context.Context, r *models.Rate) error
+	ClearOverride(ctx context.Context, indice string, data time.Time) error
+	List(ctx context.Context, indice string, from, to time.Time) ([]models.Rate, error)
+	At(ctx context.Context, indice string, data time.Time) (*models.Rate, error)
+	LastDate(ctx context.Context, indice string) (*time.Time, error)
+}
+
+type rateRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRateRepository cria uma nova instância do repositório de cotações
+func NewRateRepository(db *pgxpool.Pool) RateRepository {
+	return &rateRepository{db: db}
+}
+
+const rateColumns = `indice, data, valor, fonte, observacao, fetched_at, updated_at`
+
+func scanRate(row pgx.Row, r *models.Rate) error {
+	return row.Scan(&r.Indice, &r.Data, &r.Valor, &r.Fonte, &r.Observacao, &r.FetchedAt, &r.UpdatedAt)
+}
+
+// Upsert grava as cotações buscadas; retorna quantas linhas foram inseridas ou alteradas
+func (r *rateRepository) Upsert(ctx context.Context, rates []models.Rate) (int, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+	query := `
+		INSERT INTO rf_rates (indice, data, valor, fonte, fetched_at)
+		VALUES ($1, $2, $3, 'bcb', $4)
+		ON CONFLICT (indice, data) DO UPDATE
+		SET valor = EXCLUDED.valor, fetched_at = EXCLUDED.fetched_at
+		WHERE rf_rates.fonte <> 'manual' AND rf_rates.valor IS DISTINCT FROM EXCLUDED.valor
+	`
+	batch := &pgx.Batch{}
+	for _, rt := range rates {
+		batch.Queue(query, rt.Indice, rt.Data, rt.Valor, rt.FetchedAt)
+	}
+	br := r.db.SendBatch(ctx, batch)
+	defer br.Close()
+
+	stored := 0
+	for range rates {
+		cmd, err := br.Exec()
+		if err != nil {
+			return stored, err
+		}
+		stored += int(cmd.RowsAffected())
+	}
+	return stored, nil
+}
+
+// SetOverride grava (ou substitui) a cotação manual do índice na data
+func (r *rateRepository) SetOverride(ctx context.Context, rt *models.Rate) error {
+	query := `
+		INSERT INTO rf_rates (indice, data, valor, fonte, observacao)
+		VALUES ($1, $2, $3, 'manual', $4)
+		ON CONFLICT (indice, data) DO UPDATE
+		SET valor = EXCLUDED.valor, fonte = 'manual', observacao = EXCLUDED.observacao
+		RETURNING ` + rateColumns
+	return scanRate(r.db.QueryRow(ctx, query, rt.Indice, rt.Data, rt.Valor, rt.Observacao), rt)
+}
+
+// ClearOverride remove a cotação manual; a próxima busca (ou backfill) repõe o valor oficial
+func (r *rateRepository) ClearOverride(ctx context.Context, indice string, data time.Time) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM rf_rates WHERE indice = $1 AND data = $2 AND fonte = 'manual'`, indice, data)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrRateNotFound
+	}
+	return nil
+}
+
+// List lista as cotações do índice no intervalo [from, to]
+func (r *rateRepository) List(ctx context.Context, indice string, from, to time.Time) ([]models.Rate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+rateColumns+` FROM rf_rates
+		WHERE indice = $1 AND data BETWEEN $2 AND $3
+		ORDER BY data
+	`, indice, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []models.Rate{}
+	for rows.Next() {
+		var rt models.Rate
+		if err := scanRate(rows, &rt); err != nil {
+			return nil, err
+		}
+		items = append(items, rt)
+	}
+	return items, rows.Err()
+}
+
+// At retorna a cotação vigente na data (a última publicada até ela, ex.: fins de semana na PTAX)
+func (r *rateRepository) At(ctx context.Context, indice string, data time.Time) (*models.Rate, error) {
+	var rt models.Rate
+	err := scanRate(r.db.QueryRow(ctx, `
+		SELECT `+rateColumns+` FROM rf_rates
+		WHERE indice = $1 AND data <= $2
+		ORDER BY data DESC
+		LIMIT 1
+	`, indice, data), &rt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrRateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rt, nil
+}
+
+// LastDate data da cotação mais recente do índice (nil se não houver)
+func (r *rateRepository) LastDate(ctx context.Context, indice string) (*time.Time, error) {
+	var last *time.Time
+	err := r.db.QueryRow(ctx, `SELECT MAX(data) FROM rf_rates WHERE indice = $1 AND fonte = 'bcb'`, indice).Scan(&last)
+	return last, err
+}
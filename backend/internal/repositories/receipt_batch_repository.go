@@ -0,0 +1,263 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório dos lotes de recibos (rf_receipt_batches) e da situação de cada receita
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// ReceiptBatchRepository define as operações dos lotes de recibos.
+// Docstring: Create grava o lote e as receitas pagas sem recibo da competência na mesma transação;
+// ClaimPending usa FOR UPDATE SKIP LOCKED como os envios em massa e lotes presos em "running" além
+// do lockTimeout voltam a ser elegíveis. ListOpenItems só devolve itens pending e issued, então o
+// reprocessamento continua de onde o worker parou.
+type ReceiptBatchRepository interface {
+	Create(ctx context.Context, b *models.ReceiptBatch, competencias []string) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.ReceiptBatch, error)
+	List(ctx context.Context, ownerID uuid.UUID, limit int) ([]models.ReceiptBatch, error)
+	ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.ReceiptBatch, error)
+	ListOpenItems(ctx context.Context, batchID uuid.UUID) ([]models.ReceiptBatchItem, error)
+	HasReceipt(ctx context.Context, ownerID, incomeID uuid.UUID) (bool, error)
+	UpdateItem(ctx context.Context, it *models.ReceiptBatchItem, now time.Time) error
+	Requeue(ctx context.Context, b *models.ReceiptBatch) error
+	Finish(ctx context.Context, b *models.ReceiptBatch, now time.Time) error
+}
+
+type receiptBatchRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReceiptBatchRepository cria uma nova instância do repositório de lotes de recibos
+func NewReceiptBatchRepository(db *pgxpool.Pool) ReceiptBatchRepository {
+	return &receiptBatchRepository{db: db}
+}
+
+const receiptBatchColumns = `b.id, b.owner_id, b.competencia, b.locale, b.timezone, b.status, b.attempts, b.total,
+	b.last_error, b.created_at, b.started_at, b.finished_at`
+
+// receiptBatchProgress contadores por status dos itens, para as leituras do lote
+const receiptBatchProgress = `,
+	COALESCE(c.pending, 0), COALESCE(c.issued, 0), COALESCE(c.done, 0), COALESCE(c.skipped, 0), COALESCE(c.failed, 0)
+	FROM rf_receipt_batches b
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+		       COUNT(*) FILTER (WHERE status = 'issued') AS issued,
+		       COUNT(*) FILTER (WHERE status = 'done') AS done,
+		       COUNT(*) FILTER (WHERE status = 'skipped') AS skipped,
+		       COUNT(*) FILTER (WHERE status = 'failed') AS failed
+		FROM rf_receipt_batch_items WHERE batch_id = b.id
+	) c ON true`
+
+func scanReceiptBatch(row pgx.Row, b *models.ReceiptBatch, progress bool) error {
+	dest := []interface{}{&b.ID, &b.OwnerID, &b.Competencia, &b.Locale, &b.Timezone, &b.Status, &b.Attempts,
+		&b.Total, &b.LastError, &b.CreatedAt, &b.StartedAt, &b.FinishedAt}
+	if progress {
+		dest = append(dest, &b.Pending, &b.Issued, &b.Done, &b.Skipped, &b.Failed)
+	}
+	return row.Scan(dest...)
+}
+
+// Create grava o lote (status queued) e uma linha por receita paga da competência sem recibo.
+// Docstring: um lote sem receitas já nasce completed.
+func (r *receiptBatchRepository) Create(ctx context.Context, b *models.ReceiptBatch, competencias []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_receipt_batches (owner_id, competencia, locale, timezone, status)
+		VALUES ($1, $2, $3, $4, 'queued')
+		RETURNING id, created_at
+	`, b.OwnerID, b.Competencia, b.Locale, b.Timezone).Scan(&b.ID, &b.CreatedAt)
+	if isConstraintViolation(err, pgUniqueViolation, "uq_receipt_batches_active") {
+		return models.ErrReceiptBatchInProgress
+	}
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO rf_receipt_batch_items (batch_id, income_id, owner_id)
+		SELECT $1, i.id, i.owner_id
+		FROM rf_incomes i
+		WHERE i.owner_id = $2 AND i.deleted_at IS NULL AND i.status = 'pago' AND i.competencia = ANY($3)
+		  AND NOT EXISTS (SELECT 1 FROM rf_receipts rc WHERE rc.owner_id = i.owner_id AND rc.income_id = i.id)
+	`, b.ID, b.OwnerID, competencias)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE rf_receipt_batches
+		SET total = $2,
+		    status = CASE WHEN $2 = 0 THEN 'completed' ELSE status END,
+		    finished_at = CASE WHEN $2 = 0 THEN now() END
+		WHERE id = $1
+		RETURNING total, status, finished_at
+	`, b.ID, tag.RowsAffected()).Scan(&b.Total, &b.Status, &b.FinishedAt)
+	if err != nil {
+		return err
+	}
+	b.Pending = b.Total
+	return tx.Commit(ctx)
+}
+
+// GetByID busca um lote do usuário com o andamento e a situação de cada receita
+func (r *receiptBatchRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.ReceiptBatch, error) {
+	var b models.ReceiptBatch
+	err := scanReceiptBatch(r.db.QueryRow(ctx, `SELECT `+receiptBatchColumns+receiptBatchProgress+`
+		WHERE b.id = $1 AND b.owner_id = $2`, id, ownerID), &b, true)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrReceiptBatchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT it.batch_id, it.income_id, it.owner_id, it.receipt_id, rc.numero_formatado, it.status, it.error, it.updated_at
+		FROM rf_receipt_batch_items it
+		LEFT JOIN rf_receipts rc ON rc.id = it.receipt_id
+		WHERE it.batch_id = $1
+		ORDER BY rc.numero NULLS LAST, it.income_id
+	`, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	b.Items = []models.ReceiptBatchItem{}
+	for rows.Next() {
+		var it models.ReceiptBatchItem
+		if err := rows.Scan(&it.BatchID, &it.IncomeID, &it.OwnerID, &it.ReceiptID, &it.NumeroFormatado,
+			&it.Status, &it.Error, &it.UpdatedAt); err != nil {
+			return nil, err
+		}
+		b.Items = append(b.Items, it)
+	}
+	return &b, rows.Err()
+}
+
+// List lista os lotes mais recentes do usuário (sem os itens)
+func (r *receiptBatchRepository) List(ctx context.Context, ownerID uuid.UUID, limit int) ([]models.ReceiptBatch, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+receiptBatchColumns+receiptBatchProgress+`
+		WHERE b.owner_id = $1 ORDER BY b.created_at DESC LIMIT $2`, ownerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.ReceiptBatch{}
+	for rows.Next() {
+		var b models.ReceiptBatch
+		if err := scanReceiptBatch(rows, &b, true); err != nil {
+			return nil, err
+		}
+		items = append(items, b)
+	}
+	return items, rows.Err()
+}
+
+// ClaimPending reserva até limit lotes na fila, marcando-os como "running" e contando a tentativa
+func (r *receiptBatchRepository) ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.ReceiptBatch, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE rf_receipt_batches b
+		SET status = 'running', started_at = $1, attempts = b.attempts + 1
+		WHERE b.id IN (
+			SELECT id FROM rf_receipt_batches
+			WHERE status = 'queued' OR (status = 'running' AND started_at < $2)
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+receiptBatchColumns, now, now.Add(-lockTimeout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.ReceiptBatch
+	for rows.Next() {
+		var b models.ReceiptBatch
+		if err := scanReceiptBatch(rows, &b, false); err != nil {
+			return nil, err
+		}
+		items = append(items, b)
+	}
+	return items, rows.Err()
+}
+
+// ListOpenItems itens ainda sem PDF (pending e issued) na ordem de vencimento das receitas
+func (r *receiptBatchRepository) ListOpenItems(ctx context.Context, batchID uuid.UUID) ([]models.ReceiptBatchItem, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT it.batch_id, it.income_id, it.owner_id, it.receipt_id, it.status, it.error, it.updated_at
+		FROM rf_receipt_batch_items it
+		LEFT JOIN rf_incomes i ON i.id = it.income_id AND i.owner_id = it.owner_id
+		WHERE it.batch_id = $1 AND it.status IN ('pending', 'issued')
+		ORDER BY i.due_date NULLS LAST, it.income_id
+	`, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.ReceiptBatchItem
+	for rows.Next() {
+		var it models.ReceiptBatchItem
+		if err := rows.Scan(&it.BatchID, &it.IncomeID, &it.OwnerID, &it.ReceiptID, &it.Status, &it.Error, &it.UpdatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// HasReceipt indica se a receita já tem recibo
+func (r *receiptBatchRepository) HasReceipt(ctx context.Context, ownerID, incomeID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM rf_receipts WHERE owner_id = $1 AND income_id = $2)`,
+		ownerID, incomeID).Scan(&exists)
+	return exists, err
+}
+
+// UpdateItem grava o recibo, o status e o erro de uma receita do lote
+func (r *receiptBatchRepository) UpdateItem(ctx context.Context, it *models.ReceiptBatchItem, now time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE rf_receipt_batch_items SET receipt_id = $3, status = $4, error = $5, updated_at = $6
+		WHERE batch_id = $1 AND income_id = $2
+	`, it.BatchID, it.IncomeID, it.ReceiptID, it.Status, it.Error, now)
+	if err == nil {
+		it.UpdatedAt = now
+	}
+	return err
+}
+
+// Requeue devolve o lote à fila após uma falha transitória
+func (r *receiptBatchRepository) Requeue(ctx context.Context, b *models.ReceiptBatch) error {
+	_, err := r.db.Exec(ctx, `UPDATE rf_receipt_batches SET status = 'queued', last_error = $2 WHERE id = $1`, b.ID, b.LastError)
+	if err == nil {
+		b.Status = models.ReceiptBatchStatusQueued
+	}
+	return err
+}
+
+// Finish grava a situação final do lote
+func (r *receiptBatchRepository) Finish(ctx context.Context, b *models.ReceiptBatch, now time.Time) error {
+	return r.db.QueryRow(ctx, `
+		UPDATE rf_receipt_batches SET status = $2, last_error = $3, finished_at = $4
+		WHERE id = $1
+		RETURNING finished_at
+	`, b.ID, b.Status, b.LastError, now).Scan(&b.FinishedAt)
+}
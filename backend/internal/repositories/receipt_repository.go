@@ -9,6 +9,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -23,9 +25,23 @@ var (
 type ReceiptRepository interface {
 	Create(ctx context.Context, r *models.Receipt) error
 	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Receipt, error)
-	List(ctx context.Context, ownerID uuid.UUID, page, limit int) ([]models.Receipt, int, error)
+	List(ctx context.Context, ownerID uuid.UUID, filter *models.ReceiptFilter) ([]models.Receipt, int, error)
 	Update(ctx context.Context, r *models.Receipt) error
+	SetPDF(ctx context.Context, id, ownerID uuid.UUID, pdfURL, hash string) (*models.Receipt, error)
 	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+	NumberingReport(ctx context.Context, ownerID uuid.UUID) (*models.NumberingReport, error)
+	CreateNumberGap(ctx context.Context, j *models.NumberGapJustification) error
+	CheckConsistency(ctx context.Context, id, ownerID uuid.UUID) (*models.ReceiptConsistency, error)
+	ListInconsistent(ctx context.Context, ownerID uuid.UUID) ([]models.ReceiptConsistency, error)
+	LookupPublic(ctx context.Context, numero, documento string) (*models.ReceiptLookup, error)
+	GetPayerEmail(ctx context.Context, id, ownerID uuid.UUID) (*string, error)
+	RecordEmail(ctx context.Context, id, ownerID uuid.UUID, to string, sendErr error, at time.Time) (*models.Receipt, error)
+	GetPayerPhone(ctx context.Context, id, ownerID uuid.UUID) (*string, error)
+	CreateShare(ctx context.Context, s *models.ReceiptShare) error
+	GetShared(ctx context.Context, token uuid.UUID, now time.Time) (*models.Receipt, error)
+	DeleteExpiredShares(ctx context.Context, now time.Time) (int64, error)
+	CreateReissue(ctx context.Context, rr *models.ReceiptReissue) error
+	ListReissues(ctx context.Context, id, ownerID uuid.UUID) ([]models.ReceiptReissue, error)
 }
 
 type receiptRepository struct {
@@ -36,34 +52,58 @@ func NewReceiptRepository(db *pgxpool.Pool) ReceiptRepository {
 	return &receiptRepository{db: db}
 }
 
+// receiptColumns colunas lidas por scanReceipt, na mesma ordem
+const receiptColumns = `id, owner_id, income_id, numero, emitido_em, pdf_url, hash,
+		       signature_id, issuer_name, issuer_document, created_at, payer_id,
+		       valor, taxas, descontos, valor_liquido, competencia, categoria,
+		       payer_nome, payer_documento, income_updated_at,
+		       email_status, email_to, email_attempts, email_last_attempt_at, email_sent_at, email_error,
+		       numero_formatado, updated_at`
+
+func scanReceipt(row pgx.Row, m *models.Receipt) error {
+	return row.Scan(&m.ID, &m.OwnerID, &m.IncomeID, &m.Numero, &m.EmitidoEm, &m.PDFURL, &m.Hash,
+		&m.SignatureID, &m.IssuerName, &m.IssuerDocument, &m.CreatedAt, &m.PayerID,
+		&m.Valor, &m.Taxas, &m.Descontos, &m.ValorLiquido, &m.Competencia, &m.Categoria,
+		&m.PayerNome, &m.PayerDocumento, &m.IncomeUpdatedAt,
+		&m.EmailStatus, &m.EmailTo, &m.EmailAttempts, &m.EmailLastAttemptAt, &m.EmailSentAt, &m.EmailError,
+		&m.NumeroFormatado, &m.UpdatedAt)
+}
+
+// Create emite o recibo; o trigger congela valores da receita, pagador e emissor (migração 017)
 func (r *receiptRepository) Create(ctx context.Context, m *models.Receipt) error {
 	query := `
 		INSERT INTO rf_receipts (
-			id, owner_id, income_id, pdf_url, hash, signature_id, issuer_name, issuer_document
+			id, owner_id, income_id, pdf_url, hash, signature_id, issuer_name, issuer_document, payer_id, numero,
+			taxas, descontos
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8
-		) RETURNING numero, emitido_em, created_at
-	`
+			$1, $2, $3, $4, $5, $6, $7, $8,
+			COALESCE($9, (SELECT payer_id FROM rf_incomes WHERE id = $3 AND owner_id = $2)),
+			$10, $11, $12
+		) RETURNING ` + receiptColumns
 	if m.ID == uuid.Nil {
 		m.ID = uuid.New()
 	}
+	// Numero zero deixa o trigger atribuir o próximo número do usuário
+	var numero *int64
+	if m.Numero > 0 {
+		numero = &m.Numero
+	}
 	row := r.db.QueryRow(ctx, query,
-		m.ID, m.OwnerID, m.IncomeID, m.PDFURL, m.Hash, m.SignatureID, m.IssuerName, m.IssuerDocument,
+		m.ID, m.OwnerID, m.IncomeID, m.PDFURL, m.Hash, m.SignatureID, m.IssuerName, m.IssuerDocument, m.PayerID, numero,
+		m.Taxas, m.Descontos,
 	)
-	return row.Scan(&m.Numero, &m.EmitidoEm, &m.CreatedAt)
+	return mapReceiptError(scanReceipt(row, m))
 }
 
 func (r *receiptRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Receipt, error) {
 	query := `
-		SELECT id, owner_id, income_id, numero, emitido_em, pdf_url, hash,
-		       signature_id, issuer_name, issuer_document, created_at
+		SELECT ` + receiptColumns + `
 		FROM rf_receipts
 		WHERE id = $1 AND owner_id = $2
 	`
 	row := r.db.QueryRow(ctx, query, id, ownerID)
 	var m models.Receipt
-	if err := row.Scan(&m.ID, &m.OwnerID, &m.IncomeID, &m.Numero, &m.EmitidoEm, &m.PDFURL, &m.Hash,
-		&m.SignatureID, &m.IssuerName, &m.IssuerDocument, &m.CreatedAt); err != nil {
+	if err := scanReceipt(row, &m); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errReceiptNotFound
 		}
@@ -72,28 +112,25 @@ func (r *receiptRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID)
 	return &m, nil
 }
 
-func (r *receiptRepository) List(ctx context.Context, ownerID uuid.UUID, page, limit int) ([]models.Receipt, int, error) {
-	if page <= 0 {
-		page = 1
-	}
-	if limit <= 0 || limit > 100 {
-		limit = 10
-	}
-	offset := (page - 1) * limit
-	countQuery := `SELECT count(1) FROM rf_receipts WHERE owner_id = $1`
+// List busca recibos com filtros, ordenação e paginação
+func (r *receiptRepository) List(ctx context.Context, ownerID uuid.UUID, filter *models.ReceiptFilter) ([]models.Receipt, int, error) {
+	filter.SetDefaults()
+
+	where, args := buildReceiptListWhere(ownerID, filter)
 	var total int
-	if err := r.db.QueryRow(ctx, countQuery, ownerID).Scan(&total); err != nil {
+	if err := r.db.QueryRow(ctx, `SELECT count(1) FROM rf_receipts WHERE `+where, args...).Scan(&total); err != nil {
 		return nil, 0, err
 	}
-	query := `
-		SELECT id, owner_id, income_id, numero, emitido_em, pdf_url, hash,
-		       signature_id, issuer_name, issuer_document, created_at
+
+	offset := (filter.Page - 1) * filter.PerPage
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM rf_receipts
-		WHERE owner_id = $1
-		ORDER BY emitido_em DESC NULLS LAST, created_at DESC
-		LIMIT $2 OFFSET $3
-	`
-	rows, err := r.db.Query(ctx, query, ownerID, limit, offset)
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, receiptColumns, where, receiptOrderBy(filter), len(args)+1, len(args)+2)
+	rows, err := r.db.Query(ctx, query, append(args, filter.PerPage, offset)...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -101,27 +138,101 @@ func (r *receiptRepository) List(ctx context.Context, ownerID uuid.UUID, page, l
 	var items []models.Receipt
 	for rows.Next() {
 		var m models.Receipt
-		if err := rows.Scan(&m.ID, &m.OwnerID, &m.IncomeID, &m.Numero, &m.EmitidoEm, &m.PDFURL, &m.Hash,
-			&m.SignatureID, &m.IssuerName, &m.IssuerDocument, &m.CreatedAt); err != nil {
+		if err := scanReceipt(rows, &m); err != nil {
 			return nil, 0, err
 		}
 		items = append(items, m)
 	}
-	return items, total, nil
+	return items, total, rows.Err()
+}
+
+// receiptSortColumns colunas aceitas em sort_field (evita injeção via ORDER BY)
+var receiptSortColumns = map[string]string{
+	"emitido_em": "emitido_em",
+	"created_at": "created_at",
+	"numero":     "numero",
+	"valor":      "valor_liquido",
+	"payer_nome": "lower(payer_nome)",
+}
+
+// buildReceiptListWhere monta o WHERE da listagem com argumentos posicionais ($1 = owner_id)
+func buildReceiptListWhere(ownerID uuid.UUID, f *models.ReceiptFilter) (string, []interface{}) {
+	conds := []string{"owner_id = $1"}
+	args := []interface{}{ownerID}
+	add := func(cond string, v interface{}) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.Search != "" {
+		add(`(payer_nome ILIKE $%[1]d OR payer_documento ILIKE $%[1]d OR issuer_name ILIKE $%[1]d
+			OR issuer_document ILIKE $%[1]d OR numero_formatado ILIKE $%[1]d)`, "%"+escapeLike(f.Search)+"%")
+	}
+	if f.IncomeID != nil {
+		add("income_id = $%d", *f.IncomeID)
+	}
+	if f.PayerID != nil {
+		add("payer_id = $%d", *f.PayerID)
+	}
+	if f.Numero != nil {
+		add("numero = $%d", *f.Numero)
+	}
+	if f.EmitidoFrom != nil {
+		add("emitido_em >= $%d", *f.EmitidoFrom)
+	}
+	if f.EmitidoTo != nil {
+		add("emitido_em < $%d", *f.EmitidoTo)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// receiptOrderBy retorna a cláusula ORDER BY a partir da whitelist; created_at e id desempatam
+func receiptOrderBy(f *models.ReceiptFilter) string {
+	col, ok := receiptSortColumns[f.SortField]
+	if !ok {
+		col = "emitido_em"
+	}
+	dir := "DESC"
+	if f.SortOrder == "asc" {
+		dir = "ASC"
+	}
+	return col + " " + dir + " NULLS LAST, created_at " + dir + ", id " + dir
 }
 
 func (r *receiptRepository) Update(ctx context.Context, m *models.Receipt) error {
 	query := `
 		UPDATE rf_receipts
-		SET income_id = $2, pdf_url = $3, hash = $4, signature_id = $5,
-		    issuer_name = $6, issuer_document = $7
-		WHERE id = $1 AND owner_id = $8
-		RETURNING numero, emitido_em, created_at
-	`
+		SET income_id = $2, pdf_url = $3, hash = $4, signature_id = COALESCE($5, signature_id),
+		    payer_id = COALESCE($7, (SELECT payer_id FROM rf_incomes WHERE id = $2 AND owner_id = $6))
+		WHERE id = $1 AND owner_id = $6
+		RETURNING ` + receiptColumns
+	// Emissor e valores congelados na emissão não são regravados na edição; a assinatura (versão
+	// usada na emissão) fica a mesma quando omitida e trocá-la é recusado pelo banco (migração 049)
 	row := r.db.QueryRow(ctx, query,
-		m.ID, m.IncomeID, m.PDFURL, m.Hash, m.SignatureID, m.IssuerName, m.IssuerDocument, m.OwnerID,
+		m.ID, m.IncomeID, m.PDFURL, m.Hash, m.SignatureID, m.OwnerID, m.PayerID,
 	)
-	return row.Scan(&m.Numero, &m.EmitidoEm, &m.CreatedAt)
+	err := scanReceipt(row, m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errReceiptNotFound
+	}
+	return mapReceiptError(err)
+}
+
+// SetPDF grava o PDF enviado ao Storage (caminho e SHA-256 calculado pelo servidor)
+func (r *receiptRepository) SetPDF(ctx context.Context, id, ownerID uuid.UUID, pdfURL, hash string) (*models.Receipt, error) {
+	query := `
+		UPDATE rf_receipts SET pdf_url = $3, hash = $4
+		WHERE id = $1 AND owner_id = $2
+		RETURNING ` + receiptColumns
+	var m models.Receipt
+	err := scanReceipt(r.db.QueryRow(ctx, query, id, ownerID, pdfURL, hash), &m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errReceiptNotFound
+	}
+	if err != nil {
+		return nil, mapReceiptError(err)
+	}
+	return &m, nil
 }
 
 func (r *receiptRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
@@ -135,6 +246,442 @@ func (r *receiptRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) e
 	return nil
 }
 
+// mapReceiptError traduz violações de constraint (receita, pagador e numeração) em erros de domínio
+func mapReceiptError(err error) error {
+	switch {
+	case isConstraintViolation(err, pgForeignKeyViolation, "fk_receipts_payer"):
+		return models.ErrPayerNotFound
+	case isConstraintViolation(err, pgForeignKeyViolation, "rf_receipts_income_id_fkey"):
+		return models.ErrIncomeNotFound
+	case isConstraintViolation(err, pgUniqueViolation, "uq_receipts_owner_numero"),
+		isConstraintViolation(err, pgUniqueViolation, "uq_receipts_owner_serie"):
+		return models.ErrReceiptNumberConflict
+	case isConstraintViolation(err, pgCheckViolation, "ck_receipts_numero_monotonic"):
+		return models.ErrReceiptNumberNotMonotonic
+	case isConstraintViolation(err, pgCheckViolation, "ck_receipts_numero_immutable"):
+		return models.ErrReceiptNumberImmutable
+	case isConstraintViolation(err, pgCheckViolation, "ck_receipts_snapshot_immutable"):
+		return models.ErrReceiptSnapshotImmutable
+	case isConstraintViolation(err, pgCheckViolation, "ck_receipts_adjustments"):
+		return models.ErrInvalidReceiptAdjustment
+	}
+	return err
+}
+
+// maxNumberingItems limita o tamanho das listas do relatório de numeração
+const maxNumberingItems = 1000
+
+// NumberingReport calcula lacunas, duplicatas e quebras de ordem da numeração exibida do usuário.
+// Docstring: cada série (serie_periodo) é analisada pelo próprio serie_numero; a sequência interna
+// (numero) não aparece no recibo e não entra no relatório.
+func (r *receiptRepository) NumberingReport(ctx context.Context, ownerID uuid.UUID) (*models.NumberingReport, error) {
+	rep := &models.NumberingReport{
+		Series:          []models.NumberSeries{},
+		Gaps:            []models.NumberGap{},
+		Duplicates:      []models.NumberDuplicate{},
+		OrderViolations: []models.NumberOrderViolation{},
+	}
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*),
+		       (SELECT numero_formatado FROM rf_receipts WHERE owner_id = $1
+		        ORDER BY serie_periodo, serie_numero LIMIT 1),
+		       (SELECT numero_formatado FROM rf_receipts WHERE owner_id = $1
+		        ORDER BY serie_periodo DESC, serie_numero DESC LIMIT 1)
+		FROM rf_receipts WHERE owner_id = $1
+	`, ownerID).Scan(&rep.Count, &rep.First, &rep.Last)
+	if err != nil {
+		return nil, err
+	}
+
+	serRows, err := r.db.Query(ctx, `
+		SELECT serie_periodo, COUNT(*), MIN(serie_numero), MAX(serie_numero)
+		FROM rf_receipts WHERE owner_id = $1
+		GROUP BY serie_periodo
+		ORDER BY serie_periodo
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for serRows.Next() {
+		var ser models.NumberSeries
+		if err := serRows.Scan(&ser.Periodo, &ser.Count, &ser.First, &ser.Last); err != nil {
+			serRows.Close()
+			return nil, err
+		}
+		rep.Series = append(rep.Series, ser)
+	}
+	serRows.Close()
+	if err := serRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Lacunas: números consecutivos distintos da mesma série com diferença maior que 1
+	gapRows, err := r.db.Query(ctx, `
+		SELECT serie_periodo, prev + 1, serie_numero - 1
+		FROM (
+			SELECT serie_periodo, serie_numero,
+			       LAG(serie_numero) OVER (PARTITION BY serie_periodo ORDER BY serie_numero) AS prev
+			FROM (SELECT DISTINCT serie_periodo, serie_numero FROM rf_receipts WHERE owner_id = $1) d
+		) t
+		WHERE prev IS NOT NULL AND serie_numero - prev > 1
+		ORDER BY serie_periodo, serie_numero
+		LIMIT $2
+	`, ownerID, maxNumberingItems)
+	if err != nil {
+		return nil, err
+	}
+	for gapRows.Next() {
+		var g models.NumberGap
+		if err := gapRows.Scan(&g.Periodo, &g.From, &g.To); err != nil {
+			gapRows.Close()
+			return nil, err
+		}
+		g.Missing = g.To - g.From + 1
+		rep.Gaps = append(rep.Gaps, g)
+	}
+	gapRows.Close()
+	if err := gapRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Duplicatas (possíveis em dados anteriores à constraint de unicidade da série)
+	dupRows, err := r.db.Query(ctx, `
+		SELECT serie_periodo, serie_numero, COUNT(*), ARRAY_AGG(id ORDER BY created_at)
+		FROM rf_receipts WHERE owner_id = $1
+		GROUP BY serie_periodo, serie_numero HAVING COUNT(*) > 1
+		ORDER BY serie_periodo, serie_numero
+		LIMIT $2
+	`, ownerID, maxNumberingItems)
+	if err != nil {
+		return nil, err
+	}
+	for dupRows.Next() {
+		var d models.NumberDuplicate
+		if err := dupRows.Scan(&d.Periodo, &d.Numero, &d.Count, &d.ReceiptIDs); err != nil {
+			dupRows.Close()
+			return nil, err
+		}
+		rep.Duplicates = append(rep.Duplicates, d)
+	}
+	dupRows.Close()
+	if err := dupRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Quebras de ordem: recibo emitido depois de outro da mesma série, mas com número menor
+	ordRows, err := r.db.Query(ctx, `
+		SELECT id, serie_periodo, serie_numero, numero_formatado, emitido_em,
+		       prev_numero, prev_formatado, prev_emitido
+		FROM (
+			SELECT id, serie_periodo, serie_numero, numero_formatado, emitido_em,
+			       LAG(serie_numero) OVER w AS prev_numero,
+			       LAG(numero_formatado) OVER w AS prev_formatado,
+			       LAG(emitido_em) OVER w AS prev_emitido
+			FROM rf_receipts WHERE owner_id = $1
+			WINDOW w AS (PARTITION BY serie_periodo ORDER BY emitido_em NULLS FIRST, created_at, serie_numero)
+		) t
+		WHERE prev_numero IS NOT NULL AND serie_numero < prev_numero
+		ORDER BY emitido_em
+		LIMIT $2
+	`, ownerID, maxNumberingItems)
+	if err != nil {
+		return nil, err
+	}
+	for ordRows.Next() {
+		var v models.NumberOrderViolation
+		if err := ordRows.Scan(&v.ReceiptID, &v.Periodo, &v.Numero, &v.NumeroFormatado, &v.EmitidoEm,
+			&v.PreviousNumero, &v.PreviousFormatado, &v.PreviousEmitido); err != nil {
+			ordRows.Close()
+			return nil, err
+		}
+		rep.OrderViolations = append(rep.OrderViolations, v)
+	}
+	ordRows.Close()
+	if err := ordRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Justificativas registradas pelo usuário
+	jsRows, err := r.db.Query(ctx, `
+		SELECT id, owner_id, serie_periodo, numero_inicio, numero_fim, justificativa, created_at
+		FROM rf_receipt_number_gaps WHERE owner_id = $1 ORDER BY serie_periodo, numero_inicio
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer jsRows.Close()
+	var js []models.NumberGapJustification
+	for jsRows.Next() {
+		var j models.NumberGapJustification
+		if err := jsRows.Scan(&j.ID, &j.OwnerID, &j.SeriePeriodo, &j.NumeroInicio, &j.NumeroFim, &j.Justificativa, &j.CreatedAt); err != nil {
+			return nil, err
+		}
+		js = append(js, j)
+	}
+	if err := jsRows.Err(); err != nil {
+		return nil, err
+	}
+
+	rep.ApplyJustifications(js)
+	return rep, nil
+}
+
+// CreateNumberGap registra a justificativa de uma lacuna da série de numeração
+func (r *receiptRepository) CreateNumberGap(ctx context.Context, j *models.NumberGapJustification) error {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	query := `
+		INSERT INTO rf_receipt_number_gaps (id, owner_id, serie_periodo, numero_inicio, numero_fim, justificativa)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+	return r.db.QueryRow(ctx, query, j.ID, j.OwnerID, j.SeriePeriodo, j.NumeroInicio, j.NumeroFim, j.Justificativa).Scan(&j.CreatedAt)
+}
+
+// receiptConsistencyQuery recibo (snapshot) com o estado atual da receita e do pagador
+const receiptConsistencyQuery = `
+	SELECT r.id, r.owner_id, r.income_id, r.numero, r.emitido_em, r.pdf_url, r.hash,
+	       r.signature_id, r.issuer_name, r.issuer_document, r.created_at, r.payer_id,
+	       r.valor, r.taxas, r.descontos, r.valor_liquido, r.competencia, r.categoria,
+	       r.payer_nome, r.payer_documento, r.income_updated_at, r.numero_formatado,
+	       i.id IS NOT NULL, COALESCE(i.valor, 0), COALESCE(i.competencia, ''), i.categoria,
+	       i.updated_at, i.deleted_at, p.nome, p.documento
+	FROM rf_receipts r
+	LEFT JOIN rf_incomes i ON i.id = r.income_id AND i.owner_id = r.owner_id
+	LEFT JOIN rf_payers p ON p.id = r.payer_id AND p.owner_id = r.owner_id
+`
+
+func scanReceiptConsistency(row pgx.Row) (*models.ReceiptConsistency, error) {
+	var m models.Receipt
+	var cur models.ReceiptIncomeState
+	if err := row.Scan(&m.ID, &m.OwnerID, &m.IncomeID, &m.Numero, &m.EmitidoEm, &m.PDFURL, &m.Hash,
+		&m.SignatureID, &m.IssuerName, &m.IssuerDocument, &m.CreatedAt, &m.PayerID,
+		&m.Valor, &m.Taxas, &m.Descontos, &m.ValorLiquido, &m.Competencia, &m.Categoria,
+		&m.PayerNome, &m.PayerDocumento, &m.IncomeUpdatedAt, &m.NumeroFormatado,
+		&cur.IncomeFound, &cur.Valor, &cur.Competencia, &cur.Categoria,
+		&cur.UpdatedAt, &cur.DeletedAt, &cur.PayerNome, &cur.PayerDocumento); err != nil {
+		return nil, err
+	}
+	return models.CheckReceiptConsistency(&m, &cur), nil
+}
+
+// CheckConsistency compara o snapshot de um recibo com o estado atual da receita vinculada
+func (r *receiptRepository) CheckConsistency(ctx context.Context, id, ownerID uuid.UUID) (*models.ReceiptConsistency, error) {
+	row := r.db.QueryRow(ctx, receiptConsistencyQuery+` WHERE r.id = $1 AND r.owner_id = $2`, id, ownerID)
+	c, err := scanReceiptConsistency(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errReceiptNotFound
+	}
+	return c, err
+}
+
+// ListInconsistent lista recibos cuja receita foi editada ou excluída após a emissão
+func (r *receiptRepository) ListInconsistent(ctx context.Context, ownerID uuid.UUID) ([]models.ReceiptConsistency, error) {
+	rows, err := r.db.Query(ctx, receiptConsistencyQuery+`
+		WHERE r.owner_id = $1 AND r.income_id IS NOT NULL AND r.valor IS NOT NULL
+		  AND (i.id IS NULL OR i.deleted_at IS NOT NULL OR i.updated_at > r.income_updated_at
+		       OR i.valor <> r.valor OR p.nome IS DISTINCT FROM r.payer_nome)
+		ORDER BY r.numero
+		LIMIT $2
+	`, ownerID, maxNumberingItems)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []models.ReceiptConsistency{}
+	for rows.Next() {
+		c, err := scanReceiptConsistency(rows)
+		if err != nil {
+			return nil, err
+		}
+		if !c.Consistent {
+			items = append(items, *c)
+		}
+	}
+	return items, rows.Err()
+}
+
+// LookupPublic verifica, sem escopo de usuário, se existe recibo com o número impresso emitido pelo documento.
+// Docstring: numero é o número formatado já normalizado (models.NormalizeReceiptNumber) e documento os dígitos;
+// recibos sem emissor alternativo usam o documento do perfil. A sequência interna não é consultada.
+func (r *receiptRepository) LookupPublic(ctx context.Context, numero, documento string) (*models.ReceiptLookup, error) {
+	query := `
+		SELECT to_char(r.emitido_em, 'YYYY-MM')
+		FROM rf_receipts r
+		LEFT JOIN rf_profiles p ON p.id = r.owner_id
+		WHERE upper(r.numero_formatado) = $1
+		  AND COALESCE(rf_receipt_issuer_digits(r.issuer_document), rf_receipt_issuer_digits(p.documento)) = $2
+		LIMIT 1
+	`
+	var emitido *string
+	err := r.db.QueryRow(ctx, query, numero, documento).Scan(&emitido)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &models.ReceiptLookup{Exists: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReceiptLookup{Exists: true, EmitidoEm: emitido}, nil
+}
+
+// GetPayerEmail e-mail cadastrado do pagador do recibo (nil se o recibo não tem pagador ou e-mail)
+func (r *receiptRepository) GetPayerEmail(ctx context.Context, id, ownerID uuid.UUID) (*string, error) {
+	query := `
+		SELECT NULLIF(btrim(p.email), '')
+		FROM rf_receipts r
+		LEFT JOIN rf_payers p ON p.id = r.payer_id AND p.owner_id = r.owner_id
+		WHERE r.id = $1 AND r.owner_id = $2
+	`
+	var email *string
+	err := r.db.QueryRow(ctx, query, id, ownerID).Scan(&email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errReceiptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return email, nil
+}
+
+// RecordEmail registra uma tentativa de envio do recibo por e-mail (sendErr nil = enviado)
+func (r *receiptRepository) RecordEmail(ctx context.Context, id, ownerID uuid.UUID, to string, sendErr error, at time.Time) (*models.Receipt, error) {
+	status, sentAt := models.ReceiptEmailSent, &at
+	var errMsg *string
+	if sendErr != nil {
+		msg := sendErr.Error()
+		status, sentAt, errMsg = models.ReceiptEmailFailed, nil, &msg
+	}
+	query := `
+		UPDATE rf_receipts
+		SET email_status = $3, email_to = $4, email_attempts = email_attempts + 1,
+		    email_last_attempt_at = $5, email_sent_at = COALESCE($6, email_sent_at), email_error = $7
+		WHERE id = $1 AND owner_id = $2
+		RETURNING ` + receiptColumns
+	var m models.Receipt
+	err := scanReceipt(r.db.QueryRow(ctx, query, id, ownerID, status, to, at, sentAt, errMsg), &m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errReceiptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetPayerPhone telefone cadastrado do pagador do recibo (nil se o recibo não tem pagador ou telefone)
+func (r *receiptRepository) GetPayerPhone(ctx context.Context, id, ownerID uuid.UUID) (*string, error) {
+	query := `
+		SELECT NULLIF(btrim(p.telefone), '')
+		FROM rf_receipts r
+		LEFT JOIN rf_payers p ON p.id = r.payer_id AND p.owner_id = r.owner_id
+		WHERE r.id = $1 AND r.owner_id = $2
+	`
+	var phone *string
+	err := r.db.QueryRow(ctx, query, id, ownerID).Scan(&phone)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errReceiptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return phone, nil
+}
+
+// CreateShare gera o token do link público; recibo de outro usuário responde não encontrado
+func (r *receiptRepository) CreateShare(ctx context.Context, s *models.ReceiptShare) error {
+	query := `
+		INSERT INTO rf_receipt_shares (receipt_id, owner_id, expires_at)
+		SELECT id, owner_id, $3 FROM rf_receipts WHERE id = $1 AND owner_id = $2
+		RETURNING token, created_at
+	`
+	err := r.db.QueryRow(ctx, query, s.ReceiptID, s.OwnerID, s.ExpiresAt).Scan(&s.Token, &s.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errReceiptNotFound
+	}
+	return err
+}
+
+// GetShared recibo do link público, sem escopo de usuário; token vencido ou inexistente responde não encontrado
+func (r *receiptRepository) GetShared(ctx context.Context, token uuid.UUID, now time.Time) (*models.Receipt, error) {
+	query := `
+		SELECT ` + receiptColumns + `
+		FROM rf_receipts
+		WHERE id = (SELECT receipt_id FROM rf_receipt_shares WHERE token = $1 AND expires_at > $2)
+	`
+	var m models.Receipt
+	err := scanReceipt(r.db.QueryRow(ctx, query, token, now), &m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errReceiptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// DeleteExpiredShares apaga os tokens de compartilhamento vencidos
+func (r *receiptRepository) DeleteExpiredShares(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rf_receipt_shares WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// CreateReissue registra a próxima via do recibo (2, 3, ...) na trilha de auditoria.
+// Docstring: o recibo é travado antes de calcular a via, então pedidos simultâneos recebem vias
+// distintas; recibo de outro usuário responde não encontrado.
+func (r *receiptRepository) CreateReissue(ctx context.Context, rr *models.ReceiptReissue) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var locked uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT id FROM rf_receipts WHERE id = $1 AND owner_id = $2 FOR UPDATE`, rr.ReceiptID, rr.OwnerID).Scan(&locked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errReceiptNotFound
+	}
+	if err != nil {
+		return err
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_receipt_reissues (owner_id, receipt_id, via, actor_id, motivo)
+		SELECT $1, $2, COALESCE(MAX(via), 1) + 1, $3, $4 FROM rf_receipt_reissues WHERE receipt_id = $2
+		RETURNING id, via, created_at
+	`, rr.OwnerID, rr.ReceiptID, rr.ActorID, rr.Motivo).Scan(&rr.ID, &rr.Via, &rr.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListReissues vias reemitidas do recibo, da mais antiga para a mais recente
+func (r *receiptRepository) ListReissues(ctx context.Context, id, ownerID uuid.UUID) ([]models.ReceiptReissue, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, owner_id, receipt_id, via, actor_id, motivo, created_at
+		FROM rf_receipt_reissues
+		WHERE receipt_id = $1 AND owner_id = $2
+		ORDER BY via
+	`, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.ReceiptReissue{}
+	for rows.Next() {
+		var rr models.ReceiptReissue
+		if err := rows.Scan(&rr.ID, &rr.OwnerID, &rr.ReceiptID, &rr.Via, &rr.ActorID, &rr.Motivo, &rr.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, rr)
+	}
+	return items, rows.Err()
+}
+
 // Erros expostos para handlers
 func IsReceiptNotFound(err error) bool { return errors.Is(err, errReceiptNotFound) }
 
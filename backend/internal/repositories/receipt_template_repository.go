@@ -0,0 +1,168 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório dos modelos de recibo (rf_receipt_templates)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// ReceiptTemplateRepository define operações de persistência dos modelos de recibo
+type ReceiptTemplateRepository interface {
+	Create(ctx context.Context, t *models.ReceiptTemplate) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.ReceiptTemplate, error)
+	GetDefault(ctx context.Context, ownerID uuid.UUID) (*models.ReceiptTemplate, error)
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.ReceiptTemplate, error)
+	Update(ctx context.Context, t *models.ReceiptTemplate) error
+	SetLogo(ctx context.Context, id, ownerID uuid.UUID, logo []byte) error
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+}
+
+type receiptTemplateRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReceiptTemplateRepository cria uma nova instância do repositório de modelos de recibo
+func NewReceiptTemplateRepository(db *pgxpool.Pool) ReceiptTemplateRepository {
+	return &receiptTemplateRepository{db: db}
+}
+
+// receiptTemplateColumns colunas lidas por scanReceiptTemplate; o logotipo vem por último e só
+// é carregado por GetByID/GetDefault (na listagem vale NULL)
+const receiptTemplateColumns = `id, owner_id, nome, logo IS NOT NULL, primary_color, accent_color, header_text,
+	footer_text, issuer_name, issuer_document, issuer_address, is_default, created_at, updated_at`
+
+func scanReceiptTemplate(row pgx.Row, m *models.ReceiptTemplate) error {
+	return row.Scan(&m.ID, &m.OwnerID, &m.Nome, &m.HasLogo, &m.PrimaryColor, &m.AccentColor, &m.HeaderText,
+		&m.FooterText, &m.IssuerName, &m.IssuerDocument, &m.IssuerAddress, &m.IsDefault, &m.CreatedAt, &m.UpdatedAt, &m.Logo)
+}
+
+// Create insere um novo modelo; sendo padrão, os demais modelos do usuário deixam de ser
+func (r *receiptTemplateRepository) Create(ctx context.Context, m *models.ReceiptTemplate) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return r.withDefault(ctx, m, `
+		INSERT INTO rf_receipt_templates (id, owner_id, nome, primary_color, accent_color, header_text,
+			footer_text, issuer_name, issuer_document, issuer_address, is_default)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING `+receiptTemplateColumns+`, NULL::bytea
+	`)
+}
+
+// GetByID busca um modelo do usuário (com o logotipo)
+func (r *receiptTemplateRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.ReceiptTemplate, error) {
+	query := `SELECT ` + receiptTemplateColumns + `, logo FROM rf_receipt_templates WHERE id = $1 AND owner_id = $2`
+	var m models.ReceiptTemplate
+	if err := scanReceiptTemplate(r.db.QueryRow(ctx, query, id, ownerID), &m); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrReceiptTemplateNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetDefault busca o modelo padrão do usuário (com o logotipo); sem padrão, ErrReceiptTemplateNotFound
+func (r *receiptTemplateRepository) GetDefault(ctx context.Context, ownerID uuid.UUID) (*models.ReceiptTemplate, error) {
+	query := `SELECT ` + receiptTemplateColumns + `, logo FROM rf_receipt_templates WHERE owner_id = $1 AND is_default`
+	var m models.ReceiptTemplate
+	if err := scanReceiptTemplate(r.db.QueryRow(ctx, query, ownerID), &m); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrReceiptTemplateNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// List retorna os modelos do usuário (padrão primeiro, depois em ordem alfabética), sem os logotipos
+func (r *receiptTemplateRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.ReceiptTemplate, error) {
+	query := `SELECT ` + receiptTemplateColumns + `, NULL::bytea FROM rf_receipt_templates
+		WHERE owner_id = $1 ORDER BY is_default DESC, nome, created_at`
+	rows, err := r.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.ReceiptTemplate{}
+	for rows.Next() {
+		var m models.ReceiptTemplate
+		if err := scanReceiptTemplate(rows, &m); err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+	return items, rows.Err()
+}
+
+// Update atualiza um modelo existente (o logotipo é mantido)
+func (r *receiptTemplateRepository) Update(ctx context.Context, m *models.ReceiptTemplate) error {
+	return r.withDefault(ctx, m, `
+		UPDATE rf_receipt_templates
+		SET nome = $3, primary_color = $4, accent_color = $5, header_text = $6, footer_text = $7,
+		    issuer_name = $8, issuer_document = $9, issuer_address = $10, is_default = $11
+		WHERE id = $1 AND owner_id = $2
+		RETURNING `+receiptTemplateColumns+`, NULL::bytea
+	`)
+}
+
+// withDefault grava o modelo com query e, se ele for o padrão, desmarca os demais na mesma transação
+func (r *receiptTemplateRepository) withDefault(ctx context.Context, m *models.ReceiptTemplate, query string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if m.IsDefault {
+		if _, err := tx.Exec(ctx, `
+			UPDATE rf_receipt_templates SET is_default = false
+			WHERE owner_id = $1 AND id <> $2 AND is_default
+		`, m.OwnerID, m.ID); err != nil {
+			return err
+		}
+	}
+	row := tx.QueryRow(ctx, query, m.ID, m.OwnerID, m.Nome, m.PrimaryColor, m.AccentColor, m.HeaderText,
+		m.FooterText, m.IssuerName, m.IssuerDocument, m.IssuerAddress, m.IsDefault)
+	if err := scanReceiptTemplate(row, m); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrReceiptTemplateNotFound
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// SetLogo grava (ou remove, com nil) o logotipo do modelo
+func (r *receiptTemplateRepository) SetLogo(ctx context.Context, id, ownerID uuid.UUID, logo []byte) error {
+	cmd, err := r.db.Exec(ctx, `UPDATE rf_receipt_templates SET logo = $3 WHERE id = $1 AND owner_id = $2`, id, ownerID, logo)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrReceiptTemplateNotFound
+	}
+	return nil
+}
+
+// Delete remove um modelo; recibos já emitidos passam a sair com o novo padrão (ou sem marca)
+func (r *receiptTemplateRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM rf_receipt_templates WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrReceiptTemplateNotFound
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório da conciliação bancária (extratos, lançamentos e sugestões de vínculo)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// ReconciliationRepository define a persistência da conciliação bancária.
+// Docstring: CreateStatement ignora lançamentos já importados (uq_bank_statement_lines_fit) e
+// devolve só os novos. Confirmar é feito em duas etapas: ClaimMatch trava o lançamento e marca a
+// sugestão, o serviço lança o pagamento e SetMatchPayment o registra (rejeitando as demais
+// sugestões do lançamento); ReleaseMatch desfaz a trava se o lançamento do pagamento falhar.
+type ReconciliationRepository interface {
+	CreateStatement(ctx context.Context, st *models.BankStatement, lines []models.BankStatementLine) ([]models.BankStatementLine, error)
+	ListCandidates(ctx context.Context, ownerID uuid.UUID) ([]models.ReconciliationCandidate, error)
+	CreateMatches(ctx context.Context, matches []models.ReconciliationMatch) error
+	ListMatches(ctx context.Context, ownerID uuid.UUID, status string) ([]models.ReconciliationMatch, error)
+	ClaimMatch(ctx context.Context, id, ownerID uuid.UUID) (*models.ReconciliationMatch, error)
+	ReleaseMatch(ctx context.Context, id uuid.UUID) error
+	SetMatchPayment(ctx context.Context, m *models.ReconciliationMatch, paymentID uuid.UUID) error
+	RejectMatch(ctx context.Context, id, ownerID uuid.UUID) (*models.ReconciliationMatch, error)
+}
+
+type reconciliationRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReconciliationRepository cria uma nova instância do repositório de conciliação bancária
+func NewReconciliationRepository(db *pgxpool.Pool) ReconciliationRepository {
+	return &reconciliationRepository{db: db}
+}
+
+const statementLineColumns = `id, owner_id, statement_id, fit_id, posted_at, valor, descricao, status, income_id, payment_id`
+
+const matchColumns = `id, owner_id, line_id, income_id, score, reasons, status, payment_id, created_at, decided_at`
+
+func scanStatementLine(row pgx.Row, l *models.BankStatementLine) error {
+	return row.Scan(&l.ID, &l.OwnerID, &l.StatementID, &l.FitID, &l.PostedAt, &l.Valor, &l.Descricao, &l.Status, &l.IncomeID, &l.PaymentID)
+}
+
+func scanMatch(row pgx.Row, m *models.ReconciliationMatch) error {
+	return row.Scan(&m.ID, &m.OwnerID, &m.LineID, &m.IncomeID, &m.Score, &m.Reasons, &m.Status, &m.PaymentID, &m.CreatedAt, &m.DecidedAt)
+}
+
+// CreateStatement grava o extrato e os lançamentos novos na mesma transação
+func (r *reconciliationRepository) CreateStatement(ctx context.Context, st *models.BankStatement, lines []models.BankStatementLine) ([]models.BankStatementLine, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO rf_bank_statements (owner_id, filename, format, account)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, imported_at`, st.OwnerID, st.Filename, st.Format, st.Account).Scan(&st.ID, &st.ImportedAt)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar extrato: %w", err)
+	}
+	inserted := make([]models.BankStatementLine, 0, len(lines))
+	for _, l := range lines {
+		err := scanStatementLine(tx.QueryRow(ctx, `
+			INSERT INTO rf_bank_statement_lines (owner_id, statement_id, fit_id, posted_at, valor, descricao)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (owner_id, fit_id) DO NOTHING
+			RETURNING `+statementLineColumns,
+			st.OwnerID, st.ID, l.FitID, l.PostedAt, l.Valor, l.Descricao), &l)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao registrar lançamento do extrato: %w", err)
+		}
+		inserted = append(inserted, l)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
+
+// ListCandidates receitas em aberto (com saldo) e o pagador de cada uma
+func (r *reconciliationRepository) ListCandidates(ctx context.Context, ownerID uuid.UUID) ([]models.ReconciliationCandidate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id, i.competencia, i.categoria, i.valor, i.total_pago, i.due_date, p.nome, p.documento
+		FROM rf_incomes i
+		LEFT JOIN rf_payers p ON p.id = i.payer_id
+		WHERE i.owner_id = $1 AND i.deleted_at IS NULL
+		  AND i.status IN ('pendente', 'parcial', 'vencido') AND i.valor > i.total_pago`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.ReconciliationCandidate
+	for rows.Next() {
+		var c models.ReconciliationCandidate
+		if err := rows.Scan(&c.IncomeID, &c.Competencia, &c.Categoria, &c.Valor, &c.TotalPago, &c.DueDate, &c.PayerName, &c.PayerDocument); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// CreateMatches grava as sugestões (pares lançamento/receita repetidos são ignorados)
+func (r *reconciliationRepository) CreateMatches(ctx context.Context, matches []models.ReconciliationMatch) error {
+	for i := range matches {
+		m := &matches[i]
+		err := scanMatch(r.db.QueryRow(ctx, `
+			INSERT INTO rf_reconciliation_matches (owner_id, line_id, income_id, score, reasons)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (line_id, income_id) DO NOTHING
+			RETURNING `+matchColumns,
+			m.OwnerID, m.LineID, m.IncomeID, m.Score, m.Reasons), m)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("erro ao registrar sugestão de conciliação: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListMatches sugestões do usuário no status informado, com lançamento e receita
+func (r *reconciliationRepository) ListMatches(ctx context.Context, ownerID uuid.UUID, status string) ([]models.ReconciliationMatch, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT m.id, m.owner_id, m.line_id, m.income_id, m.score, m.reasons, m.status, m.payment_id, m.created_at, m.decided_at,
+		       l.id, l.owner_id, l.statement_id, l.fit_id, l.posted_at, l.valor, l.descricao, l.status, l.income_id, l.payment_id,
+		       i.id, i.owner_id, i.payer_id, i.categoria, i.competencia, i.valor, i.status, i.due_date, i.total_pago
+		FROM rf_reconciliation_matches m
+		JOIN rf_bank_statement_lines l ON l.id = m.line_id
+		JOIN rf_incomes i ON i.id = m.income_id
+		WHERE m.owner_id = $1 AND m.status = $2
+		ORDER BY l.posted_at DESC, m.score DESC
+		LIMIT 500`, ownerID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.ReconciliationMatch
+	for rows.Next() {
+		var m models.ReconciliationMatch
+		m.Line, m.Income = &models.BankStatementLine{}, &models.Income{}
+		l, in := m.Line, m.Income
+		if err := rows.Scan(&m.ID, &m.OwnerID, &m.LineID, &m.IncomeID, &m.Score, &m.Reasons, &m.Status, &m.PaymentID, &m.CreatedAt, &m.DecidedAt,
+			&l.ID, &l.OwnerID, &l.StatementID, &l.FitID, &l.PostedAt, &l.Valor, &l.Descricao, &l.Status, &l.IncomeID, &l.PaymentID,
+			&in.ID, &in.OwnerID, &in.PayerID, &in.Categoria, &in.Competencia, &in.Valor, &in.Status, &in.DueDate, &in.TotalPago); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// ClaimMatch trava o lançamento, confere se sugestão e lançamento estão em aberto e os marca
+func (r *reconciliationRepository) ClaimMatch(ctx context.Context, id, ownerID uuid.UUID) (*models.ReconciliationMatch, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var m models.ReconciliationMatch
+	err = scanMatch(tx.QueryRow(ctx, `SELECT `+matchColumns+` FROM rf_reconciliation_matches WHERE id = $1 AND owner_id = $2`, id, ownerID), &m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrMatchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.Line = &models.BankStatementLine{}
+	if err := scanStatementLine(tx.QueryRow(ctx, `SELECT `+statementLineColumns+` FROM rf_bank_statement_lines WHERE id = $1 FOR UPDATE`, m.LineID), m.Line); err != nil {
+		return nil, fmt.Errorf("erro ao travar lançamento do extrato: %w", err)
+	}
+	// Relê a sugestão com o lançamento travado (outra confirmação pode ter terminado antes)
+	if err := tx.QueryRow(ctx, `SELECT status FROM rf_reconciliation_matches WHERE id = $1`, id).Scan(&m.Status); err != nil {
+		return nil, err
+	}
+	if m.Status != models.MatchSuggested {
+		return nil, models.ErrMatchDecided
+	}
+	if m.Line.Status != models.StatementLineOpen {
+		return nil, models.ErrStatementLineDone
+	}
+	err = tx.QueryRow(ctx, `
+		UPDATE rf_reconciliation_matches SET status = 'confirmed', decided_at = now()
+		WHERE id = $1 RETURNING status, decided_at`, id).Scan(&m.Status, &m.DecidedAt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE rf_bank_statement_lines SET status = 'reconciled', income_id = $2 WHERE id = $1`, m.LineID, m.IncomeID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	m.Line.Status, m.Line.IncomeID = models.StatementLineReconciled, &m.IncomeID
+	return &m, nil
+}
+
+// ReleaseMatch devolve sugestão e lançamento ao estado em aberto
+func (r *reconciliationRepository) ReleaseMatch(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	var lineID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		UPDATE rf_reconciliation_matches SET status = 'suggested', decided_at = NULL
+		WHERE id = $1 AND status = 'confirmed' AND payment_id IS NULL
+		RETURNING line_id`, id).Scan(&lineID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE rf_bank_statement_lines SET status = 'open', income_id = NULL WHERE id = $1 AND payment_id IS NULL`, lineID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// SetMatchPayment registra o pagamento lançado e rejeita as outras sugestões do lançamento
+func (r *reconciliationRepository) SetMatchPayment(ctx context.Context, m *models.ReconciliationMatch, paymentID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, `UPDATE rf_reconciliation_matches SET payment_id = $2 WHERE id = $1`, m.ID, paymentID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE rf_bank_statement_lines SET payment_id = $2 WHERE id = $1`, m.LineID, paymentID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE rf_reconciliation_matches SET status = 'rejected', decided_at = now()
+		WHERE line_id = $1 AND id <> $2 AND status = 'suggested'`, m.LineID, m.ID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RejectMatch descarta a sugestão (o lançamento continua disponível para as demais)
+func (r *reconciliationRepository) RejectMatch(ctx context.Context, id, ownerID uuid.UUID) (*models.ReconciliationMatch, error) {
+	var m models.ReconciliationMatch
+	err := scanMatch(r.db.QueryRow(ctx, `
+		UPDATE rf_reconciliation_matches SET status = 'rejected', decided_at = now()
+		WHERE id = $1 AND owner_id = $2 AND status = 'suggested'
+		RETURNING `+matchColumns, id, ownerID), &m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		var status string
+		if err := r.db.QueryRow(ctx, `SELECT status FROM rf_reconciliation_matches WHERE id = $1 AND owner_id = $2`, id, ownerID).Scan(&status); errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrMatchNotFound
+		} else if err != nil {
+			return nil, err
+		}
+		return nil, models.ErrMatchDecided
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,156 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Consultas dos relatórios (mensal: receitas, pagamentos e vencidos; aging dos recebíveis)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// ReportRepository consultas somente leitura dos relatórios
+type ReportRepository interface {
+	// ListMonthIncomes receitas da competência (em qualquer das formas gravadas)
+	ListMonthIncomes(ctx context.Context, ownerID uuid.UUID, competencias []string) ([]models.MonthlyReportIncome, error)
+	// ListMonthPayments pagamentos não estornados com data em [from, to)
+	ListMonthPayments(ctx context.Context, ownerID uuid.UUID, from, to time.Time) ([]models.MonthlyReportPayment, error)
+	// ListOverdue receitas com saldo e vencimento anterior a before
+	ListOverdue(ctx context.Context, ownerID uuid.UUID, before time.Time) ([]models.MonthlyReportIncome, error)
+	// ListMonthExpenses despesas da competência ou pagas em [from, to)
+	ListMonthExpenses(ctx context.Context, ownerID uuid.UUID, competencias []string, from, to time.Time) ([]models.MonthlyReportExpense, error)
+	// ListAging receitas com saldo e vencimento anterior a before, com pagador e contrato
+	ListAging(ctx context.Context, ownerID uuid.UUID, before time.Time) ([]models.AgingItem, error)
+}
+
+type reportRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReportRepository cria uma nova instância do repositório de relatórios
+func NewReportRepository(db *pgxpool.Pool) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+const reportIncomeColumns = `i.id, i.competencia, i.categoria, p.nome, i.due_date, i.status, i.valor, i.total_pago`
+
+func (r *reportRepository) ListMonthIncomes(ctx context.Context, ownerID uuid.UUID, competencias []string) ([]models.MonthlyReportIncome, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+reportIncomeColumns+`
+		FROM rf_incomes i LEFT JOIN rf_payers p ON p.id = i.payer_id
+		WHERE i.owner_id = $1 AND i.deleted_at IS NULL AND i.competencia = ANY($2)
+		ORDER BY i.due_date NULLS LAST, p.nome NULLS LAST, i.created_at
+	`, ownerID, competencias)
+	if err != nil {
+		return nil, err
+	}
+	return collectReportIncomes(rows)
+}
+
+func (r *reportRepository) ListOverdue(ctx context.Context, ownerID uuid.UUID, before time.Time) ([]models.MonthlyReportIncome, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+reportIncomeColumns+`
+		FROM rf_incomes i LEFT JOIN rf_payers p ON p.id = i.payer_id
+		WHERE i.owner_id = $1 AND i.deleted_at IS NULL AND i.due_date < $2::date
+		  AND i.status NOT IN ('pago', 'cancelado') AND i.total_pago < i.valor
+		ORDER BY i.due_date, p.nome NULLS LAST
+		LIMIT 1000
+	`, ownerID, before)
+	if err != nil {
+		return nil, err
+	}
+	return collectReportIncomes(rows)
+}
+
+func collectReportIncomes(rows pgx.Rows) ([]models.MonthlyReportIncome, error) {
+	defer rows.Close()
+	var out []models.MonthlyReportIncome
+	for rows.Next() {
+		var i models.MonthlyReportIncome
+		if err := rows.Scan(&i.ID, &i.Competencia, &i.Categoria, &i.PayerNome, &i.DueDate, &i.Status, &i.Valor, &i.TotalPago); err != nil {
+			return nil, err
+		}
+		out = append(out, i)
+	}
+	return out, rows.Err()
+}
+
+func (r *reportRepository) ListMonthPayments(ctx context.Context, ownerID uuid.UUID, from, to time.Time) ([]models.MonthlyReportPayment, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT pg.id, pg.income_id, i.competencia, i.categoria, p.nome, pg.valor, pg.pago_em, pg.metodo
+		FROM rf_payments pg
+		JOIN rf_incomes i ON i.id = pg.income_id
+		LEFT JOIN rf_payers p ON p.id = i.payer_id
+		WHERE i.owner_id = $1 AND i.deleted_at IS NULL AND pg.reversed_at IS NULL
+		  AND pg.pago_em >= $2 AND pg.pago_em < $3
+		ORDER BY pg.pago_em, pg.id
+	`, ownerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.MonthlyReportPayment
+	for rows.Next() {
+		var p models.MonthlyReportPayment
+		if err := rows.Scan(&p.ID, &p.IncomeID, &p.Competencia, &p.Categoria, &p.PayerNome, &p.Valor, &p.PagoEm, &p.Metodo); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (r *reportRepository) ListMonthExpenses(ctx context.Context, ownerID uuid.UUID, competencias []string, from, to time.Time) ([]models.MonthlyReportExpense, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT e.id, e.descricao, e.fornecedor, e.categoria, e.competencia, e.due_date, e.status, e.valor, e.pago_em
+		FROM rf_expenses e
+		WHERE e.owner_id = $1 AND e.deleted_at IS NULL
+		  AND (e.competencia = ANY($2) OR (e.pago_em >= $3 AND e.pago_em < $4))
+		ORDER BY e.due_date NULLS LAST, e.pago_em NULLS LAST, e.created_at
+	`, ownerID, competencias, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.MonthlyReportExpense
+	for rows.Next() {
+		var e models.MonthlyReportExpense
+		if err := rows.Scan(&e.ID, &e.Descricao, &e.Fornecedor, &e.Categoria, &e.Competencia, &e.DueDate, &e.Status, &e.Valor, &e.PagoEm); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func (r *reportRepository) ListAging(ctx context.Context, ownerID uuid.UUID, before time.Time) ([]models.AgingItem, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT i.id, i.competencia, i.due_date, i.valor - i.total_pago, i.payer_id, p.nome, i.contract_id, c.numero, c.descricao
+		FROM rf_incomes i
+		LEFT JOIN rf_payers p ON p.id = i.payer_id
+		LEFT JOIN rf_contracts c ON c.id = i.contract_id
+		WHERE i.owner_id = $1 AND i.deleted_at IS NULL AND i.due_date < $2::date
+		  AND i.status NOT IN ('pago', 'cancelado') AND i.total_pago < i.valor
+		ORDER BY i.due_date
+	`, ownerID, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []models.AgingItem
+	for rows.Next() {
+		var it models.AgingItem
+		if err := rows.Scan(&it.IncomeID, &it.Competencia, &it.DueDate, &it.Saldo, &it.PayerID, &it.PayerNome,
+			&it.ContractID, &it.ContractNumero, &it.ContractDescricao); err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,138 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório das regras de categorização de receitas (rf_income_rules)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// RuleRepository define operações de persistência das regras
+type RuleRepository interface {
+	Create(ctx context.Context, rule *models.IncomeRule) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.IncomeRule, error)
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.IncomeRule, error)
+	Update(ctx context.Context, rule *models.IncomeRule) error
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+	Reorder(ctx context.Context, ownerID uuid.UUID, ids []uuid.UUID) error
+}
+
+type ruleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRuleRepository cria uma nova instância do repositório de regras
+func NewRuleRepository(db *pgxpool.Pool) RuleRepository {
+	return &ruleRepository{db: db}
+}
+
+const ruleColumns = `id, owner_id, nome, priority, enabled, conditions, actions, created_at, updated_at`
+
+func scanRule(row pgx.Row, m *models.IncomeRule) error {
+	return row.Scan(&m.ID, &m.OwnerID, &m.Nome, &m.Priority, &m.Enabled, &m.Conditions, &m.Actions, &m.CreatedAt, &m.UpdatedAt)
+}
+
+// Create insere uma nova regra
+func (r *ruleRepository) Create(ctx context.Context, m *models.IncomeRule) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	query := `
+		INSERT INTO rf_income_rules (id, owner_id, nome, priority, enabled, conditions, actions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query, m.ID, m.OwnerID, m.Nome, m.Priority, m.Enabled, m.Conditions, m.Actions).
+		Scan(&m.CreatedAt, &m.UpdatedAt)
+}
+
+// GetByID busca uma regra do usuário
+func (r *ruleRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.IncomeRule, error) {
+	query := `SELECT ` + ruleColumns + ` FROM rf_income_rules WHERE id = $1 AND owner_id = $2`
+	var m models.IncomeRule
+	if err := scanRule(r.db.QueryRow(ctx, query, id, ownerID), &m); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrRuleNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// List retorna as regras do usuário em ordem de avaliação
+func (r *ruleRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.IncomeRule, error) {
+	query := `SELECT ` + ruleColumns + ` FROM rf_income_rules WHERE owner_id = $1 ORDER BY priority, created_at`
+	rows, err := r.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.IncomeRule
+	for rows.Next() {
+		var m models.IncomeRule
+		if err := scanRule(rows, &m); err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+	return items, rows.Err()
+}
+
+// Update atualiza uma regra existente
+func (r *ruleRepository) Update(ctx context.Context, m *models.IncomeRule) error {
+	query := `
+		UPDATE rf_income_rules
+		SET nome = $3, priority = $4, enabled = $5, conditions = $6, actions = $7, updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, m.ID, m.OwnerID, m.Nome, m.Priority, m.Enabled, m.Conditions, m.Actions).
+		Scan(&m.CreatedAt, &m.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrRuleNotFound
+	}
+	return err
+}
+
+// Delete remove uma regra
+func (r *ruleRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM rf_income_rules WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrRuleNotFound
+	}
+	return nil
+}
+
+// Reorder atribui prioridades sequenciais (10, 20, 30...) na ordem dos IDs informados
+func (r *ruleRepository) Reorder(ctx context.Context, ownerID uuid.UUID, ids []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for i, id := range ids {
+		cmd, err := tx.Exec(ctx,
+			`UPDATE rf_income_rules SET priority = $3, updated_at = NOW() WHERE id = $1 AND owner_id = $2`,
+			id, ownerID, (i+1)*10)
+		if err != nil {
+			return err
+		}
+		if cmd.RowsAffected() == 0 {
+			return models.ErrRuleNotFound
+		}
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,209 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório das configurações gerais do usuário (rf_settings)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// SettingsRepository define a leitura das configurações gerais do usuário, das regras de encargos,
+// do esquema de numeração, da emissão automática dos recibos e do vencimento em dia útil
+type SettingsRepository interface {
+	Get(ctx context.Context, ownerID uuid.UUID) (*models.UserSettings, error)
+	GetFeeRules(ctx context.Context, ownerID uuid.UUID) (*models.FeeRules, error)
+	UpdateFeeRules(ctx context.Context, ownerID uuid.UUID, rules *models.FeeRules) error
+	GetInvoiceIssuer(ctx context.Context, ownerID uuid.UUID) (*models.InvoiceIssuer, error)
+	UpdateInvoiceIssuer(ctx context.Context, ownerID uuid.UUID, issuer *models.InvoiceIssuer) error
+	GetReceiptNumbering(ctx context.Context, ownerID uuid.UUID) (*models.ReceiptNumbering, error)
+	UpdateReceiptNumbering(ctx context.Context, ownerID uuid.UUID, n *models.ReceiptNumbering) error
+	LastReceiptSequence(ctx context.Context, ownerID uuid.UUID, periodo int) (int64, error)
+	GetReceiptAutomation(ctx context.Context, ownerID uuid.UUID) (*models.ReceiptAutomation, error)
+	UpdateReceiptAutomation(ctx context.Context, ownerID uuid.UUID, a *models.ReceiptAutomation) error
+	GetBusinessDays(ctx context.Context, ownerID uuid.UUID) (*models.BusinessDaySettings, error)
+	UpdateBusinessDays(ctx context.Context, ownerID uuid.UUID, b *models.BusinessDaySettings) error
+}
+
+type settingsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSettingsRepository cria uma nova instância do repositório de configurações
+func NewSettingsRepository(db *pgxpool.Pool) SettingsRepository {
+	return &settingsRepository{db: db}
+}
+
+// Get retorna as configurações do usuário; sem linha em rf_settings, retorna configurações vazias.
+// Docstring: fuso e idioma do perfil (rf_profiles) prevalecem sobre os de rf_settings.
+func (r *settingsRepository) Get(ctx context.Context, ownerID uuid.UUID) (*models.UserSettings, error) {
+	s := &models.UserSettings{OwnerID: ownerID}
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(p.timezone, s.timezone), COALESCE(p.locale, s.locale),
+		       s.template_padrao, s.unpaid_watermark, s.unpaid_watermark_text
+		FROM (SELECT $1::uuid AS id) u
+		LEFT JOIN rf_settings s ON s.owner_id = u.id
+		LEFT JOIN rf_profiles p ON p.id = u.id
+	`, ownerID).Scan(&s.Timezone, &s.Locale, &s.TemplatePadrao, &s.UnpaidWatermark, &s.UnpaidWatermarkText)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetFeeRules retorna as regras de multa e juros do usuário; sem linha em rf_settings, regras vazias
+func (r *settingsRepository) GetFeeRules(ctx context.Context, ownerID uuid.UUID) (*models.FeeRules, error) {
+	f := &models.FeeRules{}
+	err := r.db.QueryRow(ctx, `
+		SELECT multa_percent, juros_mes_percent, juros_dia_percent, carencia_dias
+		FROM rf_settings WHERE owner_id = $1
+	`, ownerID).Scan(&f.MultaPercent, &f.JurosMesPercent, &f.JurosDiaPercent, &f.CarenciaDias)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// UpdateFeeRules grava as regras de multa e juros do usuário (cria a linha de rf_settings se preciso)
+func (r *settingsRepository) UpdateFeeRules(ctx context.Context, ownerID uuid.UUID, rules *models.FeeRules) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO rf_settings (owner_id, multa_percent, juros_mes_percent, juros_dia_percent, carencia_dias)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (owner_id) DO UPDATE SET
+			multa_percent = EXCLUDED.multa_percent, juros_mes_percent = EXCLUDED.juros_mes_percent,
+			juros_dia_percent = EXCLUDED.juros_dia_percent, carencia_dias = EXCLUDED.carencia_dias
+	`, ownerID, rules.MultaPercent, rules.JurosMesPercent, rules.JurosDiaPercent, rules.CarenciaDias)
+	return err
+}
+
+// GetInvoiceIssuer retorna os dados do prestador para a NFS-e; sem linha em rf_settings, dados vazios
+func (r *settingsRepository) GetInvoiceIssuer(ctx context.Context, ownerID uuid.UUID) (*models.InvoiceIssuer, error) {
+	i := &models.InvoiceIssuer{}
+	err := r.db.QueryRow(ctx, `
+		SELECT nfse_documento, nfse_inscricao_municipal, nfse_razao_social, nfse_codigo_municipio,
+			nfse_item_lista_servico, nfse_codigo_tributacao, nfse_aliquota_iss, nfse_optante_simples,
+			nfse_serie_rps, nfse_proximo_rps
+		FROM rf_settings WHERE owner_id = $1
+	`, ownerID).Scan(&i.Documento, &i.InscricaoMunicipal, &i.RazaoSocial, &i.CodigoMunicipio, &i.ItemListaServico,
+		&i.CodigoTributacao, &i.AliquotaISS, &i.OptanteSimples, &i.SerieRPS, &i.ProximoRPS)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return i, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// UpdateInvoiceIssuer grava os dados do prestador (cria a linha de rf_settings se preciso).
+// Docstring: série nula volta ao padrão; próximo RPS nulo mantém a numeração atual.
+func (r *settingsRepository) UpdateInvoiceIssuer(ctx context.Context, ownerID uuid.UUID, i *models.InvoiceIssuer) error {
+	optante := i.OptanteSimples != nil && *i.OptanteSimples
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO rf_settings (owner_id, nfse_documento, nfse_inscricao_municipal, nfse_razao_social, nfse_codigo_municipio,
+			nfse_item_lista_servico, nfse_codigo_tributacao, nfse_aliquota_iss, nfse_optante_simples, nfse_serie_rps, nfse_proximo_rps)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, 'RF'), COALESCE($11, 1))
+		ON CONFLICT (owner_id) DO UPDATE SET
+			nfse_documento = EXCLUDED.nfse_documento, nfse_inscricao_municipal = EXCLUDED.nfse_inscricao_municipal,
+			nfse_razao_social = EXCLUDED.nfse_razao_social, nfse_codigo_municipio = EXCLUDED.nfse_codigo_municipio,
+			nfse_item_lista_servico = EXCLUDED.nfse_item_lista_servico, nfse_codigo_tributacao = EXCLUDED.nfse_codigo_tributacao,
+			nfse_aliquota_iss = EXCLUDED.nfse_aliquota_iss, nfse_optante_simples = EXCLUDED.nfse_optante_simples,
+			nfse_serie_rps = EXCLUDED.nfse_serie_rps,
+			nfse_proximo_rps = COALESCE($11, rf_settings.nfse_proximo_rps)
+	`, ownerID, i.Documento, i.InscricaoMunicipal, i.RazaoSocial, i.CodigoMunicipio, i.ItemListaServico,
+		i.CodigoTributacao, i.AliquotaISS, optante, i.SerieRPS, i.ProximoRPS)
+	return err
+}
+
+// GetReceiptNumbering retorna o esquema de numeração dos recibos; sem linha em rf_settings, o padrão
+func (r *settingsRepository) GetReceiptNumbering(ctx context.Context, ownerID uuid.UUID) (*models.ReceiptNumbering, error) {
+	n := &models.ReceiptNumbering{Reinicio: models.ReceiptNumberingNever}
+	err := r.db.QueryRow(ctx, `
+		SELECT recibo_prefixo, recibo_digitos, recibo_reinicio FROM rf_settings WHERE owner_id = $1
+	`, ownerID).Scan(&n.Prefixo, &n.Digitos, &n.Reinicio)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return n, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// UpdateReceiptNumbering grava o esquema de numeração (cria a linha de rf_settings se preciso)
+func (r *settingsRepository) UpdateReceiptNumbering(ctx context.Context, ownerID uuid.UUID, n *models.ReceiptNumbering) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO rf_settings (owner_id, recibo_prefixo, recibo_digitos, recibo_reinicio)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (owner_id) DO UPDATE SET
+			recibo_prefixo = EXCLUDED.recibo_prefixo, recibo_digitos = EXCLUDED.recibo_digitos,
+			recibo_reinicio = EXCLUDED.recibo_reinicio
+	`, ownerID, n.Prefixo, n.Digitos, n.Reinicio)
+	return err
+}
+
+// LastReceiptSequence último número exibido reservado no período (0 se nenhum recibo foi emitido nele)
+func (r *settingsRepository) LastReceiptSequence(ctx context.Context, ownerID uuid.UUID, periodo int) (int64, error) {
+	var last int64
+	err := r.db.QueryRow(ctx, `
+		SELECT ultimo FROM rf_receipt_sequences WHERE owner_id = $1 AND periodo = $2
+	`, ownerID, periodo).Scan(&last)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return last, err
+}
+
+// GetReceiptAutomation retorna a opção de recibo automático; sem linha em rf_settings, desligada
+func (r *settingsRepository) GetReceiptAutomation(ctx context.Context, ownerID uuid.UUID) (*models.ReceiptAutomation, error) {
+	a := &models.ReceiptAutomation{}
+	err := r.db.QueryRow(ctx, `SELECT recibo_automatico FROM rf_settings WHERE owner_id = $1`, ownerID).Scan(&a.EmitirAoQuitar)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// UpdateReceiptAutomation grava a opção de recibo automático (cria a linha de rf_settings se preciso)
+func (r *settingsRepository) UpdateReceiptAutomation(ctx context.Context, ownerID uuid.UUID, a *models.ReceiptAutomation) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO rf_settings (owner_id, recibo_automatico) VALUES ($1, $2)
+		ON CONFLICT (owner_id) DO UPDATE SET recibo_automatico = EXCLUDED.recibo_automatico
+	`, ownerID, a.EmitirAoQuitar)
+	return err
+}
+
+// GetBusinessDays retorna a opção de vencimento em dia útil; sem linha em rf_settings, desligada
+func (r *settingsRepository) GetBusinessDays(ctx context.Context, ownerID uuid.UUID) (*models.BusinessDaySettings, error) {
+	b := &models.BusinessDaySettings{}
+	err := r.db.QueryRow(ctx, `SELECT vencimento_dia_util FROM rf_settings WHERE owner_id = $1`, ownerID).Scan(&b.AjustarDiaUtil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// UpdateBusinessDays grava a opção de vencimento em dia útil (cria a linha de rf_settings se preciso)
+func (r *settingsRepository) UpdateBusinessDays(ctx context.Context, ownerID uuid.UUID, b *models.BusinessDaySettings) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO rf_settings (owner_id, vencimento_dia_util) VALUES ($1, $2)
+		ON CONFLICT (owner_id) DO UPDATE SET vencimento_dia_util = EXCLUDED.vencimento_dia_util
+	`, ownerID, b.AjustarDiaUtil)
+	return err
+}
@@ -7,18 +7,23 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"recibofast/internal/models"
 )
 
 // SignatureRepository define operações de persistência para assinaturas
-// Docstring: Cria registros em rf_signatures com validações por owner_id
-// Tudo em PT-BR.
+// Docstring: Cria registros em rf_signatures com validações por owner_id; cada registro é uma
+// versão (imutável) da assinatura do usuário. Tudo em PT-BR.
 
 type SignatureRepository interface {
 	Create(ctx context.Context, s *models.SignatureRecord) error
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.SignatureRecord, error)
+	GetByVersion(ctx context.Context, ownerID uuid.UUID, version int) (*models.SignatureRecord, error)
+	GetByHash(ctx context.Context, ownerID uuid.UUID, hash string) (*models.SignatureRecord, error)
 }
 
 type signatureRepository struct {
@@ -29,23 +34,94 @@ func NewSignatureRepository(db *pgxpool.Pool) SignatureRepository {
 	return &signatureRepository{db: db}
 }
 
-// Create insere metadados de assinatura em rf_signatures
+// Create insere metadados de assinatura em rf_signatures como a próxima versão do usuário.
+// Docstring: a trava por usuário serializa envios simultâneos; s.Version e s.CreatedAt recebem os
+// valores gravados.
 func (r *signatureRepository) Create(ctx context.Context, s *models.SignatureRecord) error {
 	if s.ID == uuid.Nil {
 		s.ID = uuid.New()
 	}
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('rf_signatures:' || $1))`, s.OwnerID.String()); err != nil {
+		return err
+	}
 	query := `
 		INSERT INTO rf_signatures (
 			id, owner_id, file_path, file_name, file_size, mime_type,
 			width_px, height_px, hash, version, created_at
 		) VALUES (
 			$1, $2, $3, $4, $5, $6,
-			$7, $8, $9, $10, NOW()
+			$7, $8, $9, (SELECT COALESCE(MAX(version), 0) + 1 FROM rf_signatures WHERE owner_id = $2), NOW()
 		)
+		RETURNING version, created_at
 	`
-	_, err := r.db.Exec(ctx, query,
+	if err := tx.QueryRow(ctx, query,
 		s.ID, s.OwnerID, s.FilePath, s.FileName, s.FileSize, s.MimeType,
-		s.WidthPX, s.HeightPX, s.Hash, s.Version,
-	)
-	return err
+		s.WidthPX, s.HeightPX, s.Hash,
+	).Scan(&s.Version, &s.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+const signatureColumns = `id, owner_id, file_path, COALESCE(file_name, ''), COALESCE(file_size, 0), COALESCE(mime_type, ''),
+	COALESCE(width_px, 0), COALESCE(height_px, 0), COALESCE(hash, ''), version, created_at`
+
+func scanSignature(row pgx.Row, s *models.SignatureRecord) error {
+	return row.Scan(&s.ID, &s.OwnerID, &s.FilePath, &s.FileName, &s.FileSize, &s.MimeType,
+		&s.WidthPX, &s.HeightPX, &s.Hash, &s.Version, &s.CreatedAt)
+}
+
+// List histórico de versões do usuário, da mais recente para a mais antiga
+func (r *signatureRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.SignatureRecord, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+signatureColumns+` FROM rf_signatures WHERE owner_id = $1 ORDER BY version DESC`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.SignatureRecord{}
+	for rows.Next() {
+		var s models.SignatureRecord
+		if err := scanSignature(rows, &s); err != nil {
+			return nil, err
+		}
+		items = append(items, s)
+	}
+	return items, rows.Err()
+}
+
+// GetByVersion busca uma versão da assinatura do usuário
+func (r *signatureRepository) GetByVersion(ctx context.Context, ownerID uuid.UUID, version int) (*models.SignatureRecord, error) {
+	var s models.SignatureRecord
+	err := scanSignature(r.db.QueryRow(ctx, `SELECT `+signatureColumns+` FROM rf_signatures WHERE owner_id = $1 AND version = $2`, ownerID, version), &s)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrSignatureNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetByHash versão mais recente do usuário com o mesmo conteúdo (SHA-256 da imagem tratada)
+func (r *signatureRepository) GetByHash(ctx context.Context, ownerID uuid.UUID, hash string) (*models.SignatureRecord, error) {
+	var s models.SignatureRecord
+	err := scanSignature(r.db.QueryRow(ctx, `
+		SELECT `+signatureColumns+` FROM rf_signatures
+		WHERE owner_id = $1 AND hash = $2
+		ORDER BY version DESC LIMIT 1
+	`, ownerID, hash), &s)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrSignatureNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
 }
@@ -0,0 +1,71 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório da coleta de objetos órfãos do Storage (referências em rf_signatures, rf_receipts e rf_artifacts)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StorageGCRepository consulta quais objetos do Storage ainda são referenciados no banco
+type StorageGCRepository interface {
+	// Referenced devolve, dentre paths do bucket, os que têm referência
+	Referenced(ctx context.Context, bucket string, paths []string) (map[string]bool, error)
+}
+
+type storageGCRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewStorageGCRepository cria uma nova instância do repositório da coleta do Storage
+func NewStorageGCRepository(db *pgxpool.Pool) StorageGCRepository {
+	return &storageGCRepository{db: db}
+}
+
+// Referenced confere as referências de cada caminho.
+// Docstring: objetos por usuário ficam em "{owner_id}/..." (assinaturas, PDFs de recibos e anexos de
+// despesas); o dono extraído do caminho restringe a busca ao índice por owner_id. pdf_url pode
+// guardar o caminho ou a URL completa terminada nele. Artefatos (cas/..) são conferidos pelo hash do caminho em
+// rf_artifacts, inclusive os já sem referências, cuja remoção cabe à coleta de artefatos.
+func (r *storageGCRepository) Referenced(ctx context.Context, bucket string, paths []string) (map[string]bool, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH c AS (
+			SELECT p,
+				CASE WHEN split_part(p, '/', 1) ~ '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$'
+					THEN split_part(p, '/', 1)::uuid END AS owner_id
+			FROM unnest($2::text[]) AS p
+		)
+		SELECT c.p FROM c
+		WHERE EXISTS (
+				SELECT 1 FROM rf_artifacts a
+				WHERE a.hash = substring(c.p from '^cas/[0-9a-f]{2}/([0-9a-f]{64})\.') AND a.bucket = $1 AND a.path = c.p
+			)
+			OR (c.owner_id IS NOT NULL AND (
+				EXISTS (SELECT 1 FROM rf_signatures s WHERE s.owner_id = c.owner_id AND s.file_path = c.p)
+				OR EXISTS (SELECT 1 FROM rf_expense_attachments ea WHERE ea.owner_id = c.owner_id AND ea.file_path = c.p)
+				OR EXISTS (
+					SELECT 1 FROM rf_receipts rc
+					WHERE rc.owner_id = c.owner_id
+						AND (rc.pdf_url = c.p OR right(rc.pdf_url, length(c.p) + 1) = '/' || c.p)
+				)
+			))
+	`, bucket, paths)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refs := make(map[string]bool, len(paths))
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		refs[p] = true
+	}
+	return refs, rows.Err()
+}
@@ -0,0 +1,143 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório dos conflitos de sincronização offline (rf_sync_conflicts)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// SyncConflictRepository define operações de persistência dos conflitos de sincronização.
+// Docstring: Create é usado pelo envio offline ao detectar versão desatualizada; Resolve só
+// altera conflitos ainda pendentes (ErrSyncConflictResolved se outro resolveu antes).
+type SyncConflictRepository interface {
+	Create(ctx context.Context, c *models.SyncConflict) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.SyncConflict, error)
+	ListPending(ctx context.Context, ownerID uuid.UUID, deviceID string) ([]models.SyncConflict, error)
+	Resolve(ctx context.Context, c *models.SyncConflict) error
+}
+
+type syncConflictRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSyncConflictRepository cria uma nova instância do repositório de conflitos de sincronização
+func NewSyncConflictRepository(db *pgxpool.Pool) SyncConflictRepository {
+	return &syncConflictRepository{db: db}
+}
+
+const syncConflictColumns = `id, owner_id, device_id, entity, entity_id, base_version, client_data, server_data,
+	server_version, status, resolution, resolved_data, resolved_by, created_at, resolved_at`
+
+func scanSyncConflict(row pgx.Row, c *models.SyncConflict) error {
+	var client, server, resolved []byte
+	if err := row.Scan(&c.ID, &c.OwnerID, &c.DeviceID, &c.Entity, &c.EntityID, &c.BaseVersion, &client, &server,
+		&c.ServerVersion, &c.Status, &c.Resolution, &resolved, &c.ResolvedBy, &c.CreatedAt, &c.ResolvedAt); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(client, &c.ClientData); err != nil {
+		return fmt.Errorf("erro ao decodificar client_data: %w", err)
+	}
+	if err := json.Unmarshal(server, &c.ServerData); err != nil {
+		return fmt.Errorf("erro ao decodificar server_data: %w", err)
+	}
+	if resolved != nil {
+		if err := json.Unmarshal(resolved, &c.ResolvedData); err != nil {
+			return fmt.Errorf("erro ao decodificar resolved_data: %w", err)
+		}
+	}
+	return nil
+}
+
+// Create registra um conflito pendente
+func (r *syncConflictRepository) Create(ctx context.Context, c *models.SyncConflict) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	if c.Entity == "" {
+		c.Entity = models.SyncEntityIncome
+	}
+	client, err := json.Marshal(c.ClientData)
+	if err != nil {
+		return err
+	}
+	server, err := json.Marshal(c.ServerData)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO rf_sync_conflicts (id, owner_id, device_id, entity, entity_id, base_version, client_data, server_data, server_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING status, created_at
+	`
+	return r.db.QueryRow(ctx, query, c.ID, c.OwnerID, c.DeviceID, c.Entity, c.EntityID, c.BaseVersion, client, server, c.ServerVersion).
+		Scan(&c.Status, &c.CreatedAt)
+}
+
+// GetByID busca um conflito do usuário
+func (r *syncConflictRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.SyncConflict, error) {
+	var c models.SyncConflict
+	err := scanSyncConflict(r.db.QueryRow(ctx, `SELECT `+syncConflictColumns+` FROM rf_sync_conflicts WHERE id = $1 AND owner_id = $2`, id, ownerID), &c)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrSyncConflictNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// ListPending lista os conflitos pendentes do usuário (deviceID vazio: todos os dispositivos)
+func (r *syncConflictRepository) ListPending(ctx context.Context, ownerID uuid.UUID, deviceID string) ([]models.SyncConflict, error) {
+	query := `SELECT ` + syncConflictColumns + `
+		FROM rf_sync_conflicts
+		WHERE owner_id = $1 AND status = 'pending' AND ($2 = '' OR device_id = $2)
+		ORDER BY device_id, created_at`
+	rows, err := r.db.Query(ctx, query, ownerID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []models.SyncConflict{}
+	for rows.Next() {
+		var c models.SyncConflict
+		if err := scanSyncConflict(rows, &c); err != nil {
+			return nil, err
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+// Resolve grava a resolução de um conflito pendente
+func (r *syncConflictRepository) Resolve(ctx context.Context, c *models.SyncConflict) error {
+	var resolved []byte
+	if len(c.ResolvedData) > 0 {
+		b, err := json.Marshal(c.ResolvedData)
+		if err != nil {
+			return err
+		}
+		resolved = b
+	}
+	query := `
+		UPDATE rf_sync_conflicts
+		SET status = 'resolved', resolution = $3, resolved_data = $4, resolved_by = $5, resolved_at = now()
+		WHERE id = $1 AND owner_id = $2 AND status = 'pending'
+		RETURNING status, resolved_at
+	`
+	err := r.db.QueryRow(ctx, query, c.ID, c.OwnerID, c.Resolution, resolved, c.ResolvedBy).Scan(&c.Status, &c.ResolvedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrSyncConflictResolved
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório do feed de sincronização (alterações das tabelas rf_* por updated_at)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// SyncRepository leitura incremental das entidades sincronizadas offline
+type SyncRepository interface {
+	// Changes lista até limit alterações de entity com since < updated_at <= until depois de after
+	// (nil = do início), em ordem de (updated_at, id), incluindo exclusões; columns restringe o
+	// data às colunas listadas (vazio = linha completa)
+	Changes(ctx context.Context, ownerID uuid.UUID, entity string, since, until time.Time, after *models.SyncPosition, columns []string, limit int) ([]models.SyncChange, error)
+	// State conta as linhas e exclusões de entity com updated_at <= until e devolve a mais recente
+	State(ctx context.Context, ownerID uuid.UUID, entity string, until time.Time) (models.SyncEntityState, error)
+}
+
+type syncRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSyncRepository cria uma nova instância do repositório de sincronização
+func NewSyncRepository(db *pgxpool.Pool) SyncRepository {
+	return &syncRepository{db: db}
+}
+
+// syncLive linhas atuais de cada entidade ($1 = dono); receitas excluídas saem como tombstone
+var syncLive = map[string]string{
+	models.SyncFeedIncomes: `SELECT t.id, t.updated_at, t.deleted_at IS NOT NULL AS deleted,
+		       CASE WHEN t.deleted_at IS NULL THEN to_jsonb(t) END AS data
+		FROM rf_incomes t WHERE t.owner_id = $1`,
+	models.SyncFeedPayments: `SELECT t.id, t.updated_at, false AS deleted, to_jsonb(t) AS data
+		FROM rf_payments t JOIN rf_incomes i ON i.id = t.income_id WHERE i.owner_id = $1`,
+	models.SyncFeedReceipts: `SELECT t.id, t.updated_at, false AS deleted, to_jsonb(t) AS data
+		FROM rf_receipts t WHERE t.owner_id = $1`,
+	models.SyncFeedSignatures: `SELECT t.id, t.updated_at, false AS deleted, to_jsonb(t) AS data
+		FROM rf_signatures t WHERE t.owner_id = $1`,
+}
+
+// syncWindow janela (since, until] e posição do cursor, aplicada a cada parte da consulta
+const syncWindow = ` AND t.updated_at > $2 AND t.updated_at <= $3 AND (t.updated_at, t.id) > ($4, $5)`
+
+// Changes lê as linhas alteradas e, exceto receitas, as marcas de rf_sync_tombstones
+func (r *syncRepository) Changes(ctx context.Context, ownerID uuid.UUID, entity string, since, until time.Time, after *models.SyncPosition, columns []string, limit int) ([]models.SyncChange, error) {
+	live, ok := syncLive[entity]
+	if !ok {
+		return nil, models.ErrSyncEntityInvalid
+	}
+	pos := models.SyncPosition{UpdatedAt: since}
+	if after != nil {
+		pos = *after
+	}
+	args := []any{ownerID, since, until, pos.UpdatedAt, pos.ID, limit}
+	query := live + syncWindow
+	if entity != models.SyncFeedIncomes {
+		query += `
+		UNION ALL
+		SELECT t.id, t.updated_at, true, NULL FROM (
+			SELECT entity_id AS id, deleted_at AS updated_at FROM rf_sync_tombstones
+			WHERE owner_id = $1 AND entity = $7
+		) t WHERE true` + syncWindow
+		args = append(args, entity)
+	}
+	query = fmt.Sprintf(`SELECT id, updated_at, deleted, data FROM (%s) c ORDER BY updated_at, id LIMIT $6`, query)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.SyncChange{}
+	for rows.Next() {
+		var c models.SyncChange
+		if err := rows.Scan(&c.ID, &c.UpdatedAt, &c.Deleted, &c.Data); err != nil {
+			return nil, err
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+// State agrega as linhas atuais e, exceto receitas, as marcas de exclusão até until
+func (r *syncRepository) State(ctx context.Context, ownerID uuid.UUID, entity string, until time.Time) (models.SyncEntityState, error) {
+	var st models.SyncEntityState
+	live, ok := syncLive[entity]
+	if !ok {
+		return st, models.ErrSyncEntityInvalid
+	}
+	args := []any{ownerID, until}
+	query := fmt.Sprintf(live, "NULL::jsonb") + ` AND t.updated_at <= $2`
+	if entity != models.SyncFeedIncomes {
+		args = append(args, entity)
+		query += `
+			UNION ALL
+			SELECT entity_id, deleted_at, true, NULL FROM rf_sync_tombstones
+			WHERE owner_id = $1 AND entity = $3 AND deleted_at <= $2`
+	}
+	query = fmt.Sprintf(`SELECT count(*), max(updated_at) FROM (%s) c`, query)
+	err := r.db.QueryRow(ctx, query, args...).Scan(&st.Count, &st.LastModified)
+	return st, err
+}
@@ -0,0 +1,63 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório do vetor de versões por dispositivo do envio offline (rf_sync_versions)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// SyncVersionRepository última versão de cada receita gravada por dispositivo
+type SyncVersionRepository interface {
+	// Vector devolve dispositivo -> versão gravada (vazio se nenhum dispositivo enviou a receita)
+	Vector(ctx context.Context, ownerID, incomeID uuid.UUID) (map[string]int64, error)
+	Set(ctx context.Context, ownerID, incomeID uuid.UUID, deviceID string, version int64) error
+}
+
+type syncVersionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSyncVersionRepository cria uma nova instância do repositório de versões de sincronização
+func NewSyncVersionRepository(db *pgxpool.Pool) SyncVersionRepository {
+	return &syncVersionRepository{db: db}
+}
+
+// Vector lê o vetor de versões da receita
+func (r *syncVersionRepository) Vector(ctx context.Context, ownerID, incomeID uuid.UUID) (map[string]int64, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT device_id, version FROM rf_sync_versions
+		WHERE entity = $1 AND entity_id = $2 AND owner_id = $3
+	`, models.SyncEntityIncome, incomeID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vector := map[string]int64{}
+	for rows.Next() {
+		var device string
+		var version int64
+		if err := rows.Scan(&device, &version); err != nil {
+			return nil, err
+		}
+		vector[device] = version
+	}
+	return vector, rows.Err()
+}
+
+// Set registra a versão gravada pelo dispositivo
+func (r *syncVersionRepository) Set(ctx context.Context, ownerID, incomeID uuid.UUID, deviceID string, version int64) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO rf_sync_versions (owner_id, entity, entity_id, device_id, version)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (entity, entity_id, device_id) DO UPDATE SET version = EXCLUDED.version, updated_at = now()
+	`, ownerID, models.SyncEntityIncome, incomeID, deviceID, version)
+	return err
+}
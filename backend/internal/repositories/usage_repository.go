@@ -0,0 +1,104 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório de planos e consumo (rf_plans, rf_usage)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// UsageRepository operações de planos e contadores de consumo
+type UsageRepository interface {
+	GetPlan(ctx context.Context, ownerID uuid.UUID) (*models.Plan, error)
+	Reserve(ctx context.Context, ownerID uuid.UUID, period time.Time, metric string, limit *int) (bool, error)
+	Release(ctx context.Context, ownerID uuid.UUID, period time.Time, metric string) error
+	Used(ctx context.Context, ownerID uuid.UUID, period time.Time, metric string) (int, error)
+	CountSignatures(ctx context.Context, ownerID uuid.UUID) (int, error)
+}
+
+type usageRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewUsageRepository cria uma nova instância do repositório de planos e consumo
+func NewUsageRepository(db *pgxpool.Pool) UsageRepository {
+	return &usageRepository{db: db}
+}
+
+// GetPlan plano do usuário (app_metadata.plan); sem plano ou com código desconhecido usa o padrão
+func (r *usageRepository) GetPlan(ctx context.Context, ownerID uuid.UUID) (*models.Plan, error) {
+	var p models.Plan
+	err := r.db.QueryRow(ctx, `
+		WITH wanted AS (
+			SELECT COALESCE((SELECT raw_app_meta_data->>'plan' FROM auth.users WHERE id = $1), $2) AS code
+		)
+		SELECT p.code, p.nome, p.receipts_per_month, p.max_signatures
+		FROM rf_plans p, wanted w
+		WHERE p.code IN (w.code, $2)
+		ORDER BY (p.code = w.code) DESC
+		LIMIT 1
+	`, ownerID, models.DefaultPlan).Scan(&p.Code, &p.Nome, &p.ReceiptsPerMonth, &p.MaxSignatures)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Catálogo vazio: sem limites
+		return &models.Plan{Code: models.DefaultPlan, Nome: models.DefaultPlan}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Reserve incrementa o contador se ainda houver saldo (atômico); false quando o limite foi atingido
+func (r *usageRepository) Reserve(ctx context.Context, ownerID uuid.UUID, period time.Time, metric string, limit *int) (bool, error) {
+	var used int
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO rf_usage (owner_id, period, metric, used)
+		SELECT $1, $2, $3, 1
+		WHERE $4::int IS NULL OR $4 > 0
+		ON CONFLICT (owner_id, period, metric) DO UPDATE
+		SET used = rf_usage.used + 1, updated_at = now()
+		WHERE $4::int IS NULL OR rf_usage.used < $4
+		RETURNING used
+	`, ownerID, period, metric, limit).Scan(&used)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Release devolve uma unidade reservada (operação que falhou)
+func (r *usageRepository) Release(ctx context.Context, ownerID uuid.UUID, period time.Time, metric string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE rf_usage SET used = GREATEST(used - 1, 0), updated_at = now()
+		WHERE owner_id = $1 AND period = $2 AND metric = $3
+	`, ownerID, period, metric)
+	return err
+}
+
+// Used consumo da métrica no período (0 sem registro)
+func (r *usageRepository) Used(ctx context.Context, ownerID uuid.UUID, period time.Time, metric string) (int, error) {
+	var used int
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE((SELECT used FROM rf_usage WHERE owner_id = $1 AND period = $2 AND metric = $3), 0)
+	`, ownerID, period, metric).Scan(&used)
+	return used, err
+}
+
+// CountSignatures assinaturas lógicas do usuário: só a versão atual conta (migração 049)
+func (r *usageRepository) CountSignatures(ctx context.Context, ownerID uuid.UUID) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT count(*) FROM rf_signatures
+		WHERE owner_id = $1 AND version = (SELECT max(version) FROM rf_signatures WHERE owner_id = $1)
+	`, ownerID).Scan(&n)
+	return n, err
+}
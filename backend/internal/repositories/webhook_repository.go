@@ -0,0 +1,218 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Repositório dos webhooks do usuário (rf_webhooks) e da outbox de eventos (rf_outbox_events)
+// Data: 18-10-2026
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"recibofast/internal/models"
+)
+
+// WebhookRepository define a persistência dos webhooks.
+// Docstring: a URL é única por usuário (uq_webhooks_owner_url); ListActive devolve os webhooks
+// ativos do usuário para o publicador filtrar pelos eventos assinados.
+type WebhookRepository interface {
+	Create(ctx context.Context, w *models.Webhook) error
+	GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Webhook, error)
+	List(ctx context.Context, ownerID uuid.UUID) ([]models.Webhook, error)
+	ListActive(ctx context.Context, ownerID uuid.UUID) ([]models.Webhook, error)
+	Count(ctx context.Context, ownerID uuid.UUID) (int, error)
+	Update(ctx context.Context, w *models.Webhook) error
+	RotateSecret(ctx context.Context, id, ownerID uuid.UUID, secret string) (*models.Webhook, error)
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+}
+
+type webhookRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWebhookRepository cria uma nova instância do repositório de webhooks
+func NewWebhookRepository(db *pgxpool.Pool) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+const webhookColumns = `id, owner_id, url, secret, events, description, active, created_at, updated_at`
+
+func scanWebhook(row pgx.Row, w *models.Webhook) error {
+	return row.Scan(&w.ID, &w.OwnerID, &w.URL, &w.Secret, &w.Events, &w.Description, &w.Active, &w.CreatedAt, &w.UpdatedAt)
+}
+
+// mapWebhookError traduz a violação da URL única para erro de domínio
+func mapWebhookError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrWebhookNotFound
+	}
+	if isConstraintViolation(err, pgUniqueViolation, "uq_webhooks_owner_url") {
+		return models.ErrWebhookURLTaken
+	}
+	return err
+}
+
+// Create grava um novo webhook
+func (r *webhookRepository) Create(ctx context.Context, w *models.Webhook) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	if w.Events == nil {
+		w.Events = []string{}
+	}
+	err := scanWebhook(r.db.QueryRow(ctx, `
+		INSERT INTO rf_webhooks (id, owner_id, url, secret, events, description, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+webhookColumns,
+		w.ID, w.OwnerID, w.URL, w.Secret, w.Events, w.Description, w.Active), w)
+	return mapWebhookError(err)
+}
+
+// GetByID busca um webhook do usuário
+func (r *webhookRepository) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Webhook, error) {
+	var w models.Webhook
+	err := scanWebhook(r.db.QueryRow(ctx,
+		`SELECT `+webhookColumns+` FROM rf_webhooks WHERE id = $1 AND owner_id = $2`, id, ownerID), &w)
+	if err != nil {
+		return nil, mapWebhookError(err)
+	}
+	return &w, nil
+}
+
+// List webhooks do usuário, do mais antigo ao mais recente
+func (r *webhookRepository) List(ctx context.Context, ownerID uuid.UUID) ([]models.Webhook, error) {
+	return r.list(ctx, `SELECT `+webhookColumns+` FROM rf_webhooks WHERE owner_id = $1 ORDER BY created_at`, ownerID)
+}
+
+// ListActive webhooks ativos do usuário
+func (r *webhookRepository) ListActive(ctx context.Context, ownerID uuid.UUID) ([]models.Webhook, error) {
+	return r.list(ctx, `SELECT `+webhookColumns+` FROM rf_webhooks WHERE owner_id = $1 AND active ORDER BY created_at`, ownerID)
+}
+
+func (r *webhookRepository) list(ctx context.Context, query string, args ...interface{}) ([]models.Webhook, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.Webhook{}
+	for rows.Next() {
+		var w models.Webhook
+		if err := scanWebhook(rows, &w); err != nil {
+			return nil, err
+		}
+		items = append(items, w)
+	}
+	return items, rows.Err()
+}
+
+// Count quantidade de webhooks do usuário (limite models.MaxWebhooks)
+func (r *webhookRepository) Count(ctx context.Context, ownerID uuid.UUID) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM rf_webhooks WHERE owner_id = $1`, ownerID).Scan(&n)
+	return n, err
+}
+
+// Update grava URL, eventos, descrição e situação do webhook
+func (r *webhookRepository) Update(ctx context.Context, w *models.Webhook) error {
+	err := scanWebhook(r.db.QueryRow(ctx, `
+		UPDATE rf_webhooks
+		SET url = $3, events = $4, description = $5, active = $6, updated_at = now()
+		WHERE id = $1 AND owner_id = $2
+		RETURNING `+webhookColumns,
+		w.ID, w.OwnerID, w.URL, w.Events, w.Description, w.Active), w)
+	return mapWebhookError(err)
+}
+
+// RotateSecret troca o segredo de assinatura do webhook
+func (r *webhookRepository) RotateSecret(ctx context.Context, id, ownerID uuid.UUID, secret string) (*models.Webhook, error) {
+	var w models.Webhook
+	err := scanWebhook(r.db.QueryRow(ctx, `
+		UPDATE rf_webhooks SET secret = $3, updated_at = now()
+		WHERE id = $1 AND owner_id = $2
+		RETURNING `+webhookColumns, id, ownerID, secret), &w)
+	if err != nil {
+		return nil, mapWebhookError(err)
+	}
+	return &w, nil
+}
+
+// Delete remove o webhook; entregas pendentes falham permanentemente no envio
+func (r *webhookRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rf_webhooks WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// OutboxRepository define o consumo da outbox de eventos de domínio.
+// Docstring: os eventos são gravados por trigger (migração 037); ClaimPending reserva com
+// FOR UPDATE SKIP LOCKED e eventos presos além do lockTimeout voltam a ser elegíveis.
+type OutboxRepository interface {
+	ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOutboxRepository cria uma nova instância do repositório da outbox
+func NewOutboxRepository(db *pgxpool.Pool) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// ClaimPending reserva até limit eventos não publicados, na ordem de criação
+func (r *outboxRepository) ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.OutboxEvent, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE rf_outbox_events
+		SET locked_at = $1
+		WHERE id IN (
+			SELECT id FROM rf_outbox_events
+			WHERE published_at IS NULL AND (locked_at IS NULL OR locked_at < $2)
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, owner_id, event_type, payload, created_at, published_at`,
+		now, now.Add(-lockTimeout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.OutboxEvent
+	for rows.Next() {
+		var e models.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.OwnerID, &e.EventType, &e.Payload, &e.CreatedAt, &e.PublishedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+	}
+	return items, rows.Err()
+}
+
+// MarkPublished registra que as entregas do evento foram enfileiradas
+func (r *outboxRepository) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE rf_outbox_events SET published_at = $2, locked_at = NULL WHERE id = $1`, id, at)
+	return err
+}
+
+// DeletePublishedBefore apaga eventos já publicados antes de before (job de ciclo de vida)
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rf_outbox_events WHERE published_at IS NOT NULL AND published_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
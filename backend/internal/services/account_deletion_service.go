@@ -0,0 +1,172 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Exclusão da conta a pedido do titular (LGPD): bloqueio, carência e remoção pelo worker
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// DeletionStorage operações de Storage usadas na exclusão (implementado por storage.Client)
+type DeletionStorage interface {
+	ListObjects(ctx context.Context, bucket, prefix string) ([]string, error)
+	DeleteObject(ctx context.Context, bucket, objectPath string) error
+}
+
+// AccountDeletionService pedidos de exclusão de conta.
+// Docstring: o pedido bloqueia a conta na API (SupabaseAuth) e fica em carência por
+// models.AccountDeletionGracePeriod, quando ainda pode ser cancelado. Vencida a carência, o
+// worker account-deletions apaga os objetos em "{owner_id}/..." dos buckets e depois as linhas
+// rf_* do titular; falhas são retentadas até models.AccountDeletionMaxAttempts.
+type AccountDeletionService struct {
+	repo        repositories.AccountDeletionRepository
+	store       DeletionStorage
+	buckets     []string
+	log         logging.Logger
+	lockTimeout time.Duration
+	now         func() time.Time
+}
+
+// NewAccountDeletionService cria o serviço de exclusão para os buckets com pastas por usuário
+func NewAccountDeletionService(repo repositories.AccountDeletionRepository, store DeletionStorage, buckets []string, log logging.Logger) *AccountDeletionService {
+	return &AccountDeletionService{repo: repo, store: store, buckets: buckets, log: log, lockTimeout: time.Hour, now: time.Now}
+}
+
+// Request registra o pedido e bloqueia a conta até o fim da carência
+func (s *AccountDeletionService) Request(ctx context.Context, ownerID uuid.UUID, req *models.AccountDeletionRequest) (*models.AccountDeletion, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	d := &models.AccountDeletion{
+		OwnerID:      ownerID,
+		Status:       models.AccountDeletionPending,
+		RequestedAt:  now,
+		ScheduledFor: now.Add(models.AccountDeletionGracePeriod),
+	}
+	if req.Reason != "" {
+		d.Reason = &req.Reason
+	}
+	if err := s.repo.Create(ctx, d); err != nil {
+		return nil, err
+	}
+	s.log.Info("exclusão de conta solicitada", logging.Field{Key: "deletion_id", Val: d.ID}, logging.Field{Key: "scheduled_for", Val: d.ScheduledFor})
+	return d, nil
+}
+
+// Status retorna o pedido mais recente do titular
+func (s *AccountDeletionService) Status(ctx context.Context, ownerID uuid.UUID) (*models.AccountDeletion, error) {
+	return s.repo.GetLatest(ctx, ownerID)
+}
+
+// Cancel desiste do pedido ainda em carência e desbloqueia a conta
+func (s *AccountDeletionService) Cancel(ctx context.Context, ownerID uuid.UUID) (*models.AccountDeletion, error) {
+	d, err := s.repo.GetLatest(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if !d.Locked() {
+		return nil, models.ErrDeletionNotFound
+	}
+	if !d.Cancellable() {
+		return nil, models.ErrDeletionNotCancellable
+	}
+	d, err = s.repo.Cancel(ctx, d.ID, s.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	s.log.Info("exclusão de conta cancelada", logging.Field{Key: "deletion_id", Val: d.ID})
+	return d, nil
+}
+
+// IsLocked indica se a conta está bloqueada por um pedido de exclusão
+func (s *AccountDeletionService) IsLocked(ctx context.Context, ownerID uuid.UUID) (bool, error) {
+	return s.repo.IsLocked(ctx, ownerID)
+}
+
+// ProcessDue reserva e executa até limit exclusões vencidas; retorna quantas foram processadas
+func (s *AccountDeletionService) ProcessDue(ctx context.Context, limit int) (int, error) {
+	now := s.now().UTC()
+	items, err := s.repo.ClaimDue(ctx, now, now.Add(-s.lockTimeout), limit)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao reservar exclusões de conta: %w", err)
+	}
+	for i := range items {
+		if err := s.process(ctx, &items[i]); err != nil {
+			s.log.Error("erro ao excluir conta",
+				logging.Field{Key: "deletion_id", Val: items[i].ID.String()},
+				logging.Field{Key: "attempts", Val: items[i].Attempts},
+				logging.Field{Key: "error", Val: err.Error()})
+		}
+	}
+	return len(items), nil
+}
+
+// QueueDepths profundidade da fila de exclusões (painel de jobs)
+func (s *AccountDeletionService) QueueDepths(ctx context.Context) ([]models.QueueDepth, error) {
+	q, err := s.repo.QueueDepth(ctx, s.now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar fila de exclusões de conta: %w", err)
+	}
+	return []models.QueueDepth{*q}, nil
+}
+
+// process remove os objetos do Storage e depois as linhas do titular.
+// Docstring: os objetos vêm primeiro porque as linhas guardam os caminhos; numa nova tentativa
+// os já removidos simplesmente não são mais listados.
+func (s *AccountDeletionService) process(ctx context.Context, d *models.AccountDeletion) error {
+	// O registro do resultado não deve ser interrompido pelo cancelamento do worker
+	bg := context.WithoutCancel(ctx)
+	res := &models.AccountDeletionResult{Objects: map[string]int{}}
+	var cause error
+	if err := s.deleteObjects(ctx, d.OwnerID, res); err != nil {
+		cause = fmt.Errorf("erro ao remover objetos do Storage: %w", err)
+	} else if rows, err := s.repo.Purge(ctx, d.OwnerID); err != nil {
+		cause = err
+	} else {
+		res.Rows = rows
+	}
+
+	d.Result = res
+	if cause != nil {
+		msg := cause.Error()
+		d.Status, d.Error = models.AccountDeletionFailed, &msg
+	} else {
+		d.Status, d.Error = models.AccountDeletionCompleted, nil
+	}
+	if err := s.repo.Finish(bg, d, s.now().UTC()); err != nil {
+		return errors.Join(cause, fmt.Errorf("erro ao registrar exclusão de conta: %w", err))
+	}
+	if cause == nil {
+		s.log.Info("conta excluída", logging.Field{Key: "deletion_id", Val: d.ID}, logging.Field{Key: "tables", Val: len(res.Rows)})
+	}
+	return cause
+}
+
+// deleteObjects remove os objetos da pasta do titular em cada bucket
+func (s *AccountDeletionService) deleteObjects(ctx context.Context, ownerID uuid.UUID, res *models.AccountDeletionResult) error {
+	prefix := ownerID.String()
+	for _, bucket := range s.buckets {
+		paths, err := s.store.ListObjects(ctx, bucket, prefix)
+		if err != nil {
+			return fmt.Errorf("%s: %w", bucket, err)
+		}
+		for _, p := range paths {
+			if err := s.store.DeleteObject(ctx, bucket, p); err != nil {
+				return fmt.Errorf("%s/%s: %w", bucket, p, err)
+			}
+			res.Objects[bucket]++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da exclusão de conta (carência, cancelamento e remoção pelo worker)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeDeletionRepo implementa repositories.AccountDeletionRepository em memória
+type fakeDeletionRepo struct {
+    items    []*models.AccountDeletion
+    purgeErr error
+    purged   []uuid.UUID
+}
+
+func (f *fakeDeletionRepo) Create(ctx context.Context, d *models.AccountDeletion) error {
+    for _, it := range f.items { if it.OwnerID == d.OwnerID && it.Locked() { return models.ErrDeletionExists } }
+    d.ID = uuid.New()
+    cp := *d
+    f.items = append(f.items, &cp)
+    return nil
+}
+func (f *fakeDeletionRepo) GetLatest(ctx context.Context, ownerID uuid.UUID) (*models.AccountDeletion, error) {
+    for i := len(f.items) - 1; i >= 0; i-- {
+        if f.items[i].OwnerID == ownerID { cp := *f.items[i]; return &cp, nil }
+    }
+    return nil, models.ErrDeletionNotFound
+}
+func (f *fakeDeletionRepo) IsLocked(ctx context.Context, ownerID uuid.UUID) (bool, error) {
+    d, err := f.GetLatest(ctx, ownerID)
+    if errors.Is(err, models.ErrDeletionNotFound) { return false, nil }
+    return d.Locked(), err
+}
+func (f *fakeDeletionRepo) Cancel(ctx context.Context, id uuid.UUID, now time.Time) (*models.AccountDeletion, error) {
+    for _, it := range f.items {
+        if it.ID == id && it.Status == models.AccountDeletionPending {
+            it.Status, it.CancelledAt = models.AccountDeletionCancelled, &now
+            cp := *it
+            return &cp, nil
+        }
+    }
+    return nil, models.ErrDeletionNotCancellable
+}
+func (f *fakeDeletionRepo) ClaimDue(ctx context.Context, now, staleBefore time.Time, limit int) ([]models.AccountDeletion, error) {
+    out := []models.AccountDeletion{}
+    for _, it := range f.items {
+        due := (it.Status == models.AccountDeletionPending || it.Status == models.AccountDeletionFailed) &&
+            !it.ScheduledFor.After(now) && it.Attempts < models.AccountDeletionMaxAttempts
+        if due && len(out) < limit {
+            it.Status, it.Attempts, it.StartedAt = models.AccountDeletionProcessing, it.Attempts+1, &now
+            out = append(out, *it)
+        }
+    }
+    return out, nil
+}
+func (f *fakeDeletionRepo) Purge(ctx context.Context, ownerID uuid.UUID) (map[string]int64, error) {
+    if f.purgeErr != nil { return nil, f.purgeErr }
+    f.purged = append(f.purged, ownerID)
+    return map[string]int64{"rf_incomes": 4, "rf_receipts": 2}, nil
+}
+func (f *fakeDeletionRepo) Finish(ctx context.Context, d *models.AccountDeletion, now time.Time) error {
+    for _, it := range f.items {
+        if it.ID == d.ID {
+            it.Status, it.Result, it.Error = d.Status, d.Result, d.Error
+            if d.Status == models.AccountDeletionCompleted { it.CompletedAt, it.Reason = &now, nil }
+        }
+    }
+    return nil
+}
+func (f *fakeDeletionRepo) QueueDepth(ctx context.Context, now time.Time) (*models.QueueDepth, error) {
+    return &models.QueueDepth{Queue: "account_deletions"}, nil
+}
+
+// fakeDeletionStore simula buckets com pastas por usuário
+type fakeDeletionStore struct {
+    objects map[string]bool // "bucket/caminho"
+    failOn  string
+}
+
+func (f *fakeDeletionStore) ListObjects(ctx context.Context, bucket, prefix string) ([]string, error) {
+    var out []string
+    for k := range f.objects {
+        if p, ok := strings.CutPrefix(k, bucket+"/"); ok && strings.HasPrefix(p, prefix+"/") { out = append(out, p) }
+    }
+    return out, nil
+}
+func (f *fakeDeletionStore) DeleteObject(ctx context.Context, bucket, objectPath string) error {
+    if objectPath == f.failOn { return errors.New("falha simulada") }
+    delete(f.objects, bucket+"/"+objectPath)
+    return nil
+}
+
+func newDeletionFixture() (*AccountDeletionService, *fakeDeletionRepo, *fakeDeletionStore, uuid.UUID, *time.Time) {
+    owner, other := uuid.New(), uuid.New()
+    repo := &fakeDeletionRepo{}
+    store := &fakeDeletionStore{objects: map[string]bool{
+        "signatures/" + owner.String() + "/a.png": true,
+        "receipts/" + owner.String() + "/1.pdf":   true,
+        "receipts/" + owner.String() + "/2.pdf":   true,
+        "receipts/" + other.String() + "/9.pdf":   true,
+    }}
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    svc := NewAccountDeletionService(repo, store, []string{"signatures", "receipts"}, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+    return svc, repo, store, owner, &now
+}
+
+func TestAccountDeletionRequiresConfirmation(t *testing.T) {
+    svc, repo, _, owner, _ := newDeletionFixture()
+    if _, err := svc.Request(context.Background(), owner, &models.AccountDeletionRequest{}); !errors.Is(err, models.ErrDeletionConfirmRequired) {
+        t.Fatalf("err = %v", err)
+    }
+    long := &models.AccountDeletionRequest{Confirm: true, Reason: strings.Repeat("x", models.MaxDeletionReasonLen+1)}
+    if _, err := svc.Request(context.Background(), owner, long); !errors.Is(err, models.ErrDeletionReasonTooLong) { t.Fatalf("err = %v", err) }
+    if len(repo.items) != 0 { t.Fatal("nenhum pedido deveria ser criado") }
+}
+
+func TestAccountDeletionGracePeriodAndLock(t *testing.T) {
+    svc, _, store, owner, now := newDeletionFixture()
+    d, err := svc.Request(context.Background(), owner, &models.AccountDeletionRequest{Confirm: true, Reason: "  não uso mais  "})
+    if err != nil { t.Fatal(err) }
+    if d.Status != models.AccountDeletionPending || !d.ScheduledFor.Equal(now.Add(models.AccountDeletionGracePeriod)) {
+        t.Fatalf("pedido inesperado: %+v", d)
+    }
+    if d.Reason == nil || *d.Reason != "não uso mais" { t.Fatalf("motivo = %v", d.Reason) }
+    if locked, _ := svc.IsLocked(context.Background(), owner); !locked { t.Fatal("conta deveria estar bloqueada") }
+    if _, err := svc.Request(context.Background(), owner, &models.AccountDeletionRequest{Confirm: true}); !errors.Is(err, models.ErrDeletionExists) {
+        t.Fatalf("err = %v", err)
+    }
+
+    // Ainda na carência: o worker não toca na conta
+    *now = now.Add(models.AccountDeletionGracePeriod - time.Minute)
+    if n, err := svc.ProcessDue(context.Background(), 10); err != nil || n != 0 { t.Fatalf("n = %d, err = %v", n, err) }
+    if len(store.objects) != 4 { t.Fatalf("objetos removidos antes da hora: %v", store.objects) }
+}
+
+func TestAccountDeletionCancelUnlocks(t *testing.T) {
+    svc, _, _, owner, now := newDeletionFixture()
+    if _, err := svc.Cancel(context.Background(), owner); !errors.Is(err, models.ErrDeletionNotFound) { t.Fatalf("err = %v", err) }
+    if _, err := svc.Request(context.Background(), owner, &models.AccountDeletionRequest{Confirm: true}); err != nil { t.Fatal(err) }
+    d, err := svc.Cancel(context.Background(), owner)
+    if err != nil { t.Fatal(err) }
+    if d.Status != models.AccountDeletionCancelled || d.CancelledAt == nil { t.Fatalf("pedido inesperado: %+v", d) }
+    if locked, _ := svc.IsLocked(context.Background(), owner); locked { t.Fatal("conta deveria estar desbloqueada") }
+
+    *now = now.Add(models.AccountDeletionGracePeriod + time.Hour)
+    if n, _ := svc.ProcessDue(context.Background(), 10); n != 0 { t.Fatal("pedido cancelado não deveria ser executado") }
+}
+
+func TestAccountDeletionProcessRemovesObjectsThenRows(t *testing.T) {
+    svc, repo, store, owner, now := newDeletionFixture()
+    if _, err := svc.Request(context.Background(), owner, &models.AccountDeletionRequest{Confirm: true, Reason: "privacidade"}); err != nil { t.Fatal(err) }
+    *now = now.Add(models.AccountDeletionGracePeriod)
+
+    if n, err := svc.ProcessDue(context.Background(), 10); err != nil || n != 1 { t.Fatalf("n = %d, err = %v", n, err) }
+    d := repo.items[0]
+    if d.Status != models.AccountDeletionCompleted || d.CompletedAt == nil || d.Reason != nil {
+        t.Fatalf("pedido inesperado: %+v", d)
+    }
+    if d.Result.Objects["receipts"] != 2 || d.Result.Objects["signatures"] != 1 || d.Result.Rows["rf_incomes"] != 4 {
+        t.Fatalf("resultado inesperado: %+v", d.Result)
+    }
+    if len(store.objects) != 1 || len(repo.purged) != 1 || repo.purged[0] != owner {
+        t.Fatalf("remoção inesperada: objetos %v, linhas %v", store.objects, repo.purged)
+    }
+    if _, err := svc.Cancel(context.Background(), owner); !errors.Is(err, models.ErrDeletionNotFound) { t.Fatalf("err = %v", err) }
+}
+
+func TestAccountDeletionFailureIsRetried(t *testing.T) {
+    svc, repo, store, owner, now := newDeletionFixture()
+    if _, err := svc.Request(context.Background(), owner, &models.AccountDeletionRequest{Confirm: true}); err != nil { t.Fatal(err) }
+    *now = now.Add(models.AccountDeletionGracePeriod)
+    store.failOn = owner.String() + "/a.png"
+
+    if _, err := svc.ProcessDue(context.Background(), 10); err != nil { t.Fatal(err) }
+    d := repo.items[0]
+    if d.Status != models.AccountDeletionFailed || d.Error == nil || len(repo.purged) != 0 {
+        t.Fatalf("falha não registrada: %+v", d)
+    }
+    if locked, _ := svc.IsLocked(context.Background(), owner); !locked { t.Fatal("conta deveria continuar bloqueada") }
+    if _, err := svc.Cancel(context.Background(), owner); !errors.Is(err, models.ErrDeletionNotCancellable) { t.Fatalf("err = %v", err) }
+
+    store.failOn = ""
+    if n, _ := svc.ProcessDue(context.Background(), 10); n != 1 { t.Fatal("pedido falho deveria ser retentado") }
+    if d.Status != models.AccountDeletionCompleted || d.Attempts != 2 || len(store.objects) != 1 { t.Fatalf("pedido inesperado: %+v", d) }
+}
@@ -0,0 +1,186 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Fusão assistida de contas (linhas rf_* e objetos do Storage) com auditoria
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// MergeStorage operações de Storage usadas na fusão (implementado por storage.Client)
+type MergeStorage interface {
+	ListObjects(ctx context.Context, bucket, prefix string) ([]string, error)
+	MoveObject(ctx context.Context, bucket, fromPath, toPath string) error
+}
+
+// AccountMergeService transfere tudo de uma conta para outra (ex.: cadastro duplicado Google/e-mail).
+// Docstring: os objetos em "{origem}/..." dos buckets são movidos para "{destino}/..." antes da
+// transação do banco; se a transação falhar, os objetos voltam ao lugar. Cada fusão executada
+// fica registrada em rf_account_merges.
+type AccountMergeService struct {
+	repo    repositories.AccountMergeRepository
+	store   MergeStorage
+	buckets []string
+	log     logging.Logger
+}
+
+// NewAccountMergeService cria o serviço de fusão para os buckets com pastas por usuário
+func NewAccountMergeService(repo repositories.AccountMergeRepository, store MergeStorage, buckets []string, log logging.Logger) *AccountMergeService {
+	return &AccountMergeService{repo: repo, store: store, buckets: buckets, log: log}
+}
+
+type objectMove struct {
+	bucket, from, to string
+}
+
+// Preview calcula a prévia (contagens, conflitos e ajustes) sem alterar nada
+func (s *AccountMergeService) Preview(ctx context.Context, req *models.AccountMergeRequest) (*models.AccountMergePlan, error) {
+	req.DryRun = true
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	plan, _, err := s.plan(ctx, req.SourceID, req.TargetID)
+	return plan, err
+}
+
+// Merge executa a fusão.
+// Docstring: com conflitos retorna ErrMergeConflict e a fusão (não registrada) com a prévia,
+// para que o administrador veja o motivo.
+func (s *AccountMergeService) Merge(ctx context.Context, req *models.AccountMergeRequest) (*models.AccountMerge, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	plan, moves, err := s.plan(ctx, req.SourceID, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	m := &models.AccountMerge{
+		SourceOwnerID: req.SourceID,
+		TargetOwnerID: req.TargetID,
+		RequestedBy:   req.RequestedBy,
+		Reason:        req.Reason,
+		Plan:          plan,
+	}
+	if !plan.CanMerge {
+		return m, models.ErrMergeConflict
+	}
+	if err := s.repo.CreateAudit(ctx, m); err != nil {
+		return nil, err
+	}
+	fields := []logging.Field{{Key: "merge_id", Val: m.ID}, {Key: "source", Val: m.SourceOwnerID}, {Key: "target", Val: m.TargetOwnerID}}
+
+	// A compensação e a auditoria não devem ser interrompidas se o cliente desconectar
+	bg := context.WithoutCancel(ctx)
+	moved, err := s.moveObjects(ctx, moves)
+	if err != nil {
+		s.undoMoves(bg, moved)
+		return m, s.fail(bg, m, fmt.Errorf("erro ao mover objetos do Storage: %w", err))
+	}
+	res, err := s.repo.Merge(ctx, m)
+	if err != nil {
+		s.undoMoves(bg, moved)
+		return m, s.fail(bg, m, err)
+	}
+	for _, mv := range moved {
+		res.Objects[mv.bucket]++
+	}
+
+	m.Status, m.Result = models.AccountMergeCompleted, res
+	if err := s.repo.FinishAudit(bg, m); err != nil {
+		s.log.Error("erro ao finalizar registro da fusão de contas", append(fields, logging.Field{Key: "error", Val: err.Error()})...)
+	}
+	s.log.Info("fusão de contas concluída", append(fields, logging.Field{Key: "objects", Val: len(moved)})...)
+	return m, nil
+}
+
+// ListMerges lista as fusões registradas (mais recentes primeiro)
+func (s *AccountMergeService) ListMerges(ctx context.Context, limit int) ([]models.AccountMerge, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.repo.ListAudits(ctx, limit)
+}
+
+// plan junta a prévia do banco com os objetos do Storage a mover
+func (s *AccountMergeService) plan(ctx context.Context, sourceID, targetID uuid.UUID) (*models.AccountMergePlan, []objectMove, error) {
+	plan, err := s.repo.Plan(ctx, sourceID, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	src, dst := sourceID.String(), targetID.String()
+	var moves []objectMove
+	for _, bucket := range s.buckets {
+		paths, err := s.store.ListObjects(ctx, bucket, src)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao listar objetos do Storage: %w", err)
+		}
+		if len(paths) == 0 {
+			continue
+		}
+		existing, err := s.store.ListObjects(ctx, bucket, dst)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao listar objetos do Storage: %w", err)
+		}
+		taken := make(map[string]bool, len(existing))
+		for _, p := range existing {
+			taken[p] = true
+		}
+		for _, p := range paths {
+			to := dst + strings.TrimPrefix(p, src)
+			if taken[to] {
+				plan.StorageCollisions = append(plan.StorageCollisions, bucket+"/"+to)
+				continue
+			}
+			moves = append(moves, objectMove{bucket: bucket, from: p, to: to})
+		}
+		plan.Objects[bucket] = len(paths)
+	}
+	plan.Evaluate()
+	return plan, moves, nil
+}
+
+// moveObjects move os objetos em ordem; retorna os já movidos mesmo em caso de erro
+func (s *AccountMergeService) moveObjects(ctx context.Context, moves []objectMove) ([]objectMove, error) {
+	done := make([]objectMove, 0, len(moves))
+	for _, mv := range moves {
+		if err := s.store.MoveObject(ctx, mv.bucket, mv.from, mv.to); err != nil {
+			return done, fmt.Errorf("%s/%s: %w", mv.bucket, mv.from, err)
+		}
+		done = append(done, mv)
+	}
+	return done, nil
+}
+
+// undoMoves devolve os objetos à pasta da origem (melhor esforço; falhas ficam no log)
+func (s *AccountMergeService) undoMoves(ctx context.Context, moved []objectMove) {
+	for i := len(moved) - 1; i >= 0; i-- {
+		mv := moved[i]
+		if err := s.store.MoveObject(ctx, mv.bucket, mv.to, mv.from); err != nil {
+			s.log.Error("erro ao desfazer movimentação de objeto na fusão de contas",
+				logging.Field{Key: "bucket", Val: mv.bucket}, logging.Field{Key: "path", Val: mv.to}, logging.Field{Key: "error", Val: err.Error()})
+		}
+	}
+}
+
+// fail registra a falha na auditoria e devolve o erro original
+func (s *AccountMergeService) fail(ctx context.Context, m *models.AccountMerge, cause error) error {
+	msg := cause.Error()
+	m.Status, m.Error = models.AccountMergeFailed, &msg
+	if err := s.repo.FinishAudit(ctx, m); err != nil {
+		s.log.Error("erro ao registrar falha da fusão de contas", logging.Field{Key: "merge_id", Val: m.ID}, logging.Field{Key: "error", Val: err.Error()})
+	}
+	if !errors.Is(cause, models.ErrMergeConflict) {
+		s.log.Error("fusão de contas falhou", logging.Field{Key: "merge_id", Val: m.ID}, logging.Field{Key: "error", Val: msg})
+	}
+	return cause
+}
@@ -0,0 +1,136 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da fusão de contas (movimentação no Storage, compensação e auditoria)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeMergeRepo implementa repositories.AccountMergeRepository em memória
+type fakeMergeRepo struct {
+    plan     models.AccountMergePlan
+    mergeErr error
+    merged   int
+    audits   []models.AccountMerge
+}
+
+func (f *fakeMergeRepo) Plan(ctx context.Context, sourceID, targetID uuid.UUID) (*models.AccountMergePlan, error) {
+    p := f.plan
+    p.Rows, p.Objects, p.StorageCollisions = map[string]int64{"rf_incomes": 3}, map[string]int{}, []string{}
+    p.Source.ID, p.Target.ID = sourceID, targetID
+    p.Evaluate()
+    return &p, nil
+}
+func (f *fakeMergeRepo) Merge(ctx context.Context, m *models.AccountMerge) (*models.AccountMergeResult, error) {
+    if f.mergeErr != nil { return nil, f.mergeErr }
+    f.merged++
+    return &models.AccountMergeResult{Rows: map[string]int64{"rf_incomes": 3}, Objects: map[string]int{}}, nil
+}
+func (f *fakeMergeRepo) CreateAudit(ctx context.Context, m *models.AccountMerge) error {
+    m.ID, m.Status = uuid.New(), models.AccountMergeRunning
+    f.audits = append(f.audits, *m)
+    return nil
+}
+func (f *fakeMergeRepo) FinishAudit(ctx context.Context, m *models.AccountMerge) error {
+    for i := range f.audits { if f.audits[i].ID == m.ID { f.audits[i] = *m } }
+    return nil
+}
+func (f *fakeMergeRepo) ListAudits(ctx context.Context, limit int) ([]models.AccountMerge, error) {
+    return f.audits, nil
+}
+
+// fakeMergeStore simula buckets com pastas por usuário
+type fakeMergeStore struct {
+    objects map[string]bool // "bucket/caminho"
+    failOn  string
+}
+
+func (f *fakeMergeStore) ListObjects(ctx context.Context, bucket, prefix string) ([]string, error) {
+    var out []string
+    for k := range f.objects {
+        if p, ok := strings.CutPrefix(k, bucket+"/"); ok && strings.HasPrefix(p, prefix+"/") { out = append(out, p) }
+    }
+    return out, nil
+}
+func (f *fakeMergeStore) MoveObject(ctx context.Context, bucket, fromPath, toPath string) error {
+    if fromPath == f.failOn { return errors.New("falha simulada") }
+    if !f.objects[bucket+"/"+fromPath] { return errors.New("origem inexistente") }
+    if f.objects[bucket+"/"+toPath] { return errors.New("destino existe") }
+    delete(f.objects, bucket+"/"+fromPath)
+    f.objects[bucket+"/"+toPath] = true
+    return nil
+}
+
+func newMergeFixture() (*AccountMergeService, *fakeMergeRepo, *fakeMergeStore, uuid.UUID, uuid.UUID) {
+    src, dst := uuid.New(), uuid.New()
+    repo := &fakeMergeRepo{}
+    store := &fakeMergeStore{objects: map[string]bool{
+        "signatures/" + src.String() + "/a.png": true,
+        "receipts/" + src.String() + "/1.pdf":   true,
+        "receipts/" + dst.String() + "/9.pdf":   true,
+    }}
+    svc := NewAccountMergeService(repo, store, []string{"signatures", "receipts"}, logging.NewLogger("dev"))
+    return svc, repo, store, src, dst
+}
+
+func TestAccountMergeMovesObjectsAndAudits(t *testing.T) {
+    svc, repo, store, src, dst := newMergeFixture()
+    m, err := svc.Merge(context.Background(), &models.AccountMergeRequest{SourceID: src, TargetID: dst, Reason: "duplicada", RequestedBy: "suporte"})
+    if err != nil { t.Fatal(err) }
+    if m.Status != models.AccountMergeCompleted || m.Result.Objects["receipts"] != 1 || m.Result.Objects["signatures"] != 1 {
+        t.Fatalf("resultado inesperado: %+v", m.Result)
+    }
+    if !store.objects["signatures/"+dst.String()+"/a.png"] || !store.objects["receipts/"+dst.String()+"/1.pdf"] {
+        t.Fatalf("objetos não movidos: %v", store.objects)
+    }
+    if len(repo.audits) != 1 || repo.audits[0].Status != models.AccountMergeCompleted {
+        t.Fatalf("auditoria inesperada: %+v", repo.audits)
+    }
+}
+
+func TestAccountMergeCompensatesStorageOnDBFailure(t *testing.T) {
+    svc, repo, store, src, dst := newMergeFixture()
+    repo.mergeErr = errors.New("deadlock")
+    _, err := svc.Merge(context.Background(), &models.AccountMergeRequest{SourceID: src, TargetID: dst, Reason: "duplicada", RequestedBy: "suporte"})
+    if err == nil { t.Fatal("esperava erro") }
+    if !store.objects["signatures/"+src.String()+"/a.png"] || !store.objects["receipts/"+src.String()+"/1.pdf"] {
+        t.Fatalf("objetos deveriam voltar à origem: %v", store.objects)
+    }
+    if repo.audits[0].Status != models.AccountMergeFailed || repo.audits[0].Error == nil {
+        t.Fatalf("falha não registrada: %+v", repo.audits[0])
+    }
+}
+
+func TestAccountMergeStorageFailureUndoesPartialMoves(t *testing.T) {
+    svc, repo, store, src, dst := newMergeFixture()
+    store.failOn = src.String() + "/1.pdf"
+    _, err := svc.Merge(context.Background(), &models.AccountMergeRequest{SourceID: src, TargetID: dst, Reason: "duplicada", RequestedBy: "suporte"})
+    if err == nil { t.Fatal("esperava erro") }
+    if repo.merged != 0 { t.Fatal("banco não deveria ser alterado") }
+    if !store.objects["signatures/"+src.String()+"/a.png"] { t.Fatalf("movimentação parcial não desfeita: %v", store.objects) }
+}
+
+func TestAccountMergeConflictsBlock(t *testing.T) {
+    svc, repo, store, src, dst := newMergeFixture()
+    store.objects["receipts/"+dst.String()+"/1.pdf"] = true
+    repo.plan.SourceReceipts = models.ReceiptRange{Count: 2, First: 1, Last: 2}
+    repo.plan.TargetReceipts = models.ReceiptRange{Count: 1, First: 2, Last: 2}
+
+    plan, err := svc.Preview(context.Background(), &models.AccountMergeRequest{SourceID: src, TargetID: dst})
+    if err != nil { t.Fatal(err) }
+    if plan.CanMerge || len(plan.Conflicts) != 2 { t.Fatalf("esperava 2 conflitos: %+v", plan.Conflicts) }
+
+    m, err := svc.Merge(context.Background(), &models.AccountMergeRequest{SourceID: src, TargetID: dst, Reason: "duplicada", RequestedBy: "suporte"})
+    if !errors.Is(err, models.ErrMergeConflict) || m == nil || m.Plan == nil { t.Fatalf("err = %v", err) }
+    if len(repo.audits) != 0 || !store.objects["signatures/"+src.String()+"/a.png"] { t.Fatal("nada deveria mudar com conflitos") }
+}
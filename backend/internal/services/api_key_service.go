@@ -0,0 +1,99 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Chaves de API do usuário (emissão, revogação e autenticação por X-API-Key)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// apiKeyPrefix início de toda chave emitida (facilita a detecção em vazamentos)
+const apiKeyPrefix = "rfk_"
+
+// apiKeyDisplayLen caracteres do início da chave guardados para exibição
+const apiKeyDisplayLen = 12
+
+// APIKeyService chaves de API para scripts e integrações (ex.: Zapier).
+// Docstring: a chave ("rfk_" + 32 bytes em hex) só é devolvida na criação; o banco guarda o
+// sha256. No SupabaseAuth a chave substitui o JWT e os escopos limitam recurso e acesso.
+type APIKeyService struct {
+	repo repositories.APIKeyRepository
+	log  logging.Logger
+	now  func() time.Time
+}
+
+// NewAPIKeyService cria uma nova instância do serviço de chaves de API
+func NewAPIKeyService(repo repositories.APIKeyRepository, log logging.Logger) *APIKeyService {
+	return &APIKeyService{repo: repo, log: log, now: time.Now}
+}
+
+func apiKeyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create emite uma chave com os escopos pedidos
+func (s *APIKeyService) Create(ctx context.Context, ownerID uuid.UUID, req *models.APIKeyRequest) (*models.APIKey, error) {
+	if err := req.Validate(s.now()); err != nil {
+		return nil, err
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("erro ao gerar chave de API: %w", err)
+	}
+	key := apiKeyPrefix + hex.EncodeToString(b)
+	k := &models.APIKey{
+		OwnerID:   ownerID,
+		Nome:      req.Nome,
+		Prefix:    key[:apiKeyDisplayLen],
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.repo.Create(ctx, k, apiKeyHash(key), models.MaxAPIKeys); err != nil {
+		return nil, err
+	}
+	k.Key = key
+	s.log.Info("chave de API criada", logging.Field{Key: "api_key_id", Val: k.ID}, logging.Field{Key: "scopes", Val: strings.Join(k.Scopes, ",")})
+	return k, nil
+}
+
+// List chaves do usuário (sem o valor da chave)
+func (s *APIKeyService) List(ctx context.Context, ownerID uuid.UUID) ([]models.APIKey, error) {
+	return s.repo.List(ctx, ownerID)
+}
+
+// Revoke revoga a chave imediatamente
+func (s *APIKeyService) Revoke(ctx context.Context, id, ownerID uuid.UUID) error {
+	return s.repo.Revoke(ctx, id, ownerID, s.now().UTC())
+}
+
+// Authenticate valida a chave recebida em X-API-Key e registra o uso.
+// Docstring: chaves fora do formato nem consultam o banco; falha ao registrar o uso só vai ao log.
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	key = strings.TrimSpace(key)
+	if !strings.HasPrefix(key, apiKeyPrefix) || len(key) != len(apiKeyPrefix)+64 {
+		return nil, models.ErrAPIKeyInvalid
+	}
+	now := s.now().UTC()
+	k, err := s.repo.GetActiveByHash(ctx, apiKeyHash(key), now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Touch(ctx, k.ID, now); err != nil {
+		s.log.Warn("erro ao registrar uso da chave de API", logging.Field{Key: "api_key_id", Val: k.ID}, logging.Field{Key: "error", Val: err.Error()})
+	}
+	return k, nil
+}
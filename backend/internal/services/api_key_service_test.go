@@ -0,0 +1,132 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes das chaves de API (emissão, limite, revogação e expiração)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeAPIKeyRepo implementa repositories.APIKeyRepository em memória
+type fakeAPIKeyRepo struct {
+    keys    map[string]*models.APIKey // hash -> chave
+    touched int
+}
+
+func (f *fakeAPIKeyRepo) active(k *models.APIKey, now time.Time) bool {
+    return k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+}
+func (f *fakeAPIKeyRepo) Create(ctx context.Context, k *models.APIKey, keyHash string, max int) error {
+    n := 0
+    for _, o := range f.keys { if o.OwnerID == k.OwnerID && f.active(o, time.Now()) { n++ } }
+    if n >= max { return models.ErrAPIKeyLimit }
+    k.ID = uuid.New()
+    c := *k
+    f.keys[keyHash] = &c
+    return nil
+}
+func (f *fakeAPIKeyRepo) List(ctx context.Context, ownerID uuid.UUID) ([]models.APIKey, error) {
+    out := []models.APIKey{}
+    for _, k := range f.keys { if k.OwnerID == ownerID { out = append(out, *k) } }
+    return out, nil
+}
+func (f *fakeAPIKeyRepo) Revoke(ctx context.Context, id, ownerID uuid.UUID, now time.Time) error {
+    for _, k := range f.keys {
+        if k.ID == id && k.OwnerID == ownerID && k.RevokedAt == nil { k.RevokedAt = &now; return nil }
+    }
+    return models.ErrAPIKeyNotFound
+}
+func (f *fakeAPIKeyRepo) GetActiveByHash(ctx context.Context, keyHash string, now time.Time) (*models.APIKey, error) {
+    k, ok := f.keys[keyHash]
+    if !ok || !f.active(k, now) { return nil, models.ErrAPIKeyInvalid }
+    c := *k
+    return &c, nil
+}
+func (f *fakeAPIKeyRepo) Touch(ctx context.Context, id uuid.UUID, now time.Time) error {
+    f.touched++
+    return nil
+}
+
+func newAPIKeyFixture() (*APIKeyService, *fakeAPIKeyRepo, *time.Time) {
+    repo := &fakeAPIKeyRepo{keys: map[string]*models.APIKey{}}
+    now := time.Now().UTC()
+    svc := NewAPIKeyService(repo, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+    return svc, repo, &now
+}
+
+func TestAPIKeyCreateAndAuthenticate(t *testing.T) {
+    svc, repo, _ := newAPIKeyFixture()
+    owner := uuid.New()
+    k, err := svc.Create(context.Background(), owner, &models.APIKeyRequest{Nome: " Zapier ", Scopes: []string{"Incomes:read", "incomes:read", "receipts:write"}})
+    if err != nil { t.Fatal(err) }
+    if !strings.HasPrefix(k.Key, "rfk_") || !strings.HasPrefix(k.Key, k.Prefix) || k.Nome != "Zapier" || len(k.Scopes) != 2 {
+        t.Fatalf("chave inesperada: %+v", k)
+    }
+    for hash, stored := range repo.keys {
+        if hash == k.Key || stored.Key != "" { t.Fatal("a chave não deve ser guardada em claro") }
+    }
+
+    got, err := svc.Authenticate(context.Background(), k.Key)
+    if err != nil { t.Fatal(err) }
+    if got.OwnerID != owner || repo.touched != 1 { t.Fatalf("autenticação inesperada: %+v", got) }
+    if _, err := svc.Authenticate(context.Background(), "rfk_curta"); !errors.Is(err, models.ErrAPIKeyInvalid) {
+        t.Fatalf("err = %v", err)
+    }
+    other := "0"
+    if strings.HasSuffix(k.Key, "0") { other = "1" }
+    if _, err := svc.Authenticate(context.Background(), k.Key[:len(k.Key)-1]+other); !errors.Is(err, models.ErrAPIKeyInvalid) {
+        t.Fatalf("chave alterada: %v", err)
+    }
+
+    if _, err := svc.Create(context.Background(), owner, &models.APIKeyRequest{Nome: "x", Scopes: []string{"account:write"}}); !errors.Is(err, models.ErrAPIKeyScopeInvalid) {
+        t.Fatalf("err = %v", err)
+    }
+}
+
+func TestAPIKeyRevokeAndExpiry(t *testing.T) {
+    svc, _, now := newAPIKeyFixture()
+    owner := uuid.New()
+    exp := now.Add(time.Hour)
+    k, err := svc.Create(context.Background(), owner, &models.APIKeyRequest{Nome: "script", Scopes: []string{"*:read"}, ExpiresAt: &exp})
+    if err != nil { t.Fatal(err) }
+    *now = now.Add(2 * time.Hour)
+    if _, err := svc.Authenticate(context.Background(), k.Key); !errors.Is(err, models.ErrAPIKeyInvalid) {
+        t.Fatalf("chave expirada: %v", err)
+    }
+
+    k2, err := svc.Create(context.Background(), owner, &models.APIKeyRequest{Nome: "script", Scopes: []string{"*:read"}})
+    if err != nil { t.Fatal(err) }
+    if err := svc.Revoke(context.Background(), k2.ID, uuid.New()); !errors.Is(err, models.ErrAPIKeyNotFound) {
+        t.Fatalf("revogar chave de outro usuário: %v", err)
+    }
+    if err := svc.Revoke(context.Background(), k2.ID, owner); err != nil { t.Fatal(err) }
+    if _, err := svc.Authenticate(context.Background(), k2.Key); !errors.Is(err, models.ErrAPIKeyInvalid) {
+        t.Fatalf("chave revogada: %v", err)
+    }
+    past := now.Add(-time.Minute)
+    if _, err := svc.Create(context.Background(), owner, &models.APIKeyRequest{Nome: "x", Scopes: []string{"*:read"}, ExpiresAt: &past}); !errors.Is(err, models.ErrAPIKeyExpiry) {
+        t.Fatalf("err = %v", err)
+    }
+}
+
+func TestAPIKeyLimit(t *testing.T) {
+    svc, _, _ := newAPIKeyFixture()
+    owner := uuid.New()
+    for i := 0; i < models.MaxAPIKeys; i++ {
+        if _, err := svc.Create(context.Background(), owner, &models.APIKeyRequest{Nome: "k", Scopes: []string{"*:read"}}); err != nil { t.Fatal(err) }
+    }
+    if _, err := svc.Create(context.Background(), owner, &models.APIKeyRequest{Nome: "k", Scopes: []string{"*:read"}}); !errors.Is(err, models.ErrAPIKeyLimit) {
+        t.Fatalf("err = %v", err)
+    }
+}
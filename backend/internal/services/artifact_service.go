@@ -0,0 +1,129 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Armazenamento endereçado por conteúdo de artefatos gerados (PDFs/QRs) e coleta de lixo
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/storage"
+)
+
+// ArtifactStorage operações de Storage usadas pelos artefatos (implementado por storage.Client)
+type ArtifactStorage interface {
+	UpsertObject(ctx context.Context, bucket, objectPath string, content []byte, contentType string) error
+	DeleteObject(ctx context.Context, bucket, objectPath string) error
+}
+
+// ArtifactGCGrace carência entre perder a última referência e a remoção do artefato
+const ArtifactGCGrace = 24 * time.Hour
+
+// ArtifactGCBatch limite de artefatos avaliados por execução da coleta
+const ArtifactGCBatch = 200
+
+// ArtifactService guarda artefatos pelo sha256 do conteúdo e mantém as referências de recibos/modelos.
+// Docstring: re-renderizações idênticas reutilizam o objeto existente; artefatos sem referências são
+// removidos por CollectGarbage (job de ciclo de vida) após ArtifactGCGrace.
+type ArtifactService struct {
+	repo   repositories.ArtifactRepository
+	store  ArtifactStorage
+	bucket string
+	log    logging.Logger
+	now    func() time.Time
+}
+
+// NewArtifactService cria o serviço de artefatos no bucket informado
+func NewArtifactService(repo repositories.ArtifactRepository, store ArtifactStorage, bucket string, log logging.Logger) *ArtifactService {
+	return &ArtifactService{repo: repo, store: store, bucket: bucket, log: log, now: time.Now}
+}
+
+// Store guarda o conteúdo (se ainda não existir) e o vincula ao alvo de ref (recibo ou modelo)
+func (s *ArtifactService) Store(ctx context.Context, ref *models.ArtifactRef, content []byte, contentType string) (*models.ArtifactUpload, error) {
+	if err := models.ValidArtifactType(ref.Kind, contentType); err != nil {
+		return nil, err
+	}
+	if len(content) == 0 {
+		return nil, models.ErrArtifactEmpty
+	}
+	if len(content) > models.MaxArtifactSize {
+		return nil, models.ErrArtifactTooLarge
+	}
+
+	hash := models.ArtifactHash(content)
+	a := &models.Artifact{
+		Hash:        hash,
+		Bucket:      s.bucket,
+		Path:        models.ArtifactPath(hash, ref.Kind),
+		ContentType: contentType,
+		SizeBytes:   int64(len(content)),
+	}
+	inserted, err := s.repo.Create(ctx, a)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar artefato: %w", err)
+	}
+	if inserted {
+		if err := s.store.UpsertObject(ctx, a.Bucket, a.Path, content, contentType); err != nil {
+			// Desfaz o registro para que o próximo envio do mesmo conteúdo tente o upload novamente
+			if _, cerr := s.repo.Collect(ctx, hash, s.now().Add(time.Hour), func(*models.Artifact) error { return nil }); cerr != nil {
+				s.log.Error("erro ao desfazer registro de artefato", logging.Field{Key: "hash", Val: hash}, logging.Field{Key: "error", Val: cerr.Error()})
+			}
+			return nil, fmt.Errorf("erro ao enviar artefato ao Storage: %w", err)
+		}
+	}
+
+	ref.ArtifactHash = hash
+	if err := s.repo.AttachRef(ctx, ref); err != nil {
+		return nil, err
+	}
+	a.RefCount++
+	a.UnreferencedAt = nil
+	return &models.ArtifactUpload{Artifact: *a, Ref: *ref, Deduplicated: !inserted}, nil
+}
+
+// Detach remove a referência do tipo informado; o artefato é coletado se ficar sem referências
+func (s *ArtifactService) Detach(ctx context.Context, ref *models.ArtifactRef) error {
+	if !models.ValidArtifactKind(ref.Kind) {
+		return models.ErrInvalidArtifactKind
+	}
+	return s.repo.DetachRef(ctx, ref)
+}
+
+// CollectGarbage remove do Storage e do banco os artefatos sem referências além da carência.
+// Docstring: falhas no Storage mantêm o registro para nova tentativa na próxima execução.
+func (s *ArtifactService) CollectGarbage(ctx context.Context) (*models.ArtifactGCResult, error) {
+	before := s.now().Add(-ArtifactGCGrace)
+	items, err := s.repo.ListCollectable(ctx, before, ArtifactGCBatch)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar artefatos sem referência: %w", err)
+	}
+	res := &models.ArtifactGCResult{Scanned: len(items)}
+	for _, it := range items {
+		deleted, err := s.repo.Collect(ctx, it.Hash, before, func(a *models.Artifact) error {
+			err := s.store.DeleteObject(ctx, a.Bucket, a.Path)
+			if errors.Is(err, storage.ErrObjectNotFound) {
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			res.StorageFails++
+			s.log.Error("erro ao coletar artefato",
+				logging.Field{Key: "hash", Val: it.Hash},
+				logging.Field{Key: "error", Val: err.Error()})
+			continue
+		}
+		if deleted {
+			res.Deleted++
+			res.FreedBytes += it.SizeBytes
+		}
+	}
+	return res, nil
+}
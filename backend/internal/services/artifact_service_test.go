@@ -0,0 +1,133 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do armazenamento endereçado por conteúdo (deduplicação e coleta de lixo)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/storage"
+)
+
+// fakeArtifactRepo implementa repositories.ArtifactRepository em memória (ref_count mantido à mão)
+type fakeArtifactRepo struct {
+    byHash map[string]*models.Artifact
+    refs   map[uuid.UUID]string // alvo -> hash
+}
+
+func newFakeArtifactRepo() *fakeArtifactRepo {
+    return &fakeArtifactRepo{byHash: map[string]*models.Artifact{}, refs: map[uuid.UUID]string{}}
+}
+
+func (f *fakeArtifactRepo) Get(ctx context.Context, hash string) (*models.Artifact, error) {
+    if a, ok := f.byHash[hash]; ok { return a, nil }
+    return nil, models.ErrArtifactNotFound
+}
+func (f *fakeArtifactRepo) Create(ctx context.Context, a *models.Artifact) (bool, error) {
+    if cur, ok := f.byHash[a.Hash]; ok { *a = *cur; return false, nil }
+    cp := *a
+    f.byHash[a.Hash] = &cp
+    return true, nil
+}
+func (f *fakeArtifactRepo) AttachRef(ctx context.Context, ref *models.ArtifactRef) error {
+    target := *ref.ReceiptID
+    if old, ok := f.refs[target]; ok { f.byHash[old].RefCount-- }
+    f.refs[target] = ref.ArtifactHash
+    f.byHash[ref.ArtifactHash].RefCount++
+    return nil
+}
+func (f *fakeArtifactRepo) DetachRef(ctx context.Context, ref *models.ArtifactRef) error {
+    hash, ok := f.refs[*ref.ReceiptID]
+    if !ok { return models.ErrArtifactNotFound }
+    delete(f.refs, *ref.ReceiptID)
+    f.byHash[hash].RefCount--
+    return nil
+}
+func (f *fakeArtifactRepo) ListCollectable(ctx context.Context, before time.Time, limit int) ([]models.Artifact, error) {
+    var out []models.Artifact
+    for _, a := range f.byHash { if a.RefCount == 0 { out = append(out, *a) } }
+    return out, nil
+}
+func (f *fakeArtifactRepo) Collect(ctx context.Context, hash string, before time.Time, remove func(*models.Artifact) error) (bool, error) {
+    a, ok := f.byHash[hash]
+    if !ok || a.RefCount > 0 { return false, nil }
+    if err := remove(a); err != nil { return false, err }
+    delete(f.byHash, hash)
+    return true, nil
+}
+
+type fakeArtifactStorage struct {
+    uploads   int
+    deleted   []string
+    deleteErr map[string]error
+}
+
+func (s *fakeArtifactStorage) UpsertObject(ctx context.Context, bucket, objectPath string, content []byte, contentType string) error {
+    s.uploads++; return nil
+}
+func (s *fakeArtifactStorage) DeleteObject(ctx context.Context, bucket, objectPath string) error {
+    if err := s.deleteErr[objectPath]; err != nil { return err }
+    s.deleted = append(s.deleted, objectPath); return nil
+}
+
+func TestArtifactStore_DeduplicatesIdenticalContent(t *testing.T) {
+    repo, store := newFakeArtifactRepo(), &fakeArtifactStorage{}
+    svc := NewArtifactService(repo, store, "receipts", logging.NewLogger("dev"))
+    owner := uuid.New()
+    pdf := []byte("%PDF-1.7 recibo 42")
+
+    first, err := svc.Store(context.Background(), &models.ArtifactRef{OwnerID: owner, Kind: models.ArtifactKindPDF, ReceiptID: ptrUUID(uuid.New())}, pdf, "application/pdf")
+    if err != nil { t.Fatalf("Store err: %v", err) }
+    second, err := svc.Store(context.Background(), &models.ArtifactRef{OwnerID: owner, Kind: models.ArtifactKindPDF, ReceiptID: ptrUUID(uuid.New())}, pdf, "application/pdf")
+    if err != nil { t.Fatalf("Store err: %v", err) }
+
+    if first.Deduplicated || !second.Deduplicated { t.Fatalf("deduplicated = %v/%v, want false/true", first.Deduplicated, second.Deduplicated) }
+    if store.uploads != 1 { t.Fatalf("uploads = %d, want 1", store.uploads) }
+    if first.Artifact.Hash != second.Artifact.Hash || repo.byHash[first.Artifact.Hash].RefCount != 2 {
+        t.Fatalf("esperava o mesmo artefato com 2 referências")
+    }
+    if _, err := svc.Store(context.Background(), &models.ArtifactRef{OwnerID: owner, Kind: models.ArtifactKindQR, ReceiptID: ptrUUID(uuid.New())}, pdf, "application/pdf"); !errors.Is(err, models.ErrInvalidArtifactContentType) {
+        t.Fatalf("err = %v, want ErrInvalidArtifactContentType", err)
+    }
+}
+
+func TestArtifactCollectGarbage_RemovesOnlyUnreferenced(t *testing.T) {
+    repo, store := newFakeArtifactRepo(), &fakeArtifactStorage{deleteErr: map[string]error{}}
+    svc := NewArtifactService(repo, store, "receipts", logging.NewLogger("dev"))
+    owner, receipt := uuid.New(), uuid.New()
+    ctx := context.Background()
+
+    kept, _ := svc.Store(ctx, &models.ArtifactRef{OwnerID: owner, Kind: models.ArtifactKindPDF, ReceiptID: ptrUUID(uuid.New())}, []byte("%PDF mantido"), "application/pdf")
+    old, _ := svc.Store(ctx, &models.ArtifactRef{OwnerID: owner, Kind: models.ArtifactKindPDF, ReceiptID: &receipt}, []byte("%PDF v1"), "application/pdf")
+    // Re-renderização do mesmo recibo substitui a referência; a versão antiga fica sem referências
+    if _, err := svc.Store(ctx, &models.ArtifactRef{OwnerID: owner, Kind: models.ArtifactKindPDF, ReceiptID: &receipt}, []byte("%PDF v2"), "application/pdf"); err != nil {
+        t.Fatalf("Store err: %v", err)
+    }
+    gone, _ := svc.Store(ctx, &models.ArtifactRef{OwnerID: owner, Kind: models.ArtifactKindQR, ReceiptID: ptrUUID(uuid.New())}, []byte("png"), "image/png")
+    svc.Detach(ctx, &gone.Ref)
+    store.deleteErr[gone.Artifact.Path] = storage.ErrObjectNotFound
+
+    res, err := svc.CollectGarbage(ctx)
+    if err != nil { t.Fatalf("CollectGarbage err: %v", err) }
+    if res.Deleted != 2 || res.StorageFails != 0 { t.Fatalf("resultado = %+v, want 2 removidos", res) }
+    if _, ok := repo.byHash[old.Artifact.Hash]; ok { t.Fatalf("versão antiga deveria ter sido coletada") }
+    if _, ok := repo.byHash[kept.Artifact.Hash]; !ok { t.Fatalf("artefato referenciado não pode ser coletado") }
+
+    // Falha no Storage mantém o registro para a próxima execução
+    orphan, _ := svc.Store(ctx, &models.ArtifactRef{OwnerID: owner, Kind: models.ArtifactKindPDF, ReceiptID: ptrUUID(uuid.New())}, []byte("%PDF órfão"), "application/pdf")
+    svc.Detach(ctx, &orphan.Ref)
+    store.deleteErr[orphan.Artifact.Path] = errors.New("503")
+    res, _ = svc.CollectGarbage(ctx)
+    if res.StorageFails != 1 { t.Fatalf("falhas = %d, want 1", res.StorageFails) }
+    if _, ok := repo.byHash[orphan.Artifact.Hash]; !ok { t.Fatalf("registro deveria ser mantido após falha no Storage") }
+}
+
+func ptrUUID(id uuid.UUID) *uuid.UUID { return &id }
@@ -0,0 +1,154 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Emissão automática de recibo para receitas quitadas (opção do usuário e job "auto-receipts")
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// autoReceiptClaimBatch pedidos reservados por execução do job
+const autoReceiptClaimBatch = 50
+
+// AutoReceiptService emite os recibos das receitas quitadas quando o usuário liga a opção.
+// Docstring: o pedido é gravado na transação do pagamento que quita a receita (outbox em
+// rf_auto_receipts); depois do commit IncomeService.AddPayment chama Kick e o job "auto-receipts"
+// recolhe o que sobrar. A emissão é a mesma da avulsa (numeração e snapshot pelo trigger) e consome
+// a cota do plano; receita que já tem recibo é ignorada. Falhas voltam à fila até maxAttempts,
+// exceto a cota esgotada, que falha de imediato.
+type AutoReceiptService struct {
+	repo        repositories.AutoReceiptRepository
+	settings    repositories.SettingsRepository
+	receipts    repositories.ReceiptRepository
+	quota       ReceiptQuota
+	log         logging.Logger
+	lockTimeout time.Duration
+	maxAttempts int
+	now         func() time.Time
+
+	mu sync.Mutex // serializa ProcessPending (worker e Kick)
+}
+
+// NewAutoReceiptService cria o serviço; quota pode ser nil (sem limite)
+func NewAutoReceiptService(repo repositories.AutoReceiptRepository, settings repositories.SettingsRepository,
+	receipts repositories.ReceiptRepository, quota ReceiptQuota, log logging.Logger) *AutoReceiptService {
+	return &AutoReceiptService{
+		repo:        repo,
+		settings:    settings,
+		receipts:    receipts,
+		quota:       quota,
+		log:         log,
+		lockTimeout: 10 * time.Minute,
+		maxAttempts: 5,
+		now:         time.Now,
+	}
+}
+
+// GetSettings retorna a opção de recibo automático do usuário
+func (s *AutoReceiptService) GetSettings(ctx context.Context, ownerID uuid.UUID) (*models.ReceiptAutomation, error) {
+	a, err := s.settings.GetReceiptAutomation(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar recibo automático: %w", err)
+	}
+	return a, nil
+}
+
+// UpdateSettings grava a opção; vale para os próximos pagamentos que quitarem receitas
+func (s *AutoReceiptService) UpdateSettings(ctx context.Context, ownerID uuid.UUID, a *models.ReceiptAutomation) (*models.ReceiptAutomation, error) {
+	if err := s.settings.UpdateReceiptAutomation(ctx, ownerID, a); err != nil {
+		return nil, fmt.Errorf("erro ao gravar recibo automático: %w", err)
+	}
+	return a, nil
+}
+
+// Kick processa a fila em segundo plano logo após um pagamento quitar uma receita; falhas ficam
+// no log e o pedido continua na fila para o job
+func (s *AutoReceiptService) Kick() {
+	go func() {
+		if err := s.ProcessPending(context.Background()); err != nil {
+			s.log.Warn("falha ao emitir recibos automáticos", logging.Field{Key: "error", Val: err.Error()})
+		}
+	}()
+}
+
+// ProcessPending emite os recibos pedidos (função do job "auto-receipts")
+func (s *AutoReceiptService) ProcessPending(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items, err := s.repo.ClaimPending(ctx, s.now().UTC(), s.lockTimeout, autoReceiptClaimBatch)
+	if err != nil {
+		return fmt.Errorf("erro ao reservar recibos automáticos: %w", err)
+	}
+	var firstErr error
+	for i := range items {
+		if err := s.process(ctx, &items[i]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// process emite o recibo do pedido e grava a situação; só erros ao gravar a fila são devolvidos
+func (s *AutoReceiptService) process(ctx context.Context, ar *models.AutoReceipt) error {
+	issueErr := s.issue(ctx, ar)
+	if issueErr != nil {
+		msg := issueErr.Error()
+		ar.LastError = &msg
+		var qe *models.QuotaError
+		if ar.Attempts < s.maxAttempts && !errors.As(issueErr, &qe) {
+			if err := s.repo.Release(ctx, ar); err != nil {
+				return fmt.Errorf("erro ao devolver recibo automático à fila: %w", err)
+			}
+			s.log.Warn("recibo automático devolvido à fila",
+				logging.Field{Key: "income_id", Val: ar.IncomeID.String()},
+				logging.Field{Key: "attempts", Val: ar.Attempts},
+				logging.Field{Key: "error", Val: msg})
+			return nil
+		}
+		ar.Status = models.AutoReceiptFailed
+	}
+	if err := s.repo.Finish(ctx, ar, s.now().UTC()); err != nil {
+		return fmt.Errorf("erro ao finalizar recibo automático: %w", err)
+	}
+	s.log.Info("recibo automático processado",
+		logging.Field{Key: "income_id", Val: ar.IncomeID.String()},
+		logging.Field{Key: "status", Val: ar.Status})
+	return nil
+}
+
+// issue emite o recibo da receita, a menos que ela já tenha um
+func (s *AutoReceiptService) issue(ctx context.Context, ar *models.AutoReceipt) error {
+	has, err := s.repo.HasReceipt(ctx, ar.OwnerID, ar.IncomeID)
+	if err != nil {
+		return fmt.Errorf("erro ao verificar recibo da receita: %w", err)
+	}
+	if has {
+		ar.Status, ar.LastError = models.AutoReceiptSkipped, nil
+		return nil
+	}
+	release := func() {}
+	if s.quota != nil {
+		if release, err = s.quota.Reserve(ctx, ar.OwnerID, models.QuotaMetricReceipts); err != nil {
+			return err
+		}
+	}
+	incomeID := ar.IncomeID
+	rec := &models.Receipt{OwnerID: ar.OwnerID, IncomeID: &incomeID}
+	if err := s.receipts.Create(ctx, rec); err != nil {
+		release()
+		return fmt.Errorf("erro ao emitir recibo: %w", err)
+	}
+	ar.Status, ar.ReceiptID, ar.LastError = models.AutoReceiptIssued, &rec.ID, nil
+	return nil
+}
@@ -0,0 +1,150 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do recibo automático (emissão pela fila, receitas com recibo, retentativas e disparo no pagamento)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeAutoReceiptRepo implementa repositories.AutoReceiptRepository em memória
+type fakeAutoReceiptRepo struct {
+    pending    []models.AutoReceipt
+    hasReceipt map[uuid.UUID]bool
+    released   []models.AutoReceipt
+    finished   map[uuid.UUID]models.AutoReceipt
+}
+
+func (f *fakeAutoReceiptRepo) ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.AutoReceipt, error) {
+    items := f.pending
+    f.pending = nil
+    for i := range items { items[i].Attempts++ }
+    return items, nil
+}
+func (f *fakeAutoReceiptRepo) HasReceipt(ctx context.Context, ownerID, incomeID uuid.UUID) (bool, error) {
+    return f.hasReceipt[incomeID], nil
+}
+func (f *fakeAutoReceiptRepo) Release(ctx context.Context, ar *models.AutoReceipt) error {
+    f.released = append(f.released, *ar)
+    f.pending = append(f.pending, *ar)
+    return nil
+}
+func (f *fakeAutoReceiptRepo) Finish(ctx context.Context, ar *models.AutoReceipt, now time.Time) error {
+    ar.ProcessedAt = &now
+    f.finished[ar.IncomeID] = *ar
+    return nil
+}
+
+// fakeAutoReceiptQuota nega a reserva com QuotaError quando full
+type fakeAutoReceiptQuota struct {
+    full     bool
+    released int
+}
+
+func (q *fakeAutoReceiptQuota) Reserve(ctx context.Context, ownerID uuid.UUID, metric string) (func(), error) {
+    if q.full { return nil, &models.QuotaError{Metric: metric, Plan: "free", Limit: 10, Used: 10} }
+    return func() { q.released++ }, nil
+}
+
+// failingReceiptRepo faz a emissão falhar
+type failingReceiptRepo struct {
+    fakeBatchReceiptRepo
+    err error
+}
+
+func (f *failingReceiptRepo) Create(ctx context.Context, m *models.Receipt) error { return f.err }
+
+func newAutoReceiptTest() (*AutoReceiptService, *fakeAutoReceiptRepo, *fakeBatchReceiptRepo, *fakeAutoReceiptQuota) {
+    repo := &fakeAutoReceiptRepo{hasReceipt: map[uuid.UUID]bool{}, finished: map[uuid.UUID]models.AutoReceipt{}}
+    receipts := &fakeBatchReceiptRepo{byID: map[uuid.UUID]*models.Receipt{}}
+    quota := &fakeAutoReceiptQuota{}
+    svc := NewAutoReceiptService(repo, &fakeSettingsRepo{}, receipts, quota, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) }
+    return svc, repo, receipts, quota
+}
+
+func TestAutoReceiptService_IssuesAndSkipsIncomesWithReceipt(t *testing.T) {
+    svc, repo, receipts, _ := newAutoReceiptTest()
+    owner, paid, taken := uuid.New(), uuid.New(), uuid.New()
+    repo.hasReceipt[taken] = true
+    repo.pending = []models.AutoReceipt{
+        {IncomeID: paid, OwnerID: owner, PaymentID: uuid.New(), Status: models.AutoReceiptPending},
+        {IncomeID: taken, OwnerID: owner, PaymentID: uuid.New(), Status: models.AutoReceiptPending},
+    }
+
+    if err := svc.ProcessPending(context.Background()); err != nil { t.Fatalf("ProcessPending: %v", err) }
+    if receipts.created != 1 { t.Fatalf("recibos emitidos = %d, esperado 1", receipts.created) }
+    ar := repo.finished[paid]
+    if ar.Status != models.AutoReceiptIssued || ar.ReceiptID == nil || receipts.byID[*ar.ReceiptID].IncomeID == nil || *receipts.byID[*ar.ReceiptID].IncomeID != paid {
+        t.Fatalf("receita quitada = %+v", ar)
+    }
+    if ar := repo.finished[taken]; ar.Status != models.AutoReceiptSkipped || ar.ReceiptID != nil {
+        t.Fatalf("receita com recibo = %+v", ar)
+    }
+}
+
+func TestAutoReceiptService_RetriesThenFails(t *testing.T) {
+    svc, repo, _, quota := newAutoReceiptTest()
+    income := uuid.New()
+    svc.receipts = &failingReceiptRepo{err: errors.New("conexão perdida")}
+    repo.pending = []models.AutoReceipt{{IncomeID: income, OwnerID: uuid.New(), Status: models.AutoReceiptPending}}
+
+    for i := 1; i < svc.maxAttempts; i++ {
+        if err := svc.ProcessPending(context.Background()); err != nil { t.Fatalf("ProcessPending: %v", err) }
+    }
+    if len(repo.released) != svc.maxAttempts-1 || len(repo.finished) != 0 { t.Fatalf("pedido deveria voltar à fila antes da última tentativa") }
+    if quota.released != svc.maxAttempts-1 { t.Fatalf("cota liberada %d vezes, esperado %d", quota.released, svc.maxAttempts-1) }
+
+    if err := svc.ProcessPending(context.Background()); err != nil { t.Fatalf("ProcessPending: %v", err) }
+    if ar := repo.finished[income]; ar.Status != models.AutoReceiptFailed || ar.LastError == nil { t.Fatalf("pedido = %+v, esperado failed", ar) }
+}
+
+func TestAutoReceiptService_QuotaExceededFailsAtOnce(t *testing.T) {
+    svc, repo, receipts, quota := newAutoReceiptTest()
+    income := uuid.New()
+    quota.full = true
+    repo.pending = []models.AutoReceipt{{IncomeID: income, OwnerID: uuid.New(), Status: models.AutoReceiptPending}}
+
+    if err := svc.ProcessPending(context.Background()); err != nil { t.Fatalf("ProcessPending: %v", err) }
+    if receipts.created != 0 || len(repo.released) != 0 { t.Fatalf("cota esgotada não deveria emitir nem voltar à fila") }
+    if ar := repo.finished[income]; ar.Status != models.AutoReceiptFailed { t.Fatalf("pedido = %+v, esperado failed", ar) }
+}
+
+// fakeAutoReceiptTrigger conta os disparos de AddPayment
+type fakeAutoReceiptTrigger struct{ kicks int }
+
+func (f *fakeAutoReceiptTrigger) Kick() { f.kicks++ }
+
+func TestAddPayment_KicksAutoReceiptOnlyWhenSettled(t *testing.T) {
+    ownerID := uuid.New()
+    existing := &models.Income{ID: uuid.New(), OwnerID: ownerID, Valor: models.NewMoney(100), TotalPago: models.NewMoney(40)}
+    repo := &fakeIncomeRepo{getByIDResp: existing}
+    trigger := &fakeAutoReceiptTrigger{}
+    svc := NewIncomeService(repo, WithAutoReceipts(trigger))
+
+    if _, err := svc.AddPayment(context.Background(), ownerID, &models.PaymentRequest{IncomeID: existing.ID, Valor: models.NewMoney(20)}); err != nil {
+        t.Fatalf("AddPayment: %v", err)
+    }
+    if trigger.kicks != 0 { t.Fatalf("pagamento parcial não deveria disparar o recibo automático") }
+
+    settled := *existing
+    settled.TotalPago = existing.Valor
+    repo.getByIDFn = func(id, owner uuid.UUID) (*models.Income, error) {
+        if repo.addPayTxCount == 0 { return existing, nil }
+        return &settled, nil
+    }
+    repo.addPayTxCount = 0
+    if _, err := svc.AddPayment(context.Background(), ownerID, &models.PaymentRequest{IncomeID: existing.ID, Valor: models.NewMoney(60)}); err != nil {
+        t.Fatalf("AddPayment: %v", err)
+    }
+    if trigger.kicks != 1 { t.Fatalf("disparos = %d, esperado 1 após quitar", trigger.kicks) }
+}
@@ -0,0 +1,282 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Leitura de extratos bancários em OFX (SGML 1.x e XML 2.x) e CSV para a conciliação
+// Data: 18-10-2026
+
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"recibofast/internal/models"
+)
+
+// statementEntry lançamento lido do arquivo (créditos e débitos)
+type statementEntry struct {
+	fitID    string
+	postedAt time.Time
+	valor    models.Money
+	desc     string
+}
+
+// parsedStatement extrato lido do arquivo
+type parsedStatement struct {
+	format  string
+	account string
+	entries []statementEntry
+}
+
+// parseStatement lê o extrato no formato informado ou, sem ele, pela extensão e pelo conteúdo.
+// Docstring: arquivos fora de UTF-8 são lidos como Latin-1 (padrão dos OFX dos bancos brasileiros).
+func parseStatement(r io.Reader, filename, format string) (*parsedStatement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, models.ErrStatementFileRequired
+	}
+	text := latin1ToUTF8(data)
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		switch ext := strings.ToLower(path.Ext(filename)); {
+		case ext == ".ofx" || ext == ".qfx":
+			format = models.StatementFormatOFX
+		case ext == ".csv" || ext == ".txt":
+			format = models.StatementFormatCSV
+		case strings.Contains(strings.ToUpper(text[:min(len(text), 512)]), "OFX"):
+			format = models.StatementFormatOFX
+		default:
+			format = models.StatementFormatCSV
+		}
+	}
+	var st *parsedStatement
+	switch format {
+	case models.StatementFormatOFX:
+		st, err = parseOFX(text)
+	case models.StatementFormatCSV:
+		st, err = parseStatementCSV(text)
+	default:
+		return nil, models.ErrStatementFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(st.entries) > models.MaxStatementLines {
+		return nil, models.ErrStatementTooLarge
+	}
+	return st, nil
+}
+
+// latin1ToUTF8 converte bytes Latin-1 quando o conteúdo não é UTF-8 válido
+func latin1ToUTF8(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// parseOFX lê os blocos STMTTRN; em SGML os campos simples não têm tag de fechamento, então
+// cada valor vai até o próximo "<"
+func parseOFX(text string) (*parsedStatement, error) {
+	upper := strings.ToUpper(text)
+	if !strings.Contains(upper, "<OFX>") {
+		return nil, fmt.Errorf("%w: cabeçalho OFX ausente", models.ErrStatementInvalid)
+	}
+	st := &parsedStatement{format: models.StatementFormatOFX, account: ofxField(text, upper, "ACCTID")}
+	for {
+		start := strings.Index(upper, "<STMTTRN>")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(upper[start:], "</STMTTRN>")
+		if end < 0 {
+			return nil, fmt.Errorf("%w: bloco STMTTRN sem fechamento", models.ErrStatementInvalid)
+		}
+		block, blockUpper := text[start:start+end], upper[start:start+end]
+		text, upper = text[start+end+len("</STMTTRN>"):], upper[start+end+len("</STMTTRN>"):]
+
+		posted := ofxField(block, blockUpper, "DTPOSTED")
+		if posted == "" {
+			posted = ofxField(block, blockUpper, "DTUSER")
+		}
+		if len(posted) < 8 {
+			return nil, fmt.Errorf("%w: DTPOSTED ausente", models.ErrStatementInvalid)
+		}
+		date, err := time.Parse("20060102", posted[:8])
+		if err != nil {
+			return nil, fmt.Errorf("%w: DTPOSTED %q", models.ErrStatementInvalid, posted)
+		}
+		amount := ofxField(block, blockUpper, "TRNAMT")
+		if !strings.Contains(amount, ".") {
+			amount = strings.Replace(amount, ",", ".", 1)
+		}
+		valor, err := models.ParseMoney(amount)
+		if err != nil {
+			return nil, fmt.Errorf("%w: TRNAMT %q", models.ErrStatementInvalid, amount)
+		}
+		desc := strings.Join(nonEmpty(ofxField(block, blockUpper, "NAME"), ofxField(block, blockUpper, "MEMO")), " - ")
+		e := statementEntry{fitID: ofxField(block, blockUpper, "FITID"), postedAt: date, valor: valor, desc: desc}
+		if e.fitID == "" {
+			e.fitID = statementHash(e)
+		}
+		st.entries = append(st.entries, e)
+	}
+	return st, nil
+}
+
+// ofxField valor do primeiro <TAG> do trecho (até o próximo "<" ou fim de linha)
+func ofxField(text, upper, tag string) string {
+	i := strings.Index(upper, "<"+tag+">")
+	if i < 0 {
+		return ""
+	}
+	v := text[i+len(tag)+2:]
+	if j := strings.IndexAny(v, "<\r\n"); j >= 0 {
+		v = v[:j]
+	}
+	return strings.TrimSpace(v)
+}
+
+// Cabeçalhos reconhecidos no CSV (comparados sem acentos e sem diferenciar maiúsculas)
+var (
+	statementDateHeaders   = []string{"data", "date", "data lancamento", "data do lancamento", "data movimento"}
+	statementDescHeaders   = []string{"descricao", "historico", "lancamento", "memo", "description", "detalhes"}
+	statementAmountHeaders = []string{"valor", "amount", "valor (r$)", "valor r$"}
+	statementCreditHeaders = []string{"credito", "entrada", "credito (r$)"}
+	statementDebitHeaders  = []string{"debito", "saida", "debito (r$)"}
+)
+
+// parseStatementCSV lê extratos CSV com colunas de data, histórico e valor (ou crédito/débito)
+func parseStatementCSV(text string) (*parsedStatement, error) {
+	header := text
+	if i := strings.IndexAny(text, "\r\n"); i >= 0 {
+		header = text[:i]
+	}
+	cr := csv.NewReader(strings.NewReader(text))
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		cr.Comma = ';'
+	}
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	head, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrStatementInvalid, err)
+	}
+	col := func(names []string) int {
+		for i, h := range head {
+			h = foldText(h)
+			for _, n := range names {
+				if h == n {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	dateCol, descCol, amountCol := col(statementDateHeaders), col(statementDescHeaders), col(statementAmountHeaders)
+	creditCol, debitCol := col(statementCreditHeaders), col(statementDebitHeaders)
+	if dateCol < 0 || (amountCol < 0 && creditCol < 0) {
+		return nil, fmt.Errorf("%w: colunas de data e valor não encontradas", models.ErrStatementInvalid)
+	}
+
+	st := &parsedStatement{format: models.StatementFormatCSV}
+	seen := map[string]int{}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		if err != nil {
+			return nil, fmt.Errorf("%w (linha %d): %v", models.ErrStatementInvalid, line, err)
+		}
+		if isBlankRecord(rec) {
+			continue
+		}
+		get := func(i int) string {
+			if i >= 0 && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		date, err := parseImportDate(get(dateCol))
+		if err != nil {
+			// Linhas de saldo e totais dos bancos não têm data: ficam de fora
+			continue
+		}
+		if strings.HasPrefix(foldText(get(descCol)), "saldo") || (amountCol < 0 && get(creditCol) == "" && get(debitCol) == "") {
+			continue
+		}
+		var valor models.Money
+		if amountCol >= 0 {
+			valor, err = parseImportValor(get(amountCol))
+		} else if v := get(creditCol); v != "" {
+			valor, err = parseImportValor(v)
+		} else {
+			valor, err = parseImportValor(get(debitCol))
+			if valor > 0 {
+				valor = -valor
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w (linha %d): valor inválido", models.ErrStatementInvalid, line)
+		}
+		e := statementEntry{postedAt: date, valor: valor, desc: get(descCol)}
+		// Lançamentos idênticos no mesmo dia são distintos pela ordem no arquivo
+		e.fitID = statementHash(e)
+		seen[e.fitID]++
+		if n := seen[e.fitID]; n > 1 {
+			e.fitID = fmt.Sprintf("%s#%d", e.fitID, n)
+		}
+		st.entries = append(st.entries, e)
+	}
+	return st, nil
+}
+
+// statementHash identificador estável do lançamento sem FITID (data, valor e histórico)
+func statementHash(e statementEntry) string {
+	sum := sha256.Sum256([]byte(e.postedAt.Format("2006-01-02") + "|" + e.valor.String() + "|" + foldText(e.desc)))
+	return "h:" + hex.EncodeToString(sum[:12])
+}
+
+// foldText minúsculas sem acentos e com espaços simples, para comparar textos de bancos
+func foldText(s string) string {
+	return strings.Join(strings.Fields(accentFolder.Replace(strings.ToLower(s))), " ")
+}
+
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "ê", "e", "è", "e", "ë", "e",
+	"í", "i", "î", "i", "ì", "i", "ï", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ò", "o", "ö", "o",
+	"ú", "u", "û", "u", "ù", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// nonEmpty valores preenchidos e sem repetição (NAME e MEMO costumam vir iguais)
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" && (len(out) == 0 || out[len(out)-1] != v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
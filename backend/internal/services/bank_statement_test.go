@@ -0,0 +1,110 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da leitura de extratos OFX e CSV da conciliação bancária
+// Data: 18-10-2026
+
+package services
+
+import (
+    "errors"
+    "strings"
+    "testing"
+
+    "recibofast/internal/models"
+)
+
+const ofxSGML = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+CHARSET:1252
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKACCTFROM><BANKID>0341<ACCTID>12345-6</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20261005120000[-3:BRT]
+<TRNAMT>1500.00
+<FITID>20261005001
+<MEMO>PIX RECEBIDO JOAO DA SILVA
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20261006
+<TRNAMT>-89,90
+<FITID>20261006002
+<NAME>TARIFA
+<MEMO>TARIFA
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+const ofxXML = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20261010</DTPOSTED><TRNAMT>250.5</TRNAMT><NAME>TED Maria</NAME></STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+func TestParseStatement_OFX(t *testing.T) {
+    st, err := parseStatement(strings.NewReader(ofxSGML), "extrato.ofx", "")
+    if err != nil { t.Fatalf("erro inesperado: %v", err) }
+    if st.format != models.StatementFormatOFX || st.account != "12345-6" { t.Fatalf("cabeçalho = %q %q", st.format, st.account) }
+    if len(st.entries) != 2 { t.Fatalf("lançamentos = %d, esperado 2", len(st.entries)) }
+    e := st.entries[0]
+    if e.fitID != "20261005001" || e.valor != 150000 || e.postedAt.Format("2006-01-02") != "2026-10-05" || e.desc != "PIX RECEBIDO JOAO DA SILVA" {
+        t.Fatalf("crédito lido errado: %+v", e)
+    }
+    if d := st.entries[1]; d.valor != -8990 || d.desc != "TARIFA" { t.Fatalf("débito lido errado: %+v", d) }
+
+    // OFX 2.x (XML), detectado pelo conteúdo e sem FITID
+    st, err = parseStatement(strings.NewReader(ofxXML), "", "")
+    if err != nil { t.Fatalf("erro inesperado: %v", err) }
+    if len(st.entries) != 1 || st.entries[0].valor != 25050 || !strings.HasPrefix(st.entries[0].fitID, "h:") {
+        t.Fatalf("OFX XML lido errado: %+v", st.entries)
+    }
+}
+
+func TestParseStatement_CSV(t *testing.T) {
+    csvBR := "Data;Histórico;Valor\n" +
+        "01/10/2026;Saldo anterior;1.000,00\n" +
+        "05/10/2026;PIX RECEBIDO JOAO;1.500,00\n" +
+        "05/10/2026;PIX RECEBIDO JOAO;1.500,00\n" +
+        "06/10/2026;TARIFA;-89,90\n"
+    st, err := parseStatement(strings.NewReader(csvBR), "extrato.csv", "")
+    if err != nil { t.Fatalf("erro inesperado: %v", err) }
+    if len(st.entries) != 3 { t.Fatalf("lançamentos = %d, esperado 3 (saldo fora)", len(st.entries)) }
+    if st.entries[0].valor != 150000 || st.entries[2].valor != -8990 { t.Fatalf("valores lidos errado: %+v", st.entries) }
+    if st.entries[0].fitID == st.entries[1].fitID || !strings.HasSuffix(st.entries[1].fitID, "#2") {
+        t.Fatalf("lançamentos idênticos devem ter identificadores distintos: %q %q", st.entries[0].fitID, st.entries[1].fitID)
+    }
+
+    // Reimportar o mesmo arquivo gera os mesmos identificadores
+    again, _ := parseStatement(strings.NewReader(csvBR), "extrato.csv", "")
+    if again.entries[0].fitID != st.entries[0].fitID { t.Fatal("identificador do CSV deve ser estável") }
+
+    split := "Data,Descrição,Crédito,Débito\n2026-10-07,TED EMPRESA X,300.00,\n2026-10-08,BOLETO,,45.00\n"
+    st, err = parseStatement(strings.NewReader(split), "", models.StatementFormatCSV)
+    if err != nil { t.Fatalf("erro inesperado: %v", err) }
+    if len(st.entries) != 2 || st.entries[0].valor != 30000 || st.entries[1].valor != -4500 {
+        t.Fatalf("colunas crédito/débito lidas errado: %+v", st.entries)
+    }
+}
+
+func TestParseStatement_Errors(t *testing.T) {
+    cases := []struct {
+        name, body, format string
+        want               error
+    }{
+        {"vazio", "  \n", "", models.ErrStatementFileRequired},
+        {"formato", "x", "pdf", models.ErrStatementFormat},
+        {"sem colunas", "a;b\n1;2\n", "csv", models.ErrStatementInvalid},
+        {"ofx sem fechamento", "<OFX><STMTTRN><DTPOSTED>20261001<TRNAMT>1", "ofx", models.ErrStatementInvalid},
+    }
+    for _, c := range cases {
+        if _, err := parseStatement(strings.NewReader(c.body), "", c.format); !errors.Is(err, c.want) {
+            t.Errorf("%s: erro = %v, esperado %v", c.name, err, c.want)
+        }
+    }
+}
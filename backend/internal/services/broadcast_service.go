@@ -0,0 +1,222 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Envio em massa de mensagens personalizadas para pagadores com limite por provedor
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// ScheduledEnqueuer enfileira entregas agendadas (implementado por DeliveryService)
+type ScheduledEnqueuer interface {
+	EnqueueAt(ctx context.Context, ownerID uuid.UUID, channel, destination, eventType string, payload interface{}, at time.Time) (*models.Delivery, error)
+}
+
+// broadcastClaimBatch envios reservados por execução do job
+const broadcastClaimBatch = 5
+
+// BroadcastService cria envios em massa e os processa no job "broadcasts".
+// Docstring: o pedido só grava o envio (status queued); ProcessPending resolve os pagadores,
+// personaliza o modelo e enfileira uma entrega por pagador espaçada pelo limite do canal
+// (BroadcastThrottle), de modo que o provedor nunca recebe mais que PerMinute mensagens por minuto.
+// O envio seguinte do mesmo canal começa depois da última entrega agendada do anterior.
+type BroadcastService struct {
+	repo        repositories.BroadcastRepository
+	deliveries  ScheduledEnqueuer
+	log         logging.Logger
+	throttles   map[string]models.BroadcastThrottle
+	lockTimeout time.Duration
+	now         func() time.Time
+
+	mu       sync.Mutex // serializa ProcessPending (worker e disparo pelo handler)
+	nextSlot map[string]time.Time
+}
+
+// NewBroadcastService cria o serviço com os limites padrão por canal
+func NewBroadcastService(repo repositories.BroadcastRepository, deliveries ScheduledEnqueuer, log logging.Logger) *BroadcastService {
+	return &BroadcastService{
+		repo:        repo,
+		deliveries:  deliveries,
+		log:         log,
+		throttles:   models.DefaultBroadcastThrottles(),
+		lockTimeout: 30 * time.Minute,
+		now:         time.Now,
+		nextSlot:    map[string]time.Time{},
+	}
+}
+
+// SetThrottle sobrescreve o limite de um canal
+func (s *BroadcastService) SetThrottle(channel string, t models.BroadcastThrottle) {
+	s.throttles[channel] = t
+}
+
+// Create valida e grava o envio; o idioma e o fuso do pedido formatam valores e datas da mensagem
+func (s *BroadcastService) Create(ctx context.Context, ownerID uuid.UUID, req *models.BroadcastRequest) (*models.Broadcast, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	ls := locale.FromContext(ctx)
+	b := &models.Broadcast{
+		OwnerID:   ownerID,
+		Channel:   req.Channel,
+		Template:  req.Template,
+		Filter:    req.Filter,
+		Variables: req.Variables,
+		Locale:    ls.Locale,
+		Timezone:  ls.Timezone(),
+	}
+	if req.Subject != "" {
+		b.Subject = &req.Subject
+	}
+	if err := s.repo.Create(ctx, b); err != nil {
+		return nil, fmt.Errorf("erro ao criar envio em massa: %w", err)
+	}
+	s.log.Info("envio em massa enfileirado",
+		logging.Field{Key: "broadcast_id", Val: b.ID.String()},
+		logging.Field{Key: "channel", Val: b.Channel})
+	return b, nil
+}
+
+// Get retorna o envio com o relatório de entrega
+func (s *BroadcastService) Get(ctx context.Context, id, ownerID uuid.UUID) (*models.BroadcastResponse, error) {
+	b, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		if errors.Is(err, models.ErrBroadcastNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao buscar envio em massa: %w", err)
+	}
+	rep, err := s.repo.Report(ctx, b.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar relatório do envio: %w", err)
+	}
+	return &models.BroadcastResponse{Broadcast: *b, Report: rep}, nil
+}
+
+// List lista os envios recentes do usuário
+func (s *BroadcastService) List(ctx context.Context, ownerID uuid.UUID) ([]models.Broadcast, error) {
+	items, err := s.repo.List(ctx, ownerID, 50)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar envios em massa: %w", err)
+	}
+	return items, nil
+}
+
+// ProcessPending processa os envios na fila (função do job "broadcasts")
+func (s *BroadcastService) ProcessPending(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items, err := s.repo.ClaimPending(ctx, s.now().UTC(), s.lockTimeout, broadcastClaimBatch)
+	if err != nil {
+		return fmt.Errorf("erro ao reservar envios em massa: %w", err)
+	}
+	var firstErr error
+	for i := range items {
+		if err := s.process(ctx, &items[i]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// process enfileira as mensagens de um envio e grava a situação final
+func (s *BroadcastService) process(ctx context.Context, b *models.Broadcast) error {
+	runErr := s.enqueueAll(ctx, b)
+	b.Status = models.BroadcastStatusCompleted
+	if runErr != nil {
+		msg := runErr.Error()
+		b.Status, b.LastError = models.BroadcastStatusFailed, &msg
+	}
+	if err := s.repo.Finish(ctx, b, s.now().UTC()); err != nil {
+		return fmt.Errorf("erro ao finalizar envio em massa: %w", err)
+	}
+	s.log.Info("envio em massa processado",
+		logging.Field{Key: "broadcast_id", Val: b.ID.String()},
+		logging.Field{Key: "status", Val: b.Status},
+		logging.Field{Key: "enqueued", Val: b.Enqueued},
+		logging.Field{Key: "skipped", Val: b.Skipped})
+	return runErr
+}
+
+func (s *BroadcastService) enqueueAll(ctx context.Context, b *models.Broadcast) error {
+	now := s.now().UTC()
+	targets, err := s.repo.ListTargets(ctx, b, now)
+	if err != nil {
+		return fmt.Errorf("erro ao resolver destinatários: %w", err)
+	}
+	ls := locale.Resolve(b.Locale, b.Timezone)
+	interval := s.throttles[b.Channel].Interval()
+	slot := s.nextSlot[b.Channel]
+	if slot.Before(now) {
+		slot = now
+	}
+	subject := ""
+	if b.Subject != nil {
+		subject = *b.Subject
+	}
+
+	for i := range targets {
+		t := &targets[i]
+		rc := &models.BroadcastRecipient{BroadcastID: b.ID, PayerID: t.PayerID, OwnerID: b.OwnerID}
+		dest, reason := t.Destination(b.Channel)
+		if dest == "" {
+			rc.Status, rc.Reason = models.BroadcastRecipientSkipped, &reason
+		} else {
+			vars := BroadcastVariables(t, ls, now)
+			for k, v := range b.Variables[t.PayerID.String()] {
+				vars[k] = v
+			}
+			payload := models.BroadcastPayload{
+				BroadcastID: b.ID,
+				PayerID:     t.PayerID,
+				Subject:     models.RenderBroadcastTemplate(subject, vars),
+				Body:        models.RenderBroadcastTemplate(b.Template, vars),
+			}
+			d, err := s.deliveries.EnqueueAt(ctx, b.OwnerID, b.Channel, dest, models.EventBroadcast, payload, slot)
+			if err != nil {
+				return fmt.Errorf("erro ao enfileirar mensagem para o pagador %s: %w", t.PayerID, err)
+			}
+			rc.Status, rc.Destination, rc.DeliveryID = models.BroadcastRecipientQueued, &dest, &d.ID
+			slot = slot.Add(interval)
+		}
+		if err := s.repo.AddRecipient(ctx, rc); err != nil {
+			return fmt.Errorf("erro ao registrar destinatário: %w", err)
+		}
+	}
+	s.nextSlot[b.Channel] = slot
+	return nil
+}
+
+// BroadcastVariables variáveis padrão do modelo para um pagador, formatadas no idioma do envio:
+// nome, documento, email, telefone, saldo, receitas_abertas, receitas_vencidas e proximo_vencimento.
+func BroadcastVariables(t *models.BroadcastTarget, ls locale.Settings, now time.Time) map[string]string {
+	vars := map[string]string{
+		"nome":              t.Nome,
+		"saldo":             ls.FormatAmount(t.SaldoAberto),
+		"receitas_abertas":  fmt.Sprint(t.Abertas),
+		"receitas_vencidas": fmt.Sprint(t.Vencidas),
+		"data":              ls.FormatDate(now),
+	}
+	for k, v := range map[string]*string{"documento": t.Documento, "email": t.Email, "telefone": t.Telefone} {
+		if v != nil {
+			vars[k] = *v
+		}
+	}
+	if t.ProxVencimento != nil {
+		// vencimento é data de calendário: formatado sem conversão de fuso
+		vars["proximo_vencimento"] = locale.Settings{Locale: ls.Locale, Location: time.UTC}.FormatDate(*t.ProxVencimento)
+	}
+	return vars
+}
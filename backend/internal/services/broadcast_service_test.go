@@ -0,0 +1,124 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do envio em massa (personalização, destinatários ignorados e espaçamento por provedor)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeBroadcastRepo implementa repositories.BroadcastRepository em memória
+type fakeBroadcastRepo struct {
+    pending    []models.Broadcast
+    targets    []models.BroadcastTarget
+    recipients []models.BroadcastRecipient
+    finished   *models.Broadcast
+}
+
+func (f *fakeBroadcastRepo) Create(ctx context.Context, b *models.Broadcast) error {
+    b.ID, b.Status = uuid.New(), models.BroadcastStatusQueued
+    f.pending = append(f.pending, *b)
+    return nil
+}
+func (f *fakeBroadcastRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Broadcast, error) {
+    return nil, models.ErrBroadcastNotFound
+}
+func (f *fakeBroadcastRepo) List(ctx context.Context, ownerID uuid.UUID, limit int) ([]models.Broadcast, error) {
+    return f.pending, nil
+}
+func (f *fakeBroadcastRepo) ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.Broadcast, error) {
+    items := f.pending
+    f.pending = nil
+    return items, nil
+}
+func (f *fakeBroadcastRepo) ListTargets(ctx context.Context, b *models.Broadcast, now time.Time) ([]models.BroadcastTarget, error) {
+    return f.targets, nil
+}
+func (f *fakeBroadcastRepo) AddRecipient(ctx context.Context, rc *models.BroadcastRecipient) error {
+    f.recipients = append(f.recipients, *rc); return nil
+}
+func (f *fakeBroadcastRepo) Finish(ctx context.Context, b *models.Broadcast, now time.Time) error {
+    for _, rc := range f.recipients {
+        b.Total++
+        if rc.Status == models.BroadcastRecipientSkipped { b.Skipped++ } else { b.Enqueued++ }
+    }
+    f.finished = b
+    return nil
+}
+func (f *fakeBroadcastRepo) Report(ctx context.Context, id uuid.UUID) (*models.BroadcastReport, error) {
+    return &models.BroadcastReport{}, nil
+}
+
+// fakeScheduledEnqueuer registra as entregas agendadas
+type fakeScheduledEnqueuer struct {
+    at       []time.Time
+    payloads []models.BroadcastPayload
+}
+
+func (f *fakeScheduledEnqueuer) EnqueueAt(ctx context.Context, ownerID uuid.UUID, channel, destination, eventType string, payload interface{}, at time.Time) (*models.Delivery, error) {
+    f.at = append(f.at, at)
+    f.payloads = append(f.payloads, payload.(models.BroadcastPayload))
+    return &models.Delivery{ID: uuid.New()}, nil
+}
+
+func TestBroadcastService_ProcessPendingPersonalizesAndThrottles(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    str := func(s string) *string { return &s }
+    ana, bia, caio := uuid.New(), uuid.New(), uuid.New()
+    repo := &fakeBroadcastRepo{targets: []models.BroadcastTarget{
+        {PayerID: ana, Nome: "Ana", Telefone: str("+5511999990000"), SaldoAberto: models.NewMoney(150)},
+        {PayerID: bia, Nome: "Bia"},
+        {PayerID: caio, Nome: "Caio", Telefone: str("+5511988880000")},
+    }}
+    deliveries := &fakeScheduledEnqueuer{}
+    svc := NewBroadcastService(repo, deliveries, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+
+    req := &models.BroadcastRequest{Channel: "whatsapp", Template: "Olá {{nome}}, saldo {{saldo}}. {{extra}}",
+        Variables: map[string]map[string]string{caio.String(): {"extra": "Obrigado!"}}}
+    if _, err := svc.Create(context.Background(), uuid.New(), req); err != nil {
+        t.Fatalf("Create: %v", err)
+    }
+    if err := svc.ProcessPending(context.Background()); err != nil {
+        t.Fatalf("ProcessPending: %v", err)
+    }
+
+    if len(deliveries.payloads) != 2 {
+        t.Fatalf("entregas = %d, esperado 2 (Bia sem telefone)", len(deliveries.payloads))
+    }
+    if got := deliveries.payloads[0].Body; !strings.HasPrefix(got, "Olá Ana, saldo R$ 150,00.") {
+        t.Fatalf("mensagem da Ana = %q", got)
+    }
+    if got := deliveries.payloads[1].Body; got != "Olá Caio, saldo R$ 0,00. Obrigado!" {
+        t.Fatalf("mensagem do Caio = %q", got)
+    }
+    // WhatsApp: 20 por minuto = uma mensagem a cada 3s
+    if deliveries.at[0] != now || deliveries.at[1] != now.Add(3*time.Second) {
+        t.Fatalf("agendamentos = %v", deliveries.at)
+    }
+    f := repo.finished
+    if f == nil || f.Status != models.BroadcastStatusCompleted || f.Enqueued != 2 || f.Skipped != 1 {
+        t.Fatalf("envio finalizado = %+v", f)
+    }
+    if rc := repo.recipients[1]; rc.PayerID != bia || rc.Reason == nil || *rc.Reason != models.BroadcastSkipNoPhone {
+        t.Fatalf("destinatário ignorado = %+v", rc)
+    }
+
+    // Um novo envio no mesmo canal continua depois da última mensagem agendada
+    repo.recipients = nil
+    repo.targets = repo.targets[:1]
+    _, _ = svc.Create(context.Background(), uuid.New(), &models.BroadcastRequest{Channel: "whatsapp", Template: "Oi"})
+    _ = svc.ProcessPending(context.Background())
+    if last := deliveries.at[len(deliveries.at)-1]; last != now.Add(6*time.Second) {
+        t.Fatalf("segundo envio agendado para %v, esperado %v", last, now.Add(6*time.Second))
+    }
+}
@@ -0,0 +1,92 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Serviço do cadastro de categorias (CRUD, renomeação e uso por categoria)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// CategoryService interface para serviços de categorias
+type CategoryService interface {
+	CreateCategory(ctx context.Context, ownerID uuid.UUID, req *models.CategoryRequest) (*models.Category, error)
+	GetCategory(ctx context.Context, id, ownerID uuid.UUID) (*models.Category, error)
+	UpdateCategory(ctx context.Context, id, ownerID uuid.UUID, req *models.CategoryRequest) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id, ownerID uuid.UUID, replaceWith *uuid.UUID) error
+	ListCategories(ctx context.Context, ownerID uuid.UUID) ([]models.Category, error)
+}
+
+type categoryService struct {
+	repo repositories.CategoryRepository
+}
+
+// NewCategoryService cria uma nova instância do serviço de categorias
+func NewCategoryService(repo repositories.CategoryRepository) CategoryService {
+	return &categoryService{repo: repo}
+}
+
+// CreateCategory valida e cadastra uma categoria
+func (s *categoryService) CreateCategory(ctx context.Context, ownerID uuid.UUID, req *models.CategoryRequest) (*models.Category, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	c := &models.Category{OwnerID: ownerID, Nome: req.Nome, Cor: req.Cor, Icone: req.Icone}
+	if err := s.repo.Create(ctx, c); err != nil {
+		if errors.Is(err, models.ErrDuplicateCategory) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao criar categoria: %w", err)
+	}
+	return c, nil
+}
+
+// GetCategory busca uma categoria do usuário
+func (s *categoryService) GetCategory(ctx context.Context, id, ownerID uuid.UUID) (*models.Category, error) {
+	return s.repo.GetByID(ctx, id, ownerID)
+}
+
+// UpdateCategory substitui nome, cor e ícone; a troca de nome é propagada às receitas
+func (s *categoryService) UpdateCategory(ctx context.Context, id, ownerID uuid.UUID, req *models.CategoryRequest) (*models.Category, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	c := &models.Category{ID: id, OwnerID: ownerID, Nome: req.Nome, Cor: req.Cor, Icone: req.Icone}
+	if err := s.repo.Update(ctx, c); err != nil {
+		if errors.Is(err, models.ErrCategoryNotFound) || errors.Is(err, models.ErrDuplicateCategory) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao atualizar categoria: %w", err)
+	}
+	return c, nil
+}
+
+// DeleteCategory remove a categoria, movendo as receitas para replaceWith (ou deixando-as sem categoria)
+func (s *categoryService) DeleteCategory(ctx context.Context, id, ownerID uuid.UUID, replaceWith *uuid.UUID) error {
+	if replaceWith != nil && *replaceWith == id {
+		return models.ErrCategoryReplaceInvalid
+	}
+	if err := s.repo.Delete(ctx, id, ownerID, replaceWith); err != nil {
+		if errors.Is(err, models.ErrCategoryNotFound) || errors.Is(err, models.ErrCategoryReplaceInvalid) {
+			return err
+		}
+		return fmt.Errorf("erro ao remover categoria: %w", err)
+	}
+	return nil
+}
+
+// ListCategories lista as categorias do usuário com contagens de uso
+func (s *categoryService) ListCategories(ctx context.Context, ownerID uuid.UUID) ([]models.Category, error) {
+	items, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar categorias: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,129 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Baixa dos links de pagamento: casa a notificação do gateway com a receita e lança o pagamento
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/checkout"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// CheckoutWebhookResult resumo de uma notificação: pagamentos recebidos e lançados nas receitas
+type CheckoutWebhookResult struct {
+	Received   int `json:"received"`
+	Registered int `json:"registered"`
+}
+
+// CheckoutWebhookService processa as notificações de pagamento do gateway de checkout.
+// Docstring: segue a baixa do webhook PIX: o link é marcado como pago antes do lançamento, erros
+// transitórios reabrem o link (o gateway reenvia) e recusas de negócio deixam o link pago para
+// conciliação manual. A forma de pagamento é a informada pelo gateway (cartão, por padrão).
+type CheckoutWebhookService struct {
+	links    repositories.PaymentLinkRepository
+	payments PaymentRecorder
+	gateway  checkout.Gateway
+	secret   string
+	log      logging.Logger
+}
+
+// NewCheckoutWebhookService cria o serviço do webhook de checkout; gateway pode ser nil (links desabilitados)
+func NewCheckoutWebhookService(links repositories.PaymentLinkRepository, payments PaymentRecorder, gateway checkout.Gateway, secret string, log logging.Logger) *CheckoutWebhookService {
+	return &CheckoutWebhookService{links: links, payments: payments, gateway: gateway, secret: secret, log: log}
+}
+
+// Handle valida a notificação e lança os pagamentos aprovados
+func (s *CheckoutWebhookService) Handle(ctx context.Context, req *checkout.WebhookRequest) (*CheckoutWebhookResult, error) {
+	if s.gateway == nil {
+		return nil, models.ErrCheckoutUnavailable
+	}
+	payments, err := s.gateway.ParseWebhook(ctx, req, s.secret)
+	switch {
+	case errors.Is(err, checkout.ErrInvalidSignature):
+		return nil, models.ErrCheckoutWebhookAuth
+	case errors.Is(err, checkout.ErrInvalidPayload):
+		return nil, models.ErrCheckoutWebhookPayload
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", models.ErrCheckoutProviderFailed, err)
+	}
+	res := &CheckoutWebhookResult{Received: len(payments)}
+	for _, p := range payments {
+		ok, err := s.register(ctx, p)
+		if err != nil {
+			return res, err
+		}
+		if ok {
+			res.Registered++
+		}
+	}
+	return res, nil
+}
+
+// register baixa o link da referência e lança o pagamento; false quando nada foi lançado
+func (s *CheckoutWebhookService) register(ctx context.Context, p checkout.Payment) (bool, error) {
+	linkID, err := uuid.Parse(p.Reference)
+	if err != nil {
+		s.log.Info("notificação de checkout com referência desconhecida", logging.Field{Key: "reference", Val: p.Reference})
+		return false, nil
+	}
+	var providerPaymentID *string
+	if p.PaymentID != "" {
+		providerPaymentID = &p.PaymentID
+	}
+	link, err := s.links.ClaimPaid(ctx, s.gateway.Name(), linkID, providerPaymentID, p.PaidAt.UTC())
+	if errors.Is(err, models.ErrPaymentLinkNotFound) {
+		s.log.Info("notificação de checkout sem link pendente (repetida ou desconhecida)", logging.Field{Key: "reference", Val: p.Reference})
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao baixar link de pagamento: %w", err)
+	}
+
+	metodo, pagoEm := p.Method, p.PaidAt.UTC().Format(time.RFC3339)
+	if metodo == "" {
+		metodo = models.PaymentMethodCartao
+	}
+	obs := "Link de pagamento " + link.Provider
+	if p.PaymentID != "" {
+		obs += " (pagamento " + p.PaymentID + ")"
+	}
+	req := &models.PaymentRequest{IncomeID: link.IncomeID, Valor: models.Money(p.Amount), PagoEm: &pagoEm,
+		Metodo: &metodo, Obs: &obs, Overpayment: models.OverpaymentCredit}
+	out, err := s.payments.AddPayment(ctx, link.OwnerID, req)
+	if errors.Is(err, models.ErrPaymentMethodNotFound) {
+		// Forma ausente do catálogo do usuário: lança com a forma padrão
+		req.Metodo = nil
+		out, err = s.payments.AddPayment(ctx, link.OwnerID, req)
+	}
+	switch {
+	case errors.Is(err, models.ErrInsufficientAmount), errors.Is(err, models.ErrIncomeAlreadyPaid),
+		errors.Is(err, models.ErrIncomeNotFound), errors.Is(err, models.ErrValorInvalid):
+		s.log.Warn("pagamento do link recebido sem lançamento na receita; concilie manualmente",
+			logging.Field{Key: "income_id", Val: link.IncomeID.String()},
+			logging.Field{Key: "link_id", Val: link.ID.String()},
+			logging.Field{Key: "error", Val: err.Error()})
+		return false, nil
+	case err != nil:
+		if rerr := s.links.Release(context.WithoutCancel(ctx), link.ID); rerr != nil {
+			s.log.Error("erro ao reabrir link de pagamento", logging.Field{Key: "link_id", Val: link.ID.String()}, logging.Field{Key: "error", Val: rerr.Error()})
+		}
+		return false, fmt.Errorf("erro ao lançar pagamento do link: %w", err)
+	}
+	if err := s.links.SetPayment(context.WithoutCancel(ctx), link.ID, out.Payment.ID); err != nil {
+		s.log.Warn("erro ao ligar link ao pagamento", logging.Field{Key: "link_id", Val: link.ID.String()}, logging.Field{Key: "error", Val: err.Error()})
+	}
+	s.log.Info("pagamento do link lançado",
+		logging.Field{Key: "income_id", Val: link.IncomeID.String()},
+		logging.Field{Key: "payment_id", Val: out.Payment.ID.String()},
+		logging.Field{Key: "link_id", Val: link.ID.String()})
+	return true, nil
+}
@@ -0,0 +1,112 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Reajuste anual de contratos por IGP-M/IPCA aplicado ao valor mensal e às receitas futuras
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// RateAccumulator variação acumulada de um índice mensal (implementado por RateService, que
+// busca os meses ausentes na fonte e os guarda em rf_rates)
+type RateAccumulator interface {
+	Accumulated(ctx context.Context, indice string, from, to time.Time) (float64, error)
+}
+
+// ContractReadjustService calcula e aplica o reajuste anual dos contratos.
+// Docstring: o percentual é o acumulado do índice nos 12 meses anteriores à competência inicial
+// (arredondado a 6 casas, como gravado); deflação só reduz o valor com permitir_reducao. O
+// repositório aplica o mesmo fator ao contrato, às receitas futuras em aberto e aos modelos.
+type ContractReadjustService struct {
+	repo      repositories.ContractReadjustRepository
+	contracts repositories.ContractRepository
+	rates     RateAccumulator
+	log       logging.Logger
+	now       func() time.Time
+}
+
+// NewContractReadjustService cria o serviço de reajuste de contratos
+func NewContractReadjustService(repo repositories.ContractReadjustRepository, contracts repositories.ContractRepository, rates RateAccumulator, log logging.Logger) *ContractReadjustService {
+	return &ContractReadjustService{repo: repo, contracts: contracts, rates: rates, log: log, now: time.Now}
+}
+
+// Readjust calcula o reajuste do contrato e, fora do dry run, o aplica e registra no histórico
+func (s *ContractReadjustService) Readjust(ctx context.Context, ownerID, contractID uuid.UUID, req *models.ContractReadjustRequest, ls locale.Settings) (*models.ContractReadjustment, error) {
+	local := s.now().In(ls.Location)
+	nextMonth := time.Date(local.Year(), local.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	start, from, to, err := req.ReadjustPeriod(nextMonth)
+	if err != nil {
+		return nil, err
+	}
+	// Confere o contrato antes de consultar (e talvez buscar) o índice
+	if _, err := s.contracts.GetByID(ctx, contractID, ownerID); err != nil {
+		return nil, wrapReadjustError("erro ao buscar contrato", err)
+	}
+
+	acumulado, err := s.rates.Accumulated(ctx, req.Indice, from, to)
+	if errors.Is(err, models.ErrRateNotFound) {
+		return nil, models.ErrReadjustIndexUnavailable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao calcular acumulado do índice: %w", err)
+	}
+	acumulado = math.Round(acumulado*1e6) / 1e6
+	percentual := acumulado
+	if percentual < 0 && !req.PermitirReducao {
+		percentual = 0
+	}
+
+	rj := &models.ContractReadjustment{
+		OwnerID:       ownerID,
+		ContractID:    contractID,
+		Indice:        req.Indice,
+		PeriodoInicio: from,
+		PeriodoFim:    to,
+		Acumulado:     acumulado,
+		Percentual:    percentual,
+		APartirDe:     start,
+		DryRun:        req.DryRun,
+	}
+	if err := s.repo.Apply(ctx, rj); err != nil {
+		return nil, wrapReadjustError("erro ao aplicar reajuste", err)
+	}
+	if !rj.DryRun {
+		s.log.Info("contrato reajustado", logging.Field{Key: "contract_id", Val: contractID.String()},
+			logging.Field{Key: "indice", Val: rj.Indice}, logging.Field{Key: "percentual", Val: rj.Percentual},
+			logging.Field{Key: "receitas", Val: rj.ReceitasAtualizadas})
+	}
+	return rj, nil
+}
+
+// List lista o histórico de reajustes do contrato
+func (s *ContractReadjustService) List(ctx context.Context, ownerID, contractID uuid.UUID) ([]models.ContractReadjustment, error) {
+	if _, err := s.contracts.GetByID(ctx, contractID, ownerID); err != nil {
+		return nil, wrapReadjustError("erro ao buscar contrato", err)
+	}
+	items, err := s.repo.List(ctx, contractID, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar reajustes: %w", err)
+	}
+	return items, nil
+}
+
+func wrapReadjustError(msg string, err error) error {
+	for _, known := range []error{models.ErrContractNotFound, models.ErrReadjustAlreadyApplied, models.ErrReadjustContractValue} {
+		if errors.Is(err, known) {
+			return err
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
@@ -0,0 +1,94 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do reajuste de contratos (período do índice, deflação, simulação e índice ausente)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+// fakeReadjustRepo aplica o reajuste em memória
+type fakeReadjustRepo struct {
+    repositories.ContractReadjustRepository
+    valor   models.Money
+    applied []models.ContractReadjustment
+}
+
+func (f *fakeReadjustRepo) Apply(ctx context.Context, rj *models.ContractReadjustment) error {
+    for _, a := range f.applied {
+        if a.ContractID == rj.ContractID && a.APartirDe.Equal(rj.APartirDe) { return models.ErrReadjustAlreadyApplied }
+    }
+    rj.ValorAnterior = f.valor
+    rj.ValorNovo = models.ReadjustedValue(f.valor, rj.Percentual)
+    if !rj.DryRun {
+        f.valor = rj.ValorNovo
+        f.applied = append(f.applied, *rj)
+    }
+    return nil
+}
+
+type fakeReadjustContracts struct {
+    repositories.ContractRepository
+    id, owner uuid.UUID
+}
+
+func (f *fakeReadjustContracts) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Contract, error) {
+    if id != f.id || ownerID != f.owner { return nil, models.ErrContractNotFound }
+    return &models.Contract{ID: id, OwnerID: ownerID}, nil
+}
+
+// fakeAccumulator devolve o acumulado configurado e registra o período pedido
+type fakeAccumulator struct {
+    pct      float64
+    err      error
+    from, to time.Time
+}
+
+func (f *fakeAccumulator) Accumulated(ctx context.Context, indice string, from, to time.Time) (float64, error) {
+    f.from, f.to = from, to
+    return f.pct, f.err
+}
+
+func TestContractReadjustService_Readjust(t *testing.T) {
+    owner, contract := uuid.New(), uuid.New()
+    repo := &fakeReadjustRepo{valor: models.NewMoney(2000)}
+    rates := &fakeAccumulator{pct: 4.123456789}
+    svc := NewContractReadjustService(repo, &fakeReadjustContracts{id: contract, owner: owner}, rates, logging.NewLogger("dev"))
+    // 01:30 UTC de 01/11 ainda é 31/10 em São Paulo: o padrão é reajustar a partir de novembro
+    svc.now = func() time.Time { return time.Date(2026, 11, 1, 1, 30, 0, 0, time.UTC) }
+    ls := locale.Resolve("pt-BR", "America/Sao_Paulo")
+    ctx := context.Background()
+
+    sim, err := svc.Readjust(ctx, owner, contract, &models.ContractReadjustRequest{Indice: " IGPM ", DryRun: true}, ls)
+    if err != nil { t.Fatalf("Readjust: %v", err) }
+    if sim.APartirDe.Format("2006-01") != "2026-11" || rates.from.Format("2006-01") != "2025-11" || rates.to.Format("2006-01") != "2026-10" { t.Fatalf("período = %v..%v a partir de %v", rates.from, rates.to, sim.APartirDe) }
+    if sim.Percentual != 4.123457 || sim.ValorNovo != models.NewMoney(2082.47) || len(repo.applied) != 0 { t.Fatalf("simulação = %+v", sim) }
+
+    rj, err := svc.Readjust(ctx, owner, contract, &models.ContractReadjustRequest{Indice: "igpm"}, ls)
+    if err != nil || rj.ValorNovo != models.NewMoney(2082.47) || repo.valor != rj.ValorNovo { t.Fatalf("reajuste = %+v, %v", rj, err) }
+    if _, err := svc.Readjust(ctx, owner, contract, &models.ContractReadjustRequest{Indice: "igpm"}, ls); !errors.Is(err, models.ErrReadjustAlreadyApplied) { t.Fatalf("err = %v", err) }
+
+    // deflação só reduz com permitir_reducao
+    rates.pct = -1.5
+    rj, err = svc.Readjust(ctx, owner, contract, &models.ContractReadjustRequest{Indice: "ipca", APartirDe: "2027-11", DryRun: true}, ls)
+    if err != nil || rj.Acumulado != -1.5 || rj.Percentual != 0 || rj.ValorNovo != rj.ValorAnterior { t.Fatalf("sem redução = %+v, %v", rj, err) }
+    rj, err = svc.Readjust(ctx, owner, contract, &models.ContractReadjustRequest{Indice: "ipca", APartirDe: "11/2027", PermitirReducao: true, DryRun: true}, ls)
+    if err != nil || rj.Percentual != -1.5 || rj.ValorNovo != models.NewMoney(2051.23) { t.Fatalf("com redução = %+v, %v", rj, err) }
+
+    rates.err = models.ErrRateNotFound
+    if _, err := svc.Readjust(ctx, owner, contract, &models.ContractReadjustRequest{Indice: "ipca", APartirDe: "2027-11"}, ls); !errors.Is(err, models.ErrReadjustIndexUnavailable) { t.Fatalf("err = %v", err) }
+    if _, err := svc.Readjust(ctx, uuid.New(), contract, &models.ContractReadjustRequest{Indice: "ipca"}, ls); !errors.Is(err, models.ErrContractNotFound) { t.Fatalf("err = %v", err) }
+    if _, err := svc.Readjust(ctx, owner, contract, &models.ContractReadjustRequest{Indice: "ptax_usd"}, ls); !errors.Is(err, models.ErrReadjustIndexInvalid) { t.Fatalf("err = %v", err) }
+    if _, err := svc.Readjust(ctx, owner, contract, &models.ContractReadjustRequest{Indice: "ipca", APartirDe: "2027-11", Ate: "2027-11"}, ls); !errors.Is(err, models.ErrReadjustPeriodInvalid) { t.Fatalf("err = %v", err) }
+}
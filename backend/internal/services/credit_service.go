@@ -0,0 +1,61 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Consulta e aplicação dos créditos gerados por pagamentos acima do saldo
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// CreditService lista e aplica créditos do usuário.
+// Docstring: os créditos nascem em AddPayment com overpayment "credit"; aplicar um crédito
+// registra um pagamento (método "credito") na receita de destino e reduz o saldo do crédito.
+type CreditService struct {
+	repo repositories.CreditRepository
+	log  logging.Logger
+}
+
+// NewCreditService cria o serviço de créditos
+func NewCreditService(repo repositories.CreditRepository, log logging.Logger) *CreditService {
+	return &CreditService{repo: repo, log: log}
+}
+
+// ListCredits lista os créditos do usuário; onlyAvailable restringe aos que ainda têm saldo
+func (s *CreditService) ListCredits(ctx context.Context, ownerID uuid.UUID, onlyAvailable bool) ([]models.Credit, error) {
+	items, err := s.repo.List(ctx, ownerID, onlyAvailable)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar créditos: %w", err)
+	}
+	return items, nil
+}
+
+// ApplyCredit aplica o crédito na receita informada
+func (s *CreditService) ApplyCredit(ctx context.Context, creditID, ownerID uuid.UUID, req *models.CreditApplyRequest) (*models.CreditApplyResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := s.repo.Apply(ctx, creditID, ownerID, req)
+	if err != nil {
+		for _, known := range []error{models.ErrCreditNotFound, models.ErrCreditExhausted, models.ErrCreditAmountInvalid,
+			models.ErrCreditPayerMismatch, models.ErrIncomeNotFound, models.ErrIncomeAlreadyPaid, models.ErrInsufficientAmount} {
+			if errors.Is(err, known) {
+				return nil, err
+			}
+		}
+		return nil, fmt.Errorf("erro ao aplicar crédito: %w", err)
+	}
+	s.log.Info("crédito aplicado",
+		logging.Field{Key: "credit_id", Val: creditID.String()},
+		logging.Field{Key: "income_id", Val: req.IncomeID.String()},
+		logging.Field{Key: "valor", Val: res.Application.Valor.String()})
+	return res, nil
+}
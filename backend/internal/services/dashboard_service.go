@@ -0,0 +1,62 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Série temporal do dashboard (previsto x recebido x vencido, despesas e resultado) calculada no servidor
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// DashboardService agrega receitas e pagamentos por período para os gráficos do dashboard.
+// Docstring: datas sem valor assumem os últimos 12 meses (month), 12 semanas (week) ou 30 dias
+// (day) até hoje no fuso do usuário; "hoje" também define o que está vencido.
+type DashboardService struct {
+	repo repositories.DashboardRepository
+	log  logging.Logger
+	now  func() time.Time
+}
+
+// NewDashboardService cria o serviço do dashboard
+func NewDashboardService(repo repositories.DashboardRepository, log logging.Logger) *DashboardService {
+	return &DashboardService{repo: repo, log: log, now: time.Now}
+}
+
+// Series valida o intervalo (aplicando os padrões) e consulta a série
+func (s *DashboardService) Series(ctx context.Context, ownerID uuid.UUID, q models.DashboardSeriesQuery, ls locale.Settings) (*models.DashboardSeries, error) {
+	local := s.now().In(ls.Location)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	if q.Granularity == "" {
+		q.Granularity = models.GranularityMonth
+	}
+	if q.To.IsZero() {
+		q.To = today
+	}
+	if q.From.IsZero() {
+		switch q.Granularity {
+		case models.GranularityDay:
+			q.From = q.To.AddDate(0, 0, -29)
+		case models.GranularityWeek:
+			q.From = q.To.AddDate(0, 0, -7*11)
+		default:
+			q.From = models.PeriodStart(q.To, models.GranularityMonth).AddDate(0, -11, 0)
+		}
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	points, err := s.repo.Series(ctx, ownerID, &q, today, ls.Timezone())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao calcular série do dashboard: %w", err)
+	}
+	return models.NewDashboardSeries(&q, points), nil
+}
@@ -0,0 +1,60 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da série do dashboard (padrões do intervalo e totais)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+type fakeDashboardRepo struct {
+    q        models.DashboardSeriesQuery
+    today    time.Time
+    timezone string
+    points   []models.DashboardPoint
+}
+
+func (f *fakeDashboardRepo) Series(ctx context.Context, ownerID uuid.UUID, q *models.DashboardSeriesQuery, today time.Time, timezone string) ([]models.DashboardPoint, error) {
+    f.q, f.today, f.timezone = *q, today, timezone
+    return f.points, nil
+}
+
+func TestDashboardSeries_DefaultsAndTotals(t *testing.T) {
+    oct, nov := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+    repo := &fakeDashboardRepo{points: []models.DashboardPoint{
+        {Start: oct, Expected: models.NewMoney(300), Received: models.NewMoney(200), Overdue: models.NewMoney(50), Expenses: models.NewMoney(150), ExpensesPaid: models.NewMoney(120)},
+        {Start: nov, Expected: models.NewMoney(100)},
+    }}
+    svc := NewDashboardService(repo, logging.NewLogger("dev"))
+    // 01:30 UTC de 1º/11 ainda é 31/10 em São Paulo
+    svc.now = func() time.Time { return time.Date(2026, 11, 1, 1, 30, 0, 0, time.UTC) }
+    ls := locale.Resolve("pt-BR", "America/Sao_Paulo")
+
+    out, err := svc.Series(context.Background(), uuid.New(), models.DashboardSeriesQuery{}, ls)
+    if err != nil { t.Fatal(err) }
+    if repo.q.Granularity != models.GranularityMonth || !repo.today.Equal(time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)) || repo.timezone != "America/Sao_Paulo" {
+        t.Fatalf("consulta = %+v, hoje = %v, fuso = %s", repo.q, repo.today, repo.timezone)
+    }
+    if !repo.q.From.Equal(time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)) || repo.q.Points() != 12 { t.Fatalf("início = %v", repo.q.From) }
+    if out.Points[0].Period != "2026-10" || out.Expected != models.NewMoney(400) || out.Received != models.NewMoney(200) || out.Overdue != models.NewMoney(50) {
+        t.Fatalf("série = %+v", out)
+    }
+    if out.Expenses != models.NewMoney(150) || out.ExpensesPaid != models.NewMoney(120) || out.Net != models.NewMoney(80) { t.Fatalf("resultado = %+v", out) }
+
+    if _, err := svc.Series(context.Background(), uuid.New(), models.DashboardSeriesQuery{Granularity: "hour"}, ls); !errors.Is(err, models.ErrDashboardGranularity) {
+        t.Fatalf("err = %v", err)
+    }
+    if _, err := svc.Series(context.Background(), uuid.New(), models.DashboardSeriesQuery{Granularity: models.GranularityDay}, ls); err != nil || repo.q.Points() != 30 {
+        t.Fatalf("padrão diário: %d dias, %v", repo.q.Points(), err)
+    }
+}
@@ -0,0 +1,336 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Serviço da fila de entregas com retentativas por SLA, dead-letter e desabilitação de destinos
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// DeliverySender envia uma entrega por um canal específico (e-mail, webhook, push).
+// Docstring: erros retornados contam como falha da tentativa; use ErrPermanentDelivery
+// (via errors.Is) para mandar a entrega direto para dead-letter sem novas tentativas.
+type DeliverySender interface {
+	Send(ctx context.Context, d *models.Delivery) error
+}
+
+// ErrPermanentDelivery indica falha que não se resolve com nova tentativa (ex.: e-mail inválido, 410 Gone)
+var ErrPermanentDelivery = errors.New("falha permanente de entrega")
+
+// DeliveryPolicy define a política de retentativa de um canal.
+// - MaxAttempts: total de tentativas antes do dead-letter
+// - Backoff: espera após cada falha (o último valor se repete)
+// - SLA: prazo máximo desde o enfileiramento; zero desativa
+type DeliveryPolicy struct {
+	MaxAttempts int
+	Backoff     []time.Duration
+	SLA         time.Duration
+}
+
+// DestinationFailureWindow é o tempo de falhas contínuas após o qual um destino é desabilitado
+const DestinationFailureWindow = 7 * 24 * time.Hour
+
+// Motivos de dead-letter
+const (
+	DeadReasonAttemptsExhausted  = "tentativas esgotadas"
+	DeadReasonSLAExpired         = "prazo de entrega (SLA) expirado"
+	DeadReasonDestinationOff     = "destino desabilitado"
+	DeadReasonPermanentFailure   = "falha permanente"
+	DeadReasonSenderNotAvailable = "canal sem remetente configurado"
+)
+
+// DefaultDeliveryPolicies retorna as políticas padrão por canal.
+// E-mail e push são sensíveis a prazo (lembretes); webhooks toleram atrasos maiores.
+func DefaultDeliveryPolicies() map[string]DeliveryPolicy {
+	return map[string]DeliveryPolicy{
+		models.DeliveryChannelEmail: {
+			MaxAttempts: 6,
+			Backoff:     []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour, 6 * time.Hour},
+			SLA:         24 * time.Hour,
+		},
+		models.DeliveryChannelWebhook: {
+			MaxAttempts: 10,
+			Backoff:     []time.Duration{30 * time.Second, 2 * time.Minute, 10 * time.Minute, time.Hour, 6 * time.Hour, 24 * time.Hour},
+			SLA:         72 * time.Hour,
+		},
+		models.DeliveryChannelPush: {
+			MaxAttempts: 3,
+			Backoff:     []time.Duration{time.Minute, 10 * time.Minute},
+			SLA:         6 * time.Hour,
+		},
+		models.DeliveryChannelWhatsApp: {
+			MaxAttempts: 4,
+			Backoff:     []time.Duration{time.Minute, 10 * time.Minute, time.Hour},
+			SLA:         12 * time.Hour,
+		},
+	}
+}
+
+// DeliveryService processa a fila de entregas.
+// Docstring: ProcessDue é chamado periodicamente pelo worker "deliveries" (Workers) e aplica a política do canal a cada
+// resultado: sucesso, retentativa agendada ou dead-letter. Destinos com falhas contínuas por
+// DestinationFailureWindow são desabilitados e novas entregas para eles vão direto ao dead-letter.
+type DeliveryService struct {
+	repo        repositories.DeliveryRepository
+	log         logging.Logger
+	senders     map[string]DeliverySender
+	policies    map[string]DeliveryPolicy
+	lockTimeout time.Duration
+	now         func() time.Time
+}
+
+// NewDeliveryService cria o serviço com as políticas padrão
+func NewDeliveryService(repo repositories.DeliveryRepository, log logging.Logger) *DeliveryService {
+	return &DeliveryService{
+		repo:        repo,
+		log:         log,
+		senders:     map[string]DeliverySender{},
+		policies:    DefaultDeliveryPolicies(),
+		lockTimeout: 10 * time.Minute,
+		now:         time.Now,
+	}
+}
+
+// RegisterSender associa um remetente a um canal
+func (s *DeliveryService) RegisterSender(channel string, sender DeliverySender) {
+	s.senders[channel] = sender
+}
+
+// SetPolicy sobrescreve a política de um canal
+func (s *DeliveryService) SetPolicy(channel string, p DeliveryPolicy) {
+	s.policies[channel] = p
+}
+
+func (s *DeliveryService) policy(channel string) DeliveryPolicy {
+	if p, ok := s.policies[channel]; ok && p.MaxAttempts > 0 {
+		return p
+	}
+	return DeliveryPolicy{MaxAttempts: 5, Backoff: []time.Duration{time.Minute, 10 * time.Minute, time.Hour}}
+}
+
+// Enqueue adiciona uma entrega à fila aplicando a política do canal
+func (s *DeliveryService) Enqueue(ctx context.Context, ownerID uuid.UUID, channel, destination, eventType string, payload interface{}) (*models.Delivery, error) {
+	return s.EnqueueAt(ctx, ownerID, channel, destination, eventType, payload, time.Time{})
+}
+
+// EnqueueAt adiciona uma entrega agendada para at (zero ou passado = imediata).
+// Docstring: o SLA do canal conta a partir do horário agendado, para que envios espaçados
+// por limite do provedor não expirem na fila.
+func (s *DeliveryService) EnqueueAt(ctx context.Context, ownerID uuid.UUID, channel, destination, eventType string, payload interface{}, at time.Time) (*models.Delivery, error) {
+	if !models.ValidDeliveryChannel(channel) {
+		return nil, models.ErrDeliveryChannelInvalid
+	}
+	if destination == "" {
+		return nil, models.ErrDestinationRequired
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar payload da entrega: %w", err)
+	}
+
+	if now := s.now().UTC(); at.Before(now) {
+		at = now
+	}
+	at = at.UTC()
+	p := s.policy(channel)
+	d := &models.Delivery{
+		OwnerID:       ownerID,
+		Channel:       channel,
+		Destination:   destination,
+		EventType:     eventType,
+		Payload:       raw,
+		MaxAttempts:   p.MaxAttempts,
+		NextAttemptAt: at,
+	}
+	if p.SLA > 0 {
+		deadline := at.Add(p.SLA)
+		d.SLADeadline = &deadline
+	}
+	if err := s.repo.Enqueue(ctx, d); err != nil {
+		return nil, fmt.Errorf("erro ao enfileirar entrega: %w", err)
+	}
+	return d, nil
+}
+
+// ProcessDue reserva e processa até limit entregas vencidas; retorna quantas foram processadas
+func (s *DeliveryService) ProcessDue(ctx context.Context, limit int) (int, error) {
+	items, err := s.repo.ClaimDue(ctx, s.now().UTC(), s.lockTimeout, limit)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao reservar entregas: %w", err)
+	}
+	for i := range items {
+		if err := s.process(ctx, &items[i]); err != nil {
+			s.log.Error("erro ao processar entrega",
+				logging.Field{Key: "delivery_id", Val: items[i].ID.String()},
+				logging.Field{Key: "error", Val: err.Error()})
+		}
+	}
+	return len(items), nil
+}
+
+// process executa uma tentativa e aplica o resultado
+func (s *DeliveryService) process(ctx context.Context, d *models.Delivery) error {
+	now := s.now().UTC()
+
+	dest, err := s.repo.GetDestination(ctx, d.OwnerID, d.Channel, d.Destination)
+	if err != nil && !errors.Is(err, models.ErrDestinationNotFound) {
+		return err
+	}
+	if dest != nil && dest.DisabledAt != nil {
+		return s.repo.MarkDead(ctx, d.ID, d.Attempts, DeadReasonDestinationOff, "", now)
+	}
+	if d.SLADeadline != nil && now.After(*d.SLADeadline) {
+		return s.repo.MarkDead(ctx, d.ID, d.Attempts, DeadReasonSLAExpired, "", now)
+	}
+
+	sender, ok := s.senders[d.Channel]
+	if !ok {
+		return s.repo.MarkDead(ctx, d.ID, d.Attempts, DeadReasonSenderNotAvailable, "", now)
+	}
+
+	attempts := d.Attempts + 1
+	sendErr := sender.Send(ctx, d)
+	if sendErr == nil {
+		if err := s.repo.MarkDelivered(ctx, d.ID, attempts, now); err != nil {
+			return err
+		}
+		return s.repo.RecordSuccess(ctx, d.OwnerID, d.Channel, d.Destination, now)
+	}
+
+	msg := sendErr.Error()
+	updated, err := s.repo.RecordFailure(ctx, d.OwnerID, d.Channel, d.Destination, msg, now)
+	if err != nil {
+		return err
+	}
+	if updated != nil && updated.FirstFailureAt != nil && now.Sub(*updated.FirstFailureAt) >= DestinationFailureWindow {
+		reason := fmt.Sprintf("falhas contínuas desde %s", updated.FirstFailureAt.UTC().Format(time.RFC3339))
+		if err := s.repo.SetDestinationDisabled(ctx, d.OwnerID, d.Channel, d.Destination, true, reason, now); err != nil {
+			return err
+		}
+		s.log.Warn("destino de entrega desabilitado automaticamente",
+			logging.Field{Key: "channel", Val: d.Channel},
+			logging.Field{Key: "destination", Val: d.Destination})
+		return s.repo.MarkDead(ctx, d.ID, attempts, DeadReasonDestinationOff, msg, now)
+	}
+
+	if errors.Is(sendErr, ErrPermanentDelivery) {
+		return s.repo.MarkDead(ctx, d.ID, attempts, DeadReasonPermanentFailure, msg, now)
+	}
+	if attempts >= d.MaxAttempts {
+		return s.repo.MarkDead(ctx, d.ID, attempts, DeadReasonAttemptsExhausted, msg, now)
+	}
+
+	next := now.Add(s.backoff(d.Channel, attempts))
+	if d.SLADeadline != nil && next.After(*d.SLADeadline) {
+		// Última chance dentro do SLA em vez de agendar além do prazo
+		next = *d.SLADeadline
+	}
+	return s.repo.MarkRetry(ctx, d.ID, attempts, next, msg)
+}
+
+// backoff retorna a espera após a tentativa de número attempts (1-based)
+func (s *DeliveryService) backoff(channel string, attempts int) time.Duration {
+	steps := s.policy(channel).Backoff
+	if len(steps) == 0 {
+		return time.Minute
+	}
+	if attempts-1 < len(steps) {
+		return steps[attempts-1]
+	}
+	return steps[len(steps)-1]
+}
+
+// QueueDepths profundidade da fila de entregas por canal (fonte do painel de jobs)
+func (s *DeliveryService) QueueDepths(ctx context.Context) ([]models.QueueDepth, error) {
+	items, err := s.repo.QueueDepths(ctx, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar fila de entregas: %w", err)
+	}
+	return items, nil
+}
+
+// ListDeadLetters lista entregas em dead-letter
+func (s *DeliveryService) ListDeadLetters(ctx context.Context, filter *models.DeliveryFilter) (*models.DeliveryListResponse, error) {
+	items, total, err := s.repo.ListDead(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar dead-letters: %w", err)
+	}
+	if items == nil {
+		items = []models.Delivery{}
+	}
+	return &models.DeliveryListResponse{
+		Items:      items,
+		Total:      total,
+		Page:       filter.Page,
+		PerPage:    filter.PerPage,
+		TotalPages: (total + filter.PerPage - 1) / filter.PerPage,
+	}, nil
+}
+
+// ListDeliveries lista entregas de qualquer status (log de entregas dos webhooks)
+func (s *DeliveryService) ListDeliveries(ctx context.Context, filter *models.DeliveryFilter) (*models.DeliveryListResponse, error) {
+	items, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar entregas: %w", err)
+	}
+	if items == nil {
+		items = []models.Delivery{}
+	}
+	return &models.DeliveryListResponse{
+		Items:      items,
+		Total:      total,
+		Page:       filter.Page,
+		PerPage:    filter.PerPage,
+		TotalPages: (total + filter.PerPage - 1) / filter.PerPage,
+	}, nil
+}
+
+// Requeue devolve uma entrega do dead-letter à fila.
+// Com reenable=true, reabilita o destino caso tenha sido desabilitado automaticamente.
+func (s *DeliveryService) Requeue(ctx context.Context, id uuid.UUID, reenable bool) (*models.Delivery, error) {
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != models.DeliveryStatusDead {
+		return nil, models.ErrDeliveryNotDead
+	}
+	if reenable {
+		err := s.repo.SetDestinationDisabled(ctx, current.OwnerID, current.Channel, current.Destination, false, "", s.now().UTC())
+		if err != nil && !errors.Is(err, models.ErrDestinationNotFound) {
+			return nil, fmt.Errorf("erro ao reabilitar destino: %w", err)
+		}
+	}
+	return s.repo.Requeue(ctx, id, s.now().UTC())
+}
+
+// DestinationStats lista a saúde por destino
+func (s *DeliveryService) DestinationStats(ctx context.Context, ownerID *uuid.UUID, onlyDisabled bool) ([]models.DeliveryDestination, error) {
+	items, err := s.repo.ListDestinations(ctx, ownerID, onlyDisabled)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar destinos: %w", err)
+	}
+	if items == nil {
+		items = []models.DeliveryDestination{}
+	}
+	return items, nil
+}
+
+// EnableDestination reabilita manualmente um destino desabilitado
+func (s *DeliveryService) EnableDestination(ctx context.Context, ownerID uuid.UUID, channel, destination string) error {
+	if !models.ValidDeliveryChannel(channel) {
+		return models.ErrDeliveryChannelInvalid
+	}
+	return s.repo.SetDestinationDisabled(ctx, ownerID, channel, destination, false, "", s.now().UTC())
+}
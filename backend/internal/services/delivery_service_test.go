@@ -0,0 +1,220 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes unitários do DeliveryService (retentativas, dead-letter e desabilitação de destino)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeDeliveryRepo implementa repositories.DeliveryRepository em memória
+type fakeDeliveryRepo struct {
+    due   []models.Delivery
+    dest  *models.DeliveryDestination
+    byID  map[uuid.UUID]*models.Delivery
+
+    delivered  []uuid.UUID
+    retried    map[uuid.UUID]time.Time
+    dead       map[uuid.UUID]string
+    disabled   bool
+    reenabled  bool
+    successes  int
+}
+
+func newFakeDeliveryRepo() *fakeDeliveryRepo {
+    return &fakeDeliveryRepo{byID: map[uuid.UUID]*models.Delivery{}, retried: map[uuid.UUID]time.Time{}, dead: map[uuid.UUID]string{}}
+}
+
+func (f *fakeDeliveryRepo) Enqueue(ctx context.Context, d *models.Delivery) error {
+    if d.ID == uuid.Nil { d.ID = uuid.New() }
+    d.Status = models.DeliveryStatusPending
+    f.byID[d.ID] = d
+    return nil
+}
+func (f *fakeDeliveryRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Delivery, error) {
+    if d, ok := f.byID[id]; ok { return d, nil }
+    return nil, models.ErrDeliveryNotFound
+}
+func (f *fakeDeliveryRepo) ClaimDue(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.Delivery, error) {
+    return f.due, nil
+}
+func (f *fakeDeliveryRepo) MarkDelivered(ctx context.Context, id uuid.UUID, attempts int, at time.Time) error {
+    f.delivered = append(f.delivered, id); return nil
+}
+func (f *fakeDeliveryRepo) MarkRetry(ctx context.Context, id uuid.UUID, attempts int, next time.Time, lastErr string) error {
+    f.retried[id] = next; return nil
+}
+func (f *fakeDeliveryRepo) MarkDead(ctx context.Context, id uuid.UUID, attempts int, reason, lastErr string, at time.Time) error {
+    f.dead[id] = reason; return nil
+}
+func (f *fakeDeliveryRepo) ListDead(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, int, error) {
+    filter.SetDefaults()
+    return nil, 0, nil
+}
+func (f *fakeDeliveryRepo) List(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, int, error) {
+    filter.SetDefaults()
+    var items []models.Delivery
+    for _, d := range f.byID {
+        if filter.Destination != "" && d.Destination != filter.Destination { continue }
+        if filter.Status != "" && d.Status != filter.Status { continue }
+        items = append(items, *d)
+    }
+    return items, len(items), nil
+}
+func (f *fakeDeliveryRepo) Requeue(ctx context.Context, id uuid.UUID, at time.Time) (*models.Delivery, error) {
+    d := f.byID[id]
+    d.Status = models.DeliveryStatusPending
+    d.Attempts = 0
+    return d, nil
+}
+func (f *fakeDeliveryRepo) GetDestination(ctx context.Context, ownerID uuid.UUID, channel, destination string) (*models.DeliveryDestination, error) {
+    if f.dest == nil { return nil, models.ErrDestinationNotFound }
+    return f.dest, nil
+}
+func (f *fakeDeliveryRepo) RecordSuccess(ctx context.Context, ownerID uuid.UUID, channel, destination string, at time.Time) error {
+    f.successes++; return nil
+}
+func (f *fakeDeliveryRepo) RecordFailure(ctx context.Context, ownerID uuid.UUID, channel, destination, lastErr string, at time.Time) (*models.DeliveryDestination, error) {
+    if f.dest == nil {
+        f.dest = &models.DeliveryDestination{OwnerID: ownerID, Channel: channel, Destination: destination, FirstFailureAt: &at}
+    }
+    f.dest.ConsecutiveFailures++
+    return f.dest, nil
+}
+func (f *fakeDeliveryRepo) SetDestinationDisabled(ctx context.Context, ownerID uuid.UUID, channel, destination string, disabled bool, reason string, at time.Time) error {
+    if disabled { f.disabled = true } else { f.reenabled = true }
+    return nil
+}
+func (f *fakeDeliveryRepo) ListDestinations(ctx context.Context, ownerID *uuid.UUID, onlyDisabled bool) ([]models.DeliveryDestination, error) {
+    return nil, nil
+}
+func (f *fakeDeliveryRepo) QueueDepths(ctx context.Context, now time.Time) ([]models.QueueDepth, error) {
+    return nil, nil
+}
+
+type fakeSender struct{ err error; calls int }
+
+func (s *fakeSender) Send(ctx context.Context, d *models.Delivery) error { s.calls++; return s.err }
+
+func newDeliveryServiceForTest(repo *fakeDeliveryRepo, now time.Time) *DeliveryService {
+    svc := NewDeliveryService(repo, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+    return svc
+}
+
+func TestProcessDue_SuccessRecordsDestination(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    repo := newFakeDeliveryRepo()
+    d := models.Delivery{ID: uuid.New(), Channel: models.DeliveryChannelWebhook, Destination: "https://example.com/hook", MaxAttempts: 3}
+    repo.due = []models.Delivery{d}
+
+    svc := newDeliveryServiceForTest(repo, now)
+    sender := &fakeSender{}
+    svc.RegisterSender(models.DeliveryChannelWebhook, sender)
+
+    n, err := svc.ProcessDue(context.Background(), 10)
+    if err != nil { t.Fatalf("ProcessDue err: %v", err) }
+    if n != 1 || sender.calls != 1 { t.Fatalf("processadas=%d chamadas=%d, want 1/1", n, sender.calls) }
+    if len(repo.delivered) != 1 || repo.successes != 1 { t.Fatalf("esperava entrega marcada como concluída e sucesso registrado") }
+}
+
+func TestProcessDue_FailureSchedulesBackoff(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    repo := newFakeDeliveryRepo()
+    d := models.Delivery{ID: uuid.New(), Channel: models.DeliveryChannelWebhook, Destination: "https://example.com/hook", MaxAttempts: 3}
+    repo.due = []models.Delivery{d}
+
+    svc := newDeliveryServiceForTest(repo, now)
+    svc.RegisterSender(models.DeliveryChannelWebhook, &fakeSender{err: errors.New("timeout")})
+
+    if _, err := svc.ProcessDue(context.Background(), 10); err != nil { t.Fatalf("ProcessDue err: %v", err) }
+    next, ok := repo.retried[d.ID]
+    if !ok { t.Fatalf("esperava retentativa agendada") }
+    want := now.Add(DefaultDeliveryPolicies()[models.DeliveryChannelWebhook].Backoff[0])
+    if !next.Equal(want) { t.Fatalf("next = %v, want %v", next, want) }
+}
+
+func TestProcessDue_ExhaustedGoesToDeadLetter(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    repo := newFakeDeliveryRepo()
+    d := models.Delivery{ID: uuid.New(), Channel: models.DeliveryChannelEmail, Destination: "a@b.com", Attempts: 2, MaxAttempts: 3}
+    repo.due = []models.Delivery{d}
+
+    svc := newDeliveryServiceForTest(repo, now)
+    svc.RegisterSender(models.DeliveryChannelEmail, &fakeSender{err: errors.New("smtp 451")})
+
+    if _, err := svc.ProcessDue(context.Background(), 10); err != nil { t.Fatalf("ProcessDue err: %v", err) }
+    if repo.dead[d.ID] != DeadReasonAttemptsExhausted { t.Fatalf("motivo = %q, want %q", repo.dead[d.ID], DeadReasonAttemptsExhausted) }
+}
+
+func TestProcessDue_SLAExpiredSkipsSend(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    deadline := now.Add(-time.Minute)
+    repo := newFakeDeliveryRepo()
+    d := models.Delivery{ID: uuid.New(), Channel: models.DeliveryChannelPush, Destination: "sub-1", MaxAttempts: 3, SLADeadline: &deadline}
+    repo.due = []models.Delivery{d}
+
+    svc := newDeliveryServiceForTest(repo, now)
+    sender := &fakeSender{}
+    svc.RegisterSender(models.DeliveryChannelPush, sender)
+
+    if _, err := svc.ProcessDue(context.Background(), 10); err != nil { t.Fatalf("ProcessDue err: %v", err) }
+    if sender.calls != 0 { t.Fatalf("não deveria enviar após o SLA") }
+    if repo.dead[d.ID] != DeadReasonSLAExpired { t.Fatalf("motivo = %q, want %q", repo.dead[d.ID], DeadReasonSLAExpired) }
+}
+
+func TestProcessDue_DisablesDestinationAfterSevenDays(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    firstFailure := now.Add(-DestinationFailureWindow - time.Hour)
+    repo := newFakeDeliveryRepo()
+    repo.dest = &models.DeliveryDestination{Channel: models.DeliveryChannelWebhook, Destination: "https://down.example.com", FirstFailureAt: &firstFailure, ConsecutiveFailures: 40}
+    d := models.Delivery{ID: uuid.New(), Channel: models.DeliveryChannelWebhook, Destination: "https://down.example.com", MaxAttempts: 10}
+    repo.due = []models.Delivery{d}
+
+    svc := newDeliveryServiceForTest(repo, now)
+    svc.RegisterSender(models.DeliveryChannelWebhook, &fakeSender{err: errors.New("503")})
+
+    if _, err := svc.ProcessDue(context.Background(), 10); err != nil { t.Fatalf("ProcessDue err: %v", err) }
+    if !repo.disabled { t.Fatalf("esperava destino desabilitado") }
+    if repo.dead[d.ID] != DeadReasonDestinationOff { t.Fatalf("motivo = %q, want %q", repo.dead[d.ID], DeadReasonDestinationOff) }
+}
+
+func TestRequeue_RejectsNonDead(t *testing.T) {
+    repo := newFakeDeliveryRepo()
+    d := &models.Delivery{ID: uuid.New(), Status: models.DeliveryStatusPending}
+    repo.byID[d.ID] = d
+
+    svc := newDeliveryServiceForTest(repo, time.Now())
+    if _, err := svc.Requeue(context.Background(), d.ID, false); !errors.Is(err, models.ErrDeliveryNotDead) {
+        t.Fatalf("err = %v, want ErrDeliveryNotDead", err)
+    }
+
+    d.Status = models.DeliveryStatusDead
+    out, err := svc.Requeue(context.Background(), d.ID, true)
+    if err != nil { t.Fatalf("Requeue err: %v", err) }
+    if out.Status != models.DeliveryStatusPending || !repo.reenabled { t.Fatalf("esperava entrega pendente e destino reabilitado") }
+}
+
+func TestEnqueueAt_SLACountsFromSchedule(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    svc := newDeliveryServiceForTest(newFakeDeliveryRepo(), now)
+    at := now.Add(2 * time.Hour)
+
+    d, err := svc.EnqueueAt(context.Background(), uuid.New(), models.DeliveryChannelWhatsApp, "+5511999990000", models.EventBroadcast, map[string]string{}, at)
+    if err != nil { t.Fatalf("EnqueueAt err: %v", err) }
+    if !d.NextAttemptAt.Equal(at) || d.SLADeadline == nil || !d.SLADeadline.Equal(at.Add(12*time.Hour)) {
+        t.Fatalf("agendada para %v com SLA %v", d.NextAttemptAt, d.SLADeadline)
+    }
+
+    d, _ = svc.EnqueueAt(context.Background(), uuid.New(), models.DeliveryChannelWhatsApp, "+5511999990000", models.EventBroadcast, map[string]string{}, now.Add(-time.Hour))
+    if !d.NextAttemptAt.Equal(now) { t.Fatalf("horário passado deve virar imediato; got %v", d.NextAttemptAt) }
+}
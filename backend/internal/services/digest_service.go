@@ -0,0 +1,161 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Job do resumo semanal (digest) e preferências de notificação do usuário
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// DeliveryEnqueuer enfileira entregas na fila assíncrona (implementado por DeliveryService)
+type DeliveryEnqueuer interface {
+	Enqueue(ctx context.Context, ownerID uuid.UUID, channel, destination, eventType string, payload interface{}) (*models.Delivery, error)
+}
+
+// DigestPeriod é o intervalo coberto pelo resumo semanal
+const DigestPeriod = 7 * 24 * time.Hour
+
+// DigestService compõe e enfileira o resumo semanal de cada usuário.
+// Docstring: SendDue é chamado periodicamente (Run); no dia configurado pelo usuário, compõe o
+// resumo dos últimos 7 dias e enfileira uma entrega por canal habilitado. Resumos sem atividade
+// não são enviados, mas contam como enviados para não serem recalculados a cada execução.
+type DigestService struct {
+	repo       repositories.NotificationRepository
+	deliveries DeliveryEnqueuer
+	log        logging.Logger
+	now        func() time.Time
+}
+
+// NewDigestService cria o serviço do resumo semanal
+func NewDigestService(repo repositories.NotificationRepository, deliveries DeliveryEnqueuer, log logging.Logger) *DigestService {
+	return &DigestService{repo: repo, deliveries: deliveries, log: log, now: time.Now}
+}
+
+// SendDue enfileira os resumos devidos no momento atual; retorna quantos usuários receberam
+func (s *DigestService) SendDue(ctx context.Context) (int, error) {
+	now := s.now().UTC()
+	recipients, err := s.repo.ListDigestRecipients(ctx, int(now.Weekday()), now.Add(-DigestPeriod+time.Hour))
+	if err != nil {
+		return 0, fmt.Errorf("erro ao listar destinatários do resumo: %w", err)
+	}
+	sent := 0
+	for _, rc := range recipients {
+		ok, err := s.sendTo(ctx, rc, now)
+		if err != nil {
+			s.log.Error("erro ao enviar resumo semanal",
+				logging.Field{Key: "owner_id", Val: rc.OwnerID.String()},
+				logging.Field{Key: "error", Val: err.Error()})
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+func (s *DigestService) sendTo(ctx context.Context, rc models.DigestRecipient, now time.Time) (bool, error) {
+	settings, err := s.repo.GetSettings(ctx, rc.OwnerID)
+	if err != nil {
+		return false, err
+	}
+	summary, err := s.repo.DigestSummary(ctx, rc.OwnerID, now.Add(-DigestPeriod), now)
+	if err != nil {
+		return false, err
+	}
+
+	enqueued := false
+	if !summary.Empty() {
+		ls := locale.Resolve(derefString(rc.Locale), derefString(rc.Timezone))
+		payload := models.DigestPayload{
+			Summary:          *summary,
+			UnsubscribeToken: settings.UnsubscribeToken,
+			Locale:           ls.Locale,
+			Timezone:         ls.Timezone(),
+			Formatted: models.DigestFormatted{
+				Received:    ls.FormatAmount(summary.Received),
+				Overdue:     ls.FormatAmount(summary.Overdue),
+				PeriodStart: ls.FormatDate(summary.PeriodStart),
+				PeriodEnd:   ls.FormatDate(summary.PeriodEnd),
+			},
+		}
+		for _, ch := range settings.DigestChannels {
+			for _, dest := range notificationDestinations(ch, settings, rc.AccountEmail) {
+				if _, err := s.deliveries.Enqueue(ctx, rc.OwnerID, ch, dest, models.EventDigestWeekly, payload); err != nil {
+					return false, err
+				}
+				enqueued = true
+			}
+		}
+	}
+	if err := s.repo.MarkDigestSent(ctx, rc.OwnerID, now); err != nil {
+		return enqueued, err
+	}
+	return enqueued, nil
+}
+
+// Run verifica os resumos devidos a cada interval até o contexto ser cancelado
+func (s *DigestService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.SendDue(ctx); err != nil {
+				s.log.Error("erro no job do resumo semanal", logging.Field{Key: "error", Val: err.Error()})
+			}
+		}
+	}
+}
+
+// Preview compõe o resumo dos últimos 7 dias sem enfileirar
+func (s *DigestService) Preview(ctx context.Context, ownerID uuid.UUID) (*models.DigestSummary, error) {
+	now := s.now().UTC()
+	return s.repo.DigestSummary(ctx, ownerID, now.Add(-DigestPeriod), now)
+}
+
+// GetPreferences retorna as preferências de notificação do usuário
+func (s *DigestService) GetPreferences(ctx context.Context, ownerID uuid.UUID) (*models.NotificationSettings, error) {
+	return s.repo.GetSettings(ctx, ownerID)
+}
+
+// UpdatePreferences aplica a atualização parcial das preferências do resumo
+func (s *DigestService) UpdatePreferences(ctx context.Context, ownerID uuid.UUID, req *models.DigestPreferencesRequest) (*models.NotificationSettings, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	settings, err := s.repo.GetSettings(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(settings)
+	if err := s.repo.UpdateSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("erro ao salvar preferências: %w", err)
+	}
+	return settings, nil
+}
+
+// Unsubscribe desabilita o resumo a partir do token do link enviado
+func (s *DigestService) Unsubscribe(ctx context.Context, token uuid.UUID) error {
+	return s.repo.UnsubscribeDigest(ctx, token)
+}
+
+// derefString retorna o valor do ponteiro ou "" quando nulo
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,133 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes unitários do DigestService (composição e enfileiramento do resumo semanal)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeNotificationRepo implementa repositories.NotificationRepository em memória
+type fakeNotificationRepo struct {
+    recipients []models.DigestRecipient
+    settings   map[uuid.UUID]*models.NotificationSettings
+    summary    models.DigestSummary
+    sent       map[uuid.UUID]time.Time
+    weekday    int
+    reminders  []models.DueReminder
+}
+
+func newFakeNotificationRepo() *fakeNotificationRepo {
+    return &fakeNotificationRepo{settings: map[uuid.UUID]*models.NotificationSettings{}, sent: map[uuid.UUID]time.Time{}}
+}
+
+func (f *fakeNotificationRepo) GetSettings(ctx context.Context, ownerID uuid.UUID) (*models.NotificationSettings, error) {
+    if s, ok := f.settings[ownerID]; ok { return s, nil }
+    s := &models.NotificationSettings{OwnerID: ownerID, DigestEnabled: true, DigestChannels: []string{"email"}, DigestWeekday: 1, UnsubscribeToken: uuid.New()}
+    f.settings[ownerID] = s
+    return s, nil
+}
+func (f *fakeNotificationRepo) UpdateSettings(ctx context.Context, s *models.NotificationSettings) error {
+    f.settings[s.OwnerID] = s; return nil
+}
+func (f *fakeNotificationRepo) UnsubscribeDigest(ctx context.Context, token uuid.UUID) error {
+    for _, s := range f.settings {
+        if s.UnsubscribeToken == token { s.DigestEnabled = false; return nil }
+    }
+    return models.ErrInvalidUnsubscribeToken
+}
+func (f *fakeNotificationRepo) ListDigestRecipients(ctx context.Context, weekday int, sentBefore time.Time) ([]models.DigestRecipient, error) {
+    f.weekday = weekday
+    return f.recipients, nil
+}
+func (f *fakeNotificationRepo) DigestSummary(ctx context.Context, ownerID uuid.UUID, from, to time.Time) (*models.DigestSummary, error) {
+    s := f.summary
+    s.PeriodStart, s.PeriodEnd = from, to
+    return &s, nil
+}
+func (f *fakeNotificationRepo) MarkDigestSent(ctx context.Context, ownerID uuid.UUID, at time.Time) error {
+    f.sent[ownerID] = at; return nil
+}
+func (f *fakeNotificationRepo) ClaimDueReminders(ctx context.Context, now time.Time, leadDays int, defaultTimezone string) ([]models.DueReminder, error) {
+    out := f.reminders
+    f.reminders = nil
+    return out, nil
+}
+
+type enqueued struct{ channel, destination, event string }
+
+type fakeEnqueuer struct{ items []enqueued }
+
+func (e *fakeEnqueuer) Enqueue(ctx context.Context, ownerID uuid.UUID, channel, destination, eventType string, payload interface{}) (*models.Delivery, error) {
+    e.items = append(e.items, enqueued{channel, destination, eventType})
+    return &models.Delivery{ID: uuid.New()}, nil
+}
+
+func newDigestServiceForTest(repo *fakeNotificationRepo, q *fakeEnqueuer, now time.Time) *DigestService {
+    svc := NewDigestService(repo, q, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+    return svc
+}
+
+func TestDigestSendDue_EnqueuesPerChannel(t *testing.T) {
+    now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC) // segunda-feira
+    owner := uuid.New()
+    accountEmail := "conta@example.com"
+    push := "https://push.example.com/sub-123"
+
+    repo := newFakeNotificationRepo()
+    repo.recipients = []models.DigestRecipient{{OwnerID: owner, AccountEmail: &accountEmail}}
+    repo.settings[owner] = &models.NotificationSettings{OwnerID: owner, DigestEnabled: true, DigestChannels: []string{"email", "push"}, PushEndpoints: []string{push}, UnsubscribeToken: uuid.New()}
+    repo.summary = models.DigestSummary{Received: models.NewMoney(1500), ReceivedCount: 1}
+    q := &fakeEnqueuer{}
+
+    n, err := newDigestServiceForTest(repo, q, now).SendDue(context.Background())
+    if err != nil { t.Fatalf("SendDue err: %v", err) }
+    if repo.weekday != int(time.Monday) { t.Fatalf("weekday = %d, want segunda-feira", repo.weekday) }
+    if n != 1 || len(q.items) != 2 { t.Fatalf("enviados=%d entregas=%d, want 1/2", n, len(q.items)) }
+    if q.items[0].destination != accountEmail || q.items[0].event != models.EventDigestWeekly {
+        t.Fatalf("esperava e-mail da conta como fallback, got %+v", q.items[0])
+    }
+    if q.items[1].channel != models.DeliveryChannelPush || q.items[1].destination != push {
+        t.Fatalf("entrega push inesperada: %+v", q.items[1])
+    }
+    if _, ok := repo.sent[owner]; !ok { t.Fatalf("esperava resumo marcado como enviado") }
+}
+
+func TestDigestSendDue_SkipsEmptySummary(t *testing.T) {
+    now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+    owner := uuid.New()
+    email := "conta@example.com"
+
+    repo := newFakeNotificationRepo()
+    repo.recipients = []models.DigestRecipient{{OwnerID: owner, AccountEmail: &email}}
+    q := &fakeEnqueuer{}
+
+    n, err := newDigestServiceForTest(repo, q, now).SendDue(context.Background())
+    if err != nil { t.Fatalf("SendDue err: %v", err) }
+    if n != 0 || len(q.items) != 0 { t.Fatalf("resumo vazio não deveria ser enviado") }
+    if _, ok := repo.sent[owner]; !ok { t.Fatalf("resumo vazio deveria contar como processado na semana") }
+}
+
+func TestDigestUpdatePreferences_RejectsInvalidChannel(t *testing.T) {
+    repo := newFakeNotificationRepo()
+    svc := newDigestServiceForTest(repo, &fakeEnqueuer{}, time.Now())
+
+    _, err := svc.UpdatePreferences(context.Background(), uuid.New(), &models.DigestPreferencesRequest{Channels: []string{"sms"}})
+    if err != models.ErrInvalidDigestChannel { t.Fatalf("err = %v, want ErrInvalidDigestChannel", err) }
+
+    off := false
+    out, err := svc.UpdatePreferences(context.Background(), uuid.New(), &models.DigestPreferencesRequest{Enabled: &off, Channels: []string{" Push ", "push"}})
+    if err != nil { t.Fatalf("UpdatePreferences err: %v", err) }
+    if out.DigestEnabled || len(out.DigestChannels) != 1 || out.DigestChannels[0] != "push" {
+        t.Fatalf("preferências inesperadas: %+v", out)
+    }
+}
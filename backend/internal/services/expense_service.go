@@ -0,0 +1,236 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Despesas do usuário (CRUD, estatísticas por competência e anexos de documentos fiscais)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// ExpenseStorage envio e remoção dos anexos no Storage (implementado por storage.Client)
+type ExpenseStorage interface {
+	UploadStream(ctx context.Context, bucket, objectPath string, body io.Reader, contentType string) (size int64, sha256hex string, err error)
+	DeleteObject(ctx context.Context, bucket, objectPath string) error
+}
+
+// ExpenseAttachmentUpload arquivo recebido para anexar à despesa
+type ExpenseAttachmentUpload struct {
+	Tipo        string
+	FileName    string
+	ContentType string
+	Body        io.Reader
+}
+
+// ExpenseService gerencia as despesas, contrapartida das receitas no resultado.
+// Docstring: "vencido" não é gravado: nas leituras a despesa pendente com vencimento antes de hoje
+// (no fuso do usuário) é exibida como vencida. Os anexos vão para o bucket de recibos em
+// "{owner_id}/expenses/{expense_id}/{id}.{ext}"; se o registro não for gravado o arquivo é
+// removido, e a coleta do Storage só mantém arquivos referenciados.
+type ExpenseService struct {
+	repo   repositories.ExpenseRepository
+	store  ExpenseStorage
+	bucket string
+	log    logging.Logger
+	now    func() time.Time
+}
+
+// NewExpenseService cria o serviço de despesas com os anexos no bucket informado
+func NewExpenseService(repo repositories.ExpenseRepository, store ExpenseStorage, bucket string, log logging.Logger) *ExpenseService {
+	return &ExpenseService{repo: repo, store: store, bucket: bucket, log: log, now: time.Now}
+}
+
+// today data local do usuário à meia-noite UTC, como due_date
+func (s *ExpenseService) today(ls locale.Settings) time.Time {
+	local := s.now().In(ls.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Create valida e grava uma nova despesa
+func (s *ExpenseService) Create(ctx context.Context, ownerID uuid.UUID, req *models.ExpenseRequest, ls locale.Settings) (*models.Expense, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	e := &models.Expense{OwnerID: ownerID}
+	req.Apply(e, s.now().UTC())
+	if err := s.repo.Create(ctx, e); err != nil {
+		return nil, fmt.Errorf("erro ao criar despesa: %w", err)
+	}
+	e.Status = e.DisplayStatus(s.today(ls))
+	return e, nil
+}
+
+// Get busca a despesa do usuário
+func (s *ExpenseService) Get(ctx context.Context, id, ownerID uuid.UUID, ls locale.Settings) (*models.Expense, error) {
+	e, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, wrapExpenseError("erro ao buscar despesa", err)
+	}
+	e.Status = e.DisplayStatus(s.today(ls))
+	return e, nil
+}
+
+// Update substitui os campos da despesa; a data de pagamento é mantida se continuar paga sem pago_em
+func (s *ExpenseService) Update(ctx context.Context, id, ownerID uuid.UUID, req *models.ExpenseRequest, ls locale.Settings) (*models.Expense, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	e, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, wrapExpenseError("erro ao buscar despesa", err)
+	}
+	req.Apply(e, s.now().UTC())
+	if err := s.repo.Update(ctx, e); err != nil {
+		return nil, wrapExpenseError("erro ao atualizar despesa", err)
+	}
+	e.Status = e.DisplayStatus(s.today(ls))
+	return e, nil
+}
+
+// Delete exclui a despesa (exclusão lógica)
+func (s *ExpenseService) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id, ownerID); err != nil {
+		return wrapExpenseError("erro ao excluir despesa", err)
+	}
+	return nil
+}
+
+// List lista as despesas com filtros; status aceita pendente, pago, cancelado ou vencido
+func (s *ExpenseService) List(ctx context.Context, ownerID uuid.UUID, filter *models.ExpenseFilter, ls locale.Settings) (*models.ExpenseResponse, error) {
+	switch filter.Status {
+	case "", models.StatusPendente, models.StatusPago, models.StatusCancelado, models.StatusVencido:
+	default:
+		return nil, models.ErrInvalidStatus
+	}
+	filter.Today = s.today(ls)
+	items, total, err := s.repo.List(ctx, ownerID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar despesas: %w", err)
+	}
+	for k := range items {
+		items[k].Status = items[k].DisplayStatus(filter.Today)
+	}
+	return &models.ExpenseResponse{
+		Expenses:   items,
+		Total:      total,
+		Page:       filter.Page,
+		PerPage:    filter.PerPage,
+		TotalPages: (total + filter.PerPage - 1) / filter.PerPage,
+	}, nil
+}
+
+// Stats soma as despesas da competência (vazia = mês corrente no fuso do usuário)
+func (s *ExpenseService) Stats(ctx context.Context, ownerID uuid.UUID, competencia string, ls locale.Settings) (*models.ExpenseStats, error) {
+	today := s.today(ls)
+	month := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if competencia != "" {
+		var err error
+		if month, err = models.ParseReportCompetencia(competencia); err != nil {
+			return nil, err
+		}
+	}
+	st, err := s.repo.Stats(ctx, ownerID, month, today)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao calcular estatísticas de despesas: %w", err)
+	}
+	return st, nil
+}
+
+// AddAttachment envia o documento ao Storage e o registra na despesa.
+// Docstring: o tipo MIME precisa ser PDF, XML, JPEG ou PNG; arquivo vazio ou acima de
+// models.MaxExpenseAttachmentSize é removido do Storage e recusado.
+func (s *ExpenseService) AddAttachment(ctx context.Context, ownerID, expenseID uuid.UUID, up *ExpenseAttachmentUpload) (*models.ExpenseAttachment, error) {
+	tipo, err := models.ValidExpenseDocType(up.Tipo)
+	if err != nil {
+		return nil, err
+	}
+	contentType, ext, err := models.ExpenseAttachmentType(up.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	// Confere a despesa antes de receber o arquivo, para não deixar objetos órfãos
+	if _, err := s.repo.GetByID(ctx, expenseID, ownerID); err != nil {
+		return nil, wrapExpenseError("erro ao buscar despesa", err)
+	}
+
+	a := &models.ExpenseAttachment{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		ExpenseID:   expenseID,
+		Tipo:        tipo,
+		FileName:    models.CleanAttachmentName(up.FileName, ext),
+		ContentType: contentType,
+	}
+	a.FilePath = fmt.Sprintf("%s/expenses/%s/%s.%s", ownerID, expenseID, a.ID, ext)
+	size, sha256hex, err := s.store.UploadStream(ctx, s.bucket, a.FilePath, io.LimitReader(up.Body, models.MaxExpenseAttachmentSize+1), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao enviar anexo ao Storage: %w", err)
+	}
+	switch {
+	case size == 0:
+		s.discard(ctx, a.FilePath)
+		return nil, models.ErrExpenseAttachmentEmpty
+	case size > models.MaxExpenseAttachmentSize:
+		s.discard(ctx, a.FilePath)
+		return nil, models.ErrExpenseAttachmentTooLarge
+	}
+	a.SizeBytes, a.SHA256 = size, sha256hex
+	if err := s.repo.AddAttachment(ctx, a); err != nil {
+		// Compensação: nenhum registro aponta para o objeto enviado
+		s.discard(ctx, a.FilePath)
+		return nil, fmt.Errorf("erro ao gravar anexo: %w", err)
+	}
+	return a, nil
+}
+
+// ListAttachments lista os anexos da despesa
+func (s *ExpenseService) ListAttachments(ctx context.Context, ownerID, expenseID uuid.UUID) ([]models.ExpenseAttachment, error) {
+	if _, err := s.repo.GetByID(ctx, expenseID, ownerID); err != nil {
+		return nil, wrapExpenseError("erro ao buscar despesa", err)
+	}
+	items, err := s.repo.ListAttachments(ctx, expenseID, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar anexos: %w", err)
+	}
+	return items, nil
+}
+
+// DeleteAttachment remove o anexo e o arquivo; falha ao apagar o arquivo fica para a coleta do Storage
+func (s *ExpenseService) DeleteAttachment(ctx context.Context, ownerID, expenseID, id uuid.UUID) error {
+	a, err := s.repo.GetAttachment(ctx, id, expenseID, ownerID)
+	if err != nil {
+		return wrapExpenseError("erro ao buscar anexo", err)
+	}
+	if err := s.repo.DeleteAttachment(ctx, id, expenseID, ownerID); err != nil {
+		return wrapExpenseError("erro ao excluir anexo", err)
+	}
+	s.discard(ctx, a.FilePath)
+	return nil
+}
+
+// discard remove do Storage um objeto que não ficou referenciado
+func (s *ExpenseService) discard(ctx context.Context, objectPath string) {
+	if err := s.store.DeleteObject(ctx, s.bucket, objectPath); err != nil {
+		s.log.Error("falha ao remover anexo não referenciado do Storage", logging.Field{Key: "error", Val: err.Error()}, logging.Field{Key: "objectPath", Val: objectPath})
+	}
+}
+
+func wrapExpenseError(msg string, err error) error {
+	for _, known := range []error{models.ErrExpenseNotFound, models.ErrExpenseAttachmentNotFound} {
+		if errors.Is(err, known) {
+			return err
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
@@ -0,0 +1,143 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes das despesas (status exibido, estatísticas e anexos com compensação no Storage)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "io"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+// fakeExpenseRepo implementa repositories.ExpenseRepository em memória
+type fakeExpenseRepo struct {
+    repositories.ExpenseRepository
+    byID        map[uuid.UUID]*models.Expense
+    attachments []models.ExpenseAttachment
+    addErr      error
+    filter      *models.ExpenseFilter
+    statsMonth  time.Time
+}
+
+func (f *fakeExpenseRepo) Create(ctx context.Context, e *models.Expense) error {
+    e.ID = uuid.New()
+    cp := *e
+    f.byID[e.ID] = &cp
+    return nil
+}
+func (f *fakeExpenseRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Expense, error) {
+    e, ok := f.byID[id]
+    if !ok || e.OwnerID != ownerID { return nil, models.ErrExpenseNotFound }
+    cp := *e
+    return &cp, nil
+}
+func (f *fakeExpenseRepo) List(ctx context.Context, ownerID uuid.UUID, filter *models.ExpenseFilter) ([]models.Expense, int, error) {
+    filter.SetDefaults()
+    f.filter = filter
+    var out []models.Expense
+    for _, e := range f.byID { out = append(out, *e) }
+    return out, len(out), nil
+}
+func (f *fakeExpenseRepo) Stats(ctx context.Context, ownerID uuid.UUID, month, today time.Time) (*models.ExpenseStats, error) {
+    f.statsMonth = month
+    return &models.ExpenseStats{Competencia: month.Format("2006-01")}, nil
+}
+func (f *fakeExpenseRepo) AddAttachment(ctx context.Context, a *models.ExpenseAttachment) error {
+    if f.addErr != nil { return f.addErr }
+    f.attachments = append(f.attachments, *a)
+    return nil
+}
+
+// fakeExpenseStorage guarda os objetos enviados
+type fakeExpenseStorage struct {
+    objects map[string][]byte
+    deleted []string
+}
+
+func (s *fakeExpenseStorage) UploadStream(ctx context.Context, bucket, objectPath string, body io.Reader, contentType string) (int64, string, error) {
+    b, err := io.ReadAll(body)
+    if err != nil { return 0, "", err }
+    s.objects[objectPath] = b
+    return int64(len(b)), "hash", nil
+}
+func (s *fakeExpenseStorage) DeleteObject(ctx context.Context, bucket, objectPath string) error {
+    s.deleted = append(s.deleted, objectPath)
+    delete(s.objects, objectPath)
+    return nil
+}
+
+func newExpenseTest() (*ExpenseService, *fakeExpenseRepo, *fakeExpenseStorage) {
+    repo := &fakeExpenseRepo{byID: map[uuid.UUID]*models.Expense{}}
+    store := &fakeExpenseStorage{objects: map[string][]byte{}}
+    svc := NewExpenseService(repo, store, "receipts", logging.NewLogger("dev"))
+    // 01:30 UTC de 19/10 ainda é 18/10 em São Paulo
+    svc.now = func() time.Time { return time.Date(2026, 10, 19, 1, 30, 0, 0, time.UTC) }
+    return svc, repo, store
+}
+
+func TestExpenseService_CreateListAndStats(t *testing.T) {
+    svc, repo, _ := newExpenseTest()
+    ls := locale.Resolve("pt-BR", "America/Sao_Paulo")
+    owner := uuid.New()
+    due := "2026-10-17T00:00:00Z"
+
+    e, err := svc.Create(context.Background(), owner, &models.ExpenseRequest{Descricao: "Internet", Competencia: "2026-10", Valor: models.NewMoney(99.9), DueDate: &due}, ls)
+    if err != nil { t.Fatalf("Create: %v", err) }
+    if e.Status != models.StatusVencido || repo.byID[e.ID].Status != models.StatusPendente { t.Fatalf("status = %s (gravado %s)", e.Status, repo.byID[e.ID].Status) }
+
+    paid, err := svc.Create(context.Background(), owner, &models.ExpenseRequest{Descricao: "Contador", Competencia: "2026-10", Valor: models.NewMoney(300), Status: models.StatusPago}, ls)
+    if err != nil { t.Fatalf("Create: %v", err) }
+    if paid.PagoEm == nil || !paid.PagoEm.Equal(svc.now().UTC()) { t.Fatalf("pago_em = %v", paid.PagoEm) }
+
+    res, err := svc.List(context.Background(), owner, &models.ExpenseFilter{Status: models.StatusVencido}, ls)
+    if err != nil { t.Fatalf("List: %v", err) }
+    if !repo.filter.Today.Equal(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)) || res.Total != 2 || res.TotalPages != 1 { t.Fatalf("filtro = %+v, resposta = %+v", repo.filter, res) }
+    if _, err := svc.List(context.Background(), owner, &models.ExpenseFilter{Status: "parcial"}, ls); !errors.Is(err, models.ErrInvalidStatus) { t.Fatalf("err = %v", err) }
+
+    if st, err := svc.Stats(context.Background(), owner, "", ls); err != nil || st.Competencia != "2026-10" { t.Fatalf("stats = %+v, %v", st, err) }
+    if _, err := svc.Stats(context.Background(), owner, "09/2026", ls); err != nil || repo.statsMonth.Format("2006-01") != "2026-09" { t.Fatalf("mês = %v, %v", repo.statsMonth, err) }
+}
+
+func TestExpenseService_AddAttachment(t *testing.T) {
+    svc, repo, store := newExpenseTest()
+    owner := uuid.New()
+    exp := &models.Expense{ID: uuid.New(), OwnerID: owner, Status: models.StatusPendente}
+    repo.byID[exp.ID] = exp
+
+    a, err := svc.AddAttachment(context.Background(), owner, exp.ID, &ExpenseAttachmentUpload{Tipo: "NFe", FileName: "nota.xml",
+        ContentType: "text/xml; charset=utf-8", Body: strings.NewReader("<?xml version=\"1.0\"?><nfeProc/>")})
+    if err != nil { t.Fatalf("AddAttachment: %v", err) }
+    if a.Tipo != models.ExpenseDocNFe || a.ContentType != "text/xml" || !strings.HasPrefix(a.FilePath, owner.String()+"/expenses/"+exp.ID.String()+"/") || !strings.HasSuffix(a.FilePath, ".xml") {
+        t.Fatalf("anexo = %+v", a)
+    }
+    if _, ok := store.objects[a.FilePath]; !ok || len(repo.attachments) != 1 { t.Fatal("anexo não gravado") }
+
+    // despesa de outro usuário: nada é enviado
+    if _, err := svc.AddAttachment(context.Background(), uuid.New(), exp.ID, &ExpenseAttachmentUpload{ContentType: "application/pdf", Body: strings.NewReader("%PDF-")}); !errors.Is(err, models.ErrExpenseNotFound) {
+        t.Fatalf("err = %v", err)
+    }
+    if len(store.objects) != 1 { t.Fatalf("objetos = %d", len(store.objects)) }
+
+    big := bytes.NewReader(make([]byte, models.MaxExpenseAttachmentSize+10))
+    if _, err := svc.AddAttachment(context.Background(), owner, exp.ID, &ExpenseAttachmentUpload{ContentType: "application/pdf", Body: big}); !errors.Is(err, models.ErrExpenseAttachmentTooLarge) {
+        t.Fatalf("err = %v", err)
+    }
+    // falha ao gravar o registro remove o objeto enviado
+    repo.addErr = errors.New("conexão perdida")
+    if _, err := svc.AddAttachment(context.Background(), owner, exp.ID, &ExpenseAttachmentUpload{ContentType: "image/png", Body: strings.NewReader("png")}); err == nil {
+        t.Fatal("esperado erro")
+    }
+    if len(store.objects) != 1 || len(store.deleted) != 2 { t.Fatalf("objetos = %d, removidos = %v", len(store.objects), store.deleted) }
+}
@@ -0,0 +1,110 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Calendário de feriados (nacionais e municipais) e ajuste de vencimentos para dia útil
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// HolidayService calendário de feriados do usuário e opção de vencimento em dia útil.
+// Docstring: os feriados nacionais são calculados (inclusive os móveis, a partir da Páscoa); os
+// municipais são cadastrados pelo usuário. Com a opção ligada, os vencimentos das receitas geradas
+// por modelos que caem em fim de semana ou feriado vão para o próximo dia útil.
+type HolidayService struct {
+	repo     repositories.HolidayRepository
+	settings repositories.SettingsRepository
+	log      logging.Logger
+}
+
+// NewHolidayService cria o serviço de feriados
+func NewHolidayService(repo repositories.HolidayRepository, settings repositories.SettingsRepository, log logging.Logger) *HolidayService {
+	return &HolidayService{repo: repo, settings: settings, log: log}
+}
+
+// Calendar feriados do ano (nacionais e cadastrados pelo usuário) em ordem de data
+func (s *HolidayService) Calendar(ctx context.Context, ownerID uuid.UUID, year int) ([]models.Holiday, error) {
+	if year < 1900 || year > 2200 {
+		return nil, models.ErrHolidayYearInvalid
+	}
+	cal, err := s.calendar(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return cal.Year(year), nil
+}
+
+// Create cadastra um feriado municipal
+func (s *HolidayService) Create(ctx context.Context, ownerID uuid.UUID, req *models.HolidayRequest) (*models.Holiday, error) {
+	data, err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+	h := &models.Holiday{OwnerID: &ownerID, Data: data, Nome: req.Nome, Anual: req.Anual}
+	if err := s.repo.Create(ctx, h); err != nil {
+		return nil, fmt.Errorf("erro ao cadastrar feriado: %w", err)
+	}
+	return h, nil
+}
+
+// Delete remove um feriado cadastrado; vencimentos já gerados não mudam
+func (s *HolidayService) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id, ownerID); err != nil {
+		if errors.Is(err, models.ErrHolidayNotFound) {
+			return err
+		}
+		return fmt.Errorf("erro ao remover feriado: %w", err)
+	}
+	return nil
+}
+
+// GetSettings retorna a opção de vencimento em dia útil do usuário
+func (s *HolidayService) GetSettings(ctx context.Context, ownerID uuid.UUID) (*models.BusinessDaySettings, error) {
+	b, err := s.settings.GetBusinessDays(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar opção de dia útil: %w", err)
+	}
+	return b, nil
+}
+
+// UpdateSettings grava a opção; vale para as próximas receitas geradas por modelos
+func (s *HolidayService) UpdateSettings(ctx context.Context, ownerID uuid.UUID, b *models.BusinessDaySettings) (*models.BusinessDaySettings, error) {
+	if err := s.settings.UpdateBusinessDays(ctx, ownerID, b); err != nil {
+		return nil, fmt.Errorf("erro ao gravar opção de dia útil: %w", err)
+	}
+	return b, nil
+}
+
+// AdjustDueDate leva o vencimento ao próximo dia útil quando o usuário ligou a opção
+func (s *HolidayService) AdjustDueDate(ctx context.Context, ownerID uuid.UUID, due time.Time) (time.Time, error) {
+	b, err := s.settings.GetBusinessDays(ctx, ownerID)
+	if err != nil {
+		return due, fmt.Errorf("erro ao buscar opção de dia útil: %w", err)
+	}
+	if !b.AjustarDiaUtil {
+		return due, nil
+	}
+	cal, err := s.calendar(ctx, ownerID)
+	if err != nil {
+		return due, err
+	}
+	return cal.NextBusinessDay(due), nil
+}
+
+func (s *HolidayService) calendar(ctx context.Context, ownerID uuid.UUID) (*models.HolidayCalendar, error) {
+	custom, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar feriados: %w", err)
+	}
+	return models.NewHolidayCalendar(custom), nil
+}
@@ -0,0 +1,86 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do calendário de feriados e do vencimento em dia útil nas receitas de modelos
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+// fakeHolidayRepo feriados cadastrados em memória
+type fakeHolidayRepo struct {
+    items []models.Holiday
+}
+
+func (f *fakeHolidayRepo) List(ctx context.Context, ownerID uuid.UUID) ([]models.Holiday, error) {
+    return f.items, nil
+}
+func (f *fakeHolidayRepo) Create(ctx context.Context, h *models.Holiday) error {
+    id := uuid.New()
+    h.ID, h.Tipo = &id, models.HolidayMunicipal
+    f.items = append(f.items, *h)
+    return nil
+}
+func (f *fakeHolidayRepo) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+    return models.ErrHolidayNotFound
+}
+
+// fakeTemplateRepo devolve sempre o mesmo modelo
+type fakeTemplateRepo struct {
+    repositories.IncomeTemplateRepository
+    tpl *models.IncomeTemplate
+}
+
+func (f *fakeTemplateRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.IncomeTemplate, error) {
+    return f.tpl, nil
+}
+
+func TestHolidayService_CalendarAndCreate(t *testing.T) {
+    owner := uuid.New()
+    svc := NewHolidayService(&fakeHolidayRepo{}, &fakeSettingsRepo{}, logging.NewLogger("dev"))
+    ctx := context.Background()
+
+    if _, err := svc.Create(ctx, owner, &models.HolidayRequest{Nome: " ", Data: "2026-01-25"}); !errors.Is(err, models.ErrHolidayNameRequired) { t.Fatalf("sem nome: err = %v", err) }
+    if _, err := svc.Create(ctx, owner, &models.HolidayRequest{Nome: "Aniversário", Data: "25/01/2026"}); !errors.Is(err, models.ErrHolidayDateRequired) { t.Fatalf("data inválida: err = %v", err) }
+    h, err := svc.Create(ctx, owner, &models.HolidayRequest{Nome: " Aniversário de São Paulo ", Data: "2026-01-25", Anual: true})
+    if err != nil || h.ID == nil || h.Nome != "Aniversário de São Paulo" { t.Fatalf("Create: %+v err=%v", h, err) }
+
+    items, err := svc.Calendar(ctx, owner, 2027)
+    if err != nil { t.Fatalf("Calendar: %v", err) }
+    if len(items) != 14 || items[1].Nome != "Aniversário de São Paulo" || items[1].Data.Format("2006-01-02") != "2027-01-25" { t.Fatalf("calendário: %+v", items[:2]) }
+    if _, err := svc.Calendar(ctx, owner, 99999); !errors.Is(err, models.ErrHolidayYearInvalid) { t.Fatalf("ano inválido: err = %v", err) }
+}
+
+func TestIncomeTemplateService_InstantiateRollsToBusinessDay(t *testing.T) {
+    owner := uuid.New()
+    day := 15
+    tpl := &models.IncomeTemplate{ID: uuid.New(), OwnerID: owner, Nome: "Aluguel", Valor: models.NewMoney(1500), DueDay: &day}
+    settings := &fakeSettingsRepo{}
+    holidays := NewHolidayService(&fakeHolidayRepo{}, settings, logging.NewLogger("dev"))
+    incomes := &fakeIncomeRepo{}
+    svc := NewIncomeTemplateService(&fakeTemplateRepo{tpl: tpl}, NewIncomeService(incomes), holidays)
+    ctx := context.Background()
+
+    // 15/11/2026 é domingo e feriado: sem a opção, o vencimento fica no dia do modelo
+    if _, err := svc.Instantiate(ctx, tpl.ID, owner, &models.IncomeCopyRequest{Competencia: "2026-11"}); err != nil { t.Fatalf("Instantiate: %v", err) }
+    if got := incomes.created.DueDate.Format("2006-01-02"); got != "2026-11-15" { t.Fatalf("sem ajuste: vencimento = %s", got) }
+
+    settings.businessDays.AjustarDiaUtil = true
+    if _, err := svc.Instantiate(ctx, tpl.ID, owner, &models.IncomeCopyRequest{Competencia: "2026-11"}); err != nil { t.Fatalf("Instantiate: %v", err) }
+    if got := incomes.created.DueDate.Format("2006-01-02"); got != "2026-11-16" { t.Fatalf("com ajuste: vencimento = %s", got) }
+
+    // Vencimento informado na requisição não é ajustado
+    explicit := time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+    if _, err := svc.Instantiate(ctx, tpl.ID, owner, &models.IncomeCopyRequest{Competencia: "2026-11", DueDate: &explicit}); err != nil { t.Fatalf("Instantiate: %v", err) }
+    if got := incomes.created.DueDate.Format("2006-01-02"); got != "2026-11-15" { t.Fatalf("vencimento explícito = %s", got) }
+}
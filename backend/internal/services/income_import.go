@@ -0,0 +1,217 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Importação de receitas via CSV com mapeamento de colunas e modo de simulação (dry-run)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/models"
+)
+
+// ImportIncomes valida todas as linhas do CSV e, fora do dry-run, grava tudo em uma única transação.
+// Docstring: qualquer erro de validação impede a gravação (ErrImportHasErrors) e o resultado traz
+// os erros por linha; em dry-run o resultado inclui a prévia das receitas já com as regras aplicadas.
+func (s *incomeService) ImportIncomes(ctx context.Context, ownerID uuid.UUID, r io.Reader, mapping models.IncomeImportMapping, dryRun bool) (*models.IncomeImportResult, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	rows, cols, err := readImportCSV(r, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.IncomeImportResult{DryRun: dryRun, TotalRows: len(rows), Errors: []models.IncomeImportError{}}
+	incomes := make([]*models.Income, 0, len(rows))
+	for _, row := range rows {
+		req, fieldErr := importRowRequest(row.values, cols)
+		if fieldErr != nil {
+			fieldErr.Line = row.line
+			res.Errors = append(res.Errors, *fieldErr)
+			continue
+		}
+		income, err := s.newIncome(ctx, ownerID, req)
+		if err != nil {
+			res.Errors = append(res.Errors, models.IncomeImportError{Line: row.line, Message: err.Error()})
+			continue
+		}
+		incomes = append(incomes, income)
+	}
+	res.ValidRows = len(incomes)
+
+	if dryRun {
+		for _, in := range incomes {
+			res.Preview = append(res.Preview, *in)
+		}
+		return res, nil
+	}
+	if len(res.Errors) > 0 {
+		return res, models.ErrImportHasErrors
+	}
+	if err := s.incomeRepo.CreateMany(ctx, incomes); err != nil {
+		if errors.Is(err, models.ErrPayerNotFound) {
+			return res, err
+		}
+		return res, fmt.Errorf("erro ao importar receitas: %w", err)
+	}
+	res.Imported = len(incomes)
+	return res, nil
+}
+
+type importRow struct {
+	line   int
+	values []string
+}
+
+// readImportCSV lê o CSV (vírgula ou ponto e vírgula, detectado no cabeçalho) e resolve o
+// índice da coluna de cada campo mapeado
+func readImportCSV(r io.Reader, mapping models.IncomeImportMapping) ([]importRow, map[string]int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff") // BOM do Excel
+	header := text
+	if i := strings.IndexAny(text, "\r\n"); i >= 0 {
+		header = text[:i]
+	}
+
+	cr := csv.NewReader(strings.NewReader(text))
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		cr.Comma = ';'
+	}
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	head, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil, models.ErrImportEmpty
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", models.ErrImportInvalidCSV, err)
+	}
+	index := map[string]int{}
+	for i, h := range head {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	cols := map[string]int{}
+	for _, field := range models.ImportFields() {
+		if i, ok := index[strings.ToLower(mapping.Column(field))]; ok {
+			cols[field] = i
+		} else if _, mapped := mapping[field]; mapped || field == models.ImportFieldCompetencia || field == models.ImportFieldValor {
+			return nil, nil, fmt.Errorf("%w: %s", models.ErrImportMissingColumn, mapping.Column(field))
+		}
+	}
+
+	var rows []importRow
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w (linha %d): %v", models.ErrImportInvalidCSV, line, err)
+		}
+		if isBlankRecord(rec) {
+			continue
+		}
+		rows = append(rows, importRow{line: line, values: rec})
+		if len(rows) > models.MaxImportRows {
+			return nil, nil, models.ErrImportTooManyRows
+		}
+	}
+	if len(rows) == 0 {
+		return nil, nil, models.ErrImportEmpty
+	}
+	return rows, cols, nil
+}
+
+// importRowRequest converte uma linha do CSV em IncomeRequest
+func importRowRequest(values []string, cols map[string]int) (*models.IncomeRequest, *models.IncomeImportError) {
+	get := func(field string) string {
+		if i, ok := cols[field]; ok && i < len(values) {
+			return strings.TrimSpace(values[i])
+		}
+		return ""
+	}
+	req := &models.IncomeRequest{
+		Competencia: get(models.ImportFieldCompetencia),
+		Status:      strings.ToLower(get(models.ImportFieldStatus)),
+	}
+
+	valor, err := parseImportValor(get(models.ImportFieldValor))
+	if err != nil {
+		return nil, &models.IncomeImportError{Field: models.ImportFieldValor, Message: "valor inválido"}
+	}
+	req.Valor = valor
+
+	if v := get(models.ImportFieldCategoria); v != "" {
+		req.Categoria = &v
+	}
+	if v := get(models.ImportFieldDueDate); v != "" {
+		due, err := parseImportDate(v)
+		if err != nil {
+			return nil, &models.IncomeImportError{Field: models.ImportFieldDueDate, Message: "data de vencimento inválida (use AAAA-MM-DD ou DD/MM/AAAA)"}
+		}
+		formatted := due.Format(time.RFC3339)
+		req.DueDate = &formatted
+	}
+	if v := get(models.ImportFieldPayerID); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, &models.IncomeImportError{Field: models.ImportFieldPayerID, Message: "payer_id inválido"}
+		}
+		req.PayerID = &id
+	}
+	if v := get(models.ImportFieldContractID); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, &models.IncomeImportError{Field: models.ImportFieldContractID, Message: "contract_id inválido"}
+		}
+		req.ContractID = &id
+	}
+	if v := get(models.ImportFieldTags); v != "" {
+		req.Tags = strings.Split(v, "|")
+	}
+	return req, nil
+}
+
+// parseImportValor aceita "1234.56", "1.234,56" e "R$ 1.234,56"
+func parseImportValor(v string) (models.Money, error) {
+	v = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(v), "R$"))
+	v = strings.ReplaceAll(v, " ", "")
+	if strings.Contains(v, ",") {
+		v = strings.ReplaceAll(v, ".", "")
+		v = strings.Replace(v, ",", ".", 1)
+	}
+	return models.ParseMoney(v)
+}
+
+// parseImportDate aceita AAAA-MM-DD, DD/MM/AAAA e RFC3339
+func parseImportDate(v string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "02/01/2006", time.RFC3339} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, models.ErrInvalidDateFormat
+}
+
+func isBlankRecord(rec []string) bool {
+	for _, v := range rec {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da importação de receitas via CSV (mapeamento, dry-run e erros por linha)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+
+    "github.com/google/uuid"
+    "recibofast/internal/models"
+)
+
+const importCSV = "Mês;Valor;Categoria;Vencimento;Etiquetas\n" +
+    "2026-09;R$ 1.800,00;Aluguel;05/09/2026;Apto 12|Contrato 2026\n" +
+    "2026-10;1250.50;;2026-10-10;\n"
+
+var importMapping = models.IncomeImportMapping{
+    "competencia": "Mês", "valor": "Valor", "categoria": "Categoria", "due_date": "Vencimento", "tags": "Etiquetas",
+}
+
+func TestImportIncomes_DryRunDoesNotPersist(t *testing.T) {
+    repo := &fakeIncomeRepo{}
+    svc := NewIncomeService(repo)
+
+    res, err := svc.ImportIncomes(context.Background(), uuid.New(), strings.NewReader(importCSV), importMapping, true)
+    if err != nil { t.Fatalf("ImportIncomes err: %v", err) }
+    if res.TotalRows != 2 || res.ValidRows != 2 || len(res.Errors) != 0 { t.Fatalf("resultado inesperado: %+v", res) }
+    if repo.createdMany != nil { t.Fatalf("dry-run não deveria gravar") }
+    if res.Preview[0].Valor != models.NewMoney(1800) || len(res.Preview[0].Tags) != 2 || res.Preview[0].DueDate == nil {
+        t.Fatalf("prévia da linha 2 inesperada: %+v", res.Preview[0])
+    }
+}
+
+func TestImportIncomes_CommitsAll(t *testing.T) {
+    repo := &fakeIncomeRepo{}
+    svc := NewIncomeService(repo)
+
+    res, err := svc.ImportIncomes(context.Background(), uuid.New(), strings.NewReader(importCSV), importMapping, false)
+    if err != nil { t.Fatalf("ImportIncomes err: %v", err) }
+    if res.Imported != 2 || len(repo.createdMany) != 2 { t.Fatalf("importadas=%d gravadas=%d, want 2/2", res.Imported, len(repo.createdMany)) }
+}
+
+func TestImportIncomes_ErrorsPerLineAbortCommit(t *testing.T) {
+    csv := "competencia,valor,due_date\n2026-09,abc,\n,100,\n2026-11,50,31/02/2026\n2026-12,75,\n"
+    repo := &fakeIncomeRepo{}
+    svc := NewIncomeService(repo)
+
+    res, err := svc.ImportIncomes(context.Background(), uuid.New(), strings.NewReader(csv), nil, false)
+    if !errors.Is(err, models.ErrImportHasErrors) { t.Fatalf("err = %v, want ErrImportHasErrors", err) }
+    if len(res.Errors) != 3 || res.ValidRows != 1 { t.Fatalf("erros=%d válidas=%d, want 3/1", len(res.Errors), res.ValidRows) }
+    if res.Errors[0].Line != 2 || res.Errors[0].Field != "valor" { t.Fatalf("primeiro erro inesperado: %+v", res.Errors[0]) }
+    if res.Errors[2].Line != 4 || res.Errors[2].Field != "due_date" { t.Fatalf("erro de data inesperado: %+v", res.Errors[2]) }
+    if repo.createdMany != nil { t.Fatalf("nada deveria ser gravado com erros") }
+}
+
+func TestImportIncomes_MissingRequiredColumn(t *testing.T) {
+    svc := NewIncomeService(&fakeIncomeRepo{})
+    _, err := svc.ImportIncomes(context.Background(), uuid.New(), strings.NewReader("valor\n10\n"), nil, true)
+    if !errors.Is(err, models.ErrImportMissingColumn) { t.Fatalf("err = %v, want ErrImportMissingColumn", err) }
+}
@@ -6,40 +6,141 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
 	"recibofast/internal/models"
 	"recibofast/internal/repositories"
 )
 
 // IncomeService interface para serviços de receitas
 type IncomeService interface {
-	CreateIncome(ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error)
-	GetIncome(id, ownerID uuid.UUID) (*models.Income, error)
-	UpdateIncome(id, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error)
-	DeleteIncome(id, ownerID uuid.UUID) error
-	ListIncomes(ownerID uuid.UUID, filter *models.IncomeFilter) (*models.IncomeResponse, error)
-	AddPayment(ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error)
-	GetIncomePayments(incomeID, ownerID uuid.UUID) ([]models.Payment, error)
-	CalculateIncomeStatus(income *models.Income) string
+	CreateIncome(ctx context.Context, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error)
+	ImportIncomes(ctx context.Context, ownerID uuid.UUID, r io.Reader, mapping models.IncomeImportMapping, dryRun bool) (*models.IncomeImportResult, error)
+	DuplicateIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeCopyRequest) (*models.Income, error)
+	GetIncome(ctx context.Context, id, ownerID uuid.UUID) (*models.Income, error)
+	GetIncomeDetail(ctx context.Context, id, ownerID uuid.UUID, at time.Time, loc *time.Location) (*models.IncomeDetail, error)
+	UpdateIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error)
+	PatchIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomePatchRequest) (*models.Income, error)
+	DeleteIncome(ctx context.Context, id, ownerID uuid.UUID) error
+	ListIncomes(ctx context.Context, ownerID uuid.UUID, filter *models.IncomeFilter) (*models.IncomeResponse, error)
+	IncomeStats(ctx context.Context, ownerID uuid.UUID) (*models.IncomeStats, error)
+	AddPayment(ctx context.Context, ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error)
+	UpdatePayment(ctx context.Context, paymentID, ownerID uuid.UUID, req *models.PaymentUpdateRequest) (*models.PaymentResponse, error)
+	GetIncomePayments(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.Payment, error)
+	SimulatePayment(ctx context.Context, id, ownerID uuid.UUID, payDate time.Time, valor *models.Money, loc *time.Location) (*models.PaymentSimulation, error)
+	CalculateIncomeStatus(income *models.Income, loc *time.Location) string
 }
 
 // incomeService implementação do serviço
 type incomeService struct {
 	incomeRepo repositories.IncomeRepository
+	rules      IncomeRuleEvaluator
+	credits    repositories.CreditRepository
+	methods    PaymentMethodResolver
+	events     EventDispatcher
+	autoRecs   AutoReceiptTrigger
+	log        logging.Logger
+	now        func() time.Time
+}
+
+// PaymentMethodResolver escolhe a forma de pagamento do catálogo (implementado por PaymentMethodService)
+type PaymentMethodResolver interface {
+	Resolve(ctx context.Context, ownerID uuid.UUID, methodID *uuid.UUID, metodo *string) (*models.PaymentMethod, error)
+}
+
+// PaymentRecorder lança pagamentos nas receitas (implementado por IncomeService); usado pelas
+// baixas que não partem do usuário na tela de receitas (webhook PIX, conciliação bancária)
+type PaymentRecorder interface {
+	AddPayment(ctx context.Context, ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error)
+}
+
+// AutoReceiptTrigger processa a fila de recibos automáticos (implementado por AutoReceiptService)
+type AutoReceiptTrigger interface {
+	Kick()
+}
+
+// IncomeServiceOption configura dependências opcionais do serviço de receitas
+type IncomeServiceOption func(*incomeService)
+
+// WithRuleEvaluator aplica as regras de categorização do usuário na criação de receitas
+func WithRuleEvaluator(e IncomeRuleEvaluator) IncomeServiceOption {
+	return func(s *incomeService) { s.rules = e }
+}
+
+// WithCredits habilita o modo "credit" de pagamento: o excedente ao saldo vira crédito do usuário
+func WithCredits(credits repositories.CreditRepository) IncomeServiceOption {
+	return func(s *incomeService) { s.credits = credits }
+}
+
+// WithPaymentMethods liga os pagamentos ao catálogo de formas de pagamento do usuário
+func WithPaymentMethods(methods PaymentMethodResolver) IncomeServiceOption {
+	return func(s *incomeService) { s.methods = methods }
+}
+
+// WithEvents notifica o usuário (e-mail, push no PWA, WhatsApp) dos pagamentos registrados;
+// falhas no envio são só registradas no log e não desfazem o pagamento
+func WithEvents(events EventDispatcher, log logging.Logger) IncomeServiceOption {
+	return func(s *incomeService) { s.events, s.log = events, log }
+}
+
+// WithAutoReceipts dispara a emissão do recibo logo após o pagamento que quita a receita; o pedido
+// é gravado na transação do pagamento (só para quem ligou a opção), então um disparo perdido fica
+// para o job "auto-receipts"
+func WithAutoReceipts(trigger AutoReceiptTrigger) IncomeServiceOption {
+	return func(s *incomeService) { s.autoRecs = trigger }
 }
 
 // NewIncomeService cria uma nova instância do serviço
-func NewIncomeService(incomeRepo repositories.IncomeRepository) IncomeService {
-	return &incomeService{
+func NewIncomeService(incomeRepo repositories.IncomeRepository, opts ...IncomeServiceOption) IncomeService {
+	s := &incomeService{
 		incomeRepo: incomeRepo,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // CreateIncome cria uma nova receita
-func (s *incomeService) CreateIncome(ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error) {
+func (s *incomeService) CreateIncome(ctx context.Context, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error) {
+	income, err := s.newIncome(ctx, ownerID, req)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Salvar no banco
+	err = s.incomeRepo.Create(ctx, income)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar receita: %w", err)
+	}
+	
+	return income, nil
+}
+
+// DuplicateIncome cria uma nova receita a partir de outra (ex.: recobrança do mês seguinte)
+func (s *incomeService) DuplicateIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeCopyRequest) (*models.Income, error) {
+	src, err := s.incomeRepo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	incomeReq, err := models.DuplicateIncomeRequest(src, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.CreateIncome(ctx, ownerID, incomeReq)
+}
+
+// newIncome valida a requisição e monta a receita a persistir, aplicando as regras do usuário.
+// Docstring: compartilhado pela criação individual e pela importação em lote.
+func (s *incomeService) newIncome(ctx context.Context, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error) {
 	// Validar dados de entrada
 	if err := req.Validate(); err != nil {
 		return nil, err
@@ -55,12 +156,17 @@ func (s *incomeService) CreateIncome(ownerID uuid.UUID, req *models.IncomeReques
 		ID:          uuid.New(),
 		OwnerID:     ownerID,
 		ContractID:  req.ContractID,
+		PayerID:     req.PayerID,
 		Categoria:   req.Categoria,
 		Competencia: req.Competencia,
 		Valor:       req.Valor,
 		Status:      req.Status,
+		Tags:        req.Tags,
 		TotalPago:   0,
 	}
+	if req.ID != nil {
+		income.ID = *req.ID
+	}
 	
 	// Definir status padrão se não fornecido
 	if income.Status == "" {
@@ -76,35 +182,36 @@ func (s *incomeService) CreateIncome(ownerID uuid.UUID, req *models.IncomeReques
 		income.DueDate = &dueDate
 	}
 	
-	// Salvar no banco
-	err := s.incomeRepo.Create(income)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao criar receita: %w", err)
+	// Aplicar regras de categorização do usuário (primeira regra que casar)
+	if s.rules != nil {
+		if _, err := s.rules.ApplyRules(ctx, ownerID, income); err != nil {
+			return nil, err
+		}
 	}
 	
 	return income, nil
 }
 
 // GetIncome busca uma receita por ID
-func (s *incomeService) GetIncome(id, ownerID uuid.UUID) (*models.Income, error) {
-	income, err := s.incomeRepo.GetByID(id, ownerID)
+func (s *incomeService) GetIncome(ctx context.Context, id, ownerID uuid.UUID) (*models.Income, error) {
+	income, err := s.incomeRepo.GetByID(ctx, id, ownerID)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Atualizar status baseado na data de vencimento
-	updatedStatus := s.CalculateIncomeStatus(income)
+	updatedStatus := s.CalculateIncomeStatus(income, locale.FromContext(ctx).Location)
 	if updatedStatus != income.Status {
 		income.Status = updatedStatus
 		// Atualizar no banco se necessário
-		s.incomeRepo.Update(income)
+		s.incomeRepo.Update(ctx, income)
 	}
 	
 	return income, nil
 }
 
 // UpdateIncome atualiza uma receita existente
-func (s *incomeService) UpdateIncome(id, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error) {
+func (s *incomeService) UpdateIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error) {
 	// Validar dados de entrada
 	if err := req.Validate(); err != nil {
 		return nil, err
@@ -114,18 +221,28 @@ func (s *incomeService) UpdateIncome(id, ownerID uuid.UUID, req *models.IncomeRe
 	if req.Status != "" && !models.ValidStatus(req.Status) {
 		return nil, models.ErrInvalidStatus
 	}
+	if req.Version == nil {
+		return nil, models.ErrIncomeVersionRequired
+	}
 	
 	// Buscar receita existente
-	income, err := s.incomeRepo.GetByID(id, ownerID)
+	income, err := s.incomeRepo.GetByID(ctx, id, ownerID)
 	if err != nil {
 		return nil, err
 	}
+	if income.Version != *req.Version {
+		return nil, &models.IncomeVersionConflictError{Current: income}
+	}
 	
 	// Atualizar campos
 	income.ContractID = req.ContractID
+	income.PayerID = req.PayerID
 	income.Categoria = req.Categoria
 	income.Competencia = req.Competencia
 	income.Valor = req.Valor
+	if req.Tags != nil {
+		income.Tags = req.Tags
+	}
 	
 	if req.Status != "" {
 		income.Status = req.Status
@@ -152,7 +269,7 @@ func (s *incomeService) UpdateIncome(id, ownerID uuid.UUID, req *models.IncomeRe
 	}
 	
 	// Salvar alterações
-	err = s.incomeRepo.Update(income)
+	err = s.incomeRepo.Update(ctx, income)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao atualizar receita: %w", err)
 	}
@@ -160,16 +277,48 @@ func (s *incomeService) UpdateIncome(id, ownerID uuid.UUID, req *models.IncomeRe
 	return income, nil
 }
 
+// PatchIncome atualiza apenas os campos informados, preservando os demais (PUT continua substituindo tudo)
+func (s *incomeService) PatchIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomePatchRequest) (*models.Income, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Version == nil {
+		return nil, models.ErrIncomeVersionRequired
+	}
+	
+	income, err := s.incomeRepo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if income.Version != *req.Version {
+		return nil, &models.IncomeVersionConflictError{Current: income}
+	}
+	req.Apply(income)
+	
+	// Recalcular status baseado no valor e pagamentos
+	if income.TotalPago >= income.Valor {
+		income.Status = models.StatusPago
+	} else if income.TotalPago > 0 {
+		income.Status = models.StatusParcial
+	}
+	
+	if err := s.incomeRepo.Update(ctx, income); err != nil {
+		return nil, fmt.Errorf("erro ao atualizar receita: %w", err)
+	}
+	
+	return income, nil
+}
+
 // DeleteIncome remove uma receita (soft delete)
-func (s *incomeService) DeleteIncome(id, ownerID uuid.UUID) error {
+func (s *incomeService) DeleteIncome(ctx context.Context, id, ownerID uuid.UUID) error {
 	// Verificar se a receita existe
-	_, err := s.incomeRepo.GetByID(id, ownerID)
+	_, err := s.incomeRepo.GetByID(ctx, id, ownerID)
 	if err != nil {
 		return err
 	}
 	
 	// Deletar receita
-	err = s.incomeRepo.Delete(id, ownerID)
+	err = s.incomeRepo.Delete(ctx, id, ownerID)
 	if err != nil {
 		return fmt.Errorf("erro ao deletar receita: %w", err)
 	}
@@ -178,8 +327,8 @@ func (s *incomeService) DeleteIncome(id, ownerID uuid.UUID) error {
 }
 
 // ListIncomes lista receitas com filtros e paginação
-func (s *incomeService) ListIncomes(ownerID uuid.UUID, filter *models.IncomeFilter) (*models.IncomeResponse, error) {
-	incomes, total, err := s.incomeRepo.List(ownerID, filter)
+func (s *incomeService) ListIncomes(ctx context.Context, ownerID uuid.UUID, filter *models.IncomeFilter) (*models.IncomeResponse, error) {
+	incomes, total, err := s.incomeRepo.List(ctx, ownerID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao listar receitas: %w", err)
 	}
@@ -187,14 +336,11 @@ func (s *incomeService) ListIncomes(ownerID uuid.UUID, filter *models.IncomeFilt
 	// Calcular total de páginas
 	totalPages := (total + filter.PerPage - 1) / filter.PerPage
 	
-	// Atualizar status das receitas baseado na data de vencimento
+	// Status exibido conforme o vencimento; a gravação em lote fica com o job diário (OverdueService),
+	// para que a listagem não concorra com as edições do usuário
+	loc := locale.FromContext(ctx).Location
 	for i := range incomes {
-		updatedStatus := s.CalculateIncomeStatus(&incomes[i])
-		if updatedStatus != incomes[i].Status {
-			incomes[i].Status = updatedStatus
-			// Atualizar no banco em background (opcional)
-			go s.incomeRepo.Update(&incomes[i])
-		}
+		incomes[i].Status = s.CalculateIncomeStatus(&incomes[i], loc)
 	}
 	
 	return &models.IncomeResponse{
@@ -206,22 +352,28 @@ func (s *incomeService) ListIncomes(ownerID uuid.UUID, filter *models.IncomeFilt
 	}, nil
 }
 
+// IncomeStats totais e saldos das receitas do usuário por status
+func (s *incomeService) IncomeStats(ctx context.Context, ownerID uuid.UUID) (*models.IncomeStats, error) {
+	return s.incomeRepo.Stats(ctx, ownerID)
+}
+
 // AddPayment adiciona um pagamento a uma receita
-func (s *incomeService) AddPayment(ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error) {
+func (s *incomeService) AddPayment(ctx context.Context, ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error) {
 	// Validar dados de entrada
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 	
 	// Verificar se a receita existe e pertence ao usuário
-	income, err := s.incomeRepo.GetByID(req.IncomeID, ownerID)
+	income, err := s.incomeRepo.GetByID(ctx, req.IncomeID, ownerID)
 	if err != nil {
 		return nil, err
 	}
 	
-	// Verificar se o valor do pagamento não excede o saldo devedor
+	// Verificar se o valor do pagamento não excede o saldo devedor (exceto no modo crédito)
 	saldoDevedor := income.Valor - income.TotalPago
-	if req.Valor > saldoDevedor {
+	asCredit := req.Valor > saldoDevedor && req.Overpayment == models.OverpaymentCredit && s.credits != nil
+	if req.Valor > saldoDevedor && !asCredit {
 		return nil, models.ErrInsufficientAmount
 	}
 	
@@ -233,6 +385,12 @@ func (s *incomeService) AddPayment(ownerID uuid.UUID, req *models.PaymentRequest
 		Metodo:   req.Metodo,
 		Obs:      req.Obs,
 	}
+	if req.ID != nil {
+		payment.ID = *req.ID
+	}
+	if err := s.applyPaymentMethod(ctx, ownerID, payment, req.MethodID); err != nil {
+		return nil, err
+	}
 	
 	// Definir data do pagamento
 	if req.PagoEm != nil && *req.PagoEm != "" {
@@ -245,22 +403,97 @@ func (s *incomeService) AddPayment(ownerID uuid.UUID, req *models.PaymentRequest
 		payment.PagoEm = time.Now()
 	}
 	
-	// Adicionar pagamento
-	err = s.incomeRepo.AddPayment(payment)
+	// Modo crédito: quita o saldo e guarda o excedente como crédito, na mesma transação
+	if asCredit {
+		updatedIncome, credit, err := s.credits.AddPaymentWithCredit(ctx, payment, ownerID)
+		if err != nil {
+			if errors.Is(err, models.ErrIncomeAlreadyPaid) || errors.Is(err, models.ErrIncomeNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("erro ao adicionar pagamento: %w", err)
+		}
+		s.notifyPayment(ctx, ownerID, payment, updatedIncome)
+		s.kickAutoReceipt(updatedIncome)
+		return &models.PaymentResponse{Payment: *payment, Income: *updatedIncome, Credit: credit}, nil
+	}
+
+	// Inserir pagamento e recalcular o total na mesma transação (o saldo é conferido de novo sob trava)
+	updatedIncome, err := s.incomeRepo.AddPaymentTx(ctx, payment, ownerID)
 	if err != nil {
+		if errors.Is(err, models.ErrInsufficientAmount) || errors.Is(err, models.ErrIncomeNotFound) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("erro ao adicionar pagamento: %w", err)
 	}
+	s.notifyPayment(ctx, ownerID, payment, updatedIncome)
+	s.kickAutoReceipt(updatedIncome)
 
-	// Atualizar total pago da receita
-	err = s.incomeRepo.UpdateTotalPago(req.IncomeID)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao atualizar total pago: %w", err)
+	return &models.PaymentResponse{
+		Payment: *payment,
+		Income:  *updatedIncome,
+	}, nil
+}
+
+// kickAutoReceipt adianta a emissão do recibo automático da receita quitada
+func (s *incomeService) kickAutoReceipt(income *models.Income) {
+	if s.autoRecs != nil && models.IncomeSettled(income) {
+		s.autoRecs.Kick()
 	}
+}
+
+// notifyPayment dispara o evento payment.received (valores no formato padrão pt-BR)
+func (s *incomeService) notifyPayment(ctx context.Context, ownerID uuid.UUID, payment *models.Payment, income *models.Income) {
+	if s.events == nil {
+		return
+	}
+	ls := locale.Default()
+	what := ls.FormatCompetencia(income.Competencia)
+	if income.Categoria != nil && *income.Categoria != "" {
+		what = *income.Categoria + " " + what
+	}
+	body := fmt.Sprintf("%s recebido em %s.", ls.FormatAmount(payment.Valor), what)
+	if saldo := income.Valor - income.TotalPago; saldo > 0 {
+		body += " Saldo restante: " + ls.FormatAmount(saldo) + "."
+	}
+	msg := &models.NotificationMessage{
+		Title: "Pagamento recebido",
+		Body:  body,
+		URL:   "/receitas/" + income.ID.String(),
+		Tag:   "payment-" + income.ID.String(),
+		Data:  map[string]interface{}{"income_id": income.ID, "payment_id": payment.ID},
+	}
+	// O pagamento já foi gravado: a notificação não é cancelada se o cliente desconectar
+	if _, err := s.events.Dispatch(context.WithoutCancel(ctx), ownerID, models.EventPaymentReceived, msg); err != nil && s.log != nil {
+		s.log.Error("erro ao notificar pagamento recebido",
+			logging.Field{Key: "payment_id", Val: payment.ID.String()},
+			logging.Field{Key: "error", Val: err.Error()})
+	}
+}
 
-	// Buscar receita atualizada
-	updatedIncome, err := s.incomeRepo.GetByID(req.IncomeID, ownerID)
+// UpdatePayment corrige data, método, observação ou valor de um pagamento.
+// Docstring: o novo valor é conferido contra o saldo da receita (sem o valor antigo) sob trava.
+func (s *incomeService) UpdatePayment(ctx context.Context, paymentID, ownerID uuid.UUID, req *models.PaymentUpdateRequest) (*models.PaymentResponse, error) {
+	pagoEm, err := req.Validate()
 	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar receita atualizada: %w", err)
+		return nil, err
+	}
+	payment := &models.Payment{
+		ID:     paymentID,
+		Valor:  req.Valor,
+		PagoEm: pagoEm,
+		Metodo: req.Metodo,
+		Obs:    req.Obs,
+	}
+	if err := s.applyPaymentMethod(ctx, ownerID, payment, req.MethodID); err != nil {
+		return nil, err
+	}
+	updatedIncome, err := s.incomeRepo.UpdatePaymentTx(ctx, payment, ownerID)
+	if err != nil {
+		if errors.Is(err, models.ErrInsufficientAmount) || errors.Is(err, models.ErrPaymentNotFound) ||
+			errors.Is(err, models.ErrPaymentAlreadyReversed) || errors.Is(err, models.ErrCreditPaymentLocked) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao atualizar pagamento: %w", err)
 	}
 
 	return &models.PaymentResponse{
@@ -269,16 +502,38 @@ func (s *incomeService) AddPayment(ownerID uuid.UUID, req *models.PaymentRequest
 	}, nil
 }
 
+// applyPaymentMethod resolve a forma do catálogo e grava seu nome em Metodo.
+// Docstring: sem catálogo configurado o texto livre de metodo é mantido como veio.
+func (s *incomeService) applyPaymentMethod(ctx context.Context, ownerID uuid.UUID, payment *models.Payment, methodID *uuid.UUID) error {
+	if s.methods == nil {
+		return nil
+	}
+	m, err := s.methods.Resolve(ctx, ownerID, methodID, payment.Metodo)
+	if err != nil {
+		if errors.Is(err, models.ErrPaymentMethodNotFound) || errors.Is(err, models.ErrPaymentMethodArchived) {
+			return err
+		}
+		return fmt.Errorf("erro ao resolver forma de pagamento: %w", err)
+	}
+	if m == nil {
+		payment.Metodo, payment.MethodID = nil, nil
+		return nil
+	}
+	nome := m.Nome
+	payment.Metodo, payment.MethodID = &nome, &m.ID
+	return nil
+}
+
 // GetIncomePayments busca todos os pagamentos de uma receita
-func (s *incomeService) GetIncomePayments(incomeID, ownerID uuid.UUID) ([]models.Payment, error) {
+func (s *incomeService) GetIncomePayments(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.Payment, error) {
 	// Verificar se a receita existe e pertence ao usuário
-	_, err := s.incomeRepo.GetByID(incomeID, ownerID)
+	_, err := s.incomeRepo.GetByID(ctx, incomeID, ownerID)
 	if err != nil {
 		return nil, err
 	}
 	
 	// Buscar pagamentos
-	payments, err := s.incomeRepo.GetPayments(incomeID, ownerID)
+	payments, err := s.incomeRepo.GetPayments(ctx, incomeID, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar pagamentos: %w", err)
 	}
@@ -286,8 +541,39 @@ func (s *incomeService) GetIncomePayments(incomeID, ownerID uuid.UUID) ([]models
 	return payments, nil
 }
 
-// CalculateIncomeStatus calcula o status de uma receita baseado nos pagamentos e data de vencimento
-func (s *incomeService) CalculateIncomeStatus(income *models.Income) string {
+// SimulatePayment calcula encargos e saldo de um pagamento em payDate sem registrá-lo.
+// Docstring: usa os encargos do contrato da receita (ou o padrão); loc define o dia civil de payDate.
+func (s *incomeService) SimulatePayment(ctx context.Context, id, ownerID uuid.UUID, payDate time.Time, valor *models.Money, loc *time.Location) (*models.PaymentSimulation, error) {
+	income, err := s.incomeRepo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.incomeRepo.GetFeePolicy(ctx, id, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar encargos da receita: %w", err)
+	}
+	return models.SimulatePayment(income, payDate, valor, *policy, loc)
+}
+
+// GetIncomeDetail busca a receita com o valor corrigido em at (saldo + multa e juros por atraso).
+// Docstring: os encargos seguem o contrato, depois as regras do usuário e o padrão do produto;
+// loc define o dia civil de at.
+func (s *incomeService) GetIncomeDetail(ctx context.Context, id, ownerID uuid.UUID, at time.Time, loc *time.Location) (*models.IncomeDetail, error) {
+	income, err := s.GetIncome(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.incomeRepo.GetFeePolicy(ctx, id, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar encargos da receita: %w", err)
+	}
+	return models.CorrectIncome(income, at, *policy, loc), nil
+}
+
+// CalculateIncomeStatus calcula o status de uma receita baseado nos pagamentos e data de vencimento.
+// Docstring: o vencimento é uma data; a receita só fica vencida depois da meia-noite local (loc, fuso
+// do usuário) do dia do vencimento, e não já às 21h da véspera como na comparação em UTC.
+func (s *incomeService) CalculateIncomeStatus(income *models.Income, loc *time.Location) string {
 	// Se já está pago, manter como pago
 	if income.TotalPago >= income.Valor {
 		return models.StatusPago
@@ -299,7 +585,7 @@ func (s *incomeService) CalculateIncomeStatus(income *models.Income) string {
 	}
 	
 	// Verificar se está vencido
-	if income.DueDate != nil && time.Now().After(*income.DueDate) {
+	if income.DueDate != nil && models.PastDue(*income.DueDate, s.now(), loc) {
 		return models.StatusVencido
 	}
 	
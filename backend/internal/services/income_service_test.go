@@ -6,6 +6,9 @@
 package services
 
 import (
+    "context"
+    "errors"
+    "strings"
     "testing"
     "time"
 
@@ -18,6 +21,7 @@ import (
 type fakeIncomeRepo struct {
     // Inputs capturados
     created   *models.Income
+    createdMany []*models.Income
     updated   *models.Income
     deletedID uuid.UUID
     listOwner uuid.UUID
@@ -35,11 +39,15 @@ type fakeIncomeRepo struct {
     getPaysResp     []models.Payment
     getPaysErr      error
     updateTotalErr  error
+    feePolicy       *models.FeePolicy
+    markOverdueN    int64
+    markOverdueTZ   string
 
     // Observabilidade
     addPayCalled    bool
     lastPayment     *models.Payment
     updateTotalCount int
+    addPayTxCount   int
 }
 
 func TestGetIncome_UpdatesStatusWhenOverdue(t *testing.T) {
@@ -49,15 +57,26 @@ func TestGetIncome_UpdatesStatusWhenOverdue(t *testing.T) {
     ownerID := uuid.New()
     id := uuid.New()
     yesterday := time.Now().Add(-24 * time.Hour)
-    income := &models.Income{ID: id, OwnerID: ownerID, Valor: 100, TotalPago: 0, Status: models.StatusPendente, DueDate: &yesterday}
+    income := &models.Income{ID: id, OwnerID: ownerID, Valor: models.NewMoney(100), TotalPago: 0, Status: models.StatusPendente, DueDate: &yesterday}
     repo.getByIDResp = income
 
-    got, err := svc.GetIncome(id, ownerID)
+    got, err := svc.GetIncome(context.Background(), id, ownerID)
     if err != nil { t.Fatalf("GetIncome err: %v", err) }
     if got.Status != models.StatusVencido { t.Fatalf("status = %s, want %s", got.Status, models.StatusVencido) }
     if repo.updated == nil { t.Fatalf("esperava Update ter sido chamado para persistir novo status") }
 }
 
+func TestListIncomes_ComputesStatusWithoutPersisting(t *testing.T) {
+    yesterday := time.Now().Add(-48 * time.Hour)
+    repo := &fakeIncomeRepo{listResp: []models.Income{{ID: uuid.New(), Valor: models.NewMoney(100), Status: models.StatusPendente, DueDate: &yesterday, Version: 5}}, listTotal: 1}
+    svc := NewIncomeService(repo)
+
+    out, err := svc.ListIncomes(context.Background(), uuid.New(), &models.IncomeFilter{Page: 1, PerPage: 10})
+    if err != nil { t.Fatalf("ListIncomes err: %v", err) }
+    if out.Incomes[0].Status != models.StatusVencido || out.Incomes[0].Version != 5 { t.Fatalf("receita = %+v, want vencido sem nova versão", out.Incomes[0]) }
+    if repo.updated != nil { t.Fatalf("a listagem não deveria gravar; a gravação é do job diário") }
+}
+
 func TestUpdateIncome_RecalculateStatusToPago(t *testing.T) {
     repo := &fakeIncomeRepo{}
     svc := NewIncomeService(repo)
@@ -65,11 +84,12 @@ func TestUpdateIncome_RecalculateStatusToPago(t *testing.T) {
     ownerID := uuid.New()
     id := uuid.New()
     // Receita com total pago já igual ao valor final desejado
-    existing := &models.Income{ID: id, OwnerID: ownerID, Valor: 200, TotalPago: 100, Status: models.StatusParcial}
+    existing := &models.Income{ID: id, OwnerID: ownerID, Valor: models.NewMoney(200), TotalPago: models.NewMoney(100), Status: models.StatusParcial, Version: 4}
     repo.getByIDResp = existing
 
-    req := &models.IncomeRequest{Competencia: "2025-09", Valor: 100} // ao atualizar, TotalPago(100) >= Valor(100) -> pago
-    out, err := svc.UpdateIncome(id, ownerID, req)
+    version := int64(4)
+    req := &models.IncomeRequest{Competencia: "2025-09", Valor: models.NewMoney(100), Version: &version} // ao atualizar, TotalPago(100) >= Valor(100) -> pago
+    out, err := svc.UpdateIncome(context.Background(), id, ownerID, req)
     if err != nil { t.Fatalf("UpdateIncome err: %v", err) }
     if out.Status != models.StatusPago { t.Fatalf("status = %s, want %s", out.Status, models.StatusPago) }
     if repo.updated == nil { t.Fatalf("esperava Update ter sido chamado") }
@@ -78,31 +98,55 @@ func TestUpdateIncome_RecalculateStatusToPago(t *testing.T) {
 func TestAddPayment_ExceedsSaldo(t *testing.T) {
     ownerID := uuid.New()
     incomeID := uuid.New()
-    existing := &models.Income{ID: incomeID, OwnerID: ownerID, Valor: 100, TotalPago: 80}
+    existing := &models.Income{ID: incomeID, OwnerID: ownerID, Valor: models.NewMoney(100), TotalPago: models.NewMoney(80)}
 
     repo := &fakeIncomeRepo{getByIDResp: existing}
     svc := NewIncomeService(repo)
 
-    req := &models.PaymentRequest{IncomeID: incomeID, Valor: 30}
-    if _, err := svc.AddPayment(ownerID, req); err == nil {
+    req := &models.PaymentRequest{IncomeID: incomeID, Valor: models.NewMoney(30)}
+    if _, err := svc.AddPayment(context.Background(), ownerID, req); err == nil {
         t.Fatalf("esperava erro de valor excedente")
     }
 }
 
-func (f *fakeIncomeRepo) Create(income *models.Income) error { f.created = income; return nil }
-func (f *fakeIncomeRepo) GetByID(id, ownerID uuid.UUID) (*models.Income, error) {
+func (f *fakeIncomeRepo) Create(ctx context.Context, income *models.Income) error { f.created = income; return nil }
+func (f *fakeIncomeRepo) CreateMany(ctx context.Context, incomes []*models.Income) error { f.createdMany = incomes; return nil }
+func (f *fakeIncomeRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Income, error) {
     if f.getByIDFn != nil { return f.getByIDFn(id, ownerID) }
     return f.getByIDResp, f.getByIDErr
 }
-func (f *fakeIncomeRepo) Update(income *models.Income) error { f.updated = income; return nil }
-func (f *fakeIncomeRepo) Delete(id, ownerID uuid.UUID) error { f.deletedID = id; return nil }
-func (f *fakeIncomeRepo) List(ownerID uuid.UUID, filter *models.IncomeFilter) ([]models.Income, int, error) {
+func (f *fakeIncomeRepo) Update(ctx context.Context, income *models.Income) error { f.updated = income; return nil }
+func (f *fakeIncomeRepo) Delete(ctx context.Context, id, ownerID uuid.UUID) error { f.deletedID = id; return nil }
+func (f *fakeIncomeRepo) List(ctx context.Context, ownerID uuid.UUID, filter *models.IncomeFilter) ([]models.Income, int, error) {
     f.listOwner = ownerID
     return f.listResp, f.listTotal, f.listErr
 }
-func (f *fakeIncomeRepo) AddPayment(payment *models.Payment) error { f.addPayCalled = true; f.lastPayment = payment; return f.addPayErr }
-func (f *fakeIncomeRepo) GetPayments(incomeID, ownerID uuid.UUID) ([]models.Payment, error) { return f.getPaysResp, f.getPaysErr }
-func (f *fakeIncomeRepo) UpdateTotalPago(incomeID uuid.UUID) error { f.updateTotalCount++; return f.updateTotalErr }
+func (f *fakeIncomeRepo) AddPayment(ctx context.Context, payment *models.Payment) error { f.addPayCalled = true; f.lastPayment = payment; return f.addPayErr }
+func (f *fakeIncomeRepo) AddPaymentTx(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, error) {
+    f.addPayCalled, f.lastPayment = true, payment
+    if f.addPayErr != nil { return nil, f.addPayErr }
+    f.addPayTxCount++
+    return f.GetByID(ctx, payment.IncomeID, ownerID)
+}
+func (f *fakeIncomeRepo) UpdatePaymentTx(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, error) {
+    f.lastPayment = payment
+    if f.addPayErr != nil { return nil, f.addPayErr }
+    return f.getByIDResp, f.getByIDErr
+}
+func (f *fakeIncomeRepo) GetPayments(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.Payment, error) { return f.getPaysResp, f.getPaysErr }
+func (f *fakeIncomeRepo) UpdateTotalPago(ctx context.Context, incomeID uuid.UUID) error { f.updateTotalCount++; return f.updateTotalErr }
+func (f *fakeIncomeRepo) GetFeePolicy(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.FeePolicy, error) {
+    if f.feePolicy != nil { return f.feePolicy, nil }
+    p := models.DefaultFeePolicy
+    return &p, nil
+}
+func (f *fakeIncomeRepo) MarkOverdue(ctx context.Context, now time.Time, defaultTimezone string) (int64, error) {
+    f.markOverdueTZ = defaultTimezone
+    return f.markOverdueN, nil
+}
+func (f *fakeIncomeRepo) Stats(ctx context.Context, ownerID uuid.UUID) (*models.IncomeStats, error) {
+    return &models.IncomeStats{}, nil
+}
 
 func TestCreateIncome_DefaultStatusAndDueDate(t *testing.T) {
     repo := &fakeIncomeRepo{}
@@ -115,12 +159,12 @@ func TestCreateIncome_DefaultStatusAndDueDate(t *testing.T) {
     req := &models.IncomeRequest{
         Categoria:   &cat,
         Competencia: "2025-09",
-        Valor:       150.0,
+        Valor:       models.NewMoney(150),
         // Status em branco deve virar pendente
         DueDate: &due,
     }
 
-    income, err := svc.CreateIncome(ownerID, req)
+    income, err := svc.CreateIncome(context.Background(), ownerID, req)
     if err != nil { t.Fatalf("CreateIncome err: %v", err) }
     if income.Status != models.StatusPendente { t.Fatalf("status = %s, want %s", income.Status, models.StatusPendente) }
     if repo.created == nil { t.Fatalf("esperava Create ter sido chamado") }
@@ -134,12 +178,80 @@ func TestCreateIncome_InvalidDate(t *testing.T) {
     ownerID := uuid.New()
     badDate := "2025/09/01" // formato inválido
 
-    req := &models.IncomeRequest{Competencia: "2025-09", Valor: 100, DueDate: &badDate}
-    if _, err := svc.CreateIncome(ownerID, req); err == nil {
+    req := &models.IncomeRequest{Competencia: "2025-09", Valor: models.NewMoney(100), DueDate: &badDate}
+    if _, err := svc.CreateIncome(context.Background(), ownerID, req); err == nil {
         t.Fatalf("esperava erro de formato de data")
     }
 }
 
+func TestDuplicateIncome_CopiesToNextCompetencia(t *testing.T) {
+    ownerID := uuid.New()
+    payer := uuid.New()
+    cat := "Consultoria"
+    src := &models.Income{ID: uuid.New(), OwnerID: ownerID, PayerID: &payer, Categoria: &cat, Competencia: "2026-09",
+        Valor: models.NewMoney(800), Status: models.StatusPago, TotalPago: models.NewMoney(800)}
+    repo := &fakeIncomeRepo{getByIDResp: src}
+    svc := NewIncomeService(repo)
+
+    income, err := svc.DuplicateIncome(context.Background(), src.ID, ownerID, &models.IncomeCopyRequest{})
+    if err != nil { t.Fatalf("DuplicateIncome err: %v", err) }
+    if repo.created == nil || income.ID == src.ID { t.Fatalf("esperava nova receita criada") }
+    if income.Competencia != "2026-10" || income.Valor != models.NewMoney(800) || *income.PayerID != payer { t.Fatalf("cópia inesperada: %+v", income) }
+    if income.Status != models.StatusPendente || income.TotalPago != 0 { t.Fatalf("status/pagamentos não deveriam ser copiados") }
+}
+
+func TestPatchIncome_KeepsOmittedFields(t *testing.T) {
+    ownerID := uuid.New()
+    cat := "Aluguel"
+    due := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+    existing := &models.Income{ID: uuid.New(), OwnerID: ownerID, Categoria: &cat, Competencia: "2026-10", Valor: models.NewMoney(1000),
+        Status: models.StatusPendente, DueDate: &due, Tags: []string{"apto 12"}, Version: 2}
+    repo := &fakeIncomeRepo{getByIDResp: existing}
+    svc := NewIncomeService(repo)
+
+    version := int64(2)
+    valor := models.NewMoney(1100)
+    income, err := svc.PatchIncome(context.Background(), existing.ID, ownerID, &models.IncomePatchRequest{Valor: &valor, Version: &version})
+    if err != nil { t.Fatalf("PatchIncome err: %v", err) }
+    if income.Valor != models.NewMoney(1100) || income.Categoria == nil || *income.Categoria != "Aluguel" || income.DueDate == nil || len(income.Tags) != 1 {
+        t.Fatalf("campos omitidos não deveriam mudar: %+v", income)
+    }
+
+    empty := ""
+    income, err = svc.PatchIncome(context.Background(), existing.ID, ownerID, &models.IncomePatchRequest{Categoria: &empty, DueDate: &empty, Version: &version})
+    if err != nil { t.Fatalf("PatchIncome err: %v", err) }
+    if income.Categoria != nil || income.DueDate != nil { t.Fatalf("string vazia deveria limpar categoria e vencimento") }
+
+    bad := "2026/10/01"
+    if _, err := svc.PatchIncome(context.Background(), existing.ID, ownerID, &models.IncomePatchRequest{DueDate: &bad, Version: &version}); err != models.ErrInvalidDateFormat {
+        t.Fatalf("err = %v, want ErrInvalidDateFormat", err)
+    }
+}
+
+func TestUpdateIncome_VersionRequiredAndConflict(t *testing.T) {
+    ownerID := uuid.New()
+    existing := &models.Income{ID: uuid.New(), OwnerID: ownerID, Competencia: "2026-10", Valor: models.NewMoney(500), Status: models.StatusPendente, Version: 7}
+    repo := &fakeIncomeRepo{getByIDResp: existing}
+    svc := NewIncomeService(repo)
+
+    req := &models.IncomeRequest{Competencia: "2026-10", Valor: models.NewMoney(600)}
+    if _, err := svc.UpdateIncome(context.Background(), existing.ID, ownerID, req); err != models.ErrIncomeVersionRequired {
+        t.Fatalf("err = %v, want ErrIncomeVersionRequired", err)
+    }
+
+    stale := int64(6)
+    req.Version = &stale
+    _, err := svc.UpdateIncome(context.Background(), existing.ID, ownerID, req)
+    var conflict *models.IncomeVersionConflictError
+    if !errors.As(err, &conflict) || conflict.Current.Version != 7 { t.Fatalf("err = %v, want conflito com a versão atual", err) }
+    if repo.updated != nil { t.Fatalf("não deveria gravar com versão divergente") }
+
+    valor := models.NewMoney(700)
+    if _, err := svc.PatchIncome(context.Background(), existing.ID, ownerID, &models.IncomePatchRequest{Valor: &valor, Version: &stale}); !errors.Is(err, models.ErrIncomeVersionConflict) {
+        t.Fatalf("PATCH err = %v, want ErrIncomeVersionConflict", err)
+    }
+}
+
 func TestCalculateIncomeStatus(t *testing.T) {
     repo := &fakeIncomeRepo{}
     svc := NewIncomeService(repo)
@@ -151,35 +263,52 @@ func TestCalculateIncomeStatus(t *testing.T) {
         in   models.Income
         want string
     }{
-        {in: models.Income{Valor: 100, TotalPago: 100}, want: models.StatusPago},
-        {in: models.Income{Valor: 200, TotalPago: 50}, want: models.StatusParcial},
-        {in: models.Income{Valor: 100, TotalPago: 0, DueDate: &[]time.Time{yesterday}[0]}, want: models.StatusVencido},
-        {in: models.Income{Valor: 100, TotalPago: 0}, want: models.StatusPendente},
+        {in: models.Income{Valor: models.NewMoney(100), TotalPago: models.NewMoney(100)}, want: models.StatusPago},
+        {in: models.Income{Valor: models.NewMoney(200), TotalPago: models.NewMoney(50)}, want: models.StatusParcial},
+        {in: models.Income{Valor: models.NewMoney(100), TotalPago: 0, DueDate: &[]time.Time{yesterday}[0]}, want: models.StatusVencido},
+        {in: models.Income{Valor: models.NewMoney(100), TotalPago: 0}, want: models.StatusPendente},
     }
 
     for i, c := range cases {
-        got := svc.CalculateIncomeStatus(&c.in)
+        got := svc.CalculateIncomeStatus(&c.in, time.UTC)
         if got != c.want {
             t.Fatalf("case %d: got %s, want %s", i, got, c.want)
         }
     }
 }
 
+func TestCalculateIncomeStatus_LocalMidnight(t *testing.T) {
+    sp, err := time.LoadLocation("America/Sao_Paulo")
+    if err != nil { t.Skipf("tzdata indisponível: %v", err) }
+    svc := NewIncomeService(&fakeIncomeRepo{}).(*incomeService)
+    due := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+    in := models.Income{Valor: models.NewMoney(100), DueDate: &due}
+
+    // 21h30 de 18/10 em São Paulo (00h30 de 19/10 em UTC): vence hoje, ainda pendente
+    svc.now = func() time.Time { return time.Date(2026, 10, 19, 0, 30, 0, 0, time.UTC) }
+    if got := svc.CalculateIncomeStatus(&in, sp); got != models.StatusPendente { t.Fatalf("antes da meia-noite local: %s", got) }
+    // Em UTC o mesmo instante já é o dia seguinte ao vencimento
+    if got := svc.CalculateIncomeStatus(&in, time.UTC); got != models.StatusVencido { t.Fatalf("fuso UTC: %s", got) }
+
+    svc.now = func() time.Time { return time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC) }
+    if got := svc.CalculateIncomeStatus(&in, sp); got != models.StatusVencido { t.Fatalf("após a meia-noite local: %s", got) }
+}
+
 func TestAddPayment_SuccessFlow(t *testing.T) {
     // Receita com saldo devedor
     ownerID := uuid.New()
     incomeID := uuid.New()
-    existing := &models.Income{ID: incomeID, OwnerID: ownerID, Valor: 200, TotalPago: 50, Status: models.StatusParcial}
+    existing := &models.Income{ID: incomeID, OwnerID: ownerID, Valor: models.NewMoney(200), TotalPago: models.NewMoney(50), Status: models.StatusParcial}
 
     repo := &fakeIncomeRepo{getByIDResp: existing}
     svc := NewIncomeService(repo)
 
     pago := time.Now().UTC().Format(time.RFC3339)
-    req := &models.PaymentRequest{IncomeID: incomeID, Valor: 50, PagoEm: &pago}
+    req := &models.PaymentRequest{IncomeID: incomeID, Valor: models.NewMoney(50), PagoEm: &pago}
 
-    // Após AddPayment e UpdateTotalPago, GetByID é chamado novamente no serviço; vamos mudar a resposta
+    // AddPaymentTx devolve a receita relida na transação; vamos mudar a resposta
     repo2Resp := *existing
-    repo2Resp.TotalPago = 100
+    repo2Resp.TotalPago = models.NewMoney(100)
     repo2Resp.Status = models.StatusParcial
 
     call := 0
@@ -189,10 +318,93 @@ func TestAddPayment_SuccessFlow(t *testing.T) {
         return &repo2Resp, nil
     }
 
-    resp, err := svc.AddPayment(ownerID, req)
+    resp, err := svc.AddPayment(context.Background(), ownerID, req)
     if err != nil { t.Fatalf("AddPayment err: %v", err) }
     if !repo.addPayCalled { t.Fatalf("esperava AddPayment ter sido chamado") }
-    if repo.updateTotalCount != 1 { t.Fatalf("UpdateTotalPago chamado %d, want 1", repo.updateTotalCount) }
-    if resp.Payment.Valor != 50 { t.Fatalf("payment valor = %v, want 50", resp.Payment.Valor) }
-    if resp.Income.TotalPago != 100 { t.Fatalf("income total_pago = %v, want 100", resp.Income.TotalPago) }
+    if repo.addPayTxCount != 1 { t.Fatalf("AddPaymentTx chamado %d, want 1", repo.addPayTxCount) }
+    if repo.updateTotalCount != 0 { t.Fatalf("UpdateTotalPago não deve ser chamado fora da transação") }
+    if resp.Payment.Valor != models.NewMoney(50) { t.Fatalf("payment valor = %v, want 50", resp.Payment.Valor) }
+    if resp.Income.TotalPago != models.NewMoney(100) { t.Fatalf("income total_pago = %v, want 100", resp.Income.TotalPago) }
+}
+
+func TestAddPayment_TxErrorsPropagate(t *testing.T) {
+    ownerID := uuid.New()
+    existing := &models.Income{ID: uuid.New(), OwnerID: ownerID, Valor: models.NewMoney(100)}
+    // Outro pagamento concorrente consumiu o saldo entre a leitura e a transação
+    repo := &fakeIncomeRepo{getByIDResp: existing, addPayErr: models.ErrInsufficientAmount}
+    svc := NewIncomeService(repo)
+
+    _, err := svc.AddPayment(context.Background(), ownerID, &models.PaymentRequest{IncomeID: existing.ID, Valor: models.NewMoney(40)})
+    if err != models.ErrInsufficientAmount { t.Fatalf("err = %v, want ErrInsufficientAmount sem embrulho", err) }
+
+    repo.addPayErr = errors.New("conexão perdida")
+    if _, err := svc.AddPayment(context.Background(), ownerID, &models.PaymentRequest{IncomeID: existing.ID, Valor: models.NewMoney(40)}); err == nil || !strings.Contains(err.Error(), "erro ao adicionar pagamento") {
+        t.Fatalf("err = %v", err)
+    }
+}
+
+func TestUpdatePayment_ValidatesAndPropagates(t *testing.T) {
+    ownerID := uuid.New()
+    existing := &models.Income{ID: uuid.New(), OwnerID: ownerID, Valor: models.NewMoney(100), TotalPago: models.NewMoney(40)}
+    repo := &fakeIncomeRepo{getByIDResp: existing}
+    svc := NewIncomeService(repo)
+
+    if _, err := svc.UpdatePayment(context.Background(), uuid.New(), ownerID, &models.PaymentUpdateRequest{Valor: models.NewMoney(40), PagoEm: "18/10/2026"}); err != models.ErrInvalidDateFormat {
+        t.Fatalf("data inválida: err = %v", err)
+    }
+    if _, err := svc.UpdatePayment(context.Background(), uuid.New(), ownerID, &models.PaymentUpdateRequest{PagoEm: "2026-10-18T10:00:00Z"}); err != models.ErrValorInvalid {
+        t.Fatalf("valor zero: err = %v", err)
+    }
+
+    paymentID := uuid.New()
+    metodo := "pix"
+    resp, err := svc.UpdatePayment(context.Background(), paymentID, ownerID, &models.PaymentUpdateRequest{Valor: models.NewMoney(40), PagoEm: "2026-10-18T10:00:00Z", Metodo: &metodo})
+    if err != nil { t.Fatalf("UpdatePayment: %v", err) }
+    if repo.lastPayment.ID != paymentID || *repo.lastPayment.Metodo != "pix" || repo.lastPayment.PagoEm.Day() != 18 {
+        t.Fatalf("pagamento enviado ao repositório = %+v", repo.lastPayment)
+    }
+    if resp.Income.ID != existing.ID { t.Fatalf("receita = %v", resp.Income.ID) }
+
+    repo.addPayErr = models.ErrInsufficientAmount
+    if _, err := svc.UpdatePayment(context.Background(), paymentID, ownerID, &models.PaymentUpdateRequest{Valor: models.NewMoney(90), PagoEm: "2026-10-18T10:00:00Z"}); err != models.ErrInsufficientAmount {
+        t.Fatalf("err = %v, want ErrInsufficientAmount sem embrulho", err)
+    }
+}
+
+// fakeCreditRepo implementa repositories.CreditRepository registrando o pagamento com excedente
+type fakeCreditRepo struct {
+    calls int
+}
+
+func (f *fakeCreditRepo) AddPaymentWithCredit(ctx context.Context, payment *models.Payment, ownerID uuid.UUID) (*models.Income, *models.Credit, error) {
+    f.calls++
+    return &models.Income{ID: payment.IncomeID, TotalPago: models.NewMoney(100)}, &models.Credit{Valor: models.NewMoney(20), Saldo: models.NewMoney(20)}, nil
+}
+func (f *fakeCreditRepo) List(ctx context.Context, ownerID uuid.UUID, onlyAvailable bool) ([]models.Credit, error) { return nil, nil }
+func (f *fakeCreditRepo) Apply(ctx context.Context, creditID, ownerID uuid.UUID, req *models.CreditApplyRequest) (*models.CreditApplyResponse, error) {
+    return nil, nil
+}
+
+func TestAddPayment_OverpaymentCreditMode(t *testing.T) {
+    ownerID := uuid.New()
+    existing := &models.Income{ID: uuid.New(), OwnerID: ownerID, Valor: models.NewMoney(100), TotalPago: models.NewMoney(20)}
+    repo := &fakeIncomeRepo{getByIDResp: existing}
+    credits := &fakeCreditRepo{}
+    svc := NewIncomeService(repo, WithCredits(credits))
+
+    // sem o modo, o excedente continua sendo recusado
+    req := &models.PaymentRequest{IncomeID: existing.ID, Valor: models.NewMoney(100)}
+    if _, err := svc.AddPayment(context.Background(), ownerID, req); err != models.ErrInsufficientAmount { t.Fatalf("err = %v", err) }
+
+    req.Overpayment = models.OverpaymentCredit
+    resp, err := svc.AddPayment(context.Background(), ownerID, req)
+    if err != nil { t.Fatalf("AddPayment: %v", err) }
+    if credits.calls != 1 || repo.addPayTxCount != 0 { t.Fatalf("crédito=%d tx=%d: excedente deveria ir ao repositório de créditos", credits.calls, repo.addPayTxCount) }
+    if resp.Credit == nil || resp.Credit.Saldo != models.NewMoney(20) { t.Fatalf("crédito = %+v", resp.Credit) }
+
+    // dentro do saldo, o modo crédito usa o caminho normal
+    req.Valor = models.NewMoney(50)
+    if resp, err := svc.AddPayment(context.Background(), ownerID, req); err != nil || resp.Credit != nil || repo.addPayTxCount != 1 {
+        t.Fatalf("pagamento dentro do saldo: resp=%+v err=%v", resp, err)
+    }
 }
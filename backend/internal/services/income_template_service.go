@@ -0,0 +1,147 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Serviço dos modelos de receita (cadastro e geração de receitas a partir do modelo)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// IncomeTemplateService interface para o gerenciamento de modelos de receita
+type IncomeTemplateService interface {
+	CreateTemplate(ctx context.Context, ownerID uuid.UUID, req *models.IncomeTemplateRequest) (*models.IncomeTemplate, error)
+	CreateTemplateFromIncome(ctx context.Context, incomeID, ownerID uuid.UUID, nome string) (*models.IncomeTemplate, error)
+	GetTemplate(ctx context.Context, id, ownerID uuid.UUID) (*models.IncomeTemplate, error)
+	UpdateTemplate(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeTemplateRequest) (*models.IncomeTemplate, error)
+	DeleteTemplate(ctx context.Context, id, ownerID uuid.UUID) error
+	ListTemplates(ctx context.Context, ownerID uuid.UUID) ([]models.IncomeTemplate, error)
+	Instantiate(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeCopyRequest) (*models.Income, error)
+}
+
+// DueDateAdjuster ajusta o vencimento calculado de uma receita recorrente (implementado por
+// HolidayService, que o leva ao próximo dia útil quando o usuário ligou a opção)
+type DueDateAdjuster interface {
+	AdjustDueDate(ctx context.Context, ownerID uuid.UUID, due time.Time) (time.Time, error)
+}
+
+type incomeTemplateService struct {
+	repo     repositories.IncomeTemplateRepository
+	incomes  IncomeService
+	dueDates DueDateAdjuster
+}
+
+// NewIncomeTemplateService cria uma nova instância do serviço de modelos.
+// Docstring: receitas geradas passam pelo IncomeService (validação e regras de categorização);
+// dueDates pode ser nil (vencimento sempre no dia do modelo).
+func NewIncomeTemplateService(repo repositories.IncomeTemplateRepository, incomes IncomeService, dueDates DueDateAdjuster) IncomeTemplateService {
+	return &incomeTemplateService{repo: repo, incomes: incomes, dueDates: dueDates}
+}
+
+// CreateTemplate valida e cadastra um modelo
+func (s *incomeTemplateService) CreateTemplate(ctx context.Context, ownerID uuid.UUID, req *models.IncomeTemplateRequest) (*models.IncomeTemplate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	t := &models.IncomeTemplate{OwnerID: ownerID}
+	applyTemplateRequest(t, req)
+	if err := s.repo.Create(ctx, t); err != nil {
+		return nil, fmt.Errorf("erro ao criar modelo: %w", err)
+	}
+	return t, nil
+}
+
+// CreateTemplateFromIncome salva uma receita existente como modelo; o dia de vencimento vem da receita
+func (s *incomeTemplateService) CreateTemplateFromIncome(ctx context.Context, incomeID, ownerID uuid.UUID, nome string) (*models.IncomeTemplate, error) {
+	in, err := s.incomes.GetIncome(ctx, incomeID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	req := &models.IncomeTemplateRequest{
+		Nome:       nome,
+		PayerID:    in.PayerID,
+		ContractID: in.ContractID,
+		Categoria:  in.Categoria,
+		Tags:       in.Tags,
+		Valor:      in.Valor,
+	}
+	if req.Nome == "" && in.Categoria != nil {
+		req.Nome = *in.Categoria
+	}
+	if in.DueDate != nil {
+		day := in.DueDate.Day()
+		req.DueDay = &day
+	}
+	return s.CreateTemplate(ctx, ownerID, req)
+}
+
+// GetTemplate busca um modelo do usuário
+func (s *incomeTemplateService) GetTemplate(ctx context.Context, id, ownerID uuid.UUID) (*models.IncomeTemplate, error) {
+	return s.repo.GetByID(ctx, id, ownerID)
+}
+
+// UpdateTemplate substitui os dados de um modelo
+func (s *incomeTemplateService) UpdateTemplate(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeTemplateRequest) (*models.IncomeTemplate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	t := &models.IncomeTemplate{ID: id, OwnerID: ownerID}
+	applyTemplateRequest(t, req)
+	if err := s.repo.Update(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeleteTemplate remove um modelo; receitas já geradas não são afetadas
+func (s *incomeTemplateService) DeleteTemplate(ctx context.Context, id, ownerID uuid.UUID) error {
+	return s.repo.Delete(ctx, id, ownerID)
+}
+
+// ListTemplates lista os modelos do usuário
+func (s *incomeTemplateService) ListTemplates(ctx context.Context, ownerID uuid.UUID) ([]models.IncomeTemplate, error) {
+	return s.repo.List(ctx, ownerID)
+}
+
+// Instantiate gera uma receita a partir do modelo para a competência informada.
+// Docstring: o vencimento calculado pelo dia do modelo pode ser levado ao próximo dia útil; um
+// due_date informado na requisição é usado como veio.
+func (s *incomeTemplateService) Instantiate(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeCopyRequest) (*models.Income, error) {
+	t, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	incomeReq, err := t.IncomeRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if s.dueDates != nil && req.DueDate == nil && incomeReq.DueDate != nil {
+		due, err := time.Parse(time.RFC3339, *incomeReq.DueDate)
+		if err != nil {
+			return nil, models.ErrInvalidDateFormat
+		}
+		if due, err = s.dueDates.AdjustDueDate(ctx, ownerID, due); err != nil {
+			return nil, err
+		}
+		adjusted := due.Format(time.RFC3339)
+		incomeReq.DueDate = &adjusted
+	}
+	return s.incomes.CreateIncome(ctx, ownerID, incomeReq)
+}
+
+func applyTemplateRequest(t *models.IncomeTemplate, req *models.IncomeTemplateRequest) {
+	t.Nome = req.Nome
+	t.PayerID = req.PayerID
+	t.ContractID = req.ContractID
+	t.Categoria = req.Categoria
+	t.Tags = req.Tags
+	t.Valor = req.Valor
+	t.DueDay = req.DueDay
+}
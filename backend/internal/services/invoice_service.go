@@ -0,0 +1,191 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: NFS-e das receitas pagas: monta o RPS, gera o XML ABRASF e envia à prefeitura quando há integração
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/nfse"
+	"recibofast/internal/repositories"
+)
+
+// InvoiceService gera a NFS-e (RPS em XML ABRASF) de receitas pagas.
+// Docstring: cada receita tem no máximo uma nota; gerar de novo devolve a existente. O número do
+// RPS é reservado na sequência do usuário antes da gravação. Sem integração municipal (provider
+// nil) a nota fica como XML para download e importação no portal da prefeitura.
+type InvoiceService struct {
+	invoices repositories.InvoiceRepository
+	incomes  repositories.IncomeRepository
+	payers   repositories.PayerRepository
+	settings repositories.SettingsRepository
+	provider nfse.Provider
+	log      logging.Logger
+	now      func() time.Time
+}
+
+// NewInvoiceService cria o serviço de NFS-e; provider pode ser nil (somente exportação)
+func NewInvoiceService(invoices repositories.InvoiceRepository, incomes repositories.IncomeRepository, payers repositories.PayerRepository,
+	settings repositories.SettingsRepository, provider nfse.Provider, log logging.Logger) *InvoiceService {
+	return &InvoiceService{invoices: invoices, incomes: incomes, payers: payers, settings: settings, provider: provider, log: log, now: time.Now}
+}
+
+// Generate gera a nota da receita; created indica se ela foi criada agora
+func (s *InvoiceService) Generate(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.Invoice, bool, error) {
+	if inv, err := s.invoices.GetByIncome(ctx, incomeID, ownerID); err == nil {
+		return inv, false, nil
+	} else if !errors.Is(err, models.ErrInvoiceNotFound) {
+		return nil, false, fmt.Errorf("erro ao consultar nota fiscal: %w", err)
+	}
+	income, err := s.incomes.GetByID(ctx, incomeID, ownerID)
+	if err != nil {
+		return nil, false, err
+	}
+	if income.Status != models.StatusPago {
+		return nil, false, models.ErrInvoiceIncomeNotPaid
+	}
+	issuer, err := s.settings.GetInvoiceIssuer(ctx, ownerID)
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao buscar dados do prestador: %w", err)
+	}
+	if !issuer.Complete() {
+		return nil, false, models.ErrInvoiceIssuerIncomplete
+	}
+
+	doc, err := s.document(ctx, income, issuer)
+	if err != nil {
+		return nil, false, err
+	}
+	if doc.RPSNumero, err = s.invoices.NextRPSNumber(ctx, ownerID); err != nil {
+		return nil, false, err
+	}
+	payload, err := nfse.BuildABRASF(doc)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", models.ErrInvoiceIssuerIncomplete, err)
+	}
+	inv := &models.Invoice{OwnerID: ownerID, IncomeID: incomeID, RPSSerie: doc.RPSSerie, RPSNumero: doc.RPSNumero,
+		Valor: models.Money(doc.ValorServicos), Status: models.InvoiceGenerated, XML: string(payload)}
+	if s.provider != nil {
+		name := s.provider.Name()
+		inv.Provider = &name
+	}
+	if err := s.invoices.Create(ctx, inv); err != nil {
+		if errors.Is(err, models.ErrInvoiceExists) {
+			// Outra requisição gerou a nota ao mesmo tempo; o número reservado fica sem uso
+			existing, gerr := s.invoices.GetByIncome(ctx, incomeID, ownerID)
+			return existing, false, gerr
+		}
+		return nil, false, fmt.Errorf("erro ao gravar nota fiscal: %w", err)
+	}
+	if s.provider != nil {
+		s.submit(ctx, inv, doc, payload)
+	}
+	return inv, true, nil
+}
+
+// submit envia o RPS à prefeitura; falhas ficam registradas na nota, que continua disponível em XML
+func (s *InvoiceService) submit(ctx context.Context, inv *models.Invoice, doc *nfse.Document, payload []byte) {
+	res, err := s.provider.Submit(ctx, doc, payload)
+	switch {
+	case err != nil:
+		msg := err.Error()
+		inv.Status, inv.Erro = models.InvoiceFailed, &msg
+		s.log.Warn("prefeitura recusou o RPS", logging.Field{Key: "invoice_id", Val: inv.ID.String()},
+			logging.Field{Key: "provider", Val: s.provider.Name()}, logging.Field{Key: "error", Val: msg})
+	case res.Numero != "":
+		inv.Status = models.InvoiceIssued
+		inv.Numero, inv.CodigoVerificacao, inv.Protocolo = &res.Numero, optionalString(res.CodigoVerificacao), optionalString(res.Protocolo)
+	default:
+		inv.Status, inv.Protocolo = models.InvoiceSubmitted, optionalString(res.Protocolo)
+	}
+	if err := s.invoices.UpdateResult(context.WithoutCancel(ctx), inv); err != nil {
+		s.log.Error("erro ao gravar retorno da NFS-e", logging.Field{Key: "invoice_id", Val: inv.ID.String()},
+			logging.Field{Key: "error", Val: err.Error()})
+	}
+}
+
+// document monta o RPS: prestador das configurações, tomador do pagador e valor da receita
+func (s *InvoiceService) document(ctx context.Context, income *models.Income, issuer *models.InvoiceIssuer) (*nfse.Document, error) {
+	competencia, err := time.Parse("2006-01", income.Competencia)
+	if err != nil {
+		competencia = s.now()
+	}
+	serie := strings.TrimSpace(derefString(issuer.SerieRPS))
+	if serie == "" {
+		serie = models.DefaultRPSSerie
+	}
+	doc := &nfse.Document{
+		RPSSerie:    serie,
+		DataEmissao: s.now(),
+		Competencia: competencia,
+		Prestador: nfse.Party{Documento: models.NormalizeDocument(derefString(issuer.Documento)), InscricaoMunicipal: strings.TrimSpace(derefString(issuer.InscricaoMunicipal)),
+			RazaoSocial: derefString(issuer.RazaoSocial)},
+		ValorServicos:    int64(income.Valor),
+		AliquotaISS:      *issuer.AliquotaISS,
+		ItemListaServico: strings.TrimSpace(derefString(issuer.ItemListaServico)),
+		CodigoTributacao: strings.TrimSpace(derefString(issuer.CodigoTributacao)),
+		CodigoMunicipio:  strings.TrimSpace(derefString(issuer.CodigoMunicipio)),
+		Discriminacao:    pixDescription(income),
+		OptanteSimples:   issuer.OptanteSimples != nil && *issuer.OptanteSimples,
+	}
+	if income.PayerID != nil {
+		payer, err := s.payers.GetByID(ctx, *income.PayerID, income.OwnerID)
+		if err != nil && !errors.Is(err, models.ErrPayerNotFound) {
+			return nil, fmt.Errorf("erro ao buscar pagador: %w", err)
+		}
+		if payer != nil {
+			doc.Tomador = nfse.Party{Documento: models.NormalizeDocument(derefString(payer.Documento)), RazaoSocial: payer.Nome,
+				Email: derefString(payer.Email)}
+		}
+	}
+	return doc, nil
+}
+
+// Get nota do usuário
+func (s *InvoiceService) Get(ctx context.Context, id, ownerID uuid.UUID) (*models.Invoice, error) {
+	return s.invoices.GetByID(ctx, id, ownerID)
+}
+
+// List notas do usuário
+func (s *InvoiceService) List(ctx context.Context, ownerID uuid.UUID) ([]models.Invoice, error) {
+	items, err := s.invoices.List(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []models.Invoice{}
+	}
+	return items, nil
+}
+
+// GetIssuer dados do prestador configurados pelo usuário
+func (s *InvoiceService) GetIssuer(ctx context.Context, ownerID uuid.UUID) (*models.InvoiceIssuer, error) {
+	return s.settings.GetInvoiceIssuer(ctx, ownerID)
+}
+
+// UpdateIssuer valida e grava os dados do prestador
+func (s *InvoiceService) UpdateIssuer(ctx context.Context, ownerID uuid.UUID, issuer *models.InvoiceIssuer) (*models.InvoiceIssuer, error) {
+	if err := issuer.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.settings.UpdateInvoiceIssuer(ctx, ownerID, issuer); err != nil {
+		return nil, err
+	}
+	return s.settings.GetInvoiceIssuer(ctx, ownerID)
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,123 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes das NFS-e (geração do RPS a partir da receita paga e envio à prefeitura)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/nfse"
+    "recibofast/internal/repositories"
+)
+
+type fakeInvoiceRepo struct {
+    repositories.InvoiceRepository
+    items   []models.Invoice
+    nextRPS int64
+}
+
+func (f *fakeInvoiceRepo) NextRPSNumber(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+    f.nextRPS++
+    return f.nextRPS, nil
+}
+func (f *fakeInvoiceRepo) Create(ctx context.Context, inv *models.Invoice) error {
+    for _, it := range f.items {
+        if it.IncomeID == inv.IncomeID { return models.ErrInvoiceExists }
+    }
+    inv.ID = uuid.New()
+    f.items = append(f.items, *inv)
+    return nil
+}
+func (f *fakeInvoiceRepo) GetByIncome(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.Invoice, error) {
+    for _, it := range f.items {
+        if it.IncomeID == incomeID && it.OwnerID == ownerID { return &it, nil }
+    }
+    return nil, models.ErrInvoiceNotFound
+}
+func (f *fakeInvoiceRepo) UpdateResult(ctx context.Context, inv *models.Invoice) error {
+    for i := range f.items {
+        if f.items[i].ID == inv.ID { f.items[i] = *inv }
+    }
+    return nil
+}
+
+type fakeNFSeProvider struct {
+    docs []nfse.Document
+    res  *nfse.Result
+    err  error
+}
+
+func (p *fakeNFSeProvider) Name() string { return "teste" }
+func (p *fakeNFSeProvider) Submit(ctx context.Context, doc *nfse.Document, payload []byte) (*nfse.Result, error) {
+    p.docs = append(p.docs, *doc)
+    return p.res, p.err
+}
+
+func completeIssuer() models.InvoiceIssuer {
+    doc, im, mun, item, aliq := "11.222.333/0001-81", "12345", "3550308", "17.01", 2.0
+    return models.InvoiceIssuer{Documento: &doc, InscricaoMunicipal: &im, CodigoMunicipio: &mun, ItemListaServico: &item, AliquotaISS: &aliq}
+}
+
+func TestInvoiceGenerate_ExportsXMLOncePerIncome(t *testing.T) {
+    ownerID, payerID := uuid.New(), uuid.New()
+    categoria, email, doc := "Consultoria", "maria@exemplo.com", "529.982.247-25"
+    income := &models.Income{ID: uuid.New(), OwnerID: ownerID, PayerID: &payerID, Categoria: &categoria, Competencia: "2026-10",
+        Valor: models.NewMoney(1500.5), TotalPago: models.NewMoney(1500.5), Status: models.StatusParcial}
+    invoices, settings := &fakeInvoiceRepo{}, &fakeSettingsRepo{}
+    svc := NewInvoiceService(invoices, &fakeIncomeRepo{getByIDResp: income}, &fakePixPayerRepo{payer: &models.Payer{Nome: "Maria", Email: &email, Documento: &doc}},
+        settings, nil, logging.NewLogger("dev"))
+
+    if _, _, err := svc.Generate(context.Background(), income.ID, ownerID); !errors.Is(err, models.ErrInvoiceIncomeNotPaid) {
+        t.Fatalf("erro = %v, esperado ErrInvoiceIncomeNotPaid", err)
+    }
+    income.Status = models.StatusPago
+    if _, _, err := svc.Generate(context.Background(), income.ID, ownerID); !errors.Is(err, models.ErrInvoiceIssuerIncomplete) {
+        t.Fatalf("erro = %v, esperado ErrInvoiceIssuerIncomplete", err)
+    }
+    issuer := completeIssuer()
+    if _, err := svc.UpdateIssuer(context.Background(), ownerID, &issuer); err != nil { t.Fatal(err) }
+
+    inv, created, err := svc.Generate(context.Background(), income.ID, ownerID)
+    if err != nil || !created { t.Fatalf("nota = %+v, %v, %v", inv, created, err) }
+    if inv.Status != models.InvoiceGenerated || inv.RPSSerie != models.DefaultRPSSerie || inv.RPSNumero != 1 || inv.Valor != income.Valor || inv.Provider != nil {
+        t.Fatalf("nota = %+v", inv)
+    }
+    for _, want := range []string{"<Cnpj>11222333000181</Cnpj>", "<Cpf>52998224725</Cpf>", "<Discriminacao>Consultoria - 2026-10</Discriminacao>", "<Competencia>2026-10-01</Competencia>"} {
+        if !strings.Contains(inv.XML, want) { t.Errorf("XML sem %s", want) }
+    }
+
+    again, created, err := svc.Generate(context.Background(), income.ID, ownerID)
+    if err != nil || created || again.ID != inv.ID || invoices.nextRPS != 1 { t.Fatalf("nota repetida: %+v, %v, %v", again, created, err) }
+}
+
+func TestInvoiceGenerate_SubmitsToProvider(t *testing.T) {
+    ownerID := uuid.New()
+    income := &models.Income{ID: uuid.New(), OwnerID: ownerID, Competencia: "2026-10", Valor: models.NewMoney(100), TotalPago: models.NewMoney(100), Status: models.StatusPago}
+    invoices := &fakeInvoiceRepo{}
+    provider := &fakeNFSeProvider{res: &nfse.Result{Numero: "2026000123", CodigoVerificacao: "AB12CD"}}
+    svc := NewInvoiceService(invoices, &fakeIncomeRepo{getByIDResp: income}, nil, &fakeSettingsRepo{issuer: completeIssuer()}, provider, logging.NewLogger("dev"))
+
+    inv, _, err := svc.Generate(context.Background(), income.ID, ownerID)
+    if err != nil { t.Fatal(err) }
+    if inv.Status != models.InvoiceIssued || *inv.Numero != "2026000123" || *inv.CodigoVerificacao != "AB12CD" || *inv.Provider != "teste" {
+        t.Fatalf("nota = %+v", inv)
+    }
+    if len(provider.docs) != 1 || provider.docs[0].Tomador != (nfse.Party{}) { t.Fatalf("documentos enviados = %+v", provider.docs) }
+
+    provider.err = errors.New("lote rejeitado: inscrição municipal inválida")
+    other := *income
+    other.ID = uuid.New()
+    svc.incomes = &fakeIncomeRepo{getByIDResp: &other}
+    inv, created, err := svc.Generate(context.Background(), other.ID, ownerID)
+    if err != nil || !created || inv.Status != models.InvoiceFailed || inv.Erro == nil || inv.XML == "" {
+        t.Fatalf("recusa da prefeitura deveria manter o XML: %+v, %v", inv, err)
+    }
+}
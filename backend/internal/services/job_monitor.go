@@ -0,0 +1,222 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Monitor dos jobs em segundo plano (última execução, falhas, próxima execução e filas)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+)
+
+// JobFailureThreshold falhas consecutivas a partir das quais o job é considerado não saudável
+const JobFailureThreshold = 3
+
+// JobStaleFactor múltiplo do intervalo sem execução a partir do qual um worker iniciado é considerado parado
+const JobStaleFactor = 3
+
+// QueueDepthSource fornece a profundidade de uma ou mais filas para o painel
+type QueueDepthSource func(ctx context.Context) ([]models.QueueDepth, error)
+
+type jobRecord struct {
+	interval            time.Duration
+	started             bool
+	startedAt           time.Time
+	running             bool
+	runs                int64
+	failures            int64
+	consecutiveFailures int
+	lastStartedAt       time.Time
+	lastFinishedAt      time.Time
+	lastSuccessAt       time.Time
+	lastDuration        time.Duration
+	lastError           string
+}
+
+// JobMonitor registra as execuções dos workers periódicos e compõe o painel de jobs.
+// Docstring: os workers são executados por Run, que substitui o laço com ticker de cada serviço
+// e registra início, término, duração e erro de cada rodada. Seguro para uso concorrente.
+type JobMonitor struct {
+	mu     sync.Mutex
+	jobs   map[string]*jobRecord
+	queues []QueueDepthSource
+	log    logging.Logger
+	now    func() time.Time
+}
+
+// NewJobMonitor cria um monitor de jobs vazio
+func NewJobMonitor(log logging.Logger) *JobMonitor {
+	return &JobMonitor{jobs: map[string]*jobRecord{}, log: log, now: time.Now}
+}
+
+// Register declara um job e seu intervalo para que apareça no painel mesmo antes de executar
+func (m *JobMonitor) Register(name string, interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(name).interval = interval
+}
+
+// AddQueueSource registra uma fonte de profundidade de filas
+func (m *JobMonitor) AddQueueSource(src QueueDepthSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queues = append(m.queues, src)
+}
+
+// Run executa fn a cada interval até o contexto ser cancelado, registrando cada rodada
+func (m *JobMonitor) Run(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	rec := m.record(name)
+	rec.interval = interval
+	rec.started = true
+	rec.startedAt = m.now()
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		rec.started = false
+		m.mu.Unlock()
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Track(ctx, name, fn); err != nil {
+				m.log.Error("erro no job em segundo plano",
+					logging.Field{Key: "job", Val: name},
+					logging.Field{Key: "error", Val: err.Error()})
+			}
+		}
+	}
+}
+
+// Track executa fn uma vez registrando a rodada do job (também usado por execuções manuais)
+func (m *JobMonitor) Track(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	m.mu.Lock()
+	rec := m.record(name)
+	start := m.now()
+	rec.running = true
+	rec.lastStartedAt = start
+	m.mu.Unlock()
+
+	err := fn(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	end := m.now()
+	rec.running = false
+	rec.runs++
+	rec.lastFinishedAt = end
+	rec.lastDuration = end.Sub(start)
+	if err != nil {
+		rec.failures++
+		rec.consecutiveFailures++
+		rec.lastError = err.Error()
+	} else {
+		rec.consecutiveFailures = 0
+		rec.lastError = ""
+		rec.lastSuccessAt = end
+	}
+	return err
+}
+
+// Overview compõe o estado dos jobs e a profundidade das filas
+func (m *JobMonitor) Overview(ctx context.Context) *models.JobsOverview {
+	m.mu.Lock()
+	now := m.now()
+	ov := &models.JobsOverview{Healthy: true, GeneratedAt: now.UTC(), Jobs: []models.JobStatus{}, Queues: []models.QueueDepth{}}
+	for name, rec := range m.jobs {
+		st := rec.status(name, now)
+		if !st.Healthy {
+			ov.Healthy = false
+		}
+		ov.Jobs = append(ov.Jobs, st)
+	}
+	queues := append([]QueueDepthSource(nil), m.queues...)
+	m.mu.Unlock()
+
+	sort.Slice(ov.Jobs, func(i, j int) bool { return ov.Jobs[i].Name < ov.Jobs[j].Name })
+	for _, src := range queues {
+		items, err := src(ctx)
+		if err != nil {
+			ov.Healthy = false
+			ov.Errors = append(ov.Errors, err.Error())
+			continue
+		}
+		ov.Queues = append(ov.Queues, items...)
+	}
+	return ov
+}
+
+// record retorna (criando se necessário) o registro do job; requer m.mu
+func (m *JobMonitor) record(name string) *jobRecord {
+	rec, ok := m.jobs[name]
+	if !ok {
+		rec = &jobRecord{}
+		m.jobs[name] = rec
+	}
+	return rec
+}
+
+// status converte o registro no estado exibido no painel
+func (rec *jobRecord) status(name string, now time.Time) models.JobStatus {
+	st := models.JobStatus{
+		Name:                name,
+		Interval:            rec.interval.String(),
+		Started:             rec.started,
+		Running:             rec.running,
+		Runs:                rec.runs,
+		Failures:            rec.failures,
+		ConsecutiveFailures: rec.consecutiveFailures,
+		LastDurationMs:      rec.lastDuration.Milliseconds(),
+		LastStartedAt:       timePtrUTC(rec.lastStartedAt),
+		LastFinishedAt:      timePtrUTC(rec.lastFinishedAt),
+		LastSuccessAt:       timePtrUTC(rec.lastSuccessAt),
+	}
+	if rec.lastError != "" {
+		e := rec.lastError
+		st.LastError = &e
+	}
+
+	// Próxima execução: um intervalo após a última rodada (ou após o início do worker)
+	var stale bool
+	if rec.started && rec.interval > 0 {
+		base := rec.lastFinishedAt
+		if base.Before(rec.startedAt) {
+			base = rec.startedAt
+		}
+		st.NextRunAt = timePtrUTC(base.Add(rec.interval))
+		stale = !rec.running && now.Sub(base) > JobStaleFactor*rec.interval
+	}
+	switch {
+	case rec.running:
+		st.State = models.JobStateRunning
+	case stale:
+		st.State = models.JobStateStale
+	case rec.lastError != "":
+		st.State = models.JobStateFailing
+	case rec.runs > 0:
+		st.State = models.JobStateOK
+	default:
+		st.State = models.JobStateIdle
+	}
+	st.Healthy = !stale && rec.consecutiveFailures < JobFailureThreshold
+	return st
+}
+
+func timePtrUTC(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
@@ -0,0 +1,51 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do monitor de jobs em segundo plano (falhas consecutivas e filas)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+func TestJobMonitor_ConsecutiveFailuresMarkUnhealthy(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    m := NewJobMonitor(logging.NewLogger("dev"))
+    m.now = func() time.Time { return now }
+    m.Register("deliveries", 30*time.Second)
+
+    boom := errors.New("falha")
+    for i := 0; i < JobFailureThreshold; i++ {
+        if err := m.Track(context.Background(), "deliveries", func(context.Context) error { return boom }); !errors.Is(err, boom) { t.Fatalf("Track deveria propagar o erro: %v", err) }
+    }
+    ov := m.Overview(context.Background())
+    if ov.Healthy { t.Fatalf("job com %d falhas seguidas deveria estar não saudável", JobFailureThreshold) }
+    st := ov.Jobs[0]
+    if st.State != models.JobStateFailing || st.Failures != int64(JobFailureThreshold) || st.LastError == nil { t.Fatalf("estado inesperado: %+v", st) }
+
+    if err := m.Track(context.Background(), "deliveries", func(context.Context) error { return nil }); err != nil { t.Fatalf("Track err: %v", err) }
+    ov = m.Overview(context.Background())
+    if !ov.Healthy || ov.Jobs[0].ConsecutiveFailures != 0 || ov.Jobs[0].State != models.JobStateOK { t.Fatalf("sucesso deveria zerar as falhas consecutivas: %+v", ov.Jobs[0]) }
+    if ov.Jobs[0].Runs != int64(JobFailureThreshold)+1 { t.Fatalf("runs = %d", ov.Jobs[0].Runs) }
+}
+
+func TestJobMonitor_QueueSourceErrorMarksUnhealthy(t *testing.T) {
+    m := NewJobMonitor(logging.NewLogger("dev"))
+    m.Register("lifecycle", time.Hour)
+    m.AddQueueSource(func(context.Context) ([]models.QueueDepth, error) {
+        return []models.QueueDepth{{Queue: "deliveries", Channel: "email", Pending: 3, Due: 1}}, nil
+    })
+    ov := m.Overview(context.Background())
+    if !ov.Healthy || len(ov.Queues) != 1 || ov.Jobs[0].State != models.JobStateIdle { t.Fatalf("overview inesperado: %+v", ov) }
+
+    m.AddQueueSource(func(context.Context) ([]models.QueueDepth, error) { return nil, errors.New("banco indisponível") })
+    ov = m.Overview(context.Background())
+    if ov.Healthy || len(ov.Errors) != 1 { t.Fatalf("erro na fila deveria marcar não saudável: %+v", ov) }
+}
@@ -0,0 +1,56 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Regras de multa e juros por atraso configuradas pelo usuário
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// LateFeeService consulta e grava as regras de encargos do usuário.
+// Docstring: as regras do usuário valem para receitas sem contrato ou cujo contrato não define
+// o campo; o cálculo do valor corrigido fica em IncomeService.GetIncomeDetail.
+type LateFeeService struct {
+	settings repositories.SettingsRepository
+	log      logging.Logger
+}
+
+// NewLateFeeService cria o serviço de regras de encargos
+func NewLateFeeService(settings repositories.SettingsRepository, log logging.Logger) *LateFeeService {
+	return &LateFeeService{settings: settings, log: log}
+}
+
+// LateFeeSettings regras gravadas pelo usuário e a política efetiva (com o padrão do produto)
+type LateFeeSettings struct {
+	Rules     models.FeeRules  `json:"rules"`
+	Effective models.FeePolicy `json:"effective"`
+}
+
+// GetRules retorna as regras do usuário e a política que vale para receitas sem contrato
+func (s *LateFeeService) GetRules(ctx context.Context, ownerID uuid.UUID) (*LateFeeSettings, error) {
+	rules, err := s.settings.GetFeeRules(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar regras de encargos: %w", err)
+	}
+	return &LateFeeSettings{Rules: *rules, Effective: models.ResolveFeePolicy(rules)}, nil
+}
+
+// UpdateRules valida e grava as regras do usuário; campos nulos voltam ao padrão do produto
+func (s *LateFeeService) UpdateRules(ctx context.Context, ownerID uuid.UUID, rules *models.FeeRules) (*LateFeeSettings, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.settings.UpdateFeeRules(ctx, ownerID, rules); err != nil {
+		return nil, fmt.Errorf("erro ao gravar regras de encargos: %w", err)
+	}
+	s.log.Info("regras de encargos atualizadas", logging.Field{Key: "owner_id", Val: ownerID.String()})
+	return &LateFeeSettings{Rules: *rules, Effective: models.ResolveFeePolicy(rules)}, nil
+}
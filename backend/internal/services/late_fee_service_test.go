@@ -0,0 +1,54 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes das regras de multa e juros do usuário e do valor corrigido da receita
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+func TestLateFeeService_UpdateRulesValidatesAndResolves(t *testing.T) {
+    repo := &fakeSettingsRepo{}
+    svc := NewLateFeeService(repo, logging.NewLogger("dev"))
+    owner := uuid.New()
+
+    bad := 150.0
+    if _, err := svc.UpdateRules(context.Background(), owner, &models.FeeRules{JurosMesPercent: &bad}); !errors.Is(err, models.ErrFeePercentInvalid) {
+        t.Fatalf("err = %v, want ErrFeePercentInvalid", err)
+    }
+    if repo.rules != nil { t.Fatalf("regras inválidas não deveriam ser gravadas") }
+
+    multa := 10.0
+    out, err := svc.UpdateRules(context.Background(), owner, &models.FeeRules{MultaPercent: &multa})
+    if err != nil { t.Fatalf("UpdateRules err: %v", err) }
+    want := models.FeePolicy{MultaPercent: 10, JurosMesPercent: models.DefaultFeePolicy.JurosMesPercent}
+    if out.Effective != want { t.Fatalf("política efetiva = %+v, want %+v", out.Effective, want) }
+
+    got, err := svc.GetRules(context.Background(), owner)
+    if err != nil || got.Rules.MultaPercent == nil || *got.Rules.MultaPercent != 10 { t.Fatalf("GetRules = %+v, err %v", got, err) }
+}
+
+func TestGetIncomeDetail_AddsLateCharges(t *testing.T) {
+    repo := &fakeIncomeRepo{feePolicy: &models.FeePolicy{MultaPercent: 2, JurosDiaPercent: 0.033}}
+    svc := NewIncomeService(repo)
+
+    due := time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+    repo.getByIDResp = &models.Income{ID: uuid.New(), Valor: models.NewMoney(1500), Status: models.StatusVencido, DueDate: &due}
+
+    at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    d, err := svc.GetIncomeDetail(context.Background(), repo.getByIDResp.ID, uuid.New(), at, time.UTC)
+    if err != nil { t.Fatalf("GetIncomeDetail err: %v", err) }
+    // 18 dias: multa 30,00 e juros 1500 × 0,033% × 18 = 8,91
+    if d.Encargos.Multa != models.NewMoney(30) || d.Encargos.Juros != models.NewMoney(8.91) || d.ValorCorrigido != models.NewMoney(1538.91) {
+        t.Fatalf("valor corrigido inesperado: %+v", d)
+    }
+}
@@ -0,0 +1,80 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Job de ciclo de vida (manutenção periódica: coleta de artefatos sem referência)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"recibofast/internal/logging"
+)
+
+// LifecycleTask tarefa de manutenção executada a cada rodada do job de ciclo de vida
+type LifecycleTask struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// LifecycleJob executa as tarefas de manutenção em sequência.
+// Docstring: a falha de uma tarefa é registrada e não impede as demais.
+type LifecycleJob struct {
+	tasks []LifecycleTask
+	log   logging.Logger
+}
+
+// NewLifecycleJob cria o job de ciclo de vida; a coleta de artefatos é registrada quando informada
+func NewLifecycleJob(artifacts *ArtifactService, log logging.Logger) *LifecycleJob {
+	j := &LifecycleJob{log: log}
+	if artifacts != nil {
+		j.AddTask("artifact_gc", func(ctx context.Context) error {
+			res, err := artifacts.CollectGarbage(ctx)
+			if err != nil {
+				return err
+			}
+			if res.Deleted > 0 || res.StorageFails > 0 {
+				log.Info("coleta de artefatos concluída",
+					logging.Field{Key: "deleted", Val: res.Deleted},
+					logging.Field{Key: "freed_bytes", Val: res.FreedBytes},
+					logging.Field{Key: "failures", Val: res.StorageFails})
+			}
+			return nil
+		})
+	}
+	return j
+}
+
+// AddTask registra uma tarefa adicional
+func (j *LifecycleJob) AddTask(name string, run func(ctx context.Context) error) {
+	j.tasks = append(j.tasks, LifecycleTask{Name: name, Run: run})
+}
+
+// RunOnce executa todas as tarefas uma vez; retorna quantas falharam
+func (j *LifecycleJob) RunOnce(ctx context.Context) int {
+	failed := 0
+	for _, t := range j.tasks {
+		if err := t.Run(ctx); err != nil {
+			failed++
+			j.log.Error("erro no job de ciclo de vida",
+				logging.Field{Key: "task", Val: t.Name},
+				logging.Field{Key: "error", Val: err.Error()})
+		}
+	}
+	return failed
+}
+
+// Run executa as tarefas a cada interval até o contexto ser cancelado
+func (j *LifecycleJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.RunOnce(ctx)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Preferências de notificação por evento/canal e despacho respeitando essas preferências
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// EventDispatcher envia notificações de eventos respeitando as preferências (NotificationDispatcher)
+type EventDispatcher interface {
+	Dispatch(ctx context.Context, ownerID uuid.UUID, eventType string, payload interface{}) ([]*models.Delivery, error)
+}
+
+// NotificationDispatcher é o ponto único de envio de notificações ao usuário.
+// Docstring: antes de enfileirar, consulta a matriz evento x canal do usuário; apenas canais
+// em modo "immediate" com destino configurado recebem a entrega. Eventos em modo "digest"
+// não geram envio imediato e ficam para o resumo semanal; "off" descarta.
+type NotificationDispatcher struct {
+	repo       repositories.NotificationRepository
+	deliveries DeliveryEnqueuer
+	log        logging.Logger
+}
+
+// NewNotificationDispatcher cria o despachante de notificações
+func NewNotificationDispatcher(repo repositories.NotificationRepository, deliveries DeliveryEnqueuer, log logging.Logger) *NotificationDispatcher {
+	return &NotificationDispatcher{repo: repo, deliveries: deliveries, log: log}
+}
+
+// Dispatch enfileira a notificação nos canais habilitados para o evento; retorna as entregas criadas
+func (d *NotificationDispatcher) Dispatch(ctx context.Context, ownerID uuid.UUID, eventType string, payload interface{}) ([]*models.Delivery, error) {
+	settings, err := d.repo.GetSettings(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar preferências de notificação: %w", err)
+	}
+	var out []*models.Delivery
+	for _, ch := range models.NotificationChannels() {
+		if settings.Preferences.Mode(eventType, ch) != models.NotificationModeImmediate {
+			continue
+		}
+		for _, dest := range notificationDestinations(ch, settings, settings.AccountEmail) {
+			delivery, err := d.deliveries.Enqueue(ctx, ownerID, ch, dest, eventType, payload)
+			if err != nil {
+				return out, err
+			}
+			out = append(out, delivery)
+		}
+	}
+	if len(out) == 0 {
+		d.log.Info("notificação não enviada pelas preferências do usuário",
+			logging.Field{Key: "owner_id", Val: ownerID.String()},
+			logging.Field{Key: "event_type", Val: eventType})
+	}
+	return out, nil
+}
+
+// GetSettings retorna as preferências com a matriz completa (padrões preenchidos)
+func (d *NotificationDispatcher) GetSettings(ctx context.Context, ownerID uuid.UUID) (*models.NotificationSettings, error) {
+	settings, err := d.repo.GetSettings(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	settings.Preferences = settings.Preferences.Resolved()
+	return settings, nil
+}
+
+// UpdateSettings valida e mescla a atualização de destinos, resumo e matriz de preferências
+func (d *NotificationDispatcher) UpdateSettings(ctx context.Context, ownerID uuid.UUID, req *models.NotificationSettingsRequest) (*models.NotificationSettings, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	settings, err := d.repo.GetSettings(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(settings)
+	if err := d.repo.UpdateSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("erro ao salvar preferências: %w", err)
+	}
+	settings.Preferences = settings.Preferences.Resolved()
+	return settings, nil
+}
+
+// notificationDestinations resolve os destinos do canal a partir das preferências (e-mail cai
+// para o e-mail da conta quando não configurado; push tem um destino por dispositivo registrado)
+func notificationDestinations(channel string, settings *models.NotificationSettings, accountEmail *string) []string {
+	switch channel {
+	case models.DeliveryChannelEmail:
+		if settings.Email != nil && *settings.Email != "" {
+			return []string{*settings.Email}
+		}
+		if accountEmail != nil {
+			return []string{*accountEmail}
+		}
+	case models.DeliveryChannelPush:
+		return settings.PushEndpoints
+	case models.DeliveryChannelWhatsApp:
+		if settings.WhatsApp != nil {
+			return []string{*settings.WhatsApp}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do NotificationDispatcher (respeito à matriz evento x canal)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "testing"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+func TestDispatch_RespectsPreferences(t *testing.T) {
+    owner := uuid.New()
+    email, phone, push := "conta@example.com", "5511999998888", "sub-1"
+    repo := newFakeNotificationRepo()
+    repo.settings[owner] = &models.NotificationSettings{
+        OwnerID: owner, AccountEmail: &email, WhatsApp: &phone, PushSubscription: &push,
+        Preferences: models.NotificationPreferences{
+            models.EventPaymentReceived: {"email": "digest", "whatsapp": "immediate", "push": "off"},
+        },
+    }
+    q := &fakeEnqueuer{}
+    d := NewNotificationDispatcher(repo, q, logging.NewLogger("dev"))
+
+    out, err := d.Dispatch(context.Background(), owner, models.EventPaymentReceived, map[string]string{"x": "y"})
+    if err != nil { t.Fatalf("Dispatch err: %v", err) }
+    if len(out) != 1 || len(q.items) != 1 || q.items[0].channel != models.DeliveryChannelWhatsApp || q.items[0].destination != phone {
+        t.Fatalf("esperava apenas WhatsApp imediato, got %+v", q.items)
+    }
+
+    // Evento sem configuração usa o padrão: e-mail imediato (e-mail da conta como destino)
+    q.items = nil
+    if _, err := d.Dispatch(context.Background(), owner, models.EventReceiptIssued, nil); err != nil { t.Fatalf("Dispatch err: %v", err) }
+    if len(q.items) != 1 || q.items[0].channel != models.DeliveryChannelEmail || q.items[0].destination != email {
+        t.Fatalf("esperava e-mail padrão, got %+v", q.items)
+    }
+}
+
+func TestUpdateSettings_MergesAndValidates(t *testing.T) {
+    owner := uuid.New()
+    repo := newFakeNotificationRepo()
+    d := NewNotificationDispatcher(repo, &fakeEnqueuer{}, logging.NewLogger("dev"))
+
+    bad := &models.NotificationSettingsRequest{Preferences: models.NotificationPreferences{models.EventIncomeOverdue: {"sms": "immediate"}}}
+    if _, err := d.UpdateSettings(context.Background(), owner, bad); err != models.ErrInvalidNotificationChannel {
+        t.Fatalf("err = %v, want ErrInvalidNotificationChannel", err)
+    }
+
+    req := &models.NotificationSettingsRequest{Preferences: models.NotificationPreferences{models.EventIncomeOverdue: {"push": "immediate"}}}
+    out, err := d.UpdateSettings(context.Background(), owner, req)
+    if err != nil { t.Fatalf("UpdateSettings err: %v", err) }
+    if out.Preferences[models.EventIncomeOverdue]["push"] != "immediate" || out.Preferences[models.EventIncomeOverdue]["email"] != "immediate" {
+        t.Fatalf("matriz resolvida inesperada: %+v", out.Preferences[models.EventIncomeOverdue])
+    }
+    if out.Preferences[models.EventIncomeDueSoon]["whatsapp"] != "off" {
+        t.Fatalf("WhatsApp deveria vir desligado por padrão")
+    }
+}
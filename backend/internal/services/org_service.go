@@ -0,0 +1,214 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Organizações (espaço compartilhado): criação, membros, papéis e convites
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// OrgService organizações que compartilham o espaço de dados do dono (ex.: casal, pequeno escritório).
+// Docstring: com o cabeçalho X-Org-ID o SupabaseAuth troca o dono dos dados da requisição pelo
+// dono da organização (ResolveWorkspace); assim todas as consultas por owner_id passam a valer
+// para a organização. Membros e convites só são geridos pelo owner; editor altera os dados e
+// viewer apenas lê.
+type OrgService struct {
+	repo repositories.OrgRepository
+	log  logging.Logger
+	now  func() time.Time
+}
+
+// NewOrgService cria uma nova instância do serviço de organizações
+func NewOrgService(repo repositories.OrgRepository, log logging.Logger) *OrgService {
+	return &OrgService{repo: repo, log: log, now: time.Now}
+}
+
+// newInvitationToken gera o token do convite (32 bytes aleatórios) e o hash guardado no banco
+func newInvitationToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = "rfinv_" + hex.EncodeToString(b)
+	return token, invitationTokenHash(token), nil
+}
+
+func invitationTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create cria a organização do usuário (o próprio espaço de dados passa a ser compartilhável)
+func (s *OrgService) Create(ctx context.Context, userID uuid.UUID, req *models.OrgRequest) (*models.Org, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	org := &models.Org{OwnerID: userID, Nome: req.Nome}
+	if err := s.repo.Create(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// List organizações de que o usuário participa, com o papel dele
+func (s *OrgService) List(ctx context.Context, userID uuid.UUID) ([]models.Org, error) {
+	return s.repo.ListForUser(ctx, userID)
+}
+
+// Get organização com os membros (visível a qualquer membro)
+func (s *OrgService) Get(ctx context.Context, orgID, userID uuid.UUID) (*models.Org, error) {
+	org, err := s.repo.GetByID(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if org.Members, err = s.repo.ListMembers(ctx, orgID); err != nil {
+		return nil, fmt.Errorf("erro ao listar membros: %w", err)
+	}
+	return org, nil
+}
+
+// Rename renomeia a organização (owner)
+func (s *OrgService) Rename(ctx context.Context, orgID, userID uuid.UUID, req *models.OrgRequest) (*models.Org, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireOwner(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	org, err := s.repo.UpdateName(ctx, orgID, req.Nome)
+	if err != nil {
+		return nil, err
+	}
+	org.Role = models.OrgRoleOwner
+	return org, nil
+}
+
+// Invite convida um e-mail com papel editor ou viewer (owner); o token só é devolvido aqui
+func (s *OrgService) Invite(ctx context.Context, orgID, userID uuid.UUID, req *models.OrgInvitationRequest) (*models.OrgInvitation, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireOwner(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	token, hash, err := newInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar convite: %w", err)
+	}
+	now := s.now().UTC()
+	inv := &models.OrgInvitation{
+		OrgID:     orgID,
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(models.OrgInvitationTTL),
+	}
+	if err := s.repo.CreateInvitation(ctx, inv, hash); err != nil {
+		return nil, err
+	}
+	inv.Token = token
+	s.log.Info("convite para organização criado", logging.Field{Key: "org_id", Val: orgID}, logging.Field{Key: "invitation_id", Val: inv.ID})
+	return inv, nil
+}
+
+// ListInvitations convites pendentes (owner)
+func (s *OrgService) ListInvitations(ctx context.Context, orgID, userID uuid.UUID) ([]models.OrgInvitation, error) {
+	if _, err := s.requireOwner(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListInvitations(ctx, orgID)
+}
+
+// RevokeInvitation cancela um convite pendente (owner)
+func (s *OrgService) RevokeInvitation(ctx context.Context, orgID, userID, invitationID uuid.UUID) error {
+	if _, err := s.requireOwner(ctx, orgID, userID); err != nil {
+		return err
+	}
+	return s.repo.RevokeInvitation(ctx, orgID, invitationID, s.now().UTC())
+}
+
+// Accept aceita o convite; o e-mail da conta precisa ser o convidado
+func (s *OrgService) Accept(ctx context.Context, userID uuid.UUID, req *models.OrgAcceptRequest) (*models.OrgMember, error) {
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		return nil, models.ErrOrgInvitationNotFound
+	}
+	now := s.now().UTC()
+	inv, err := s.repo.GetInvitationByToken(ctx, invitationTokenHash(token), now)
+	if err != nil {
+		return nil, err
+	}
+	email, err := s.repo.UserEmail(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar e-mail da conta: %w", err)
+	}
+	if !strings.EqualFold(strings.TrimSpace(email), inv.Email) {
+		return nil, models.ErrOrgInvitationEmail
+	}
+	m, err := s.repo.AcceptInvitation(ctx, inv, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Info("convite para organização aceito", logging.Field{Key: "org_id", Val: inv.OrgID}, logging.Field{Key: "invitation_id", Val: inv.ID})
+	return m, nil
+}
+
+// UpdateMember troca o papel de um membro (owner); o papel do dono não muda
+func (s *OrgService) UpdateMember(ctx context.Context, orgID, userID, memberID uuid.UUID, req *models.OrgMemberUpdate) (*models.OrgMember, error) {
+	if !models.InvitableOrgRole(req.Role) {
+		return nil, models.ErrOrgRoleInvalid
+	}
+	if _, err := s.requireOwner(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	if memberID == userID {
+		return nil, models.ErrOrgOwnerImmutable
+	}
+	return s.repo.UpdateMemberRole(ctx, orgID, memberID, req.Role)
+}
+
+// RemoveMember remove um membro (owner) ou o próprio usuário (sair da organização)
+func (s *OrgService) RemoveMember(ctx context.Context, orgID, userID, memberID uuid.UUID) error {
+	m, err := s.repo.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case memberID == userID && m.Role == models.OrgRoleOwner:
+		return models.ErrOrgOwnerImmutable
+	case memberID != userID && m.Role != models.OrgRoleOwner:
+		return models.ErrOrgForbidden
+	}
+	return s.repo.RemoveMember(ctx, orgID, memberID)
+}
+
+// ResolveWorkspace vínculo do usuário com a organização (escopo das requisições com X-Org-ID)
+func (s *OrgService) ResolveWorkspace(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMembership, error) {
+	return s.repo.GetMembership(ctx, orgID, userID)
+}
+
+// requireOwner exige o papel owner; quem não é membro recebe ErrOrgNotFound
+func (s *OrgService) requireOwner(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMembership, error) {
+	m, err := s.repo.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if m.Role != models.OrgRoleOwner {
+		return nil, models.ErrOrgForbidden
+	}
+	return m, nil
+}
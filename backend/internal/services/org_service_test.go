@@ -0,0 +1,203 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes das organizações (papéis, convites e saída de membros)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeOrgRepo implementa repositories.OrgRepository em memória
+type fakeOrgRepo struct {
+    orgs    map[uuid.UUID]*models.Org
+    members map[uuid.UUID]map[uuid.UUID]string // org -> usuário -> papel
+    invites map[string]*models.OrgInvitation   // hash do token -> convite
+    emails  map[uuid.UUID]string
+}
+
+func newFakeOrgRepo() *fakeOrgRepo {
+    return &fakeOrgRepo{orgs: map[uuid.UUID]*models.Org{}, members: map[uuid.UUID]map[uuid.UUID]string{},
+        invites: map[string]*models.OrgInvitation{}, emails: map[uuid.UUID]string{}}
+}
+
+func (f *fakeOrgRepo) Create(ctx context.Context, org *models.Org) error {
+    for _, o := range f.orgs { if o.OwnerID == org.OwnerID { return models.ErrOrgExists } }
+    org.ID, org.Role = uuid.New(), models.OrgRoleOwner
+    f.orgs[org.ID] = org
+    f.members[org.ID] = map[uuid.UUID]string{org.OwnerID: models.OrgRoleOwner}
+    return nil
+}
+func (f *fakeOrgRepo) GetByID(ctx context.Context, orgID, userID uuid.UUID) (*models.Org, error) {
+    role, ok := f.members[orgID][userID]
+    if !ok { return nil, models.ErrOrgNotFound }
+    o := *f.orgs[orgID]
+    o.Role = role
+    return &o, nil
+}
+func (f *fakeOrgRepo) ListForUser(ctx context.Context, userID uuid.UUID) ([]models.Org, error) {
+    out := []models.Org{}
+    for id := range f.orgs { if o, err := f.GetByID(ctx, id, userID); err == nil { out = append(out, *o) } }
+    return out, nil
+}
+func (f *fakeOrgRepo) UpdateName(ctx context.Context, orgID uuid.UUID, nome string) (*models.Org, error) {
+    f.orgs[orgID].Nome = nome
+    o := *f.orgs[orgID]
+    return &o, nil
+}
+func (f *fakeOrgRepo) GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMembership, error) {
+    role, ok := f.members[orgID][userID]
+    if !ok { return nil, models.ErrOrgNotFound }
+    return &models.OrgMembership{OrgID: orgID, WorkspaceOwnerID: f.orgs[orgID].OwnerID, Role: role}, nil
+}
+func (f *fakeOrgRepo) ListMembers(ctx context.Context, orgID uuid.UUID) ([]models.OrgMember, error) {
+    out := []models.OrgMember{}
+    for id, role := range f.members[orgID] { out = append(out, models.OrgMember{OrgID: orgID, UserID: id, Role: role}) }
+    return out, nil
+}
+func (f *fakeOrgRepo) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string) (*models.OrgMember, error) {
+    cur, ok := f.members[orgID][userID]
+    if !ok || cur == models.OrgRoleOwner { return nil, models.ErrOrgMemberNotFound }
+    f.members[orgID][userID] = role
+    return &models.OrgMember{OrgID: orgID, UserID: userID, Role: role}, nil
+}
+func (f *fakeOrgRepo) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+    cur, ok := f.members[orgID][userID]
+    if !ok || cur == models.OrgRoleOwner { return models.ErrOrgMemberNotFound }
+    delete(f.members[orgID], userID)
+    return nil
+}
+func (f *fakeOrgRepo) CreateInvitation(ctx context.Context, inv *models.OrgInvitation, tokenHash string) error {
+    for _, old := range f.invites {
+        if old.OrgID == inv.OrgID && old.Email == inv.Email && old.RevokedAt == nil && old.AcceptedAt == nil { old.RevokedAt = &inv.CreatedAt }
+    }
+    inv.ID = uuid.New()
+    cp := *inv
+    f.invites[tokenHash] = &cp
+    return nil
+}
+func (f *fakeOrgRepo) ListInvitations(ctx context.Context, orgID uuid.UUID) ([]models.OrgInvitation, error) {
+    out := []models.OrgInvitation{}
+    for _, inv := range f.invites { if inv.OrgID == orgID && inv.RevokedAt == nil && inv.AcceptedAt == nil { out = append(out, *inv) } }
+    return out, nil
+}
+func (f *fakeOrgRepo) RevokeInvitation(ctx context.Context, orgID, id uuid.UUID, now time.Time) error {
+    for _, inv := range f.invites { if inv.ID == id && inv.OrgID == orgID && inv.RevokedAt == nil { inv.RevokedAt = &now; return nil } }
+    return models.ErrOrgInvitationNotFound
+}
+func (f *fakeOrgRepo) GetInvitationByToken(ctx context.Context, tokenHash string, now time.Time) (*models.OrgInvitation, error) {
+    inv, ok := f.invites[tokenHash]
+    if !ok || inv.RevokedAt != nil || inv.AcceptedAt != nil || !inv.ExpiresAt.After(now) { return nil, models.ErrOrgInvitationNotFound }
+    cp := *inv
+    return &cp, nil
+}
+func (f *fakeOrgRepo) AcceptInvitation(ctx context.Context, inv *models.OrgInvitation, userID uuid.UUID, now time.Time) (*models.OrgMember, error) {
+    if _, ok := f.members[inv.OrgID][userID]; ok { return nil, models.ErrOrgAlreadyMember }
+    for _, stored := range f.invites { if stored.ID == inv.ID { stored.AcceptedAt = &now } }
+    f.members[inv.OrgID][userID] = inv.Role
+    return &models.OrgMember{OrgID: inv.OrgID, UserID: userID, Role: inv.Role, CreatedAt: now}, nil
+}
+func (f *fakeOrgRepo) UserEmail(ctx context.Context, userID uuid.UUID) (string, error) {
+    return f.emails[userID], nil
+}
+
+func newOrgFixture(t *testing.T) (*OrgService, *fakeOrgRepo, *models.Org, *time.Time) {
+    repo := newFakeOrgRepo()
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    svc := NewOrgService(repo, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+    org, err := svc.Create(context.Background(), uuid.New(), &models.OrgRequest{Nome: "  Escritório Silva "})
+    if err != nil { t.Fatal(err) }
+    return svc, repo, org, &now
+}
+
+func TestOrgCreateOnePerOwner(t *testing.T) {
+    svc, _, org, _ := newOrgFixture(t)
+    if org.Nome != "Escritório Silva" || org.Role != models.OrgRoleOwner { t.Fatalf("organização inesperada: %+v", org) }
+    if _, err := svc.Create(context.Background(), org.OwnerID, &models.OrgRequest{Nome: "Outra"}); !errors.Is(err, models.ErrOrgExists) {
+        t.Fatalf("err = %v", err)
+    }
+    if _, err := svc.Create(context.Background(), uuid.New(), &models.OrgRequest{Nome: " "}); !errors.Is(err, models.ErrOrgNameRequired) {
+        t.Fatalf("err = %v", err)
+    }
+}
+
+func TestOrgInvitationFlow(t *testing.T) {
+    svc, repo, org, now := newOrgFixture(t)
+    guest := uuid.New()
+    repo.emails[guest] = "Ana@Exemplo.com"
+
+    if _, err := svc.Invite(context.Background(), org.ID, org.OwnerID, &models.OrgInvitationRequest{Email: "ana@exemplo.com", Role: models.OrgRoleOwner}); !errors.Is(err, models.ErrOrgRoleInvalid) {
+        t.Fatalf("owner não pode ser convidado: %v", err)
+    }
+    inv, err := svc.Invite(context.Background(), org.ID, org.OwnerID, &models.OrgInvitationRequest{Email: " ANA@exemplo.com ", Role: models.OrgRoleEditor})
+    if err != nil { t.Fatal(err) }
+    if !strings.HasPrefix(inv.Token, "rfinv_") || inv.Email != "ana@exemplo.com" || !inv.ExpiresAt.Equal(now.Add(models.OrgInvitationTTL)) {
+        t.Fatalf("convite inesperado: %+v", inv)
+    }
+    if _, ok := repo.invites[inv.Token]; ok { t.Fatal("o token não deve ser guardado em claro") }
+
+    // Outro usuário com o token não entra
+    intruder := uuid.New()
+    repo.emails[intruder] = "outro@exemplo.com"
+    if _, err := svc.Accept(context.Background(), intruder, &models.OrgAcceptRequest{Token: inv.Token}); !errors.Is(err, models.ErrOrgInvitationEmail) {
+        t.Fatalf("err = %v", err)
+    }
+
+    m, err := svc.Accept(context.Background(), guest, &models.OrgAcceptRequest{Token: inv.Token})
+    if err != nil { t.Fatal(err) }
+    if m.Role != models.OrgRoleEditor { t.Fatalf("membro inesperado: %+v", m) }
+    if _, err := svc.Accept(context.Background(), guest, &models.OrgAcceptRequest{Token: inv.Token}); !errors.Is(err, models.ErrOrgInvitationNotFound) {
+        t.Fatalf("convite não pode ser reutilizado: %v", err)
+    }
+
+    ws, err := svc.ResolveWorkspace(context.Background(), org.ID, guest)
+    if err != nil { t.Fatal(err) }
+    if ws.WorkspaceOwnerID != org.OwnerID || ws.Role != models.OrgRoleEditor { t.Fatalf("espaço inesperado: %+v", ws) }
+
+    // Membro que não é dono não gerencia convites
+    if _, err := svc.Invite(context.Background(), org.ID, guest, &models.OrgInvitationRequest{Email: "b@exemplo.com", Role: models.OrgRoleViewer}); !errors.Is(err, models.ErrOrgForbidden) {
+        t.Fatalf("err = %v", err)
+    }
+}
+
+func TestOrgInvitationExpires(t *testing.T) {
+    svc, repo, org, now := newOrgFixture(t)
+    guest := uuid.New()
+    repo.emails[guest] = "ana@exemplo.com"
+    inv, err := svc.Invite(context.Background(), org.ID, org.OwnerID, &models.OrgInvitationRequest{Email: "ana@exemplo.com", Role: models.OrgRoleViewer})
+    if err != nil { t.Fatal(err) }
+    *now = now.Add(models.OrgInvitationTTL)
+    if _, err := svc.Accept(context.Background(), guest, &models.OrgAcceptRequest{Token: inv.Token}); !errors.Is(err, models.ErrOrgInvitationNotFound) {
+        t.Fatalf("err = %v", err)
+    }
+}
+
+func TestOrgMemberRolesAndRemoval(t *testing.T) {
+    svc, repo, org, _ := newOrgFixture(t)
+    editor, viewer := uuid.New(), uuid.New()
+    repo.members[org.ID][editor] = models.OrgRoleEditor
+    repo.members[org.ID][viewer] = models.OrgRoleViewer
+
+    if _, err := svc.UpdateMember(context.Background(), org.ID, org.OwnerID, org.OwnerID, &models.OrgMemberUpdate{Role: models.OrgRoleViewer}); !errors.Is(err, models.ErrOrgOwnerImmutable) {
+        t.Fatalf("err = %v", err)
+    }
+    m, err := svc.UpdateMember(context.Background(), org.ID, org.OwnerID, viewer, &models.OrgMemberUpdate{Role: models.OrgRoleEditor})
+    if err != nil || m.Role != models.OrgRoleEditor { t.Fatalf("m = %+v, err = %v", m, err) }
+
+    if err := svc.RemoveMember(context.Background(), org.ID, editor, viewer); !errors.Is(err, models.ErrOrgForbidden) { t.Fatalf("err = %v", err) }
+    if err := svc.RemoveMember(context.Background(), org.ID, org.OwnerID, org.OwnerID); !errors.Is(err, models.ErrOrgOwnerImmutable) { t.Fatalf("err = %v", err) }
+    if err := svc.RemoveMember(context.Background(), org.ID, editor, editor); err != nil { t.Fatalf("membro deveria poder sair: %v", err) }
+    if err := svc.RemoveMember(context.Background(), org.ID, org.OwnerID, viewer); err != nil { t.Fatal(err) }
+    if len(repo.members[org.ID]) != 1 { t.Fatalf("membros restantes: %v", repo.members[org.ID]) }
+    if _, err := svc.Get(context.Background(), org.ID, editor); !errors.Is(err, models.ErrOrgNotFound) { t.Fatalf("err = %v", err) }
+}
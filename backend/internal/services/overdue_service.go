@@ -0,0 +1,42 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Job diário que marca em lote as receitas vencidas
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/repositories"
+)
+
+// OverdueService persiste o status "vencido" das receitas pendentes com vencimento passado.
+// Docstring: substitui a gravação feita nas leituras (ListIncomes), que concorria com as
+// edições do usuário; a listagem só calcula o status exibido.
+type OverdueService struct {
+	repo repositories.IncomeRepository
+	log  logging.Logger
+	now  func() time.Time
+}
+
+// NewOverdueService cria o serviço de receitas vencidas
+func NewOverdueService(repo repositories.IncomeRepository, log logging.Logger) *OverdueService {
+	return &OverdueService{repo: repo, log: log, now: time.Now}
+}
+
+// MarkOverdue executa a atualização em lote; retorna quantas receitas passaram a vencidas
+func (s *OverdueService) MarkOverdue(ctx context.Context) (int64, error) {
+	n, err := s.repo.MarkOverdue(ctx, s.now(), locale.DefaultTimezone)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao marcar receitas vencidas: %w", err)
+	}
+	if n > 0 {
+		s.log.Info("receitas marcadas como vencidas", logging.Field{Key: "count", Val: n})
+	}
+	return n, nil
+}
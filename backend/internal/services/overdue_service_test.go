@@ -0,0 +1,52 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do job diário de receitas vencidas e do subsistema de workers
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+)
+
+func TestOverdueService_UsesDefaultTimezone(t *testing.T) {
+    repo := &fakeIncomeRepo{markOverdueN: 3}
+    svc := NewOverdueService(repo, logging.NewLogger("dev"))
+    n, err := svc.MarkOverdue(context.Background())
+    if err != nil || n != 3 { t.Fatalf("MarkOverdue = %d, %v", n, err) }
+    if repo.markOverdueTZ != locale.DefaultTimezone { t.Fatalf("fuso = %q, want %q", repo.markOverdueTZ, locale.DefaultTimezone) }
+}
+
+func TestWorkers_RunAtStartAndStopOnCancel(t *testing.T) {
+    monitor := NewJobMonitor(logging.NewLogger("dev"))
+    ws := NewWorkers(monitor, logging.NewLogger("dev"))
+    var daily, lazy atomic.Int32
+    ws.Add(Worker{Name: "overdue", Interval: 24 * time.Hour, RunAtStart: true, Run: func(ctx context.Context) error {
+        daily.Add(1)
+        return errors.New("falha " + uuid.NewString())
+    }})
+    ws.Add(Worker{Name: "digest", Interval: time.Hour, Run: func(ctx context.Context) error { lazy.Add(1); return nil }})
+
+    if ov := monitor.Overview(context.Background()); len(ov.Jobs) != 2 { t.Fatalf("jobs registrados = %d, want 2", len(ov.Jobs)) }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    ws.Start(ctx)
+    deadline := time.Now().Add(time.Second)
+    for daily.Load() == 0 && time.Now().Before(deadline) { time.Sleep(5 * time.Millisecond) }
+    cancel()
+    ws.Wait()
+
+    if daily.Load() != 1 || lazy.Load() != 0 { t.Fatalf("rodadas = %d/%d, want 1/0", daily.Load(), lazy.Load()) }
+    for _, j := range monitor.Overview(context.Background()).Jobs {
+        if j.Name == "overdue" && (j.Runs != 1 || j.LastError == nil) { t.Fatalf("rodada inicial não registrada: %+v", j) }
+        if j.Started { t.Fatalf("%s deveria estar parado após o cancelamento", j.Name) }
+    }
+}
@@ -0,0 +1,111 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Serviço com regras de negócio do cadastro de pagadores
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// PayerService interface para serviços de pagadores
+type PayerService interface {
+	CreatePayer(ctx context.Context, ownerID uuid.UUID, req *models.PayerRequest) (*models.Payer, error)
+	GetPayer(ctx context.Context, id, ownerID uuid.UUID) (*models.Payer, error)
+	UpdatePayer(ctx context.Context, id, ownerID uuid.UUID, req *models.PayerRequest) (*models.Payer, error)
+	DeletePayer(ctx context.Context, id, ownerID uuid.UUID) error
+	ListPayers(ctx context.Context, ownerID uuid.UUID, filter *models.PayerFilter) (*models.PayerListResponse, error)
+}
+
+type payerService struct {
+	repo repositories.PayerRepository
+}
+
+// NewPayerService cria uma nova instância do serviço de pagadores
+func NewPayerService(repo repositories.PayerRepository) PayerService {
+	return &payerService{repo: repo}
+}
+
+// CreatePayer valida e cadastra um pagador
+func (s *payerService) CreatePayer(ctx context.Context, ownerID uuid.UUID, req *models.PayerRequest) (*models.Payer, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	p := &models.Payer{OwnerID: ownerID}
+	applyPayerRequest(p, req)
+	if err := s.repo.Create(ctx, p); err != nil {
+		if errors.Is(err, models.ErrDuplicateDocument) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao criar pagador: %w", err)
+	}
+	return p, nil
+}
+
+// GetPayer busca um pagador do usuário
+func (s *payerService) GetPayer(ctx context.Context, id, ownerID uuid.UUID) (*models.Payer, error) {
+	return s.repo.GetByID(ctx, id, ownerID)
+}
+
+// UpdatePayer substitui os dados de um pagador
+func (s *payerService) UpdatePayer(ctx context.Context, id, ownerID uuid.UUID, req *models.PayerRequest) (*models.Payer, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	p := &models.Payer{ID: id, OwnerID: ownerID}
+	applyPayerRequest(p, req)
+	if err := s.repo.Update(ctx, p); err != nil {
+		if errors.Is(err, models.ErrPayerNotFound) || errors.Is(err, models.ErrDuplicateDocument) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao atualizar pagador: %w", err)
+	}
+	return p, nil
+}
+
+// DeletePayer remove um pagador
+func (s *payerService) DeletePayer(ctx context.Context, id, ownerID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id, ownerID); err != nil {
+		if errors.Is(err, models.ErrPayerNotFound) {
+			return err
+		}
+		return fmt.Errorf("erro ao remover pagador: %w", err)
+	}
+	return nil
+}
+
+// ListPayers lista pagadores com busca e paginação
+func (s *payerService) ListPayers(ctx context.Context, ownerID uuid.UUID, filter *models.PayerFilter) (*models.PayerListResponse, error) {
+	items, total, err := s.repo.List(ctx, ownerID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pagadores: %w", err)
+	}
+	if items == nil {
+		items = []models.Payer{}
+	}
+	return &models.PayerListResponse{
+		Items:      items,
+		Total:      total,
+		Page:       filter.Page,
+		PerPage:    filter.PerPage,
+		TotalPages: (total + filter.PerPage - 1) / filter.PerPage,
+	}, nil
+}
+
+func applyPayerRequest(p *models.Payer, req *models.PayerRequest) {
+	p.Nome = req.Nome
+	p.Documento = req.Documento
+	p.Email = req.Email
+	p.Telefone = req.Telefone
+	p.Endereco = req.Endereco
+	p.Cidade = req.Cidade
+	p.UF = req.UF
+	p.CEP = req.CEP
+}
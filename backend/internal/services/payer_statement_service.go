@@ -0,0 +1,142 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Extrato do pagador com saldo corrente (JSON e PDF) para conferir o que já foi pago
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/pdf"
+	"recibofast/internal/repositories"
+)
+
+// PayerStatementService monta o extrato de um pagador.
+// Docstring: receitas debitam, pagamentos creditam e estornos voltam a debitar; recibos aparecem
+// para referência sem alterar o saldo. As datas do período são dias no fuso do usuário.
+type PayerStatementService struct {
+	payers repositories.PayerRepository
+	log    logging.Logger
+	now    func() time.Time
+}
+
+// NewPayerStatementService cria o serviço do extrato do pagador
+func NewPayerStatementService(payers repositories.PayerRepository, log logging.Logger) *PayerStatementService {
+	return &PayerStatementService{payers: payers, log: log, now: time.Now}
+}
+
+// Build carrega o pagador e os lançamentos e calcula o saldo no período [from, to]
+func (s *PayerStatementService) Build(ctx context.Context, payerID, ownerID uuid.UUID, from, to *time.Time, ls locale.Settings) (*models.PayerStatement, error) {
+	payer, err := s.payers.GetByID(ctx, payerID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.payers.ListStatementEntries(ctx, payerID, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar lançamentos do pagador: %w", err)
+	}
+	return models.BuildPayerStatement(payer, entries, from, to, ls.Location, s.now())
+}
+
+// Render gera o PDF do extrato
+func (s *PayerStatementService) Render(st *models.PayerStatement, ls locale.Settings) []byte {
+	p := &reportPDF{doc: pdf.New(), title: "Extrato - " + fitText(st.Payer.Nome, 380, 15, true),
+		footer: "Gerado pelo ReciboFast em " + ls.FormatDateTime(st.GeneratedAt)}
+	p.newPage()
+
+	periodo := "todo o histórico"
+	switch {
+	case st.From != nil && st.To != nil:
+		periodo = statementDay(*st.From, ls) + " a " + statementDay(*st.To, ls)
+	case st.From != nil:
+		periodo = "a partir de " + statementDay(*st.From, ls)
+	case st.To != nil:
+		periodo = "até " + statementDay(*st.To, ls)
+	}
+	info := [][2]string{{"Pagador", st.Payer.Nome}, {"CPF/CNPJ", orDash(st.Payer.Documento)}, {"Período", periodo},
+		{"Saldo anterior", ls.FormatAmount(st.OpeningBalance)}}
+	for _, kv := range info {
+		p.doc.Text(reportMargin, p.y, 9, true, kv[0]+":")
+		p.doc.Text(reportMargin+90, p.y, 9, false, kv[1])
+		p.y += reportRow
+	}
+	p.y += reportRow
+
+	cols := []reportColumn{{"Data", reportMargin, 60, false}, {"Lançamento", reportMargin + 62, 220, false},
+		{"Débito", reportMargin + 284, 75, true}, {"Crédito", reportMargin + 361, 75, true}, {"Saldo", reportMargin + 438, 77, true}}
+	p.section("Lançamentos", cols, len(st.Entries), func(k int) []string {
+		e := &st.Entries[k]
+		return []string{statementEntryDate(e, ls), statementDescription(e, ls), amountOrBlank(e.Debit, ls), amountOrBlank(e.Credit, ls),
+			ls.FormatAmount(e.Balance)}
+	})
+
+	if p.y+4*reportRow > reportBottom {
+		p.newPage()
+	}
+	for _, kv := range [][2]string{{"Total de débitos", ls.FormatAmount(st.Debits)}, {"Total de créditos", ls.FormatAmount(st.Credits)},
+		{"Saldo final", ls.FormatAmount(st.ClosingBalance)}} {
+		p.doc.Text(reportMargin+284, p.y, 9, true, kv[0])
+		p.doc.TextRight(reportRight, p.y, 9, kv[0] == "Saldo final", kv[1])
+		p.y += reportRow
+	}
+	if st.ClosingBalance < 0 {
+		p.doc.Text(reportMargin, p.y+reportRow, 8, false, "Saldo negativo indica crédito a favor do pagador.")
+	}
+	return p.doc.Bytes()
+}
+
+// statementDescription texto do lançamento ("Receita 10/2026 - Aluguel", "Pagamento (PIX)", "Recibo nº 12")
+func statementDescription(e *models.StatementEntry, ls locale.Settings) string {
+	ref := ls.FormatCompetencia(derefString(e.Competencia))
+	if c := strings.TrimSpace(derefString(e.Categoria)); c != "" {
+		ref += " - " + c
+	}
+	switch e.Kind {
+	case models.StatementIncome:
+		return "Receita " + ref
+	case models.StatementPayment:
+		if m := strings.TrimSpace(derefString(e.Metodo)); m != "" {
+			return fmt.Sprintf("Pagamento %s (%s)", ref, m)
+		}
+		return "Pagamento " + ref
+	case models.StatementReversal:
+		return "Estorno de pagamento " + ref
+	case models.StatementReceipt:
+		if e.ReceiptNumero != nil {
+			return fmt.Sprintf("Recibo nº %d (%s)", *e.ReceiptNumero, ls.FormatAmount(e.Valor))
+		}
+		return "Recibo " + ref
+	}
+	return e.Kind
+}
+
+func statementEntryDate(e *models.StatementEntry, ls locale.Settings) string {
+	if e.DateOnly {
+		return reportDate(&e.At, ls)
+	}
+	return ls.FormatDate(e.At)
+}
+
+// statementDay data AAAA-MM-DD do período no formato do idioma
+func statementDay(d string, ls locale.Settings) string {
+	t, err := time.Parse("2006-01-02", d)
+	if err != nil {
+		return d
+	}
+	return reportDate(&t, ls)
+}
+
+func amountOrBlank(m models.Money, ls locale.Settings) string {
+	if m == 0 {
+		return ""
+	}
+	return ls.FormatAmount(m)
+}
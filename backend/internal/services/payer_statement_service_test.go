@@ -0,0 +1,60 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do extrato do pagador (montagem e PDF)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+type fakeStatementPayerRepo struct {
+    repositories.PayerRepository
+    payer   *models.Payer
+    entries []models.StatementEntry
+}
+
+func (f *fakeStatementPayerRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Payer, error) {
+    if f.payer == nil || f.payer.ID != id { return nil, models.ErrPayerNotFound }
+    return f.payer, nil
+}
+func (f *fakeStatementPayerRepo) ListStatementEntries(ctx context.Context, id, ownerID uuid.UUID) ([]models.StatementEntry, error) {
+    return f.entries, nil
+}
+
+func TestPayerStatement_BuildAndRender(t *testing.T) {
+    doc, comp, metodo, numero := "529.982.247-25", "2026-10", "PIX", int64(7)
+    payer := &models.Payer{ID: uuid.New(), Nome: "Maria Silva", Documento: &doc}
+    due := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+    repo := &fakeStatementPayerRepo{payer: payer, entries: []models.StatementEntry{
+        {Kind: models.StatementIncome, At: due, DateOnly: true, Competencia: &comp, Valor: models.NewMoney(1500)},
+        {Kind: models.StatementPayment, At: due.Add(15 * time.Hour), Competencia: &comp, Metodo: &metodo, Valor: models.NewMoney(1600)},
+        {Kind: models.StatementReceipt, At: due.Add(16 * time.Hour), Competencia: &comp, ReceiptNumero: &numero, Valor: models.NewMoney(1500)},
+    }}
+    svc := NewPayerStatementService(repo, logging.NewLogger("dev"))
+    ls := locale.Resolve("pt-BR", "America/Sao_Paulo")
+
+    st, err := svc.Build(context.Background(), payer.ID, uuid.New(), nil, nil, ls)
+    if err != nil { t.Fatal(err) }
+    if st.ClosingBalance != models.NewMoney(-100) || len(st.Entries) != 3 { t.Fatalf("extrato = %+v", st) }
+    if d := statementDescription(&st.Entries[1], ls); d != "Pagamento 10/2026 (PIX)" { t.Fatalf("descrição = %q", d) }
+    if d := statementDescription(&st.Entries[2], ls); d != "Recibo nº 7 (R$ 1.500,00)" { t.Fatalf("descrição = %q", d) }
+
+    out := svc.Render(st, ls)
+    if !bytes.HasPrefix(out, []byte("%PDF-")) || !bytes.Contains(out, []byte("Maria Silva")) { t.Fatal("PDF sem o pagador") }
+
+    if _, err := svc.Build(context.Background(), uuid.New(), uuid.New(), nil, nil, ls); !errors.Is(err, models.ErrPayerNotFound) {
+        t.Fatalf("err = %v", err)
+    }
+}
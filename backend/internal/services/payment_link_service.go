@@ -0,0 +1,115 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Links de pagamento das receitas: cria o checkout hospedado no gateway e guarda a sessão
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/checkout"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// PaymentLinkService gera links de pagamento (Stripe Checkout ou Mercado Pago Checkout Pro).
+// Docstring: o valor padrão é o saldo a receber; enquanto houver link aberto e válido com o mesmo
+// valor ele é devolvido em vez de criar outra sessão no gateway. Sem gateway configurado
+// (gateway nil) responde ErrCheckoutUnavailable.
+type PaymentLinkService struct {
+	links      repositories.PaymentLinkRepository
+	incomes    repositories.IncomeRepository
+	payers     repositories.PayerRepository
+	gateway    checkout.Gateway
+	successURL string
+	cancelURL  string
+	log        logging.Logger
+	now        func() time.Time
+}
+
+// NewPaymentLinkService cria o serviço de links de pagamento; gateway pode ser nil (links desabilitados)
+func NewPaymentLinkService(links repositories.PaymentLinkRepository, incomes repositories.IncomeRepository, payers repositories.PayerRepository,
+	gateway checkout.Gateway, successURL, cancelURL string, log logging.Logger) *PaymentLinkService {
+	return &PaymentLinkService{links: links, incomes: incomes, payers: payers, gateway: gateway,
+		successURL: strings.TrimSpace(successURL), cancelURL: strings.TrimSpace(cancelURL), log: log, now: time.Now}
+}
+
+// Create cria (ou reaproveita) o link de pagamento da receita
+func (s *PaymentLinkService) Create(ctx context.Context, incomeID, ownerID uuid.UUID, req *models.PaymentLinkRequest) (*models.PaymentLink, error) {
+	if s.gateway == nil {
+		return nil, models.ErrCheckoutUnavailable
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	income, err := s.incomes.GetByID(ctx, incomeID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if income.Status == models.StatusPago || income.Status == models.StatusCancelado {
+		return nil, models.ErrPaymentLinkIncomeClosed
+	}
+	amount := income.Valor - income.TotalPago
+	if req.Valor != nil {
+		amount = *req.Valor
+	} else if amount <= 0 {
+		return nil, models.ErrPaymentLinkNothingDue
+	}
+
+	now := s.now().UTC()
+	if open, err := s.links.GetOpen(ctx, incomeID, ownerID, now); err == nil && open.Valor == amount && open.Provider == s.gateway.Name() {
+		return open, nil
+	} else if err != nil && !errors.Is(err, models.ErrPaymentLinkNotFound) {
+		return nil, fmt.Errorf("erro ao consultar link de pagamento: %w", err)
+	}
+
+	link := &models.PaymentLink{ID: uuid.New(), OwnerID: ownerID, IncomeID: incomeID, Valor: amount}
+	sreq := &checkout.SessionRequest{
+		Reference:   link.ID.String(),
+		Amount:      int64(amount),
+		Description: pixDescription(income),
+		SuccessURL:  s.successURL,
+		CancelURL:   s.cancelURL,
+	}
+	if req.Expiracao != nil {
+		sreq.Expiration = time.Duration(*req.Expiracao) * time.Second
+	}
+	if income.PayerID != nil {
+		payer, err := s.payers.GetByID(ctx, *income.PayerID, ownerID)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar pagador: %w", err)
+		}
+		sreq.PayerEmail = strings.TrimSpace(derefString(payer.Email))
+	}
+
+	session, err := s.gateway.CreateSession(ctx, sreq)
+	if err != nil {
+		s.log.Warn("falha ao criar link de pagamento",
+			logging.Field{Key: "income_id", Val: incomeID.String()},
+			logging.Field{Key: "provider", Val: s.gateway.Name()},
+			logging.Field{Key: "error", Val: err.Error()})
+		return nil, fmt.Errorf("%w: %v", models.ErrCheckoutProviderFailed, err)
+	}
+	link.Provider, link.SessionID, link.URL, link.ExpiresAt = session.Provider, session.SessionID, session.URL, session.ExpiresAt.UTC()
+	// A sessão já existe no gateway: grava mesmo se o cliente desistiu da requisição
+	if err := s.links.Create(context.WithoutCancel(ctx), link); err != nil {
+		return nil, fmt.Errorf("erro ao registrar link de pagamento: %w", err)
+	}
+	s.log.Info("link de pagamento criado",
+		logging.Field{Key: "income_id", Val: incomeID.String()},
+		logging.Field{Key: "provider", Val: link.Provider},
+		logging.Field{Key: "session_id", Val: link.SessionID})
+	return link, nil
+}
+
+// Current link aberto e válido da receita
+func (s *PaymentLinkService) Current(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.PaymentLink, error) {
+	return s.links.GetOpen(ctx, incomeID, ownerID, s.now().UTC())
+}
@@ -0,0 +1,145 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes dos links de pagamento (criação no gateway e baixa pelo webhook)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/checkout"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+type fakePaymentLinkRepo struct {
+    repositories.PaymentLinkRepository
+    items []models.PaymentLink
+}
+
+func (f *fakePaymentLinkRepo) Create(ctx context.Context, l *models.PaymentLink) error {
+    l.Status = models.PaymentLinkOpen
+    f.items = append(f.items, *l)
+    return nil
+}
+func (f *fakePaymentLinkRepo) GetOpen(ctx context.Context, incomeID, ownerID uuid.UUID, now time.Time) (*models.PaymentLink, error) {
+    for i := len(f.items) - 1; i >= 0; i-- {
+        l := f.items[i]
+        if l.IncomeID == incomeID && l.OwnerID == ownerID && l.Status == models.PaymentLinkOpen && l.ExpiresAt.After(now) { return &l, nil }
+    }
+    return nil, models.ErrPaymentLinkNotFound
+}
+func (f *fakePaymentLinkRepo) ClaimPaid(ctx context.Context, provider string, id uuid.UUID, providerPaymentID *string, paidAt time.Time) (*models.PaymentLink, error) {
+    for i := range f.items {
+        l := &f.items[i]
+        if l.Provider == provider && l.ID == id && (l.Status == models.PaymentLinkOpen || l.Status == models.PaymentLinkExpired) {
+            l.Status, l.PaidAt, l.ProviderPaymentID = models.PaymentLinkPaid, &paidAt, providerPaymentID
+            out := *l
+            return &out, nil
+        }
+    }
+    return nil, models.ErrPaymentLinkNotFound
+}
+func (f *fakePaymentLinkRepo) Release(ctx context.Context, id uuid.UUID) error {
+    for i := range f.items {
+        if f.items[i].ID == id && f.items[i].PaymentID == nil { f.items[i].Status, f.items[i].PaidAt = models.PaymentLinkOpen, nil }
+    }
+    return nil
+}
+func (f *fakePaymentLinkRepo) SetPayment(ctx context.Context, id, paymentID uuid.UUID) error {
+    for i := range f.items {
+        if f.items[i].ID == id { f.items[i].PaymentID = &paymentID }
+    }
+    return nil
+}
+
+type fakeCheckoutGateway struct {
+    reqs     []checkout.SessionRequest
+    err      error
+    payments []checkout.Payment
+    parseErr error
+}
+
+func (g *fakeCheckoutGateway) Name() string { return checkout.ProviderStripe }
+func (g *fakeCheckoutGateway) CreateSession(ctx context.Context, req *checkout.SessionRequest) (*checkout.Session, error) {
+    g.reqs = append(g.reqs, *req)
+    if g.err != nil { return nil, g.err }
+    return &checkout.Session{Provider: checkout.ProviderStripe, SessionID: "cs_" + req.Reference, URL: "https://checkout.exemplo.com/" + req.Reference,
+        ExpiresAt: time.Now().Add(checkout.DefaultExpiration)}, nil
+}
+func (g *fakeCheckoutGateway) ParseWebhook(ctx context.Context, req *checkout.WebhookRequest, secret string) ([]checkout.Payment, error) {
+    return g.payments, g.parseErr
+}
+
+func TestPaymentLink_DefaultsToBalanceAndReusesOpenLink(t *testing.T) {
+    ownerID, payerID := uuid.New(), uuid.New()
+    income := &models.Income{ID: uuid.New(), OwnerID: ownerID, PayerID: &payerID, Competencia: "2026-10", Valor: models.NewMoney(150), TotalPago: models.NewMoney(50), Status: models.StatusParcial}
+    email := "pagador@exemplo.com"
+    links, gw := &fakePaymentLinkRepo{}, &fakeCheckoutGateway{}
+    svc := NewPaymentLinkService(links, &fakeIncomeRepo{getByIDResp: income}, &fakePixPayerRepo{payer: &models.Payer{Email: &email}}, gw,
+        "https://app.exemplo.com/ok", "", logging.NewLogger("dev"))
+
+    l, err := svc.Create(context.Background(), income.ID, ownerID, &models.PaymentLinkRequest{})
+    if err != nil { t.Fatalf("erro inesperado: %v", err) }
+    if l.Valor != models.NewMoney(100) || l.URL == "" || l.ID.String() != gw.reqs[0].Reference { t.Fatalf("link = %+v", l) }
+    if gw.reqs[0].PayerEmail != email || gw.reqs[0].SuccessURL != "https://app.exemplo.com/ok" || gw.reqs[0].Amount != 10000 {
+        t.Fatalf("sessão pedida = %+v", gw.reqs[0])
+    }
+
+    again, err := svc.Create(context.Background(), income.ID, ownerID, &models.PaymentLinkRequest{})
+    if err != nil || again.ID != l.ID || len(gw.reqs) != 1 { t.Fatalf("link aberto deveria ser reaproveitado: %+v, %v", again, err) }
+
+    other := models.NewMoney(40)
+    if _, err := svc.Create(context.Background(), income.ID, ownerID, &models.PaymentLinkRequest{Valor: &other}); err != nil || len(gw.reqs) != 2 {
+        t.Fatalf("valor diferente deveria criar outra sessão: %v", err)
+    }
+
+    one := models.NewMoney(1)
+    gw.err = errors.New("stripe fora do ar")
+    if _, err := svc.Create(context.Background(), income.ID, ownerID, &models.PaymentLinkRequest{Valor: &one}); !errors.Is(err, models.ErrCheckoutProviderFailed) {
+        t.Fatalf("erro = %v, esperado ErrCheckoutProviderFailed", err)
+    }
+    income.Status = models.StatusPago
+    if _, err := svc.Create(context.Background(), income.ID, ownerID, &models.PaymentLinkRequest{}); !errors.Is(err, models.ErrPaymentLinkIncomeClosed) {
+        t.Fatalf("erro = %v, esperado ErrPaymentLinkIncomeClosed", err)
+    }
+    if _, err := NewPaymentLinkService(links, nil, nil, nil, "", "", logging.NewLogger("dev")).Create(context.Background(), income.ID, ownerID, &models.PaymentLinkRequest{}); !errors.Is(err, models.ErrCheckoutUnavailable) {
+        t.Fatalf("erro = %v, esperado ErrCheckoutUnavailable", err)
+    }
+}
+
+func TestCheckoutWebhook_RegistersPaymentOnce(t *testing.T) {
+    link := models.PaymentLink{ID: uuid.New(), OwnerID: uuid.New(), IncomeID: uuid.New(), Provider: checkout.ProviderStripe, Valor: 15050,
+        Status: models.PaymentLinkOpen, ExpiresAt: time.Now().Add(time.Hour)}
+    links := &fakePaymentLinkRepo{items: []models.PaymentLink{link}}
+    paidAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    gw := &fakeCheckoutGateway{payments: []checkout.Payment{{Reference: link.ID.String(), PaymentID: "pi_1", Method: "cartao", Amount: 15050, PaidAt: paidAt}}}
+    rec := &fakePixRecorder{errs: []error{errors.New("banco indisponível")}}
+    svc := NewCheckoutWebhookService(links, rec, gw, "whsec_1", logging.NewLogger("dev"))
+
+    // Erro transitório: o link volta a aberto e o gateway reenvia
+    if _, err := svc.Handle(context.Background(), &checkout.WebhookRequest{}); err == nil || links.items[0].Status != models.PaymentLinkOpen {
+        t.Fatalf("erro = %v, status = %s", err, links.items[0].Status)
+    }
+    res, err := svc.Handle(context.Background(), &checkout.WebhookRequest{})
+    if err != nil || res.Registered != 1 { t.Fatalf("resultado = %+v, %v", res, err) }
+    req := rec.reqs[len(rec.reqs)-1]
+    if req.IncomeID != link.IncomeID || req.Valor != 15050 || *req.Metodo != models.PaymentMethodCartao || *req.PagoEm != paidAt.Format(time.RFC3339) {
+        t.Fatalf("pagamento lançado = %+v", req)
+    }
+    if links.items[0].PaymentID == nil || *links.items[0].ProviderPaymentID != "pi_1" { t.Fatalf("link = %+v", links.items[0]) }
+
+    res, err = svc.Handle(context.Background(), &checkout.WebhookRequest{})
+    if err != nil || res.Registered != 0 || len(rec.reqs) != 2 { t.Fatalf("notificação repetida: %+v, %v", res, err) }
+
+    gw.parseErr = checkout.ErrInvalidSignature
+    if _, err := svc.Handle(context.Background(), &checkout.WebhookRequest{}); !errors.Is(err, models.ErrCheckoutWebhookAuth) {
+        t.Fatalf("erro = %v, esperado ErrCheckoutWebhookAuth", err)
+    }
+}
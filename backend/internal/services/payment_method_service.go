@@ -0,0 +1,183 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Catálogo de formas de pagamento do usuário e resolução da forma de cada pagamento
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// PaymentMethodService gerencia o catálogo de formas de pagamento.
+// Docstring: o catálogo nasce com PIX (padrão), Transferência e Dinheiro no primeiro acesso;
+// Resolve traduz method_id, o campo legado metodo ou a forma padrão na forma de um pagamento.
+type PaymentMethodService struct {
+	repo repositories.PaymentMethodRepository
+	log  logging.Logger
+}
+
+// NewPaymentMethodService cria o serviço de formas de pagamento
+func NewPaymentMethodService(repo repositories.PaymentMethodRepository, log logging.Logger) *PaymentMethodService {
+	return &PaymentMethodService{repo: repo, log: log}
+}
+
+// List lista as formas do usuário, criando o catálogo inicial quando vazio
+func (s *PaymentMethodService) List(ctx context.Context, ownerID uuid.UUID, includeArchived bool) ([]models.PaymentMethod, error) {
+	items, err := s.repo.List(ctx, ownerID, true)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar formas de pagamento: %w", err)
+	}
+	if len(items) == 0 {
+		var defaults []models.PaymentMethod
+		for _, d := range models.DefaultPaymentMethods() {
+			defaults = append(defaults, models.PaymentMethod{Tipo: d.Tipo, Nome: d.Nome, IsDefault: d.IsDefault})
+		}
+		if err := s.repo.CreateDefaults(ctx, ownerID, defaults); err != nil {
+			return nil, fmt.Errorf("erro ao criar formas de pagamento iniciais: %w", err)
+		}
+		if items, err = s.repo.List(ctx, ownerID, true); err != nil {
+			return nil, fmt.Errorf("erro ao listar formas de pagamento: %w", err)
+		}
+	}
+	if includeArchived {
+		return items, nil
+	}
+	active := make([]models.PaymentMethod, 0, len(items))
+	for _, m := range items {
+		if m.ArchivedAt == nil {
+			active = append(active, m)
+		}
+	}
+	return active, nil
+}
+
+// Get busca uma forma do usuário
+func (s *PaymentMethodService) Get(ctx context.Context, id, ownerID uuid.UUID) (*models.PaymentMethod, error) {
+	m, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, wrapPaymentMethodError("erro ao buscar forma de pagamento", err)
+	}
+	return m, nil
+}
+
+// Create valida e grava uma nova forma
+func (s *PaymentMethodService) Create(ctx context.Context, ownerID uuid.UUID, req *models.PaymentMethodRequest) (*models.PaymentMethod, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	m := &models.PaymentMethod{OwnerID: ownerID}
+	applyPaymentMethodRequest(m, req)
+	m.IsDefault = req.IsDefault
+	if err := s.repo.Create(ctx, m); err != nil {
+		return nil, wrapPaymentMethodError("erro ao criar forma de pagamento", err)
+	}
+	return m, nil
+}
+
+// Update substitui tipo, nome e dados da forma; is_default=true também a torna padrão
+func (s *PaymentMethodService) Update(ctx context.Context, id, ownerID uuid.UUID, req *models.PaymentMethodRequest) (*models.PaymentMethod, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	m := &models.PaymentMethod{ID: id, OwnerID: ownerID}
+	applyPaymentMethodRequest(m, req)
+	if err := s.repo.Update(ctx, m); err != nil {
+		return nil, wrapPaymentMethodError("erro ao atualizar forma de pagamento", err)
+	}
+	if req.IsDefault && !m.IsDefault {
+		return s.SetDefault(ctx, id, ownerID)
+	}
+	return m, nil
+}
+
+// SetDefault torna a forma a padrão dos novos pagamentos
+func (s *PaymentMethodService) SetDefault(ctx context.Context, id, ownerID uuid.UUID) (*models.PaymentMethod, error) {
+	if err := s.repo.SetDefault(ctx, id, ownerID); err != nil {
+		return nil, wrapPaymentMethodError("erro ao definir forma de pagamento padrão", err)
+	}
+	return s.Get(ctx, id, ownerID)
+}
+
+// Archive arquiva a forma; pagamentos antigos continuam apontando para ela
+func (s *PaymentMethodService) Archive(ctx context.Context, id, ownerID uuid.UUID) error {
+	if err := s.repo.Archive(ctx, id, ownerID); err != nil {
+		return wrapPaymentMethodError("erro ao arquivar forma de pagamento", err)
+	}
+	return nil
+}
+
+// ListPayments lista os pagamentos lançados com a forma (inclusive arquivada)
+func (s *PaymentMethodService) ListPayments(ctx context.Context, id, ownerID uuid.UUID, filter *models.PaymentMethodPaymentsFilter) ([]models.Payment, int, error) {
+	if _, err := s.Get(ctx, id, ownerID); err != nil {
+		return nil, 0, err
+	}
+	items, total, err := s.repo.ListPayments(ctx, id, ownerID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar pagamentos da forma: %w", err)
+	}
+	return items, total, nil
+}
+
+// Resolve escolhe a forma de um novo pagamento (implementa PaymentMethodResolver).
+// Docstring: method_id tem precedência; o campo legado metodo precisa corresponder ao nome (ou,
+// na falta, ao tipo) de uma forma ativa; sem nenhum dos dois usa a forma padrão, se houver.
+func (s *PaymentMethodService) Resolve(ctx context.Context, ownerID uuid.UUID, methodID *uuid.UUID, metodo *string) (*models.PaymentMethod, error) {
+	if methodID != nil {
+		m, err := s.Get(ctx, *methodID, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		if m.ArchivedAt != nil {
+			return nil, models.ErrPaymentMethodArchived
+		}
+		return m, nil
+	}
+	items, err := s.List(ctx, ownerID, false)
+	if err != nil {
+		return nil, err
+	}
+	if metodo == nil || strings.TrimSpace(*metodo) == "" {
+		for i := range items {
+			if items[i].IsDefault {
+				return &items[i], nil
+			}
+		}
+		return nil, nil
+	}
+	text := strings.TrimSpace(*metodo)
+	for i := range items {
+		if strings.EqualFold(items[i].Nome, text) {
+			return &items[i], nil
+		}
+	}
+	for i := range items {
+		if strings.EqualFold(items[i].Tipo, text) {
+			return &items[i], nil
+		}
+	}
+	return nil, models.ErrPaymentMethodNotFound
+}
+
+func applyPaymentMethodRequest(m *models.PaymentMethod, req *models.PaymentMethodRequest) {
+	m.Tipo, m.Nome = req.Tipo, req.Nome
+	m.PixChave, m.Banco, m.Agencia, m.Conta = req.PixChave, req.Banco, req.Agencia, req.Conta
+}
+
+// wrapPaymentMethodError preserva os erros de domínio e contextualiza os demais
+func wrapPaymentMethodError(msg string, err error) error {
+	for _, known := range []error{models.ErrPaymentMethodNotFound, models.ErrPaymentMethodArchived, models.ErrPaymentMethodNameTaken} {
+		if errors.Is(err, known) {
+			return err
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
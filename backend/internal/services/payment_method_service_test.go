@@ -0,0 +1,129 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do catálogo de formas de pagamento (catálogo inicial e resolução da forma do pagamento)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakePaymentMethodRepo implementa repositories.PaymentMethodRepository em memória
+type fakePaymentMethodRepo struct {
+    items         []models.PaymentMethod
+    defaultsCalls int
+}
+
+func (f *fakePaymentMethodRepo) Create(ctx context.Context, m *models.PaymentMethod) error {
+    m.ID = uuid.New()
+    f.items = append(f.items, *m)
+    return nil
+}
+func (f *fakePaymentMethodRepo) CreateDefaults(ctx context.Context, ownerID uuid.UUID, items []models.PaymentMethod) error {
+    f.defaultsCalls++
+    for _, m := range items {
+        m.ID, m.OwnerID = uuid.New(), ownerID
+        f.items = append(f.items, m)
+    }
+    return nil
+}
+func (f *fakePaymentMethodRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.PaymentMethod, error) {
+    for i := range f.items {
+        if f.items[i].ID == id && f.items[i].OwnerID == ownerID { m := f.items[i]; return &m, nil }
+    }
+    return nil, models.ErrPaymentMethodNotFound
+}
+func (f *fakePaymentMethodRepo) GetDefault(ctx context.Context, ownerID uuid.UUID) (*models.PaymentMethod, error) {
+    return nil, models.ErrPaymentMethodNotFound
+}
+func (f *fakePaymentMethodRepo) List(ctx context.Context, ownerID uuid.UUID, includeArchived bool) ([]models.PaymentMethod, error) {
+    var out []models.PaymentMethod
+    for _, m := range f.items {
+        if m.OwnerID == ownerID && (includeArchived || m.ArchivedAt == nil) { out = append(out, m) }
+    }
+    return out, nil
+}
+func (f *fakePaymentMethodRepo) Update(ctx context.Context, m *models.PaymentMethod) error { return nil }
+func (f *fakePaymentMethodRepo) SetDefault(ctx context.Context, id, ownerID uuid.UUID) error { return nil }
+func (f *fakePaymentMethodRepo) Archive(ctx context.Context, id, ownerID uuid.UUID) error {
+    for i := range f.items {
+        if f.items[i].ID == id { now := time.Now(); f.items[i].ArchivedAt, f.items[i].IsDefault = &now, false; return nil }
+    }
+    return models.ErrPaymentMethodNotFound
+}
+func (f *fakePaymentMethodRepo) ListPayments(ctx context.Context, id, ownerID uuid.UUID, filter *models.PaymentMethodPaymentsFilter) ([]models.Payment, int, error) {
+    return nil, 0, nil
+}
+
+func TestPaymentMethodService_ListCreatesDefaultsOnce(t *testing.T) {
+    repo := &fakePaymentMethodRepo{}
+    svc := NewPaymentMethodService(repo, logging.NewLogger("dev"))
+    owner := uuid.New()
+
+    items, err := svc.List(context.Background(), owner, false)
+    if err != nil { t.Fatalf("List: %v", err) }
+    if len(items) != 3 || !items[0].IsDefault || items[0].Tipo != models.PaymentMethodPix {
+        t.Fatalf("catálogo inicial = %+v", items)
+    }
+    _, _ = svc.List(context.Background(), owner, false)
+    if repo.defaultsCalls != 1 { t.Fatalf("catálogo inicial criado %d vezes", repo.defaultsCalls) }
+}
+
+func TestPaymentMethodService_Resolve(t *testing.T) {
+    repo := &fakePaymentMethodRepo{}
+    svc := NewPaymentMethodService(repo, logging.NewLogger("dev"))
+    owner := uuid.New()
+    ctx := context.Background()
+    str := func(s string) *string { return &s }
+
+    // Catálogo criado pelo usuário: o inicial (PIX, Transferência, Dinheiro) não é gerado
+    itau, _ := svc.Create(ctx, owner, &models.PaymentMethodRequest{Tipo: "pix", Nome: "Pix Itaú"})
+    def, _ := svc.Create(ctx, owner, &models.PaymentMethodRequest{Tipo: "dinheiro", Nome: "Espécie", IsDefault: true})
+
+    m, err := svc.Resolve(ctx, owner, nil, str("pix itaú"))
+    if err != nil || m.ID != itau.ID { t.Fatalf("por nome: %+v, %v", m, err) }
+    if m, err = svc.Resolve(ctx, owner, nil, str("PIX")); err != nil || m.ID != itau.ID {
+        t.Fatalf("por tipo: %+v, %v", m, err)
+    }
+    if m, err = svc.Resolve(ctx, owner, nil, nil); err != nil || m.ID != def.ID {
+        t.Fatalf("sem forma usa a padrão: %+v, %v", m, err)
+    }
+    if _, err = svc.Resolve(ctx, owner, nil, str("cheque")); !errors.Is(err, models.ErrPaymentMethodNotFound) {
+        t.Fatalf("texto desconhecido: err = %v", err)
+    }
+    _ = svc.Archive(ctx, itau.ID, owner)
+    if _, err = svc.Resolve(ctx, owner, &itau.ID, nil); !errors.Is(err, models.ErrPaymentMethodArchived) {
+        t.Fatalf("forma arquivada: err = %v", err)
+    }
+    if _, err = svc.Resolve(ctx, uuid.New(), &def.ID, nil); !errors.Is(err, models.ErrPaymentMethodNotFound) {
+        t.Fatalf("forma de outro usuário: err = %v", err)
+    }
+}
+
+func TestAddPayment_ResolvesCatalogMethod(t *testing.T) {
+    ownerID := uuid.New()
+    existing := &models.Income{ID: uuid.New(), OwnerID: ownerID, Valor: models.NewMoney(100), Status: models.StatusPendente}
+    repo := &fakeIncomeRepo{getByIDResp: existing}
+    methods := NewPaymentMethodService(&fakePaymentMethodRepo{}, logging.NewLogger("dev"))
+    svc := NewIncomeService(repo, WithPaymentMethods(methods))
+
+    pix := "pix"
+    resp, err := svc.AddPayment(context.Background(), ownerID, &models.PaymentRequest{IncomeID: existing.ID, Valor: models.NewMoney(10), Metodo: &pix})
+    if err != nil { t.Fatalf("AddPayment: %v", err) }
+    if resp.Payment.MethodID == nil || resp.Payment.Metodo == nil || *resp.Payment.Metodo != "PIX" {
+        t.Fatalf("pagamento sem forma do catálogo: %+v", resp.Payment)
+    }
+
+    outro := "cheque"
+    if _, err := svc.AddPayment(context.Background(), ownerID, &models.PaymentRequest{IncomeID: existing.ID, Valor: models.NewMoney(10), Metodo: &outro}); !errors.Is(err, models.ErrPaymentMethodNotFound) {
+        t.Fatalf("método fora do catálogo: err = %v", err)
+    }
+}
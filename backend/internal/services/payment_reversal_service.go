@@ -0,0 +1,76 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Exclusão e estorno de pagamentos com recálculo da receita e auditoria
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// PaymentReversalService desfaz pagamentos lançados.
+// Docstring: a exclusão remove um lançamento errado; o estorno mantém o pagamento no histórico
+// fora do total pago. Nos dois casos total pago e status da receita são recalculados na mesma
+// transação e a operação fica registrada em rf_payment_reversals.
+type PaymentReversalService struct {
+	repo repositories.PaymentReversalRepository
+	log  logging.Logger
+}
+
+// NewPaymentReversalService cria o serviço de exclusão e estorno de pagamentos
+func NewPaymentReversalService(repo repositories.PaymentReversalRepository, log logging.Logger) *PaymentReversalService {
+	return &PaymentReversalService{repo: repo, log: log}
+}
+
+// DeletePayment exclui o pagamento (motivo opcional)
+func (s *PaymentReversalService) DeletePayment(ctx context.Context, paymentID, ownerID uuid.UUID, req *models.PaymentReversalRequest) (*models.PaymentReversalResponse, error) {
+	return s.reverse(ctx, paymentID, ownerID, models.PaymentReversalDelete, req)
+}
+
+// ReversePayment estorna o pagamento (motivo obrigatório)
+func (s *PaymentReversalService) ReversePayment(ctx context.Context, paymentID, ownerID uuid.UUID, req *models.PaymentReversalRequest) (*models.PaymentReversalResponse, error) {
+	return s.reverse(ctx, paymentID, ownerID, models.PaymentReversalReverse, req)
+}
+
+func (s *PaymentReversalService) reverse(ctx context.Context, paymentID, ownerID uuid.UUID, kind string, req *models.PaymentReversalRequest) (*models.PaymentReversalResponse, error) {
+	if req == nil {
+		req = &models.PaymentReversalRequest{}
+	}
+	if err := req.Validate(kind); err != nil {
+		return nil, err
+	}
+	rev := &models.PaymentReversal{OwnerID: ownerID, PaymentID: paymentID, Kind: kind}
+	if req.Reason != "" {
+		rev.Reason = &req.Reason
+	}
+	income, err := s.repo.Reverse(ctx, rev)
+	if err != nil {
+		if errors.Is(err, models.ErrPaymentNotFound) || errors.Is(err, models.ErrPaymentAlreadyReversed) ||
+			errors.Is(err, models.ErrCreditAlreadyApplied) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao desfazer pagamento: %w", err)
+	}
+	s.log.Info("pagamento desfeito",
+		logging.Field{Key: "kind", Val: kind},
+		logging.Field{Key: "payment_id", Val: paymentID.String()},
+		logging.Field{Key: "income_id", Val: income.ID.String()})
+	return &models.PaymentReversalResponse{Reversal: *rev, Income: *income}, nil
+}
+
+// ListReversals lista as exclusões e estornos de pagamentos de uma receita
+func (s *PaymentReversalService) ListReversals(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.PaymentReversal, error) {
+	items, err := s.repo.ListByIncome(ctx, incomeID, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar estornos: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,186 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Cobranças PIX das receitas: cria a cobrança no PSP, guarda o txid e devolve BR Code e QR Code
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/pix"
+	"recibofast/internal/repositories"
+)
+
+// PixService gera cobranças PIX (QR dinâmico) para as receitas.
+// Docstring: o valor padrão é o saldo a receber; enquanto houver cobrança ativa e válida com o
+// mesmo valor ela é devolvida em vez de criar outra no PSP. A chave recebedora vem da forma de
+// pagamento PIX do usuário (a padrão primeiro) ou, sem ela, de PIX_KEY. Sem PSP configurado
+// (provider nil) responde ErrPixUnavailable.
+type PixService struct {
+	charges    repositories.PixChargeRepository
+	incomes    repositories.IncomeRepository
+	payers     repositories.PayerRepository
+	methods    repositories.PaymentMethodRepository
+	provider   pix.Provider
+	defaultKey string
+	log        logging.Logger
+	now        func() time.Time
+}
+
+// NewPixService cria o serviço de cobranças PIX; provider pode ser nil (cobrança desabilitada)
+func NewPixService(charges repositories.PixChargeRepository, incomes repositories.IncomeRepository, payers repositories.PayerRepository,
+	methods repositories.PaymentMethodRepository, provider pix.Provider, defaultKey string, log logging.Logger) *PixService {
+	return &PixService{charges: charges, incomes: incomes, payers: payers, methods: methods, provider: provider,
+		defaultKey: strings.TrimSpace(defaultKey), log: log, now: time.Now}
+}
+
+// Charge cria (ou reaproveita) a cobrança PIX da receita
+func (s *PixService) Charge(ctx context.Context, incomeID, ownerID uuid.UUID, req *models.PixChargeRequest) (*models.PixChargeResponse, error) {
+	if s.provider == nil {
+		return nil, models.ErrPixUnavailable
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	income, err := s.incomes.GetByID(ctx, incomeID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if income.Status == models.StatusPago || income.Status == models.StatusCancelado {
+		return nil, models.ErrPixIncomeClosed
+	}
+	amount := income.Valor - income.TotalPago
+	if req.Valor != nil {
+		amount = *req.Valor
+	} else if amount <= 0 {
+		return nil, models.ErrPixNothingDue
+	}
+
+	now := s.now().UTC()
+	if active, err := s.charges.GetActive(ctx, incomeID, ownerID, now); err == nil && active.Valor == amount && active.Provider == s.provider.Name() {
+		return pixChargeResponse(active)
+	} else if err != nil && !errors.Is(err, models.ErrPixChargeNotFound) {
+		return nil, fmt.Errorf("erro ao consultar cobrança PIX: %w", err)
+	}
+
+	key, err := s.receiverKey(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	creq := &pix.ChargeRequest{
+		TxID:        strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Amount:      int64(amount),
+		Key:         key,
+		Description: pixDescription(income),
+		Reference:   income.ID.String(),
+	}
+	if req.Expiracao != nil {
+		creq.Expiration = time.Duration(*req.Expiracao) * time.Second
+	}
+	if income.PayerID != nil {
+		payer, err := s.payers.GetByID(ctx, *income.PayerID, ownerID)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar pagador: %w", err)
+		}
+		creq.PayerName = payer.Nome
+		creq.PayerDocument = models.NormalizeDocument(derefString(payer.Documento))
+		creq.PayerEmail = strings.TrimSpace(derefString(payer.Email))
+	}
+
+	charge, err := s.provider.CreateCharge(ctx, creq)
+	if errors.Is(err, pix.ErrPayerEmailRequired) {
+		return nil, models.ErrPixPayerEmail
+	}
+	if err != nil {
+		s.log.Warn("falha ao criar cobrança PIX",
+			logging.Field{Key: "income_id", Val: incomeID.String()},
+			logging.Field{Key: "provider", Val: s.provider.Name()},
+			logging.Field{Key: "error", Val: err.Error()})
+		return nil, fmt.Errorf("%w: %v", models.ErrPixProviderFailed, err)
+	}
+
+	c := &models.PixCharge{
+		OwnerID:   ownerID,
+		IncomeID:  incomeID,
+		Provider:  charge.Provider,
+		TxID:      charge.TxID,
+		Valor:     amount,
+		BRCode:    charge.BRCode,
+		ExpiresAt: charge.ExpiresAt.UTC(),
+	}
+	if charge.Location != "" {
+		c.Location = &charge.Location
+	}
+	// A cobrança já existe no PSP: grava mesmo se o cliente desistiu da requisição
+	if err := s.charges.Create(context.WithoutCancel(ctx), c); err != nil {
+		return nil, fmt.Errorf("erro ao registrar cobrança PIX: %w", err)
+	}
+	s.log.Info("cobrança PIX criada",
+		logging.Field{Key: "income_id", Val: incomeID.String()},
+		logging.Field{Key: "txid", Val: c.TxID})
+	return pixChargeResponse(c)
+}
+
+// Current cobrança ativa e válida da receita
+func (s *PixService) Current(ctx context.Context, incomeID, ownerID uuid.UUID) (*models.PixChargeResponse, error) {
+	c, err := s.charges.GetActive(ctx, incomeID, ownerID, s.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	return pixChargeResponse(c)
+}
+
+// receiverKey chave PIX recebedora: forma de pagamento PIX do usuário (a padrão primeiro) ou PIX_KEY
+func (s *PixService) receiverKey(ctx context.Context, ownerID uuid.UUID) (string, error) {
+	items, err := s.methods.List(ctx, ownerID, false)
+	if err != nil {
+		return "", fmt.Errorf("erro ao carregar formas de pagamento: %w", err)
+	}
+	key := ""
+	for _, m := range items {
+		if m.Tipo != models.PaymentMethodPix || m.PixChave == nil {
+			continue
+		}
+		if key == "" || m.IsDefault {
+			key = *m.PixChave
+		}
+	}
+	if key == "" {
+		key = s.defaultKey
+	}
+	if key == "" {
+		return "", models.ErrPixKeyMissing
+	}
+	return key, nil
+}
+
+// pixDescription texto exibido ao pagador no app do banco (solicitação ao pagador)
+func pixDescription(income *models.Income) string {
+	desc := "Receita " + income.Competencia
+	if c := strings.TrimSpace(derefString(income.Categoria)); c != "" {
+		desc = c + " - " + income.Competencia
+	}
+	return desc
+}
+
+// pixChargeResponse anexa o QR Code em PNG (data URI) gerado a partir do BR Code
+func pixChargeResponse(c *models.PixCharge) (*models.PixChargeResponse, error) {
+	qr, err := pix.EncodeQR(c.BRCode)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar QR Code: %w", err)
+	}
+	img, err := qr.PNG(8)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar QR Code: %w", err)
+	}
+	return &models.PixChargeResponse{PixCharge: c, QRCodeImage: "data:image/png;base64," + base64.StdEncoding.EncodeToString(img)}, nil
+}
@@ -0,0 +1,142 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do PixService (valor padrão, reaproveitamento da cobrança e chave recebedora)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/pix"
+    "recibofast/internal/repositories"
+)
+
+type fakePixChargeRepo struct {
+    repositories.PixChargeRepository
+    items []models.PixCharge
+}
+
+func (f *fakePixChargeRepo) Create(ctx context.Context, c *models.PixCharge) error {
+    c.ID, c.Status = uuid.New(), models.PixChargeActive
+    f.items = append(f.items, *c)
+    return nil
+}
+func (f *fakePixChargeRepo) GetActive(ctx context.Context, incomeID, ownerID uuid.UUID, now time.Time) (*models.PixCharge, error) {
+    for i := len(f.items) - 1; i >= 0; i-- {
+        c := f.items[i]
+        if c.IncomeID == incomeID && c.OwnerID == ownerID && c.Status == models.PixChargeActive && c.ExpiresAt.After(now) { return &c, nil }
+    }
+    return nil, models.ErrPixChargeNotFound
+}
+
+type fakePixPayerRepo struct {
+    repositories.PayerRepository
+    payer *models.Payer
+}
+
+func (f *fakePixPayerRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Payer, error) {
+    return f.payer, nil
+}
+
+type fakePixProvider struct {
+    reqs     []pix.ChargeRequest
+    err      error
+    payments []pix.Payment
+    parseErr error
+}
+
+func (p *fakePixProvider) Name() string { return pix.ProviderBank }
+func (p *fakePixProvider) CreateCharge(ctx context.Context, req *pix.ChargeRequest) (*pix.Charge, error) {
+    p.reqs = append(p.reqs, *req)
+    if p.err != nil { return nil, p.err }
+    return &pix.Charge{Provider: pix.ProviderBank, TxID: req.TxID, BRCode: "000201" + req.TxID, ExpiresAt: time.Now().Add(pix.DefaultExpiration)}, nil
+}
+func (p *fakePixProvider) ParseWebhook(ctx context.Context, req *pix.WebhookRequest, secret string) ([]pix.Payment, error) {
+    return p.payments, p.parseErr
+}
+
+func newPixServiceForTest(income *models.Income, methods []models.PaymentMethod, provider pix.Provider, defaultKey string) (*PixService, *fakePixChargeRepo) {
+    charges := &fakePixChargeRepo{}
+    email, doc := "pagador@exemplo.com", "123.456.789-09"
+    payers := &fakePixPayerRepo{payer: &models.Payer{Nome: "Maria", Email: &email, Documento: &doc}}
+    svc := NewPixService(charges, &fakeIncomeRepo{getByIDResp: income}, payers, &fakePaymentMethodRepo{items: methods}, provider, defaultKey, logging.NewLogger("dev"))
+    return svc, charges
+}
+
+func TestPixCharge_DefaultsToBalanceAndReusesActiveCharge(t *testing.T) {
+    ownerID, payerID := uuid.New(), uuid.New()
+    income := &models.Income{ID: uuid.New(), OwnerID: ownerID, PayerID: &payerID, Competencia: "2026-10", Valor: models.NewMoney(150), TotalPago: models.NewMoney(50), Status: models.StatusParcial}
+    key, other := "chave@exemplo.com", "outra@exemplo.com"
+    methods := []models.PaymentMethod{
+        {OwnerID: ownerID, Tipo: models.PaymentMethodPix, PixChave: &other},
+        {OwnerID: ownerID, Tipo: models.PaymentMethodPix, PixChave: &key, IsDefault: true},
+    }
+    provider := &fakePixProvider{}
+    svc, charges := newPixServiceForTest(income, methods, provider, "")
+
+    out, err := svc.Charge(context.Background(), income.ID, ownerID, &models.PixChargeRequest{})
+    if err != nil { t.Fatalf("Charge: %v", err) }
+    if out.Valor != models.NewMoney(100) || !strings.HasPrefix(out.QRCodeImage, "data:image/png;base64,") {
+        t.Fatalf("cobrança = %+v", out.PixCharge)
+    }
+    req := provider.reqs[0]
+    if req.Amount != int64(models.NewMoney(100)) || req.Key != key || req.PayerDocument != "12345678909" || req.PayerEmail != "pagador@exemplo.com" {
+        t.Fatalf("pedido ao PSP = %+v", req)
+    }
+
+    again, err := svc.Charge(context.Background(), income.ID, ownerID, &models.PixChargeRequest{})
+    if err != nil || again.TxID != out.TxID || len(provider.reqs) != 1 || len(charges.items) != 1 {
+        t.Fatalf("cobrança ativa não reaproveitada: err=%v chamadas=%d", err, len(provider.reqs))
+    }
+
+    valor := models.NewMoney(30)
+    if _, err := svc.Charge(context.Background(), income.ID, ownerID, &models.PixChargeRequest{Valor: &valor}); err != nil || len(provider.reqs) != 2 {
+        t.Fatalf("novo valor deveria gerar nova cobrança: err=%v chamadas=%d", err, len(provider.reqs))
+    }
+    cur, err := svc.Current(context.Background(), income.ID, ownerID)
+    if err != nil || cur.Valor != valor { t.Fatalf("Current = %+v, %v", cur, err) }
+}
+
+func TestPixCharge_Errors(t *testing.T) {
+    ownerID := uuid.New()
+    income := &models.Income{ID: uuid.New(), OwnerID: ownerID, Competencia: "2026-10", Valor: models.NewMoney(100), Status: models.StatusPendente}
+
+    svc, _ := newPixServiceForTest(income, nil, nil, "")
+    if _, err := svc.Charge(context.Background(), income.ID, ownerID, &models.PixChargeRequest{}); !errors.Is(err, models.ErrPixUnavailable) {
+        t.Fatalf("sem PSP: err = %v", err)
+    }
+
+    svc, _ = newPixServiceForTest(income, nil, &fakePixProvider{}, "")
+    if _, err := svc.Charge(context.Background(), income.ID, ownerID, &models.PixChargeRequest{}); !errors.Is(err, models.ErrPixKeyMissing) {
+        t.Fatalf("sem chave: err = %v", err)
+    }
+
+    provider := &fakePixProvider{}
+    svc, _ = newPixServiceForTest(income, nil, provider, "fallback@exemplo.com")
+    if _, err := svc.Charge(context.Background(), income.ID, ownerID, &models.PixChargeRequest{}); err != nil || provider.reqs[0].Key != "fallback@exemplo.com" {
+        t.Fatalf("PIX_KEY como fallback: err = %v", err)
+    }
+
+    provider.err = pix.ErrPayerEmailRequired
+    svc, _ = newPixServiceForTest(income, nil, provider, "fallback@exemplo.com")
+    if _, err := svc.Charge(context.Background(), income.ID, ownerID, &models.PixChargeRequest{}); !errors.Is(err, models.ErrPixPayerEmail) {
+        t.Fatalf("sem e-mail do pagador: err = %v", err)
+    }
+    provider.err = errors.New("timeout")
+    if _, err := svc.Charge(context.Background(), income.ID, ownerID, &models.PixChargeRequest{}); !errors.Is(err, models.ErrPixProviderFailed) {
+        t.Fatalf("falha do PSP: err = %v", err)
+    }
+
+    income.Status = models.StatusPago
+    if _, err := svc.Charge(context.Background(), income.ID, ownerID, &models.PixChargeRequest{}); !errors.Is(err, models.ErrPixIncomeClosed) {
+        t.Fatalf("receita paga: err = %v", err)
+    }
+}
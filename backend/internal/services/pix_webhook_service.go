@@ -0,0 +1,122 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Baixa automática das cobranças PIX: casa a notificação do PSP com a receita e lança o pagamento
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/pix"
+	"recibofast/internal/repositories"
+)
+
+// PixWebhookResult resumo de uma notificação: pagamentos recebidos e lançados nas receitas
+type PixWebhookResult struct {
+	Received   int `json:"received"`
+	Registered int `json:"registered"`
+}
+
+// PixWebhookService processa as notificações de pagamento do PSP configurado.
+// Docstring: a cobrança é marcada como paga antes do lançamento, de modo que notificações
+// repetidas não duplicam o pagamento; se o lançamento falhar por erro transitório a cobrança
+// volta a ativa e o erro faz o PSP reenviar. O valor lançado é o pago no PSP e o excedente ao
+// saldo vira crédito (quando habilitado). Recusas de negócio (receita já quitada, por exemplo)
+// deixam a cobrança paga para conciliação manual, já que o dinheiro entrou.
+type PixWebhookService struct {
+	charges  repositories.PixChargeRepository
+	payments PaymentRecorder
+	provider pix.Provider
+	secret   string
+	log      logging.Logger
+}
+
+// NewPixWebhookService cria o serviço do webhook PIX; provider pode ser nil (cobrança desabilitada)
+func NewPixWebhookService(charges repositories.PixChargeRepository, payments PaymentRecorder, provider pix.Provider, secret string, log logging.Logger) *PixWebhookService {
+	return &PixWebhookService{charges: charges, payments: payments, provider: provider, secret: secret, log: log}
+}
+
+// Handle valida a notificação e lança os pagamentos confirmados
+func (s *PixWebhookService) Handle(ctx context.Context, req *pix.WebhookRequest) (*PixWebhookResult, error) {
+	if s.provider == nil {
+		return nil, models.ErrPixUnavailable
+	}
+	payments, err := s.provider.ParseWebhook(ctx, req, s.secret)
+	switch {
+	case errors.Is(err, pix.ErrInvalidSignature):
+		return nil, models.ErrPixWebhookAuth
+	case errors.Is(err, pix.ErrInvalidPayload):
+		return nil, models.ErrPixWebhookPayload
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", models.ErrPixProviderFailed, err)
+	}
+	res := &PixWebhookResult{Received: len(payments)}
+	for _, p := range payments {
+		ok, err := s.register(ctx, p)
+		if err != nil {
+			return res, err
+		}
+		if ok {
+			res.Registered++
+		}
+	}
+	return res, nil
+}
+
+// register baixa a cobrança do txid e lança o pagamento; false quando nada foi lançado
+func (s *PixWebhookService) register(ctx context.Context, p pix.Payment) (bool, error) {
+	var endToEndID *string
+	if p.EndToEndID != "" {
+		endToEndID = &p.EndToEndID
+	}
+	charge, err := s.charges.ClaimPaid(ctx, s.provider.Name(), p.TxID, endToEndID, p.PaidAt.UTC())
+	if errors.Is(err, models.ErrPixChargeNotFound) {
+		s.log.Info("notificação PIX sem cobrança pendente (repetida ou desconhecida)", logging.Field{Key: "txid", Val: p.TxID})
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao baixar cobrança PIX: %w", err)
+	}
+
+	metodo, pagoEm := models.PaymentMethodPix, p.PaidAt.UTC().Format(time.RFC3339)
+	obs := "PIX txid " + p.TxID
+	if p.EndToEndID != "" {
+		obs += " (E2E " + p.EndToEndID + ")"
+	}
+	req := &models.PaymentRequest{IncomeID: charge.IncomeID, Valor: models.Money(p.Amount), PagoEm: &pagoEm,
+		Metodo: &metodo, Obs: &obs, Overpayment: models.OverpaymentCredit}
+	out, err := s.payments.AddPayment(ctx, charge.OwnerID, req)
+	if errors.Is(err, models.ErrPaymentMethodNotFound) {
+		// Sem forma PIX no catálogo do usuário: lança com a forma padrão
+		req.Metodo = nil
+		out, err = s.payments.AddPayment(ctx, charge.OwnerID, req)
+	}
+	switch {
+	case errors.Is(err, models.ErrInsufficientAmount), errors.Is(err, models.ErrIncomeAlreadyPaid),
+		errors.Is(err, models.ErrIncomeNotFound), errors.Is(err, models.ErrValorInvalid):
+		s.log.Warn("pagamento PIX recebido sem lançamento na receita; concilie manualmente",
+			logging.Field{Key: "income_id", Val: charge.IncomeID.String()},
+			logging.Field{Key: "txid", Val: p.TxID},
+			logging.Field{Key: "error", Val: err.Error()})
+		return false, nil
+	case err != nil:
+		if rerr := s.charges.Release(context.WithoutCancel(ctx), charge.ID); rerr != nil {
+			s.log.Error("erro ao reabrir cobrança PIX", logging.Field{Key: "txid", Val: p.TxID}, logging.Field{Key: "error", Val: rerr.Error()})
+		}
+		return false, fmt.Errorf("erro ao lançar pagamento PIX: %w", err)
+	}
+	if err := s.charges.SetPayment(context.WithoutCancel(ctx), charge.ID, out.Payment.ID); err != nil {
+		s.log.Warn("erro ao ligar cobrança PIX ao pagamento", logging.Field{Key: "txid", Val: p.TxID}, logging.Field{Key: "error", Val: err.Error()})
+	}
+	s.log.Info("pagamento PIX lançado",
+		logging.Field{Key: "income_id", Val: charge.IncomeID.String()},
+		logging.Field{Key: "payment_id", Val: out.Payment.ID.String()},
+		logging.Field{Key: "txid", Val: p.TxID})
+	return true, nil
+}
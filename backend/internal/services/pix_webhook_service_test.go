@@ -0,0 +1,128 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da baixa automática das cobranças PIX pelo webhook do PSP
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/pix"
+)
+
+// ClaimPaid, Release e SetPayment do fake em memória de pix_service_test.go
+func (f *fakePixChargeRepo) ClaimPaid(ctx context.Context, provider, txid string, endToEndID *string, paidAt time.Time) (*models.PixCharge, error) {
+    for i := range f.items {
+        c := &f.items[i]
+        if c.Provider == provider && c.TxID == txid && (c.Status == models.PixChargeActive || c.Status == models.PixChargeExpired) {
+            c.Status, c.PaidAt, c.EndToEndID = models.PixChargePaid, &paidAt, endToEndID
+            out := *c
+            return &out, nil
+        }
+    }
+    return nil, models.ErrPixChargeNotFound
+}
+func (f *fakePixChargeRepo) Release(ctx context.Context, id uuid.UUID) error {
+    for i := range f.items {
+        if f.items[i].ID == id && f.items[i].PaymentID == nil { f.items[i].Status, f.items[i].PaidAt = models.PixChargeActive, nil }
+    }
+    return nil
+}
+func (f *fakePixChargeRepo) SetPayment(ctx context.Context, id, paymentID uuid.UUID) error {
+    for i := range f.items {
+        if f.items[i].ID == id { f.items[i].PaymentID = &paymentID }
+    }
+    return nil
+}
+
+// fakePixRecorder registra os pagamentos lançados; errs são devolvidos em ordem antes de aceitar
+type fakePixRecorder struct {
+    reqs []models.PaymentRequest
+    errs []error
+}
+
+func (f *fakePixRecorder) AddPayment(ctx context.Context, ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error) {
+    f.reqs = append(f.reqs, *req)
+    if len(f.errs) > 0 {
+        err := f.errs[0]
+        f.errs = f.errs[1:]
+        if err != nil { return nil, err }
+    }
+    return &models.PaymentResponse{Payment: models.Payment{ID: uuid.New(), IncomeID: req.IncomeID, Valor: req.Valor}}, nil
+}
+
+func newPixWebhookFixture() (*fakePixChargeRepo, *models.PixCharge) {
+    charges := &fakePixChargeRepo{}
+    c := &models.PixCharge{OwnerID: uuid.New(), IncomeID: uuid.New(), Provider: pix.ProviderBank, TxID: "tx1", Valor: models.NewMoney(100), ExpiresAt: time.Now().Add(time.Hour)}
+    _ = charges.Create(context.Background(), c)
+    return charges, c
+}
+
+func TestPixWebhook_RegistersPaymentOnce(t *testing.T) {
+    charges, c := newPixWebhookFixture()
+    paidAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    provider := &fakePixProvider{payments: []pix.Payment{
+        {TxID: "tx1", EndToEndID: "E123", Amount: 10000, PaidAt: paidAt},
+        {TxID: "desconhecido", Amount: 500, PaidAt: paidAt},
+    }}
+    recorder := &fakePixRecorder{}
+    svc := NewPixWebhookService(charges, recorder, provider, "segredo", logging.NewLogger("dev"))
+
+    res, err := svc.Handle(context.Background(), &pix.WebhookRequest{})
+    if err != nil { t.Fatalf("Handle: %v", err) }
+    if res.Received != 2 || res.Registered != 1 || len(recorder.reqs) != 1 { t.Fatalf("resultado = %+v, lançamentos = %d", res, len(recorder.reqs)) }
+    req := recorder.reqs[0]
+    if req.IncomeID != c.IncomeID || req.Valor != models.NewMoney(100) || *req.PagoEm != "2026-10-18T12:00:00Z" ||
+        *req.Metodo != models.PaymentMethodPix || req.Overpayment != models.OverpaymentCredit {
+        t.Fatalf("pagamento lançado = %+v", req)
+    }
+    stored := charges.items[0]
+    if stored.Status != models.PixChargePaid || stored.PaymentID == nil || *stored.EndToEndID != "E123" { t.Fatalf("cobrança = %+v", stored) }
+
+    // Notificação repetida: nada é lançado de novo
+    res, err = svc.Handle(context.Background(), &pix.WebhookRequest{})
+    if err != nil || res.Registered != 0 || len(recorder.reqs) != 1 { t.Fatalf("repetida: %+v, %v", res, err) }
+}
+
+func TestPixWebhook_FallbacksAndFailures(t *testing.T) {
+    payment := pix.Payment{TxID: "tx1", Amount: 10000, PaidAt: time.Now()}
+
+    // Sem forma PIX no catálogo: lança com a forma padrão
+    charges, _ := newPixWebhookFixture()
+    recorder := &fakePixRecorder{errs: []error{models.ErrPaymentMethodNotFound}}
+    svc := NewPixWebhookService(charges, recorder, &fakePixProvider{payments: []pix.Payment{payment}}, "segredo", logging.NewLogger("dev"))
+    if res, err := svc.Handle(context.Background(), &pix.WebhookRequest{}); err != nil || res.Registered != 1 || len(recorder.reqs) != 2 || recorder.reqs[1].Metodo != nil {
+        t.Fatalf("fallback da forma: %+v, %v", res, err)
+    }
+
+    // Erro transitório: a cobrança volta a ativa para o PSP reenviar
+    charges, _ = newPixWebhookFixture()
+    recorder = &fakePixRecorder{errs: []error{errors.New("conexão perdida")}}
+    svc = NewPixWebhookService(charges, recorder, &fakePixProvider{payments: []pix.Payment{payment}}, "segredo", logging.NewLogger("dev"))
+    if _, err := svc.Handle(context.Background(), &pix.WebhookRequest{}); err == nil { t.Fatal("erro transitório deveria falhar") }
+    if charges.items[0].Status != models.PixChargeActive { t.Fatalf("cobrança não reaberta: %s", charges.items[0].Status) }
+    if res, err := svc.Handle(context.Background(), &pix.WebhookRequest{}); err != nil || res.Registered != 1 { t.Fatalf("reenvio: %+v, %v", res, err) }
+
+    // Receita já quitada: a cobrança fica paga para conciliação manual
+    charges, _ = newPixWebhookFixture()
+    recorder = &fakePixRecorder{errs: []error{models.ErrInsufficientAmount}}
+    svc = NewPixWebhookService(charges, recorder, &fakePixProvider{payments: []pix.Payment{payment}}, "segredo", logging.NewLogger("dev"))
+    if res, err := svc.Handle(context.Background(), &pix.WebhookRequest{}); err != nil || res.Registered != 0 || charges.items[0].Status != models.PixChargePaid {
+        t.Fatalf("receita quitada: %+v, %v", res, err)
+    }
+
+    // Assinatura inválida, payload malformado e PSP desabilitado
+    svc = NewPixWebhookService(charges, recorder, &fakePixProvider{parseErr: pix.ErrInvalidSignature}, "segredo", logging.NewLogger("dev"))
+    if _, err := svc.Handle(context.Background(), &pix.WebhookRequest{}); !errors.Is(err, models.ErrPixWebhookAuth) { t.Fatalf("assinatura: err = %v", err) }
+    svc = NewPixWebhookService(charges, recorder, &fakePixProvider{parseErr: pix.ErrInvalidPayload}, "segredo", logging.NewLogger("dev"))
+    if _, err := svc.Handle(context.Background(), &pix.WebhookRequest{}); !errors.Is(err, models.ErrPixWebhookPayload) { t.Fatalf("payload: err = %v", err) }
+    svc = NewPixWebhookService(charges, recorder, nil, "segredo", logging.NewLogger("dev"))
+    if _, err := svc.Handle(context.Background(), &pix.WebhookRequest{}); !errors.Is(err, models.ErrPixUnavailable) { t.Fatalf("sem PSP: err = %v", err) }
+}
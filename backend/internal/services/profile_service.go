@@ -0,0 +1,63 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Perfil do usuário (dados de contato, emissor padrão dos recibos, idioma e fuso)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// ProfileService lê e grava o perfil do usuário.
+// Docstring: o emissor padrão entra no snapshot dos recibos emitidos depois da gravação (recibos já
+// emitidos não mudam) e no carnê dos contratos sem emissor; idioma e fuso passam a valer para as
+// próximas requisições, PDFs e notificações.
+type ProfileService struct {
+	repo repositories.ProfileRepository
+	log  logging.Logger
+}
+
+// NewProfileService cria o serviço de perfil
+func NewProfileService(repo repositories.ProfileRepository, log logging.Logger) *ProfileService {
+	return &ProfileService{repo: repo, log: log}
+}
+
+// Get retorna o perfil do usuário (vazio se ainda não foi preenchido)
+func (s *ProfileService) Get(ctx context.Context, ownerID uuid.UUID) (*models.Profile, error) {
+	p, err := s.repo.Get(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar perfil: %w", err)
+	}
+	return p, nil
+}
+
+// Update valida e grava o perfil; o idioma é gravado na forma suportada (ex.: "pt-br" vira "pt-BR")
+func (s *ProfileService) Update(ctx context.Context, ownerID uuid.UUID, req *models.ProfileRequest) (*models.Profile, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Locale != nil {
+		lang := locale.Normalize(*req.Locale)
+		if lang == "" {
+			return nil, models.ErrInvalidLocale
+		}
+		req.Locale = &lang
+	}
+	if req.Timezone != nil && !locale.ValidTimezone(*req.Timezone) {
+		return nil, models.ErrInvalidTimezone
+	}
+	p := &models.Profile{ID: ownerID}
+	req.Apply(p)
+	if err := s.repo.Upsert(ctx, p); err != nil {
+		return nil, fmt.Errorf("erro ao gravar perfil: %w", err)
+	}
+	return p, nil
+}
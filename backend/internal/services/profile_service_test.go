@@ -0,0 +1,51 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do perfil do usuário (idioma normalizado e fuso validado)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeProfileRepo guarda o perfil gravado
+type fakeProfileRepo struct {
+    saved *models.Profile
+}
+
+func (f *fakeProfileRepo) Get(ctx context.Context, ownerID uuid.UUID) (*models.Profile, error) {
+    if f.saved == nil { return &models.Profile{ID: ownerID}, nil }
+    cp := *f.saved
+    return &cp, nil
+}
+func (f *fakeProfileRepo) Upsert(ctx context.Context, p *models.Profile) error {
+    cp := *p
+    f.saved = &cp
+    return nil
+}
+
+func TestProfileService_Update(t *testing.T) {
+    repo := &fakeProfileRepo{}
+    svc := NewProfileService(repo, logging.NewLogger("dev"))
+    owner := uuid.New()
+    ctx := context.Background()
+
+    if p, err := svc.Get(ctx, owner); err != nil || p.ID != owner || p.Locale != nil { t.Fatalf("perfil vazio = %+v, %v", p, err) }
+
+    lang, tz := "pt_br", "America/Recife"
+    p, err := svc.Update(ctx, owner, &models.ProfileRequest{Locale: &lang, Timezone: &tz})
+    if err != nil { t.Fatalf("Update: %v", err) }
+    if *p.Locale != "pt-BR" || *repo.saved.Timezone != "America/Recife" || repo.saved.ID != owner { t.Fatalf("gravado = %+v", repo.saved) }
+
+    klingon, lua := "tlh", "Lua/Base_Alfa"
+    if _, err := svc.Update(ctx, owner, &models.ProfileRequest{Locale: &klingon}); !errors.Is(err, models.ErrInvalidLocale) { t.Fatalf("err = %v", err) }
+    if _, err := svc.Update(ctx, owner, &models.ProfileRequest{Timezone: &lua}); !errors.Is(err, models.ErrInvalidTimezone) { t.Fatalf("err = %v", err) }
+    if *repo.saved.Locale != "pt-BR" { t.Fatal("pedido inválido não deveria gravar") }
+}
@@ -0,0 +1,186 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Notificações Web Push para o PWA: registro de dispositivos e envio pelo canal push da fila de entregas
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/webpush"
+)
+
+// PushClient entrega mensagens ao serviço de push do navegador (implementado por webpush.Client)
+type PushClient interface {
+	PublicKey() string
+	Send(ctx context.Context, sub *webpush.Subscription, payload []byte, opts webpush.Options) error
+}
+
+// PushService gerencia os dispositivos com push e envia as notificações.
+// Docstring: registrado como DeliverySender do canal push, recebe as entregas de lembretes,
+// pagamentos e resumo semanal (uma por dispositivo). Assinaturas que o serviço de push dá como
+// inexistentes (404/410) são apagadas e a entrega vai para dead-letter sem novas tentativas.
+// Sem chaves VAPID (client nil) o registro responde ErrPushUnavailable.
+type PushService struct {
+	repo   repositories.PushSubscriptionRepository
+	client PushClient
+	log    logging.Logger
+	now    func() time.Time
+}
+
+// NewPushService cria o serviço de push; client pode ser nil (push desabilitado)
+func NewPushService(repo repositories.PushSubscriptionRepository, client PushClient, log logging.Logger) *PushService {
+	return &PushService{repo: repo, client: client, log: log, now: time.Now}
+}
+
+// PublicKey chave pública VAPID usada pelo frontend em pushManager.subscribe
+func (s *PushService) PublicKey() (string, error) {
+	if s.client == nil {
+		return "", models.ErrPushUnavailable
+	}
+	return s.client.PublicKey(), nil
+}
+
+// Subscribe registra (ou atualiza) o dispositivo do usuário
+func (s *PushService) Subscribe(ctx context.Context, ownerID uuid.UUID, req *models.PushSubscriptionRequest, userAgent string) (*models.PushSubscription, error) {
+	if s.client == nil {
+		return nil, models.ErrPushUnavailable
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	sub := &models.PushSubscription{OwnerID: ownerID, Endpoint: req.Endpoint, P256dh: req.Keys.P256dh, Auth: req.Keys.Auth}
+	if ua := strings.TrimSpace(userAgent); ua != "" {
+		if len(ua) > 300 {
+			ua = ua[:300]
+		}
+		sub.UserAgent = &ua
+	}
+	if err := s.repo.Upsert(ctx, sub); err != nil {
+		return nil, fmt.Errorf("erro ao registrar assinatura push: %w", err)
+	}
+	return sub, nil
+}
+
+// Unsubscribe remove o dispositivo (pushSubscription.unsubscribe no frontend)
+func (s *PushService) Unsubscribe(ctx context.Context, ownerID uuid.UUID, endpoint string) error {
+	return s.repo.Delete(ctx, ownerID, strings.TrimSpace(endpoint))
+}
+
+// List dispositivos registrados do usuário
+func (s *PushService) List(ctx context.Context, ownerID uuid.UUID) ([]models.PushSubscription, error) {
+	return s.repo.List(ctx, ownerID)
+}
+
+// Notify envia a mensagem direto a todos os dispositivos do usuário (sem fila); retorna quantos aceitaram
+func (s *PushService) Notify(ctx context.Context, ownerID uuid.UUID, msg *models.NotificationMessage) (int, error) {
+	if s.client == nil {
+		return 0, models.ErrPushUnavailable
+	}
+	subs, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for i := range subs {
+		if err := s.deliver(ctx, &subs[i], payload, webpush.Options{Urgency: webpush.UrgencyHigh}); err == nil {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// Send implementa DeliverySender para o canal push: o destino da entrega é o endpoint do dispositivo
+func (s *PushService) Send(ctx context.Context, d *models.Delivery) error {
+	if s.client == nil {
+		return fmt.Errorf("%w: %v", ErrPermanentDelivery, models.ErrPushUnavailable)
+	}
+	sub, err := s.repo.GetByEndpoint(ctx, d.OwnerID, d.Destination)
+	if errors.Is(err, models.ErrPushSubscriptionNotFound) {
+		return fmt.Errorf("%w: %v", ErrPermanentDelivery, err)
+	}
+	if err != nil {
+		return err
+	}
+	msg := pushMessage(d)
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPermanentDelivery, err)
+	}
+	opts := webpush.Options{Urgency: webpush.UrgencyNormal, Topic: pushTopic(msg.Tag)}
+	if d.EventType == models.EventDigestWeekly {
+		opts.Urgency = webpush.UrgencyLow
+	}
+	if d.SLADeadline != nil {
+		opts.TTL = d.SLADeadline.Sub(s.now())
+	}
+	return s.deliver(ctx, sub, payload, opts)
+}
+
+// deliver envia a um dispositivo; assinatura inexistente é apagada e vira falha permanente
+func (s *PushService) deliver(ctx context.Context, sub *models.PushSubscription, payload []byte, opts webpush.Options) error {
+	err := s.client.Send(ctx, &webpush.Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth}, payload, opts)
+	if errors.Is(err, webpush.ErrGone) {
+		if derr := s.repo.Delete(ctx, sub.OwnerID, sub.Endpoint); derr != nil && !errors.Is(derr, models.ErrPushSubscriptionNotFound) {
+			s.log.Error("erro ao remover assinatura push expirada", logging.Field{Key: "error", Val: derr.Error()})
+		}
+		s.log.Info("assinatura push expirada removida", logging.Field{Key: "owner_id", Val: sub.OwnerID.String()})
+		return fmt.Errorf("%w: %v", ErrPermanentDelivery, err)
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Touch(ctx, sub.ID, s.now().UTC()); err != nil {
+		s.log.Warn("erro ao registrar uso da assinatura push", logging.Field{Key: "error", Val: err.Error()})
+	}
+	return nil
+}
+
+// pushMessage converte o payload da entrega na mensagem exibida pelo service worker; eventos
+// com NotificationMessage (lembretes, pagamentos) passam direto e o resumo semanal é resumido
+func pushMessage(d *models.Delivery) *models.NotificationMessage {
+	if d.EventType == models.EventDigestWeekly {
+		var p models.DigestPayload
+		if json.Unmarshal(d.Payload, &p) == nil {
+			return &models.NotificationMessage{
+				Title: "Resumo semanal",
+				Body:  fmt.Sprintf("Recebido: %s · Em atraso: %s", p.Formatted.Received, p.Formatted.Overdue),
+				URL:   "/dashboard",
+				Tag:   models.EventDigestWeekly,
+			}
+		}
+	}
+	var msg models.NotificationMessage
+	if json.Unmarshal(d.Payload, &msg) == nil && msg.Title != "" {
+		return &msg
+	}
+	return &models.NotificationMessage{Title: "ReciboFast", Body: "Você tem uma nova notificação.", Tag: d.EventType}
+}
+
+// pushTopic Topic substitui mensagens pendentes com o mesmo assunto; só aceita até 32 caracteres base64url
+func pushTopic(tag string) string {
+	var b strings.Builder
+	for _, r := range tag {
+		if b.Len() == 32 {
+			break
+		}
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
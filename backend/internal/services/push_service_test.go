@@ -0,0 +1,181 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes das notificações push (entrega por dispositivo, assinaturas expiradas e lembretes)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/webpush"
+)
+
+// fakePushRepo implementa repositories.PushSubscriptionRepository em memória
+type fakePushRepo struct{ subs []models.PushSubscription }
+
+func (f *fakePushRepo) Upsert(ctx context.Context, s *models.PushSubscription) error {
+    s.ID = uuid.New()
+    f.subs = append(f.subs, *s)
+    return nil
+}
+func (f *fakePushRepo) List(ctx context.Context, ownerID uuid.UUID) ([]models.PushSubscription, error) {
+    var out []models.PushSubscription
+    for _, s := range f.subs {
+        if s.OwnerID == ownerID { out = append(out, s) }
+    }
+    return out, nil
+}
+func (f *fakePushRepo) GetByEndpoint(ctx context.Context, ownerID uuid.UUID, endpoint string) (*models.PushSubscription, error) {
+    for i := range f.subs {
+        if f.subs[i].OwnerID == ownerID && f.subs[i].Endpoint == endpoint { return &f.subs[i], nil }
+    }
+    return nil, models.ErrPushSubscriptionNotFound
+}
+func (f *fakePushRepo) Delete(ctx context.Context, ownerID uuid.UUID, endpoint string) error {
+    for i := range f.subs {
+        if f.subs[i].OwnerID == ownerID && f.subs[i].Endpoint == endpoint {
+            f.subs = append(f.subs[:i], f.subs[i+1:]...)
+            return nil
+        }
+    }
+    return models.ErrPushSubscriptionNotFound
+}
+func (f *fakePushRepo) Touch(ctx context.Context, id uuid.UUID, at time.Time) error { return nil }
+
+type fakePushClient struct {
+    sent []models.NotificationMessage
+    opts []webpush.Options
+    err  error
+}
+
+func (c *fakePushClient) PublicKey() string { return "BPublic" }
+func (c *fakePushClient) Send(ctx context.Context, sub *webpush.Subscription, payload []byte, opts webpush.Options) error {
+    var msg models.NotificationMessage
+    json.Unmarshal(payload, &msg)
+    c.sent = append(c.sent, msg)
+    c.opts = append(c.opts, opts)
+    return c.err
+}
+
+func TestPushService_SubscribeValidatesKeys(t *testing.T) {
+    svc := NewPushService(&fakePushRepo{}, &fakePushClient{}, logging.NewLogger("dev"))
+    req := &models.PushSubscriptionRequest{Endpoint: "https://fcm.googleapis.com/fcm/send/abc"}
+    req.Keys.P256dh = "curta"
+    req.Keys.Auth = base64.RawURLEncoding.EncodeToString(make([]byte, 16))
+    if _, err := svc.Subscribe(context.Background(), uuid.New(), req, "Firefox"); !errors.Is(err, models.ErrPushKeysInvalid) {
+        t.Fatalf("err = %v, want ErrPushKeysInvalid", err)
+    }
+    p := make([]byte, 65)
+    p[0] = 4
+    req.Keys.P256dh = base64.URLEncoding.EncodeToString(p) // com padding, como alguns navegadores enviam
+    sub, err := svc.Subscribe(context.Background(), uuid.New(), req, "Firefox")
+    if err != nil || strings.HasSuffix(sub.P256dh, "=") || *sub.UserAgent != "Firefox" {
+        t.Fatalf("Subscribe = %+v, %v", sub, err)
+    }
+
+    off := NewPushService(&fakePushRepo{}, nil, logging.NewLogger("dev"))
+    if _, err := off.Subscribe(context.Background(), uuid.New(), req, ""); !errors.Is(err, models.ErrPushUnavailable) {
+        t.Fatalf("sem VAPID: err = %v", err)
+    }
+}
+
+func TestPushService_SendDeliveryAndDropGoneSubscription(t *testing.T) {
+    owner := uuid.New()
+    repo := &fakePushRepo{subs: []models.PushSubscription{{ID: uuid.New(), OwnerID: owner, Endpoint: "https://push.example.com/1"}}}
+    client := &fakePushClient{}
+    svc := NewPushService(repo, client, logging.NewLogger("dev"))
+
+    payload, _ := json.Marshal(models.NotificationMessage{Title: "Pagamento recebido", Body: "R$ 10,00", Tag: "payment-x"})
+    d := &models.Delivery{OwnerID: owner, Channel: models.DeliveryChannelPush, Destination: "https://push.example.com/1", EventType: models.EventPaymentReceived, Payload: payload}
+    if err := svc.Send(context.Background(), d); err != nil { t.Fatalf("Send: %v", err) }
+    if len(client.sent) != 1 || client.sent[0].Title != "Pagamento recebido" || client.opts[0].Topic != "payment-x" {
+        t.Fatalf("mensagem = %+v opts = %+v", client.sent, client.opts)
+    }
+
+    digest, _ := json.Marshal(models.DigestPayload{Formatted: models.DigestFormatted{Received: "R$ 15,00", Overdue: "R$ 0,00"}})
+    d2 := &models.Delivery{OwnerID: owner, Destination: d.Destination, EventType: models.EventDigestWeekly, Payload: digest}
+    if err := svc.Send(context.Background(), d2); err != nil || client.sent[1].Title != "Resumo semanal" || client.opts[1].Urgency != webpush.UrgencyLow {
+        t.Fatalf("resumo = %+v, %v", client.sent[1], err)
+    }
+
+    client.err = webpush.ErrGone
+    if err := svc.Send(context.Background(), d); !errors.Is(err, ErrPermanentDelivery) {
+        t.Fatalf("err = %v, want ErrPermanentDelivery", err)
+    }
+    if len(repo.subs) != 0 { t.Fatalf("assinatura expirada deveria ser removida") }
+    if err := svc.Send(context.Background(), d); !errors.Is(err, ErrPermanentDelivery) {
+        t.Fatalf("dispositivo removido: err = %v", err)
+    }
+}
+
+func TestReminderService_DispatchesDueSoon(t *testing.T) {
+    owner := uuid.New()
+    repo := newFakeNotificationRepo()
+    repo.settings[owner] = &models.NotificationSettings{OwnerID: owner, PushEndpoints: []string{"https://push.example.com/a", "https://push.example.com/b"},
+        Preferences: models.NotificationPreferences{models.EventIncomeDueSoon: {"email": "off", "push": "immediate"}}}
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    aluguel := "Aluguel"
+    repo.reminders = []models.DueReminder{{OwnerID: owner, Timezone: "America/Sao_Paulo",
+        DigestDue: models.DigestDue{IncomeID: uuid.New(), Competencia: "2026-10", Categoria: &aluguel, Saldo: models.NewMoney(1200), DueDate: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)}}}
+    q := &fakeEnqueuer{}
+    svc := NewReminderService(repo, NewNotificationDispatcher(repo, q, logging.NewLogger("dev")), logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+
+    n, err := svc.SendDueReminders(context.Background())
+    if err != nil || n != 1 { t.Fatalf("SendDueReminders = %d, %v", n, err) }
+    if len(q.items) != 2 || q.items[0].channel != models.DeliveryChannelPush || q.items[1].destination != "https://push.example.com/b" {
+        t.Fatalf("esperava uma entrega push por dispositivo, got %+v", q.items)
+    }
+    if n, _ := svc.SendDueReminders(context.Background()); n != 0 { t.Fatalf("lembrete repetido: %d", n) }
+}
+
+func TestDueReminderMessage_RelativeDay(t *testing.T) {
+    ls := locale.Default()
+    y, m, d := ls.Now().Date()
+    today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+    it := models.DueReminder{DigestDue: models.DigestDue{IncomeID: uuid.New(), Competencia: "2026-10", Saldo: models.NewMoney(50)}}
+    for days, want := range map[int]string{0: "vence hoje", 1: "vence amanhã", 3: "vence em 3 dias"} {
+        it.DueDate = today.AddDate(0, 0, days)
+        if msg := dueReminderMessage(it, ls); !strings.Contains(msg.Body, want) {
+            t.Errorf("%d dias: %q, want %q", days, msg.Body, want)
+        }
+    }
+}
+
+// fakeEventDispatcher captura os eventos disparados pelo IncomeService
+type fakeEventDispatcher struct {
+    events []string
+    msgs   []*models.NotificationMessage
+}
+
+func (f *fakeEventDispatcher) Dispatch(ctx context.Context, ownerID uuid.UUID, eventType string, payload interface{}) ([]*models.Delivery, error) {
+    f.events = append(f.events, eventType)
+    f.msgs = append(f.msgs, payload.(*models.NotificationMessage))
+    return nil, nil
+}
+
+func TestAddPayment_DispatchesPaymentReceived(t *testing.T) {
+    ownerID := uuid.New()
+    existing := &models.Income{ID: uuid.New(), OwnerID: ownerID, Competencia: "2026-10", Valor: models.NewMoney(200)}
+    repo := &fakeIncomeRepo{getByIDResp: existing}
+    events := &fakeEventDispatcher{}
+    svc := NewIncomeService(repo, WithEvents(events, logging.NewLogger("dev")))
+
+    if _, err := svc.AddPayment(context.Background(), ownerID, &models.PaymentRequest{IncomeID: existing.ID, Valor: models.NewMoney(50)}); err != nil {
+        t.Fatalf("AddPayment: %v", err)
+    }
+    if len(events.events) != 1 || events.events[0] != models.EventPaymentReceived || !strings.Contains(events.msgs[0].Body, "50,00") {
+        t.Fatalf("eventos = %v %+v", events.events, events.msgs)
+    }
+}
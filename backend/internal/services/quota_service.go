@@ -0,0 +1,106 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Limites por plano (reserva de cota antes da operação) e consumo para o frontend
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// QuotaService aplica os limites do plano do dono dos dados.
+// Docstring: recibos têm cota mensal reservada de forma atômica antes da criação e devolvida se ela
+// falhar; assinaturas são limitadas pelo total de assinaturas lógicas (as versões de uma assinatura
+// não contam). Com X-Org-ID vale o plano do dono da organização.
+type QuotaService struct {
+	repo repositories.UsageRepository
+	log  logging.Logger
+	now  func() time.Time
+}
+
+// NewQuotaService cria uma nova instância do serviço de limites por plano
+func NewQuotaService(repo repositories.UsageRepository, log logging.Logger) *QuotaService {
+	return &QuotaService{repo: repo, log: log, now: time.Now}
+}
+
+// Reserve consome uma unidade da métrica ou retorna *models.QuotaError.
+// Docstring: a função devolvida libera a reserva (chamar quando a operação falhar); para métricas
+// sem contador ela não faz nada.
+func (s *QuotaService) Reserve(ctx context.Context, ownerID uuid.UUID, metric string) (func(), error) {
+	plan, err := s.repo.GetPlan(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	limit := plan.Limit(metric)
+
+	if metric != models.QuotaMetricReceipts {
+		if limit == nil {
+			return func() {}, nil
+		}
+		used, err := s.repo.CountSignatures(ctx, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		// Cada envio vira a próxima versão da assinatura do usuário: só o primeiro cria uma nova
+		adds := 0
+		if used == 0 {
+			adds = 1
+		}
+		if used+adds > *limit {
+			return nil, &models.QuotaError{Metric: metric, Plan: plan.Code, Limit: *limit, Used: used}
+		}
+		return func() {}, nil
+	}
+
+	period := models.UsagePeriod(s.now())
+	ok, err := s.repo.Reserve(ctx, ownerID, period, metric, limit)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		used, err := s.repo.Used(ctx, ownerID, period, metric)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &models.QuotaError{Metric: metric, Plan: plan.Code, Limit: *limit, Used: used}
+	}
+	return func() {
+		// Contexto próprio: a requisição pode já ter sido cancelada
+		if err := s.repo.Release(context.Background(), ownerID, period, metric); err != nil {
+			s.log.Error("erro ao devolver cota reservada", logging.Field{Key: "metric", Val: metric}, logging.Field{Key: "error", Val: err.Error()})
+		}
+	}, nil
+}
+
+// Usage plano, consumo e saldo do mês corrente
+func (s *QuotaService) Usage(ctx context.Context, ownerID uuid.UUID) (*models.Usage, error) {
+	plan, err := s.repo.GetPlan(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	period := models.UsagePeriod(s.now())
+	receipts, err := s.repo.Used(ctx, ownerID, period, models.QuotaMetricReceipts)
+	if err != nil {
+		return nil, err
+	}
+	signatures, err := s.repo.CountSignatures(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Usage{
+		Plan:     *plan,
+		Period:   period.Format("2006-01"),
+		ResetsAt: period.AddDate(0, 1, 0),
+		Metrics: []models.UsageMetric{
+			models.NewUsageMetric(models.QuotaMetricReceipts, true, receipts, plan.ReceiptsPerMonth),
+			models.NewUsageMetric(models.QuotaMetricSignatures, false, signatures, plan.MaxSignatures),
+		},
+	}, nil
+}
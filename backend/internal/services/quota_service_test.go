@@ -0,0 +1,117 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes dos limites por plano (cota mensal de recibos, assinaturas e consumo)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeUsageRepo implementa repositories.UsageRepository em memória
+type fakeUsageRepo struct {
+    plan       models.Plan
+    used       map[string]int // período|métrica -> uso
+    signatures int
+}
+
+func usageKey(period time.Time, metric string) string { return period.Format("2006-01") + "|" + metric }
+
+func (f *fakeUsageRepo) GetPlan(ctx context.Context, ownerID uuid.UUID) (*models.Plan, error) {
+    p := f.plan
+    return &p, nil
+}
+func (f *fakeUsageRepo) Reserve(ctx context.Context, ownerID uuid.UUID, period time.Time, metric string, limit *int) (bool, error) {
+    k := usageKey(period, metric)
+    if limit != nil && f.used[k] >= *limit { return false, nil }
+    f.used[k]++
+    return true, nil
+}
+func (f *fakeUsageRepo) Release(ctx context.Context, ownerID uuid.UUID, period time.Time, metric string) error {
+    if k := usageKey(period, metric); f.used[k] > 0 { f.used[k]-- }
+    return nil
+}
+func (f *fakeUsageRepo) Used(ctx context.Context, ownerID uuid.UUID, period time.Time, metric string) (int, error) {
+    return f.used[usageKey(period, metric)], nil
+}
+func (f *fakeUsageRepo) CountSignatures(ctx context.Context, ownerID uuid.UUID) (int, error) {
+    return f.signatures, nil
+}
+
+func intPtr(n int) *int { return &n }
+
+func newQuotaFixture(plan models.Plan) (*QuotaService, *fakeUsageRepo, *time.Time) {
+    repo := &fakeUsageRepo{plan: plan, used: map[string]int{}}
+    now := time.Date(2026, 10, 31, 23, 0, 0, 0, time.UTC)
+    svc := NewQuotaService(repo, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+    return svc, repo, &now
+}
+
+func TestQuotaReceiptsPerMonth(t *testing.T) {
+    svc, _, now := newQuotaFixture(models.Plan{Code: "free", ReceiptsPerMonth: intPtr(2), MaxSignatures: intPtr(1)})
+    owner := uuid.New()
+    for i := 0; i < 2; i++ {
+        if _, err := svc.Reserve(context.Background(), owner, models.QuotaMetricReceipts); err != nil { t.Fatal(err) }
+    }
+    _, err := svc.Reserve(context.Background(), owner, models.QuotaMetricReceipts)
+    var qe *models.QuotaError
+    if !errors.As(err, &qe) || qe.Limit != 2 || qe.Used != 2 || qe.Unavailable() || qe.Code() != "quota_exceeded" {
+        t.Fatalf("err = %v", err)
+    }
+
+    // Reserva devolvida libera uma nova criação
+    *now = now.Add(2 * time.Hour) // novo mês
+    release, err := svc.Reserve(context.Background(), owner, models.QuotaMetricReceipts)
+    if err != nil { t.Fatalf("virada do mês: %v", err) }
+    release()
+    u, err := svc.Usage(context.Background(), owner)
+    if err != nil { t.Fatal(err) }
+    if u.Period != "2026-11" || u.Metrics[0].Used != 0 || *u.Metrics[0].Remaining != 2 || !u.ResetsAt.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)) {
+        t.Fatalf("consumo inesperado: %+v", u)
+    }
+}
+
+func TestQuotaSignatures(t *testing.T) {
+    svc, repo, _ := newQuotaFixture(models.Plan{Code: "free", ReceiptsPerMonth: intPtr(20), MaxSignatures: intPtr(1)})
+    owner := uuid.New()
+    if _, err := svc.Reserve(context.Background(), owner, models.QuotaMetricSignatures); err != nil { t.Fatal(err) }
+
+    // Plano free com a assinatura já cadastrada: o novo envio é a segunda versão, não outra assinatura
+    repo.signatures = 1
+    if _, err := svc.Reserve(context.Background(), owner, models.QuotaMetricSignatures); err != nil {
+        t.Fatalf("segunda versão no plano free: %v", err)
+    }
+
+    var qe *models.QuotaError
+    repo.plan.MaxSignatures = intPtr(0)
+    if _, err := svc.Reserve(context.Background(), owner, models.QuotaMetricSignatures); !errors.As(err, &qe) || !qe.Unavailable() || qe.Used != 1 {
+        t.Fatalf("nova versão fora do plano: %v", err)
+    }
+    repo.signatures = 0
+    if _, err := svc.Reserve(context.Background(), owner, models.QuotaMetricSignatures); !errors.As(err, &qe) || !qe.Unavailable() {
+        t.Fatalf("recurso fora do plano: %v", err)
+    }
+}
+
+func TestQuotaUnlimitedPlan(t *testing.T) {
+    svc, repo, _ := newQuotaFixture(models.Plan{Code: "pro"})
+    owner := uuid.New()
+    repo.signatures = 50
+    for i := 0; i < 100; i++ {
+        if _, err := svc.Reserve(context.Background(), owner, models.QuotaMetricReceipts); err != nil { t.Fatal(err) }
+    }
+    if _, err := svc.Reserve(context.Background(), owner, models.QuotaMetricSignatures); err != nil { t.Fatal(err) }
+    u, _ := svc.Usage(context.Background(), owner)
+    if u.Metrics[0].Used != 100 || u.Metrics[0].Limit != nil || u.Metrics[0].Remaining != nil {
+        t.Fatalf("consumo inesperado: %+v", u.Metrics[0])
+    }
+}
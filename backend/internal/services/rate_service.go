@@ -0,0 +1,238 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Serviço do histórico de cotações e índices (busca periódica, backfill, consulta e sobrescrita)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// RateSource fonte externa das cotações (implementado por bcb.Client)
+type RateSource interface {
+	FetchRates(ctx context.Context, indice string, from, to time.Time) ([]models.Rate, error)
+}
+
+// RateSyncLookback janela buscada na primeira sincronização de um índice
+const RateSyncLookback = 400 * 24 * time.Hour
+
+// RateSyncOverlap janela rebuscada antes da última data gravada (revisões e publicação mensal)
+const RateSyncOverlap = 45 * 24 * time.Hour
+
+// RateService mantém rf_rates e atende as consultas de reajuste, relatórios multimoeda e previsões.
+// Docstring: as datas são dias civis (meia-noite UTC); "hoje" segue o fuso do Banco Central.
+type RateService struct {
+	repo   repositories.RateRepository
+	source RateSource
+	log    logging.Logger
+	now    func() time.Time
+}
+
+// NewRateService cria o serviço de cotações
+func NewRateService(repo repositories.RateRepository, source RateSource, log logging.Logger) *RateService {
+	return &RateService{repo: repo, source: source, log: log, now: time.Now}
+}
+
+// Sync busca as cotações novas de todos os índices; falhas de um índice não impedem os demais
+func (s *RateService) Sync(ctx context.Context) ([]models.RateSyncResult, error) {
+	today := s.today()
+	var (
+		results []models.RateSyncResult
+		errs    []error
+	)
+	for _, indice := range models.RateIndices() {
+		from := today.Add(-RateSyncLookback)
+		last, err := s.repo.LastDate(ctx, indice)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", indice, err))
+			continue
+		}
+		if last != nil && last.Add(-RateSyncOverlap).After(from) {
+			from = last.Add(-RateSyncOverlap)
+		}
+		res, err := s.fetch(ctx, indice, from, today)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", indice, err))
+			continue
+		}
+		results = append(results, *res)
+	}
+	return results, errors.Join(errs...)
+}
+
+// Backfill busca retroativamente o índice no intervalo informado (AAAA-MM-DD)
+func (s *RateService) Backfill(ctx context.Context, req *models.RateBackfillRequest) (*models.RateSyncResult, error) {
+	if !models.ValidRateIndex(req.Indice) {
+		return nil, models.ErrInvalidRateIndex
+	}
+	from, to, err := s.parseRange(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	return s.fetch(ctx, req.Indice, from, to)
+}
+
+// List lista as cotações do índice no intervalo (AAAA-MM-DD; vazio = últimos 30 dias)
+func (s *RateService) List(ctx context.Context, indice, from, to string) ([]models.Rate, error) {
+	if !models.ValidRateIndex(indice) {
+		return nil, models.ErrInvalidRateIndex
+	}
+	if from == "" {
+		from = s.today().AddDate(0, 0, -30).Format(models.RateLayout)
+	}
+	f, t, err := s.parseRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, indice, f, t)
+}
+
+// At retorna a cotação vigente na data; índices mensais usam o mês da data
+func (s *RateService) At(ctx context.Context, indice string, data time.Time) (*models.Rate, error) {
+	if !models.ValidRateIndex(indice) {
+		return nil, models.ErrInvalidRateIndex
+	}
+	data = civilDate(data)
+	if models.MonthlyRate(indice) {
+		data = time.Date(data.Year(), data.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return s.repo.At(ctx, indice, data)
+}
+
+// Accumulated variação acumulada (%) de um índice mensal entre os meses de from e to (inclusive).
+// Docstring: base do motor de reajuste. Usa o cache de rf_rates; se faltar algum mês, busca o
+// período na fonte uma vez e confere de novo, retornando ErrRateNotFound se o índice ainda não
+// foi publicado para todos os meses.
+func (s *RateService) Accumulated(ctx context.Context, indice string, from, to time.Time) (float64, error) {
+	if !models.MonthlyRate(indice) {
+		return 0, models.ErrInvalidRateIndex
+	}
+	f := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	t := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if t.Before(f) {
+		return 0, models.ErrInvalidRateRange
+	}
+	months := (t.Year()-f.Year())*12 + int(t.Month()-f.Month()) + 1
+	rates, err := s.repo.List(ctx, indice, f, t)
+	if err != nil {
+		return 0, err
+	}
+	if len(rates) != months && !f.After(s.today()) {
+		// Cache incompleto: busca o período na fonte (até hoje) e relê
+		end := t.AddDate(0, 1, -1)
+		if today := s.today(); end.After(today) {
+			end = today
+		}
+		if _, err := s.fetch(ctx, indice, f, end); err != nil {
+			return 0, err
+		}
+		if rates, err = s.repo.List(ctx, indice, f, t); err != nil {
+			return 0, err
+		}
+	}
+	if len(rates) != months {
+		return 0, models.ErrRateNotFound
+	}
+	return models.AccumulatedRate(rates), nil
+}
+
+// SetOverride grava uma cotação manual, que a busca automática não sobrescreve
+func (s *RateService) SetOverride(ctx context.Context, indice, data string, req *models.RateOverrideRequest) (*models.Rate, error) {
+	d, err := s.parseRateKey(indice, data)
+	if err != nil {
+		return nil, err
+	}
+	if req.Valor == 0 || (req.Valor < 0 && !models.MonthlyRate(indice)) {
+		return nil, models.ErrRateValueInvalid
+	}
+	rt := &models.Rate{Indice: indice, Data: d, Valor: req.Valor, Observacao: req.Observacao}
+	if err := s.repo.SetOverride(ctx, rt); err != nil {
+		return nil, fmt.Errorf("erro ao gravar cotação manual: %w", err)
+	}
+	return rt, nil
+}
+
+// ClearOverride remove a cotação manual do índice na data
+func (s *RateService) ClearOverride(ctx context.Context, indice, data string) error {
+	d, err := s.parseRateKey(indice, data)
+	if err != nil {
+		return err
+	}
+	return s.repo.ClearOverride(ctx, indice, d)
+}
+
+// fetch busca e grava o intervalo; índices mensais são alinhados ao 1º dia do mês
+func (s *RateService) fetch(ctx context.Context, indice string, from, to time.Time) (*models.RateSyncResult, error) {
+	if models.MonthlyRate(indice) {
+		from = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	rates, err := s.source.FetchRates(ctx, indice, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar cotações: %w", err)
+	}
+	stored, err := s.repo.Upsert(ctx, rates)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gravar cotações: %w", err)
+	}
+	return &models.RateSyncResult{
+		Indice:  indice,
+		From:    from.Format(models.RateLayout),
+		To:      to.Format(models.RateLayout),
+		Fetched: len(rates),
+		Stored:  stored,
+	}, nil
+}
+
+// parseRange valida o intervalo (to vazio = hoje), limitado a MaxRateRange e a datas não futuras
+func (s *RateService) parseRange(from, to string) (time.Time, time.Time, error) {
+	today := s.today()
+	f, err := time.Parse(models.RateLayout, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, models.ErrInvalidRateRange
+	}
+	t := today
+	if to != "" {
+		if t, err = time.Parse(models.RateLayout, to); err != nil {
+			return time.Time{}, time.Time{}, models.ErrInvalidRateRange
+		}
+	}
+	if t.After(today) {
+		t = today
+	}
+	if f.After(t) || t.Sub(f) > models.MaxRateRange {
+		return time.Time{}, time.Time{}, models.ErrInvalidRateRange
+	}
+	return f, t, nil
+}
+
+// parseRateKey valida índice e data de uma cotação; índices mensais exigem o 1º dia do mês
+func (s *RateService) parseRateKey(indice, data string) (time.Time, error) {
+	if !models.ValidRateIndex(indice) {
+		return time.Time{}, models.ErrInvalidRateIndex
+	}
+	d, err := time.Parse(models.RateLayout, data)
+	if err != nil || (models.MonthlyRate(indice) && d.Day() != 1) {
+		return time.Time{}, models.ErrInvalidRateRange
+	}
+	return d, nil
+}
+
+// today data civil de hoje no fuso do Banco Central (Brasília)
+func (s *RateService) today() time.Time {
+	return civilDate(s.now().In(locale.Default().Location))
+}
+
+// civilDate data civil de t como meia-noite UTC
+func civilDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
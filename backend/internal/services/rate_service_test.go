@@ -0,0 +1,133 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do serviço de cotações (sincronização incremental, acumulado e sobrescrita manual)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "math"
+    "testing"
+    "time"
+
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+type fakeRateRepo struct {
+    rows map[string]models.Rate
+    last map[string]time.Time
+}
+
+func newFakeRateRepo() *fakeRateRepo {
+    return &fakeRateRepo{rows: map[string]models.Rate{}, last: map[string]time.Time{}}
+}
+
+func rateKey(indice string, d time.Time) string { return indice + "|" + d.Format(models.RateLayout) }
+
+func (f *fakeRateRepo) Upsert(_ context.Context, rates []models.Rate) (int, error) {
+    n := 0
+    for _, r := range rates {
+        if cur, ok := f.rows[rateKey(r.Indice, r.Data)]; ok && cur.Fonte == models.RateSourceManual { continue }
+        f.rows[rateKey(r.Indice, r.Data)] = r
+        n++
+    }
+    return n, nil
+}
+func (f *fakeRateRepo) SetOverride(_ context.Context, r *models.Rate) error {
+    r.Fonte = models.RateSourceManual
+    f.rows[rateKey(r.Indice, r.Data)] = *r
+    return nil
+}
+func (f *fakeRateRepo) ClearOverride(_ context.Context, indice string, d time.Time) error {
+    if r, ok := f.rows[rateKey(indice, d)]; !ok || r.Fonte != models.RateSourceManual { return models.ErrRateNotFound }
+    delete(f.rows, rateKey(indice, d))
+    return nil
+}
+func (f *fakeRateRepo) List(_ context.Context, indice string, from, to time.Time) ([]models.Rate, error) {
+    var out []models.Rate
+    for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+        if r, ok := f.rows[rateKey(indice, d)]; ok { out = append(out, r) }
+    }
+    return out, nil
+}
+func (f *fakeRateRepo) At(_ context.Context, indice string, d time.Time) (*models.Rate, error) {
+    if r, ok := f.rows[rateKey(indice, d)]; ok { return &r, nil }
+    return nil, models.ErrRateNotFound
+}
+func (f *fakeRateRepo) LastDate(_ context.Context, indice string) (*time.Time, error) {
+    if d, ok := f.last[indice]; ok { return &d, nil }
+    return nil, nil
+}
+
+type fakeRateSource struct {
+    calls map[string][2]time.Time
+    fail  string
+}
+
+func (s *fakeRateSource) FetchRates(_ context.Context, indice string, from, to time.Time) ([]models.Rate, error) {
+    if indice == s.fail { return nil, errors.New("indisponível") }
+    s.calls[indice] = [2]time.Time{from, to}
+    return []models.Rate{{Indice: indice, Data: from, Valor: 1, Fonte: models.RateSourceBCB}}, nil
+}
+
+func newTestRateService(repo *fakeRateRepo, src *fakeRateSource) *RateService {
+    s := NewRateService(repo, src, logging.NewLogger("dev"))
+    s.now = func() time.Time { return time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC) } // 17/10 em Brasília
+    return s
+}
+
+func TestRateService_SyncIsIncrementalAndIsolatesFailures(t *testing.T) {
+    repo := newFakeRateRepo()
+    repo.last[models.RatePTAXUSD] = time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+    src := &fakeRateSource{calls: map[string][2]time.Time{}, fail: models.RateIPCA}
+    s := newTestRateService(repo, src)
+
+    results, err := s.Sync(context.Background())
+    if err == nil { t.Fatalf("falha do IPCA deveria ser reportada") }
+    if len(results) != 3 { t.Fatalf("demais índices deveriam sincronizar: %+v", results) }
+
+    today := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+    usd := src.calls[models.RatePTAXUSD]
+    if !usd[0].Equal(repo.last[models.RatePTAXUSD].Add(-RateSyncOverlap)) || !usd[1].Equal(today) { t.Fatalf("janela PTAX inesperada: %v", usd) }
+    igpm := src.calls[models.RateIGPM]
+    if igpm[0].Day() != 1 { t.Fatalf("índice mensal deveria começar no 1º dia do mês: %v", igpm[0]) }
+}
+
+func TestRateService_AccumulatedRequiresEveryMonth(t *testing.T) {
+    repo := newFakeRateRepo()
+    src := &fakeRateSource{calls: map[string][2]time.Time{}}
+    s := newTestRateService(repo, src)
+    for m := time.January; m <= time.March; m++ {
+        d := time.Date(2026, m, 1, 0, 0, 0, 0, time.UTC)
+        repo.rows[rateKey(models.RateIGPM, d)] = models.Rate{Indice: models.RateIGPM, Data: d, Valor: 1}
+    }
+    from := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+    pct, err := s.Accumulated(context.Background(), models.RateIGPM, from, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+    if err != nil { t.Fatalf("Accumulated err: %v", err) }
+    if math.Abs(pct-3.0301) > 1e-9 { t.Fatalf("acumulado = %v", pct) }
+
+    if _, ok := src.calls[models.RateIGPM]; ok { t.Fatal("cache completo não deveria consultar a fonte") }
+
+    if _, err := s.Accumulated(context.Background(), models.RateIGPM, from, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, models.ErrRateNotFound) { t.Fatalf("mês ausente deveria falhar: %v", err) }
+    if c := src.calls[models.RateIGPM]; !c[0].Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || !c[1].Equal(time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)) { t.Fatalf("mês ausente deveria ser buscado na fonte: %v", c) }
+    if _, err := s.Accumulated(context.Background(), models.RatePTAXUSD, from, from); !errors.Is(err, models.ErrInvalidRateIndex) { t.Fatalf("PTAX não é índice mensal: %v", err) }
+}
+
+func TestRateService_OverrideSurvivesSync(t *testing.T) {
+    repo := newFakeRateRepo()
+    s := newTestRateService(repo, &fakeRateSource{calls: map[string][2]time.Time{}})
+    ctx := context.Background()
+
+    if _, err := s.SetOverride(ctx, models.RateIGPM, "2026-09-15", &models.RateOverrideRequest{Valor: 0.5}); !errors.Is(err, models.ErrInvalidRateRange) { t.Fatalf("índice mensal exige o 1º dia: %v", err) }
+    if _, err := s.SetOverride(ctx, models.RatePTAXUSD, "2026-09-15", &models.RateOverrideRequest{Valor: -1}); !errors.Is(err, models.ErrRateValueInvalid) { t.Fatalf("cotação negativa deveria falhar: %v", err) }
+    if _, err := s.SetOverride(ctx, models.RateIGPM, "2026-09-01", &models.RateOverrideRequest{Valor: -0.4}); err != nil { t.Fatalf("deflação é válida: %v", err) }
+
+    if _, err := s.Backfill(ctx, &models.RateBackfillRequest{Indice: models.RateIGPM, From: "2026-09-10", To: "2026-09-30"}); err != nil { t.Fatalf("Backfill err: %v", err) }
+    if r := repo.rows[rateKey(models.RateIGPM, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))]; r.Fonte != models.RateSourceManual || r.Valor != -0.4 { t.Fatalf("busca não deveria sobrescrever a cotação manual: %+v", r) }
+
+    if err := s.ClearOverride(ctx, models.RateIGPM, "2026-09-01"); err != nil { t.Fatalf("ClearOverride err: %v", err) }
+    if err := s.ClearOverride(ctx, models.RateIGPM, "2026-09-01"); !errors.Is(err, models.ErrRateNotFound) { t.Fatalf("segunda remoção deveria falhar: %v", err) }
+}
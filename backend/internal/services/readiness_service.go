@@ -0,0 +1,76 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Readiness check das dependências do servidor (banco, Storage, JWKS e jobs)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+)
+
+// ReadinessCheckTimeout tempo máximo de cada verificação (os probes costumam esperar poucos segundos)
+const ReadinessCheckTimeout = 3 * time.Second
+
+type readinessCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// ReadinessService verifica as dependências registradas em paralelo, cada uma com seu timeout
+type ReadinessService struct {
+	checks  []readinessCheck
+	timeout time.Duration
+	log     logging.Logger
+	now     func() time.Time
+}
+
+// NewReadinessService cria o serviço sem verificações (pronto até que alguma seja registrada)
+func NewReadinessService(log logging.Logger) *ReadinessService {
+	return &ReadinessService{timeout: ReadinessCheckTimeout, log: log, now: time.Now}
+}
+
+// AddCheck registra uma dependência; check retorna nil quando ela está disponível
+func (s *ReadinessService) AddCheck(name string, check func(ctx context.Context) error) {
+	s.checks = append(s.checks, readinessCheck{name: name, check: check})
+}
+
+// Check executa todas as verificações e compõe o relatório na ordem de registro
+func (s *ReadinessService) Check(ctx context.Context) *models.ReadinessReport {
+	report := &models.ReadinessReport{Ready: true, CheckedAt: s.now().UTC(), Dependencies: make([]models.DependencyStatus, len(s.checks))}
+	var wg sync.WaitGroup
+	for i, c := range s.checks {
+		wg.Add(1)
+		go func(i int, c readinessCheck) {
+			defer wg.Done()
+			report.Dependencies[i] = s.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, d := range report.Dependencies {
+		if d.Status != models.DependencyUp {
+			report.Ready = false
+			s.log.Warn("readiness: dependência indisponível", logging.Field{Key: "dependency", Val: d.Name}, logging.Field{Key: "error", Val: *d.Error})
+		}
+	}
+	return report
+}
+
+func (s *ReadinessService) run(ctx context.Context, c readinessCheck) models.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	start := s.now()
+	err := c.check(ctx)
+	st := models.DependencyStatus{Name: c.name, Status: models.DependencyUp, LatencyMs: s.now().Sub(start).Milliseconds()}
+	if err != nil {
+		msg := err.Error()
+		st.Status, st.Error = models.DependencyDown, &msg
+	}
+	return st
+}
@@ -0,0 +1,41 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do readiness check (dependências em paralelo, timeout e ordem do relatório)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+func TestReadiness_ReportsEachDependency(t *testing.T) {
+    s := NewReadinessService(logging.NewLogger("dev"))
+    s.timeout = 50 * time.Millisecond
+    s.AddCheck("database", func(context.Context) error { return nil })
+    s.AddCheck("storage", func(context.Context) error { return errors.New("status=503") })
+    s.AddCheck("jwks", func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() })
+
+    r := s.Check(context.Background())
+    if r.Ready { t.Fatalf("relatório pronto com dependências fora") }
+    if len(r.Dependencies) != 3 { t.Fatalf("dependências = %+v", r.Dependencies) }
+    want := []string{models.DependencyUp, models.DependencyDown, models.DependencyDown}
+    for i, name := range []string{"database", "storage", "jwks"} {
+        d := r.Dependencies[i]
+        if d.Name != name || d.Status != want[i] { t.Fatalf("dependência %d = %+v, want %s %s", i, d, name, want[i]) }
+    }
+    if e := r.Dependencies[2].Error; e == nil || *e != context.DeadlineExceeded.Error() { t.Fatalf("timeout não reportado: %v", e) }
+}
+
+func TestReadiness_ReadyWhenAllUp(t *testing.T) {
+    s := NewReadinessService(logging.NewLogger("dev"))
+    if r := s.Check(context.Background()); !r.Ready || len(r.Dependencies) != 0 { t.Fatalf("sem verificações: %+v", r) }
+    s.AddCheck("database", func(context.Context) error { return nil })
+    if r := s.Check(context.Background()); !r.Ready || r.Dependencies[0].Error != nil { t.Fatalf("relatório = %+v", r) }
+}
@@ -0,0 +1,264 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Emissão de recibos em lote para as receitas pagas de uma competência, com PDFs gerados no job
+// Data: 18-10-2026
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/storage"
+)
+
+// ReceiptPDFStore envio dos PDFs gerados ao Storage (implementado por storage.Client)
+type ReceiptPDFStore interface {
+	UploadStream(ctx context.Context, bucket, objectPath string, body io.Reader, contentType string) (size int64, sha256hex string, err error)
+	DeleteObject(ctx context.Context, bucket, objectPath string) error
+}
+
+// ReceiptQuota reserva da cota mensal de recibos do plano (implementado por QuotaService)
+type ReceiptQuota interface {
+	Reserve(ctx context.Context, ownerID uuid.UUID, metric string) (func(), error)
+}
+
+// receiptBatchClaimBatch lotes reservados por execução do job
+const receiptBatchClaimBatch = 2
+
+// ReceiptBatchService cria lotes de recibos e os processa no job "receipt-batches".
+// Docstring: o pedido grava o lote com as receitas pagas sem recibo da competência; ProcessPending
+// emite um recibo por receita (numeração e snapshot pelo trigger, como na emissão avulsa), gera o
+// PDF com o modelo padrão do usuário e o envia ao bucket de recibos. Falha transitória do Storage
+// devolve o lote à fila (até maxAttempts execuções); as demais falhas ficam registradas na receita
+// e o lote segue. Cada recibo consome a cota do plano como na emissão avulsa.
+type ReceiptBatchService struct {
+	repo        repositories.ReceiptBatchRepository
+	receipts    repositories.ReceiptRepository
+	store       ReceiptPDFStore
+	bucket      string
+	branding    ReceiptBranding
+	quota       ReceiptQuota
+	log         logging.Logger
+	lockTimeout time.Duration
+	maxAttempts int
+	now         func() time.Time
+
+	mu sync.Mutex // serializa ProcessPending (worker e disparo pelo handler)
+}
+
+// NewReceiptBatchService cria o serviço; branding e quota podem ser nil (PDF sem marca, sem limite)
+func NewReceiptBatchService(repo repositories.ReceiptBatchRepository, receipts repositories.ReceiptRepository, store ReceiptPDFStore,
+	bucket string, branding ReceiptBranding, quota ReceiptQuota, log logging.Logger) *ReceiptBatchService {
+	return &ReceiptBatchService{
+		repo:        repo,
+		receipts:    receipts,
+		store:       store,
+		bucket:      bucket,
+		branding:    branding,
+		quota:       quota,
+		log:         log,
+		lockTimeout: 30 * time.Minute,
+		maxAttempts: 5,
+		now:         time.Now,
+	}
+}
+
+// Create grava o lote da competência; o idioma e o fuso do pedido formatam os PDFs
+func (s *ReceiptBatchService) Create(ctx context.Context, ownerID uuid.UUID, req *models.ReceiptBatchRequest) (*models.ReceiptBatch, error) {
+	month, err := req.Month()
+	if err != nil {
+		return nil, err
+	}
+	ls := locale.FromContext(ctx)
+	b := &models.ReceiptBatch{
+		OwnerID:     ownerID,
+		Competencia: month.Format("2006-01"),
+		Locale:      ls.Locale,
+		Timezone:    ls.Timezone(),
+	}
+	if err := s.repo.Create(ctx, b, models.CompetenciaForms(month)); err != nil {
+		if errors.Is(err, models.ErrReceiptBatchInProgress) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao criar lote de recibos: %w", err)
+	}
+	s.log.Info("lote de recibos criado",
+		logging.Field{Key: "batch_id", Val: b.ID.String()},
+		logging.Field{Key: "competencia", Val: b.Competencia},
+		logging.Field{Key: "total", Val: b.Total})
+	return b, nil
+}
+
+// Get retorna o lote com o andamento e a situação de cada receita
+func (s *ReceiptBatchService) Get(ctx context.Context, id, ownerID uuid.UUID) (*models.ReceiptBatch, error) {
+	b, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		if errors.Is(err, models.ErrReceiptBatchNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao buscar lote de recibos: %w", err)
+	}
+	return b, nil
+}
+
+// List lista os lotes recentes do usuário
+func (s *ReceiptBatchService) List(ctx context.Context, ownerID uuid.UUID) ([]models.ReceiptBatch, error) {
+	items, err := s.repo.List(ctx, ownerID, 50)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar lotes de recibos: %w", err)
+	}
+	return items, nil
+}
+
+// ProcessPending processa os lotes na fila (função do job "receipt-batches")
+func (s *ReceiptBatchService) ProcessPending(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items, err := s.repo.ClaimPending(ctx, s.now().UTC(), s.lockTimeout, receiptBatchClaimBatch)
+	if err != nil {
+		return fmt.Errorf("erro ao reservar lotes de recibos: %w", err)
+	}
+	var firstErr error
+	for i := range items {
+		if err := s.process(ctx, &items[i]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// process emite os recibos pendentes do lote e grava a situação final
+func (s *ReceiptBatchService) process(ctx context.Context, b *models.ReceiptBatch) error {
+	runErr := s.issueAll(ctx, b)
+	if runErr != nil && storage.IsTransient(runErr) && b.Attempts < s.maxAttempts {
+		msg := runErr.Error()
+		b.LastError = &msg
+		if err := s.repo.Requeue(ctx, b); err != nil {
+			return fmt.Errorf("erro ao devolver lote de recibos à fila: %w", err)
+		}
+		s.log.Warn("lote de recibos devolvido à fila",
+			logging.Field{Key: "batch_id", Val: b.ID.String()},
+			logging.Field{Key: "attempts", Val: b.Attempts},
+			logging.Field{Key: "error", Val: msg})
+		return runErr
+	}
+
+	b.Status, b.LastError = models.ReceiptBatchStatusCompleted, nil
+	if runErr != nil {
+		msg := runErr.Error()
+		b.Status, b.LastError = models.ReceiptBatchStatusFailed, &msg
+	}
+	if err := s.repo.Finish(ctx, b, s.now().UTC()); err != nil {
+		return fmt.Errorf("erro ao finalizar lote de recibos: %w", err)
+	}
+	s.log.Info("lote de recibos processado",
+		logging.Field{Key: "batch_id", Val: b.ID.String()},
+		logging.Field{Key: "competencia", Val: b.Competencia},
+		logging.Field{Key: "status", Val: b.Status})
+	return runErr
+}
+
+// issueAll percorre as receitas abertas do lote; só erros que interrompem o lote são devolvidos
+func (s *ReceiptBatchService) issueAll(ctx context.Context, b *models.ReceiptBatch) error {
+	items, err := s.repo.ListOpenItems(ctx, b.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao listar receitas do lote: %w", err)
+	}
+	ls := locale.Resolve(b.Locale, b.Timezone)
+	tpl := defaultReceiptTemplate(ctx, s.branding, b.OwnerID)
+	lastAttempt := b.Attempts >= s.maxAttempts
+
+	for i := range items {
+		it := &items[i]
+		itemErr := s.issue(ctx, b, it, ls, tpl)
+		if itemErr == nil {
+			continue
+		}
+		// Storage fora do ar: as receitas restantes falhariam igual; o lote volta à fila
+		if storage.IsTransient(itemErr) && !lastAttempt {
+			return itemErr
+		}
+		msg := itemErr.Error()
+		it.Status, it.Error = models.ReceiptBatchItemFailed, &msg
+		if err := s.repo.UpdateItem(ctx, it, s.now().UTC()); err != nil {
+			return fmt.Errorf("erro ao registrar falha da receita %s: %w", it.IncomeID, err)
+		}
+	}
+	return nil
+}
+
+// issue emite o recibo da receita (quando ainda não emitido) e gera o PDF
+func (s *ReceiptBatchService) issue(ctx context.Context, b *models.ReceiptBatch, it *models.ReceiptBatchItem,
+	ls locale.Settings, tpl *models.ReceiptTemplate) error {
+	var rec *models.Receipt
+	if it.ReceiptID == nil {
+		has, err := s.repo.HasReceipt(ctx, b.OwnerID, it.IncomeID)
+		if err != nil {
+			return fmt.Errorf("erro ao verificar recibo da receita: %w", err)
+		}
+		if has {
+			reason := models.ReceiptBatchSkipHasReceipt
+			it.Status, it.Error = models.ReceiptBatchItemSkipped, &reason
+			return s.repo.UpdateItem(ctx, it, s.now().UTC())
+		}
+		release := func() {}
+		if s.quota != nil {
+			if release, err = s.quota.Reserve(ctx, b.OwnerID, models.QuotaMetricReceipts); err != nil {
+				return err
+			}
+		}
+		incomeID := it.IncomeID
+		rec = &models.Receipt{OwnerID: b.OwnerID, IncomeID: &incomeID}
+		if err := s.receipts.Create(ctx, rec); err != nil {
+			release()
+			return fmt.Errorf("erro ao emitir recibo: %w", err)
+		}
+		it.ReceiptID, it.Status, it.Error = &rec.ID, models.ReceiptBatchItemIssued, nil
+		if err := s.repo.UpdateItem(ctx, it, s.now().UTC()); err != nil {
+			return fmt.Errorf("erro ao registrar recibo emitido: %w", err)
+		}
+	} else {
+		var err error
+		if rec, err = s.receipts.GetByID(ctx, *it.ReceiptID, b.OwnerID); err != nil {
+			return fmt.Errorf("erro ao buscar recibo emitido: %w", err)
+		}
+	}
+
+	if rec.PDFURL == nil || *rec.PDFURL == "" {
+		if err := s.uploadPDF(ctx, rec, ls, tpl); err != nil {
+			return err
+		}
+	}
+	it.Status, it.Error = models.ReceiptBatchItemDone, nil
+	return s.repo.UpdateItem(ctx, it, s.now().UTC())
+}
+
+// uploadPDF gera o PDF e o grava no recibo no mesmo caminho usado pelo envio manual
+func (s *ReceiptBatchService) uploadPDF(ctx context.Context, rec *models.Receipt, ls locale.Settings, tpl *models.ReceiptTemplate) error {
+	content := RenderReceiptPDF(rec, ls, tpl)
+	objectPath := fmt.Sprintf("%s/receipts/%s_%d.pdf", rec.OwnerID, rec.ID, s.now().UTC().Unix())
+	_, sha256hex, err := s.store.UploadStream(ctx, s.bucket, objectPath, bytes.NewReader(content), "application/pdf")
+	if err != nil {
+		return fmt.Errorf("erro ao enviar PDF do recibo ao Storage: %w", err)
+	}
+	if _, err := s.receipts.SetPDF(ctx, rec.ID, rec.OwnerID, objectPath, sha256hex); err != nil {
+		// Compensação: o recibo não aponta para o objeto enviado
+		if derr := s.store.DeleteObject(ctx, s.bucket, objectPath); derr != nil {
+			s.log.Error("falha ao remover PDF não referenciado do Storage",
+				logging.Field{Key: "error", Val: derr.Error()}, logging.Field{Key: "objectPath", Val: objectPath})
+		}
+		return fmt.Errorf("erro ao gravar PDF do recibo: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da emissão de recibos em lote (emissão, PDFs, receitas ignoradas e retomada)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+    "recibofast/internal/storage"
+)
+
+// fakeReceiptBatchRepo implementa repositories.ReceiptBatchRepository em memória
+type fakeReceiptBatchRepo struct {
+    pending    []models.ReceiptBatch
+    items      map[uuid.UUID]*models.ReceiptBatchItem
+    order      []uuid.UUID
+    hasReceipt map[uuid.UUID]bool
+    requeued   *models.ReceiptBatch
+    finished   *models.ReceiptBatch
+}
+
+func (f *fakeReceiptBatchRepo) Create(ctx context.Context, b *models.ReceiptBatch, competencias []string) error {
+    b.ID, b.Status, b.Total = uuid.New(), models.ReceiptBatchStatusQueued, len(f.order)
+    f.pending = append(f.pending, *b)
+    return nil
+}
+func (f *fakeReceiptBatchRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.ReceiptBatch, error) {
+    return nil, models.ErrReceiptBatchNotFound
+}
+func (f *fakeReceiptBatchRepo) List(ctx context.Context, ownerID uuid.UUID, limit int) ([]models.ReceiptBatch, error) {
+    return f.pending, nil
+}
+func (f *fakeReceiptBatchRepo) ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.ReceiptBatch, error) {
+    items := f.pending
+    f.pending = nil
+    for i := range items { items[i].Status = models.ReceiptBatchStatusRunning; items[i].Attempts++ }
+    return items, nil
+}
+func (f *fakeReceiptBatchRepo) ListOpenItems(ctx context.Context, batchID uuid.UUID) ([]models.ReceiptBatchItem, error) {
+    var out []models.ReceiptBatchItem
+    for _, id := range f.order {
+        if it := f.items[id]; it.Status == models.ReceiptBatchItemPending || it.Status == models.ReceiptBatchItemIssued {
+            out = append(out, *it)
+        }
+    }
+    return out, nil
+}
+func (f *fakeReceiptBatchRepo) HasReceipt(ctx context.Context, ownerID, incomeID uuid.UUID) (bool, error) {
+    return f.hasReceipt[incomeID], nil
+}
+func (f *fakeReceiptBatchRepo) UpdateItem(ctx context.Context, it *models.ReceiptBatchItem, now time.Time) error {
+    cp := *it
+    f.items[it.IncomeID] = &cp
+    return nil
+}
+func (f *fakeReceiptBatchRepo) Requeue(ctx context.Context, b *models.ReceiptBatch) error {
+    b.Status = models.ReceiptBatchStatusQueued
+    f.requeued = b
+    f.pending = append(f.pending, *b)
+    return nil
+}
+func (f *fakeReceiptBatchRepo) Finish(ctx context.Context, b *models.ReceiptBatch, now time.Time) error {
+    f.finished = b; return nil
+}
+
+func (f *fakeReceiptBatchRepo) add(it models.ReceiptBatchItem) {
+    if f.items == nil { f.items = map[uuid.UUID]*models.ReceiptBatchItem{} }
+    f.items[it.IncomeID] = &it
+    f.order = append(f.order, it.IncomeID)
+}
+
+// fakeBatchReceiptRepo implementa só o que o ReceiptBatchService usa
+type fakeBatchReceiptRepo struct {
+    repositories.ReceiptRepository
+    byID    map[uuid.UUID]*models.Receipt
+    created int
+}
+
+func (f *fakeBatchReceiptRepo) Create(ctx context.Context, m *models.Receipt) error {
+    f.created++
+    m.ID, m.Numero = uuid.New(), int64(f.created)
+    numero := fmt.Sprintf("RB-%d", f.created)
+    m.NumeroFormatado = &numero
+    cp := *m
+    f.byID[m.ID] = &cp
+    return nil
+}
+func (f *fakeBatchReceiptRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Receipt, error) {
+    m, ok := f.byID[id]
+    if !ok { return nil, errors.New("recibo não encontrado") }
+    cp := *m
+    return &cp, nil
+}
+func (f *fakeBatchReceiptRepo) SetPDF(ctx context.Context, id, ownerID uuid.UUID, pdfURL, hash string) (*models.Receipt, error) {
+    m := f.byID[id]
+    m.PDFURL, m.Hash = &pdfURL, &hash
+    return m, nil
+}
+
+// fakeBatchStore grava os PDFs enviados; failWith faz os próximos envios falharem
+type fakeBatchStore struct {
+    uploaded map[string][]byte
+    failWith error
+}
+
+func (s *fakeBatchStore) UploadStream(ctx context.Context, bucket, objectPath string, body io.Reader, contentType string) (int64, string, error) {
+    if s.failWith != nil { return 0, "", s.failWith }
+    b, _ := io.ReadAll(body)
+    s.uploaded[objectPath] = b
+    return int64(len(b)), "hash-" + objectPath, nil
+}
+func (s *fakeBatchStore) DeleteObject(ctx context.Context, bucket, objectPath string) error {
+    delete(s.uploaded, objectPath); return nil
+}
+
+func newReceiptBatchTest() (*ReceiptBatchService, *fakeReceiptBatchRepo, *fakeBatchReceiptRepo, *fakeBatchStore) {
+    repo := &fakeReceiptBatchRepo{hasReceipt: map[uuid.UUID]bool{}}
+    receipts := &fakeBatchReceiptRepo{byID: map[uuid.UUID]*models.Receipt{}}
+    store := &fakeBatchStore{uploaded: map[string][]byte{}}
+    svc := NewReceiptBatchService(repo, receipts, store, "receipts", nil, nil, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) }
+    return svc, repo, receipts, store
+}
+
+func TestReceiptBatchService_IssuesReceiptsAndPDFs(t *testing.T) {
+    svc, repo, receipts, store := newReceiptBatchTest()
+    owner := uuid.New()
+    paid, taken, resumed := uuid.New(), uuid.New(), uuid.New()
+    repo.hasReceipt[taken] = true
+    // Recibo emitido numa execução anterior, antes de o worker cair: só falta o PDF
+    prev := &models.Receipt{ID: uuid.New(), OwnerID: owner, IncomeID: &resumed}
+    receipts.byID[prev.ID] = prev
+    repo.add(models.ReceiptBatchItem{IncomeID: paid, OwnerID: owner, Status: models.ReceiptBatchItemPending})
+    repo.add(models.ReceiptBatchItem{IncomeID: taken, OwnerID: owner, Status: models.ReceiptBatchItemPending})
+    repo.add(models.ReceiptBatchItem{IncomeID: resumed, OwnerID: owner, ReceiptID: &prev.ID, Status: models.ReceiptBatchItemIssued})
+
+    b, err := svc.Create(context.Background(), owner, &models.ReceiptBatchRequest{Competencia: "10/2026"})
+    if err != nil { t.Fatalf("Create: %v", err) }
+    if b.Competencia != "2026-10" { t.Fatalf("competência = %q, esperado 2026-10", b.Competencia) }
+    if err := svc.ProcessPending(context.Background()); err != nil { t.Fatalf("ProcessPending: %v", err) }
+
+    if receipts.created != 1 { t.Fatalf("recibos emitidos = %d, esperado 1", receipts.created) }
+    if it := repo.items[paid]; it.Status != models.ReceiptBatchItemDone || it.ReceiptID == nil {
+        t.Fatalf("receita paga = %+v", it)
+    }
+    if it := repo.items[taken]; it.Status != models.ReceiptBatchItemSkipped || it.Error == nil || *it.Error != models.ReceiptBatchSkipHasReceipt {
+        t.Fatalf("receita com recibo = %+v", it)
+    }
+    if it := repo.items[resumed]; it.Status != models.ReceiptBatchItemDone || *it.ReceiptID != prev.ID {
+        t.Fatalf("receita retomada = %+v", it)
+    }
+    if len(store.uploaded) != 2 { t.Fatalf("PDFs enviados = %d, esperado 2", len(store.uploaded)) }
+    for path, content := range store.uploaded {
+        if !strings.HasPrefix(path, owner.String()+"/receipts/") || !bytes.HasPrefix(content, []byte("%PDF-")) {
+            t.Fatalf("PDF inesperado em %q", path)
+        }
+    }
+    if f := repo.finished; f == nil || f.Status != models.ReceiptBatchStatusCompleted { t.Fatalf("lote finalizado = %+v", f) }
+}
+
+func TestReceiptBatchService_TransientStorageRequeuesWithoutReissuing(t *testing.T) {
+    svc, repo, receipts, store := newReceiptBatchTest()
+    owner, income := uuid.New(), uuid.New()
+    repo.add(models.ReceiptBatchItem{IncomeID: income, OwnerID: owner, Status: models.ReceiptBatchItemPending})
+    store.failWith = &storage.Error{Status: 503, Transient: true, Err: errors.New("storage indisponível")}
+
+    if _, err := svc.Create(context.Background(), owner, &models.ReceiptBatchRequest{Competencia: "2026-10"}); err != nil {
+        t.Fatalf("Create: %v", err)
+    }
+    if err := svc.ProcessPending(context.Background()); err == nil { t.Fatalf("esperava erro transitório") }
+    if repo.requeued == nil || repo.finished != nil { t.Fatalf("lote deveria voltar à fila sem finalizar") }
+    if it := repo.items[income]; it.Status != models.ReceiptBatchItemIssued { t.Fatalf("item = %+v, esperado issued", it) }
+
+    // Storage de volta: o PDF sai para o recibo já emitido
+    store.failWith = nil
+    if err := svc.ProcessPending(context.Background()); err != nil { t.Fatalf("ProcessPending: %v", err) }
+    if receipts.created != 1 { t.Fatalf("recibos emitidos = %d, esperado 1 (sem duplicar)", receipts.created) }
+    if it := repo.items[income]; it.Status != models.ReceiptBatchItemDone { t.Fatalf("item = %+v, esperado done", it) }
+    if f := repo.finished; f == nil || f.Status != models.ReceiptBatchStatusCompleted { t.Fatalf("lote finalizado = %+v", f) }
+
+    // Esgotadas as tentativas a falha fica na receita e o lote termina
+    repo.finished, repo.requeued = nil, nil
+    other := uuid.New()
+    repo.add(models.ReceiptBatchItem{IncomeID: other, OwnerID: owner, Status: models.ReceiptBatchItemPending})
+    store.failWith = &storage.Error{Status: 503, Transient: true, Err: errors.New("storage indisponível")}
+    repo.pending = []models.ReceiptBatch{{ID: uuid.New(), OwnerID: owner, Competencia: "2026-10", Attempts: svc.maxAttempts - 1}}
+    if err := svc.ProcessPending(context.Background()); err != nil { t.Fatalf("ProcessPending: %v", err) }
+    if it := repo.items[other]; it.Status != models.ReceiptBatchItemFailed || it.Error == nil || it.ReceiptID == nil {
+        t.Fatalf("item = %+v, esperado failed com o recibo emitido", it)
+    }
+    if repo.requeued != nil || repo.finished == nil { t.Fatalf("lote deveria terminar na última tentativa") }
+}
+
+func TestReceiptBatchService_RejectsInvalidCompetencia(t *testing.T) {
+    svc, _, _, _ := newReceiptBatchTest()
+    if _, err := svc.Create(context.Background(), uuid.New(), &models.ReceiptBatchRequest{Competencia: "2026-13"}); !errors.Is(err, models.ErrReportCompetenciaInvalid) {
+        t.Fatalf("err = %v, esperado ErrReportCompetenciaInvalid", err)
+    }
+}
@@ -0,0 +1,193 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Serviço do carnê de recibos por contrato (PDF único com uma folha por competência)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/pdf"
+	"recibofast/internal/repositories"
+)
+
+// ReceiptBookService monta e desenha o carnê anual de um contrato.
+// Docstring: cada folha tem um canhoto destacável (fica com o emissor) e o recibo entregue ao
+// pagador; valores e datas seguem o idioma/fuso do usuário. Folhas de receitas não quitadas
+// levam a marca d'água das configurações do usuário (padrão "SEM VALOR DE QUITAÇÃO"), aplicada
+// aqui para valer tanto na prévia JSON quanto no PDF.
+type ReceiptBookService struct {
+	repo     repositories.ContractRepository
+	settings repositories.SettingsRepository
+	branding ReceiptBranding
+	log      logging.Logger
+}
+
+// NewReceiptBookService cria o serviço do carnê; branding (opcional) aplica o modelo de recibo padrão
+func NewReceiptBookService(repo repositories.ContractRepository, settings repositories.SettingsRepository, branding ReceiptBranding, log logging.Logger) *ReceiptBookService {
+	return &ReceiptBookService{repo: repo, settings: settings, branding: branding, log: log}
+}
+
+// Build carrega o contrato e as receitas do ano e monta as folhas
+func (s *ReceiptBookService) Build(ctx context.Context, contractID, ownerID uuid.UUID, year int) (*models.ReceiptBook, error) {
+	if year < 2000 || year > 2100 {
+		return nil, models.ErrInvalidReceiptBookYear
+	}
+	c, err := s.repo.GetByID(ctx, contractID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repo.ListReceiptBookEntries(ctx, contractID, ownerID, year)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar receitas do contrato: %w", err)
+	}
+	book, err := models.BuildReceiptBook(c, year, entries)
+	if err != nil {
+		return nil, err
+	}
+	book.ApplyUnpaidWatermark(s.unpaidWatermark(ctx, ownerID))
+	book.Template = defaultReceiptTemplate(ctx, s.branding, ownerID)
+	return book, nil
+}
+
+// unpaidWatermark texto da marca d'água do usuário; na falha ao ler as configurações usa o padrão
+func (s *ReceiptBookService) unpaidWatermark(ctx context.Context, ownerID uuid.UUID) string {
+	if s.settings == nil {
+		return models.DefaultUnpaidWatermark
+	}
+	us, err := s.settings.Get(ctx, ownerID)
+	if err != nil {
+		s.log.Warn("erro ao carregar marca d'água do usuário", logging.Field{Key: "error", Val: err.Error()})
+		return models.DefaultUnpaidWatermark
+	}
+	return us.WatermarkForUnpaid()
+}
+
+// Render gera o PDF do carnê
+func (s *ReceiptBookService) Render(book *models.ReceiptBook, ls locale.Settings) []byte {
+	doc := pdf.New()
+	brand := newReceiptBrand(doc, book.Template)
+	for _, p := range book.Pages {
+		doc.AddPage()
+		doc.Watermark(p.Watermark)
+		drawReceiptBookPage(doc, book, &p, ls, brand)
+	}
+	return doc.Bytes()
+}
+
+const (
+	bookMargin = 50.0
+	bookRight  = pdf.PageWidth - bookMargin
+)
+
+// drawReceiptBookPage desenha uma folha; com modelo de recibo, canhoto e recibo descem para dar
+// lugar ao cabeçalho da marca
+func drawReceiptBookPage(doc *pdf.Document, book *models.ReceiptBook, p *models.ReceiptBookPage, ls locale.Settings, brand *receiptBrand) {
+	c := &book.Contract
+	folha := fmt.Sprintf("%02d/%02d", p.Seq, p.Total)
+	comp := ls.FormatCompetencia(p.Competencia)
+	valor, importancia := "R$ ____________", "R$ ____________"
+	if p.Valor != nil {
+		valor = ls.FormatAmount(*p.Valor)
+		importancia = fmt.Sprintf("%s (%s)", valor, locale.Extenso(*p.Valor))
+	}
+	venc := "___/___/______"
+	if p.DueDate != nil {
+		// vencimento é uma data (meia-noite UTC), não um instante: não converte de fuso
+		venc = locale.Settings{Locale: ls.Locale, Location: time.UTC}.FormatDate(*p.DueDate)
+	}
+
+	y0 := 0.0
+	if brand != nil {
+		y0 = brandHeaderHeight
+	}
+	brand.header(doc)
+
+	// Canhoto
+	doc.Rect(bookMargin, y0+40, bookRight-bookMargin, 170, 0)
+	doc.Text(bookMargin+12, y0+62, 11, true, fmt.Sprintf("CANHOTO - Carnê %d", book.Year))
+	doc.TextRight(bookRight-12, y0+62, 11, true, "Folha "+folha)
+	y := y0 + 86
+	for _, kv := range [][2]string{
+		{"Contrato", contractLabel(c)},
+		{"Pagador", orDash(c.PayerNome)},
+		{"Competência", comp},
+		{"Vencimento", venc},
+		{"Valor", valor},
+	} {
+		doc.Text(bookMargin+12, y, 9, true, kv[0]+":")
+		doc.Text(bookMargin+90, y, 9, false, kv[1])
+		y += 16
+	}
+	doc.Text(bookMargin+12, y0+192, 9, false, "Pago em ___/___/______     Visto: ______________________")
+
+	doc.Line(bookMargin-20, y0+232, bookRight+20, y0+232, 0.6, true)
+	doc.TextRight(bookRight, y0+228, 7, false, "destaque aqui")
+
+	// Recibo
+	top := y0 + 260
+	doc.Rect(bookMargin, top, bookRight-bookMargin, 420, 0)
+	brand.title(doc, bookMargin+16, top+36, 22, "RECIBO")
+	doc.Text(bookMargin+16, top+56, 10, false, fmt.Sprintf("Carnê %d - folha %s", book.Year, folha))
+	if p.ReceiptNumeroFormatado != nil {
+		doc.Text(bookMargin+16, top+72, 10, true, "Recibo nº "+*p.ReceiptNumeroFormatado)
+	} else if p.ReceiptNumero != nil {
+		doc.Text(bookMargin+16, top+72, 10, true, fmt.Sprintf("Recibo nº %d", *p.ReceiptNumero))
+	}
+	brand.valueBox(doc, bookRight-196, top+18, 180, 44)
+	doc.TextRight(bookRight-28, top+47, 16, true, valor)
+	if p.Status == models.StatusPago {
+		doc.TextRight(bookRight-28, top+80, 12, true, "PAGO")
+	}
+
+	text := fmt.Sprintf("Recebi(emos) de %s a importância de %s, referente a %s, competência %s, com vencimento em %s.",
+		payerLabel(c), importancia, contractLabel(c), comp, venc)
+	y = doc.Paragraph(bookMargin+16, top+120, bookRight-bookMargin-32, 12, text)
+	doc.Text(bookMargin+16, y+24, 12, false, "Pelo que firmo(amos) o presente recibo, dando plena quitação do valor acima.")
+
+	doc.Text(bookMargin+16, top+300, 11, false, "Local e data: ______________________, ___/___/______")
+	brand.drawIssuer(doc, top+370, c.IssuerName, c.IssuerDocument)
+
+	brand.footer(doc)
+	doc.Text(bookMargin, pdf.PageHeight-40, 7, false, fmt.Sprintf("Gerado pelo ReciboFast - contrato %s - folha %s", c.ID, folha))
+}
+
+func contractLabel(c *models.Contract) string {
+	desc := strings.TrimSpace(derefString(c.Descricao))
+	num := strings.TrimSpace(derefString(c.Numero))
+	switch {
+	case desc != "" && num != "":
+		return fmt.Sprintf("%s (contrato nº %s)", desc, num)
+	case desc != "":
+		return desc
+	case num != "":
+		return "contrato nº " + num
+	}
+	return "contrato " + c.ID.String()[:8]
+}
+
+func payerLabel(c *models.Contract) string {
+	nome := strings.TrimSpace(derefString(c.PayerNome))
+	if nome == "" {
+		nome = "______________________________"
+	}
+	if d := strings.TrimSpace(derefString(c.PayerDocumento)); d != "" {
+		return fmt.Sprintf("%s (CPF/CNPJ %s)", nome, d)
+	}
+	return nome
+}
+
+func orDash(s *string) string {
+	if v := strings.TrimSpace(derefString(s)); v != "" {
+		return v
+	}
+	return "-"
+}
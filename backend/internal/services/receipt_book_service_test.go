@@ -0,0 +1,135 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do serviço do carnê de recibos (montagem e PDF)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "testing"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+type fakeContractRepo struct {
+    contract *models.Contract
+    entries  []models.ReceiptBookEntry
+    year     int
+}
+
+func (f *fakeContractRepo) Create(_ context.Context, c *models.Contract) error { return nil }
+
+func (f *fakeContractRepo) GetByID(_ context.Context, id, ownerID uuid.UUID) (*models.Contract, error) {
+    if f.contract == nil || f.contract.ID != id || f.contract.OwnerID != ownerID { return nil, models.ErrContractNotFound }
+    return f.contract, nil
+}
+func (f *fakeContractRepo) ListReceiptBookEntries(_ context.Context, _, _ uuid.UUID, year int) ([]models.ReceiptBookEntry, error) {
+    f.year = year
+    return f.entries, nil
+}
+
+func TestReceiptBookService_RendersOnePagePerCompetencia(t *testing.T) {
+    owner := uuid.New()
+    nome, desc := "Maria da Conceição", "Aluguel Apto 12"
+    c := &models.Contract{ID: uuid.New(), OwnerID: owner, Descricao: &desc, PayerNome: &nome, ValorMensal: models.NewMoney(1234.5)}
+    repo := &fakeContractRepo{contract: c}
+    svc := NewReceiptBookService(repo, nil, nil, logging.NewLogger("dev"))
+
+    if _, err := svc.Build(context.Background(), c.ID, uuid.New(), 2026); !errors.Is(err, models.ErrContractNotFound) { t.Fatalf("contrato de outro usuário: err = %v", err) }
+    if _, err := svc.Build(context.Background(), c.ID, owner, 26); !errors.Is(err, models.ErrInvalidReceiptBookYear) { t.Fatalf("ano inválido: err = %v", err) }
+
+    book, err := svc.Build(context.Background(), c.ID, owner, 2026)
+    if err != nil { t.Fatalf("Build err: %v", err) }
+    if repo.year != 2026 || len(book.Pages) != 12 { t.Fatalf("esperava 12 folhas de 2026, got %d", len(book.Pages)) }
+
+    out := svc.Render(book, locale.Default())
+    if !bytes.Contains(out, []byte("/Count 12")) { t.Fatalf("PDF deveria ter 12 páginas") }
+    for _, want := range []string{"Folha 01/12", "Folha 12/12", "R$ 1.234,50", "cinquenta centavos", "12/2026"} {
+        if !bytes.Contains(out, []byte(want)) { t.Fatalf("PDF sem %q", want) }
+    }
+}
+
+// fakeSettingsRepo configurações fixas do usuário
+type fakeSettingsRepo struct {
+    settings     *models.UserSettings
+    rules        *models.FeeRules
+    issuer       models.InvoiceIssuer
+    numbering    *models.ReceiptNumbering
+    sequences    map[int]int64
+    automation   models.ReceiptAutomation
+    businessDays models.BusinessDaySettings
+}
+
+func (f *fakeSettingsRepo) Get(_ context.Context, ownerID uuid.UUID) (*models.UserSettings, error) {
+    return f.settings, nil
+}
+func (f *fakeSettingsRepo) GetFeeRules(_ context.Context, ownerID uuid.UUID) (*models.FeeRules, error) {
+    if f.rules == nil { return &models.FeeRules{}, nil }
+    return f.rules, nil
+}
+func (f *fakeSettingsRepo) UpdateFeeRules(_ context.Context, ownerID uuid.UUID, rules *models.FeeRules) error {
+    f.rules = rules
+    return nil
+}
+func (f *fakeSettingsRepo) GetInvoiceIssuer(_ context.Context, ownerID uuid.UUID) (*models.InvoiceIssuer, error) {
+    out := f.issuer
+    return &out, nil
+}
+func (f *fakeSettingsRepo) UpdateInvoiceIssuer(_ context.Context, ownerID uuid.UUID, issuer *models.InvoiceIssuer) error {
+    f.issuer = *issuer
+    return nil
+}
+func (f *fakeSettingsRepo) GetReceiptNumbering(_ context.Context, ownerID uuid.UUID) (*models.ReceiptNumbering, error) {
+    if f.numbering == nil { return &models.ReceiptNumbering{Reinicio: models.ReceiptNumberingNever}, nil }
+    out := *f.numbering
+    return &out, nil
+}
+func (f *fakeSettingsRepo) UpdateReceiptNumbering(_ context.Context, ownerID uuid.UUID, n *models.ReceiptNumbering) error {
+    f.numbering = n
+    return nil
+}
+func (f *fakeSettingsRepo) LastReceiptSequence(_ context.Context, ownerID uuid.UUID, periodo int) (int64, error) {
+    return f.sequences[periodo], nil
+}
+func (f *fakeSettingsRepo) GetReceiptAutomation(_ context.Context, ownerID uuid.UUID) (*models.ReceiptAutomation, error) {
+    out := f.automation
+    return &out, nil
+}
+func (f *fakeSettingsRepo) UpdateReceiptAutomation(_ context.Context, ownerID uuid.UUID, a *models.ReceiptAutomation) error {
+    f.automation = *a
+    return nil
+}
+func (f *fakeSettingsRepo) GetBusinessDays(_ context.Context, ownerID uuid.UUID) (*models.BusinessDaySettings, error) {
+    out := f.businessDays
+    return &out, nil
+}
+func (f *fakeSettingsRepo) UpdateBusinessDays(_ context.Context, ownerID uuid.UUID, b *models.BusinessDaySettings) error {
+    f.businessDays = *b
+    return nil
+}
+
+func TestReceiptBookService_WatermarksUnpaidPages(t *testing.T) {
+    owner := uuid.New()
+    c := &models.Contract{ID: uuid.New(), OwnerID: owner, ValorMensal: models.NewMoney(100)}
+    paid := models.ReceiptBookEntry{IncomeID: uuid.New(), Competencia: "2026-01", Valor: models.NewMoney(100), Status: models.StatusPago}
+    repo := &fakeContractRepo{contract: c, entries: []models.ReceiptBookEntry{paid}}
+    settings := &fakeSettingsRepo{settings: &models.UserSettings{OwnerID: owner}}
+    svc := NewReceiptBookService(repo, settings, nil, logging.NewLogger("dev"))
+
+    book, err := svc.Build(context.Background(), c.ID, owner, 2026)
+    if err != nil { t.Fatalf("Build err: %v", err) }
+    if book.Pages[0].Watermark != "" || book.Pages[1].Watermark != models.DefaultUnpaidWatermark { t.Fatalf("folhas: %+v", book.Pages[:2]) }
+    out := svc.Render(book, locale.Default())
+    if n := bytes.Count(out, []byte(`(SEM VALOR DE QUITA\307\303O) Tj`)); n != 11 { t.Fatalf("esperava 11 folhas com marca d'água, got %d", n) }
+
+    off := false
+    settings.settings.UnpaidWatermark = &off
+    book, _ = svc.Build(context.Background(), c.ID, owner, 2026)
+    if out := svc.Render(book, locale.Default()); bytes.Contains(out, []byte(" Tm ")) { t.Fatalf("marca d'água desligada nas configurações") }
+}
@@ -0,0 +1,170 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Identidade visual do modelo de recibo aplicada aos PDFs (logotipo, cores, cabeçalho e rodapé)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	_ "image/jpeg" // logotipos JPEG
+	_ "image/png"
+	"strings"
+
+	"github.com/google/uuid"
+	"recibofast/internal/models"
+	"recibofast/internal/pdf"
+)
+
+// ReceiptBranding fornece o modelo de recibo padrão do usuário para os PDFs (implementado por
+// ReceiptTemplateService); nil quando o usuário não tem modelo padrão
+type ReceiptBranding interface {
+	DefaultTemplate(ctx context.Context, ownerID uuid.UUID) *models.ReceiptTemplate
+}
+
+// defaultReceiptTemplate modelo padrão de ownerID, tolerando branding nil (PDF sem marca)
+func defaultReceiptTemplate(ctx context.Context, b ReceiptBranding, ownerID uuid.UUID) *models.ReceiptTemplate {
+	if b == nil {
+		return nil
+	}
+	return b.DefaultTemplate(ctx, ownerID)
+}
+
+// Área reservada ao cabeçalho da marca no topo da página
+const (
+	brandBand       = 8.0  // faixas na cor principal no topo e no rodapé
+	brandLogoTop    = 18.0 // topo do logotipo
+	brandLogoWidth  = 150.0
+	brandLogoHeight = 44.0
+	// brandHeaderHeight altura ocupada pelo cabeçalho; layouts que começam no topo descem isso
+	brandHeaderHeight = 34.0
+)
+
+// receiptBrand modelo de recibo preparado para um documento (logotipo incluído uma única vez)
+type receiptBrand struct {
+	tpl       *models.ReceiptTemplate
+	primary   pdf.Color
+	accent    pdf.Color
+	hasAccent bool
+	band      bool
+	logo      pdf.ImageRef
+	hasLogo   bool
+}
+
+// newReceiptBrand prepara o modelo para doc; nil sem modelo. Logotipo ilegível é ignorado (o
+// envio já valida o arquivo; o PDF não deixa de sair por causa dele)
+func newReceiptBrand(doc *pdf.Document, tpl *models.ReceiptTemplate) *receiptBrand {
+	if tpl == nil {
+		return nil
+	}
+	b := &receiptBrand{tpl: tpl, primary: pdf.Black}
+	if c, ok := pdf.HexColor(derefString(tpl.PrimaryColor)); ok {
+		b.primary, b.band = c, true
+	}
+	b.accent, b.hasAccent = pdf.HexColor(derefString(tpl.AccentColor))
+	if len(tpl.Logo) > 0 {
+		if img, _, err := image.Decode(bytes.NewReader(tpl.Logo)); err == nil {
+			b.logo, b.hasLogo = doc.AddImage(img), true
+		}
+	}
+	return b
+}
+
+// header desenha faixa, logotipo e texto do cabeçalho na página atual
+func (b *receiptBrand) header(doc *pdf.Document) {
+	if b == nil {
+		return
+	}
+	if b.band {
+		doc.FillRect(0, 0, pdf.PageWidth, brandBand, b.primary)
+	}
+	textLeft := bookMargin
+	if b.hasLogo {
+		w, h := doc.FitImage(b.logo, brandLogoWidth, brandLogoHeight)
+		doc.DrawImage(b.logo, bookMargin, brandLogoTop, w, h)
+		textLeft += w + 16
+	}
+	if text := strings.TrimSpace(derefString(b.tpl.HeaderText)); text != "" {
+		y := brandLogoTop + 9
+		for i, line := range pdf.Wrap(text, bookRight-textLeft, 9, false) {
+			if i == 4 {
+				break
+			}
+			doc.TextColor(bookRight-pdf.TextWidth(line, 9, false), y, 9, false, line, b.primary)
+			y += 11
+		}
+	}
+}
+
+// footer desenha o texto do rodapé e a faixa inferior na página atual
+func (b *receiptBrand) footer(doc *pdf.Document) {
+	if b == nil {
+		return
+	}
+	if text := strings.TrimSpace(derefString(b.tpl.FooterText)); text != "" {
+		lines := pdf.Wrap(text, bookRight-bookMargin, 8, false)
+		if len(lines) > 2 {
+			lines = lines[:2]
+		}
+		y := pdf.PageHeight - 56 - 10*float64(len(lines)-1)
+		for _, line := range lines {
+			doc.Text(bookMargin, y, 8, false, line)
+			y += 10
+		}
+	}
+	if b.band {
+		doc.FillRect(0, pdf.PageHeight-brandBand, pdf.PageWidth, brandBand, b.primary)
+	}
+}
+
+// title escreve o título do recibo na cor principal
+func (b *receiptBrand) title(doc *pdf.Document, x, y, size float64, s string) {
+	if b == nil {
+		doc.Text(x, y, size, true, s)
+		return
+	}
+	doc.TextColor(x, y, size, true, s, b.primary)
+}
+
+// valueBox caixa do valor: cor de destaque do modelo ou cinza claro
+func (b *receiptBrand) valueBox(doc *pdf.Document, x, y, w, h float64) {
+	if b == nil || !b.hasAccent {
+		doc.Rect(x, y, w, h, 0.92)
+		return
+	}
+	doc.FillRect(x, y, w, h, b.accent)
+	doc.Rect(x, y, w, h, 0)
+}
+
+// issuer emissor do recibo ou, sem ele, o emissor padrão do modelo (nome, documento e endereço)
+func (b *receiptBrand) issuer(name, document *string) (string, string, string) {
+	n, d := strings.TrimSpace(derefString(name)), strings.TrimSpace(derefString(document))
+	if b == nil {
+		return n, d, ""
+	}
+	if n == "" && d == "" {
+		n, d = strings.TrimSpace(derefString(b.tpl.IssuerName)), strings.TrimSpace(derefString(b.tpl.IssuerDocument))
+	}
+	return n, d, strings.TrimSpace(derefString(b.tpl.IssuerAddress))
+}
+
+// drawIssuer linha de assinatura com o bloco do emissor (nome, CPF/CNPJ e endereço)
+func (b *receiptBrand) drawIssuer(doc *pdf.Document, y float64, name, document *string) {
+	doc.Line(pdf.PageWidth/2-130, y, pdf.PageWidth/2+130, y, 0.8, false)
+	n, d, addr := b.issuer(name, document)
+	if n == "" {
+		n = "Assinatura do emissor"
+	}
+	doc.Text(pdf.PageWidth/2-130, y+16, 10, false, n)
+	if d != "" {
+		doc.Text(pdf.PageWidth/2-130, y+30, 9, false, "CPF/CNPJ: "+d)
+	}
+	for i, line := range pdf.Wrap(addr, 260, 8, false) {
+		if i == 2 {
+			break
+		}
+		doc.Text(pdf.PageWidth/2-130, y+44+10*float64(i), 8, false, line)
+	}
+}
@@ -0,0 +1,200 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Envio do recibo em PDF por e-mail ao pagador, com registro da situação no recibo
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/email"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/pdf"
+	"recibofast/internal/repositories"
+)
+
+// ReceiptMailService gera o PDF do recibo a partir do snapshot congelado e o envia por e-mail.
+// Docstring: cada tentativa (sucesso ou falha) é gravada no recibo (email_status, email_error...);
+// sem provedor configurado (mailer nil) o envio responde ErrEmailUnavailable.
+type ReceiptMailService struct {
+	repo     repositories.ReceiptRepository
+	branding ReceiptBranding
+	mailer   email.Mailer
+	log      logging.Logger
+	now      func() time.Time
+}
+
+// NewReceiptMailService cria o serviço de envio de recibos; mailer pode ser nil (envio desabilitado)
+// e branding também (PDF sem a identidade visual do usuário)
+func NewReceiptMailService(repo repositories.ReceiptRepository, branding ReceiptBranding, mailer email.Mailer, log logging.Logger) *ReceiptMailService {
+	return &ReceiptMailService{repo: repo, branding: branding, mailer: mailer, log: log, now: time.Now}
+}
+
+// Send envia o recibo ao destinatário informado ou, sem ele, ao e-mail do pagador
+func (s *ReceiptMailService) Send(ctx context.Context, id, ownerID uuid.UUID, req *models.ReceiptSendRequest, ls locale.Settings) (*models.ReceiptSendResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if s.mailer == nil {
+		return nil, models.ErrEmailUnavailable
+	}
+	rec, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	to := req.To
+	if to == nil {
+		if to, err = s.repo.GetPayerEmail(ctx, id, ownerID); err != nil {
+			return nil, err
+		}
+		if to == nil {
+			return nil, models.ErrReceiptRecipientMissing
+		}
+	}
+
+	msg := receiptEmailMessage(rec, *to, derefString(req.Mensagem), ls)
+	msg.Attachments = []email.Attachment{{
+		Filename:    fmt.Sprintf("recibo-%d.pdf", rec.Numero),
+		ContentType: "application/pdf",
+		Content:     RenderReceiptPDF(rec, ls, defaultReceiptTemplate(ctx, s.branding, ownerID)),
+	}}
+	sendErr := s.mailer.Send(ctx, msg)
+
+	// A tentativa é registrada mesmo se o cliente desistiu da requisição
+	updated, err := s.repo.RecordEmail(context.WithoutCancel(ctx), id, ownerID, *to, sendErr, s.now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar envio do recibo: %w", err)
+	}
+	if sendErr != nil {
+		s.log.Warn("falha ao enviar recibo por e-mail",
+			logging.Field{Key: "receipt_id", Val: id.String()},
+			logging.Field{Key: "error", Val: sendErr.Error()})
+		return &models.ReceiptSendResponse{Receipt: updated, To: *to, Status: models.ReceiptEmailFailed},
+			fmt.Errorf("%w: %v", models.ErrEmailSendFailed, sendErr)
+	}
+	s.log.Info("recibo enviado por e-mail", logging.Field{Key: "receipt_id", Val: id.String()})
+	return &models.ReceiptSendResponse{Receipt: updated, To: *to, Status: models.ReceiptEmailSent}, nil
+}
+
+// receiptEmailMessage compõe assunto e corpo do e-mail (texto simples) a partir do recibo
+func receiptEmailMessage(rec *models.Receipt, to, mensagem string, ls locale.Settings) *email.Message {
+	subject := "Recibo nº " + rec.NumeroExibido()
+	if comp := derefString(rec.Competencia); comp != "" {
+		subject += " - " + ls.FormatCompetencia(comp)
+	}
+	var b strings.Builder
+	if nome := strings.TrimSpace(derefString(rec.PayerNome)); nome != "" {
+		fmt.Fprintf(&b, "Olá, %s!\n\n", nome)
+	} else {
+		b.WriteString("Olá!\n\n")
+	}
+	fmt.Fprintf(&b, "Segue em anexo o recibo nº %s", rec.NumeroExibido())
+	if v := receiptAmount(rec); v != nil {
+		fmt.Fprintf(&b, ", no valor de %s", ls.FormatAmount(*v))
+	}
+	if comp := derefString(rec.Competencia); comp != "" {
+		fmt.Fprintf(&b, ", referente à competência %s", ls.FormatCompetencia(comp))
+	}
+	b.WriteString(".\n")
+	if mensagem != "" {
+		b.WriteString("\n" + mensagem + "\n")
+	}
+	if issuer := strings.TrimSpace(derefString(rec.IssuerName)); issuer != "" {
+		fmt.Fprintf(&b, "\nAtenciosamente,\n%s\n", issuer)
+	}
+	b.WriteString("\n--\nEnviado pelo ReciboFast")
+	return &email.Message{To: to, Subject: subject, Text: b.String()}
+}
+
+// receiptAmount valor quitado pelo recibo: líquido (valor + taxas - descontos) ou, sem ele, o valor
+func receiptAmount(rec *models.Receipt) *models.Money {
+	if rec.ValorLiquido != nil {
+		return rec.ValorLiquido
+	}
+	return rec.Valor
+}
+
+// RenderReceiptPDF gera o PDF de uma página do recibo com os valores congelados na emissão.
+// Docstring: tpl (opcional) aplica a identidade visual do modelo de recibo do usuário.
+func RenderReceiptPDF(rec *models.Receipt, ls locale.Settings, tpl *models.ReceiptTemplate) []byte {
+	return renderReceiptPDF(rec, ls, tpl, nil)
+}
+
+// RenderReceiptReissuePDF gera a via rr do recibo: mesmo número e data de emissão do original,
+// com a marca "Nª VIA" em marca d'água e a data em que a via foi emitida
+func RenderReceiptReissuePDF(rec *models.Receipt, ls locale.Settings, tpl *models.ReceiptTemplate, rr *models.ReceiptReissue) []byte {
+	return renderReceiptPDF(rec, ls, tpl, rr)
+}
+
+func renderReceiptPDF(rec *models.Receipt, ls locale.Settings, tpl *models.ReceiptTemplate, rr *models.ReceiptReissue) []byte {
+	doc := pdf.New()
+	doc.AddPage()
+	if rr != nil {
+		doc.Watermark(rr.Label())
+	}
+	brand := newReceiptBrand(doc, tpl)
+	brand.header(doc)
+
+	top := 80.0
+	doc.Rect(bookMargin, top, bookRight-bookMargin, 420, 0)
+	brand.title(doc, bookMargin+16, top+36, 22, "RECIBO")
+	doc.Text(bookMargin+16, top+56, 10, true, "Recibo nº "+rec.NumeroExibido())
+	if rec.EmitidoEm != nil {
+		doc.Text(bookMargin+16, top+72, 10, false, "Emitido em "+ls.FormatDate(*rec.EmitidoEm))
+	}
+	if rr != nil {
+		doc.Text(bookMargin+16, top+88, 10, true, fmt.Sprintf("%s emitida em %s", rr.Label(), ls.FormatDate(rr.CreatedAt)))
+	}
+	valor, importancia := "R$ ____________", "R$ ____________"
+	if v := receiptAmount(rec); v != nil {
+		valor = ls.FormatAmount(*v)
+		importancia = fmt.Sprintf("%s (%s)", valor, locale.Extenso(*v))
+	}
+	brand.valueBox(doc, bookRight-196, top+18, 180, 44)
+	doc.TextRight(bookRight-28, top+47, 16, true, valor)
+
+	pagador := strings.TrimSpace(derefString(rec.PayerNome))
+	if pagador == "" {
+		pagador = "______________________________"
+	}
+	if d := strings.TrimSpace(derefString(rec.PayerDocumento)); d != "" {
+		pagador = fmt.Sprintf("%s (CPF/CNPJ %s)", pagador, d)
+	}
+	referente := "serviços prestados"
+	if c := strings.TrimSpace(derefString(rec.Categoria)); c != "" {
+		referente = c
+	}
+	if comp := derefString(rec.Competencia); comp != "" {
+		referente += ", competência " + ls.FormatCompetencia(comp)
+	}
+	text := fmt.Sprintf("Recebi(emos) de %s a importância de %s, referente a %s.", pagador, importancia, referente)
+	y := doc.Paragraph(bookMargin+16, top+120, bookRight-bookMargin-32, 12, text)
+	doc.Text(bookMargin+16, y+24, 12, false, "Pelo que firmo(amos) o presente recibo, dando plena quitação do valor acima.")
+	if rec.Taxas > 0 || rec.Descontos > 0 {
+		doc.Text(bookMargin+16, y+48, 9, false, fmt.Sprintf("Valor original %s, acréscimos %s, descontos %s.",
+			ls.FormatAmount(derefMoney(rec.Valor)), ls.FormatAmount(rec.Taxas), ls.FormatAmount(rec.Descontos)))
+	}
+
+	brand.drawIssuer(doc, top+370, rec.IssuerName, rec.IssuerDocument)
+	brand.footer(doc)
+	footer := fmt.Sprintf("Gerado pelo ReciboFast - recibo %s", rec.ID)
+	if rr != nil {
+		footer += fmt.Sprintf(" - %dª via", rr.Via)
+	}
+	doc.Text(bookMargin, pdf.PageHeight-40, 7, false, footer)
+	return doc.Bytes()
+}
+
+func derefMoney(m *models.Money) models.Money {
+	if m == nil {
+		return 0
+	}
+	return *m
+}
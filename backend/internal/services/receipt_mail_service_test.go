@@ -0,0 +1,100 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do envio de recibos por e-mail (destinatário, anexo e registro da tentativa)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/email"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+// fakeMailReceiptRepo implementa só o que o ReceiptMailService usa
+type fakeMailReceiptRepo struct {
+    repositories.ReceiptRepository
+    rec        *models.Receipt
+    payerEmail *string
+    recordedTo string
+    recordErr  error
+}
+
+func (f *fakeMailReceiptRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Receipt, error) {
+    return f.rec, nil
+}
+func (f *fakeMailReceiptRepo) GetPayerEmail(ctx context.Context, id, ownerID uuid.UUID) (*string, error) {
+    return f.payerEmail, nil
+}
+func (f *fakeMailReceiptRepo) RecordEmail(ctx context.Context, id, ownerID uuid.UUID, to string, sendErr error, at time.Time) (*models.Receipt, error) {
+    f.recordedTo, f.recordErr = to, sendErr
+    cp := *f.rec
+    cp.EmailAttempts++
+    return &cp, nil
+}
+
+type fakeMailer struct {
+    sent []*email.Message
+    err  error
+}
+
+func (m *fakeMailer) Send(ctx context.Context, msg *email.Message) error {
+    m.sent = append(m.sent, msg)
+    return m.err
+}
+
+func newMailTestReceipt() *models.Receipt {
+    v := models.NewMoney(1500)
+    nome := "Maria"
+    return &models.Receipt{ID: uuid.New(), OwnerID: uuid.New(), Numero: 42, Valor: &v, ValorLiquido: &v, PayerNome: &nome}
+}
+
+func TestReceiptMailService_SendsToPayerWithPDF(t *testing.T) {
+    payer := "maria@exemplo.com"
+    repo := &fakeMailReceiptRepo{rec: newMailTestReceipt(), payerEmail: &payer}
+    mailer := &fakeMailer{}
+    svc := NewReceiptMailService(repo, nil, mailer, logging.NewLogger("dev"))
+
+    res, err := svc.Send(context.Background(), repo.rec.ID, repo.rec.OwnerID, &models.ReceiptSendRequest{}, locale.Default())
+    if err != nil { t.Fatalf("Send: %v", err) }
+    if res.To != payer || res.Status != models.ReceiptEmailSent || repo.recordedTo != payer {
+        t.Fatalf("res = %+v, registrado para %q", res, repo.recordedTo)
+    }
+    if len(mailer.sent) != 1 || len(mailer.sent[0].Attachments) != 1 || mailer.sent[0].Attachments[0].Filename != "recibo-42.pdf" {
+        t.Fatalf("mensagem enviada sem o PDF: %+v", mailer.sent)
+    }
+}
+
+func TestReceiptMailService_RecordsFailure(t *testing.T) {
+    repo := &fakeMailReceiptRepo{rec: newMailTestReceipt()}
+    mailer := &fakeMailer{err: errors.New("smtp: 550")}
+    svc := NewReceiptMailService(repo, nil, mailer, logging.NewLogger("dev"))
+
+    to := "outro@exemplo.com"
+    res, err := svc.Send(context.Background(), repo.rec.ID, repo.rec.OwnerID, &models.ReceiptSendRequest{To: &to}, locale.Default())
+    if !errors.Is(err, models.ErrEmailSendFailed) { t.Fatalf("err = %v, want ErrEmailSendFailed", err) }
+    if res == nil || res.Status != models.ReceiptEmailFailed || repo.recordErr == nil {
+        t.Fatalf("falha não registrada: res=%+v recordErr=%v", res, repo.recordErr)
+    }
+}
+
+func TestReceiptMailService_RecipientAndProviderRequired(t *testing.T) {
+    repo := &fakeMailReceiptRepo{rec: newMailTestReceipt()}
+    svc := NewReceiptMailService(repo, nil, &fakeMailer{}, logging.NewLogger("dev"))
+    if _, err := svc.Send(context.Background(), repo.rec.ID, repo.rec.OwnerID, &models.ReceiptSendRequest{}, locale.Default()); !errors.Is(err, models.ErrReceiptRecipientMissing) {
+        t.Fatalf("sem e-mail do pagador: err = %v", err)
+    }
+
+    svc = NewReceiptMailService(repo, nil, nil, logging.NewLogger("dev"))
+    if _, err := svc.Send(context.Background(), repo.rec.ID, repo.rec.OwnerID, &models.ReceiptSendRequest{}, locale.Default()); !errors.Is(err, models.ErrEmailUnavailable) {
+        t.Fatalf("sem provedor: err = %v", err)
+    }
+}
@@ -0,0 +1,70 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Esquema de numeração dos recibos configurado pelo usuário
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// ReceiptNumberingService consulta e grava o esquema de numeração dos recibos.
+// Docstring: a reserva do número acontece no banco, no INSERT do recibo (migração 051); aqui só
+// se grava o esquema e se calcula a prévia do próximo número.
+type ReceiptNumberingService struct {
+	settings repositories.SettingsRepository
+	log      logging.Logger
+	now      func() time.Time
+}
+
+// NewReceiptNumberingService cria o serviço de numeração dos recibos
+func NewReceiptNumberingService(settings repositories.SettingsRepository, log logging.Logger) *ReceiptNumberingService {
+	return &ReceiptNumberingService{settings: settings, log: log, now: time.Now}
+}
+
+// ReceiptNumberingSettings esquema gravado e o número que o próximo recibo receberá
+type ReceiptNumberingSettings struct {
+	Scheme  models.ReceiptNumbering `json:"scheme"`
+	Proximo string                  `json:"proximo"`
+}
+
+// Get retorna o esquema do usuário e a prévia do próximo número
+func (s *ReceiptNumberingService) Get(ctx context.Context, ownerID uuid.UUID) (*ReceiptNumberingSettings, error) {
+	n, err := s.settings.GetReceiptNumbering(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar numeração dos recibos: %w", err)
+	}
+	return s.withNext(ctx, ownerID, n)
+}
+
+// Update valida e grava o esquema; recibos já emitidos mantêm o número com que saíram
+func (s *ReceiptNumberingService) Update(ctx context.Context, ownerID uuid.UUID, n *models.ReceiptNumbering) (*ReceiptNumberingSettings, error) {
+	if err := n.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.settings.UpdateReceiptNumbering(ctx, ownerID, n); err != nil {
+		return nil, fmt.Errorf("erro ao gravar numeração dos recibos: %w", err)
+	}
+	s.log.Info("numeração dos recibos atualizada", logging.Field{Key: "owner_id", Val: ownerID.String()},
+		logging.Field{Key: "reinicio", Val: n.Reinicio})
+	return s.withNext(ctx, ownerID, n)
+}
+
+// withNext calcula o próximo número no período atual (ano no fuso padrão, como no trigger)
+func (s *ReceiptNumberingService) withNext(ctx context.Context, ownerID uuid.UUID, n *models.ReceiptNumbering) (*ReceiptNumberingSettings, error) {
+	periodo := n.Periodo(s.now().In(locale.Default().Location).Year())
+	last, err := s.settings.LastReceiptSequence(ctx, ownerID, periodo)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar sequência dos recibos: %w", err)
+	}
+	return &ReceiptNumberingSettings{Scheme: *n, Proximo: n.Format(last+1, periodo)}, nil
+}
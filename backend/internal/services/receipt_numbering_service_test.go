@@ -0,0 +1,42 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do esquema de numeração dos recibos e da prévia do próximo número
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+func TestReceiptNumberingService_PreviewFollowsSchemeAndPeriod(t *testing.T) {
+    repo := &fakeSettingsRepo{sequences: map[int]int64{0: 41, 2027: 3}}
+    svc := NewReceiptNumberingService(repo, logging.NewLogger("dev"))
+    // 31/12/2026 23h em São Paulo já é 2027 em UTC: o ano vem do fuso padrão
+    svc.now = func() time.Time { return time.Date(2027, 1, 1, 2, 0, 0, 0, time.UTC) }
+    owner := uuid.New()
+
+    out, err := svc.Get(context.Background(), owner)
+    if err != nil { t.Fatalf("Get err: %v", err) }
+    if out.Scheme.Reinicio != models.ReceiptNumberingNever || out.Proximo != "42" { t.Fatalf("padrão: %+v", out) }
+
+    if _, err := svc.Update(context.Background(), owner, &models.ReceiptNumbering{Digitos: 11}); !errors.Is(err, models.ErrReceiptDigitsInvalid) {
+        t.Fatalf("err = %v, want ErrReceiptDigitsInvalid", err)
+    }
+    if repo.numbering != nil { t.Fatalf("esquema inválido não deveria ser gravado") }
+
+    prefix := "RB-"
+    out, err = svc.Update(context.Background(), owner, &models.ReceiptNumbering{Prefixo: &prefix, Digitos: 4, Reinicio: models.ReceiptNumberingYearly})
+    if err != nil { t.Fatalf("Update err: %v", err) }
+    if out.Proximo != "RB-0001/2026" { t.Fatalf("próximo no ano corrente = %q", out.Proximo) }
+
+    svc.now = func() time.Time { return time.Date(2027, 6, 1, 12, 0, 0, 0, time.UTC) }
+    if out, _ = svc.Get(context.Background(), owner); out.Proximo != "RB-0004/2027" { t.Fatalf("próximo em 2027 = %q", out.Proximo) }
+}
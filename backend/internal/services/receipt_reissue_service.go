@@ -0,0 +1,76 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Segunda via de recibos com registro na trilha de auditoria
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// ReceiptReissueService reemite o PDF do recibo como segunda via.
+// Docstring: o recibo não é alterado (número, data de emissão e valores continuam os do original);
+// cada via recebe o próximo número de via e fica registrada em rf_receipt_reissues com quem a
+// pediu e o motivo antes de o PDF ser gerado.
+type ReceiptReissueService struct {
+	repo     repositories.ReceiptRepository
+	branding ReceiptBranding
+	log      logging.Logger
+}
+
+// NewReceiptReissueService cria o serviço de segunda via; branding (opcional) aplica o modelo de
+// recibo padrão do emissor ao PDF
+func NewReceiptReissueService(repo repositories.ReceiptRepository, branding ReceiptBranding, log logging.Logger) *ReceiptReissueService {
+	return &ReceiptReissueService{repo: repo, branding: branding, log: log}
+}
+
+// ReceiptReissueResult via registrada com o recibo e o PDF gerado
+type ReceiptReissueResult struct {
+	Receipt *models.Receipt
+	Reissue *models.ReceiptReissue
+	PDF     []byte
+}
+
+// Reissue registra a próxima via do recibo pedida por actorID e gera o PDF marcado
+func (s *ReceiptReissueService) Reissue(ctx context.Context, id, ownerID, actorID uuid.UUID, req *models.ReceiptReissueRequest, ls locale.Settings) (*ReceiptReissueResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	rec, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	rr := &models.ReceiptReissue{OwnerID: ownerID, ReceiptID: id, ActorID: actorID, Motivo: req.Motivo}
+	if err := s.repo.CreateReissue(ctx, rr); err != nil {
+		if repositories.IsReceiptNotFound(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao registrar segunda via: %w", err)
+	}
+	s.log.Info("segunda via de recibo emitida",
+		logging.Field{Key: "receipt_id", Val: id.String()},
+		logging.Field{Key: "via", Val: rr.Via},
+		logging.Field{Key: "actor_id", Val: actorID.String()})
+	pdf := RenderReceiptReissuePDF(rec, ls, defaultReceiptTemplate(ctx, s.branding, ownerID), rr)
+	return &ReceiptReissueResult{Receipt: rec, Reissue: rr, PDF: pdf}, nil
+}
+
+// List vias reemitidas do recibo (trilha de auditoria)
+func (s *ReceiptReissueService) List(ctx context.Context, id, ownerID uuid.UUID) ([]models.ReceiptReissue, error) {
+	if _, err := s.repo.GetByID(ctx, id, ownerID); err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListReissues(ctx, id, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar segundas vias: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,73 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da segunda via de recibos (número e data do original, marca da via e auditoria)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+type fakeReissueReceiptRepo struct {
+    repositories.ReceiptRepository
+    rec      *models.Receipt
+    reissues []models.ReceiptReissue
+}
+
+func (f *fakeReissueReceiptRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Receipt, error) {
+    return f.rec, nil
+}
+func (f *fakeReissueReceiptRepo) CreateReissue(ctx context.Context, rr *models.ReceiptReissue) error {
+    rr.ID, rr.Via, rr.CreatedAt = uuid.New(), len(f.reissues)+2, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    f.reissues = append(f.reissues, *rr)
+    return nil
+}
+
+func TestReceiptReissueService_KeepsOriginalAndRecordsAudit(t *testing.T) {
+    rec := newMailTestReceipt()
+    emitido := time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC)
+    rec.EmitidoEm = &emitido
+    repo := &fakeReissueReceiptRepo{rec: rec}
+    svc := NewReceiptReissueService(repo, nil, logging.NewLogger("dev"))
+    actor := uuid.New()
+    motivo := "  pagador perdeu o original  "
+
+    out, err := svc.Reissue(context.Background(), rec.ID, rec.OwnerID, actor, &models.ReceiptReissueRequest{Motivo: &motivo}, locale.Default())
+    if err != nil { t.Fatalf("Reissue: %v", err) }
+    if out.Reissue.Via != 2 || out.Reissue.ActorID != actor || *out.Reissue.Motivo != "pagador perdeu o original" {
+        t.Fatalf("auditoria = %+v", out.Reissue)
+    }
+    if !bytes.HasPrefix(out.PDF, []byte("%PDF-")) { t.Fatalf("esperava um PDF") }
+    body := string(out.PDF)
+    for _, want := range []string{"Recibo n\\272 42", "Emitido em 05/03/2026", "2\\252 VIA emitida em 18/10/2026"} {
+        if !strings.Contains(body, want) { t.Fatalf("PDF sem %q", want) }
+    }
+    if rec.Numero != 42 || !rec.EmitidoEm.Equal(emitido) { t.Fatalf("recibo original alterado: %+v", rec) }
+
+    // A via seguinte recebe o próximo número
+    out, err = svc.Reissue(context.Background(), rec.ID, rec.OwnerID, actor, &models.ReceiptReissueRequest{}, locale.Default())
+    if err != nil || out.Reissue.Via != 3 || !strings.Contains(string(out.PDF), "3\\252 VIA") {
+        t.Fatalf("terceira via: err=%v via=%v", err, out)
+    }
+}
+
+func TestReceiptReissueService_RejectsLongReason(t *testing.T) {
+    repo := &fakeReissueReceiptRepo{rec: newMailTestReceipt()}
+    svc := NewReceiptReissueService(repo, nil, logging.NewLogger("dev"))
+    motivo := strings.Repeat("a", models.MaxReissueReasonLen+1)
+    _, err := svc.Reissue(context.Background(), repo.rec.ID, repo.rec.OwnerID, uuid.New(), &models.ReceiptReissueRequest{Motivo: &motivo}, locale.Default())
+    if !errors.Is(err, models.ErrReissueReasonTooLong) { t.Fatalf("err = %v, esperado ErrReissueReasonTooLong", err) }
+    if len(repo.reissues) != 0 { t.Fatalf("via registrada com motivo inválido") }
+}
@@ -0,0 +1,77 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Emissão e consulta de recibos (usado pelas rotas /receipts e pelo gRPC)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// ReceiptService emite e consulta os recibos do usuário.
+// Docstring: erros do repositório voltam sem embrulho para que REST e gRPC mapeiem os mesmos
+// casos (receita ou pagador inexistente, número repetido ou fora da sequência).
+type ReceiptService struct {
+	repo repositories.ReceiptRepository
+}
+
+// NewReceiptService cria o serviço de recibos
+func NewReceiptService(repo repositories.ReceiptRepository) *ReceiptService {
+	return &ReceiptService{repo: repo}
+}
+
+// CreateReceipt valida o pedido e emite o recibo (o banco congela o snapshot e numera a série)
+func (s *ReceiptService) CreateReceipt(ctx context.Context, ownerID uuid.UUID, req *models.ReceiptRequest) (*models.Receipt, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	m := &models.Receipt{
+		OwnerID:        ownerID,
+		IncomeID:       req.IncomeID,
+		PayerID:        req.PayerID,
+		PDFURL:         req.PDFURL,
+		Hash:           req.Hash,
+		SignatureID:    req.SignatureID,
+		IssuerName:     req.IssuerName,
+		IssuerDocument: req.IssuerDocument,
+	}
+	if req.Numero != nil {
+		m.Numero = *req.Numero
+	}
+	if req.Taxas != nil {
+		m.Taxas = *req.Taxas
+	}
+	if req.Descontos != nil {
+		m.Descontos = *req.Descontos
+	}
+	if err := s.repo.Create(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GetReceipt busca um recibo do usuário (repositories.IsReceiptNotFound quando não existe)
+func (s *ReceiptService) GetReceipt(ctx context.Context, id, ownerID uuid.UUID) (*models.Receipt, error) {
+	return s.repo.GetByID(ctx, id, ownerID)
+}
+
+// ListReceipts lista os recibos com os filtros e a paginação já normalizados (SetDefaults)
+func (s *ReceiptService) ListReceipts(ctx context.Context, ownerID uuid.UUID, filter *models.ReceiptFilter) (*models.ReceiptListResponse, error) {
+	filter.SetDefaults()
+	items, total, err := s.repo.List(ctx, ownerID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReceiptListResponse{
+		Items:      items,
+		Total:      total,
+		Page:       filter.Page,
+		Limit:      filter.PerPage,
+		TotalPages: (total + filter.PerPage - 1) / filter.PerPage,
+	}, nil
+}
@@ -0,0 +1,39 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da emissão de recibos (validação e ajustes de valor)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "testing"
+
+    "github.com/google/uuid"
+    "recibofast/internal/models"
+)
+
+func TestReceiptService_CreateReceipt(t *testing.T) {
+    repo := &fakeBatchReceiptRepo{byID: map[uuid.UUID]*models.Receipt{}}
+    svc := NewReceiptService(repo)
+    owner, incomeID := uuid.New(), uuid.New()
+
+    zero := int64(0)
+    if _, err := svc.CreateReceipt(context.Background(), owner, &models.ReceiptRequest{Numero: &zero}); err != models.ErrReceiptNumberInvalid {
+        t.Fatalf("err = %v, want ErrReceiptNumberInvalid", err)
+    }
+    neg := models.NewMoney(-1)
+    if _, err := svc.CreateReceipt(context.Background(), owner, &models.ReceiptRequest{Taxas: &neg}); err != models.ErrInvalidReceiptAdjustment {
+        t.Fatalf("err = %v, want ErrInvalidReceiptAdjustment", err)
+    }
+    if repo.created != 0 { t.Fatalf("pedido inválido não deveria chegar ao repositório") }
+
+    taxas, descontos := models.NewMoney(5), models.NewMoney(2)
+    m, err := svc.CreateReceipt(context.Background(), owner, &models.ReceiptRequest{IncomeID: &incomeID, Taxas: &taxas, Descontos: &descontos})
+    if err != nil { t.Fatalf("CreateReceipt: %v", err) }
+    if m.OwnerID != owner || m.IncomeID == nil || *m.IncomeID != incomeID || m.Taxas != taxas || m.Descontos != descontos {
+        t.Fatalf("recibo emitido = %+v", m)
+    }
+    got, err := svc.GetReceipt(context.Background(), m.ID, owner)
+    if err != nil || got.ID != m.ID { t.Fatalf("GetReceipt = %+v, %v", got, err) }
+}
@@ -0,0 +1,133 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Compartilhamento do recibo por link público de curta duração e mensagem para o WhatsApp
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// ReceiptSharePath rota pública (sob /api/v1) que entrega o PDF do recibo compartilhado
+const ReceiptSharePath = "/api/v1/public/receipts/shared/"
+
+// ReceiptShareService gera links públicos do recibo (válidos por models.ReceiptShareTTL) e a
+// mensagem pronta para o WhatsApp, canal em que a maioria dos usuários entrega os recibos.
+type ReceiptShareService struct {
+	repo     repositories.ReceiptRepository
+	branding ReceiptBranding
+	log      logging.Logger
+	now      func() time.Time
+}
+
+// NewReceiptShareService cria o serviço de compartilhamento de recibos; branding (opcional) aplica
+// o modelo de recibo padrão do emissor ao PDF
+func NewReceiptShareService(repo repositories.ReceiptRepository, branding ReceiptBranding, log logging.Logger) *ReceiptShareService {
+	return &ReceiptShareService{repo: repo, branding: branding, log: log, now: time.Now}
+}
+
+// Share cria um novo link público do recibo sob baseURL (ex.: https://api.recibofast.com.br)
+func (s *ReceiptShareService) Share(ctx context.Context, id, ownerID uuid.UUID, baseURL string, ls locale.Settings) (*models.ReceiptShareResponse, error) {
+	rec, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	phone, err := s.repo.GetPayerPhone(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	share := &models.ReceiptShare{ReceiptID: id, OwnerID: ownerID, ExpiresAt: s.now().UTC().Add(models.ReceiptShareTTL)}
+	if err := s.repo.CreateShare(ctx, share); err != nil {
+		return nil, err
+	}
+
+	link := strings.TrimRight(baseURL, "/") + ReceiptSharePath + share.Token.String()
+	msg := receiptShareMessage(rec, link, share.ExpiresAt, ls)
+	wa := "https://wa.me/"
+	if n := whatsAppNumber(derefString(phone)); n != "" {
+		wa += n
+	}
+	return &models.ReceiptShareResponse{
+		URL:         link,
+		ExpiresAt:   share.ExpiresAt,
+		Message:     msg,
+		WhatsAppURL: wa + "?text=" + url.QueryEscape(msg),
+	}, nil
+}
+
+// Open recibo do link público; token vencido ou inexistente responde não encontrado
+func (s *ReceiptShareService) Open(ctx context.Context, token uuid.UUID) (*models.Receipt, error) {
+	return s.repo.GetShared(ctx, token, s.now().UTC())
+}
+
+// RenderPDF PDF do recibo compartilhado com a identidade visual do emissor
+func (s *ReceiptShareService) RenderPDF(ctx context.Context, rec *models.Receipt, ls locale.Settings) []byte {
+	return RenderReceiptPDF(rec, ls, defaultReceiptTemplate(ctx, s.branding, rec.OwnerID))
+}
+
+// DeleteExpired apaga os links vencidos (tarefa do job de ciclo de vida)
+func (s *ReceiptShareService) DeleteExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpiredShares(ctx, s.now().UTC())
+}
+
+// receiptShareMessage texto da mensagem do WhatsApp (negrito com *...*, como o app interpreta)
+func receiptShareMessage(rec *models.Receipt, link string, expires time.Time, ls locale.Settings) string {
+	var b strings.Builder
+	if nome := strings.TrimSpace(derefString(rec.PayerNome)); nome != "" {
+		fmt.Fprintf(&b, "Olá, %s!\n\n", nome)
+	} else {
+		b.WriteString("Olá!\n\n")
+	}
+	fmt.Fprintf(&b, "Segue o *recibo nº %s*", rec.NumeroExibido())
+	if v := receiptAmount(rec); v != nil {
+		fmt.Fprintf(&b, " no valor de *%s*", ls.FormatAmount(*v))
+	}
+	if comp := derefString(rec.Competencia); comp != "" {
+		fmt.Fprintf(&b, ", referente à competência %s", ls.FormatCompetencia(comp))
+	}
+	b.WriteString(".\n\n")
+	fmt.Fprintf(&b, "Baixe o PDF: %s\n(link válido até %s)", link, ls.FormatDateTime(expires))
+	if issuer := strings.TrimSpace(derefString(rec.IssuerName)); issuer != "" {
+		fmt.Fprintf(&b, "\n\n%s", issuer)
+	}
+	return b.String()
+}
+
+// whatsAppNumber número no formato do wa.me (DDI + DDD + número, só dígitos). Com "+" ou "00"
+// o número já traz o DDI; sem eles é nacional e recebe o 55 do Brasil (se ainda não o tiver). Números fora do padrão
+// retornam "" (o usuário escolhe o contato no WhatsApp).
+func whatsAppNumber(phone string) string {
+	phone = strings.TrimSpace(phone)
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	n := b.String()
+	if strings.HasPrefix(phone, "+") || strings.HasPrefix(phone, "00") {
+		n = strings.TrimPrefix(n, "00")
+		if len(n) >= 10 && len(n) <= 15 {
+			return n
+		}
+		return ""
+	}
+	switch {
+	case len(n) == 10 || len(n) == 11:
+		return "55" + n
+	case (len(n) == 12 || len(n) == 13) && strings.HasPrefix(n, "55"):
+		return n
+	}
+	return ""
+}
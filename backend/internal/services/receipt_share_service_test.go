@@ -0,0 +1,83 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do compartilhamento de recibos (link público e mensagem do WhatsApp)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "net/url"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+type fakeShareReceiptRepo struct {
+    repositories.ReceiptRepository
+    rec    *models.Receipt
+    phone  *string
+    shares map[uuid.UUID]models.ReceiptShare
+}
+
+func (f *fakeShareReceiptRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Receipt, error) {
+    return f.rec, nil
+}
+func (f *fakeShareReceiptRepo) GetPayerPhone(ctx context.Context, id, ownerID uuid.UUID) (*string, error) {
+    return f.phone, nil
+}
+func (f *fakeShareReceiptRepo) CreateShare(ctx context.Context, s *models.ReceiptShare) error {
+    s.Token = uuid.New()
+    f.shares[s.Token] = *s
+    return nil
+}
+func (f *fakeShareReceiptRepo) GetShared(ctx context.Context, token uuid.UUID, now time.Time) (*models.Receipt, error) {
+    if s, ok := f.shares[token]; ok && s.ExpiresAt.After(now) { return f.rec, nil }
+    return nil, models.ErrIncomeNotFound
+}
+
+func TestReceiptShareService_LinkAndWhatsAppMessage(t *testing.T) {
+    phone := "(11) 98765-4321"
+    repo := &fakeShareReceiptRepo{rec: newMailTestReceipt(), phone: &phone, shares: map[uuid.UUID]models.ReceiptShare{}}
+    svc := NewReceiptShareService(repo, nil, logging.NewLogger("dev"))
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    svc.now = func() time.Time { return now }
+
+    out, err := svc.Share(context.Background(), repo.rec.ID, repo.rec.OwnerID, "https://api.exemplo.com/", locale.Default())
+    if err != nil { t.Fatalf("Share: %v", err) }
+    if !strings.HasPrefix(out.URL, "https://api.exemplo.com"+ReceiptSharePath) || !out.ExpiresAt.Equal(now.Add(models.ReceiptShareTTL)) {
+        t.Fatalf("link = %s até %s", out.URL, out.ExpiresAt)
+    }
+    if !strings.Contains(out.Message, "Olá, Maria!") || !strings.Contains(out.Message, "*recibo nº 42*") || !strings.Contains(out.Message, out.URL) {
+        t.Fatalf("mensagem inesperada:\n%s", out.Message)
+    }
+    u, err := url.Parse(out.WhatsAppURL)
+    if err != nil || u.Path != "/5511987654321" || u.Query().Get("text") != out.Message {
+        t.Fatalf("whatsapp_url = %s", out.WhatsAppURL)
+    }
+
+    token := uuid.MustParse(out.URL[strings.LastIndex(out.URL, "/")+1:])
+    if _, err := svc.Open(context.Background(), token); err != nil { t.Fatalf("Open: %v", err) }
+    svc.now = func() time.Time { return now.Add(models.ReceiptShareTTL) }
+    if _, err := svc.Open(context.Background(), token); err == nil { t.Fatal("link vencido não deveria abrir") }
+}
+
+func TestWhatsAppNumber(t *testing.T) {
+    cases := map[string]string{
+        "(11) 98765-4321":   "5511987654321",
+        "+55 21 3333-4444":  "552133334444",
+        "0055 11 987654321": "5511987654321",
+        "9876-5432":         "",
+        "+1 415 555 0100":   "14155550100",
+        "55 11 98765-4321":  "5511987654321",
+    }
+    for in, want := range cases {
+        if got := whatsAppNumber(in); got != want { t.Errorf("whatsAppNumber(%q) = %q, want %q", in, got, want) }
+    }
+}
@@ -0,0 +1,156 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Serviço dos modelos de recibo (cadastro, logotipo, prévia e modelo padrão dos PDFs)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// MaxTemplateLogoPixels limita largura e altura do logotipo (é desenhado com no máximo 150x44 pt)
+const MaxTemplateLogoPixels = 2000
+
+// ReceiptTemplateService cadastra os modelos de recibo e fornece o padrão do usuário aos PDFs
+type ReceiptTemplateService struct {
+	repo repositories.ReceiptTemplateRepository
+	log  logging.Logger
+	now  func() time.Time
+}
+
+// NewReceiptTemplateService cria o serviço de modelos de recibo
+func NewReceiptTemplateService(repo repositories.ReceiptTemplateRepository, log logging.Logger) *ReceiptTemplateService {
+	return &ReceiptTemplateService{repo: repo, log: log, now: time.Now}
+}
+
+// Create valida e cadastra um modelo
+func (s *ReceiptTemplateService) Create(ctx context.Context, ownerID uuid.UUID, req *models.ReceiptTemplateRequest) (*models.ReceiptTemplate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	t := &models.ReceiptTemplate{OwnerID: ownerID}
+	applyReceiptTemplateRequest(t, req)
+	if err := s.repo.Create(ctx, t); err != nil {
+		return nil, fmt.Errorf("erro ao criar modelo de recibo: %w", err)
+	}
+	return t, nil
+}
+
+// Get busca um modelo do usuário
+func (s *ReceiptTemplateService) Get(ctx context.Context, id, ownerID uuid.UUID) (*models.ReceiptTemplate, error) {
+	return s.repo.GetByID(ctx, id, ownerID)
+}
+
+// List lista os modelos do usuário (o padrão primeiro)
+func (s *ReceiptTemplateService) List(ctx context.Context, ownerID uuid.UUID) ([]models.ReceiptTemplate, error) {
+	return s.repo.List(ctx, ownerID)
+}
+
+// Update substitui os dados de um modelo (o logotipo é mantido)
+func (s *ReceiptTemplateService) Update(ctx context.Context, id, ownerID uuid.UUID, req *models.ReceiptTemplateRequest) (*models.ReceiptTemplate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	t := &models.ReceiptTemplate{ID: id, OwnerID: ownerID}
+	applyReceiptTemplateRequest(t, req)
+	if err := s.repo.Update(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Delete remove um modelo
+func (s *ReceiptTemplateService) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	return s.repo.Delete(ctx, id, ownerID)
+}
+
+// SetLogo valida e grava o logotipo (PNG ou JPEG) do modelo
+func (s *ReceiptTemplateService) SetLogo(ctx context.Context, id, ownerID uuid.UUID, logo []byte) error {
+	if err := validateTemplateLogo(logo); err != nil {
+		return err
+	}
+	return s.repo.SetLogo(ctx, id, ownerID, logo)
+}
+
+// RemoveLogo remove o logotipo do modelo
+func (s *ReceiptTemplateService) RemoveLogo(ctx context.Context, id, ownerID uuid.UUID) error {
+	return s.repo.SetLogo(ctx, id, ownerID, nil)
+}
+
+// DefaultTemplate modelo padrão do usuário para os PDFs (nil sem padrão). Falha ao ler o modelo
+// só é registrada: o recibo sai sem a marca, mas sai.
+func (s *ReceiptTemplateService) DefaultTemplate(ctx context.Context, ownerID uuid.UUID) *models.ReceiptTemplate {
+	t, err := s.repo.GetDefault(ctx, ownerID)
+	if err != nil {
+		if !errors.Is(err, models.ErrReceiptTemplateNotFound) {
+			s.log.Warn("erro ao carregar modelo de recibo padrão", logging.Field{Key: "error", Val: err.Error()})
+		}
+		return nil
+	}
+	return t
+}
+
+// Preview PDF de um recibo de exemplo com o modelo, para conferir a identidade visual
+func (s *ReceiptTemplateService) Preview(ctx context.Context, id, ownerID uuid.UUID, ls locale.Settings) ([]byte, error) {
+	t, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	valor := models.NewMoney(1500)
+	nome, doc := "Pagador de Exemplo", "529.982.247-25"
+	categoria := "Serviços prestados"
+	comp := now.Format("2006-01")
+	sample := &models.Receipt{
+		ID:             uuid.Nil,
+		OwnerID:        ownerID,
+		Numero:         1,
+		EmitidoEm:      &now,
+		Valor:          &valor,
+		ValorLiquido:   &valor,
+		PayerNome:      &nome,
+		PayerDocumento: &doc,
+		Categoria:      &categoria,
+		Competencia:    &comp,
+	}
+	return RenderReceiptPDF(sample, ls, t), nil
+}
+
+// validateTemplateLogo aceita PNG ou JPEG dentro dos limites de tamanho e dimensões
+func validateTemplateLogo(logo []byte) error {
+	if len(logo) == 0 || len(logo) > models.MaxTemplateLogoSize {
+		return models.ErrInvalidTemplateLogo
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(logo))
+	if err != nil || (format != "png" && format != "jpeg") {
+		return models.ErrInvalidTemplateLogo
+	}
+	if cfg.Width < 1 || cfg.Height < 1 || cfg.Width > MaxTemplateLogoPixels || cfg.Height > MaxTemplateLogoPixels {
+		return models.ErrInvalidTemplateLogo
+	}
+	return nil
+}
+
+func applyReceiptTemplateRequest(t *models.ReceiptTemplate, req *models.ReceiptTemplateRequest) {
+	t.Nome = req.Nome
+	t.PrimaryColor = req.PrimaryColor
+	t.AccentColor = req.AccentColor
+	t.HeaderText = req.HeaderText
+	t.FooterText = req.FooterText
+	t.IssuerName = req.IssuerName
+	t.IssuerDocument = req.IssuerDocument
+	t.IssuerAddress = req.IssuerAddress
+	t.IsDefault = req.IsDefault
+}
@@ -0,0 +1,128 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do serviço de modelos de recibo (logotipo, modelo padrão e PDFs com a marca)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "image"
+    "image/color"
+    "image/png"
+    "testing"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+// fakeReceiptTemplateRepo guarda um único modelo em memória
+type fakeReceiptTemplateRepo struct {
+    repositories.ReceiptTemplateRepository
+    tpl        *models.ReceiptTemplate
+    defaultErr error
+}
+
+func (f *fakeReceiptTemplateRepo) GetByID(_ context.Context, id, ownerID uuid.UUID) (*models.ReceiptTemplate, error) {
+    if f.tpl == nil || f.tpl.ID != id || f.tpl.OwnerID != ownerID { return nil, models.ErrReceiptTemplateNotFound }
+    return f.tpl, nil
+}
+
+func (f *fakeReceiptTemplateRepo) GetDefault(_ context.Context, ownerID uuid.UUID) (*models.ReceiptTemplate, error) {
+    if f.defaultErr != nil { return nil, f.defaultErr }
+    if f.tpl == nil || !f.tpl.IsDefault || f.tpl.OwnerID != ownerID { return nil, models.ErrReceiptTemplateNotFound }
+    return f.tpl, nil
+}
+
+func (f *fakeReceiptTemplateRepo) SetLogo(_ context.Context, id, ownerID uuid.UUID, logo []byte) error {
+    if f.tpl == nil || f.tpl.ID != id || f.tpl.OwnerID != ownerID { return models.ErrReceiptTemplateNotFound }
+    f.tpl.Logo, f.tpl.HasLogo = logo, logo != nil
+    return nil
+}
+
+func makeLogoPNG(t *testing.T, w, h int) []byte {
+    t.Helper()
+    img := image.NewNRGBA(image.Rect(0, 0, w, h))
+    img.SetNRGBA(0, 0, color.NRGBA{R: 0x1e, G: 0x40, B: 0xaf, A: 0xff})
+    var buf bytes.Buffer
+    if err := png.Encode(&buf, img); err != nil { t.Fatalf("png: %v", err) }
+    return buf.Bytes()
+}
+
+func brandedTemplate(owner uuid.UUID) *models.ReceiptTemplate {
+    str := func(s string) *string { return &s }
+    return &models.ReceiptTemplate{ID: uuid.New(), OwnerID: owner, Nome: "Consultório", IsDefault: true,
+        PrimaryColor: str("#1e40af"), AccentColor: str("#fde68a"), HeaderText: str("Clínica Exemplo - CRP 06/12345"),
+        FooterText: str("Rua das Flores, 100 - São Paulo"), IssuerName: str("Dra. Ana Souza"), IssuerDocument: str("52998224725"),
+        IssuerAddress: str("Rua das Flores, 100")}
+}
+
+func TestReceiptTemplateService_SetLogoValidates(t *testing.T) {
+    owner := uuid.New()
+    repo := &fakeReceiptTemplateRepo{tpl: brandedTemplate(owner)}
+    svc := NewReceiptTemplateService(repo, logging.NewLogger("dev"))
+    ctx := context.Background()
+
+    for name, logo := range map[string][]byte{
+        "vazio":      nil,
+        "não imagem": []byte("%PDF-1.4"),
+        "grande":     makeLogoPNG(t, MaxTemplateLogoPixels+1, 1),
+    } {
+        if err := svc.SetLogo(ctx, repo.tpl.ID, owner, logo); !errors.Is(err, models.ErrInvalidTemplateLogo) { t.Fatalf("%s: err = %v", name, err) }
+    }
+    if err := svc.SetLogo(ctx, repo.tpl.ID, owner, makeLogoPNG(t, 40, 20)); err != nil { t.Fatalf("logo válido: %v", err) }
+    if !repo.tpl.HasLogo { t.Fatalf("logo não gravado") }
+    if err := svc.SetLogo(ctx, uuid.New(), owner, makeLogoPNG(t, 40, 20)); !errors.Is(err, models.ErrReceiptTemplateNotFound) { t.Fatalf("modelo inexistente: err = %v", err) }
+    if err := svc.RemoveLogo(ctx, repo.tpl.ID, owner); err != nil || repo.tpl.HasLogo { t.Fatalf("logo não removido: %v", err) }
+}
+
+func TestReceiptTemplateService_DefaultTemplateToleratesFailures(t *testing.T) {
+    owner := uuid.New()
+    svc := NewReceiptTemplateService(&fakeReceiptTemplateRepo{}, logging.NewLogger("dev"))
+    if tpl := svc.DefaultTemplate(context.Background(), owner); tpl != nil { t.Fatalf("sem modelo padrão: %+v", tpl) }
+    svc = NewReceiptTemplateService(&fakeReceiptTemplateRepo{defaultErr: errors.New("db down")}, logging.NewLogger("dev"))
+    if tpl := svc.DefaultTemplate(context.Background(), owner); tpl != nil { t.Fatalf("falha ao ler deveria sair sem marca") }
+}
+
+func TestRenderReceiptPDF_AppliesBranding(t *testing.T) {
+    owner := uuid.New()
+    tpl := brandedTemplate(owner)
+    tpl.Logo = makeLogoPNG(t, 40, 20)
+    rec := &models.Receipt{ID: uuid.New(), OwnerID: owner, Numero: 7}
+
+    plain := RenderReceiptPDF(rec, locale.Default(), nil)
+    if bytes.Contains(plain, []byte("/Subtype /Image")) || bytes.Contains(plain, []byte(" rg ")) { t.Fatalf("PDF sem modelo não deveria ter marca") }
+    if !bytes.Contains(plain, []byte("(Assinatura do emissor)")) { t.Fatalf("sem emissor e sem modelo: linha de assinatura genérica") }
+
+    out := RenderReceiptPDF(rec, locale.Default(), tpl)
+    for _, want := range []string{"/Subtype /Image", "/Im0 Do", "0.12 0.25 0.69 rg", "0.99 0.9 0.54 rg",
+        "(Cl\\355nica Exemplo - CRP 06/12345)", "(Rua das Flores, 100 - S\\343o Paulo)", "(Dra. Ana Souza)", "(CPF/CNPJ: 52998224725)"} {
+        if !bytes.Contains(out, []byte(want)) { t.Fatalf("PDF com modelo sem %q", want) }
+    }
+
+    // Emissor congelado no recibo prevalece sobre o emissor padrão do modelo
+    issuer := "João Emissor"
+    rec.IssuerName = &issuer
+    out = RenderReceiptPDF(rec, locale.Default(), tpl)
+    if !bytes.Contains(out, []byte("(Jo\\343o Emissor)")) || bytes.Contains(out, []byte("(Dra. Ana Souza)")) { t.Fatalf("emissor do recibo deveria prevalecer") }
+}
+
+func TestReceiptBookService_UsesDefaultTemplate(t *testing.T) {
+    owner := uuid.New()
+    c := &models.Contract{ID: uuid.New(), OwnerID: owner, ValorMensal: models.NewMoney(100)}
+    tpl := brandedTemplate(owner)
+    tpl.Logo = makeLogoPNG(t, 40, 20)
+    branding := NewReceiptTemplateService(&fakeReceiptTemplateRepo{tpl: tpl}, logging.NewLogger("dev"))
+    svc := NewReceiptBookService(&fakeContractRepo{contract: c}, nil, branding, logging.NewLogger("dev"))
+
+    book, err := svc.Build(context.Background(), c.ID, owner, 2026)
+    if err != nil { t.Fatalf("Build err: %v", err) }
+    out := svc.Render(book, locale.Default())
+    if n := bytes.Count(out, []byte("/Width 40 /Height 20 /ColorSpace /DeviceRGB")); n != 1 { t.Fatalf("logo deveria ser incluído uma vez, got %d", n) }
+    if n := bytes.Count(out, []byte("/Im0 Do")); n != 12 { t.Fatalf("logo deveria estar nas 12 folhas, got %d", n) }
+}
@@ -0,0 +1,244 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Conciliação bancária: importação de extratos, sugestões de vínculo com receitas e confirmação
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// ReconciliationService importa extratos e sugere quais créditos correspondem a receitas em aberto.
+// Docstring: só os créditos do extrato são guardados. Confirmar uma sugestão lança o pagamento
+// na receita com o valor e a data do extrato (o excedente vira crédito, quando habilitado).
+type ReconciliationService struct {
+	repo     repositories.ReconciliationRepository
+	payments PaymentRecorder
+	log      logging.Logger
+}
+
+// NewReconciliationService cria o serviço de conciliação bancária
+func NewReconciliationService(repo repositories.ReconciliationRepository, payments PaymentRecorder, log logging.Logger) *ReconciliationService {
+	return &ReconciliationService{repo: repo, payments: payments, log: log}
+}
+
+// Import lê o extrato (OFX ou CSV), grava os créditos novos e gera as sugestões de conciliação
+func (s *ReconciliationService) Import(ctx context.Context, ownerID uuid.UUID, r io.Reader, filename, format string) (*models.ReconciliationImportResult, error) {
+	parsed, err := parseStatement(r, filename, format)
+	if err != nil {
+		return nil, err
+	}
+	res := &models.ReconciliationImportResult{TotalLines: len(parsed.entries), Suggestions: []models.ReconciliationMatch{}}
+	var lines []models.BankStatementLine
+	for _, e := range parsed.entries {
+		if e.valor <= 0 {
+			res.IgnoredDebits++
+			continue
+		}
+		lines = append(lines, models.BankStatementLine{FitID: e.fitID, PostedAt: e.postedAt, Valor: e.valor, Descricao: e.desc})
+	}
+	if len(lines) == 0 {
+		return nil, models.ErrStatementEmpty
+	}
+
+	st := &models.BankStatement{OwnerID: ownerID, Format: parsed.format}
+	if name := strings.TrimSpace(filename); name != "" {
+		st.Filename = &name
+	}
+	if parsed.account != "" {
+		st.Account = &parsed.account
+	}
+	inserted, err := s.repo.CreateStatement(ctx, st, lines)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao importar extrato: %w", err)
+	}
+	res.Statement, res.Imported, res.Duplicates = *st, len(inserted), len(lines)-len(inserted)
+	if len(inserted) == 0 {
+		return res, nil
+	}
+
+	candidates, err := s.repo.ListCandidates(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar receitas em aberto: %w", err)
+	}
+	byIncome := make(map[uuid.UUID]*models.ReconciliationCandidate, len(candidates))
+	for i := range candidates {
+		byIncome[candidates[i].IncomeID] = &candidates[i]
+	}
+	var matches []models.ReconciliationMatch
+	for i := range inserted {
+		matches = append(matches, suggestMatches(&inserted[i], candidates)...)
+	}
+	if err := s.repo.CreateMatches(ctx, matches); err != nil {
+		return nil, err
+	}
+	lineByID := make(map[uuid.UUID]*models.BankStatementLine, len(inserted))
+	for i := range inserted {
+		lineByID[inserted[i].ID] = &inserted[i]
+	}
+	for _, m := range matches {
+		if m.ID == uuid.Nil {
+			continue
+		}
+		c := byIncome[m.IncomeID]
+		m.Line = lineByID[m.LineID]
+		m.Income = &models.Income{ID: c.IncomeID, OwnerID: ownerID, Competencia: c.Competencia, Categoria: c.Categoria,
+			Valor: c.Valor, TotalPago: c.TotalPago, DueDate: c.DueDate}
+		res.Suggestions = append(res.Suggestions, m)
+	}
+	s.log.Info("extrato importado",
+		logging.Field{Key: "statement_id", Val: st.ID.String()},
+		logging.Field{Key: "imported", Val: res.Imported},
+		logging.Field{Key: "suggestions", Val: len(res.Suggestions)})
+	return res, nil
+}
+
+// ListMatches sugestões no status informado (padrão: suggested)
+func (s *ReconciliationService) ListMatches(ctx context.Context, ownerID uuid.UUID, status string) ([]models.ReconciliationMatch, error) {
+	if status == "" {
+		status = models.MatchSuggested
+	}
+	if !models.ValidMatchStatus(status) {
+		return nil, models.ErrInvalidMatchStatus
+	}
+	items, err := s.repo.ListMatches(ctx, ownerID, status)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar sugestões de conciliação: %w", err)
+	}
+	if items == nil {
+		items = []models.ReconciliationMatch{}
+	}
+	return items, nil
+}
+
+// Confirm confirma a sugestão e lança o pagamento na receita
+func (s *ReconciliationService) Confirm(ctx context.Context, id, ownerID uuid.UUID) (*models.ReconciliationMatch, *models.PaymentResponse, error) {
+	m, err := s.repo.ClaimMatch(ctx, id, ownerID)
+	if err != nil {
+		if errors.Is(err, models.ErrMatchNotFound) || errors.Is(err, models.ErrMatchDecided) || errors.Is(err, models.ErrStatementLineDone) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("erro ao confirmar conciliação: %w", err)
+	}
+	pagoEm := m.Line.PostedAt.Format(time.RFC3339)
+	obs := "Conciliação bancária"
+	if m.Line.Descricao != "" {
+		obs += ": " + m.Line.Descricao
+	}
+	out, err := s.payments.AddPayment(ctx, ownerID, &models.PaymentRequest{IncomeID: m.IncomeID, Valor: m.Line.Valor, PagoEm: &pagoEm,
+		Obs: &obs, Overpayment: models.OverpaymentCredit})
+	if err != nil {
+		if rerr := s.repo.ReleaseMatch(context.WithoutCancel(ctx), m.ID); rerr != nil {
+			s.log.Error("erro ao reabrir sugestão de conciliação", logging.Field{Key: "match_id", Val: m.ID.String()}, logging.Field{Key: "error", Val: rerr.Error()})
+		}
+		return nil, nil, err
+	}
+	if err := s.repo.SetMatchPayment(context.WithoutCancel(ctx), m, out.Payment.ID); err != nil {
+		return nil, nil, fmt.Errorf("erro ao registrar pagamento da conciliação: %w", err)
+	}
+	m.PaymentID, m.Line.PaymentID = &out.Payment.ID, &out.Payment.ID
+	m.Income = &out.Income
+	return m, out, nil
+}
+
+// Reject descarta a sugestão
+func (s *ReconciliationService) Reject(ctx context.Context, id, ownerID uuid.UUID) (*models.ReconciliationMatch, error) {
+	m, err := s.repo.RejectMatch(ctx, id, ownerID)
+	if err != nil {
+		if errors.Is(err, models.ErrMatchNotFound) || errors.Is(err, models.ErrMatchDecided) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao rejeitar sugestão de conciliação: %w", err)
+	}
+	return m, nil
+}
+
+// suggestMatches pontua as receitas em aberto para um crédito do extrato.
+// Docstring: o valor é obrigatório (igual ao saldo: 60; igual ao valor cheio: 50; até 1% de
+// diferença: 35); somam-se a proximidade da data com o vencimento (até 25, ou 10 pelo mês de
+// competência sem vencimento) e o pagador citado no histórico (documento: 20; nome: até 15).
+// Ficam as MaxSuggestionsPerLine melhores com pelo menos MinMatchScore.
+func suggestMatches(line *models.BankStatementLine, candidates []models.ReconciliationCandidate) []models.ReconciliationMatch {
+	desc := foldText(line.Descricao)
+	descDigits := models.NormalizeDocument(line.Descricao)
+	var out []models.ReconciliationMatch
+	for _, c := range candidates {
+		saldo := c.Valor - c.TotalPago
+		score, reasons := 0, []string{}
+		switch diff := line.Valor - saldo; {
+		case diff == 0:
+			score, reasons = 60, append(reasons, "valor igual ao saldo da receita")
+		case line.Valor == c.Valor:
+			score, reasons = 50, append(reasons, "valor igual ao da receita")
+		case diff*100 >= -saldo && diff*100 <= saldo:
+			score, reasons = 35, append(reasons, "valor próximo do saldo da receita")
+		default:
+			continue
+		}
+
+		if c.DueDate != nil {
+			days := line.PostedAt.Sub(*c.DueDate).Hours() / 24
+			if days < 0 {
+				days = -days
+			}
+			switch {
+			case days <= 3:
+				score, reasons = score+25, append(reasons, "crédito até 3 dias do vencimento")
+			case days <= 10:
+				score, reasons = score+15, append(reasons, "crédito até 10 dias do vencimento")
+			case days <= 31:
+				score, reasons = score+5, append(reasons, "crédito no mês do vencimento")
+			}
+		} else if line.PostedAt.Format("2006-01") == c.Competencia {
+			score, reasons = score+10, append(reasons, "crédito no mês de competência")
+		}
+
+		if doc := models.NormalizeDocument(derefString(c.PayerDocument)); len(doc) >= 11 && strings.Contains(descDigits, doc) {
+			score, reasons = score+20, append(reasons, "documento do pagador no histórico")
+		} else if name := derefString(c.PayerName); name != "" {
+			if hits, total := nameTokensIn(name, desc); total > 0 && (hits == total || hits >= 2) {
+				score, reasons = score+15, append(reasons, "nome do pagador no histórico")
+			} else if hits == 1 {
+				score, reasons = score+8, append(reasons, "parte do nome do pagador no histórico")
+			}
+		}
+
+		if score < models.MinMatchScore {
+			continue
+		}
+		out = append(out, models.ReconciliationMatch{OwnerID: line.OwnerID, LineID: line.ID, IncomeID: c.IncomeID,
+			Score: min(score, 100), Reasons: reasons, Status: models.MatchSuggested})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	if len(out) > models.MaxSuggestionsPerLine {
+		out = out[:models.MaxSuggestionsPerLine]
+	}
+	return out
+}
+
+// nameTokensIn conta quantas palavras significativas do nome aparecem no histórico
+func nameTokensIn(name, desc string) (hits, total int) {
+	words := " " + desc + " "
+	for _, tok := range strings.Fields(foldText(name)) {
+		if len(tok) < 3 || tok == "dos" || tok == "das" {
+			continue
+		}
+		total++
+		if strings.Contains(words, " "+tok+" ") {
+			hits++
+		}
+	}
+	return hits, total
+}
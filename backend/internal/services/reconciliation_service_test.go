@@ -0,0 +1,142 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do motor de sugestões e da confirmação da conciliação bancária
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+// fakeReconciliationRepo repositório de conciliação em memória
+type fakeReconciliationRepo struct {
+    repositories.ReconciliationRepository
+    lines      []models.BankStatementLine
+    candidates []models.ReconciliationCandidate
+    matches    []models.ReconciliationMatch
+    released   int
+}
+
+func (f *fakeReconciliationRepo) CreateStatement(ctx context.Context, st *models.BankStatement, lines []models.BankStatementLine) ([]models.BankStatementLine, error) {
+    st.ID = uuid.New()
+    var out []models.BankStatementLine
+    for _, l := range lines {
+        dup := false
+        for _, e := range f.lines {
+            if e.FitID == l.FitID { dup = true }
+        }
+        if dup { continue }
+        l.ID, l.OwnerID, l.StatementID, l.Status = uuid.New(), st.OwnerID, st.ID, models.StatementLineOpen
+        f.lines = append(f.lines, l)
+        out = append(out, l)
+    }
+    return out, nil
+}
+func (f *fakeReconciliationRepo) ListCandidates(ctx context.Context, ownerID uuid.UUID) ([]models.ReconciliationCandidate, error) {
+    return f.candidates, nil
+}
+func (f *fakeReconciliationRepo) CreateMatches(ctx context.Context, matches []models.ReconciliationMatch) error {
+    for i := range matches {
+        matches[i].ID = uuid.New()
+        f.matches = append(f.matches, matches[i])
+    }
+    return nil
+}
+func (f *fakeReconciliationRepo) ClaimMatch(ctx context.Context, id, ownerID uuid.UUID) (*models.ReconciliationMatch, error) {
+    for i := range f.matches {
+        m := &f.matches[i]
+        if m.ID != id { continue }
+        if m.Status != models.MatchSuggested { return nil, models.ErrMatchDecided }
+        m.Status = models.MatchConfirmed
+        out := *m
+        for _, l := range f.lines {
+            if l.ID == m.LineID { l := l; out.Line = &l }
+        }
+        return &out, nil
+    }
+    return nil, models.ErrMatchNotFound
+}
+func (f *fakeReconciliationRepo) ReleaseMatch(ctx context.Context, id uuid.UUID) error {
+    for i := range f.matches {
+        if f.matches[i].ID == id { f.matches[i].Status = models.MatchSuggested; f.released++ }
+    }
+    return nil
+}
+func (f *fakeReconciliationRepo) SetMatchPayment(ctx context.Context, m *models.ReconciliationMatch, paymentID uuid.UUID) error {
+    for i := range f.matches {
+        if f.matches[i].ID == m.ID { f.matches[i].PaymentID = &paymentID }
+    }
+    return nil
+}
+
+func TestSuggestMatches(t *testing.T) {
+    due := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+    nome, doc := "João da Silva", "123.456.789-09"
+    exact := models.ReconciliationCandidate{IncomeID: uuid.New(), Competencia: "2026-10", Valor: 150000, DueDate: &due, PayerName: &nome}
+    partial := models.ReconciliationCandidate{IncomeID: uuid.New(), Competencia: "2026-10", Valor: 200000, TotalPago: 50000, PayerDocument: &doc}
+    other := models.ReconciliationCandidate{IncomeID: uuid.New(), Competencia: "2026-10", Valor: 99000}
+    line := &models.BankStatementLine{ID: uuid.New(), PostedAt: time.Date(2026, 10, 6, 0, 0, 0, 0, time.UTC), Valor: 150000,
+        Descricao: "PIX RECEBIDO JOAO SILVA 12345678909"}
+
+    got := suggestMatches(line, []models.ReconciliationCandidate{other, partial, exact})
+    if len(got) != 2 { t.Fatalf("sugestões = %d, esperado 2: %+v", len(got), got) }
+    // exact: saldo (60) + vencimento a 1 dia (25) + nome (15) = 100
+    if got[0].IncomeID != exact.IncomeID || got[0].Score != 100 { t.Fatalf("melhor sugestão = %+v", got[0]) }
+    // partial: saldo (60) + competência (10) + documento (20) = 90
+    if got[1].IncomeID != partial.IncomeID || got[1].Score != 90 { t.Fatalf("segunda sugestão = %+v", got[1]) }
+    if len(got[0].Reasons) != 3 || got[0].Status != models.MatchSuggested { t.Fatalf("motivos = %v", got[0].Reasons) }
+
+    // Valor sem relação com a receita não gera sugestão, mesmo com pagador no histórico
+    line.Valor = 120000
+    if got := suggestMatches(line, []models.ReconciliationCandidate{exact}); len(got) != 0 { t.Fatalf("não deveria sugerir: %+v", got) }
+}
+
+func TestReconciliationService_ImportAndConfirm(t *testing.T) {
+    due := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+    cand := models.ReconciliationCandidate{IncomeID: uuid.New(), Competencia: "2026-10", Valor: 150000, DueDate: &due}
+    repo := &fakeReconciliationRepo{candidates: []models.ReconciliationCandidate{cand}}
+    rec := &fakePixRecorder{}
+    svc := NewReconciliationService(repo, rec, logging.NewLogger("dev"))
+    owner := uuid.New()
+    csvBody := "Data;Histórico;Valor\n05/10/2026;PIX RECEBIDO;1.500,00\n06/10/2026;TARIFA;-10,00\n"
+
+    res, err := svc.Import(context.Background(), owner, strings.NewReader(csvBody), "extrato.csv", "")
+    if err != nil { t.Fatalf("erro inesperado: %v", err) }
+    if res.TotalLines != 2 || res.Imported != 1 || res.IgnoredDebits != 1 || len(res.Suggestions) != 1 { t.Fatalf("resultado = %+v", res) }
+    s := res.Suggestions[0]
+    if s.Line == nil || s.Income == nil || s.Income.ID != cand.IncomeID { t.Fatalf("sugestão sem lançamento/receita: %+v", s) }
+
+    again, err := svc.Import(context.Background(), owner, strings.NewReader(csvBody), "extrato.csv", "")
+    if err != nil || again.Imported != 0 || again.Duplicates != 1 || len(again.Suggestions) != 0 { t.Fatalf("reimportação = %+v, %v", again, err) }
+
+    if _, err := svc.Import(context.Background(), owner, strings.NewReader("Data;Histórico;Valor\n06/10/2026;TARIFA;-10,00\n"), "", ""); !errors.Is(err, models.ErrStatementEmpty) {
+        t.Fatalf("erro = %v, esperado ErrStatementEmpty", err)
+    }
+
+    // Falha no lançamento devolve a sugestão para decisão
+    rec.errs = []error{models.ErrIncomeAlreadyPaid}
+    if _, _, err := svc.Confirm(context.Background(), s.ID, owner); !errors.Is(err, models.ErrIncomeAlreadyPaid) || repo.released != 1 {
+        t.Fatalf("erro = %v, reabertas = %d", err, repo.released)
+    }
+
+    m, out, err := svc.Confirm(context.Background(), s.ID, owner)
+    if err != nil { t.Fatalf("erro inesperado: %v", err) }
+    req := rec.reqs[len(rec.reqs)-1]
+    if req.Valor != 150000 || req.PagoEm == nil || !strings.HasPrefix(*req.PagoEm, "2026-10-05") || req.Overpayment != models.OverpaymentCredit {
+        t.Fatalf("pagamento lançado errado: %+v", req)
+    }
+    if m.PaymentID == nil || *m.PaymentID != out.Payment.ID || repo.matches[0].PaymentID == nil { t.Fatalf("sugestão sem pagamento: %+v", m) }
+
+    if _, _, err := svc.Confirm(context.Background(), s.ID, owner); !errors.Is(err, models.ErrMatchDecided) { t.Fatalf("erro = %v, esperado ErrMatchDecided", err) }
+    if _, err := svc.ListMatches(context.Background(), owner, "x"); !errors.Is(err, models.ErrInvalidMatchStatus) { t.Fatalf("erro = %v", err) }
+}
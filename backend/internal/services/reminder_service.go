@@ -0,0 +1,81 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Job de lembretes de vencimento (evento income.due_soon) enviados pelos canais do usuário
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// ReminderLeadDays antecedência do lembrete: receitas que vencem de hoje até daqui a 3 dias
+const ReminderLeadDays = 3
+
+// ReminderService avisa o usuário das receitas prestes a vencer.
+// Docstring: cada receita é lembrada uma vez (reservada com due_reminder_at no banco) e o
+// envio segue a matriz de preferências (e-mail, push no PWA, WhatsApp) do NotificationDispatcher.
+type ReminderService struct {
+	repo       repositories.NotificationRepository
+	dispatcher EventDispatcher
+	log        logging.Logger
+	now        func() time.Time
+}
+
+// NewReminderService cria o serviço de lembretes de vencimento
+func NewReminderService(repo repositories.NotificationRepository, dispatcher EventDispatcher, log logging.Logger) *ReminderService {
+	return &ReminderService{repo: repo, dispatcher: dispatcher, log: log, now: time.Now}
+}
+
+// SendDueReminders reserva e notifica as receitas a vencer; retorna quantas foram lembradas
+func (s *ReminderService) SendDueReminders(ctx context.Context) (int, error) {
+	items, err := s.repo.ClaimDueReminders(ctx, s.now().UTC(), ReminderLeadDays, locale.DefaultTimezone)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao reservar lembretes de vencimento: %w", err)
+	}
+	for _, it := range items {
+		ls := locale.Resolve(derefString(it.Locale), it.Timezone)
+		if _, err := s.dispatcher.Dispatch(ctx, it.OwnerID, models.EventIncomeDueSoon, dueReminderMessage(it, ls)); err != nil {
+			s.log.Error("erro ao enviar lembrete de vencimento",
+				logging.Field{Key: "income_id", Val: it.IncomeID.String()},
+				logging.Field{Key: "error", Val: err.Error()})
+		}
+	}
+	if len(items) > 0 {
+		s.log.Info("lembretes de vencimento enviados", logging.Field{Key: "count", Val: len(items)})
+	}
+	return len(items), nil
+}
+
+// dueReminderMessage texto do lembrete no idioma e fuso do usuário
+func dueReminderMessage(it models.DueReminder, ls locale.Settings) *models.NotificationMessage {
+	what := ls.FormatCompetencia(it.Competencia)
+	if c := strings.TrimSpace(derefString(it.Categoria)); c != "" {
+		what = c + " " + what
+	}
+	// due_date é data civil (meia-noite UTC); compara com a data de hoje no fuso do usuário
+	y, m, d := ls.Now().Date()
+	days := int(it.DueDate.Sub(time.Date(y, m, d, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+	due := fmt.Sprintf("vence em %d dias", days)
+	switch days {
+	case 0:
+		due = "vence hoje"
+	case 1:
+		due = "vence amanhã"
+	}
+	return &models.NotificationMessage{
+		Title: "Receita a vencer",
+		Body:  fmt.Sprintf("%s (%s) %s.", what, ls.FormatAmount(it.Saldo), due),
+		URL:   "/receitas/" + it.IncomeID.String(),
+		Tag:   "due-" + it.IncomeID.String(),
+		Data:  map[string]interface{}{"income_id": it.IncomeID, "due_date": it.DueDate.Format("2006-01-02")},
+	}
+}
@@ -0,0 +1,329 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Relatórios: mensal de receitas e despesas (JSON, PDF e CSV) para o contador e aging dos recebíveis vencidos
+// Data: 18-10-2026
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/locale"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/pdf"
+	"recibofast/internal/repositories"
+)
+
+// ReportService monta o relatório mensal de uma competência.
+// Docstring: receitas e saldo em aberto são os da competência; pagamentos são os recebidos no mês
+// civil no fuso do usuário (regime de caixa); vencidos são as receitas de qualquer competência com
+// saldo e vencimento antes do fim do mês (ou de hoje, para o mês corrente). Despesas entram pela
+// competência e pelo pagamento no mês, que define o resultado (recebido - despesas pagas).
+type ReportService struct {
+	repo repositories.ReportRepository
+	log  logging.Logger
+	now  func() time.Time
+}
+
+// NewReportService cria o serviço de relatórios
+func NewReportService(repo repositories.ReportRepository, log logging.Logger) *ReportService {
+	return &ReportService{repo: repo, log: log, now: time.Now}
+}
+
+// Monthly carrega os dados da competência e calcula os totais
+func (s *ReportService) Monthly(ctx context.Context, ownerID uuid.UUID, competencia string, ls locale.Settings) (*models.MonthlyReport, error) {
+	month, err := models.ParseReportCompetencia(competencia)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().In(ls.Location)
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, ls.Location)
+	end := start.AddDate(0, 1, 0)
+
+	incomes, err := s.repo.ListMonthIncomes(ctx, ownerID, models.CompetenciaForms(month))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar receitas da competência: %w", err)
+	}
+	payments, err := s.repo.ListMonthPayments(ctx, ownerID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar pagamentos do mês: %w", err)
+	}
+	// vencimento é uma data: compara com a data local (meia-noite UTC), como em rf_incomes.due_date
+	cutoff := end
+	if now.Before(end) {
+		cutoff = now
+	}
+	overdue, err := s.repo.ListOverdue(ctx, ownerID, time.Date(cutoff.Year(), cutoff.Month(), cutoff.Day(), 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar receitas vencidas: %w", err)
+	}
+	expenses, err := s.repo.ListMonthExpenses(ctx, ownerID, models.CompetenciaForms(month), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar despesas do mês: %w", err)
+	}
+	rep := models.BuildMonthlyReport(month, start, end, now, incomes, payments, overdue)
+	rep.AddExpenses(expenses)
+	return rep, nil
+}
+
+// Aging distribui o saldo vencido por faixa de atraso, agrupado por pagador ou contrato, na data de hoje do usuário
+func (s *ReportService) Aging(ctx context.Context, ownerID uuid.UUID, groupBy string, ls locale.Settings) (*models.AgingReport, error) {
+	if groupBy != "" && groupBy != models.AgingByPayer && groupBy != models.AgingByContract {
+		return nil, models.ErrAgingGroupInvalid
+	}
+	local := s.now().In(ls.Location)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	items, err := s.repo.ListAging(ctx, ownerID, today)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar receitas vencidas: %w", err)
+	}
+	return models.BuildAgingReport(items, today, groupBy)
+}
+
+// RenderCSV gera o relatório em CSV (separador ";", decimais no padrão do idioma) com uma seção por linha
+func (s *ReportService) RenderCSV(rep *models.MonthlyReport, ls locale.Settings) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff") // BOM: planilhas em português abrem o UTF-8 com acentos corretos
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+	money := func(m models.Money) string {
+		if ls.Locale == "en-US" {
+			return m.String()
+		}
+		return strings.Replace(m.String(), ".", ",", 1)
+	}
+	w.Write([]string{"secao", "competencia", "vencimento", "pago_em", "pagador", "categoria", "status", "forma", "valor", "pago", "saldo"})
+	t := rep.Totals
+	for _, kv := range []struct {
+		label string
+		valor models.Money
+	}{{"faturado", t.Faturado}, {"recebido", t.Recebido}, {"em_aberto", t.EmAberto}, {"vencido", t.Vencido},
+		{"despesas", t.Despesas}, {"despesas_pagas", t.DespesasPagas}, {"resultado", t.Resultado}} {
+		w.Write([]string{"resumo", rep.Competencia, "", "", "", kv.label, "", "", money(kv.valor), "", ""})
+	}
+	incomeRow := func(section string, i *models.MonthlyReportIncome) []string {
+		return []string{section, i.Competencia, reportDate(i.DueDate, ls), "", derefString(i.PayerNome), derefString(i.Categoria),
+			i.Status, "", money(i.Valor), money(i.TotalPago), money(i.Saldo)}
+	}
+	for k := range rep.Incomes {
+		w.Write(incomeRow("receita", &rep.Incomes[k]))
+	}
+	for _, p := range rep.Payments {
+		w.Write([]string{"pagamento", p.Competencia, "", ls.FormatDate(p.PagoEm), derefString(p.PayerNome), derefString(p.Categoria),
+			"", derefString(p.Metodo), money(p.Valor), "", ""})
+	}
+	for k := range rep.Overdue {
+		w.Write(incomeRow("vencida", &rep.Overdue[k]))
+	}
+	for _, e := range rep.Expenses {
+		pagoEm := ""
+		if e.PagoEm != nil {
+			pagoEm = ls.FormatDate(*e.PagoEm)
+		}
+		w.Write([]string{"despesa", e.Competencia, reportDate(e.DueDate, ls), pagoEm, derefString(e.Fornecedor), derefString(e.Categoria),
+			e.Status, "", money(e.Valor), "", ""})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+const (
+	reportMargin = 40.0
+	reportRight  = pdf.PageWidth - reportMargin
+	reportBottom = pdf.PageHeight - 50
+	reportRow    = 14.0
+)
+
+// reportColumn coluna de tabela do PDF; right alinha valores à direita da coluna
+type reportColumn struct {
+	title string
+	x, w  float64
+	right bool
+}
+
+// reportPDF escreve as seções do relatório quebrando páginas e repetindo o cabeçalho das tabelas
+type reportPDF struct {
+	doc    *pdf.Document
+	y      float64
+	title  string
+	footer string
+}
+
+// RenderPDF gera o relatório em PDF (A4 retrato)
+func (s *ReportService) RenderPDF(rep *models.MonthlyReport, ls locale.Settings) []byte {
+	comp := ls.FormatCompetencia(rep.Competencia)
+	p := &reportPDF{doc: pdf.New(), title: "Relatório mensal - competência " + comp,
+		footer: "Gerado pelo ReciboFast em " + ls.FormatDateTime(rep.GeneratedAt)}
+	p.newPage()
+
+	t := rep.Totals
+	p.doc.Rect(reportMargin, p.y, reportRight-reportMargin, 74, 0.95)
+	cards := [][2]string{
+		{"Faturado", ls.FormatAmount(t.Faturado)},
+		{"Recebido no mês", ls.FormatAmount(t.Recebido)},
+		{"Em aberto", ls.FormatAmount(t.EmAberto)},
+		{"Vencido", ls.FormatAmount(t.Vencido)},
+	}
+	cw := (reportRight - reportMargin) / float64(len(cards))
+	for k, c := range cards {
+		x := reportMargin + float64(k)*cw + 12
+		p.doc.Text(x, p.y+24, 9, false, c[0])
+		p.doc.Text(x, p.y+44, 13, true, c[1])
+	}
+	p.doc.Text(reportMargin+12, p.y+64, 8, false, fmt.Sprintf("%d receitas (%d pagas, %d canceladas) - %d pagamentos - %d vencidas",
+		t.Receitas, t.ReceitasPagas, t.Canceladas, t.Pagamentos, t.Vencidas))
+	p.y += 84
+
+	p.doc.Rect(reportMargin, p.y, reportRight-reportMargin, 54, 0.95)
+	results := [][2]string{
+		{"Despesas da competência", ls.FormatAmount(t.Despesas)},
+		{"Despesas pagas no mês", ls.FormatAmount(t.DespesasPagas)},
+		{"Resultado (recebido - pago)", ls.FormatAmount(t.Resultado)},
+	}
+	rw := (reportRight - reportMargin) / float64(len(results))
+	for k, c := range results {
+		x := reportMargin + float64(k)*rw + 12
+		p.doc.Text(x, p.y+22, 9, false, c[0])
+		p.doc.Text(x, p.y+42, 13, true, c[1])
+	}
+	p.y += 80
+
+	incomeCols := []reportColumn{{"Vencimento", reportMargin, 60, false}, {"Pagador", reportMargin + 62, 130, false},
+		{"Categoria", reportMargin + 194, 90, false}, {"Situação", reportMargin + 286, 56, false},
+		{"Valor", reportMargin + 344, 56, true}, {"Pago", reportMargin + 402, 56, true}, {"Saldo", reportMargin + 460, 55, true}}
+	incomeCells := func(i *models.MonthlyReportIncome) []string {
+		return []string{reportDate(i.DueDate, ls), orDash(i.PayerNome), orDash(i.Categoria), reportStatusLabel(i.Status),
+			ls.FormatAmount(i.Valor), ls.FormatAmount(i.TotalPago), ls.FormatAmount(i.Saldo)}
+	}
+	p.section("Receitas da competência", incomeCols, len(rep.Incomes), func(k int) []string { return incomeCells(&rep.Incomes[k]) })
+
+	paymentCols := []reportColumn{{"Data", reportMargin, 60, false}, {"Pagador", reportMargin + 62, 150, false},
+		{"Competência", reportMargin + 214, 60, false}, {"Categoria", reportMargin + 276, 100, false},
+		{"Forma", reportMargin + 378, 70, false}, {"Valor", reportMargin + 450, 65, true}}
+	p.section("Pagamentos recebidos no mês", paymentCols, len(rep.Payments), func(k int) []string {
+		pg := &rep.Payments[k]
+		return []string{ls.FormatDate(pg.PagoEm), orDash(pg.PayerNome), ls.FormatCompetencia(pg.Competencia), orDash(pg.Categoria),
+			orDash(pg.Metodo), ls.FormatAmount(pg.Valor)}
+	})
+
+	overdueCols := []reportColumn{{"Vencimento", reportMargin, 60, false}, {"Pagador", reportMargin + 62, 150, false},
+		{"Competência", reportMargin + 214, 60, false}, {"Categoria", reportMargin + 276, 110, false},
+		{"Valor", reportMargin + 388, 62, true}, {"Saldo", reportMargin + 452, 63, true}}
+	p.section("Receitas vencidas", overdueCols, len(rep.Overdue), func(k int) []string {
+		i := &rep.Overdue[k]
+		return []string{reportDate(i.DueDate, ls), orDash(i.PayerNome), ls.FormatCompetencia(i.Competencia), orDash(i.Categoria),
+			ls.FormatAmount(i.Valor), ls.FormatAmount(i.Saldo)}
+	})
+
+	expenseCols := []reportColumn{{"Vencimento", reportMargin, 60, false}, {"Descrição", reportMargin + 62, 140, false},
+		{"Categoria", reportMargin + 204, 90, false}, {"Situação", reportMargin + 296, 56, false},
+		{"Pago em", reportMargin + 354, 60, false}, {"Valor", reportMargin + 416, 99, true}}
+	p.section("Despesas", expenseCols, len(rep.Expenses), func(k int) []string {
+		e := &rep.Expenses[k]
+		pagoEm := ""
+		if e.PagoEm != nil {
+			pagoEm = ls.FormatDate(*e.PagoEm)
+		}
+		return []string{reportDate(e.DueDate, ls), e.Descricao, orDash(e.Categoria), reportStatusLabel(e.Status),
+			pagoEm, ls.FormatAmount(e.Valor)}
+	})
+
+	return p.doc.Bytes()
+}
+
+func (p *reportPDF) newPage() {
+	p.doc.AddPage()
+	p.doc.Text(reportMargin, 50, 15, true, p.title)
+	p.doc.Line(reportMargin, 60, reportRight, 60, 0.6, false)
+	p.doc.Text(reportMargin, pdf.PageHeight-30, 7, false, fmt.Sprintf("%s - página %d", p.footer, p.doc.PageCount()))
+	p.y = 78
+}
+
+// section desenha o título, o cabeçalho e as linhas; sem linhas escreve "Nenhum registro"
+func (p *reportPDF) section(title string, cols []reportColumn, n int, cells func(k int) []string) {
+	if p.y+3*reportRow > reportBottom {
+		p.newPage()
+	}
+	p.doc.Text(reportMargin, p.y, 11, true, title)
+	p.y += reportRow + 2
+	p.header(cols)
+	if n == 0 {
+		p.doc.Text(reportMargin, p.y, 8, false, "Nenhum registro.")
+		p.y += reportRow
+	}
+	for k := 0; k < n; k++ {
+		if p.y > reportBottom {
+			p.newPage()
+			p.header(cols)
+		}
+		for c, v := range cells(k) {
+			col := cols[c]
+			v = fitText(v, col.w, 8, false)
+			if col.right {
+				p.doc.TextRight(col.x+col.w, p.y, 8, false, v)
+			} else {
+				p.doc.Text(col.x, p.y, 8, false, v)
+			}
+		}
+		p.y += reportRow
+	}
+	p.y += reportRow
+}
+
+func (p *reportPDF) header(cols []reportColumn) {
+	for _, col := range cols {
+		if col.right {
+			p.doc.TextRight(col.x+col.w, p.y, 8, true, col.title)
+		} else {
+			p.doc.Text(col.x, p.y, 8, true, col.title)
+		}
+	}
+	p.doc.Line(reportMargin, p.y+4, reportRight, p.y+4, 0.4, false)
+	p.y += reportRow
+}
+
+// fitText corta s com reticências para caber em width
+func fitText(s string, width, size float64, bold bool) string {
+	if pdf.TextWidth(s, size, bold) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && pdf.TextWidth(string(r)+"...", size, bold) > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "..."
+}
+
+// reportDate data de vencimento (meia-noite UTC, sem conversão de fuso) ou vazio
+func reportDate(d *time.Time, ls locale.Settings) string {
+	if d == nil {
+		return ""
+	}
+	return locale.Settings{Locale: ls.Locale, Location: time.UTC}.FormatDate(*d)
+}
+
+func reportStatusLabel(status string) string {
+	switch status {
+	case models.StatusPendente:
+		return "Pendente"
+	case models.StatusParcial:
+		return "Parcial"
+	case models.StatusPago:
+		return "Pago"
+	case models.StatusVencido:
+		return "Vencido"
+	case models.StatusCancelado:
+		return "Cancelado"
+	}
+	return status
+}
@@ -0,0 +1,126 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do relatório mensal (período no fuso do usuário, PDF e CSV)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "bytes"
+    "context"
+    "encoding/csv"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/locale"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+type fakeReportRepo struct {
+    competencias []string
+    from, to     time.Time
+    before       time.Time
+    incomes      []models.MonthlyReportIncome
+    payments     []models.MonthlyReportPayment
+    overdue      []models.MonthlyReportIncome
+    aging        []models.AgingItem
+    expenses     []models.MonthlyReportExpense
+}
+
+func (f *fakeReportRepo) ListMonthIncomes(ctx context.Context, ownerID uuid.UUID, competencias []string) ([]models.MonthlyReportIncome, error) {
+    f.competencias = competencias
+    return f.incomes, nil
+}
+func (f *fakeReportRepo) ListMonthPayments(ctx context.Context, ownerID uuid.UUID, from, to time.Time) ([]models.MonthlyReportPayment, error) {
+    f.from, f.to = from, to
+    return f.payments, nil
+}
+func (f *fakeReportRepo) ListOverdue(ctx context.Context, ownerID uuid.UUID, before time.Time) ([]models.MonthlyReportIncome, error) {
+    f.before = before
+    return f.overdue, nil
+}
+func (f *fakeReportRepo) ListMonthExpenses(ctx context.Context, ownerID uuid.UUID, competencias []string, from, to time.Time) ([]models.MonthlyReportExpense, error) {
+    return f.expenses, nil
+}
+func (f *fakeReportRepo) ListAging(ctx context.Context, ownerID uuid.UUID, before time.Time) ([]models.AgingItem, error) {
+    f.before = before
+    return f.aging, nil
+}
+
+func TestReportMonthly_PeriodInUserTimezone(t *testing.T) {
+    ls := locale.Resolve("pt-BR", "America/Sao_Paulo")
+    due := time.Date(2025, 9, 10, 0, 0, 0, 0, time.UTC)
+    nome, cat, metodo := "Maria; Silva", "Aluguel", "PIX"
+    repo := &fakeReportRepo{
+        incomes:  []models.MonthlyReportIncome{{ID: uuid.New(), Competencia: "2025-09", PayerNome: &nome, Categoria: &cat, DueDate: &due, Status: models.StatusParcial, Valor: models.NewMoney(1500), TotalPago: models.NewMoney(500.5)}},
+        payments: []models.MonthlyReportPayment{{ID: uuid.New(), Competencia: "2025-09", PayerNome: &nome, Valor: models.NewMoney(500.5), PagoEm: time.Date(2025, 9, 30, 23, 0, 0, 0, time.UTC), Metodo: &metodo}},
+    }
+    // despesa de agosto paga em setembro: fora das despesas da competência, dentro do resultado
+    pagoEm := time.Date(2025, 9, 5, 15, 0, 0, 0, time.UTC)
+    repo.expenses = []models.MonthlyReportExpense{
+        {ID: uuid.New(), Descricao: "Condomínio", Competencia: "08/2025", Status: models.StatusPago, Valor: models.NewMoney(200), PagoEm: &pagoEm},
+        {ID: uuid.New(), Descricao: "IPTU", Competencia: "2025-09", Status: models.StatusPendente, Valor: models.NewMoney(80)},
+    }
+    svc := NewReportService(repo, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return time.Date(2025, 9, 15, 12, 0, 0, 0, time.UTC) }
+
+    rep, err := svc.Monthly(context.Background(), uuid.New(), "2025-09", ls)
+    if err != nil { t.Fatal(err) }
+    if len(repo.competencias) != 2 || repo.competencias[1] != "09/2025" { t.Fatalf("competências consultadas = %v", repo.competencias) }
+    if !repo.from.Equal(time.Date(2025, 9, 1, 3, 0, 0, 0, time.UTC)) || !repo.to.Equal(time.Date(2025, 10, 1, 3, 0, 0, 0, time.UTC)) {
+        t.Fatalf("período = %v - %v", repo.from, repo.to)
+    }
+    // mês corrente: vencidos até hoje, não até o fim do mês
+    if !repo.before.Equal(time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)) { t.Fatalf("corte dos vencidos = %v", repo.before) }
+    if rep.Totals.EmAberto != models.NewMoney(999.5) || rep.Totals.Recebido != models.NewMoney(500.5) { t.Fatalf("totais = %+v", rep.Totals) }
+    if rep.Totals.Despesas != models.NewMoney(80) || rep.Totals.DespesasPagas != models.NewMoney(200) || rep.Totals.Resultado != models.NewMoney(300.5) {
+        t.Fatalf("despesas = %+v", rep.Totals)
+    }
+
+    out, err := svc.RenderCSV(rep, ls)
+    if err != nil { t.Fatal(err) }
+    r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(out, []byte("\ufeff"))))
+    r.Comma = ';'
+    rows, err := r.ReadAll()
+    if err != nil { t.Fatalf("CSV inválido: %v\n%s", err, out) }
+    if len(rows) != 1+7+1+1+2 || rows[8][0] != "receita" || rows[8][2] != "10/09/2025" || rows[8][4] != nome || rows[8][10] != "999,50" {
+        t.Fatalf("linhas = %q", rows)
+    }
+    if rows[9][0] != "pagamento" || rows[9][3] != "30/09/2025" || rows[9][7] != "PIX" { t.Fatalf("pagamento = %q", rows[9]) }
+    if rows[7][5] != "resultado" || rows[7][8] != "300,50" || rows[10][0] != "despesa" || rows[10][3] != "05/09/2025" { t.Fatalf("despesas = %q", rows) }
+
+    pdfOut := svc.RenderPDF(rep, ls)
+    if !bytes.HasPrefix(pdfOut, []byte("%PDF-")) || !strings.Contains(string(pdfOut), "09/2025") { t.Fatal("PDF sem competência") }
+
+    if _, err := svc.Monthly(context.Background(), uuid.New(), "setembro", ls); err != models.ErrReportCompetenciaInvalid {
+        t.Fatalf("err = %v", err)
+    }
+}
+
+func TestReportPDF_BreaksPages(t *testing.T) {
+    ls := locale.Resolve("pt-BR", "America/Sao_Paulo")
+    items := make([]models.MonthlyReportIncome, 120)
+    for k := range items { items[k] = models.MonthlyReportIncome{Competencia: "2025-09", Status: models.StatusPendente, Valor: models.NewMoney(10)} }
+    month := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+    rep := models.BuildMonthlyReport(month, month, month.AddDate(0, 1, 0), month, items, nil, nil)
+    out := NewReportService(nil, logging.NewLogger("dev")).RenderPDF(rep, ls)
+    if n := bytes.Count(out, []byte("/Type /Page ")); n < 3 { t.Fatalf("páginas = %d", n) }
+}
+
+func TestReportAging_UsesUserToday(t *testing.T) {
+    repo := &fakeReportRepo{aging: []models.AgingItem{{DueDate: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Saldo: models.NewMoney(10)}}}
+    svc := NewReportService(repo, logging.NewLogger("dev"))
+    // 02:00 UTC de 19/10 ainda é 18/10 em São Paulo: um dia de atraso
+    svc.now = func() time.Time { return time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC) }
+    rep, err := svc.Aging(context.Background(), uuid.New(), "", locale.Resolve("pt-BR", "America/Sao_Paulo"))
+    if err != nil { t.Fatal(err) }
+    if rep.AsOf != "2026-10-18" || !repo.before.Equal(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)) || rep.Groups[0].MaxDays != 1 {
+        t.Fatalf("aging = %+v", rep)
+    }
+    if _, err := svc.Aging(context.Background(), uuid.New(), "tag", locale.Resolve("pt-BR", "America/Sao_Paulo")); err != models.ErrAgingGroupInvalid {
+        t.Fatalf("err = %v", err)
+    }
+}
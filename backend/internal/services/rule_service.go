@@ -0,0 +1,165 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Serviço do motor de regras de categorização de receitas
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// IncomeRuleEvaluator aplica as regras do usuário a uma receita antes de persistir.
+// Docstring: usado por IncomeService na criação e pela importação de receitas.
+type IncomeRuleEvaluator interface {
+	ApplyRules(ctx context.Context, ownerID uuid.UUID, income *models.Income) (*models.IncomeRule, error)
+}
+
+// RuleService interface para o gerenciamento e avaliação de regras
+type RuleService interface {
+	IncomeRuleEvaluator
+	CreateRule(ctx context.Context, ownerID uuid.UUID, req *models.IncomeRuleRequest) (*models.IncomeRule, error)
+	GetRule(ctx context.Context, id, ownerID uuid.UUID) (*models.IncomeRule, error)
+	UpdateRule(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeRuleRequest) (*models.IncomeRule, error)
+	DeleteRule(ctx context.Context, id, ownerID uuid.UUID) error
+	ListRules(ctx context.Context, ownerID uuid.UUID) ([]models.IncomeRule, error)
+	ReorderRules(ctx context.Context, ownerID uuid.UUID, ids []uuid.UUID) ([]models.IncomeRule, error)
+	TestRules(ctx context.Context, ownerID uuid.UUID, req *models.RuleTestRequest) (*models.RuleTestResponse, error)
+}
+
+type ruleService struct {
+	repo repositories.RuleRepository
+}
+
+// NewRuleService cria uma nova instância do serviço de regras
+func NewRuleService(repo repositories.RuleRepository) RuleService {
+	return &ruleService{repo: repo}
+}
+
+// CreateRule valida e cadastra uma regra; sem prioridade informada, entra ao final da lista
+func (s *ruleService) CreateRule(ctx context.Context, ownerID uuid.UUID, req *models.IncomeRuleRequest) (*models.IncomeRule, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	rule := &models.IncomeRule{OwnerID: ownerID, Nome: req.Nome, Enabled: true, Conditions: req.Conditions, Actions: req.Actions}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	if req.Priority != nil {
+		rule.Priority = *req.Priority
+	} else {
+		existing, err := s.repo.List(ctx, ownerID)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao listar regras: %w", err)
+		}
+		rule.Priority = 10
+		if n := len(existing); n > 0 {
+			rule.Priority = existing[n-1].Priority + 10
+		}
+	}
+	if err := s.repo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("erro ao criar regra: %w", err)
+	}
+	return rule, nil
+}
+
+// GetRule busca uma regra do usuário
+func (s *ruleService) GetRule(ctx context.Context, id, ownerID uuid.UUID) (*models.IncomeRule, error) {
+	return s.repo.GetByID(ctx, id, ownerID)
+}
+
+// UpdateRule substitui uma regra mantendo a prioridade quando não informada
+func (s *ruleService) UpdateRule(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomeRuleRequest) (*models.IncomeRule, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	rule, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	rule.Nome = req.Nome
+	rule.Conditions = req.Conditions
+	rule.Actions = req.Actions
+	if req.Priority != nil {
+		rule.Priority = *req.Priority
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	if err := s.repo.Update(ctx, rule); err != nil {
+		if errors.Is(err, models.ErrRuleNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao atualizar regra: %w", err)
+	}
+	return rule, nil
+}
+
+// DeleteRule remove uma regra
+func (s *ruleService) DeleteRule(ctx context.Context, id, ownerID uuid.UUID) error {
+	return s.repo.Delete(ctx, id, ownerID)
+}
+
+// ListRules lista as regras em ordem de avaliação
+func (s *ruleService) ListRules(ctx context.Context, ownerID uuid.UUID) ([]models.IncomeRule, error) {
+	rules, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar regras: %w", err)
+	}
+	if rules == nil {
+		rules = []models.IncomeRule{}
+	}
+	return rules, nil
+}
+
+// ReorderRules redefine a prioridade das regras na ordem informada
+func (s *ruleService) ReorderRules(ctx context.Context, ownerID uuid.UUID, ids []uuid.UUID) ([]models.IncomeRule, error) {
+	if len(ids) == 0 {
+		return nil, models.ErrRuleNotFound
+	}
+	if err := s.repo.Reorder(ctx, ownerID, ids); err != nil {
+		if errors.Is(err, models.ErrRuleNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erro ao reordenar regras: %w", err)
+	}
+	return s.ListRules(ctx, ownerID)
+}
+
+// ApplyRules aplica a primeira regra que casar à receita
+func (s *ruleService) ApplyRules(ctx context.Context, ownerID uuid.UUID, income *models.Income) (*models.IncomeRule, error) {
+	rules, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar regras: %w", err)
+	}
+	matched, _ := models.EvaluateRules(rules, income)
+	return matched, nil
+}
+
+// TestRules avalia uma receita de exemplo sem persistir nada
+func (s *ruleService) TestRules(ctx context.Context, ownerID uuid.UUID, req *models.RuleTestRequest) (*models.RuleTestResponse, error) {
+	rules, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar regras: %w", err)
+	}
+	sample := &models.Income{
+		OwnerID:    ownerID,
+		PayerID:    req.PayerID,
+		ContractID: req.ContractID,
+		Categoria:  req.Categoria,
+		Valor:      req.Valor,
+		Tags:       req.Tags,
+	}
+	matched, evals := models.EvaluateRules(rules, sample)
+	tags := sample.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return &models.RuleTestResponse{Matched: matched, Categoria: sample.Categoria, Tags: tags, Evaluations: evals}, nil
+}
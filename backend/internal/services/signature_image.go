@@ -0,0 +1,210 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Tratamento da imagem de assinatura (remoção do fundo branco, recorte e altura padrão)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+// Parâmetros do tratamento da assinatura
+const (
+	// SignatureHeight altura padrão (px) da assinatura tratada, usada nos PDFs de recibo
+	SignatureHeight = 200
+	// SignatureMaxWidth largura máxima (px); assinaturas muito alongadas ficam mais baixas
+	SignatureMaxWidth = 1200
+
+	// Fundo: canais acima de signatureWhite viram transparentes; entre signatureSoft e
+	// signatureWhite a opacidade cai aos poucos, suavizando a borda dos traços
+	signatureWhite = 240
+	signatureSoft  = 200
+	// signatureMinAlpha pixels mais transparentes que isso não contam no recorte
+	signatureMinAlpha = 8
+)
+
+// ErrSignatureBlank nenhum traço restou depois de remover o fundo
+var ErrSignatureBlank = &SignatureError{Code: SignatureCodeBlank, Message: "assinatura em branco: nenhum traço encontrado na imagem"}
+
+// NormalizePNG limpa a assinatura enviada e devolve um novo PNG.
+// Docstring: fundo branco ou quase branco vira transparente (scanners e fotos raramente têm
+// branco puro), bordas transparentes são recortadas e a imagem é redimensionada para
+// SignatureHeight mantendo a proporção (limitada a SignatureMaxWidth).
+func (s *SignatureService) NormalizePNG(data []byte) ([]byte, error) {
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errSignatureInvalidPNG
+	}
+	// mesmo limite de pixels da validação, antes de decodificar a imagem inteira
+	if err := (SignatureLimits{MaxMegapixels: s.limits.MaxMegapixels}).check(cfg.Width, cfg.Height); err != nil {
+		return nil, err
+	}
+	src, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errSignatureInvalidPNG
+	}
+
+	img := removeSignatureBackground(src)
+	bounds, ok := signatureInkBounds(img)
+	if !ok {
+		return nil, ErrSignatureBlank
+	}
+	img = img.SubImage(bounds).(*image.NRGBA)
+
+	w, h := signatureTargetSize(bounds.Dx(), bounds.Dy())
+	out := resizeNRGBA(img, w, h)
+
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(&buf, out); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// removeSignatureBackground copia a imagem para NRGBA tornando transparente o fundo claro
+func removeSignatureBackground(src image.Image) *image.NRGBA {
+	b := src.Bounds()
+	img := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(img, img.Bounds(), src, b.Min, draw.Src)
+	for i := 0; i < len(img.Pix); i += 4 {
+		p := img.Pix[i : i+4 : i+4]
+		light := min(p[0], p[1], p[2])
+		switch {
+		case light >= signatureWhite:
+			p[0], p[1], p[2], p[3] = 0, 0, 0, 0
+		case light > signatureSoft:
+			fade := float64(signatureWhite-light) / float64(signatureWhite-signatureSoft)
+			p[3] = uint8(math.Round(float64(p[3]) * fade))
+		}
+	}
+	return img
+}
+
+// signatureInkBounds menor retângulo que contém os traços (false se não houver nenhum)
+func signatureInkBounds(img *image.NRGBA) (image.Rectangle, bool) {
+	b := img.Bounds()
+	minX, minY, maxX, maxY := b.Max.X, b.Max.Y, b.Min.X-1, b.Min.Y-1
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if img.NRGBAAt(x, y).A < signatureMinAlpha {
+				continue
+			}
+			minX, maxX = min(minX, x), max(maxX, x)
+			minY, maxY = min(minY, y), max(maxY, y)
+		}
+	}
+	if maxX < minX {
+		return image.Rectangle{}, false
+	}
+	return image.Rect(minX, minY, maxX+1, maxY+1), true
+}
+
+// signatureTargetSize dimensões finais mantendo a proporção
+func signatureTargetSize(w, h int) (int, int) {
+	scale := float64(SignatureHeight) / float64(h)
+	if float64(w)*scale > SignatureMaxWidth {
+		scale = float64(SignatureMaxWidth) / float64(w)
+	}
+	return max(1, int(math.Round(float64(w)*scale))), max(1, int(math.Round(float64(h)*scale)))
+}
+
+// resizeNRGBA redimensiona com filtro triangular separável (bilinear ao ampliar, média da
+// área ao reduzir), em alfa pré-multiplicado para não escurecer as bordas dos traços
+func resizeNRGBA(src *image.NRGBA, w, h int) *image.NRGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	// pré-multiplica
+	buf := make([]float64, sw*sh*4)
+	for y := 0; y < sh; y++ {
+		for x := 0; x < sw; x++ {
+			c := src.NRGBAAt(b.Min.X+x, b.Min.Y+y)
+			a := float64(c.A) / 255
+			i := (y*sw + x) * 4
+			buf[i], buf[i+1], buf[i+2], buf[i+3] = float64(c.R)*a, float64(c.G)*a, float64(c.B)*a, float64(c.A)
+		}
+	}
+	buf = resampleAxis(buf, sw, sh, w, true)
+	buf = resampleAxis(buf, w, sh, h, false)
+
+	out := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < w*h; i++ {
+		a := buf[i*4+3]
+		if a <= 0 {
+			continue
+		}
+		k := 255 / a
+		out.Pix[i*4] = clampUint8(buf[i*4] * k)
+		out.Pix[i*4+1] = clampUint8(buf[i*4+1] * k)
+		out.Pix[i*4+2] = clampUint8(buf[i*4+2] * k)
+		out.Pix[i*4+3] = clampUint8(a)
+	}
+	return out
+}
+
+// resampleAxis redimensiona um eixo (horizontal ou vertical) do buffer w×h de 4 canais
+func resampleAxis(in []float64, w, h, n int, horizontal bool) []float64 {
+	size, lines := h, w
+	if horizontal {
+		size, lines = w, h
+	}
+	outW, outH := w, n
+	if horizontal {
+		outW, outH = n, h
+	}
+	out := make([]float64, outW*outH*4)
+	scale := float64(size) / float64(n)
+	support := math.Max(scale, 1)
+	idx := func(line, pos, width int) int {
+		if horizontal {
+			return (line*width + pos) * 4
+		}
+		return (pos*width + line) * 4
+	}
+	for d := 0; d < n; d++ {
+		center := (float64(d)+0.5)*scale - 0.5
+		lo := max(0, int(math.Floor(center-support)))
+		hi := min(size-1, int(math.Ceil(center+support)))
+		var weights []float64
+		var total float64
+		for s := lo; s <= hi; s++ {
+			wt := 1 - math.Abs(float64(s)-center)/support
+			if wt < 0 {
+				wt = 0
+			}
+			weights = append(weights, wt)
+			total += wt
+		}
+		if total == 0 {
+			weights[0], total = 1, 1
+		}
+		for line := 0; line < lines; line++ {
+			o := idx(line, d, outW)
+			for k, wt := range weights {
+				if wt == 0 {
+					continue
+				}
+				i := idx(line, lo+k, w)
+				for c := 0; c < 4; c++ {
+					out[o+c] += in[i+c] * wt / total
+				}
+			}
+		}
+	}
+	return out
+}
+
+func clampUint8(v float64) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 255:
+		return 255
+	}
+	return uint8(math.Round(v))
+}
@@ -0,0 +1,123 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do tratamento da imagem de assinatura (fundo, recorte e altura padrão)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// signatureScan simula uma assinatura escaneada: fundo quase branco com um traço escuro
+func signatureScan(t *testing.T, w, h int, stroke image.Rectangle) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 248, G: 246, B: 250, A: 255}
+			if (image.Point{X: x, Y: y}).In(stroke) {
+				c = color.RGBA{R: 20, G: 30, B: 90, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("falha ao gerar PNG de teste: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func decodeSignaturePNG(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("PNG tratado inválido: %v", err)
+	}
+	return img
+}
+
+func TestNormalizePNG_RemovesBackgroundTrimsAndScales(t *testing.T) {
+	svc := NewSignatureService()
+	// traço de 40x10 no meio de uma folha 300x100
+	out, err := svc.NormalizePNG(signatureScan(t, 300, 100, image.Rect(100, 40, 140, 50)))
+	if err != nil {
+		t.Fatalf("NormalizePNG retornou erro: %v", err)
+	}
+	img := decodeSignaturePNG(t, out)
+	b := img.Bounds()
+	if b.Dy() != SignatureHeight || b.Dx() != 4*SignatureHeight {
+		t.Fatalf("dimensões = %dx%d, want %dx%d (recorte + altura padrão)", b.Dx(), b.Dy(), 4*SignatureHeight, SignatureHeight)
+	}
+	if _, _, _, a := img.At(b.Dx()/2, b.Dy()/2).RGBA(); a != 0xffff {
+		t.Fatalf("centro do traço deveria ser opaco, alpha = %d", a)
+	}
+	if _, _, _, _, err := svc.ValidatePNG(out); err != nil {
+		t.Fatalf("saída deve continuar um PNG válido: %v", err)
+	}
+}
+
+func TestNormalizePNG_BackgroundBecomesTransparent(t *testing.T) {
+	svc := NewSignatureService()
+	// dois traços separados: o fundo entre eles fica dentro do recorte
+	src := image.NewRGBA(image.Rect(0, 0, 60, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 60; x++ {
+			c := color.RGBA{R: 245, G: 245, B: 245, A: 255}
+			if x < 10 || x >= 50 {
+				c = color.RGBA{A: 255}
+			}
+			src.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("falha ao gerar PNG de teste: %v", err)
+	}
+	out, err := svc.NormalizePNG(buf.Bytes())
+	if err != nil {
+		t.Fatalf("NormalizePNG retornou erro: %v", err)
+	}
+	img := decodeSignaturePNG(t, out)
+	b := img.Bounds()
+	if _, _, _, a := img.At(b.Dx()/2, b.Dy()/2).RGBA(); a != 0 {
+		t.Fatalf("fundo entre os traços deveria ser transparente, alpha = %d", a)
+	}
+	if b.Dy() != SignatureHeight {
+		t.Fatalf("altura = %d, want %d", b.Dy(), SignatureHeight)
+	}
+}
+
+func TestNormalizePNG_LimitsWidth(t *testing.T) {
+	svc := NewSignatureService()
+	// traço 20:1 fica com SignatureMaxWidth e altura menor que a padrão
+	out, err := svc.NormalizePNG(signatureScan(t, 220, 12, image.Rect(0, 1, 200, 11)))
+	if err != nil {
+		t.Fatalf("NormalizePNG retornou erro: %v", err)
+	}
+	b := decodeSignaturePNG(t, out).Bounds()
+	if b.Dx() != SignatureMaxWidth || b.Dy() != SignatureMaxWidth/20 {
+		t.Fatalf("dimensões = %dx%d, want %dx%d", b.Dx(), b.Dy(), SignatureMaxWidth, SignatureMaxWidth/20)
+	}
+}
+
+func TestNormalizePNG_Blank(t *testing.T) {
+	svc := NewSignatureService()
+	_, err := svc.NormalizePNG(signatureScan(t, 50, 20, image.Rectangle{}))
+	if !errors.Is(err, ErrSignatureBlank) {
+		t.Fatalf("esperava ErrSignatureBlank, got %v", err)
+	}
+}
+
+func TestNormalizePNG_InvalidPNG(t *testing.T) {
+	svc := NewSignatureService()
+	if _, err := svc.NormalizePNG([]byte("not a png")); err == nil {
+		t.Fatalf("esperava erro para PNG inválido")
+	}
+}
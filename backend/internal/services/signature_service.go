@@ -9,21 +9,95 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
-	"errors"
+	"fmt"
 	"image/png"
 )
 
 // SignatureService provê validação e metadados para imagens de assinatura.
-// Docstring: Valida PNG até 2MB, retorna dimensões, hash e content-type.
-// Tudo em PT-BR.
+// Docstring: Valida PNG até 2MB, dimensões, proporção e total de pixels (limites configuráveis),
+// retorna dimensões, hash e content-type. Tudo em PT-BR.
 
-type SignatureService struct{}
+type SignatureService struct {
+	limits SignatureLimits
+}
+
+// SignatureLimits limites de dimensão da imagem enviada.
+// Docstring: zero desliga o limite correspondente. Aspect é largura/altura; MaxMegapixels
+// evita "bombas de descompressão" (PNG pequeno que expande para uma imagem enorme na memória).
+type SignatureLimits struct {
+	MinWidth      int
+	MinHeight     int
+	MaxWidth      int
+	MaxHeight     int
+	MinAspect     float64
+	MaxAspect     float64
+	MaxMegapixels float64
+}
+
+// DefaultSignatureLimits limites padrão: de 50x20 a 4000x4000 px, proporção entre 1:2 e 12:1, até 16 MP
+func DefaultSignatureLimits() SignatureLimits {
+	return SignatureLimits{MinWidth: 50, MinHeight: 20, MaxWidth: 4000, MaxHeight: 4000, MinAspect: 0.5, MaxAspect: 12, MaxMegapixels: 16}
+}
+
+// SignatureServiceOption configura o serviço de assinaturas
+type SignatureServiceOption func(*SignatureService)
 
-func NewSignatureService() *SignatureService { return &SignatureService{} }
+// WithSignatureLimits substitui os limites padrão de dimensão
+func WithSignatureLimits(l SignatureLimits) SignatureServiceOption {
+	return func(s *SignatureService) { s.limits = l }
+}
+
+func NewSignatureService(opts ...SignatureServiceOption) *SignatureService {
+	s := &SignatureService{limits: DefaultSignatureLimits()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
 
 // MaxSignatureSize define o tamanho máximo do arquivo de assinatura (2MB)
 const MaxSignatureSize int64 = 2 * 1024 * 1024 // 2MB
 
+// Códigos das violações na validação da assinatura (campo code da resposta de erro)
+const (
+	SignatureCodeEmpty          = "signature_empty"
+	SignatureCodeFileTooLarge   = "signature_file_too_large"
+	SignatureCodeInvalidPNG     = "signature_invalid_png"
+	SignatureCodeWidthTooSmall  = "signature_width_too_small"
+	SignatureCodeWidthTooLarge  = "signature_width_too_large"
+	SignatureCodeHeightTooSmall = "signature_height_too_small"
+	SignatureCodeHeightTooLarge = "signature_height_too_large"
+	SignatureCodeAspectTooTall  = "signature_aspect_too_tall"
+	SignatureCodeAspectTooWide  = "signature_aspect_too_wide"
+	SignatureCodeTooManyPixels  = "signature_too_many_pixels"
+	SignatureCodeBlank          = "signature_blank"
+)
+
+// SignatureError violação na validação da imagem de assinatura.
+// Docstring: Code é estável para o frontend; Limit e Value (quando há) vão em details.
+type SignatureError struct {
+	Code    string  `json:"-"`
+	Message string  `json:"-"`
+	Limit   float64 `json:"limit"`
+	Value   float64 `json:"value"`
+}
+
+func (e *SignatureError) Error() string { return e.Message }
+
+// Details dados da resposta de erro (nil quando a violação não tem limite numérico)
+func (e *SignatureError) Details() interface{} {
+	if e.Limit == 0 {
+		return nil
+	}
+	return e
+}
+
+var (
+	errSignatureEmpty      = &SignatureError{Code: SignatureCodeEmpty, Message: "arquivo vazio"}
+	errSignatureFileSize   = &SignatureError{Code: SignatureCodeFileTooLarge, Message: "arquivo excede 2MB"}
+	errSignatureInvalidPNG = &SignatureError{Code: SignatureCodeInvalidPNG, Message: "apenas arquivos PNG válidos são aceitos"}
+)
+
 // ValidatePNG valida os bytes de uma imagem PNG e retorna dimensões e hash.
 // Parâmetros:
 // - data: conteúdo bruto do arquivo PNG
@@ -31,19 +105,31 @@ const MaxSignatureSize int64 = 2 * 1024 * 1024 // 2MB
 // - width, height: dimensões em pixels
 // - sha256hex: hash do conteúdo em hexadecimal
 // - contentType: sempre "image/png" quando válido
-// - err: erro de validação, se houver
+// - err: erro de validação (*SignatureError), se houver
 func (s *SignatureService) ValidatePNG(data []byte) (width int, height int, sha256hex string, contentType string, err error) {
 	if int64(len(data)) == 0 {
-		return 0, 0, "", "", errors.New("arquivo vazio")
+		return 0, 0, "", "", errSignatureEmpty
 	}
 	if int64(len(data)) > MaxSignatureSize {
-		return 0, 0, "", "", errors.New("arquivo excede 2MB")
+		return 0, 0, "", "", errSignatureFileSize
 	}
 
+	width, height, sha256hex, contentType, err = s.InspectPNG(data)
+	if err != nil {
+		return 0, 0, "", "", err
+	}
+	if err := s.limits.check(width, height); err != nil {
+		return 0, 0, "", "", err
+	}
+	return width, height, sha256hex, contentType, nil
+}
+
+// InspectPNG dimensões, hash e content-type de um PNG sem aplicar os limites (imagem já tratada)
+func (s *SignatureService) InspectPNG(data []byte) (width int, height int, sha256hex string, contentType string, err error) {
 	// Decodifica cabeçalho para obter dimensões (apenas PNG)
 	cfg, err := png.DecodeConfig(bytes.NewReader(data))
 	if err != nil {
-		return 0, 0, "", "", errors.New("apenas arquivos PNG válidos são aceitos")
+		return 0, 0, "", "", errSignatureInvalidPNG
 	}
 
 	// Calcula SHA-256 do conteúdo
@@ -52,3 +138,28 @@ func (s *SignatureService) ValidatePNG(data []byte) (width int, height int, sha2
 
 	return cfg.Width, cfg.Height, sha256hex, "image/png", nil
 }
+
+// check aplica os limites na ordem: pixels (antes de qualquer decodificação completa), lados, proporção
+func (l SignatureLimits) check(w, h int) error {
+	if mp := float64(w) * float64(h) / 1e6; l.MaxMegapixels > 0 && mp > l.MaxMegapixels {
+		return &SignatureError{Code: SignatureCodeTooManyPixels, Message: fmt.Sprintf("imagem excede %g megapixels", l.MaxMegapixels), Limit: l.MaxMegapixels, Value: mp}
+	}
+	switch {
+	case l.MinWidth > 0 && w < l.MinWidth:
+		return &SignatureError{Code: SignatureCodeWidthTooSmall, Message: fmt.Sprintf("largura mínima de %d px", l.MinWidth), Limit: float64(l.MinWidth), Value: float64(w)}
+	case l.MaxWidth > 0 && w > l.MaxWidth:
+		return &SignatureError{Code: SignatureCodeWidthTooLarge, Message: fmt.Sprintf("largura máxima de %d px", l.MaxWidth), Limit: float64(l.MaxWidth), Value: float64(w)}
+	case l.MinHeight > 0 && h < l.MinHeight:
+		return &SignatureError{Code: SignatureCodeHeightTooSmall, Message: fmt.Sprintf("altura mínima de %d px", l.MinHeight), Limit: float64(l.MinHeight), Value: float64(h)}
+	case l.MaxHeight > 0 && h > l.MaxHeight:
+		return &SignatureError{Code: SignatureCodeHeightTooLarge, Message: fmt.Sprintf("altura máxima de %d px", l.MaxHeight), Limit: float64(l.MaxHeight), Value: float64(h)}
+	}
+	aspect := float64(w) / float64(h)
+	switch {
+	case l.MinAspect > 0 && aspect < l.MinAspect:
+		return &SignatureError{Code: SignatureCodeAspectTooTall, Message: fmt.Sprintf("proporção largura/altura mínima de %g", l.MinAspect), Limit: l.MinAspect, Value: aspect}
+	case l.MaxAspect > 0 && aspect > l.MaxAspect:
+		return &SignatureError{Code: SignatureCodeAspectTooWide, Message: fmt.Sprintf("proporção largura/altura máxima de %g", l.MaxAspect), Limit: l.MaxAspect, Value: aspect}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ package services
 
 import (
 	"bytes"
+	"errors"
 	"image"
 	"image/color"
 	"image/png"
@@ -15,7 +16,7 @@ import (
 )
 
 func TestValidatePNG_Success(t *testing.T) {
-	img := image.NewRGBA(image.Rect(0, 0, 2, 3))
+	img := image.NewRGBA(image.Rect(0, 0, 120, 40))
 	// pinta um pixel para evitar otimizações
 	img.Set(0, 0, color.RGBA{R: 1, G: 2, B: 3, A: 255})
 	var buf bytes.Buffer
@@ -28,7 +29,7 @@ func TestValidatePNG_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ValidatePNG retornou erro inesperado: %v", err)
 	}
-	if w != 2 || h != 3 {
+	if w != 120 || h != 40 {
 		t.Fatalf("dimensões incorretas: got %dx%d", w, h)
 	}
 	if ct != "image/png" {
@@ -61,3 +62,62 @@ func TestValidatePNG_InvalidPNG(t *testing.T) {
 		t.Fatalf("mensagem de erro esperada conter 'apenas arquivos PNG', got: %v", err)
 	}
 }
+
+func encodeBlankPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatalf("falha ao gerar PNG de teste: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidatePNG_DimensionLimits(t *testing.T) {
+	svc := NewSignatureService(WithSignatureLimits(SignatureLimits{
+		MinWidth: 40, MinHeight: 20, MaxWidth: 400, MaxHeight: 200, MinAspect: 0.5, MaxAspect: 8, MaxMegapixels: 0.05,
+	}))
+	cases := []struct {
+		name  string
+		w, h  int
+		code  string
+		value float64
+	}{
+		{"largura pequena", 30, 40, SignatureCodeWidthTooSmall, 30},
+		{"largura grande", 401, 100, SignatureCodeWidthTooLarge, 401},
+		{"altura pequena", 100, 10, SignatureCodeHeightTooSmall, 10},
+		{"altura grande", 100, 201, SignatureCodeHeightTooLarge, 201},
+		{"muito alta", 40, 100, SignatureCodeAspectTooTall, 0.4},
+		{"muito larga", 360, 40, SignatureCodeAspectTooWide, 9},
+		{"pixels demais", 300, 200, SignatureCodeTooManyPixels, 0.06},
+	}
+	for _, c := range cases {
+		_, _, _, _, err := svc.ValidatePNG(encodeBlankPNG(t, c.w, c.h))
+		var se *SignatureError
+		if !errors.As(err, &se) {
+			t.Fatalf("%s: esperava *SignatureError, got %v", c.name, err)
+		}
+		if se.Code != c.code || se.Value != c.value {
+			t.Fatalf("%s: code = %s value = %g, want %s %g", c.name, se.Code, se.Value, c.code, c.value)
+		}
+	}
+	if _, _, _, _, err := svc.ValidatePNG(encodeBlankPNG(t, 200, 100)); err != nil {
+		t.Fatalf("dentro dos limites: erro inesperado %v", err)
+	}
+}
+
+func TestValidatePNG_ZeroLimitsDisabled(t *testing.T) {
+	svc := NewSignatureService(WithSignatureLimits(SignatureLimits{}))
+	if _, _, _, _, err := svc.ValidatePNG(encodeBlankPNG(t, 2, 300)); err != nil {
+		t.Fatalf("limites zerados não devem recusar: %v", err)
+	}
+}
+
+func TestValidatePNG_DefaultMegapixelCap(t *testing.T) {
+	// 4000x4001 excede o limite padrão de 16 MP por uma linha
+	svc := NewSignatureService(WithSignatureLimits(SignatureLimits{MaxMegapixels: DefaultSignatureLimits().MaxMegapixels}))
+	_, _, _, _, err := svc.ValidatePNG(encodeBlankPNG(t, 4000, 4001))
+	var se *SignatureError
+	if !errors.As(err, &se) || se.Code != SignatureCodeTooManyPixels {
+		t.Fatalf("esperava %s, got %v", SignatureCodeTooManyPixels, err)
+	}
+}
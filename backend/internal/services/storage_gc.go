@@ -0,0 +1,115 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Coleta periódica de objetos órfãos do Storage (sem registro no banco após a carência)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+	"recibofast/internal/storage"
+)
+
+// StorageGCStore operações de Storage usadas pela coleta (implementado por storage.Client)
+type StorageGCStore interface {
+	ListObjectInfos(ctx context.Context, bucket, prefix string) ([]storage.ObjectInfo, error)
+	DeleteObject(ctx context.Context, bucket, objectPath string) error
+}
+
+// StorageGCBatch caminhos conferidos no banco por consulta
+const StorageGCBatch = 500
+
+// StorageGCMaxDeletes limite de remoções por execução (um erro de referência não apaga o bucket inteiro)
+const StorageGCMaxDeletes = 1000
+
+// StorageGCService remove objetos que nenhum registro referencia.
+// Docstring: sobram objetos quando o upload dá certo e um passo posterior falha fora da
+// compensação (queda do processo, erro na compensação). Só objetos criados há mais de minAge
+// entram, o que protege uploads em andamento; a referência é conferida em rf_signatures,
+// rf_receipts e rf_artifacts (StorageGCRepository).
+type StorageGCService struct {
+	repo    repositories.StorageGCRepository
+	store   StorageGCStore
+	buckets []string
+	minAge  time.Duration
+	log     logging.Logger
+	now     func() time.Time
+}
+
+// NewStorageGCService cria a coleta para os buckets informados
+func NewStorageGCService(repo repositories.StorageGCRepository, store StorageGCStore, buckets []string, minAge time.Duration, log logging.Logger) *StorageGCService {
+	return &StorageGCService{repo: repo, store: store, buckets: buckets, minAge: minAge, log: log, now: time.Now}
+}
+
+// Collect executa uma rodada da coleta em todos os buckets.
+// Docstring: falhas ao remover um objeto são contadas e ele fica para a próxima execução;
+// falhas ao listar ou consultar o banco interrompem a rodada.
+func (s *StorageGCService) Collect(ctx context.Context) (*models.StorageGCResult, error) {
+	res := &models.StorageGCResult{}
+	cutoff := s.now().Add(-s.minAge)
+	for _, bucket := range s.buckets {
+		objects, err := s.store.ListObjectInfos(ctx, bucket, "")
+		if err != nil {
+			return res, fmt.Errorf("erro ao listar objetos do bucket %s: %w", bucket, err)
+		}
+		res.Scanned += len(objects)
+
+		var old []storage.ObjectInfo
+		for _, o := range objects {
+			// sem data de criação não há como garantir a carência
+			if !o.CreatedAt.IsZero() && o.CreatedAt.Before(cutoff) {
+				old = append(old, o)
+			}
+		}
+		for start := 0; start < len(old); start += StorageGCBatch {
+			batch := old[start:min(start+StorageGCBatch, len(old))]
+			if err := s.collectBatch(ctx, bucket, batch, res); err != nil {
+				return res, err
+			}
+			if res.Truncated {
+				return res, nil
+			}
+		}
+	}
+	return res, nil
+}
+
+func (s *StorageGCService) collectBatch(ctx context.Context, bucket string, batch []storage.ObjectInfo, res *models.StorageGCResult) error {
+	paths := make([]string, len(batch))
+	for i, o := range batch {
+		paths[i] = o.Path
+	}
+	refs, err := s.repo.Referenced(ctx, bucket, paths)
+	if err != nil {
+		return fmt.Errorf("erro ao conferir referências no bucket %s: %w", bucket, err)
+	}
+	for _, o := range batch {
+		if refs[o.Path] {
+			continue
+		}
+		res.Orphans++
+		if res.Deleted+res.StorageFails >= StorageGCMaxDeletes {
+			res.Truncated = true
+			return nil
+		}
+		err := s.store.DeleteObject(ctx, bucket, o.Path)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+			res.StorageFails++
+			s.log.Error("erro ao remover objeto órfão do Storage",
+				logging.Field{Key: "bucket", Val: bucket},
+				logging.Field{Key: "path", Val: o.Path},
+				logging.Field{Key: "error", Val: err.Error()})
+			continue
+		}
+		res.Deleted++
+		res.FreedBytes += o.Size
+	}
+	return nil
+}
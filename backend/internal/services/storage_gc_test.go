@@ -0,0 +1,110 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da coleta de objetos órfãos do Storage
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "testing"
+    "time"
+
+    "recibofast/internal/logging"
+    "recibofast/internal/storage"
+)
+
+type fakeGCStore struct {
+    objects map[string][]storage.ObjectInfo // bucket -> objetos
+    deleted []string
+    failOn  string
+}
+
+func (f *fakeGCStore) ListObjectInfos(ctx context.Context, bucket, prefix string) ([]storage.ObjectInfo, error) {
+    return f.objects[bucket], nil
+}
+func (f *fakeGCStore) DeleteObject(ctx context.Context, bucket, objectPath string) error {
+    if objectPath == f.failOn { return &storage.Error{Status: 503, Transient: true, Err: errors.New("indisponível")} }
+    f.deleted = append(f.deleted, bucket+"/"+objectPath)
+    return nil
+}
+
+type fakeGCRepo struct {
+    referenced map[string]bool
+    calls      int
+}
+
+func (f *fakeGCRepo) Referenced(ctx context.Context, bucket string, paths []string) (map[string]bool, error) {
+    f.calls++
+    out := map[string]bool{}
+    for _, p := range paths { if f.referenced[bucket+"/"+p] { out[p] = true } }
+    return out, nil
+}
+
+func TestStorageGC_DeletesOnlyOldUnreferenced(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    old, recent := now.Add(-10*24*time.Hour), now.Add(-time.Hour)
+    store := &fakeGCStore{objects: map[string][]storage.ObjectInfo{
+        "signatures": {
+            {Path: "u1/ok.png", Size: 10, CreatedAt: old},
+            {Path: "u1/orphan.png", Size: 20, CreatedAt: old},
+            {Path: "u1/uploading.png", Size: 30, CreatedAt: recent},
+            {Path: "u1/undated.png", Size: 40},
+        },
+        "receipts": {
+            {Path: "cas/ab/abc.pdf", Size: 50, CreatedAt: old},
+            {Path: "u2/lost.pdf", Size: 60, CreatedAt: old},
+        },
+    }}
+    repo := &fakeGCRepo{referenced: map[string]bool{"signatures/u1/ok.png": true, "receipts/cas/ab/abc.pdf": true}}
+    svc := NewStorageGCService(repo, store, []string{"signatures", "receipts"}, 7*24*time.Hour, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+
+    res, err := svc.Collect(context.Background())
+    if err != nil { t.Fatalf("Collect: %v", err) }
+    if fmt.Sprint(store.deleted) != "[signatures/u1/orphan.png receipts/u2/lost.pdf]" {
+        t.Fatalf("removidos = %v", store.deleted)
+    }
+    if res.Scanned != 6 || res.Orphans != 2 || res.Deleted != 2 || res.FreedBytes != 80 || res.Truncated {
+        t.Fatalf("resultado = %+v", res)
+    }
+}
+
+func TestStorageGC_FailureKeepsObjectAndContinues(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    old := now.Add(-30 * 24 * time.Hour)
+    store := &fakeGCStore{failOn: "u1/a.png", objects: map[string][]storage.ObjectInfo{
+        "signatures": {{Path: "u1/a.png", CreatedAt: old}, {Path: "u1/b.png", Size: 5, CreatedAt: old}},
+    }}
+    svc := NewStorageGCService(&fakeGCRepo{}, store, []string{"signatures"}, 24*time.Hour, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+
+    res, err := svc.Collect(context.Background())
+    if err != nil { t.Fatalf("Collect: %v", err) }
+    if res.StorageFails != 1 || res.Deleted != 1 || len(store.deleted) != 1 || store.deleted[0] != "signatures/u1/b.png" {
+        t.Fatalf("resultado = %+v, removidos = %v", res, store.deleted)
+    }
+}
+
+func TestStorageGC_BatchesAndDeleteLimit(t *testing.T) {
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    var objects []storage.ObjectInfo
+    for i := 0; i < StorageGCMaxDeletes+StorageGCBatch; i++ {
+        objects = append(objects, storage.ObjectInfo{Path: fmt.Sprintf("u/%d.png", i), CreatedAt: now.Add(-48 * time.Hour)})
+    }
+    store := &fakeGCStore{objects: map[string][]storage.ObjectInfo{"signatures": objects}}
+    repo := &fakeGCRepo{}
+    svc := NewStorageGCService(repo, store, []string{"signatures"}, 24*time.Hour, logging.NewLogger("dev"))
+    svc.now = func() time.Time { return now }
+
+    res, err := svc.Collect(context.Background())
+    if err != nil { t.Fatalf("Collect: %v", err) }
+    if !res.Truncated || res.Deleted != StorageGCMaxDeletes || len(store.deleted) != StorageGCMaxDeletes {
+        t.Fatalf("resultado = %+v; limite de remoções por execução não respeitado", res)
+    }
+    if repo.calls != StorageGCMaxDeletes/StorageGCBatch+1 {
+        t.Fatalf("consultas ao banco = %d", repo.calls)
+    }
+}
@@ -9,29 +9,128 @@ import (
 	"context"
 	"crypto/sha1"
 	"encoding/hex"
+	"fmt"
+	"strings"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/google/uuid"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
 )
 
-type SyncService struct{ db *pgxpool.Pool }
-
-func NewSyncService(db *pgxpool.Pool) *SyncService { return &SyncService{db: db} }
-
-// FetchChanges retorna um payload mínimo por enquanto.
-// Docstring: Deve retornar alterações por entidade com base em updated_at > since, incluindo soft-deletes.
-func (s *SyncService) FetchChanges(ctx context.Context, userID string, since time.Time, limit int, cursor, fields string) (interface{}, string, string, error) {
-	// TODO: Consultar tabelas rf_* aplicando owner_id = userID e updated_at > since
-	// Por ora, retorna vazio com ETag derivado do since/user/limit
-	etag := makeETag(userID + since.UTC().Format(time.RFC3339) + ":" + cursor)
-	return map[string]interface{}{
-		"incomes":  []interface{}{},
-		"payments": []interface{}{},
-		"receipts": []interface{}{},
-	}, "", etag, nil
+// syncCommitLag margem antes de "agora" no limite superior do sincronismo.
+// Docstring: updated_at é gravado no início da transação; uma transação ainda aberta pode
+// confirmar depois com data anterior ao until. Linhas dentro da margem ficam para a próxima vez.
+const syncCommitLag = 5 * time.Second
+
+// SyncService feed incremental das entidades usadas offline (receitas, pagamentos, recibos e assinaturas)
+type SyncService struct {
+	repo repositories.SyncRepository
+	now  func() time.Time
+}
+
+func NewSyncService(repo repositories.SyncRepository) *SyncService {
+	return &SyncService{repo: repo, now: time.Now}
+}
+
+// SyncSnapshot pedido ao feed já resolvido (entidades, projeção e janela) com o estado dos dados.
+// Docstring: ETag (forte) e LastModified saem do estado de cada entidade (quantidade de linhas e
+// exclusões e a alteração mais recente) e do pedido, então são conhecidos antes de listar as
+// alterações: o handler responde 304 sem consultar as linhas.
+type SyncSnapshot struct {
+	ETag         string
+	LastModified time.Time // zero quando não há linhas
+
+	cursor *models.SyncCursor
+	limit  int
+	until  time.Time
+}
+
+// Snapshot resolve o pedido e lê o estado das entidades selecionadas.
+// Docstring: entities restringe as entidades e fields projeta colunas ("receipts.numero,...");
+// com cursor valem since, until, entidades e projeção gravados nele.
+func (s *SyncService) Snapshot(ctx context.Context, ownerID uuid.UUID, since time.Time, limit int, cursor, entities, fields string) (*SyncSnapshot, error) {
+	var c *models.SyncCursor
+	if cursor != "" {
+		var err error
+		if c, err = models.DecodeSyncCursor(cursor); err != nil {
+			return nil, err
+		}
+	} else {
+		list, err := models.ParseSyncEntities(entities)
+		if err != nil {
+			return nil, err
+		}
+		projection, err := models.ParseSyncFields(fields)
+		if err != nil {
+			return nil, err
+		}
+		until := s.now().UTC().Add(-syncCommitLag).Truncate(time.Microsecond)
+		if until.Before(since) {
+			until = since
+		}
+		c = &models.SyncCursor{Since: since.UTC(), Until: until, Entities: list, Fields: projection}
+	}
+
+	snap := &SyncSnapshot{cursor: c, limit: limit}
+	var sig strings.Builder
+	fmt.Fprintf(&sig, "%s|%s|%d|%s", ownerID, c.Since.Format(time.RFC3339Nano), limit, cursor)
+	for _, entity := range c.Entities {
+		st, err := s.repo.State(ctx, ownerID, entity, c.Until)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler estado de %s: %w", entity, err)
+		}
+		fmt.Fprintf(&sig, "|%s:%d", entity, st.Count)
+		if st.LastModified != nil {
+			fmt.Fprintf(&sig, ":%s", st.LastModified.UTC().Format(time.RFC3339Nano))
+			if st.LastModified.After(snap.LastModified) {
+				snap.LastModified = st.LastModified.UTC()
+			}
+		}
+		if cols, ok := c.Fields[entity]; ok {
+			fmt.Fprintf(&sig, "=%s", strings.Join(cols, ","))
+		}
+	}
+	snap.until = c.Since
+	if snap.LastModified.After(snap.until) {
+		snap.until = snap.LastModified
+	}
+	snap.ETag = makeETag(sig.String())
+	return snap, nil
+}
+
+// FetchChanges retorna as alterações de cada entidade com since < updated_at, incluindo exclusões.
+// Docstring: limit vale por entidade; entidades com mais linhas seguem em next_cursor (que
+// carrega since, until, a projeção e a posição de cada uma).
+func (s *SyncService) FetchChanges(ctx context.Context, ownerID uuid.UUID, snap *SyncSnapshot) (*models.SyncChanges, error) {
+	c := snap.cursor
+	out := &models.SyncChanges{Changes: map[string][]models.SyncChange{}, Until: snap.until}
+	next := &models.SyncCursor{Since: c.Since, Until: c.Until, After: map[string]models.SyncPosition{}, Fields: c.Fields}
+	for _, entity := range c.Entities {
+		var after *models.SyncPosition
+		if pos, ok := c.After[entity]; ok {
+			after = &pos
+		}
+		items, err := s.repo.Changes(ctx, ownerID, entity, c.Since, c.Until, after, c.Fields[entity], snap.limit+1)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao listar alterações de %s: %w", entity, err)
+		}
+		if len(items) > snap.limit {
+			items = items[:snap.limit]
+			last := items[len(items)-1]
+			next.Entities = append(next.Entities, entity)
+			next.After[entity] = models.SyncPosition{UpdatedAt: last.UpdatedAt, ID: last.ID}
+		}
+		out.Changes[entity] = items
+	}
+	if len(next.Entities) > 0 {
+		out.NextCursor = next.Encode()
+	}
+	return out, nil
 }
 
+// makeETag ETag forte (o mesmo estado e pedido produzem sempre o mesmo corpo)
 func makeETag(s string) string {
 	h := sha1.Sum([]byte(s))
-	return "W/\"" + hex.EncodeToString(h[:]) + "\""
+	return "\"" + hex.EncodeToString(h[:]) + "\""
 }
@@ -0,0 +1,136 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Inspeção e resolução de conflitos de sincronização offline (usuário e suporte)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// SyncConflictIncomes operações de receitas usadas na resolução (implementado por IncomeService)
+type SyncConflictIncomes interface {
+	GetIncome(ctx context.Context, id, ownerID uuid.UUID) (*models.Income, error)
+	PatchIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomePatchRequest) (*models.Income, error)
+}
+
+// SyncConflictService lista e resolve os conflitos registrados pelo envio offline.
+// Docstring: a versão do servidor é sempre relida da receita atual; "manter a minha" e a
+// mesclagem aplicam os campos do dispositivo via PATCH sobre a versão atual, então a trava
+// otimista continua valendo se a receita mudar durante a resolução.
+type SyncConflictService struct {
+	repo    repositories.SyncConflictRepository
+	incomes SyncConflictIncomes
+	log     logging.Logger
+}
+
+// NewSyncConflictService cria o serviço de conflitos de sincronização
+func NewSyncConflictService(repo repositories.SyncConflictRepository, incomes SyncConflictIncomes, log logging.Logger) *SyncConflictService {
+	return &SyncConflictService{repo: repo, incomes: incomes, log: log}
+}
+
+// ListPending lista os conflitos pendentes agrupados por dispositivo, com as duas versões lado a lado
+func (s *SyncConflictService) ListPending(ctx context.Context, ownerID uuid.UUID, deviceID string) ([]models.SyncConflictDevice, error) {
+	items, err := s.repo.ListPending(ctx, ownerID, deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar conflitos de sincronização: %w", err)
+	}
+	for i := range items {
+		if err := s.refreshServer(ctx, &items[i]); err != nil {
+			return nil, err
+		}
+		items[i].Compare()
+	}
+	return models.GroupSyncConflictsByDevice(items), nil
+}
+
+// Resolve aplica a resolução escolhida e marca o conflito como resolvido.
+// Docstring: retorna o conflito e a receita resultante (nil se a receita foi excluída e a
+// resolução mantém o servidor).
+func (s *SyncConflictService) Resolve(ctx context.Context, id, ownerID uuid.UUID, resolvedBy string, res *models.SyncConflictResolution) (*models.SyncConflict, *models.Income, error) {
+	c, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.Status != models.SyncConflictPending {
+		return nil, nil, models.ErrSyncConflictResolved
+	}
+	fields, err := c.ResolvedFields(res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var income *models.Income
+	if len(fields) > 0 {
+		if income, err = s.applyFields(ctx, c, fields); err != nil {
+			return nil, nil, err
+		}
+	} else if income, err = s.incomes.GetIncome(ctx, c.EntityID, ownerID); err != nil && !errors.Is(err, models.ErrIncomeNotFound) {
+		return nil, nil, err
+	}
+
+	strategy, by := res.Strategy, resolvedBy
+	c.Resolution, c.ResolvedBy, c.ResolvedData = &strategy, &by, fields
+	if err := s.repo.Resolve(ctx, c); err != nil {
+		return nil, nil, err
+	}
+	if income != nil {
+		if err := s.setServer(c, income); err != nil {
+			return nil, nil, err
+		}
+	}
+	c.Compare()
+	s.log.Info("conflito de sincronização resolvido",
+		logging.Field{Key: "conflict_id", Val: c.ID}, logging.Field{Key: "device_id", Val: c.DeviceID},
+		logging.Field{Key: "resolution", Val: strategy}, logging.Field{Key: "resolved_by", Val: by})
+	return c, income, nil
+}
+
+// applyFields grava os campos do dispositivo sobre a versão atual da receita
+func (s *SyncConflictService) applyFields(ctx context.Context, c *models.SyncConflict, fields map[string]json.RawMessage) (*models.Income, error) {
+	current, err := s.incomes.GetIncome(ctx, c.EntityID, c.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var patch models.IncomePatchRequest
+	if err := json.Unmarshal(raw, &patch); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrSyncMergeFieldInvalid, err)
+	}
+	patch.Version = &current.Version
+	return s.incomes.PatchIncome(ctx, c.EntityID, c.OwnerID, &patch)
+}
+
+// refreshServer substitui o estado registrado pelo estado atual da receita
+func (s *SyncConflictService) refreshServer(ctx context.Context, c *models.SyncConflict) error {
+	income, err := s.incomes.GetIncome(ctx, c.EntityID, c.OwnerID)
+	if errors.Is(err, models.ErrIncomeNotFound) {
+		c.ServerDeleted = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.setServer(c, income)
+}
+
+func (s *SyncConflictService) setServer(c *models.SyncConflict, income *models.Income) error {
+	data, err := models.IncomeSyncFields(income)
+	if err != nil {
+		return err
+	}
+	c.ServerData, c.ServerVersion = data, income.Version
+	return nil
+}
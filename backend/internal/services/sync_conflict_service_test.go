@@ -0,0 +1,121 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da resolução de conflitos de sincronização (manter, mesclar e receita excluída)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "testing"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+)
+
+// fakeConflictRepo implementa repositories.SyncConflictRepository em memória
+type fakeConflictRepo struct {
+    items map[uuid.UUID]*models.SyncConflict
+}
+
+func (f *fakeConflictRepo) Create(ctx context.Context, c *models.SyncConflict) error {
+    c.ID, c.Status = uuid.New(), models.SyncConflictPending
+    f.items[c.ID] = c
+    return nil
+}
+func (f *fakeConflictRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.SyncConflict, error) {
+    c, ok := f.items[id]
+    if !ok || c.OwnerID != ownerID { return nil, models.ErrSyncConflictNotFound }
+    cp := *c
+    return &cp, nil
+}
+func (f *fakeConflictRepo) ListPending(ctx context.Context, ownerID uuid.UUID, deviceID string) ([]models.SyncConflict, error) {
+    var out []models.SyncConflict
+    for _, c := range f.items {
+        if c.OwnerID == ownerID && c.Status == models.SyncConflictPending && (deviceID == "" || c.DeviceID == deviceID) { out = append(out, *c) }
+    }
+    return out, nil
+}
+func (f *fakeConflictRepo) Resolve(ctx context.Context, c *models.SyncConflict) error {
+    if f.items[c.ID].Status != models.SyncConflictPending { return models.ErrSyncConflictResolved }
+    c.Status = models.SyncConflictResolved
+    cp := *c
+    f.items[c.ID] = &cp
+    return nil
+}
+
+// fakeConflictIncomes guarda uma receita e registra os PATCHs recebidos
+type fakeConflictIncomes struct {
+    income  *models.Income
+    patches []models.IncomePatchRequest
+}
+
+func (f *fakeConflictIncomes) GetIncome(ctx context.Context, id, ownerID uuid.UUID) (*models.Income, error) {
+    if f.income == nil || f.income.ID != id { return nil, models.ErrIncomeNotFound }
+    cp := *f.income
+    return &cp, nil
+}
+func (f *fakeConflictIncomes) PatchIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomePatchRequest) (*models.Income, error) {
+    if req.Version == nil || *req.Version != f.income.Version { return nil, models.ErrIncomeVersionConflict }
+    f.patches = append(f.patches, *req)
+    if req.Valor != nil { f.income.Valor = *req.Valor }
+    if req.Categoria != nil { f.income.Categoria = req.Categoria }
+    f.income.Version++
+    cp := *f.income
+    return &cp, nil
+}
+
+func newConflictFixture() (*SyncConflictService, *fakeConflictRepo, *fakeConflictIncomes, *models.SyncConflict) {
+    owner := uuid.New()
+    cat := "Serviços"
+    incomes := &fakeConflictIncomes{income: &models.Income{ID: uuid.New(), OwnerID: owner, Competencia: "2026-09", Valor: models.NewMoney(120), Categoria: &cat, Version: 4}}
+    repo := &fakeConflictRepo{items: map[uuid.UUID]*models.SyncConflict{}}
+    c := &models.SyncConflict{
+        OwnerID: owner, DeviceID: "android", Entity: models.SyncEntityIncome, EntityID: incomes.income.ID,
+        ClientData: map[string]json.RawMessage{"valor": json.RawMessage(`"150.00"`), "categoria": json.RawMessage(`"Aluguel"`)},
+        ServerData: map[string]json.RawMessage{}, ServerVersion: 3,
+    }
+    repo.Create(context.Background(), c)
+    return NewSyncConflictService(repo, incomes, logging.NewLogger("dev")), repo, incomes, c
+}
+
+func TestSyncConflictService_ListPendingRefreshesServer(t *testing.T) {
+    svc, _, _, c := newConflictFixture()
+    groups, err := svc.ListPending(context.Background(), c.OwnerID, "")
+    if err != nil { t.Fatalf("ListPending: %v", err) }
+    if len(groups) != 1 || len(groups[0].Conflicts) != 1 { t.Fatalf("grupos = %+v", groups) }
+    got := groups[0].Conflicts[0]
+    if got.ServerVersion != 4 || len(got.Fields) != 2 || !got.Fields[0].Differs || string(got.Fields[0].Server) != `"120.00"` {
+        t.Errorf("conflito = %+v, esperado estado atual do servidor", got)
+    }
+}
+
+func TestSyncConflictService_ResolveMergeAppliesChosenFields(t *testing.T) {
+    svc, repo, incomes, c := newConflictFixture()
+    res := &models.SyncConflictResolution{Strategy: models.SyncResolveMerge, Fields: map[string]string{"valor": "mine", "categoria": "server"}}
+    got, income, err := svc.Resolve(context.Background(), c.ID, c.OwnerID, models.SyncResolvedBySupport, res)
+    if err != nil { t.Fatalf("Resolve: %v", err) }
+    if len(incomes.patches) != 1 || incomes.patches[0].Categoria != nil || *incomes.patches[0].Version != 4 {
+        t.Fatalf("patches = %+v, esperado só valor sobre a versão 4", incomes.patches)
+    }
+    if income.Valor != models.NewMoney(150) || *income.Categoria != "Serviços" { t.Errorf("receita = %+v", income) }
+    if got.Status != models.SyncConflictResolved || *got.ResolvedBy != models.SyncResolvedBySupport { t.Errorf("conflito = %+v", got) }
+
+    if _, _, err := svc.Resolve(context.Background(), c.ID, c.OwnerID, models.SyncResolvedByUser, res); !errors.Is(err, models.ErrSyncConflictResolved) {
+        t.Errorf("segunda resolução: err = %v", err)
+    }
+    if repo.items[c.ID].ResolvedData["valor"] == nil { t.Error("resolved_data deveria registrar o valor aplicado") }
+}
+
+func TestSyncConflictService_KeepServerOnDeletedIncome(t *testing.T) {
+    svc, _, incomes, c := newConflictFixture()
+    incomes.income = nil
+    if _, _, err := svc.Resolve(context.Background(), c.ID, c.OwnerID, models.SyncResolvedByUser, &models.SyncConflictResolution{Strategy: models.SyncResolveKeepMine}); !errors.Is(err, models.ErrIncomeNotFound) {
+        t.Fatalf("keep_mine em receita excluída: err = %v", err)
+    }
+    got, income, err := svc.Resolve(context.Background(), c.ID, c.OwnerID, models.SyncResolvedByUser, &models.SyncConflictResolution{Strategy: models.SyncResolveKeepServer})
+    if err != nil || income != nil || got.Status != models.SyncConflictResolved { t.Errorf("keep_server = %+v, %+v, %v", got, income, err) }
+}
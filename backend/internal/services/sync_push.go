@@ -0,0 +1,236 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Envio offline (sync push): aplica receitas e pagamentos do dispositivo com detecção de conflitos
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// SyncPushIncomes operações de receitas usadas pelo envio offline (implementado por IncomeService)
+type SyncPushIncomes interface {
+	CreateIncome(ctx context.Context, ownerID uuid.UUID, req *models.IncomeRequest) (*models.Income, error)
+	GetIncome(ctx context.Context, id, ownerID uuid.UUID) (*models.Income, error)
+	PatchIncome(ctx context.Context, id, ownerID uuid.UUID, req *models.IncomePatchRequest) (*models.Income, error)
+	DeleteIncome(ctx context.Context, id, ownerID uuid.UUID) error
+	AddPayment(ctx context.Context, ownerID uuid.UUID, req *models.PaymentRequest) (*models.PaymentResponse, error)
+	GetIncomePayments(ctx context.Context, incomeID, ownerID uuid.UUID) ([]models.Payment, error)
+}
+
+// syncPushRejections erros de domínio que recusam só o registro (os demais interrompem o lote,
+// que pode ser reenviado inteiro: os IDs do dispositivo tornam o reenvio idempotente)
+var syncPushRejections = []error{
+	models.ErrSyncPushDataInvalid, models.ErrCompetenciaRequired, models.ErrValorInvalid,
+	models.ErrInvalidStatus, models.ErrInvalidDateFormat, models.ErrIncomeNotFound,
+	models.ErrIncomeVersionConflict, models.ErrPayerNotFound, models.ErrInsufficientAmount,
+	models.ErrIncomeAlreadyPaid, models.ErrPaymentMethodNotFound, models.ErrPaymentMethodArchived,
+}
+
+// SyncPushService aplica o lote enviado pelo dispositivo ao reconectar.
+// Docstring: uma edição é concorrente quando a receita mudou no servidor depois da versão lida
+// pelo dispositivo por outra origem (vetor de versões em rf_sync_versions). Nesse caso vence a
+// edição mais recente (last-write-wins por updated_at); se o servidor vencer, a edição do
+// dispositivo fica registrada como conflito pendente para o usuário ou o suporte.
+type SyncPushService struct {
+	incomes   SyncPushIncomes
+	conflicts repositories.SyncConflictRepository
+	versions  repositories.SyncVersionRepository
+	log       logging.Logger
+	now       func() time.Time
+}
+
+// NewSyncPushService cria o serviço de envio offline
+func NewSyncPushService(incomes SyncPushIncomes, conflicts repositories.SyncConflictRepository, versions repositories.SyncVersionRepository, log logging.Logger) *SyncPushService {
+	return &SyncPushService{incomes: incomes, conflicts: conflicts, versions: versions, log: log, now: time.Now}
+}
+
+// Push aplica receitas e depois pagamentos, devolvendo o resultado de cada registro
+func (s *SyncPushService) Push(ctx context.Context, ownerID uuid.UUID, req *models.SyncPushRequest) (*models.SyncPushResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	out := &models.SyncPushResponse{Results: make([]models.SyncPushResult, 0, len(req.Incomes)+len(req.Payments))}
+	for i := range req.Incomes {
+		res, err := s.pushIncome(ctx, ownerID, req.DeviceID, &req.Incomes[i])
+		if err != nil {
+			return nil, fmt.Errorf("erro ao sincronizar receita %s: %w", req.Incomes[i].ID, err)
+		}
+		out.Results = append(out.Results, res)
+	}
+	for i := range req.Payments {
+		res, err := s.pushPayment(ctx, ownerID, &req.Payments[i])
+		if err != nil {
+			return nil, fmt.Errorf("erro ao sincronizar pagamento %s: %w", req.Payments[i].ID, err)
+		}
+		out.Results = append(out.Results, res)
+	}
+	return out, nil
+}
+
+func (s *SyncPushService) pushIncome(ctx context.Context, ownerID uuid.UUID, deviceID string, c *models.SyncIncomeChange) (models.SyncPushResult, error) {
+	res := models.SyncPushResult{Entity: models.SyncFeedIncomes, ID: c.ID}
+	current, err := s.incomes.GetIncome(ctx, c.ID, ownerID)
+	if errors.Is(err, models.ErrIncomeNotFound) {
+		switch {
+		case c.Deleted:
+			res.Status = models.SyncPushUnchanged
+			return res, nil
+		case c.BaseVersion != nil:
+			// editada offline, mas excluída no servidor
+			return rejectSyncPush(res, err)
+		}
+		return s.createIncome(ctx, ownerID, deviceID, c, res)
+	}
+	if err != nil {
+		return res, err
+	}
+
+	vector, err := s.versions.Vector(ctx, ownerID, c.ID)
+	if err != nil {
+		return res, err
+	}
+	if models.SyncVersionsConcurrent(vector, deviceID, c.BaseVersion, current.Version) {
+		if !s.clientWins(c, current) {
+			return s.recordConflict(ctx, ownerID, deviceID, c, current, res)
+		}
+		res.Resolution = models.SyncPushClientWins
+	}
+
+	if c.Deleted {
+		if err := s.incomes.DeleteIncome(ctx, c.ID, ownerID); err != nil {
+			return rejectSyncPush(res, err)
+		}
+		res.Status = models.SyncPushDeleted
+		return res, nil
+	}
+	patch, err := models.IncomePatchFromSync(c.Data, current.Version)
+	if err != nil {
+		return rejectSyncPush(res, err)
+	}
+	updated, err := s.incomes.PatchIncome(ctx, c.ID, ownerID, patch)
+	if err != nil {
+		return rejectSyncPush(res, err)
+	}
+	if err := s.versions.Set(ctx, ownerID, c.ID, deviceID, updated.Version); err != nil {
+		return res, err
+	}
+	res.Status, res.Version = models.SyncPushUpdated, updated.Version
+	return res, nil
+}
+
+func (s *SyncPushService) createIncome(ctx context.Context, ownerID uuid.UUID, deviceID string, c *models.SyncIncomeChange, res models.SyncPushResult) (models.SyncPushResult, error) {
+	req, err := models.IncomeRequestFromSync(c.ID, c.Data)
+	if err != nil {
+		return rejectSyncPush(res, err)
+	}
+	income, err := s.incomes.CreateIncome(ctx, ownerID, req)
+	if err != nil {
+		return rejectSyncPush(res, err)
+	}
+	if err := s.versions.Set(ctx, ownerID, c.ID, deviceID, income.Version); err != nil {
+		return res, err
+	}
+	res.Status, res.Version = models.SyncPushCreated, income.Version
+	return res, nil
+}
+
+// clientWins last-write-wins entre a edição do dispositivo e a última alteração no servidor.
+// Docstring: datas do dispositivo no futuro valem "agora" (relógio adiantado não vence sempre);
+// empate fica com o servidor.
+func (s *SyncPushService) clientWins(c *models.SyncIncomeChange, current *models.Income) bool {
+	clientAt := c.UpdatedAt
+	if now := s.now(); clientAt.After(now) {
+		clientAt = now
+	}
+	return current.UpdatedAt == nil || clientAt.After(*current.UpdatedAt)
+}
+
+// recordConflict registra a edição perdedora como conflito pendente (exclusões perdedoras só
+// são informadas: não há campos a recuperar)
+func (s *SyncPushService) recordConflict(ctx context.Context, ownerID uuid.UUID, deviceID string, c *models.SyncIncomeChange, current *models.Income, res models.SyncPushResult) (models.SyncPushResult, error) {
+	res.Status, res.Resolution, res.Version = models.SyncPushConflict, models.SyncPushServerWins, current.Version
+	if c.Deleted {
+		return res, nil
+	}
+	// Reenvio do mesmo lote: reaproveita o conflito pendente em vez de duplicá-lo
+	pending, err := s.conflicts.ListPending(ctx, ownerID, deviceID)
+	if err != nil {
+		return res, err
+	}
+	for _, p := range pending {
+		if p.EntityID == c.ID && p.ServerVersion == current.Version && sameInt64(p.BaseVersion, c.BaseVersion) {
+			res.ConflictID = &p.ID
+			return res, nil
+		}
+	}
+	server, err := models.IncomeSyncFields(current)
+	if err != nil {
+		return res, err
+	}
+	conflict := &models.SyncConflict{
+		OwnerID:       ownerID,
+		DeviceID:      deviceID,
+		Entity:        models.SyncEntityIncome,
+		EntityID:      c.ID,
+		BaseVersion:   c.BaseVersion,
+		ClientData:    c.Data,
+		ServerData:    server,
+		ServerVersion: current.Version,
+	}
+	if err := s.conflicts.Create(ctx, conflict); err != nil {
+		return res, err
+	}
+	res.ConflictID = &conflict.ID
+	s.log.Info("conflito de sincronização registrado",
+		logging.Field{Key: "conflict_id", Val: conflict.ID}, logging.Field{Key: "device_id", Val: deviceID},
+		logging.Field{Key: "income_id", Val: c.ID})
+	return res, nil
+}
+
+func (s *SyncPushService) pushPayment(ctx context.Context, ownerID uuid.UUID, p *models.SyncPaymentChange) (models.SyncPushResult, error) {
+	res := models.SyncPushResult{Entity: models.SyncFeedPayments, ID: p.ID}
+	existing, err := s.incomes.GetIncomePayments(ctx, p.IncomeID, ownerID)
+	if err != nil {
+		return rejectSyncPush(res, err)
+	}
+	for _, e := range existing {
+		if e.ID == p.ID {
+			res.Status = models.SyncPushUnchanged
+			return res, nil
+		}
+	}
+	resp, err := s.incomes.AddPayment(ctx, ownerID, p.PaymentRequest())
+	if err != nil {
+		return rejectSyncPush(res, err)
+	}
+	res.Status, res.Version = models.SyncPushCreated, resp.Income.Version
+	return res, nil
+}
+
+// rejectSyncPush recusa só o registro em erros de domínio; os demais sobem e interrompem o lote
+func rejectSyncPush(res models.SyncPushResult, err error) (models.SyncPushResult, error) {
+	for _, target := range syncPushRejections {
+		if errors.Is(err, target) {
+			res.Status, res.Error = models.SyncPushRejected, target.Error()
+			return res, nil
+		}
+	}
+	return res, err
+}
+
+func sameInt64(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
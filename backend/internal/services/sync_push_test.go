@@ -0,0 +1,143 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do envio offline (criação, reenvio, edições concorrentes e pagamentos)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+// fakeVersionRepo implementa repositories.SyncVersionRepository em memória
+type fakeVersionRepo struct {
+    vectors map[uuid.UUID]map[string]int64
+}
+
+func (f *fakeVersionRepo) Vector(ctx context.Context, ownerID, incomeID uuid.UUID) (map[string]int64, error) {
+    out := map[string]int64{}
+    for d, v := range f.vectors[incomeID] { out[d] = v }
+    return out, nil
+}
+func (f *fakeVersionRepo) Set(ctx context.Context, ownerID, incomeID uuid.UUID, deviceID string, version int64) error {
+    if f.vectors[incomeID] == nil { f.vectors[incomeID] = map[string]int64{} }
+    f.vectors[incomeID][deviceID] = version
+    return nil
+}
+
+func newSyncPushFixture() (*SyncPushService, IncomeService, *fakeConflictRepo) {
+    incomes := NewIncomeService(repositories.NewMemoryIncomeRepository())
+    conflicts := &fakeConflictRepo{items: map[uuid.UUID]*models.SyncConflict{}}
+    svc := NewSyncPushService(incomes, conflicts, &fakeVersionRepo{vectors: map[uuid.UUID]map[string]int64{}}, logging.NewLogger("dev"))
+    return svc, incomes, conflicts
+}
+
+func syncData(kv ...string) map[string]json.RawMessage {
+    out := map[string]json.RawMessage{}
+    for i := 0; i+1 < len(kv); i += 2 { out[kv[i]] = json.RawMessage(kv[i+1]) }
+    return out
+}
+
+func TestSyncPush_CreatesIncomeWithClientIDAndRetryIsNotAConflict(t *testing.T) {
+    svc, incomes, conflicts := newSyncPushFixture()
+    ctx, owner, id := context.Background(), uuid.New(), uuid.New()
+    req := &models.SyncPushRequest{DeviceID: "android", Incomes: []models.SyncIncomeChange{{
+        ID: id, UpdatedAt: time.Now(), Data: syncData("competencia", `"2026-10"`, "valor", `"250.00"`),
+    }}}
+
+    out, err := svc.Push(ctx, owner, req)
+    if err != nil { t.Fatalf("Push: %v", err) }
+    if r := out.Results[0]; r.Status != models.SyncPushCreated || r.ID != id || r.Version != 1 { t.Fatalf("resultado = %+v", r) }
+    inc, err := incomes.GetIncome(ctx, id, owner)
+    if err != nil || inc.Valor != models.NewMoney(250) { t.Fatalf("receita = %+v, err %v", inc, err) }
+
+    // resposta perdida: o dispositivo reenvia o mesmo lote (versão 1 foi gravada por ele)
+    out, err = svc.Push(ctx, owner, req)
+    if err != nil { t.Fatalf("reenvio: %v", err) }
+    if r := out.Results[0]; r.Status != models.SyncPushUpdated || r.Resolution != "" { t.Fatalf("reenvio = %+v", r) }
+    if len(conflicts.items) != 0 { t.Fatalf("reenvio não deveria gerar conflito") }
+}
+
+func TestSyncPush_ConcurrentEditServerWinsRecordsConflictOnce(t *testing.T) {
+    svc, incomes, conflicts := newSyncPushFixture()
+    ctx, owner := context.Background(), uuid.New()
+    inc, _ := incomes.CreateIncome(ctx, owner, &models.IncomeRequest{Competencia: "2026-10", Valor: models.NewMoney(100)})
+    base := inc.Version
+    // alteração feita na web depois da leitura do dispositivo
+    web := models.NewMoney(110)
+    if _, err := incomes.PatchIncome(ctx, inc.ID, owner, &models.IncomePatchRequest{Valor: &web, Version: &base}); err != nil { t.Fatal(err) }
+
+    req := &models.SyncPushRequest{DeviceID: "android", Incomes: []models.SyncIncomeChange{{
+        ID: inc.ID, BaseVersion: &base, UpdatedAt: time.Now().Add(-time.Hour), Data: syncData("valor", `"150.00"`),
+    }}}
+    out, err := svc.Push(ctx, owner, req)
+    if err != nil { t.Fatalf("Push: %v", err) }
+    r := out.Results[0]
+    if r.Status != models.SyncPushConflict || r.Resolution != models.SyncPushServerWins || r.ConflictID == nil { t.Fatalf("resultado = %+v", r) }
+    c := conflicts.items[*r.ConflictID]
+    if c.DeviceID != "android" || c.ServerVersion != base+1 || string(c.ClientData["valor"]) != `"150.00"` { t.Fatalf("conflito = %+v", c) }
+    cur, _ := incomes.GetIncome(ctx, inc.ID, owner)
+    if cur.Valor != web { t.Fatalf("servidor venceu, valor = %v", cur.Valor) }
+
+    out, err = svc.Push(ctx, owner, req)
+    if err != nil { t.Fatalf("reenvio: %v", err) }
+    if out.Results[0].ConflictID == nil || *out.Results[0].ConflictID != *r.ConflictID || len(conflicts.items) != 1 { t.Fatalf("reenvio duplicou o conflito: %+v", out.Results[0]) }
+}
+
+func TestSyncPush_ConcurrentEditClientWinsWhenNewer(t *testing.T) {
+    svc, incomes, conflicts := newSyncPushFixture()
+    svc.now = func() time.Time { return time.Now().Add(time.Minute) }
+    ctx, owner := context.Background(), uuid.New()
+    inc, _ := incomes.CreateIncome(ctx, owner, &models.IncomeRequest{Competencia: "2026-10", Valor: models.NewMoney(100)})
+    base := inc.Version
+    web := models.NewMoney(110)
+    incomes.PatchIncome(ctx, inc.ID, owner, &models.IncomePatchRequest{Valor: &web, Version: &base})
+
+    out, err := svc.Push(ctx, owner, &models.SyncPushRequest{DeviceID: "android", Incomes: []models.SyncIncomeChange{{
+        ID: inc.ID, BaseVersion: &base, UpdatedAt: time.Now().Add(30 * time.Second), Data: syncData("valor", `"150.00"`),
+    }}})
+    if err != nil { t.Fatalf("Push: %v", err) }
+    if r := out.Results[0]; r.Status != models.SyncPushUpdated || r.Resolution != models.SyncPushClientWins { t.Fatalf("resultado = %+v", r) }
+    cur, _ := incomes.GetIncome(ctx, inc.ID, owner)
+    if cur.Valor != models.NewMoney(150) || len(conflicts.items) != 0 { t.Fatalf("valor = %v, conflitos = %d", cur.Valor, len(conflicts.items)) }
+}
+
+func TestSyncPush_PaymentsAreIdempotentAndRejectedPerRecord(t *testing.T) {
+    svc, incomes, _ := newSyncPushFixture()
+    ctx, owner, incomeID, payID := context.Background(), uuid.New(), uuid.New(), uuid.New()
+    req := &models.SyncPushRequest{
+        DeviceID: "android",
+        Incomes:  []models.SyncIncomeChange{{ID: incomeID, UpdatedAt: time.Now(), Data: syncData("competencia", `"2026-10"`, "valor", `"100.00"`)}},
+        Payments: []models.SyncPaymentChange{
+            {ID: payID, IncomeID: incomeID, Valor: models.NewMoney(40), CreatedAt: time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)},
+            {ID: uuid.New(), IncomeID: uuid.New(), Valor: models.NewMoney(10)},
+        },
+    }
+    out, err := svc.Push(ctx, owner, req)
+    if err != nil { t.Fatalf("Push: %v", err) }
+    if r := out.Results[1]; r.Entity != models.SyncFeedPayments || r.Status != models.SyncPushCreated { t.Fatalf("pagamento = %+v", r) }
+    if r := out.Results[2]; r.Status != models.SyncPushRejected || r.Error != models.ErrIncomeNotFound.Error() { t.Fatalf("pagamento órfão = %+v", r) }
+    pays, _ := incomes.GetIncomePayments(ctx, incomeID, owner)
+    if len(pays) != 1 || pays[0].ID != payID || !pays[0].PagoEm.Equal(time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)) { t.Fatalf("pagamentos = %+v", pays) }
+
+    out, err = svc.Push(ctx, owner, req)
+    if err != nil { t.Fatalf("reenvio: %v", err) }
+    if out.Results[1].Status != models.SyncPushUnchanged { t.Fatalf("reenvio do pagamento = %+v", out.Results[1]) }
+    pays, _ = incomes.GetIncomePayments(ctx, incomeID, owner)
+    if len(pays) != 1 { t.Fatalf("pagamento duplicado: %d", len(pays)) }
+}
+
+func TestSyncPush_InvalidBatch(t *testing.T) {
+    svc, _, _ := newSyncPushFixture()
+    _, err := svc.Push(context.Background(), uuid.New(), &models.SyncPushRequest{Payments: []models.SyncPaymentChange{{ID: uuid.New(), IncomeID: uuid.New()}}})
+    if !errors.Is(err, models.ErrSyncDeviceRequired) { t.Fatalf("err = %v", err) }
+}
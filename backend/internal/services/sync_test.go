@@ -0,0 +1,174 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do feed de sincronização (janela, paginação por cursor e ETag)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "errors"
+    "sort"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/models"
+)
+
+// fakeSyncRepo implementa repositories.SyncRepository sobre listas por entidade
+type fakeSyncRepo struct {
+    rows    map[string][]models.SyncChange
+    calls   []string
+    columns map[string][]string
+}
+
+func (f *fakeSyncRepo) Changes(ctx context.Context, ownerID uuid.UUID, entity string, since, until time.Time, after *models.SyncPosition, columns []string, limit int) ([]models.SyncChange, error) {
+    f.calls = append(f.calls, entity)
+    if columns != nil {
+        if f.columns == nil { f.columns = map[string][]string{} }
+        f.columns[entity] = columns
+    }
+    all := append([]models.SyncChange(nil), f.rows[entity]...)
+    sort.Slice(all, func(i, j int) bool {
+        if !all[i].UpdatedAt.Equal(all[j].UpdatedAt) { return all[i].UpdatedAt.Before(all[j].UpdatedAt) }
+        return all[i].ID.String() < all[j].ID.String()
+    })
+    out := []models.SyncChange{}
+    for _, c := range all {
+        if !c.UpdatedAt.After(since) || c.UpdatedAt.After(until) { continue }
+        if after != nil && (c.UpdatedAt.Before(after.UpdatedAt) || c.UpdatedAt.Equal(after.UpdatedAt) && c.ID.String() <= after.ID.String()) { continue }
+        if len(out) == limit { break }
+        out = append(out, c)
+    }
+    return out, nil
+}
+
+func (f *fakeSyncRepo) State(ctx context.Context, ownerID uuid.UUID, entity string, until time.Time) (models.SyncEntityState, error) {
+    var st models.SyncEntityState
+    for _, c := range f.rows[entity] {
+        if c.UpdatedAt.After(until) { continue }
+        st.Count++
+        if st.LastModified == nil || c.UpdatedAt.After(*st.LastModified) { at := c.UpdatedAt; st.LastModified = &at }
+    }
+    return st, nil
+}
+
+// fetchChanges Snapshot seguido de FetchChanges, como no handler
+func fetchChanges(svc *SyncService, ctx context.Context, ownerID uuid.UUID, since time.Time, limit int, cursor, entities, fields string) (*models.SyncChanges, string, error) {
+    snap, err := svc.Snapshot(ctx, ownerID, since, limit, cursor, entities, fields)
+    if err != nil { return nil, "", err }
+    page, err := svc.FetchChanges(ctx, ownerID, snap)
+    return page, snap.ETag, err
+}
+
+func newTestSync(rows map[string][]models.SyncChange, now time.Time) (*SyncService, *fakeSyncRepo) {
+    repo := &fakeSyncRepo{rows: rows}
+    svc := NewSyncService(repo)
+    svc.now = func() time.Time { return now }
+    return svc, repo
+}
+
+func TestFetchChanges_PaginatesPerEntityWithCursor(t *testing.T) {
+    base := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+    var incomes []models.SyncChange
+    for i := 0; i < 5; i++ {
+        incomes = append(incomes, models.SyncChange{ID: uuid.New(), UpdatedAt: base.Add(time.Duration(i) * time.Minute)})
+    }
+    incomes[4].Deleted = true
+    payments := []models.SyncChange{{ID: uuid.New(), UpdatedAt: base}}
+    // Fora da janela: alterado depois de until (agora - syncCommitLag)
+    late := models.SyncChange{ID: uuid.New(), UpdatedAt: base.Add(time.Hour)}
+    svc, repo := newTestSync(map[string][]models.SyncChange{
+        models.SyncFeedIncomes: append(incomes, late), models.SyncFeedPayments: payments,
+    }, base.Add(time.Hour))
+    owner := uuid.New()
+
+    page, _, err := fetchChanges(svc, context.Background(), owner, base.Add(-time.Second), 2, "", "incomes,payments", "")
+    if err != nil { t.Fatalf("FetchChanges: %v", err) }
+    if len(page.Changes[models.SyncFeedIncomes]) != 2 || len(page.Changes[models.SyncFeedPayments]) != 1 || page.NextCursor == "" {
+        t.Fatalf("primeira página = %+v", page)
+    }
+    // until = última alteração dentro da janela (a linha "late" fica para a próxima vez)
+    if !page.Until.Equal(base.Add(4 * time.Minute)) { t.Fatalf("until = %s", page.Until) }
+
+    var got []models.SyncChange
+    got = append(got, page.Changes[models.SyncFeedIncomes]...)
+    repo.calls = nil
+    for page.NextCursor != "" {
+        if page, _, err = fetchChanges(svc, context.Background(), owner, time.Time{}, 2, page.NextCursor, "", ""); err != nil { t.Fatalf("FetchChanges(cursor): %v", err) }
+        if _, ok := page.Changes[models.SyncFeedPayments]; ok { t.Fatalf("pagamentos já concluídos voltaram no cursor") }
+        got = append(got, page.Changes[models.SyncFeedIncomes]...)
+    }
+    if len(got) != 5 || got[4].ID != incomes[4].ID || !got[4].Deleted { t.Fatalf("receitas = %+v", got) }
+    if len(repo.calls) != 2 { t.Fatalf("consultas com cursor = %v, want só incomes ×2", repo.calls) }
+}
+
+func TestFetchChanges_ETagStableUntilChange(t *testing.T) {
+    now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+    rows := map[string][]models.SyncChange{models.SyncFeedReceipts: {{ID: uuid.New(), UpdatedAt: now.Add(-time.Minute)}}}
+    svc, _ := newTestSync(rows, now)
+    owner, since := uuid.New(), now.Add(-time.Hour)
+
+    _, etag1, _ := fetchChanges(svc, context.Background(), owner, since, 10, "", "", "")
+    svc.now = func() time.Time { return now.Add(time.Second) }
+    _, etag2, _ := fetchChanges(svc, context.Background(), owner, since, 10, "", "", "")
+    if etag1 != etag2 { t.Fatalf("ETag mudou sem alterações: %s != %s", etag1, etag2) }
+
+    rows[models.SyncFeedReceipts] = append(rows[models.SyncFeedReceipts], models.SyncChange{ID: uuid.New(), UpdatedAt: now.Add(-30 * time.Second), Deleted: true})
+    _, etag3, _ := fetchChanges(svc, context.Background(), owner, since, 10, "", "", "")
+    if etag3 == etag1 { t.Fatal("ETag deveria mudar com a exclusão") }
+    if etag3[0] != '"' { t.Fatalf("ETag deveria ser forte: %s", etag3) }
+}
+
+func TestSnapshot_StateBeforeListingRows(t *testing.T) {
+    now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+    id := uuid.New()
+    rows := map[string][]models.SyncChange{models.SyncFeedIncomes: {{ID: id, UpdatedAt: now.Add(-time.Minute)}}}
+    svc, repo := newTestSync(rows, now)
+    owner, since := uuid.New(), now.Add(-time.Hour)
+
+    snap, err := svc.Snapshot(context.Background(), owner, since, 10, "", "incomes", "")
+    if err != nil { t.Fatalf("Snapshot: %v", err) }
+    if len(repo.calls) != 0 { t.Fatalf("Snapshot não deveria listar linhas: %v", repo.calls) }
+    if !snap.LastModified.Equal(now.Add(-time.Minute)) { t.Fatalf("LastModified = %s", snap.LastModified) }
+
+    // mesma quantidade de linhas, mas alterada: muda a última data e o ETag
+    rows[models.SyncFeedIncomes][0].UpdatedAt = now.Add(-20 * time.Second)
+    snap2, _ := svc.Snapshot(context.Background(), owner, since, 10, "", "incomes", "")
+    if snap2.ETag == snap.ETag || !snap2.LastModified.After(snap.LastModified) { t.Fatalf("estado alterado não mudou o ETag: %+v", snap2) }
+
+    // sem linhas: until volta o próprio since
+    empty, _ := newTestSync(nil, now)
+    snap3, _ := empty.Snapshot(context.Background(), owner, since, 10, "", "", "")
+    page, _ := empty.FetchChanges(context.Background(), owner, snap3)
+    if !snap3.LastModified.IsZero() || !page.Until.Equal(since) { t.Fatalf("sem linhas: last=%s until=%s", snap3.LastModified, page.Until) }
+}
+
+func TestFetchChanges_InvalidInput(t *testing.T) {
+    svc, _ := newTestSync(nil, time.Now())
+    if _, _, err := fetchChanges(svc, context.Background(), uuid.New(), time.Time{}, 10, "", "contracts", ""); !errors.Is(err, models.ErrSyncEntityInvalid) { t.Fatalf("err = %v", err) }
+    if _, _, err := fetchChanges(svc, context.Background(), uuid.New(), time.Time{}, 10, "xx", "", ""); !errors.Is(err, models.ErrSyncCursorInvalid) { t.Fatalf("err = %v", err) }
+    if _, _, err := fetchChanges(svc, context.Background(), uuid.New(), time.Time{}, 10, "", "", "numero"); !errors.Is(err, models.ErrSyncFieldInvalid) { t.Fatalf("err = %v", err) }
+}
+
+func TestFetchChanges_ProjectsColumnsAcrossPages(t *testing.T) {
+    base := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+    receipts := []models.SyncChange{{ID: uuid.New(), UpdatedAt: base}, {ID: uuid.New(), UpdatedAt: base.Add(time.Minute)}}
+    svc, repo := newTestSync(map[string][]models.SyncChange{models.SyncFeedReceipts: receipts}, base.Add(time.Hour))
+    owner := uuid.New()
+
+    page, etagFull, err := fetchChanges(svc, context.Background(), owner, base.Add(-time.Second), 1, "", "receipts", "")
+    if err != nil { t.Fatalf("FetchChanges: %v", err) }
+    if repo.columns != nil { t.Fatalf("sem fields não deveria projetar: %v", repo.columns) }
+
+    page, etagProj, err := fetchChanges(svc, context.Background(), owner, base.Add(-time.Second), 1, "", "receipts", "receipts.valor, receipts.numero")
+    if err != nil { t.Fatalf("FetchChanges(fields): %v", err) }
+    if got := repo.columns[models.SyncFeedReceipts]; len(got) != 2 || got[0] != "numero" || got[1] != "valor" { t.Fatalf("colunas = %v", got) }
+    if len(repo.calls) != 2 || repo.calls[1] != models.SyncFeedReceipts { t.Fatalf("entidades consultadas = %v", repo.calls) }
+    if etagProj == etagFull { t.Fatal("ETag deveria variar com a projeção") }
+
+    repo.columns = nil
+    if _, _, err = fetchChanges(svc, context.Background(), owner, time.Time{}, 1, page.NextCursor, "", ""); err != nil { t.Fatalf("FetchChanges(cursor): %v", err) }
+    if len(repo.columns[models.SyncFeedReceipts]) != 2 { t.Fatalf("projeção perdida no cursor: %v", repo.columns) }
+}
@@ -0,0 +1,316 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Webhooks do usuário: cadastro, publicação dos eventos da outbox e envio assinado com HMAC
+// Data: 18-10-2026
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"recibofast/internal/logging"
+	"recibofast/internal/models"
+	"recibofast/internal/repositories"
+)
+
+// Cabeçalhos enviados em cada POST de webhook.
+// Docstring: a assinatura é "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + corpo)); o
+// receptor deve recusar timestamps antigos (ex.: mais de 5 minutos) e deduplicar pelo id do
+// evento no corpo, pois a entrega é "pelo menos uma vez".
+const (
+	WebhookHeaderEvent     = "X-ReciboFast-Event"
+	WebhookHeaderDelivery  = "X-ReciboFast-Delivery"
+	WebhookHeaderTimestamp = "X-ReciboFast-Timestamp"
+	WebhookHeaderSignature = "X-ReciboFast-Signature"
+)
+
+// WebhookTimeout tempo máximo de resposta do endpoint do usuário
+const WebhookTimeout = 10 * time.Second
+
+// SignWebhookPayload calcula a assinatura do corpo enviado no instante ts (segundos Unix)
+func SignWebhookPayload(secret string, ts int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookSecret gera um segredo aleatório de 32 bytes
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// WebhookService gerencia os webhooks do usuário e consulta o log de entregas.
+// Docstring: o segredo só é devolvido na criação e na rotação; as entregas ficam na fila
+// rf_deliveries com canal webhook e destino igual ao id do webhook.
+type WebhookService struct {
+	repo       repositories.WebhookRepository
+	deliveries *DeliveryService
+	log        logging.Logger
+}
+
+// NewWebhookService cria o serviço de webhooks
+func NewWebhookService(repo repositories.WebhookRepository, deliveries *DeliveryService, log logging.Logger) *WebhookService {
+	return &WebhookService{repo: repo, deliveries: deliveries, log: log}
+}
+
+// Create cadastra um webhook ativo com segredo novo
+func (s *WebhookService) Create(ctx context.Context, ownerID uuid.UUID, req *models.WebhookRequest) (*models.Webhook, error) {
+	if err := req.Validate(true); err != nil {
+		return nil, err
+	}
+	n, err := s.repo.Count(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao contar webhooks: %w", err)
+	}
+	if n >= models.MaxWebhooks {
+		return nil, models.ErrWebhookLimit
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar segredo do webhook: %w", err)
+	}
+	w := &models.Webhook{OwnerID: ownerID, Secret: secret, Active: true}
+	req.Apply(w)
+	if err := s.repo.Create(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// List webhooks do usuário (sem o segredo)
+func (s *WebhookService) List(ctx context.Context, ownerID uuid.UUID) ([]models.Webhook, error) {
+	items, err := s.repo.List(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar webhooks: %w", err)
+	}
+	for i := range items {
+		items[i].Secret = ""
+	}
+	return items, nil
+}
+
+// Get webhook do usuário (sem o segredo)
+func (s *WebhookService) Get(ctx context.Context, id, ownerID uuid.UUID) (*models.Webhook, error) {
+	w, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	w.Secret = ""
+	return w, nil
+}
+
+// Update altera URL, eventos, descrição ou situação do webhook
+func (s *WebhookService) Update(ctx context.Context, id, ownerID uuid.UUID, req *models.WebhookRequest) (*models.Webhook, error) {
+	if err := req.Validate(false); err != nil {
+		return nil, err
+	}
+	w, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(w)
+	if err := s.repo.Update(ctx, w); err != nil {
+		return nil, err
+	}
+	w.Secret = ""
+	return w, nil
+}
+
+// RotateSecret gera um novo segredo; entregas ainda na fila passam a ser assinadas com ele
+func (s *WebhookService) RotateSecret(ctx context.Context, id, ownerID uuid.UUID) (*models.Webhook, error) {
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar segredo do webhook: %w", err)
+	}
+	return s.repo.RotateSecret(ctx, id, ownerID, secret)
+}
+
+// Delete remove o webhook
+func (s *WebhookService) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	return s.repo.Delete(ctx, id, ownerID)
+}
+
+// Ping enfileira um evento webhook.ping para testar o endpoint (aparece no log de entregas)
+func (s *WebhookService) Ping(ctx context.Context, id, ownerID uuid.UUID) (*models.Delivery, error) {
+	w, err := s.repo.GetByID(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(map[string]interface{}{"webhook_id": w.ID, "url": w.URL})
+	env := models.WebhookEnvelope{ID: uuid.New(), Type: models.EventWebhookPing, CreatedAt: time.Now().UTC(), Data: data}
+	return s.deliveries.Enqueue(ctx, ownerID, models.DeliveryChannelWebhook, w.ID.String(), env.Type, env)
+}
+
+// Deliveries log de entregas do webhook (todas as situações, das mais recentes às mais antigas)
+func (s *WebhookService) Deliveries(ctx context.Context, id, ownerID uuid.UUID, filter *models.DeliveryFilter) (*models.DeliveryListResponse, error) {
+	if _, err := s.repo.GetByID(ctx, id, ownerID); err != nil {
+		return nil, err
+	}
+	filter.OwnerID = &ownerID
+	filter.Channel = models.DeliveryChannelWebhook
+	filter.Destination = id.String()
+	return s.deliveries.ListDeliveries(ctx, filter)
+}
+
+// WebhookPublisher lê a outbox e enfileira uma entrega por webhook assinante do evento.
+// Docstring: o evento só é marcado como publicado depois de todas as entregas enfileiradas;
+// uma falha no meio faz o evento voltar após o lockTimeout (o id do envelope permite ao
+// receptor descartar duplicatas).
+type WebhookPublisher struct {
+	outbox      repositories.OutboxRepository
+	webhooks    repositories.WebhookRepository
+	deliveries  DeliveryEnqueuer
+	log         logging.Logger
+	lockTimeout time.Duration
+	now         func() time.Time
+}
+
+// NewWebhookPublisher cria o publicador da outbox
+func NewWebhookPublisher(outbox repositories.OutboxRepository, webhooks repositories.WebhookRepository, deliveries DeliveryEnqueuer, log logging.Logger) *WebhookPublisher {
+	return &WebhookPublisher{outbox: outbox, webhooks: webhooks, deliveries: deliveries, log: log, lockTimeout: 5 * time.Minute, now: time.Now}
+}
+
+// PublishPending publica até limit eventos; retorna quantos foram publicados
+func (p *WebhookPublisher) PublishPending(ctx context.Context, limit int) (int, error) {
+	events, err := p.outbox.ClaimPending(ctx, p.now().UTC(), p.lockTimeout, limit)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao reservar eventos da outbox: %w", err)
+	}
+	published := 0
+	for i := range events {
+		if err := p.publish(ctx, &events[i]); err != nil {
+			p.log.Error("erro ao publicar evento da outbox",
+				logging.Field{Key: "event_id", Val: events[i].ID.String()},
+				logging.Field{Key: "error", Val: err.Error()})
+			continue
+		}
+		published++
+	}
+	return published, nil
+}
+
+func (p *WebhookPublisher) publish(ctx context.Context, e *models.OutboxEvent) error {
+	hooks, err := p.webhooks.ListActive(ctx, e.OwnerID)
+	if err != nil {
+		return err
+	}
+	env := models.WebhookEnvelope{ID: e.ID, Type: e.EventType, CreatedAt: e.CreatedAt.UTC(), Data: e.Payload}
+	for i := range hooks {
+		if !hooks[i].Subscribed(e.EventType) {
+			continue
+		}
+		if _, err := p.deliveries.Enqueue(ctx, e.OwnerID, models.DeliveryChannelWebhook, hooks[i].ID.String(), e.EventType, env); err != nil {
+			return err
+		}
+	}
+	return p.outbox.MarkPublished(ctx, e.ID, p.now().UTC())
+}
+
+// Cleanup apaga eventos publicados há mais de retention (tarefa do job de ciclo de vida)
+func (p *WebhookPublisher) Cleanup(ctx context.Context, retention time.Duration) (int64, error) {
+	return p.outbox.DeletePublishedBefore(ctx, p.now().UTC().Add(-retention))
+}
+
+// WebhookSender envia as entregas do canal webhook (DeliverySender).
+// Docstring: o webhook é relido a cada tentativa, de modo que URL e segredo atualizados valem
+// para as entregas na fila; webhook removido ou desativado e resposta 410 Gone são falhas
+// permanentes. Redirecionamentos não são seguidos e o discador recusa IPs privados.
+type WebhookSender struct {
+	repo repositories.WebhookRepository
+	hc   *http.Client
+	now  func() time.Time
+}
+
+// NewWebhookSender cria o remetente do canal webhook
+func NewWebhookSender(repo repositories.WebhookRepository) *WebhookSender {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: publicAddressOnly}
+	return &WebhookSender{
+		repo: repo,
+		hc: &http.Client{
+			Timeout:   WebhookTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: 5 * time.Second},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// publicAddressOnly recusa conexões para endereços internos (proteção contra SSRF via DNS)
+func publicAddressOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return fmt.Errorf("endereço %s não permitido para webhooks", host)
+	}
+	return nil
+}
+
+// Send faz o POST assinado do envelope ao endpoint do webhook
+func (s *WebhookSender) Send(ctx context.Context, d *models.Delivery) error {
+	id, err := uuid.Parse(d.Destination)
+	if err != nil {
+		return fmt.Errorf("%w: destino de webhook inválido", ErrPermanentDelivery)
+	}
+	w, err := s.repo.GetByID(ctx, id, d.OwnerID)
+	if errors.Is(err, models.ErrWebhookNotFound) {
+		return fmt.Errorf("%w: webhook removido", ErrPermanentDelivery)
+	}
+	if err != nil {
+		return err
+	}
+	if !w.Active {
+		return fmt.Errorf("%w: webhook desativado", ErrPermanentDelivery)
+	}
+
+	ts := s.now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPermanentDelivery, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ReciboFast-Webhooks/1.0")
+	req.Header.Set(WebhookHeaderEvent, d.EventType)
+	req.Header.Set(WebhookHeaderDelivery, d.ID.String())
+	req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(w.Secret, ts, d.Payload))
+
+	resp, err := s.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusGone:
+		return fmt.Errorf("%w: endpoint respondeu 410 Gone", ErrPermanentDelivery)
+	}
+	return fmt.Errorf("webhook respondeu %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+}
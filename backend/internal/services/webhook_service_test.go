@@ -0,0 +1,157 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes dos webhooks (publicação da outbox, assinatura HMAC e envio)
+// Data: 18-10-2026
+
+package services
+
+import (
+    "context"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "recibofast/internal/logging"
+    "recibofast/internal/models"
+    "recibofast/internal/repositories"
+)
+
+// fakeWebhookRepo implementa repositories.WebhookRepository em memória
+type fakeWebhookRepo struct {
+    repositories.WebhookRepository
+    hooks []models.Webhook
+}
+
+func (f *fakeWebhookRepo) GetByID(ctx context.Context, id, ownerID uuid.UUID) (*models.Webhook, error) {
+    for i := range f.hooks {
+        if f.hooks[i].ID == id && f.hooks[i].OwnerID == ownerID { w := f.hooks[i]; return &w, nil }
+    }
+    return nil, models.ErrWebhookNotFound
+}
+func (f *fakeWebhookRepo) ListActive(ctx context.Context, ownerID uuid.UUID) ([]models.Webhook, error) {
+    var out []models.Webhook
+    for _, w := range f.hooks {
+        if w.OwnerID == ownerID && w.Active { out = append(out, w) }
+    }
+    return out, nil
+}
+
+// fakeOutboxRepo implementa repositories.OutboxRepository em memória
+type fakeOutboxRepo struct {
+    pending   []models.OutboxEvent
+    published []uuid.UUID
+}
+
+func (f *fakeOutboxRepo) ClaimPending(ctx context.Context, now time.Time, lockTimeout time.Duration, limit int) ([]models.OutboxEvent, error) {
+    out := f.pending
+    f.pending = nil
+    return out, nil
+}
+func (f *fakeOutboxRepo) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+    f.published = append(f.published, id); return nil
+}
+func (f *fakeOutboxRepo) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) { return 0, nil }
+
+func TestSignWebhookPayload(t *testing.T) {
+    body := []byte(`{"id":"1","type":"payment.added"}`)
+    mac := hmac.New(sha256.New, []byte("whsec_teste"))
+    mac.Write([]byte("1760781600." + string(body)))
+    want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+    if got := SignWebhookPayload("whsec_teste", 1760781600, body); got != want {
+        t.Fatalf("signature = %s, want %s", got, want)
+    }
+    if SignWebhookPayload("outro", 1760781600, body) == want {
+        t.Fatal("signature must depend on the secret")
+    }
+}
+
+func TestWebhookPublisher_EnqueuesSubscribedHooks(t *testing.T) {
+    owner := uuid.New()
+    all := models.Webhook{ID: uuid.New(), OwnerID: owner, Active: true}
+    receipts := models.Webhook{ID: uuid.New(), OwnerID: owner, Active: true, Events: []string{models.EventReceiptIssued}}
+    inactive := models.Webhook{ID: uuid.New(), OwnerID: owner}
+    event := models.OutboxEvent{ID: uuid.New(), OwnerID: owner, EventType: models.EventPaymentAdded,
+        Payload: json.RawMessage(`{"valor":"150.00"}`), CreatedAt: time.Now()}
+
+    outbox := &fakeOutboxRepo{pending: []models.OutboxEvent{event}}
+    q := &fakeEnqueuer{}
+    pub := NewWebhookPublisher(outbox, &fakeWebhookRepo{hooks: []models.Webhook{all, receipts, inactive}}, q, logging.NewLogger("dev"))
+
+    n, err := pub.PublishPending(context.Background(), 10)
+    if err != nil || n != 1 { t.Fatalf("PublishPending = %d, %v", n, err) }
+    if len(q.items) != 1 { t.Fatalf("expected 1 delivery, got %+v", q.items) }
+    got := q.items[0]
+    if got.channel != models.DeliveryChannelWebhook || got.destination != all.ID.String() || got.event != models.EventPaymentAdded {
+        t.Errorf("unexpected delivery %+v", got)
+    }
+    if len(outbox.published) != 1 || outbox.published[0] != event.ID { t.Errorf("event not marked as published: %v", outbox.published) }
+}
+
+func TestWebhookSender_SignsAndPosts(t *testing.T) {
+    owner := uuid.New()
+    var gotHeaders http.Header
+    var gotBody []byte
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        gotHeaders = r.Header.Clone()
+        gotBody, _ = io.ReadAll(r.Body)
+        w.WriteHeader(http.StatusNoContent)
+    }))
+    defer srv.Close()
+
+    hook := models.Webhook{ID: uuid.New(), OwnerID: owner, URL: srv.URL, Secret: "whsec_teste", Active: true}
+    sender := NewWebhookSender(&fakeWebhookRepo{hooks: []models.Webhook{hook}})
+    sender.hc = srv.Client()
+    now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+    sender.now = func() time.Time { return now }
+
+    d := &models.Delivery{ID: uuid.New(), OwnerID: owner, Destination: hook.ID.String(), EventType: models.EventReceiptIssued,
+        Payload: json.RawMessage(`{"type":"receipt.issued"}`)}
+    if err := sender.Send(context.Background(), d); err != nil { t.Fatalf("Send: %v", err) }
+
+    if gotHeaders.Get(WebhookHeaderEvent) != models.EventReceiptIssued || gotHeaders.Get(WebhookHeaderDelivery) != d.ID.String() {
+        t.Errorf("unexpected headers %v", gotHeaders)
+    }
+    if want := SignWebhookPayload("whsec_teste", now.Unix(), gotBody); gotHeaders.Get(WebhookHeaderSignature) != want {
+        t.Errorf("signature = %s, want %s", gotHeaders.Get(WebhookHeaderSignature), want)
+    }
+    if string(gotBody) != `{"type":"receipt.issued"}` { t.Errorf("body = %s", gotBody) }
+}
+
+func TestWebhookSender_Failures(t *testing.T) {
+    owner := uuid.New()
+    status := http.StatusInternalServerError
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(status) }))
+    defer srv.Close()
+
+    hook := models.Webhook{ID: uuid.New(), OwnerID: owner, URL: srv.URL, Secret: "s", Active: true}
+    repo := &fakeWebhookRepo{hooks: []models.Webhook{hook}}
+    sender := NewWebhookSender(repo)
+    sender.hc = srv.Client()
+    d := &models.Delivery{ID: uuid.New(), OwnerID: owner, Destination: hook.ID.String(), Payload: json.RawMessage(`{}`)}
+
+    err := sender.Send(context.Background(), d)
+    if err == nil || errors.Is(err, ErrPermanentDelivery) { t.Fatalf("500 should be retried, got %v", err) }
+
+    status = http.StatusGone
+    if err := sender.Send(context.Background(), d); !errors.Is(err, ErrPermanentDelivery) { t.Fatalf("410 should be permanent, got %v", err) }
+
+    repo.hooks[0].Active = false
+    if err := sender.Send(context.Background(), d); !errors.Is(err, ErrPermanentDelivery) { t.Fatalf("inactive webhook should be permanent, got %v", err) }
+
+    repo.hooks = nil
+    if err := sender.Send(context.Background(), d); !errors.Is(err, ErrPermanentDelivery) { t.Fatalf("removed webhook should be permanent, got %v", err) }
+}
+
+func TestWebhookSender_RefusesPrivateAddresses(t *testing.T) {
+    if err := publicAddressOnly("tcp", "127.0.0.1:443", nil); err == nil { t.Error("loopback should be refused") }
+    if err := publicAddressOnly("tcp", "10.1.2.3:443", nil); err == nil { t.Error("private address should be refused") }
+    if err := publicAddressOnly("tcp", "203.0.113.10:443", nil); err != nil { t.Errorf("public address refused: %v", err) }
+}
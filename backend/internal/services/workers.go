@@ -0,0 +1,68 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Subsistema de workers em segundo plano (execução periódica registrada no JobMonitor)
+// Data: 18-10-2026
+
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"recibofast/internal/logging"
+)
+
+// Worker job periódico; RunAtStart executa uma rodada ao iniciar, para que jobs de intervalo
+// longo (ex.: diário) não fiquem adiados a cada reinício do processo
+type Worker struct {
+	Name       string
+	Interval   time.Duration
+	RunAtStart bool
+	Run        func(ctx context.Context) error
+}
+
+// Workers conjunto de workers do processo.
+// Docstring: cada worker roda em sua goroutine via JobMonitor.Run, que registra as rodadas no
+// painel de jobs; Start dispara todos e Wait aguarda o encerramento após o cancelamento do contexto.
+type Workers struct {
+	monitor *JobMonitor
+	workers []Worker
+	log     logging.Logger
+	wg      sync.WaitGroup
+}
+
+// NewWorkers cria o conjunto de workers sobre o monitor de jobs
+func NewWorkers(monitor *JobMonitor, log logging.Logger) *Workers {
+	return &Workers{monitor: monitor, log: log}
+}
+
+// Add registra um worker (e o declara no painel de jobs, mesmo antes de Start)
+func (ws *Workers) Add(w Worker) {
+	ws.monitor.Register(w.Name, w.Interval)
+	ws.workers = append(ws.workers, w)
+}
+
+// Start inicia todos os workers até o contexto ser cancelado
+func (ws *Workers) Start(ctx context.Context) {
+	for _, w := range ws.workers {
+		ws.wg.Add(1)
+		go func(w Worker) {
+			defer ws.wg.Done()
+			if w.RunAtStart {
+				if err := ws.monitor.Track(ctx, w.Name, w.Run); err != nil {
+					ws.log.Error("erro no job em segundo plano",
+						logging.Field{Key: "job", Val: w.Name},
+						logging.Field{Key: "error", Val: err.Error()})
+				}
+			}
+			ws.monitor.Run(ctx, w.Name, w.Interval, w.Run)
+		}(w)
+	}
+	ws.log.Info("workers em segundo plano iniciados", logging.Field{Key: "count", Val: len(ws.workers)})
+}
+
+// Wait aguarda o término de todos os workers iniciados
+func (ws *Workers) Wait() {
+	ws.wg.Wait()
+}
@@ -0,0 +1,205 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Novas tentativas com backoff, circuit breaker e classificação de erros do Storage
+// Data: 18-10-2026
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen o Storage falhou seguidamente e as chamadas estão suspensas até o fim da pausa
+var ErrCircuitOpen = errors.New("circuito do Storage aberto após falhas seguidas; tente novamente em instantes")
+
+// Error falha de uma chamada ao Storage com a classificação para quem chama.
+// Docstring: Transient indica falha temporária (rede, timeout, 5xx, 408, 429, circuito aberto),
+// em que vale tentar de novo mais tarde; as demais (4xx, configuração) não mudam repetindo.
+type Error struct {
+	Status    int // 0 em falhas de rede
+	Transient bool
+	Err       error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// IsTransient indica se err é uma falha temporária do Storage
+func IsTransient(err error) bool {
+	var se *Error
+	return errors.As(err, &se) && se.Transient
+}
+
+// RetryPolicy novas tentativas e circuit breaker do cliente.
+// Docstring: a espera antes da tentativa n é sorteada entre 0 e min(MaxDelay, BaseDelay·2^(n-1))
+// (full jitter, para instâncias não repetirem juntas). BreakerThreshold falhas seguidas abrem o
+// circuito por BreakerCooldown; depois uma única chamada de teste decide se ele fecha. Zero em
+// MaxAttempts vale 1 (sem repetição); zero em BreakerThreshold desliga o breaker.
+type RetryPolicy struct {
+	MaxAttempts      int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// transientStatus status HTTP que valem nova tentativa
+func transientStatus(code int) bool {
+	return code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
+
+// statusError erro de uma resposta não 2xx, classificado pelo status
+func statusError(code int, err error) error {
+	return &Error{Status: code, Transient: transientStatus(code), Err: err}
+}
+
+// bodyError falha ao ler o conteúdo enviado (ex.: limite do http.MaxBytesReader): é do cliente,
+// não do Storage, então não repete nem conta para o breaker
+type bodyError struct{ err error }
+
+func (e *bodyError) Error() string { return e.err.Error() }
+
+func (e *bodyError) Unwrap() error { return e.err }
+
+// do executa a requisição criada por newReq com novas tentativas em falhas temporárias.
+// Docstring: newReq é chamado a cada tentativa (o corpo precisa ser recriado); attempts limita as
+// tentativas desta chamada (1 para corpos que não podem ser relidos). Na última tentativa uma
+// resposta 5xx é devolvida ao chamador, que monta o erro com statusError.
+func (c *Client) do(ctx context.Context, attempts int, newReq func() (*http.Request, error)) (*http.Response, error) {
+	attempts = min(max(attempts, 1), max(c.retry.MaxAttempts, 1))
+	for n := 1; ; n++ {
+		if !c.breaker.allow() {
+			return nil, &Error{Transient: true, Err: ErrCircuitOpen}
+		}
+		req, err := newReq()
+		if err != nil {
+			c.breaker.release()
+			return nil, err
+		}
+		resp, err := c.hc.Do(req)
+		var be *bodyError
+		switch {
+		case errors.As(err, &be):
+			c.breaker.release()
+			return nil, be.err
+		case err != nil && ctx.Err() != nil:
+			c.breaker.release()
+			return nil, ctx.Err()
+		case err != nil:
+			c.breaker.record(false)
+			if n >= attempts {
+				return nil, &Error{Transient: true, Err: fmt.Errorf("falha de rede no Storage: %w", err)}
+			}
+		case transientStatus(resp.StatusCode):
+			c.breaker.record(false)
+			if n >= attempts {
+				return resp, nil
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		default:
+			c.breaker.record(true)
+			return resp, nil
+		}
+		if err := c.sleep(ctx, c.backoff(n)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff espera antes da tentativa n+1 (full jitter)
+func (c *Client) backoff(n int) time.Duration {
+	if c.retry.BaseDelay <= 0 {
+		return 0
+	}
+	ceil := c.retry.BaseDelay << min(n-1, 30)
+	if c.retry.MaxDelay > 0 && (ceil > c.retry.MaxDelay || ceil <= 0) {
+		ceil = c.retry.MaxDelay
+	}
+	return time.Duration(rand.Int64N(int64(ceil) + 1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// breaker circuit breaker simples por contagem de falhas seguidas (nil ou threshold 0 desliga)
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &breaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow libera a chamada; com o circuito aberto só passa uma chamada de teste após a pausa
+func (b *breaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record registra o resultado da chamada liberada por allow
+func (b *breaker) record(ok bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if ok {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// release encerra a chamada liberada por allow sem resultado do Storage (cancelada, erro local)
+func (b *breaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
@@ -8,8 +8,12 @@ package storage
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"strings"
@@ -18,6 +22,9 @@ import (
 	"recibofast/internal/config"
 )
 
+// ErrObjectNotFound indica que o objeto não existe no bucket (ex.: upload interrompido)
+var ErrObjectNotFound = errors.New("objeto não encontrado no Storage")
+
 // Client provê operações básicas no Supabase Storage via REST.
 // Docstring: usa Service Role Key para upload em buckets privados. Em PT-BR.
 
@@ -25,6 +32,9 @@ type Client struct {
 	baseURL    string
 	serviceKey string
 	hc         *http.Client
+	retry      RetryPolicy
+	breaker    *breaker
+	sleep      func(ctx context.Context, d time.Duration) error
 }
 
 // DeleteObject remove um objeto do bucket/caminho informado.
@@ -38,33 +48,124 @@ func (c *Client) DeleteObject(ctx context.Context, bucket, objectPath string) er
     }
 
     url := fmt.Sprintf("%s/storage/v1/object/%s/%s", c.baseURL, bucket, objectPath)
-    req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
-    if err != nil { return err }
-    req.Header.Set("Authorization", "Bearer "+c.serviceKey)
-
-    resp, err := c.hc.Do(req)
+    resp, err := c.do(ctx, c.retry.MaxAttempts, func() (*http.Request, error) {
+        req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+        if err != nil { return nil, err }
+        req.Header.Set("Authorization", "Bearer "+c.serviceKey)
+        return req, nil
+    })
     if err != nil { return err }
     defer resp.Body.Close()
 
     if resp.StatusCode >= 200 && resp.StatusCode < 300 {
         return nil
     }
+    if resp.StatusCode == http.StatusNotFound {
+        return ErrObjectNotFound
+    }
     b, _ := io.ReadAll(resp.Body)
-    return fmt.Errorf("falha ao deletar objeto no Storage: status=%d body=%s", resp.StatusCode, string(b))
+    return statusError(resp.StatusCode, fmt.Errorf("falha ao deletar objeto no Storage: status=%d body=%s", resp.StatusCode, string(b)))
 }
 
 // NewClient cria um cliente de Storage a partir da configuração.
+// Docstring: novas tentativas e circuit breaker vêm de STORAGE_MAX_ATTEMPTS,
+// STORAGE_RETRY_BASE_DELAY, STORAGE_RETRY_MAX_DELAY, STORAGE_BREAKER_THRESHOLD e
+// STORAGE_BREAKER_COOLDOWN (ver RetryPolicy).
 func NewClient(cfg *config.Config) *Client {
+	retry := RetryPolicy{
+		MaxAttempts:      cfg.StorageMaxAttempts,
+		BaseDelay:        cfg.StorageRetryBaseDelay,
+		MaxDelay:         cfg.StorageRetryMaxDelay,
+		BreakerThreshold: cfg.StorageBreakerThreshold,
+		BreakerCooldown:  cfg.StorageBreakerCooldown,
+	}
 	return &Client{
 		baseURL:    strings.TrimRight(cfg.SupabaseURL, "/"),
 		serviceKey: cfg.SupabaseServiceRoleKey,
 		hc: &http.Client{Timeout: 20 * time.Second},
+		retry:      retry,
+		breaker:    newBreaker(retry.BreakerThreshold, retry.BreakerCooldown),
+		sleep:      sleepContext,
 	}
 }
 
 // UploadObject envia um objeto para o bucket/caminho informado.
 // contentType deve ser um MIME válido (ex.: image/png).
 func (c *Client) UploadObject(ctx context.Context, bucket, objectPath string, content []byte, contentType string) error {
+	_, _, err := c.UploadStream(ctx, bucket, objectPath, bytes.NewReader(content), contentType)
+	return err
+}
+
+// UploadStream envia o conteúdo lido de body sem carregá-lo inteiro na memória, calculando no
+// caminho (io.TeeReader) o tamanho e o SHA-256 do que foi enviado.
+// Docstring: um erro de leitura de body (ex.: limite do http.MaxBytesReader) aborta a requisição
+// e o Storage descarta o objeto parcial; o erro devolvido é o da leitura. Só há nova tentativa
+// quando body pode ser relido do início (io.Seeker, ex.: bytes.Reader).
+func (c *Client) UploadStream(ctx context.Context, bucket, objectPath string, body io.Reader, contentType string) (size int64, sha256hex string, err error) {
+	if c.baseURL == "" || c.serviceKey == "" {
+		return 0, "", errors.New("configuração do Supabase Storage ausente (SUPABASE_URL ou SUPABASE_SERVICE_ROLE_KEY)")
+	}
+	if bucket == "" || objectPath == "" {
+		return 0, "", errors.New("bucket ou caminho do objeto não informado")
+	}
+
+	attempts := 1
+	seeker, canRewind := body.(io.Seeker)
+	if canRewind {
+		attempts = c.retry.MaxAttempts
+	}
+	var (
+		h   hash.Hash
+		src *readTracker
+	)
+	url := fmt.Sprintf("%s/storage/v1/object/%s/%s", c.baseURL, bucket, objectPath)
+	resp, err := c.do(ctx, attempts, func() (*http.Request, error) {
+		if src != nil {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+		h = sha256.New()
+		src = &readTracker{r: io.TeeReader(body, h)}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, src)
+		if err != nil { return nil, err }
+		if br, ok := body.(*bytes.Reader); ok {
+			req.ContentLength = int64(br.Len())
+		}
+		req.Header.Set("Authorization", "Bearer "+c.serviceKey)
+		req.Header.Set("Content-Type", contentType)
+		return req, nil
+	})
+	if err != nil { return 0, "", err }
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return src.n, hex.EncodeToString(h.Sum(nil)), nil
+	}
+	b, _ := io.ReadAll(resp.Body)
+	return 0, "", statusError(resp.StatusCode, fmt.Errorf("falha no upload para Storage: status=%d body=%s", resp.StatusCode, string(b)))
+}
+
+// readTracker conta os bytes lidos; erros de leitura saem como bodyError, que o http.Client
+// preserva e do() devolve sem repetir
+type readTracker struct {
+	r io.Reader
+	n int64
+}
+
+func (t *readTracker) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	t.n += int64(n)
+	if err != nil && err != io.EOF {
+		return n, &bodyError{err: err}
+	}
+	return n, err
+}
+
+// UpsertObject envia um objeto sobrescrevendo o existente no mesmo caminho (header x-upsert).
+// Docstring: usado pelo armazenamento endereçado por conteúdo, em que o caminho deriva do hash e
+// sobrescrever é idempotente.
+func (c *Client) UpsertObject(ctx context.Context, bucket, objectPath string, content []byte, contentType string) error {
 	if c.baseURL == "" || c.serviceKey == "" {
 		return errors.New("configuração do Supabase Storage ausente (SUPABASE_URL ou SUPABASE_SERVICE_ROLE_KEY)")
 	}
@@ -73,18 +174,221 @@ func (c *Client) UploadObject(ctx context.Context, bucket, objectPath string, co
 	}
 
 	url := fmt.Sprintf("%s/storage/v1/object/%s/%s", c.baseURL, bucket, objectPath)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, io.NopCloser(bytes.NewReader(content)))
+	resp, err := c.do(ctx, c.retry.MaxAttempts, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, io.NopCloser(bytes.NewReader(content)))
+		if err != nil { return nil, err }
+		req.Header.Set("Authorization", "Bearer "+c.serviceKey)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("x-upsert", "true")
+		return req, nil
+	})
 	if err != nil { return err }
-	req.Header.Set("Authorization", "Bearer "+c.serviceKey)
-	req.Header.Set("Content-Type", contentType)
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	b, _ := io.ReadAll(resp.Body)
+	return statusError(resp.StatusCode, fmt.Errorf("falha no upload para Storage: status=%d body=%s", resp.StatusCode, string(b)))
+}
+
+// ObjectInfo objeto listado no bucket
+type ObjectInfo struct {
+	Path      string
+	Size      int64
+	CreatedAt time.Time
+}
 
-	resp, err := c.hc.Do(req)
+// ListObjects lista os caminhos dos objetos sob o prefixo (pasta), descendo nas subpastas.
+// Docstring: a API lista um nível por vez; entradas sem id são pastas.
+func (c *Client) ListObjects(ctx context.Context, bucket, prefix string) ([]string, error) {
+	infos, err := c.ListObjectInfos(ctx, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(infos))
+	for _, o := range infos {
+		paths = append(paths, o.Path)
+	}
+	return paths, nil
+}
+
+// ListObjectInfos como ListObjects, com tamanho e data de criação de cada objeto
+func (c *Client) ListObjectInfos(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	if c.baseURL == "" || c.serviceKey == "" {
+		return nil, errors.New("configuração do Supabase Storage ausente (SUPABASE_URL ou SUPABASE_SERVICE_ROLE_KEY)")
+	}
+	if bucket == "" {
+		return nil, errors.New("bucket não informado")
+	}
+	prefix = strings.Trim(prefix, "/")
+
+	const pageSize = 1000
+	var objects []ObjectInfo
+	for offset := 0; ; offset += pageSize {
+		body, _ := json.Marshal(map[string]any{"prefix": prefix, "limit": pageSize, "offset": offset})
+		url := fmt.Sprintf("%s/storage/v1/object/list/%s", c.baseURL, bucket)
+		resp, err := c.do(ctx, c.retry.MaxAttempts, func() (*http.Request, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+			if err != nil { return nil, err }
+			req.Header.Set("Authorization", "Bearer "+c.serviceKey)
+			req.Header.Set("Content-Type", "application/json")
+			return req, nil
+		})
+		if err != nil { return nil, err }
+		var entries []struct {
+			ID        *string    `json:"id"`
+			Name      string     `json:"name"`
+			CreatedAt *time.Time `json:"created_at"`
+			Metadata  *struct {
+				Size int64 `json:"size"`
+			} `json:"metadata"`
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			b, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, statusError(resp.StatusCode, fmt.Errorf("falha ao listar objetos no Storage: status=%d body=%s", resp.StatusCode, string(b)))
+		}
+		err = json.NewDecoder(resp.Body).Decode(&entries)
+		resp.Body.Close()
+		if err != nil { return nil, err }
+
+		for _, e := range entries {
+			full := e.Name
+			if prefix != "" {
+				full = prefix + "/" + e.Name
+			}
+			if e.ID == nil {
+				sub, err := c.ListObjectInfos(ctx, bucket, full)
+				if err != nil { return nil, err }
+				objects = append(objects, sub...)
+				continue
+			}
+			o := ObjectInfo{Path: full}
+			if e.CreatedAt != nil {
+				o.CreatedAt = *e.CreatedAt
+			}
+			if e.Metadata != nil {
+				o.Size = e.Metadata.Size
+			}
+			objects = append(objects, o)
+		}
+		if len(entries) < pageSize {
+			return objects, nil
+		}
+	}
+}
+
+// MoveObject move (renomeia) um objeto dentro do bucket.
+// Docstring: falha se o destino já existir; ErrObjectNotFound se a origem não existir.
+func (c *Client) MoveObject(ctx context.Context, bucket, fromPath, toPath string) error {
+	if c.baseURL == "" || c.serviceKey == "" {
+		return errors.New("configuração do Supabase Storage ausente (SUPABASE_URL ou SUPABASE_SERVICE_ROLE_KEY)")
+	}
+	if bucket == "" || fromPath == "" || toPath == "" {
+		return errors.New("bucket ou caminho do objeto não informado")
+	}
+
+	body, _ := json.Marshal(map[string]string{"bucketId": bucket, "sourceKey": fromPath, "destinationKey": toPath})
+	url := fmt.Sprintf("%s/storage/v1/object/move", c.baseURL)
+	resp, err := c.do(ctx, c.retry.MaxAttempts, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil { return nil, err }
+		req.Header.Set("Authorization", "Bearer "+c.serviceKey)
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil { return err }
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		return nil
 	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrObjectNotFound
+	}
 	b, _ := io.ReadAll(resp.Body)
-	return fmt.Errorf("falha no upload para Storage: status=%d body=%s", resp.StatusCode, string(b))
+	return statusError(resp.StatusCode, fmt.Errorf("falha ao mover objeto no Storage: status=%d body=%s", resp.StatusCode, string(b)))
+}
+
+// EnsureBucket garante que o bucket exista, criando-o como privado se necessário.
+// Docstring: retorna created=true quando o bucket foi criado agora; uma criação concorrente
+// (bucket já existente) não é erro.
+func (c *Client) EnsureBucket(ctx context.Context, bucket string) (created bool, err error) {
+	if c.baseURL == "" || c.serviceKey == "" {
+		return false, errors.New("configuração do Supabase Storage ausente (SUPABASE_URL ou SUPABASE_SERVICE_ROLE_KEY)")
+	}
+	if bucket == "" {
+		return false, errors.New("bucket não informado")
+	}
+
+	resp, err := c.do(ctx, c.retry.MaxAttempts, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/storage/v1/bucket/%s", c.baseURL, bucket), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.serviceKey)
+		return req, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	// O Storage responde 400 ou 404 ("Bucket not found") para bucket inexistente
+	if resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusBadRequest {
+		return false, statusError(resp.StatusCode, fmt.Errorf("falha ao consultar bucket no Storage: status=%d body=%s", resp.StatusCode, string(b)))
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"id": bucket, "name": bucket, "public": false})
+	resp, err = c.do(ctx, c.retry.MaxAttempts, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/storage/v1/bucket", c.baseURL), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.serviceKey)
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return true, nil
+	}
+	b, _ = io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusConflict || strings.Contains(strings.ToLower(string(b)), "already exists") {
+		return false, nil
+	}
+	return false, statusError(resp.StatusCode, fmt.Errorf("falha ao criar bucket no Storage: status=%d body=%s", resp.StatusCode, string(b)))
+}
+
+// Ping verifica se o Storage responde e o bucket é acessível com a Service Role Key (readiness).
+// Docstring: sem novas tentativas, para o readiness refletir o estado atual; com o circuito aberto
+// falha na hora.
+func (c *Client) Ping(ctx context.Context, bucket string) error {
+	if c.baseURL == "" || c.serviceKey == "" {
+		return errors.New("configuração do Supabase Storage ausente (SUPABASE_URL ou SUPABASE_SERVICE_ROLE_KEY)")
+	}
+	resp, err := c.do(ctx, 1, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/storage/v1/bucket/%s", c.baseURL, bucket), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.serviceKey)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return statusError(resp.StatusCode, fmt.Errorf("falha ao consultar bucket no Storage: status=%d body=%s", resp.StatusCode, string(b)))
+	}
+	return nil
 }
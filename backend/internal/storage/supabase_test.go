@@ -0,0 +1,214 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do cliente do Supabase Storage (streaming, novas tentativas e circuit breaker)
+// Data: 18-10-2026
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+// newTestClient cliente apontando para srv, sem espera entre tentativas
+func newTestClient(srv *httptest.Server, cfg config.Config) *Client {
+	cfg.SupabaseURL, cfg.SupabaseServiceRoleKey = srv.URL, "k"
+	c := NewClient(&cfg)
+	c.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+	return c
+}
+
+func TestUploadStream_TeesHashAndSize(t *testing.T) {
+	var got []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/storage/v1/object/signatures/u/a.png" || r.Header.Get("Content-Type") != "image/png" {
+			t.Errorf("requisição inesperada: %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		got, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+	c := NewClient(&config.Config{SupabaseURL: srv.URL, SupabaseServiceRoleKey: "k"})
+
+	content := strings.Repeat("assinatura", 10000)
+	// io.MultiReader esconde o tamanho: o corpo segue em chunks, sem buffer
+	size, sum, err := c.UploadStream(context.Background(), "signatures", "u/a.png", io.MultiReader(strings.NewReader(content)), "image/png")
+	if err != nil {
+		t.Fatalf("UploadStream: %v", err)
+	}
+	want := sha256.Sum256([]byte(content))
+	if size != int64(len(content)) || sum != hex.EncodeToString(want[:]) {
+		t.Fatalf("size = %d hash = %s, want %d %x", size, sum, len(content), want)
+	}
+	if !bytes.Equal(got, []byte(content)) {
+		t.Fatalf("Storage recebeu %d bytes, want %d", len(got), len(content))
+	}
+}
+
+func TestUploadStream_ReadErrorAborts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer srv.Close()
+	c := NewClient(&config.Config{SupabaseURL: srv.URL, SupabaseServiceRoleKey: "k"})
+
+	body := http.MaxBytesReader(httptest.NewRecorder(), io.NopCloser(strings.NewReader(strings.Repeat("x", 2048))), 1024)
+	_, _, err := c.UploadStream(context.Background(), "signatures", "u/a.png", body, "image/png")
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) || IsTransient(err) {
+		t.Fatalf("esperava *http.MaxBytesError permanente, got %v", err)
+	}
+}
+
+func TestUploadStream_RetriesTransientWithRewind(t *testing.T) {
+	var calls int
+	var got []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		got = body
+	}))
+	defer srv.Close()
+	c := newTestClient(srv, config.Config{StorageMaxAttempts: 3})
+
+	content := []byte("png")
+	size, sum, err := c.UploadStream(context.Background(), "signatures", "u/a.png", bytes.NewReader(content), "image/png")
+	if err != nil {
+		t.Fatalf("UploadStream: %v", err)
+	}
+	want := sha256.Sum256(content)
+	if calls != 3 || !bytes.Equal(got, content) || size != 3 || sum != hex.EncodeToString(want[:]) {
+		t.Fatalf("calls = %d body = %q size = %d hash = %s", calls, got, size, sum)
+	}
+}
+
+func TestUploadStream_NonSeekableNotRetried(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	c := newTestClient(srv, config.Config{StorageMaxAttempts: 3})
+
+	_, _, err := c.UploadStream(context.Background(), "signatures", "u/a.png", io.MultiReader(strings.NewReader("png")), "image/png")
+	var se *Error
+	if !errors.As(err, &se) || !se.Transient || se.Status != http.StatusServiceUnavailable || calls != 1 {
+		t.Fatalf("calls = %d err = %v; corpo não relido deve ter uma tentativa e erro temporário", calls, err)
+	}
+}
+
+func TestClient_PermanentErrorNotRetried(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+	c := newTestClient(srv, config.Config{StorageMaxAttempts: 3})
+
+	err := c.UpsertObject(context.Background(), "receipts", "a.pdf", []byte("pdf"), "application/pdf")
+	if err == nil || IsTransient(err) || calls != 1 {
+		t.Fatalf("calls = %d err = %v; 403 é permanente e não repete", calls, err)
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var calls int
+	healthy := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(srv, config.Config{StorageMaxAttempts: 2, StorageBreakerThreshold: 4, StorageBreakerCooldown: time.Minute})
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	c.breaker.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := c.DeleteObject(ctx, "receipts", "a.pdf"); !IsTransient(err) {
+			t.Fatalf("tentativa %d: esperava erro temporário, got %v", i, err)
+		}
+	}
+	if calls != 4 {
+		t.Fatalf("calls = %d, want 4 (2 chamadas x 2 tentativas)", calls)
+	}
+	// circuito aberto: falha sem chamar o Storage
+	err := c.DeleteObject(ctx, "receipts", "a.pdf")
+	if !errors.Is(err, ErrCircuitOpen) || !IsTransient(err) || calls != 4 {
+		t.Fatalf("calls = %d err = %v, want ErrCircuitOpen", calls, err)
+	}
+	// após a pausa uma chamada de teste fecha o circuito
+	now = now.Add(time.Minute)
+	healthy = true
+	if err := c.DeleteObject(ctx, "receipts", "a.pdf"); err != nil {
+		t.Fatalf("chamada de teste: %v", err)
+	}
+	if err := c.DeleteObject(ctx, "receipts", "a.pdf"); err != nil || calls != 6 {
+		t.Fatalf("calls = %d err = %v; circuito deveria estar fechado", calls, err)
+	}
+}
+
+func TestClient_NetworkErrorIsTransient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	c := newTestClient(srv, config.Config{StorageMaxAttempts: 2})
+	srv.Close()
+
+	if err := c.Ping(context.Background(), "receipts"); !IsTransient(err) {
+		t.Fatalf("falha de rede deveria ser temporária, got %v", err)
+	}
+}
+
+func TestClient_Backoff(t *testing.T) {
+	c := &Client{retry: RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 250 * time.Millisecond}}
+	for n, ceil := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 250 * time.Millisecond, 40: 250 * time.Millisecond} {
+		for i := 0; i < 50; i++ {
+			if d := c.backoff(n); d < 0 || d > ceil {
+				t.Fatalf("backoff(%d) = %v, want entre 0 e %v", n, d, ceil)
+			}
+		}
+	}
+}
+
+func TestListObjectInfos_DescendsFoldersWithMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ Prefix string }
+		json.NewDecoder(r.Body).Decode(&req)
+		switch req.Prefix {
+		case "":
+			io.WriteString(w, `[{"id":null,"name":"u1"},{"id":"1","name":"root.png","created_at":"2026-10-01T10:00:00Z","metadata":{"size":7}}]`)
+		case "u1":
+			io.WriteString(w, `[{"id":"2","name":"a.png","created_at":"2026-10-02T10:00:00Z","metadata":{"size":9}}]`)
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(srv, config.Config{})
+
+	objects, err := c.ListObjectInfos(context.Background(), "signatures", "")
+	if err != nil {
+		t.Fatalf("ListObjectInfos: %v", err)
+	}
+	if len(objects) != 2 || objects[0].Path != "u1/a.png" || objects[0].Size != 9 ||
+		!objects[0].CreatedAt.Equal(time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC)) || objects[1].Path != "root.png" {
+		t.Fatalf("objetos = %+v", objects)
+	}
+}
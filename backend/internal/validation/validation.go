@@ -0,0 +1,238 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Validação de requisições pelas tags `validate:` dos modelos
+// Data: 18-10-2026
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError falha de uma regra em um campo (nome do campo no JSON).
+// Docstring: Rule e Param permitem ao frontend traduzir a mensagem; Message é o texto em português
+// no formato "campo: motivo".
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Errors falhas de validação de uma requisição
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Struct valida v (struct ou ponteiro para struct) pelas tags `validate:`.
+// Docstring: regras suportadas: required (não vazio; strings só com espaços contam como vazias),
+// gt/gte/lt/lte=N (números), min/max=N (tamanho de strings e listas), oneof=a b c e
+// gtefield/ltefield=Campo. Ponteiros nulos só falham em required; structs aninhadas são
+// validadas com o caminho do campo (ex.: conditions.valor_min). Retorna nil sem falhas.
+func Struct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	var errs Errors
+	validateStruct(rv, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func validateStruct(rv reflect.Value, prefix string, errs *Errors) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := fieldName(sf)
+		if name == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+		// Structs embutidas sem tag json têm os campos promovidos no JSON
+		if sf.Anonymous && sf.Tag.Get("json") == "" && fv.Kind() == reflect.Struct {
+			validateStruct(fv, prefix, errs)
+			continue
+		}
+		path := prefix + name
+
+		if tag := sf.Tag.Get("validate"); tag != "" && tag != "-" {
+			validateField(rv, fv, path, tag, errs)
+		}
+
+		// Structs aninhadas (por valor ou ponteiro não nulo)
+		inner := fv
+		if inner.Kind() == reflect.Pointer && !inner.IsNil() {
+			inner = inner.Elem()
+		}
+		if inner.Kind() == reflect.Struct && inner.Type() != timeType {
+			validateStruct(inner, path+".", errs)
+		}
+	}
+}
+
+func fieldName(sf reflect.StructField) string {
+	if tag := sf.Tag.Get("json"); tag != "" {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	return sf.Name
+}
+
+func validateField(parent, fv reflect.Value, path, tag string, errs *Errors) {
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name == "required" {
+			if isEmpty(fv) {
+				errs.add(path, name, "", "é obrigatório")
+				return
+			}
+			continue
+		}
+		// Demais regras só valem para valores presentes
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				return
+			}
+			fv = fv.Elem()
+		}
+		if msg, ok := check(parent, fv, name, param); !ok {
+			errs.add(path, name, param, msg)
+		}
+	}
+}
+
+func (e *Errors) add(field, rule, param, msg string) {
+	*e = append(*e, FieldError{Field: field, Rule: rule, Param: param, Message: field + ": " + msg})
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// check aplica uma regra; a mensagem só é usada quando ok é false
+func check(parent, v reflect.Value, rule, param string) (string, bool) {
+	switch rule {
+	case "gt", "gte", "lt", "lte":
+		n, ok := number(v)
+		p, err := strconv.ParseFloat(param, 64)
+		if !ok || err != nil {
+			return "regra " + rule + " inválida para o campo", false
+		}
+		return compare(rule, n, p, param)
+	case "min", "max":
+		size, ok := length(v)
+		p, err := strconv.Atoi(param)
+		if !ok || err != nil {
+			return "regra " + rule + " inválida para o campo", false
+		}
+		if rule == "min" && size < p {
+			return fmt.Sprintf("deve ter no mínimo %d", p), false
+		}
+		if rule == "max" && size > p {
+			return fmt.Sprintf("deve ter no máximo %d", p), false
+		}
+	case "oneof":
+		s := fmt.Sprint(v.Interface())
+		for _, opt := range strings.Fields(param) {
+			if s == opt {
+				return "", true
+			}
+		}
+		return "deve ser um de: " + strings.Join(strings.Fields(param), ", "), false
+	case "gtefield", "ltefield":
+		other := parent.FieldByName(param)
+		if other.Kind() == reflect.Pointer {
+			if other.IsNil() {
+				return "", true
+			}
+			other = other.Elem()
+		}
+		n, ok1 := number(v)
+		o, ok2 := number(other)
+		if !ok1 || !ok2 {
+			return "regra " + rule + " inválida para o campo", false
+		}
+		otherName := param
+		if sf, found := parent.Type().FieldByName(param); found {
+			otherName = fieldName(sf)
+		}
+		if rule == "gtefield" && n < o {
+			return "deve ser maior ou igual a " + otherName, false
+		}
+		if rule == "ltefield" && n > o {
+			return "deve ser menor ou igual a " + otherName, false
+		}
+	default:
+		return "regra desconhecida: " + rule, false
+	}
+	return "", true
+}
+
+func compare(rule string, n, p float64, param string) (string, bool) {
+	switch {
+	case rule == "gt" && !(n > p):
+		return "deve ser maior que " + param, false
+	case rule == "gte" && !(n >= p):
+		return "deve ser maior ou igual a " + param, false
+	case rule == "lt" && !(n < p):
+		return "deve ser menor que " + param, false
+	case rule == "lte" && !(n <= p):
+		return "deve ser menor ou igual a " + param, false
+	}
+	return "", true
+}
+
+func number(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+func length(v reflect.Value) (int, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return len([]rune(v.String())), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,83 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes da validação por tags
+// Data: 18-10-2026
+
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+type gapRequest struct {
+	Inicio int64   `json:"numero_inicio" validate:"required,gt=0"`
+	Fim    int64   `json:"numero_fim" validate:"required,gtefield=Inicio"`
+	Motivo string  `json:"justificativa" validate:"required,max=10"`
+	Tipo   string  `json:"tipo" validate:"oneof=pix boleto"`
+	Obs    *string `json:"obs" validate:"min=3"`
+}
+
+type wrapper struct {
+	gapRequest
+	Itens []gapRequest `json:"itens"`
+	Extra *gapRequest  `json:"extra"`
+}
+
+func fields(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("err = %v, want Errors", err)
+	}
+	out := map[string]string{}
+	for _, fe := range errs {
+		out[fe.Field] = fe.Message
+	}
+	return out
+}
+
+func TestStructValid(t *testing.T) {
+	req := gapRequest{Inicio: 3, Fim: 3, Motivo: "cancelado", Tipo: "pix"}
+	if err := Struct(&req); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestStructFieldErrors(t *testing.T) {
+	obs := "ok"
+	got := fields(t, Struct(gapRequest{Inicio: 5, Fim: 2, Motivo: "   ", Tipo: "cheque", Obs: &obs}))
+	want := map[string]string{
+		"numero_fim":    "numero_fim: deve ser maior ou igual a numero_inicio",
+		"justificativa": "justificativa: é obrigatório",
+		"tipo":          "tipo: deve ser um de: pix, boleto",
+		"obs":           "obs: deve ter no mínimo 3",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v", got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+
+	got = fields(t, Struct(gapRequest{Inicio: -1, Fim: 1, Motivo: "muito longo demais", Tipo: "pix"}))
+	if got["numero_inicio"] != "numero_inicio: deve ser maior que 0" || got["justificativa"] != "justificativa: deve ter no máximo 10" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestStructEmbeddedAndNested(t *testing.T) {
+	w := wrapper{
+		gapRequest: gapRequest{Inicio: 1, Fim: 1, Motivo: "x", Tipo: "pix"},
+		Extra:      &gapRequest{Fim: 1, Motivo: "x", Tipo: "pix"},
+	}
+	got := fields(t, Struct(&w))
+	if len(got) != 1 || got["extra.numero_inicio"] != "extra.numero_inicio: é obrigatório" {
+		t.Fatalf("got %v", got)
+	}
+	if err := Struct((*wrapper)(nil)); err != nil {
+		t.Fatalf("nil: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Criptografia do payload Web Push no formato aes128gcm (RFC 8291 / RFC 8188)
+// Data: 18-10-2026
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// maxPayload limite de texto claro que cabe em um único registro de 4096 bytes
+const maxPayload = 4096 - 16 - 1 - 86
+
+var ErrPayloadTooLarge = errors.New("payload web push acima de 3993 bytes")
+
+// encrypt monta o corpo aes128gcm: salt(16) || rs(4) || idlen(1) || chave pública efêmera || registro.
+// Docstring: o segredo vem do ECDH entre uma chave efêmera do servidor e a p256dh do navegador,
+// combinado ao auth da assinatura (HKDF-SHA256); o payload é um único registro terminado em 0x02.
+func encrypt(sub *Subscription, payload []byte) ([]byte, error) {
+	if len(payload) > maxPayload {
+		return nil, ErrPayloadTooLarge
+	}
+	uaPublic, err := decode(sub.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("web push: p256dh inválida: %w", err)
+	}
+	authSecret, err := decode(sub.Auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, fmt.Errorf("web push: auth inválido")
+	}
+	uaKey, err := ecdh.P256().NewPublicKey(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("web push: p256dh inválida: %w", err)
+	}
+	asKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	shared, err := asKey.ECDH(uaKey)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asKey.PublicKey().Bytes()
+	cek, nonce := deriveKeys(shared, authSecret, salt, uaPublic, asPublic)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	plain := append(append([]byte{}, payload...), 0x02)
+
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, 4096)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+	return gcm.Seal(header, nonce, plain, nil), nil
+}
+
+// deriveKeys chave de conteúdo (16 bytes) e nonce (12 bytes) da RFC 8291 §3.4 a partir do segredo
+// ECDH e das chaves públicas do navegador (ua) e do servidor (as)
+func deriveKeys(shared, authSecret, salt, uaPublic, asPublic []byte) (cek, nonce []byte) {
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublic...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm := hkdf(authSecret, shared, keyInfo, 32)
+	cek = hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce = hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+	return cek, nonce
+}
+
+// hkdf HKDF-SHA256 (extract + expand) para saídas de até 32 bytes
+func hkdf(salt, ikm, info []byte, length int) []byte {
+	ext := hmac.New(sha256.New, salt)
+	ext.Write(ikm)
+	prk := ext.Sum(nil)
+	exp := hmac.New(sha256.New, prk)
+	exp.Write(info)
+	exp.Write([]byte{1})
+	return exp.Sum(nil)[:length]
+}
@@ -0,0 +1,46 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Token VAPID (JWT ES256) que identifica o servidor perante o serviço de push
+// Data: 18-10-2026
+
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// vapidTTL validade do token; o limite aceito pelos serviços de push é 24h
+const vapidTTL = 12 * time.Hour
+
+// vapidToken JWT com aud = origem do endpoint, exp e sub (contato do responsável pelo servidor)
+func (c *Client) vapidToken(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("web push: endpoint inválido")
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": c.now().Add(vapidTTL).Unix(),
+		"sub": c.subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	signing := encode([]byte(`{"typ":"JWT","alg":"ES256"}`)) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signing))
+	r, s, err := ecdsa.Sign(rand.Reader, c.private, digest[:])
+	if err != nil {
+		return "", err
+	}
+	// ES256 usa a assinatura crua r || s, 32 bytes cada (não DER)
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return signing + "." + encode(sig), nil
+}
@@ -0,0 +1,166 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Cliente Web Push (VAPID, RFC 8292) com payload criptografado (aes128gcm, RFC 8291)
+// Data: 18-10-2026
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"recibofast/internal/config"
+)
+
+// Urgência da mensagem (cabeçalho Urgency); o serviço de push pode segurar mensagens "low"
+const (
+	UrgencyLow    = "low"
+	UrgencyNormal = "normal"
+	UrgencyHigh   = "high"
+)
+
+var (
+	ErrNotConfigured = errors.New("web push não configurado (VAPID_PUBLIC_KEY/VAPID_PRIVATE_KEY)")
+	ErrInvalidKey    = errors.New("chave VAPID inválida")
+	// ErrGone o serviço de push respondeu 404/410: a assinatura não existe mais e deve ser removida
+	ErrGone = errors.New("assinatura push expirada ou removida")
+)
+
+// Subscription assinatura do navegador (PushSubscription.toJSON): endpoint e chaves em base64url
+type Subscription struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// Options parâmetros de entrega da mensagem
+type Options struct {
+	TTL     time.Duration
+	Urgency string
+	Topic   string
+}
+
+// Client envia mensagens assinadas com as chaves VAPID do servidor
+type Client struct {
+	publicKey string
+	private   *ecdsa.PrivateKey
+	subject   string
+	hc        *http.Client
+	now       func() time.Time
+}
+
+// New cria o cliente a partir de VAPID_PRIVATE_KEY (escalar P-256 de 32 bytes em base64url, formato
+// do web-push) e VAPID_SUBJECT; a chave pública é derivada e conferida com VAPID_PUBLIC_KEY, se informada
+func New(cfg *config.Config) (*Client, error) {
+	if strings.TrimSpace(cfg.VAPIDPrivateKey) == "" {
+		return nil, ErrNotConfigured
+	}
+	priv, err := parsePrivateKey(cfg.VAPIDPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	pub := encode(publicBytes(&priv.PublicKey))
+	if want := strings.TrimRight(strings.TrimSpace(cfg.VAPIDPublicKey), "="); want != "" && want != pub {
+		return nil, fmt.Errorf("%w: VAPID_PUBLIC_KEY não corresponde à chave privada", ErrInvalidKey)
+	}
+	subject := strings.TrimSpace(cfg.VAPIDSubject)
+	if subject == "" {
+		subject = "mailto:" + cfg.EmailFrom
+	}
+	return &Client{publicKey: pub, private: priv, subject: subject, hc: &http.Client{Timeout: 15 * time.Second}, now: time.Now}, nil
+}
+
+// PublicKey chave pública VAPID (applicationServerKey do pushManager.subscribe no frontend)
+func (c *Client) PublicKey() string {
+	return c.publicKey
+}
+
+// Send criptografa o payload para a assinatura e o entrega ao serviço de push do navegador
+func (c *Client) Send(ctx context.Context, sub *Subscription, payload []byte, opts Options) error {
+	body, err := encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+	token, err := c.vapidToken(sub.Endpoint)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	req.Header.Set("Authorization", "vapid t="+token+", k="+c.publicKey)
+	if opts.Urgency != "" {
+		req.Header.Set("Urgency", opts.Urgency)
+	}
+	if opts.Topic != "" {
+		req.Header.Set("Topic", opts.Topic)
+	}
+
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("web push: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return fmt.Errorf("%w (%d)", ErrGone, resp.StatusCode)
+	}
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("web push: status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func parsePrivateKey(s string) (*ecdsa.PrivateKey, error) {
+	d, err := decode(s)
+	if err != nil || len(d) != 32 {
+		return nil, fmt.Errorf("%w: VAPID_PRIVATE_KEY deve ter 32 bytes em base64url", ErrInvalidKey)
+	}
+	k, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	pub := k.PublicKey().Bytes() // 0x04 || X || Y
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(pub[1:33]), Y: new(big.Int).SetBytes(pub[33:])},
+		D:         new(big.Int).SetBytes(d),
+	}, nil
+}
+
+// publicBytes ponto não comprimido (65 bytes) da chave pública
+func publicBytes(pub *ecdsa.PublicKey) []byte {
+	out := make([]byte, 65)
+	out[0] = 4
+	pub.X.FillBytes(out[1:33])
+	pub.Y.FillBytes(out[33:])
+	return out
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decode aceita base64url com ou sem padding (navegadores variam)
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(s), "="))
+}
@@ -0,0 +1,127 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes do cliente Web Push (payload aes128gcm e token VAPID)
+// Data: 18-10-2026
+
+package webpush
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"recibofast/internal/config"
+)
+
+// decryptForTest faz o papel do navegador: decifra o corpo com a chave privada da assinatura
+func decryptForTest(t *testing.T, body []byte, uaPriv *ecdh.PrivateKey, authSecret []byte) []byte {
+	t.Helper()
+	salt, idlen := body[:16], int(body[20])
+	asPublic := body[21 : 21+idlen]
+	asKey, err := ecdh.P256().NewPublicKey(asPublic)
+	if err != nil {
+		t.Fatalf("chave do servidor: %v", err)
+	}
+	shared, _ := uaPriv.ECDH(asKey)
+	cek, nonce := deriveKeys(shared, authSecret, salt, uaPriv.PublicKey().Bytes(), asPublic)
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plain, err := gcm.Open(nil, nonce, body[21+idlen:], nil)
+	if err != nil {
+		t.Fatalf("decifrar: %v", err)
+	}
+	if plain[len(plain)-1] != 0x02 {
+		t.Fatalf("delimitador do último registro ausente")
+	}
+	return plain[:len(plain)-1]
+}
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	d := make([]byte, 32)
+	rand.Read(d)
+	c, err := New(&config.Config{VAPIDPrivateKey: encode(d), VAPIDSubject: "mailto:ops@exemplo.com"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestSend_EncryptsPayloadAndSignsVAPID(t *testing.T) {
+	c := newTestClient(t)
+	uaPriv, _ := ecdh.P256().GenerateKey(rand.Reader)
+	authSecret := make([]byte, 16)
+	rand.Read(authSecret)
+
+	var got []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "aes128gcm" || r.Header.Get("TTL") == "" {
+			t.Errorf("cabeçalhos: %v", r.Header)
+		}
+		auth := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(strings.Split(auth, ",")[0], "vapid t=")
+		if !strings.HasSuffix(auth, "k="+c.PublicKey()) {
+			t.Errorf("Authorization sem a chave pública: %s", auth)
+		}
+		parts := strings.Split(token, ".")
+		sig, _ := decode(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if len(sig) != 64 || !ecdsa.Verify(&c.private.PublicKey, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])) {
+			t.Errorf("assinatura VAPID inválida")
+		}
+		body, _ := io.ReadAll(r.Body)
+		got = decryptForTest(t, body, uaPriv, authSecret)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	sub := &Subscription{Endpoint: srv.URL + "/push/abc", P256dh: encode(uaPriv.PublicKey().Bytes()), Auth: encode(authSecret)}
+	if err := c.Send(context.Background(), sub, []byte(`{"title":"Pagamento recebido"}`), Options{Urgency: UrgencyHigh}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if string(got) != `{"title":"Pagamento recebido"}` {
+		t.Fatalf("payload decifrado = %q", got)
+	}
+}
+
+func TestSend_GoneSubscription(t *testing.T) {
+	c := newTestClient(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer srv.Close()
+	uaPriv, _ := ecdh.P256().GenerateKey(rand.Reader)
+	sub := &Subscription{Endpoint: srv.URL, P256dh: encode(uaPriv.PublicKey().Bytes()), Auth: encode(make([]byte, 16))}
+	if err := c.Send(context.Background(), sub, []byte("{}"), Options{}); !errors.Is(err, ErrGone) {
+		t.Fatalf("err = %v, want ErrGone", err)
+	}
+}
+
+func TestNew_ChecksKeys(t *testing.T) {
+	if _, err := New(&config.Config{}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("sem chave: err = %v", err)
+	}
+	if _, err := New(&config.Config{VAPIDPrivateKey: "curta"}); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("chave curta: err = %v", err)
+	}
+	c := newTestClient(t)
+	d := make([]byte, 32)
+	c.private.D.FillBytes(d)
+	if _, err := New(&config.Config{VAPIDPrivateKey: encode(d), VAPIDPublicKey: "outra"}); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("par divergente: err = %v", err)
+	}
+	if _, err := New(&config.Config{VAPIDPrivateKey: encode(d), VAPIDPublicKey: c.PublicKey() + "="}); err != nil {
+		t.Fatalf("par correto: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Numeração de recibos única e monotônica por usuário, com justificativa de lacunas
+-- Data: 18-10-2026
+
+-- Unicidade por usuário (antes o bigserial era global e não impedia duplicatas importadas)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_receipts_owner_numero ON rf_receipts(owner_id, numero);
+
+-- O número passa a ser atribuído por usuário (MAX + 1) no trigger abaixo
+ALTER TABLE rf_receipts ALTER COLUMN numero DROP DEFAULT;
+
+CREATE OR REPLACE FUNCTION rf_receipts_numero_guard()
+RETURNS trigger AS $$
+DECLARE
+  last_numero bigint;
+BEGIN
+  IF TG_OP = 'UPDATE' THEN
+    IF NEW.numero IS DISTINCT FROM OLD.numero THEN
+      RAISE EXCEPTION 'número do recibo não pode ser alterado'
+        USING ERRCODE = 'check_violation', CONSTRAINT = 'ck_receipts_numero_immutable';
+    END IF;
+    RETURN NEW;
+  END IF;
+
+  -- Serializa emissões do mesmo usuário para evitar corrida entre MAX e INSERT
+  PERFORM pg_advisory_xact_lock(hashtext('rf_receipts:' || NEW.owner_id::text));
+  SELECT MAX(numero) INTO last_numero FROM rf_receipts WHERE owner_id = NEW.owner_id;
+
+  IF NEW.numero IS NULL THEN
+    NEW.numero := COALESCE(last_numero, 0) + 1;
+  ELSIF last_numero IS NOT NULL AND NEW.numero <= last_numero THEN
+    -- Números informados (migração de talões em papel) devem seguir a sequência
+    RAISE EXCEPTION 'número % não é maior que o último emitido (%)', NEW.numero, last_numero
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'ck_receipts_numero_monotonic';
+  END IF;
+  RETURN NEW;
+END; $$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tg_receipts_numero_guard ON rf_receipts;
+CREATE TRIGGER tg_receipts_numero_guard BEFORE INSERT OR UPDATE ON rf_receipts
+FOR EACH ROW EXECUTE FUNCTION rf_receipts_numero_guard();
+
+-- Justificativas de lacunas na sequência (ex.: folhas canceladas do talão em papel)
+CREATE TABLE IF NOT EXISTS rf_receipt_number_gaps (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    numero_inicio bigint NOT NULL,
+    numero_fim bigint NOT NULL,
+    justificativa text NOT NULL,
+    created_at timestamptz DEFAULT now(),
+    CHECK (numero_fim >= numero_inicio)
+);
+
+CREATE INDEX IF NOT EXISTS idx_receipt_number_gaps_owner ON rf_receipt_number_gaps(owner_id, numero_inicio);
+
+ALTER TABLE rf_receipt_number_gaps ENABLE ROW LEVEL SECURITY;
+CREATE POLICY receipt_number_gaps_isolate ON rf_receipt_number_gaps
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+COMMENT ON TABLE rf_receipt_number_gaps IS 'Justificativas de lacunas na numeração de recibos (migração de talões em papel)';
@@ -0,0 +1,35 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Regras de categorização automática de receitas e tags em rf_incomes
+-- Data: 18-10-2026
+
+-- Tags livres aplicadas pelas regras (ex.: "Apto 12")
+ALTER TABLE IF EXISTS rf_incomes
+  ADD COLUMN IF NOT EXISTS tags text[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_incomes_tags ON rf_incomes USING gin(tags);
+
+-- Regras avaliadas em ordem de prioridade (menor primeiro); a primeira que casar é aplicada
+CREATE TABLE IF NOT EXISTS rf_income_rules (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    nome text NOT NULL,
+    priority int NOT NULL DEFAULT 100,
+    enabled boolean NOT NULL DEFAULT true,
+    conditions jsonb NOT NULL DEFAULT '{}'::jsonb,
+    actions jsonb NOT NULL DEFAULT '{}'::jsonb,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_income_rules_owner_priority ON rf_income_rules(owner_id, priority);
+
+ALTER TABLE rf_income_rules ENABLE ROW LEVEL SECURITY;
+CREATE POLICY income_rules_isolate ON rf_income_rules
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+CREATE TRIGGER tg_income_rules_updated BEFORE UPDATE ON rf_income_rules
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+COMMENT ON COLUMN rf_income_rules.conditions IS 'Condições (payer_id, contract_id, valor_min, valor_max, categoria_vazia); todas devem casar';
+COMMENT ON COLUMN rf_income_rules.actions IS 'Ações (categoria, tags, sobrescrever_categoria) aplicadas quando a regra casa';
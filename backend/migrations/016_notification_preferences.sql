@@ -0,0 +1,17 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Preferências de notificação por tipo de evento e canal (e-mail, push, WhatsApp)
+-- Data: 18-10-2026
+
+-- Destino WhatsApp (E.164) e matriz evento x canal -> modo (immediate | digest | off)
+ALTER TABLE IF EXISTS rf_notification_settings
+  ADD COLUMN IF NOT EXISTS whatsapp text,
+  ADD COLUMN IF NOT EXISTS preferences jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN rf_notification_settings.preferences IS
+  'Modo por evento e canal, ex.: {"payment.received": {"email": "immediate", "push": "digest"}}; ausente = padrão do canal';
+
+-- WhatsApp passa a ser um canal da fila de entregas
+ALTER TABLE IF EXISTS rf_deliveries DROP CONSTRAINT IF EXISTS rf_deliveries_channel_check;
+ALTER TABLE IF EXISTS rf_deliveries
+  ADD CONSTRAINT ck_deliveries_channel CHECK (channel IN ('email', 'webhook', 'push', 'whatsapp'));
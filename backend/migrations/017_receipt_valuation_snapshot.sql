@@ -0,0 +1,91 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Congela valores, pagador e emissor no recibo no momento da emissão
+-- Data: 18-10-2026
+
+-- Snapshot da receita/pagador/emissor; edições posteriores da receita não alteram o recibo emitido
+ALTER TABLE rf_receipts
+  ADD COLUMN IF NOT EXISTS valor numeric(12,2),
+  ADD COLUMN IF NOT EXISTS taxas numeric(12,2) NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS descontos numeric(12,2) NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS valor_liquido numeric(12,2),
+  ADD COLUMN IF NOT EXISTS competencia text,
+  ADD COLUMN IF NOT EXISTS categoria text,
+  ADD COLUMN IF NOT EXISTS payer_nome text,
+  ADD COLUMN IF NOT EXISTS payer_documento text,
+  ADD COLUMN IF NOT EXISTS income_updated_at timestamptz;
+
+ALTER TABLE rf_receipts DROP CONSTRAINT IF EXISTS ck_receipts_adjustments;
+ALTER TABLE rf_receipts ADD CONSTRAINT ck_receipts_adjustments
+  CHECK (taxas >= 0 AND descontos >= 0 AND (valor_liquido IS NULL OR valor_liquido >= 0));
+
+CREATE OR REPLACE FUNCTION rf_receipts_snapshot_guard()
+RETURNS trigger AS $$
+BEGIN
+  IF TG_OP = 'UPDATE' THEN
+    IF NEW.valor IS DISTINCT FROM OLD.valor
+       OR NEW.taxas IS DISTINCT FROM OLD.taxas
+       OR NEW.descontos IS DISTINCT FROM OLD.descontos
+       OR NEW.valor_liquido IS DISTINCT FROM OLD.valor_liquido
+       OR NEW.competencia IS DISTINCT FROM OLD.competencia
+       OR NEW.categoria IS DISTINCT FROM OLD.categoria
+       OR NEW.payer_nome IS DISTINCT FROM OLD.payer_nome
+       OR NEW.payer_documento IS DISTINCT FROM OLD.payer_documento
+       OR NEW.income_updated_at IS DISTINCT FROM OLD.income_updated_at
+       OR NEW.issuer_name IS DISTINCT FROM OLD.issuer_name
+       OR NEW.issuer_document IS DISTINCT FROM OLD.issuer_document THEN
+      RAISE EXCEPTION 'valores congelados do recibo não podem ser alterados'
+        USING ERRCODE = 'check_violation', CONSTRAINT = 'ck_receipts_snapshot_immutable';
+    END IF;
+    RETURN NEW;
+  END IF;
+
+  IF NEW.income_id IS NOT NULL THEN
+    SELECT i.valor, i.competencia, i.categoria, i.updated_at
+      INTO NEW.valor, NEW.competencia, NEW.categoria, NEW.income_updated_at
+      FROM rf_incomes i
+     WHERE i.id = NEW.income_id AND i.owner_id = NEW.owner_id;
+  END IF;
+
+  IF NEW.payer_id IS NOT NULL THEN
+    SELECT p.nome, p.documento INTO NEW.payer_nome, NEW.payer_documento
+      FROM rf_payers p
+     WHERE p.id = NEW.payer_id AND p.owner_id = NEW.owner_id;
+  END IF;
+
+  -- Emissor alternativo informado na emissão prevalece sobre o perfil do usuário
+  IF NEW.issuer_name IS NULL OR NEW.issuer_document IS NULL THEN
+    SELECT COALESCE(NEW.issuer_name, pr.nome), COALESCE(NEW.issuer_document, pr.documento)
+      INTO NEW.issuer_name, NEW.issuer_document
+      FROM rf_profiles pr
+     WHERE pr.id = NEW.owner_id;
+  END IF;
+
+  -- Líquido = valor + taxas (multa/juros) - descontos
+  IF NEW.valor IS NOT NULL THEN
+    NEW.valor_liquido := NEW.valor + NEW.taxas - NEW.descontos;
+  END IF;
+  RETURN NEW;
+END; $$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tg_receipts_snapshot_guard ON rf_receipts;
+CREATE TRIGGER tg_receipts_snapshot_guard BEFORE INSERT OR UPDATE ON rf_receipts
+FOR EACH ROW EXECUTE FUNCTION rf_receipts_snapshot_guard();
+
+-- Recibos já emitidos recebem o estado atual da receita como melhor aproximação do snapshot
+ALTER TABLE rf_receipts DISABLE TRIGGER tg_receipts_snapshot_guard;
+UPDATE rf_receipts r
+   SET valor = i.valor,
+       competencia = i.competencia,
+       categoria = i.categoria,
+       valor_liquido = i.valor + r.taxas - r.descontos,
+       income_updated_at = i.updated_at
+  FROM rf_incomes i
+ WHERE r.income_id = i.id AND r.valor IS NULL;
+UPDATE rf_receipts r
+   SET payer_nome = p.nome, payer_documento = p.documento
+  FROM rf_payers p
+ WHERE r.payer_id = p.id AND r.payer_nome IS NULL;
+ALTER TABLE rf_receipts ENABLE TRIGGER tg_receipts_snapshot_guard;
+
+COMMENT ON COLUMN rf_receipts.income_updated_at IS 'updated_at da receita no momento da emissão (verificação de consistência)';
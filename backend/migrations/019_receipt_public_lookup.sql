@@ -0,0 +1,16 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Índice para consulta pública de existência de recibo por número e documento do emissor
+-- Data: 18-10-2026
+
+-- Documento do emissor normalizado (somente dígitos), com fallback para o perfil do usuário
+CREATE OR REPLACE FUNCTION rf_receipt_issuer_digits(doc text)
+RETURNS text AS $$
+  SELECT NULLIF(regexp_replace(COALESCE(doc, ''), '\D', '', 'g'), '');
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX IF NOT EXISTS idx_receipts_issuer_numero
+  ON rf_receipts (rf_receipt_issuer_digits(issuer_document), numero);
+
+CREATE INDEX IF NOT EXISTS idx_profiles_documento_digits
+  ON rf_profiles (rf_receipt_issuer_digits(documento));
@@ -0,0 +1,64 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Armazenamento endereçado por conteúdo de artefatos gerados (PDFs/QRs) com contagem de referências
+-- Data: 18-10-2026
+
+-- Um artefato por conteúdo (sha256); re-renderizações idênticas reutilizam o mesmo objeto no Storage
+CREATE TABLE IF NOT EXISTS rf_artifacts (
+    hash text PRIMARY KEY CHECK (hash ~ '^[0-9a-f]{64}$'),
+    bucket text NOT NULL,
+    path text NOT NULL,
+    content_type text NOT NULL,
+    size_bytes bigint NOT NULL,
+    ref_count int NOT NULL DEFAULT 0 CHECK (ref_count >= 0),
+    unreferenced_at timestamptz,
+    created_at timestamptz DEFAULT now()
+);
+
+-- Referências de recibos e modelos; um artefato por (alvo, tipo). Exclusão do alvo remove a referência.
+CREATE TABLE IF NOT EXISTS rf_artifact_refs (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    artifact_hash text NOT NULL REFERENCES rf_artifacts(hash),
+    kind text NOT NULL CHECK (kind IN ('pdf','qr')),
+    receipt_id uuid REFERENCES rf_receipts(id) ON DELETE CASCADE,
+    template_id uuid REFERENCES rf_income_templates(id) ON DELETE CASCADE,
+    created_at timestamptz DEFAULT now(),
+    CONSTRAINT ck_artifact_refs_target CHECK ((receipt_id IS NULL) <> (template_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_artifact_refs_receipt ON rf_artifact_refs(receipt_id, kind) WHERE receipt_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_artifact_refs_template ON rf_artifact_refs(template_id, kind) WHERE template_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_artifact_refs_hash ON rf_artifact_refs(artifact_hash);
+CREATE INDEX IF NOT EXISTS idx_artifacts_unreferenced ON rf_artifacts(unreferenced_at) WHERE ref_count = 0;
+
+-- Mantém ref_count; ao chegar a zero marca unreferenced_at para a coleta de lixo respeitar a carência
+CREATE OR REPLACE FUNCTION rf_artifact_refs_count()
+RETURNS trigger AS $$
+BEGIN
+  IF TG_OP = 'UPDATE' AND NEW.artifact_hash = OLD.artifact_hash THEN
+    RETURN NULL;
+  END IF;
+  IF TG_OP IN ('INSERT', 'UPDATE') THEN
+    UPDATE rf_artifacts SET ref_count = ref_count + 1, unreferenced_at = NULL WHERE hash = NEW.artifact_hash;
+  END IF;
+  IF TG_OP IN ('DELETE', 'UPDATE') THEN
+    UPDATE rf_artifacts
+       SET ref_count = ref_count - 1,
+           unreferenced_at = CASE WHEN ref_count - 1 = 0 THEN now() ELSE NULL END
+     WHERE hash = OLD.artifact_hash;
+  END IF;
+  RETURN NULL;
+END; $$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tg_artifact_refs_count ON rf_artifact_refs;
+CREATE TRIGGER tg_artifact_refs_count AFTER INSERT OR DELETE OR UPDATE OF artifact_hash ON rf_artifact_refs
+FOR EACH ROW EXECUTE FUNCTION rf_artifact_refs_count();
+
+-- Artefatos são compartilhados entre usuários (mesmo conteúdo); acesso apenas pelo backend
+ALTER TABLE rf_artifacts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE rf_artifact_refs ENABLE ROW LEVEL SECURITY;
+CREATE POLICY artifact_refs_isolate ON rf_artifact_refs
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+COMMENT ON TABLE rf_artifacts IS 'Artefatos gerados endereçados por sha256; removidos pela coleta de lixo quando sem referências';
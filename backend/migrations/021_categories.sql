@@ -0,0 +1,66 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Categorias como recurso próprio (rf_categories) com cor/ícone por usuário
+-- Data: 18-10-2026
+
+-- rf_incomes.categoria continua guardando o nome (texto); rf_categories é o cadastro
+-- de nomes do usuário, mantido em sincronia por trigger e pela renomeação via API
+CREATE TABLE IF NOT EXISTS rf_categories (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    nome text NOT NULL CHECK (btrim(nome) <> '' AND length(nome) <= 100),
+    cor text CHECK (cor ~ '^#[0-9A-Fa-f]{6}$'),
+    icone text CHECK (length(icone) <= 50),
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now()
+);
+
+-- Nome único por usuário, sem diferenciar maiúsculas
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_owner_nome ON rf_categories(owner_id, lower(nome));
+CREATE INDEX IF NOT EXISTS idx_incomes_owner_categoria ON rf_incomes(owner_id, lower(categoria));
+
+ALTER TABLE rf_categories ENABLE ROW LEVEL SECURITY;
+CREATE POLICY categories_isolate ON rf_categories
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+CREATE TRIGGER tg_categories_updated BEFORE UPDATE ON rf_categories
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Categorias digitadas em receitas e modelos passam a existir no cadastro
+CREATE OR REPLACE FUNCTION rf_categories_ensure()
+RETURNS trigger AS $$
+BEGIN
+  IF NEW.categoria IS NOT NULL AND btrim(NEW.categoria) <> '' THEN
+    INSERT INTO rf_categories (owner_id, nome)
+    VALUES (NEW.owner_id, btrim(NEW.categoria))
+    ON CONFLICT (owner_id, lower(nome)) DO NOTHING;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public;
+
+CREATE TRIGGER tg_incomes_category_ensure
+AFTER INSERT OR UPDATE OF categoria ON rf_incomes
+FOR EACH ROW EXECUTE FUNCTION rf_categories_ensure();
+
+CREATE TRIGGER tg_income_templates_category_ensure
+AFTER INSERT OR UPDATE OF categoria ON rf_income_templates
+FOR EACH ROW EXECUTE FUNCTION rf_categories_ensure();
+
+-- Backfill a partir das categorias já usadas
+INSERT INTO rf_categories (owner_id, nome)
+SELECT DISTINCT ON (owner_id, lower(btrim(categoria))) owner_id, btrim(categoria)
+FROM (
+  SELECT owner_id, categoria FROM rf_incomes
+  UNION ALL
+  SELECT owner_id, categoria FROM rf_income_templates
+) c
+WHERE categoria IS NOT NULL AND btrim(categoria) <> ''
+ORDER BY owner_id, lower(btrim(categoria)), btrim(categoria)
+ON CONFLICT (owner_id, lower(nome)) DO NOTHING;
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON rf_categories TO authenticated;
+
+COMMENT ON TABLE rf_categories IS 'Categorias do usuário; receitas referenciam pelo nome (rf_incomes.categoria)';
+COMMENT ON COLUMN rf_categories.cor IS 'Cor hexadecimal (#RRGGBB) exibida no app';
+COMMENT ON COLUMN rf_categories.icone IS 'Identificador do ícone no app';
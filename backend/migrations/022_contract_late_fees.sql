@@ -0,0 +1,14 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Encargos por atraso (multa, juros de mora e carência) configuráveis por contrato
+-- Data: 18-10-2026
+
+-- Nulos usam o padrão do produto (multa 2%, juros 1% a.m. pro rata die, sem carência)
+ALTER TABLE IF EXISTS rf_contracts
+  ADD COLUMN IF NOT EXISTS multa_percent numeric(5,2) CHECK (multa_percent BETWEEN 0 AND 100),
+  ADD COLUMN IF NOT EXISTS juros_mes_percent numeric(5,2) CHECK (juros_mes_percent BETWEEN 0 AND 100),
+  ADD COLUMN IF NOT EXISTS carencia_dias smallint CHECK (carencia_dias BETWEEN 0 AND 90);
+
+COMMENT ON COLUMN rf_contracts.multa_percent IS 'Multa por atraso (%) sobre o saldo em aberto; nulo usa o padrão (2%)';
+COMMENT ON COLUMN rf_contracts.juros_mes_percent IS 'Juros de mora ao mês (%) pro rata die sobre o saldo; nulo usa o padrão (1%)';
+COMMENT ON COLUMN rf_contracts.carencia_dias IS 'Dias após o vencimento sem cobrança de encargos; nulo usa o padrão (0)';
@@ -0,0 +1,31 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Histórico de cotações e índices (PTAX, IGP-M, IPCA) com sobrescrita manual
+-- Data: 18-10-2026
+
+-- Dados globais (não pertencem a um usuário): leitura para autenticados, escrita pelo backend
+CREATE TABLE IF NOT EXISTS rf_rates (
+    indice text NOT NULL CHECK (indice IN ('ptax_usd', 'ptax_eur', 'igpm', 'ipca')),
+    data date NOT NULL,
+    valor numeric(18,8) NOT NULL,
+    fonte text NOT NULL DEFAULT 'bcb' CHECK (fonte IN ('bcb', 'manual')),
+    observacao text,
+    fetched_at timestamptz,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now(),
+    PRIMARY KEY (indice, data)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rates_indice_data_desc ON rf_rates(indice, data DESC);
+
+ALTER TABLE rf_rates ENABLE ROW LEVEL SECURITY;
+CREATE POLICY rates_read ON rf_rates FOR SELECT TO authenticated USING (true);
+
+CREATE TRIGGER tg_rates_updated BEFORE UPDATE ON rf_rates
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+GRANT SELECT ON rf_rates TO authenticated;
+
+COMMENT ON TABLE rf_rates IS 'Cotações diárias (PTAX) e índices mensais (IGP-M, IPCA; data = 1º dia do mês)';
+COMMENT ON COLUMN rf_rates.valor IS 'PTAX: reais por unidade da moeda (venda); índices: variação mensal em %';
+COMMENT ON COLUMN rf_rates.fonte IS 'bcb = buscado na API do Banco Central; manual = sobrescrita administrativa (o job não altera)';
@@ -0,0 +1,43 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Fusão assistida de contas (mesmo usuário cadastrado duas vezes) com registro de auditoria
+-- Data: 18-10-2026
+
+-- Auditoria das fusões; gravada apenas pelo backend (rotas administrativas)
+CREATE TABLE IF NOT EXISTS rf_account_merges (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_owner_id uuid NOT NULL,
+    target_owner_id uuid NOT NULL,
+    requested_by text NOT NULL,
+    reason text NOT NULL,
+    status text NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed', 'failed')),
+    plan jsonb NOT NULL DEFAULT '{}'::jsonb,
+    result jsonb,
+    error text,
+    started_at timestamptz NOT NULL DEFAULT now(),
+    finished_at timestamptz,
+    CHECK (source_owner_id <> target_owner_id)
+);
+
+-- Sem FK para auth.users: o registro deve sobreviver à remoção da conta de origem
+CREATE INDEX IF NOT EXISTS idx_account_merges_started ON rf_account_merges(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_account_merges_source ON rf_account_merges(source_owner_id);
+CREATE INDEX IF NOT EXISTS idx_account_merges_target ON rf_account_merges(target_owner_id);
+
+ALTER TABLE rf_account_merges ENABLE ROW LEVEL SECURITY;
+
+-- A fusão troca o dono das linhas sem que isso conte como edição: com rf.preserve_updated_at
+-- ligado na transação, updated_at é mantido (recibos comparam income_updated_at com a receita)
+CREATE OR REPLACE FUNCTION set_updated_at()
+RETURNS trigger AS $$
+BEGIN
+  IF current_setting('rf.preserve_updated_at', true) = 'on' THEN
+    RETURN NEW;
+  END IF;
+  NEW.updated_at = now();
+  RETURN NEW;
+END; $$ LANGUAGE plpgsql;
+
+COMMENT ON TABLE rf_account_merges IS 'Fusões de contas: linhas rf_* e objetos do Storage da origem passam para o destino';
+COMMENT ON COLUMN rf_account_merges.plan IS 'Prévia calculada antes da fusão (contagens, conflitos e ajustes automáticos)';
+COMMENT ON COLUMN rf_account_merges.result IS 'Linhas e objetos efetivamente transferidos';
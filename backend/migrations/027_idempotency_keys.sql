@@ -0,0 +1,26 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Chaves de idempotência (Idempotency-Key) com a resposta original para repetição
+-- Data: 18-10-2026
+
+-- Uma linha por usuário e chave. status_code nulo: requisição original ainda em processamento.
+-- request_hash (método, rota e corpo) impede reutilizar a chave em outra requisição.
+CREATE TABLE IF NOT EXISTS rf_idempotency_keys (
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    key text NOT NULL CHECK (length(key) BETWEEN 1 AND 255),
+    method text NOT NULL,
+    path text NOT NULL,
+    request_hash text NOT NULL,
+    status_code int,
+    response_headers jsonb,
+    response_body bytea,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    completed_at timestamptz,
+    PRIMARY KEY (owner_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created ON rf_idempotency_keys(created_at);
+
+ALTER TABLE rf_idempotency_keys ENABLE ROW LEVEL SECURITY;
+CREATE POLICY idempotency_keys_isolate ON rf_idempotency_keys
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
@@ -0,0 +1,41 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Créditos do usuário gerados por pagamentos acima do saldo (modo crédito) e suas aplicações
+-- Data: 18-10-2026
+
+-- Um crédito por pagamento excedente: valor é o excedente original e saldo o que ainda pode ser
+-- aplicado. payer_id vem da receita de origem; crédito de um pagador só quita receitas dele.
+CREATE TABLE IF NOT EXISTS rf_credits (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    payer_id uuid REFERENCES rf_payers(id) ON DELETE SET NULL,
+    source_income_id uuid REFERENCES rf_incomes(id) ON DELETE SET NULL,
+    source_payment_id uuid,
+    valor numeric(12,2) NOT NULL CHECK (valor > 0),
+    saldo numeric(12,2) NOT NULL CHECK (saldo >= 0 AND saldo <= valor),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_credits_owner_available ON rf_credits(owner_id, created_at) WHERE saldo > 0;
+
+-- Cada aplicação vira um pagamento (metodo 'credito') na receita de destino
+CREATE TABLE IF NOT EXISTS rf_credit_applications (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    credit_id uuid NOT NULL REFERENCES rf_credits(id) ON DELETE CASCADE,
+    income_id uuid NOT NULL REFERENCES rf_incomes(id) ON DELETE CASCADE,
+    payment_id uuid NOT NULL,
+    valor numeric(12,2) NOT NULL CHECK (valor > 0),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_credit_applications_credit ON rf_credit_applications(credit_id, created_at);
+
+ALTER TABLE rf_credits ENABLE ROW LEVEL SECURITY;
+CREATE POLICY credits_isolate ON rf_credits
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+ALTER TABLE rf_credit_applications ENABLE ROW LEVEL SECURITY;
+CREATE POLICY credit_applications_isolate ON rf_credit_applications
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
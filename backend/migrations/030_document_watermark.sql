@@ -0,0 +1,10 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Marca d'água dos documentos de receitas não quitadas (configurações do modelo do usuário)
+-- Data: 18-10-2026
+
+-- Documentos (carnê, prévias) de receitas não pagas recebem a marca d'água diagonal.
+-- Texto nulo usa o padrão "SEM VALOR DE QUITAÇÃO"; desligar é uma escolha explícita do usuário.
+ALTER TABLE rf_settings ADD COLUMN IF NOT EXISTS unpaid_watermark boolean NOT NULL DEFAULT true;
+ALTER TABLE rf_settings ADD COLUMN IF NOT EXISTS unpaid_watermark_text text
+    CHECK (unpaid_watermark_text IS NULL OR length(unpaid_watermark_text) BETWEEN 1 AND 60);
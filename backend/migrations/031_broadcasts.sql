@@ -0,0 +1,52 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Envio em massa de notificações personalizadas para pagadores (rf_broadcasts) e relatório por destinatário
+-- Data: 18-10-2026
+
+-- Um envio em massa: modelo com variáveis ({{nome}}, {{saldo}}...), filtro de pagadores e
+-- variáveis extras por pagador. O job "broadcasts" resolve os destinatários, enfileira as
+-- entregas espaçadas pelo limite do provedor e fecha os contadores.
+CREATE TABLE IF NOT EXISTS rf_broadcasts (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    channel text NOT NULL CHECK (channel IN ('email', 'whatsapp')),
+    subject text,
+    template text NOT NULL,
+    filter jsonb NOT NULL DEFAULT '{}'::jsonb,
+    variables jsonb NOT NULL DEFAULT '{}'::jsonb,
+    locale text NOT NULL DEFAULT 'pt-BR',
+    timezone text NOT NULL DEFAULT 'America/Sao_Paulo',
+    status text NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'completed', 'failed')),
+    total integer NOT NULL DEFAULT 0,
+    enqueued integer NOT NULL DEFAULT 0,
+    skipped integer NOT NULL DEFAULT 0,
+    last_error text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    started_at timestamptz,
+    finished_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcasts_owner ON rf_broadcasts(owner_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_broadcasts_pending ON rf_broadcasts(created_at) WHERE status IN ('queued', 'running');
+
+-- Um registro por pagador alcançado pelo filtro: enfileirado (delivery_id) ou ignorado (reason).
+-- A chave (broadcast_id, payer_id) torna o reprocessamento após queda do worker idempotente.
+CREATE TABLE IF NOT EXISTS rf_broadcast_recipients (
+    broadcast_id uuid NOT NULL REFERENCES rf_broadcasts(id) ON DELETE CASCADE,
+    payer_id uuid NOT NULL,
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    destination text,
+    delivery_id uuid REFERENCES rf_deliveries(id) ON DELETE SET NULL,
+    status text NOT NULL CHECK (status IN ('queued', 'skipped')),
+    reason text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (broadcast_id, payer_id)
+);
+
+ALTER TABLE rf_broadcasts ENABLE ROW LEVEL SECURITY;
+CREATE POLICY broadcasts_isolate ON rf_broadcasts
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+ALTER TABLE rf_broadcast_recipients ENABLE ROW LEVEL SECURITY;
+CREATE POLICY broadcast_recipients_isolate ON rf_broadcast_recipients
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
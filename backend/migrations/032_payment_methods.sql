@@ -0,0 +1,61 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Catálogo de formas de pagamento por usuário (rf_payment_methods) no lugar do método em texto livre
+-- Data: 18-10-2026
+
+-- Uma forma de pagamento do usuário: tipo (pix, transferencia, dinheiro...), nome exibido e os
+-- dados do tipo (chave PIX; banco, agência e conta da transferência). No máximo uma é padrão;
+-- formas arquivadas continuam referenciadas pelos pagamentos antigos mas não aceitam novos.
+CREATE TABLE IF NOT EXISTS rf_payment_methods (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    tipo text NOT NULL CHECK (tipo IN ('pix', 'transferencia', 'dinheiro', 'cartao', 'boleto', 'outro')),
+    nome text NOT NULL CHECK (length(btrim(nome)) BETWEEN 1 AND 60),
+    is_default boolean NOT NULL DEFAULT false,
+    pix_chave text,
+    banco text,
+    agencia text,
+    conta text,
+    archived_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_methods_owner_nome
+  ON rf_payment_methods(owner_id, lower(nome)) WHERE archived_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_methods_owner_default
+  ON rf_payment_methods(owner_id) WHERE is_default AND archived_at IS NULL;
+
+-- Pagamento aponta para a forma do catálogo; metodo guarda o nome exibido no momento do lançamento
+ALTER TABLE rf_payments ADD COLUMN IF NOT EXISTS method_id uuid REFERENCES rf_payment_methods(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_method ON rf_payments(method_id, pago_em DESC) WHERE method_id IS NOT NULL;
+
+-- Migração dos métodos em texto livre: uma forma por texto distinto do usuário, com o tipo
+-- inferido pelo nome. Pagamentos de aplicação de crédito ('credito') ficam fora do catálogo.
+INSERT INTO rf_payment_methods (owner_id, tipo, nome)
+SELECT DISTINCT ON (i.owner_id, lower(btrim(p.metodo)))
+       i.owner_id,
+       CASE
+         WHEN lower(btrim(p.metodo)) LIKE '%pix%' THEN 'pix'
+         WHEN lower(btrim(p.metodo)) ~ '(transf|ted|doc|dep)' THEN 'transferencia'
+         WHEN lower(btrim(p.metodo)) ~ '(dinheiro|esp[eé]cie)' THEN 'dinheiro'
+         WHEN lower(btrim(p.metodo)) ~ '(cart|d[eé]bito)' THEN 'cartao'
+         WHEN lower(btrim(p.metodo)) LIKE '%boleto%' THEN 'boleto'
+         ELSE 'outro'
+       END,
+       left(btrim(p.metodo), 60)
+FROM rf_payments p
+JOIN rf_incomes i ON i.id = p.income_id
+WHERE p.metodo IS NOT NULL AND btrim(p.metodo) <> '' AND lower(btrim(p.metodo)) <> 'credito'
+ORDER BY i.owner_id, lower(btrim(p.metodo)), p.created_at
+ON CONFLICT DO NOTHING;
+
+UPDATE rf_payments p SET method_id = m.id
+FROM rf_incomes i, rf_payment_methods m
+WHERE i.id = p.income_id AND m.owner_id = i.owner_id
+  AND m.archived_at IS NULL AND lower(m.nome) = lower(left(btrim(p.metodo), 60))
+  AND p.method_id IS NULL;
+
+ALTER TABLE rf_payment_methods ENABLE ROW LEVEL SECURITY;
+CREATE POLICY payment_methods_isolate ON rf_payment_methods
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
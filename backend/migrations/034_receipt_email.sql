@@ -0,0 +1,17 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Situação do envio do recibo por e-mail ao pagador (POST /api/v1/receipts/{id}/send)
+-- Data: 18-10-2026
+
+-- Colunas fora do snapshot congelado (017): podem mudar a cada reenvio.
+-- email_status: sent (último envio aceito pelo provedor) ou failed (último envio recusado/sem resposta)
+ALTER TABLE rf_receipts
+  ADD COLUMN IF NOT EXISTS email_status text CHECK (email_status IN ('sent', 'failed')),
+  ADD COLUMN IF NOT EXISTS email_to text,
+  ADD COLUMN IF NOT EXISTS email_attempts integer NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS email_last_attempt_at timestamptz,
+  ADD COLUMN IF NOT EXISTS email_sent_at timestamptz,
+  ADD COLUMN IF NOT EXISTS email_error text;
+
+COMMENT ON COLUMN rf_receipts.email_sent_at IS 'Último envio bem-sucedido do recibo por e-mail';
+COMMENT ON COLUMN rf_receipts.email_error IS 'Erro do último envio com falha (limpo no sucesso)';
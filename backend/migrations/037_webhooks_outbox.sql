@@ -0,0 +1,124 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Webhooks configurados pelo usuário e outbox de eventos de domínio
+-- Data: 18-10-2026
+
+-- Cada webhook recebe os eventos assinados (events vazio = todos) por POST com assinatura
+-- HMAC-SHA256 do segredo; as entregas usam a fila rf_deliveries (canal webhook, destino = id).
+CREATE TABLE IF NOT EXISTS rf_webhooks (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events text[] NOT NULL DEFAULT '{}',
+    description text,
+    active boolean NOT NULL DEFAULT true,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT uq_webhooks_owner_url UNIQUE (owner_id, url)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_owner ON rf_webhooks(owner_id) WHERE active;
+
+ALTER TABLE rf_webhooks ENABLE ROW LEVEL SECURITY;
+CREATE POLICY webhooks_isolate ON rf_webhooks
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+-- Outbox: os eventos são gravados por trigger na mesma transação da alteração de domínio, de
+-- modo que nenhum evento se perde nem é emitido para uma escrita desfeita. O publicador marca
+-- published_at depois de enfileirar as entregas dos webhooks.
+CREATE TABLE IF NOT EXISTS rf_outbox_events (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    event_type text NOT NULL,
+    payload jsonb NOT NULL DEFAULT '{}',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    locked_at timestamptz,
+    published_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON rf_outbox_events(created_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published ON rf_outbox_events(published_at) WHERE published_at IS NOT NULL;
+
+ALTER TABLE rf_outbox_events ENABLE ROW LEVEL SECURITY;
+CREATE POLICY outbox_events_isolate ON rf_outbox_events
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+-- income.created
+CREATE OR REPLACE FUNCTION rf_outbox_income_created()
+RETURNS trigger AS $$
+BEGIN
+  INSERT INTO rf_outbox_events (owner_id, event_type, payload)
+  VALUES (NEW.owner_id, 'income.created', jsonb_build_object(
+    'id', NEW.id,
+    'contract_id', NEW.contract_id,
+    'payer_id', NEW.payer_id,
+    'categoria', NEW.categoria,
+    'competencia', NEW.competencia,
+    'valor', NEW.valor,
+    'status', NEW.status,
+    'due_date', NEW.due_date,
+    'tags', NEW.tags,
+    'created_at', NEW.created_at));
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_outbox_income_created ON rf_incomes;
+CREATE TRIGGER trg_outbox_income_created
+  AFTER INSERT ON rf_incomes
+  FOR EACH ROW EXECUTE FUNCTION rf_outbox_income_created();
+
+-- payment.added (rf_payments não tem owner_id; vem da receita)
+CREATE OR REPLACE FUNCTION rf_outbox_payment_added()
+RETURNS trigger AS $$
+BEGIN
+  INSERT INTO rf_outbox_events (owner_id, event_type, payload)
+  SELECT i.owner_id, 'payment.added', jsonb_build_object(
+    'id', NEW.id,
+    'income_id', NEW.income_id,
+    'valor', NEW.valor,
+    'pago_em', NEW.pago_em,
+    'metodo', NEW.metodo,
+    'method_id', NEW.method_id,
+    'obs', NEW.obs,
+    'created_at', NEW.created_at)
+  FROM rf_incomes i
+  WHERE i.id = NEW.income_id;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_outbox_payment_added ON rf_payments;
+CREATE TRIGGER trg_outbox_payment_added
+  AFTER INSERT ON rf_payments
+  FOR EACH ROW EXECUTE FUNCTION rf_outbox_payment_added();
+
+-- receipt.issued (valores já congelados pelo trigger da migração 017)
+CREATE OR REPLACE FUNCTION rf_outbox_receipt_issued()
+RETURNS trigger AS $$
+BEGIN
+  INSERT INTO rf_outbox_events (owner_id, event_type, payload)
+  VALUES (NEW.owner_id, 'receipt.issued', jsonb_build_object(
+    'id', NEW.id,
+    'numero', NEW.numero,
+    'income_id', NEW.income_id,
+    'payer_id', NEW.payer_id,
+    'payer_nome', NEW.payer_nome,
+    'payer_documento', NEW.payer_documento,
+    'valor', NEW.valor,
+    'taxas', NEW.taxas,
+    'descontos', NEW.descontos,
+    'valor_liquido', NEW.valor_liquido,
+    'competencia', NEW.competencia,
+    'categoria', NEW.categoria,
+    'hash', NEW.hash,
+    'emitido_em', NEW.emitido_em));
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_outbox_receipt_issued ON rf_receipts;
+CREATE TRIGGER trg_outbox_receipt_issued
+  AFTER INSERT ON rf_receipts
+  FOR EACH ROW EXECUTE FUNCTION rf_outbox_receipt_issued();
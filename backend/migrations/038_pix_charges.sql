@@ -0,0 +1,28 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Cobranças PIX (QR dinâmico) geradas no PSP para as receitas
+-- Data: 18-10-2026
+
+-- Uma linha por cobrança criada no PSP; txid é o identificador do PSP (API Pix do BCB ou id do
+-- pagamento no Mercado Pago) e liga a confirmação do pagamento à receita.
+CREATE TABLE IF NOT EXISTS rf_pix_charges (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    income_id uuid NOT NULL REFERENCES rf_incomes(id) ON DELETE CASCADE,
+    provider text NOT NULL,
+    txid text NOT NULL,
+    valor numeric(12,2) NOT NULL CHECK (valor > 0),
+    br_code text NOT NULL,
+    location text,
+    status text NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paid', 'expired', 'canceled')),
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    paid_at timestamptz,
+    CONSTRAINT uq_pix_charges_txid UNIQUE (provider, txid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pix_charges_income ON rf_pix_charges(income_id, created_at DESC);
+
+ALTER TABLE rf_pix_charges ENABLE ROW LEVEL SECURITY;
+CREATE POLICY pix_charges_isolate ON rf_pix_charges
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
@@ -0,0 +1,13 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Baixa automática das cobranças PIX pelo webhook do PSP (endToEndId e pagamento lançado)
+-- Data: 18-10-2026
+
+-- A confirmação do PSP marca a cobrança como paga (uma única vez, mesmo com notificações
+-- repetidas) e lança o pagamento na receita; payment_id liga a cobrança ao lançamento.
+ALTER TABLE rf_pix_charges
+    ADD COLUMN IF NOT EXISTS end_to_end_id text,
+    ADD COLUMN IF NOT EXISTS payment_id uuid REFERENCES rf_payments(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_pix_charges_end_to_end
+    ON rf_pix_charges(end_to_end_id) WHERE end_to_end_id IS NOT NULL;
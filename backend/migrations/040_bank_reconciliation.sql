@@ -0,0 +1,60 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Conciliação bancária: extratos importados (OFX/CSV), lançamentos de crédito e sugestões de vínculo com receitas
+-- Data: 18-10-2026
+
+CREATE TABLE IF NOT EXISTS rf_bank_statements (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    filename text,
+    format text NOT NULL CHECK (format IN ('ofx', 'csv')),
+    account text,
+    imported_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- Só os créditos do extrato são guardados; fit_id (FITID do OFX ou hash do lançamento no CSV)
+-- impede importar o mesmo crédito duas vezes, mesmo em extratos com períodos sobrepostos.
+CREATE TABLE IF NOT EXISTS rf_bank_statement_lines (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    statement_id uuid NOT NULL REFERENCES rf_bank_statements(id) ON DELETE CASCADE,
+    fit_id text NOT NULL,
+    posted_at date NOT NULL,
+    valor numeric(12,2) NOT NULL CHECK (valor > 0),
+    descricao text NOT NULL DEFAULT '',
+    status text NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'reconciled')),
+    income_id uuid REFERENCES rf_incomes(id) ON DELETE SET NULL,
+    payment_id uuid REFERENCES rf_payments(id) ON DELETE SET NULL,
+    CONSTRAINT uq_bank_statement_lines_fit UNIQUE (owner_id, fit_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_statement ON rf_bank_statement_lines(statement_id);
+
+-- Sugestões do motor de conciliação (pontuação 0-100 e motivos); confirmar lança o pagamento
+CREATE TABLE IF NOT EXISTS rf_reconciliation_matches (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    line_id uuid NOT NULL REFERENCES rf_bank_statement_lines(id) ON DELETE CASCADE,
+    income_id uuid NOT NULL REFERENCES rf_incomes(id) ON DELETE CASCADE,
+    score integer NOT NULL CHECK (score BETWEEN 0 AND 100),
+    reasons text[] NOT NULL DEFAULT '{}',
+    status text NOT NULL DEFAULT 'suggested' CHECK (status IN ('suggested', 'confirmed', 'rejected')),
+    payment_id uuid REFERENCES rf_payments(id) ON DELETE SET NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    decided_at timestamptz,
+    CONSTRAINT uq_reconciliation_matches_pair UNIQUE (line_id, income_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_matches_owner_status ON rf_reconciliation_matches(owner_id, status, score DESC);
+
+ALTER TABLE rf_bank_statements ENABLE ROW LEVEL SECURITY;
+CREATE POLICY bank_statements_isolate ON rf_bank_statements
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+ALTER TABLE rf_bank_statement_lines ENABLE ROW LEVEL SECURITY;
+CREATE POLICY bank_statement_lines_isolate ON rf_bank_statement_lines
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+ALTER TABLE rf_reconciliation_matches ENABLE ROW LEVEL SECURITY;
+CREATE POLICY reconciliation_matches_isolate ON rf_reconciliation_matches
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
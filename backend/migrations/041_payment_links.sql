@@ -0,0 +1,30 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Links de pagamento (checkout hospedado no Stripe ou Mercado Pago) gerados para as receitas
+-- Data: 18-10-2026
+
+-- Uma linha por sessão de checkout criada no gateway. O id do link vai como referência ao gateway
+-- (client_reference_id / external_reference) e liga a notificação de pagamento à receita; a
+-- baixa marca o link como pago uma única vez e payment_id aponta o pagamento lançado.
+CREATE TABLE IF NOT EXISTS rf_payment_links (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    income_id uuid NOT NULL REFERENCES rf_incomes(id) ON DELETE CASCADE,
+    provider text NOT NULL,
+    session_id text NOT NULL,
+    url text NOT NULL,
+    valor numeric(12,2) NOT NULL CHECK (valor > 0),
+    status text NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'paid', 'expired', 'canceled')),
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    paid_at timestamptz,
+    provider_payment_id text,
+    payment_id uuid REFERENCES rf_payments(id) ON DELETE SET NULL,
+    CONSTRAINT uq_payment_links_session UNIQUE (provider, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_links_income ON rf_payment_links(income_id, created_at DESC);
+
+ALTER TABLE rf_payment_links ENABLE ROW LEVEL SECURITY;
+CREATE POLICY payment_links_isolate ON rf_payment_links
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
@@ -0,0 +1,46 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: NFS-e das receitas pagas (XML ABRASF do RPS) e dados do prestador em rf_settings
+-- Data: 18-10-2026
+
+-- Dados do prestador usados no RPS. nfse_proximo_rps é reservado a cada nota gerada: a prefeitura
+-- exige numeração sem repetição, então números de notas que falharem não são reaproveitados.
+ALTER TABLE rf_settings
+  ADD COLUMN IF NOT EXISTS nfse_documento text,
+  ADD COLUMN IF NOT EXISTS nfse_inscricao_municipal text,
+  ADD COLUMN IF NOT EXISTS nfse_razao_social text,
+  ADD COLUMN IF NOT EXISTS nfse_codigo_municipio text CHECK (nfse_codigo_municipio ~ '^[0-9]{7}$'),
+  ADD COLUMN IF NOT EXISTS nfse_item_lista_servico text,
+  ADD COLUMN IF NOT EXISTS nfse_codigo_tributacao text,
+  ADD COLUMN IF NOT EXISTS nfse_aliquota_iss numeric(5,2) CHECK (nfse_aliquota_iss BETWEEN 0 AND 5),
+  ADD COLUMN IF NOT EXISTS nfse_optante_simples boolean NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS nfse_serie_rps text NOT NULL DEFAULT 'RF',
+  ADD COLUMN IF NOT EXISTS nfse_proximo_rps bigint NOT NULL DEFAULT 1 CHECK (nfse_proximo_rps > 0);
+
+-- Uma nota por receita; xml é o GerarNfseEnvio (ABRASF 2.04) enviado ou a enviar à prefeitura.
+-- provider é nulo quando não há integração municipal (o usuário baixa o XML e envia por conta própria).
+CREATE TABLE IF NOT EXISTS rf_invoices (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    income_id uuid NOT NULL REFERENCES rf_incomes(id) ON DELETE CASCADE,
+    provider text,
+    rps_serie text NOT NULL,
+    rps_numero bigint NOT NULL,
+    valor numeric(12,2) NOT NULL CHECK (valor > 0),
+    status text NOT NULL DEFAULT 'generated' CHECK (status IN ('generated', 'submitted', 'issued', 'failed')),
+    xml text NOT NULL,
+    numero text,
+    codigo_verificacao text,
+    protocolo text,
+    erro text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz,
+    CONSTRAINT uq_invoices_income UNIQUE (income_id),
+    CONSTRAINT uq_invoices_rps UNIQUE (owner_id, rps_serie, rps_numero)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_owner ON rf_invoices(owner_id, created_at DESC);
+
+ALTER TABLE rf_invoices ENABLE ROW LEVEL SECURITY;
+CREATE POLICY invoices_isolate ON rf_invoices
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
@@ -0,0 +1,36 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Exclusão da conta a pedido do titular (LGPD): bloqueio, carência e remoção em segundo plano
+-- Data: 18-10-2026
+
+-- Um pedido por vez: enquanto pending (carência), processing ou failed a conta fica bloqueada
+-- na API; o worker account-deletions executa os pedidos vencidos. Sem FK para auth.users: o
+-- registro (sem dados pessoais) sobrevive à remoção e comprova o atendimento do pedido.
+CREATE TABLE IF NOT EXISTS rf_account_deletions (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL,
+    status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'cancelled', 'processing', 'completed', 'failed')),
+    reason text,
+    requested_at timestamptz NOT NULL DEFAULT now(),
+    scheduled_for timestamptz NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    started_at timestamptz,
+    cancelled_at timestamptz,
+    completed_at timestamptz,
+    result jsonb,
+    error text,
+    CHECK (scheduled_for >= requested_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_account_deletions_active ON rf_account_deletions(owner_id)
+  WHERE status IN ('pending', 'processing', 'failed');
+CREATE INDEX IF NOT EXISTS idx_account_deletions_due ON rf_account_deletions(scheduled_for)
+  WHERE status IN ('pending', 'processing', 'failed');
+
+-- Gravada apenas pelo backend; o titular consulta o próprio pedido
+ALTER TABLE rf_account_deletions ENABLE ROW LEVEL SECURITY;
+CREATE POLICY account_deletions_select ON rf_account_deletions
+  FOR SELECT USING (owner_id = auth.uid());
+
+COMMENT ON TABLE rf_account_deletions IS 'Pedidos de exclusão de conta (LGPD art. 18, VI): linhas rf_* e objetos do Storage removidos após a carência';
+COMMENT ON COLUMN rf_account_deletions.result IS 'Linhas (por tabela) e objetos (por bucket) removidos';
@@ -0,0 +1,93 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Organizações (espaço compartilhado): membros com papéis, convites e acesso às linhas rf_*
+-- Data: 18-10-2026
+
+-- A organização compartilha o espaço de dados do dono: as linhas rf_* continuam com owner_id do
+-- dono e os membros passam a enxergá-las conforme o papel. Por isso cada usuário tem no máximo
+-- uma organização própria.
+CREATE TABLE IF NOT EXISTS rf_orgs (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    nome text NOT NULL CHECK (length(btrim(nome)) BETWEEN 1 AND 120),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz,
+    CONSTRAINT uq_orgs_owner UNIQUE (owner_id)
+);
+
+CREATE TABLE IF NOT EXISTS rf_org_members (
+    org_id uuid NOT NULL REFERENCES rf_orgs(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    role text NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    invited_by uuid,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz,
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_org_members_owner ON rf_org_members(org_id) WHERE role = 'owner';
+CREATE INDEX IF NOT EXISTS idx_org_members_user ON rf_org_members(user_id);
+
+-- Convites por e-mail; só o hash do token é guardado (o token aparece uma única vez na criação).
+-- Um novo convite para o mesmo e-mail revoga o anterior ainda pendente.
+CREATE TABLE IF NOT EXISTS rf_org_invitations (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id uuid NOT NULL REFERENCES rf_orgs(id) ON DELETE CASCADE,
+    email text NOT NULL,
+    role text NOT NULL CHECK (role IN ('editor', 'viewer')),
+    token_hash text NOT NULL,
+    invited_by uuid NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    expires_at timestamptz NOT NULL,
+    accepted_at timestamptz,
+    accepted_by uuid,
+    revoked_at timestamptz,
+    CONSTRAINT uq_org_invitations_token UNIQUE (token_hash)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_org_invitations_pending ON rf_org_invitations(org_id, lower(email))
+  WHERE accepted_at IS NULL AND revoked_at IS NULL;
+
+-- Papel do usuário autenticado no espaço de dados de workspace_owner (nulo se não for membro)
+CREATE OR REPLACE FUNCTION rf_workspace_role(workspace_owner uuid)
+RETURNS text
+LANGUAGE sql STABLE SECURITY DEFINER SET search_path = public AS $$
+  SELECT m.role
+  FROM rf_org_members m JOIN rf_orgs o ON o.id = m.org_id
+  WHERE o.owner_id = workspace_owner AND m.user_id = auth.uid()
+$$;
+
+ALTER TABLE rf_orgs ENABLE ROW LEVEL SECURITY;
+CREATE POLICY orgs_members_select ON rf_orgs
+  FOR SELECT USING (rf_workspace_role(owner_id) IS NOT NULL);
+
+ALTER TABLE rf_org_members ENABLE ROW LEVEL SECURITY;
+CREATE POLICY org_members_select ON rf_org_members
+  FOR SELECT USING (EXISTS (SELECT 1 FROM rf_orgs o WHERE o.id = org_id AND rf_workspace_role(o.owner_id) IS NOT NULL));
+
+-- Convites são gravados e lidos apenas pelo backend
+ALTER TABLE rf_org_invitations ENABLE ROW LEVEL SECURITY;
+
+-- Membros nas tabelas rf_* com owner_id: leitura para todos os papéis, escrita para owner/editor.
+-- As políticas somam-se às de isolamento por owner_id; tabelas criadas depois desta migração
+-- precisam repetir o bloco.
+DO $$
+DECLARE t text;
+BEGIN
+  FOR t IN
+    SELECT c.table_name
+    FROM information_schema.columns c
+    JOIN information_schema.tables tb ON tb.table_schema = c.table_schema AND tb.table_name = c.table_name
+    WHERE c.table_schema = 'public' AND c.column_name = 'owner_id' AND tb.table_type = 'BASE TABLE'
+      AND c.table_name LIKE 'rf\_%' AND c.table_name NOT IN ('rf_orgs', 'rf_account_deletions')
+  LOOP
+    EXECUTE format('DROP POLICY IF EXISTS %I ON %I', t || '_org_read', t);
+    EXECUTE format('CREATE POLICY %I ON %I FOR SELECT USING (rf_workspace_role(owner_id) IS NOT NULL)', t || '_org_read', t);
+    EXECUTE format('DROP POLICY IF EXISTS %I ON %I', t || '_org_write', t);
+    EXECUTE format('CREATE POLICY %I ON %I FOR ALL USING (rf_workspace_role(owner_id) IN (''owner'', ''editor''))'
+      ' WITH CHECK (rf_workspace_role(owner_id) IN (''owner'', ''editor''))', t || '_org_write', t);
+  END LOOP;
+END $$;
+
+COMMENT ON TABLE rf_orgs IS 'Organizações: o espaço de dados do dono (owner_id) compartilhado com os membros';
+COMMENT ON COLUMN rf_org_members.role IS 'owner: tudo, inclusive membros e convites; editor: lê e altera os dados; viewer: só leitura';
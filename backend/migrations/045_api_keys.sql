@@ -0,0 +1,26 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Chaves de API do usuário (acesso programático com X-API-Key e escopos por recurso)
+-- Data: 18-10-2026
+
+-- Só o hash (sha256) da chave é guardado; prefix identifica a chave nas listagens.
+-- scopes: "<recurso>:read" ou "<recurso>:write" (write inclui read); "*" vale para todos os recursos.
+CREATE TABLE IF NOT EXISTS rf_api_keys (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    nome text NOT NULL CHECK (length(btrim(nome)) BETWEEN 1 AND 100),
+    prefix text NOT NULL,
+    key_hash text NOT NULL,
+    scopes text[] NOT NULL CHECK (cardinality(scopes) > 0),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    CONSTRAINT uq_api_keys_hash UNIQUE (key_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_owner ON rf_api_keys(owner_id, created_at DESC);
+
+ALTER TABLE rf_api_keys ENABLE ROW LEVEL SECURITY;
+CREATE POLICY api_keys_isolate ON rf_api_keys
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
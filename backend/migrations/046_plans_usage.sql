@@ -0,0 +1,36 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Planos (rf_plans) e consumo mensal (rf_usage) para limites por plano
+-- Data: 18-10-2026
+
+-- O plano do usuário vem de auth.users.raw_app_meta_data->>'plan' (só o service role altera);
+-- sem plano ou com código desconhecido vale 'free'. Limite nulo = ilimitado; 0 = recurso fora do plano.
+CREATE TABLE IF NOT EXISTS rf_plans (
+    code text PRIMARY KEY,
+    nome text NOT NULL,
+    receipts_per_month integer CHECK (receipts_per_month >= 0),
+    max_signatures integer CHECK (max_signatures >= 0),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+INSERT INTO rf_plans (code, nome, receipts_per_month, max_signatures) VALUES
+    ('free', 'Gratuito', 20, 1),
+    ('pro', 'Profissional', NULL, NULL)
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE rf_plans ENABLE ROW LEVEL SECURITY;
+CREATE POLICY plans_read ON rf_plans FOR SELECT USING (true);
+
+-- Contadores mensais por dono dos dados; period é o primeiro dia do mês (UTC).
+-- Assinaturas não têm contador: o limite vale para as cadastradas em rf_signatures.
+CREATE TABLE IF NOT EXISTS rf_usage (
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    period date NOT NULL,
+    metric text NOT NULL CHECK (metric IN ('receipts')),
+    used integer NOT NULL DEFAULT 0 CHECK (used >= 0),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (owner_id, period, metric)
+);
+
+ALTER TABLE rf_usage ENABLE ROW LEVEL SECURITY;
+CREATE POLICY usage_read ON rf_usage FOR SELECT USING (owner_id = auth.uid());
@@ -0,0 +1,90 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Feed incremental de sincronização (updated_at em pagamentos, recibos e assinaturas e marcas de exclusão)
+-- Data: 18-10-2026
+
+-- updated_at mantido pelo banco (update_updated_at_column, migração 002); linhas existentes
+-- recebem a data de criação para não reaparecerem todas na próxima sincronização
+ALTER TABLE rf_payments ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+UPDATE rf_payments SET updated_at = COALESCE(reversed_at, created_at, now()) WHERE updated_at IS NULL;
+ALTER TABLE rf_payments ALTER COLUMN updated_at SET DEFAULT now(), ALTER COLUMN updated_at SET NOT NULL;
+
+ALTER TABLE rf_receipts ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+UPDATE rf_receipts SET updated_at = COALESCE(email_last_attempt_at, created_at, now()) WHERE updated_at IS NULL;
+ALTER TABLE rf_receipts ALTER COLUMN updated_at SET DEFAULT now(), ALTER COLUMN updated_at SET NOT NULL;
+
+ALTER TABLE rf_signatures ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+UPDATE rf_signatures SET updated_at = COALESCE(created_at, now()) WHERE updated_at IS NULL;
+ALTER TABLE rf_signatures ALTER COLUMN updated_at SET DEFAULT now(), ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS tg_payments_updated_at ON rf_payments;
+CREATE TRIGGER tg_payments_updated_at BEFORE UPDATE ON rf_payments
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+DROP TRIGGER IF EXISTS tg_receipts_updated_at ON rf_receipts;
+CREATE TRIGGER tg_receipts_updated_at BEFORE UPDATE ON rf_receipts
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+DROP TRIGGER IF EXISTS tg_signatures_updated_at ON rf_signatures;
+CREATE TRIGGER tg_signatures_updated_at BEFORE UPDATE ON rf_signatures
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Marcas de exclusão (tombstones) das entidades removidas de fato; receitas usam deleted_at
+CREATE TABLE IF NOT EXISTS rf_sync_tombstones (
+    entity text NOT NULL CHECK (entity IN ('payments', 'receipts', 'signatures')),
+    entity_id uuid NOT NULL,
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    deleted_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (entity, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_tombstones_feed ON rf_sync_tombstones(owner_id, entity, deleted_at, entity_id);
+
+ALTER TABLE rf_sync_tombstones ENABLE ROW LEVEL SECURITY;
+CREATE POLICY sync_tombstones_isolate ON rf_sync_tombstones
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+-- Pagamentos não têm owner_id: o dono vem da receita (já ausente quando a exclusão é em cascata
+-- a partir dela; nesse caso a marca da receita basta). Na exclusão da conta o usuário já não
+-- existe e nenhuma marca é gravada (SECURITY DEFINER para ler auth.users via PostgREST)
+CREATE OR REPLACE FUNCTION rf_sync_tombstone()
+RETURNS trigger AS $$
+DECLARE
+  v_owner uuid;
+BEGIN
+  IF TG_TABLE_NAME = 'rf_payments' THEN
+    SELECT i.owner_id INTO v_owner FROM rf_incomes i WHERE i.id = OLD.income_id;
+  ELSE
+    v_owner := OLD.owner_id;
+  END IF;
+  IF v_owner IS NOT NULL AND EXISTS (SELECT 1 FROM auth.users u WHERE u.id = v_owner) THEN
+    INSERT INTO rf_sync_tombstones (entity, entity_id, owner_id)
+    VALUES (substr(TG_TABLE_NAME, 4), OLD.id, v_owner)
+    ON CONFLICT (entity, entity_id) DO UPDATE SET deleted_at = now();
+  END IF;
+  RETURN OLD;
+END; $$ LANGUAGE plpgsql SECURITY DEFINER SET search_path = public;
+
+DROP TRIGGER IF EXISTS tg_payments_tombstone ON rf_payments;
+CREATE TRIGGER tg_payments_tombstone AFTER DELETE ON rf_payments
+FOR EACH ROW EXECUTE FUNCTION rf_sync_tombstone();
+DROP TRIGGER IF EXISTS tg_receipts_tombstone ON rf_receipts;
+CREATE TRIGGER tg_receipts_tombstone AFTER DELETE ON rf_receipts
+FOR EACH ROW EXECUTE FUNCTION rf_sync_tombstone();
+DROP TRIGGER IF EXISTS tg_signatures_tombstone ON rf_signatures;
+CREATE TRIGGER tg_signatures_tombstone AFTER DELETE ON rf_signatures
+FOR EACH ROW EXECUTE FUNCTION rf_sync_tombstone();
+
+-- Paginação por (updated_at, id) dentro do dono
+CREATE INDEX IF NOT EXISTS idx_incomes_sync ON rf_incomes(owner_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_payments_sync ON rf_payments(income_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_receipts_sync ON rf_receipts(owner_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_signatures_sync ON rf_signatures(owner_id, updated_at, id);
+
+COMMENT ON TABLE rf_sync_tombstones IS 'Exclusões de pagamentos, recibos e assinaturas para o feed GET /api/v1/sync/changes';
@@ -0,0 +1,106 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Esquema de numeração de recibos por usuário (prefixo, dígitos, reinício anual) com sequência sem lacunas
+-- Data: 18-10-2026
+
+-- Esquema do usuário. numero continua sendo a sequência interna (única e crescente, migração 013);
+-- o número exibido nos recibos vem de rf_receipt_sequences conforme o esquema vigente na emissão.
+ALTER TABLE rf_settings
+  ADD COLUMN IF NOT EXISTS recibo_prefixo text CHECK (char_length(recibo_prefixo) <= 20 AND strpos(recibo_prefixo, '/') = 0),
+  ADD COLUMN IF NOT EXISTS recibo_digitos int NOT NULL DEFAULT 0 CHECK (recibo_digitos BETWEEN 0 AND 10),
+  ADD COLUMN IF NOT EXISTS recibo_reinicio text NOT NULL DEFAULT 'never' CHECK (recibo_reinicio IN ('never', 'yearly'));
+
+-- Último número exibido por usuário e período (0 = sem reinício; ano = reinício anual).
+-- Atualizada na mesma transação do INSERT do recibo: um recibo que falha desfaz a reserva.
+CREATE TABLE IF NOT EXISTS rf_receipt_sequences (
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    periodo int NOT NULL CHECK (periodo >= 0),
+    ultimo bigint NOT NULL CHECK (ultimo > 0),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (owner_id, periodo)
+);
+
+ALTER TABLE rf_receipt_sequences ENABLE ROW LEVEL SECURITY;
+CREATE POLICY receipt_sequences_isolate ON rf_receipt_sequences
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+ALTER TABLE rf_receipts
+  ADD COLUMN IF NOT EXISTS serie_periodo int,
+  ADD COLUMN IF NOT EXISTS serie_numero bigint,
+  ADD COLUMN IF NOT EXISTS numero_formatado text;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_receipts_owner_serie ON rf_receipts(owner_id, serie_periodo, serie_numero);
+
+CREATE OR REPLACE FUNCTION rf_receipts_numero_guard()
+RETURNS trigger AS $$
+DECLARE
+  last_numero bigint;
+  explicit_numero bigint;
+  prefixo text;
+  digitos int;
+  reinicio text;
+BEGIN
+  IF TG_OP = 'UPDATE' THEN
+    IF NEW.numero IS DISTINCT FROM OLD.numero
+       OR NEW.serie_periodo IS DISTINCT FROM OLD.serie_periodo
+       OR NEW.serie_numero IS DISTINCT FROM OLD.serie_numero
+       OR NEW.numero_formatado IS DISTINCT FROM OLD.numero_formatado THEN
+      RAISE EXCEPTION 'número do recibo não pode ser alterado'
+        USING ERRCODE = 'check_violation', CONSTRAINT = 'ck_receipts_numero_immutable';
+    END IF;
+    RETURN NEW;
+  END IF;
+
+  -- Serializa emissões do mesmo usuário para evitar corrida entre MAX e INSERT
+  PERFORM pg_advisory_xact_lock(hashtext('rf_receipts:' || NEW.owner_id::text));
+  SELECT MAX(numero) INTO last_numero FROM rf_receipts WHERE owner_id = NEW.owner_id;
+
+  explicit_numero := NEW.numero;
+  IF NEW.numero IS NULL THEN
+    NEW.numero := COALESCE(last_numero, 0) + 1;
+  ELSIF last_numero IS NOT NULL AND NEW.numero <= last_numero THEN
+    -- Números informados (migração de talões em papel) devem seguir a sequência
+    RAISE EXCEPTION 'número % não é maior que o último emitido (%)', NEW.numero, last_numero
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'ck_receipts_numero_monotonic';
+  END IF;
+
+  SELECT s.recibo_prefixo, s.recibo_digitos, s.recibo_reinicio INTO prefixo, digitos, reinicio
+    FROM rf_settings s WHERE s.owner_id = NEW.owner_id;
+
+  -- O ano do reinício é o da emissão no fuso padrão do produto
+  IF reinicio = 'yearly' THEN
+    NEW.serie_periodo := extract(year FROM COALESCE(NEW.emitido_em, now()) AT TIME ZONE 'America/Sao_Paulo')::int;
+    explicit_numero := NULL;
+  ELSE
+    NEW.serie_periodo := 0;
+  END IF;
+
+  -- Sem reinício, um número informado também avança a sequência exibida (talão em papel)
+  INSERT INTO rf_receipt_sequences AS q (owner_id, periodo, ultimo)
+  VALUES (NEW.owner_id, NEW.serie_periodo, COALESCE(explicit_numero, 1))
+  ON CONFLICT (owner_id, periodo) DO UPDATE
+    SET ultimo = GREATEST(q.ultimo + 1, EXCLUDED.ultimo), updated_at = now()
+  RETURNING ultimo INTO NEW.serie_numero;
+
+  NEW.numero_formatado := COALESCE(prefixo, '')
+    || lpad(NEW.serie_numero::text, GREATEST(COALESCE(digitos, 0), length(NEW.serie_numero::text)), '0')
+    || CASE WHEN reinicio = 'yearly' THEN '/' || NEW.serie_periodo ELSE '' END;
+  RETURN NEW;
+END; $$ LANGUAGE plpgsql;
+
+-- Recibos já emitidos mantêm o número atual como número exibido; a sequência sem reinício
+-- continua de onde a numeração parou (updated_at preservado: não é edição do recibo)
+ALTER TABLE rf_receipts DISABLE TRIGGER tg_receipts_numero_guard;
+ALTER TABLE rf_receipts DISABLE TRIGGER tg_receipts_updated_at;
+UPDATE rf_receipts SET serie_periodo = 0, serie_numero = numero, numero_formatado = numero::text
+ WHERE serie_numero IS NULL;
+ALTER TABLE rf_receipts ENABLE TRIGGER tg_receipts_updated_at;
+ALTER TABLE rf_receipts ENABLE TRIGGER tg_receipts_numero_guard;
+
+INSERT INTO rf_receipt_sequences (owner_id, periodo, ultimo)
+SELECT owner_id, 0, MAX(numero) FROM rf_receipts GROUP BY owner_id
+ON CONFLICT (owner_id, periodo) DO NOTHING;
+
+COMMENT ON COLUMN rf_settings.recibo_reinicio IS 'Reinício da numeração exibida dos recibos: never ou yearly (a cada ano de emissão)';
+COMMENT ON COLUMN rf_receipts.numero_formatado IS 'Número exibido (prefixo, dígitos e ano do esquema vigente na emissão); imutável';
+COMMENT ON TABLE rf_receipt_sequences IS 'Último número exibido de recibo por usuário e período, reservado na transação da emissão';
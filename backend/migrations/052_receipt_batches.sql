@@ -0,0 +1,53 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Emissão de recibos em lote por competência (rf_receipt_batches) com situação por receita
+-- Data: 18-10-2026
+
+-- Um lote: as receitas pagas da competência sem recibo são gravadas como itens no pedido; o job
+-- "receipt-batches" emite os recibos, gera e envia os PDFs e fecha o lote.
+CREATE TABLE IF NOT EXISTS rf_receipt_batches (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    competencia text NOT NULL CHECK (competencia ~ '^[0-9]{4}-[0-9]{2}$'),
+    locale text NOT NULL DEFAULT 'pt-BR',
+    timezone text NOT NULL DEFAULT 'America/Sao_Paulo',
+    status text NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'completed', 'failed')),
+    total integer NOT NULL DEFAULT 0,
+    attempts integer NOT NULL DEFAULT 0,
+    last_error text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    started_at timestamptz,
+    finished_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_receipt_batches_owner ON rf_receipt_batches(owner_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_receipt_batches_pending ON rf_receipt_batches(created_at) WHERE status IN ('queued', 'running');
+-- Um lote em andamento por competência: um segundo pedido emitiria recibos em dobro
+CREATE UNIQUE INDEX IF NOT EXISTS uq_receipt_batches_active ON rf_receipt_batches(owner_id, competencia)
+  WHERE status IN ('queued', 'running');
+
+-- Uma linha por receita do lote. pending: aguardando emissão; issued: recibo emitido, PDF pendente;
+-- done: recibo com PDF; skipped: a receita ganhou recibo por outro caminho; failed: ver error.
+-- receipt_id gravado antes do PDF torna o reprocessamento após queda do worker idempotente.
+CREATE TABLE IF NOT EXISTS rf_receipt_batch_items (
+    batch_id uuid NOT NULL REFERENCES rf_receipt_batches(id) ON DELETE CASCADE,
+    income_id uuid NOT NULL,
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    receipt_id uuid REFERENCES rf_receipts(id) ON DELETE SET NULL,
+    status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'issued', 'done', 'skipped', 'failed')),
+    error text,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (batch_id, income_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_receipt_batch_items_open ON rf_receipt_batch_items(batch_id) WHERE status IN ('pending', 'issued');
+
+ALTER TABLE rf_receipt_batches ENABLE ROW LEVEL SECURITY;
+CREATE POLICY receipt_batches_isolate ON rf_receipt_batches
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+ALTER TABLE rf_receipt_batch_items ENABLE ROW LEVEL SECURITY;
+CREATE POLICY receipt_batch_items_isolate ON rf_receipt_batch_items
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+COMMENT ON TABLE rf_receipt_batches IS 'Emissão de recibos em lote para as receitas pagas de uma competência';
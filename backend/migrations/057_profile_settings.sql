@@ -0,0 +1,71 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Perfil do usuário (contato, emissor padrão dos recibos, idioma e fuso) em rf_profiles
+-- Data: 18-10-2026
+
+-- nome/documento já existiam (exibição e emissor de fallback). Idioma e fuso do perfil prevalecem
+-- sobre os de rf_settings, gravados pelo app antes do endpoint de perfil.
+ALTER TABLE rf_profiles
+  ADD COLUMN IF NOT EXISTS endereco text,
+  ADD COLUMN IF NOT EXISTS telefone text,
+  ADD COLUMN IF NOT EXISTS emissor_nome text,
+  ADD COLUMN IF NOT EXISTS emissor_documento text,
+  ADD COLUMN IF NOT EXISTS locale text,
+  ADD COLUMN IF NOT EXISTS timezone text,
+  ADD COLUMN IF NOT EXISTS updated_at timestamptz DEFAULT now();
+
+COMMENT ON COLUMN rf_profiles.emissor_nome IS 'Emissor padrão dos recibos (sem ele, nome do perfil)';
+COMMENT ON COLUMN rf_profiles.locale IS 'Idioma do usuário; prevalece sobre rf_settings.locale';
+COMMENT ON COLUMN rf_profiles.timezone IS 'Fuso IANA do usuário; prevalece sobre rf_settings.timezone';
+
+-- Recibos novos usam o emissor padrão do perfil quando a emissão não informa outro
+CREATE OR REPLACE FUNCTION rf_receipts_snapshot_guard()
+RETURNS trigger AS $$
+BEGIN
+  IF TG_OP = 'UPDATE' THEN
+    IF NEW.valor IS DISTINCT FROM OLD.valor
+       OR NEW.taxas IS DISTINCT FROM OLD.taxas
+       OR NEW.descontos IS DISTINCT FROM OLD.descontos
+       OR NEW.valor_liquido IS DISTINCT FROM OLD.valor_liquido
+       OR NEW.competencia IS DISTINCT FROM OLD.competencia
+       OR NEW.categoria IS DISTINCT FROM OLD.categoria
+       OR NEW.payer_nome IS DISTINCT FROM OLD.payer_nome
+       OR NEW.payer_documento IS DISTINCT FROM OLD.payer_documento
+       OR NEW.income_updated_at IS DISTINCT FROM OLD.income_updated_at
+       OR NEW.issuer_name IS DISTINCT FROM OLD.issuer_name
+       OR NEW.issuer_document IS DISTINCT FROM OLD.issuer_document THEN
+      RAISE EXCEPTION 'valores congelados do recibo não podem ser alterados'
+        USING ERRCODE = 'check_violation', CONSTRAINT = 'ck_receipts_snapshot_immutable';
+    END IF;
+    RETURN NEW;
+  END IF;
+
+  IF NEW.income_id IS NOT NULL THEN
+    SELECT i.valor, i.competencia, i.categoria, i.updated_at
+      INTO NEW.valor, NEW.competencia, NEW.categoria, NEW.income_updated_at
+      FROM rf_incomes i
+     WHERE i.id = NEW.income_id AND i.owner_id = NEW.owner_id;
+  END IF;
+
+  IF NEW.payer_id IS NOT NULL THEN
+    SELECT p.nome, p.documento INTO NEW.payer_nome, NEW.payer_documento
+      FROM rf_payers p
+     WHERE p.id = NEW.payer_id AND p.owner_id = NEW.owner_id;
+  END IF;
+
+  -- Emissor alternativo informado na emissão prevalece sobre o emissor padrão do perfil,
+  -- que prevalece sobre o nome e o documento do próprio usuário
+  IF NEW.issuer_name IS NULL OR NEW.issuer_document IS NULL THEN
+    SELECT COALESCE(NEW.issuer_name, pr.emissor_nome, pr.nome),
+           COALESCE(NEW.issuer_document, pr.emissor_documento, pr.documento)
+      INTO NEW.issuer_name, NEW.issuer_document
+      FROM rf_profiles pr
+     WHERE pr.id = NEW.owner_id;
+  END IF;
+
+  -- Líquido = valor + taxas (multa/juros) - descontos
+  IF NEW.valor IS NOT NULL THEN
+    NEW.valor_liquido := NEW.valor + NEW.taxas - NEW.descontos;
+  END IF;
+  RETURN NEW;
+END; $$ LANGUAGE plpgsql;
@@ -0,0 +1,17 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Migrações SQL do esquema rf_* embutidas no binário
+// Data: 18-10-2026
+
+// Package migrations embute os arquivos SQL do esquema do banco.
+// Docstring: os arquivos espelham supabase/001_init.sql e supabase/migrations (usados pelo
+// Supabase CLI e pelo SQL Editor); uma migração nova entra nos dois lugares com o mesmo nome.
+// A ordem de aplicação é a ordem alfabética dos nomes (prefixo numérico NNN_).
+package migrations
+
+import "embed"
+
+// FS arquivos *.sql das migrações
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,44 @@
+// MIT License
+// Autor atual: David Assef
+// Descrição: Testes de sincronia entre as migrações embutidas e supabase/migrations
+// Data: 18-10-2026
+
+package migrations
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFS_MatchesSupabaseMigrations(t *testing.T) {
+	dir := filepath.Join("..", "..", "supabase")
+	sources, err := filepath.Glob(filepath.Join(dir, "migrations", "*.sql"))
+	if err != nil || len(sources) == 0 {
+		t.Skip("supabase/migrations indisponível fora do repositório")
+	}
+	sources = append(sources, filepath.Join(dir, "001_init.sql"))
+
+	embedded, err := fs.Glob(FS, "*.sql")
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	if len(embedded) != len(sources) {
+		t.Fatalf("%d migrações embutidas, %d em supabase/", len(embedded), len(sources))
+	}
+	for _, src := range sources {
+		want, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatalf("ler %s: %v", src, err)
+		}
+		got, err := fs.ReadFile(FS, filepath.Base(src))
+		if err != nil {
+			t.Fatalf("%s não está em backend/migrations", filepath.Base(src))
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s difere de %s", filepath.Base(src), src)
+		}
+	}
+}
@@ -10,7 +10,7 @@ import { Pagamento, PagamentoForm, FormaPagamento } from '../types/pagamento';
 interface BackendPayment {
   id: string;
   income_id: string;
-  valor: number;
+  valor: string | number; // decimal em string ("1234.56"); versões antigas enviavam número
   pago_em: string; // RFC3339
   metodo?: string | null;
   obs?: string | null;
@@ -65,7 +65,7 @@ const fromBackendPayment = (p: BackendPayment): Pagamento => {
   return {
     id: p.id,
     receita_id: p.income_id,
-    valor: p.valor,
+    valor: Number(p.valor),
     data_pagamento: p.pago_em,
     forma_pagamento: metodo,
     observacoes: p.obs ?? undefined,
@@ -0,0 +1,72 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Fila de entregas assíncronas (e-mail/webhook/push) com dead-letter e estatísticas por destino
+-- Data: 18-10-2026
+
+-- Entregas pendentes, concluídas e em dead-letter
+CREATE TABLE IF NOT EXISTS rf_deliveries (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    channel text NOT NULL CHECK (channel IN ('email', 'webhook', 'push')),
+    destination text NOT NULL,
+    event_type text NOT NULL,
+    payload jsonb NOT NULL DEFAULT '{}'::jsonb,
+    status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'delivered', 'dead')),
+    attempts int NOT NULL DEFAULT 0,
+    max_attempts int NOT NULL DEFAULT 5,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    sla_deadline timestamptz,
+    locked_at timestamptz,
+    last_error text,
+    last_attempt_at timestamptz,
+    delivered_at timestamptz,
+    dead_at timestamptz,
+    dead_reason text,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_deliveries_due ON rf_deliveries(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_deliveries_dead ON rf_deliveries(dead_at DESC) WHERE status = 'dead';
+CREATE INDEX IF NOT EXISTS idx_deliveries_destination ON rf_deliveries(owner_id, channel, destination);
+
+-- Saúde por destino: sequência de falhas, totais e desabilitação automática
+CREATE TABLE IF NOT EXISTS rf_delivery_destinations (
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    channel text NOT NULL,
+    destination text NOT NULL,
+    total_success bigint NOT NULL DEFAULT 0,
+    total_failures bigint NOT NULL DEFAULT 0,
+    consecutive_failures int NOT NULL DEFAULT 0,
+    first_failure_at timestamptz,
+    last_failure_at timestamptz,
+    last_success_at timestamptz,
+    last_error text,
+    disabled_at timestamptz,
+    disabled_reason text,
+    updated_at timestamptz DEFAULT now(),
+    PRIMARY KEY (owner_id, channel, destination)
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_destinations_disabled
+  ON rf_delivery_destinations(disabled_at) WHERE disabled_at IS NOT NULL;
+
+-- RLS: o usuário enxerga apenas as próprias entregas; o worker usa service role
+ALTER TABLE rf_deliveries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE rf_delivery_destinations ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY deliveries_isolate ON rf_deliveries
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+CREATE POLICY delivery_destinations_isolate ON rf_delivery_destinations
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+CREATE TRIGGER tg_deliveries_updated BEFORE UPDATE ON rf_deliveries
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER tg_delivery_destinations_updated BEFORE UPDATE ON rf_delivery_destinations
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Comentários
+COMMENT ON COLUMN rf_deliveries.sla_deadline IS 'Prazo máximo para entrega; após ele a entrega vai para dead-letter mesmo com tentativas restantes';
+COMMENT ON COLUMN rf_deliveries.dead_reason IS 'Motivo do envio para dead-letter (tentativas esgotadas, SLA expirado, destino desabilitado)';
+COMMENT ON COLUMN rf_delivery_destinations.first_failure_at IS 'Início da sequência atual de falhas; zerado ao primeiro sucesso';
+COMMENT ON COLUMN rf_delivery_destinations.disabled_at IS 'Preenchido automaticamente quando o destino falha continuamente por 7 dias ou mais';
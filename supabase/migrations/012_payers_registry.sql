@@ -0,0 +1,42 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Cadastro de pagadores (rf_payers) com contato/endereço e vínculo com receitas e recibos
+-- Data: 18-10-2026
+
+-- rf_payers já existe (001_init.sql); adiciona dados de contato e endereço
+ALTER TABLE IF EXISTS rf_payers
+  ADD COLUMN IF NOT EXISTS email TEXT,
+  ADD COLUMN IF NOT EXISTS telefone TEXT,
+  ADD COLUMN IF NOT EXISTS endereco TEXT,
+  ADD COLUMN IF NOT EXISTS cidade TEXT,
+  ADD COLUMN IF NOT EXISTS uf CHAR(2),
+  ADD COLUMN IF NOT EXISTS cep TEXT;
+
+-- Documento (CPF/CNPJ, apenas dígitos) único por usuário quando informado
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payers_owner_documento
+  ON rf_payers(owner_id, documento) WHERE documento IS NOT NULL AND documento <> '';
+CREATE INDEX IF NOT EXISTS idx_payers_owner_nome ON rf_payers(owner_id, lower(nome));
+
+-- Chave composta para garantir que receitas/recibos só referenciem pagadores do mesmo usuário
+ALTER TABLE rf_payers
+  ADD CONSTRAINT uq_payers_id_owner UNIQUE (id, owner_id);
+
+ALTER TABLE IF EXISTS rf_incomes
+  ADD COLUMN IF NOT EXISTS payer_id uuid;
+ALTER TABLE rf_incomes
+  ADD CONSTRAINT fk_incomes_payer FOREIGN KEY (payer_id, owner_id)
+  REFERENCES rf_payers(id, owner_id) ON DELETE SET NULL (payer_id);
+
+ALTER TABLE IF EXISTS rf_receipts
+  ADD COLUMN IF NOT EXISTS payer_id uuid;
+ALTER TABLE rf_receipts
+  ADD CONSTRAINT fk_receipts_payer FOREIGN KEY (payer_id, owner_id)
+  REFERENCES rf_payers(id, owner_id) ON DELETE SET NULL (payer_id);
+
+CREATE INDEX IF NOT EXISTS idx_incomes_payer ON rf_incomes(owner_id, payer_id);
+CREATE INDEX IF NOT EXISTS idx_receipts_payer ON rf_receipts(owner_id, payer_id);
+
+-- Comentários
+COMMENT ON COLUMN rf_payers.documento IS 'CPF ou CNPJ do pagador, somente dígitos';
+COMMENT ON COLUMN rf_incomes.payer_id IS 'Pagador da receita (mesmo owner_id garantido por FK composta)';
+COMMENT ON COLUMN rf_receipts.payer_id IS 'Pagador do recibo; herdado da receita quando não informado';
@@ -0,0 +1,38 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Preferências de notificação e controle do resumo semanal (digest)
+-- Data: 18-10-2026
+
+-- Preferências de notificação por usuário. Sem linha = digest habilitado por e-mail
+-- na segunda-feira, usando o e-mail de auth.users.
+CREATE TABLE IF NOT EXISTS rf_notification_settings (
+    owner_id uuid PRIMARY KEY REFERENCES auth.users(id) ON DELETE CASCADE,
+    email text,
+    push_subscription text,
+    digest_enabled boolean NOT NULL DEFAULT true,
+    digest_channels text[] NOT NULL DEFAULT '{email}',
+    digest_weekday smallint NOT NULL DEFAULT 1,
+    unsubscribe_token uuid NOT NULL DEFAULT gen_random_uuid(),
+    last_digest_at timestamptz,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now(),
+    CONSTRAINT ck_notification_digest_weekday CHECK (digest_weekday BETWEEN 0 AND 6),
+    CONSTRAINT ck_notification_digest_channels CHECK (digest_channels <@ ARRAY['email', 'push']::text[])
+);
+
+-- Token usado no link de descadastro (sem login)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_notification_unsubscribe_token ON rf_notification_settings(unsubscribe_token);
+
+ALTER TABLE rf_notification_settings ENABLE ROW LEVEL SECURITY;
+CREATE POLICY notification_settings_isolate ON rf_notification_settings
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+CREATE TRIGGER tg_notification_settings_updated BEFORE UPDATE ON rf_notification_settings
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Apoio às consultas do resumo (pagamentos recebidos e recibos emitidos no período)
+CREATE INDEX IF NOT EXISTS idx_payments_pago_em ON rf_payments(pago_em);
+CREATE INDEX IF NOT EXISTS idx_receipts_owner_emitido ON rf_receipts(owner_id, emitido_em);
+
+COMMENT ON COLUMN rf_notification_settings.digest_weekday IS 'Dia da semana do envio (0 = domingo ... 6 = sábado)';
+COMMENT ON COLUMN rf_notification_settings.last_digest_at IS 'Último resumo enfileirado; evita envio duplicado na mesma semana';
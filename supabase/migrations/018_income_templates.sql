@@ -0,0 +1,29 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Modelos de receita para recobranças mensais sem redigitar categoria, valor e pagador
+-- Data: 18-10-2026
+
+CREATE TABLE IF NOT EXISTS rf_income_templates (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    nome text NOT NULL,
+    payer_id uuid REFERENCES rf_payers(id) ON DELETE SET NULL,
+    contract_id uuid REFERENCES rf_contracts(id) ON DELETE SET NULL,
+    categoria text,
+    tags text[] NOT NULL DEFAULT '{}',
+    valor numeric(12,2) NOT NULL CHECK (valor > 0),
+    due_day smallint CHECK (due_day BETWEEN 1 AND 31),
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_income_templates_owner ON rf_income_templates(owner_id, nome);
+
+ALTER TABLE rf_income_templates ENABLE ROW LEVEL SECURITY;
+CREATE POLICY income_templates_isolate ON rf_income_templates
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+CREATE TRIGGER tg_income_templates_updated BEFORE UPDATE ON rf_income_templates
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+COMMENT ON COLUMN rf_income_templates.due_day IS 'Dia de vencimento no mês da competência (limitado ao último dia do mês)';
@@ -0,0 +1,22 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Versão das receitas para controle de concorrência otimista (If-Match/version)
+-- Data: 18-10-2026
+
+ALTER TABLE rf_incomes
+  ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+
+-- Toda alteração incrementa a versão, inclusive as feitas pelo app via Supabase e pelo
+-- trigger de total_pago; o backend grava com WHERE version = <versão lida>
+CREATE OR REPLACE FUNCTION rf_incomes_bump_version()
+RETURNS trigger AS $$
+BEGIN
+  NEW.version := OLD.version + 1;
+  RETURN NEW;
+END; $$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tg_incomes_version ON rf_incomes;
+CREATE TRIGGER tg_incomes_version BEFORE UPDATE ON rf_incomes
+FOR EACH ROW EXECUTE FUNCTION rf_incomes_bump_version();
+
+COMMENT ON COLUMN rf_incomes.version IS 'Versão da receita (incrementada a cada alteração); exposta como ETag';
@@ -0,0 +1,35 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Conflitos de sincronização offline pendentes (por dispositivo) e sua resolução
+-- Data: 18-10-2026
+
+-- Registrado pelo envio offline (sync push) quando a versão editada no dispositivo não é mais
+-- a do servidor; resolvido pelo usuário ou pelo suporte (manter a minha, a do servidor ou mesclar)
+CREATE TABLE IF NOT EXISTS rf_sync_conflicts (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    device_id text NOT NULL CHECK (length(device_id) BETWEEN 1 AND 200),
+    entity text NOT NULL CHECK (entity IN ('income')),
+    entity_id uuid NOT NULL,
+    base_version bigint,
+    client_data jsonb NOT NULL,
+    server_data jsonb NOT NULL,
+    server_version bigint NOT NULL,
+    status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'resolved')),
+    resolution text CHECK (resolution IN ('keep_mine', 'keep_server', 'merge')),
+    resolved_data jsonb,
+    resolved_by text CHECK (resolved_by IN ('user', 'support')),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    resolved_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_conflicts_pending
+  ON rf_sync_conflicts(owner_id, device_id, created_at) WHERE status = 'pending';
+
+ALTER TABLE rf_sync_conflicts ENABLE ROW LEVEL SECURITY;
+CREATE POLICY sync_conflicts_isolate ON rf_sync_conflicts
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+COMMENT ON COLUMN rf_sync_conflicts.client_data IS 'Campos da receita como editados no dispositivo (JSON da API)';
+COMMENT ON COLUMN rf_sync_conflicts.server_data IS 'Estado da receita no servidor quando o conflito foi detectado';
+COMMENT ON COLUMN rf_sync_conflicts.resolved_data IS 'Campos efetivamente gravados na resolução (vazio em keep_server)';
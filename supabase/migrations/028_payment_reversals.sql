@@ -0,0 +1,34 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Exclusão e estorno de pagamentos com trilha de auditoria
+-- Data: 18-10-2026
+
+-- Pagamento estornado continua listado (com data e motivo), mas deixa de contar no total pago
+ALTER TABLE rf_payments ADD COLUMN IF NOT EXISTS reversed_at timestamptz;
+ALTER TABLE rf_payments ADD COLUMN IF NOT EXISTS reversal_reason text;
+
+-- Trilha de auditoria: uma linha por exclusão ou estorno, com a cópia do pagamento e o
+-- efeito na receita (payment_id sem FK: o pagamento excluído não existe mais)
+CREATE TABLE IF NOT EXISTS rf_payment_reversals (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    payment_id uuid NOT NULL,
+    income_id uuid NOT NULL REFERENCES rf_incomes(id) ON DELETE CASCADE,
+    kind text NOT NULL CHECK (kind IN ('delete', 'reverse')),
+    valor numeric(12,2) NOT NULL,
+    payment jsonb NOT NULL,
+    reason text CHECK (reason IS NULL OR length(reason) <= 500),
+    total_pago_before numeric(12,2) NOT NULL,
+    total_pago_after numeric(12,2) NOT NULL,
+    status_before text NOT NULL,
+    status_after text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_reversals_income ON rf_payment_reversals(income_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_payment_reversals_owner ON rf_payment_reversals(owner_id, created_at);
+
+ALTER TABLE rf_payment_reversals ENABLE ROW LEVEL SECURITY;
+CREATE POLICY payment_reversals_isolate ON rf_payment_reversals
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
@@ -0,0 +1,22 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Regras de multa e juros por usuário (rf_settings) e juros diários por contrato
+-- Data: 18-10-2026
+
+-- Precedência: contrato > usuário > padrão do produto (multa 2%, juros 1% a.m. pro rata die).
+-- Multa e carência são herdadas campo a campo; os juros (ao mês ou ao dia) são herdados como uma
+-- regra só. Com juros ao dia informados, os juros ao mês do mesmo nível são ignorados.
+ALTER TABLE rf_settings
+  ADD COLUMN IF NOT EXISTS multa_percent numeric(5,2) CHECK (multa_percent BETWEEN 0 AND 100),
+  ADD COLUMN IF NOT EXISTS juros_mes_percent numeric(5,2) CHECK (juros_mes_percent BETWEEN 0 AND 100),
+  ADD COLUMN IF NOT EXISTS juros_dia_percent numeric(6,4) CHECK (juros_dia_percent BETWEEN 0 AND 100),
+  ADD COLUMN IF NOT EXISTS carencia_dias smallint CHECK (carencia_dias BETWEEN 0 AND 90);
+
+ALTER TABLE IF EXISTS rf_contracts
+  ADD COLUMN IF NOT EXISTS juros_dia_percent numeric(6,4) CHECK (juros_dia_percent BETWEEN 0 AND 100);
+
+COMMENT ON COLUMN rf_settings.multa_percent IS 'Multa por atraso (%) padrão do usuário; nulo usa o padrão do produto';
+COMMENT ON COLUMN rf_settings.juros_mes_percent IS 'Juros de mora ao mês (%) pro rata die padrão do usuário';
+COMMENT ON COLUMN rf_settings.juros_dia_percent IS 'Juros de mora ao dia (%) padrão do usuário; prevalece sobre os juros ao mês';
+COMMENT ON COLUMN rf_settings.carencia_dias IS 'Dias após o vencimento sem encargos, padrão do usuário';
+COMMENT ON COLUMN rf_contracts.juros_dia_percent IS 'Juros de mora ao dia (%) do contrato; prevalece sobre os juros ao mês';
@@ -0,0 +1,21 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Links públicos de curta duração para compartilhar o recibo (WhatsApp)
+-- Data: 18-10-2026
+
+-- Cada GET /api/v1/receipts/{id}/share gera um token; quem tem o link baixa o PDF do recibo
+-- sem autenticação até expires_at. Tokens vencidos são apagados pelo job de ciclo de vida.
+CREATE TABLE IF NOT EXISTS rf_receipt_shares (
+    token uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    receipt_id uuid NOT NULL REFERENCES rf_receipts(id) ON DELETE CASCADE,
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_receipt_shares_expires ON rf_receipt_shares(expires_at);
+CREATE INDEX IF NOT EXISTS idx_receipt_shares_receipt ON rf_receipt_shares(receipt_id);
+
+ALTER TABLE rf_receipt_shares ENABLE ROW LEVEL SECURITY;
+CREATE POLICY receipt_shares_isolate ON rf_receipt_shares
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
@@ -0,0 +1,50 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Assinaturas Web Push por dispositivo (PWA) e controle dos lembretes de vencimento
+-- Data: 18-10-2026
+
+-- Uma linha por dispositivo/navegador que aceitou notificações; o endpoint é único (o mesmo
+-- navegador registrado de novo atualiza as chaves). Assinaturas que o serviço de push responde
+-- 404/410 são apagadas pelo envio.
+CREATE TABLE IF NOT EXISTS rf_push_subscriptions (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    endpoint text NOT NULL UNIQUE,
+    p256dh text NOT NULL,
+    auth text NOT NULL,
+    user_agent text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    last_used_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_subscriptions_owner ON rf_push_subscriptions(owner_id, created_at);
+
+ALTER TABLE rf_push_subscriptions ENABLE ROW LEVEL SECURITY;
+CREATE POLICY push_subscriptions_isolate ON rf_push_subscriptions
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+-- Importa a assinatura única guardada em rf_notification_settings.push_subscription (JSON de
+-- PushSubscription.toJSON()); valores que não são JSON válido são ignorados.
+DO $$
+DECLARE
+  r record;
+  sub jsonb;
+BEGIN
+  FOR r IN SELECT owner_id, push_subscription FROM rf_notification_settings WHERE push_subscription IS NOT NULL LOOP
+    BEGIN
+      sub := r.push_subscription::jsonb;
+      IF sub->>'endpoint' LIKE 'https://%' AND sub->'keys'->>'p256dh' IS NOT NULL AND sub->'keys'->>'auth' IS NOT NULL THEN
+        INSERT INTO rf_push_subscriptions (owner_id, endpoint, p256dh, auth)
+        VALUES (r.owner_id, sub->>'endpoint', sub->'keys'->>'p256dh', sub->'keys'->>'auth')
+        ON CONFLICT (endpoint) DO NOTHING;
+      END IF;
+    EXCEPTION WHEN others THEN
+      NULL;
+    END;
+  END LOOP;
+END $$;
+
+-- Lembrete de vencimento: marcado quando o job notifica a receita, para não repetir o aviso
+ALTER TABLE rf_incomes ADD COLUMN IF NOT EXISTS due_reminder_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_incomes_due_reminder ON rf_incomes(due_date)
+  WHERE due_reminder_at IS NULL AND deleted_at IS NULL AND due_date IS NOT NULL;
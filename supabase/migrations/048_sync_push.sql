@@ -0,0 +1,25 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Vetor de versões por dispositivo para o envio offline (sync push)
+-- Data: 18-10-2026
+
+-- Última versão da receita gravada por cada dispositivo via POST /api/v1/sync/push. Com o
+-- base_version enviado, distingue edições concorrentes (outro dispositivo ou a web alterou
+-- depois da leitura) de versões que o próprio dispositivo gerou (reenvio de um lote)
+CREATE TABLE IF NOT EXISTS rf_sync_versions (
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    entity text NOT NULL CHECK (entity IN ('income')),
+    entity_id uuid NOT NULL,
+    device_id text NOT NULL CHECK (length(device_id) BETWEEN 1 AND 200),
+    version bigint NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (entity, entity_id, device_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_versions_owner ON rf_sync_versions(owner_id);
+
+ALTER TABLE rf_sync_versions ENABLE ROW LEVEL SECURITY;
+CREATE POLICY sync_versions_isolate ON rf_sync_versions
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+COMMENT ON TABLE rf_sync_versions IS 'Vetor de versões (dispositivo -> última versão gravada) das receitas enviadas offline';
@@ -0,0 +1,33 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Versionamento das assinaturas (histórico por usuário) e assinatura congelada no recibo
+-- Data: 18-10-2026
+
+-- Cada envio é uma nova versão do usuário (1, 2, 3...); as anteriores continuam no histórico.
+-- Linhas existentes são numeradas pela ordem de criação
+WITH numbered AS (
+  SELECT id, row_number() OVER (PARTITION BY owner_id ORDER BY created_at NULLS FIRST, id) AS v
+  FROM rf_signatures
+)
+UPDATE rf_signatures s SET version = n.v FROM numbered n WHERE n.id = s.id AND s.version IS DISTINCT FROM n.v;
+
+ALTER TABLE rf_signatures ALTER COLUMN version SET NOT NULL;
+ALTER TABLE rf_signatures DROP CONSTRAINT IF EXISTS uq_signatures_owner_version;
+ALTER TABLE rf_signatures ADD CONSTRAINT uq_signatures_owner_version UNIQUE (owner_id, version);
+
+-- O recibo referencia a linha (versão) usada na emissão: a assinatura não muda depois
+CREATE OR REPLACE FUNCTION rf_receipts_signature_guard()
+RETURNS trigger AS $$
+BEGIN
+  IF NEW.signature_id IS DISTINCT FROM OLD.signature_id THEN
+    RAISE EXCEPTION 'valores congelados do recibo não podem ser alterados'
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'ck_receipts_snapshot_immutable';
+  END IF;
+  RETURN NEW;
+END; $$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tg_receipts_signature_guard ON rf_receipts;
+CREATE TRIGGER tg_receipts_signature_guard BEFORE UPDATE OF signature_id ON rf_receipts
+FOR EACH ROW EXECUTE FUNCTION rf_receipts_signature_guard();
+
+COMMENT ON COLUMN rf_signatures.version IS 'Versão da assinatura do usuário (sequencial; versões anteriores ficam no histórico)';
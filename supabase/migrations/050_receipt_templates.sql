@@ -0,0 +1,35 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Modelos de recibo com a identidade visual do profissional (logotipo, cores, cabeçalho, rodapé e emissor padrão)
+-- Data: 18-10-2026
+
+CREATE TABLE IF NOT EXISTS rf_receipt_templates (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    nome text NOT NULL,
+    logo bytea CHECK (octet_length(logo) <= 524288),
+    primary_color text CHECK (primary_color ~ '^#[0-9a-f]{6}$'),
+    accent_color text CHECK (accent_color ~ '^#[0-9a-f]{6}$'),
+    header_text text,
+    footer_text text,
+    issuer_name text,
+    issuer_document text,
+    issuer_address text,
+    is_default boolean NOT NULL DEFAULT false,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_receipt_templates_owner ON rf_receipt_templates(owner_id, nome);
+-- No máximo um modelo padrão por usuário (é o usado nos PDFs)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_receipt_templates_default ON rf_receipt_templates(owner_id) WHERE is_default;
+
+ALTER TABLE rf_receipt_templates ENABLE ROW LEVEL SECURITY;
+CREATE POLICY receipt_templates_isolate ON rf_receipt_templates
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+CREATE TRIGGER tg_receipt_templates_updated BEFORE UPDATE ON rf_receipt_templates
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+COMMENT ON COLUMN rf_receipt_templates.logo IS 'Logotipo (PNG ou JPEG, até 512 KB) desenhado no cabeçalho dos PDFs';
+COMMENT ON COLUMN rf_receipt_templates.issuer_name IS 'Emissor padrão: usado nos PDFs de recibos emitidos sem emissor';
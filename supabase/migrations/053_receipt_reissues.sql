@@ -0,0 +1,24 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Trilha de auditoria das segundas vias de recibos (POST /api/v1/receipts/{id}/reissue)
+-- Data: 18-10-2026
+
+-- Uma linha por via reemitida. O recibo não muda: a via sai com o número e a data de emissão
+-- originais e a marca "Nª VIA"; via começa em 2 (o original é a 1ª) e é única por recibo.
+-- actor_id é quem pediu (membro da organização ou o próprio dono).
+CREATE TABLE IF NOT EXISTS rf_receipt_reissues (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    receipt_id uuid NOT NULL REFERENCES rf_receipts(id) ON DELETE CASCADE,
+    via integer NOT NULL CHECK (via >= 2),
+    actor_id uuid NOT NULL,
+    motivo text CHECK (motivo IS NULL OR length(motivo) <= 500),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT uq_receipt_reissues_via UNIQUE (receipt_id, via)
+);
+
+CREATE INDEX IF NOT EXISTS idx_receipt_reissues_owner ON rf_receipt_reissues(owner_id, created_at);
+
+ALTER TABLE rf_receipt_reissues ENABLE ROW LEVEL SECURITY;
+CREATE POLICY receipt_reissues_isolate ON rf_receipt_reissues
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
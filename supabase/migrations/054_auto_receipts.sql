@@ -0,0 +1,35 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Emissão automática de recibo ao quitar a receita (opção em rf_settings e fila rf_auto_receipts)
+-- Data: 18-10-2026
+
+-- Opção do usuário: o pagamento que quita a receita agenda a emissão do recibo
+ALTER TABLE rf_settings
+  ADD COLUMN IF NOT EXISTS recibo_automatico boolean NOT NULL DEFAULT false;
+
+-- Fila de emissão (outbox): a linha é gravada na mesma transação do pagamento que quitou a receita,
+-- então nenhum pagamento confirmado fica sem o pedido de recibo e nenhum pedido sobra de um pagamento
+-- desfeito. O job "auto-receipts" emite o recibo. pending: aguardando; issued: recibo emitido;
+-- skipped: a receita já tinha recibo; failed: ver last_error. Uma linha por receita: quitar de novo
+-- após um estorno só reabre pedidos ignorados ou com falha.
+CREATE TABLE IF NOT EXISTS rf_auto_receipts (
+    income_id uuid PRIMARY KEY REFERENCES rf_incomes(id) ON DELETE CASCADE,
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    payment_id uuid NOT NULL,
+    status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'issued', 'skipped', 'failed')),
+    receipt_id uuid REFERENCES rf_receipts(id) ON DELETE SET NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    last_error text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    locked_at timestamptz,
+    processed_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_auto_receipts_pending ON rf_auto_receipts(created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_auto_receipts_owner ON rf_auto_receipts(owner_id, created_at DESC);
+
+ALTER TABLE rf_auto_receipts ENABLE ROW LEVEL SECURITY;
+CREATE POLICY auto_receipts_isolate ON rf_auto_receipts
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+COMMENT ON TABLE rf_auto_receipts IS 'Fila de emissão automática de recibos para receitas quitadas';
@@ -0,0 +1,63 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Despesas (rf_expenses) com anexos de documentos fiscais (rf_expense_attachments)
+-- Data: 18-10-2026
+
+-- Despesa do usuário, espelhando as receitas: categoria pelo nome (rf_categories, renomeada junto),
+-- competência AAAA-MM ou MM/AAAA e vencimento opcional. Pagamento único: pago_em preenchido marca a
+-- despesa como paga; "vencido" é derivado na leitura (pendente com vencimento passado).
+CREATE TABLE IF NOT EXISTS rf_expenses (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    descricao text NOT NULL CHECK (char_length(descricao) BETWEEN 1 AND 200),
+    fornecedor text CHECK (char_length(fornecedor) <= 200),
+    categoria text,
+    tags text[] NOT NULL DEFAULT '{}',
+    competencia text NOT NULL,
+    valor numeric(12,2) NOT NULL CHECK (valor > 0),
+    status text NOT NULL DEFAULT 'pendente' CHECK (status IN ('pendente', 'pago', 'cancelado')),
+    due_date date,
+    pago_em timestamptz,
+    metodo text,
+    obs text CHECK (char_length(obs) <= 1000),
+    deleted_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CHECK ((status = 'pago') = (pago_em IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_expenses_owner ON rf_expenses(owner_id, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_expenses_owner_competencia ON rf_expenses(owner_id, competencia) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_expenses_owner_pago_em ON rf_expenses(owner_id, pago_em) WHERE deleted_at IS NULL AND pago_em IS NOT NULL;
+
+DROP TRIGGER IF EXISTS tg_expenses_updated_at ON rf_expenses;
+CREATE TRIGGER tg_expenses_updated_at BEFORE UPDATE ON rf_expenses
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Documentos fiscais da despesa (NF-e, NFS-e, cupom, boleto, recibo). O arquivo fica no bucket de
+-- recibos em "{owner_id}/expenses/{expense_id}/{id}.{ext}"; a coleta do Storage confere file_path.
+CREATE TABLE IF NOT EXISTS rf_expense_attachments (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    expense_id uuid NOT NULL REFERENCES rf_expenses(id) ON DELETE CASCADE,
+    tipo text NOT NULL DEFAULT 'outro' CHECK (tipo IN ('nfe', 'nfse', 'cupom', 'boleto', 'recibo', 'outro')),
+    file_name text NOT NULL CHECK (char_length(file_name) BETWEEN 1 AND 255),
+    file_path text NOT NULL,
+    content_type text NOT NULL,
+    size_bytes bigint NOT NULL CHECK (size_bytes > 0),
+    sha256 text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_attachments_expense ON rf_expense_attachments(expense_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_expense_attachments_owner_path ON rf_expense_attachments(owner_id, file_path);
+
+ALTER TABLE rf_expenses ENABLE ROW LEVEL SECURITY;
+CREATE POLICY expenses_isolate ON rf_expenses
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+ALTER TABLE rf_expense_attachments ENABLE ROW LEVEL SECURITY;
+CREATE POLICY expense_attachments_isolate ON rf_expense_attachments
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+COMMENT ON TABLE rf_expenses IS 'Despesas do usuário (contas a pagar) para o cálculo do resultado';
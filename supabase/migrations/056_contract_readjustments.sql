@@ -0,0 +1,34 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Histórico de reajustes de contratos por índice (IGP-M/IPCA) aplicados às receitas futuras
+-- Data: 18-10-2026
+
+-- Cada reajuste aplicado: índice e período acumulado, percentual aplicado (o acumulado negativo só é
+-- aplicado se o usuário permitir redução) e valores do contrato antes e depois. Um reajuste por
+-- contrato e competência inicial, para o mesmo pedido não ser aplicado duas vezes.
+CREATE TABLE IF NOT EXISTS rf_contract_readjustments (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    contract_id uuid NOT NULL REFERENCES rf_contracts(id) ON DELETE CASCADE,
+    indice text NOT NULL CHECK (indice IN ('igpm', 'ipca')),
+    periodo_inicio date NOT NULL,
+    periodo_fim date NOT NULL,
+    acumulado numeric(12,6) NOT NULL,
+    percentual numeric(12,6) NOT NULL,
+    valor_anterior numeric(12,2) NOT NULL,
+    valor_novo numeric(12,2) NOT NULL,
+    a_partir_de date NOT NULL,
+    receitas_atualizadas int NOT NULL DEFAULT 0,
+    modelos_atualizados int NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CHECK (periodo_fim >= periodo_inicio)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_contract_readjustments_start ON rf_contract_readjustments(contract_id, a_partir_de);
+CREATE INDEX IF NOT EXISTS idx_contract_readjustments_owner ON rf_contract_readjustments(owner_id, created_at DESC);
+
+ALTER TABLE rf_contract_readjustments ENABLE ROW LEVEL SECURITY;
+CREATE POLICY contract_readjustments_isolate ON rf_contract_readjustments
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+COMMENT ON TABLE rf_contract_readjustments IS 'Reajustes anuais de contratos por IGP-M/IPCA aplicados às receitas futuras';
@@ -0,0 +1,28 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Feriados municipais do usuário e opção de vencimento em dia útil nas receitas recorrentes
+-- Data: 18-10-2026
+
+-- Feriados cadastrados pelo usuário (municipais, estaduais ou locais); os nacionais são calculados
+-- pelo backend. Anual repete o feriado todo ano no mesmo dia e mês a partir da data informada.
+CREATE TABLE IF NOT EXISTS rf_holidays (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id uuid NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    data date NOT NULL,
+    nome text NOT NULL CHECK (char_length(nome) BETWEEN 1 AND 80),
+    anual boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_holidays_owner ON rf_holidays(owner_id, data);
+
+ALTER TABLE rf_holidays ENABLE ROW LEVEL SECURITY;
+CREATE POLICY holidays_isolate ON rf_holidays
+  USING (owner_id = auth.uid()) WITH CHECK (owner_id = auth.uid());
+
+COMMENT ON TABLE rf_holidays IS 'Feriados municipais cadastrados pelo usuário para o cálculo de dias úteis';
+
+-- Vencimentos gerados a partir de modelos que caem em fim de semana ou feriado vão para o próximo dia útil
+ALTER TABLE rf_settings ADD COLUMN IF NOT EXISTS vencimento_dia_util boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN rf_settings.vencimento_dia_util IS 'Ajusta para o próximo dia útil os vencimentos das receitas geradas por modelos';
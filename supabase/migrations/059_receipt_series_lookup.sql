@@ -0,0 +1,23 @@
+-- MIT License
+-- Autor atual: David Assef
+-- Descrição: Consulta pública e relatório de numeração pela série exibida do recibo (numero_formatado)
+-- Data: 18-10-2026
+
+-- A consulta pública compara o número impresso (numero_formatado, sem diferenciar maiúsculas) com o
+-- documento do emissor; a sequência interna (numero) não aparece no recibo e deixa de ser consultada.
+CREATE INDEX IF NOT EXISTS idx_receipts_numero_formatado_lookup
+  ON rf_receipts (upper(numero_formatado));
+
+DROP INDEX IF EXISTS idx_receipts_issuer_numero;
+
+-- Justificativas de lacunas passam a referir-se a uma série (0 = sem reinício; ano = reinício anual).
+-- As já registradas valem para a série sem reinício, em que serie_numero = numero nos recibos migrados.
+ALTER TABLE rf_receipt_number_gaps
+  ADD COLUMN IF NOT EXISTS serie_periodo int NOT NULL DEFAULT 0 CHECK (serie_periodo >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_receipt_number_gaps_serie
+  ON rf_receipt_number_gaps(owner_id, serie_periodo, numero_inicio);
+
+DROP INDEX IF EXISTS idx_receipt_number_gaps_owner;
+
+COMMENT ON COLUMN rf_receipt_number_gaps.serie_periodo IS 'Série da lacuna (0 = sem reinício; ano = reinício anual); numero_inicio/numero_fim são serie_numero';